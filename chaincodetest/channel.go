@@ -0,0 +1,385 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package chaincodetest runs chaincodes in-process against an in-memory
+// ledger, so that they can be unit tested with the semantics of a peer: the
+// reads of a transaction do not see its own writes, the transactions are
+// validated at commit time for MVCC read conflicts and phantom reads, the
+// composite keys, private data, events and history of the keys behave as
+// with a peer, and the chaincodes can invoke each other within a transaction.
+//
+// The rich queries, which need a state database interpreting them, and the
+// endorsement policies are not simulated.
+package chaincodetest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Proposal is the invocation of a chaincode by a transaction
+type Proposal struct {
+	// Chaincode is the name of the invoked chaincode
+	Chaincode string
+	// Args are the arguments of the invocation
+	Args [][]byte
+	// Init invokes the Init function of the chaincode instead of Invoke
+	Init bool
+	// TxID is the ID of the transaction, computed from the nonce of the
+	// proposal and its creator when empty
+	TxID string
+	// Creator is the serialized identity of the creator of the proposal, the
+	// default creator of the channel when nil
+	Creator []byte
+	// Transient is the transient data of the proposal
+	Transient map[string][]byte
+	// Decorations are the decorations of the input of the chaincode
+	Decorations map[string][]byte
+	// Timestamp is the timestamp of the transaction, the current time when zero
+	Timestamp time.Time
+}
+
+// Transaction is a simulated transaction
+type Transaction struct {
+	TxID      string
+	Chaincode string
+	Timestamp *timestamp.Timestamp
+	// Response is the response of the chaincode
+	Response pb.Response
+	// Event is the event set by the invoked chaincode, if any
+	Event *pb.ChaincodeEvent
+	// BlockNumber and ValidationCode are set once the transaction is committed
+	BlockNumber    uint64
+	ValidationCode pb.TxValidationCode
+	committed      bool
+
+	sim *simulation
+}
+
+// Committed returns true if the transaction was committed in a block, valid or not
+func (tx *Transaction) Committed() bool {
+	return tx.committed
+}
+
+// Valid returns true if the transaction was committed and validated
+func (tx *Transaction) Valid() bool {
+	return tx.committed && tx.ValidationCode == pb.TxValidationCode_VALID
+}
+
+// Results returns the read-write sets of the transaction, as endorsed by a peer
+func (tx *Transaction) Results() (*ledger.TxSimulationResults, error) {
+	return tx.sim.results()
+}
+
+// Block is a block of transactions committed by a channel
+type Block struct {
+	Number       uint64
+	Transactions []*Transaction
+}
+
+// Channel is a channel of a single peer, with the chaincodes installed on it
+// and an in-memory ledger
+type Channel struct {
+	// Creator is the default serialized identity of the creators of the
+	// proposals
+	Creator []byte
+	// ChannelConfig is the channel config returned to the chaincodes by
+	// GetChannelConfig
+	ChannelConfig *pb.ChannelConfigSnapshot
+
+	mutex      sync.RWMutex
+	channelID  string
+	chaincodes map[string]shim.Chaincode
+	state      *worldState
+	history    map[string]map[string][]*queryresult.KeyModification
+	txIDs      map[string]struct{}
+	events     []*pb.ChaincodeEvent
+	height     uint64
+}
+
+// NewChannel returns a channel with an empty ledger
+func NewChannel(channelID string) *Channel {
+	return &Channel{
+		channelID:  channelID,
+		chaincodes: map[string]shim.Chaincode{},
+		state:      newWorldState(),
+		history:    map[string]map[string][]*queryresult.KeyModification{},
+		txIDs:      map[string]struct{}{},
+	}
+}
+
+// ChannelID returns the ID of the channel
+func (c *Channel) ChannelID() string {
+	return c.channelID
+}
+
+// Install installs a chaincode on the channel under the given name, which is
+// the namespace of its state
+func (c *Channel) Install(name string, cc shim.Chaincode) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.chaincodes[name] = cc
+}
+
+// Simulate simulates a proposal against the committed state of the channel.
+// The returned transaction is committed by Commit
+func (c *Channel) Simulate(prop *Proposal) (*Transaction, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	cc, ok := c.chaincodes[prop.Chaincode]
+	if !ok {
+		return nil, errors.Errorf("chaincode %s is not installed on channel %s", prop.Chaincode, c.channelID)
+	}
+	txctx, err := c.newTxContext(prop)
+	if err != nil {
+		return nil, err
+	}
+	s := &stub{
+		txContext:   txctx,
+		namespace:   prop.Chaincode,
+		args:        prop.Args,
+		decorations: prop.Decorations,
+	}
+	var resp pb.Response
+	if prop.Init {
+		resp = cc.Init(s)
+	} else {
+		resp = cc.Invoke(s)
+	}
+	return &Transaction{
+		TxID:      txctx.txID,
+		Chaincode: prop.Chaincode,
+		Timestamp: txctx.timestamp,
+		Response:  resp,
+		Event:     s.event,
+		sim:       txctx.sim,
+	}, nil
+}
+
+// newTxContext creates the signed proposal of a transaction and returns its
+// context
+func (c *Channel) newTxContext(prop *Proposal) (*txContext, error) {
+	creator := prop.Creator
+	if creator == nil {
+		creator = c.Creator
+	}
+	ts := prop.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	txTimestamp, err := ptypes.TimestampProto(ts)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid timestamp")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: prop.Chaincode},
+			Input:       &pb.ChaincodeInput{Args: prop.Args, Decorations: prop.Decorations, IsInit: prop.Init},
+		},
+	}
+	proposal, txID, err := utils.CreateChaincodeProposalWithTxIDAndTransient(cb.HeaderType_ENDORSER_TRANSACTION, c.channelID, cis, creator, prop.TxID, prop.Transient)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create the proposal")
+	}
+	// the channel header carries the timestamp of the transaction
+	hdr, err := utils.GetHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	chdr.Timestamp = txTimestamp
+	hdr.ChannelHeader = utils.MarshalOrPanic(chdr)
+	proposal.Header = utils.MarshalOrPanic(hdr)
+
+	binding, err := utils.ComputeProposalBinding(proposal)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to compute the binding of the proposal")
+	}
+	return &txContext{
+		channel:        c,
+		sim:            newSimulation(),
+		txID:           txID,
+		creator:        creator,
+		transient:      prop.Transient,
+		binding:        binding,
+		signedProposal: &pb.SignedProposal{ProposalBytes: utils.MarshalOrPanic(proposal)},
+		timestamp:      txTimestamp,
+		depth:          1,
+	}, nil
+}
+
+// Commit commits a block of simulated transactions. The transactions are
+// validated in order against the state resulting from the valid transactions
+// before them, their validation code being set. The transactions whose
+// simulation failed cannot be committed, as no peer endorses them
+func (c *Channel) Commit(txs ...*Transaction) (*Block, error) {
+	for _, tx := range txs {
+		if tx.committed {
+			return nil, errors.Errorf("transaction %s is already committed", tx.TxID)
+		}
+		if tx.Response.Status >= shim.ERRORTHRESHOLD {
+			return nil, errors.Errorf("transaction %s cannot be committed, its chaincode returned status %d: %s", tx.TxID, tx.Response.Status, tx.Response.Message)
+		}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	block := &Block{Number: c.height, Transactions: txs}
+	for txNum, tx := range txs {
+		tx.committed = true
+		tx.BlockNumber = block.Number
+		if _, ok := c.txIDs[tx.TxID]; ok {
+			tx.ValidationCode = pb.TxValidationCode_DUPLICATE_TXID
+			continue
+		}
+		c.txIDs[tx.TxID] = struct{}{}
+		if tx.ValidationCode = tx.sim.validate(c.state); tx.ValidationCode != pb.TxValidationCode_VALID {
+			continue
+		}
+		tx.sim.apply(c.state, version.NewHeight(block.Number, uint64(txNum)))
+		c.addHistory(tx)
+		if tx.Event != nil {
+			c.events = append(c.events, tx.Event)
+		}
+	}
+	c.height++
+	return block, nil
+}
+
+// addHistory records the modifications of the public keys by a valid transaction
+func (c *Channel) addHistory(tx *Transaction) {
+	for ns, writes := range tx.sim.writes {
+		if _, ok := tx.sim.collections[ns]; ok {
+			continue
+		}
+		keys, ok := c.history[ns]
+		if !ok {
+			keys = map[string][]*queryresult.KeyModification{}
+			c.history[ns] = keys
+		}
+		for key, value := range writes {
+			keys[key] = append(keys[key], &queryresult.KeyModification{
+				TxId:      tx.TxID,
+				Value:     value,
+				Timestamp: tx.Timestamp,
+				IsDelete:  value == nil,
+			})
+		}
+	}
+	for ns, increments := range tx.sim.increments {
+		keys, ok := c.history[ns]
+		if !ok {
+			keys = map[string][]*queryresult.KeyModification{}
+			c.history[ns] = keys
+		}
+		for key := range increments {
+			keys[key] = append(keys[key], &queryresult.KeyModification{
+				TxId:      tx.TxID,
+				Value:     c.state.get(ns, key).value,
+				Timestamp: tx.Timestamp,
+			})
+		}
+	}
+}
+
+// Invoke simulates a proposal invoking a chaincode and commits its transaction
+// in a block of its own, unless the chaincode fails
+func (c *Channel) Invoke(chaincode string, args ...[]byte) (*Transaction, error) {
+	return c.Execute(&Proposal{Chaincode: chaincode, Args: args})
+}
+
+// Init simulates a proposal invoking the Init function of a chaincode and
+// commits its transaction in a block of its own, unless the chaincode fails
+func (c *Channel) Init(chaincode string, args ...[]byte) (*Transaction, error) {
+	return c.Execute(&Proposal{Chaincode: chaincode, Args: args, Init: true})
+}
+
+// Execute simulates a proposal and commits its transaction in a block of its
+// own, unless the chaincode fails
+func (c *Channel) Execute(prop *Proposal) (*Transaction, error) {
+	tx, err := c.Simulate(prop)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Response.Status >= shim.ERRORTHRESHOLD {
+		return tx, nil
+	}
+	if _, err := c.Commit(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// Query simulates a proposal invoking a chaincode and returns the response of
+// the chaincode, without committing its transaction
+func (c *Channel) Query(chaincode string, args ...[]byte) (pb.Response, error) {
+	tx, err := c.Simulate(&Proposal{Chaincode: chaincode, Args: args})
+	if err != nil {
+		return pb.Response{}, err
+	}
+	return tx.Response, nil
+}
+
+// GetState returns the committed value of a key of a chaincode, nil if the
+// key does not exist
+func (c *Channel) GetState(chaincode, key string) []byte {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	if vv := c.state.get(chaincode, key); vv != nil {
+		return vv.value
+	}
+	return nil
+}
+
+// GetPrivateData returns the committed value of a key of a collection of a
+// chaincode, nil if the key does not exist
+func (c *Channel) GetPrivateData(chaincode, collection, key string) []byte {
+	return c.GetState(privateNamespace(chaincode, collection), key)
+}
+
+// PutState writes the value of a key of a chaincode in a transaction of its
+// own, to set up the state of a test
+func (c *Channel) PutState(chaincode, key string, value []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.state.put(chaincode, key, value, version.NewHeight(c.height, 0))
+	c.height++
+}
+
+// Events returns the events of the valid transactions, in commit order
+func (c *Channel) Events() []*pb.ChaincodeEvent {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	events := make([]*pb.ChaincodeEvent, 0, len(c.events))
+	for _, event := range c.events {
+		events = append(events, proto.Clone(event).(*pb.ChaincodeEvent))
+	}
+	return events
+}
+
+// Height returns the number of blocks committed by the channel
+func (c *Channel) Height() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.height
+}
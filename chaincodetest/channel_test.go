@@ -0,0 +1,568 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincodetest_test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/chaincodetest"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kvChaincode is a chaincode exposing the functions of the stub
+type kvChaincode struct{}
+
+func (kvChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (kvChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	fn, args := stub.GetFunctionAndParameters()
+	switch fn {
+	case "put":
+		if err := stub.PutState(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "get":
+		value, err := stub.GetState(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(value)
+	case "putget":
+		if err := stub.PutState(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		value, _ := stub.GetState(args[0])
+		return shim.Success(value)
+	case "incr":
+		value, _ := stub.GetState(args[0])
+		if err := stub.PutState(args[0], append(value, '+')); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "del":
+		if err := stub.DelState(args[0]); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "add":
+		delta, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.IncrementState(args[0], delta); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "delrange":
+		if err := stub.DelStateRange(args[0], args[1]); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "count":
+		itr, err := stub.GetStateByRange(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer itr.Close()
+		n := 0
+		for itr.HasNext() {
+			if _, err := itr.Next(); err != nil {
+				return shim.Error(err.Error())
+			}
+			n++
+		}
+		if err := stub.PutState("count", []byte(fmt.Sprint(n))); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(fmt.Sprint(n)))
+	case "first":
+		itr, err := stub.GetStateByRange(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer itr.Close()
+		kv, err := itr.Next()
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.PutState("first", []byte(kv.Key)); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(kv.Key))
+	case "putcolor":
+		key, err := stub.CreateCompositeKey("color", args)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if err := stub.PutState(key, []byte{1}); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "colors":
+		var filters []*pb.CompositeKeyFilter
+		if len(args) > 1 {
+			filters = append(filters, &pb.CompositeKeyFilter{Attribute: 1, Operator: pb.CompositeKeyFilter_EQUALS, Value: args[1]})
+		}
+		itr, err := stub.GetStateByPartialCompositeKeyWithFilters("color", args[:1], filters)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer itr.Close()
+		var names []string
+		for itr.HasNext() {
+			kv, _ := itr.Next()
+			_, attributes, err := stub.SplitCompositeKey(kv.Key)
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			names = append(names, strings.Join(attributes, "/"))
+		}
+		return shim.Success([]byte(strings.Join(names, ",")))
+	case "page":
+		itr, metadata, err := stub.GetStateByRangeWithPagination("", "", 2, args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer itr.Close()
+		var keys []string
+		for itr.HasNext() {
+			kv, _ := itr.Next()
+			keys = append(keys, kv.Key)
+		}
+		if len(args) > 1 {
+			if err := stub.PutState(args[1], []byte{1}); err != nil {
+				return shim.Error(err.Error())
+			}
+		}
+		return shim.Success([]byte(strings.Join(keys, ",") + ";" + metadata.Bookmark))
+	case "call":
+		var ccArgs [][]byte
+		for _, arg := range args[1:] {
+			ccArgs = append(ccArgs, []byte(arg))
+		}
+		return stub.InvokeChaincode(args[0], ccArgs, "")
+	case "event":
+		if err := stub.SetEvent(args[0], []byte(args[1])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "putprivate":
+		if err := stub.PutPrivateData(args[0], args[1], []byte(args[2])); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	case "privatehash":
+		hash, err := stub.GetPrivateDataHash(args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(hash)
+	case "proof":
+		proof, err := stub.GetStateWithProof(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(fmt.Sprintf("%s:%d:%d", proof.Value, proof.BlockNum, proof.TxNum)))
+	case "history":
+		itr, err := stub.GetHistoryForKey(args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		defer itr.Close()
+		var values []string
+		for itr.HasNext() {
+			km, _ := itr.Next()
+			if km.IsDelete {
+				values = append(values, "<deleted>")
+				continue
+			}
+			values = append(values, string(km.Value))
+		}
+		return shim.Success([]byte(strings.Join(values, ",")))
+	default:
+		return shim.Error("unknown function " + fn)
+	}
+}
+
+func args(strargs ...string) [][]byte {
+	return util.ToChaincodeArgs(strargs...)
+}
+
+func newChannel(t *testing.T) *chaincodetest.Channel {
+	c := chaincodetest.NewChannel("testchannel")
+	c.Install("kv", kvChaincode{})
+	c.Install("other", kvChaincode{})
+	tx, err := c.Init("kv")
+	require.NoError(t, err)
+	require.True(t, tx.Valid())
+	return c
+}
+
+func TestInvokeAndQuery(t *testing.T) {
+	c := newChannel(t)
+
+	tx, err := c.Invoke("kv", args("put", "a", "1")...)
+	require.NoError(t, err)
+	assert.True(t, tx.Valid())
+	assert.Equal(t, uint64(1), tx.BlockNumber)
+	assert.Equal(t, uint64(2), c.Height())
+	assert.Equal(t, []byte("1"), c.GetState("kv", "a"))
+
+	resp, err := c.Query("kv", args("get", "a")...)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("1"), resp.Payload)
+
+	// the reads of a transaction do not see its writes
+	resp, err = c.Query("kv", args("putget", "b", "2")...)
+	require.NoError(t, err)
+	assert.Nil(t, resp.Payload)
+	assert.Nil(t, c.GetState("kv", "b"))
+
+	tx, err = c.Invoke("kv", args("unknown")...)
+	require.NoError(t, err)
+	assert.Equal(t, int32(shim.ERROR), tx.Response.Status)
+	assert.False(t, tx.Committed())
+	_, err = c.Commit(tx)
+	assert.EqualError(t, err, fmt.Sprintf("transaction %s cannot be committed, its chaincode returned status 500: unknown function unknown", tx.TxID))
+
+	_, err = c.Invoke("missing")
+	assert.EqualError(t, err, "chaincode missing is not installed on channel testchannel")
+}
+
+func TestMVCCReadConflict(t *testing.T) {
+	c := newChannel(t)
+	c.PutState("kv", "a", []byte("1"))
+
+	tx1, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("incr", "a")})
+	require.NoError(t, err)
+	tx2, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("incr", "a")})
+	require.NoError(t, err)
+	block, err := c.Commit(tx1, tx2)
+	require.NoError(t, err)
+	assert.Len(t, block.Transactions, 2)
+	assert.Equal(t, pb.TxValidationCode_VALID, tx1.ValidationCode)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, tx2.ValidationCode)
+	assert.Equal(t, []byte("1+"), c.GetState("kv", "a"))
+
+	// the reads of keys which do not exist conflict with their creation
+	tx3, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("incr", "b")})
+	require.NoError(t, err)
+	_, err = c.Invoke("kv", args("put", "b", "1")...)
+	require.NoError(t, err)
+	_, err = c.Commit(tx3)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, tx3.ValidationCode)
+	assert.Equal(t, []byte("1"), c.GetState("kv", "b"))
+
+	_, err = c.Commit(tx3)
+	assert.EqualError(t, err, fmt.Sprintf("transaction %s is already committed", tx3.TxID))
+}
+
+func TestPhantomReadConflict(t *testing.T) {
+	c := newChannel(t)
+	c.PutState("kv", "k1", []byte("1"))
+	c.PutState("kv", "k3", []byte("3"))
+
+	tx, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("count", "k", "l")})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("2"), tx.Response.Payload)
+	_, err = c.Invoke("kv", args("put", "k2", "2")...)
+	require.NoError(t, err)
+	_, err = c.Commit(tx)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_PHANTOM_READ_CONFLICT, tx.ValidationCode)
+
+	// the keys after the last key read by an iterator which is not exhausted
+	// are not validated
+	tx, err = c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("first", "k", "l")})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("k1"), tx.Response.Payload)
+	_, err = c.Invoke("kv", args("put", "k4", "4")...)
+	require.NoError(t, err)
+	_, err = c.Commit(tx)
+	require.NoError(t, err)
+	assert.True(t, tx.Valid())
+
+	tx, err = c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("first", "k", "l")})
+	require.NoError(t, err)
+	_, err = c.Invoke("kv", args("put", "k0", "0")...)
+	require.NoError(t, err)
+	_, err = c.Commit(tx)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_PHANTOM_READ_CONFLICT, tx.ValidationCode)
+}
+
+func TestDelStateRange(t *testing.T) {
+	c := newChannel(t)
+	c.PutState("kv", "a", []byte("a"))
+	c.PutState("kv", "k1", []byte("1"))
+	c.PutState("kv", "k3", []byte("3"))
+	c.PutState("kv", "l", []byte("l"))
+
+	tx, err := c.Invoke("kv", args("delrange", "k", "l")...)
+	require.NoError(t, err)
+	assert.True(t, tx.Valid())
+	assert.Nil(t, c.GetState("kv", "k1"))
+	assert.Nil(t, c.GetState("kv", "k3"))
+	assert.Equal(t, []byte("a"), c.GetState("kv", "a"))
+	assert.Equal(t, []byte("l"), c.GetState("kv", "l"))
+
+	// the deleted range is validated like a range query
+	c.PutState("kv", "k1", []byte("1"))
+	tx, err = c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("delrange", "k", "l")})
+	require.NoError(t, err)
+	_, err = c.Invoke("kv", args("put", "k2", "2")...)
+	require.NoError(t, err)
+	_, err = c.Commit(tx)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_PHANTOM_READ_CONFLICT, tx.ValidationCode)
+	assert.Equal(t, []byte("1"), c.GetState("kv", "k1"))
+}
+
+func TestIncrementState(t *testing.T) {
+	c := newChannel(t)
+
+	// concurrent increments of a key do not conflict
+	tx1, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("add", "counter", "5")})
+	require.NoError(t, err)
+	tx2, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("add", "counter", "-7")})
+	require.NoError(t, err)
+	_, err = c.Commit(tx1, tx2)
+	require.NoError(t, err)
+	assert.True(t, tx1.Valid())
+	assert.True(t, tx2.Valid())
+	assert.Equal(t, []byte("-2"), c.GetState("kv", "counter"))
+
+	// an increment of a value that is not a counter invalidates the transaction
+	c.PutState("kv", "text", []byte("value"))
+	tx, err := c.Invoke("kv", args("add", "text", "1")...)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_INVALID_WRITESET, tx.ValidationCode)
+	assert.Equal(t, []byte("value"), c.GetState("kv", "text"))
+}
+
+func TestCompositeKeys(t *testing.T) {
+	c := newChannel(t)
+	for _, attributes := range [][]string{{"blue", "car"}, {"blue", "bike"}, {"red", "car"}} {
+		tx, err := c.Invoke("kv", args(append([]string{"putcolor"}, attributes...)...)...)
+		require.NoError(t, err)
+		require.True(t, tx.Valid())
+	}
+
+	resp, err := c.Query("kv", args("colors", "blue")...)
+	require.NoError(t, err)
+	assert.Equal(t, "blue/bike,blue/car", string(resp.Payload))
+	resp, err = c.Query("kv", args("colors", "blue", "car")...)
+	require.NoError(t, err)
+	assert.Equal(t, "blue/car", string(resp.Payload))
+
+	// the range queries of simple keys skip the composite keys
+	resp, err = c.Query("kv", args("count", "", "")...)
+	require.NoError(t, err)
+	assert.Equal(t, "0", string(resp.Payload))
+}
+
+func TestPaginatedQueries(t *testing.T) {
+	c := newChannel(t)
+	for _, key := range []string{"a", "b", "c"} {
+		c.PutState("kv", key, []byte(key))
+	}
+
+	resp, err := c.Query("kv", args("page", "")...)
+	require.NoError(t, err)
+	assert.Equal(t, "a,b;c", string(resp.Payload))
+	resp, err = c.Query("kv", args("page", "c")...)
+	require.NoError(t, err)
+	assert.Equal(t, "c;", string(resp.Payload))
+
+	resp, err = c.Query("kv", args("page", "", "d")...)
+	require.NoError(t, err)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "paginated queries are only supported by read-only transactions, the transaction cannot write keys after a paginated query", resp.Message)
+}
+
+func TestInvokeChaincode(t *testing.T) {
+	c := newChannel(t)
+
+	tx, err := c.Invoke("kv", args("call", "other", "put", "a", "1")...)
+	require.NoError(t, err)
+	assert.True(t, tx.Valid())
+	assert.Equal(t, []byte("1"), c.GetState("other", "a"))
+	assert.Nil(t, c.GetState("kv", "a"))
+
+	results, err := tx.Results()
+	require.NoError(t, err)
+	require.Len(t, results.PubSimulationResults.NsRwset, 1)
+	assert.Equal(t, "other", results.PubSimulationResults.NsRwset[0].Namespace)
+
+	resp, err := c.Query("kv", args("call", "missing", "get", "a")...)
+	require.NoError(t, err)
+	assert.Equal(t, "chaincode missing is not installed on channel testchannel", resp.Message)
+}
+
+func TestEvents(t *testing.T) {
+	c := newChannel(t)
+
+	tx, err := c.Invoke("kv", args("event", "created", "a")...)
+	require.NoError(t, err)
+	require.NotNil(t, tx.Event)
+	assert.Equal(t, "created", tx.Event.EventName)
+	assert.Equal(t, tx.TxID, tx.Event.TxId)
+	assert.Equal(t, "kv", tx.Event.ChaincodeId)
+
+	// the events of the invalid transactions are not delivered
+	c.PutState("kv", "a", []byte("1"))
+	tx1, err := c.Simulate(&chaincodetest.Proposal{Chaincode: "kv", Args: args("get", "a")})
+	require.NoError(t, err)
+	c.PutState("kv", "a", []byte("2"))
+	_, err = c.Commit(tx1)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, tx1.ValidationCode)
+
+	events := c.Events()
+	require.Len(t, events, 1)
+	assert.Equal(t, []byte("a"), events[0].Payload)
+}
+
+func TestPrivateData(t *testing.T) {
+	c := newChannel(t)
+
+	tx, err := c.Invoke("kv", args("putprivate", "secrets", "a", "1")...)
+	require.NoError(t, err)
+	assert.True(t, tx.Valid())
+	assert.Equal(t, []byte("1"), c.GetPrivateData("kv", "secrets", "a"))
+	assert.Nil(t, c.GetState("kv", "a"))
+
+	results, err := tx.Results()
+	require.NoError(t, err)
+	require.NotNil(t, results.PvtSimulationResults)
+	assert.Equal(t, 1, len(results.PubSimulationResults.NsRwset[0].CollectionHashedRwset))
+
+	resp, err := c.Query("kv", args("privatehash", "secrets", "a")...)
+	require.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256([]byte("1")), resp.Payload)
+
+	resp, err = c.Query("kv", args("privatehash", "secrets", "b")...)
+	require.NoError(t, err)
+	assert.Nil(t, resp.Payload)
+}
+
+func TestHistory(t *testing.T) {
+	c := newChannel(t)
+	_, err := c.Invoke("kv", args("put", "a", "1")...)
+	require.NoError(t, err)
+	_, err = c.Invoke("kv", args("put", "a", "2")...)
+	require.NoError(t, err)
+	_, err = c.Invoke("kv", args("del", "a")...)
+	require.NoError(t, err)
+
+	resp, err := c.Query("kv", args("history", "a")...)
+	require.NoError(t, err)
+	assert.Equal(t, "1,2,<deleted>", string(resp.Payload))
+}
+
+func TestStateProof(t *testing.T) {
+	c := newChannel(t)
+	_, err := c.Invoke("kv", args("put", "a", "1")...)
+	require.NoError(t, err)
+
+	resp, err := c.Query("kv", args("proof", "a")...)
+	require.NoError(t, err)
+	assert.Equal(t, "1:1:0", string(resp.Payload))
+
+	resp, err = c.Query("kv", args("proof", "b")...)
+	require.NoError(t, err)
+	assert.Equal(t, "key [b] does not exist, the absence of a key cannot be proven", resp.Message)
+}
+
+func TestTxContext(t *testing.T) {
+	c := newChannel(t)
+	c.Creator = []byte("creator")
+	ts := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var stub shim.ChaincodeStubInterface
+	c.Install("context", chaincodeFunc(func(s shim.ChaincodeStubInterface) pb.Response {
+		stub = s
+		return shim.Success(nil)
+	}))
+
+	tx, err := c.Simulate(&chaincodetest.Proposal{
+		Chaincode: "context",
+		TxID:      "tx1",
+		Transient: map[string][]byte{"key": []byte("value")},
+		Timestamp: ts,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tx1", tx.TxID)
+	assert.Equal(t, "tx1", stub.GetTxID())
+	assert.Equal(t, "testchannel", stub.GetChannelID())
+
+	creator, err := stub.GetCreator()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("creator"), creator)
+	transient, err := stub.GetTransient()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key": []byte("value")}, transient)
+	txTimestamp, err := stub.GetTxTimestamp()
+	require.NoError(t, err)
+	assert.Equal(t, ts.Unix(), txTimestamp.Seconds)
+	txTime, err := stub.GetTxTime()
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(txTime))
+	assert.Equal(t, shim.NewTxRand("testchannel", "tx1").Int63(), stub.GetTxRand().Int63())
+
+	sp, err := stub.GetSignedProposal()
+	require.NoError(t, err)
+	prop, err := utils.GetProposal(sp.ProposalBytes)
+	require.NoError(t, err)
+	hdr, err := utils.GetHeader(prop.Header)
+	require.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "tx1", chdr.TxId)
+	chdrTimestamp, err := ptypes.Timestamp(chdr.Timestamp)
+	require.NoError(t, err)
+	assert.True(t, ts.Equal(chdrTimestamp))
+	binding, err := stub.GetBinding()
+	require.NoError(t, err)
+	expectedBinding, err := utils.ComputeProposalBinding(prop)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBinding, binding)
+
+	// the transaction IDs are unique
+	_, err = c.Commit(tx)
+	require.NoError(t, err)
+	tx, err = c.Simulate(&chaincodetest.Proposal{Chaincode: "context", TxID: "tx1"})
+	require.NoError(t, err)
+	_, err = c.Commit(tx)
+	require.NoError(t, err)
+	assert.Equal(t, pb.TxValidationCode_DUPLICATE_TXID, tx.ValidationCode)
+}
+
+// chaincodeFunc is a chaincode whose Init and Invoke call the function
+type chaincodeFunc func(stub shim.ChaincodeStubInterface) pb.Response
+
+func (f chaincodeFunc) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return f(stub)
+}
+
+func (f chaincodeFunc) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	return f(stub)
+}
@@ -0,0 +1,292 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincodetest
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// kvRead is the read of a key at a version, nil when the key did not exist
+type kvRead struct {
+	key     string
+	version *version.Height
+}
+
+// rangeQuery is a range query of a simulation with the keys it read, which
+// are read again when the transaction is validated to detect phantom reads
+type rangeQuery struct {
+	ns        string
+	startKey  string
+	endKey    string
+	filters   []*pb.CompositeKeyFilter
+	exhausted bool
+	reads     []kvRead
+}
+
+// collection names the collection of a chaincode held by a private namespace
+type collection struct {
+	ns   string
+	coll string
+}
+
+// simulation records the reads and writes of the simulation of a transaction,
+// by namespace, the way the transaction simulator of the peer does
+type simulation struct {
+	reads          map[string]map[string]*version.Height
+	rangeQueries   []*rangeQuery
+	writes         map[string]map[string][]byte
+	increments     map[string]map[string]int64
+	metadataWrites map[string]map[string]map[string][]byte
+	collections    map[string]collection
+	paginated      bool
+}
+
+func newSimulation() *simulation {
+	return &simulation{
+		reads:          map[string]map[string]*version.Height{},
+		writes:         map[string]map[string][]byte{},
+		increments:     map[string]map[string]int64{},
+		metadataWrites: map[string]map[string]map[string][]byte{},
+		collections:    map[string]collection{},
+	}
+}
+
+var (
+	errPaginatedQueryAfterWrite = errors.New("paginated queries are only supported by read-only transactions, the transaction has already written keys")
+	errWriteAfterPaginatedQuery = errors.New("paginated queries are only supported by read-only transactions, the transaction cannot write keys after a paginated query")
+)
+
+func (s *simulation) hasWrites() bool {
+	return len(s.writes) != 0 || len(s.increments) != 0 || len(s.metadataWrites) != 0
+}
+
+func (s *simulation) addRead(ns, key string, v *version.Height) {
+	reads, ok := s.reads[ns]
+	if !ok {
+		reads = map[string]*version.Height{}
+		s.reads[ns] = reads
+	}
+	if _, ok := reads[key]; !ok {
+		reads[key] = v
+	}
+}
+
+func (s *simulation) addWrite(ns, key string, value []byte) error {
+	if s.paginated {
+		return errWriteAfterPaginatedQuery
+	}
+	writes, ok := s.writes[ns]
+	if !ok {
+		writes = map[string][]byte{}
+		s.writes[ns] = writes
+	}
+	writes[key] = value
+	if increments, ok := s.increments[ns]; ok {
+		delete(increments, key)
+	}
+	return nil
+}
+
+// addIncrement adds the delta to the value written to the key, if the simulation
+// writes it, or else to the increment of the key applied when the transaction is
+// committed
+func (s *simulation) addIncrement(ns, key string, delta int64) error {
+	if s.paginated {
+		return errWriteAfterPaginatedQuery
+	}
+	if value, ok := s.writes[ns][key]; ok {
+		incremented, err := rwsetutil.IncrementCounter(value, delta)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to increment key [%s] in namespace [%s]", key, ns))
+		}
+		s.writes[ns][key] = incremented
+		return nil
+	}
+	increments, ok := s.increments[ns]
+	if !ok {
+		increments = map[string]int64{}
+		s.increments[ns] = increments
+	}
+	accumulated := increments[key] + delta
+	if (delta > 0 && accumulated < increments[key]) || (delta < 0 && accumulated > increments[key]) {
+		return errors.Errorf("failed to increment key [%s] in namespace [%s]: accumulated delta overflows", key, ns)
+	}
+	increments[key] = accumulated
+	return nil
+}
+
+func (s *simulation) addMetadataWrite(ns, key string, metadata map[string][]byte) error {
+	if s.paginated {
+		return errWriteAfterPaginatedQuery
+	}
+	writes, ok := s.metadataWrites[ns]
+	if !ok {
+		writes = map[string]map[string][]byte{}
+		s.metadataWrites[ns] = writes
+	}
+	writes[key] = metadata
+	return nil
+}
+
+func (s *simulation) addPaginatedQuery() error {
+	if s.hasWrites() {
+		return errPaginatedQueryAfterWrite
+	}
+	s.paginated = true
+	return nil
+}
+
+// results builds the simulation results the peer would endorse, the private
+// data being hashed in the public read-write set
+func (s *simulation) results() (*ledger.TxSimulationResults, error) {
+	b := rwsetutil.NewRWSetBuilder()
+	for ns, reads := range s.reads {
+		for key, v := range reads {
+			if c, ok := s.collections[ns]; ok {
+				b.AddToHashedReadSet(c.ns, c.coll, key, v)
+				continue
+			}
+			b.AddToReadSet(ns, key, v)
+		}
+	}
+	for _, rq := range s.rangeQueries {
+		var reads []*kvrwset.KVRead
+		for _, r := range rq.reads {
+			reads = append(reads, rwsetutil.NewKVRead(r.key, r.version))
+		}
+		b.AddToRangeQuerySet(rq.ns, &kvrwset.RangeQueryInfo{
+			StartKey:     rq.startKey,
+			EndKey:       rq.endKey,
+			ItrExhausted: rq.exhausted,
+			ReadsInfo:    &kvrwset.RangeQueryInfo_RawReads{RawReads: &kvrwset.QueryReads{KvReads: reads}},
+		})
+	}
+	for ns, writes := range s.writes {
+		for key, value := range writes {
+			if c, ok := s.collections[ns]; ok {
+				b.AddToPvtAndHashedWriteSet(c.ns, c.coll, key, value)
+				continue
+			}
+			b.AddToWriteSet(ns, key, value)
+		}
+	}
+	for ns, increments := range s.increments {
+		for key, delta := range increments {
+			if err := b.AddToIncrementSet(ns, key, delta); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for ns, writes := range s.metadataWrites {
+		for key, metadata := range writes {
+			if c, ok := s.collections[ns]; ok {
+				b.AddToHashedMetadataWriteSet(c.ns, c.coll, key, metadata)
+				continue
+			}
+			b.AddToMetadataWriteSet(ns, key, metadata)
+		}
+	}
+	return b.GetTxSimulationResults()
+}
+
+// validate checks the reads of the simulation against the world state, which
+// holds the writes of the valid transactions committed before it
+func (s *simulation) validate(state *worldState) pb.TxValidationCode {
+	for ns, reads := range s.reads {
+		for key, v := range reads {
+			if !version.AreSame(state.version(ns, key), v) {
+				return pb.TxValidationCode_MVCC_READ_CONFLICT
+			}
+		}
+	}
+	for _, rq := range s.rangeQueries {
+		if !rq.validate(state) {
+			return pb.TxValidationCode_PHANTOM_READ_CONFLICT
+		}
+	}
+	if _, err := s.incremented(state); err != nil {
+		return pb.TxValidationCode_INVALID_WRITESET
+	}
+	return pb.TxValidationCode_VALID
+}
+
+// incremented returns the values of the keys incremented by the simulation, the
+// increments being added to the values of the world state
+func (s *simulation) incremented(state *worldState) (map[string]map[string][]byte, error) {
+	values := map[string]map[string][]byte{}
+	for ns, increments := range s.increments {
+		values[ns] = map[string][]byte{}
+		for key, delta := range increments {
+			var value []byte
+			if vv := state.get(ns, key); vv != nil {
+				value = vv.value
+			}
+			incremented, err := rwsetutil.IncrementCounter(value, delta)
+			if err != nil {
+				return nil, err
+			}
+			values[ns][key] = incremented
+		}
+	}
+	return values, nil
+}
+
+// validate runs the range query again and checks that it reads the same keys
+// at the same versions, up to the last key read when the simulation did not
+// exhaust the iterator
+func (rq *rangeQuery) validate(state *worldState) bool {
+	keys := state.rangeKeys(rq.ns, rq.startKey, rq.endKey, rq.filters)
+	if !rq.exhausted {
+		if len(rq.reads) == 0 {
+			return true
+		}
+		last := rq.reads[len(rq.reads)-1].key
+		for i, key := range keys {
+			if key > last {
+				keys = keys[:i]
+				break
+			}
+		}
+	}
+	if len(keys) != len(rq.reads) {
+		return false
+	}
+	for i, key := range keys {
+		if key != rq.reads[i].key || !version.AreSame(state.version(rq.ns, key), rq.reads[i].version) {
+			return false
+		}
+	}
+	return true
+}
+
+// apply writes the writes of a valid transaction to the world state at the
+// given height
+func (s *simulation) apply(state *worldState, height *version.Height) {
+	for ns, writes := range s.writes {
+		for key, value := range writes {
+			state.put(ns, key, value, height)
+		}
+	}
+	incremented, _ := s.incremented(state)
+	for ns, values := range incremented {
+		for key, value := range values {
+			state.put(ns, key, value, height)
+		}
+	}
+	for ns, writes := range s.metadataWrites {
+		for key, metadata := range writes {
+			state.putMetadata(ns, key, metadata, height)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincodetest
+
+import (
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// versionedValue is a value of the world state with the height of the
+// transaction which wrote it last
+type versionedValue struct {
+	value    []byte
+	metadata map[string][]byte
+	version  *version.Height
+}
+
+// worldState is the in-memory state database of a channel. The private data
+// of the collections of a chaincode are held in namespaces of their own, as
+// the real state database does
+type worldState struct {
+	namespaces map[string]map[string]*versionedValue
+}
+
+func newWorldState() *worldState {
+	return &worldState{namespaces: map[string]map[string]*versionedValue{}}
+}
+
+// privateNamespace returns the namespace holding the private data of the given
+// collection of a chaincode
+func privateNamespace(ns, coll string) string {
+	return ns + "$$p" + coll
+}
+
+func (s *worldState) get(ns, key string) *versionedValue {
+	return s.namespaces[ns][key]
+}
+
+// version returns the version of the given key, nil if it does not exist
+func (s *worldState) version(ns, key string) *version.Height {
+	if vv := s.get(ns, key); vv != nil {
+		return vv.version
+	}
+	return nil
+}
+
+// rangeKeys returns the sorted keys of the namespace from startKey included
+// to endKey excluded which satisfy the filters. An empty endKey does not
+// bound the range
+func (s *worldState) rangeKeys(ns, startKey, endKey string, filters []*pb.CompositeKeyFilter) []string {
+	var keys []string
+	for key := range s.namespaces[ns] {
+		if key < startKey || (endKey != "" && key >= endKey) {
+			continue
+		}
+		if !pb.MatchCompositeKey(key, filters) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// put writes the value of a key at the given height. A nil value deletes the
+// key. The metadata of a key is kept when only its value is written
+func (s *worldState) put(ns, key string, value []byte, height *version.Height) {
+	if value == nil {
+		delete(s.namespaces[ns], key)
+		return
+	}
+	keys, ok := s.namespaces[ns]
+	if !ok {
+		keys = map[string]*versionedValue{}
+		s.namespaces[ns] = keys
+	}
+	vv := &versionedValue{value: value, version: height}
+	if existing, ok := keys[key]; ok {
+		vv.metadata = existing.metadata
+	}
+	keys[key] = vv
+}
+
+// putMetadata writes the metadata of an existing key at the given height
+func (s *worldState) putMetadata(ns, key string, metadata map[string][]byte, height *version.Height) {
+	existing := s.get(ns, key)
+	if existing == nil {
+		return
+	}
+	s.namespaces[ns][key] = &versionedValue{value: existing.value, metadata: metadata, version: height}
+}
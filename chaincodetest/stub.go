@@ -0,0 +1,646 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincodetest
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+const (
+	minUnicodeRuneValue   = 0            //U+0000
+	maxUnicodeRuneValue   = utf8.MaxRune //U+10FFFF - maximum (and unallocated) code point
+	compositeKeyNamespace = "\x00"
+	emptyKeySubstitute    = "\x01"
+)
+
+// txContext is the context of a transaction, shared by the chaincodes it
+// invokes
+type txContext struct {
+	channel        *Channel
+	sim            *simulation
+	txID           string
+	creator        []byte
+	transient      map[string][]byte
+	binding        []byte
+	signedProposal *pb.SignedProposal
+	timestamp      *timestamp.Timestamp
+	// depth is the number of chaincodes in the chain of invocations
+	depth int
+	// txRand is the source of pseudo-random numbers of the transaction,
+	// created on first use
+	txRand *rand.Rand
+}
+
+// maxInvocationDepth bounds the chains of chaincode to chaincode invocations
+const maxInvocationDepth = 100
+
+// stub implements shim.ChaincodeStubInterface for a chaincode invoked by a
+// transaction simulated by a channel
+type stub struct {
+	*txContext
+	namespace   string
+	args        [][]byte
+	decorations map[string][]byte
+	event       *pb.ChaincodeEvent
+}
+
+var _ shim.ChaincodeStubInterface = &stub{}
+
+func (s *stub) GetArgs() [][]byte {
+	return s.args
+}
+
+func (s *stub) GetStringArgs() []string {
+	strargs := make([]string, 0, len(s.args))
+	for _, barg := range s.args {
+		strargs = append(strargs, string(barg))
+	}
+	return strargs
+}
+
+func (s *stub) GetFunctionAndParameters() (function string, params []string) {
+	allargs := s.GetStringArgs()
+	function = ""
+	params = []string{}
+	if len(allargs) >= 1 {
+		function = allargs[0]
+		params = allargs[1:]
+	}
+	return
+}
+
+func (s *stub) GetArgsSlice() ([]byte, error) {
+	var res []byte
+	for _, barg := range s.args {
+		res = append(res, barg...)
+	}
+	return res, nil
+}
+
+func (s *stub) GetTxID() string {
+	return s.txID
+}
+
+func (s *stub) GetChannelID() string {
+	return s.channel.channelID
+}
+
+// InvokeChaincode invokes a chaincode of the channel within the transaction,
+// the reads and writes of the invoked chaincode being recorded in its own
+// namespace. The channels other than the one of the transaction are not
+// simulated
+func (s *stub) InvokeChaincode(chaincodeName string, args [][]byte, channel string) pb.Response {
+	if channel != "" && channel != s.channel.channelID {
+		return shim.Error(fmt.Sprintf("cannot invoke chaincode %s on channel %s, only channel %s is simulated", chaincodeName, channel, s.channel.channelID))
+	}
+	cc, ok := s.channel.chaincodes[chaincodeName]
+	if !ok {
+		return shim.Error(fmt.Sprintf("chaincode %s is not installed on channel %s", chaincodeName, s.channel.channelID))
+	}
+	if s.depth >= maxInvocationDepth {
+		return shim.Error(fmt.Sprintf("cannot invoke chaincode %s, the transaction already invoked %d chaincodes in a chain", chaincodeName, s.depth))
+	}
+	s.depth++
+	defer func() { s.depth-- }()
+	return cc.Invoke(&stub{
+		txContext:   s.txContext,
+		namespace:   chaincodeName,
+		args:        args,
+		decorations: s.decorations,
+	})
+}
+
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+	if !utf8.ValidString(key) {
+		return errors.Errorf("invalid key. Key must be a valid UTF-8 string: [%x]", key)
+	}
+	return nil
+}
+
+func (s *stub) getState(ns, key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	vv := s.channel.state.get(ns, key)
+	if vv == nil {
+		s.sim.addRead(ns, key, nil)
+		return nil, nil
+	}
+	s.sim.addRead(ns, key, vv.version)
+	return vv.value, nil
+}
+
+func (s *stub) putState(ns, key string, value []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	return s.sim.addWrite(ns, key, value)
+}
+
+func (s *stub) delState(ns, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.sim.addWrite(ns, key, nil)
+}
+
+func (s *stub) getValidationParameter(ns, key string) ([]byte, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	vv := s.channel.state.get(ns, key)
+	if vv == nil {
+		s.sim.addRead(ns, key, nil)
+		return nil, nil
+	}
+	s.sim.addRead(ns, key, vv.version)
+	return vv.metadata[pb.MetaDataKeys_VALIDATION_PARAMETER.String()], nil
+}
+
+func (s *stub) setValidationParameter(ns, key string, ep []byte) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.sim.addMetadataWrite(ns, key, map[string][]byte{pb.MetaDataKeys_VALIDATION_PARAMETER.String(): ep})
+}
+
+// collectionNamespace returns the namespace of the private data of a
+// collection of the chaincode
+func (s *stub) collectionNamespace(coll string) (string, error) {
+	if coll == "" {
+		return "", errors.New("collection must not be an empty string")
+	}
+	ns := privateNamespace(s.namespace, coll)
+	s.sim.collections[ns] = collection{ns: s.namespace, coll: coll}
+	return ns, nil
+}
+
+func (s *stub) GetState(key string) ([]byte, error) {
+	return s.getState(s.namespace, key)
+}
+
+// GetStateWithProof returns the value of the key and the height it was written
+// at in a proof which is not anchored to any commit hash, since the in-memory
+// ledger does not record the commit hashes of the blocks
+func (s *stub) GetStateWithProof(key string) (*queryresult.StateProof, error) {
+	value, err := s.getState(s.namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.Errorf("key [%s] does not exist, the absence of a key cannot be proven", key)
+	}
+	height := s.channel.state.version(s.namespace, key)
+	return &queryresult.StateProof{
+		Namespace: s.namespace,
+		Key:       key,
+		Value:     value,
+		BlockNum:  height.BlockNum,
+		TxNum:     height.TxNum,
+	}, nil
+}
+
+func (s *stub) PutState(key string, value []byte) error {
+	return s.putState(s.namespace, key, value)
+}
+
+func (s *stub) DelState(key string) error {
+	return s.delState(s.namespace, key)
+}
+
+func (s *stub) DelStateRange(startKey, endKey string) error {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return err
+	}
+	if s.sim.paginated {
+		return errWriteAfterPaginatedQuery
+	}
+	itr := s.rangeQuery(s.namespace, startKey, endKey, nil, true)
+	for itr.HasNext() {
+		kv, err := itr.Next()
+		if err != nil {
+			return err
+		}
+		if err := s.sim.addWrite(s.namespace, kv.Key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stub) IncrementState(key string, delta int64) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.sim.addIncrement(s.namespace, key, delta)
+}
+
+func (s *stub) SetStateValidationParameter(key string, ep []byte) error {
+	return s.setValidationParameter(s.namespace, key, ep)
+}
+
+func (s *stub) GetStateValidationParameter(key string) ([]byte, error) {
+	return s.getValidationParameter(s.namespace, key)
+}
+
+// rangeQuery returns an iterator over the keys of the namespace from startKey
+// to endKey. The range queries of the public state are recorded to detect
+// phantom reads, the paginated ones being only allowed in read-only
+// transactions
+func (s *stub) rangeQuery(ns, startKey, endKey string, filters []*pb.CompositeKeyFilter, record bool) *stateIterator {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	itr := &stateIterator{
+		state: s.channel.state,
+		ns:    ns,
+		keys:  s.channel.state.rangeKeys(ns, startKey, endKey, filters),
+	}
+	if record {
+		itr.query = &rangeQuery{ns: ns, startKey: startKey, endKey: endKey, filters: filters}
+		s.sim.rangeQueries = append(s.sim.rangeQueries, itr.query)
+	}
+	return itr
+}
+
+// paginatedQuery returns an iterator over a page of the keys of the namespace
+// from startKey, or the bookmark, to endKey
+func (s *stub) paginatedQuery(ns, startKey, endKey string, filters []*pb.CompositeKeyFilter, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if err := s.sim.addPaginatedQuery(); err != nil {
+		return nil, nil, err
+	}
+	if pageSize <= 0 {
+		return nil, nil, errors.Errorf("invalid page size %d, it must be greater than zero", pageSize)
+	}
+	if bookmark != "" {
+		startKey = bookmark
+	}
+	itr := s.rangeQuery(ns, startKey, endKey, filters, false)
+	metadata := &pb.QueryResponseMetadata{}
+	if len(itr.keys) > int(pageSize) {
+		metadata.Bookmark = itr.keys[pageSize]
+		itr.keys = itr.keys[:pageSize]
+	}
+	metadata.FetchedRecordsCount = int32(len(itr.keys))
+	return itr, metadata, nil
+}
+
+func (s *stub) GetStateByRange(startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return s.rangeQuery(s.namespace, startKey, endKey, nil, true), nil
+}
+
+func (s *stub) GetStateByRangeWithPagination(startKey, endKey string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, nil, err
+	}
+	return s.paginatedQuery(s.namespace, startKey, endKey, nil, pageSize, bookmark)
+}
+
+func (s *stub) GetStateByPartialCompositeKey(objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	return s.GetStateByPartialCompositeKeyWithFilters(objectType, keys, nil)
+}
+
+func (s *stub) GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
+	pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return s.GetStateByPartialCompositeKeyWithFiltersAndPagination(objectType, keys, nil, pageSize, bookmark)
+}
+
+func (s *stub) GetStateByPartialCompositeKeyWithFilters(objectType string, keys []string,
+	filters []*pb.CompositeKeyFilter) (shim.StateQueryIteratorInterface, error) {
+	startKey, endKey, err := partialCompositeKeyRange(objectType, keys, filters)
+	if err != nil {
+		return nil, err
+	}
+	return s.rangeQuery(s.namespace, startKey, endKey, filters, true), nil
+}
+
+func (s *stub) GetStateByPartialCompositeKeyWithFiltersAndPagination(objectType string, keys []string,
+	filters []*pb.CompositeKeyFilter, pageSize int32, bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	startKey, endKey, err := partialCompositeKeyRange(objectType, keys, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.paginatedQuery(s.namespace, startKey, endKey, filters, pageSize, bookmark)
+}
+
+func (s *stub) CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	return createCompositeKey(objectType, attributes)
+}
+
+func (s *stub) SplitCompositeKey(compositeKey string) (string, []string, error) {
+	return splitCompositeKey(compositeKey)
+}
+
+// errRichQuery is returned by the rich queries, which need a state database
+// interpreting the queries
+var errRichQuery = errors.New("rich queries are not supported by the in-memory state database of chaincodetest")
+
+func (s *stub) GetQueryResult(query string) (shim.StateQueryIteratorInterface, error) {
+	return nil, errRichQuery
+}
+
+func (s *stub) GetQueryResultWithPagination(query string, pageSize int32,
+	bookmark string) (shim.StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, errRichQuery
+}
+
+func (s *stub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	return &historyIterator{modifications: s.channel.history[s.namespace][key]}, nil
+}
+
+func (s *stub) GetPrivateData(collection, key string) ([]byte, error) {
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return nil, err
+	}
+	return s.getState(ns, key)
+}
+
+func (s *stub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	value, err := s.GetPrivateData(collection, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return util.ComputeSHA256(value), nil
+}
+
+func (s *stub) PutPrivateData(collection string, key string, value []byte) error {
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return err
+	}
+	return s.putState(ns, key, value)
+}
+
+func (s *stub) DelPrivateData(collection, key string) error {
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return err
+	}
+	return s.delState(ns, key)
+}
+
+func (s *stub) SetPrivateDataValidationParameter(collection, key string, ep []byte) error {
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return err
+	}
+	return s.setValidationParameter(ns, key, ep)
+}
+
+func (s *stub) GetPrivateDataValidationParameter(collection, key string) ([]byte, error) {
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return nil, err
+	}
+	return s.getValidationParameter(ns, key)
+}
+
+// GetPrivateDataByRange returns an iterator over the private data of a
+// collection. As with the peer, the range queries of the private data are
+// not validated for phantom reads
+func (s *stub) GetPrivateDataByRange(collection, startKey, endKey string) (shim.StateQueryIteratorInterface, error) {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return nil, err
+	}
+	return s.rangeQuery(ns, startKey, endKey, nil, false), nil
+}
+
+func (s *stub) GetPrivateDataByPartialCompositeKey(collection, objectType string, keys []string) (shim.StateQueryIteratorInterface, error) {
+	startKey, endKey, err := partialCompositeKeyRange(objectType, keys, nil)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := s.collectionNamespace(collection)
+	if err != nil {
+		return nil, err
+	}
+	return s.rangeQuery(ns, startKey, endKey, nil, false), nil
+}
+
+func (s *stub) GetPrivateDataQueryResult(collection, query string) (shim.StateQueryIteratorInterface, error) {
+	return nil, errRichQuery
+}
+
+func (s *stub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+func (s *stub) GetTransient() (map[string][]byte, error) {
+	return s.transient, nil
+}
+
+func (s *stub) GetBinding() ([]byte, error) {
+	return s.binding, nil
+}
+
+func (s *stub) GetDecorations() map[string][]byte {
+	return s.decorations
+}
+
+func (s *stub) GetSignedProposal() (*pb.SignedProposal, error) {
+	return s.signedProposal, nil
+}
+
+func (s *stub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return s.timestamp, nil
+}
+
+func (s *stub) GetTxTime() (time.Time, error) {
+	return shim.TxTime(s.timestamp)
+}
+
+func (s *stub) GetTxRand() *rand.Rand {
+	if s.txRand == nil {
+		s.txRand = shim.NewTxRand(s.channel.channelID, s.txID)
+	}
+	return s.txRand
+}
+
+func (s *stub) GetChannelConfig() (*pb.ChannelConfigSnapshot, error) {
+	if s.channel.ChannelConfig == nil {
+		return nil, errors.Errorf("no channel config set on channel %s", s.channel.channelID)
+	}
+	return s.channel.ChannelConfig, nil
+}
+
+func (s *stub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be empty string")
+	}
+	s.event = &pb.ChaincodeEvent{
+		ChaincodeId: s.namespace,
+		TxId:        s.txID,
+		EventName:   name,
+		Payload:     payload,
+	}
+	return nil
+}
+
+// stateIterator iterates over the keys of a range query, recording the keys it
+// returns in the range query of the simulation, if any
+type stateIterator struct {
+	state  *worldState
+	ns     string
+	keys   []string
+	query  *rangeQuery
+	closed bool
+}
+
+func (itr *stateIterator) HasNext() bool {
+	if itr.closed {
+		return false
+	}
+	if len(itr.keys) == 0 {
+		if itr.query != nil {
+			itr.query.exhausted = true
+		}
+		return false
+	}
+	return true
+}
+
+func (itr *stateIterator) Next() (*queryresult.KV, error) {
+	if itr.closed {
+		return nil, errors.New("iterator is closed")
+	}
+	if !itr.HasNext() {
+		return nil, errors.New("no such key")
+	}
+	key := itr.keys[0]
+	itr.keys = itr.keys[1:]
+	vv := itr.state.get(itr.ns, key)
+	if itr.query != nil {
+		itr.query.reads = append(itr.query.reads, kvRead{key: key, version: vv.version})
+	}
+	return &queryresult.KV{Namespace: itr.ns, Key: key, Value: vv.value}, nil
+}
+
+func (itr *stateIterator) Close() error {
+	itr.closed = true
+	return nil
+}
+
+// historyIterator iterates over the committed modifications of a key
+type historyIterator struct {
+	modifications []*queryresult.KeyModification
+	closed        bool
+}
+
+func (itr *historyIterator) HasNext() bool {
+	return !itr.closed && len(itr.modifications) != 0
+}
+
+func (itr *historyIterator) Next() (*queryresult.KeyModification, error) {
+	if !itr.HasNext() {
+		return nil, errors.New("no such modification")
+	}
+	km := itr.modifications[0]
+	itr.modifications = itr.modifications[1:]
+	return proto.Clone(km).(*queryresult.KeyModification), nil
+}
+
+func (itr *historyIterator) Close() error {
+	itr.closed = true
+	return nil
+}
+
+func createCompositeKey(objectType string, attributes []string) (string, error) {
+	if err := validateCompositeKeyAttribute(objectType); err != nil {
+		return "", err
+	}
+	ck := compositeKeyNamespace + objectType + string(rune(minUnicodeRuneValue))
+	for _, att := range attributes {
+		if err := validateCompositeKeyAttribute(att); err != nil {
+			return "", err
+		}
+		ck += att + string(rune(minUnicodeRuneValue))
+	}
+	return ck, nil
+}
+
+func splitCompositeKey(compositeKey string) (string, []string, error) {
+	componentIndex := 1
+	components := []string{}
+	for i := 1; i < len(compositeKey); i++ {
+		if compositeKey[i] == minUnicodeRuneValue {
+			components = append(components, compositeKey[componentIndex:i])
+			componentIndex = i + 1
+		}
+	}
+	if len(components) == 0 {
+		return "", nil, errors.Errorf("invalid composite key [%x]", compositeKey)
+	}
+	return components[0], components[1:], nil
+}
+
+// partialCompositeKeyRange returns the range of the composite keys starting
+// with the given partial composite key
+func partialCompositeKeyRange(objectType string, attributes []string, filters []*pb.CompositeKeyFilter) (string, string, error) {
+	for _, filter := range filters {
+		if err := filter.Validate(); err != nil {
+			return "", "", err
+		}
+	}
+	partialCompositeKey, err := createCompositeKey(objectType, attributes)
+	if err != nil {
+		return "", "", err
+	}
+	return partialCompositeKey, partialCompositeKey + string(rune(maxUnicodeRuneValue)), nil
+}
+
+func validateCompositeKeyAttribute(str string) error {
+	if !utf8.ValidString(str) {
+		return errors.Errorf("not a valid utf8 string: [%x]", str)
+	}
+	for index, runeValue := range str {
+		if runeValue == minUnicodeRuneValue || runeValue == maxUnicodeRuneValue {
+			return errors.Errorf(`input contain unicode %#U starting at position [%d]. %#U and %#U are not allowed in the input attribute of a composite key`,
+				runeValue, index, minUnicodeRuneValue, maxUnicodeRuneValue)
+		}
+	}
+	return nil
+}
+
+// validateSimpleKeys checks that the simple keys do not start with the
+// namespace of the composite keys
+func validateSimpleKeys(simpleKeys ...string) error {
+	for _, key := range simpleKeys {
+		if len(key) > 0 && key[0] == compositeKeyNamespace[0] {
+			return errors.Errorf(`first character of the key [%s] contains a null character which is not allowed`, key)
+		}
+	}
+	return nil
+}
@@ -27,15 +27,80 @@ const (
 
 	// ApplicationResourcesTreeExperimental is the capabilties string for private data using the experimental feature of collections/sideDB.
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
+
+	// ApplicationFabTokenExperimental is the capabilties string for the experimental token transactions (FabToken).
+	ApplicationFabTokenExperimental = "V1_4_FABTOKEN_EXPERIMENTAL"
+
+	// ApplicationCommutativeUpdatesExperimental is the capabilties string for the experimental commutative updates (increments) of keys.
+	ApplicationCommutativeUpdatesExperimental = "V1_4_COMMUTATIVE_UPDATES_EXPERIMENTAL"
+
+	// ApplicationCommitHashExperimental is the capabilties string for the experimental commit hashes recorded in the block metadata.
+	ApplicationCommitHashExperimental = "V1_4_COMMIT_HASH_EXPERIMENTAL"
+
+	// ApplicationChaincodeMigrationExperimental is the capabilties string for the experimental migrations of the state of the chaincodes upon upgrade.
+	ApplicationChaincodeMigrationExperimental = "V1_4_CHAINCODE_MIGRATION_EXPERIMENTAL"
+
+	// ApplicationStateBasedEndorsement is the capabilties string for the key level endorsement policies
+	// (state-based endorsement), independently of the v1.3 application capabilities.
+	ApplicationStateBasedEndorsement = "STATE_BASED_ENDORSEMENT"
+
+	// ApplicationCommitHash is the capabilties string for the commit hashes recorded in the block metadata.
+	ApplicationCommitHash = "COMMIT_HASH"
+
+	// ApplicationTokenTransactions is the capabilties string for the token transactions.
+	ApplicationTokenTransactions = "TOKEN_TRANSACTIONS"
+
+	// ApplicationTxExpiration is the capabilties string for the expiration heights of the transactions.
+	ApplicationTxExpiration = "TX_EXPIRATION"
+
+	// ApplicationChaincodeBatches is the capabilties string for the transactions invoking several chaincodes atomically.
+	ApplicationChaincodeBatches = "CHAINCODE_BATCHES"
+
+	// ApplicationScheduledTransactions is the capabilties string for the transactions submitted on behalf of their creator.
+	ApplicationScheduledTransactions = "SCHEDULED_TRANSACTIONS"
+
+	// ApplicationCustomConfigGroups is the capabilties string for the custom groups of the application config.
+	ApplicationCustomConfigGroups = "CUSTOM_CONFIG_GROUPS"
+
+	// ApplicationResourceBudgets is the capabilties string for the budgets of the resources used by the simulation of the proposals.
+	ApplicationResourceBudgets = "RESOURCE_BUDGETS"
+
+	// ApplicationChaincodeContracts is the capabilties string for the contracts of the chaincodes validated with their own endorsement policy.
+	ApplicationChaincodeContracts = "CHAINCODE_CONTRACTS"
+
+	// ApplicationBinaryChaincodes is the capabilties string for the chaincodes packaged as prebuilt binaries.
+	ApplicationBinaryChaincodes = "BINARY_CHAINCODES"
+
+	// ApplicationSystemChaincodePolicies is the capabilties string for the endorsement policies of the namespaces of the system chaincodes.
+	ApplicationSystemChaincodePolicies = "SYSTEM_CHAINCODE_POLICIES"
+
+	// ApplicationRangeDeletes is the capabilties string for the deletions of ranges of keys recorded as a single entry of the write set.
+	ApplicationRangeDeletes = "RANGE_DELETES"
 )
 
 // ApplicationProvider provides capabilities information for application level config.
 type ApplicationProvider struct {
 	*registry
-	v11                    bool
-	v12                    bool
-	v13                    bool
-	v11PvtDataExperimental bool
+	v11                     bool
+	v12                     bool
+	v13                     bool
+	v11PvtDataExperimental  bool
+	v14FabTokenExperimental bool
+	v14CommutativeUpdates   bool
+	v14CommitHash           bool
+	v14ChaincodeMigration   bool
+	stateBasedEndorsement   bool
+	commitHash              bool
+	tokenTransactions       bool
+	txExpiration            bool
+	chaincodeBatches        bool
+	scheduledTransactions   bool
+	customConfigGroups      bool
+	resourceBudgets         bool
+	chaincodeContracts      bool
+	binaryChaincodes        bool
+	systemChaincodePolicies bool
+	rangeDeletes            bool
 }
 
 // NewApplicationProvider creates a application capabilities provider.
@@ -46,6 +111,22 @@ func NewApplicationProvider(capabilities map[string]*cb.Capability) *Application
 	_, ap.v12 = capabilities[ApplicationV1_2]
 	_, ap.v13 = capabilities[ApplicationV1_3]
 	_, ap.v11PvtDataExperimental = capabilities[ApplicationPvtDataExperimental]
+	_, ap.v14FabTokenExperimental = capabilities[ApplicationFabTokenExperimental]
+	_, ap.v14CommutativeUpdates = capabilities[ApplicationCommutativeUpdatesExperimental]
+	_, ap.v14CommitHash = capabilities[ApplicationCommitHashExperimental]
+	_, ap.v14ChaincodeMigration = capabilities[ApplicationChaincodeMigrationExperimental]
+	_, ap.stateBasedEndorsement = capabilities[ApplicationStateBasedEndorsement]
+	_, ap.commitHash = capabilities[ApplicationCommitHash]
+	_, ap.tokenTransactions = capabilities[ApplicationTokenTransactions]
+	_, ap.txExpiration = capabilities[ApplicationTxExpiration]
+	_, ap.chaincodeBatches = capabilities[ApplicationChaincodeBatches]
+	_, ap.scheduledTransactions = capabilities[ApplicationScheduledTransactions]
+	_, ap.customConfigGroups = capabilities[ApplicationCustomConfigGroups]
+	_, ap.resourceBudgets = capabilities[ApplicationResourceBudgets]
+	_, ap.chaincodeContracts = capabilities[ApplicationChaincodeContracts]
+	_, ap.binaryChaincodes = capabilities[ApplicationBinaryChaincodes]
+	_, ap.systemChaincodePolicies = capabilities[ApplicationSystemChaincodePolicies]
+	_, ap.rangeDeletes = capabilities[ApplicationRangeDeletes]
 	return ap
 }
 
@@ -104,14 +185,96 @@ func (ap *ApplicationProvider) MetadataLifecycle() bool {
 }
 
 // KeyLevelEndorsement returns true if this channel supports endorsement
-// policies expressible at a ledger key granularity, as described in FAB-8812
+// policies expressible at a ledger key granularity, as described in FAB-8812.
+// They are enabled by the v1.3 capabilities, or on their own by the
+// STATE_BASED_ENDORSEMENT capability.
 func (ap *ApplicationProvider) KeyLevelEndorsement() bool {
-	return ap.v13
+	return ap.v13 || ap.stateBasedEndorsement
 }
 
-// There is no fabtoken support in v1.4, so always return false
+// FabToken returns true if this channel supports the token transactions (FabToken).
+// The token transactions have to be enabled explicitly, by the TOKEN_TRANSACTIONS
+// capability or by its experimental predecessor.
 func (ap *ApplicationProvider) FabToken() bool {
-	return false
+	return ap.v14FabTokenExperimental || ap.tokenTransactions
+}
+
+// CommutativeUpdates returns true if this channel supports the increments of keys, which are
+// applied at commit time and are therefore exempt from MVCC read conflicts.
+// The commutative updates are experimental and have to be enabled explicitly.
+func (ap *ApplicationProvider) CommutativeUpdates() bool {
+	return ap.v14CommutativeUpdates
+}
+
+// CommitHash returns true if the committers of this channel record in the metadata of each block
+// a hash of the updates of the state committed up to the block, which the peers compare.
+// The commit hashes have to be enabled explicitly, by the COMMIT_HASH capability or by its
+// experimental predecessor.
+func (ap *ApplicationProvider) CommitHash() bool {
+	return ap.v14CommitHash || ap.commitHash
+}
+
+// ChaincodeMigration returns true if the chaincodes of this channel may be upgraded with a migration,
+// which invokes the Migrate function of the chaincode instead of its Init function.
+// The chaincode migrations are experimental and have to be enabled explicitly.
+func (ap *ApplicationProvider) ChaincodeMigration() bool {
+	return ap.v14ChaincodeMigration
+}
+
+// TxExpiration returns true if the committers of this channel invalidate the transactions
+// committed in a block whose number is not lower than the expiration height of their header.
+func (ap *ApplicationProvider) TxExpiration() bool {
+	return ap.txExpiration
+}
+
+// ChaincodeBatches returns true if the transactions of this channel may invoke several chaincodes
+// atomically through the batch system chaincode, in which case the committers validate the
+// namespace of each application chaincode written by a system chaincode with its own policy.
+func (ap *ApplicationProvider) ChaincodeBatches() bool {
+	return ap.chaincodeBatches
+}
+
+// ScheduledTransactions returns true if the committers of this channel accept the scheduled
+// transactions, whose envelope carries the signature of their proposal by their creator, and
+// invalidate those committed in a block whose number is lower than their scheduled height.
+func (ap *ApplicationProvider) ScheduledTransactions() bool {
+	return ap.scheduledTransactions
+}
+
+// CustomConfigGroups returns true if the application config of this channel may hold custom groups
+// defined by the consortium, which are the groups holding no MSP, alongside the organizations.
+func (ap *ApplicationProvider) CustomConfigGroups() bool {
+	return ap.customConfigGroups
+}
+
+// ResourceBudgets returns true if the application config of this channel may hold a budget of the
+// resources used by the simulation of each proposal, which the transactions must not exceed.
+func (ap *ApplicationProvider) ResourceBudgets() bool {
+	return ap.resourceBudgets
+}
+
+// ChaincodeContracts returns true if the chaincode definitions of this channel may define contracts, in which case the
+// committers validate the invocations of the functions of a contract with the endorsement policy of the contract.
+func (ap *ApplicationProvider) ChaincodeContracts() bool {
+	return ap.chaincodeContracts
+}
+
+// BinaryChaincodes returns true if the chaincodes of this channel may be packaged as prebuilt statically linked binaries,
+// in which case the committers validate the deployments and upgrades of the chaincodes of the binary platform.
+func (ap *ApplicationProvider) BinaryChaincodes() bool {
+	return ap.binaryChaincodes
+}
+
+// SystemChaincodePolicies returns true if the namespaces of the system chaincodes of this channel may be validated with the endorsement
+// policies defined by the channel config, rather than with the endorsement of any member of the channel.
+func (ap *ApplicationProvider) SystemChaincodePolicies() bool {
+	return ap.systemChaincodePolicies
+}
+
+// RangeDeletes returns true if the transactions of this channel may delete a range of keys with a single entry of their
+// write set, which the committers validate against the fingerprint of the keys of the range and expand at commit time.
+func (ap *ApplicationProvider) RangeDeletes() bool {
+	return ap.rangeDeletes
 }
 
 // HasCapability returns true if the capability is supported by this binary.
@@ -128,6 +291,38 @@ func (ap *ApplicationProvider) HasCapability(capability string) bool {
 		return true
 	case ApplicationResourcesTreeExperimental:
 		return true
+	case ApplicationFabTokenExperimental:
+		return true
+	case ApplicationCommutativeUpdatesExperimental:
+		return true
+	case ApplicationCommitHashExperimental:
+		return true
+	case ApplicationChaincodeMigrationExperimental:
+		return true
+	case ApplicationStateBasedEndorsement:
+		return true
+	case ApplicationCommitHash:
+		return true
+	case ApplicationTokenTransactions:
+		return true
+	case ApplicationTxExpiration:
+		return true
+	case ApplicationChaincodeBatches:
+		return true
+	case ApplicationScheduledTransactions:
+		return true
+	case ApplicationCustomConfigGroups:
+		return true
+	case ApplicationResourceBudgets:
+		return true
+	case ApplicationChaincodeContracts:
+		return true
+	case ApplicationBinaryChaincodes:
+		return true
+	case ApplicationSystemChaincodePolicies:
+		return true
+	case ApplicationRangeDeletes:
+		return true
 	default:
 		return false
 	}
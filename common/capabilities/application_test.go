@@ -65,6 +65,174 @@ func TestApplicationPvtDataExperimental(t *testing.T) {
 func TestFabToken(t *testing.T) {
 	ap := NewApplicationProvider(map[string]*cb.Capability{})
 	assert.False(t, ap.FabToken())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationFabTokenExperimental: {},
+	})
+	assert.True(t, ap.FabToken())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationTokenTransactions: {},
+	})
+	assert.True(t, ap.FabToken())
+}
+
+func TestCommutativeUpdates(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.CommutativeUpdates())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationCommutativeUpdatesExperimental: {},
+	})
+	assert.True(t, ap.CommutativeUpdates())
+}
+
+func TestCommitHash(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.CommitHash())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationCommitHashExperimental: {},
+	})
+	assert.True(t, ap.CommitHash())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationCommitHash: {},
+	})
+	assert.True(t, ap.CommitHash())
+}
+
+func TestTxExpiration(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.TxExpiration())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationTxExpiration: {},
+	})
+	assert.True(t, ap.TxExpiration())
+}
+
+func TestChaincodeBatches(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.ChaincodeBatches())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationChaincodeBatches: {},
+	})
+	assert.True(t, ap.ChaincodeBatches())
+}
+
+func TestScheduledTransactions(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.ScheduledTransactions())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationScheduledTransactions: {},
+	})
+	assert.True(t, ap.ScheduledTransactions())
+}
+
+func TestCustomConfigGroups(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.CustomConfigGroups())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationCustomConfigGroups: {},
+	})
+	assert.True(t, ap.CustomConfigGroups())
+}
+
+func TestResourceBudgets(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.ResourceBudgets())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationResourceBudgets: {},
+	})
+	assert.True(t, ap.ResourceBudgets())
+}
+
+func TestChaincodeContracts(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.ChaincodeContracts())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationChaincodeContracts: {},
+	})
+	assert.True(t, ap.ChaincodeContracts())
+}
+
+func TestBinaryChaincodes(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.BinaryChaincodes())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationBinaryChaincodes: {},
+	})
+	assert.True(t, ap.BinaryChaincodes())
+}
+
+func TestSystemChaincodePolicies(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.SystemChaincodePolicies())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationSystemChaincodePolicies: {},
+	})
+	assert.True(t, ap.SystemChaincodePolicies())
+}
+
+func TestRangeDeletes(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_3: {},
+	})
+	assert.False(t, ap.RangeDeletes())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationRangeDeletes: {},
+	})
+	assert.True(t, ap.RangeDeletes())
+}
+
+func TestStateBasedEndorsement(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_2: {},
+	})
+	assert.False(t, ap.KeyLevelEndorsement())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_2:                  {},
+		ApplicationStateBasedEndorsement: {},
+	})
+	assert.NoError(t, ap.Supported())
+	assert.True(t, ap.KeyLevelEndorsement())
+	assert.False(t, ap.V1_3Validation())
+}
+
+func TestChaincodeMigration(t *testing.T) {
+	ap := NewApplicationProvider(map[string]*cb.Capability{})
+	assert.False(t, ap.ChaincodeMigration())
+
+	ap = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationChaincodeMigrationExperimental: {},
+	})
+	assert.True(t, ap.ChaincodeMigration())
 }
 
 func TestHasCapability(t *testing.T) {
@@ -74,5 +242,21 @@ func TestHasCapability(t *testing.T) {
 	assert.True(t, ap.HasCapability(ApplicationV1_3))
 	assert.True(t, ap.HasCapability(ApplicationPvtDataExperimental))
 	assert.True(t, ap.HasCapability(ApplicationResourcesTreeExperimental))
+	assert.True(t, ap.HasCapability(ApplicationFabTokenExperimental))
+	assert.True(t, ap.HasCapability(ApplicationCommutativeUpdatesExperimental))
+	assert.True(t, ap.HasCapability(ApplicationCommitHashExperimental))
+	assert.True(t, ap.HasCapability(ApplicationChaincodeMigrationExperimental))
+	assert.True(t, ap.HasCapability(ApplicationStateBasedEndorsement))
+	assert.True(t, ap.HasCapability(ApplicationCommitHash))
+	assert.True(t, ap.HasCapability(ApplicationTokenTransactions))
+	assert.True(t, ap.HasCapability(ApplicationTxExpiration))
+	assert.True(t, ap.HasCapability(ApplicationChaincodeBatches))
+	assert.True(t, ap.HasCapability(ApplicationScheduledTransactions))
+	assert.True(t, ap.HasCapability(ApplicationCustomConfigGroups))
+	assert.True(t, ap.HasCapability(ApplicationResourceBudgets))
+	assert.True(t, ap.HasCapability(ApplicationChaincodeContracts))
+	assert.True(t, ap.HasCapability(ApplicationBinaryChaincodes))
+	assert.True(t, ap.HasCapability(ApplicationSystemChaincodePolicies))
+	assert.True(t, ap.HasCapability(ApplicationRangeDeletes))
 	assert.False(t, ap.HasCapability("default"))
 }
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package capabilities
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/pkg/errors"
@@ -37,15 +40,43 @@ func newRegistry(p provider, capabilities map[string]*cb.Capability) *registry {
 	}
 }
 
+// UnsupportedError is returned when the config requires capabilities which
+// this binary does not support. The binary must be upgraded before it
+// processes the channel any further.
+type UnsupportedError struct {
+	// Type is the type of the capabilities, such as Application
+	Type string
+	// Capabilities are the sorted names of the unsupported capabilities
+	Capabilities []string
+}
+
+func (e *UnsupportedError) Error() string {
+	if len(e.Capabilities) == 1 {
+		return e.Type + " capability " + e.Capabilities[0] + " is required but not supported"
+	}
+	return e.Type + " capabilities " + strings.Join(e.Capabilities, ", ") + " are required but not supported"
+}
+
+// IsUnsupported returns whether the cause of the error is a capability
+// required but not supported by this binary
+func IsUnsupported(err error) bool {
+	_, ok := errors.Cause(err).(*UnsupportedError)
+	return ok
+}
+
 // Supported checks that all of the required capabilities are supported by this binary.
 func (r *registry) Supported() error {
+	var unsupported []string
 	for capabilityName := range r.capabilities {
 		if r.provider.HasCapability(capabilityName) {
 			logger.Debugf("%s capability %s is supported and is enabled", r.provider.Type(), capabilityName)
 			continue
 		}
-
-		return errors.Errorf("%s capability %s is required but not supported", r.provider.Type(), capabilityName)
+		unsupported = append(unsupported, capabilityName)
+	}
+	if len(unsupported) == 0 {
+		return nil
 	}
-	return nil
+	sort.Strings(unsupported)
+	return &UnsupportedError{Type: r.provider.Type(), Capabilities: unsupported}
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +37,17 @@ func TestNotSatisfied(t *testing.T) {
 		assert.Error(t, provider.Supported())
 	}
 }
+
+func TestUnsupportedError(t *testing.T) {
+	err := NewApplicationProvider(map[string]*cb.Capability{
+		"FakeCapability2": {},
+		ApplicationV1_3:   {},
+		"FakeCapability1": {},
+	}).Supported()
+	assert.EqualError(t, err, "Application capabilities FakeCapability1, FakeCapability2 are required but not supported")
+	assert.True(t, IsUnsupported(errors.WithMessage(err, "channel mychannel")))
+	assert.False(t, IsUnsupported(errors.New("another error")))
+
+	err = NewChannelProvider(map[string]*cb.Capability{"FakeCapability": {}}).Supported()
+	assert.Equal(t, &UnsupportedError{Type: "Channel", Capabilities: []string{"FakeCapability"}}, err)
+}
@@ -0,0 +1,392 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cauthdsl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Predicates of the principals
+const (
+	PredicateOU        = "ou"
+	PredicateAnonymous = "anonymous"
+	PredicateNominal   = "nominal"
+)
+
+// Compile compiles a policy written in the policy language into a
+// SignaturePolicyEnvelope. The language is a superset of the one of
+// FromString:
+//
+//	POLICY := PRINCIPAL | GATE
+//	GATE := AND(POLICY, ...) | OR(POLICY, ...) | OutOf(N, POLICY, ...) | N OF (POLICY, ...)
+//	PRINCIPAL := MSP.ROLE | MSP.ROLE[PREDICATE, ...]
+//	PREDICATE := ou=OU | anonymous | nominal
+//
+// The gates and the roles are case insensitive. N is the number of policies
+// which must be satisfied, between 1 and the number of policies of the gate.
+// MSP is the MSP identifier and ROLE takes the value of any of the RoleXXX
+// constants; the principal may be quoted, as in 'Org1MSP.peer'. The predicates
+// restrict the identities satisfying the principal to the ones of an
+// organizational unit, or to the anonymous or nominal ones.
+//
+// For instance, AND(Org1MSP.peer, 2 OF (Org2MSP.admin, Org3MSP.peer[ou=ops],
+// Org4MSP.peer)) requires a signature from a peer of Org1MSP, and from two of
+// an admin of Org2MSP, a peer of the ops organizational unit of Org3MSP and a
+// peer of Org4MSP. The identities are shared by the principals which are
+// identical.
+func Compile(policy string) (*common.SignaturePolicyEnvelope, error) {
+	tokens, err := tokenize(policy)
+	if err != nil {
+		return nil, err
+	}
+	c := &compiler{tokens: tokens, identityIndexes: map[string]int32{}}
+	rule, err := c.policy()
+	if err != nil {
+		return nil, err
+	}
+	if tok := c.peek(); tok.kind != tokenEOF {
+		return nil, c.unexpected(tok, "the end of the policy")
+	}
+	return &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       rule,
+		Identities: c.identities,
+	}, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenQuoted
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+	tokenEquals
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "the end of the policy"
+	}
+	if t.kind == tokenQuoted {
+		return fmt.Sprintf("'%s' at position %d", t.value, t.pos)
+	}
+	return fmt.Sprintf("%s at position %d", t.value, t.pos)
+}
+
+var punctuation = map[byte]tokenKind{
+	'(': tokenLParen,
+	')': tokenRParen,
+	'[': tokenLBracket,
+	']': tokenRBracket,
+	',': tokenComma,
+	'=': tokenEquals,
+}
+
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '.' || c == '-' || c == '_'
+}
+
+func tokenize(policy string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(policy); {
+		c := policy[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case punctuation[c] != tokenEOF:
+			tokens = append(tokens, token{kind: punctuation[c], value: string(c), pos: i})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(policy[i+1:], c)
+			if end < 0 {
+				return nil, errors.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, value: policy[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case isWordChar(c):
+			start := i
+			for i < len(policy) && isWordChar(policy[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: policy[start:i], pos: start})
+		default:
+			return nil, errors.Errorf("unexpected character '%c' at position %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(policy)}), nil
+}
+
+type compiler struct {
+	tokens          []token
+	next            int
+	identities      []*msp.MSPPrincipal
+	identityIndexes map[string]int32
+}
+
+func (c *compiler) peek() token {
+	return c.tokens[c.next]
+}
+
+func (c *compiler) peekAt(offset int) token {
+	if c.next+offset >= len(c.tokens) {
+		return c.tokens[len(c.tokens)-1]
+	}
+	return c.tokens[c.next+offset]
+}
+
+func (c *compiler) consume() token {
+	tok := c.tokens[c.next]
+	if tok.kind != tokenEOF {
+		c.next++
+	}
+	return tok
+}
+
+func (c *compiler) expect(kind tokenKind, expected string) (token, error) {
+	tok := c.consume()
+	if tok.kind != kind {
+		return tok, c.unexpected(tok, expected)
+	}
+	return tok, nil
+}
+
+func (c *compiler) unexpected(tok token, expected string) error {
+	return errors.Errorf("unexpected %s, expected %s", tok, expected)
+}
+
+func (c *compiler) policy() (*common.SignaturePolicy, error) {
+	tok := c.peek()
+	switch tok.kind {
+	case tokenQuoted:
+		return c.principal()
+	case tokenWord:
+		if c.peekAt(1).kind == tokenLParen {
+			switch strings.ToLower(tok.value) {
+			case strings.ToLower(GateAnd):
+				c.consume()
+				return c.gate(tok, -1)
+			case strings.ToLower(GateOr):
+				c.consume()
+				return c.gate(tok, 1)
+			case strings.ToLower(GateOutOf):
+				c.consume()
+				return c.outOf(tok)
+			}
+		}
+		if next := c.peekAt(1); next.kind == tokenWord && strings.ToLower(next.value) == "of" {
+			n, err := c.threshold()
+			if err != nil {
+				return nil, err
+			}
+			c.consume()
+			return c.gate(tok, n)
+		}
+		return c.principal()
+	default:
+		return nil, c.unexpected(c.consume(), "a principal or a gate")
+	}
+}
+
+func (c *compiler) threshold() (int, error) {
+	tok := c.consume()
+	n, err := strconv.Atoi(tok.value)
+	if err != nil {
+		return 0, errors.Errorf("invalid threshold %s", tok)
+	}
+	return n, nil
+}
+
+func (c *compiler) outOf(gate token) (*common.SignaturePolicy, error) {
+	if _, err := c.expect(tokenLParen, "'('"); err != nil {
+		return nil, err
+	}
+	n, err := c.threshold()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.expect(tokenComma, "','"); err != nil {
+		return nil, err
+	}
+	policies, err := c.policies()
+	if err != nil {
+		return nil, err
+	}
+	return c.nOutOf(gate, n, policies)
+}
+
+// gate compiles the policies of a gate requiring n of them, or all of them
+// if n is negative
+func (c *compiler) gate(gate token, n int) (*common.SignaturePolicy, error) {
+	if _, err := c.expect(tokenLParen, "'('"); err != nil {
+		return nil, err
+	}
+	policies, err := c.policies()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		n = len(policies)
+	}
+	return c.nOutOf(gate, n, policies)
+}
+
+func (c *compiler) nOutOf(gate token, n int, policies []*common.SignaturePolicy) (*common.SignaturePolicy, error) {
+	if n < 1 || n > len(policies) {
+		return nil, errors.Errorf("invalid threshold %d of %s, expected a threshold between 1 and %d", n, gate, len(policies))
+	}
+	return NOutOf(int32(n), policies), nil
+}
+
+// policies compiles the comma separated policies of a gate, up to the closing parenthesis
+func (c *compiler) policies() ([]*common.SignaturePolicy, error) {
+	var policies []*common.SignaturePolicy
+	for {
+		policy, err := c.policy()
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+
+		tok := c.consume()
+		switch tok.kind {
+		case tokenComma:
+		case tokenRParen:
+			return policies, nil
+		default:
+			return nil, c.unexpected(tok, "',' or ')'")
+		}
+	}
+}
+
+func (c *compiler) principal() (*common.SignaturePolicy, error) {
+	tok := c.consume()
+	i := strings.LastIndex(tok.value, ".")
+	if i <= 0 || i == len(tok.value)-1 {
+		return nil, errors.Errorf("invalid principal %s, expected MSP.ROLE", tok)
+	}
+	mspID, roleName := tok.value[:i], tok.value[i+1:]
+
+	var role msp.MSPRole_MSPRoleType
+	switch strings.ToLower(roleName) {
+	case RoleMember:
+		role = msp.MSPRole_MEMBER
+	case RoleAdmin:
+		role = msp.MSPRole_ADMIN
+	case RoleClient:
+		role = msp.MSPRole_CLIENT
+	case RolePeer:
+		role = msp.MSPRole_PEER
+	default:
+		return nil, errors.Errorf("invalid role %s of principal %s", roleName, tok)
+	}
+	principal := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{MspIdentifier: mspID, Role: role}),
+	}
+
+	if c.peek().kind == tokenLBracket {
+		c.consume()
+		predicates, err := c.predicates(mspID)
+		if err != nil {
+			return nil, err
+		}
+		principal = &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_COMBINED,
+			Principal: utils.MarshalOrPanic(&msp.CombinedPrincipal{
+				Principals: append([]*msp.MSPPrincipal{principal}, predicates...),
+			}),
+		}
+	}
+
+	return SignedBy(c.identityIndex(principal)), nil
+}
+
+// predicates compiles the comma separated predicates of a principal, up to the closing bracket
+func (c *compiler) predicates(mspID string) ([]*msp.MSPPrincipal, error) {
+	var principals []*msp.MSPPrincipal
+	anonymity := false
+	for {
+		tok, err := c.expect(tokenWord, "a predicate")
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(tok.value) {
+		case PredicateOU:
+			if _, err := c.expect(tokenEquals, "'='"); err != nil {
+				return nil, err
+			}
+			value := c.consume()
+			if value.kind != tokenWord && value.kind != tokenQuoted || value.value == "" {
+				return nil, c.unexpected(value, "an organizational unit")
+			}
+			principals = append(principals, &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+				Principal: utils.MarshalOrPanic(&msp.OrganizationUnit{
+					MspIdentifier:                mspID,
+					OrganizationalUnitIdentifier: value.value,
+				}),
+			})
+		case PredicateAnonymous, PredicateNominal:
+			if anonymity {
+				return nil, errors.Errorf("unexpected %s, the anonymity of the principal is already specified", tok)
+			}
+			anonymity = true
+			anonymityType := msp.MSPIdentityAnonymity_NOMINAL
+			if strings.ToLower(tok.value) == PredicateAnonymous {
+				anonymityType = msp.MSPIdentityAnonymity_ANONYMOUS
+			}
+			principals = append(principals, &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_ANONYMITY,
+				Principal:               utils.MarshalOrPanic(&msp.MSPIdentityAnonymity{AnonymityType: anonymityType}),
+			})
+		default:
+			return nil, errors.Errorf("unknown predicate %s", tok)
+		}
+
+		tok = c.consume()
+		switch tok.kind {
+		case tokenComma:
+		case tokenRBracket:
+			return principals, nil
+		default:
+			return nil, c.unexpected(tok, "',' or ']'")
+		}
+	}
+}
+
+// identityIndex returns the index of the given principal in the identities of
+// the policy, adding it if it is not there yet
+func (c *compiler) identityIndex(principal *msp.MSPPrincipal) int32 {
+	key := proto.CompactTextString(principal)
+	if index, exists := c.identityIndexes[key]; exists {
+		return index
+	}
+	index := int32(len(c.identities))
+	c.identities = append(c.identities, principal)
+	c.identityIndexes[key] = index
+	return index
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cauthdsl
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rolePrincipal(mspID string, role msp.MSPRole_MSPRoleType) *msp.MSPPrincipal {
+	return &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ROLE,
+		Principal:               utils.MarshalOrPanic(&msp.MSPRole{MspIdentifier: mspID, Role: role}),
+	}
+}
+
+func TestCompile(t *testing.T) {
+	p, err := Compile("AND(Org1.peer, OR(Org2.admin, Org3.peer))")
+	require.NoError(t, err)
+	assert.Equal(t, &common.SignaturePolicyEnvelope{
+		Version: 0,
+		Rule: NOutOf(2, []*common.SignaturePolicy{
+			SignedBy(0),
+			NOutOf(1, []*common.SignaturePolicy{SignedBy(1), SignedBy(2)}),
+		}),
+		Identities: []*msp.MSPPrincipal{
+			rolePrincipal("Org1", msp.MSPRole_PEER),
+			rolePrincipal("Org2", msp.MSPRole_ADMIN),
+			rolePrincipal("Org3", msp.MSPRole_PEER),
+		},
+	}, p)
+}
+
+func TestCompileCompatibleWithFromString(t *testing.T) {
+	for _, policy := range []string{
+		"AND('A.member', 'B.member')",
+		"OutOf(1, 'A.member', 'B.client')",
+		"or('A.peer', 'B.member', 'C.admin')",
+	} {
+		expected, err := FromString(policy)
+		require.NoError(t, err, policy)
+		p, err := Compile(policy)
+		require.NoError(t, err, policy)
+		assert.Equal(t, expected, p, policy)
+	}
+}
+
+func TestCompileThreshold(t *testing.T) {
+	p, err := Compile("2 of (Org1.member, Org2.member, Org3.member)")
+	require.NoError(t, err)
+	expected, err := Compile("OutOf(2, Org1.member, Org2.member, Org3.member)")
+	require.NoError(t, err)
+	assert.Equal(t, expected, p)
+	assert.Equal(t, int32(2), p.Rule.GetNOutOf().N)
+
+	p, err = Compile("AND(Org1.peer, 2 OF (Org2.peer, Org3.peer, Org4.peer))")
+	require.NoError(t, err)
+	assert.Len(t, p.Rule.GetNOutOf().Rules[1].GetNOutOf().Rules, 3)
+}
+
+func TestCompileSharedIdentities(t *testing.T) {
+	p, err := Compile("OR(AND(Org1.peer, Org2.peer), AND(Org1.peer, Org3.peer))")
+	require.NoError(t, err)
+	assert.Len(t, p.Identities, 3)
+	assert.Equal(t, NOutOf(1, []*common.SignaturePolicy{
+		NOutOf(2, []*common.SignaturePolicy{SignedBy(0), SignedBy(1)}),
+		NOutOf(2, []*common.SignaturePolicy{SignedBy(0), SignedBy(2)}),
+	}), p.Rule)
+}
+
+func TestCompilePredicates(t *testing.T) {
+	p, err := Compile("OR(Org1.peer[ou=ops, nominal], Org1.peer, 'Org2.admin'[OU='west coast'])")
+	require.NoError(t, err)
+	require.Len(t, p.Identities, 3)
+
+	assert.Equal(t, msp.MSPPrincipal_COMBINED, p.Identities[0].PrincipalClassification)
+	assert.Equal(t, utils.MarshalOrPanic(&msp.CombinedPrincipal{Principals: []*msp.MSPPrincipal{
+		rolePrincipal("Org1", msp.MSPRole_PEER),
+		{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+			Principal:               utils.MarshalOrPanic(&msp.OrganizationUnit{MspIdentifier: "Org1", OrganizationalUnitIdentifier: "ops"}),
+		},
+		{
+			PrincipalClassification: msp.MSPPrincipal_ANONYMITY,
+			Principal:               utils.MarshalOrPanic(&msp.MSPIdentityAnonymity{AnonymityType: msp.MSPIdentityAnonymity_NOMINAL}),
+		},
+	}}), p.Identities[0].Principal)
+
+	assert.Equal(t, rolePrincipal("Org1", msp.MSPRole_PEER), p.Identities[1])
+
+	combined := &msp.CombinedPrincipal{}
+	require.NoError(t, proto.Unmarshal(p.Identities[2].Principal, combined))
+	require.Len(t, combined.Principals, 2)
+	ou := &msp.OrganizationUnit{}
+	require.NoError(t, proto.Unmarshal(combined.Principals[1].Principal, ou))
+	assert.Equal(t, "west coast", ou.OrganizationalUnitIdentifier)
+}
+
+func TestCompileErrors(t *testing.T) {
+	for policy, expectedErr := range map[string]string{
+		"":                               "unexpected the end of the policy, expected a principal or a gate",
+		"AND(Org1.peer":                  "unexpected the end of the policy, expected ',' or ')'",
+		"AND(Org1.peer,)":                "unexpected ) at position 14, expected a principal or a gate",
+		"AND(Org1.peer) Org2.peer":       "unexpected Org2.peer at position 15, expected the end of the policy",
+		"Org1.boss":                      "invalid role boss of principal Org1.boss at position 0",
+		"Org1":                           "invalid principal Org1 at position 0, expected MSP.ROLE",
+		"'Org1.peer":                     "unterminated string at position 0",
+		"AND(Org1.peer; Org2.peer)":      "unexpected character ';' at position 13",
+		"OutOf(3, Org1.peer, Org2.peer)": "invalid threshold 3 of OutOf at position 0, expected a threshold between 1 and 2",
+		"OutOf(0, Org1.peer)":            "invalid threshold 0 of OutOf at position 0, expected a threshold between 1 and 1",
+		"OutOf(two, Org1.peer)":          "invalid threshold two at position 6",
+		"3 of (Org1.peer, Org2.peer)":    "invalid threshold 3 of 3 at position 0, expected a threshold between 1 and 2",
+		"Org1.peer[color=red]":           "unknown predicate color at position 10",
+		"Org1.peer[ou]":                  "unexpected ] at position 12, expected '='",
+		"Org1.peer[ou=]":                 "unexpected ] at position 13, expected an organizational unit",
+		"Org1.peer[nominal, anonymous]":  "unexpected anonymous at position 19, the anonymity of the principal is already specified",
+		"Org1.peer[ou=ops":               "unexpected the end of the policy, expected ',' or ']'",
+	} {
+		_, err := Compile(policy)
+		assert.EqualError(t, err, expectedErr, policy)
+	}
+}
@@ -14,9 +14,10 @@ import (
 
 // InstalledChaincode defines metadata about an installed chaincode
 type InstalledChaincode struct {
-	Name    string
-	Version string
-	Id      []byte
+	Name      string
+	Version   string
+	Id        []byte
+	PackageID string
 }
 
 // Metadata defines channel-scoped metadata of a chaincode
@@ -26,6 +27,7 @@ type Metadata struct {
 	Policy            []byte
 	Id                []byte
 	CollectionsConfig []byte
+	Annotations       map[string]string
 }
 
 // MetadataSet defines an aggregation of Metadata
@@ -12,18 +12,28 @@ import (
 
 // aclsProvider provides mappings for resource to policy names
 type aclsProvider struct {
-	aclPolicyRefs map[string]string
+	aclPolicyRefs    map[string]string
+	aclDeliverLimits map[string]*pb.DeliverLimits
 }
 
 func (ag *aclsProvider) PolicyRefForAPI(aclName string) string {
 	return ag.aclPolicyRefs[aclName]
 }
 
+func (ag *aclsProvider) DeliverLimitsForAPI(aclName string) *pb.DeliverLimits {
+	return ag.aclDeliverLimits[aclName]
+}
+
 // this translates policies to absolute paths if needed
 func newAPIsProvider(acls map[string]*pb.APIResource) *aclsProvider {
 	aclPolicyRefs := make(map[string]string)
+	aclDeliverLimits := make(map[string]*pb.DeliverLimits)
 
 	for key, acl := range acls {
+		if acl.DeliverLimits != nil {
+			aclDeliverLimits[key] = acl.DeliverLimits
+		}
+
 		// If the policy is fully qualified, ie to /Channel/Application/Readers leave it alone
 		// otherwise, make it fully qualified referring to /Channel/Application/policyName
 		if '/' != acl.PolicyRef[0] {
@@ -34,6 +44,7 @@ func newAPIsProvider(acls map[string]*pb.APIResource) *aclsProvider {
 	}
 
 	return &aclsProvider{
-		aclPolicyRefs: aclPolicyRefs,
+		aclPolicyRefs:    aclPolicyRefs,
+		aclDeliverLimits: aclDeliverLimits,
 	}
 }
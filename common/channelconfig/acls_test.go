@@ -55,3 +55,15 @@ func TestEmptyACLs(t *testing.T) {
 	assert.NotNil(t, ccg.aclPolicyRefs)
 	assert.Empty(t, ccg.aclPolicyRefs)
 }
+
+func TestDeliverLimits(t *testing.T) {
+	limits := &pb.DeliverLimits{MaxStreamsPerIdentity: 2, MaxBytesPerSecondPerOrg: 1024}
+	ag := newAPIsProvider(map[string]*pb.APIResource{
+		"event/Block":         {PolicyRef: "Readers", DeliverLimits: limits},
+		"event/FilteredBlock": {PolicyRef: "Readers"},
+	})
+
+	assert.Equal(t, limits, ag.DeliverLimitsForAPI("event/Block"))
+	assert.Nil(t, ag.DeliverLimitsForAPI("event/FilteredBlock"))
+	assert.Nil(t, ag.DeliverLimitsForAPI("missing"))
+}
@@ -44,6 +44,18 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// CustomGroups returns a map of group name to the custom groups of the application
+	// config, which are not organizations
+	CustomGroups() map[string]CustomGroup
+
+	// ResourceBudget returns the budget of the resources used by the simulation of
+	// each proposal, or nil if the channel has no budget
+	ResourceBudget() *pb.ResourceBudget
+
+	// SystemChaincodePolicies returns the endorsement policies of the namespaces of
+	// the system chaincodes, or nil if the channel defines none
+	SystemChaincodePolicies() *pb.SystemChaincodePolicies
 }
 
 // Channel gives read only access to the channel configuration
@@ -169,6 +181,54 @@ type ApplicationCapabilities interface {
 
 	// FabToken returns true if this channel supports FabToken functions
 	FabToken() bool
+
+	// CommutativeUpdates returns true if this channel supports the increments of keys
+	// that are applied at commit time without causing MVCC read conflicts
+	CommutativeUpdates() bool
+
+	// CommitHash returns true if the committers of this channel record the hash
+	// of the state updates committed up to each block in the block metadata
+	CommitHash() bool
+
+	// ChaincodeMigration returns true if this channel supports the upgrades of chaincodes
+	// which migrate the state of the chaincode with its Migrate function
+	ChaincodeMigration() bool
+
+	// TxExpiration returns true if the committers of this channel invalidate the
+	// transactions committed at or beyond the expiration height of their header
+	TxExpiration() bool
+
+	// ChaincodeBatches returns true if this channel supports the transactions invoking
+	// several application chaincodes atomically through the batch system chaincode
+	ChaincodeBatches() bool
+
+	// ScheduledTransactions returns true if this channel supports the transactions
+	// submitted on behalf of their creator once their scheduled height is reached
+	ScheduledTransactions() bool
+
+	// CustomConfigGroups returns true if this channel supports the custom groups of the
+	// application config, which hold the parameters defined by the consortium
+	CustomConfigGroups() bool
+
+	// ResourceBudgets returns true if this channel supports the budgets of the resources
+	// used by the simulation of the proposals
+	ResourceBudgets() bool
+
+	// ChaincodeContracts returns true if this channel supports the contracts of the chaincode
+	// definitions, whose functions are validated with the endorsement policy of the contract
+	ChaincodeContracts() bool
+
+	// BinaryChaincodes returns true if this channel supports the chaincodes packaged as prebuilt
+	// binaries, whose deployments are validated by the committers
+	BinaryChaincodes() bool
+
+	// SystemChaincodePolicies returns true if this channel supports the endorsement policies of
+	// the namespaces of the system chaincodes defined by the application config
+	SystemChaincodePolicies() bool
+
+	// RangeDeletes returns true if this channel supports the deletions of ranges of keys
+	// recorded as a single entry of the write set of the transactions
+	RangeDeletes() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -197,6 +257,10 @@ type PolicyMapper interface {
 	// PolicyRefForAPI takes the name of an API, and returns the policy name
 	// or the empty string if the API is not found
 	PolicyRefForAPI(apiName string) string
+
+	// DeliverLimitsForAPI takes the name of an API, and returns the limits of
+	// the deliver streams set in its ACL, or nil if it sets none
+	DeliverLimitsForAPI(apiName string) *pb.DeliverLimits
 }
 
 // Resources is the common set of config resources for all channels
@@ -19,17 +19,26 @@ const (
 
 	// ACLsKey is the name of the ACLs config
 	ACLsKey = "ACLs"
+
+	// ResourceBudgetKey is the name of the ResourceBudget config
+	ResourceBudgetKey = "ResourceBudget"
+
+	// SystemChaincodePoliciesKey is the name of the SystemChaincodePolicies config
+	SystemChaincodePoliciesKey = "SystemChaincodePolicies"
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
-	ACLs         *pb.ACLs
-	Capabilities *cb.Capabilities
+	ACLs                    *pb.ACLs
+	Capabilities            *cb.Capabilities
+	ResourceBudget          *pb.ResourceBudget
+	SystemChaincodePolicies *pb.SystemChaincodePolicies
 }
 
 // ApplicationConfig implements the Application interface
 type ApplicationConfig struct {
 	applicationOrgs map[string]ApplicationOrg
+	customGroups    map[string]CustomGroup
 	protos          *ApplicationProtos
 }
 
@@ -37,6 +46,7 @@ type ApplicationConfig struct {
 func NewApplicationConfig(appGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler) (*ApplicationConfig, error) {
 	ac := &ApplicationConfig{
 		applicationOrgs: make(map[string]ApplicationOrg),
+		customGroups:    make(map[string]CustomGroup),
 		protos:          &ApplicationProtos{},
 	}
 
@@ -50,8 +60,28 @@ func NewApplicationConfig(appGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		}
 	}
 
+	if _, ok := appGroup.Values[ResourceBudgetKey]; !ok {
+		// the deserialization allocates the budget even if the config has none
+		ac.protos.ResourceBudget = nil
+	} else if !ac.Capabilities().ResourceBudgets() {
+		return nil, errors.New("ResourceBudget may not be specified without the required capability")
+	}
+
+	if _, ok := appGroup.Values[SystemChaincodePoliciesKey]; !ok {
+		ac.protos.SystemChaincodePolicies = nil
+	} else if !ac.Capabilities().SystemChaincodePolicies() {
+		return nil, errors.New("SystemChaincodePolicies may not be specified without the required capability")
+	}
+
 	var err error
 	for orgName, orgGroup := range appGroup.Groups {
+		if _, ok := orgGroup.Values[MSPKey]; !ok && ac.Capabilities().CustomConfigGroups() {
+			ac.customGroups[orgName], err = NewCustomGroupConfig(orgName, orgGroup)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
 		ac.applicationOrgs[orgName], err = NewApplicationOrgConfig(orgName, orgGroup, mspConfig)
 		if err != nil {
 			return nil, err
@@ -66,6 +96,24 @@ func (ac *ApplicationConfig) Organizations() map[string]ApplicationOrg {
 	return ac.applicationOrgs
 }
 
+// CustomGroups returns a map of group name to the custom groups of the application config,
+// which are the groups holding no MSP once the custom groups are enabled by the capabilities
+func (ac *ApplicationConfig) CustomGroups() map[string]CustomGroup {
+	return ac.customGroups
+}
+
+// ResourceBudget returns the budget of the resources used by the simulation of each
+// proposal, or nil if the channel has no budget
+func (ac *ApplicationConfig) ResourceBudget() *pb.ResourceBudget {
+	return ac.protos.ResourceBudget
+}
+
+// SystemChaincodePolicies returns the endorsement policies of the namespaces of the
+// system chaincodes, or nil if the channel defines none
+func (ac *ApplicationConfig) SystemChaincodePolicies() *pb.SystemChaincodePolicies {
+	return ac.protos.SystemChaincodePolicies
+}
+
 // Capabilities returns a map of capability name to Capability
 func (ac *ApplicationConfig) Capabilities() ApplicationCapabilities {
 	return capabilities.NewApplicationProvider(ac.protos.Capabilities.Capabilities)
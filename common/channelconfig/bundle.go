@@ -211,7 +211,12 @@ func NewBundle(channelID string, config *cb.Config) (*Bundle, error) {
 		}
 	}
 
-	policyManager, err := policies.NewManagerImpl(RootGroupKey, policyProviderMap, config.ChannelGroup)
+	policyGroup := config.ChannelGroup
+	if ac := channelConfig.ApplicationConfig(); ac != nil && len(ac.CustomGroups()) > 0 {
+		policyGroup = withoutCustomGroups(config.ChannelGroup, ac.CustomGroups())
+	}
+
+	policyManager, err := policies.NewManagerImpl(RootGroupKey, policyProviderMap, policyGroup)
 	if err != nil {
 		return nil, errors.Wrap(err, "initializing policymanager failed")
 	}
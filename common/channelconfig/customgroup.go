@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelconfig
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// CustomGroup is a group of the application config defined by the consortium, such as
+// a fee schedule or network parameters. Unlike the organizations, it holds no MSP, and
+// its values are opaque to the channel config
+type CustomGroup interface {
+	// Name returns the name of the group in the application config
+	Name() string
+
+	// Keys returns the sorted keys of the values of the group
+	Keys() []string
+
+	// Value returns the value of the group with the given key, or false if the group
+	// has no such value
+	Value(key string) ([]byte, bool)
+
+	// UnmarshalValue unmarshals the value of the group with the given key into the
+	// given message
+	UnmarshalValue(key string, msg proto.Message) error
+}
+
+// CustomGroupValidator validates a custom group of the application config. A config
+// holding a custom group for which the validator returns an error is rejected
+type CustomGroupValidator func(group CustomGroup) error
+
+var customGroupValidators = struct {
+	sync.RWMutex
+	validators map[string]CustomGroupValidator
+}{validators: map[string]CustomGroupValidator{}}
+
+// RegisterCustomGroupValidator registers the validator of the custom groups of the
+// application config with the given name, replacing the previous one, if any. As the
+// configs rejected by the validator are rejected by the orderers and by the peers, all
+// of them must register the same validators, before the channels are loaded
+func RegisterCustomGroupValidator(name string, validator CustomGroupValidator) {
+	customGroupValidators.Lock()
+	defer customGroupValidators.Unlock()
+	customGroupValidators.validators[name] = validator
+}
+
+func customGroupValidator(name string) CustomGroupValidator {
+	customGroupValidators.RLock()
+	defer customGroupValidators.RUnlock()
+	return customGroupValidators.validators[name]
+}
+
+// CustomGroupConfig implements the CustomGroup interface
+type CustomGroupConfig struct {
+	name   string
+	values map[string][]byte
+}
+
+// NewCustomGroupConfig creates the config of a custom group of the application config,
+// validated by the validator registered for its name, if any. The custom groups hold no
+// sub-groups and no policies, and are modified with the absolute policies of the channel
+func NewCustomGroupConfig(name string, group *cb.ConfigGroup) (*CustomGroupConfig, error) {
+	if len(group.Groups) > 0 {
+		return nil, errors.Errorf("custom group %s does not support sub-groups", name)
+	}
+	if len(group.Policies) > 0 {
+		return nil, errors.Errorf("custom group %s does not support policies", name)
+	}
+	if err := validateCustomGroupModPolicy(group.ModPolicy); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid custom group %s", name))
+	}
+
+	cgc := &CustomGroupConfig{
+		name:   name,
+		values: make(map[string][]byte, len(group.Values)),
+	}
+	for key, value := range group.Values {
+		if err := validateCustomGroupModPolicy(value.ModPolicy); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid value %s of custom group %s", key, name))
+		}
+		cgc.values[key] = value.Value
+	}
+
+	if validator := customGroupValidator(name); validator != nil {
+		if err := validator(cgc); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("custom group %s was rejected by its validator", name))
+		}
+	}
+	return cgc, nil
+}
+
+// validateCustomGroupModPolicy checks that a mod policy of a custom group is absolute,
+// as the custom groups have no policies from which a relative policy can be resolved
+func validateCustomGroupModPolicy(modPolicy string) error {
+	if modPolicy != "" && !strings.HasPrefix(modPolicy, policies.PathSeparator) {
+		return errors.Errorf("mod policy %s is not an absolute policy path", modPolicy)
+	}
+	return nil
+}
+
+// Name returns the name of the group in the application config
+func (cgc *CustomGroupConfig) Name() string {
+	return cgc.name
+}
+
+// Keys returns the sorted keys of the values of the group
+func (cgc *CustomGroupConfig) Keys() []string {
+	keys := make([]string, 0, len(cgc.values))
+	for key := range cgc.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Value returns the value of the group with the given key, or false if the group has
+// no such value
+func (cgc *CustomGroupConfig) Value(key string) ([]byte, bool) {
+	value, ok := cgc.values[key]
+	return value, ok
+}
+
+// UnmarshalValue unmarshals the value of the group with the given key into the given
+// message
+func (cgc *CustomGroupConfig) UnmarshalValue(key string, msg proto.Message) error {
+	value, ok := cgc.values[key]
+	if !ok {
+		return errors.Errorf("custom group %s has no value %s", cgc.name, key)
+	}
+	if err := proto.Unmarshal(value, msg); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal value %s of custom group %s", key, cgc.name)
+	}
+	return nil
+}
+
+// withoutCustomGroups returns a copy of the given channel group whose application group
+// lacks the given custom groups, from which the policies of the channel are built, so
+// that the implicit meta policies of the application group are not evaluated over them
+func withoutCustomGroups(channelGroup *cb.ConfigGroup, customGroups map[string]CustomGroup) *cb.ConfigGroup {
+	appGroup := channelGroup.Groups[ApplicationGroupKey]
+	policyAppGroup := *appGroup
+	policyAppGroup.Groups = make(map[string]*cb.ConfigGroup, len(appGroup.Groups))
+	for name, group := range appGroup.Groups {
+		if _, ok := customGroups[name]; !ok {
+			policyAppGroup.Groups[name] = group
+		}
+	}
+
+	policyChannelGroup := *channelGroup
+	policyChannelGroup.Groups = make(map[string]*cb.ConfigGroup, len(channelGroup.Groups))
+	for name, group := range channelGroup.Groups {
+		policyChannelGroup.Groups[name] = group
+	}
+	policyChannelGroup.Groups[ApplicationGroupKey] = &policyAppGroup
+	return &policyChannelGroup
+}
@@ -0,0 +1,144 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelconfig
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func customGroupApplication(customGroupsCapability bool) *cb.ConfigGroup {
+	caps := map[string]bool{capabilities.ApplicationV1_3: true}
+	if customGroupsCapability {
+		caps[capabilities.ApplicationCustomConfigGroups] = true
+	}
+	return &cb.ConfigGroup{
+		Groups: map[string]*cb.ConfigGroup{
+			"FeeSchedule": {
+				Values: map[string]*cb.ConfigValue{
+					"Fee": {
+						Value:     utils.MarshalOrPanic(&cb.BlockDataHashingStructure{Width: 10}),
+						ModPolicy: "/Channel/Application/Admins",
+					},
+					"Currency": {
+						Value:     []byte("EUR"),
+						ModPolicy: "/Channel/Application/Admins",
+					},
+				},
+				ModPolicy: "/Channel/Application/Admins",
+			},
+		},
+		Values: map[string]*cb.ConfigValue{
+			CapabilitiesKey: {
+				Value: utils.MarshalOrPanic(CapabilitiesValue(caps).Value()),
+			},
+		},
+	}
+}
+
+func TestApplicationCustomGroups(t *testing.T) {
+	ac, err := NewApplicationConfig(customGroupApplication(true), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, ac.Organizations())
+	assert.Len(t, ac.CustomGroups(), 1)
+
+	group := ac.CustomGroups()["FeeSchedule"]
+	assert.Equal(t, "FeeSchedule", group.Name())
+	assert.Equal(t, []string{"Currency", "Fee"}, group.Keys())
+
+	value, ok := group.Value("Currency")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("EUR"), value)
+	_, ok = group.Value("Missing")
+	assert.False(t, ok)
+
+	fee := &cb.BlockDataHashingStructure{}
+	assert.NoError(t, group.UnmarshalValue("Fee", fee))
+	assert.Equal(t, uint32(10), fee.Width)
+	assert.EqualError(t, group.UnmarshalValue("Missing", fee), "custom group FeeSchedule has no value Missing")
+	assert.Error(t, group.UnmarshalValue("Currency", fee))
+}
+
+func TestApplicationCustomGroupsWithoutCapability(t *testing.T) {
+	// Without the capability, the groups of the application config are organizations,
+	// which must hold an MSP
+	_, err := NewApplicationConfig(customGroupApplication(false), NewMSPConfigHandler(0))
+	assert.Error(t, err)
+}
+
+func TestNewCustomGroupConfig(t *testing.T) {
+	t.Run("SubGroups", func(t *testing.T) {
+		cg := customGroupApplication(true).Groups["FeeSchedule"]
+		cg.Groups = map[string]*cb.ConfigGroup{"Nested": {}}
+		_, err := NewCustomGroupConfig("FeeSchedule", cg)
+		assert.EqualError(t, err, "custom group FeeSchedule does not support sub-groups")
+	})
+
+	t.Run("Policies", func(t *testing.T) {
+		cg := customGroupApplication(true).Groups["FeeSchedule"]
+		cg.Policies = map[string]*cb.ConfigPolicy{"Admins": {}}
+		_, err := NewCustomGroupConfig("FeeSchedule", cg)
+		assert.EqualError(t, err, "custom group FeeSchedule does not support policies")
+	})
+
+	t.Run("RelativeGroupModPolicy", func(t *testing.T) {
+		cg := customGroupApplication(true).Groups["FeeSchedule"]
+		cg.ModPolicy = "Admins"
+		_, err := NewCustomGroupConfig("FeeSchedule", cg)
+		assert.EqualError(t, err, "invalid custom group FeeSchedule: mod policy Admins is not an absolute policy path")
+	})
+
+	t.Run("RelativeValueModPolicy", func(t *testing.T) {
+		cg := customGroupApplication(true).Groups["FeeSchedule"]
+		cg.Values["Fee"].ModPolicy = "Admins"
+		_, err := NewCustomGroupConfig("FeeSchedule", cg)
+		assert.EqualError(t, err, "invalid value Fee of custom group FeeSchedule: mod policy Admins is not an absolute policy path")
+	})
+
+	t.Run("Validator", func(t *testing.T) {
+		defer RegisterCustomGroupValidator("Validated", nil)
+		RegisterCustomGroupValidator("Validated", func(group CustomGroup) error {
+			if _, ok := group.Value("Currency"); !ok {
+				return errors.New("no currency")
+			}
+			return nil
+		})
+
+		cg := customGroupApplication(true).Groups["FeeSchedule"]
+		_, err := NewCustomGroupConfig("Validated", proto.Clone(cg).(*cb.ConfigGroup))
+		assert.NoError(t, err)
+
+		delete(cg.Values, "Currency")
+		_, err = NewCustomGroupConfig("Validated", cg)
+		assert.EqualError(t, err, "custom group Validated was rejected by its validator: no currency")
+	})
+}
+
+func TestWithoutCustomGroups(t *testing.T) {
+	appGroup := customGroupApplication(true)
+	appGroup.Groups["Org1"] = &cb.ConfigGroup{}
+	channelGroup := &cb.ConfigGroup{
+		Groups: map[string]*cb.ConfigGroup{
+			ApplicationGroupKey: appGroup,
+			OrdererGroupKey:     {},
+		},
+	}
+
+	policyGroup := withoutCustomGroups(channelGroup, map[string]CustomGroup{"FeeSchedule": nil})
+	assert.Len(t, policyGroup.Groups, 2)
+	assert.Equal(t, channelGroup.Groups[OrdererGroupKey], policyGroup.Groups[OrdererGroupKey])
+	assert.Len(t, policyGroup.Groups[ApplicationGroupKey].Groups, 1)
+	assert.Contains(t, policyGroup.Groups[ApplicationGroupKey].Groups, "Org1")
+	// The given channel group is left untouched
+	assert.Len(t, channelGroup.Groups[ApplicationGroupKey].Groups, 2)
+}
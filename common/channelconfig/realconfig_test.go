@@ -9,10 +9,13 @@ package channelconfig_test
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	newchannelconfig "github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/configtxgentest"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,3 +42,40 @@ func TestWithRealConfigtx(t *testing.T) {
 	_, err := newchannelconfig.NewBundleFromEnvelope(env)
 	assert.NoError(t, err)
 }
+
+func TestWithRealConfigtxCustomGroups(t *testing.T) {
+	conf := configtxgentest.Load(genesisconfig.SampleSingleMSPSoloProfile)
+	conf.Application = &genesisconfig.Application{
+		Organizations: []*genesisconfig.Organization{
+			conf.Orderer.Organizations[0],
+		},
+		Capabilities: map[string]bool{
+			capabilities.ApplicationV1_3:               true,
+			capabilities.ApplicationCustomConfigGroups: true,
+		},
+	}
+	gb := encoder.New(conf).GenesisBlockForChannel("foo")
+	env := utils.ExtractEnvelopeOrPanic(gb, 0)
+	config, err := configtx.UnmarshalConfigEnvelope(utils.UnmarshalPayloadOrPanic(env.Payload).Data)
+	assert.NoError(t, err)
+
+	config.Config.ChannelGroup.Groups[newchannelconfig.ApplicationGroupKey].Groups["FeeSchedule"] = &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			"Fee": {Value: []byte("10"), ModPolicy: "/Channel/Application/Admins"},
+		},
+		ModPolicy: "/Channel/Application/Admins",
+	}
+	bundle, err := newchannelconfig.NewBundle("foo", config.Config)
+	assert.NoError(t, err)
+
+	ac, ok := bundle.ApplicationConfig()
+	assert.True(t, ok)
+	assert.Len(t, ac.Organizations(), 1)
+	assert.Contains(t, ac.CustomGroups(), "FeeSchedule")
+
+	// The custom groups have no policies, and take no part in the policies of the application
+	_, ok = bundle.PolicyManager().Manager([]string{newchannelconfig.ApplicationGroupKey, "FeeSchedule"})
+	assert.False(t, ok)
+	_, ok = bundle.PolicyManager().Manager([]string{newchannelconfig.ApplicationGroupKey, conf.Orderer.Organizations[0].Name})
+	assert.True(t, ok)
+}
@@ -206,6 +206,33 @@ func ChannelCreationPolicyValue(policy *cb.Policy) *StandardConfigValue {
 	}
 }
 
+// ResourceBudgetValue returns the config definition for the budget of the resources used by the
+// simulation of each proposal to the application chaincodes.
+// It is a value for the /Channel/Application/.
+func ResourceBudgetValue(maxSimulationTimeMs, maxStateReads, maxStateWrites, maxBytesProduced uint64) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: ResourceBudgetKey,
+		value: &pb.ResourceBudget{
+			MaxSimulationTimeMs: maxSimulationTimeMs,
+			MaxStateReads:       maxStateReads,
+			MaxStateWrites:      maxStateWrites,
+			MaxBytesProduced:    maxBytesProduced,
+		},
+	}
+}
+
+// SystemChaincodePoliciesValue returns the config definition for the endorsement policies of
+// the namespaces of the system chaincodes, keyed by the name of the system chaincode.
+// It is a value for the /Channel/Application/.
+func SystemChaincodePoliciesValue(policies map[string]*cb.SignaturePolicyEnvelope) *StandardConfigValue {
+	return &StandardConfigValue{
+		key: SystemChaincodePoliciesKey,
+		value: &pb.SystemChaincodePolicies{
+			Policies: policies,
+		},
+	}
+}
+
 // ACLsValues returns the config definition for an applications resources based ACL definitions.
 // It is a value for the /Channel/Application/.
 func ACLValues(acls map[string]string) *StandardConfigValue {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package configtx
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/hyperledger/fabric/common/policies"
@@ -44,6 +45,36 @@ func computeDeltaSet(readSet, writeSet map[string]comparable) map[string]compara
 	return result
 }
 
+// UpdateKeys returns the sorted keys of the config elements which the config
+// update depends on, the ones of its read set and write set, and of the ones
+// which it modifies. A config update conflicts with the config updates which
+// modify one of its dependencies
+func UpdateKeys(configUpdate *cb.ConfigUpdate, namespace string) (dependencies, modified []string, err error) {
+	readSet, err := mapConfig(configUpdate.ReadSet, namespace)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error mapping ReadSet")
+	}
+	writeSet, err := mapConfig(configUpdate.WriteSet, namespace)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error mapping WriteSet")
+	}
+
+	for key := range readSet {
+		dependencies = append(dependencies, key)
+	}
+	for key := range writeSet {
+		if _, ok := readSet[key]; !ok {
+			dependencies = append(dependencies, key)
+		}
+	}
+	for key := range computeDeltaSet(readSet, writeSet) {
+		modified = append(modified, key)
+	}
+	sort.Strings(dependencies)
+	sort.Strings(modified)
+	return dependencies, modified, nil
+}
+
 func validateModPolicy(modPolicy string) error {
 	if modPolicy == "" {
 		return errors.Errorf("mod_policy not set")
@@ -60,6 +60,46 @@ func TestComputeDeltaSet(t *testing.T) {
 	assert.NotNil(t, result["3"], "Element was new")
 }
 
+func TestUpdateKeys(t *testing.T) {
+	configUpdate := &cb.ConfigUpdate{
+		ReadSet: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				"Application": {
+					Groups: map[string]*cb.ConfigGroup{"Org1MSP": {}},
+				},
+			},
+		},
+		WriteSet: &cb.ConfigGroup{
+			Groups: map[string]*cb.ConfigGroup{
+				"Application": {
+					Version: 1,
+					Groups: map[string]*cb.ConfigGroup{
+						"Org1MSP": {},
+						"Org2MSP": {},
+					},
+				},
+			},
+		},
+	}
+
+	dependencies, modified, err := UpdateKeys(configUpdate, "Channel")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"[Group]  /Channel",
+		"[Group]  /Channel/Application",
+		"[Group]  /Channel/Application/Org1MSP",
+		"[Group]  /Channel/Application/Org2MSP",
+	}, dependencies)
+	assert.Equal(t, []string{
+		"[Group]  /Channel/Application",
+		"[Group]  /Channel/Application/Org2MSP",
+	}, modified)
+
+	configUpdate.WriteSet.Groups["Bad Key"] = &cb.ConfigGroup{}
+	_, _, err = UpdateKeys(configUpdate, "Channel")
+	assert.EqualError(t, err, "error mapping WriteSet: Illegal characters in key: [Group]  ")
+}
+
 func TestVerifyDeltaSet(t *testing.T) {
 	vi := &ValidatorImpl{
 		pm: &mockpolicies.Manager{
@@ -7,9 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package deliver
 
 import (
+	"crypto/x509"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 )
 
@@ -34,6 +38,7 @@ func NewSessionAC(chain ConfigSequencer, env *common.Envelope, policyChecker Pol
 		channelID:      channelID,
 		sequencer:      chain,
 		policyChecker:  policyChecker,
+		signer:         signedData[0].Identity,
 		sessionEndTime: expiresAt(signedData[0].Identity),
 	}, nil
 }
@@ -46,6 +51,9 @@ type SessionAccessControl struct {
 	policyChecker      PolicyChecker
 	channelID          string
 	envelope           *common.Envelope
+	signer             []byte
+	tlsCert            *x509.Certificate
+	mspManager         func() msp.MSPManager
 	lastConfigSequence uint64
 	sessionEndTime     time.Time
 	usedAtLeastOnce    bool
@@ -71,5 +79,56 @@ func (ac *SessionAccessControl) Evaluate() error {
 	}
 
 	ac.usedAtLeastOnce = true
-	return ac.policyChecker.CheckPolicy(ac.envelope, ac.channelID)
+	if err := ac.policyChecker.CheckPolicy(ac.envelope, ac.channelID); err != nil {
+		return err
+	}
+	if ac.tlsCert == nil {
+		return nil
+	}
+	return verifyTLSIdentity(ac.mspManager(), ac.signer, ac.tlsCert)
+}
+
+// BindTLSIdentity binds the session to the given TLS client certificate, which
+// is required at each evaluation to be issued by the TLS CAs of the
+// organization of the identity that signed the request. The session ends when
+// the certificate expires.
+func (ac *SessionAccessControl) BindTLSIdentity(cert *x509.Certificate, mspManager func() msp.MSPManager) {
+	ac.tlsCert = cert
+	ac.mspManager = mspManager
+	if ac.sessionEndTime.IsZero() || cert.NotAfter.Before(ac.sessionEndTime) {
+		ac.sessionEndTime = cert.NotAfter
+	}
+}
+
+// verifyTLSIdentity verifies that the TLS client certificate is issued by the
+// TLS CAs of the organization of the given serialized identity
+func verifyTLSIdentity(mspManager msp.MSPManager, signer []byte, cert *x509.Certificate) error {
+	sID := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(signer, sID); err != nil {
+		return errors.Wrap(err, "invalid identity of the signer")
+	}
+	msps, err := mspManager.GetMSPs()
+	if err != nil {
+		return errors.Wrap(err, "failed retrieving the MSPs of the channel")
+	}
+	m, exists := msps[sID.Mspid]
+	if !exists {
+		return errors.Errorf("organization %s of the signer is not a member of the channel", sID.Mspid)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, root := range m.GetTLSRootCerts() {
+		opts.Roots.AppendCertsFromPEM(root)
+	}
+	for _, intermediate := range m.GetTLSIntermediateCerts() {
+		opts.Intermediates.AppendCertsFromPEM(intermediate)
+	}
+	if _, err := cert.Verify(opts); err != nil {
+		return errors.Wrapf(err, "TLS client certificate is not issued by the TLS CAs of %s", sID.Mspid)
+	}
+	return nil
 }
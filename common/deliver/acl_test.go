@@ -7,17 +7,32 @@ SPDX-License-Identifier: Apache-2.0
 package deliver_test
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"time"
 
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/deliver/mock"
+	"github.com/hyperledger/fabric/msp"
+	mspmocks "github.com/hyperledger/fabric/msp/mocks"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 )
 
+type mspManager struct {
+	msp.MSPManager
+	msps map[string]msp.MSP
+}
+
+func (m *mspManager) GetMSPs() (map[string]msp.MSP, error) {
+	return m.msps, nil
+}
+
 var _ = Describe("SessionAccessControl", func() {
 	var (
 		fakeChain         *mock.Chain
@@ -133,4 +148,83 @@ var _ = Describe("SessionAccessControl", func() {
 			Expect(err).To(Equal(expectedError))
 		})
 	})
+
+	Context("when the session is bound to a TLS client certificate", func() {
+		var (
+			orgCA      tlsgen.CA
+			clientCert *x509.Certificate
+			msps       map[string]msp.MSP
+		)
+
+		newClientCert := func(ca tlsgen.CA) *x509.Certificate {
+			keyPair, err := ca.NewClientCertKeyPair()
+			Expect(err).NotTo(HaveOccurred())
+			block, _ := pem.Decode(keyPair.Cert)
+			cert, err := x509.ParseCertificate(block.Bytes)
+			Expect(err).NotTo(HaveOccurred())
+			return cert
+		}
+
+		BeforeEach(func() {
+			var err error
+			orgCA, err = tlsgen.NewCA()
+			Expect(err).NotTo(HaveOccurred())
+			clientCert = newClientCert(orgCA)
+
+			orgMSP := &mspmocks.MockMSP{}
+			orgMSP.On("GetTLSRootCerts").Return([][]byte{orgCA.CertBytes()})
+			orgMSP.On("GetTLSIntermediateCerts").Return([][]byte{})
+			msps = map[string]msp.MSP{"Org1MSP": orgMSP}
+			fakeChain.MSPManagerReturns(&mspManager{msps: msps})
+
+			envelope = &cb.Envelope{
+				Payload: utils.MarshalOrPanic(&cb.Payload{
+					Header: &cb.Header{
+						SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{
+							Creator: utils.MarshalOrPanic(&mspprotos.SerializedIdentity{Mspid: "Org1MSP"}),
+						}),
+					},
+				}),
+			}
+		})
+
+		It("accepts a certificate issued by the TLS CAs of the organization of the signer", func() {
+			sac, err := deliver.NewSessionAC(fakeChain, envelope, fakePolicyChecker, "chain-id", expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+			sac.BindTLSIdentity(clientCert, fakeChain.MSPManager)
+
+			Expect(sac.Evaluate()).To(Succeed())
+		})
+
+		It("rejects a certificate issued by another CA", func() {
+			otherCA, err := tlsgen.NewCA()
+			Expect(err).NotTo(HaveOccurred())
+
+			sac, err := deliver.NewSessionAC(fakeChain, envelope, fakePolicyChecker, "chain-id", expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+			sac.BindTLSIdentity(newClientCert(otherCA), fakeChain.MSPManager)
+
+			Expect(sac.Evaluate()).To(MatchError(ContainSubstring("TLS client certificate is not issued by the TLS CAs of Org1MSP")))
+		})
+
+		It("rejects the session when the organization leaves the channel", func() {
+			sac, err := deliver.NewSessionAC(fakeChain, envelope, fakePolicyChecker, "chain-id", expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+			sac.BindTLSIdentity(clientCert, fakeChain.MSPManager)
+			Expect(sac.Evaluate()).To(Succeed())
+
+			delete(msps, "Org1MSP")
+			fakeChain.SequenceReturns(2)
+			Expect(sac.Evaluate()).To(MatchError("organization Org1MSP of the signer is not a member of the channel"))
+		})
+
+		It("ends the session when the certificate expires", func() {
+			sac, err := deliver.NewSessionAC(fakeChain, envelope, fakePolicyChecker, "chain-id", expiresAt)
+			Expect(err).NotTo(HaveOccurred())
+			clientCert.NotAfter = time.Now().Add(-time.Minute)
+			sac.BindTLSIdentity(clientCert, fakeChain.MSPManager)
+
+			Expect(sac.Evaluate()).To(MatchError(ContainSubstring("client identity expired")))
+		})
+	})
 })
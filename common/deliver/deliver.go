@@ -20,7 +20,9 @@ import (
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -50,6 +52,9 @@ type Chain interface {
 
 	// Errored returns a channel which closes when the backing consenter has errored
 	Errored() <-chan struct{}
+
+	// MSPManager returns the MSP manager of the chain
+	MSPManager() msp.MSPManager
 }
 
 //go:generate counterfeiter -o mock/policy_checker.go -fake-name PolicyChecker . PolicyChecker
@@ -91,6 +96,22 @@ type Handler struct {
 	TimeWindow       time.Duration
 	BindingInspector Inspector
 	Metrics          *Metrics
+	// BindTLSIdentity requires the TLS client certificate of the connection to
+	// be issued by the TLS CAs of the organization of the identity that signed
+	// the request, which must satisfy the readers policy of the channel
+	BindTLSIdentity bool
+	// ReevaluationInterval is the interval at which the access control of the
+	// streams that are waiting for a block is evaluated again, so that the
+	// streams of revoked or expired clients are closed even when no block is
+	// committed. Zero disables the periodic evaluation
+	ReevaluationInterval time.Duration
+	// Sessions tracks the blocks delivered to the clients, so that they can
+	// resume their deliver and are throttled when they stream the blocks they
+	// already received again. Nil disables the sessions
+	Sessions *Sessions
+	// Throttle limits the concurrent streams and the bandwidth of each client
+	// identity and of each organization. Nil disables the throttling
+	Throttle *Throttle
 }
 
 //go:generate counterfeiter -o mock/receiver.go -fake-name Receiver . Receiver
@@ -115,6 +136,17 @@ type Filtered interface {
 	IsFiltered() bool
 }
 
+// Resumer is implemented by the response senders which track the blocks
+// processed by their clients, so that a resumed deliver starts after the last
+// block the client processed rather than after the last block it was sent.
+type Resumer interface {
+	// Resume binds the sender to the client with the given serialized identity
+	// on the channel, and returns the number of the next block to deliver to
+	// the client if it processed any. It returns an error if the blocks cannot
+	// be delivered to the client.
+	Resume(channelID string, identity []byte) (next uint64, ok bool, err error)
+}
+
 // Server is a polymorphic structure to support generalization of this handler
 // to be able to deliver different type of responses.
 type Server struct {
@@ -218,9 +250,10 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return cb.Status_NOT_FOUND, nil
 	}
 
+	filtered := isFiltered(srv)
 	labels := []string{
 		"channel", chdr.ChannelId,
-		"filtered", strconv.FormatBool(isFiltered(srv)),
+		"filtered", strconv.FormatBool(filtered),
 	}
 	h.Metrics.RequestsReceived.With(labels...).Add(1)
 	defer func() {
@@ -242,6 +275,15 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		return cb.Status_BAD_REQUEST, nil
 	}
 
+	if h.BindTLSIdentity {
+		cert := comm.ExtractCertificateFromContext(ctx)
+		if cert == nil {
+			logger.Warningf("[channel: %s] Rejecting deliver request from %s without a TLS client certificate", chdr.ChannelId, addr)
+			return cb.Status_FORBIDDEN, nil
+		}
+		accessControl.BindTLSIdentity(cert, chain.MSPManager)
+	}
+
 	if err := accessControl.Evaluate(); err != nil {
 		logger.Warningf("[channel: %s] Client authorization revoked for deliver request from %s: %s", chdr.ChannelId, addr, err)
 		return cb.Status_FORBIDDEN, nil
@@ -260,7 +302,55 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 
 	logger.Debugf("[channel: %s] Received seekInfo (%p) %v from %s", chdr.ChannelId, seekInfo, seekInfo, addr)
 
-	cursor, number := chain.Reader().Iterator(seekInfo.Start)
+	resumer, isResumer := srv.ResponseSender.(Resumer)
+	var shdr *cb.SignatureHeader
+	if h.Sessions != nil || h.Throttle != nil || isResumer {
+		if shdr, err = utils.GetSignatureHeader(payload.Header.SignatureHeader); err != nil {
+			logger.Warningf("[channel: %s] Received a deliver request from %s with malformed signature header: %s", chdr.ChannelId, addr, err)
+			return cb.Status_BAD_REQUEST, nil
+		}
+	}
+
+	var stream *ThrottledStream
+	if h.Throttle != nil {
+		stream, err = h.Throttle.Acquire(chdr.ChannelId, shdr.Creator, creatorMSPID(shdr.Creator), h.Throttle.Limits(chain, filtered))
+		if err != nil {
+			logger.Warningf("[channel: %s] Throttling deliver for %s: %s", chdr.ChannelId, addr, err)
+			h.Metrics.StreamsThrottled.With(labels...).Add(1)
+			return cb.Status_SERVICE_UNAVAILABLE, nil
+		}
+		defer stream.Release()
+	}
+
+	start := seekInfo.Start
+	var session *Session
+	var resumed bool
+	if h.Sessions != nil {
+		session = h.Sessions.Open(chdr.ChannelId, shdr.Creator, filtered)
+		defer session.Close()
+
+		if next, ok := session.Cursor(); ok && seekInfo.Resume {
+			logger.Debugf("[channel: %s] Resuming deliver for %s at block [%d]", chdr.ChannelId, addr, next)
+			start = &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: next}}}
+			resumed = true
+			h.Metrics.SessionsResumed.With(labels...).Add(1)
+		}
+	}
+
+	if isResumer {
+		next, ok, err := resumer.Resume(chdr.ChannelId, shdr.Creator)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting deliver for %s: %s", chdr.ChannelId, addr, err)
+			return cb.Status_SERVICE_UNAVAILABLE, nil
+		}
+		if ok && seekInfo.Resume {
+			logger.Debugf("[channel: %s] Resuming deliver for %s after its last processed block at block [%d]", chdr.ChannelId, addr, next)
+			start = &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: next}}}
+			resumed = true
+		}
+	}
+
+	cursor, number := chain.Reader().Iterator(start)
 	defer cursor.Close()
 	var stopNum uint64
 	switch stop := seekInfo.Stop.Type.(type) {
@@ -270,12 +360,24 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		stopNum = chain.Reader().Height() - 1
 	case *ab.SeekPosition_Specified:
 		stopNum = stop.Specified.Number
-		if stopNum < number {
+		if stopNum < number && !resumed {
 			logger.Warningf("[channel: %s] Received invalid seekInfo message from %s: start number %d greater than stop number %d", chdr.ChannelId, addr, number, stopNum)
 			return cb.Status_BAD_REQUEST, nil
 		}
 	}
 
+	if resumed && stopNum < number {
+		logger.Debugf("[channel: %s] Resumed deliver for %s is already past the stop block [%d]", chdr.ChannelId, addr, stopNum)
+		return cb.Status_SUCCESS, nil
+	}
+
+	var reevaluate <-chan time.Time
+	if h.ReevaluationInterval > 0 {
+		ticker := time.NewTicker(h.ReevaluationInterval)
+		defer ticker.Stop()
+		reevaluate = ticker.C
+	}
+
 	for {
 		if seekInfo.Behavior == ab.SeekInfo_FAIL_IF_NOT_READY {
 			if number > chain.Reader().Height()-1 {
@@ -292,17 +394,26 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			close(iterCh)
 		}()
 
-		select {
-		case <-ctx.Done():
-			logger.Debugf("Context canceled, aborting wait for next block")
-			return cb.Status_INTERNAL_SERVER_ERROR, errors.Wrapf(ctx.Err(), "context finished before block retrieved")
-		case <-erroredChan:
-			// TODO, today, the only user of the errorChan is the orderer consensus implementations.  If the peer ever reports
-			// this error, we will need to update this error message, possibly finding a way to signal what error text to return.
-			logger.Warningf("Aborting deliver for request because the backing consensus implementation indicates an error")
-			return cb.Status_SERVICE_UNAVAILABLE, nil
-		case <-iterCh:
-			// Iterator has set the block and status vars
+	waitForBlock:
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Debugf("Context canceled, aborting wait for next block")
+				return cb.Status_INTERNAL_SERVER_ERROR, errors.Wrapf(ctx.Err(), "context finished before block retrieved")
+			case <-erroredChan:
+				// TODO, today, the only user of the errorChan is the orderer consensus implementations.  If the peer ever reports
+				// this error, we will need to update this error message, possibly finding a way to signal what error text to return.
+				logger.Warningf("Aborting deliver for request because the backing consensus implementation indicates an error")
+				return cb.Status_SERVICE_UNAVAILABLE, nil
+			case <-reevaluate:
+				if err := accessControl.Evaluate(); err != nil {
+					logger.Warningf("[channel: %s] Client authorization revoked for deliver request from %s: %s", chdr.ChannelId, addr, err)
+					return cb.Status_FORBIDDEN, nil
+				}
+			case <-iterCh:
+				// Iterator has set the block and status vars
+				break waitForBlock
+			}
 		}
 
 		if status != cb.Status_SUCCESS {
@@ -318,6 +429,37 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 			return cb.Status_FORBIDDEN, nil
 		}
 
+		// filtered blocks are only a fraction of the size of the blocks
+		if !filtered {
+			size := blockResponseSize(block)
+			if maxSize := comm.MaxSendMsgSizeTo(ctx); size > maxSize {
+				logger.Warningf("[channel: %s] Block [%d] of %d bytes exceeds the maximum message size of %d bytes that can be sent to %s",
+					chdr.ChannelId, block.Header.Number, size, maxSize, addr)
+				return cb.Status_REQUEST_ENTITY_TOO_LARGE, nil
+			}
+			if stream != nil {
+				delay, err := stream.Wait(ctx, size)
+				if err != nil {
+					logger.Debugf("Context canceled, aborting wait for the bandwidth of the client")
+					return cb.Status_INTERNAL_SERVER_ERROR, errors.Wrapf(err, "context finished before block sent")
+				}
+				if delay > 0 {
+					logger.Debugf("[channel: %s] Delayed block [%d] by %s for the bandwidth of %s", chdr.ChannelId, block.Header.Number, delay, addr)
+					h.Metrics.BlocksDelayed.With(labels...).Add(1)
+				}
+			}
+		}
+
+		replay := false
+		if session != nil {
+			var admitted bool
+			if replay, admitted = session.Admit(block.Header.Number); !admitted {
+				logger.Warningf("[channel: %s] Throttling deliver for %s which exceeded its replayed blocks at block [%d]", chdr.ChannelId, addr, block.Header.Number)
+				h.Metrics.ReplaysThrottled.With(labels...).Add(1)
+				return cb.Status_SERVICE_UNAVAILABLE, nil
+			}
+		}
+
 		logger.Debugf("[channel: %s] Delivering block for (%p) for %s", chdr.ChannelId, seekInfo, addr)
 
 		if err := srv.SendBlockResponse(block); err != nil {
@@ -326,6 +468,12 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 		}
 
 		h.Metrics.BlocksSent.With(labels...).Add(1)
+		if session != nil {
+			session.Delivered(block.Header.Number)
+			if replay {
+				h.Metrics.BlocksReplayed.With(labels...).Add(1)
+			}
+		}
 
 		if stopNum == block.Header.Number {
 			break
@@ -337,6 +485,30 @@ func (h *Handler) deliverBlocks(ctx context.Context, srv *Server, envelope *cb.E
 	return cb.Status_SUCCESS, nil
 }
 
+// creatorMSPID returns the MSP ID of the serialized identity of the creator of
+// a request, or the empty string if it cannot be unmarshaled
+func creatorMSPID(creator []byte) string {
+	sid := &mspprotos.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, sid); err != nil {
+		return ""
+	}
+	return sid.Mspid
+}
+
+// blockResponseSize returns the size of the deliver response carrying the given block,
+// approximated by the size of the data and metadata of the block, which dominate it.
+// The block is not marshaled as this would cache the size in the shared block
+func blockResponseSize(block *cb.Block) int {
+	size := 0
+	for _, data := range block.GetData().GetData() {
+		size += len(data)
+	}
+	for _, metadata := range block.GetMetadata().GetMetadata() {
+		size += len(metadata)
+	}
+	return size
+}
+
 func (h *Handler) validateChannelHeader(ctx context.Context, chdr *cb.ChannelHeader) error {
 	if chdr.GetTimestamp() == nil {
 		err := errors.New("channel header in envelope must contain timestamp")
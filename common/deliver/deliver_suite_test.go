@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/deliver/mock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -20,6 +21,21 @@ type filteredResponseSender interface {
 	deliver.Filtered
 }
 
+// resumingResponseSender is a response sender which resumes the deliver of its
+// clients at the given block
+type resumingResponseSender struct {
+	*mock.ResponseSender
+	next      uint64
+	ok        bool
+	err       error
+	channelID string
+}
+
+func (r *resumingResponseSender) Resume(channelID string, identity []byte) (uint64, bool, error) {
+	r.channelID = channelID
+	return r.next, r.ok, r.err
+}
+
 func TestDeliver(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Deliver Suite")
@@ -19,12 +19,14 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/comm"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/utils"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
 )
 
 var (
@@ -101,6 +103,11 @@ var _ = Describe("Deliver", func() {
 			fakeRequestsReceived  *metricsfakes.Counter
 			fakeRequestsCompleted *metricsfakes.Counter
 			fakeBlocksSent        *metricsfakes.Counter
+			fakeBlocksReplayed    *metricsfakes.Counter
+			fakeReplaysThrottled  *metricsfakes.Counter
+			fakeSessionsResumed   *metricsfakes.Counter
+			fakeStreamsThrottled  *metricsfakes.Counter
+			fakeBlocksDelayed     *metricsfakes.Counter
 
 			handler *deliver.Handler
 			server  *deliver.Server
@@ -149,6 +156,16 @@ var _ = Describe("Deliver", func() {
 			fakeRequestsCompleted.WithReturns(fakeRequestsCompleted)
 			fakeBlocksSent = &metricsfakes.Counter{}
 			fakeBlocksSent.WithReturns(fakeBlocksSent)
+			fakeBlocksReplayed = &metricsfakes.Counter{}
+			fakeBlocksReplayed.WithReturns(fakeBlocksReplayed)
+			fakeReplaysThrottled = &metricsfakes.Counter{}
+			fakeReplaysThrottled.WithReturns(fakeReplaysThrottled)
+			fakeSessionsResumed = &metricsfakes.Counter{}
+			fakeSessionsResumed.WithReturns(fakeSessionsResumed)
+			fakeStreamsThrottled = &metricsfakes.Counter{}
+			fakeStreamsThrottled.WithReturns(fakeStreamsThrottled)
+			fakeBlocksDelayed = &metricsfakes.Counter{}
+			fakeBlocksDelayed.WithReturns(fakeBlocksDelayed)
 
 			deliverMetrics := &deliver.Metrics{
 				StreamsOpened:     fakeStreamsOpened,
@@ -156,6 +173,11 @@ var _ = Describe("Deliver", func() {
 				RequestsReceived:  fakeRequestsReceived,
 				RequestsCompleted: fakeRequestsCompleted,
 				BlocksSent:        fakeBlocksSent,
+				BlocksReplayed:    fakeBlocksReplayed,
+				ReplaysThrottled:  fakeReplaysThrottled,
+				SessionsResumed:   fakeSessionsResumed,
+				StreamsThrottled:  fakeStreamsThrottled,
+				BlocksDelayed:     fakeBlocksDelayed,
 			}
 
 			handler = &deliver.Handler{
@@ -488,6 +510,269 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the deliver sessions are tracked", func() {
+			var seekEnvelope func(creator []byte, seekInfo *ab.SeekInfo) *cb.Envelope
+
+			BeforeEach(func() {
+				handler.Sessions = deliver.NewSessions(deliver.SessionConfig{
+					TTL:               time.Minute,
+					MaxReplayedBlocks: 2,
+					ReplayWindow:      time.Minute,
+				})
+
+				fakeBlockReader.HeightReturns(103)
+				fakeBlockReader.IteratorStub = func(start *ab.SeekPosition) (blockledger.Iterator, uint64) {
+					number := start.GetSpecified().GetNumber()
+					iterator := &mock.BlockIterator{}
+					iterator.NextStub = func() (*cb.Block, cb.Status) {
+						blk := &cb.Block{
+							Header: &cb.BlockHeader{Number: number + uint64(iterator.NextCallCount()) - 1},
+						}
+						return blk, cb.Status_SUCCESS
+					}
+					return iterator, number
+				}
+
+				seekEnvelope = func(creator []byte, seekInfo *ab.SeekInfo) *cb.Envelope {
+					payload := &cb.Payload{
+						Header: &cb.Header{
+							ChannelHeader:   utils.MarshalOrPanic(channelHeader),
+							SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: creator}),
+						},
+						Data: utils.MarshalOrPanic(seekInfo),
+					}
+					return &cb.Envelope{Payload: utils.MarshalOrPanic(payload)}
+				}
+				seekInfo.Stop = seekNewest
+				fakeReceiver.RecvReturnsOnCall(0, seekEnvelope([]byte("client"), seekInfo), nil)
+				fakeReceiver.RecvReturnsOnCall(2, nil, io.EOF)
+			})
+
+			Context("when the client resumes its deliver", func() {
+				BeforeEach(func() {
+					fakeReceiver.RecvReturnsOnCall(1, seekEnvelope([]byte("client"), &ab.SeekInfo{Start: seekOldest, Stop: seekNewest, Resume: true}), nil)
+				})
+
+				It("resumes after the last block delivered to the client", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(2))
+					start := fakeBlockReader.IteratorArgsForCall(1)
+					Expect(start.GetSpecified().GetNumber()).To(Equal(uint64(103)))
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(3))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(2))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(1)).To(Equal(cb.Status_SUCCESS))
+
+					Expect(fakeSessionsResumed.AddCallCount()).To(Equal(1))
+					Expect(fakeSessionsResumed.WithArgsForCall(0)).To(Equal([]string{
+						"channel", "chain-id",
+						"filtered", "false",
+					}))
+					Expect(fakeBlocksReplayed.AddCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when another client resumes its deliver", func() {
+				BeforeEach(func() {
+					fakeReceiver.RecvReturnsOnCall(1, seekEnvelope([]byte("other-client"), &ab.SeekInfo{Start: seekOldest, Stop: seekNewest, Resume: true}), nil)
+				})
+
+				It("starts at the start position", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(2))
+					start := fakeBlockReader.IteratorArgsForCall(1)
+					Expect(proto.Equal(start, seekOldest)).To(BeTrue())
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(106))
+					Expect(fakeSessionsResumed.AddCallCount()).To(Equal(0))
+					Expect(fakeBlocksReplayed.AddCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the client streams the blocks it received again", func() {
+				BeforeEach(func() {
+					fakeReceiver.RecvReturnsOnCall(1, seekEnvelope([]byte("client"), seekInfo), nil)
+				})
+
+				It("throttles the client once it exceeds its replayed blocks", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(5))
+					Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(2))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(1)).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+
+					Expect(fakeBlocksReplayed.AddCallCount()).To(Equal(2))
+					Expect(fakeReplaysThrottled.AddCallCount()).To(Equal(1))
+					Expect(fakeReplaysThrottled.WithArgsForCall(0)).To(Equal([]string{
+						"channel", "chain-id",
+						"filtered", "false",
+					}))
+				})
+			})
+		})
+
+		Context("when the response sender tracks the blocks processed by the client", func() {
+			var fakeResponseSender *resumingResponseSender
+
+			BeforeEach(func() {
+				fakeResponseSender = &resumingResponseSender{
+					ResponseSender: &mock.ResponseSender{},
+					next:           100,
+					ok:             true,
+				}
+				server.ResponseSender = fakeResponseSender
+				seekInfo.Start = seekOldest
+				seekInfo.Resume = true
+			})
+
+			It("resumes after the last block processed by the client", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.channelID).To(Equal("chain-id"))
+				Expect(fakeBlockReader.IteratorCallCount()).To(Equal(1))
+				start := fakeBlockReader.IteratorArgsForCall(0)
+				Expect(start.GetSpecified().GetNumber()).To(Equal(uint64(100)))
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+			})
+
+			Context("when the client does not resume its deliver", func() {
+				BeforeEach(func() {
+					seekInfo.Resume = false
+				})
+
+				It("starts at the start position", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.channelID).To(Equal("chain-id"))
+					start := fakeBlockReader.IteratorArgsForCall(0)
+					Expect(proto.Equal(start, seekOldest)).To(BeTrue())
+				})
+			})
+
+			Context("when the blocks cannot be delivered to the client", func() {
+				BeforeEach(func() {
+					fakeResponseSender.err = errors.New("consumer busy")
+				})
+
+				It("rejects the request with service unavailable", func() {
+					err := handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBlockReader.IteratorCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+				})
+			})
+		})
+
+		Context("when the deliver streams are throttled", func() {
+			BeforeEach(func() {
+				handler.Throttle = deliver.NewThrottle(deliver.ThrottleLimits{MaxStreamsPerIdentity: 1, MaxBytesPerSecondPerIdentity: 1})
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header: &cb.BlockHeader{Number: 100},
+					Data:   &cb.BlockData{Data: [][]byte{[]byte("tx-1")}},
+				}, cb.Status_SUCCESS)
+			})
+
+			It("delivers the first block without delay", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+				Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SUCCESS))
+				Expect(fakeBlocksDelayed.AddCallCount()).To(Equal(0))
+			})
+
+			Context("when the client exceeds its concurrent streams", func() {
+				It("rejects the request with service unavailable", func() {
+					stream, err := handler.Throttle.Acquire("chain-id", nil, "", deliver.ThrottleLimits{})
+					Expect(err).NotTo(HaveOccurred())
+					defer stream.Release()
+
+					err = handler.Handle(context.Background(), server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+					Expect(fakeResponseSender.SendStatusResponseArgsForCall(0)).To(Equal(cb.Status_SERVICE_UNAVAILABLE))
+					Expect(fakeStreamsThrottled.AddCallCount()).To(Equal(1))
+					Expect(fakeStreamsThrottled.WithArgsForCall(0)).To(Equal([]string{
+						"channel", "chain-id",
+						"filtered", "false",
+					}))
+				})
+			})
+
+			Context("when the client exceeds its bandwidth", func() {
+				It("waits for the bandwidth until the client disconnects", func() {
+					stream, err := handler.Throttle.Acquire("chain-id", nil, "", deliver.ThrottleLimits{MaxBytesPerSecondPerIdentity: 1})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = stream.Wait(context.Background(), 3600)
+					Expect(err).NotTo(HaveOccurred())
+					stream.Release()
+
+					ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+					defer cancel()
+
+					err = handler.Handle(ctx, server)
+					Expect(err).To(MatchError("context finished before block sent: context deadline exceeded"))
+					Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		Context("when the block exceeds the maximum message size advertised by the client", func() {
+			var ctx context.Context
+
+			BeforeEach(func() {
+				ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(comm.MaxRecvMsgSizeMetadataKey, "4"))
+				fakeBlockIterator.NextReturns(&cb.Block{
+					Header: &cb.BlockHeader{Number: 100},
+					Data:   &cb.BlockData{Data: [][]byte{[]byte("tx-1"), []byte("tx-2")}},
+				}, cb.Status_SUCCESS)
+			})
+
+			It("does not send the block and returns a request entity too large status", func() {
+				err := handler.Handle(ctx, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(0))
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_REQUEST_ENTITY_TOO_LARGE))
+			})
+
+			Context("and filtered blocks are requested", func() {
+				BeforeEach(func() {
+					fakeResponseSender := &mock.FilteredResponseSender{}
+					fakeResponseSender.IsFilteredReturns(true)
+					server.ResponseSender = fakeResponseSender
+				})
+
+				It("sends the block", func() {
+					err := handler.Handle(ctx, server)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(server.ResponseSender.(*mock.FilteredResponseSender).SendBlockResponseCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when the block fits in the maximum message size advertised by the client", func() {
+			It("sends the block", func() {
+				ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(comm.MaxRecvMsgSizeMetadataKey, "1024"))
+				err := handler.Handle(ctx, server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendBlockResponseCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when sending the block fails", func() {
 			BeforeEach(func() {
 				fakeResponseSender.SendBlockResponseReturns(errors.New("send-fails"))
@@ -761,6 +1046,53 @@ var _ = Describe("Deliver", func() {
 			})
 		})
 
+		Context("when the access is revoked while waiting for a block", func() {
+			var done chan struct{}
+
+			BeforeEach(func() {
+				done = make(chan struct{})
+				fakeBlockIterator.NextStub = func() (*cb.Block, cb.Status) {
+					<-done
+					return nil, cb.Status_BAD_REQUEST
+				}
+				fakeChain.SequenceStub = func() uint64 {
+					return uint64(fakeChain.SequenceCallCount())
+				}
+				fakePolicyChecker.CheckPolicyReturnsOnCall(1, errors.New("no-access-for-you"))
+				handler.ReevaluationInterval = 10 * time.Millisecond
+			})
+
+			AfterEach(func() {
+				close(done)
+			})
+
+			It("sends status forbidden", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_FORBIDDEN))
+				Expect(fakePolicyChecker.CheckPolicyCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the TLS identity is bound and the client has no TLS certificate", func() {
+			BeforeEach(func() {
+				handler.BindTLSIdentity = true
+			})
+
+			It("sends status forbidden", func() {
+				err := handler.Handle(context.Background(), server)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeResponseSender.SendStatusResponseCallCount()).To(Equal(1))
+				resp := fakeResponseSender.SendStatusResponseArgsForCall(0)
+				Expect(resp).To(Equal(cb.Status_FORBIDDEN))
+				Expect(fakePolicyChecker.CheckPolicyCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when unmarshaling seek info fails", func() {
 			BeforeEach(func() {
 				seekInfoPayload = []byte("complete-nonsense")
@@ -44,6 +44,41 @@ var (
 		LabelNames:   []string{"channel", "filtered"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
 	}
+	blocksReplayed = metrics.CounterOpts{
+		Namespace:    "deliver",
+		Name:         "blocks_replayed",
+		Help:         "The number of blocks sent by the deliver service to clients they were already sent to.",
+		LabelNames:   []string{"channel", "filtered"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
+	}
+	replaysThrottled = metrics.CounterOpts{
+		Namespace:    "deliver",
+		Name:         "replays_throttled",
+		Help:         "The number of deliver requests closed because the client exceeded its replayed blocks.",
+		LabelNames:   []string{"channel", "filtered"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
+	}
+	streamsThrottled = metrics.CounterOpts{
+		Namespace:    "deliver",
+		Name:         "streams_throttled",
+		Help:         "The number of deliver requests rejected because the client or its organization exceeded its concurrent streams.",
+		LabelNames:   []string{"channel", "filtered"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
+	}
+	blocksDelayed = metrics.CounterOpts{
+		Namespace:    "deliver",
+		Name:         "blocks_delayed",
+		Help:         "The number of blocks delayed because the client or its organization exceeded its bandwidth.",
+		LabelNames:   []string{"channel", "filtered"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
+	}
+	sessionsResumed = metrics.CounterOpts{
+		Namespace:    "deliver",
+		Name:         "sessions_resumed",
+		Help:         "The number of deliver requests resumed after the last block sent to the client.",
+		LabelNames:   []string{"channel", "filtered"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{filtered}",
+	}
 )
 
 type Metrics struct {
@@ -52,6 +87,11 @@ type Metrics struct {
 	RequestsReceived  metrics.Counter
 	RequestsCompleted metrics.Counter
 	BlocksSent        metrics.Counter
+	BlocksReplayed    metrics.Counter
+	ReplaysThrottled  metrics.Counter
+	SessionsResumed   metrics.Counter
+	StreamsThrottled  metrics.Counter
+	BlocksDelayed     metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -61,5 +101,10 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		RequestsReceived:  p.NewCounter(requestsReceived),
 		RequestsCompleted: p.NewCounter(requestsCompleted),
 		BlocksSent:        p.NewCounter(blocksSent),
+		BlocksReplayed:    p.NewCounter(blocksReplayed),
+		ReplaysThrottled:  p.NewCounter(replaysThrottled),
+		SessionsResumed:   p.NewCounter(sessionsResumed),
+		StreamsThrottled:  p.NewCounter(streamsThrottled),
+		BlocksDelayed:     p.NewCounter(blocksDelayed),
 	}
 }
@@ -7,6 +7,7 @@ import (
 	deliver "github.com/hyperledger/fabric/common/deliver"
 	blockledger "github.com/hyperledger/fabric/common/ledger/blockledger"
 	policies "github.com/hyperledger/fabric/common/policies"
+	msp "github.com/hyperledger/fabric/msp"
 )
 
 type Chain struct {
@@ -20,6 +21,16 @@ type Chain struct {
 	erroredReturnsOnCall map[int]struct {
 		result1 <-chan struct{}
 	}
+	MSPManagerStub        func() msp.MSPManager
+	mSPManagerMutex       sync.RWMutex
+	mSPManagerArgsForCall []struct {
+	}
+	mSPManagerReturns struct {
+		result1 msp.MSPManager
+	}
+	mSPManagerReturnsOnCall map[int]struct {
+		result1 msp.MSPManager
+	}
 	PolicyManagerStub        func() policies.Manager
 	policyManagerMutex       sync.RWMutex
 	policyManagerArgsForCall []struct {
@@ -106,6 +117,58 @@ func (fake *Chain) ErroredReturnsOnCall(i int, result1 <-chan struct{}) {
 	}{result1}
 }
 
+func (fake *Chain) MSPManager() msp.MSPManager {
+	fake.mSPManagerMutex.Lock()
+	ret, specificReturn := fake.mSPManagerReturnsOnCall[len(fake.mSPManagerArgsForCall)]
+	fake.mSPManagerArgsForCall = append(fake.mSPManagerArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MSPManager", []interface{}{})
+	fake.mSPManagerMutex.Unlock()
+	if fake.MSPManagerStub != nil {
+		return fake.MSPManagerStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.mSPManagerReturns
+	return fakeReturns.result1
+}
+
+func (fake *Chain) MSPManagerCallCount() int {
+	fake.mSPManagerMutex.RLock()
+	defer fake.mSPManagerMutex.RUnlock()
+	return len(fake.mSPManagerArgsForCall)
+}
+
+func (fake *Chain) MSPManagerCalls(stub func() msp.MSPManager) {
+	fake.mSPManagerMutex.Lock()
+	defer fake.mSPManagerMutex.Unlock()
+	fake.MSPManagerStub = stub
+}
+
+func (fake *Chain) MSPManagerReturns(result1 msp.MSPManager) {
+	fake.mSPManagerMutex.Lock()
+	defer fake.mSPManagerMutex.Unlock()
+	fake.MSPManagerStub = nil
+	fake.mSPManagerReturns = struct {
+		result1 msp.MSPManager
+	}{result1}
+}
+
+func (fake *Chain) MSPManagerReturnsOnCall(i int, result1 msp.MSPManager) {
+	fake.mSPManagerMutex.Lock()
+	defer fake.mSPManagerMutex.Unlock()
+	fake.MSPManagerStub = nil
+	if fake.mSPManagerReturnsOnCall == nil {
+		fake.mSPManagerReturnsOnCall = make(map[int]struct {
+			result1 msp.MSPManager
+		})
+	}
+	fake.mSPManagerReturnsOnCall[i] = struct {
+		result1 msp.MSPManager
+	}{result1}
+}
+
 func (fake *Chain) PolicyManager() policies.Manager {
 	fake.policyManagerMutex.Lock()
 	ret, specificReturn := fake.policyManagerReturnsOnCall[len(fake.policyManagerArgsForCall)]
@@ -267,6 +330,8 @@ func (fake *Chain) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.erroredMutex.RLock()
 	defer fake.erroredMutex.RUnlock()
+	fake.mSPManagerMutex.RLock()
+	defer fake.mSPManagerMutex.RUnlock()
 	fake.policyManagerMutex.RLock()
 	defer fake.policyManagerMutex.RUnlock()
 	fake.readerMutex.RLock()
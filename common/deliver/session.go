@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"crypto/sha256"
+	"sync"
+	"time"
+)
+
+// SessionConfig configures the tracking of the deliver sessions of the clients.
+type SessionConfig struct {
+	// TTL is how long the session of a client is retained after its last
+	// stream on the channel has closed, so that a reconnecting client resumes
+	// where it left instead of streaming the blocks it already received again
+	TTL time.Duration
+	// MaxReplayedBlocks is the maximum number of blocks already delivered to a
+	// client that it may be delivered again within a ReplayWindow, the streams
+	// exceeding it being closed with SERVICE_UNAVAILABLE. Zero means no limit
+	MaxReplayedBlocks uint64
+	// ReplayWindow is the window over which the replayed blocks are counted
+	ReplayWindow time.Duration
+}
+
+// Sessions tracks the blocks delivered to each client identity on each
+// channel, so that the clients can resume their deliver after a reconnection
+// and the clients which repeatedly stream the same blocks are throttled.
+type Sessions struct {
+	config SessionConfig
+	now    func() time.Time
+
+	mutex     sync.Mutex
+	sessions  map[sessionKey]*Session
+	lastSweep time.Time
+}
+
+type sessionKey struct {
+	channelID string
+	identity  [sha256.Size]byte
+	filtered  bool
+}
+
+// NewSessions creates the tracker of the deliver sessions with the given config.
+func NewSessions(config SessionConfig) *Sessions {
+	return &Sessions{
+		config:   config,
+		now:      time.Now,
+		sessions: map[sessionKey]*Session{},
+	}
+}
+
+// Open returns the session of the client with the given serialized identity
+// on the channel for the blocks of the given kind, creating it if the client
+// has none, and holds it until the stream is closed.
+func (s *Sessions) Open(channelID string, identity []byte, filtered bool) *Session {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	key := sessionKey{channelID: channelID, identity: sha256.Sum256(identity), filtered: filtered}
+	session, ok := s.sessions[key]
+	if !ok {
+		session = &Session{sessions: s, windowStart: now}
+		s.sessions[key] = session
+	}
+	session.streams++
+	session.lastSeen = now
+	return session
+}
+
+// sweep drops the sessions without streams which expired, at most once per TTL.
+func (s *Sessions) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < s.config.TTL {
+		return
+	}
+	s.lastSweep = now
+	for key, session := range s.sessions {
+		if session.streams == 0 && now.Sub(session.lastSeen) > s.config.TTL {
+			delete(s.sessions, key)
+		}
+	}
+}
+
+// Session is the range of blocks delivered to a client on a channel.
+type Session struct {
+	sessions *Sessions
+
+	// the guarded fields are protected by the mutex of the sessions
+	streams   int
+	lastSeen  time.Time
+	delivered bool
+	first     uint64
+	next      uint64
+
+	windowStart time.Time
+	replayed    uint64
+}
+
+// Cursor returns the number of the block following the last block delivered
+// to the client, and false if no block has been delivered to it.
+func (s *Session) Cursor() (uint64, bool) {
+	s.sessions.mutex.Lock()
+	defer s.sessions.mutex.Unlock()
+
+	return s.next, s.delivered
+}
+
+// Admit checks whether the block with the given number may be delivered to
+// the client. It returns whether the block was already delivered to the
+// client, and false when it was and the client has exhausted its replays.
+func (s *Session) Admit(number uint64) (replay bool, admitted bool) {
+	s.sessions.mutex.Lock()
+	defer s.sessions.mutex.Unlock()
+
+	if !s.delivered || number < s.first || number >= s.next {
+		return false, true
+	}
+
+	config := s.sessions.config
+	if config.MaxReplayedBlocks == 0 {
+		return true, true
+	}
+	now := s.sessions.now()
+	if now.Sub(s.windowStart) >= config.ReplayWindow {
+		s.windowStart = now
+		s.replayed = 0
+	}
+	if s.replayed >= config.MaxReplayedBlocks {
+		return true, false
+	}
+	s.replayed++
+	return true, true
+}
+
+// Delivered records the delivery of the block with the given number. A block
+// which does not extend the range of the delivered blocks starts a new one.
+func (s *Session) Delivered(number uint64) {
+	s.sessions.mutex.Lock()
+	defer s.sessions.mutex.Unlock()
+
+	s.lastSeen = s.sessions.now()
+	switch {
+	case s.delivered && number >= s.first && number < s.next:
+	case s.delivered && number == s.next:
+		s.next++
+	default:
+		s.delivered = true
+		s.first = number
+		s.next = number + 1
+	}
+}
+
+// Close releases the session held by a stream, which expires after the TTL
+// once no stream holds it.
+func (s *Session) Close() {
+	s.sessions.mutex.Lock()
+	defer s.sessions.mutex.Unlock()
+
+	s.streams--
+	s.lastSeen = s.sessions.now()
+}
@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver_test
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/deliver"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sessions", func() {
+	var (
+		config   deliver.SessionConfig
+		sessions *deliver.Sessions
+	)
+
+	BeforeEach(func() {
+		config = deliver.SessionConfig{
+			TTL:               time.Minute,
+			MaxReplayedBlocks: 2,
+			ReplayWindow:      time.Minute,
+		}
+	})
+
+	JustBeforeEach(func() {
+		sessions = deliver.NewSessions(config)
+	})
+
+	It("tracks the blocks delivered to the client", func() {
+		session := sessions.Open("channel", []byte("client"), false)
+		_, ok := session.Cursor()
+		Expect(ok).To(BeFalse())
+
+		for number := uint64(5); number < 8; number++ {
+			replay, admitted := session.Admit(number)
+			Expect(replay).To(BeFalse())
+			Expect(admitted).To(BeTrue())
+			session.Delivered(number)
+		}
+		session.Close()
+
+		session = sessions.Open("channel", []byte("client"), false)
+		next, ok := session.Cursor()
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(uint64(8)))
+	})
+
+	It("keeps the sessions of the channels, clients and kinds of blocks apart", func() {
+		session := sessions.Open("channel", []byte("client"), false)
+		session.Delivered(5)
+
+		for _, other := range []*deliver.Session{
+			sessions.Open("other-channel", []byte("client"), false),
+			sessions.Open("channel", []byte("other-client"), false),
+			sessions.Open("channel", []byte("client"), true),
+		} {
+			_, ok := other.Cursor()
+			Expect(ok).To(BeFalse())
+			replay, _ := other.Admit(5)
+			Expect(replay).To(BeFalse())
+		}
+	})
+
+	It("starts a new range when a block does not extend the delivered ones", func() {
+		session := sessions.Open("channel", []byte("client"), false)
+		session.Delivered(5)
+		session.Delivered(6)
+		session.Delivered(10)
+
+		next, _ := session.Cursor()
+		Expect(next).To(Equal(uint64(11)))
+		replay, _ := session.Admit(5)
+		Expect(replay).To(BeFalse())
+		replay, _ = session.Admit(10)
+		Expect(replay).To(BeTrue())
+	})
+
+	It("admits the replayed blocks up to the limit", func() {
+		session := sessions.Open("channel", []byte("client"), false)
+		session.Delivered(5)
+		session.Delivered(6)
+		session.Delivered(7)
+
+		for _, number := range []uint64{5, 6} {
+			replay, admitted := session.Admit(number)
+			Expect(replay).To(BeTrue())
+			Expect(admitted).To(BeTrue())
+		}
+		replay, admitted := session.Admit(7)
+		Expect(replay).To(BeTrue())
+		Expect(admitted).To(BeFalse())
+
+		// the blocks which were not delivered are not limited
+		replay, admitted = session.Admit(8)
+		Expect(replay).To(BeFalse())
+		Expect(admitted).To(BeTrue())
+	})
+
+	Context("when the replayed blocks are not limited", func() {
+		BeforeEach(func() {
+			config.MaxReplayedBlocks = 0
+		})
+
+		It("admits all the replayed blocks", func() {
+			session := sessions.Open("channel", []byte("client"), false)
+			session.Delivered(5)
+
+			for i := 0; i < 10; i++ {
+				replay, admitted := session.Admit(5)
+				Expect(replay).To(BeTrue())
+				Expect(admitted).To(BeTrue())
+			}
+		})
+	})
+
+	Context("when the replay window has elapsed", func() {
+		BeforeEach(func() {
+			config.ReplayWindow = 10 * time.Millisecond
+		})
+
+		It("admits the replayed blocks again", func() {
+			session := sessions.Open("channel", []byte("client"), false)
+			session.Delivered(5)
+			session.Admit(5)
+			session.Admit(5)
+			_, admitted := session.Admit(5)
+			Expect(admitted).To(BeFalse())
+
+			time.Sleep(20 * time.Millisecond)
+			_, admitted = session.Admit(5)
+			Expect(admitted).To(BeTrue())
+		})
+	})
+
+	Context("when the TTL has elapsed", func() {
+		BeforeEach(func() {
+			config.TTL = 10 * time.Millisecond
+		})
+
+		It("drops the sessions without streams", func() {
+			closed := sessions.Open("channel", []byte("client"), false)
+			closed.Delivered(5)
+			closed.Close()
+			open := sessions.Open("channel", []byte("other-client"), false)
+			open.Delivered(5)
+
+			time.Sleep(20 * time.Millisecond)
+			_, ok := sessions.Open("channel", []byte("client"), false).Cursor()
+			Expect(ok).To(BeFalse())
+			_, ok = sessions.Open("channel", []byte("other-client"), false).Cursor()
+			Expect(ok).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ThrottleLimits limits the deliver streams of the clients on a channel.
+// Zero means no limit.
+type ThrottleLimits struct {
+	// MaxStreamsPerIdentity is the maximum number of concurrent deliver
+	// streams of a client identity
+	MaxStreamsPerIdentity int
+	// MaxStreamsPerOrg is the maximum number of concurrent deliver streams of
+	// the client identities of an organization
+	MaxStreamsPerOrg int
+	// MaxBytesPerSecondPerIdentity is the maximum bandwidth of the blocks
+	// delivered to a client identity
+	MaxBytesPerSecondPerIdentity int64
+	// MaxBytesPerSecondPerOrg is the maximum bandwidth of the blocks delivered
+	// to the client identities of an organization
+	MaxBytesPerSecondPerOrg int64
+}
+
+// ThrottleLimitsProvider is implemented by the chains whose configuration
+// overrides the default limits of the deliver streams.
+type ThrottleLimitsProvider interface {
+	// ThrottleLimits returns the limits of the streams of blocks, or of
+	// filtered blocks, on the channel given the default limits
+	ThrottleLimits(defaults ThrottleLimits, filtered bool) ThrottleLimits
+}
+
+// Throttle limits the concurrent deliver streams and the bandwidth of the
+// blocks delivered to each client identity and to each organization on each
+// channel, protecting the server from the misconfigured consumers of blocks
+// and events.
+type Throttle struct {
+	defaults ThrottleLimits
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	clients   map[throttleKey]*throttleState
+	lastSweep time.Time
+}
+
+type throttleKey struct {
+	channelID string
+	identity  [sha256.Size]byte
+	org       string
+	isOrg     bool
+}
+
+type throttleState struct {
+	streams int
+	// next is the time at which the bandwidth of the client allows the next
+	// block to be sent
+	next time.Time
+}
+
+// throttleSweepInterval is the interval at which the throttle drops the
+// state of the clients without streams
+const throttleSweepInterval = time.Minute
+
+// NewThrottle creates the throttle of the deliver streams with the given
+// default limits.
+func NewThrottle(defaults ThrottleLimits) *Throttle {
+	return &Throttle{
+		defaults: defaults,
+		now:      time.Now,
+		clients:  map[throttleKey]*throttleState{},
+	}
+}
+
+// Limits returns the limits of the deliver streams of the chain, which are
+// the default limits unless the configuration of the chain overrides them.
+func (t *Throttle) Limits(chain Chain, filtered bool) ThrottleLimits {
+	if provider, ok := chain.(ThrottleLimitsProvider); ok {
+		return provider.ThrottleLimits(t.defaults, filtered)
+	}
+	return t.defaults
+}
+
+// Acquire opens a deliver stream on the channel for the client with the given
+// serialized identity of the given organization. It returns an error if the
+// client or its organization would exceed its concurrent streams.
+func (t *Throttle) Acquire(channelID string, identity []byte, org string, limits ThrottleLimits) (*ThrottledStream, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.sweep(t.now())
+
+	identityKey := throttleKey{channelID: channelID, identity: sha256.Sum256(identity)}
+	orgKey := throttleKey{channelID: channelID, org: org, isOrg: true}
+	identityState, orgState := t.state(identityKey), t.state(orgKey)
+	if limits.MaxStreamsPerIdentity > 0 && identityState.streams >= limits.MaxStreamsPerIdentity {
+		return nil, errors.Errorf("the client has %d deliver streams open, the maximum", identityState.streams)
+	}
+	if limits.MaxStreamsPerOrg > 0 && orgState.streams >= limits.MaxStreamsPerOrg {
+		return nil, errors.Errorf("organization %s has %d deliver streams open, the maximum", org, orgState.streams)
+	}
+	identityState.streams++
+	orgState.streams++
+
+	return &ThrottledStream{
+		throttle:    t,
+		limits:      limits,
+		identityKey: identityKey,
+		orgKey:      orgKey,
+	}, nil
+}
+
+func (t *Throttle) state(key throttleKey) *throttleState {
+	state, ok := t.clients[key]
+	if !ok {
+		state = &throttleState{}
+		t.clients[key] = state
+	}
+	return state
+}
+
+// sweep drops the state of the clients without streams whose bandwidth no
+// longer delays them, at most once per sweep interval.
+func (t *Throttle) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < throttleSweepInterval {
+		return
+	}
+	t.lastSweep = now
+	for key, state := range t.clients {
+		if state.streams == 0 && !state.next.After(now) {
+			delete(t.clients, key)
+		}
+	}
+}
+
+// ThrottledStream is a deliver stream held by a client.
+type ThrottledStream struct {
+	throttle    *Throttle
+	limits      ThrottleLimits
+	identityKey throttleKey
+	orgKey      throttleKey
+}
+
+// Wait waits until a block of the given size can be delivered within the
+// bandwidth of the client and of its organization, and returns how long it
+// waited. It returns an error if the context is done before.
+func (s *ThrottledStream) Wait(ctx context.Context, size int) (time.Duration, error) {
+	s.throttle.mutex.Lock()
+	now := s.throttle.now()
+	delay := s.reserve(s.identityKey, s.limits.MaxBytesPerSecondPerIdentity, now, size)
+	if orgDelay := s.reserve(s.orgKey, s.limits.MaxBytesPerSecondPerOrg, now, size); orgDelay > delay {
+		delay = orgDelay
+	}
+	s.throttle.mutex.Unlock()
+
+	if delay <= 0 {
+		return 0, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+// reserve reserves the bandwidth of the block for the client with the given
+// key, and returns how long the block has to wait for it.
+func (s *ThrottledStream) reserve(key throttleKey, bytesPerSecond int64, now time.Time, size int) time.Duration {
+	if bytesPerSecond <= 0 {
+		return 0
+	}
+	state := s.throttle.state(key)
+	start := state.next
+	if start.Before(now) {
+		start = now
+	}
+	state.next = start.Add(time.Duration(int64(size) * int64(time.Second) / bytesPerSecond))
+	return start.Sub(now)
+}
+
+// Release closes the stream.
+func (s *ThrottledStream) Release() {
+	s.throttle.mutex.Lock()
+	defer s.throttle.mutex.Unlock()
+
+	s.throttle.state(s.identityKey).streams--
+	s.throttle.state(s.orgKey).streams--
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package deliver_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/deliver/mock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// configuredChain is a chain whose configuration limits the streams of blocks
+// of an identity to one
+type configuredChain struct {
+	*mock.Chain
+}
+
+func (configuredChain) ThrottleLimits(defaults deliver.ThrottleLimits, filtered bool) deliver.ThrottleLimits {
+	if !filtered {
+		defaults.MaxStreamsPerIdentity = 1
+	}
+	return defaults
+}
+
+var _ = Describe("Throttle", func() {
+	var (
+		limits   deliver.ThrottleLimits
+		throttle *deliver.Throttle
+	)
+
+	BeforeEach(func() {
+		limits = deliver.ThrottleLimits{
+			MaxStreamsPerIdentity: 2,
+			MaxStreamsPerOrg:      3,
+		}
+	})
+
+	JustBeforeEach(func() {
+		throttle = deliver.NewThrottle(limits)
+	})
+
+	It("limits the concurrent streams of the clients and of their organizations", func() {
+		var streams []*deliver.ThrottledStream
+		for _, client := range []string{"client1", "client1", "client2"} {
+			stream, err := throttle.Acquire("channel", []byte(client), "Org1MSP", limits)
+			Expect(err).NotTo(HaveOccurred())
+			streams = append(streams, stream)
+		}
+
+		_, err := throttle.Acquire("channel", []byte("client1"), "Org2MSP", limits)
+		Expect(err).To(MatchError("the client has 2 deliver streams open, the maximum"))
+		_, err = throttle.Acquire("channel", []byte("client3"), "Org1MSP", limits)
+		Expect(err).To(MatchError("organization Org1MSP has 3 deliver streams open, the maximum"))
+
+		By("keeping the channels apart")
+		_, err = throttle.Acquire("other-channel", []byte("client1"), "Org1MSP", limits)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("releasing the streams")
+		streams[0].Release()
+		_, err = throttle.Acquire("channel", []byte("client1"), "Org1MSP", limits)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("paces the blocks within the bandwidth of the clients and of their organizations", func() {
+		limits := deliver.ThrottleLimits{MaxBytesPerSecondPerIdentity: 1000, MaxBytesPerSecondPerOrg: 500}
+		stream1, err := throttle.Acquire("channel", []byte("client1"), "Org1MSP", limits)
+		Expect(err).NotTo(HaveOccurred())
+		stream2, err := throttle.Acquire("channel", []byte("client2"), "Org1MSP", limits)
+		Expect(err).NotTo(HaveOccurred())
+
+		delay, err := stream1.Wait(context.Background(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(delay).To(BeZero())
+
+		// the organization has 20ms of blocks reserved
+		delay, err = stream2.Wait(context.Background(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(delay).To(BeNumerically("~", 20*time.Millisecond, 10*time.Millisecond))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = stream1.Wait(ctx, 10)
+		Expect(err).To(Equal(context.Canceled))
+	})
+
+	It("applies the limits of the configuration of the chain", func() {
+		Expect(throttle.Limits(&mock.Chain{}, false)).To(Equal(limits))
+		chain := configuredChain{Chain: &mock.Chain{}}
+		Expect(throttle.Limits(chain, true)).To(Equal(limits))
+		Expect(throttle.Limits(chain, false)).To(Equal(deliver.ThrottleLimits{
+			MaxStreamsPerIdentity: 1,
+			MaxStreamsPerOrg:      3,
+		}))
+	})
+})
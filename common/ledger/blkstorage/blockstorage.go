@@ -64,3 +64,9 @@ type BlockStore interface {
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
 	Shutdown()
 }
+
+// DiskUsageReporter is implemented by the block stores that are able to report the disk space they use
+type DiskUsageReporter interface {
+	// DiskUsage returns the disk space used by the blocks and by the index of the block store, in bytes
+	DiskUsage() (blocks int64, index int64, err error)
+}
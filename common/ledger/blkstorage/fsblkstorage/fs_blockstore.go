@@ -17,11 +17,14 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"io/ioutil"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 )
 
 // fsBlockStore - filesystem based implementation for `BlockStore`
@@ -85,6 +88,25 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// DiskUsage returns the size of the block files and the approximate size of the index of the block store
+func (store *fsBlockStore) DiskUsage() (int64, int64, error) {
+	files, err := ioutil.ReadDir(store.fileMgr.rootDir)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "error reading the block files of ledger [%s]", store.id)
+	}
+	var blocks int64
+	for _, file := range files {
+		if !file.IsDir() {
+			blocks += file.Size()
+		}
+	}
+	index, err := store.fileMgr.db.ApproximateSize()
+	if err != nil {
+		return 0, 0, err
+	}
+	return blocks, index, nil
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
@@ -19,6 +19,8 @@ package fsblkstorage
 import (
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/stretchr/testify/assert"
 )
@@ -39,3 +41,32 @@ func TestWrongBlockNumber(t *testing.T) {
 	err := store.AddBlock(blocks[4])
 	assert.Error(t, err, "Error shold have been thrown when adding block number 4 while block number 3 is expected")
 }
+
+func TestDiskUsage(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+
+	provider := env.provider
+	store, _ := provider.OpenBlockStore("testLedger")
+	defer store.Shutdown()
+	otherStore, _ := provider.OpenBlockStore("otherLedger")
+	defer otherStore.Shutdown()
+
+	blocks := testutil.ConstructTestBlocks(t, 5)
+	size := 0
+	for _, block := range blocks {
+		assert.NoError(t, store.AddBlock(block))
+		blockBytes, _, err := serializeBlock(block)
+		assert.NoError(t, err)
+		size += len(blockBytes) + len(proto.EncodeVarint(uint64(len(blockBytes))))
+	}
+
+	blockFiles, _, err := store.(blkstorage.DiskUsageReporter).DiskUsage()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(size), blockFiles)
+
+	blockFiles, index, err := otherStore.(blkstorage.DiskUsageReporter).DiskUsage()
+	assert.NoError(t, err)
+	assert.Zero(t, blockFiles)
+	assert.Zero(t, index)
+}
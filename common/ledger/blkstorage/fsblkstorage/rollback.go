@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"os"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/pkg/errors"
+)
+
+// Rollback reverts the block store of the given ledger so that the block with number
+// `targetBlockNum` becomes the last block in the store. All the blocks after the target block
+// are removed from the block files and their entries are removed from the block index.
+// This function is expected to be invoked only when the peer is not running
+func Rollback(blockStorageDir, ledgerID string, targetBlockNum uint64, indexConfig *blkstorage.IndexConfig) error {
+	conf := NewConf(blockStorageDir, 0)
+	if err := validateRollbackParams(conf, ledgerID, targetBlockNum); err != nil {
+		return err
+	}
+	indexStoreProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer indexStoreProvider.Close()
+
+	mgr := newBlockfileMgr(ledgerID, conf, indexConfig, indexStoreProvider.GetDBHandle(ledgerID))
+	defer mgr.close()
+	return mgr.rollback(targetBlockNum)
+}
+
+// ValidateRollbackParams checks that the ledger exists and that the target block number
+// is lower than the number of the last block present in the block store
+func ValidateRollbackParams(blockStorageDir, ledgerID string, targetBlockNum uint64) error {
+	return validateRollbackParams(NewConf(blockStorageDir, 0), ledgerID, targetBlockNum)
+}
+
+// GetHeight returns the number of blocks in the block store of the given ledger, as of its
+// last checkpoint. This function is expected to be invoked only when the peer is not running
+func GetHeight(blockStorageDir, ledgerID string) (uint64, error) {
+	conf := NewConf(blockStorageDir, 0)
+	exists, _, err := util.FileExists(conf.getLedgerBlockDir(ledgerID))
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+
+	indexStoreProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer indexStoreProvider.Close()
+	mgr := &blockfileMgr{db: indexStoreProvider.GetDBHandle(ledgerID)}
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return 0, err
+	}
+	if cpInfo == nil || cpInfo.isChainEmpty {
+		return 0, nil
+	}
+	return cpInfo.lastBlockNumber + 1, nil
+}
+
+func validateRollbackParams(conf *Conf, ledgerID string, targetBlockNum uint64) error {
+	logger.Infof("Validating the rollback parameters: ledgerID [%s], block number [%d]", ledgerID, targetBlockNum)
+	exists, _, err := util.FileExists(conf.getLedgerBlockDir(ledgerID))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("ledgerID [%s] does not exist", ledgerID)
+	}
+
+	indexStoreProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: conf.getIndexDir()})
+	defer indexStoreProvider.Close()
+	mgr := &blockfileMgr{db: indexStoreProvider.GetDBHandle(ledgerID)}
+	cpInfo, err := mgr.loadCurrentInfo()
+	if err != nil {
+		return err
+	}
+	if cpInfo == nil || cpInfo.isChainEmpty {
+		return errors.Errorf("ledger [%s] does not contain any block", ledgerID)
+	}
+	if targetBlockNum >= cpInfo.lastBlockNumber {
+		return errors.Errorf("target block number [%d] should be less than the biggest block number [%d]",
+			targetBlockNum, cpInfo.lastBlockNumber)
+	}
+	return nil
+}
+
+// rollback removes all the blocks after the target block. The block index is updated first,
+// then the checkpoint info and finally the block files are truncated. If a crash happens in between,
+// the next start of the block store re-indexes the blocks present in the files and the rollback
+// can simply be retried
+func (mgr *blockfileMgr) rollback(targetBlockNum uint64) error {
+	lastBlockNum := mgr.cpInfo.lastBlockNumber
+	logger.Infof("Rolling back block store from block [%d] to block [%d]", lastBlockNum, targetBlockNum)
+
+	targetBlockLoc, err := mgr.index.getBlockLocByBlockNum(targetBlockNum)
+	if err != nil {
+		return errors.WithMessage(err, "error retrieving the location of the target block")
+	}
+	stream, err := newBlockStream(mgr.rootDir, targetBlockLoc.fileSuffixNum, int64(targetBlockLoc.offset), mgr.cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	if _, err := stream.nextBlockBytes(); err != nil {
+		return err
+	}
+	targetBlockEndOffset := int(stream.currentFileStream.currentOffset)
+
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		blockBytes, err := stream.nextBlockBytes()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			break
+		}
+		info, err := extractSerializedBlockInfo(blockBytes)
+		if err != nil {
+			return err
+		}
+		if err := mgr.addIndexDeletesToBatch(batch, info, targetBlockLoc.fileSuffixNum, targetBlockEndOffset); err != nil {
+			return err
+		}
+	}
+	batch.Put(indexCheckpointKey, encodeBlockNum(targetBlockNum))
+	if err := mgr.db.WriteBatch(batch, true); err != nil {
+		return err
+	}
+
+	cpInfo := &checkpointInfo{
+		latestFileChunkSuffixNum: targetBlockLoc.fileSuffixNum,
+		latestFileChunksize:      targetBlockEndOffset,
+		isChainEmpty:             false,
+		lastBlockNumber:          targetBlockNum,
+	}
+	if err := mgr.saveCurrentInfo(cpInfo, true); err != nil {
+		return err
+	}
+	if err := mgr.currentFileWriter.close(); err != nil {
+		return err
+	}
+	for fileNum := mgr.cpInfo.latestFileChunkSuffixNum; fileNum > targetBlockLoc.fileSuffixNum; fileNum-- {
+		filePath := deriveBlockfilePath(mgr.rootDir, fileNum)
+		logger.Debugf("Removing block file [%s]", filePath)
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "error removing block file [%s]", filePath)
+		}
+	}
+	if mgr.currentFileWriter, err = newBlockfileWriter(deriveBlockfilePath(mgr.rootDir, targetBlockLoc.fileSuffixNum)); err != nil {
+		return err
+	}
+	if err := mgr.currentFileWriter.truncateFile(targetBlockEndOffset); err != nil {
+		return err
+	}
+	mgr.updateCheckpoint(cpInfo)
+	logger.Infof("Rolled back block store to block [%d]", targetBlockNum)
+	return nil
+}
+
+// addIndexDeletesToBatch adds the deletes for all the index entries of a removed block. The txid based
+// entries are removed only if they point to a removed block, as a duplicate txid keeps pointing to the
+// location of the first occurrence of the txid
+func (mgr *blockfileMgr) addIndexDeletesToBatch(batch *leveldbhelper.UpdateBatch, info *serializedBlockInfo,
+	targetFileNum, targetBlockEndOffset int) error {
+	blockNum := info.blockHeader.Number
+	logger.Debugf("Removing index entries for block [%d]", blockNum)
+	batch.Delete(constructBlockNumKey(blockNum))
+	batch.Delete(constructBlockHashKey(info.blockHeader.Hash()))
+	for txNum, txOffset := range info.txOffsets {
+		batch.Delete(constructBlockNumTranNumKey(blockNum, uint64(txNum)))
+
+		txLoc, err := mgr.index.getTxLoc(txOffset.txID)
+		switch {
+		case err == blkstorage.ErrNotFoundInIndex || err == blkstorage.ErrAttrNotIndexed:
+			continue
+		case err != nil:
+			return err
+		}
+		if txLoc.fileSuffixNum < targetFileNum ||
+			(txLoc.fileSuffixNum == targetFileNum && txLoc.offset < targetBlockEndOffset) {
+			logger.Debugf("txid [%s] in block [%d] is a duplicate of a retained transaction. Retaining its index entries",
+				txOffset.txID, blockNum)
+			continue
+		}
+		batch.Delete(constructTxIDKey(txOffset.txID))
+		batch.Delete(constructBlockTxIDKey(txOffset.txID))
+		batch.Delete(constructTxValidationCodeIDKey(txOffset.txID))
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package fsblkstorage
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	path := testPath()
+	blocks := testutil.ConstructTestBlocks(t, 50)
+	// a small file size so that the blocks span across multiple block files
+	conf := NewConf(path, 8*1024)
+
+	env := newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	assert.True(t, blkfileMgrWrapper.blockfileMgr.cpInfo.latestFileChunkSuffixNum > 1)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	indexConfig := env.provider.indexConfig
+	height, err := GetHeight(path, "testLedger")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(50), height)
+	_, err = GetHeight(path, "missingLedger")
+	assert.EqualError(t, err, "ledgerID [missingLedger] does not exist")
+
+	assert.NoError(t, ValidateRollbackParams(path, "testLedger", 20))
+	assert.NoError(t, Rollback(path, "testLedger", 20, indexConfig))
+
+	env = newTestEnv(t, conf)
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	mgr := blkfileMgrWrapper.blockfileMgr
+	assert.Equal(t, uint64(21), mgr.getBlockchainInfo().Height)
+	blkfileMgrWrapper.testGetBlockByNumber(blocks[:21], 0)
+	blkfileMgrWrapper.testGetBlockByHash(blocks[:21])
+
+	for _, block := range blocks[21:] {
+		_, err := mgr.retrieveBlockByHash(block.Header.Hash())
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+		txEnv, err := utils.ExtractEnvelope(block, 0)
+		assert.NoError(t, err)
+		chdr, err := utils.ChannelHeader(txEnv)
+		assert.NoError(t, err)
+		_, err = mgr.retrieveTransactionByID(chdr.TxId)
+		assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+	}
+
+	// the removed blocks can be committed again
+	blkfileMgrWrapper.addBlocks(blocks[21:])
+	blkfileMgrWrapper.testGetBlockByNumber(blocks, 0)
+}
+
+func TestRollbackRetainsDuplicateTxIDEntries(t *testing.T) {
+	path := testPath()
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	blocks := append([]*common.Block{gb}, bg.NextBlockWithTxid([][]byte{[]byte("tx1")}, []string{"txid1"}))
+	blocks = append(blocks, bg.NextBlockWithTxid([][]byte{[]byte("tx2")}, []string{"txid1"}))
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(blocks)
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	assert.NoError(t, Rollback(path, "testLedger", 1, env.provider.indexConfig))
+
+	env = newTestEnv(t, NewConf(path, 0))
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, "testLedger")
+	defer blkfileMgrWrapper.close()
+	block, err := blkfileMgrWrapper.blockfileMgr.retrieveBlockByTxID("txid1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), block.Header.Number)
+}
+
+func TestValidateRollbackParams(t *testing.T) {
+	path := testPath()
+	env := newTestEnv(t, NewConf(path, 0))
+	defer env.Cleanup()
+
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testLedger")
+	blkfileMgrWrapper.addBlocks(testutil.ConstructTestBlocks(t, 10))
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	err := ValidateRollbackParams(path, "nonExistingLedger", 5)
+	assert.EqualError(t, err, "ledgerID [nonExistingLedger] does not exist")
+
+	err = ValidateRollbackParams(path, "testLedger", 9)
+	assert.EqualError(t, err, "target block number [9] should be less than the biggest block number [9]")
+
+	assert.NoError(t, ValidateRollbackParams(path, "testLedger", 8))
+}
@@ -0,0 +1,140 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	goleveldbutil "github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// CompactionConf tunes the compactions of a leveldb. A zero value keeps the
+// default of goleveldb
+type CompactionConf struct {
+	// L0Trigger is the number of tables at level-0 that triggers a compaction
+	L0Trigger int
+	// WriteL0SlowdownTrigger is the number of tables at level-0 above which the
+	// writes are slowed down to let the compaction catch up
+	WriteL0SlowdownTrigger int
+	// WriteL0PauseTrigger is the number of tables at level-0 above which the
+	// writes are paused until the compaction catches up
+	WriteL0PauseTrigger int
+	// TableSize is the size, in bytes, of the tables generated by the compactions
+	TableSize int
+	// TotalSize is the total size, in bytes, of the tables of level-1, the
+	// size of each next level being ten times larger
+	TotalSize int
+	// ManualRateLimit is the maximum number of bytes per second of the data
+	// compacted by a manual compaction. Zero compacts the db at once
+	ManualRateLimit int
+}
+
+func (c *CompactionConf) apply(dbOpts *opt.Options) {
+	if c == nil {
+		return
+	}
+	dbOpts.CompactionL0Trigger = c.L0Trigger
+	dbOpts.WriteL0SlowdownTrigger = c.WriteL0SlowdownTrigger
+	dbOpts.WriteL0PauseTrigger = c.WriteL0PauseTrigger
+	dbOpts.CompactionTableSize = c.TableSize
+	dbOpts.CompactionTotalSize = c.TotalSize
+}
+
+// openDBs registers the open dbs by path, so that they can be compacted on demand
+var openDBs = struct {
+	sync.Mutex
+	dbs map[string]*DB
+}{dbs: map[string]*DB{}}
+
+func registerDB(dbInst *DB) {
+	openDBs.Lock()
+	defer openDBs.Unlock()
+	openDBs.dbs[dbInst.conf.DBPath] = dbInst
+}
+
+func unregisterDB(dbInst *DB) {
+	openDBs.Lock()
+	defer openDBs.Unlock()
+	if openDBs.dbs[dbInst.conf.DBPath] == dbInst {
+		delete(openDBs.dbs, dbInst.conf.DBPath)
+	}
+}
+
+// OpenDBPaths returns the sorted paths of the dbs open in this process
+func OpenDBPaths() []string {
+	openDBs.Lock()
+	defer openDBs.Unlock()
+	var paths []string
+	for path := range openDBs.dbs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// CompactDB compacts the open db at the given path
+func CompactDB(path string) error {
+	openDBs.Lock()
+	dbInst := openDBs.dbs[path]
+	openDBs.Unlock()
+	if dbInst == nil {
+		return errors.Errorf("no leveldb is open at %s", path)
+	}
+	return dbInst.Compact()
+}
+
+// compactionStep is the duration in which a rate limited compaction compacts
+// at most the rate limit of bytes
+var compactionStep = time.Second
+
+// Compact compacts the whole db. When the compaction conf of the db sets a
+// manual rate limit, the key space is compacted by ranges holding about the
+// rate limit of bytes, at most one range per second, so that the compaction
+// leaves some disk IO to the commits
+func (dbInst *DB) Compact() error {
+	limit := 0
+	if dbInst.conf.Compaction != nil {
+		limit = dbInst.conf.Compaction.ManualRateLimit
+	}
+	if limit <= 0 {
+		return dbInst.compactRange(nil, nil)
+	}
+
+	itr := dbInst.GetIterator(nil, nil)
+	defer itr.Release()
+	var start []byte
+	size := 0
+	for itr.Next() {
+		size += len(itr.Key()) + len(itr.Value())
+		if size < limit {
+			continue
+		}
+		// the range includes its last key, the next range starts right after it
+		end := append(append([]byte{}, itr.Key()...), 0x00)
+		stepStart := time.Now()
+		if err := dbInst.compactRange(start, end); err != nil {
+			return err
+		}
+		start, size = end, 0
+		time.Sleep(compactionStep - time.Since(stepStart))
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrap(err, "error while iterating over leveldb to compact it")
+	}
+	return dbInst.compactRange(start, nil)
+}
+
+func (dbInst *DB) compactRange(start, end []byte) error {
+	if err := dbInst.db.CompactRange(goleveldbutil.Range{Start: start, Limit: end}); err != nil {
+		return errors.Wrapf(err, "error compacting leveldb at %s", dbInst.conf.DBPath)
+	}
+	return nil
+}
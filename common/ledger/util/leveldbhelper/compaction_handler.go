@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CompactionHandler lists the open dbs on GET requests and compacts the db
+// named by the database query parameter on POST requests, so that the
+// operators can compact the dbs when the commit load is low. The dbs are named
+// by their path relative to the root directory
+type CompactionHandler struct {
+	RootDir string
+}
+
+// CompactionStatus is the response of the compaction handler
+type CompactionStatus struct {
+	Databases []string `json:"databases,omitempty"`
+	Database  string   `json:"database,omitempty"`
+	Duration  string   `json:"duration,omitempty"`
+}
+
+func (h *CompactionHandler) names() map[string]string {
+	paths := map[string]string{}
+	for _, path := range OpenDBPaths() {
+		name, err := filepath.Rel(h.RootDir, path)
+		if err != nil || strings.HasPrefix(name, "..") {
+			continue
+		}
+		paths[filepath.ToSlash(name)] = path
+	}
+	return paths
+}
+
+// ServeHTTP lists the open dbs in JSON on GET requests, and compacts a db on POST
+// requests, responding once the compaction is done
+func (h *CompactionHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	status := &CompactionStatus{}
+	switch req.Method {
+	case http.MethodGet:
+		for name := range h.names() {
+			status.Databases = append(status.Databases, name)
+		}
+		sort.Strings(status.Databases)
+	case http.MethodPost:
+		name := req.URL.Query().Get("database")
+		if name == "" {
+			http.Error(resp, "the database query parameter is missing", http.StatusBadRequest)
+			return
+		}
+		path, ok := h.names()[name]
+		if !ok {
+			http.Error(resp, fmt.Sprintf("database %s is not open", name), http.StatusNotFound)
+			return
+		}
+		logger.Infof("Compacting database %s", name)
+		start := time.Now()
+		if err := CompactDB(path); err != nil {
+			logger.Errorf("Failed to compact database %s: %s", name, err)
+			http.Error(resp, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		status.Database = name
+		status.Duration = time.Since(start).String()
+		logger.Infof("Compacted database %s in %s", name, status.Duration)
+	default:
+		resp.Header().Set("Allow", "GET, POST")
+		http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(status); err != nil {
+		logger.Errorf("failed to encode the compaction status: %s", err)
+	}
+}
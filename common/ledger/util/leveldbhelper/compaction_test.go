@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package leveldbhelper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+func TestCompactionConf(t *testing.T) {
+	dbOpts := &opt.Options{}
+	var conf *CompactionConf
+	conf.apply(dbOpts)
+	assert.Equal(t, &opt.Options{}, dbOpts)
+
+	conf = &CompactionConf{L0Trigger: 8, WriteL0SlowdownTrigger: 16, WriteL0PauseTrigger: 24, TableSize: 4096, TotalSize: 8192}
+	conf.apply(dbOpts)
+	assert.Equal(t, 8, dbOpts.GetCompactionL0Trigger())
+	assert.Equal(t, 16, dbOpts.GetWriteL0SlowdownTrigger())
+	assert.Equal(t, 24, dbOpts.GetWriteL0PauseTrigger())
+	assert.Equal(t, 4096, dbOpts.GetCompactionTableSize(0))
+	assert.Equal(t, int64(8192), dbOpts.GetCompactionTotalSize(0))
+}
+
+func TestCompact(t *testing.T) {
+	defer func(step time.Duration) { compactionStep = step }(compactionStep)
+	compactionStep = time.Millisecond
+
+	for _, rateLimit := range []int{0, 100} {
+		env := newTestDBEnv(t, testDBPath)
+		db := env.db
+		db.conf.Compaction = &CompactionConf{ManualRateLimit: rateLimit}
+		db.Open()
+		for i := 0; i < 100; i++ {
+			require.NoError(t, db.Put([]byte(fmt.Sprintf("key%03d", i)), []byte("value"), false))
+		}
+		for i := 0; i < 50; i++ {
+			require.NoError(t, db.Delete([]byte(fmt.Sprintf("key%03d", i)), false))
+		}
+
+		assert.Equal(t, []string{testDBPath}, OpenDBPaths())
+		assert.NoError(t, CompactDB(testDBPath))
+		value, err := db.Get([]byte("key075"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("value"), value)
+		itr := db.GetIterator(nil, nil)
+		count := 0
+		for itr.Next() {
+			count++
+		}
+		itr.Release()
+		assert.Equal(t, 50, count)
+
+		env.cleanup()
+		assert.Empty(t, OpenDBPaths())
+		assert.EqualError(t, CompactDB(testDBPath), "no leveldb is open at "+testDBPath)
+	}
+}
+
+func TestCompactionHandler(t *testing.T) {
+	env := newTestDBEnv(t, filepath.Join(testDBPath, "stateLeveldb"))
+	defer env.cleanup()
+	env.db.Open()
+	handler := &CompactionHandler{RootDir: testDBPath}
+
+	serve := func(method, target string) (*httptest.ResponseRecorder, *CompactionStatus) {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(method, target, nil))
+		status := &CompactionStatus{}
+		if resp.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), status))
+		}
+		return resp, status
+	}
+
+	resp, status := serve(http.MethodGet, "/ledger/compaction")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, &CompactionStatus{Databases: []string{"stateLeveldb"}}, status)
+
+	resp, status = serve(http.MethodPost, "/ledger/compaction?database=stateLeveldb")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "stateLeveldb", status.Database)
+	assert.NotEmpty(t, status.Duration)
+
+	resp, _ = serve(http.MethodPost, "/ledger/compaction")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, "the database query parameter is missing\n", resp.Body.String())
+
+	resp, _ = serve(http.MethodPost, "/ledger/compaction?database=historyLeveldb")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "database historyLeveldb is not open\n", resp.Body.String())
+
+	resp, _ = serve(http.MethodDelete, "/ledger/compaction")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "GET, POST", resp.Header().Get("Allow"))
+}
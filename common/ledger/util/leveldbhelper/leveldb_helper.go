@@ -30,7 +30,8 @@ const (
 
 // Conf configuration for `DB`
 type Conf struct {
-	DBPath string
+	DBPath     string
+	Compaction *CompactionConf
 }
 
 // DB - a wrapper on an actual store
@@ -68,6 +69,7 @@ func (dbInst *DB) Open() {
 		return
 	}
 	dbOpts := &opt.Options{}
+	dbInst.conf.Compaction.apply(dbOpts)
 	dbPath := dbInst.conf.DBPath
 	var err error
 	var dirEmpty bool
@@ -79,6 +81,7 @@ func (dbInst *DB) Open() {
 		panic(fmt.Sprintf("Error opening leveldb: %s", err))
 	}
 	dbInst.dbState = opened
+	registerDB(dbInst)
 }
 
 // Close closes the underlying db
@@ -88,6 +91,7 @@ func (dbInst *DB) Close() {
 	if dbInst.dbState == closed {
 		return
 	}
+	unregisterDB(dbInst)
 	if err := dbInst.db.Close(); err != nil {
 		logger.Errorf("Error closing leveldb: %s", err)
 	}
@@ -143,6 +147,26 @@ func (dbInst *DB) GetIterator(startKey []byte, endKey []byte) iterator.Iterator
 	return dbInst.db.NewIterator(&goleveldbutil.Range{Start: startKey, Limit: endKey}, dbInst.readOpts)
 }
 
+// GetSnapshot returns a read-only snapshot of the current state of the db, unaffected by the later writes.
+// The snapshot should be released after the use
+func (dbInst *DB) GetSnapshot() (*leveldb.Snapshot, error) {
+	snapshot, err := dbInst.db.GetSnapshot()
+	if err != nil {
+		return nil, errors.Wrap(err, "error taking leveldb snapshot")
+	}
+	return snapshot, nil
+}
+
+// ApproximateSize returns the approximate disk space used by the keys in the range [startKey, endKey), in bytes.
+// The keys not yet flushed from the memory table to the disk are not accounted
+func (dbInst *DB) ApproximateSize(startKey []byte, endKey []byte) (int64, error) {
+	sizes, err := dbInst.db.SizeOf([]goleveldbutil.Range{{Start: startKey, Limit: endKey}})
+	if err != nil {
+		return 0, errors.Wrap(err, "error computing leveldb size")
+	}
+	return sizes.Sum(), nil
+}
+
 // WriteBatch writes a batch
 func (dbInst *DB) WriteBatch(batch *leveldb.Batch, sync bool) error {
 	wo := dbInst.writeOptsNoSync
@@ -20,13 +20,18 @@ import (
 	"bytes"
 	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
+	goleveldbutil "github.com/syndtr/goleveldb/leveldb/util"
 )
 
 var dbNameKeySep = []byte{0x00}
 var lastKeyIndicator = byte(0x01)
 
+// deleteAllBatchSize is the number of deletes that are written in one batch by `DeleteAll`
+const deleteAllBatchSize = 1000
+
 // Provider enables to use a single leveldb as multiple logical leveldbs
 type Provider struct {
 	db        *DB
@@ -113,6 +118,80 @@ func (h *DBHandle) GetIterator(startKey []byte, endKey []byte) *Iterator {
 	return &Iterator{h.db.GetIterator(sKey, eKey)}
 }
 
+// GetSnapshot returns a read-only snapshot of the current state of the named db, unaffected by the later writes.
+// The snapshot should be released after the use
+func (h *DBHandle) GetSnapshot() (*Snapshot, error) {
+	snapshot, err := h.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &Snapshot{h.dbName, snapshot}, nil
+}
+
+// ApproximateSize returns the approximate disk space used by the keys of this named db, in bytes
+func (h *DBHandle) ApproximateSize() (int64, error) {
+	sKey := constructLevelKey(h.dbName, nil)
+	eKey := constructLevelKey(h.dbName, nil)
+	eKey[len(eKey)-1] = lastKeyIndicator
+	return h.db.ApproximateSize(sKey, eKey)
+}
+
+// DeleteAll deletes all the keys that belong to this named db
+func (h *DBHandle) DeleteAll() error {
+	itr := h.GetIterator(nil, nil)
+	defer itr.Release()
+	levelBatch := &leveldb.Batch{}
+	for itr.Next() {
+		levelBatch.Delete(constructLevelKey(h.dbName, itr.Key()))
+		if levelBatch.Len() == deleteAllBatchSize {
+			if err := h.db.WriteBatch(levelBatch, true); err != nil {
+				return err
+			}
+			levelBatch.Reset()
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return errors.Wrapf(err, "error while iterating over db [%s]", h.dbName)
+	}
+	return h.db.WriteBatch(levelBatch, true)
+}
+
+// Snapshot is a read-only view of a named db as of the time the snapshot was taken
+type Snapshot struct {
+	dbName   string
+	snapshot *leveldb.Snapshot
+}
+
+// Get returns the value for the given key as of the snapshot
+func (s *Snapshot) Get(key []byte) ([]byte, error) {
+	levelKey := constructLevelKey(s.dbName, key)
+	value, err := s.snapshot.Get(levelKey, nil)
+	if err == leveldb.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "error retrieving leveldb key [%#v] from snapshot", levelKey)
+	}
+	return value, nil
+}
+
+// GetIterator gets an handle to an iterator over the snapshot. The iterator should be released after the use.
+// The startKey and the endKey are interpreted as in the function `DBHandle.GetIterator`
+func (s *Snapshot) GetIterator(startKey []byte, endKey []byte) *Iterator {
+	sKey := constructLevelKey(s.dbName, startKey)
+	eKey := constructLevelKey(s.dbName, endKey)
+	if endKey == nil {
+		// replace the last byte 'dbNameKeySep' by 'lastKeyIndicator'
+		eKey[len(eKey)-1] = lastKeyIndicator
+	}
+	return &Iterator{s.snapshot.NewIterator(&goleveldbutil.Range{Start: sKey, Limit: eKey}, nil)}
+}
+
+// Release releases the snapshot. The iterators obtained from the snapshot remain usable until released
+func (s *Snapshot) Release() {
+	s.snapshot.Release()
+}
+
 // UpdateBatch encloses the details of multiple `updates`
 type UpdateBatch struct {
 	KVs map[string][]byte
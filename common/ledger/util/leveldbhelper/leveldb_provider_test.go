@@ -18,6 +18,7 @@ package leveldbhelper
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,6 +55,85 @@ func TestIterator(t *testing.T) {
 	checkItrResults(t, itr3, createTestKeys(0, 19), createTestValues("db2", 0, 19))
 }
 
+func TestSnapshot(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	for i := 0; i < 10; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	snapshot, err := db1.GetSnapshot()
+	assert.NoError(t, err)
+	db1.Put([]byte(createTestKey(0)), []byte("updated"), false)
+	db1.Delete([]byte(createTestKey(1)), false)
+	db1.Put([]byte(createTestKey(10)), []byte(createTestValue("db1", 10)), false)
+
+	val, err := snapshot.Get([]byte(createTestKey(0)))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(createTestValue("db1", 0)), val)
+	val, err = snapshot.Get([]byte(createTestKey(10)))
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+
+	itr := snapshot.GetIterator(nil, nil)
+	defer itr.Release()
+	snapshot.Release()
+	checkItrResults(t, itr, createTestKeys(0, 9), createTestValues("db1", 0, 9))
+
+	val, err = db1.Get([]byte(createTestKey(0)))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("updated"), val)
+}
+
+func TestApproximateSize(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+
+	db1 := env.provider.GetDBHandle("db1")
+	for i := 0; i < 100; i++ {
+		value := make([]byte, 10000)
+		rand.Read(value)
+		db1.Put([]byte(createTestKey(i)), value, false)
+	}
+	// the keys in the memory table are flushed to the disk when the db is reopened
+	env.provider.Close()
+	env.provider = NewProvider(&Conf{DBPath: testDBPath})
+
+	size, err := env.provider.GetDBHandle("db1").ApproximateSize()
+	assert.NoError(t, err)
+	assert.True(t, size > 100*10000/2, "unexpected size %d", size)
+	size, err = env.provider.GetDBHandle("db2").ApproximateSize()
+	assert.NoError(t, err)
+	assert.Zero(t, size)
+}
+
+func TestDeleteAll(t *testing.T) {
+	env := newTestProviderEnv(t, testDBPath)
+	defer env.cleanup()
+	p := env.provider
+
+	db1 := p.GetDBHandle("db1")
+	db2 := p.GetDBHandle("db2")
+	for i := 0; i < deleteAllBatchSize+10; i++ {
+		db1.Put([]byte(createTestKey(i)), []byte(createTestValue("db1", i)), false)
+		db2.Put([]byte(createTestKey(i)), []byte(createTestValue("db2", i)), false)
+	}
+
+	assert.NoError(t, db1.DeleteAll())
+	itr1 := db1.GetIterator(nil, nil)
+	defer itr1.Release()
+	assert.False(t, itr1.Next())
+
+	itr2 := db2.GetIterator(nil, nil)
+	defer itr2.Release()
+	checkItrResults(t, itr2, createTestKeys(0, deleteAllBatchSize+9), createTestValues("db2", 0, deleteAllBatchSize+9))
+}
+
 func TestBatchedUpdates(t *testing.T) {
 	env := newTestProviderEnv(t, testDBPath)
 	defer env.cleanup()
@@ -8,11 +8,16 @@ package config
 
 import (
 	"github.com/hyperledger/fabric/common/channelconfig"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 type MockApplication struct {
-	CapabilitiesRv channelconfig.ApplicationCapabilities
-	Acls           map[string]string
+	CapabilitiesRv            channelconfig.ApplicationCapabilities
+	Acls                      map[string]string
+	DeliverLimits             map[string]*pb.DeliverLimits
+	CustomGroupsRv            map[string]channelconfig.CustomGroup
+	ResourceBudgetRv          *pb.ResourceBudget
+	SystemChaincodePoliciesRv *pb.SystemChaincodePolicies
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
@@ -23,6 +28,18 @@ func (m *MockApplication) Capabilities() channelconfig.ApplicationCapabilities {
 	return m.CapabilitiesRv
 }
 
+func (m *MockApplication) CustomGroups() map[string]channelconfig.CustomGroup {
+	return m.CustomGroupsRv
+}
+
+func (m *MockApplication) ResourceBudget() *pb.ResourceBudget {
+	return m.ResourceBudgetRv
+}
+
+func (m *MockApplication) SystemChaincodePolicies() *pb.SystemChaincodePolicies {
+	return m.SystemChaincodePoliciesRv
+}
+
 func (m *MockApplication) PolicyRefForAPI(apiName string) string {
 	if m.Acls == nil {
 		return ""
@@ -30,6 +47,10 @@ func (m *MockApplication) PolicyRefForAPI(apiName string) string {
 	return m.Acls[apiName]
 }
 
+func (m *MockApplication) DeliverLimitsForAPI(apiName string) *pb.DeliverLimits {
+	return m.DeliverLimits[apiName]
+}
+
 // Returns the mock which itself is a provider
 func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
 	return m
@@ -47,6 +68,18 @@ type MockApplicationCapabilities struct {
 	KeyLevelEndorsementRv        bool
 	V1_3ValidationRv             bool
 	FabTokenRv                   bool
+	CommutativeUpdatesRv         bool
+	CommitHashRv                 bool
+	ChaincodeMigrationRv         bool
+	TxExpirationRv               bool
+	ChaincodeBatchesRv           bool
+	ScheduledTransactionsRv      bool
+	CustomConfigGroupsRv         bool
+	ResourceBudgetsRv            bool
+	ChaincodeContractsRv         bool
+	BinaryChaincodesRv           bool
+	SystemChaincodePoliciesRv    bool
+	RangeDeletesRv               bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -92,3 +125,51 @@ func (mac *MockApplicationCapabilities) V1_3Validation() bool {
 func (mac *MockApplicationCapabilities) FabToken() bool {
 	return mac.FabTokenRv
 }
+
+func (mac *MockApplicationCapabilities) CommutativeUpdates() bool {
+	return mac.CommutativeUpdatesRv
+}
+
+func (mac *MockApplicationCapabilities) CommitHash() bool {
+	return mac.CommitHashRv
+}
+
+func (mac *MockApplicationCapabilities) ChaincodeMigration() bool {
+	return mac.ChaincodeMigrationRv
+}
+
+func (mac *MockApplicationCapabilities) TxExpiration() bool {
+	return mac.TxExpirationRv
+}
+
+func (mac *MockApplicationCapabilities) ChaincodeBatches() bool {
+	return mac.ChaincodeBatchesRv
+}
+
+func (mac *MockApplicationCapabilities) ScheduledTransactions() bool {
+	return mac.ScheduledTransactionsRv
+}
+
+func (mac *MockApplicationCapabilities) CustomConfigGroups() bool {
+	return mac.CustomConfigGroupsRv
+}
+
+func (mac *MockApplicationCapabilities) ResourceBudgets() bool {
+	return mac.ResourceBudgetsRv
+}
+
+func (mac *MockApplicationCapabilities) ChaincodeContracts() bool {
+	return mac.ChaincodeContractsRv
+}
+
+func (mac *MockApplicationCapabilities) BinaryChaincodes() bool {
+	return mac.BinaryChaincodesRv
+}
+
+func (mac *MockApplicationCapabilities) SystemChaincodePolicies() bool {
+	return mac.SystemChaincodePoliciesRv
+}
+
+func (mac *MockApplicationCapabilities) RangeDeletes() bool {
+	return mac.RangeDeletesRv
+}
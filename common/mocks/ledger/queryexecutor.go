@@ -75,6 +75,10 @@ func (m *MockQueryExecutor) GetPrivateDataMetadataByHash(namespace, collection s
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	return nil, nil
+}
+
 func (m *MockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	return nil, nil
 }
@@ -52,6 +52,7 @@ type MocksccProviderImpl struct {
 	PolicyManagerRv       policies.Manager
 	PolicyManagerBool     bool
 	SysCCMap              map[string]bool
+	RequiresPolicyMap     map[string]bool
 }
 
 func (c *MocksccProviderImpl) IsSysCC(name string) bool {
@@ -70,6 +71,10 @@ func (c *MocksccProviderImpl) IsSysCCAndNotInvokableExternal(name string) bool {
 	return (name == "escc") || (name == "vscc") || (name == "notext")
 }
 
+func (c *MocksccProviderImpl) IsSysCCAndRequiresEndorsementPolicy(name string) bool {
+	return c.RequiresPolicyMap[name]
+}
+
 func (c *MocksccProviderImpl) GetQueryExecutorForLedger(cid string) (ledger.QueryExecutor, error) {
 	return c.Qe, c.QErr
 }
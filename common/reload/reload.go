@@ -0,0 +1,272 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reload
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+var logger = flogging.MustGetLogger("reload")
+
+// Loader reads the local configuration of the process again, and returns its
+// settings by key
+type Loader func() (map[string]interface{}, error)
+
+// Reloader applies the reloadable subset of the local configuration of a process,
+// such as the logging spec, the limits, the timeouts and the cache sizes, without
+// restarting it. A reload reads the configuration again and applies the settings
+// which changed since the last reload with the functions registered for them. The
+// other settings which changed are reported as requiring a restart
+type Reloader struct {
+	load Loader
+
+	mutex    sync.Mutex
+	settings map[string]interface{}
+	appliers []*applier
+}
+
+type applier struct {
+	keys  []string
+	apply func() error
+}
+
+// Result is the result of a reload
+type Result struct {
+	// Applied are the keys of the settings which changed and were applied
+	Applied []string `json:"applied"`
+	// RequireRestart are the keys of the settings which changed but are only
+	// applied when the process restarts
+	RequireRestart []string `json:"require_restart"`
+	// Errors are the errors of the settings which could not be applied, which
+	// keep their previous value
+	Errors []string `json:"errors"`
+}
+
+// New creates a Reloader of the configuration read by the given loader, the
+// current settings being the ones it reads when the Reloader is created
+func New(load Loader) (*Reloader, error) {
+	settings, err := load()
+	if err != nil {
+		return nil, err
+	}
+	return &Reloader{load: load, settings: normalize(settings)}, nil
+}
+
+// Register registers the function applying the settings with the given keys,
+// called upon a reload when any of the settings changed. A key also covers the
+// settings nested under it. The keys are not case sensitive
+func (r *Reloader) Register(apply func() error, keys ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i := range keys {
+		keys[i] = strings.ToLower(keys[i])
+	}
+	r.appliers = append(r.appliers, &applier{keys: keys, apply: apply})
+}
+
+// Reload reads the configuration again and applies the settings which changed.
+// An error is returned if the configuration cannot be read, in which case no
+// setting is applied
+func (r *Reloader) Reload() (*Result, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	settings, err := r.load()
+	if err != nil {
+		logger.Errorf("Failed to reload the configuration: %s", err)
+		return nil, errors.WithMessage(err, "failed to reload the configuration")
+	}
+	settings = normalize(settings)
+
+	changed := changedKeys(r.settings, settings)
+	result := &Result{Applied: []string{}, RequireRestart: []string{}, Errors: []string{}}
+	reloadable := map[string]bool{}
+	for _, a := range r.appliers {
+		keys := a.changed(changed)
+		if len(keys) == 0 {
+			continue
+		}
+		for _, key := range keys {
+			reloadable[key] = true
+		}
+		if err := a.apply(); err != nil {
+			logger.Errorf("Failed to apply the settings %s: %s", strings.Join(keys, ", "), err)
+			result.Errors = append(result.Errors, err.Error())
+			// the settings keep their previous value, so that they are
+			// applied again by the next reload
+			for _, key := range keys {
+				restore(settings, r.settings, key)
+			}
+			continue
+		}
+		logger.Infof("Applied the settings %s", strings.Join(keys, ", "))
+		result.Applied = append(result.Applied, keys...)
+	}
+	for _, key := range changed {
+		if !reloadable[key] {
+			logger.Warningf("The setting %s changed, it will be applied when the process restarts", key)
+			result.RequireRestart = append(result.RequireRestart, key)
+		}
+	}
+	sort.Strings(result.Applied)
+
+	r.settings = settings
+	return result, nil
+}
+
+// changed returns the given changed keys which the applier covers
+func (a *applier) changed(changed []string) []string {
+	var keys []string
+	for _, key := range changed {
+		for _, prefix := range a.keys {
+			if key == prefix || strings.HasPrefix(key, prefix+".") {
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+	return keys
+}
+
+// ServeHTTP reloads the configuration on POST requests, and reports the result
+// of the reload in JSON
+func (r *Reloader) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.Header().Set("Allow", "POST")
+		http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	result, err := r.Reload()
+	if err != nil {
+		http.Error(resp, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(result); err != nil {
+		logger.Errorf("failed to encode the result of the reload: %s", err)
+	}
+}
+
+// changedKeys returns the sorted keys whose values differ between the given
+// settings, including the keys present in only one of them
+func changedKeys(old, new map[string]interface{}) []string {
+	var changed []string
+	for key, value := range new {
+		if oldValue, ok := old[key]; !ok || !reflect.DeepEqual(oldValue, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range old {
+		if _, ok := new[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func restore(settings, previous map[string]interface{}, key string) {
+	if value, ok := previous[key]; ok {
+		settings[key] = value
+		return
+	}
+	delete(settings, key)
+}
+
+func normalize(settings map[string]interface{}) map[string]interface{} {
+	normalized := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		normalized[strings.ToLower(key)] = value
+	}
+	return normalized
+}
+
+// ViperLoader returns a Loader which reads the configuration file of the global
+// viper instance again, if the configuration was read from a file. The settings
+// overridden by environment variables or set explicitly keep their values
+func ViperLoader() Loader {
+	return func() (map[string]interface{}, error) {
+		if viper.ConfigFileUsed() != "" {
+			if err := viper.ReadInConfig(); err != nil {
+				return nil, err
+			}
+		}
+		var keys []string
+		for _, key := range viper.AllKeys() {
+			keys = appendLeafKeys(keys, key, viper.Get(key))
+		}
+		settings := make(map[string]interface{}, len(keys))
+		for _, key := range keys {
+			// the nested settings are read by their full key, so that the
+			// environment variables overriding them are taken into account
+			settings[strings.ToLower(key)] = viper.Get(key)
+		}
+		return settings, nil
+	}
+}
+
+// appendLeafKeys appends the keys of the settings nested under the given key,
+// or the key itself if its value is not a map
+func appendLeafKeys(keys []string, key string, value interface{}) []string {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		for k, v := range m {
+			keys = appendLeafKeys(keys, key+"."+k, v)
+		}
+	case map[interface{}]interface{}:
+		for k, v := range m {
+			keys = appendLeafKeys(keys, key+"."+fmt.Sprint(k), v)
+		}
+	default:
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// StructSettings returns the settings of the given configuration struct by key,
+// the key of a field being the path of its name through the nested structs,
+// such as General.Broadcast.MaxClockSkew
+func StructSettings(config interface{}) map[string]interface{} {
+	settings := map[string]interface{}{}
+	flatten(settings, "", reflect.ValueOf(config))
+	return settings
+}
+
+func flatten(settings map[string]interface{}, prefix string, v reflect.Value) {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		settings[prefix] = v.Interface()
+		return
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		key := field.Name
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flatten(settings, key, v.Field(i))
+	}
+}
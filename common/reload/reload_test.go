@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reload
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type settingsLoader struct {
+	settings map[string]interface{}
+	err      error
+}
+
+func (l *settingsLoader) load() (map[string]interface{}, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	settings := map[string]interface{}{}
+	for key, value := range l.settings {
+		settings[key] = value
+	}
+	return settings, nil
+}
+
+func TestNew(t *testing.T) {
+	_, err := New((&settingsLoader{err: errors.New("boom")}).load)
+	assert.EqualError(t, err, "boom")
+}
+
+func TestReload(t *testing.T) {
+	loader := &settingsLoader{settings: map[string]interface{}{
+		"log.spec":         "info",
+		"cache.size":       10,
+		"cache.ttl":        time.Minute,
+		"listen.address":   "0.0.0.0:7051",
+		"limits.maxMemory": 100,
+	}}
+	r, err := New(loader.load)
+	require.NoError(t, err)
+
+	var logApplied, cacheApplied int
+	var limitsErr error
+	r.Register(func() error { logApplied++; return nil }, "Log.Spec")
+	r.Register(func() error { cacheApplied++; return nil }, "cache")
+	r.Register(func() error { return limitsErr }, "limits")
+
+	// nothing changed
+	result, err := r.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Applied: []string{}, RequireRestart: []string{}, Errors: []string{}}, result)
+	assert.Equal(t, 0, logApplied+cacheApplied)
+
+	loader.settings["log.spec"] = "debug"
+	loader.settings["cache.ttl"] = time.Hour
+	loader.settings["listen.address"] = "0.0.0.0:8051"
+	loader.settings["tls.enabled"] = true
+	result, err = r.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cache.ttl", "log.spec"}, result.Applied)
+	assert.Equal(t, []string{"listen.address", "tls.enabled"}, result.RequireRestart)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, logApplied)
+	assert.Equal(t, 1, cacheApplied)
+
+	// the settings which failed to be applied are applied again by the next reload
+	loader.settings["limits.maxmemory"] = 50
+	delete(loader.settings, "limits.maxMemory")
+	limitsErr = errors.New("invalid limits")
+	result, err = r.Reload()
+	require.NoError(t, err)
+	assert.Empty(t, result.Applied)
+	assert.Empty(t, result.RequireRestart)
+	assert.Equal(t, []string{"invalid limits"}, result.Errors)
+
+	limitsErr = nil
+	result, err = r.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"limits.maxmemory"}, result.Applied)
+	assert.Empty(t, result.Errors)
+
+	// no setting is applied when the configuration cannot be read
+	loader.err = errors.New("boom")
+	_, err = r.Reload()
+	assert.EqualError(t, err, "failed to reload the configuration: boom")
+	assert.Equal(t, 1, logApplied)
+}
+
+func TestServeHTTP(t *testing.T) {
+	loader := &settingsLoader{settings: map[string]interface{}{"log.spec": "info"}}
+	r, err := New(loader.load)
+	require.NoError(t, err)
+	r.Register(func() error { return nil }, "log.spec")
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/reload", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "POST", resp.Header().Get("Allow"))
+
+	loader.settings["log.spec"] = "debug"
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+	result := &Result{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), result))
+	assert.Equal(t, &Result{Applied: []string{"log.spec"}, RequireRestart: []string{}, Errors: []string{}}, result)
+
+	loader.err = errors.New("boom")
+	resp = httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodPost, "/reload", nil))
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, resp.Body.String(), "failed to reload the configuration: boom")
+}
+
+func TestViperLoader(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.id", "peer0")
+	load := ViperLoader()
+	// the configuration was not read from a file
+	settings, err := load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"peer.id": "peer0"}, settings)
+
+	tempDir, err := ioutil.TempDir("", "reload")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	configFile := filepath.Join(tempDir, "core.yaml")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte("peer:\n  queryCache:\n    ttl: 1m\n"), 0644))
+	viper.SetConfigFile(configFile)
+	settings, err = load()
+	require.NoError(t, err)
+	assert.Equal(t, "1m", settings["peer.querycache.ttl"])
+	assert.Equal(t, "peer0", settings["peer.id"])
+
+	require.NoError(t, ioutil.WriteFile(configFile, []byte("peer:\n  queryCache:\n    ttl: 2m\n"), 0644))
+	settings, err = load()
+	require.NoError(t, err)
+	assert.Equal(t, "2m", settings["peer.querycache.ttl"])
+	assert.Equal(t, 2*time.Minute, viper.GetDuration("peer.queryCache.ttl"))
+
+	viper.SetConfigFile(filepath.Join(tempDir, "missing.yaml"))
+	_, err = load()
+	assert.Error(t, err)
+}
+
+func TestStructSettings(t *testing.T) {
+	type broadcast struct {
+		MaxMessageBytes uint32
+		MaxClockSkew    time.Duration
+	}
+	type general struct {
+		Broadcast  broadcast
+		Profile    *broadcast
+		Interfaces []string
+		secret     string
+	}
+	settings := StructSettings(&struct{ General general }{
+		General: general{
+			Broadcast:  broadcast{MaxMessageBytes: 10, MaxClockSkew: time.Minute},
+			Interfaces: []string{"eth0"},
+			secret:     "secret",
+		},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"General.Broadcast.MaxMessageBytes": uint32(10),
+		"General.Broadcast.MaxClockSkew":    time.Minute,
+		"General.Interfaces":                []string{"eth0"},
+	}, settings)
+}
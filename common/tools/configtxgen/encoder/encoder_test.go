@@ -8,6 +8,7 @@ package encoder_test
 
 import (
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/protos/msp"
 	ab "github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -329,6 +331,27 @@ var _ = Describe("Encoder", func() {
 			Expect(cg.Values["Capabilities"]).NotTo(BeNil())
 		})
 
+		Context("when a resource budget is set", func() {
+			BeforeEach(func() {
+				conf.ResourceBudget = &genesisconfig.ResourceBudget{
+					MaxSimulationTime: 2 * time.Second,
+					MaxStateWrites:    100,
+				}
+			})
+
+			It("adds the resource budget", func() {
+				cg, err := encoder.NewApplicationGroup(conf)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cg.Values["ResourceBudget"]).NotTo(BeNil())
+				budget := &pb.ResourceBudget{}
+				err = proto.Unmarshal(cg.Values["ResourceBudget"].Value, budget)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(budget.MaxSimulationTimeMs).To(Equal(uint64(2000)))
+				Expect(budget.MaxStateReads).To(BeZero())
+				Expect(budget.MaxStateWrites).To(Equal(uint64(100)))
+			})
+		})
+
 		Context("when the policies are ommitted", func() {
 			BeforeEach(func() {
 				conf.Policies = nil
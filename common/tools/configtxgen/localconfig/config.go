@@ -113,11 +113,21 @@ type Consortium struct {
 // Application encodes the application-level configuration needed in config
 // transactions.
 type Application struct {
-	Organizations []*Organization    `yaml:"Organizations"`
-	Capabilities  map[string]bool    `yaml:"Capabilities"`
-	Resources     *Resources         `yaml:"Resources"`
-	Policies      map[string]*Policy `yaml:"Policies"`
-	ACLs          map[string]string  `yaml:"ACLs"`
+	Organizations  []*Organization    `yaml:"Organizations"`
+	Capabilities   map[string]bool    `yaml:"Capabilities"`
+	Resources      *Resources         `yaml:"Resources"`
+	Policies       map[string]*Policy `yaml:"Policies"`
+	ACLs           map[string]string  `yaml:"ACLs"`
+	ResourceBudget *ResourceBudget    `yaml:"ResourceBudget"`
+}
+
+// ResourceBudget encodes the budget of the resources used by the simulation of each
+// proposal to the application chaincodes, the limits left to zero being unlimited
+type ResourceBudget struct {
+	MaxSimulationTime time.Duration `yaml:"MaxSimulationTime"`
+	MaxStateReads     uint64        `yaml:"MaxStateReads"`
+	MaxStateWrites    uint64        `yaml:"MaxStateWrites"`
+	MaxBytesProduced  uint64        `yaml:"MaxBytesProduced"`
 }
 
 // Resources encodes the application-level resources configuration needed to
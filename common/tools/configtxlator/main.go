@@ -8,6 +8,7 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -20,11 +21,12 @@ import (
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/orderer/consensus/migration/kafka2raft"
 	_ "github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common" // Import these to register the proto types
 	_ "github.com/hyperledger/fabric/protos/msp"
 	_ "github.com/hyperledger/fabric/protos/orderer"
-	_ "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
 	_ "github.com/hyperledger/fabric/protos/peer"
 
 	"github.com/gorilla/handlers"
@@ -57,6 +59,17 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	migrateConfig         = app.Command("migrate_config", "Takes the latest config block of a channel and computes the config update of a step of its migration from Kafka to Raft.")
+	migrateConfigStep     = migrateConfig.Flag("step", "The step of the migration: 'start' and 'commit' on the system channel, 'context' on the standard channels.").Required().Enum(string(kafka2raft.StepStart), string(kafka2raft.StepContext), string(kafka2raft.StepCommit))
+	migrateConfigSource   = migrateConfig.Flag("input", "A file containing the latest config block of the channel.").Default(os.Stdin.Name()).File()
+	migrateConfigMetadata = migrateConfig.Flag("metadata", "A file containing the Raft metadata of the channel as a JSON etcdraft.ConfigMetadata, required by the 'context' and 'commit' steps.").File()
+	migrateConfigSystem   = migrateConfig.Flag("system_block", "A file containing the config block of the system channel which started the migration, required by the 'context' and 'commit' steps.").File()
+	migrateConfigDest     = migrateConfig.Flag("output", "A file to write the config update to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	verifyMigration         = app.Command("verify_migration", "Takes the latest config blocks of the channels of an ordering service and reports the progress of their migration from Kafka to Raft.")
+	verifyMigrationSystem   = verifyMigration.Flag("system_block", "A file containing the latest config block of the system channel.").Required().File()
+	verifyMigrationChannels = verifyMigration.Flag("channel_block", "A file containing the latest config block of a standard channel (may be repeated).").ExistingFiles()
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -91,6 +104,22 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case migrateConfig.FullCommand():
+		defer (*migrateConfigSource).Close()
+		defer (*migrateConfigDest).Close()
+		err := migrateConfigBlock(kafka2raft.Step(*migrateConfigStep), *migrateConfigSource, *migrateConfigMetadata, *migrateConfigSystem, *migrateConfigDest)
+		if err != nil {
+			app.Fatalf("Error migrating config: %s", err)
+		}
+	case verifyMigration.FullCommand():
+		defer (*verifyMigrationSystem).Close()
+		ok, err := verifyMigrationBlocks(*verifyMigrationSystem, *verifyMigrationChannels, os.Stdout)
+		if err != nil {
+			app.Fatalf("Error verifying migration: %s", err)
+		}
+		if !ok {
+			os.Exit(1)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -218,3 +247,115 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func readBlock(input *os.File) (*cb.Block, error) {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading %s", input.Name())
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(in, block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling block of %s", input.Name())
+	}
+
+	return block, nil
+}
+
+func migrateConfigBlock(step kafka2raft.Step, input, metadataInput, systemInput, output *os.File) error {
+	block, err := readBlock(input)
+	if err != nil {
+		return err
+	}
+
+	origConf, channelID, err := kafka2raft.ConfigFromBlock(block)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config block")
+	}
+
+	var metadata *etcdraft.ConfigMetadata
+	var context uint64
+	if step != kafka2raft.StepStart {
+		if metadataInput == nil || systemInput == nil {
+			return errors.Errorf("the %s step requires the Raft metadata and the config block of the system channel which started the migration", step)
+		}
+		defer metadataInput.Close()
+		defer systemInput.Close()
+
+		metadata = &etcdraft.ConfigMetadata{}
+		err = protolator.DeepUnmarshalJSON(metadataInput, metadata)
+		if err != nil {
+			return errors.Wrapf(err, "error decoding Raft metadata")
+		}
+
+		systemBlock, err := readBlock(systemInput)
+		if err != nil {
+			return err
+		}
+		context, err = kafka2raft.MigrationContext(systemBlock)
+		if err != nil {
+			return errors.Wrapf(err, "error reading migration context")
+		}
+	}
+
+	updtConf, err := kafka2raft.MigrateConfig(origConf, step, metadata, context)
+	if err != nil {
+		return errors.Wrapf(err, "error migrating config of channel %s", channelID)
+	}
+
+	cu, err := update.Compute(origConf, updtConf)
+	if err != nil {
+		return errors.Wrapf(err, "error computing config update")
+	}
+
+	cu.ChannelId = channelID
+
+	outBytes, err := proto.Marshal(cu)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling computed config update")
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config update to output")
+	}
+
+	return nil
+}
+
+func verifyMigrationBlocks(systemInput *os.File, channelInputs []string, output io.Writer) (bool, error) {
+	systemBlock, err := readBlock(systemInput)
+	if err != nil {
+		return false, err
+	}
+
+	var channelBlocks []*cb.Block
+	for _, channelInput := range channelInputs {
+		in, err := ioutil.ReadFile(channelInput)
+		if err != nil {
+			return false, errors.Wrapf(err, "error reading %s", channelInput)
+		}
+		block := &cb.Block{}
+		err = proto.Unmarshal(in, block)
+		if err != nil {
+			return false, errors.Wrapf(err, "error unmarshaling block of %s", channelInput)
+		}
+		channelBlocks = append(channelBlocks, block)
+	}
+
+	report, err := kafka2raft.Verify(systemBlock, channelBlocks)
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Fprintf(output, "Migration phase: %s\n", report.Phase)
+	if report.Context != 0 {
+		fmt.Fprintf(output, "Migration context: %d\n", report.Context)
+	}
+	for _, problem := range report.Problems {
+		fmt.Fprintf(output, "Problem: %s\n", problem)
+	}
+
+	return report.OK(), nil
+}
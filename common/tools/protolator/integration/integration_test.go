@@ -59,6 +59,30 @@ func TestConfigUpdate(t *testing.T) {
 	})
 }
 
+func TestCustomApplicationGroup(t *testing.T) {
+	cg, err := encoder.NewChannelGroup(configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile))
+	assert.NoError(t, err)
+	cg.Groups["Application"].Groups["FeeSchedule"] = &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			"Fee": {Value: []byte("10"), ModPolicy: "/Channel/Application/Admins"},
+		},
+		ModPolicy: "/Channel/Application/Admins",
+	}
+
+	bidirectionalMarshal(t, &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(&cb.ConfigUpdate{
+			ReadSet:  cg,
+			WriteSet: cg,
+		}),
+	})
+
+	var buffer bytes.Buffer
+	assert.NoError(t, protolator.DeepMarshalJSON(&buffer, cg))
+	decoded := &cb.ConfigGroup{}
+	assert.NoError(t, protolator.DeepUnmarshalJSON(bytes.NewReader(buffer.Bytes()), decoded))
+	assert.Equal(t, []byte("10"), decoded.Groups["Application"].Groups["FeeSchedule"].Values["Fee"].Value)
+}
+
 func TestIdemix(t *testing.T) {
 	bidirectionalMarshal(t, &msp.MSPConfig{
 		Type: 1,
@@ -8,6 +8,7 @@ package aclmgmt
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
@@ -23,6 +24,29 @@ const (
 	CHANNELWRITERS = policies.ChannelApplicationWriters
 )
 
+//resourcePolicies are the default policies of the resources defined by the
+//extensions of the peer
+var resourcePolicies = struct {
+	sync.RWMutex
+	policies map[string]string
+}{policies: map[string]string{}}
+
+//RegisterResourcePolicy registers the default policy of a resource defined by an
+//extension of the peer, such as a function of a system chaincode plugin, which
+//applies when the channel config holds no ACL for the resource. The default
+//policies of the resources of the peer itself cannot be replaced
+func RegisterResourcePolicy(resName string, policyRef string) {
+	resourcePolicies.Lock()
+	defer resourcePolicies.Unlock()
+	resourcePolicies.policies[resName] = policyRef
+}
+
+func registeredResourcePolicy(resName string) string {
+	resourcePolicies.RLock()
+	defer resourcePolicies.RUnlock()
+	return resourcePolicies.policies[resName]
+}
+
 //defaultACLProvider used if resource-based ACL Provider is not provided or
 //if it does not contain a policy for the named resource
 type defaultACLProvider struct {
@@ -75,6 +99,10 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Qscc_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetBlocksByRange] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetTransactionsByBlockNumber] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetBlockStatsByRange] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Qscc_GetStateProof] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -85,11 +113,26 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.Cscc_GetConfigBlock] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Cscc_GetConfigTree] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.Cscc_SimulateConfigTreeUpdate] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Cscc_GetChannelConfig] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Cscc_GetChannelOrgs] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Cscc_GetChannelCapabilities] = CHANNELREADERS
+
+	//------------- INTEROPSCC resources -------------
+	//c resources
+	d.cResourcePolicyMap[resources.Interopscc_RegisterNetwork] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Interopscc_GetNetwork] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Interopscc_VerifyView] = CHANNELREADERS
+
+	//------------- BATCHSCC resources -------------
+	//c resources
+	d.cResourcePolicyMap[resources.Batchscc_Invoke] = CHANNELWRITERS
 
 	//---------------- non-scc resources ------------
 	//Peer resources
 	d.cResourcePolicyMap[resources.Peer_Propose] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Peer_ChaincodeToChaincode] = CHANNELWRITERS
+	d.cResourcePolicyMap[resources.Peer_GetPrivateDataHash] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.Peer_Schedule] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Issue] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_Transfer] = CHANNELWRITERS
 	d.cResourcePolicyMap[resources.Token_List] = CHANNELREADERS
@@ -104,6 +147,9 @@ func (d *defaultACLProvider) defaultPolicy(resName string, cprovider bool) strin
 	var pol string
 	if cprovider {
 		pol = d.cResourcePolicyMap[resName]
+		if pol == "" {
+			pol = registeredResourcePolicy(resName)
+		}
 	} else {
 		pol = d.pResourcePolicyMap[resName]
 	}
@@ -0,0 +1,30 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package aclmgmt
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterResourcePolicy(t *testing.T) {
+	d := &defaultACLProvider{
+		pResourcePolicyMap: map[string]string{},
+		cResourcePolicyMap: map[string]string{resources.Qscc_GetChainInfo: CHANNELREADERS},
+	}
+	assert.Equal(t, "", d.defaultPolicy("myscc/put", true))
+
+	RegisterResourcePolicy("myscc/put", CHANNELWRITERS)
+	RegisterResourcePolicy(resources.Qscc_GetChainInfo, CHANNELWRITERS)
+	assert.Equal(t, CHANNELWRITERS, d.defaultPolicy("myscc/put", true))
+	// the policies of the resources of the peer cannot be replaced
+	assert.Equal(t, CHANNELREADERS, d.defaultPolicy(resources.Qscc_GetChainInfo, true))
+	// the resources of the extensions are channel resources
+	assert.Equal(t, "", d.defaultPolicy("myscc/put", false))
+}
@@ -125,6 +125,8 @@ func (rp *aclmgmtPolicyProviderImpl) CheckACL(polName string, idinfo interface{}
 		if err != nil {
 			return err
 		}
+	case []*common.SignedData:
+		sd = idinfo.([]*common.SignedData)
 	default:
 		return InvalidIdInfo(polName)
 	}
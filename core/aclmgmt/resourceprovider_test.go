@@ -66,6 +66,10 @@ func TestPolicyBase(t *testing.T) {
 	assert.NoError(t, err)
 	err = pprov.CheckACL("pol", env)
 	assert.NoError(t, err)
+
+	sd := []*common.SignedData{{Data: []byte("msg1"), Identity: []byte("Alice"), Signature: []byte("msg1")}}
+	err = pprov.CheckACL("pol", sd)
+	assert.NoError(t, err)
 }
 
 func TestPolicyBad(t *testing.T) {
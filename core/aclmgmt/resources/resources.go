@@ -23,11 +23,15 @@ const (
 	Lscc_GetCollectionsConfig      = "lscc/GetCollectionsConfig"
 
 	//Qscc resources
-	Qscc_GetChainInfo       = "qscc/GetChainInfo"
-	Qscc_GetBlockByNumber   = "qscc/GetBlockByNumber"
-	Qscc_GetBlockByHash     = "qscc/GetBlockByHash"
-	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
-	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
+	Qscc_GetChainInfo                 = "qscc/GetChainInfo"
+	Qscc_GetBlockByNumber             = "qscc/GetBlockByNumber"
+	Qscc_GetBlockByHash               = "qscc/GetBlockByHash"
+	Qscc_GetTransactionByID           = "qscc/GetTransactionByID"
+	Qscc_GetBlockByTxID               = "qscc/GetBlockByTxID"
+	Qscc_GetBlocksByRange             = "qscc/GetBlocksByRange"
+	Qscc_GetTransactionsByBlockNumber = "qscc/GetTransactionsByBlockNumber"
+	Qscc_GetBlockStatsByRange         = "qscc/GetBlockStatsByRange"
+	Qscc_GetStateProof                = "qscc/GetStateProof"
 
 	//Cscc resources
 	Cscc_JoinChain                = "cscc/JoinChain"
@@ -35,10 +39,23 @@ const (
 	Cscc_GetChannels              = "cscc/GetChannels"
 	Cscc_GetConfigTree            = "cscc/GetConfigTree"
 	Cscc_SimulateConfigTreeUpdate = "cscc/SimulateConfigTreeUpdate"
+	Cscc_GetChannelConfig         = "cscc/GetChannelConfig"
+	Cscc_GetChannelOrgs           = "cscc/GetChannelOrgs"
+	Cscc_GetChannelCapabilities   = "cscc/GetChannelCapabilities"
+
+	//Interopscc resources
+	Interopscc_RegisterNetwork = "interopscc/RegisterNetwork"
+	Interopscc_GetNetwork      = "interopscc/GetNetwork"
+	Interopscc_VerifyView      = "interopscc/VerifyView"
+
+	//Batchscc resources
+	Batchscc_Invoke = "batchscc/Invoke"
 
 	//Peer resources
 	Peer_Propose              = "peer/Propose"
 	Peer_ChaincodeToChaincode = "peer/ChaincodeToChaincode"
+	Peer_GetPrivateDataHash   = "peer/GetPrivateDataHash"
+	Peer_Schedule             = "peer/Schedule"
 
 	//Events
 	Event_Block         = "event/Block"
@@ -34,20 +34,23 @@ type AccessControlEvaluator interface {
 	Evaluate(signatureSet []*common.SignedData) error
 }
 
-// NewAdminServer creates and returns a Admin service instance.
-func NewAdminServer(ace AccessControlEvaluator) *ServerAdmin {
+// NewAdminServer creates and returns a Admin service instance. The channel
+// management calls are rejected if the ChannelManager is nil.
+func NewAdminServer(ace AccessControlEvaluator, cm ChannelManager) *ServerAdmin {
 	s := &ServerAdmin{
 		v: &validator{
 			ace: ace,
 		},
-		specAtStartup: flogging.Global.Spec(),
+		channelManager: cm,
+		specAtStartup:  flogging.Global.Spec(),
 	}
 	return s
 }
 
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
-	v requestValidator
+	v              requestValidator
+	channelManager ChannelManager
 
 	specAtStartup string
 }
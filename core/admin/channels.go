@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ChannelManager manages the channels joined by the peer on behalf of the admin service
+type ChannelManager interface {
+	// JoinChannel joins the channel of the given genesis block
+	JoinChannel(genesisBlock *common.Block) error
+	// LeaveChannel stops the processing of the channel and removes its ledger
+	LeaveChannel(channelID string) error
+	// Channels returns the channels joined by the peer along with the height of their ledgers
+	Channels() ([]*pb.ChannelHeight, error)
+}
+
+func (s *ServerAdmin) JoinChannel(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetJoinChannelReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if s.channelManager == nil {
+		return nil, status.Error(codes.Unimplemented, "channel management is not available")
+	}
+
+	if len(request.GenesisBlock) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "a genesis block must be provided")
+	}
+	block, err := utils.UnmarshalBlock(request.GenesisBlock)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid genesis block: %s", err)
+	}
+	if err := s.channelManager.JoinChannel(block); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed joining the channel: %s", err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerAdmin) LeaveChannel(ctx context.Context, env *common.Envelope) (*empty.Empty, error) {
+	op, err := s.v.validate(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	request := op.GetLeaveChannelReq()
+	if request == nil {
+		return nil, errors.New("request is nil")
+	}
+	if s.channelManager == nil {
+		return nil, status.Error(codes.Unimplemented, "channel management is not available")
+	}
+	if request.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "a channel ID must be provided")
+	}
+	if err := s.channelManager.LeaveChannel(request.ChannelId); err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed leaving the channel %s: %s", request.ChannelId, err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (s *ServerAdmin) ListChannels(ctx context.Context, env *common.Envelope) (*pb.ChannelList, error) {
+	if _, err := s.v.validate(ctx, env); err != nil {
+		return nil, err
+	}
+	if s.channelManager == nil {
+		return nil, status.Error(codes.Unimplemented, "channel management is not available")
+	}
+	channels, err := s.channelManager.Channels()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed listing the channels: %s", err)
+	}
+	return &pb.ChannelList{Channels: channels}, nil
+}
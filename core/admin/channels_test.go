@@ -0,0 +1,141 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockChannelManager struct {
+	joinedBlock *common.Block
+	leftChannel string
+	channels    []*pb.ChannelHeight
+	err         error
+}
+
+func (m *mockChannelManager) JoinChannel(genesisBlock *common.Block) error {
+	m.joinedBlock = genesisBlock
+	return m.err
+}
+
+func (m *mockChannelManager) LeaveChannel(channelID string) error {
+	m.leftChannel = channelID
+	return m.err
+}
+
+func (m *mockChannelManager) Channels() ([]*pb.ChannelHeight, error) {
+	return m.channels, m.err
+}
+
+func wrapJoinChannelRequest(r *pb.JoinChannelRequest) *pb.AdminOperation {
+	return &pb.AdminOperation{Content: &pb.AdminOperation_JoinChannelReq{JoinChannelReq: r}}
+}
+
+func TestJoinChannel(t *testing.T) {
+	cm := &mockChannelManager{}
+	adminServer := NewAdminServer(nil, cm)
+	mv := &mockValidator{}
+	adminServer.v = mv
+	ctx := context.Background()
+
+	block := &common.Block{Header: &common.BlockHeader{Number: 0}, Data: &common.BlockData{}}
+	mv.On("validate").Return(wrapJoinChannelRequest(&pb.JoinChannelRequest{GenesisBlock: utils.MarshalOrPanic(block)}), nil).Once()
+	_, err := adminServer.JoinChannel(ctx, nil)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(block, cm.joinedBlock))
+
+	mv.On("validate").Return(wrapJoinChannelRequest(&pb.JoinChannelRequest{}), nil).Once()
+	_, err = adminServer.JoinChannel(ctx, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	mv.On("validate").Return(wrapJoinChannelRequest(&pb.JoinChannelRequest{GenesisBlock: []byte("garbage")}), nil).Once()
+	_, err = adminServer.JoinChannel(ctx, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	cm.err = errors.New("ledger already exists")
+	mv.On("validate").Return(wrapJoinChannelRequest(&pb.JoinChannelRequest{GenesisBlock: utils.MarshalOrPanic(block)}), nil).Once()
+	_, err = adminServer.JoinChannel(ctx, nil)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "failed joining the channel: ledger already exists")
+
+	mv.On("validate").Return(&pb.AdminOperation{}, nil).Once()
+	_, err = adminServer.JoinChannel(ctx, nil)
+	assert.EqualError(t, err, "request is nil")
+}
+
+func TestLeaveChannel(t *testing.T) {
+	cm := &mockChannelManager{}
+	adminServer := NewAdminServer(nil, cm)
+	mv := &mockValidator{}
+	adminServer.v = mv
+	ctx := context.Background()
+
+	wrap := func(r *pb.LeaveChannelRequest) *pb.AdminOperation {
+		return &pb.AdminOperation{Content: &pb.AdminOperation_LeaveChannelReq{LeaveChannelReq: r}}
+	}
+
+	mv.On("validate").Return(wrap(&pb.LeaveChannelRequest{ChannelId: "mychannel"}), nil).Once()
+	_, err := adminServer.LeaveChannel(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "mychannel", cm.leftChannel)
+
+	mv.On("validate").Return(wrap(&pb.LeaveChannelRequest{}), nil).Once()
+	_, err = adminServer.LeaveChannel(ctx, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	cm.err = errors.New("channel mychannel does not exist")
+	mv.On("validate").Return(wrap(&pb.LeaveChannelRequest{ChannelId: "mychannel"}), nil).Once()
+	_, err = adminServer.LeaveChannel(ctx, nil)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestListChannels(t *testing.T) {
+	cm := &mockChannelManager{channels: []*pb.ChannelHeight{{ChannelId: "mychannel", Height: 42}}}
+	adminServer := NewAdminServer(nil, cm)
+	mv := &mockValidator{}
+	adminServer.v = mv
+
+	mv.On("validate").Return(nil, nil).Once()
+	list, err := adminServer.ListChannels(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, cm.channels, list.Channels)
+}
+
+func TestChannelManagementUnavailable(t *testing.T) {
+	adminServer := NewAdminServer(nil, nil)
+	mv := &mockValidator{}
+	adminServer.v = mv
+
+	mv.On("validate").Return(nil, nil).Once()
+	_, err := adminServer.ListChannels(context.Background(), nil)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestChannelManagementForbidden(t *testing.T) {
+	adminServer := NewAdminServer(nil, &mockChannelManager{})
+	mv := &mockValidator{}
+	adminServer.v = mv
+	mv.On("validate").Return(nil, accessDenied).Times(3)
+
+	ctx := context.Background()
+	_, err := adminServer.JoinChannel(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.LeaveChannel(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+	_, err = adminServer.ListChannels(ctx, nil)
+	assert.Equal(t, accessDenied, err)
+}
@@ -50,10 +50,11 @@ func DeployedChaincodes(q Query, filter ChaincodePredicate, loadCollections bool
 		}
 
 		instCC := chaincode.Metadata{
-			Name:    ccInfo.Name,
-			Version: ccInfo.Version,
-			Id:      ccInfo.Id,
-			Policy:  ccInfo.Policy,
+			Name:        ccInfo.Name,
+			Version:     ccInfo.Version,
+			Id:          ccInfo.Id,
+			Policy:      ccInfo.Policy,
+			Annotations: ccInfo.Annotations,
 		}
 
 		if !filter(instCC) {
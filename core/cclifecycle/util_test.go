@@ -47,7 +47,7 @@ func TestChaincodeInspection(t *testing.T) {
 	corruptBytes = append(corruptBytes, cc1Bytes...)
 	corruptBytes = append(corruptBytes, 0)
 
-	cc2 := &ccprovider.ChaincodeData{Name: "cc2", Version: "1.1"}
+	cc2 := &ccprovider.ChaincodeData{Name: "cc2", Version: "1.1", Annotations: map[string]string{"owner": "payments"}}
 	cc2Bytes, _ := proto.Marshal(cc2)
 
 	tests := []struct {
@@ -111,8 +111,9 @@ func TestChaincodeInspection(t *testing.T) {
 					Policy:  policyBytes,
 				},
 				{
-					Name:    "cc2",
-					Version: "1.1",
+					Name:        "cc2",
+					Version:     "1.1",
+					Annotations: map[string]string{"owner": "payments"},
 				},
 			}),
 		},
@@ -9,6 +9,7 @@ package chaincode
 import (
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/metrics"
@@ -23,6 +24,11 @@ import (
 	"github.com/pkg/errors"
 )
 
+// InitializedKeyName is the key of the namespace of a chaincode requiring
+// initialization which records the version the chaincode was initialized at.
+// The chaincodes cannot build it as a composite key
+const InitializedKeyName = "\x00" + string(utf8.MaxRune) + "initialized"
+
 // Runtime is used to manage chaincode runtime instances.
 type Runtime interface {
 	Start(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) error
@@ -48,6 +54,7 @@ type Lifecycle interface {
 type ChaincodeSupport struct {
 	Keepalive        time.Duration
 	ExecuteTimeout   time.Duration
+	MaxConcurrency   int
 	UserRunsCC       bool
 	Runtime          Runtime
 	ACLProvider      ACLProvider
@@ -80,6 +87,7 @@ func NewChaincodeSupport(
 		UserRunsCC:       userRunsCC,
 		Keepalive:        config.Keepalive,
 		ExecuteTimeout:   config.ExecuteTimeout,
+		MaxConcurrency:   config.MaxConcurrency,
 		HandlerRegistry:  NewHandlerRegistry(userRunsCC),
 		ACLProvider:      aclProvider,
 		SystemCCProvider: SystemCCProvider,
@@ -105,6 +113,7 @@ func NewChaincodeSupport(
 			"CORE_CHAINCODE_LOGGING_SHIM=" + config.ShimLogLevel,
 			"CORE_CHAINCODE_LOGGING_FORMAT=" + config.LogFormat,
 		},
+		TLSEnv: config.tlsPolicyEnv(),
 	}
 
 	cs.Launcher = &RuntimeLauncher{
@@ -174,6 +183,7 @@ func (cs *ChaincodeSupport) HandleChaincodeStream(stream ccintf.ChaincodeStream)
 		Invoker:                    cs,
 		DefinitionGetter:           cs.Lifecycle,
 		Keepalive:                  cs.Keepalive,
+		MaxConcurrency:             cs.MaxConcurrency,
 		Registry:                   cs.HandlerRegistry,
 		ACLProvider:                cs.ACLProvider,
 		TXContexts:                 NewTransactionContexts(),
@@ -214,7 +224,9 @@ func createCCMessage(messageType pb.ChaincodeMessage_Type, cid string, txid stri
 // ExecuteLegacyInit is a temporary method which should be removed once the old style lifecycle
 // is entirely deprecated.  Ideally one release after the introduction of the new lifecycle.
 // It does not attempt to start the chaincode based on the information from lifecycle, but instead
-// accepts the container information directly in the form of a ChaincodeDeploymentSpec.
+// accepts the container information directly in the form of a ChaincodeDeploymentSpec. When the
+// deployment spec requests a migration, the Migrate function of the chaincode is invoked instead
+// of its Init function.
 func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, spec *pb.ChaincodeDeploymentSpec) (*pb.Response, *pb.ChaincodeEvent, error) {
 	ccci := ccprovider.DeploymentSpecToChaincodeContainerInfo(spec)
 	ccci.Version = cccid.Version
@@ -230,7 +242,18 @@ func (cs *ChaincodeSupport) ExecuteLegacyInit(txParams *ccprovider.TransactionPa
 		return nil, nil, errors.Wrapf(err, "[channel %s] claimed to start chaincode container for %s but could not find handler", txParams.ChannelID, cname)
 	}
 
-	resp, err := cs.execute(pb.ChaincodeMessage_INIT, txParams, cccid, spec.GetChaincodeSpec().Input, h)
+	// the chaincodes requiring initialization are initialized by a subsequent
+	// invocation flagged as init rather than upon instantiation or upgrade
+	if spec.InitRequired {
+		return &pb.Response{Status: 200}, nil, nil
+	}
+
+	cctyp := pb.ChaincodeMessage_INIT
+	if spec.Migrate {
+		cctyp = pb.ChaincodeMessage_MIGRATE
+	}
+
+	resp, err := cs.execute(cctyp, txParams, cccid, spec.GetChaincodeSpec().Input, h)
 	return processChaincodeExecutionResult(txParams.TxID, cccid.Name, resp, err)
 }
 
@@ -287,21 +310,70 @@ func (cs *ChaincodeSupport) Invoke(txParams *ccprovider.TransactionParams, cccid
 		return nil, err
 	}
 
-	// TODO add Init exactly once semantics here once new lifecycle
-	// is available.  Enforced if the target channel is using the new lifecycle
-	//
-	// First, the function name of the chaincode to invoke should be checked.  If it is
-	// "init", then consider this invocation to be of type pb.ChaincodeMessage_INIT,
-	// otherwise consider it to be of type pb.ChaincodeMessage_TRANSACTION,
-	//
-	// Secondly, A check should be made whether the chaincode has been
-	// inited, then, if true, only allow cctyp pb.ChaincodeMessage_TRANSACTION,
-	// otherwise, only allow cctype pb.ChaincodeMessage_INIT,
+	isInit, err := cs.CheckInit(txParams, cccid, input)
+	if err != nil {
+		return nil, err
+	}
+
 	cctype := pb.ChaincodeMessage_TRANSACTION
+	if isInit {
+		cctype = pb.ChaincodeMessage_INIT
+	}
 
 	return cs.execute(cctype, txParams, cccid, input, h)
 }
 
+// CheckInit enforces the initialization of the chaincodes whose definition
+// requires it: their Init function is invoked exactly once per version, by an
+// invocation flagged as init, before any other invocation. The version the
+// chaincode was initialized at is recorded under InitializedKeyName in the
+// namespace of the chaincode, so that the concurrent initializations conflict
+// upon validation. It returns true when the invocation initializes the chaincode
+func (cs *ChaincodeSupport) CheckInit(txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput) (bool, error) {
+	if cs.SystemCCProvider.IsSysCC(cccid.Name) {
+		if input.IsInit {
+			return false, errors.Errorf("system chaincode '%s' cannot be invoked as init", cccid.Name)
+		}
+		return false, nil
+	}
+
+	cd, err := cs.Lifecycle.ChaincodeDefinition(cccid.Name, txParams.TXSimulator)
+	if err != nil {
+		return false, errors.WithMessage(err, fmt.Sprintf("could not get the definition of chaincode '%s'", cccid.Name))
+	}
+	initRequired := false
+	if ccData, ok := cd.(*ccprovider.ChaincodeData); ok {
+		initRequired = ccData.InitRequired
+	}
+	if !initRequired {
+		if input.IsInit {
+			return false, errors.Errorf("chaincode '%s' does not require initialization but was invoked as init", cccid.Name)
+		}
+		return false, nil
+	}
+
+	value, err := txParams.TXSimulator.GetState(cccid.Name, InitializedKeyName)
+	if err != nil {
+		return false, errors.WithMessage(err, fmt.Sprintf("could not get the initialization of chaincode '%s'", cccid.Name))
+	}
+	initialized := string(value) == cccid.Version
+
+	if !input.IsInit {
+		if !initialized {
+			return false, errors.Errorf("chaincode '%s' has not been initialized at version %s, it must be invoked as init first", cccid.Name, cccid.Version)
+		}
+		return false, nil
+	}
+	if initialized {
+		return false, errors.Errorf("chaincode '%s' is already initialized at version %s but was invoked as init", cccid.Name, cccid.Version)
+	}
+
+	if err := txParams.TXSimulator.SetState(cccid.Name, InitializedKeyName, []byte(cccid.Version)); err != nil {
+		return false, errors.WithMessage(err, fmt.Sprintf("could not record the initialization of chaincode '%s'", cccid.Name))
+	}
+	return true, nil
+}
+
 // execute executes a transaction and waits for it to complete until a timeout value.
 func (cs *ChaincodeSupport) execute(cctyp pb.ChaincodeMessage_Type, txParams *ccprovider.TransactionParams, cccid *ccprovider.CCContext, input *pb.ChaincodeInput, h *Handler) (*pb.ChaincodeMessage, error) {
 	input.Decorations = txParams.ProposalDecorations
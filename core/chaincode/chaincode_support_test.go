@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
@@ -1440,3 +1441,94 @@ func TestCCFramework(t *testing.T) {
 
 	ccSide.Quit()
 }
+
+// definitionLifecycle returns the same chaincode definition for all the chaincodes
+type definitionLifecycle struct {
+	Lifecycle
+	definition ccprovider.ChaincodeDefinition
+	err        error
+}
+
+func (l *definitionLifecycle) ChaincodeDefinition(chaincodeName string, qe ledger.QueryExecutor) (ccprovider.ChaincodeDefinition, error) {
+	return l.definition, l.err
+}
+
+type sysCCProvider struct {
+	sysccprovider.SystemChaincodeProvider
+	sysCC bool
+}
+
+func (p *sysCCProvider) IsSysCC(name string) bool {
+	return p.sysCC
+}
+
+func TestCheckInit(t *testing.T) {
+	fakeLifecycle := &definitionLifecycle{}
+	fakeSysCCProvider := &sysCCProvider{}
+	cs := &ChaincodeSupport{Lifecycle: fakeLifecycle, SystemCCProvider: fakeSysCCProvider}
+	cccid := &ccprovider.CCContext{Name: "mycc", Version: "2.0"}
+
+	checkInit := func(isInit bool, initialized []byte) (*mock.TxSimulator, bool, error) {
+		txsim := &mock.TxSimulator{}
+		txsim.GetStateReturns(initialized, nil)
+		ok, err := cs.CheckInit(&ccprovider.TransactionParams{TXSimulator: txsim}, cccid, &pb.ChaincodeInput{IsInit: isInit})
+		return txsim, ok, err
+	}
+
+	t.Run("InitNotRequired", func(t *testing.T) {
+		fakeLifecycle.definition, fakeLifecycle.err = &ccprovider.ChaincodeData{Name: "mycc", Version: "2.0"}, nil
+		txsim, isInit, err := checkInit(false, nil)
+		assert.NoError(t, err)
+		assert.False(t, isInit)
+		assert.Equal(t, 0, txsim.GetStateCallCount())
+
+		_, _, err = checkInit(true, nil)
+		assert.EqualError(t, err, "chaincode 'mycc' does not require initialization but was invoked as init")
+	})
+
+	t.Run("InitRequired", func(t *testing.T) {
+		fakeLifecycle.definition, fakeLifecycle.err = &ccprovider.ChaincodeData{Name: "mycc", Version: "2.0", InitRequired: true}, nil
+
+		_, _, err := checkInit(false, nil)
+		assert.EqualError(t, err, "chaincode 'mycc' has not been initialized at version 2.0, it must be invoked as init first")
+		_, _, err = checkInit(false, []byte("1.0"))
+		assert.EqualError(t, err, "chaincode 'mycc' has not been initialized at version 2.0, it must be invoked as init first")
+
+		txsim, isInit, err := checkInit(true, []byte("1.0"))
+		assert.NoError(t, err)
+		assert.True(t, isInit)
+		ns, key := txsim.GetStateArgsForCall(0)
+		assert.Equal(t, "mycc", ns)
+		assert.Equal(t, InitializedKeyName, key)
+		assert.Equal(t, 1, txsim.SetStateCallCount())
+		ns, key, value := txsim.SetStateArgsForCall(0)
+		assert.Equal(t, "mycc", ns)
+		assert.Equal(t, InitializedKeyName, key)
+		assert.Equal(t, []byte("2.0"), value)
+
+		txsim, isInit, err = checkInit(false, []byte("2.0"))
+		assert.NoError(t, err)
+		assert.False(t, isInit)
+		assert.Equal(t, 0, txsim.SetStateCallCount())
+
+		_, _, err = checkInit(true, []byte("2.0"))
+		assert.EqualError(t, err, "chaincode 'mycc' is already initialized at version 2.0 but was invoked as init")
+	})
+
+	t.Run("DefinitionNotFound", func(t *testing.T) {
+		fakeLifecycle.definition, fakeLifecycle.err = nil, errors.New("not found")
+		_, _, err := checkInit(false, nil)
+		assert.EqualError(t, err, "could not get the definition of chaincode 'mycc': not found")
+	})
+
+	t.Run("SystemChaincode", func(t *testing.T) {
+		fakeSysCCProvider.sysCC = true
+		defer func() { fakeSysCCProvider.sysCC = false }()
+		_, isInit, err := checkInit(false, nil)
+		assert.NoError(t, err)
+		assert.False(t, isInit)
+
+		_, _, err = checkInit(true, nil)
+		assert.EqualError(t, err, "system chaincode 'mycc' cannot be invoked as init")
+	})
+}
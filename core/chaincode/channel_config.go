@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// newChannelConfigSnapshot extracts from the given channel config the values
+// returned to the chaincodes. It only decodes the config, without building the
+// MSPs and the policies of the channel, as the config was validated when it
+// was committed
+func newChannelConfigSnapshot(config *common.Config) (*pb.ChannelConfigSnapshot, error) {
+	channelGroup := config.GetChannelGroup()
+	if channelGroup == nil {
+		return nil, errors.New("channel config has no channel group")
+	}
+	snapshot := &pb.ChannelConfigSnapshot{Sequence: config.Sequence}
+
+	var err error
+	if snapshot.ChannelCapabilities, err = capabilityNames(channelGroup); err != nil {
+		return nil, errors.WithMessage(err, "invalid channel capabilities")
+	}
+	if ordererGroup, ok := channelGroup.Groups[channelconfig.OrdererGroupKey]; ok {
+		if snapshot.OrdererCapabilities, err = capabilityNames(ordererGroup); err != nil {
+			return nil, errors.WithMessage(err, "invalid orderer capabilities")
+		}
+		if snapshot.OrdererOrganizations, _, err = organizations(ordererGroup); err != nil {
+			return nil, err
+		}
+	}
+	if applicationGroup, ok := channelGroup.Groups[channelconfig.ApplicationGroupKey]; ok {
+		if snapshot.ApplicationCapabilities, err = capabilityNames(applicationGroup); err != nil {
+			return nil, errors.WithMessage(err, "invalid application capabilities")
+		}
+		if snapshot.ApplicationOrganizations, snapshot.ApplicationGroups, err = organizations(applicationGroup); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+// capabilityNames returns the sorted names of the capabilities of the given group
+func capabilityNames(group *common.ConfigGroup) ([]string, error) {
+	value, ok := group.Values[channelconfig.CapabilitiesKey]
+	if !ok {
+		return nil, nil
+	}
+	capabilities := &common.Capabilities{}
+	if err := proto.Unmarshal(value.Value, capabilities); err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range capabilities.Capabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// organizations returns the organizations of the given group sorted by name, which are
+// the subgroups holding an MSP, along with the other subgroups
+func organizations(group *common.ConfigGroup) ([]*pb.ChannelConfigOrganization, map[string]*common.ConfigGroup, error) {
+	var orgs []*pb.ChannelConfigOrganization
+	var others map[string]*common.ConfigGroup
+	for name, subgroup := range group.Groups {
+		value, ok := subgroup.Values[channelconfig.MSPKey]
+		if !ok {
+			if others == nil {
+				others = map[string]*common.ConfigGroup{}
+			}
+			others[name] = subgroup
+			continue
+		}
+		mspID, err := mspIdentifier(value.Value)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "invalid MSP of organization "+name)
+		}
+		orgs = append(orgs, &pb.ChannelConfigOrganization{Name: name, MspId: mspID})
+	}
+	sort.Slice(orgs, func(i, j int) bool {
+		return orgs[i].Name < orgs[j].Name
+	})
+	return orgs, others, nil
+}
+
+// mspIdentifier returns the identifier of the MSP of the given serialized config
+func mspIdentifier(serializedConfig []byte) (string, error) {
+	mspConfig := &mspprotos.MSPConfig{}
+	if err := proto.Unmarshal(serializedConfig, mspConfig); err != nil {
+		return "", err
+	}
+	switch msp.ProviderType(mspConfig.Type) {
+	case msp.FABRIC:
+		fabricConfig := &mspprotos.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+			return "", err
+		}
+		return fabricConfig.Name, nil
+	case msp.IDEMIX:
+		idemixConfig := &mspprotos.IdemixMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, idemixConfig); err != nil {
+			return "", err
+		}
+		return idemixConfig.Name, nil
+	default:
+		return "", errors.Errorf("unsupported MSP type %d", mspConfig.Type)
+	}
+}
@@ -22,13 +22,18 @@ const (
 )
 
 type Config struct {
-	TLSEnabled     bool
-	Keepalive      time.Duration
-	ExecuteTimeout time.Duration
-	StartupTimeout time.Duration
-	LogFormat      string
-	LogLevel       string
-	ShimLogLevel   string
+	TLSEnabled      bool
+	TLSMinVersion   string
+	TLSMaxVersion   string
+	TLSCipherSuites []string
+	TLSFIPS         bool
+	Keepalive       time.Duration
+	ExecuteTimeout  time.Duration
+	StartupTimeout  time.Duration
+	LogFormat       string
+	LogLevel        string
+	ShimLogLevel    string
+	MaxConcurrency  int
 }
 
 func GlobalConfig() *Config {
@@ -44,6 +49,10 @@ func (c *Config) load() {
 	viper.SetEnvKeyReplacer(replacer)
 
 	c.TLSEnabled = viper.GetBool("peer.tls.enabled")
+	c.TLSMinVersion = viper.GetString("peer.tls.minVersion")
+	c.TLSMaxVersion = viper.GetString("peer.tls.maxVersion")
+	c.TLSCipherSuites = viper.GetStringSlice("peer.tls.cipherSuites")
+	c.TLSFIPS = viper.GetBool("peer.tls.fips")
 
 	c.Keepalive = toSeconds(viper.GetString("chaincode.keepalive"), 0)
 	c.ExecuteTimeout = viper.GetDuration("chaincode.executetimeout")
@@ -55,11 +64,35 @@ func (c *Config) load() {
 		c.StartupTimeout = minimumStartupTimeout
 	}
 
+	c.MaxConcurrency = viper.GetInt("chaincode.maxConcurrency")
+	if c.MaxConcurrency < 0 {
+		c.MaxConcurrency = 0
+	}
+
 	c.LogFormat = viper.GetString("chaincode.logging.format")
 	c.LogLevel = getLogLevelFromViper("chaincode.logging.level")
 	c.ShimLogLevel = getLogLevelFromViper("chaincode.logging.shim")
 }
 
+// tlsPolicyEnv returns the environment variables passing the TLS policy of the
+// peer to the chaincodes, which connect to the peer with the same policy
+func (c *Config) tlsPolicyEnv() []string {
+	var env []string
+	if c.TLSMinVersion != "" {
+		env = append(env, "CORE_PEER_TLS_MINVERSION="+c.TLSMinVersion)
+	}
+	if c.TLSMaxVersion != "" {
+		env = append(env, "CORE_PEER_TLS_MAXVERSION="+c.TLSMaxVersion)
+	}
+	if len(c.TLSCipherSuites) > 0 {
+		env = append(env, "CORE_PEER_TLS_CIPHERSUITES="+strings.Join(c.TLSCipherSuites, " "))
+	}
+	if c.TLSFIPS {
+		env = append(env, "CORE_PEER_TLS_FIPS=true")
+	}
+	return env
+}
+
 func toSeconds(s string, def int) time.Duration {
 	seconds, err := strconv.Atoi(s)
 	if err != nil {
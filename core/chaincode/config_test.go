@@ -29,21 +29,42 @@ var _ = Describe("Config", func() {
 	Describe("GlobalConfig", func() {
 		It("captures the configuration from viper", func() {
 			viper.Set("peer.tls.enabled", "true")
+			viper.Set("peer.tls.minVersion", "1.2")
+			viper.Set("peer.tls.maxVersion", "1.3")
+			viper.Set("peer.tls.cipherSuites", []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"})
+			viper.Set("peer.tls.fips", "true")
 			viper.Set("chaincode.keepalive", "50")
 			viper.Set("chaincode.executetimeout", "20h")
 			viper.Set("chaincode.startuptimeout", "30h")
 			viper.Set("chaincode.logging.format", "test-chaincode-logging-format")
 			viper.Set("chaincode.logging.level", "WARNING")
 			viper.Set("chaincode.logging.shim", "WARNING")
+			viper.Set("chaincode.maxConcurrency", "64")
 
 			config := chaincode.GlobalConfig()
 			Expect(config.TLSEnabled).To(BeTrue())
+			Expect(config.TLSMinVersion).To(Equal("1.2"))
+			Expect(config.TLSMaxVersion).To(Equal("1.3"))
+			Expect(config.TLSCipherSuites).To(Equal([]string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"}))
+			Expect(config.TLSFIPS).To(BeTrue())
 			Expect(config.Keepalive).To(Equal(50 * time.Second))
 			Expect(config.ExecuteTimeout).To(Equal(20 * time.Hour))
 			Expect(config.StartupTimeout).To(Equal(30 * time.Hour))
 			Expect(config.LogFormat).To(Equal("test-chaincode-logging-format"))
 			Expect(config.LogLevel).To(Equal("WARNING"))
 			Expect(config.ShimLogLevel).To(Equal("WARNING"))
+			Expect(config.MaxConcurrency).To(Equal(64))
+		})
+
+		Context("when a negative max concurrency is configured", func() {
+			BeforeEach(func() {
+				viper.Set("chaincode.maxConcurrency", "-1")
+			})
+
+			It("falls back to no limit", func() {
+				config := chaincode.GlobalConfig()
+				Expect(config.MaxConcurrency).To(Equal(0))
+			})
 		})
 
 		Context("when an invalid keepalive is configured", func() {
@@ -113,12 +134,17 @@ func capture() (restore func()) {
 	viper.AutomaticEnv()
 	config := map[string]string{
 		"peer.tls.enabled":         viper.GetString("peer.tls.enabled"),
+		"peer.tls.minVersion":      viper.GetString("peer.tls.minVersion"),
+		"peer.tls.maxVersion":      viper.GetString("peer.tls.maxVersion"),
+		"peer.tls.cipherSuites":    viper.GetString("peer.tls.cipherSuites"),
+		"peer.tls.fips":            viper.GetString("peer.tls.fips"),
 		"chaincode.keepalive":      viper.GetString("chaincode.keepalive"),
 		"chaincode.executetimeout": viper.GetString("chaincode.executetimeout"),
 		"chaincode.startuptimeout": viper.GetString("chaincode.startuptimeout"),
 		"chaincode.logging.format": viper.GetString("chaincode.logging.format"),
 		"chaincode.logging.level":  viper.GetString("chaincode.logging.level"),
 		"chaincode.logging.shim":   viper.GetString("chaincode.logging.shim"),
+		"chaincode.maxConcurrency": viper.GetString("chaincode.maxConcurrency"),
 	}
 
 	return func() {
@@ -8,11 +8,14 @@ package chaincode
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/container"
@@ -39,6 +42,7 @@ type ContainerRuntime struct {
 	Processor        Processor
 	CACert           []byte
 	CommonEnv        []string
+	TLSEnv           []string
 	PeerAddress      string
 	PlatformRegistry *platforms.Registry
 }
@@ -52,6 +56,12 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 		return err
 	}
 
+	packageID, err := codePackageID(ccci, codePackage)
+	if err != nil {
+		// the chaincode gets an image of its own
+		chaincodeLogger.Debugf("could not identify the code package of %s: %s", cname, err)
+	}
+
 	chaincodeLogger.Debugf("start container: %s", cname)
 	chaincodeLogger.Debugf("start container with args: %s", strings.Join(lc.Args, " "))
 	chaincodeLogger.Debugf("start container with env:\n\t%s", strings.Join(lc.Envs, "\n\t"))
@@ -64,13 +74,15 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 			Path:             ccci.Path,
 			CodePackage:      codePackage,
 			PlatformRegistry: c.PlatformRegistry,
+			PackageID:        packageID,
 		},
 		Args:          lc.Args,
 		Env:           lc.Envs,
 		FilesToUpload: lc.Files,
 		CCID: ccintf.CCID{
-			Name:    ccci.Name,
-			Version: ccci.Version,
+			Name:      ccci.Name,
+			Version:   ccci.Version,
+			PackageID: packageID,
 		},
 	}
 
@@ -81,6 +93,24 @@ func (c *ContainerRuntime) Start(ccci *ccprovider.ChaincodeContainerInfo, codePa
 	return nil
 }
 
+// codePackageID identifies the image built from a code package: the chaincode
+// versions installed from the same package, whatever their name, version or
+// channel, share the image. The ID is the ID of the package labeled with the
+// type of the chaincode, digested with the path of the chaincode, which selects
+// the program built out of the package
+func codePackageID(ccci *ccprovider.ChaincodeContainerInfo, codePackage []byte) (string, error) {
+	if len(codePackage) == 0 {
+		return "", errors.New("empty code package")
+	}
+	label := strings.ToLower(ccci.Type)
+	id, err := persistence.ComputePackageID(persistence.CurrentPackageIDVersion, label, codePackage)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256([]byte(ccci.Path + "\x00" + id))
+	return label + ":" + hex.EncodeToString(digest[:]), nil
+}
+
 // Stop terminates chaincode and its container runtime environment.
 func (c *ContainerRuntime) Stop(ccci *ccprovider.ChaincodeContainerInfo) error {
 	scr := container.StopContainerReq{
@@ -161,7 +191,7 @@ func (c *ContainerRuntime) LaunchConfig(cname string, ccType string) (*LaunchCon
 
 	// language specific arguments
 	switch ccType {
-	case pb.ChaincodeSpec_GOLANG.String(), pb.ChaincodeSpec_CAR.String():
+	case pb.ChaincodeSpec_GOLANG.String(), pb.ChaincodeSpec_CAR.String(), pb.ChaincodeSpec_BINARY.String():
 		lc.Args = []string{"chaincode", fmt.Sprintf("-peer.address=%s", c.PeerAddress)}
 	case pb.ChaincodeSpec_JAVA.String():
 		lc.Args = []string{"/root/chaincode-java/start", "--peerAddress", c.PeerAddress}
@@ -186,6 +216,7 @@ func (c *ContainerRuntime) LaunchConfig(cname string, ccType string) (*LaunchCon
 		lc.Envs = append(lc.Envs, fmt.Sprintf("CORE_TLS_CLIENT_KEY_PATH=%s", TLSClientKeyPath))
 		lc.Envs = append(lc.Envs, fmt.Sprintf("CORE_TLS_CLIENT_CERT_PATH=%s", TLSClientCertPath))
 		lc.Envs = append(lc.Envs, fmt.Sprintf("CORE_PEER_TLS_ROOTCERT_FILE=%s", TLSClientRootCertPath))
+		lc.Envs = append(lc.Envs, c.TLSEnv...)
 	} else {
 		lc.Envs = append(lc.Envs, "CORE_PEER_TLS_ENABLED=false")
 	}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/chaincode"
@@ -51,6 +54,7 @@ func TestContainerRuntimeLaunchConfigArgs(t *testing.T) {
 		expectedErr  string
 	}{
 		{"car-chaincode", pb.ChaincodeSpec_CAR, []string{"chaincode", "-peer.address=peer-address"}, ""},
+		{"binary-chaincode", pb.ChaincodeSpec_BINARY, []string{"chaincode", "-peer.address=peer-address"}, ""},
 		{"golang-chaincode", pb.ChaincodeSpec_GOLANG, []string{"chaincode", "-peer.address=peer-address"}, ""},
 		{"java-chaincode", pb.ChaincodeSpec_JAVA, []string{"/root/chaincode-java/start", "--peerAddress", "peer-address"}, ""},
 		{"node-chaincode", pb.ChaincodeSpec_NODE, []string{"/bin/sh", "-c", "cd /usr/local/src; npm start -- --peer.address peer-address"}, ""},
@@ -118,6 +122,22 @@ func TestContainerRuntimeLaunchConfigEnv(t *testing.T) {
 	}
 }
 
+func TestContainerRuntimeLaunchConfigTLSPolicy(t *testing.T) {
+	tlsEnv := []string{"CORE_PEER_TLS_MINVERSION=1.3"}
+	certGenerator := &mock.CertGenerator{}
+	certGenerator.GenerateReturns(&accesscontrol.CertAndPrivKeyPair{Cert: "certificate", Key: "key"}, nil)
+
+	cr := &chaincode.ContainerRuntime{TLSEnv: tlsEnv}
+	lc, err := cr.LaunchConfig("tls-disabled", pb.ChaincodeSpec_GOLANG.String())
+	assert.NoError(t, err)
+	assert.NotContains(t, lc.Envs, "CORE_PEER_TLS_MINVERSION=1.3")
+
+	cr.CertGenerator = certGenerator
+	lc, err = cr.LaunchConfig("tls-enabled", pb.ChaincodeSpec_GOLANG.String())
+	assert.NoError(t, err)
+	assert.Contains(t, lc.Envs, "CORE_PEER_TLS_MINVERSION=1.3")
+}
+
 func TestContainerRuntimeLaunchConfigFiles(t *testing.T) {
 	keyPair := &accesscontrol.CertAndPrivKeyPair{Cert: "certificate", Key: "key"}
 	certGenerator := &mock.CertGenerator{}
@@ -189,6 +209,61 @@ func TestContainerRuntimeStart(t *testing.T) {
 	})
 }
 
+// codePackage returns a tar.gz code package holding a file with the content
+func codePackage(t *testing.T, content string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	err := tw.WriteHeader(&tar.Header{Name: "src/chaincode.go", Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	assert.NoError(t, err)
+	_, err = tw.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestContainerRuntimeStartPackageID(t *testing.T) {
+	fakeProcessor := &mock.Processor{}
+	cr := &chaincode.ContainerRuntime{
+		Processor:   fakeProcessor,
+		PeerAddress: "peer.example.com",
+	}
+
+	start := func(name, version, path string, codePackage []byte) ccintf.CCID {
+		ccci := &ccprovider.ChaincodeContainerInfo{
+			Type:          pb.ChaincodeSpec_GOLANG.String(),
+			Name:          name,
+			Version:       version,
+			Path:          path,
+			ContainerType: "container-type",
+		}
+		err := cr.Start(ccci, codePackage)
+		assert.NoError(t, err)
+		_, req := fakeProcessor.ProcessArgsForCall(fakeProcessor.ProcessCallCount() - 1)
+		startReq := req.(container.StartContainerReq)
+		assert.Equal(t, startReq.CCID.PackageID, startReq.Builder.(*container.PlatformBuilder).PackageID)
+		return startReq.CCID
+	}
+
+	ccid := start("mycc", "1.0", "github.com/example/cc", codePackage(t, "package main"))
+	assert.Regexp(t, "^golang:[0-9a-f]{64}$", ccid.PackageID)
+	assert.Equal(t, "mycc", ccid.Name)
+
+	// the versions of the same package share the package ID
+	other := start("othercc", "2.0", "github.com/example/cc", codePackage(t, "package main"))
+	assert.Equal(t, ccid.PackageID, other.PackageID)
+
+	other = start("mycc", "1.0", "github.com/example/other", codePackage(t, "package main"))
+	assert.NotEqual(t, ccid.PackageID, other.PackageID)
+	other = start("mycc", "1.0", "github.com/example/cc", codePackage(t, "package other"))
+	assert.NotEqual(t, ccid.PackageID, other.PackageID)
+
+	// the chaincodes whose package cannot be identified get an image of their own
+	other = start("mycc", "1.0", "github.com/example/cc", []byte("not a package"))
+	assert.Empty(t, other.PackageID)
+}
+
 func TestContainerRuntimeStartErrors(t *testing.T) {
 	tests := []struct {
 		chaincodeType string
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// filteredResultsIterator returns the results of a range query whose keys are composite
+// keys satisfying the filters of the query, so that the keys that the chaincode would
+// discard are not shipped to it
+type filteredResultsIterator struct {
+	commonledger.ResultsIterator
+	filters []*pb.CompositeKeyFilter
+}
+
+// newFilteredResultsIterator wraps the given iterator with one that applies the filters.
+// The iterator is returned as is if there are no filters
+func newFilteredResultsIterator(iter commonledger.ResultsIterator, filters []*pb.CompositeKeyFilter) commonledger.ResultsIterator {
+	if len(filters) == 0 {
+		return iter
+	}
+	filteredIter := &filteredResultsIterator{ResultsIterator: iter, filters: filters}
+	if _, ok := iter.(commonledger.QueryResultsIterator); ok {
+		return &filteredQueryResultsIterator{filteredIter}
+	}
+	return filteredIter
+}
+
+// Next returns the next result whose key satisfies the filters
+func (f *filteredResultsIterator) Next() (commonledger.QueryResult, error) {
+	for {
+		queryResult, err := f.ResultsIterator.Next()
+		if err != nil || queryResult == nil {
+			return queryResult, err
+		}
+		kv, ok := queryResult.(*queryresult.KV)
+		if !ok {
+			return nil, errors.Errorf("unexpected result type %T for a range query", queryResult)
+		}
+		if pb.MatchCompositeKey(kv.Key, f.filters) {
+			return queryResult, nil
+		}
+	}
+}
+
+// filteredQueryResultsIterator preserves the bookmark of a paginated range query. As the
+// results are read one at a time, the bookmark of the wrapped iterator follows the last
+// result returned
+type filteredQueryResultsIterator struct {
+	*filteredResultsIterator
+}
+
+func (f *filteredQueryResultsIterator) GetBookmarkAndClose() string {
+	return f.ResultsIterator.(commonledger.QueryResultsIterator).GetBookmarkAndClose()
+}
+
+// validateFilters checks the filters of a query
+func validateFilters(filters []*pb.CompositeKeyFilter) error {
+	for _, filter := range filters {
+		if filter == nil {
+			return errors.New("nil composite key filter")
+		}
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"strconv"
@@ -33,6 +34,15 @@ import (
 
 var chaincodeLogger = flogging.MustGetLogger("chaincode")
 
+var (
+	// ErrExecutionTimeout is returned when the chaincode does not complete a
+	// transaction within the execute timeout
+	ErrExecutionTimeout = errors.New("timeout expired while executing transaction")
+	// ErrChaincodeDisconnected is returned when the stream of the chaincode ends
+	// while a transaction is executed
+	ErrChaincodeDisconnected = errors.New("chaincode stream terminated while executing transaction")
+)
+
 // An ACLProvider performs access control checks when invoking
 // chaincode.
 type ACLProvider interface {
@@ -121,6 +131,9 @@ type ApplicationConfigRetriever interface {
 type Handler struct {
 	// Keepalive specifies the interval at which keep-alive messages are sent.
 	Keepalive time.Duration
+	// MaxConcurrency limits the number of requests from the chaincode that are
+	// processed concurrently. Zero means no limit.
+	MaxConcurrency int
 	// SystemCCVersion specifies the current system chaincode version
 	SystemCCVersion string
 	// DefinitionGetter is used to retrieve the chaincode definition from the
@@ -166,6 +179,12 @@ type Handler struct {
 	chatStream ccintf.ChaincodeStream
 	// errChan is used to communicate errors from the async send to the receive loop
 	errChan chan error
+	// requestSlots bounds the requests from the chaincode processed concurrently,
+	// nil if there is no limit
+	requestSlots chan struct{}
+	// streamDone is closed when the chat stream ends, to fail the transactions
+	// in flight
+	streamDone chan struct{}
 	// Metrics holds chaincode handler metrics
 	Metrics *HandlerMetrics
 }
@@ -207,6 +226,10 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandlePutState)
 	case pb.ChaincodeMessage_DEL_STATE:
 		go h.HandleTransaction(msg, h.HandleDelState)
+	case pb.ChaincodeMessage_DEL_STATE_RANGE:
+		go h.HandleTransaction(msg, h.HandleDelStateRange)
+	case pb.ChaincodeMessage_INCREMENT_STATE:
+		go h.HandleTransaction(msg, h.HandleIncrementState)
 	case pb.ChaincodeMessage_INVOKE_CHAINCODE:
 		go h.HandleTransaction(msg, h.HandleInvokeChaincode)
 
@@ -214,6 +237,8 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetState)
 	case pb.ChaincodeMessage_GET_STATE_BY_RANGE:
 		go h.HandleTransaction(msg, h.HandleGetStateByRange)
+	case pb.ChaincodeMessage_GET_CHANNEL_CONFIG:
+		go h.HandleTransaction(msg, h.HandleGetChannelConfig)
 	case pb.ChaincodeMessage_GET_QUERY_RESULT:
 		go h.HandleTransaction(msg, h.HandleGetQueryResult)
 	case pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:
@@ -227,6 +252,10 @@ func (h *Handler) handleMessageReadyState(msg *pb.ChaincodeMessage) error {
 		go h.HandleTransaction(msg, h.HandleGetStateMetadata)
 	case pb.ChaincodeMessage_PUT_STATE_METADATA:
 		go h.HandleTransaction(msg, h.HandlePutStateMetadata)
+	case pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH:
+		go h.HandleTransaction(msg, h.HandleGetPrivateDataHash)
+	case pb.ChaincodeMessage_GET_STATE_PROOF:
+		go h.HandleTransaction(msg, h.HandleGetStateProof)
 	default:
 		return fmt.Errorf("[%s] Fabric side handler cannot handle message (%s) while in ready state", msg.Txid, msg.Type)
 	}
@@ -250,6 +279,13 @@ func (h *Handler) HandleTransaction(msg *pb.ChaincodeMessage, delegate handleFun
 		return
 	}
 
+	// the invocations of other chaincodes do not take a slot, as they wait for
+	// the completion of a transaction which may itself need one
+	if h.requestSlots != nil && msg.Type != pb.ChaincodeMessage_INVOKE_CHAINCODE {
+		h.requestSlots <- struct{}{}
+		defer func() { <-h.requestSlots }()
+	}
+
 	startTime := time.Now()
 	var txContext *TransactionContext
 	var err error
@@ -394,6 +430,11 @@ func (h *Handler) ProcessStream(stream ccintf.ChaincodeStream) error {
 
 	h.chatStream = stream
 	h.errChan = make(chan error, 1)
+	h.streamDone = make(chan struct{})
+	defer close(h.streamDone)
+	if h.MaxConcurrency > 0 {
+		h.requestSlots = make(chan struct{}, h.MaxConcurrency)
+	}
 
 	var keepaliveCh <-chan time.Time
 	if h.Keepalive != 0 {
@@ -534,8 +575,18 @@ func (h *Handler) Notify(msg *pb.ChaincodeMessage) {
 	}
 
 	chaincodeLogger.Debugf("[%s] notifying Txid:%s, channelID:%s", shorttxid(msg.Txid), msg.Txid, msg.ChannelId)
-	tctx.ResponseNotifier <- msg
-	tctx.CloseQueryIterators()
+	// the receive loop must not block on a duplicate completion, as it would
+	// stall every other transaction multiplexed over the stream
+	select {
+	case tctx.ResponseNotifier <- msg:
+	default:
+		chaincodeLogger.Warningf("[%s] dropping %s for Txid:%s, channelID:%s: the transaction has already been notified", shorttxid(msg.Txid), msg.Type, msg.Txid, msg.ChannelId)
+		return
+	}
+	if leaked := tctx.CloseQueryIterators(); leaked > 0 {
+		chaincodeLogger.Warningf("[%s] chaincode %s did not close %d query iterator(s) opened by function [%s] on channel %s",
+			shorttxid(msg.Txid), h.ChaincodeName(), leaked, tctx.InvokedFunction(), msg.ChannelId)
+	}
 }
 
 // is this a txid for which there is a valid txsim
@@ -647,6 +698,85 @@ func (h *Handler) HandleGetState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// HandleGetStateProof returns the value of a key along with the proof that it was set to this value by
+// a valid transaction of a block. The read is recorded by the simulator, so that the transaction is
+// invalidated if the key is updated before it is committed
+func (h *Handler) HandleGetStateProof(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getState := &pb.GetState{}
+	err := proto.Unmarshal(msg.Payload, getState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	chaincodeLogger.Debugf("[%s] getting state proof for chaincode %s, key %s, channel %s", shorttxid(msg.Txid), chaincodeName, getState.Key, txContext.ChainID)
+
+	if isCollectionSet(getState.Collection) {
+		return nil, errors.New("the proofs of private data are not supported")
+	}
+	lgr := h.LedgerGetter.GetLedger(txContext.ChainID)
+	if lgr == nil {
+		return nil, errors.Errorf("failed to find ledger for channel: %s", txContext.ChainID)
+	}
+
+	value, err := txContext.TXSimulator.GetState(chaincodeName, getState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	proof, err := lgr.GetStateProof(chaincodeName, getState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if !bytes.Equal(proof.Value, value) {
+		return nil, errors.Errorf("key %s was updated while its proof was retrieved", getState.Key)
+	}
+
+	res, err := proto.Marshal(proof)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// HandleGetPrivateDataHash returns the hash of the value of a private data key. Unlike
+// the private data itself, the hash can be read by the invocations of the creators who
+// are not members of the collection, provided they satisfy the GetPrivateDataHash ACL
+func (h *Handler) HandleGetPrivateDataHash(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	getState := &pb.GetState{}
+	err := proto.Unmarshal(msg.Payload, getState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	chaincodeName := h.ChaincodeName()
+	collection := getState.Collection
+	chaincodeLogger.Debugf("[%s] getting private data hash for chaincode %s, collection %s, key %s, channel %s", shorttxid(msg.Txid), chaincodeName, collection, getState.Key, txContext.ChainID)
+
+	if !isCollectionSet(collection) {
+		return nil, errors.New("the collection is not specified")
+	}
+	if txContext.IsInitTransaction {
+		return nil, errors.New("private data APIs are not allowed in chaincode Init()")
+	}
+	if txContext.SignedProp == nil {
+		return nil, errors.New("signed proposal must not be nil to read private data hashes")
+	}
+	if err := h.ACLProvider.CheckACL(resources.Peer_GetPrivateDataHash, txContext.ChainID, txContext.SignedProp); err != nil {
+		return nil, errors.WithMessage(err, "tx creator is not allowed to read private data hashes")
+	}
+
+	res, err := txContext.TXSimulator.GetPrivateDataHash(chaincodeName, collection, getState.Key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if res == nil {
+		chaincodeLogger.Debugf("[%s] No private data hash associated with key: %s. Sending %s with an empty payload", shorttxid(msg.Txid), getState.Key, pb.ChaincodeMessage_RESPONSE)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles query to ledger to get state metadata
 func (h *Handler) HandleGetStateMetadata(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	err := h.checkMetadataCap(msg)
@@ -706,6 +836,10 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, err
 	}
 
+	if err := validateFilters(metadata.GetFilters()); err != nil {
+		return nil, err
+	}
+
 	totalReturnLimit := calculateTotalReturnLimit(metadata)
 
 	iterID := h.UUIDGenerator.New()
@@ -748,6 +882,7 @@ func (h *Handler) HandleGetStateByRange(msg *pb.ChaincodeMessage, txContext *Tra
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
+	rangeIter = newFilteredResultsIterator(rangeIter, metadata.GetFilters())
 	txContext.InitializeQueryContext(iterID, rangeIter)
 
 	payload, err := h.QueryResponseBuilder.BuildQueryResponse(txContext, rangeIter, iterID, isPaginated, totalReturnLimit)
@@ -985,6 +1120,11 @@ func (h *Handler) getTxContextForInvoke(channelID string, txid string, payload [
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
+	// the chaincodes are initialized by the invocations of the clients only
+	if chaincodeSpec.GetInput().GetIsInit() {
+		return nil, errors.New("a chaincode cannot be invoked as init by another chaincode")
+	}
+
 	// Get the chaincodeID to invoke. The chaincodeID to be called may
 	// contain composite info like "chaincode-name:version/channel-name"
 	// We are not using version now but default to the latest
@@ -1023,6 +1163,9 @@ func (h *Handler) HandlePutState(msg *pb.ChaincodeMessage, txContext *Transactio
 		}
 		err = txContext.TXSimulator.SetPrivateData(chaincodeName, collection, putState.Key, putState.Value)
 	} else {
+		if err := checkReservedKey(putState.Key); err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.SetState(chaincodeName, putState.Key, putState.Value)
 	}
 	if err != nil {
@@ -1055,6 +1198,9 @@ func (h *Handler) HandlePutStateMetadata(msg *pb.ChaincodeMessage, txContext *Tr
 		}
 		err = txContext.TXSimulator.SetPrivateDataMetadata(chaincodeName, collection, putStateMetadata.Key, metadata)
 	} else {
+		if err := checkReservedKey(putStateMetadata.Key); err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.SetStateMetadata(chaincodeName, putStateMetadata.Key, metadata)
 	}
 	if err != nil {
@@ -1079,6 +1225,9 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 		}
 		err = txContext.TXSimulator.DeletePrivateData(chaincodeName, collection, delState.Key)
 	} else {
+		if err := checkReservedKey(delState.Key); err != nil {
+			return nil, err
+		}
 		err = txContext.TXSimulator.DeleteState(chaincodeName, delState.Key)
 	}
 	if err != nil {
@@ -1089,6 +1238,92 @@ func (h *Handler) HandleDelState(msg *pb.ChaincodeMessage, txContext *Transactio
 	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
 }
 
+// HandleIncrementState adds a delta to a counter of the state of the chaincode. The increment
+// is recorded in the writeset and applied to the value committed when the transaction is
+// committed, so it does not read the key
+func (h *Handler) HandleIncrementState(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	incrementState := &pb.IncrementState{}
+	err := proto.Unmarshal(msg.Payload, incrementState)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	if err := checkReservedKey(incrementState.Key); err != nil {
+		return nil, err
+	}
+
+	chaincodeName := h.ChaincodeName()
+	chaincodeLogger.Debugf("[%s] incrementing key [%s] of chaincode %s by %d, channel %s", shorttxid(msg.Txid), incrementState.Key, chaincodeName, incrementState.Delta, txContext.ChainID)
+	err = txContext.TXSimulator.IncrementState(chaincodeName, incrementState.Key, incrementState.Delta)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// HandleDelStateRange deletes the keys of a range of the state of the chaincode. The range
+// is recorded in the readset like a range query, which guards the deletion against the keys
+// added to or removed from the range before the transaction is committed
+func (h *Handler) HandleDelStateRange(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	delStateRange := &pb.GetStateByRange{}
+	err := proto.Unmarshal(msg.Payload, delStateRange)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal failed")
+	}
+
+	if isCollectionSet(delStateRange.Collection) {
+		return nil, errors.New("range deletes are not supported on private data")
+	}
+	// the range is [StartKey, EndKey), an empty EndKey leaving it unbounded
+	if delStateRange.StartKey <= InitializedKeyName && (delStateRange.EndKey == "" || InitializedKeyName < delStateRange.EndKey) {
+		return nil, errors.Errorf("range [%q, %q) contains a key reserved by the peer", delStateRange.StartKey, delStateRange.EndKey)
+	}
+
+	chaincodeName := h.ChaincodeName()
+	chaincodeLogger.Debugf("[%s] deleting range [%s, %s) of chaincode %s, channel %s", shorttxid(msg.Txid), delStateRange.StartKey, delStateRange.EndKey, chaincodeName, txContext.ChainID)
+	err = txContext.TXSimulator.DeleteStateRange(chaincodeName, delStateRange.StartKey, delStateRange.EndKey)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
+// checkReservedKey rejects the writes of the chaincodes to the key of their namespace
+// which records the version they were initialized at
+func checkReservedKey(key string) error {
+	if key == InitializedKeyName {
+		return errors.Errorf("key %q is reserved by the peer", key)
+	}
+	return nil
+}
+
+// HandleGetChannelConfig returns the values of the channel config as of the state read by the
+// transaction. The config is read with the simulator of the transaction, so that all the endorsers
+// return the same values and the transaction is invalidated if the channel config is updated before
+// it is committed
+func (h *Handler) HandleGetChannelConfig(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
+	chaincodeLogger.Debugf("[%s] getting channel config for chaincode %s, channel %s", shorttxid(msg.Txid), h.ChaincodeName(), txContext.ChainID)
+	config, err := peer.ReadChannelConfig(txContext.TXSimulator)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if config == nil {
+		return nil, errors.Errorf("no channel config found for channel %s", txContext.ChainID)
+	}
+	snapshot, err := newChannelConfigSnapshot(config)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the channel config")
+	}
+	res, err := proto.Marshal(snapshot)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal failed")
+	}
+
+	return &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: res, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil
+}
+
 // Handles requests that modify ledger state
 func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *TransactionContext) (*pb.ChaincodeMessage, error) {
 	chaincodeLogger.Debugf("[%s] C-call-C", shorttxid(msg.Txid))
@@ -1099,6 +1334,11 @@ func (h *Handler) HandleInvokeChaincode(msg *pb.ChaincodeMessage, txContext *Tra
 		return nil, errors.Wrap(err, "unmarshal failed")
 	}
 
+	// the chaincodes are initialized by the invocations of the clients only
+	if chaincodeSpec.GetInput().GetIsInit() {
+		return nil, errors.New("a chaincode cannot be invoked as init by another chaincode")
+	}
+
 	// Get the chaincodeID to invoke. The chaincodeID to be called may
 	// contain composite info like "chaincode-name:version/channel-name".
 	// We are not using version now but default to the latest.
@@ -1202,13 +1442,14 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovi
 	defer chaincodeLogger.Debugf("Exit")
 
 	txParams.CollectionStore = h.getCollectionStore(msg.ChannelId)
-	txParams.IsInitTransaction = (msg.Type == pb.ChaincodeMessage_INIT)
+	txParams.IsInitTransaction = (msg.Type == pb.ChaincodeMessage_INIT || msg.Type == pb.ChaincodeMessage_MIGRATE)
 
 	txctx, err := h.TXContexts.Create(txParams)
 	if err != nil {
 		return nil, err
 	}
 	defer h.TXContexts.Delete(msg.ChannelId, msg.Txid)
+	txctx.invocationPayload = msg.Payload
 
 	if err := h.setChaincodeProposal(txParams.SignedProp, txParams.Proposal, msg); err != nil {
 		return nil, err
@@ -1221,8 +1462,10 @@ func (h *Handler) Execute(txParams *ccprovider.TransactionParams, cccid *ccprovi
 	case ccresp = <-txctx.ResponseNotifier:
 		// response is sent to user or calling chaincode. ChaincodeMessage_ERROR
 		// are typically treated as error
+	case <-h.streamDone:
+		err = ErrChaincodeDisconnected
 	case <-time.After(timeout):
-		err = errors.New("timeout expired while executing transaction")
+		err = ErrExecutionTimeout
 		ccName := cccid.Name + ":" + cccid.Version
 		h.Metrics.ExecuteTimeouts.With(
 			"chaincode", ccName,
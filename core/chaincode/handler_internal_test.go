@@ -25,3 +25,11 @@ func SetHandlerChatStream(h *Handler, chatStream ccintf.ChaincodeStream) {
 func SetHandlerCCInstance(h *Handler, ccInstance *sysccprovider.ChaincodeInstance) {
 	h.ccInstance = ccInstance
 }
+
+func SetHandlerRequestSlots(h *Handler, slots int) {
+	h.requestSlots = make(chan struct{}, slots)
+}
+
+func SetHandlerStreamDone(h *Handler, streamDone chan struct{}) {
+	h.streamDone = streamDone
+}
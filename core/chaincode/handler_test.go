@@ -20,6 +20,9 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/mock"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -297,6 +300,36 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the concurrency of the requests is limited", func() {
+			var release chan struct{}
+
+			BeforeEach(func() {
+				chaincode.SetHandlerRequestSlots(handler, 1)
+				release = make(chan struct{})
+				fakeMessageHandler.HandleStub = func(*pb.ChaincodeMessage, *chaincode.TransactionContext) (*pb.ChaincodeMessage, error) {
+					<-release
+					return expectedResponse, nil
+				}
+			})
+
+			It("waits for a slot before calling the delegate", func() {
+				otherMessage := &pb.ChaincodeMessage{
+					Type:      pb.ChaincodeMessage_GET_STATE,
+					Txid:      "other-tx-id",
+					ChannelId: "channel-id",
+				}
+				go handler.HandleTransaction(incomingMessage, fakeMessageHandler.Handle)
+				go handler.HandleTransaction(otherMessage, fakeMessageHandler.Handle)
+
+				Eventually(fakeMessageHandler.HandleCallCount).Should(Equal(1))
+				Consistently(fakeMessageHandler.HandleCallCount).Should(Equal(1))
+
+				close(release)
+				Eventually(fakeMessageHandler.HandleCallCount).Should(Equal(2))
+				Eventually(fakeChatStream.SendCallCount).Should(Equal(2))
+			})
+		})
+
 		Context("when the transaction ID has already been registered", func() {
 			BeforeEach(func() {
 				fakeTransactionRegistry.AddReturns(false)
@@ -575,6 +608,21 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the key is reserved by the peer", func() {
+			BeforeEach(func() {
+				request.Key = chaincode.InitializedKeyName
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutState(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("is reserved by the peer")))
+				Expect(fakeTxSimulator.SetStateCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when the collection is provided", func() {
 			BeforeEach(func() {
 				request.Collection = "collection-name"
@@ -723,6 +771,21 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the key is reserved by the peer", func() {
+			BeforeEach(func() {
+				request.Key = chaincode.InitializedKeyName
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandlePutStateMetadata(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("is reserved by the peer")))
+				Expect(fakeTxSimulator.SetStateMetadataCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when the collection is provided", func() {
 			BeforeEach(func() {
 				request.Collection = "collection-name"
@@ -821,6 +884,21 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the key is reserved by the peer", func() {
+			BeforeEach(func() {
+				request.Key = chaincode.InitializedKeyName
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelState(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("is reserved by the peer")))
+				Expect(fakeTxSimulator.DeleteStateCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when collection is set", func() {
 			BeforeEach(func() {
 				request.Collection = "collection-name"
@@ -864,6 +942,296 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleDelStateRange", func() {
+		var incomingMessage *pb.ChaincodeMessage
+		var request *pb.GetStateByRange
+
+		BeforeEach(func() {
+			request = &pb.GetStateByRange{
+				StartKey: "start-key",
+				EndKey:   "end-key",
+			}
+			payload, err := proto.Marshal(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_DEL_STATE_RANGE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("calls DeleteStateRange on the transaction simulator", func() {
+			resp, err := handler.HandleDelStateRange(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.DeleteStateRangeCallCount()).To(Equal(1))
+			ccname, startKey, endKey := fakeTxSimulator.DeleteStateRangeArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(startKey).To(Equal("start-key"))
+			Expect(endKey).To(Equal("end-key"))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelStateRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when collection is set", func() {
+			BeforeEach(func() {
+				request.Collection = "collection-name"
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelStateRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("range deletes are not supported on private data"))
+				Expect(fakeTxSimulator.DeleteStateRangeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the range contains the key reserved by the peer", func() {
+			It("returns an error", func() {
+				for _, r := range [][2]string{{"", ""}, {"\x00", ""}, {"", "\xff"}, {chaincode.InitializedKeyName, chaincode.InitializedKeyName + "\x00"}} {
+					request.StartKey, request.EndKey = r[0], r[1]
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					_, err = handler.HandleDelStateRange(incomingMessage, txContext)
+					Expect(err).To(MatchError(ContainSubstring("contains a key reserved by the peer")))
+				}
+				Expect(fakeTxSimulator.DeleteStateRangeCallCount()).To(Equal(0))
+			})
+
+			It("deletes the ranges around the key", func() {
+				for _, r := range [][2]string{{"\x01", ""}, {"", chaincode.InitializedKeyName}, {chaincode.InitializedKeyName + "\x00", ""}} {
+					request.StartKey, request.EndKey = r[0], r[1]
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+
+					_, err = handler.HandleDelStateRange(incomingMessage, txContext)
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(fakeTxSimulator.DeleteStateRangeCallCount()).To(Equal(3))
+			})
+		})
+
+		Context("when DeleteStateRange returns an error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.DeleteStateRangeReturns(errors.New("papaya"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleDelStateRange(incomingMessage, txContext)
+				Expect(err).To(MatchError("papaya"))
+			})
+		})
+	})
+
+	Describe("HandleIncrementState", func() {
+		var incomingMessage *pb.ChaincodeMessage
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.IncrementState{
+				Key:   "counter-key",
+				Delta: -7,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_INCREMENT_STATE,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+		})
+
+		It("calls IncrementState on the transaction simulator", func() {
+			resp, err := handler.HandleIncrementState(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.IncrementStateCallCount()).To(Equal(1))
+			ccname, key, delta := fakeTxSimulator.IncrementStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("counter-key"))
+			Expect(delta).To(Equal(int64(-7)))
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleIncrementState(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the key is reserved by the peer", func() {
+			BeforeEach(func() {
+				payload, err := proto.Marshal(&pb.IncrementState{Key: chaincode.InitializedKeyName, Delta: 1})
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleIncrementState(incomingMessage, txContext)
+				Expect(err).To(MatchError(ContainSubstring("is reserved by the peer")))
+				Expect(fakeTxSimulator.IncrementStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when IncrementState returns an error", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.IncrementStateReturns(errors.New("papaya"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleIncrementState(incomingMessage, txContext)
+				Expect(err).To(MatchError("papaya"))
+			})
+		})
+	})
+
+	Describe("HandleGetChannelConfig", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			config          *cb.Config
+		)
+
+		marshalOrFail := func(msg proto.Message) []byte {
+			b, err := proto.Marshal(msg)
+			Expect(err).NotTo(HaveOccurred())
+			return b
+		}
+		capabilitiesValue := func(names ...string) *cb.ConfigValue {
+			capabilities := &cb.Capabilities{Capabilities: map[string]*cb.Capability{}}
+			for _, name := range names {
+				capabilities.Capabilities[name] = &cb.Capability{}
+			}
+			return &cb.ConfigValue{Value: marshalOrFail(capabilities)}
+		}
+		orgGroup := func(mspID string) *cb.ConfigGroup {
+			mspConfig := &mspprotos.MSPConfig{Config: marshalOrFail(&mspprotos.FabricMSPConfig{Name: mspID})}
+			return &cb.ConfigGroup{Values: map[string]*cb.ConfigValue{"MSP": {Value: marshalOrFail(mspConfig)}}}
+		}
+
+		BeforeEach(func() {
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_CHANNEL_CONFIG,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+			config = &cb.Config{
+				Sequence: 3,
+				ChannelGroup: &cb.ConfigGroup{
+					Values: map[string]*cb.ConfigValue{"Capabilities": capabilitiesValue("V1_3")},
+					Groups: map[string]*cb.ConfigGroup{
+						"Orderer": {
+							Values: map[string]*cb.ConfigValue{"Capabilities": capabilitiesValue("V1_1")},
+							Groups: map[string]*cb.ConfigGroup{"OrdererOrg": orgGroup("OrdererMSP")},
+						},
+						"Application": {
+							Values: map[string]*cb.ConfigValue{"Capabilities": capabilitiesValue("V1_3", "V1_2")},
+							Groups: map[string]*cb.ConfigGroup{
+								"Org2":        orgGroup("Org2MSP"),
+								"Org1":        orgGroup("Org1MSP"),
+								"FeeSchedule": {Values: map[string]*cb.ConfigValue{"Fee": {Value: []byte("10")}}},
+							},
+						},
+					},
+				},
+			}
+			fakeTxSimulator.GetStateStub = func(namespace, key string) ([]byte, error) {
+				return marshalOrFail(config), nil
+			}
+		})
+
+		It("returns the values of the channel config read with the transaction simulator", func() {
+			resp, err := handler.HandleGetChannelConfig(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Txid).To(Equal("tx-id"))
+			Expect(resp.ChannelId).To(Equal("channel-id"))
+
+			snapshot := &pb.ChannelConfigSnapshot{}
+			Expect(proto.Unmarshal(resp.Payload, snapshot)).To(Succeed())
+			Expect(snapshot.Sequence).To(Equal(uint64(3)))
+			Expect(snapshot.ChannelCapabilities).To(Equal([]string{"V1_3"}))
+			Expect(snapshot.OrdererCapabilities).To(Equal([]string{"V1_1"}))
+			Expect(snapshot.ApplicationCapabilities).To(Equal([]string{"V1_2", "V1_3"}))
+			Expect(proto.Equal(&pb.ChannelConfigSnapshot{OrdererOrganizations: snapshot.OrdererOrganizations},
+				&pb.ChannelConfigSnapshot{OrdererOrganizations: []*pb.ChannelConfigOrganization{{Name: "OrdererOrg", MspId: "OrdererMSP"}}})).To(BeTrue())
+			Expect(proto.Equal(&pb.ChannelConfigSnapshot{ApplicationOrganizations: snapshot.ApplicationOrganizations},
+				&pb.ChannelConfigSnapshot{ApplicationOrganizations: []*pb.ChannelConfigOrganization{{Name: "Org1", MspId: "Org1MSP"}, {Name: "Org2", MspId: "Org2MSP"}}})).To(BeTrue())
+			Expect(snapshot.ApplicationGroups).To(HaveLen(1))
+			Expect(snapshot.ApplicationGroups["FeeSchedule"].Values["Fee"].Value).To(Equal([]byte("10")))
+
+			Expect(fakeTxSimulator.GetStateCallCount()).To(Equal(1))
+			namespace, key := fakeTxSimulator.GetStateArgsForCall(0)
+			Expect(namespace).To(Equal(""))
+			Expect(key).To(Equal("resourcesconfigtx.CHANNEL_CONFIG_KEY"))
+		})
+
+		Context("when the channel config is not found", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateStub = nil
+				fakeTxSimulator.GetStateReturns(nil, nil)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetChannelConfig(incomingMessage, txContext)
+				Expect(err).To(MatchError("no channel config found for channel channel-id"))
+			})
+		})
+
+		Context("when the transaction simulator fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateStub = nil
+				fakeTxSimulator.GetStateReturns(nil, errors.New("tangerine"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetChannelConfig(incomingMessage, txContext)
+				Expect(err).To(MatchError("tangerine"))
+			})
+		})
+
+		Context("when the MSP of an organization is invalid", func() {
+			BeforeEach(func() {
+				config.ChannelGroup.Groups["Application"].Groups["Org1"].Values["MSP"].Value = []byte("garbage")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetChannelConfig(incomingMessage, txContext)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(HavePrefix("failed to read the channel config: invalid MSP of organization Org1"))
+			})
+		})
+	})
+
 	Describe("HandleGetState", func() {
 		var (
 			incomingMessage  *pb.ChaincodeMessage
@@ -1247,6 +1615,238 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	Describe("HandleGetPrivateDataHash", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			signedProp      *pb.SignedProposal
+		)
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.GetState{Key: "get-state-key", Collection: "collection-name"})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			signedProp = &pb.SignedProposal{ProposalBytes: []byte("signed-proposal-bytes")}
+			txContext.SignedProp = signedProp
+			fakeTxSimulator.GetPrivateDataHashReturns([]byte("value-hash"), nil)
+		})
+
+		It("returns the hash of the private data without checking the collection membership", func() {
+			resp, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(Equal(&pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_RESPONSE,
+				Payload:   []byte("value-hash"),
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}))
+
+			Expect(fakeTxSimulator.GetPrivateDataHashCallCount()).To(Equal(1))
+			ccname, collection, key := fakeTxSimulator.GetPrivateDataHashArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(collection).To(Equal("collection-name"))
+			Expect(key).To(Equal("get-state-key"))
+			Expect(fakeCollectionStore.HasReadAccessCallCount()).To(Equal(0))
+		})
+
+		It("evaluates the access control policy", func() {
+			_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(1))
+			resource, chainID, proposal := fakeACLProvider.CheckACLArgsForCall(0)
+			Expect(resource).To(Equal(resources.Peer_GetPrivateDataHash))
+			Expect(chainID).To(Equal("channel-id"))
+			Expect(proposal).To(Equal(signedProp))
+		})
+
+		Context("when the access control check fails", func() {
+			BeforeEach(func() {
+				fakeACLProvider.CheckACLReturns(errors.New("no-soup-for-you"))
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("tx creator is not allowed to read private data hashes: no-soup-for-you"))
+				Expect(fakeTxSimulator.GetPrivateDataHashCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the signed proposal is missing", func() {
+			BeforeEach(func() {
+				txContext.SignedProp = nil
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("signed proposal must not be nil to read private data hashes"))
+				Expect(fakeACLProvider.CheckACLCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the collection is not set", func() {
+			BeforeEach(func() {
+				payload, err := proto.Marshal(&pb.GetState{Key: "get-state-key"})
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("the collection is not specified"))
+			})
+		})
+
+		Context("when the transaction is an Init transaction", func() {
+			BeforeEach(func() {
+				txContext.IsInitTransaction = true
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("private data APIs are not allowed in chaincode Init()"))
+			})
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+
+		Context("when the ledger fails", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetPrivateDataHashReturns(nil, errors.New("french fries"))
+			})
+
+			It("returns the error", func() {
+				_, err := handler.HandleGetPrivateDataHash(incomingMessage, txContext)
+				Expect(err).To(MatchError("french fries"))
+			})
+		})
+	})
+
+	Describe("HandleGetStateProof", func() {
+		var (
+			incomingMessage *pb.ChaincodeMessage
+			fakePeerLedger  *mock.PeerLedger
+			proof           *queryresult.StateProof
+		)
+
+		BeforeEach(func() {
+			payload, err := proto.Marshal(&pb.GetState{Key: "get-state-key"})
+			Expect(err).NotTo(HaveOccurred())
+
+			incomingMessage = &pb.ChaincodeMessage{
+				Type:      pb.ChaincodeMessage_GET_STATE_PROOF,
+				Payload:   payload,
+				Txid:      "tx-id",
+				ChannelId: "channel-id",
+			}
+
+			proof = &queryresult.StateProof{
+				Namespace:  "cc-instance-name",
+				Key:        "get-state-key",
+				Value:      []byte("get-state-response"),
+				BlockNum:   5,
+				CommitHash: []byte("commit-hash"),
+			}
+			fakeTxSimulator.GetStateReturns([]byte("get-state-response"), nil)
+			fakePeerLedger = &mock.PeerLedger{}
+			fakePeerLedger.GetStateProofReturns(proof, nil)
+			fakeLedgerGetter.GetLedgerReturns(fakePeerLedger)
+		})
+
+		It("returns the proof of the value read by the simulator", func() {
+			resp, err := handler.HandleGetStateProof(incomingMessage, txContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.Type).To(Equal(pb.ChaincodeMessage_RESPONSE))
+			Expect(resp.Txid).To(Equal("tx-id"))
+			Expect(resp.ChannelId).To(Equal("channel-id"))
+			returnedProof := &queryresult.StateProof{}
+			Expect(proto.Unmarshal(resp.Payload, returnedProof)).To(Succeed())
+			Expect(proto.Equal(returnedProof, proof)).To(BeTrue())
+
+			Expect(fakeTxSimulator.GetStateCallCount()).To(Equal(1))
+			ccname, key := fakeTxSimulator.GetStateArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("get-state-key"))
+			Expect(fakeLedgerGetter.GetLedgerArgsForCall(0)).To(Equal("channel-id"))
+			ccname, key = fakePeerLedger.GetStateProofArgsForCall(0)
+			Expect(ccname).To(Equal("cc-instance-name"))
+			Expect(key).To(Equal("get-state-key"))
+		})
+
+		Context("when the key was updated after it was read", func() {
+			BeforeEach(func() {
+				fakeTxSimulator.GetStateReturns([]byte("old-value"), nil)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateProof(incomingMessage, txContext)
+				Expect(err).To(MatchError("key get-state-key was updated while its proof was retrieved"))
+			})
+		})
+
+		Context("when the collection is set", func() {
+			BeforeEach(func() {
+				payload, err := proto.Marshal(&pb.GetState{Key: "get-state-key", Collection: "collection-name"})
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateProof(incomingMessage, txContext)
+				Expect(err).To(MatchError("the proofs of private data are not supported"))
+				Expect(fakeTxSimulator.GetStateCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the ledger cannot be found", func() {
+			BeforeEach(func() {
+				fakeLedgerGetter.GetLedgerReturns(nil)
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateProof(incomingMessage, txContext)
+				Expect(err).To(MatchError("failed to find ledger for channel: channel-id"))
+			})
+		})
+
+		Context("when the proof cannot be retrieved", func() {
+			BeforeEach(func() {
+				fakePeerLedger.GetStateProofReturns(nil, errors.New("no-proof"))
+			})
+
+			It("returns the error", func() {
+				_, err := handler.HandleGetStateProof(incomingMessage, txContext)
+				Expect(err).To(MatchError("no-proof"))
+			})
+		})
+
+		Context("when unmarshalling the request fails", func() {
+			BeforeEach(func() {
+				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
+			})
+
+			It("returns an error", func() {
+				_, err := handler.HandleGetStateProof(incomingMessage, txContext)
+				Expect(err).To(MatchError("unmarshal failed: proto: can't skip unknown wire type 4"))
+			})
+		})
+	})
+
 	Describe("HandleGetStateByRange", func() {
 		var (
 			incomingMessage       *pb.ChaincodeMessage
@@ -1411,6 +2011,70 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when composite key filters are set", func() {
+			BeforeEach(func() {
+				metadata, err := proto.Marshal(&pb.QueryMetadata{
+					Filters: []*pb.CompositeKeyFilter{
+						{Attribute: 0, Operator: pb.CompositeKeyFilter_EQUALS, Value: "blue"},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				request.Metadata = metadata
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+
+				fakeIterator.NextReturnsOnCall(0, &queryresult.KV{Key: "\x00color~name\x00red\x00tom\x00"}, nil)
+				fakeIterator.NextReturnsOnCall(1, &queryresult.KV{Key: "not-a-composite-key"}, nil)
+				fakeIterator.NextReturnsOnCall(2, &queryresult.KV{Key: "\x00color~name\x00blue\x00bob\x00"}, nil)
+				fakeIterator.GetBookmarkAndCloseReturns("bookmark")
+			})
+
+			It("initializes the query context with an iterator returning the matching keys", func() {
+				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				iter := txContext.GetQueryIterator("generated-query-id")
+				Expect(iter).NotTo(Equal(fakeIterator))
+				result, err := iter.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(&queryresult.KV{Key: "\x00color~name\x00blue\x00bob\x00"}))
+				result, err = iter.Next()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(BeNil())
+				Expect(fakeIterator.NextCallCount()).To(Equal(4))
+			})
+
+			It("preserves the bookmark of the ledger iterator", func() {
+				_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				bookmark := txContext.CleanupQueryContextWithBookmark("generated-query-id")
+				Expect(bookmark).To(Equal("bookmark"))
+			})
+
+			Context("and a filter is invalid", func() {
+				BeforeEach(func() {
+					metadata, err := proto.Marshal(&pb.QueryMetadata{
+						Filters: []*pb.CompositeKeyFilter{
+							{Attribute: 1, Operator: pb.CompositeKeyFilter_Operator(42)},
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					request.Metadata = metadata
+					payload, err := proto.Marshal(request)
+					Expect(err).NotTo(HaveOccurred())
+					incomingMessage.Payload = payload
+				})
+
+				It("returns an error", func() {
+					_, err := handler.HandleGetStateByRange(incomingMessage, txContext)
+					Expect(err).To(MatchError("unknown operator 42 in the filter on attribute 1"))
+					Expect(fakeTxSimulator.GetStateRangeScanIteratorCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when unmarshalling the request fails", func() {
 			BeforeEach(func() {
 				incomingMessage.Payload = []byte("this-is-a-bogus-payload")
@@ -2065,6 +2729,21 @@ var _ = Describe("Handler", func() {
 			Expect(name).To(Equal("target-chaincode-name"))
 		})
 
+		Context("when the target is invoked as init", func() {
+			BeforeEach(func() {
+				request.Input = &pb.ChaincodeInput{IsInit: true}
+				payload, err := proto.Marshal(request)
+				Expect(err).NotTo(HaveOccurred())
+				incomingMessage.Payload = payload
+			})
+
+			It("returns an error without invoking the target", func() {
+				_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
+				Expect(err).To(MatchError("a chaincode cannot be invoked as init by another chaincode"))
+				Expect(fakeInvoker.InvokeCallCount()).To(Equal(0))
+			})
+		})
+
 		It("evaluates the access control policy", func() {
 			_, err := handler.HandleInvokeChaincode(incomingMessage, txContext)
 			Expect(err).NotTo(HaveOccurred())
@@ -2377,6 +3056,28 @@ var _ = Describe("Handler", func() {
 
 			Expect(fakeContextRegistry.CreateCallCount()).To(Equal(1))
 			Expect(fakeContextRegistry.CreateArgsForCall(0)).To(Equal(txParams))
+			Expect(txParams.IsInitTransaction).To(BeFalse())
+		})
+
+		Context("when the message is a migration", func() {
+			BeforeEach(func() {
+				incomingMessage.Type = pb.ChaincodeMessage_MIGRATE
+			})
+
+			It("creates an init transaction context", func() {
+				close(responseNotifier)
+				handler.Execute(txParams, cccid, incomingMessage, time.Second)
+
+				Expect(fakeContextRegistry.CreateCallCount()).To(Equal(1))
+				Expect(fakeContextRegistry.CreateArgsForCall(0).IsInitTransaction).To(BeTrue())
+			})
+		})
+
+		It("records the invoked function on the transaction context", func() {
+			close(responseNotifier)
+			handler.Execute(txParams, cccid, incomingMessage, time.Second)
+
+			Expect(txContext.InvokedFunction()).To(Equal("arg1"))
 		})
 
 		It("sends an execute message to the chaincode with the correct proposal", func() {
@@ -2506,6 +3207,21 @@ var _ = Describe("Handler", func() {
 			})
 		})
 
+		Context("when the chaincode stream ends", func() {
+			It("returns an error", func() {
+				streamDone := make(chan struct{})
+				chaincode.SetHandlerStreamDone(handler, streamDone)
+				errCh := make(chan error, 1)
+				go func() {
+					_, err := handler.Execute(txParams, cccid, incomingMessage, time.Minute)
+					errCh <- err
+				}()
+				Consistently(errCh).ShouldNot(Receive())
+				close(streamDone)
+				Eventually(errCh).Should(Receive(Equal(chaincode.ErrChaincodeDisconnected)))
+			})
+		})
+
 		Context("when execute times out", func() {
 			It("returns an error", func() {
 				errCh := make(chan error, 1)
@@ -2884,6 +3600,16 @@ var _ = Describe("Handler", func() {
 			Eventually(fakeIterator.CloseCallCount).Should(Equal(1))
 		})
 
+		Context("when the transaction has already been notified", func() {
+			It("drops the message without blocking", func() {
+				handler.Notify(incomingMessage)
+				handler.Notify(&pb.ChaincodeMessage{Txid: "tx-id", ChannelId: "channel-id"})
+
+				Expect(responseNotifier).To(Receive(Equal(incomingMessage)))
+				Expect(responseNotifier).NotTo(Receive())
+			})
+		})
+
 		Context("when the transaction context cannot be found", func() {
 			BeforeEach(func() {
 				fakeContextRegistry.GetReturns(nil)
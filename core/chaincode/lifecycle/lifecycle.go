@@ -7,8 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package lifecycle
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
 
+	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/pkg/errors"
 )
@@ -23,11 +26,50 @@ type PackageParser interface {
 	Parse(data []byte) (*persistence.ChaincodePackage, error)
 }
 
+// InstalledChaincodesLister lists the chaincodes installed on the peer,
+// whether they were installed through the lifecycle or lscc
+type InstalledChaincodesLister interface {
+	ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error)
+}
+
+// ChannelDefinitions provides the chaincode definitions of the channels
+type ChannelDefinitions interface {
+	// ChaincodeDefinitions returns the chaincodes defined on each channel
+	// of the peer, by channel ID
+	ChaincodeDefinitions() (map[string]chaincode.MetadataSet, error)
+}
+
 // Lifecycle implements the lifecycle operations which are invoked
 // by the SCC as well as internally
 type Lifecycle struct {
-	ChaincodeStore ChaincodeStore
-	PackageParser  PackageParser
+	ChaincodeStore      ChaincodeStore
+	PackageParser       PackageParser
+	InstalledChaincodes InstalledChaincodesLister
+	ChannelDefinitions  ChannelDefinitions
+}
+
+// ChaincodeReference is a chaincode definition of a channel which
+// references an installed chaincode
+type ChaincodeReference struct {
+	ChannelID string
+	Name      string
+	Version   string
+}
+
+// InstalledChaincode is a chaincode installed on the peer along with the
+// chaincode definitions referencing it
+type InstalledChaincode struct {
+	Name       string
+	Version    string
+	Hash       []byte
+	PackageID  string
+	References []ChaincodeReference
+}
+
+// Orphaned returns true when no chaincode definition of the channels of the
+// peer references the installed chaincode, which may then be removed
+func (ic InstalledChaincode) Orphaned() bool {
+	return len(ic.References) == 0
 }
 
 // InstallChaincode installs a given chaincode to the peer's chaincode store.
@@ -56,3 +98,62 @@ func (l *Lifecycle) QueryInstalledChaincode(name, version string) ([]byte, error
 
 	return hash, nil
 }
+
+// QueryInstalledChaincodes returns the chaincodes installed on the peer along
+// with the chaincode definitions of its channels referencing each of them. A
+// definition references an installed chaincode when it is defined with its
+// hash, or with its name and version
+func (l *Lifecycle) QueryInstalledChaincodes() ([]InstalledChaincode, error) {
+	installed, err := l.InstalledChaincodes.ListInstalledChaincodes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not list the installed chaincodes")
+	}
+
+	definitions, err := l.ChannelDefinitions.ChaincodeDefinitions()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not retrieve the chaincode definitions of the channels")
+	}
+	channels := make([]string, 0, len(definitions))
+	for channelID := range definitions {
+		channels = append(channels, channelID)
+	}
+	sort.Strings(channels)
+
+	result := make([]InstalledChaincode, 0, len(installed))
+	for _, ic := range installed {
+		chaincode := InstalledChaincode{
+			Name:      ic.Name,
+			Version:   ic.Version,
+			Hash:      ic.Id,
+			PackageID: ic.PackageID,
+		}
+		for _, channelID := range channels {
+			for _, def := range definitions[channelID] {
+				if !references(def, ic) {
+					continue
+				}
+				chaincode.References = append(chaincode.References, ChaincodeReference{
+					ChannelID: channelID,
+					Name:      def.Name,
+					Version:   def.Version,
+				})
+			}
+		}
+		result = append(result, chaincode)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Version < result[j].Version
+	})
+	return result, nil
+}
+
+func references(def chaincode.Metadata, ic chaincode.InstalledChaincode) bool {
+	if len(def.Id) != 0 && bytes.Equal(def.Id, ic.Id) {
+		return true
+	}
+	return def.Name == ic.Name && def.Version == ic.Version
+}
@@ -30,6 +30,16 @@ type packageParser interface {
 	lifecycle.PackageParser
 }
 
+//go:generate counterfeiter -o mock/installed_chaincodes_lister.go --fake-name InstalledChaincodesLister . installedChaincodesLister
+type installedChaincodesLister interface {
+	lifecycle.InstalledChaincodesLister
+}
+
+//go:generate counterfeiter -o mock/channel_definitions.go --fake-name ChannelDefinitions . channelDefinitions
+type channelDefinitions interface {
+	lifecycle.ChannelDefinitions
+}
+
 //go:generate counterfeiter -o mock/scc_functions.go --fake-name SCCFunctions . sccFunctions
 type sccFunctions interface {
 	lifecycle.SCCFunctions
@@ -9,6 +9,7 @@ package lifecycle_test
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	. "github.com/onsi/ginkgo"
@@ -17,18 +18,24 @@ import (
 
 var _ = Describe("Lifecycle", func() {
 	var (
-		l           *lifecycle.Lifecycle
-		fakeCCStore *mock.ChaincodeStore
-		fakeParser  *mock.PackageParser
+		l                *lifecycle.Lifecycle
+		fakeCCStore      *mock.ChaincodeStore
+		fakeParser       *mock.PackageParser
+		fakeInstalledCCs *mock.InstalledChaincodesLister
+		fakeDefinitions  *mock.ChannelDefinitions
 	)
 
 	BeforeEach(func() {
 		fakeCCStore = &mock.ChaincodeStore{}
 		fakeParser = &mock.PackageParser{}
+		fakeInstalledCCs = &mock.InstalledChaincodesLister{}
+		fakeDefinitions = &mock.ChannelDefinitions{}
 
 		l = &lifecycle.Lifecycle{
-			PackageParser:  fakeParser,
-			ChaincodeStore: fakeCCStore,
+			PackageParser:       fakeParser,
+			ChaincodeStore:      fakeCCStore,
+			InstalledChaincodes: fakeInstalledCCs,
+			ChannelDefinitions:  fakeDefinitions,
 		}
 	})
 
@@ -103,4 +110,75 @@ var _ = Describe("Lifecycle", func() {
 			})
 		})
 	})
+
+	Describe("QueryInstalledChaincodes", func() {
+		BeforeEach(func() {
+			fakeInstalledCCs.ListInstalledChaincodesReturns([]chaincode.InstalledChaincode{
+				{Name: "mycc", Version: "2.0", Id: []byte("hash-2")},
+				{Name: "mycc", Version: "1.0", Id: []byte("hash-1"), PackageID: "mycc_1.0:digest-1"},
+				{Name: "other", Version: "1.0", Id: []byte("hash-3")},
+				{Name: "renamed", Version: "1.0", Id: []byte("hash-4")},
+			}, nil)
+			fakeDefinitions.ChaincodeDefinitionsReturns(map[string]chaincode.MetadataSet{
+				"channel2": {
+					{Name: "mycc", Version: "1.0", Id: []byte("hash-1")},
+				},
+				"channel1": {
+					{Name: "mycc", Version: "1.0"},
+					{Name: "alias", Version: "3.0", Id: []byte("hash-4")},
+				},
+			}, nil)
+		})
+
+		It("returns the installed chaincodes with the definitions referencing them", func() {
+			chaincodes, err := l.QueryInstalledChaincodes()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(chaincodes).To(Equal([]lifecycle.InstalledChaincode{
+				{
+					Name:      "mycc",
+					Version:   "1.0",
+					Hash:      []byte("hash-1"),
+					PackageID: "mycc_1.0:digest-1",
+					References: []lifecycle.ChaincodeReference{
+						{ChannelID: "channel1", Name: "mycc", Version: "1.0"},
+						{ChannelID: "channel2", Name: "mycc", Version: "1.0"},
+					},
+				},
+				{Name: "mycc", Version: "2.0", Hash: []byte("hash-2")},
+				{Name: "other", Version: "1.0", Hash: []byte("hash-3")},
+				{
+					Name:    "renamed",
+					Version: "1.0",
+					Hash:    []byte("hash-4"),
+					References: []lifecycle.ChaincodeReference{
+						{ChannelID: "channel1", Name: "alias", Version: "3.0"},
+					},
+				},
+			}))
+			Expect(chaincodes[0].Orphaned()).To(BeFalse())
+			Expect(chaincodes[1].Orphaned()).To(BeTrue())
+		})
+
+		Context("when listing the installed chaincodes fails", func() {
+			BeforeEach(func() {
+				fakeInstalledCCs.ListInstalledChaincodesReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.QueryInstalledChaincodes()
+				Expect(err).To(MatchError("could not list the installed chaincodes: fake-error"))
+			})
+		})
+
+		Context("when retrieving the chaincode definitions fails", func() {
+			BeforeEach(func() {
+				fakeDefinitions.ChaincodeDefinitionsReturns(nil, fmt.Errorf("fake-error"))
+			})
+
+			It("wraps and returns the error", func() {
+				_, err := l.QueryInstalledChaincodes()
+				Expect(err).To(MatchError("could not retrieve the chaincode definitions of the channels: fake-error"))
+			})
+		})
+	})
 })
@@ -3,6 +3,8 @@ package mock
 
 import (
 	sync "sync"
+
+	lifecycle "github.com/hyperledger/fabric/core/chaincode/lifecycle"
 )
 
 type SCCFunctions struct {
@@ -35,6 +37,18 @@ type SCCFunctions struct {
 		result1 []byte
 		result2 error
 	}
+	QueryInstalledChaincodesStub        func() ([]lifecycle.InstalledChaincode, error)
+	queryInstalledChaincodesMutex       sync.RWMutex
+	queryInstalledChaincodesArgsForCall []struct {
+	}
+	queryInstalledChaincodesReturns struct {
+		result1 []lifecycle.InstalledChaincode
+		result2 error
+	}
+	queryInstalledChaincodesReturnsOnCall map[int]struct {
+		result1 []lifecycle.InstalledChaincode
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -173,6 +187,61 @@ func (fake *SCCFunctions) QueryInstalledChaincodeReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) QueryInstalledChaincodes() ([]lifecycle.InstalledChaincode, error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	ret, specificReturn := fake.queryInstalledChaincodesReturnsOnCall[len(fake.queryInstalledChaincodesArgsForCall)]
+	fake.queryInstalledChaincodesArgsForCall = append(fake.queryInstalledChaincodesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QueryInstalledChaincodes", []interface{}{})
+	fake.queryInstalledChaincodesMutex.Unlock()
+	if fake.QueryInstalledChaincodesStub != nil {
+		return fake.QueryInstalledChaincodesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.queryInstalledChaincodesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesCallCount() int {
+	fake.queryInstalledChaincodesMutex.RLock()
+	defer fake.queryInstalledChaincodesMutex.RUnlock()
+	return len(fake.queryInstalledChaincodesArgsForCall)
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesCalls(stub func() ([]lifecycle.InstalledChaincode, error)) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = stub
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesReturns(result1 []lifecycle.InstalledChaincode, result2 error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = nil
+	fake.queryInstalledChaincodesReturns = struct {
+		result1 []lifecycle.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) QueryInstalledChaincodesReturnsOnCall(i int, result1 []lifecycle.InstalledChaincode, result2 error) {
+	fake.queryInstalledChaincodesMutex.Lock()
+	defer fake.queryInstalledChaincodesMutex.Unlock()
+	fake.QueryInstalledChaincodesStub = nil
+	if fake.queryInstalledChaincodesReturnsOnCall == nil {
+		fake.queryInstalledChaincodesReturnsOnCall = make(map[int]struct {
+			result1 []lifecycle.InstalledChaincode
+			result2 error
+		})
+	}
+	fake.queryInstalledChaincodesReturnsOnCall[i] = struct {
+		result1 []lifecycle.InstalledChaincode
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -180,6 +249,8 @@ func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	defer fake.installChaincodeMutex.RUnlock()
 	fake.queryInstalledChaincodeMutex.RLock()
 	defer fake.queryInstalledChaincodeMutex.RUnlock()
+	fake.queryInstalledChaincodesMutex.RLock()
+	defer fake.queryInstalledChaincodesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
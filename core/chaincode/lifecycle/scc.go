@@ -21,6 +21,10 @@ const (
 
 	// QueryInstalledChaincodeFuncName is the chaincode function name used to query an installed chaincode
 	QueryInstalledChaincodeFuncName = "QueryInstalledChaincode"
+
+	// QueryInstalledChaincodesFuncName is the chaincode function name used to query
+	// the installed chaincodes along with the chaincode definitions referencing them
+	QueryInstalledChaincodesFuncName = "QueryInstalledChaincodes"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -31,6 +35,10 @@ type SCCFunctions interface {
 
 	// QueryInstalledChaincode returns the hash for a given name and version of an installed chaincode
 	QueryInstalledChaincode(name, version string) (hash []byte, err error)
+
+	// QueryInstalledChaincodes returns the installed chaincodes along with the
+	// chaincode definitions referencing them
+	QueryInstalledChaincodes() ([]InstalledChaincode, error)
 }
 
 // SCC implements the required methods to satisfy the chaincode interface.
@@ -145,6 +153,45 @@ func (scc *SCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 			return shim.Error(err.Error())
 		}
 
+		return shim.Success(resultBytes)
+	case QueryInstalledChaincodesFuncName:
+		input := &lb.QueryInstalledChaincodesArgs{}
+		err := scc.Protobuf.Unmarshal(inputBytes, input)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to decode input arg to QueryInstalledChaincodes")
+			return shim.Error(err.Error())
+		}
+
+		chaincodes, err := scc.Functions.QueryInstalledChaincodes()
+		if err != nil {
+			err = errors.WithMessage(err, "failed to invoke backing QueryInstalledChaincodes")
+			return shim.Error(err.Error())
+		}
+
+		result := &lb.QueryInstalledChaincodesResult{}
+		for _, chaincode := range chaincodes {
+			installed := &lb.QueryInstalledChaincodesResult_InstalledChaincode{
+				Name:      chaincode.Name,
+				Version:   chaincode.Version,
+				Hash:      chaincode.Hash,
+				PackageId: chaincode.PackageID,
+			}
+			for _, ref := range chaincode.References {
+				installed.References = append(installed.References, &lb.QueryInstalledChaincodesResult_Reference{
+					ChannelId: ref.ChannelID,
+					Name:      ref.Name,
+					Version:   ref.Version,
+				})
+			}
+			result.InstalledChaincodes = append(result.InstalledChaincodes, installed)
+		}
+
+		resultBytes, err := scc.Protobuf.Marshal(result)
+		if err != nil {
+			err = errors.WithMessage(err, "failed to marshal result")
+			return shim.Error(err.Error())
+		}
+
 		return shim.Success(resultBytes)
 	default:
 		return shim.Error(fmt.Sprintf("unknown lifecycle function: %s", funcName))
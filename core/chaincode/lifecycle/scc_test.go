@@ -268,5 +268,90 @@ var _ = Describe("SCC", func() {
 				})
 			})
 		})
+
+		Describe("QueryInstalledChaincodes", func() {
+			BeforeEach(func() {
+				marshaledArg, err := proto.Marshal(&lb.QueryInstalledChaincodesArgs{})
+				Expect(err).NotTo(HaveOccurred())
+
+				fakeStub.GetArgsReturns([][]byte{[]byte("QueryInstalledChaincodes"), marshaledArg})
+
+				fakeProto.UnmarshalStub = proto.Unmarshal
+				fakeProto.MarshalStub = proto.Marshal
+
+				fakeSCCFuncs.QueryInstalledChaincodesReturns([]lifecycle.InstalledChaincode{
+					{
+						Name:      "mycc",
+						Version:   "1.0",
+						Hash:      []byte("hash-1"),
+						PackageID: "mycc_1.0:digest-1",
+						References: []lifecycle.ChaincodeReference{
+							{ChannelID: "channel1", Name: "mycc", Version: "1.0"},
+						},
+					},
+					{Name: "mycc", Version: "0.9", Hash: []byte("hash-0")},
+				}, nil)
+			})
+
+			It("returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &lb.QueryInstalledChaincodesResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(payload, &lb.QueryInstalledChaincodesResult{
+					InstalledChaincodes: []*lb.QueryInstalledChaincodesResult_InstalledChaincode{
+						{
+							Name:      "mycc",
+							Version:   "1.0",
+							Hash:      []byte("hash-1"),
+							PackageId: "mycc_1.0:digest-1",
+							References: []*lb.QueryInstalledChaincodesResult_Reference{
+								{ChannelId: "channel1", Name: "mycc", Version: "1.0"},
+							},
+						},
+						{Name: "mycc", Version: "0.9", Hash: []byte("hash-0")},
+					},
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.QueryInstalledChaincodesCallCount()).To(Equal(1))
+			})
+
+			Context("when the underlying function implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.QueryInstalledChaincodesReturns(nil, fmt.Errorf("underlying-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing QueryInstalledChaincodes: underlying-error"))
+				})
+			})
+
+			Context("when unmarshaling the input fails", func() {
+				BeforeEach(func() {
+					fakeProto.UnmarshalReturns(fmt.Errorf("unmarshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to decode input arg to QueryInstalledChaincodes: unmarshal-error"))
+				})
+			})
+
+			Context("when marshaling the output fails", func() {
+				BeforeEach(func() {
+					fakeProto.MarshalReturns(nil, fmt.Errorf("marshal-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to marshal result: marshal-error"))
+				})
+			})
+		})
 	})
 })
@@ -7,6 +7,7 @@ import (
 	ledger "github.com/hyperledger/fabric/common/ledger"
 	ledgera "github.com/hyperledger/fabric/core/ledger"
 	common "github.com/hyperledger/fabric/protos/common"
+	queryresult "github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -39,6 +40,11 @@ type PeerLedger struct {
 	commitWithPvtDataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	EnableCommitHashStub        func(bool)
+	enableCommitHashMutex       sync.RWMutex
+	enableCommitHashArgsForCall []struct {
+		arg1 bool
+	}
 	GetBlockByHashStub        func([]byte) (*common.Block, error)
 	getBlockByHashMutex       sync.RWMutex
 	getBlockByHashArgsForCall []struct {
@@ -155,6 +161,20 @@ type PeerLedger struct {
 		result1 []*ledgera.TxPvtData
 		result2 error
 	}
+	GetStateProofStub        func(string, string) (*queryresult.StateProof, error)
+	getStateProofMutex       sync.RWMutex
+	getStateProofArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getStateProofReturns struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}
+	getStateProofReturnsOnCall map[int]struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}
 	GetTransactionByIDStub        func(string) (*peer.ProcessedTransaction, error)
 	getTransactionByIDMutex       sync.RWMutex
 	getTransactionByIDArgsForCall []struct {
@@ -407,6 +427,37 @@ func (fake *PeerLedger) CommitWithPvtDataReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *PeerLedger) EnableCommitHash(arg1 bool) {
+	fake.enableCommitHashMutex.Lock()
+	fake.enableCommitHashArgsForCall = append(fake.enableCommitHashArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("EnableCommitHash", []interface{}{arg1})
+	fake.enableCommitHashMutex.Unlock()
+	if fake.EnableCommitHashStub != nil {
+		fake.EnableCommitHashStub(arg1)
+	}
+}
+
+func (fake *PeerLedger) EnableCommitHashCallCount() int {
+	fake.enableCommitHashMutex.RLock()
+	defer fake.enableCommitHashMutex.RUnlock()
+	return len(fake.enableCommitHashArgsForCall)
+}
+
+func (fake *PeerLedger) EnableCommitHashCalls(stub func(bool)) {
+	fake.enableCommitHashMutex.Lock()
+	defer fake.enableCommitHashMutex.Unlock()
+	fake.EnableCommitHashStub = stub
+}
+
+func (fake *PeerLedger) EnableCommitHashArgsForCall(i int) bool {
+	fake.enableCommitHashMutex.RLock()
+	defer fake.enableCommitHashMutex.RUnlock()
+	argsForCall := fake.enableCommitHashArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *PeerLedger) GetBlockByHash(arg1 []byte) (*common.Block, error) {
 	var arg1Copy []byte
 	if arg1 != nil {
@@ -957,6 +1008,70 @@ func (fake *PeerLedger) GetPvtDataByNumReturnsOnCall(i int, result1 []*ledgera.T
 	}{result1, result2}
 }
 
+func (fake *PeerLedger) GetStateProof(arg1 string, arg2 string) (*queryresult.StateProof, error) {
+	fake.getStateProofMutex.Lock()
+	ret, specificReturn := fake.getStateProofReturnsOnCall[len(fake.getStateProofArgsForCall)]
+	fake.getStateProofArgsForCall = append(fake.getStateProofArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetStateProof", []interface{}{arg1, arg2})
+	fake.getStateProofMutex.Unlock()
+	if fake.GetStateProofStub != nil {
+		return fake.GetStateProofStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateProofReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PeerLedger) GetStateProofCallCount() int {
+	fake.getStateProofMutex.RLock()
+	defer fake.getStateProofMutex.RUnlock()
+	return len(fake.getStateProofArgsForCall)
+}
+
+func (fake *PeerLedger) GetStateProofCalls(stub func(string, string) (*queryresult.StateProof, error)) {
+	fake.getStateProofMutex.Lock()
+	defer fake.getStateProofMutex.Unlock()
+	fake.GetStateProofStub = stub
+}
+
+func (fake *PeerLedger) GetStateProofArgsForCall(i int) (string, string) {
+	fake.getStateProofMutex.RLock()
+	defer fake.getStateProofMutex.RUnlock()
+	argsForCall := fake.getStateProofArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *PeerLedger) GetStateProofReturns(result1 *queryresult.StateProof, result2 error) {
+	fake.getStateProofMutex.Lock()
+	defer fake.getStateProofMutex.Unlock()
+	fake.GetStateProofStub = nil
+	fake.getStateProofReturns = struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PeerLedger) GetStateProofReturnsOnCall(i int, result1 *queryresult.StateProof, result2 error) {
+	fake.getStateProofMutex.Lock()
+	defer fake.getStateProofMutex.Unlock()
+	fake.GetStateProofStub = nil
+	if fake.getStateProofReturnsOnCall == nil {
+		fake.getStateProofReturnsOnCall = make(map[int]struct {
+			result1 *queryresult.StateProof
+			result2 error
+		})
+	}
+	fake.getStateProofReturnsOnCall[i] = struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PeerLedger) GetTransactionByID(arg1 string) (*peer.ProcessedTransaction, error) {
 	fake.getTransactionByIDMutex.Lock()
 	ret, specificReturn := fake.getTransactionByIDReturnsOnCall[len(fake.getTransactionByIDArgsForCall)]
@@ -1440,6 +1555,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.commitPvtDataOfOldBlocksMutex.RUnlock()
 	fake.commitWithPvtDataMutex.RLock()
 	defer fake.commitWithPvtDataMutex.RUnlock()
+	fake.enableCommitHashMutex.RLock()
+	defer fake.enableCommitHashMutex.RUnlock()
 	fake.getBlockByHashMutex.RLock()
 	defer fake.getBlockByHashMutex.RUnlock()
 	fake.getBlockByNumberMutex.RLock()
@@ -1458,6 +1575,8 @@ func (fake *PeerLedger) Invocations() map[string][][]interface{} {
 	defer fake.getPvtDataAndBlockByNumMutex.RUnlock()
 	fake.getPvtDataByNumMutex.RLock()
 	defer fake.getPvtDataByNumMutex.RUnlock()
+	fake.getStateProofMutex.RLock()
+	defer fake.getStateProofMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
 	fake.getTxValidationCodeByTxIDMutex.RLock()
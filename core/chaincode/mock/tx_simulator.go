@@ -59,6 +59,19 @@ type TxSimulator struct {
 	deleteStateMetadataReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteStateRangeStub        func(string, string, string) error
+	deleteStateRangeMutex       sync.RWMutex
+	deleteStateRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	deleteStateRangeReturns struct {
+		result1 error
+	}
+	deleteStateRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	DoneStub        func()
 	doneMutex       sync.RWMutex
 	doneArgsForCall []struct {
@@ -133,6 +146,21 @@ type TxSimulator struct {
 		result1 []byte
 		result2 error
 	}
+	GetPrivateDataHashStub        func(string, string, string) ([]byte, error)
+	getPrivateDataHashMutex       sync.RWMutex
+	getPrivateDataHashArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getPrivateDataHashReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPrivateDataHashReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	GetPrivateDataMetadataStub        func(string, string, string) (map[string][]byte, error)
 	getPrivateDataMetadataMutex       sync.RWMutex
 	getPrivateDataMetadataArgsForCall []struct {
@@ -279,6 +307,19 @@ type TxSimulator struct {
 		result1 *ledgera.TxSimulationResults
 		result2 error
 	}
+	IncrementStateStub        func(string, string, int64) error
+	incrementStateMutex       sync.RWMutex
+	incrementStateArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}
+	incrementStateReturns struct {
+		result1 error
+	}
+	incrementStateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetPrivateDataStub        func(string, string, string, []byte) error
 	setPrivateDataMutex       sync.RWMutex
 	setPrivateDataArgsForCall []struct {
@@ -569,6 +610,8 @@ func (fake *TxSimulator) DeleteStateMetadata(arg1 string, arg2 string) error {
 func (fake *TxSimulator) DeleteStateMetadataCallCount() int {
 	fake.deleteStateMetadataMutex.RLock()
 	defer fake.deleteStateMetadataMutex.RUnlock()
+	fake.deleteStateRangeMutex.RLock()
+	defer fake.deleteStateRangeMutex.RUnlock()
 	return len(fake.deleteStateMetadataArgsForCall)
 }
 
@@ -581,6 +624,8 @@ func (fake *TxSimulator) DeleteStateMetadataCalls(stub func(string, string) erro
 func (fake *TxSimulator) DeleteStateMetadataArgsForCall(i int) (string, string) {
 	fake.deleteStateMetadataMutex.RLock()
 	defer fake.deleteStateMetadataMutex.RUnlock()
+	fake.deleteStateRangeMutex.RLock()
+	defer fake.deleteStateRangeMutex.RUnlock()
 	argsForCall := fake.deleteStateMetadataArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2
 }
@@ -608,6 +653,68 @@ func (fake *TxSimulator) DeleteStateMetadataReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *TxSimulator) DeleteStateRange(arg1 string, arg2 string, arg3 string) error {
+	fake.deleteStateRangeMutex.Lock()
+	ret, specificReturn := fake.deleteStateRangeReturnsOnCall[len(fake.deleteStateRangeArgsForCall)]
+	fake.deleteStateRangeArgsForCall = append(fake.deleteStateRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteStateRange", []interface{}{arg1, arg2, arg3})
+	fake.deleteStateRangeMutex.Unlock()
+	if fake.DeleteStateRangeStub != nil {
+		return fake.DeleteStateRangeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteStateRangeReturns
+	return fakeReturns.result1
+}
+
+func (fake *TxSimulator) DeleteStateRangeCallCount() int {
+	fake.deleteStateRangeMutex.RLock()
+	defer fake.deleteStateRangeMutex.RUnlock()
+	return len(fake.deleteStateRangeArgsForCall)
+}
+
+func (fake *TxSimulator) DeleteStateRangeCalls(stub func(string, string, string) error) {
+	fake.deleteStateRangeMutex.Lock()
+	defer fake.deleteStateRangeMutex.Unlock()
+	fake.DeleteStateRangeStub = stub
+}
+
+func (fake *TxSimulator) DeleteStateRangeArgsForCall(i int) (string, string, string) {
+	fake.deleteStateRangeMutex.RLock()
+	defer fake.deleteStateRangeMutex.RUnlock()
+	argsForCall := fake.deleteStateRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TxSimulator) DeleteStateRangeReturns(result1 error) {
+	fake.deleteStateRangeMutex.Lock()
+	defer fake.deleteStateRangeMutex.Unlock()
+	fake.DeleteStateRangeStub = nil
+	fake.deleteStateRangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TxSimulator) DeleteStateRangeReturnsOnCall(i int, result1 error) {
+	fake.deleteStateRangeMutex.Lock()
+	defer fake.deleteStateRangeMutex.Unlock()
+	fake.DeleteStateRangeStub = nil
+	if fake.deleteStateRangeReturnsOnCall == nil {
+		fake.deleteStateRangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteStateRangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *TxSimulator) Done() {
 	fake.doneMutex.Lock()
 	fake.doneArgsForCall = append(fake.doneArgsForCall, struct {
@@ -950,6 +1057,71 @@ func (fake *TxSimulator) GetPrivateDataReturnsOnCall(i int, result1 []byte, resu
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) GetPrivateDataHash(arg1 string, arg2 string, arg3 string) ([]byte, error) {
+	fake.getPrivateDataHashMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHashReturnsOnCall[len(fake.getPrivateDataHashArgsForCall)]
+	fake.getPrivateDataHashArgsForCall = append(fake.getPrivateDataHashArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetPrivateDataHash", []interface{}{arg1, arg2, arg3})
+	fake.getPrivateDataHashMutex.Unlock()
+	if fake.GetPrivateDataHashStub != nil {
+		return fake.GetPrivateDataHashStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *TxSimulator) GetPrivateDataHashCallCount() int {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	return len(fake.getPrivateDataHashArgsForCall)
+}
+
+func (fake *TxSimulator) GetPrivateDataHashCalls(stub func(string, string, string) ([]byte, error)) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = stub
+}
+
+func (fake *TxSimulator) GetPrivateDataHashArgsForCall(i int) (string, string, string) {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TxSimulator) GetPrivateDataHashReturns(result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	fake.getPrivateDataHashReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TxSimulator) GetPrivateDataHashReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	if fake.getPrivateDataHashReturnsOnCall == nil {
+		fake.getPrivateDataHashReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPrivateDataHashReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TxSimulator) GetPrivateDataMetadata(arg1 string, arg2 string, arg3 string) (map[string][]byte, error) {
 	fake.getPrivateDataMetadataMutex.Lock()
 	ret, specificReturn := fake.getPrivateDataMetadataReturnsOnCall[len(fake.getPrivateDataMetadataArgsForCall)]
@@ -1604,6 +1776,68 @@ func (fake *TxSimulator) GetTxSimulationResultsReturnsOnCall(i int, result1 *led
 	}{result1, result2}
 }
 
+func (fake *TxSimulator) IncrementState(arg1 string, arg2 string, arg3 int64) error {
+	fake.incrementStateMutex.Lock()
+	ret, specificReturn := fake.incrementStateReturnsOnCall[len(fake.incrementStateArgsForCall)]
+	fake.incrementStateArgsForCall = append(fake.incrementStateArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int64
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("IncrementState", []interface{}{arg1, arg2, arg3})
+	fake.incrementStateMutex.Unlock()
+	if fake.IncrementStateStub != nil {
+		return fake.IncrementStateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.incrementStateReturns
+	return fakeReturns.result1
+}
+
+func (fake *TxSimulator) IncrementStateCallCount() int {
+	fake.incrementStateMutex.RLock()
+	defer fake.incrementStateMutex.RUnlock()
+	return len(fake.incrementStateArgsForCall)
+}
+
+func (fake *TxSimulator) IncrementStateCalls(stub func(string, string, int64) error) {
+	fake.incrementStateMutex.Lock()
+	defer fake.incrementStateMutex.Unlock()
+	fake.IncrementStateStub = stub
+}
+
+func (fake *TxSimulator) IncrementStateArgsForCall(i int) (string, string, int64) {
+	fake.incrementStateMutex.RLock()
+	defer fake.incrementStateMutex.RUnlock()
+	argsForCall := fake.incrementStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *TxSimulator) IncrementStateReturns(result1 error) {
+	fake.incrementStateMutex.Lock()
+	defer fake.incrementStateMutex.Unlock()
+	fake.IncrementStateStub = nil
+	fake.incrementStateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *TxSimulator) IncrementStateReturnsOnCall(i int, result1 error) {
+	fake.incrementStateMutex.Lock()
+	defer fake.incrementStateMutex.Unlock()
+	fake.IncrementStateStub = nil
+	if fake.incrementStateReturnsOnCall == nil {
+		fake.incrementStateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.incrementStateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *TxSimulator) SetPrivateData(arg1 string, arg2 string, arg3 string, arg4 []byte) error {
 	var arg4Copy []byte
 	if arg4 != nil {
@@ -1631,6 +1865,8 @@ func (fake *TxSimulator) SetPrivateData(arg1 string, arg2 string, arg3 string, a
 }
 
 func (fake *TxSimulator) SetPrivateDataCallCount() int {
+	fake.incrementStateMutex.RLock()
+	defer fake.incrementStateMutex.RUnlock()
 	fake.setPrivateDataMutex.RLock()
 	defer fake.setPrivateDataMutex.RUnlock()
 	return len(fake.setPrivateDataArgsForCall)
@@ -1998,6 +2234,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.deleteStateMutex.RUnlock()
 	fake.deleteStateMetadataMutex.RLock()
 	defer fake.deleteStateMetadataMutex.RUnlock()
+	fake.deleteStateRangeMutex.RLock()
+	defer fake.deleteStateRangeMutex.RUnlock()
 	fake.doneMutex.RLock()
 	defer fake.doneMutex.RUnlock()
 	fake.executeQueryMutex.RLock()
@@ -2010,6 +2248,8 @@ func (fake *TxSimulator) Invocations() map[string][][]interface{} {
 	defer fake.executeUpdateMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
 	fake.getPrivateDataMetadataMutex.RLock()
 	defer fake.getPrivateDataMetadataMutex.RUnlock()
 	fake.getPrivateDataMetadataByHashMutex.RLock()
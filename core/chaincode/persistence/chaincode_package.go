@@ -36,10 +36,12 @@ type ChaincodePackage struct {
 }
 
 // ChaincodePackageMetadata contains the information necessary to understand
-// the embedded code package.
+// the embedded code package. The optional Label is the prefix of the ID of the
+// package, which otherwise derives from the name and version it is installed with.
 type ChaincodePackageMetadata struct {
-	Type string `json:"Type"`
-	Path string `json:"Path"`
+	Type  string `json:"Type"`
+	Path  string `json:"Path"`
+	Label string `json:"Label,omitempty"`
 }
 
 // ChaincodePackageParser provides the ability to parse chaincode packages
@@ -82,6 +84,11 @@ func (ccpp ChaincodePackageParser) Parse(source []byte) (*ChaincodePackage, erro
 			if err != nil {
 				return nil, errors.Wrapf(err, "could not unmarshal %s as json", ChaincodePackageMetadataFile)
 			}
+			if ccPackageMetadata.Label != "" {
+				if err := ValidateLabel(ccPackageMetadata.Label); err != nil {
+					return nil, errors.WithMessage(err, "invalid "+ChaincodePackageMetadataFile)
+				}
+			}
 
 			continue
 		}
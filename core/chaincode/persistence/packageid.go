@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package persistence
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// The ID of a chaincode package is its label followed by a digest of its
+// content, label:digest. The digest is computed by a versioned algorithm, and
+// the version used for a package is recorded along with its ID when it is
+// installed, so that a package keeps its ID across upgrades of the peer. Once
+// released, the computation of a version must never change; a change of the
+// computation requires a new version.
+const (
+	// PackageIDV1 digests the normalized content of the package: the names
+	// and the contents of its entries in the order of their names, with the
+	// nested tar.gz archives normalized in the same way. The modification
+	// times, owners and modes of the entries, their order in the archive and
+	// the gzip compression are ignored.
+	PackageIDV1 = 1
+
+	// CurrentPackageIDVersion is the version of the computation of the IDs of
+	// the packages installed from now on
+	CurrentPackageIDVersion = PackageIDV1
+)
+
+var labelRegexp = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.+-]*$`)
+
+// ValidateLabel checks that a package label is made of alphanumerics and of
+// the '_', '.', '+' and '-' characters, and starts with an alphanumeric
+func ValidateLabel(label string) error {
+	if !labelRegexp.MatchString(label) {
+		return errors.Errorf("invalid package label '%s'", label)
+	}
+	return nil
+}
+
+// DefaultLabel returns the label of the packages with no label in their
+// metadata, which is derived from the name and the version they are
+// installed with
+func DefaultLabel(name, version string) string {
+	return name + "_" + version
+}
+
+// ComputePackageID returns the ID of a package with the computation of the
+// version
+func ComputePackageID(version int, label string, ccInstallPkg []byte) (string, error) {
+	if err := ValidateLabel(label); err != nil {
+		return "", err
+	}
+
+	var digest []byte
+	var err error
+	switch version {
+	case PackageIDV1:
+		digest, err = normalizedDigestV1(ccInstallPkg, 0)
+	default:
+		return "", errors.Errorf("unknown package ID version %d", version)
+	}
+	if err != nil {
+		return "", errors.WithMessage(err, "could not compute the digest of the package")
+	}
+	return label + ":" + hex.EncodeToString(digest), nil
+}
+
+// maxNestedArchives bounds the normalization of the archives nested in the
+// package
+const maxNestedArchives = 2
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+type tarEntry struct {
+	name   string
+	digest []byte
+}
+
+// normalizedDigestV1 is the digest of PackageIDV1. It hashes, for each regular
+// file of the archive in the order of their names, the length of the name as
+// a big endian uint64, the name, and the SHA-256 of the content of the file,
+// or the normalized digest of the file when it is itself a tar.gz archive
+func normalizedDigestV1(archive []byte, depth int) ([]byte, error) {
+	gzReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading as gzip stream")
+	}
+	tarReader := tar.NewReader(gzReader)
+
+	var entries []tarEntry
+	names := map[string]struct{}{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "error inspecting next tar header")
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			return nil, errors.Errorf("tar entry %s is not a regular file, type %v", header.Name, header.Typeflag)
+		}
+		if _, ok := names[header.Name]; ok {
+			return nil, errors.Errorf("duplicate tar entry %s", header.Name)
+		}
+		names[header.Name] = struct{}{}
+
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read %s from tar", header.Name)
+		}
+
+		var digest []byte
+		if depth < maxNestedArchives && bytes.HasPrefix(content, gzipMagic) {
+			// a file which is not a valid tar.gz archive is digested as is
+			digest, _ = normalizedDigestV1(content, depth+1)
+		}
+		if digest == nil {
+			sum := sha256.Sum256(content)
+			digest = sum[:]
+		}
+		entries = append(entries, tarEntry{name: header.Name, digest: digest})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	h := sha256.New()
+	for _, entry := range entries {
+		binary.Write(h, binary.BigEndian, uint64(len(entry.name)))
+		h.Write([]byte(entry.name))
+		h.Write(entry.digest)
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package persistence_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testEntry struct {
+	name    string
+	content []byte
+}
+
+func testArchive(entries []testEntry, modTime time.Time, level int) []byte {
+	buf := &bytes.Buffer{}
+	gw, err := gzip.NewWriterLevel(buf, level)
+	Expect(err).NotTo(HaveOccurred())
+	tw := tar.NewWriter(gw)
+	for _, entry := range entries {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     entry.name,
+			Typeflag: tar.TypeReg,
+			Mode:     0644,
+			Size:     int64(len(entry.content)),
+			ModTime:  modTime,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tw.Write(entry.content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(tw.Close()).To(Succeed())
+	Expect(gw.Close()).To(Succeed())
+	return buf.Bytes()
+}
+
+var _ = Describe("PackageID", func() {
+	var (
+		epoch       time.Time
+		codePackage []byte
+		pkg         []byte
+	)
+
+	BeforeEach(func() {
+		epoch = time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+		codePackage = testArchive([]testEntry{
+			{name: "src/mycc/main.go", content: []byte("package main\n")},
+			{name: "META-INF/statedb/couchdb/indexes/index.json", content: []byte("{}")},
+		}, epoch, gzip.BestCompression)
+		pkg = testArchive([]testEntry{
+			{name: persistence.ChaincodePackageMetadataFile, content: []byte(`{"Type":"GOLANG","Path":"mycc"}`)},
+			{name: "Code-Package.tar.gz", content: codePackage},
+		}, epoch, gzip.DefaultCompression)
+	})
+
+	It("never changes for a version", func() {
+		// the IDs of the installed packages depend on this value
+		packageID, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", pkg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(packageID).To(Equal("mycc_1.0:05c19492c0578532cd8b62e152244a383c4356c735ecf15fa782923fe75468d9"))
+	})
+
+	It("ignores the times, the order and the compression of the entries", func() {
+		later := epoch.Add(24 * time.Hour)
+		repacked := testArchive([]testEntry{
+			{name: "Code-Package.tar.gz", content: testArchive([]testEntry{
+				{name: "META-INF/statedb/couchdb/indexes/index.json", content: []byte("{}")},
+				{name: "src/mycc/main.go", content: []byte("package main\n")},
+			}, later, gzip.NoCompression)},
+			{name: persistence.ChaincodePackageMetadataFile, content: []byte(`{"Type":"GOLANG","Path":"mycc"}`)},
+		}, later, gzip.BestSpeed)
+		Expect(repacked).NotTo(Equal(pkg))
+
+		packageID, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", pkg)
+		Expect(err).NotTo(HaveOccurred())
+		repackedID, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", repacked)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repackedID).To(Equal(packageID))
+	})
+
+	It("depends on the content and the label of the package", func() {
+		modified := testArchive([]testEntry{
+			{name: persistence.ChaincodePackageMetadataFile, content: []byte(`{"Type":"GOLANG","Path":"mycc"}`)},
+			{name: "Code-Package.tar.gz", content: testArchive([]testEntry{
+				{name: "src/mycc/main.go", content: []byte("package main\n\n")},
+			}, epoch, gzip.BestCompression)},
+		}, epoch, gzip.DefaultCompression)
+
+		packageID, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", pkg)
+		Expect(err).NotTo(HaveOccurred())
+		modifiedID, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", modified)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(modifiedID).NotTo(Equal(packageID))
+
+		relabeledID, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.1", pkg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(relabeledID[len("mycc_1.1"):]).To(Equal(packageID[len("mycc_1.0"):]))
+	})
+
+	Context("when the version is unknown", func() {
+		It("fails", func() {
+			_, err := persistence.ComputePackageID(2, "mycc_1.0", pkg)
+			Expect(err).To(MatchError("unknown package ID version 2"))
+		})
+	})
+
+	Context("when the label is invalid", func() {
+		It("fails", func() {
+			_, err := persistence.ComputePackageID(persistence.PackageIDV1, "my:cc", pkg)
+			Expect(err).To(MatchError("invalid package label 'my:cc'"))
+		})
+	})
+
+	Context("when the package has duplicate entries", func() {
+		It("fails", func() {
+			duplicated := testArchive([]testEntry{
+				{name: "Code-Package.tar.gz", content: codePackage},
+				{name: "Code-Package.tar.gz", content: codePackage},
+			}, epoch, gzip.DefaultCompression)
+			_, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", duplicated)
+			Expect(err).To(MatchError("could not compute the digest of the package: duplicate tar entry Code-Package.tar.gz"))
+		})
+	})
+
+	Context("when the package is not a tar.gz archive", func() {
+		It("fails", func() {
+			_, err := persistence.ComputePackageID(persistence.PackageIDV1, "mycc_1.0", []byte("package"))
+			Expect(err).To(MatchError(ContainSubstring("could not compute the digest of the package: error reading as gzip stream")))
+		})
+	})
+
+	Describe("ValidateLabel", func() {
+		It("accepts alphanumerics with _ . + and -", func() {
+			Expect(persistence.ValidateLabel("mycc_1.0+build-2")).To(Succeed())
+			Expect(persistence.ValidateLabel("")).To(MatchError("invalid package label ''"))
+			Expect(persistence.ValidateLabel("_mycc")).To(MatchError("invalid package label '_mycc'"))
+			Expect(persistence.ValidateLabel("my cc")).To(MatchError("invalid package label 'my cc'"))
+		})
+	})
+
+	Describe("the label of the package metadata", func() {
+		It("is validated by the parser", func() {
+			labeled := testArchive([]testEntry{
+				{name: persistence.ChaincodePackageMetadataFile, content: []byte(`{"Type":"GOLANG","Path":"mycc","Label":"my:cc"}`)},
+				{name: "Code-Package.tar.gz", content: codePackage},
+			}, epoch, gzip.DefaultCompression)
+			_, err := persistence.ChaincodePackageParser{}.Parse(labeled)
+			Expect(err).To(MatchError("invalid Chaincode-Package-Metadata.json: invalid package label 'my:cc'"))
+		})
+	})
+})
@@ -70,9 +70,19 @@ type Store struct {
 }
 
 // Save persists chaincode install package bytes with the given name
-// and version
+// and version, along with the ID of the package
 func (s *Store) Save(name, version string, ccInstallPkg []byte) ([]byte, error) {
-	metadataJSON, err := toJSON(name, version)
+	packageID, err := packageID(name, version, ccInstallPkg)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataJSON, err := toJSON(&ChaincodeMetadata{
+		Name:             name,
+		Version:          version,
+		PackageID:        packageID,
+		PackageIDVersion: CurrentPackageIDVersion,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -129,19 +139,26 @@ func (s *Store) Load(hash []byte) (ccInstallPkg []byte, name, version string, er
 
 // LoadMetadata loads the chaincode metadata stored at the specified path
 func (s *Store) LoadMetadata(path string) (name, version string, err error) {
-	metadataBytes, err := s.ReadWriter.ReadFile(path)
+	ccMetadata, err := s.loadMetadata(path)
 	if err != nil {
-		err = errors.Wrapf(err, "error reading metadata at %s", path)
 		return "", "", err
 	}
+
+	return ccMetadata.Name, ccMetadata.Version, nil
+}
+
+func (s *Store) loadMetadata(path string) (*ChaincodeMetadata, error) {
+	metadataBytes, err := s.ReadWriter.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading metadata at %s", path)
+	}
 	ccMetadata := &ChaincodeMetadata{}
 	err = json.Unmarshal(metadataBytes, ccMetadata)
 	if err != nil {
-		err = errors.Wrapf(err, "error unmarshaling metadata at %s", path)
-		return "", "", err
+		return nil, errors.Wrapf(err, "error unmarshaling metadata at %s", path)
 	}
 
-	return ccMetadata.Name, ccMetadata.Version, nil
+	return ccMetadata, nil
 }
 
 // CodePackageNotFoundErr is the error returned when a code package cannot
@@ -189,7 +206,7 @@ func (s *Store) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error
 	for _, file := range files {
 		if strings.HasSuffix(file.Name(), ".json") {
 			metadataPath := filepath.Join(s.Path, file.Name())
-			ccName, ccVersion, err := s.LoadMetadata(metadataPath)
+			ccMetadata, err := s.loadMetadata(metadataPath)
 			if err != nil {
 				logger.Warning(err.Error())
 				continue
@@ -202,9 +219,10 @@ func (s *Store) ListInstalledChaincodes() ([]chaincode.InstalledChaincode, error
 				return nil, errors.Wrapf(err, "error decoding hash from hex string: %s", hashString)
 			}
 			installedChaincode := chaincode.InstalledChaincode{
-				Name:    ccName,
-				Version: ccVersion,
-				Id:      hash,
+				Name:      ccMetadata.Name,
+				Version:   ccMetadata.Version,
+				Id:        hash,
+				PackageID: ccMetadata.PackageID,
 			}
 			installedChaincodes = append(installedChaincodes, installedChaincode)
 		}
@@ -218,18 +236,81 @@ func (s *Store) GetChaincodeInstallPath() string {
 	return s.Path
 }
 
-// ChaincodeMetadata holds the name and version of a chaincode
+// MigratePackageIDs records the ID of the packages installed before the
+// package IDs were, computed with PackageIDV1 which is the computation their
+// IDs were defined with. The packages whose ID cannot be computed are
+// skipped, and it returns the number of packages migrated
+func (s *Store) MigratePackageIDs() (int, error) {
+	files, err := s.ReadWriter.ReadDir(s.Path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "error reading chaincode directory at %s", s.Path)
+	}
+
+	migrated := 0
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		metadataPath := filepath.Join(s.Path, file.Name())
+		ccMetadata, err := s.loadMetadata(metadataPath)
+		if err != nil {
+			logger.Warning(err.Error())
+			continue
+		}
+		if ccMetadata.PackageID != "" {
+			continue
+		}
+
+		ccInstallPkgPath := strings.TrimSuffix(metadataPath, ".json") + ".bin"
+		ccInstallPkg, err := s.ReadWriter.ReadFile(ccInstallPkgPath)
+		if err != nil {
+			logger.Warningf("error reading chaincode install package at %s: %s", ccInstallPkgPath, err)
+			continue
+		}
+		label := DefaultLabel(ccMetadata.Name, ccMetadata.Version)
+		ccMetadata.PackageID, err = ComputePackageID(PackageIDV1, label, ccInstallPkg)
+		if err != nil {
+			logger.Warningf("could not compute the ID of the package at %s: %s", ccInstallPkgPath, err)
+			continue
+		}
+		ccMetadata.PackageIDVersion = PackageIDV1
+
+		metadataJSON, err := toJSON(ccMetadata)
+		if err != nil {
+			return migrated, err
+		}
+		if err := s.ReadWriter.WriteFile(metadataPath, metadataJSON, 0600); err != nil {
+			return migrated, errors.Wrapf(err, "error writing metadata file to %s", metadataPath)
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// ChaincodeMetadata holds the name and version of a chaincode, and the ID of
+// its package with the version of the computation of the ID
 type ChaincodeMetadata struct {
-	Name    string `json:"Name"`
-	Version string `json:"Version"`
+	Name             string `json:"Name"`
+	Version          string `json:"Version"`
+	PackageID        string `json:"PackageID,omitempty"`
+	PackageIDVersion int    `json:"PackageIDVersion,omitempty"`
 }
 
-func toJSON(name, version string) ([]byte, error) {
-	metadata := &ChaincodeMetadata{
-		Name:    name,
-		Version: version,
+// packageID returns the ID of a package to install with the current
+// computation, labeled with the label of its metadata if any
+func packageID(name, version string, ccInstallPkg []byte) (string, error) {
+	ccPackage, err := ChaincodePackageParser{}.Parse(ccInstallPkg)
+	if err != nil {
+		return "", errors.WithMessage(err, "could not parse the chaincode install package")
 	}
+	label := ccPackage.Metadata.Label
+	if label == "" {
+		label = DefaultLabel(name, version)
+	}
+	return ComputePackageID(CurrentPackageIDVersion, label, ccInstallPkg)
+}
 
+func toJSON(metadata *ChaincodeMetadata) ([]byte, error) {
 	metadataBytes, err := json.Marshal(metadata)
 	if err != nil {
 		return nil, errors.Wrap(err, "error marshaling name and version into JSON")
@@ -114,14 +114,36 @@ var _ = Describe("Persistence", func() {
 				ReadWriter: mockReadWriter,
 			}
 
-			pkgBytes = []byte("testpkg")
+			var err error
+			pkgBytes, err = ioutil.ReadFile("testdata/good-package.tar.gz")
+			Expect(err).NotTo(HaveOccurred())
 			hashString = hex.EncodeToString(util.ComputeSHA256(pkgBytes))
 		})
 
 		It("saves successfully", func() {
 			hash, err := store.Save("testcc", "1.0", pkgBytes)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(hash).To(Equal(util.ComputeSHA256([]byte("testpkg"))))
+			Expect(hash).To(Equal(util.ComputeSHA256(pkgBytes)))
+		})
+
+		It("saves the ID of the package with its metadata", func() {
+			_, err := store.Save("testcc", "1.0", pkgBytes)
+			Expect(err).NotTo(HaveOccurred())
+
+			packageID, err := persistence.ComputePackageID(persistence.PackageIDV1, "testcc_1.0", pkgBytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockReadWriter.WriteFileCallCount()).To(Equal(2))
+			path, metadataJSON, _ := mockReadWriter.WriteFileArgsForCall(0)
+			Expect(path).To(Equal(hashString + ".json"))
+			Expect(metadataJSON).To(MatchJSON(`{"Name":"testcc","Version":"1.0","PackageID":"` + packageID + `","PackageIDVersion":1}`))
+		})
+
+		Context("when the package cannot be parsed", func() {
+			It("returns an error", func() {
+				hash, err := store.Save("testcc", "1.0", []byte("testpkg"))
+				Expect(hash).To(BeNil())
+				Expect(err).To(MatchError(ContainSubstring("could not parse the chaincode install package")))
+			})
 		})
 
 		Context("when the metadata file already exists", func() {
@@ -277,7 +299,7 @@ var _ = Describe("Persistence", func() {
 			mockFileInfo2 := &mock.OSFileInfo{}
 			mockFileInfo2.NameReturns(hex.EncodeToString([]byte("hash2")) + ".json")
 			mockReadWriter.ReadDirReturns([]os.FileInfo{mockFileInfo, mockFileInfo2}, nil)
-			mockReadWriter.ReadFileReturnsOnCall(0, []byte(`{"Name":"test1","Version":"1.0"}`), nil)
+			mockReadWriter.ReadFileReturnsOnCall(0, []byte(`{"Name":"test1","Version":"1.0","PackageID":"test1_1.0:abcd","PackageIDVersion":1}`), nil)
 			mockReadWriter.ReadFileReturnsOnCall(1, []byte(`{"Name":"test2","Version":"2.0"}`), nil)
 			store = &persistence.Store{
 				ReadWriter: mockReadWriter,
@@ -367,7 +389,7 @@ var _ = Describe("Persistence", func() {
 			mockFileInfo2 := &mock.OSFileInfo{}
 			mockFileInfo2.NameReturns(hex.EncodeToString([]byte("hash2")) + ".json")
 			mockReadWriter.ReadDirReturns([]os.FileInfo{mockFileInfo, mockFileInfo2}, nil)
-			mockReadWriter.ReadFileReturnsOnCall(0, []byte(`{"Name":"test1","Version":"1.0"}`), nil)
+			mockReadWriter.ReadFileReturnsOnCall(0, []byte(`{"Name":"test1","Version":"1.0","PackageID":"test1_1.0:abcd","PackageIDVersion":1}`), nil)
 			mockReadWriter.ReadFileReturnsOnCall(1, []byte(`{"Name":"test2","Version":"2.0"}`), nil)
 			store = &persistence.Store{
 				ReadWriter: mockReadWriter,
@@ -378,6 +400,8 @@ var _ = Describe("Persistence", func() {
 			installedChaincodes, err := store.ListInstalledChaincodes()
 			Expect(err).NotTo(HaveOccurred())
 			Expect(len(installedChaincodes)).To(Equal(2))
+			Expect(installedChaincodes[0].PackageID).To(Equal("test1_1.0:abcd"))
+			Expect(installedChaincodes[1].PackageID).To(BeEmpty())
 		})
 
 		Context("when the hash cannot be decoded from the filename", func() {
@@ -395,6 +419,80 @@ var _ = Describe("Persistence", func() {
 		})
 	})
 
+	Describe("MigratePackageIDs", func() {
+		var (
+			mockReadWriter *mock.IOReadWriter
+			store          *persistence.Store
+			pkgBytes       []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			pkgBytes, err = ioutil.ReadFile("testdata/good-package.tar.gz")
+			Expect(err).NotTo(HaveOccurred())
+
+			mockReadWriter = &mock.IOReadWriter{}
+			legacy := &mock.OSFileInfo{}
+			legacy.NameReturns("legacy.json")
+			migrated := &mock.OSFileInfo{}
+			migrated.NameReturns("migrated.json")
+			pkg := &mock.OSFileInfo{}
+			pkg.NameReturns("legacy.bin")
+			mockReadWriter.ReadDirReturns([]os.FileInfo{legacy, pkg, migrated}, nil)
+			mockReadWriter.ReadFileStub = func(path string) ([]byte, error) {
+				switch path {
+				case "legacy.json":
+					return []byte(`{"Name":"test1","Version":"1.0"}`), nil
+				case "legacy.bin":
+					return pkgBytes, nil
+				case "migrated.json":
+					return []byte(`{"Name":"test2","Version":"2.0","PackageID":"test2_2.0:abcd","PackageIDVersion":1}`), nil
+				}
+				return nil, errors.New("not found")
+			}
+			store = &persistence.Store{
+				ReadWriter: mockReadWriter,
+			}
+		})
+
+		It("records the IDs of the packages installed without one", func() {
+			migrated, err := store.MigratePackageIDs()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(Equal(1))
+
+			packageID, err := persistence.ComputePackageID(persistence.PackageIDV1, "test1_1.0", pkgBytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockReadWriter.WriteFileCallCount()).To(Equal(1))
+			path, metadataJSON, _ := mockReadWriter.WriteFileArgsForCall(0)
+			Expect(path).To(Equal("legacy.json"))
+			Expect(metadataJSON).To(MatchJSON(`{"Name":"test1","Version":"1.0","PackageID":"` + packageID + `","PackageIDVersion":1}`))
+		})
+
+		Context("when the package cannot be read", func() {
+			BeforeEach(func() {
+				pkgBytes = []byte("corrupted")
+			})
+
+			It("skips it", func() {
+				migrated, err := store.MigratePackageIDs()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(migrated).To(Equal(0))
+				Expect(mockReadWriter.WriteFileCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when writing the metadata fails", func() {
+			BeforeEach(func() {
+				mockReadWriter.WriteFileReturns(errors.New("disk full"))
+			})
+
+			It("returns an error", func() {
+				_, err := store.MigratePackageIDs()
+				Expect(err).To(MatchError("error writing metadata file to legacy.json: disk full"))
+			})
+		})
+	})
+
 	Describe("GetChaincodeInstallPath", func() {
 		var (
 			store *persistence.Store
@@ -0,0 +1,397 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package binary provides the platform of the chaincodes delivered as
+// prebuilt, statically linked executables, for the teams which compile their
+// chaincodes out of band with their own toolchains.
+package binary
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/util"
+	cutil "github.com/hyperledger/fabric/core/container/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("chaincode.platform.binary")
+
+const (
+	// ManifestFile is the name of the manifest of the executable, in the
+	// chaincode directory and at the root of the code package
+	ManifestFile = "manifest.json"
+
+	// binDir is the directory of the executable in the code package
+	binDir = "bin/"
+
+	// executableName is the name of the executable in the chaincode image,
+	// under /usr/local/bin, which the peer launches as for golang chaincodes
+	executableName = "chaincode"
+)
+
+// Manifest describes the executable of a binary chaincode
+type Manifest struct {
+	// Entrypoint is the name of the executable, in the chaincode directory
+	// and under bin/ in the code package
+	Entrypoint string `json:"entrypoint"`
+	// Arch is the architecture the executable is built for, as named by
+	// GOARCH, e.g. amd64
+	Arch string `json:"arch"`
+	// Env is the environment of the executable in the chaincode container
+	Env map[string]string `json:"env,omitempty"`
+}
+
+var (
+	entrypointRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	envNameRegexp    = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Validate checks the entrypoint, architecture and environment of the manifest
+func (m *Manifest) Validate() error {
+	if !entrypointRegexp.MatchString(m.Entrypoint) {
+		return errors.Errorf("invalid entrypoint %q, it must be the file name of the executable", m.Entrypoint)
+	}
+	if _, ok := architectures[m.Arch]; !ok {
+		return errors.Errorf("unsupported architecture %q", m.Arch)
+	}
+	for name, value := range m.Env {
+		if !envNameRegexp.MatchString(name) {
+			return errors.Errorf("invalid environment variable name %q", name)
+		}
+		// the peer sets the CORE_ variables of the chaincodes it launches
+		if strings.HasPrefix(strings.ToUpper(name), "CORE_") {
+			return errors.Errorf("environment variable %s is reserved to the peer", name)
+		}
+		if strings.IndexFunc(value, unicode.IsControl) >= 0 {
+			return errors.Errorf("the value of environment variable %s contains control characters", name)
+		}
+	}
+	return nil
+}
+
+// architecture identifies the executables of an architecture by their ELF header
+type architecture struct {
+	machine   elf.Machine
+	class     elf.Class
+	byteOrder binary.ByteOrder
+}
+
+// architectures are the architectures of the executables by their GOARCH name
+var architectures = map[string]architecture{
+	"386":     {elf.EM_386, elf.ELFCLASS32, binary.LittleEndian},
+	"amd64":   {elf.EM_X86_64, elf.ELFCLASS64, binary.LittleEndian},
+	"arm":     {elf.EM_ARM, elf.ELFCLASS32, binary.LittleEndian},
+	"arm64":   {elf.EM_AARCH64, elf.ELFCLASS64, binary.LittleEndian},
+	"ppc64le": {elf.EM_PPC64, elf.ELFCLASS64, binary.LittleEndian},
+	"s390x":   {elf.EM_S390, elf.ELFCLASS64, binary.BigEndian},
+}
+
+// Platform for the chaincodes delivered as prebuilt executables. The chaincode
+// path is a directory holding the manifest and the executable it names, and
+// optionally the META-INF directory of the chaincode metadata. The executable
+// must be a statically linked ELF executable for the architecture of the
+// manifest, which must be the architecture of the peers running the chaincode.
+type Platform struct {
+}
+
+// Name returns the name of this platform
+func (p *Platform) Name() string {
+	return pb.ChaincodeSpec_BINARY.String()
+}
+
+// ValidatePath checks that the chaincode path is a local directory
+func (p *Platform) ValidatePath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("error validating chaincode path: %s", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("chaincode path %s is not a directory", path)
+	}
+	return nil
+}
+
+// ValidateCodePackage checks that the code package holds a valid manifest and
+// the statically linked executable it names, built for its architecture. The
+// check is independent of the peer, as the committers run it when they
+// validate the deployments of the chaincodes.
+func (p *Platform) ValidateCodePackage(code []byte) error {
+	if len(code) == 0 {
+		// Nothing to validate if no CodePackage was included
+		return nil
+	}
+	_, err := readCodePackage(code)
+	return err
+}
+
+// codePackage is the content of the code package of a binary chaincode
+type codePackage struct {
+	manifest   *Manifest
+	executable []byte
+}
+
+// readCodePackage reads and validates the code package of a binary chaincode
+func readCodePackage(code []byte) (*codePackage, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return nil, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+	validator := util.NewPackageValidator(util.PackageRulesFromConfig())
+
+	var manifestBytes []byte
+	executables := map[string][]byte{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failure reading codepackage: %s", err)
+		}
+		if err := validator.Validate(header); err != nil {
+			return nil, err
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		switch name := strings.TrimPrefix(header.Name, "/"); {
+		case strings.HasPrefix(name, "META-INF/"):
+			continue
+		case name == ManifestFile:
+			if manifestBytes, err = ioutil.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("failure reading %s: %s", ManifestFile, err)
+			}
+		case strings.HasPrefix(name, binDir) && !strings.Contains(name[len(binDir):], "/"):
+			if executables[name[len(binDir):]], err = ioutil.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("failure reading %s: %s", name, err)
+			}
+		default:
+			return nil, fmt.Errorf("illegal file detected in payload: \"%s\"", header.Name)
+		}
+	}
+
+	if manifestBytes == nil {
+		return nil, fmt.Errorf("no %s found at the root of the chaincode package", ManifestFile)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", ManifestFile, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", ManifestFile, err)
+	}
+	executable, ok := executables[manifest.Entrypoint]
+	if !ok {
+		return nil, fmt.Errorf("entrypoint %s not found under %s in the chaincode package", manifest.Entrypoint, binDir)
+	}
+	if len(executables) != 1 {
+		return nil, fmt.Errorf("the chaincode package holds %d files under %s, only the entrypoint is allowed", len(executables), binDir)
+	}
+	if err := checkExecutable(executable, manifest.Arch); err != nil {
+		return nil, fmt.Errorf("invalid entrypoint %s: %s", manifest.Entrypoint, err)
+	}
+
+	return &codePackage{manifest: manifest, executable: executable}, nil
+}
+
+// checkExecutable checks that the executable is a statically linked ELF
+// executable for the given architecture
+func checkExecutable(executable []byte, arch string) error {
+	f, err := elf.NewFile(bytes.NewReader(executable))
+	if err != nil {
+		return errors.Wrap(err, "not an ELF file")
+	}
+	defer f.Close()
+
+	if f.Type != elf.ET_EXEC && f.Type != elf.ET_DYN {
+		return errors.Errorf("ELF file of type %s is not an executable", f.Type)
+	}
+	expected := architectures[arch]
+	if f.Machine != expected.machine || f.Class != expected.class || f.ByteOrder != expected.byteOrder {
+		return errors.Errorf("executable for %s %s is not compatible with architecture %s", f.Class, f.Machine, arch)
+	}
+	for _, prog := range f.Progs {
+		if prog.Type == elf.PT_INTERP {
+			return errors.New("executable is dynamically linked, it must be statically linked")
+		}
+	}
+	libraries, err := f.ImportedLibraries()
+	if err != nil {
+		return errors.Wrap(err, "could not read the dynamic section")
+	}
+	if len(libraries) != 0 {
+		return errors.Errorf("executable depends on the shared libraries %s, it must be statically linked", strings.Join(libraries, ", "))
+	}
+	return nil
+}
+
+// GetDeploymentPayload packages the manifest and the executable of the
+// chaincode directory, and its metadata, in .tar.gz format
+func (p *Platform) GetDeploymentPayload(path string) ([]byte, error) {
+	manifestBytes, err := ioutil.ReadFile(filepath.Join(path, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the manifest of the chaincode: %s", err)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", ManifestFile, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", ManifestFile, err)
+	}
+	executable, err := ioutil.ReadFile(filepath.Join(path, manifest.Entrypoint))
+	if err != nil {
+		return nil, fmt.Errorf("could not read the entrypoint of the chaincode: %s", err)
+	}
+	if err := checkExecutable(executable, manifest.Arch); err != nil {
+		return nil, fmt.Errorf("invalid entrypoint %s: %s", manifest.Entrypoint, err)
+	}
+
+	logger.Debugf("Packaging binary chaincode %s from path %s", manifest.Entrypoint, path)
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	if err := cutil.WriteBytesToPackage(ManifestFile, manifestBytes, tw); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+	if err := cutil.WriteBytesToPackage(binDir+manifest.Entrypoint, executable, tw); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+	if err := writeMetadata(path, tw); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf("Error writing Chaincode package contents: %s", err)
+	}
+
+	return payload.Bytes(), nil
+}
+
+// writeMetadata writes the files of the META-INF directory of the chaincode,
+// if any, to the code package
+func writeMetadata(path string, tw *tar.Writer) error {
+	metadataPath := filepath.Join(path, "META-INF")
+	if _, err := os.Stat(metadataPath); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.Walk(metadataPath, func(localpath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// hidden files are not supported as metadata
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		packagepath, err := filepath.Rel(path, localpath)
+		if err != nil {
+			return err
+		}
+		return cutil.WriteFileToPackage(localpath, filepath.ToSlash(packagepath), tw)
+	})
+}
+
+// GenerateDockerfile returns the Dockerfile of the minimal runtime image of
+// the chaincode, to which the executable is added
+func (p *Platform) GenerateDockerfile() (string, error) {
+	var buf []string
+
+	buf = append(buf, "FROM "+cutil.GetDockerfileFromConfig("chaincode.binary.runtime"))
+	buf = append(buf, "ADD binpackage.tar /usr/local/bin")
+
+	return strings.Join(buf, "\n"), nil
+}
+
+// ExtendDockerfile returns the instructions setting the environment of the
+// manifest of the chaincode, sorted by name
+func (p *Platform) ExtendDockerfile(code []byte) ([]string, error) {
+	pkg, err := readCodePackage(code)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range pkg.manifest.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var instructions []string
+	for _, name := range names {
+		instructions = append(instructions, fmt.Sprintf(`ENV %s="%s"`, name, envValueEscaper.Replace(pkg.manifest.Env[name])))
+	}
+	return instructions, nil
+}
+
+// envValueEscaper escapes the values of the environment variables in the
+// double quotes of the ENV instructions, without variable substitution
+var envValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `$`, `\$`)
+
+// GenerateDockerBuild adds the executable to the build of the chaincode image.
+// Unlike the other platforms, nothing is built in the builder image, the
+// executable only has to run on the architecture of the peer.
+func (p *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+	pkg, err := readCodePackage(code)
+	if err != nil {
+		return err
+	}
+	if pkg.manifest.Arch != runtime.GOARCH {
+		return fmt.Errorf("chaincode executable built for %s cannot run on the %s peer", pkg.manifest.Arch, runtime.GOARCH)
+	}
+
+	binpackage := bytes.NewBuffer(nil)
+	btw := tar.NewWriter(binpackage)
+	var zeroTime time.Time
+	err = btw.WriteHeader(&tar.Header{
+		Name:       executableName,
+		Size:       int64(len(pkg.executable)),
+		ModTime:    zeroTime,
+		AccessTime: zeroTime,
+		ChangeTime: zeroTime,
+		Mode:       0100755,
+	})
+	if err != nil {
+		return fmt.Errorf("Error writing the chaincode executable: %s", err)
+	}
+	if _, err := btw.Write(pkg.executable); err != nil {
+		return fmt.Errorf("Error writing the chaincode executable: %s", err)
+	}
+	if err := btw.Close(); err != nil {
+		return fmt.Errorf("Error writing the chaincode executable: %s", err)
+	}
+
+	return cutil.WriteBytesToPackage("binpackage.tar", binpackage.Bytes(), tw)
+}
+
+// GetMetadataProvider fetches metadata provider given deployment spec
+func (p *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
+	return &ccmetadata.TargzMetadataProvider{Code: code}
+}
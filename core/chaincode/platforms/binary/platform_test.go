@@ -0,0 +1,290 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package binary
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ = platforms.Platform(&Platform{})
+var _ = platforms.DockerfileExtender(&Platform{})
+
+// elfExecutable returns a minimal 64-bit little endian ELF executable for the
+// given machine, with an interpreter if it is dynamically linked
+func elfExecutable(t *testing.T, machine elf.Machine, dynamic bool) []byte {
+	progs := []elf.Prog64{{Type: uint32(elf.PT_LOAD), Flags: uint32(elf.PF_R | elf.PF_X), Align: 0x1000}}
+	if dynamic {
+		progs = append(progs, elf.Prog64{Type: uint32(elf.PT_INTERP), Flags: uint32(elf.PF_R), Align: 1})
+	}
+	header := elf.Header64{
+		Type:      uint16(elf.ET_EXEC),
+		Machine:   uint16(machine),
+		Version:   uint32(elf.EV_CURRENT),
+		Entry:     0x400000,
+		Phoff:     64,
+		Ehsize:    64,
+		Phentsize: 56,
+		Phnum:     uint16(len(progs)),
+	}
+	copy(header.Ident[:], elf.ELFMAG)
+	header.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, header))
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, progs))
+	return buf.Bytes()
+}
+
+type packageEntry struct {
+	name    string
+	content []byte
+}
+
+func buildPackage(t *testing.T, entries ...packageEntry) []byte {
+	payload := &bytes.Buffer{}
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+	for _, entry := range entries {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: entry.name, Size: int64(len(entry.content)), Mode: 0100644}))
+		_, err := tw.Write(entry.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return payload.Bytes()
+}
+
+func manifestJSON(t *testing.T, manifest *Manifest) []byte {
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	return manifestBytes
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "BINARY", (&Platform{}).Name())
+	assert.Equal(t, pb.ChaincodeSpec_BINARY, pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["BINARY"]))
+}
+
+func TestValidatePath(t *testing.T) {
+	p := &Platform{}
+	assert.NoError(t, p.ValidatePath("."))
+	assert.Contains(t, p.ValidatePath("there/is/no/way/this/path/exists").Error(), "error validating chaincode path")
+	assert.EqualError(t, p.ValidatePath("platform.go"), "chaincode path platform.go is not a directory")
+}
+
+func TestValidateCodePackage(t *testing.T) {
+	p := &Platform{}
+	static := elfExecutable(t, elf.EM_X86_64, false)
+	manifest := manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: "amd64", Env: map[string]string{"LOG_LEVEL": "debug"}})
+
+	assert.NoError(t, p.ValidateCodePackage(nil))
+	assert.NoError(t, p.ValidateCodePackage(buildPackage(t,
+		packageEntry{"manifest.json", manifest},
+		packageEntry{"bin/mycc", static},
+		packageEntry{"META-INF/statedb/couchdb/indexes/index.json", []byte("{}")},
+	)))
+
+	for _, tc := range []struct {
+		name     string
+		entries  []packageEntry
+		expected string
+	}{
+		{
+			"no manifest",
+			[]packageEntry{{"bin/mycc", static}},
+			"no manifest.json found at the root of the chaincode package",
+		},
+		{
+			"no entrypoint",
+			[]packageEntry{{"manifest.json", manifest}, {"bin/yourcc", static}},
+			"entrypoint mycc not found under bin/ in the chaincode package",
+		},
+		{
+			"other executable",
+			[]packageEntry{{"manifest.json", manifest}, {"bin/mycc", static}, {"bin/yourcc", static}},
+			"the chaincode package holds 2 files under bin/, only the entrypoint is allowed",
+		},
+		{
+			"other file",
+			[]packageEntry{{"manifest.json", manifest}, {"bin/mycc", static}, {"src/main.c", nil}},
+			`illegal file detected in payload: "src/main.c"`,
+		},
+		{
+			"invalid entrypoint",
+			[]packageEntry{{"manifest.json", manifestJSON(t, &Manifest{Entrypoint: "../mycc", Arch: "amd64"})}},
+			`invalid manifest.json: invalid entrypoint "../mycc", it must be the file name of the executable`,
+		},
+		{
+			"unsupported architecture",
+			[]packageEntry{{"manifest.json", manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: "mips"})}},
+			`invalid manifest.json: unsupported architecture "mips"`,
+		},
+		{
+			"reserved variable",
+			[]packageEntry{{"manifest.json", manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: "amd64", Env: map[string]string{"CORE_PEER_ADDRESS": "x"}})}},
+			"invalid manifest.json: environment variable CORE_PEER_ADDRESS is reserved to the peer",
+		},
+		{
+			"multiline variable",
+			[]packageEntry{{"manifest.json", manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: "amd64", Env: map[string]string{"A": "x\nRUN true"}})}},
+			"invalid manifest.json: the value of environment variable A contains control characters",
+		},
+		{
+			"not an ELF file",
+			[]packageEntry{{"manifest.json", manifest}, {"bin/mycc", []byte("#!/bin/sh\nexec /usr/local/bin/mycc\n")}},
+			"invalid entrypoint mycc: not an ELF file: bad magic number '[35 33 47 98]' in record at byte 0x0",
+		},
+		{
+			"other architecture",
+			[]packageEntry{{"manifest.json", manifest}, {"bin/mycc", elfExecutable(t, elf.EM_AARCH64, false)}},
+			"invalid entrypoint mycc: executable for ELFCLASS64 EM_AARCH64 is not compatible with architecture amd64",
+		},
+		{
+			"dynamically linked",
+			[]packageEntry{{"manifest.json", manifest}, {"bin/mycc", elfExecutable(t, elf.EM_X86_64, true)}},
+			"invalid entrypoint mycc: executable is dynamically linked, it must be statically linked",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, p.ValidateCodePackage(buildPackage(t, tc.entries...)), tc.expected)
+		})
+	}
+}
+
+func TestGetDeploymentPayload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "binarycc")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := &Platform{}
+	_, err = p.GetDeploymentPayload(dir)
+	assert.Contains(t, err.Error(), "could not read the manifest of the chaincode")
+
+	manifest := manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: "amd64"})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"), manifest, 0644))
+	_, err = p.GetDeploymentPayload(dir)
+	assert.Contains(t, err.Error(), "could not read the entrypoint of the chaincode")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "mycc"), elfExecutable(t, elf.EM_X86_64, true), 0755))
+	_, err = p.GetDeploymentPayload(dir)
+	assert.EqualError(t, err, "invalid entrypoint mycc: executable is dynamically linked, it must be statically linked")
+
+	static := elfExecutable(t, elf.EM_X86_64, false)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "mycc"), static, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "build.log"), []byte("built"), 0644))
+	indexes := filepath.Join(dir, "META-INF", "statedb", "couchdb", "indexes")
+	require.NoError(t, os.MkdirAll(indexes, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(indexes, "index.json"), []byte("{}"), 0644))
+
+	payload, err := p.GetDeploymentPayload(dir)
+	require.NoError(t, err)
+	assert.NoError(t, p.ValidateCodePackage(payload))
+
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	var names []string
+	for header, err := tr.Next(); err == nil; header, err = tr.Next() {
+		names = append(names, header.Name)
+	}
+	assert.Equal(t, []string{"manifest.json", "bin/mycc", "META-INF/statedb/couchdb/indexes/index.json"}, names)
+
+	metadata, err := p.GetMetadataProvider(payload).GetMetadataAsTarEntries()
+	require.NoError(t, err)
+	assert.NotEmpty(t, metadata)
+}
+
+func TestGenerateDockerBuild(t *testing.T) {
+	p := &Platform{}
+	arch := runtime.GOARCH
+	if _, ok := architectures[arch]; !ok || architectures[arch].class != elf.ELFCLASS64 || architectures[arch].byteOrder != binary.LittleEndian {
+		t.Skipf("no test executable for %s", arch)
+	}
+	static := elfExecutable(t, architectures[arch].machine, false)
+	code := buildPackage(t,
+		packageEntry{"manifest.json", manifestJSON(t, &Manifest{
+			Entrypoint: "mycc",
+			Arch:       arch,
+			Env:        map[string]string{"LOG_LEVEL": "debug", "GREETING": `say "hello" to $USER`},
+		})},
+		packageEntry{"bin/mycc", static},
+	)
+
+	instructions, err := p.ExtendDockerfile(code)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`ENV GREETING="say \"hello\" to \$USER"`, `ENV LOG_LEVEL="debug"`}, instructions)
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	require.NoError(t, p.GenerateDockerBuild("", code, tw))
+	require.NoError(t, tw.Close())
+
+	tr := tar.NewReader(buf)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "binpackage.tar", header.Name)
+	btr := tar.NewReader(tr)
+	header, err = btr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "chaincode", header.Name)
+	assert.Equal(t, int64(0100755), header.Mode)
+	executable, err := ioutil.ReadAll(btr)
+	require.NoError(t, err)
+	assert.Equal(t, static, executable)
+
+	other := "arm64"
+	if arch == other {
+		other = "amd64"
+	}
+	code = buildPackage(t,
+		packageEntry{"manifest.json", manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: other})},
+		packageEntry{"bin/mycc", elfExecutable(t, architectures[other].machine, false)},
+	)
+	err = p.GenerateDockerBuild("", code, tar.NewWriter(&bytes.Buffer{}))
+	assert.EqualError(t, err, "chaincode executable built for "+other+" cannot run on the "+arch+" peer")
+}
+
+func TestRegistryGenerateDockerBuild(t *testing.T) {
+	code := buildPackage(t,
+		packageEntry{"manifest.json", manifestJSON(t, &Manifest{Entrypoint: "mycc", Arch: "amd64", Env: map[string]string{"LOG_LEVEL": "debug"}})},
+		packageEntry{"bin/mycc", elfExecutable(t, elf.EM_X86_64, false)},
+	)
+	registry := platforms.NewRegistry(&Platform{})
+	var dockerfile string
+	registry.PackageWriter = platforms.PackageWriterWrapper(func(name string, payload []byte, tw *tar.Writer) error {
+		if name == "Dockerfile" {
+			dockerfile = string(payload)
+		}
+		return nil
+	})
+	reader, err := registry.GenerateDockerBuild("BINARY", "", "mycc", "1.0", code)
+	require.NoError(t, err)
+	// wait for the build context to be streamed
+	ioutil.ReadAll(reader)
+	assert.Contains(t, dockerfile, "ADD binpackage.tar /usr/local/bin")
+	assert.Contains(t, dockerfile, "\nENV LOG_LEVEL=\"debug\"")
+
+	_, err = registry.GenerateDockerBuild("BINARY", "", "mycc", "1.0", []byte("garbage"))
+	assert.EqualError(t, err, "Failed to generate a Dockerfile: failure opening codepackage gzip stream: unexpected EOF")
+}
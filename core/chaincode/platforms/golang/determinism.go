@@ -0,0 +1,152 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// The modes of the check of the chaincode sources for the sources of
+// non-determinism, configured by chaincode.golang.determinismCheck
+const (
+	// DeterminismCheckWarn logs the hints of the check
+	DeterminismCheckWarn = "warn"
+	// DeterminismCheckReject rejects the code packages the check reports hints for
+	DeterminismCheckReject = "reject"
+	// DeterminismCheckOff disables the check
+	DeterminismCheckOff = "off"
+)
+
+// determinismCheckMode returns the configured mode of the check of the
+// chaincode sources, warn by default
+func determinismCheckMode() (string, error) {
+	mode := viper.GetString("chaincode.golang.determinismCheck")
+	switch mode {
+	case "":
+		return DeterminismCheckWarn, nil
+	case DeterminismCheckWarn, DeterminismCheckReject, DeterminismCheckOff:
+		return mode, nil
+	default:
+		return "", errors.Errorf("invalid chaincode.golang.determinismCheck %s, it must be one of %s, %s or %s", mode, DeterminismCheckWarn, DeterminismCheckReject, DeterminismCheckOff)
+	}
+}
+
+// nondeterministicTimeFuncs are the functions of the time package reading the
+// clock of the peer
+var nondeterministicTimeFuncs = map[string]bool{
+	"Now":   true,
+	"Since": true,
+	"Until": true,
+}
+
+// deterministicMathRandFuncs are the functions of the math/rand package which
+// do not use its global source, seeded by the chaincode
+var deterministicMathRandFuncs = map[string]bool{
+	"New":       true,
+	"NewSource": true,
+	"NewZipf":   true,
+}
+
+// isChaincodeSource returns true if the entry of a code package is a source
+// of the chaincode itself rather than of its tests or its dependencies
+func isChaincodeSource(name string) bool {
+	if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+		return false
+	}
+	for _, dir := range strings.Split(name, "/") {
+		if dir == "vendor" {
+			return false
+		}
+	}
+	return true
+}
+
+// determinismHints returns the hints about the uses of the clock and of the
+// random sources of the peer by a source of the main package of a chaincode,
+// which differ between the endorsers of a transaction. The sources which do
+// not parse are left to the compiler
+func determinismHints(name string, src []byte) []string {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, name, src, 0)
+	if err != nil || f.Name.Name != "main" {
+		return nil
+	}
+
+	// the local names of the imports of the packages of interest
+	imports := map[string]string{}
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		local := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			local = spec.Name.Name
+		}
+		switch path {
+		case "time", "math/rand", "crypto/rand":
+			imports[local] = path
+		}
+	}
+	if len(imports) == 0 {
+		return nil
+	}
+
+	var hints []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok || ident.Obj != nil {
+			// not a package, or shadowed by a local declaration
+			return true
+		}
+		var hint string
+		switch imports[ident.Name] {
+		case "time":
+			if nondeterministicTimeFuncs[sel.Sel.Name] {
+				hint = "time.%s reads the clock of the peer, use the GetTxTime function of the stub instead"
+			}
+		case "math/rand":
+			if !deterministicMathRandFuncs[sel.Sel.Name] {
+				hint = "math/rand.%s uses a source of random numbers seeded differently by each endorser, use the GetTxRand function of the stub instead"
+			}
+		case "crypto/rand":
+			hint = "crypto/rand.%s returns random numbers which differ between the endorsers, use the GetTxRand function of the stub instead"
+		}
+		if hint != "" {
+			hints = append(hints, fmt.Sprintf("%s: "+hint, fset.Position(sel.Pos()), sel.Sel.Name))
+		}
+		return true
+	})
+	return hints
+}
+
+// checkDeterminism reports the hints of the check of the chaincode sources
+// according to the configured mode
+func checkDeterminism(mode string, hints []string) error {
+	if len(hints) == 0 {
+		return nil
+	}
+	if mode == DeterminismCheckReject {
+		return errors.Errorf("the chaincode uses sources of non-determinism, which lead the endorsers of its transactions to different results:\n%s", strings.Join(hints, "\n"))
+	}
+	for _, hint := range hints {
+		logger.Warningf("The chaincode uses a source of non-determinism: %s", hint)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nondeterministicChaincode = `package main
+
+import (
+	"crypto/rand"
+	mrand "math/rand"
+	"time"
+)
+
+func invoke() {
+	_ = time.Now()
+	_ = time.Since(time.Unix(0, 0))
+	_ = mrand.Intn(10)
+	_ = mrand.New(mrand.NewSource(1)).Intn(10)
+	b := make([]byte, 8)
+	rand.Read(b)
+}
+
+func shadowed(time struct{ Now int }) int {
+	return time.Now
+}
+`
+
+func TestDeterminismHints(t *testing.T) {
+	hints := determinismHints("src/cc/cc.go", []byte(nondeterministicChaincode))
+	assert.Equal(t, []string{
+		"src/cc/cc.go:10:6: time.Now reads the clock of the peer, use the GetTxTime function of the stub instead",
+		"src/cc/cc.go:11:6: time.Since reads the clock of the peer, use the GetTxTime function of the stub instead",
+		"src/cc/cc.go:12:6: math/rand.Intn uses a source of random numbers seeded differently by each endorser, use the GetTxRand function of the stub instead",
+		"src/cc/cc.go:15:2: crypto/rand.Read returns random numbers which differ between the endorsers, use the GetTxRand function of the stub instead",
+	}, hints)
+
+	// only the main package of the chaincode is checked
+	lib := bytes.Replace([]byte(nondeterministicChaincode), []byte("package main"), []byte("package lib"), 1)
+	assert.Empty(t, determinismHints("src/cc/lib/lib.go", lib))
+	assert.Empty(t, determinismHints("src/cc/cc.go", []byte("not go")))
+}
+
+func TestIsChaincodeSource(t *testing.T) {
+	assert.True(t, isChaincodeSource("src/cc/cc.go"))
+	assert.False(t, isChaincodeSource("src/cc/cc_test.go"))
+	assert.False(t, isChaincodeSource("src/cc/vendor/dep/dep.go"))
+	assert.False(t, isChaincodeSource("META-INF/statedb/couchdb/indexes/index.json"))
+}
+
+func TestValidateCodePackageDeterminism(t *testing.T) {
+	defer viper.Set("chaincode.golang.determinismCheck", nil)
+
+	codePackage := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(codePackage)
+	tw := tar.NewWriter(gw)
+	require.NoError(t, writeBytesToPackage("src/cc/cc.go", []byte(nondeterministicChaincode), 0100644, tw))
+	tw.Close()
+	gw.Close()
+
+	platform := &Platform{}
+	viper.Set("chaincode.golang.determinismCheck", "reject")
+	err := platform.ValidateCodePackage(codePackage.Bytes())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the chaincode uses sources of non-determinism, which lead the endorsers of its transactions to different results:\nsrc/cc/cc.go:10:6: time.Now")
+
+	for _, mode := range []string{"", "warn", "off"} {
+		viper.Set("chaincode.golang.determinismCheck", mode)
+		assert.NoError(t, platform.ValidateCodePackage(codePackage.Bytes()))
+	}
+
+	viper.Set("chaincode.golang.determinismCheck", "strict")
+	assert.EqualError(t, platform.ValidateCodePackage(codePackage.Bytes()), "invalid chaincode.golang.determinismCheck strict, it must be one of warn, reject or off")
+}
@@ -27,6 +27,11 @@ import (
 
 //runProgram non-nil Env, timeout (typically secs or millisecs), program name and args
 func runProgram(env Env, timeout time.Duration, pgm string, args ...string) ([]byte, error) {
+	return runProgramInDir(env, "", timeout, pgm, args...)
+}
+
+//runProgramInDir runs a program like runProgram, from the given working directory
+func runProgramInDir(env Env, dir string, timeout time.Duration, pgm string, args ...string) ([]byte, error) {
 	if env == nil {
 		return nil, fmt.Errorf("<%s, %v>: nil env provided", pgm, args)
 	}
@@ -35,6 +40,7 @@ func runProgram(env Env, timeout time.Duration, pgm string, args ...string) ([]b
 	defer cancel()
 	cmd := exec.CommandContext(ctx, pgm, args...)
 	cmd.Env = flattenEnv(env)
+	cmd.Dir = dir
 	stdErr := &bytes.Buffer{}
 	cmd.Stderr = stdErr
 
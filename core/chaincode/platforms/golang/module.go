@@ -0,0 +1,655 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package golang
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
+	cutil "github.com/hyperledger/fabric/core/container/util"
+	"github.com/pkg/errors"
+)
+
+// moduleFiles are the files of a module that are packaged along with the sources
+var moduleFiles = map[string]bool{
+	"go.mod": true,
+	"go.sum": true,
+}
+
+// ModuleInfo describes the go module which contains a chaincode package
+type ModuleInfo struct {
+	// Path is the path of the module
+	Path string
+	// Dir is the root directory of the module
+	Dir string
+	// ImportPath is the import path of the chaincode package
+	ImportPath string
+	// PackageDir is the directory of the chaincode package
+	PackageDir string
+}
+
+// listedModule is a module as reported by go list
+type listedModule struct {
+	Path      string
+	Version   string
+	Dir       string
+	Main      bool
+	GoVersion string
+	Replace   *listedModule
+}
+
+// listedPackage is a package as reported by go list
+type listedPackage struct {
+	ImportPath string
+	Dir        string
+	Standard   bool
+	GoFiles    []string
+	CgoFiles   []string
+	CFiles     []string
+	HFiles     []string
+	SFiles     []string
+	Module     *listedModule
+}
+
+// moduleEnv returns the environment of the go commands run in module mode
+func moduleEnv() Env {
+	env := getEnv()
+	env["GO111MODULE"] = "on"
+	return env
+}
+
+// listPackages runs go list on the given packages from the given directory
+func listPackages(env Env, dir string, args ...string) ([]*listedPackage, error) {
+	out, err := runProgramInDir(env, dir, 60*time.Second, "go", append([]string{"list", "-json"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var pkgs []*listedPackage
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for {
+		pkg := &listedPackage{}
+		err := decoder.Decode(pkg)
+		if err == io.EOF {
+			return pkgs, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed parsing the output of go list")
+		}
+		pkgs = append(pkgs, pkg)
+	}
+}
+
+// isLocalDir returns true if the chaincode path is a directory of the filesystem
+// rather than an import path, i.e. if it is absolute or relative to the working directory
+func isLocalDir(path string) bool {
+	if !filepath.IsAbs(path) && path != "." && path != ".." &&
+		!strings.HasPrefix(path, "./") && !strings.HasPrefix(path, "../") {
+		return false
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// describeModule resolves the module which contains the chaincode package at the given
+// path, which is either a local directory or an import path resolved from the module of
+// the working directory
+func describeModule(path string) (*ModuleInfo, error) {
+	dir, pkg := "", path
+	if isLocalDir(path) {
+		dir, pkg = path, "."
+	}
+
+	pkgs, err := listPackages(moduleEnv(), dir, pkg)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed resolving chaincode package %s", path))
+	}
+	if len(pkgs) != 1 || pkgs[0].Module == nil || pkgs[0].Module.Dir == "" {
+		return nil, errors.Errorf("chaincode package %s is not part of a go module", path)
+	}
+
+	return &ModuleInfo{
+		Path:       pkgs[0].Module.Path,
+		Dir:        pkgs[0].Module.Dir,
+		ImportPath: pkgs[0].ImportPath,
+		PackageDir: pkgs[0].Dir,
+	}, nil
+}
+
+// findModuleSource walks the module and returns its sources, its go.mod and go.sum,
+// and the metadata of the chaincode package. The nested modules are skipped
+func findModuleSource(module *ModuleInfo) (Sources, bool, error) {
+	return walkModule(module.Dir, "src", module.PackageDir)
+}
+
+// walkModule returns the sources of the module rooted at the given directory, named
+// under the given prefix. The metadata is collected from the chaincode package directory
+// when one is given
+func walkModule(root, prefix, packageDir string) (Sources, bool, error) {
+	var files Sources
+	vendored := false
+	walkFn := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path == root {
+				return nil
+			}
+			if strings.HasPrefix(info.Name(), ".") || info.Name() == "testdata" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				logger.Debugf("skipping nested module: %s", path)
+				return filepath.SkipDir
+			}
+			if path == filepath.Join(root, "vendor") {
+				vendored = true
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("error obtaining relative path for %s: %s", path, err)
+		}
+
+		if packageDir != "" && isMetadataDir(path, packageDir) {
+			name, err := filepath.Rel(packageDir, path)
+			if err != nil {
+				return fmt.Errorf("error obtaining relative path for %s: %s", path, err)
+			}
+			files = append(files, SourceDescriptor{Name: name, Path: path, IsMetadata: true, Info: info})
+			return nil
+		}
+
+		if !includeFileTypes[filepath.Ext(path)] && !moduleFiles[rel] && rel != filepath.Join("vendor", "modules.txt") {
+			return nil
+		}
+		files = append(files, SourceDescriptor{Name: filepath.Join(prefix, rel), Path: path, Info: info})
+		return nil
+	}
+
+	if err := filepath.Walk(root, walkFn); err != nil {
+		return nil, false, fmt.Errorf("Error walking directory: %s", err)
+	}
+	return files, vendored, nil
+}
+
+// moduleVersion is a module, or the replacement of a module, recorded in a go.mod
+type moduleVersion struct {
+	Path    string
+	Version string
+}
+
+func (m moduleVersion) String() string {
+	if m.Version == "" {
+		return m.Path
+	}
+	return m.Path + " " + m.Version
+}
+
+// goMod is a go.mod as reported by go mod edit
+type goMod struct {
+	Module  moduleVersion
+	Go      string
+	Require []moduleVersion
+	Replace []struct {
+		Old moduleVersion
+		New moduleVersion
+	}
+}
+
+// readGoMod parses the go.mod of the module rooted at the given directory
+func readGoMod(env Env, dir string) (*goMod, error) {
+	out, err := runProgramInDir(env, dir, 10*time.Second, "go", "mod", "edit", "-json")
+	if err != nil {
+		return nil, err
+	}
+	gomod := &goMod{}
+	if err := json.Unmarshal(out, gomod); err != nil {
+		return nil, errors.Wrap(err, "failed parsing go.mod")
+	}
+	return gomod, nil
+}
+
+// replaceDir is the directory of the code package, relative to the root of the chaincode
+// module, which the modules replaced by local directories are packaged under
+const replaceDir = "_replace"
+
+// localReplacement is a replace directive of the chaincode module whose
+// replacement is a directory of the filesystem
+type localReplacement struct {
+	Old moduleVersion
+	// New is the replacement directory as written in the go.mod
+	New string
+	// Dir is the replacement directory
+	Dir string
+	// Packaged is the replacement directory in the code package
+	Packaged string
+}
+
+// localReplacements returns the replace directives of the go.mod which point to local
+// directories, and checks that each of them is the root of the module it replaces
+func localReplacements(env Env, module *ModuleInfo, gomod *goMod) ([]*localReplacement, error) {
+	var replacements []*localReplacement
+	dirs := map[string]string{}
+	for _, r := range gomod.Replace {
+		if r.New.Version != "" {
+			continue
+		}
+
+		dir := r.New.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(module.Dir, dir)
+		}
+		replaced, err := readGoMod(env, dir)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("module %s is replaced by %s, which is not a module directory", r.Old.Path, r.New.Path))
+		}
+		if replaced.Module.Path != r.Old.Path {
+			return nil, errors.Errorf("module %s is replaced by %s, which declares module %s", r.Old.Path, r.New.Path, replaced.Module.Path)
+		}
+		if prev, ok := dirs[r.Old.Path]; ok && prev != dir {
+			return nil, errors.Errorf("module %s is replaced by more than one directory", r.Old.Path)
+		}
+		dirs[r.Old.Path] = dir
+
+		replacements = append(replacements, &localReplacement{
+			Old:      r.Old,
+			New:      r.New.Path,
+			Dir:      dir,
+			Packaged: "./" + replaceDir + "/" + r.Old.Path,
+		})
+	}
+	return replacements, nil
+}
+
+// findReplacementSources returns the sources of the modules replaced by local
+// directories, packaged under src/_replace
+func findReplacementSources(replacements []*localReplacement) (Sources, error) {
+	var files Sources
+	packaged := map[string]bool{}
+	for _, r := range replacements {
+		if packaged[r.Dir] {
+			continue
+		}
+		packaged[r.Dir] = true
+
+		sources, _, err := walkModule(r.Dir, filepath.Join("src", replaceDir, r.Old.Path), "")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range sources {
+			// the dependencies of the chaincode are vendored from the
+			// chaincode module only
+			if strings.HasPrefix(file.Name, filepath.Join("src", replaceDir, r.Old.Path, "vendor")+"/") {
+				continue
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// rewriteGoMod returns the go.mod of the chaincode module with the local
+// replacements pointing to their directory in the code package
+func rewriteGoMod(env Env, module *ModuleInfo, replacements []*localReplacement) ([]byte, error) {
+	gomod, err := ioutil.ReadFile(filepath.Join(module.Dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	if len(replacements) == 0 {
+		return gomod, nil
+	}
+
+	tmp, err := ioutil.TempDir("", "chaincode-module")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	if err := ioutil.WriteFile(filepath.Join(tmp, "go.mod"), gomod, 0644); err != nil {
+		return nil, err
+	}
+
+	args := []string{"mod", "edit"}
+	for _, r := range replacements {
+		old := r.Old.Path
+		if r.Old.Version != "" {
+			old += "@" + r.Old.Version
+		}
+		args = append(args, fmt.Sprintf("-replace=%s=%s", old, r.Packaged))
+	}
+	if _, err := runProgramInDir(env, tmp, 10*time.Second, "go", append(args, "go.mod")...); err != nil {
+		return nil, errors.WithMessage(err, "failed rewriting the replace directives of go.mod")
+	}
+	return ioutil.ReadFile(filepath.Join(tmp, "go.mod"))
+}
+
+// rewriteModulesTxt rewrites the local replacements recorded in the vendor/modules.txt
+// of a vendored chaincode module to their directory in the code package
+func rewriteModulesTxt(modulesTxt []byte, replacements []*localReplacement) []byte {
+	lines := strings.Split(string(modulesTxt), "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+		for _, r := range replacements {
+			if strings.HasPrefix(line, "# "+r.Old.Path+" ") && strings.HasSuffix(line, " => "+r.New) {
+				lines[i] = strings.TrimSuffix(line, r.New) + r.Packaged
+			}
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// packagedReplacement returns the replacement of a module as recorded in the code package
+func packagedReplacement(replace *listedModule, replacements []*localReplacement) string {
+	if replace.Version != "" {
+		return moduleVersion{Path: replace.Path, Version: replace.Version}.String()
+	}
+	for _, r := range replacements {
+		if r.New == replace.Path {
+			return r.Packaged
+		}
+	}
+	return replace.Path
+}
+
+// atLeastGo returns true if the go version of a go.mod is the given minor release of go1 or a later one
+func atLeastGo(version string, minor int) bool {
+	var major, m int
+	if _, err := fmt.Sscanf(version, "%d.%d", &major, &m); err != nil {
+		return false
+	}
+	return major > 1 || (major == 1 && m >= minor)
+}
+
+// vendorModuleDependencies returns the sources of the packages the chaincode depends on
+// from outside of its module, vendored under src/vendor, along with the vendor/modules.txt
+// which lists them, so that the chaincode is built without downloading its dependencies
+func vendorModuleDependencies(env Env, module *ModuleInfo, gomod *goMod, replacements []*localReplacement) (Sources, []byte, error) {
+	deps, err := listPackages(env, module.Dir, "-deps", module.ImportPath)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed listing the dependencies of the chaincode")
+	}
+
+	required := map[string]bool{}
+	for _, r := range gomod.Require {
+		required[r.Path] = true
+	}
+
+	var files Sources
+	modules := map[string]*listedModule{}
+	packages := map[string][]string{}
+	for _, dep := range deps {
+		if dep.Standard || dep.Module == nil || dep.Module.Main {
+			continue
+		}
+		modules[dep.Module.Path] = dep.Module
+		packages[dep.Module.Path] = append(packages[dep.Module.Path], dep.ImportPath)
+
+		var names []string
+		for _, list := range [][]string{dep.GoFiles, dep.CgoFiles, dep.CFiles, dep.HFiles, dep.SFiles} {
+			names = append(names, list...)
+		}
+		for _, name := range names {
+			path := filepath.Join(dep.Dir, name)
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, nil, err
+			}
+			files = append(files, SourceDescriptor{
+				Name: filepath.Join("src", "vendor", dep.ImportPath, name),
+				Path: path,
+				Info: info,
+			})
+		}
+	}
+
+	modulePaths := make([]string, 0, len(modules))
+	for path := range modules {
+		modulePaths = append(modulePaths, path)
+	}
+	sort.Strings(modulePaths)
+
+	// the format of vendor/modules.txt is the one of go mod vendor since go 1.14,
+	// recording the go version of the dependencies for the modules of go 1.17 and later
+	modulesTxt := &bytes.Buffer{}
+	for _, path := range modulePaths {
+		m := modules[path]
+		header := moduleVersion{Path: path, Version: m.Version}.String()
+		if m.Replace != nil {
+			header += " => " + packagedReplacement(m.Replace, replacements)
+		}
+		fmt.Fprintf(modulesTxt, "# %s\n", header)
+		if required[path] {
+			explicit := "## explicit"
+			if atLeastGo(gomod.Go, 17) && m.GoVersion != "" {
+				explicit += "; go " + m.GoVersion
+			}
+			fmt.Fprintln(modulesTxt, explicit)
+		}
+		sort.Strings(packages[path])
+		for _, pkg := range packages[path] {
+			fmt.Fprintln(modulesTxt, pkg)
+		}
+	}
+
+	// the replacements of any version of a module, and the ones of the
+	// modules which are not built, are recorded on their own
+	for _, r := range gomod.Replace {
+		if r.Old.Version != "" && modules[r.Old.Path] != nil && modules[r.Old.Path].Version == r.Old.Version {
+			continue
+		}
+		replacement := r.New.String()
+		for _, l := range replacements {
+			if l.Old == r.Old {
+				replacement = l.Packaged
+			}
+		}
+		fmt.Fprintf(modulesTxt, "# %s => %s\n", r.Old, replacement)
+	}
+
+	return files, modulesTxt.Bytes(), nil
+}
+
+// getModuleDeploymentPayload packages a chaincode which is part of a go module. The payload
+// contains the module under src, along with its go.mod and go.sum, the modules it replaces
+// by local directories under src/_replace, the dependencies of the chaincode under src/vendor
+// unless the module vendors them already, and the metadata of the chaincode under META-INF
+func getModuleDeploymentPayload(module *ModuleInfo) ([]byte, error) {
+	env := moduleEnv()
+	gomod, err := readGoMod(env, module.Dir)
+	if err != nil {
+		return nil, err
+	}
+	replacements, err := localReplacements(env, module, gomod)
+	if err != nil {
+		return nil, err
+	}
+
+	files, vendored, err := findModuleSource(module)
+	if err != nil {
+		return nil, err
+	}
+	replaced, err := findReplacementSources(replacements)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, replaced...)
+
+	// go.mod and vendor/modules.txt are rewritten for the
+	// local replacements to point inside the code package
+	generated := map[string][]byte{}
+	generated[filepath.Join("src", "go.mod")], err = rewriteGoMod(env, module, replacements)
+	if err != nil {
+		return nil, err
+	}
+
+	modulesTxtName := filepath.Join("src", "vendor", "modules.txt")
+	if vendored {
+		modulesTxt, err := ioutil.ReadFile(filepath.Join(module.Dir, "vendor", "modules.txt"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			generated[modulesTxtName] = rewriteModulesTxt(modulesTxt, replacements)
+		}
+	} else {
+		deps, modulesTxt, err := vendorModuleDependencies(env, module, gomod, replacements)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, deps...)
+		if len(modulesTxt) != 0 {
+			generated[modulesTxtName] = modulesTxt
+		}
+	}
+	sort.Sort(files)
+
+	payload := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(payload)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range files {
+		if _, ok := generated[file.Name]; ok {
+			continue
+		}
+
+		if file.IsMetadata {
+			// Hidden files are not supported as metadata, therefore ignore them
+			if strings.HasPrefix(filepath.Base(file.Name), ".") {
+				logger.Warningf("Ignoring hidden file in metadata directory: %s", file.Name)
+				continue
+			}
+
+			fileBytes, err := ioutil.ReadFile(file.Path)
+			if err != nil {
+				return nil, err
+			}
+			if err := ccmetadata.ValidateMetadataFile(file.Name, fileBytes); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := cutil.WriteFileToPackage(file.Path, file.Name, tw); err != nil {
+			return nil, fmt.Errorf("Error writing %s to tar: %s", file.Name, err)
+		}
+	}
+
+	names := make([]string, 0, len(generated))
+	for name := range generated {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := cutil.WriteBytesToPackage(name, generated[name], tw); err != nil {
+			return nil, fmt.Errorf("Error writing %s to tar: %s", name, err)
+		}
+	}
+
+	err = tw.Close()
+	if err == nil {
+		err = gw.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create tar for chaincode")
+	}
+
+	return payload.Bytes(), nil
+}
+
+// isModulePackage returns true if the code package contains a go module
+func isModulePackage(code []byte) (bool, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return false, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if strings.TrimPrefix(header.Name, "/") == "src/go.mod" {
+			return true, nil
+		}
+	}
+}
+
+// replaceDirectives returns the replace directives of a go.mod as pairs of the replaced
+// module and of its replacement, which are the fields on each side of the arrow
+func replaceDirectives(gomod []byte) [][2][]string {
+	var directives [][2][]string
+	inBlock := false
+	for _, line := range strings.Split(string(gomod), "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case !inBlock && fields[0] == "replace" && len(fields) == 2 && fields[1] == "(":
+			inBlock = true
+			continue
+		case !inBlock && fields[0] == "replace":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+
+		for i, field := range fields {
+			if field == "=>" {
+				directives = append(directives, [2][]string{fields[:i], fields[i+1:]})
+				break
+			}
+		}
+	}
+	return directives
+}
+
+// validateModuleReplacements checks that the replace directives of the go.mod of a code
+// package which point to local directories resolve to modules packaged under src/_replace
+func validateModuleReplacements(gomod []byte, entries map[string]bool) error {
+	for _, directive := range replaceDirectives(gomod) {
+		old, replacement := directive[0], directive[1]
+		if len(old) == 0 || len(replacement) != 1 {
+			// the replacements by another module version are resolved from the vendored dependencies
+			continue
+		}
+
+		dir := pathpkg.Clean(replacement[0])
+		if !strings.HasPrefix(dir, replaceDir+"/") {
+			return fmt.Errorf("illegal replacement of module %s in go.mod: %s is not packaged under %s", old[0], replacement[0], replaceDir)
+		}
+		if !entries[pathpkg.Join("src", dir, "go.mod")] {
+			return fmt.Errorf("illegal replacement of module %s in go.mod: %s is not part of the code package", old[0], replacement[0])
+		}
+	}
+	return nil
+}
@@ -45,7 +45,10 @@ func getCodeFromFS(path string) (codegopath string, err error) {
 
 type CodeDescriptor struct {
 	Gopath, Pkg string
-	Cleanup     func()
+	// Module is set when the chaincode is part of a go module
+	// rather than of the GOPATH
+	Module  *ModuleInfo
+	Cleanup func()
 }
 
 // collectChaincodeFiles collects chaincode files. If path is a HTTP(s) url it
@@ -61,11 +64,20 @@ func getCode(path string) (*CodeDescriptor, error) {
 	// code root will point to the directory where the code exists
 	var gopath string
 	gopath, err := getCodeFromFS(path)
-	if err != nil {
+	if err == nil && !isLocalDir(path) {
+		return &CodeDescriptor{Gopath: gopath, Pkg: path, Cleanup: nil}, nil
+	}
+
+	// the chaincode is not in the GOPATH, so it must be part of a go module
+	module, merr := describeModule(path)
+	if merr != nil {
+		if err == nil {
+			err = merr
+		}
 		return nil, fmt.Errorf("Error getting code %s", err)
 	}
 
-	return &CodeDescriptor{Gopath: gopath, Pkg: path, Cleanup: nil}, nil
+	return &CodeDescriptor{Pkg: module.ImportPath, Module: module, Cleanup: nil}, nil
 }
 
 type SourceDescriptor struct {
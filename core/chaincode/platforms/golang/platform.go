@@ -104,6 +104,13 @@ func (goPlatform *Platform) ValidatePath(rawPath string) error {
 	//which we do later anyway. But we *can* - and *should* - test for existence of local paths.
 	//Treat empty scheme as a local filesystem path
 	if path.Scheme == "" {
+		// local directories and the import paths outside of the GOPATH
+		// are accepted when they resolve to a package of a go module
+		if isLocalDir(rawPath) {
+			_, err := describeModule(rawPath)
+			return err
+		}
+
 		gopath, err := getGopath()
 		if err != nil {
 			return err
@@ -114,12 +121,29 @@ func (goPlatform *Platform) ValidatePath(rawPath string) error {
 			return fmt.Errorf("error validating chaincode path: %s", err)
 		}
 		if !exists {
+			if _, merr := describeModule(rawPath); merr == nil {
+				return nil
+			}
 			return fmt.Errorf("path to chaincode does not exist: %s", pathToCheck)
 		}
 	}
 	return nil
 }
 
+// NormalizePath returns the import path of the chaincode package at the given
+// path, which is either a local directory or an import path. The chaincode path of
+// the deployment spec must be an import path as the chaincode is built from it
+func (goPlatform *Platform) NormalizePath(rawPath string) (string, error) {
+	if !isLocalDir(rawPath) {
+		return rawPath, nil
+	}
+	module, err := describeModule(rawPath)
+	if err != nil {
+		return "", err
+	}
+	return module.ImportPath, nil
+}
+
 func (goPlatform *Platform) ValidateCodePackage(code []byte) error {
 
 	if len(code) == 0 {
@@ -144,6 +168,16 @@ func (goPlatform *Platform) ValidateCodePackage(code []byte) error {
 		return fmt.Errorf("failure opening codepackage gzip stream: %s", err)
 	}
 	tr := tar.NewReader(gr)
+	validator := util.NewPackageValidator(util.PackageRulesFromConfig())
+	determinismCheck, err := determinismCheckMode()
+	if err != nil {
+		return err
+	}
+	var hints []string
+
+	// the go.mod of a module is checked once all the entries are known
+	var gomod []byte
+	entries := map[string]bool{}
 
 	for {
 		header, err := tr.Next()
@@ -152,6 +186,21 @@ func (goPlatform *Platform) ValidateCodePackage(code []byte) error {
 			break
 		}
 
+		// --------------------------------------------------------------------------------------
+		// Check the entry against the configured package rules
+		// --------------------------------------------------------------------------------------
+		if err := validator.Validate(header); err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(header.Name, "/")
+		entries[name] = true
+		if name == "src/go.mod" {
+			if gomod, err = ioutil.ReadAll(tr); err != nil {
+				return fmt.Errorf("failure reading %s: %s", header.Name, err)
+			}
+		}
+
 		// --------------------------------------------------------------------------------------
 		// Check name for conforming path
 		// --------------------------------------------------------------------------------------
@@ -171,6 +220,25 @@ func (goPlatform *Platform) ValidateCodePackage(code []byte) error {
 		if header.Mode&^0100666 != 0 {
 			return fmt.Errorf("illegal file mode detected for file %s: %o", header.Name, header.Mode)
 		}
+
+		// --------------------------------------------------------------------------------------
+		// Check the sources of the chaincode for the sources of non-determinism
+		// --------------------------------------------------------------------------------------
+		if determinismCheck != DeterminismCheckOff && isChaincodeSource(name) {
+			src, err := ioutil.ReadAll(tr)
+			if err != nil {
+				return fmt.Errorf("failure reading %s: %s", header.Name, err)
+			}
+			hints = append(hints, determinismHints(name, src)...)
+		}
+	}
+
+	if err := checkDeterminism(determinismCheck, hints); err != nil {
+		return err
+	}
+
+	if gomod != nil {
+		return validateModuleReplacements(gomod, entries)
 	}
 
 	return nil
@@ -260,6 +328,10 @@ func (goPlatform *Platform) GetDeploymentPayload(path string) ([]byte, error) {
 		defer code.Cleanup()
 	}
 
+	if code.Module != nil {
+		return getModuleDeploymentPayload(code.Module)
+	}
+
 	// --------------------------------------------------------------------------------------
 	// Update our environment for the purposes of executing go-list directives
 	// --------------------------------------------------------------------------------------
@@ -507,10 +579,21 @@ func (goPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *ta
 	ldflagsOpt := getLDFlagsOpts()
 	logger.Infof("building chaincode with ldflagsOpt: '%s'", ldflagsOpt)
 
+	// the chaincodes of a go module are built in module mode from the
+	// dependencies vendored in the code package
+	cmd := fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go build  %s -o /chaincode/output/chaincode %s", ldflagsOpt, pkgname)
+	module, err := isModulePackage(code)
+	if err != nil {
+		return err
+	}
+	if module {
+		cmd = fmt.Sprintf("cd /chaincode/input/src && GO111MODULE=on go build -mod=vendor %s -o /chaincode/output/chaincode %s", ldflagsOpt, pkgname)
+	}
+
 	codepackage := bytes.NewReader(code)
 	binpackage := bytes.NewBuffer(nil)
 	err = util.DockerBuild(util.DockerBuildOptions{
-		Cmd:          fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go build  %s -o /chaincode/output/chaincode %s", ldflagsOpt, pkgname),
+		Cmd:          cmd,
 		InputStream:  codepackage,
 		OutputStream: binpackage,
 	})
@@ -521,6 +604,30 @@ func (goPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *ta
 	return cutil.WriteBytesToPackage("binpackage.tar", binpackage.Bytes(), tw)
 }
 
+// RunTests runs go test on the chaincode package in the builder, with the
+// dependencies of the code package
+func (goPlatform *Platform) RunTests(path string, code []byte) error {
+	pkgname, err := decodeUrl(path)
+	if err != nil {
+		return fmt.Errorf("could not decode url: %s", err)
+	}
+
+	cmd := fmt.Sprintf("GOPATH=/chaincode/input:$GOPATH go test %s", pkgname)
+	module, err := isModulePackage(code)
+	if err != nil {
+		return err
+	}
+	if module {
+		cmd = fmt.Sprintf("cd /chaincode/input/src && GO111MODULE=on go test -mod=vendor %s", pkgname)
+	}
+
+	return util.DockerBuild(util.DockerBuildOptions{
+		Cmd:          cmd,
+		InputStream:  bytes.NewReader(code),
+		OutputStream: ioutil.Discard,
+	})
+}
+
 //GetMetadataProvider fetches metadata provider given deployment spec
 func (goPlatform *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
 	return &ccmetadata.TargzMetadataProvider{Code: code}
@@ -13,6 +13,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -93,6 +94,8 @@ func TestValidateCDS(t *testing.T) {
 	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/META-INF/path/to/a/meta1", Mode: 0100555, SuccessExpected: false})
 	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/META-Inf/path/to/a/meta2", Mode: 0100400, SuccessExpected: false})
 	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "META-INF/path/to/a/meta3", Mode: 0100400, SuccessExpected: true})
+	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/src/path/to/somewhere/lib.so", Mode: 0100400, SuccessExpected: false})
+	specs = append(specs, spec{CCName: "NoCode", Path: "path/to/somewhere", File: "/src/../../etc/passwd", Mode: 0100400, SuccessExpected: false})
 
 	for _, s := range specs {
 		cds, err := generateFakeCDS(s.CCName, s.Path, s.File, s.Mode)
@@ -222,6 +225,133 @@ func Test_decodeUrl(t *testing.T) {
 	}
 }
 
+func Test_ModuleDeploymentPayload(t *testing.T) {
+	platform := &Platform{}
+
+	path, err := filepath.Abs("testdata/modules/cc/chaincode")
+	require.NoError(t, err)
+
+	payload, err := platform.GetDeploymentPayload(path)
+	require.NoError(t, err)
+	require.NoError(t, platform.ValidateCodePackage(payload))
+
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			// We only get here if there are no more entries to scan
+			break
+		}
+		names = append(names, header.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"META-INF/statedb/couchdb/indexes/indexOwner.json",
+		"src/chaincode/main.go",
+		"src/go.mod",
+		"src/store/store.go",
+	}, names)
+
+	module, err := isModulePackage(payload)
+	assert.NoError(t, err)
+	assert.True(t, module)
+}
+
+func Test_ModuleDeploymentPayloadWithReplacement(t *testing.T) {
+	platform := &Platform{}
+
+	path, err := filepath.Abs("testdata/modules/monorepo/chaincode")
+	require.NoError(t, err)
+
+	payload, err := platform.GetDeploymentPayload(path)
+	require.NoError(t, err)
+	require.NoError(t, platform.ValidateCodePackage(payload))
+
+	gr, err := gzip.NewReader(bytes.NewReader(payload))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			// We only get here if there are no more entries to scan
+			break
+		}
+		content, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "src/chaincode/main.go")
+	assert.Contains(t, files, "src/_replace/example.com/common/go.mod")
+	assert.Contains(t, files, "src/_replace/example.com/common/asset/asset.go")
+	assert.Contains(t, files, "src/vendor/example.com/common/asset/asset.go")
+	assert.Contains(t, files["src/go.mod"], "replace example.com/common => ./_replace/example.com/common")
+	assert.Equal(t, "# example.com/common v0.0.0 => ./_replace/example.com/common\n"+
+		"## explicit\n"+
+		"example.com/common/asset\n"+
+		"# example.com/common => ./_replace/example.com/common\n", files["src/vendor/modules.txt"])
+}
+
+func TestValidateModuleReplacements(t *testing.T) {
+	entries := map[string]bool{
+		"src/go.mod":                             true,
+		"src/_replace/example.com/common/go.mod": true,
+	}
+
+	var tests = []struct {
+		gomod string
+		err   string
+	}{
+		{gomod: "module example.com/cc\n\nreplace example.com/common => ./_replace/example.com/common\n"},
+		{gomod: "module example.com/cc\n\nreplace (\n\texample.com/common v1.0.0 => ./_replace/example.com/common // local\n)\n"},
+		{gomod: "module example.com/cc\n\nreplace example.com/common => example.com/fork v1.0.0\n"},
+		{
+			gomod: "module example.com/cc\n\nreplace example.com/common => ../common\n",
+			err:   "illegal replacement of module example.com/common in go.mod: ../common is not packaged under _replace",
+		},
+		{
+			gomod: "module example.com/cc\n\nreplace example.com/common => ./_replace/../../common\n",
+			err:   "illegal replacement of module example.com/common in go.mod: ./_replace/../../common is not packaged under _replace",
+		},
+		{
+			gomod: "module example.com/cc\n\nreplace (\n\texample.com/other => ./_replace/example.com/other\n)\n",
+			err:   "illegal replacement of module example.com/other in go.mod: ./_replace/example.com/other is not part of the code package",
+		},
+	}
+
+	for _, tst := range tests {
+		err := validateModuleReplacements([]byte(tst.gomod), entries)
+		if tst.err == "" {
+			assert.NoError(t, err, tst.gomod)
+		} else {
+			assert.EqualError(t, err, tst.err, tst.gomod)
+		}
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	platform := &Platform{}
+
+	path, err := filepath.Abs("testdata/modules/cc/chaincode")
+	require.NoError(t, err)
+
+	normalized, err := platform.NormalizePath(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com/cc/chaincode", normalized)
+
+	normalized, err = platform.NormalizePath("github.com/hyperledger/fabric/examples/chaincode/go/map")
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/hyperledger/fabric/examples/chaincode/go/map", normalized)
+
+	_, err = platform.NormalizePath("./testdata/src/chaincodes/BadImport")
+	assert.Error(t, err)
+}
+
 func TestValidatePath(t *testing.T) {
 	platform := &Platform{}
 
@@ -234,6 +364,8 @@ func TestValidatePath(t *testing.T) {
 		{path: "github.com/hyperledger/fabric/examples/chaincode/go/map", succ: true},
 		{path: "github.com/hyperledger/fabric/bad/chaincode/go/map", succ: false},
 		{path: ":github.com/hyperledger/fabric/examples/chaincode/go/map", succ: false},
+		{path: "./testdata/modules/cc/chaincode", succ: true},
+		{path: "./testdata/src/chaincodes/BadImport", succ: false},
 	}
 
 	for _, tst := range tests {
@@ -0,0 +1,3 @@
+module example.com/cc
+
+go 1.12
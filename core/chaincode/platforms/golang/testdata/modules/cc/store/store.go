@@ -0,0 +1,12 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+// Key returns the key an asset is stored under
+func Key(owner, name string) string {
+	return owner + "~" + name
+}
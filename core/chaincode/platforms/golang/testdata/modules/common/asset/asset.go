@@ -0,0 +1,13 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package asset
+
+// Asset is an asset shared by the chaincodes of the repository
+type Asset struct {
+	Owner string
+	Name  string
+}
@@ -0,0 +1,17 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"fmt"
+
+	"example.com/common/asset"
+)
+
+func main() {
+	fmt.Println(asset.Asset{Owner: "alice", Name: "marble"})
+}
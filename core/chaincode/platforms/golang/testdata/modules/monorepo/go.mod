@@ -0,0 +1,7 @@
+module example.com/monorepo
+
+go 1.12
+
+require example.com/common v0.0.0
+
+replace example.com/common => ../common
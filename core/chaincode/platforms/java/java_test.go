@@ -75,6 +75,50 @@ func TestValidateCodePackage(t *testing.T) {
 	assert.NoError(t, platform.ValidateCodePackage(b))
 }
 
+func TestValidateCodePackageLanguages(t *testing.T) {
+	platform := java.Platform{}
+
+	b, _ := generateMockPackage(map[string]string{
+		"src/build.gradle.kts":                `plugins { kotlin("jvm") version "1.3.21" }`,
+		"src/settings.gradle.kts":             `rootProject.name = "kotlincc"`,
+		"src/gradle.properties":               `kotlin.code.style=official`,
+		"src/src/main/kotlin/example/CC.kt":   "package example",
+		"src/src/main/java/example/Util.java": "package example;",
+	})
+	assert.NoError(t, platform.ValidateCodePackage(b))
+
+	b, _ = generateMockPackage(map[string]string{
+		"src/build.gradle":                  `apply plugin: 'org.jetbrains.kotlin.jvm'`,
+		"src/src/main/kotlin/example/CC.kt": "package example",
+	})
+	assert.NoError(t, platform.ValidateCodePackage(b))
+
+	b, _ = generateMockPackage(map[string]string{
+		"src/build.gradle":                  `plugins { id 'java' }`,
+		"src/src/main/kotlin/example/CC.kt": "package example",
+	})
+	assert.EqualError(t, platform.ValidateCodePackage(b), "the gradle build of the kotlin chaincode does not apply the kotlin plugin")
+
+	b, _ = generateMockPackage(map[string]string{
+		"src/pom.xml":                       "<project/>",
+		"src/src/main/kotlin/example/CC.kt": "package example",
+	})
+	assert.EqualError(t, platform.ValidateCodePackage(b), "kotlin chaincode must be built with gradle")
+
+	b, _ = generateMockPackage(map[string]string{
+		"src/build.gradle":                    `plugins { id 'scala' }`,
+		"src/src/main/scala/example/CC.scala": "package example",
+		"src/src/main/kotlin/example/Util.kt": "package example",
+	})
+	assert.NoError(t, platform.ValidateCodePackage(b))
+
+	b, _ = generateMockPackage(map[string]string{
+		"src/build.gradle.kts":                `plugins { java }`,
+		"src/src/main/scala/example/CC.scala": "package example",
+	})
+	assert.EqualError(t, platform.ValidateCodePackage(b), "the gradle build of the scala chaincode does not apply the scala plugin")
+}
+
 func TestGetDeploymentPayload(t *testing.T) {
 	platform := java.Platform{}
 
@@ -211,3 +255,21 @@ func generateMockPackegeBytes(fileName string, mode int64) ([]byte, error) {
 	gw.Close()
 	return codePackage.Bytes(), nil
 }
+
+func generateMockPackage(files map[string]string) ([]byte, error) {
+	codePackage := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(codePackage)
+	tw := tar.NewWriter(gw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Size: int64(len(content)), Mode: 0100644})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			return nil, err
+		}
+	}
+	tw.Close()
+	gw.Close()
+	return codePackage.Bytes(), nil
+}
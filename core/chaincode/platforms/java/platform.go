@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"regexp"
 	"strings"
@@ -53,7 +54,7 @@ func (javaPlatform *Platform) ValidateCodePackage(code []byte) error {
 	}
 
 	// File to be valid should match first RegExp and not match second one.
-	filesToMatch := regexp.MustCompile(`^(/)?src/((src|META-INF)/.*|(build\.gradle|settings\.gradle|pom\.xml))`)
+	filesToMatch := regexp.MustCompile(`^(/)?src/((src|META-INF)/.*|(build\.gradle(\.kts)?|settings\.gradle(\.kts)?|gradle\.properties|pom\.xml))`)
 	filesToIgnore := regexp.MustCompile(`.*\.class$`)
 	is := bytes.NewReader(code)
 	gr, err := gzip.NewReader(is)
@@ -61,7 +62,9 @@ func (javaPlatform *Platform) ValidateCodePackage(code []byte) error {
 		return fmt.Errorf("failure opening codepackage gzip stream: %s", err)
 	}
 	tr := tar.NewReader(gr)
+	validator := util.NewPackageValidator(util.PackageRulesFromConfig())
 
+	p := newProject()
 	for {
 		header, err := tr.Next()
 		if err != nil {
@@ -73,6 +76,13 @@ func (javaPlatform *Platform) ValidateCodePackage(code []byte) error {
 			}
 		}
 
+		// --------------------------------------------------------------------------------------
+		// Check the entry against the configured package rules
+		// --------------------------------------------------------------------------------------
+		if err := validator.Validate(header); err != nil {
+			return err
+		}
+
 		// --------------------------------------------------------------------------------------
 		// Check name for conforming path
 		// --------------------------------------------------------------------------------------
@@ -92,8 +102,16 @@ func (javaPlatform *Platform) ValidateCodePackage(code []byte) error {
 		if header.Mode&^0100666 != 0 {
 			return fmt.Errorf("illegal file mode detected for file %s: %o", header.Name, header.Mode)
 		}
+
+		if err := p.add(header, tr); err != nil {
+			return err
+		}
 	}
-	return nil
+
+	// --------------------------------------------------------------------------------------
+	// Check that the kotlin and scala sources are built with the plugin of their language
+	// --------------------------------------------------------------------------------------
+	return p.validate()
 }
 
 // WritePackage writes the java chaincode package
@@ -143,17 +161,26 @@ func (javaPlatform *Platform) GenerateDockerfile() (string, error) {
 	return dockerFileContents, nil
 }
 
+// GenerateDockerBuild builds the java, kotlin and scala chaincodes in the java runtime
+// image. The resulting jar only requires a JVM, so the chaincodes of all these languages
+// share the same runtime Dockerfile
 func (javaPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *tar.Writer) error {
+	p, err := inspectCodePackage(code)
+	if err != nil {
+		return err
+	}
+
 	codepackage := bytes.NewReader(code)
 	binpackage := bytes.NewBuffer(nil)
 	buildOptions := util.DockerBuildOptions{
 		Image:        cutil.GetDockerfileFromConfig("chaincode.java.runtime"),
-		Cmd:          "./build.sh",
+		Env:          p.buildEnv(),
+		Cmd:          p.buildCmd(),
 		InputStream:  codepackage,
 		OutputStream: binpackage,
 	}
-	logger.Debugf("Executing docker build %v, %v", buildOptions.Image, buildOptions.Cmd)
-	err := util.DockerBuild(buildOptions)
+	logger.Debugf("Executing docker build of %s chaincode %v, %v", p.language, buildOptions.Image, buildOptions.Cmd)
+	err = util.DockerBuild(buildOptions)
 	if err != nil {
 		logger.Errorf("Can't build java chaincode %v", err)
 		return err
@@ -163,6 +190,24 @@ func (javaPlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *
 	return cutil.WriteBytesToPackage("binpackage.tar", resultBytes, tw)
 }
 
+// RunTests runs the tests of the chaincode with gradle or maven in the java runtime image
+func (javaPlatform *Platform) RunTests(path string, code []byte) error {
+	p, err := inspectCodePackage(code)
+	if err != nil {
+		return err
+	}
+
+	testOptions := util.DockerBuildOptions{
+		Image:        cutil.GetDockerfileFromConfig("chaincode.java.runtime"),
+		Env:          p.buildEnv(),
+		Cmd:          p.testCmd(),
+		InputStream:  bytes.NewReader(code),
+		OutputStream: ioutil.Discard,
+	}
+	logger.Debugf("Executing the tests of %s chaincode %v, %v", p.language, testOptions.Image, testOptions.Cmd)
+	return util.DockerBuild(testOptions)
+}
+
 //GetMetadataProvider fetches metadata provider given deployment spec
 func (javaPlatform *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
 	return &ccmetadata.TargzMetadataProvider{Code: code}
@@ -0,0 +1,155 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package java
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Language is the JVM language the sources of a chaincode are written in
+type Language string
+
+const (
+	Java   Language = "java"
+	Kotlin Language = "kotlin"
+	Scala  Language = "scala"
+)
+
+var (
+	// the Kotlin DSL build scripts refer to the kotlin plugin as kotlin("jvm"),
+	// and the Groovy ones with its full identifier
+	kotlinPlugin = regexp.MustCompile(`org\.jetbrains\.kotlin|kotlin\(\s*"jvm"\s*\)`)
+	scalaPlugin  = regexp.MustCompile("(id|plugin:)\\s*\\(?\\s*['\"]scala['\"]|`scala`")
+)
+
+// project describes the sources and the build of a java chaincode package
+type project struct {
+	language    Language
+	gradle      bool
+	kotlinDSL   bool
+	buildScript []byte
+}
+
+func newProject() *project {
+	return &project{language: Java}
+}
+
+// inspectCodePackage reads the sources and the build scripts of a code package
+func inspectCodePackage(code []byte) (*project, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return nil, fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(gr)
+
+	p := newProject()
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return p, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := p.add(header, tr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// add records an entry of the code package, reading the content of the build scripts
+func (p *project) add(header *tar.Header, tr *tar.Reader) error {
+	name := strings.TrimPrefix(header.Name, "/")
+	switch name {
+	case "src/build.gradle", "src/build.gradle.kts":
+		script, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("failure reading %s: %s", header.Name, err)
+		}
+		p.gradle = true
+		p.kotlinDSL = name == "src/build.gradle.kts"
+		p.buildScript = script
+		return nil
+	}
+
+	// the language with the most specific sources wins, as the kotlin
+	// and the scala projects may contain java sources too
+	switch {
+	case strings.HasPrefix(name, "src/src/main/kotlin/") || filepath.Ext(name) == ".kt":
+		if p.language != Scala {
+			p.language = Kotlin
+		}
+	case strings.HasPrefix(name, "src/src/main/scala/") || filepath.Ext(name) == ".scala":
+		p.language = Scala
+	}
+	return nil
+}
+
+// validate checks that the kotlin and the scala sources are built by
+// gradle with the plugin of their language
+func (p *project) validate() error {
+	var plugin *regexp.Regexp
+	switch p.language {
+	case Kotlin:
+		plugin = kotlinPlugin
+	case Scala:
+		plugin = scalaPlugin
+	default:
+		return nil
+	}
+
+	if !p.gradle {
+		return fmt.Errorf("%s chaincode must be built with gradle", p.language)
+	}
+	if !plugin.Match(p.buildScript) {
+		return fmt.Errorf("the gradle build of the %s chaincode does not apply the %s plugin", p.language, p.language)
+	}
+	return nil
+}
+
+// gradleKotlinDSLBuildCmd builds the projects with a Kotlin DSL build script,
+// which the build script of the java runtime image does not detect
+const gradleKotlinDSLBuildCmd = `set -e
+TMP_DIR=$(mktemp -d)
+cp -r /chaincode/input/src/. $TMP_DIR
+cd $TMP_DIR
+gradle build shadowJar -x test
+cp build/libs/chaincode.jar /chaincode/output/`
+
+// buildCmd returns the command which builds the project in the java runtime image
+func (p *project) buildCmd() string {
+	if p.kotlinDSL {
+		return gradleKotlinDSLBuildCmd
+	}
+	return "./build.sh"
+}
+
+// testCmd returns the command which runs the tests of the project in the java runtime image
+func (p *project) testCmd() string {
+	tool := "mvn test"
+	if p.gradle {
+		tool = "gradle test"
+	}
+	return fmt.Sprintf(`set -e
+TMP_DIR=$(mktemp -d)
+cp -r /chaincode/input/src/. $TMP_DIR
+cd $TMP_DIR
+%s`, tool)
+}
+
+// buildEnv returns the environment of the build of the project
+func (p *project) buildEnv() []string {
+	return []string{"CHAINCODE_LANGUAGE=" + string(p.language)}
+}
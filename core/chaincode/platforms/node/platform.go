@@ -12,6 +12,7 @@ import (
 	"compress/gzip"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -95,6 +96,7 @@ func (nodePlatform *Platform) ValidateCodePackage(code []byte) error {
 		return fmt.Errorf("failure opening codepackage gzip stream: %s", err)
 	}
 	tr := tar.NewReader(gr)
+	validator := util.NewPackageValidator(util.PackageRulesFromConfig())
 
 	var foundPackageJson = false
 	for {
@@ -104,6 +106,13 @@ func (nodePlatform *Platform) ValidateCodePackage(code []byte) error {
 			break
 		}
 
+		// --------------------------------------------------------------------------------------
+		// Check the entry against the configured package rules
+		// --------------------------------------------------------------------------------------
+		if err := validator.Validate(header); err != nil {
+			return err
+		}
+
 		// --------------------------------------------------------------------------------------
 		// Check name for conforming path
 		// --------------------------------------------------------------------------------------
@@ -202,6 +211,16 @@ func (nodePlatform *Platform) GenerateDockerBuild(path string, code []byte, tw *
 	return cutil.WriteBytesToPackage("binpackage.tar", binpackage.Bytes(), tw)
 }
 
+// RunTests runs npm test on the chaincode in the builder, with its
+// development dependencies installed
+func (nodePlatform *Platform) RunTests(path string, code []byte) error {
+	return util.DockerBuild(util.DockerBuildOptions{
+		Cmd:          "mkdir -p /tmp/chaincode && cp -R /chaincode/input/src/. /tmp/chaincode && cd /tmp/chaincode && npm install && npm test",
+		InputStream:  bytes.NewReader(code),
+		OutputStream: ioutil.Discard,
+	})
+}
+
 //GetMetadataProvider fetches metadata provider given deployment spec
 func (nodePlatform *Platform) GetMetadataProvider(code []byte) platforms.MetadataProvider {
 	return &ccmetadata.TargzMetadataProvider{Code: code}
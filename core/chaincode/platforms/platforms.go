@@ -8,13 +8,16 @@ package platforms
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
 	"strings"
+	"text/template"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metadata"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/util"
 	cutil "github.com/hyperledger/fabric/core/container/util"
 )
 
@@ -39,6 +42,24 @@ type Platform interface {
 	GetMetadataProvider(code []byte) MetadataProvider
 }
 
+// PathNormalizer is implemented by the platforms which accept chaincode paths
+// that must be rewritten before they are recorded in the deployment spec
+type PathNormalizer interface {
+	NormalizePath(path string) (string, error)
+}
+
+// TestRunner is implemented by the platforms which can run the tests of a chaincode
+// in the builder before its deployment payload is distributed
+type TestRunner interface {
+	RunTests(path string, code []byte) error
+}
+
+// DockerfileExtender is implemented by the platforms whose Dockerfile depends
+// on the code package of the chaincode, e.g. on the environment it declares
+type DockerfileExtender interface {
+	ExtendDockerfile(code []byte) ([]string, error)
+}
+
 type PackageWriter interface {
 	Write(name string, payload []byte, tw *tar.Writer) error
 }
@@ -78,11 +99,48 @@ func (r *Registry) ValidateSpec(ccType, path string) error {
 	return platform.ValidatePath(path)
 }
 
+// NormalizePath returns the path of the chaincode as the platform builds it,
+// which is the given path for the platforms that do not rewrite paths
+func (r *Registry) NormalizePath(ccType, path string) (string, error) {
+	platform, ok := r.Platforms[ccType]
+	if !ok {
+		return "", fmt.Errorf("Unknown chaincodeType: %s", ccType)
+	}
+	normalizer, ok := platform.(PathNormalizer)
+	if !ok {
+		return path, nil
+	}
+	return normalizer.NormalizePath(path)
+}
+
+// RunTests runs the tests of the code package of a chaincode in the builder of its platform
+func (r *Registry) RunTests(ccType, path string, codePackage []byte) error {
+	platform, ok := r.Platforms[ccType]
+	if !ok {
+		return fmt.Errorf("Unknown chaincodeType: %s", ccType)
+	}
+	runner, ok := platform.(TestRunner)
+	if !ok {
+		return fmt.Errorf("running the tests of %s chaincodes is not supported", ccType)
+	}
+	return runner.RunTests(path, codePackage)
+}
+
+// CheckPackageLimits checks a code package against the configured limits on its
+// size, so that it is rejected before it is expanded. The limits are reported
+// as a *util.PackageLimitError
+func (r *Registry) CheckPackageLimits(codePackage []byte) error {
+	return util.CheckPackageLimits(codePackage, util.PackageRulesFromConfig())
+}
+
 func (r *Registry) ValidateDeploymentSpec(ccType string, codePackage []byte) error {
 	platform, ok := r.Platforms[ccType]
 	if !ok {
 		return fmt.Errorf("Unknown chaincodeType: %s", ccType)
 	}
+	if err := r.CheckPackageLimits(codePackage); err != nil {
+		return err
+	}
 	return platform.ValidateCodePackage(codePackage)
 }
 
@@ -100,10 +158,57 @@ func (r *Registry) GetDeploymentPayload(ccType, path string) ([]byte, error) {
 		return nil, fmt.Errorf("Unknown chaincodeType: %s", ccType)
 	}
 
-	return platform.GetDeploymentPayload(path)
+	payload, err := platform.GetDeploymentPayload(path)
+	if err != nil {
+		return payload, err
+	}
+	if err := r.CheckPackageLimits(payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// DockerfileTemplate is the data of the Dockerfile templates which the
+// operators configure under chaincode.<platform>.dockerfile, e.g. to build the
+// chaincode images from corporate base images or behind proxies. The default
+// template is
+//
+//	FROM {{.BaseImage}}
+//	{{.Instructions}}
+//	{{.Labels}}
+//	{{.Env}}
+//
+// where $(ARCH), $(DOCKER_NS) and the other variables of the runtime images
+// are replaced as in the runtime images.
+type DockerfileTemplate struct {
+	// BaseImage is the runtime image of the platform, from the FROM
+	// instruction of the platform
+	BaseImage string
+	// Instructions are the instructions of the platform following its FROM
+	// instruction, which add the chaincode to the image
+	Instructions string
+	// Labels is the LABEL instruction identifying the chaincode
+	Labels string
+	// Env are the ENV instructions of the peer and of the platform
+	Env string
+	// CACerts is the file of the build context holding the CA certificates
+	// configured under chaincode.build.caCerts, if any, e.g. for a template
+	// adding them to the trust store of the image
+	CACerts string
+	// Name, Version and Type identify the chaincode
+	Name    string
+	Version string
+	Type    string
 }
 
 func (r *Registry) GenerateDockerfile(ccType, name, version string) (string, error) {
+	return r.generateDockerfile(ccType, name, version, nil, false)
+}
+
+// generateDockerfile generates the Dockerfile of the chaincode, with the
+// given additional ENV instructions of the platform, for a build context
+// which holds the CA certificates of the builds or not
+func (r *Registry) generateDockerfile(ccType, name, version string, env []string, caCerts bool) (string, error) {
 	platform, ok := r.Platforms[ccType]
 	if !ok {
 		return "", fmt.Errorf("Unknown chaincodeType: %s", ccType)
@@ -118,31 +223,77 @@ func (r *Registry) GenerateDockerfile(ccType, name, version string) (string, err
 	if err != nil {
 		return "", fmt.Errorf("Failed to generate platform-specific Dockerfile: %s", err)
 	}
-	buf = append(buf, base)
 
 	// ----------------------------------------------------------------------------------------------------
 	// Add some handy labels
 	// ----------------------------------------------------------------------------------------------------
-	buf = append(buf, fmt.Sprintf(`LABEL %s.chaincode.id.name="%s" \`, metadata.BaseDockerLabel, name))
-	buf = append(buf, fmt.Sprintf(`      %s.chaincode.id.version="%s" \`, metadata.BaseDockerLabel, version))
-	buf = append(buf, fmt.Sprintf(`      %s.chaincode.type="%s" \`, metadata.BaseDockerLabel, ccType))
-	buf = append(buf, fmt.Sprintf(`      %s.version="%s" \`, metadata.BaseDockerLabel, metadata.Version))
-	buf = append(buf, fmt.Sprintf(`      %s.base.version="%s"`, metadata.BaseDockerLabel, metadata.BaseVersion))
+	var labels []string
+	labels = append(labels, fmt.Sprintf(`LABEL %s.chaincode.id.name="%s" \`, metadata.BaseDockerLabel, name))
+	labels = append(labels, fmt.Sprintf(`      %s.chaincode.id.version="%s" \`, metadata.BaseDockerLabel, version))
+	labels = append(labels, fmt.Sprintf(`      %s.chaincode.type="%s" \`, metadata.BaseDockerLabel, ccType))
+	labels = append(labels, fmt.Sprintf(`      %s.version="%s" \`, metadata.BaseDockerLabel, metadata.Version))
+	labels = append(labels, fmt.Sprintf(`      %s.base.version="%s"`, metadata.BaseDockerLabel, metadata.BaseVersion))
 	// ----------------------------------------------------------------------------------------------------
 	// Then augment it with any general options
 	// ----------------------------------------------------------------------------------------------------
 	//append version so chaincode build version can be compared against peer build version
-	buf = append(buf, fmt.Sprintf("ENV CORE_CHAINCODE_BUILDLEVEL=%s", metadata.Version))
+	env = append([]string{fmt.Sprintf("ENV CORE_CHAINCODE_BUILDLEVEL=%s", metadata.Version)}, env...)
+
+	// ----------------------------------------------------------------------------------------------------
+	// Render the template of the operator, if any
+	// ----------------------------------------------------------------------------------------------------
+	templateKey := fmt.Sprintf("chaincode.%s.dockerfile", strings.ToLower(ccType))
+	if tmpl := cutil.GetDockerfileFromConfig(templateKey); tmpl != "" {
+		data := DockerfileTemplate{
+			Instructions: base,
+			Labels:       strings.Join(labels, "\n"),
+			Env:          strings.Join(env, "\n"),
+			Name:         name,
+			Version:      version,
+			Type:         ccType,
+		}
+		if caCerts {
+			data.CACerts = cutil.BuildCACertsFile
+		}
+		if lines := strings.SplitN(base, "\n", 2); strings.HasPrefix(lines[0], "FROM ") {
+			data.BaseImage = strings.TrimSpace(strings.TrimPrefix(lines[0], "FROM "))
+			data.Instructions = ""
+			if len(lines) == 2 {
+				data.Instructions = lines[1]
+			}
+		}
+		contents, err := renderDockerfile(tmpl, data)
+		if err != nil {
+			return "", fmt.Errorf("Invalid Dockerfile template %s: %s", templateKey, err)
+		}
+		logger.Debugf("\n%s", contents)
+		return contents, nil
+	}
 
 	// ----------------------------------------------------------------------------------------------------
 	// Finalize it
 	// ----------------------------------------------------------------------------------------------------
+	buf = append(buf, base)
+	buf = append(buf, labels...)
+	buf = append(buf, env...)
 	contents := strings.Join(buf, "\n")
 	logger.Debugf("\n%s", contents)
 
 	return contents, nil
 }
 
+func renderDockerfile(tmpl string, data DockerfileTemplate) (string, error) {
+	t, err := template.New("Dockerfile").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	var contents bytes.Buffer
+	if err := t.Execute(&contents, data); err != nil {
+		return "", err
+	}
+	return contents.String(), nil
+}
+
 func (r *Registry) StreamDockerBuild(ccType, path string, codePackage []byte, inputFiles map[string][]byte, tw *tar.Writer) error {
 	var err error
 
@@ -182,12 +333,27 @@ func (r *Registry) GenerateDockerBuild(ccType, path, name, version string, codeP
 	// ----------------------------------------------------------------------------------------------------
 	// Generate the Dockerfile specific to our context
 	// ----------------------------------------------------------------------------------------------------
-	dockerFile, err := r.GenerateDockerfile(ccType, name, version)
+	var env []string
+	if extender, ok := r.Platforms[ccType].(DockerfileExtender); ok {
+		instructions, err := extender.ExtendDockerfile(codePackage)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to generate a Dockerfile: %s", err)
+		}
+		env = instructions
+	}
+	caCerts, err := cutil.BuildCACertsFromConfig()
+	if err != nil {
+		return nil, err
+	}
+	dockerFile, err := r.generateDockerfile(ccType, name, version, env, len(caCerts) > 0)
 	if err != nil {
 		return nil, fmt.Errorf("Failed to generate a Dockerfile: %s", err)
 	}
 
 	inputFiles["Dockerfile"] = []byte(dockerFile)
+	if len(caCerts) > 0 {
+		inputFiles[cutil.BuildCACertsFile] = caCerts
+	}
 
 	// ----------------------------------------------------------------------------------------------------
 	// Finally, launch an asynchronous process to stream all of the above into a docker build context
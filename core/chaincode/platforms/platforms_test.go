@@ -12,12 +12,17 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/mock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/spf13/viper"
 )
 
 var _ = Describe("Platforms", func() {
@@ -88,6 +93,63 @@ var _ = Describe("Platforms", func() {
 			})
 		})
 
+		Describe("NormalizePath", func() {
+			It("returns the path when the platform does not normalize paths", func() {
+				path, err := registry.NormalizePath("fakeType", "cc-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(path).To(Equal("cc-path"))
+			})
+
+			Context("when the platform normalizes paths", func() {
+				BeforeEach(func() {
+					registry.Platforms["fakeType"] = &normalizingPlatform{Platform: fakePlatform}
+				})
+
+				It("returns the result of the underlying platform", func() {
+					path, err := registry.NormalizePath("fakeType", "cc-path")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(path).To(Equal("normalized/cc-path"))
+				})
+			})
+
+			Context("when the platform is unknown", func() {
+				It("returns an error", func() {
+					_, err := registry.NormalizePath("badType", "")
+					Expect(err).To(MatchError("Unknown chaincodeType: badType"))
+				})
+			})
+		})
+
+		Describe("RunTests", func() {
+			It("returns an error when the platform does not run tests", func() {
+				err := registry.RunTests("fakeType", "cc-path", []byte("code-package"))
+				Expect(err).To(MatchError("running the tests of fakeType chaincodes is not supported"))
+			})
+
+			Context("when the platform runs tests", func() {
+				var runner *testingPlatform
+
+				BeforeEach(func() {
+					runner = &testingPlatform{Platform: fakePlatform, err: errors.New("fake-error")}
+					registry.Platforms["fakeType"] = runner
+				})
+
+				It("returns the result of the underlying platform", func() {
+					err := registry.RunTests("fakeType", "cc-path", []byte("code-package"))
+					Expect(err).To(MatchError("fake-error"))
+					Expect(runner.path).To(Equal("cc-path"))
+					Expect(runner.code).To(Equal([]byte("code-package")))
+				})
+			})
+
+			Context("when the platform is unknown", func() {
+				It("returns an error", func() {
+					err := registry.RunTests("badType", "", nil)
+					Expect(err).To(MatchError("Unknown chaincodeType: badType"))
+				})
+			})
+		})
+
 		Describe("GetDeploymentPayload", func() {
 			It("returns the result of the underlying platform", func() {
 				fakePlatform.GetDeploymentPayloadReturns([]byte("payload"), errors.New("fake-error"))
@@ -131,6 +193,63 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 			})
 		})
 
+		Context("when a Dockerfile template is configured for the platform", func() {
+			BeforeEach(func() {
+				fakePlatform.GenerateDockerfileReturns("FROM fake-runtime\nADD binpackage.tar /usr/local/bin", nil)
+				viper.Set("chaincode.faketype.dockerfile", `FROM registry.example.com/{{.BaseImage}}
+ENV HTTP_PROXY=http://proxy.example.com:3128
+{{.Instructions}}
+RUN [ "/usr/local/bin/chaincode", "--check" ]
+{{.Labels}}
+LABEL com.example.chaincode="{{.Name}}:{{.Version}}" com.example.arch="$(ARCH)"
+{{.Env}}`)
+			})
+
+			AfterEach(func() {
+				viper.Set("chaincode.faketype.dockerfile", "")
+			})
+
+			It("renders the template", func() {
+				df, err := registry.GenerateDockerfile("fakeType", "cc-name", "cc-version")
+				Expect(err).NotTo(HaveOccurred())
+				expectedDockerfile := fmt.Sprintf(`FROM registry.example.com/fake-runtime
+ENV HTTP_PROXY=http://proxy.example.com:3128
+ADD binpackage.tar /usr/local/bin
+RUN [ "/usr/local/bin/chaincode", "--check" ]
+LABEL org.hyperledger.fabric.chaincode.id.name="cc-name" \
+      org.hyperledger.fabric.chaincode.id.version="cc-version" \
+      org.hyperledger.fabric.chaincode.type="fakeType" \
+      org.hyperledger.fabric.version="%s" \
+      org.hyperledger.fabric.base.version="%s"
+LABEL com.example.chaincode="cc-name:cc-version" com.example.arch="%s"
+ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, runtime.GOARCH, metadata.Version)
+				Expect(df).To(Equal(expectedDockerfile))
+			})
+
+			Context("when the platform has no FROM instruction", func() {
+				It("passes its instructions whole", func() {
+					fakePlatform.GenerateDockerfileReturns("docker-header", nil)
+					viper.Set("chaincode.faketype.dockerfile", "FROM base-image\n{{.Instructions}}")
+					df, err := registry.GenerateDockerfile("fakeType", "cc-name", "cc-version")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(df).To(Equal("FROM base-image\ndocker-header"))
+				})
+			})
+
+			Context("when the template is invalid", func() {
+				It("returns an error", func() {
+					viper.Set("chaincode.faketype.dockerfile", "FROM {{.Image}}")
+					_, err := registry.GenerateDockerfile("fakeType", "cc-name", "cc-version")
+					Expect(err).To(MatchError(ContainSubstring("Invalid Dockerfile template chaincode.faketype.dockerfile: ")))
+					Expect(err).To(MatchError(ContainSubstring("can't evaluate field Image")))
+
+					viper.Set("chaincode.faketype.dockerfile", "FROM {{.BaseImage")
+					_, err = registry.GenerateDockerfile("fakeType", "cc-name", "cc-version")
+					Expect(err).To(MatchError(ContainSubstring("Invalid Dockerfile template chaincode.faketype.dockerfile: ")))
+				})
+			})
+		})
+
 		Context("when the platform is unknown", func() {
 			It("returns an error", func() {
 				df, err := registry.GenerateDockerfile("badType", "", "")
@@ -218,6 +337,55 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 				})
 			})
 
+			Context("when CA certificates are configured for the builds", func() {
+				var (
+					dir  string
+					cert []byte
+				)
+
+				BeforeEach(func() {
+					var err error
+					dir, err = ioutil.TempDir("", "buildcacerts")
+					Expect(err).NotTo(HaveOccurred())
+					ca, err := tlsgen.NewCA()
+					Expect(err).NotTo(HaveOccurred())
+					cert = ca.CertBytes()
+					err = ioutil.WriteFile(filepath.Join(dir, "ca.pem"), cert, 0644)
+					Expect(err).NotTo(HaveOccurred())
+					viper.Set("chaincode.build.caCerts", []string{filepath.Join(dir, "ca.pem")})
+					viper.Set("chaincode.faketype.dockerfile", "FROM {{.BaseImage}}\nCOPY {{.CACerts}} /usr/local/share/ca-certificates/")
+				})
+
+				AfterEach(func() {
+					viper.Set("chaincode.build.caCerts", nil)
+					viper.Set("chaincode.faketype.dockerfile", "")
+					os.RemoveAll(dir)
+				})
+
+				It("adds them to the build context", func() {
+					fakePlatform.GenerateDockerfileReturns("FROM fake-runtime", nil)
+					reader, err := registry.GenerateDockerBuild("fakeType", "", "", "", nil)
+					Expect(err).NotTo(HaveOccurred())
+					_, err = ioutil.ReadAll(reader)
+					Expect(err).NotTo(HaveOccurred())
+
+					files := map[string][]byte{}
+					for i := 0; i < pw.WriteCallCount(); i++ {
+						name, data, _ := pw.WriteArgsForCall(i)
+						files[name] = data
+					}
+					Expect(files).To(HaveLen(2))
+					Expect(files["fabric-build-ca.crt"]).To(Equal(cert))
+					Expect(string(files["Dockerfile"])).To(Equal("FROM fake-runtime\nCOPY fabric-build-ca.crt /usr/local/share/ca-certificates/"))
+				})
+
+				It("returns an error when they cannot be read", func() {
+					viper.Set("chaincode.build.caCerts", []string{filepath.Join(dir, "missing.pem")})
+					_, err := registry.GenerateDockerBuild("fakeType", "", "", "", nil)
+					Expect(err).To(MatchError(ContainSubstring("could not read the CA certificates of the chaincode builds")))
+				})
+			})
+
 			Context("when there is a problem streaming the dockerbuild", func() {
 				It("closes the reader with an error", func() {
 					pw.WriteReturns(errors.New("fake-error"))
@@ -272,3 +440,23 @@ ENV CORE_CHAINCODE_BUILDLEVEL=%s`, metadata.Version, metadata.BaseVersion, metad
 		})
 	})
 })
+
+type normalizingPlatform struct {
+	*mock.Platform
+}
+
+func (p *normalizingPlatform) NormalizePath(path string) (string, error) {
+	return "normalized/" + path, nil
+}
+
+type testingPlatform struct {
+	*mock.Platform
+	path string
+	code []byte
+	err  error
+}
+
+func (p *testingPlatform) RunTests(path string, code []byte) error {
+	p.path, p.code = path, code
+	return p.err
+}
@@ -109,6 +109,15 @@ func IsCodeExist(tmppath string) error {
 	return nil
 }
 
+// buildCACertsPath is the path of the CA certificates of the builds in the
+// builder containers
+var buildCACertsPath = "/tmp/" + cutil.BuildCACertsFile
+
+// trustBuildCACerts adds the CA certificates of the builds to the trust
+// store of the builders based on Debian or Alpine before their command
+var trustBuildCACerts = fmt.Sprintf("if [ -d /usr/local/share/ca-certificates ] && command -v update-ca-certificates >/dev/null 2>&1; "+
+	"then cp %s /usr/local/share/ca-certificates/ && update-ca-certificates >/dev/null 2>&1; fi; ", buildCACertsPath)
+
 type DockerBuildOptions struct {
 	Image        string
 	Env          []string
@@ -135,11 +144,15 @@ type DockerBuildOptions struct {
 //
 // The input parameters are fairly simple:
 //      - Image:        (optional) The builder image to use or "chaincode.builder"
-//      - Env:          (optional) environment variables for the build environment.
+//      - Env:          (optional) environment variables for the build environment, to
+//                      which the proxies configured under chaincode.build are added.
 //      - Cmd:          The command to execute inside the container.
 //      - InputStream:  A tarball of files that will be expanded into /chaincode/input.
 //      - OutputStream: A tarball of files that will be gathered from /chaincode/output
 //                      after successful execution of Cmd.
+//
+// The CA certificates configured under chaincode.build.caCerts are added to the
+// trust store of the builder, if it has update-ca-certificates, and to the CAs of npm.
 //-------------------------------------------------------------------------------------------
 func DockerBuild(opts DockerBuildOptions) error {
 	client, err := cutil.NewDockerClient()
@@ -153,6 +166,17 @@ func DockerBuild(opts DockerBuildOptions) error {
 		}
 	}
 
+	caCerts, err := cutil.BuildCACertsFromConfig()
+	if err != nil {
+		return err
+	}
+	env := append(append([]string{}, opts.Env...), cutil.BuildProxyEnvFromConfig()...)
+	cmd := opts.Cmd
+	if len(caCerts) > 0 {
+		env = append(env, "NODE_EXTRA_CA_CERTS="+buildCACertsPath)
+		cmd = trustBuildCACerts + cmd
+	}
+
 	logger.Debugf("Attempting build with image %s", opts.Image)
 
 	//-----------------------------------------------------------------------------------
@@ -174,8 +198,8 @@ func DockerBuild(opts DockerBuildOptions) error {
 	container, err := client.CreateContainer(docker.CreateContainerOptions{
 		Config: &docker.Config{
 			Image:        opts.Image,
-			Env:          opts.Env,
-			Cmd:          []string{"/bin/sh", "-c", opts.Cmd},
+			Env:          env,
+			Cmd:          []string{"/bin/sh", "-c", cmd},
 			AttachStdout: true,
 			AttachStderr: true,
 		},
@@ -195,6 +219,19 @@ func DockerBuild(opts DockerBuildOptions) error {
 	if err != nil {
 		return fmt.Errorf("Error uploading input to container: %s", err)
 	}
+	if len(caCerts) > 0 {
+		certs := bytes.NewBuffer(nil)
+		tw := tar.NewWriter(certs)
+		cutil.WriteBytesToPackage(cutil.BuildCACertsFile, caCerts, tw)
+		tw.Close()
+		err = client.UploadToContainer(container.ID, docker.UploadToContainerOptions{
+			Path:        filepath.Dir(buildCACertsPath),
+			InputStream: certs,
+		})
+		if err != nil {
+			return fmt.Errorf("Error uploading CA certificates to container: %s", err)
+		}
+	}
 
 	//-----------------------------------------------------------------------------------
 	// Attach stdout buffer to capture possible compilation errors
@@ -0,0 +1,247 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// PackageRules are the rules the entries of a code package are validated against
+type PackageRules struct {
+	// MaxEntries is the maximum number of entries of a code package, 0 for no limit
+	MaxEntries int
+	// MaxEntrySize is the maximum size in bytes of an entry, 0 for no limit
+	MaxEntrySize int64
+	// ForbiddenExtensions are the extensions of the files which are rejected
+	ForbiddenExtensions []string
+	// Allow are the patterns of the entries which are accepted even
+	// though their extension is forbidden
+	Allow []string
+	// Deny are the patterns of the entries which are rejected
+	Deny []string
+	// MaxPackageSize is the maximum size in bytes of a compressed code package, 0 for no limit
+	MaxPackageSize int64
+	// MaxDecompressedSize is the maximum size in bytes of a decompressed
+	// code package, 0 for no limit
+	MaxDecompressedSize int64
+	// MaxCompressionRatio is the maximum ratio of the decompressed size of
+	// a code package to its compressed size, 0 for no limit
+	MaxCompressionRatio int64
+}
+
+// DefaultPackageRules are the rules of the peers which do not configure
+// the validation of the code packages
+var DefaultPackageRules = PackageRules{
+	MaxEntries:          10000,
+	MaxEntrySize:        100 * 1024 * 1024,
+	ForbiddenExtensions: []string{".exe", ".dll", ".so", ".dylib"},
+	MaxPackageSize:      100 * 1024 * 1024,
+	MaxDecompressedSize: 500 * 1024 * 1024,
+	MaxCompressionRatio: 100,
+}
+
+// PackageRulesFromConfig returns the rules configured under chaincode.packageValidation,
+// falling back to the default rules for the settings which are not configured
+func PackageRulesFromConfig() PackageRules {
+	rules := DefaultPackageRules
+	if viper.IsSet("chaincode.packageValidation.maxEntries") {
+		rules.MaxEntries = viper.GetInt("chaincode.packageValidation.maxEntries")
+	}
+	if viper.IsSet("chaincode.packageValidation.maxEntrySize") {
+		rules.MaxEntrySize = int64(viper.GetInt("chaincode.packageValidation.maxEntrySize"))
+	}
+	if viper.IsSet("chaincode.packageValidation.forbiddenExtensions") {
+		rules.ForbiddenExtensions = viper.GetStringSlice("chaincode.packageValidation.forbiddenExtensions")
+	}
+	if viper.IsSet("chaincode.packageValidation.maxPackageSize") {
+		rules.MaxPackageSize = int64(viper.GetInt("chaincode.packageValidation.maxPackageSize"))
+	}
+	if viper.IsSet("chaincode.packageValidation.maxDecompressedSize") {
+		rules.MaxDecompressedSize = int64(viper.GetInt("chaincode.packageValidation.maxDecompressedSize"))
+	}
+	if viper.IsSet("chaincode.packageValidation.maxCompressionRatio") {
+		rules.MaxCompressionRatio = int64(viper.GetInt("chaincode.packageValidation.maxCompressionRatio"))
+	}
+	rules.Allow = viper.GetStringSlice("chaincode.packageValidation.allow")
+	rules.Deny = viper.GetStringSlice("chaincode.packageValidation.deny")
+	return rules
+}
+
+// PackageValidator validates the entries of a code package as they are read
+// from its tar stream, so that the package is never trusted as a whole
+type PackageValidator struct {
+	rules   PackageRules
+	entries int
+}
+
+// NewPackageValidator returns a validator of the entries of a code package
+func NewPackageValidator(rules PackageRules) *PackageValidator {
+	return &PackageValidator{rules: rules}
+}
+
+// Validate checks the next entry of the code package against the rules
+func (v *PackageValidator) Validate(header *tar.Header) error {
+	v.entries++
+	if v.rules.MaxEntries > 0 && v.entries > v.rules.MaxEntries {
+		return fmt.Errorf("code package exceeds the maximum of %d entries", v.rules.MaxEntries)
+	}
+
+	name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+	if name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("illegal file detected in payload: \"%s\" is outside of the code package", header.Name)
+	}
+
+	switch header.Typeflag {
+	case tar.TypeReg, tar.TypeRegA, tar.TypeDir:
+	case tar.TypeSymlink, tar.TypeLink:
+		target := header.Linkname
+		if header.Typeflag == tar.TypeSymlink && !path.IsAbs(target) {
+			target = path.Join(path.Dir(name), target)
+		}
+		target = path.Clean(strings.TrimPrefix(target, "/"))
+		if path.IsAbs(header.Linkname) || target == ".." || strings.HasPrefix(target, "../") {
+			return fmt.Errorf("illegal link detected in payload: \"%s\" points outside of the code package to %s", header.Name, header.Linkname)
+		}
+	default:
+		return fmt.Errorf("illegal file type detected for file %s: %c", header.Name, header.Typeflag)
+	}
+
+	if header.Mode&(04000|02000) != 0 {
+		return fmt.Errorf("illegal setuid or setgid file detected in payload: %s", header.Name)
+	}
+
+	if v.rules.MaxEntrySize > 0 && header.Size > v.rules.MaxEntrySize {
+		return fmt.Errorf("file %s of %d bytes exceeds the maximum size of %d bytes", header.Name, header.Size, v.rules.MaxEntrySize)
+	}
+
+	if matchAny(v.rules.Deny, name) {
+		return fmt.Errorf("illegal file detected in payload: \"%s\" is denied", header.Name)
+	}
+	if matchAny(v.rules.Allow, name) {
+		return nil
+	}
+	ext := strings.ToLower(path.Ext(name))
+	for _, forbidden := range v.rules.ForbiddenExtensions {
+		if ext != "" && ext == strings.ToLower(forbidden) {
+			return fmt.Errorf("illegal file detected in payload: \"%s\" has the forbidden extension %s", header.Name, forbidden)
+		}
+	}
+
+	return nil
+}
+
+// matchAny returns true if the entry or its base name matches one of the patterns
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PackageLimitError is returned when a code package exceeds one of the limits on its size
+type PackageLimitError struct {
+	// Limit is the name of the limit, e.g. "decompressed size"
+	Limit string
+	// Value is the value of the code package, possibly a lower bound
+	// of it when the package was not read entirely
+	Value int64
+	// Max is the limit
+	Max int64
+}
+
+func (e *PackageLimitError) Error() string {
+	return fmt.Sprintf("code package exceeds the maximum %s: %d > %d", e.Limit, e.Value, e.Max)
+}
+
+// decompressionLimiter aborts the decompression of a code package as soon as
+// it exceeds the maximum decompressed size or compression ratio
+type decompressionLimiter struct {
+	r          io.Reader
+	compressed int64
+	read       int64
+	rules      PackageRules
+	err        error
+}
+
+func (l *decompressionLimiter) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.rules.MaxDecompressedSize > 0 && l.read > l.rules.MaxDecompressedSize {
+		l.err = &PackageLimitError{Limit: "decompressed size", Value: l.read, Max: l.rules.MaxDecompressedSize}
+		return n, l.err
+	}
+	if l.rules.MaxCompressionRatio > 0 && l.read > l.rules.MaxCompressionRatio*l.compressed {
+		l.err = &PackageLimitError{Limit: "compression ratio", Value: (l.read + l.compressed - 1) / l.compressed, Max: l.rules.MaxCompressionRatio}
+		return n, l.err
+	}
+	return n, err
+}
+
+// CheckPackageLimits checks the size of a code package, and the size, compression ratio
+// and number of entries of its content when it is a gzipped tar, which it decompresses
+// no further than the limits so that compression bombs are not expanded
+func CheckPackageLimits(code []byte, rules PackageRules) error {
+	if rules.MaxPackageSize > 0 && int64(len(code)) > rules.MaxPackageSize {
+		return &PackageLimitError{Limit: "package size", Value: int64(len(code)), Max: rules.MaxPackageSize}
+	}
+
+	// the packages of the platforms which do not use gzipped tars are opaque
+	if len(code) < 2 || code[0] != 0x1f || code[1] != 0x8b {
+		return nil
+	}
+
+	gr, err := gzip.NewReader(bytes.NewReader(code))
+	if err != nil {
+		return fmt.Errorf("failure opening codepackage gzip stream: %s", err)
+	}
+	tr := tar.NewReader(&decompressionLimiter{r: gr, compressed: int64(len(code)), rules: rules})
+
+	entries := 0
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return limitError(err)
+		}
+
+		entries++
+		if rules.MaxEntries > 0 && entries > rules.MaxEntries {
+			return &PackageLimitError{Limit: "number of entries", Value: int64(entries), Max: int64(rules.MaxEntries)}
+		}
+
+		if _, err := io.Copy(ioutil.Discard, tr); err != nil {
+			return limitError(err)
+		}
+	}
+}
+
+// limitError returns the limit error a read of the code package failed with, if any
+func limitError(err error) error {
+	if limitErr, ok := err.(*PackageLimitError); ok {
+		return limitErr
+	}
+	return fmt.Errorf("failure reading codepackage: %s", err)
+}
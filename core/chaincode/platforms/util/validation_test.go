@@ -0,0 +1,202 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageValidator(t *testing.T) {
+	rules := PackageRules{
+		MaxEntrySize:        10,
+		ForbiddenExtensions: []string{".so", ".exe"},
+		Allow:               []string{"src/lib/*.so"},
+		Deny:                []string{"*.pem"},
+	}
+
+	var tests = []struct {
+		header *tar.Header
+		err    string
+	}{
+		{header: &tar.Header{Name: "src/main.go", Typeflag: tar.TypeReg, Mode: 0100644, Size: 10}},
+		{header: &tar.Header{Name: "src/lib", Typeflag: tar.TypeDir, Mode: 040755}},
+		{header: &tar.Header{Name: "src/lib/native.so", Typeflag: tar.TypeReg, Mode: 0100644}},
+		{header: &tar.Header{Name: "src/vendor/link.go", Typeflag: tar.TypeSymlink, Linkname: "../main.go"}},
+		{header: &tar.Header{Name: "src/copy.go", Typeflag: tar.TypeLink, Linkname: "src/main.go"}},
+		{
+			header: &tar.Header{Name: "src/main.go", Typeflag: tar.TypeReg, Mode: 0100644, Size: 11},
+			err:    "file src/main.go of 11 bytes exceeds the maximum size of 10 bytes",
+		},
+		{
+			header: &tar.Header{Name: "src/../../etc/passwd", Typeflag: tar.TypeReg, Mode: 0100644},
+			err:    `illegal file detected in payload: "src/../../etc/passwd" is outside of the code package`,
+		},
+		{
+			header: &tar.Header{Name: "src/link", Typeflag: tar.TypeSymlink, Linkname: "../../etc/passwd"},
+			err:    `illegal link detected in payload: "src/link" points outside of the code package to ../../etc/passwd`,
+		},
+		{
+			header: &tar.Header{Name: "src/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"},
+			err:    `illegal link detected in payload: "src/link" points outside of the code package to /etc/passwd`,
+		},
+		{
+			header: &tar.Header{Name: "src/fifo", Typeflag: tar.TypeFifo},
+			err:    "illegal file type detected for file src/fifo: 6",
+		},
+		{
+			header: &tar.Header{Name: "src/tool", Typeflag: tar.TypeReg, Mode: 0104755},
+			err:    "illegal setuid or setgid file detected in payload: src/tool",
+		},
+		{
+			header: &tar.Header{Name: "src/tool.EXE", Typeflag: tar.TypeReg, Mode: 0100644},
+			err:    `illegal file detected in payload: "src/tool.EXE" has the forbidden extension .exe`,
+		},
+		{
+			header: &tar.Header{Name: "src/native.so", Typeflag: tar.TypeReg, Mode: 0100644},
+			err:    `illegal file detected in payload: "src/native.so" has the forbidden extension .so`,
+		},
+		{
+			header: &tar.Header{Name: "src/certs/key.pem", Typeflag: tar.TypeReg, Mode: 0100644},
+			err:    `illegal file detected in payload: "src/certs/key.pem" is denied`,
+		},
+	}
+
+	for _, tst := range tests {
+		err := NewPackageValidator(rules).Validate(tst.header)
+		if tst.err == "" {
+			assert.NoError(t, err, tst.header.Name)
+		} else {
+			assert.EqualError(t, err, tst.err, tst.header.Name)
+		}
+	}
+}
+
+func TestPackageValidatorMaxEntries(t *testing.T) {
+	validator := NewPackageValidator(PackageRules{MaxEntries: 2})
+	header := &tar.Header{Name: "src/main.go", Typeflag: tar.TypeReg, Mode: 0100644}
+
+	assert.NoError(t, validator.Validate(header))
+	assert.NoError(t, validator.Validate(header))
+	assert.EqualError(t, validator.Validate(header), "code package exceeds the maximum of 2 entries")
+}
+
+func TestPackageRulesFromConfig(t *testing.T) {
+	rules := PackageRulesFromConfig()
+	assert.Equal(t, DefaultPackageRules.MaxEntries, rules.MaxEntries)
+	assert.Equal(t, DefaultPackageRules.MaxEntrySize, rules.MaxEntrySize)
+	assert.Equal(t, DefaultPackageRules.ForbiddenExtensions, rules.ForbiddenExtensions)
+	assert.Empty(t, rules.Allow)
+	assert.Empty(t, rules.Deny)
+
+	config := viper.Get("chaincode.packageValidation")
+	defer viper.Set("chaincode.packageValidation", config)
+
+	viper.Set("chaincode.packageValidation.maxEntries", 0)
+	viper.Set("chaincode.packageValidation.maxEntrySize", 1024)
+	viper.Set("chaincode.packageValidation.forbiddenExtensions", []string{".jar"})
+	viper.Set("chaincode.packageValidation.allow", []string{"*.so"})
+	viper.Set("chaincode.packageValidation.deny", []string{"*.pem"})
+	assert.Equal(t, PackageRules{
+		MaxEntries:          0,
+		MaxEntrySize:        1024,
+		ForbiddenExtensions: []string{".jar"},
+		Allow:               []string{"*.so"},
+		Deny:                []string{"*.pem"},
+		MaxPackageSize:      DefaultPackageRules.MaxPackageSize,
+		MaxDecompressedSize: DefaultPackageRules.MaxDecompressedSize,
+		MaxCompressionRatio: DefaultPackageRules.MaxCompressionRatio,
+	}, PackageRulesFromConfig())
+}
+
+func gzippedTar(t *testing.T, entries map[string][]byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range entries {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0100644, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func TestCheckPackageLimits(t *testing.T) {
+	code := gzippedTar(t, map[string][]byte{
+		"src/main.go": []byte("package main"),
+		"src/lib.go":  []byte("package main"),
+	})
+	bomb := gzippedTar(t, map[string][]byte{
+		"src/zeros": make([]byte, 10*1024*1024),
+	})
+
+	var tests = []struct {
+		name  string
+		code  []byte
+		rules PackageRules
+		err   *PackageLimitError
+	}{
+		{name: "no limits", code: bomb},
+		{name: "opaque package", code: []byte("car package"), rules: PackageRules{MaxEntries: 1, MaxCompressionRatio: 1}},
+		{name: "within limits", code: code, rules: DefaultPackageRules},
+		{
+			name:  "package size",
+			code:  code,
+			rules: PackageRules{MaxPackageSize: 10},
+			err:   &PackageLimitError{Limit: "package size", Value: int64(len(code)), Max: 10},
+		},
+		{
+			name:  "number of entries",
+			code:  code,
+			rules: PackageRules{MaxEntries: 1},
+			err:   &PackageLimitError{Limit: "number of entries", Value: 2, Max: 1},
+		},
+		{
+			name:  "decompressed size",
+			code:  bomb,
+			rules: PackageRules{MaxDecompressedSize: 1024 * 1024},
+			err:   &PackageLimitError{Limit: "decompressed size", Max: 1024 * 1024},
+		},
+		{
+			name:  "compression ratio",
+			code:  bomb,
+			rules: DefaultPackageRules,
+			err:   &PackageLimitError{Limit: "compression ratio", Max: 100},
+		},
+	}
+
+	for _, tst := range tests {
+		t.Run(tst.name, func(t *testing.T) {
+			err := CheckPackageLimits(tst.code, tst.rules)
+			if tst.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			limitErr, ok := err.(*PackageLimitError)
+			if !assert.True(t, ok, "unexpected error: %v", err) {
+				return
+			}
+			assert.Equal(t, tst.err.Limit, limitErr.Limit)
+			assert.Equal(t, tst.err.Max, limitErr.Max)
+			assert.True(t, limitErr.Value > limitErr.Max)
+			if tst.err.Value != 0 {
+				assert.Equal(t, tst.err.Value, limitErr.Value)
+			}
+		})
+	}
+
+	// the bombs are not expanded beyond the limit
+	err := CheckPackageLimits(bomb, PackageRules{MaxDecompressedSize: 1024 * 1024})
+	assert.True(t, err.(*PackageLimitError).Value < 2*1024*1024)
+}
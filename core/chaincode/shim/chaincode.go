@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"os"
 	"strings"
 	"sync"
@@ -66,6 +67,13 @@ type ChaincodeStub struct {
 	binding   []byte
 
 	decorations map[string][]byte
+
+	// txRand is the source of pseudo-random numbers of the transaction,
+	// created on first use
+	txRand *rand.Rand
+	// channelConfig is the channel config of the transaction, fetched on
+	// first use
+	channelConfig *pb.ChannelConfigSnapshot
 }
 
 // Peer address derived from command line or env var
@@ -312,8 +320,19 @@ func newPeerClientConnection() (*grpc.ClientConn, error) {
 		ClientTimeout:  time.Duration(20) * time.Second,
 	}
 	if viper.GetBool("peer.tls.enabled") {
+		// the peer passes its TLS policy to the chaincode, so that the chaincode
+		// connects with the protocol versions and cipher suites the peer allows
+		tlsPolicy, err := comm.NewTLSPolicy(
+			viper.GetString("peer.tls.minVersion"),
+			viper.GetString("peer.tls.maxVersion"),
+			viper.GetStringSlice("peer.tls.cipherSuites"),
+			viper.GetBool("peer.tls.fips"),
+		)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid TLS policy")
+		}
 		return comm.NewClientConnectionWithAddress(peerAddress, true, true,
-			comm.InitTLSForShim(key, cert), kaOpts)
+			comm.InitTLSForShim(key, cert, tlsPolicy), kaOpts)
 	}
 	return comm.NewClientConnectionWithAddress(peerAddress, true, false, nil, kaOpts)
 }
@@ -458,6 +477,11 @@ func (stub *ChaincodeStub) GetState(key string) ([]byte, error) {
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetStateWithProof documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateWithProof(key string) (*queryresult.StateProof, error) {
+	return stub.handler.handleGetStateProof(key, stub.ChannelId, stub.TxID)
+}
+
 // SetStateValidationParameter documentation can be found in interfaces.go
 func (stub *ChaincodeStub) SetStateValidationParameter(key string, ep []byte) error {
 	return stub.handler.handlePutStateMetadataEntry("", key, stub.validationParameterMetakey, ep, stub.ChannelId, stub.TxID)
@@ -511,6 +535,25 @@ func (stub *ChaincodeStub) DelState(key string) error {
 	return stub.handler.handleDelState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// DelStateRange documentation can be found in interfaces.go
+func (stub *ChaincodeStub) DelStateRange(startKey, endKey string) error {
+	if startKey == "" {
+		startKey = emptyKeySubstitute
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return err
+	}
+	return stub.handler.handleDelStateRange(startKey, endKey, stub.ChannelId, stub.TxID)
+}
+
+// IncrementState documentation can be found in interfaces.go
+func (stub *ChaincodeStub) IncrementState(key string, delta int64) error {
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+	return stub.handler.handleIncrementState(key, delta, stub.ChannelId, stub.TxID)
+}
+
 //  ---------  private state functions  ---------
 
 // GetPrivateData documentation can be found in interfaces.go
@@ -521,6 +564,14 @@ func (stub *ChaincodeStub) GetPrivateData(collection string, key string) ([]byte
 	return stub.handler.handleGetState(collection, key, stub.ChannelId, stub.TxID)
 }
 
+// GetPrivateDataHash documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	if collection == "" {
+		return nil, fmt.Errorf("collection must not be an empty string")
+	}
+	return stub.handler.handleGetPrivateDataHash(collection, key, stub.ChannelId, stub.TxID)
+}
+
 // PutPrivateData documentation can be found in interfaces.go
 func (stub *ChaincodeStub) PutPrivateData(collection string, key string, value []byte) error {
 	if collection == "" {
@@ -788,8 +839,12 @@ func (stub *ChaincodeStub) GetStateByPartialCompositeKey(objectType string, attr
 }
 
 func createQueryMetadata(pageSize int32, bookmark string) ([]byte, error) {
-	// Construct the QueryMetadata with a page size and a bookmark needed for pagination
-	metadata := &pb.QueryMetadata{PageSize: pageSize, Bookmark: bookmark}
+	return createFilteredQueryMetadata(nil, pageSize, bookmark)
+}
+
+func createFilteredQueryMetadata(filters []*pb.CompositeKeyFilter, pageSize int32, bookmark string) ([]byte, error) {
+	// Construct the QueryMetadata with the filters, a page size and a bookmark needed for pagination
+	metadata := &pb.QueryMetadata{PageSize: pageSize, Bookmark: bookmark, Filters: filters}
 	metadataBytes, err := proto.Marshal(metadata)
 	if err != nil {
 		return nil, err
@@ -834,6 +889,45 @@ func (stub *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(objectTyp
 	return stub.handleGetStateByRange(collection, startKey, endKey, metadata)
 }
 
+// GetStateByPartialCompositeKeyWithFilters documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByPartialCompositeKeyWithFilters(objectType string, keys []string,
+	filters []*pb.CompositeKeyFilter) (StateQueryIteratorInterface, error) {
+
+	collection := ""
+
+	metadata, err := createFilteredQueryMetadata(filters, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	startKey, endKey, err := stub.createRangeKeysForPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, err
+	}
+	// ignore QueryResponseMetadata as it is not applicable for a partial composite key query without pagination
+	iterator, _, err := stub.handleGetStateByRange(collection, startKey, endKey, metadata)
+
+	return iterator, err
+}
+
+// GetStateByPartialCompositeKeyWithFiltersAndPagination documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPagination(objectType string, keys []string,
+	filters []*pb.CompositeKeyFilter, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+
+	collection := ""
+
+	metadata, err := createFilteredQueryMetadata(filters, pageSize, bookmark)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startKey, endKey, err := stub.createRangeKeysForPartialCompositeKey(objectType, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stub.handleGetStateByRange(collection, startKey, endKey, metadata)
+}
+
 func (stub *ChaincodeStub) GetQueryResultWithPagination(query string, pageSize int32,
 	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
 	// Access public data by setting the collection to empty string
@@ -1013,6 +1107,35 @@ func (stub *ChaincodeStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	return chdr.GetTimestamp(), nil
 }
 
+// GetTxTime documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetTxTime() (time.Time, error) {
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return TxTime(ts)
+}
+
+// GetTxRand documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetTxRand() *rand.Rand {
+	if stub.txRand == nil {
+		stub.txRand = NewTxRand(stub.ChannelId, stub.TxID)
+	}
+	return stub.txRand
+}
+
+// GetChannelConfig documentation can be found in interfaces.go
+func (stub *ChaincodeStub) GetChannelConfig() (*pb.ChannelConfigSnapshot, error) {
+	if stub.channelConfig == nil {
+		channelConfig, err := stub.handler.handleGetChannelConfig(stub.ChannelId, stub.TxID)
+		if err != nil {
+			return nil, err
+		}
+		stub.channelConfig = channelConfig
+	}
+	return stub.channelConfig, nil
+}
+
 // ------------- ChaincodeEvent API ----------------------
 
 // SetEvent documentation can be found in interfaces.go
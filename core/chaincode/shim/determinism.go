@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/pkg/errors"
+)
+
+// NewTxRand returns the source of pseudo-random numbers of a transaction,
+// seeded from the channel and the ID of the transaction so that all the
+// endorsers of the transaction generate the same numbers
+func NewTxRand(channelID, txID string) *rand.Rand {
+	h := sha256.New()
+	h.Write([]byte(channelID))
+	h.Write([]byte{0})
+	h.Write([]byte(txID))
+	seed := binary.BigEndian.Uint64(h.Sum(nil))
+	return rand.New(rand.NewSource(int64(seed)))
+}
+
+// TxTime converts the timestamp of a transaction to a time.Time
+func TxTime(ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, errors.New("the transaction has no timestamp")
+	}
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "invalid transaction timestamp")
+	}
+	return t, nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTxRand(t *testing.T) {
+	assert.Equal(t, NewTxRand("mychannel", "tx1").Int63(), NewTxRand("mychannel", "tx1").Int63())
+	assert.NotEqual(t, NewTxRand("mychannel", "tx1").Int63(), NewTxRand("mychannel", "tx2").Int63())
+	assert.NotEqual(t, NewTxRand("mychannel", "tx1").Int63(), NewTxRand("otherchannel", "tx1").Int63())
+}
+
+func TestTxTime(t *testing.T) {
+	now := time.Unix(1546300800, 42).UTC()
+	ts, err := ptypes.TimestampProto(now)
+	require.NoError(t, err)
+	txTime, err := TxTime(ts)
+	require.NoError(t, err)
+	assert.True(t, now.Equal(txTime))
+
+	_, err = TxTime(nil)
+	assert.EqualError(t, err, "the transaction has no timestamp")
+}
+
+func TestMockStubTxRandAndTime(t *testing.T) {
+	stub := NewMockStub("determinism", nil)
+	stub.ChannelID = "mychannel"
+
+	stub.MockTransactionStart("tx1")
+	first := stub.GetTxRand().Int63()
+	assert.NotEqual(t, first, stub.GetTxRand().Int63(), "the numbers of a transaction come from a single source")
+	txTime, err := stub.GetTxTime()
+	require.NoError(t, err)
+	assert.Equal(t, stub.TxTimestamp.Seconds, txTime.Unix())
+	stub.MockTransactionEnd("tx1")
+
+	stub.MockTransactionStart("tx1")
+	assert.Equal(t, first, stub.GetTxRand().Int63())
+	stub.MockTransactionEnd("tx1")
+}
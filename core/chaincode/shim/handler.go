@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -52,6 +53,17 @@ type Handler struct {
 	// Multiple queries (and one transaction) with different txids can be executing in parallel for this chaincode
 	// responseChannel is the channel on which responses are communicated by the shim to the chaincodeStub.
 	responseChannel map[string]chan pb.ChaincodeMessage
+	// txQueues holds the queues of the transactions having requests to the peer in progress
+	txQueues map[string]*txQueue
+}
+
+// txQueue serializes the requests of a transaction to the peer, which handles at most
+// one pending request per transaction. The requests of different transactions are
+// multiplexed over the stream without waiting for each other
+type txQueue struct {
+	sync.Mutex
+	// refs is the number of requests holding or waiting for the queue
+	refs int
 }
 
 func shorttxid(txid string) string {
@@ -102,27 +114,62 @@ func (handler *Handler) createChannel(channelID, txid string) (chan pb.Chaincode
 	if handler.responseChannel[txCtxID] != nil {
 		return nil, errors.Errorf("[%s] channel exists", shorttxid(txCtxID))
 	}
-	c := make(chan pb.ChaincodeMessage)
+	// the channel is buffered so that delivering the response never blocks
+	// the receive loop, which is shared by all the transactions
+	c := make(chan pb.ChaincodeMessage, 1)
 	handler.responseChannel[txCtxID] = c
 	return c, nil
 }
 
 func (handler *Handler) sendChannel(msg *pb.ChaincodeMessage) error {
 	handler.Lock()
-	defer handler.Unlock()
 	if handler.responseChannel == nil {
+		handler.Unlock()
 		return errors.Errorf("[%s] Cannot send message response channel", shorttxid(msg.Txid))
 	}
 	txCtxID := handler.getTxCtxId(msg.ChannelId, msg.Txid)
-	if handler.responseChannel[txCtxID] == nil {
+	c := handler.responseChannel[txCtxID]
+	handler.Unlock()
+	if c == nil {
 		return errors.Errorf("[%s] sendChannel does not exist", shorttxid(msg.Txid))
 	}
 
-	chaincodeLogger.Debugf("[%s] before send", shorttxid(msg.Txid))
-	handler.responseChannel[txCtxID] <- *msg
-	chaincodeLogger.Debugf("[%s] after send", shorttxid(msg.Txid))
+	select {
+	case c <- *msg:
+		chaincodeLogger.Debugf("[%s] response sent", shorttxid(msg.Txid))
+		return nil
+	default:
+		return errors.Errorf("[%s] a response is already pending", shorttxid(msg.Txid))
+	}
+}
 
-	return nil
+// acquireTx waits for the pending request of the transaction, if any, to complete
+func (handler *Handler) acquireTx(channelID, txid string) {
+	txCtxID := handler.getTxCtxId(channelID, txid)
+	handler.Lock()
+	q, ok := handler.txQueues[txCtxID]
+	if !ok {
+		q = &txQueue{}
+		handler.txQueues[txCtxID] = q
+	}
+	q.refs++
+	handler.Unlock()
+
+	q.Lock()
+}
+
+// releaseTx lets the next request of the transaction proceed
+func (handler *Handler) releaseTx(channelID, txid string) {
+	txCtxID := handler.getTxCtxId(channelID, txid)
+	handler.Lock()
+	q := handler.txQueues[txCtxID]
+	q.refs--
+	if q.refs == 0 {
+		delete(handler.txQueues, txCtxID)
+	}
+	handler.Unlock()
+
+	q.Unlock()
 }
 
 //sends a message and selects
@@ -169,6 +216,7 @@ func newChaincodeHandler(peerChatStream PeerChaincodeStream, chaincode Chaincode
 		cc:         chaincode,
 	}
 	v.responseChannel = make(map[string]chan pb.ChaincodeMessage)
+	v.txQueues = make(map[string]*txQueue)
 	v.state = created
 	return v
 }
@@ -210,7 +258,12 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 		if nextStateMsg = errFunc(err, nil, stub.chaincodeEvent, "[%s] Init get error response. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR.String()); nextStateMsg != nil {
 			return
 		}
-		res := handler.cc.Init(stub)
+		var res pb.Response
+		if msg.Type == pb.ChaincodeMessage_MIGRATE {
+			res = handler.migrate(stub)
+		} else {
+			res = handler.cc.Init(stub)
+		}
 		chaincodeLogger.Debugf("[%s] Init get response status: %d", shorttxid(msg.Txid), res.Status)
 
 		if res.Status >= ERROR {
@@ -234,6 +287,17 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage, errc chan error) {
 	}()
 }
 
+// migrate calls the Migrate function of the chaincode if it implements Migrator.
+// The chaincodes which do not implement it have nothing to migrate.
+func (handler *Handler) migrate(stub ChaincodeStubInterface) pb.Response {
+	migrator, ok := handler.cc.(Migrator)
+	if !ok {
+		chaincodeLogger.Debugf("[%s] Chaincode does not implement Migrate, nothing to migrate", shorttxid(stub.GetTxID()))
+		return Success(nil)
+	}
+	return migrator.Migrate(stub)
+}
+
 // handleTransaction Handles request to execute a transaction.
 func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage, errc chan error) {
 	// The defer followed by triggering a go routine dance is needed to ensure that the previous state transition
@@ -287,6 +351,10 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage, errc chan er
 // callPeerWithChaincodeMsg sends a chaincode message (for e.g., GetState along with the key) to the peer for a given txid
 // and receives the response.
 func (handler *Handler) callPeerWithChaincodeMsg(msg *pb.ChaincodeMessage, channelID, txid string) (pb.ChaincodeMessage, error) {
+	// The requests made concurrently by a transaction are sent one at a time
+	handler.acquireTx(channelID, txid)
+	defer handler.releaseTx(channelID, txid)
+
 	// Create the channel on which to communicate the response from the peer
 	var respChan chan pb.ChaincodeMessage
 	var err error
@@ -329,6 +397,68 @@ func (handler *Handler) handleGetState(collection string, key string, channelId
 	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetStateProof communicates with the peer to fetch the value of a key along with its proof
+func (handler *Handler) handleGetStateProof(key string, channelID string, txID string) (*queryresult.StateProof, error) {
+	// Construct payload for GET_STATE_PROOF
+	payloadBytes, _ := proto.Marshal(&pb.GetState{Key: key})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE_PROOF, Payload: payloadBytes, Txid: txID, ChannelId: channelID}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_STATE_PROOF)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelID, txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_STATE_PROOF", shorttxid(txID)))
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetStateWithProof received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		proof := &queryresult.StateProof{}
+		if err := proto.Unmarshal(responseMsg.Payload, proof); err != nil {
+			return nil, errors.Wrapf(err, "[%s] GetStateWithProof unmarshal error", shorttxid(responseMsg.Txid))
+		}
+		return proof, nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetStateWithProof received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
+// handleGetPrivateDataHash communicates with the peer to fetch the hash of the value of a private data key
+func (handler *Handler) handleGetPrivateDataHash(collection string, key string, channelID string, txID string) ([]byte, error) {
+	// Construct payload for GET_PRIVATE_DATA_HASH
+	payloadBytes, _ := proto.Marshal(&pb.GetState{Collection: collection, Key: key})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH, Payload: payloadBytes, Txid: txID, ChannelId: channelID}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelID, txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_PRIVATE_DATA_HASH", shorttxid(txID)))
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetPrivateDataHash received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return responseMsg.Payload, nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetPrivateDataHash received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handleGetStateMetadata(collection string, key string, channelID string, txID string) (map[string][]byte, error) {
 	// Construct payload for GET_STATE_METADATA
 	payloadBytes, _ := proto.Marshal(&pb.GetStateMetadata{Collection: collection, Key: key})
@@ -461,6 +591,94 @@ func (handler *Handler) handleDelState(collection string, key string, channelId
 	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleDelStateRange communicates with the peer to delete the keys of a range from the state in the ledger.
+func (handler *Handler) handleDelStateRange(startKey, endKey string, channelID string, txID string) error {
+	// Construct payload for DEL_STATE_RANGE
+	payloadBytes, _ := proto.Marshal(&pb.GetStateByRange{StartKey: startKey, EndKey: endKey})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_DEL_STATE_RANGE, Payload: payloadBytes, Txid: txID, ChannelId: channelID}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_DEL_STATE_RANGE)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelID, txID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("[%s] error sending DEL_STATE_RANGE", shorttxid(txID)))
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		chaincodeLogger.Debugf("[%s] Received %s. Successfully deleted state range", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		chaincodeLogger.Errorf("[%s] Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
+// handleIncrementState communicates with the peer to increment a counter of the state in the ledger.
+func (handler *Handler) handleIncrementState(key string, delta int64, channelID string, txID string) error {
+	// Construct payload for INCREMENT_STATE
+	payloadBytes, _ := proto.Marshal(&pb.IncrementState{Key: key, Delta: delta})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INCREMENT_STATE, Payload: payloadBytes, Txid: txID, ChannelId: channelID}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_INCREMENT_STATE)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelID, txID)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("[%s] error sending INCREMENT_STATE", shorttxid(txID)))
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		chaincodeLogger.Debugf("[%s] Received %s. Successfully incremented state", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		chaincodeLogger.Errorf("[%s] Received %s. Payload: %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR, responseMsg.Payload)
+		return errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
+// handleGetChannelConfig communicates with the peer to fetch the values of the channel config
+func (handler *Handler) handleGetChannelConfig(channelID string, txID string) (*pb.ChannelConfigSnapshot, error) {
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_CHANNEL_CONFIG, Txid: txID, ChannelId: channelID}
+	chaincodeLogger.Debugf("[%s] Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_CHANNEL_CONFIG)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelID, txID)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s] error sending GET_CHANNEL_CONFIG", shorttxid(txID)))
+	}
+
+	if responseMsg.Type == pb.ChaincodeMessage_RESPONSE {
+		// Success response
+		chaincodeLogger.Debugf("[%s] GetChannelConfig received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		channelConfig := &pb.ChannelConfigSnapshot{}
+		if err := proto.Unmarshal(responseMsg.Payload, channelConfig); err != nil {
+			return nil, errors.Wrapf(err, "[%s] GetChannelConfig unmarshal error", shorttxid(responseMsg.Txid))
+		}
+		return channelConfig, nil
+	}
+	if responseMsg.Type == pb.ChaincodeMessage_ERROR {
+		// Error response
+		chaincodeLogger.Errorf("[%s] GetChannelConfig received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	chaincodeLogger.Errorf("[%s] Incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+	return nil, errors.Errorf("[%s] incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 func (handler *Handler) handleGetStateByRange(collection, startKey, endKey string, metadata []byte,
 	channelId string, txid string) (*pb.QueryResponse, error) {
 	// Send GET_STATE_BY_RANGE message to peer chaincode support
@@ -746,9 +964,11 @@ func (handler *Handler) handleInvokeChaincode(chaincodeName string, args [][]byt
 func (handler *Handler) handleReady(msg *pb.ChaincodeMessage, errc chan error) error {
 	switch msg.Type {
 	case pb.ChaincodeMessage_RESPONSE:
+		// a late or unexpected response concerns a single transaction, and must
+		// not end the stream shared by all the others
 		if err := handler.sendChannel(msg); err != nil {
-			chaincodeLogger.Errorf("[%s] error sending %s (state:%s): %s", shorttxid(msg.Txid), msg.Type, handler.state, err)
-			return err
+			chaincodeLogger.Warningf("[%s] dropping %s (state:%s): %s", shorttxid(msg.Txid), msg.Type, handler.state, err)
+			return nil
 		}
 		chaincodeLogger.Debugf("[%s] Received %s, communicated (state:%s)", shorttxid(msg.Txid), msg.Type, handler.state)
 		return nil
@@ -763,9 +983,10 @@ func (handler *Handler) handleReady(msg *pb.ChaincodeMessage, errc chan error) e
 		//we don't return error on ERROR
 		return nil
 
-	case pb.ChaincodeMessage_INIT:
+	case pb.ChaincodeMessage_INIT, pb.ChaincodeMessage_MIGRATE:
 		chaincodeLogger.Debugf("[%s] Received %s, initializing chaincode", shorttxid(msg.Txid), msg.Type)
-		// Call the chaincode's Run function to initialize
+		// Call the chaincode's Run function to initialize, or its Migrate
+		// function upon an upgrade with a migration
 		handler.handleInit(msg, errc)
 		return nil
 
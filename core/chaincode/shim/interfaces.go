@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package shim
 
 import (
+	"math/rand"
+	"time"
+
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -26,6 +29,16 @@ type Chaincode interface {
 	Invoke(stub ChaincodeStubInterface) pb.Response
 }
 
+// Migrator may be implemented by the chaincodes which migrate their state when
+// they are upgraded. Upon an upgrade with a migration, the fabric calls Migrate
+// instead of Init, once per organization endorsing the upgrade transaction, and
+// the updates of Migrate are committed along with the new chaincode definition.
+type Migrator interface {
+	// Migrate is called during an Upgrade transaction with a migration, allowing
+	// the chaincode to migrate the data written by its previous version
+	Migrate(stub ChaincodeStubInterface) pb.Response
+}
+
 // ChaincodeStubInterface is used by deployable chaincode apps to access and
 // modify their ledgers
 type ChaincodeStubInterface interface {
@@ -82,6 +95,16 @@ type ChaincodeStubInterface interface {
 	// If the key does not exist in the state database, (nil, nil) is returned.
 	GetState(key string) ([]byte, error)
 
+	// GetStateWithProof returns the value of the specified `key` from the
+	// ledger along with a proof that it was set to this value by a valid
+	// transaction of a block, which a client verifies against the commit hash
+	// of the block obtained from other peers, without trusting the peer that
+	// endorsed the transaction. The read is recorded in the readset of the
+	// transaction like with GetState. An error is returned if the key does not
+	// exist or if the peer did not record the digest of the updates of the
+	// block that last updated the key (see ledger.state.proofs.enabled).
+	GetStateWithProof(key string) (*queryresult.StateProof, error)
+
 	// PutState puts the specified `key` and `value` into the transaction's
 	// writeset as a data-write proposal. PutState doesn't effect the ledger
 	// until the transaction is validated and successfully committed.
@@ -96,6 +119,26 @@ type ChaincodeStubInterface interface {
 	// the ledger when the transaction is validated and successfully committed.
 	DelState(key string) error
 
+	// DelStateRange records the keys between the startKey (inclusive) and
+	// endKey (exclusive) to be deleted in the writeset of the transaction
+	// proposal. The keys are deleted by the peer without being returned to
+	// the chaincode, hence deleting a large range takes a single call. Note
+	// that startKey and endKey can be empty string, which implies unbounded
+	// range on start or end. Like for GetStateByRange, the range is re-executed
+	// during validation phase and the transaction is invalidated if a key was
+	// added to or removed from the range since endorsement.
+	DelStateRange(startKey, endKey string) error
+
+	// IncrementState records in the writeset of the transaction proposal the
+	// addition of `delta` to the counter held by `key`. A counter is a signed
+	// 64-bit integer stored as its base 10 string representation, a key that
+	// does not exist holding a counter of value zero. The delta is added to
+	// the value committed when the transaction is validated, without the key
+	// being read, hence concurrent transactions incrementing the same key do
+	// not invalidate each other. The transaction is invalidated if the value
+	// committed is not a counter or if the addition overflows.
+	IncrementState(key string, delta int64) error
+
 	// SetStateValidationParameter sets the key-level endorsement policy for `key`.
 	SetStateValidationParameter(key string, ep []byte) error
 
@@ -172,6 +215,29 @@ type ChaincodeStubInterface interface {
 	GetStateByPartialCompositeKeyWithPagination(objectType string, keys []string,
 		pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
 
+	// GetStateByPartialCompositeKeyWithFilters queries the state in the ledger based on
+	// a given partial composite key, as GetStateByPartialCompositeKey does, and
+	// returns an iterator over the matching composite keys whose attributes also
+	// satisfy all the given filters. The filters are evaluated by the peer so that
+	// the keys that do not satisfy them are not shipped to the chaincode.
+	// The index of the attribute of a filter counts all the attributes of the
+	// composite key, including the ones of the partial composite key.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// The query is re-executed during validation phase to ensure result set
+	// has not changed since transaction endorsement (phantom reads detected).
+	GetStateByPartialCompositeKeyWithFilters(objectType string, keys []string,
+		filters []*pb.CompositeKeyFilter) (StateQueryIteratorInterface, error)
+
+	// GetStateByPartialCompositeKeyWithFiltersAndPagination combines
+	// GetStateByPartialCompositeKeyWithFilters and
+	// GetStateByPartialCompositeKeyWithPagination. A page holds at most `pageSize`
+	// composite keys satisfying the filters and the bookmark of the returned
+	// ResponseMetadata resumes the query after the last key of the page.
+	// Call Close() on the returned StateQueryIteratorInterface object when done.
+	// This call is only supported in a read only transaction.
+	GetStateByPartialCompositeKeyWithFiltersAndPagination(objectType string, keys []string,
+		filters []*pb.CompositeKeyFilter, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error)
+
 	// CreateCompositeKey combines the given `attributes` to form a composite
 	// key. The objectType and attributes are expected to have only valid utf8
 	// strings and should not contain U+0000 (nil byte) and U+10FFFF
@@ -239,6 +305,15 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetPrivateDataHash returns the hash of the value of the specified `key`
+	// from the specified `collection`. Unlike GetPrivateData, it can be invoked
+	// on the peers which are not eligible for the private data of the collection,
+	// on behalf of the creators who are not members of the collection, provided
+	// that they satisfy the peer/GetPrivateDataHash ACL of the channel. The read
+	// of the hash is recorded in the readset of the transaction, hence the value
+	// must not change before the transaction is committed for it to be valid.
+	GetPrivateDataHash(collection, key string) ([]byte, error)
+
 	// PutPrivateData puts the specified `key` and `value` into the transaction's
 	// private writeset. Note that only hash of the private writeset goes into the
 	// transaction proposal response (which is sent to the client who issued the
@@ -336,6 +411,30 @@ type ChaincodeStubInterface interface {
 	// client's timestamp and will have the same value across all endorsers.
 	GetTxTimestamp() (*timestamp.Timestamp, error)
 
+	// GetTxTime returns the timestamp of the transaction as a time.Time. Unlike
+	// the clock of the peer, it has the same value across all endorsers, hence
+	// the chaincodes must use it instead of time.Now to produce the same
+	// results on all the endorsers.
+	GetTxTime() (time.Time, error)
+
+	// GetTxRand returns a source of pseudo-random numbers seeded from the
+	// channel and the ID of the transaction. It generates the same numbers
+	// across all endorsers, hence the chaincodes must use it instead of
+	// math/rand and crypto/rand to produce the same results on all the
+	// endorsers. As the creator of the transaction can predict the numbers, they
+	// must not be used as secrets.
+	GetTxRand() *rand.Rand
+
+	// GetChannelConfig returns the organizations, the capability levels and
+	// the custom groups of the Application group of the channel config, as of
+	// the state read by the transaction. All the endorsers return the same
+	// values, and the transaction is invalidated if the channel config is
+	// updated before it is committed, hence the chaincodes can use it instead
+	// of keeping a copy of the channel config in their state. The config is
+	// fetched from the peer on the first call and reused by the later calls of
+	// the transaction.
+	GetChannelConfig() (*pb.ChannelConfigSnapshot, error)
+
 	// SetEvent allows the chaincode to set an event on the response to the
 	// proposal to be included as part of a transaction. The event will be
 	// available within the transaction in the committed block regardless of the
@@ -11,7 +11,11 @@ package shim
 import (
 	"container/list"
 	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/util"
@@ -55,6 +59,9 @@ type MockStub struct {
 	// mocked signedProposal
 	signedProposal *pb.SignedProposal
 
+	// Creator is the serialized identity of the creator of the mocked transactions
+	Creator []byte
+
 	// stores a channel ID of the proposal
 	ChannelID string
 
@@ -67,6 +74,12 @@ type MockStub struct {
 	ChaincodeEventsChannel chan *pb.ChaincodeEvent
 
 	Decorations map[string][]byte
+
+	// ChannelConfig is the channel config returned by GetChannelConfig
+	ChannelConfig *pb.ChannelConfigSnapshot
+
+	// source of pseudo-random numbers of the transaction, created on first use
+	txRand *rand.Rand
 }
 
 func (stub *MockStub) GetTxID() string {
@@ -106,6 +119,7 @@ func (stub *MockStub) GetFunctionAndParameters() (function string, params []stri
 // MockStub doesn't support concurrent transactions at present.
 func (stub *MockStub) MockTransactionStart(txid string) {
 	stub.TxID = txid
+	stub.txRand = nil
 	stub.setSignedProposal(&pb.SignedProposal{})
 	stub.setTxTimestamp(util.CreateUtcTimestamp())
 }
@@ -114,6 +128,7 @@ func (stub *MockStub) MockTransactionStart(txid string) {
 func (stub *MockStub) MockTransactionEnd(uuid string) {
 	stub.signedProposal = nil
 	stub.TxID = ""
+	stub.txRand = nil
 }
 
 // Register a peer chaincode with this MockStub
@@ -132,6 +147,20 @@ func (stub *MockStub) MockInit(uuid string, args [][]byte) pb.Response {
 	return res
 }
 
+// Migrate this chaincode, also starts and ends a transaction. The chaincodes which
+// do not implement Migrator have nothing to migrate.
+func (stub *MockStub) MockMigrate(uuid string, args [][]byte) pb.Response {
+	migrator, ok := stub.cc.(Migrator)
+	if !ok {
+		return Success(nil)
+	}
+	stub.args = args
+	stub.MockTransactionStart(uuid)
+	res := migrator.Migrate(stub)
+	stub.MockTransactionEnd(uuid)
+	return res
+}
+
 // Invoke this chaincode, also starts and ends a transaction.
 func (stub *MockStub) MockInvoke(uuid string, args [][]byte) pb.Response {
 	stub.args = args
@@ -165,6 +194,14 @@ func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, err
 	return m[key], nil
 }
 
+func (stub *MockStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	value, err := stub.GetPrivateData(collection, key)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return util.ComputeSHA256(value), nil
+}
+
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
 	m, in := stub.PvtState[collection]
 	if !in {
@@ -203,6 +240,16 @@ func (stub *MockStub) GetState(key string) ([]byte, error) {
 	return value, nil
 }
 
+// GetStateWithProof returns the value of the key in a proof which is not anchored
+// to any commit hash, since the MockStub does not commit blocks
+func (stub *MockStub) GetStateWithProof(key string) (*queryresult.StateProof, error) {
+	value, ok := stub.State[key]
+	if !ok {
+		return nil, errors.Errorf("key [%s] does not exist, the absence of a key cannot be proven", key)
+	}
+	return &queryresult.StateProof{Namespace: stub.Name, Key: key, Value: value}, nil
+}
+
 // PutState writes the specified `value` and `key` into the ledger.
 func (stub *MockStub) PutState(key string, value []byte) error {
 	if stub.TxID == "" {
@@ -267,6 +314,48 @@ func (stub *MockStub) DelState(key string) error {
 	return nil
 }
 
+// DelStateRange removes the keys between startKey (inclusive) and endKey
+// (exclusive) and their values from the ledger.
+func (stub *MockStub) DelStateRange(startKey, endKey string) error {
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return err
+	}
+	var keys []string
+	iter := NewMockStateRangeQueryIterator(stub, startKey, endKey)
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		keys = append(keys, kv.Key)
+	}
+	for _, key := range keys {
+		if err := stub.DelState(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IncrementState adds delta to the counter held by the specified `key`,
+// which is applied to the state right away, as for PutState.
+func (stub *MockStub) IncrementState(key string, delta int64) error {
+	if key == "" {
+		return errors.New("key must not be an empty string")
+	}
+	var counter int64
+	if value := stub.State[key]; len(value) > 0 {
+		var err error
+		if counter, err = strconv.ParseInt(string(value), 10, 64); err != nil {
+			return errors.Errorf("value [%s] of key [%s] is not a counter", value, key)
+		}
+	}
+	if (delta > 0 && counter > math.MaxInt64-delta) || (delta < 0 && counter < math.MinInt64-delta) {
+		return errors.Errorf("incrementing counter %d of key [%s] by %d overflows", counter, key, delta)
+	}
+	return stub.PutState(key, []byte(strconv.FormatInt(counter+delta, 10)))
+}
+
 func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if err := validateSimpleKeys(startKey, endKey); err != nil {
 		return nil, err
@@ -328,6 +417,30 @@ func (stub *MockStub) GetStateByPartialCompositeKeyWithPagination(objectType str
 	return nil, nil, nil
 }
 
+// GetStateByPartialCompositeKeyWithFilters function can be invoked by a chaincode to
+// query the state based on a given partial composite key and return the composite keys
+// satisfying the given filters.
+func (stub *MockStub) GetStateByPartialCompositeKeyWithFilters(objectType string, attributes []string,
+	filters []*pb.CompositeKeyFilter) (StateQueryIteratorInterface, error) {
+	for _, filter := range filters {
+		if err := filter.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	iter := NewMockStateRangeQueryIterator(stub, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))
+	iter.Filters = filters
+	return iter, nil
+}
+
+func (stub *MockStub) GetStateByPartialCompositeKeyWithFiltersAndPagination(objectType string, keys []string,
+	filters []*pb.CompositeKeyFilter, pageSize int32, bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
+	return nil, nil, nil
+}
+
 func (stub *MockStub) GetQueryResultWithPagination(query string, pageSize int32,
 	bookmark string) (StateQueryIteratorInterface, *pb.QueryResponseMetadata, error) {
 	return nil, nil, nil
@@ -351,9 +464,9 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 	return res
 }
 
-// Not implemented
+// GetCreator returns the Creator of the mocked transactions
 func (stub *MockStub) GetCreator() ([]byte, error) {
-	return nil, nil
+	return stub.Creator, nil
 }
 
 // Not implemented
@@ -391,6 +504,29 @@ func (stub *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	return stub.TxTimestamp, nil
 }
 
+// GetTxTime returns the timestamp of the mocked transaction as a time.Time
+func (stub *MockStub) GetTxTime() (time.Time, error) {
+	return TxTime(stub.TxTimestamp)
+}
+
+// GetTxRand returns the source of pseudo-random numbers of the mocked
+// transaction, seeded from its channel and ID
+func (stub *MockStub) GetTxRand() *rand.Rand {
+	if stub.txRand == nil {
+		stub.txRand = NewTxRand(stub.ChannelID, stub.TxID)
+	}
+	return stub.txRand
+}
+
+// GetChannelConfig returns the ChannelConfig of the mock, which the tests set
+// to the channel config of the mocked transaction
+func (stub *MockStub) GetChannelConfig() (*pb.ChannelConfigSnapshot, error) {
+	if stub.ChannelConfig == nil {
+		return nil, errors.New("no channel config set in the mock")
+	}
+	return stub.ChannelConfig, nil
+}
+
 func (stub *MockStub) SetEvent(name string, payload []byte) error {
 	stub.ChaincodeEventsChannel <- &pb.ChaincodeEvent{EventName: name, Payload: payload}
 	return nil
@@ -452,6 +588,8 @@ type MockStateRangeQueryIterator struct {
 	StartKey string
 	EndKey   string
 	Current  *list.Element
+	// Filters, if any, restrict the keys returned to the composite keys satisfying them
+	Filters []*pb.CompositeKeyFilter
 }
 
 // HasNext returns true if the range query iterator contains additional keys
@@ -472,14 +610,20 @@ func (iter *MockStateRangeQueryIterator) HasNext() bool {
 	for current != nil {
 		// if this is an open-ended query for all keys, return true
 		if iter.StartKey == "" && iter.EndKey == "" {
-			return true
+			if pb.MatchCompositeKey(current.Value.(string), iter.Filters) {
+				return true
+			}
+			current = current.Next()
+			continue
 		}
 		comp1 := strings.Compare(current.Value.(string), iter.StartKey)
 		comp2 := strings.Compare(current.Value.(string), iter.EndKey)
 		if comp1 >= 0 {
 			if comp2 < 0 {
-				mockLogger.Debug("HasNext() got next")
-				return true
+				if pb.MatchCompositeKey(current.Value.(string), iter.Filters) {
+					mockLogger.Debug("HasNext() got next")
+					return true
+				}
 			} else {
 				mockLogger.Debug("HasNext() but no next")
 				return false
@@ -513,7 +657,8 @@ func (iter *MockStateRangeQueryIterator) Next() (*queryresult.KV, error) {
 		comp2 := strings.Compare(iter.Current.Value.(string), iter.EndKey)
 		// compare to start and end keys. or, if this is an open-ended query for
 		// all keys, it should always return the key and value
-		if (comp1 >= 0 && comp2 < 0) || (iter.StartKey == "" && iter.EndKey == "") {
+		inRange := (comp1 >= 0 && comp2 < 0) || (iter.StartKey == "" && iter.EndKey == "")
+		if inRange && pb.MatchCompositeKey(iter.Current.Value.(string), iter.Filters) {
 			key := iter.Current.Value.(string)
 			value, err := iter.Stub.GetState(key)
 			iter.Current = iter.Current.Next()
@@ -545,6 +690,7 @@ func (iter *MockStateRangeQueryIterator) Print() {
 	mockLogger.Debug("Stub", iter.Stub)
 	mockLogger.Debug("StartKey", iter.StartKey)
 	mockLogger.Debug("EndKey", iter.EndKey)
+	mockLogger.Debug("Filters", iter.Filters)
 	mockLogger.Debug("Current", iter.Current)
 	mockLogger.Debug("HasNext?", iter.HasNext())
 	mockLogger.Debug("}")
@@ -9,10 +9,14 @@ package shim
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -184,6 +188,42 @@ func TestGetStateByPartialCompositeKey(t *testing.T) {
 	}
 }
 
+func TestGetStateByPartialCompositeKeyWithFilters(t *testing.T) {
+	stub := NewMockStub("GetStateByPartialCompositeKeyWithFiltersTest", nil)
+	stub.MockTransactionStart("init")
+	for _, attributes := range [][]string{
+		{"set-1", "blue", "jerry"},
+		{"set-1", "green", "tom"},
+		{"set-1", "red", "tom"},
+		{"set-2", "red", "tom"},
+	} {
+		key, _ := stub.CreateCompositeKey("marble", attributes)
+		stub.PutState(key, []byte(attributes[2]))
+	}
+	stub.PutState("marble", []byte("not a composite key"))
+	stub.MockTransactionEnd("init")
+
+	rqi, err := stub.GetStateByPartialCompositeKeyWithFilters("marble", []string{"set-1"}, []*pb.CompositeKeyFilter{
+		{Attribute: 1, Operator: pb.CompositeKeyFilter_NOT_EQUALS, Value: "blue"},
+		{Attribute: 2, Operator: pb.CompositeKeyFilter_EQUALS, Value: "tom"},
+	})
+	assert.NoError(t, err)
+	var attributes [][]string
+	for rqi.HasNext() {
+		response, err := rqi.Next()
+		assert.NoError(t, err)
+		_, keyAttributes, _ := stub.SplitCompositeKey(response.Key)
+		attributes = append(attributes, keyAttributes)
+	}
+	assert.Equal(t, [][]string{{"set-1", "green", "tom"}, {"set-1", "red", "tom"}}, attributes)
+	rqi.Close()
+
+	_, err = stub.GetStateByPartialCompositeKeyWithFilters("marble", nil, []*pb.CompositeKeyFilter{
+		{Attribute: 0, Operator: pb.CompositeKeyFilter_Operator(42)},
+	})
+	assert.EqualError(t, err, "unknown operator 42 in the filter on attribute 0")
+}
+
 func TestGetStateByPartialCompositeKeyCollision(t *testing.T) {
 	stub := NewMockStub("GetStateByPartialCompositeKeyCollisionTest", nil)
 	stub.MockTransactionStart("init")
@@ -284,6 +324,98 @@ func TestPutEmptyState(t *testing.T) {
 
 }
 
+func TestMockGetPrivateDataHash(t *testing.T) {
+	stub := NewMockStub("GetPrivateDataHash", nil)
+
+	stub.MockTransactionStart("1")
+	err := stub.PutPrivateData("coll", "key", []byte("value"))
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("1")
+
+	stub.MockTransactionStart("2")
+	hash, err := stub.GetPrivateDataHash("coll", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeSHA256([]byte("value")), hash)
+	hash, err = stub.GetPrivateDataHash("coll", "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, hash)
+	stub.MockTransactionEnd("2")
+}
+
+func TestMockGetStateWithProof(t *testing.T) {
+	stub := NewMockStub("GetStateWithProof", nil)
+
+	stub.MockTransactionStart("1")
+	err := stub.PutState("key", []byte("value"))
+	assert.NoError(t, err)
+	stub.MockTransactionEnd("1")
+
+	proof, err := stub.GetStateWithProof("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "GetStateWithProof", proof.Namespace)
+	assert.Equal(t, "key", proof.Key)
+	assert.Equal(t, []byte("value"), proof.Value)
+	_, err = stub.GetStateWithProof("missing")
+	assert.EqualError(t, err, "key [missing] does not exist, the absence of a key cannot be proven")
+}
+
+func TestMockDelStateRange(t *testing.T) {
+	stub := NewMockStub("DelStateRange", nil)
+
+	stub.MockTransactionStart("1")
+	for _, key := range []string{"a", "k1", "k2", "l"} {
+		assert.NoError(t, stub.PutState(key, []byte(key)))
+	}
+	compositeKey, err := stub.CreateCompositeKey("color", []string{"blue"})
+	assert.NoError(t, err)
+	assert.NoError(t, stub.PutState(compositeKey, []byte("blue")))
+	stub.MockTransactionEnd("1")
+
+	stub.MockTransactionStart("2")
+	assert.NoError(t, stub.DelStateRange("k", "l"))
+	stub.MockTransactionEnd("2")
+	assert.Nil(t, stub.State["k1"])
+	assert.Nil(t, stub.State["k2"])
+	assert.Equal(t, []byte("a"), stub.State["a"])
+	assert.Equal(t, []byte("l"), stub.State["l"])
+	assert.Equal(t, []byte("blue"), stub.State[compositeKey])
+
+	err = stub.DelStateRange(compositeKey, "")
+	assert.Error(t, err)
+}
+
+func TestMockIncrementState(t *testing.T) {
+	stub := NewMockStub("IncrementState", nil)
+
+	stub.MockTransactionStart("1")
+	assert.NoError(t, stub.IncrementState("counter", 5))
+	assert.NoError(t, stub.IncrementState("counter", -7))
+	assert.NoError(t, stub.PutState("text", []byte("value")))
+	assert.NoError(t, stub.PutState("max", []byte(strconv.FormatInt(math.MaxInt64, 10))))
+	stub.MockTransactionEnd("1")
+	assert.Equal(t, []byte("-2"), stub.State["counter"])
+
+	stub.MockTransactionStart("2")
+	assert.EqualError(t, stub.IncrementState("text", 1), "value [value] of key [text] is not a counter")
+	assert.EqualError(t, stub.IncrementState("max", 1), "incrementing counter 9223372036854775807 of key [max] by 1 overflows")
+	assert.EqualError(t, stub.IncrementState("", 1), "key must not be an empty string")
+	stub.MockTransactionEnd("2")
+}
+
+func TestMockGetChannelConfig(t *testing.T) {
+	stub := NewMockStub("GetChannelConfig", nil)
+	_, err := stub.GetChannelConfig()
+	assert.EqualError(t, err, "no channel config set in the mock")
+
+	stub.ChannelConfig = &pb.ChannelConfigSnapshot{
+		Sequence:                 2,
+		ApplicationOrganizations: []*pb.ChannelConfigOrganization{{Name: "Org1", MspId: "Org1MSP"}},
+	}
+	channelConfig, err := stub.GetChannelConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, stub.ChannelConfig, channelConfig)
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -0,0 +1,148 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// ContractSeparator separates the name of a contract from the name of its
+// function in the function argument of an invocation, as in <contract>:<function>.
+// The peer validates the invocations of the functions of a contract with the
+// endorsement policy the chaincode definition sets for that contract
+const ContractSeparator = ":"
+
+// ContractRouter is a Chaincode exposing several named contracts out of a
+// single chaincode package. It routes the invocations of <contract>:<function>
+// to the contract registered under that name, which sees the function without
+// its contract qualifier. The functions which are not qualified by a contract
+// are routed to the default contract, if any
+type ContractRouter struct {
+	contracts       map[string]Chaincode
+	names           []string
+	defaultContract string
+}
+
+// NewContractRouter returns a ContractRouter without contracts
+func NewContractRouter() *ContractRouter {
+	return &ContractRouter{contracts: map[string]Chaincode{}}
+}
+
+// Register registers a contract under the given name
+func (r *ContractRouter) Register(name string, contract Chaincode) error {
+	if name == "" || strings.Contains(name, ContractSeparator) {
+		return fmt.Errorf("invalid contract name '%s'", name)
+	}
+	if _, exists := r.contracts[name]; exists {
+		return fmt.Errorf("contract '%s' is already registered", name)
+	}
+	r.contracts[name] = contract
+	r.names = append(r.names, name)
+	return nil
+}
+
+// SetDefault sets the registered contract to route the functions which are
+// not qualified by a contract to
+func (r *ContractRouter) SetDefault(name string) error {
+	if _, exists := r.contracts[name]; !exists {
+		return fmt.Errorf("contract '%s' is not registered", name)
+	}
+	r.defaultContract = name
+	return nil
+}
+
+// Init routes the initialization of the chaincode to the contract qualifying
+// the function. When the function is not qualified by a contract, every
+// contract is initialized in the order of its registration
+func (r *ContractRouter) Init(stub ChaincodeStubInterface) pb.Response {
+	function, _ := stub.GetFunctionAndParameters()
+	if contract, _ := splitContractFunction(function); contract != "" {
+		return r.route(stub, func(cc Chaincode, stub ChaincodeStubInterface) pb.Response {
+			return cc.Init(stub)
+		})
+	}
+
+	for _, name := range r.names {
+		if res := r.contracts[name].Init(stub); res.Status >= ERRORTHRESHOLD {
+			return Error(fmt.Sprintf("failed initializing contract '%s': %s", name, res.Message))
+		}
+	}
+	return Success(nil)
+}
+
+// Invoke routes the invocation to the contract qualifying the function, or
+// to the default contract when the function is not qualified by a contract
+func (r *ContractRouter) Invoke(stub ChaincodeStubInterface) pb.Response {
+	return r.route(stub, func(cc Chaincode, stub ChaincodeStubInterface) pb.Response {
+		return cc.Invoke(stub)
+	})
+}
+
+func (r *ContractRouter) route(stub ChaincodeStubInterface, call func(Chaincode, ChaincodeStubInterface) pb.Response) pb.Response {
+	function, _ := stub.GetFunctionAndParameters()
+	contract, name := splitContractFunction(function)
+	if contract == "" {
+		if r.defaultContract == "" {
+			return Error(fmt.Sprintf("function '%s' is not qualified by a contract and there is no default contract", function))
+		}
+		return call(r.contracts[r.defaultContract], stub)
+	}
+
+	cc, exists := r.contracts[contract]
+	if !exists {
+		return Error(fmt.Sprintf("unknown contract '%s'", contract))
+	}
+	return call(cc, &contractStub{ChaincodeStubInterface: stub, function: name})
+}
+
+func splitContractFunction(function string) (contract, name string) {
+	i := strings.Index(function, ContractSeparator)
+	if i < 0 {
+		return "", function
+	}
+	return function[:i], function[i+len(ContractSeparator):]
+}
+
+// contractStub is the stub of an invocation routed to a contract, whose
+// function is not qualified by the contract
+type contractStub struct {
+	ChaincodeStubInterface
+	function string
+}
+
+func (s *contractStub) GetArgs() [][]byte {
+	args := append([][]byte{}, s.ChaincodeStubInterface.GetArgs()...)
+	if len(args) > 0 {
+		args[0] = []byte(s.function)
+	}
+	return args
+}
+
+func (s *contractStub) GetStringArgs() []string {
+	args := s.GetArgs()
+	strargs := make([]string, 0, len(args))
+	for _, barg := range args {
+		strargs = append(strargs, string(barg))
+	}
+	return strargs
+}
+
+func (s *contractStub) GetFunctionAndParameters() (string, []string) {
+	_, params := s.ChaincodeStubInterface.GetFunctionAndParameters()
+	return s.function, params
+}
+
+func (s *contractStub) GetArgsSlice() ([]byte, error) {
+	res := []byte{}
+	for _, barg := range s.GetArgs() {
+		res = append(res, barg...)
+	}
+	return res, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// echoContract responds with its name, the function it sees and its parameters
+type echoContract struct {
+	name    string
+	initErr bool
+	inits   int
+}
+
+func (c *echoContract) Init(stub ChaincodeStubInterface) pb.Response {
+	c.inits++
+	if c.initErr {
+		return Error("init failed")
+	}
+	return c.Invoke(stub)
+}
+
+func (c *echoContract) Invoke(stub ChaincodeStubInterface) pb.Response {
+	function, params := stub.GetFunctionAndParameters()
+	args := stub.GetStringArgs()
+	argsSlice, _ := stub.GetArgsSlice()
+	return Success([]byte(fmt.Sprintf("%s %s %s %s %s", c.name, function, strings.Join(params, ","), strings.Join(args, ","), argsSlice)))
+}
+
+func TestContractRouter(t *testing.T) {
+	transfers := &echoContract{name: "transfers"}
+	audit := &echoContract{name: "audit"}
+	router := NewContractRouter()
+	assert.NoError(t, router.Register("transfers", transfers))
+	assert.NoError(t, router.Register("audit", audit))
+	assert.EqualError(t, router.Register("audit", audit), "contract 'audit' is already registered")
+	assert.EqualError(t, router.Register("audit:v2", audit), "invalid contract name 'audit:v2'")
+	assert.EqualError(t, router.Register("", audit), "invalid contract name ''")
+
+	stub := NewMockStub("router", router)
+	invoke := func(args ...string) pb.Response {
+		bargs := make([][]byte, 0, len(args))
+		for _, arg := range args {
+			bargs = append(bargs, []byte(arg))
+		}
+		return stub.MockInvoke("1", bargs)
+	}
+
+	res := invoke("transfers:move", "a", "b")
+	assert.Equal(t, int32(OK), res.Status, res.Message)
+	assert.Equal(t, "transfers move a,b move,a,b moveab", string(res.Payload))
+
+	res = invoke("audit:report")
+	assert.Equal(t, int32(OK), res.Status, res.Message)
+	assert.Equal(t, "audit report  report report", string(res.Payload))
+
+	res = invoke("billing:charge")
+	assert.Equal(t, int32(ERROR), res.Status)
+	assert.Equal(t, "unknown contract 'billing'", res.Message)
+
+	res = invoke("move", "a")
+	assert.Equal(t, int32(ERROR), res.Status)
+	assert.Equal(t, "function 'move' is not qualified by a contract and there is no default contract", res.Message)
+
+	assert.EqualError(t, router.SetDefault("billing"), "contract 'billing' is not registered")
+	assert.NoError(t, router.SetDefault("transfers"))
+	res = invoke("move", "a")
+	assert.Equal(t, int32(OK), res.Status, res.Message)
+	// the default contract sees the invocation as is
+	assert.Equal(t, "transfers move a move,a ", string(res.Payload))
+}
+
+func TestContractRouterInit(t *testing.T) {
+	transfers := &echoContract{name: "transfers"}
+	audit := &echoContract{name: "audit"}
+	router := NewContractRouter()
+	assert.NoError(t, router.Register("transfers", transfers))
+	assert.NoError(t, router.Register("audit", audit))
+	stub := NewMockStub("router", router)
+
+	// an unqualified init function initializes every contract
+	res := stub.MockInit("1", [][]byte{[]byte("init")})
+	assert.Equal(t, int32(OK), res.Status, res.Message)
+	assert.Equal(t, 1, transfers.inits)
+	assert.Equal(t, 1, audit.inits)
+
+	// a qualified init function initializes its contract only
+	res = stub.MockInit("2", [][]byte{[]byte("audit:init"), []byte("a")})
+	assert.Equal(t, int32(OK), res.Status, res.Message)
+	assert.Equal(t, "audit init a init,a inita", string(res.Payload))
+	assert.Equal(t, 1, transfers.inits)
+	assert.Equal(t, 2, audit.inits)
+
+	audit.initErr = true
+	res = stub.MockInit("3", [][]byte{[]byte("init")})
+	assert.Equal(t, int32(ERROR), res.Status)
+	assert.Equal(t, "failed initializing contract 'audit': init failed", res.Message)
+}
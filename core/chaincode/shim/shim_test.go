@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	mockpeer "github.com/hyperledger/fabric/common/mocks/peer"
@@ -72,6 +73,9 @@ func (t *shimTestCC) Invoke(stub ChaincodeStubInterface) pb.Response {
 	} else if function == "delete" {
 		// Deletes an entity from its state
 		return t.delete(stub, args)
+	} else if function == "increment" {
+		// Increments a counter of its state
+		return t.increment(stub, args)
 	} else if function == "query" {
 		// the old "Query" is now implemtned in invoke
 		return t.query(stub, args)
@@ -165,6 +169,25 @@ func (t *shimTestCC) delete(stub ChaincodeStubInterface, args []string) pb.Respo
 	return Success(nil)
 }
 
+// Increments a counter by a delta
+func (t *shimTestCC) increment(stub ChaincodeStubInterface, args []string) pb.Response {
+	if len(args) != 2 {
+		return Error("Incorrect number of arguments. Expecting 2")
+	}
+
+	delta, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return Error("Invalid delta, expecting a integer value")
+	}
+
+	err = stub.IncrementState(args[0], delta)
+	if err != nil {
+		return Error("Failed to increment state")
+	}
+
+	return Success(nil)
+}
+
 // query callback representing the query of a chaincode
 func (t *shimTestCC) query(stub ChaincodeStubInterface, args []string) pb.Response {
 	var A string // Entities
@@ -717,6 +740,42 @@ func TestInvoke(t *testing.T) {
 	//wait for done
 	processDone(t, done, false)
 
+	//bad increment
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INCREMENT_STATE, Txid: "4b", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Txid: "4b", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "4b", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("increment"), []byte("C"), []byte("5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4b", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
+	//good increment
+	respSet = &mockpeer.MockResponseSet{
+		DoneFunc:  errorFunc,
+		ErrorFunc: errorFunc,
+		Responses: []*mockpeer.MockResponse{
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INCREMENT_STATE, Txid: "4c", ChannelId: channelId}, RespMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "4c", ChannelId: channelId}},
+			{RecvMsg: &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, Txid: "4c", ChannelId: channelId}, RespMsg: nil},
+		},
+	}
+	peerSide.SetResponses(respSet)
+
+	ci = &pb.ChaincodeInput{Args: [][]byte{[]byte("increment"), []byte("C"), []byte("-5")}, Decorations: nil}
+	payload = utils.MarshalOrPanic(ci)
+	peerSide.Send(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_TRANSACTION, Payload: payload, Txid: "4c", ChannelId: channelId})
+
+	//wait for done
+	processDone(t, done, false)
+
 	//bad invoke
 	respSet = &mockpeer.MockResponseSet{
 		DoneFunc:  errorFunc,
@@ -1187,3 +1246,103 @@ func TestSend(t *testing.T) {
 	err := stream.Send(msg)
 	assert.NotNil(t, err, "should have errored on panic")
 }
+
+type migratingCC struct {
+	shimTestCC
+}
+
+func (t *migratingCC) Migrate(stub ChaincodeStubInterface) pb.Response {
+	if err := stub.PutState("schema", []byte("2")); err != nil {
+		return Error(err.Error())
+	}
+	return Success([]byte("migrated"))
+}
+
+func TestMigrate(t *testing.T) {
+	// the chaincodes which do not implement Migrate have nothing to migrate
+	handler := &Handler{cc: &shimTestCC{}}
+	res := handler.migrate(&ChaincodeStub{TxID: "1"})
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Nil(t, res.Payload)
+
+	stub := NewMockStub("migrating", &migratingCC{})
+	res = stub.MockMigrate("1", nil)
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Equal(t, []byte("migrated"), res.Payload)
+	assert.Equal(t, []byte("2"), stub.State["schema"])
+
+	res = NewMockStub("plain", &shimTestCC{}).MockMigrate("1", nil)
+	assert.Equal(t, int32(OK), res.Status)
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	sent := make(chan *pb.ChaincodeMessage, 10)
+	handler := newChaincodeHandler(newInProcStream(nil, sent), &shimTestCC{})
+	handler.state = ready
+
+	getState := func(txid, key string, values chan<- string) {
+		value, err := handler.handleGetState("", key, "channel", txid)
+		assert.NoError(t, err)
+		values <- string(value)
+	}
+	respond := func(msg *pb.ChaincodeMessage) {
+		err := handler.handleReady(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Payload: msg.Payload, Txid: msg.Txid, ChannelId: msg.ChannelId}, nil)
+		assert.NoError(t, err)
+	}
+	receive := func() *pb.ChaincodeMessage {
+		select {
+		case msg := <-sent:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("no request sent to the peer")
+			return nil
+		}
+	}
+
+	// the requests of a transaction are sent one at a time
+	values1 := make(chan string, 2)
+	go getState("tx1", "a", values1)
+	go getState("tx1", "b", values1)
+	first := receive()
+	select {
+	case msg := <-sent:
+		t.Fatalf("unexpected request %s sent while another one of the transaction is pending", msg.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the requests of other transactions do not wait
+	values2 := make(chan string, 1)
+	go getState("tx2", "c", values2)
+	other := receive()
+	assert.Equal(t, "tx2", other.Txid)
+	respond(other)
+	assert.Equal(t, string(other.Payload), <-values2)
+
+	respond(first)
+	second := receive()
+	assert.Equal(t, "tx1", second.Txid)
+	respond(second)
+	assert.ElementsMatch(t, []string{string(first.Payload), string(second.Payload)}, []string{<-values1, <-values1})
+
+	handler.Lock()
+	assert.Empty(t, handler.txQueues)
+	assert.Empty(t, handler.responseChannel)
+	handler.Unlock()
+}
+
+func TestUnexpectedResponse(t *testing.T) {
+	handler := newChaincodeHandler(newInProcStream(nil, nil), &shimTestCC{})
+	handler.state = ready
+
+	// a response to no pending request does not end the stream
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, Txid: "tx1", ChannelId: "channel"}
+	assert.NoError(t, handler.handleReady(msg, nil))
+
+	// nor does a duplicate response
+	c, err := handler.createChannel("channel", "tx1")
+	assert.NoError(t, err)
+	assert.NoError(t, handler.handleReady(msg, nil))
+	assert.NoError(t, handler.handleReady(msg, nil))
+	assert.Equal(t, *msg, <-c)
+	assert.Len(t, c, 0)
+}
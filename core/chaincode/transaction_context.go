@@ -9,6 +9,7 @@ package chaincode
 import (
 	"sync"
 
+	"github.com/golang/protobuf/proto"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -25,6 +26,10 @@ type TransactionContext struct {
 	CollectionStore      privdata.CollectionStore
 	IsInitTransaction    bool
 
+	// the payload of the message that initiated the transaction (a marshaled
+	// ChaincodeInput), used to attribute leaked query iterators to the function
+	invocationPayload []byte
+
 	// tracks open iterators used for range queries
 	queryMutex          sync.Mutex
 	queryIteratorMap    map[string]commonledger.ResultsIterator
@@ -106,10 +111,24 @@ func (t *TransactionContext) CleanupQueryContextWithBookmark(queryID string) str
 	return bookmark
 }
 
-func (t *TransactionContext) CloseQueryIterators() {
+// CloseQueryIterators closes the query iterators that are still open and returns their count.
+// As the iterators that are exhausted or closed by the chaincode are removed from the context,
+// the returned count indicates the iterators leaked by the chaincode
+func (t *TransactionContext) CloseQueryIterators() int {
 	t.queryMutex.Lock()
 	defer t.queryMutex.Unlock()
 	for _, iter := range t.queryIteratorMap {
 		iter.Close()
 	}
+	return len(t.queryIteratorMap)
+}
+
+// InvokedFunction returns the name of the chaincode function invoked in this
+// transaction context, i.e., the first argument of the chaincode input
+func (t *TransactionContext) InvokedFunction() string {
+	input := &pb.ChaincodeInput{}
+	if err := proto.Unmarshal(t.invocationPayload, input); err != nil || len(input.Args) == 0 {
+		return ""
+	}
+	return string(input.Args[0])
 }
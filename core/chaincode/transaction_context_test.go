@@ -160,10 +160,16 @@ var _ = Describe("TransactionContext", func() {
 		})
 
 		It("closes all initialized results iterators", func() {
-			transactionContext.CloseQueryIterators()
+			Expect(transactionContext.CloseQueryIterators()).To(Equal(5))
 			for _, iter := range resultsIterators {
 				Expect(iter.CloseCallCount()).To(Equal(1))
 			}
 		})
+
+		It("does not count the iterators that have been cleaned up", func() {
+			transactionContext.CleanupQueryContext("query-id-1")
+			transactionContext.CleanupQueryContextWithBookmark("query-id-2")
+			Expect(transactionContext.CloseQueryIterators()).To(Equal(3))
+		})
 	})
 })
@@ -77,7 +77,12 @@ func (client *GRPCClient) parseSecureOptions(opts *SecureOptions) error {
 	}
 	client.tlsConfig = &tls.Config{
 		VerifyPeerCertificate: opts.VerifyCertificate,
-		MinVersion:            tls.VersionTLS12} // TLS 1.2 only
+		MinVersion:            tls.VersionTLS12, // TLS 1.2 or later
+		MaxVersion:            opts.MaxVersion,
+		CipherSuites:          opts.CipherSuites}
+	if opts.MinVersion != 0 {
+		client.tlsConfig.MinVersion = opts.MinVersion
+	}
 	if len(opts.ServerRootCAs) > 0 {
 		client.tlsConfig.RootCAs = x509.NewCertPool()
 		for _, certBytes := range opts.ServerRootCAs {
@@ -137,6 +142,11 @@ func (client *GRPCClient) SetMaxRecvMsgSize(size int) {
 	client.maxRecvMsgSize = size
 }
 
+// MaxRecvMsgSize returns the maximum message size the client can receive
+func (client *GRPCClient) MaxRecvMsgSize() int {
+	return client.maxRecvMsgSize
+}
+
 // SetMaxSendMsgSize sets the maximum message size the client can send
 func (client *GRPCClient) SetMaxSendMsgSize(size int) {
 	client.maxSendMsgSize = size
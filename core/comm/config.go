@@ -39,6 +39,14 @@ var (
 		tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
 		tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
 	}
+	// TLS cipher suites approved by FIPS 140-2, which only use ECDHE key
+	// exchanges and AES-GCM ciphers
+	FIPSTLSCipherSuites = []uint16{
+		tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	}
 	// default connection timeout
 	DefaultConnectionTimeout = 5 * time.Second
 )
@@ -62,6 +70,9 @@ type ServerConfig struct {
 	Logger *flogging.FabricLogger
 	// Metrics Provider
 	MetricsProvider metrics.Provider
+	// ReusePort sets SO_REUSEPORT on the listener of the server, so that other
+	// listeners can bind the same port, e.g. on another interface
+	ReusePort bool
 }
 
 // ClientConfig defines the parameters for configuring a GRPCClient instance
@@ -100,6 +111,24 @@ type SecureOptions struct {
 	RequireClientCert bool
 	// CipherSuites is a list of supported cipher suites for TLS
 	CipherSuites []uint16
+	// MinVersion is the minimum TLS version accepted, TLS 1.2 if not set
+	MinVersion uint16
+	// MaxVersion is the maximum TLS version accepted. Servers default to
+	// TLS 1.2, and clients to the latest version supported
+	MaxVersion uint16
+	// SNICertificates are presented by a server, instead of Certificate, to
+	// the clients that request their server name
+	SNICertificates []SNICertificate
+}
+
+// SNICertificate is a certificate presented by a server to the clients that
+// request a given server name (SNI)
+type SNICertificate struct {
+	ServerName string
+	// PEM-encoded X509 public key
+	Certificate []byte
+	// PEM-encoded private key
+	Key []byte
 }
 
 // KeepaliveOptions is used to set the gRPC keepalive settings for both
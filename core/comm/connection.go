@@ -43,6 +43,7 @@ type CASupport struct {
 type CredentialSupport struct {
 	*CASupport
 	clientCert tls.Certificate
+	tlsPolicy  *TLSPolicy
 }
 
 // GetCredentialSupport returns the singleton CredentialSupport instance
@@ -89,6 +90,23 @@ func (cs *CredentialSupport) SetClientCertificate(cert tls.Certificate) {
 	cs.clientCert = cert
 }
 
+// SetTLSPolicy sets the TLS policy of the gRPC client connections
+func (cs *CredentialSupport) SetTLSPolicy(policy *TLSPolicy) {
+	cs.tlsPolicy = policy
+}
+
+// newTLSConfig returns the TLS config of a gRPC client connection, which
+// presents the client certificate and applies the TLS policy, if set
+func (cs *CredentialSupport) newTLSConfig() *tls.Config {
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cs.clientCert},
+	}
+	if cs.tlsPolicy != nil {
+		cs.tlsPolicy.Configure(tlsConfig)
+	}
+	return tlsConfig
+}
+
 // GetClientCertificate returns the client certificate of the CredentialSupport
 func (cs *CredentialSupport) GetClientCertificate() tls.Certificate {
 	return cs.clientCert
@@ -106,9 +124,7 @@ func (cs *CredentialSupport) GetDeliverServiceCredentials(
 	defer cs.RUnlock()
 
 	var creds credentials.TransportCredentials
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cs.clientCert},
-	}
+	tlsConfig := cs.newTLSConfig()
 	certPool := x509.NewCertPool()
 
 	rootCACerts, exists := cs.OrdererRootCAsByChain[channelID]
@@ -156,9 +172,7 @@ func (cs *CredentialSupport) GetPeerCredentials() credentials.TransportCredentia
 	cs.RLock()
 	defer cs.RUnlock()
 
-	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cs.clientCert},
-	}
+	tlsConfig := cs.newTLSConfig()
 	certPool := x509.NewCertPool()
 	appRootCAs := [][]byte{}
 	for _, appRootCA := range cs.AppRootCAsByChain {
@@ -220,7 +234,9 @@ func NewClientConnectionWithAddress(peerAddress string, block bool, tslEnabled b
 	return conn, err
 }
 
-func InitTLSForShim(key, certStr string) credentials.TransportCredentials {
+// InitTLSForShim returns the gRPC transport credentials of the connection of a
+// chaincode to its peer, applying the given TLS policy if it is not nil
+func InitTLSForShim(key, certStr string, policy *TLSPolicy) credentials.TransportCredentials {
 	var sn string
 	priv, err := base64.StdEncoding.DecodeString(key)
 	if err != nil {
@@ -242,9 +258,13 @@ func InitTLSForShim(key, certStr string) credentials.TransportCredentials {
 	if !cp.AppendCertsFromPEM(b) {
 		commLogger.Panicf("failed to append certificates")
 	}
-	return credentials.NewTLS(&tls.Config{
+	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{cert},
 		RootCAs:      cp,
 		ServerName:   sn,
-	})
+	}
+	if policy != nil {
+		policy.Configure(tlsConfig)
+	}
+	return credentials.NewTLS(tlsConfig)
 }
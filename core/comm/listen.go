@@ -0,0 +1,23 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"context"
+	"net"
+)
+
+// Listen announces on the given TCP address. When reusePort is true, the
+// socket is bound with SO_REUSEPORT so that other listeners, of this process
+// or of another one, can bind the same port
+func Listen(address string, reusePort bool) (net.Listener, error) {
+	if !reusePort {
+		return net.Listen("tcp", address)
+	}
+	lc := net.ListenConfig{Control: reusePortControl}
+	return lc.Listen(context.Background(), "tcp", address)
+}
@@ -22,6 +22,30 @@ var (
 		Name:      "conn_closed",
 		Help:      "gRPC connections closed. Open minus closed is the active number of connections.",
 	}
+
+	failoversOpts = metrics.CounterOpts{
+		Namespace:    "deliver_client",
+		Name:         "failovers",
+		Help:         "The number of connections to an ordering service endpoint other than the one of the previous connection.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	connectionFailuresOpts = metrics.CounterOpts{
+		Namespace:    "deliver_client",
+		Name:         "connection_failures",
+		Help:         "The number of failed connections to an ordering service endpoint.",
+		LabelNames:   []string{"channel", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{endpoint}",
+	}
+
+	dnsChangesOpts = metrics.CounterOpts{
+		Namespace:    "deliver_client",
+		Name:         "dns_changes",
+		Help:         "The number of changes of the addresses of an ordering service endpoint found when re-resolving it after a failure.",
+		LabelNames:   []string{"channel", "endpoint"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{endpoint}",
+	}
 )
 
 func NewServerStatsHandler(p metrics.Provider) *ServerStatsHandler {
@@ -30,3 +54,13 @@ func NewServerStatsHandler(p metrics.Provider) *ServerStatsHandler {
 		ClosedConnCounter: p.NewCounter(closedConnCounterOpts),
 	}
 }
+
+// NewProducerMetrics returns the metrics of the connections of the
+// ConnectionProducers
+func NewProducerMetrics(p metrics.Provider) *ProducerMetrics {
+	return &ProducerMetrics{
+		Failovers:          p.NewCounter(failoversOpts),
+		ConnectionFailures: p.NewCounter(connectionFailuresOpts),
+		DNSChanges:         p.NewCounter(dnsChangesOpts),
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// MaxRecvMsgSizeMetadataKey is the key of the gRPC metadata through which a client
+// advertises the maximum size of the messages it is able to receive, so that a server
+// about to send a larger message can reply with an explicit error instead of having
+// the client fail with an opaque ResourceExhausted error
+const MaxRecvMsgSizeMetadataKey = "fabric-max-recv-msg-size"
+
+// WithMaxRecvMsgSize returns a context that advertises to the server the given
+// maximum size of the messages the client is able to receive
+func WithMaxRecvMsgSize(ctx context.Context, size int) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MaxRecvMsgSizeMetadataKey, strconv.Itoa(size))
+}
+
+// MaxRecvMsgSizeFromContext returns the maximum size of the messages that the client of
+// a server side context advertised it is able to receive, and false if it did not
+// advertise a valid one
+func MaxRecvMsgSizeFromContext(ctx context.Context) (int, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	values := md.Get(MaxRecvMsgSizeMetadataKey)
+	if len(values) == 0 {
+		return 0, false
+	}
+	size, err := strconv.Atoi(values[0])
+	if err != nil || size <= 0 {
+		return 0, false
+	}
+	return size, true
+}
+
+// MaxSendMsgSizeTo returns the maximum size of a message that a server can send to the
+// client of the given context, which is the smallest of the maximum size of the messages
+// the server sends and of the one advertised by the client
+func MaxSendMsgSizeTo(ctx context.Context) int {
+	size := MaxSendMsgSize
+	if clientSize, ok := MaxRecvMsgSizeFromContext(ctx); ok && clientSize < size {
+		size = clientSize
+	}
+	return size
+}
@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMaxRecvMsgSizeMetadata(t *testing.T) {
+	outgoing, _ := metadata.FromOutgoingContext(WithMaxRecvMsgSize(context.Background(), 1024))
+	ctx := metadata.NewIncomingContext(context.Background(), outgoing)
+	size, ok := MaxRecvMsgSizeFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, 1024, size)
+	assert.Equal(t, 1024, MaxSendMsgSizeTo(ctx))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MaxRecvMsgSizeMetadataKey, "2147483647"))
+	assert.Equal(t, MaxSendMsgSize, MaxSendMsgSizeTo(ctx))
+
+	for _, value := range []string{"", "-1", "abc"} {
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(MaxRecvMsgSizeMetadataKey, value))
+		_, ok = MaxRecvMsgSizeFromContext(ctx)
+		assert.False(t, ok, "value %q", value)
+	}
+
+	_, ok = MaxRecvMsgSizeFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Equal(t, MaxSendMsgSize, MaxSendMsgSizeTo(context.Background()))
+}
@@ -7,12 +7,17 @@ SPDX-License-Identifier: Apache-2.0
 package comm
 
 import (
+	"context"
 	"fmt"
-	"math/rand"
+	"net"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"google.golang.org/grpc"
 )
 
@@ -20,6 +25,10 @@ var logger = flogging.MustGetLogger("ConnProducer")
 
 var EndpointDisableInterval = time.Second * 10
 
+// EndpointFailureInterval is the time after which a failure to connect to an
+// endpoint no longer lowers its health
+var EndpointFailureInterval = time.Second * 30
+
 // ConnectionFactory creates a connection to a certain endpoint
 type ConnectionFactory func(endpoint string) (*grpc.ClientConn, error)
 
@@ -39,20 +48,95 @@ type ConnectionProducer interface {
 	GetEndpoints() []string
 }
 
+// HostResolver resolves the host of an endpoint into its addresses
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// ProducerMetrics are the metrics of the connections of a ConnectionProducer,
+// labeled with the channel and, but for the failovers, with the endpoint
+type ProducerMetrics struct {
+	// Failovers counts the connections to an endpoint other than the one of
+	// the previous connection
+	Failovers metrics.Counter
+	// ConnectionFailures counts the failed connections to each endpoint
+	ConnectionFailures metrics.Counter
+	// DNSChanges counts the changes of the addresses of each endpoint found
+	// when re-resolving it after a failure
+	DNSChanges metrics.Counter
+}
+
+// ProducerConfig configures the selection of the endpoints of a
+// ConnectionProducer
+type ProducerConfig struct {
+	// Channel labels the metrics
+	Channel string
+	// Metrics are the metrics of the connections, disabled if nil
+	Metrics *ProducerMetrics
+	// Resolver re-resolves the endpoints which fail, the default resolver
+	// if nil
+	Resolver HostResolver
+}
+
+// endpointHealth tracks the recent failures of an endpoint
+type endpointHealth struct {
+	failures    int
+	lastFailure time.Time
+	addresses   []string
+}
+
+// score returns the number of recent failures of the endpoint, the lowest
+// being the healthiest
+func (h *endpointHealth) score() int {
+	if time.Since(h.lastFailure) >= EndpointFailureInterval {
+		h.failures = 0
+	}
+	return h.failures
+}
+
 type connProducer struct {
 	sync.RWMutex
 	endpoints         []string
 	disabledEndpoints map[string]time.Time
 	connect           ConnectionFactory
+	channel           string
+	metrics           *ProducerMetrics
+	resolver          HostResolver
+	health            map[string]*endpointHealth
+	// next is the index of the endpoint the round-robin starts from
+	next         int
+	lastEndpoint string
 }
 
 // NewConnectionProducer creates a new ConnectionProducer with given endpoints and connection factory.
 // It returns nil, if the given endpoints slice is empty.
 func NewConnectionProducer(factory ConnectionFactory, endpoints []string) ConnectionProducer {
+	return NewConnectionProducerWithConfig(factory, endpoints, ProducerConfig{})
+}
+
+// NewConnectionProducerWithConfig creates a new ConnectionProducer which
+// connects to the healthiest of the given endpoints, in turn when several
+// are equally healthy, and re-resolves the endpoints that fail. It returns
+// nil, if the given endpoints slice is empty.
+func NewConnectionProducerWithConfig(factory ConnectionFactory, endpoints []string, config ProducerConfig) ConnectionProducer {
 	if len(endpoints) == 0 {
 		return nil
 	}
-	return &connProducer{endpoints: endpoints, connect: factory, disabledEndpoints: make(map[string]time.Time)}
+	if config.Metrics == nil {
+		config.Metrics = NewProducerMetrics(&disabled.Provider{})
+	}
+	if config.Resolver == nil {
+		config.Resolver = net.DefaultResolver
+	}
+	return &connProducer{
+		endpoints:         endpoints,
+		connect:           factory,
+		disabledEndpoints: make(map[string]time.Time),
+		channel:           config.Channel,
+		metrics:           config.Metrics,
+		resolver:          config.Resolver,
+		health:            make(map[string]*endpointHealth),
+	}
 }
 
 // NewConnection creates a new connection.
@@ -68,22 +152,140 @@ func (cp *connProducer) NewConnection() (*grpc.ClientConn, string, error) {
 		}
 	}
 
-	endpoints := shuffle(cp.endpoints)
 	checkedEndpoints := make([]string, 0)
-	for _, endpoint := range endpoints {
-		if _, ok := cp.disabledEndpoints[endpoint]; !ok {
-			checkedEndpoints = append(checkedEndpoints, endpoint)
-			conn, err := cp.connect(endpoint)
-			if err != nil {
-				logger.Error("Failed connecting to", endpoint, ", error:", err)
+	for _, endpoint := range cp.candidates() {
+		checkedEndpoints = append(checkedEndpoints, endpoint)
+		conn, err := cp.connect(endpoint)
+		if err != nil {
+			logger.Error("Failed connecting to", endpoint, ", error:", err)
+			cp.failed(endpoint)
+			if !cp.reResolve(endpoint) {
+				continue
+			}
+			// the endpoint moved, its new addresses are worth a try
+			if conn, err = cp.connect(endpoint); err != nil {
+				logger.Error("Failed connecting to", endpoint, "after its re-resolution, error:", err)
+				cp.failed(endpoint)
 				continue
 			}
-			return conn, endpoint, nil
 		}
+		cp.succeeded(endpoint)
+		return conn, endpoint, nil
 	}
 	return nil, "", fmt.Errorf("Could not connect to any of the endpoints: %v", checkedEndpoints)
 }
 
+// candidates returns the endpoints which are not disabled, the healthiest
+// first, the equally healthy ones in round-robin order
+func (cp *connProducer) candidates() []string {
+	type candidate struct {
+		endpoint string
+		score    int
+	}
+	var candidates []candidate
+	for i := range cp.endpoints {
+		endpoint := cp.endpoints[(cp.next+i)%len(cp.endpoints)]
+		if _, disabled := cp.disabledEndpoints[endpoint]; disabled {
+			continue
+		}
+		candidates = append(candidates, candidate{endpoint: endpoint, score: cp.healthOf(endpoint).score()})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].score < candidates[j].score })
+
+	endpoints := make([]string, len(candidates))
+	for i, c := range candidates {
+		endpoints[i] = c.endpoint
+	}
+	return endpoints
+}
+
+func (cp *connProducer) healthOf(endpoint string) *endpointHealth {
+	health, ok := cp.health[endpoint]
+	if !ok {
+		health = &endpointHealth{}
+		cp.health[endpoint] = health
+	}
+	return health
+}
+
+func (cp *connProducer) failed(endpoint string) {
+	cp.penalize(endpoint)
+	cp.metrics.ConnectionFailures.With("channel", cp.channel, "endpoint", endpoint).Add(1)
+}
+
+// penalize lowers the health of an endpoint
+func (cp *connProducer) penalize(endpoint string) {
+	health := cp.healthOf(endpoint)
+	health.score()
+	health.failures++
+	health.lastFailure = time.Now()
+}
+
+func (cp *connProducer) succeeded(endpoint string) {
+	health := cp.healthOf(endpoint)
+	health.failures = 0
+	if health.addresses == nil {
+		// the addresses the endpoint resolves to are recorded to detect when
+		// it moves
+		health.addresses, _ = cp.resolve(endpoint)
+	}
+
+	for i, e := range cp.endpoints {
+		if e == endpoint {
+			cp.next = i + 1
+			break
+		}
+	}
+	if cp.lastEndpoint != "" && cp.lastEndpoint != endpoint {
+		logger.Infof("Failed over from %s to %s for channel %s", cp.lastEndpoint, endpoint, cp.channel)
+		cp.metrics.Failovers.With("channel", cp.channel).Add(1)
+	}
+	cp.lastEndpoint = endpoint
+}
+
+// reResolve resolves again an endpoint which failed, and returns whether its
+// addresses changed since it was last resolved, in which case its failures
+// are forgotten
+func (cp *connProducer) reResolve(endpoint string) bool {
+	addresses, err := cp.resolve(endpoint)
+	if err != nil {
+		logger.Warningf("Failed resolving %s: %s", endpoint, err)
+		return false
+	}
+	if addresses == nil {
+		return false
+	}
+	health := cp.healthOf(endpoint)
+	previous := health.addresses
+	health.addresses = addresses
+	if previous == nil || reflect.DeepEqual(previous, addresses) {
+		return false
+	}
+
+	logger.Infof("Endpoint %s moved from %v to %v", endpoint, previous, addresses)
+	cp.metrics.DNSChanges.With("channel", cp.channel, "endpoint", endpoint).Add(1)
+	health.failures = 0
+	delete(cp.disabledEndpoints, endpoint)
+	return true
+}
+
+// resolve returns the sorted addresses of the host of an endpoint, or nil if
+// the endpoint has no host name to resolve
+func (cp *connProducer) resolve(endpoint string) ([]string, error) {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil || net.ParseIP(host) != nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	addresses, err := cp.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
+
 // UpdateEndpoints updates the endpoints of the ConnectionProducer
 // to be the given endpoints
 func (cp *connProducer) UpdateEndpoints(endpoints []string) {
@@ -95,13 +297,19 @@ func (cp *connProducer) UpdateEndpoints(endpoints []string) {
 	defer cp.Unlock()
 
 	newDisabled := make(map[string]time.Time)
+	newHealth := make(map[string]*endpointHealth)
 	for i := range endpoints {
 		if startTime, ok := cp.disabledEndpoints[endpoints[i]]; ok {
 			newDisabled[endpoints[i]] = startTime
 		}
+		if health, ok := cp.health[endpoints[i]]; ok {
+			newHealth[endpoints[i]] = health
+		}
 	}
 	cp.endpoints = endpoints
 	cp.disabledEndpoints = newDisabled
+	cp.health = newHealth
+	cp.next = 0
 }
 
 func (cp *connProducer) DisableEndpoint(endpoint string) {
@@ -116,22 +324,12 @@ func (cp *connProducer) DisableEndpoint(endpoint string) {
 	for _, currEndpoint := range cp.endpoints {
 		if currEndpoint == endpoint {
 			cp.disabledEndpoints[endpoint] = time.Now()
+			cp.penalize(endpoint)
 			break
 		}
 	}
 }
 
-func shuffle(a []string) []string {
-	n := len(a)
-	returnedSlice := make([]string, n)
-	rand.Seed(time.Now().UnixNano())
-	indices := rand.Perm(n)
-	for i, idx := range indices {
-		returnedSlice[i] = a[idx]
-	}
-	return returnedSlice
-}
-
 // GetEndpoints returns configured endpoints for ordering service
 func (cp *connProducer) GetEndpoints() []string {
 	cp.RLock()
@@ -7,10 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package comm
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 )
@@ -24,7 +27,10 @@ func TestEmptyEndpoints(t *testing.T) {
 }
 
 func TestConnFailures(t *testing.T) {
-	t.Parallel()
+	orgEndpointFailureInterval := EndpointFailureInterval
+	EndpointFailureInterval = time.Millisecond * 100
+	defer func() { EndpointFailureInterval = orgEndpointFailureInterval }()
+
 	conn2Endpoint := make(map[string]string)
 	shouldConnFail := map[string]bool{
 		"a": true,
@@ -45,16 +51,16 @@ func TestConnFailures(t *testing.T) {
 	assert.NoError(t, err)
 	// We should not return 'a' because connecting to 'a' fails
 	assert.NotEqual(t, "a", conn2Endpoint[fmt.Sprintf("%p", conn)])
-	// Now, revive 'a'
+	// Now, revive 'a', and wait for its failure to be forgotten
 	shouldConnFail["a"] = false
-	// Try obtaining a connection 1000 times in order to ensure selection is shuffled
+	time.Sleep(EndpointFailureInterval)
+	// Try obtaining a connection several times in order to ensure the endpoints are selected in turn
 	selected := make(map[string]struct{})
-	for i := 0; i < 1000; i++ {
+	for i := 0; i < 3; i++ {
 		conn, _, err := producer.NewConnection()
 		assert.NoError(t, err)
 		selected[conn2Endpoint[fmt.Sprintf("%p", conn)]] = struct{}{}
 	}
-	// The probability of a, b or c not to be selected is really small
 	_, isAselected := selected["a"]
 	_, isBselected := selected["b"]
 	_, isCselected := selected["c"]
@@ -132,3 +138,127 @@ func TestDisableEndpoint(t *testing.T) {
 	assert.Equal(t, "b", a)
 
 }
+
+func TestRoundRobinAndHealth(t *testing.T) {
+	shouldConnFail := map[string]bool{}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		if shouldConnFail[endpoint] {
+			return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+		}
+		return &grpc.ClientConn{}, nil
+	}
+	fakeFailovers := &metricsfakes.Counter{}
+	fakeFailovers.WithReturns(fakeFailovers)
+	fakeFailures := &metricsfakes.Counter{}
+	fakeFailures.WithReturns(fakeFailures)
+	fakeDNSChanges := &metricsfakes.Counter{}
+	fakeDNSChanges.WithReturns(fakeDNSChanges)
+	producer := NewConnectionProducerWithConfig(connFactory, []string{"a", "b", "c"}, ProducerConfig{
+		Channel: "mychannel",
+		Metrics: &ProducerMetrics{
+			Failovers:          fakeFailovers,
+			ConnectionFailures: fakeFailures,
+			DNSChanges:         fakeDNSChanges,
+		},
+	})
+
+	var selected []string
+	for i := 0; i < 4; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		selected = append(selected, endpoint)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, selected)
+	assert.Equal(t, 3, fakeFailovers.AddCallCount())
+	assert.Equal(t, []string{"channel", "mychannel"}, fakeFailovers.WithArgsForCall(0))
+
+	// 'b' fails, so the next connection fails over to 'c', and 'b' is
+	// tried after the healthy endpoints
+	shouldConnFail["b"] = true
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", endpoint)
+	assert.Equal(t, 1, fakeFailures.AddCallCount())
+	assert.Equal(t, []string{"channel", "mychannel", "endpoint", "b"}, fakeFailures.WithArgsForCall(0))
+
+	shouldConnFail["b"] = false
+	selected = nil
+	for i := 0; i < 3; i++ {
+		_, endpoint, err := producer.NewConnection()
+		assert.NoError(t, err)
+		selected = append(selected, endpoint)
+	}
+	assert.Equal(t, []string{"a", "c", "a"}, selected)
+}
+
+// fakeResolver resolves the hosts and refreshes the cache of the
+// addresses the connections are made to
+type fakeResolver struct {
+	addresses map[string]string
+	cache     map[string]string
+}
+
+func (r *fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	address, ok := r.addresses[host]
+	if !ok {
+		return nil, fmt.Errorf("no such host %s", host)
+	}
+	r.cache[host] = address
+	return []string{address}, nil
+}
+
+func TestReResolution(t *testing.T) {
+	resolver := &fakeResolver{
+		addresses: map[string]string{"orderer0": "10.0.0.1", "orderer1": "10.0.0.2"},
+		cache:     map[string]string{"orderer0": "10.0.0.1", "orderer1": "10.0.0.2"},
+	}
+	reachable := map[string]bool{"10.0.0.1": true, "10.0.0.2": true}
+	connFactory := func(endpoint string) (*grpc.ClientConn, error) {
+		host, _, _ := net.SplitHostPort(endpoint)
+		if !reachable[resolver.cache[host]] {
+			return nil, fmt.Errorf("Failed connecting to %s", endpoint)
+		}
+		return &grpc.ClientConn{}, nil
+	}
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	fakeDNSChanges := &metricsfakes.Counter{}
+	fakeDNSChanges.WithReturns(fakeDNSChanges)
+	producer := NewConnectionProducerWithConfig(connFactory, []string{"orderer0:7050", "orderer1:7050"}, ProducerConfig{
+		Channel: "mychannel",
+		Metrics: &ProducerMetrics{
+			Failovers:          fakeCounter,
+			ConnectionFailures: fakeCounter,
+			DNSChanges:         fakeDNSChanges,
+		},
+		Resolver: resolver,
+	})
+	_, endpoint, err := producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "orderer0:7050", endpoint)
+
+	// orderer1 fails at the same address it resolves to
+	reachable["10.0.0.2"] = false
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "orderer0:7050", endpoint)
+	assert.Equal(t, 0, fakeDNSChanges.AddCallCount())
+
+	// orderer0 moves, and is connected to at its new address once re-resolved
+	reachable["10.0.0.1"] = false
+	resolver.addresses["orderer0"] = "10.0.0.3"
+	reachable["10.0.0.3"] = true
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "orderer0:7050", endpoint)
+	assert.Equal(t, 1, fakeDNSChanges.AddCallCount())
+	assert.Equal(t, []string{"channel", "mychannel", "endpoint", "orderer0:7050"}, fakeDNSChanges.WithArgsForCall(0))
+
+	// an endpoint which no longer resolves is failed over
+	reachable["10.0.0.2"] = true
+	reachable["10.0.0.3"] = false
+	delete(resolver.addresses, "orderer0")
+	_, endpoint, err = producer.NewConnection()
+	assert.NoError(t, err)
+	assert.Equal(t, "orderer1:7050", endpoint)
+}
@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -38,6 +39,8 @@ type GRPCServer struct {
 	clientRootCAs map[string]*x509.Certificate
 	// TLS configuration used by the grpc server
 	tlsConfig *tls.Config
+	// Certificates presented to the clients requesting a given server name
+	sniCertificates map[string]tls.Certificate
 }
 
 // NewGRPCServer creates a new implementation of a GRPCServer given a
@@ -47,7 +50,7 @@ func NewGRPCServer(address string, serverConfig ServerConfig) (*GRPCServer, erro
 		return nil, errors.New("Missing address parameter")
 	}
 	//create our listener
-	lis, err := net.Listen("tcp", address)
+	lis, err := Listen(address, serverConfig.ReusePort)
 
 	if err != nil {
 		return nil, err
@@ -82,11 +85,23 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 			}
 			grpcServer.serverCertificate.Store(cert)
 
+			grpcServer.sniCertificates = make(map[string]tls.Certificate)
+			for _, sniCert := range secureConfig.SNICertificates {
+				cert, err := tls.X509KeyPair(sniCert.Certificate, sniCert.Key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid certificate for server name %s: %s", sniCert.ServerName, err)
+				}
+				grpcServer.sniCertificates[strings.ToLower(sniCert.ServerName)] = cert
+			}
+
 			//set up our TLS config
 			if len(secureConfig.CipherSuites) == 0 {
 				secureConfig.CipherSuites = DefaultTLSCipherSuites
 			}
-			getCert := func(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+			getCert := func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+				if cert, ok := grpcServer.sniCertificates[strings.ToLower(hello.ServerName)]; ok {
+					return &cert, nil
+				}
 				cert := grpcServer.serverCertificate.Load().(tls.Certificate)
 				return &cert, nil
 			}
@@ -96,6 +111,8 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 				GetCertificate:         getCert,
 				SessionTicketsDisabled: true,
 				CipherSuites:           secureConfig.CipherSuites,
+				MinVersion:             secureConfig.MinVersion,
+				MaxVersion:             secureConfig.MaxVersion,
 			}
 			grpcServer.tlsConfig.ClientAuth = tls.RequestClientCert
 			//check if client authentication is required
@@ -183,6 +200,12 @@ func (gServer *GRPCServer) ServerCertificate() tls.Certificate {
 	return gServer.serverCertificate.Load().(tls.Certificate)
 }
 
+// SNICertificates returns the certificates presented by the grpc.Server to
+// the clients requesting a server name, indexed by the lower-cased name
+func (gServer *GRPCServer) SNICertificates() map[string]tls.Certificate {
+	return gServer.sniCertificates
+}
+
 // TLSEnabled is a flag indicating whether or not TLS is enabled for the
 // GRPCServer instance
 func (gServer *GRPCServer) TLSEnabled() bool {
@@ -202,6 +225,12 @@ func (gServer *GRPCServer) Start() error {
 	return gServer.server.Serve(gServer.listener)
 }
 
+// Serve serves the underlying grpc.Server on an additional listener. It
+// blocks until the listener fails or the server is stopped
+func (gServer *GRPCServer) Serve(listener net.Listener) error {
+	return gServer.server.Serve(listener)
+}
+
 // Stop stops the underlying grpc.Server
 func (gServer *GRPCServer) Stop() {
 	gServer.server.Stop()
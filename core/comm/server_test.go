@@ -27,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	testpb "github.com/hyperledger/fabric/core/comm/testdata/grpc"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -1836,3 +1837,72 @@ func TestServerInterceptors(t *testing.T) {
 	assert.Equal(t, grpc.ErrorDesc(err), msg, "Expected error from second ssi")
 	assert.Equal(t, uint32(2), atomic.LoadUint32(&ssiCount), "Expected both ssi handlers to be invoked")
 }
+
+func TestSNICertificates(t *testing.T) {
+	t.Parallel()
+
+	readFile := func(path string) []byte {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "dynamic_cert_update", path))
+		require.NoError(t, err)
+		return data
+	}
+
+	cfg := comm.ServerConfig{
+		SecOpts: &comm.SecureOptions{
+			UseTLS:      true,
+			Key:         readFile("notlocalhost/server.key"),
+			Certificate: readFile("notlocalhost/server.crt"),
+			SNICertificates: []comm.SNICertificate{{
+				ServerName:  "External.Example.com",
+				Key:         readFile("localhost/server.key"),
+				Certificate: readFile("localhost/server.crt"),
+			}},
+		},
+	}
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", cfg)
+	require.NoError(t, err)
+	go srv.Start()
+	defer srv.Stop()
+
+	sniCert, exists := srv.SNICertificates()["external.example.com"]
+	require.True(t, exists)
+
+	presentedCert := func(serverName string) []byte {
+		conn, err := tls.Dial("tcp", srv.Address(), &tls.Config{ServerName: serverName, InsecureSkipVerify: true})
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Raw
+	}
+	assert.Equal(t, sniCert.Certificate[0], presentedCert("external.example.com"))
+	assert.Equal(t, srv.ServerCertificate().Certificate[0], presentedCert("other.example.com"))
+
+	cfg.SecOpts.SNICertificates[0].Key = []byte("invalid")
+	_, err = comm.NewGRPCServer("127.0.0.1:0", cfg)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid certificate for server name External.Example.com")
+}
+
+func TestAdditionalListener(t *testing.T) {
+	t.Parallel()
+
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{ReusePort: true})
+	require.NoError(t, err)
+	testpb.RegisterEmptyServiceServer(srv.Server(), &emptyServiceServer{})
+	go srv.Start()
+	defer srv.Stop()
+
+	// without SO_REUSEPORT, the port of the server cannot be bound again
+	_, err = comm.Listen(srv.Address(), false)
+	assert.Error(t, err)
+
+	lis, err := comm.Listen(srv.Address(), true)
+	require.NoError(t, err)
+	go srv.Serve(lis)
+
+	additional, err := comm.Listen("127.0.0.1:0", false)
+	require.NoError(t, err)
+	go srv.Serve(additional)
+
+	_, err = invokeEmptyCall(additional.Addr().String(), []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// versionTLS13 is tls.VersionTLS13, which the Go releases before 1.12 do not
+// define. TLS 1.3 is negotiated by the binaries built with Go 1.13 or later
+const versionTLS13 uint16 = 0x0304
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": versionTLS13,
+}
+
+var tlsCipherSuites = map[string]uint16{
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384":   tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384": tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305":    tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305,
+	"TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305":  tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305,
+	"TLS_RSA_WITH_AES_128_GCM_SHA256":         tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	"TLS_RSA_WITH_AES_256_GCM_SHA384":         tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256":   tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256": tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+	"TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA":      tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	"TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA":    tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	"TLS_RSA_WITH_AES_128_CBC_SHA256":         tls.TLS_RSA_WITH_AES_128_CBC_SHA256,
+	"TLS_RSA_WITH_AES_128_CBC_SHA":            tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+	"TLS_RSA_WITH_AES_256_CBC_SHA":            tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+}
+
+// TLSPolicy is the TLS protocol versions and cipher suites allowed for the
+// connections of a process, which apply to its gRPC servers and clients
+type TLSPolicy struct {
+	MinVersion   uint16
+	MaxVersion   uint16
+	CipherSuites []uint16
+}
+
+// NewTLSPolicy creates the TLS policy of the given versions, such as 1.2 or 1.3,
+// and the given cipher suites, named as in the crypto/tls package. The versions
+// default to TLS 1.2, and the cipher suites to DefaultTLSCipherSuites. If fips is
+// set, only the cipher suites approved by FIPS 140-2 are allowed and they default
+// to FIPSTLSCipherSuites. The cipher suites of TLS 1.3 cannot be configured, so a
+// FIPS policy cannot allow TLS 1.3, and the cipher suites of a TLS 1.3 only
+// policy cannot be set
+func NewTLSPolicy(minVersion, maxVersion string, cipherSuites []string, fips bool) (*TLSPolicy, error) {
+	policy := &TLSPolicy{}
+	var err error
+	if policy.MinVersion, err = parseTLSVersion(minVersion); err != nil {
+		return nil, err
+	}
+	if policy.MaxVersion, err = parseTLSVersion(maxVersion); err != nil {
+		return nil, err
+	}
+	if maxVersion == "" && policy.MinVersion > policy.MaxVersion {
+		policy.MaxVersion = policy.MinVersion
+	}
+	if policy.MinVersion > policy.MaxVersion {
+		return nil, errors.Errorf("the minimum TLS version %s is greater than the maximum TLS version %s", minVersion, maxVersion)
+	}
+	if fips && policy.MaxVersion == versionTLS13 {
+		return nil, errors.New("TLS 1.3 is not allowed by a FIPS policy, as its cipher suites cannot be restricted")
+	}
+	if policy.MinVersion == versionTLS13 && len(cipherSuites) > 0 {
+		return nil, errors.New("the cipher suites of TLS 1.3 cannot be configured")
+	}
+
+	allowed := map[uint16]bool{}
+	for _, suite := range FIPSTLSCipherSuites {
+		allowed[suite] = true
+	}
+	for _, name := range cipherSuites {
+		suite, ok := tlsCipherSuites[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, errors.Errorf("unknown TLS cipher suite %s", name)
+		}
+		if fips && !allowed[suite] {
+			return nil, errors.Errorf("the TLS cipher suite %s is not approved by FIPS", name)
+		}
+		policy.CipherSuites = append(policy.CipherSuites, suite)
+	}
+	if len(policy.CipherSuites) == 0 {
+		policy.CipherSuites = DefaultTLSCipherSuites
+		if fips {
+			policy.CipherSuites = FIPSTLSCipherSuites
+		}
+	}
+	return policy, nil
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	if version == "" {
+		return tls.VersionTLS12, nil
+	}
+	v, ok := tlsVersions[strings.TrimPrefix(strings.ToLower(version), "tls")]
+	if !ok {
+		return 0, errors.Errorf("unsupported TLS version %s, the supported versions are 1.2 and 1.3", version)
+	}
+	return v, nil
+}
+
+// Apply applies the policy to the given secure options
+func (p *TLSPolicy) Apply(opts *SecureOptions) {
+	opts.MinVersion = p.MinVersion
+	opts.MaxVersion = p.MaxVersion
+	opts.CipherSuites = p.CipherSuites
+}
+
+// Configure applies the policy to the given TLS config
+func (p *TLSPolicy) Configure(config *tls.Config) {
+	config.MinVersion = p.MinVersion
+	config.MaxVersion = p.MaxVersion
+	config.CipherSuites = p.CipherSuites
+}
@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTLSPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		minVersion    string
+		maxVersion    string
+		cipherSuites  []string
+		fips          bool
+		expected      *TLSPolicy
+		expectedError string
+	}{
+		{
+			name:     "defaults",
+			expected: &TLSPolicy{MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12, CipherSuites: DefaultTLSCipherSuites},
+		},
+		{
+			name:       "TLS 1.2 and 1.3",
+			maxVersion: "1.3",
+			expected:   &TLSPolicy{MinVersion: tls.VersionTLS12, MaxVersion: versionTLS13, CipherSuites: DefaultTLSCipherSuites},
+		},
+		{
+			name:       "TLS 1.3 only",
+			minVersion: "TLS1.3",
+			expected:   &TLSPolicy{MinVersion: versionTLS13, MaxVersion: versionTLS13, CipherSuites: DefaultTLSCipherSuites},
+		},
+		{
+			name:         "cipher suites",
+			cipherSuites: []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", " tls_ecdhe_ecdsa_with_chacha20_poly1305"},
+			expected: &TLSPolicy{
+				MinVersion:   tls.VersionTLS12,
+				MaxVersion:   tls.VersionTLS12,
+				CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305},
+			},
+		},
+		{
+			name:     "FIPS",
+			fips:     true,
+			expected: &TLSPolicy{MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12, CipherSuites: FIPSTLSCipherSuites},
+		},
+		{
+			name:         "FIPS cipher suites",
+			cipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			fips:         true,
+			expected:     &TLSPolicy{MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12, CipherSuites: []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}},
+		},
+		{
+			name:          "unsupported version",
+			minVersion:    "1.1",
+			expectedError: "unsupported TLS version 1.1, the supported versions are 1.2 and 1.3",
+		},
+		{
+			name:          "inverted versions",
+			minVersion:    "1.3",
+			maxVersion:    "1.2",
+			expectedError: "the minimum TLS version 1.3 is greater than the maximum TLS version 1.2",
+		},
+		{
+			name:          "unknown cipher suite",
+			cipherSuites:  []string{"TLS_NULL"},
+			expectedError: "unknown TLS cipher suite TLS_NULL",
+		},
+		{
+			name:          "cipher suite not approved by FIPS",
+			cipherSuites:  []string{"TLS_RSA_WITH_AES_128_GCM_SHA256"},
+			fips:          true,
+			expectedError: "the TLS cipher suite TLS_RSA_WITH_AES_128_GCM_SHA256 is not approved by FIPS",
+		},
+		{
+			name:          "FIPS and TLS 1.3",
+			maxVersion:    "1.3",
+			fips:          true,
+			expectedError: "TLS 1.3 is not allowed by a FIPS policy, as its cipher suites cannot be restricted",
+		},
+		{
+			name:          "TLS 1.3 only with cipher suites",
+			minVersion:    "1.3",
+			cipherSuites:  []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+			expectedError: "the cipher suites of TLS 1.3 cannot be configured",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			policy, err := NewTLSPolicy(tt.minVersion, tt.maxVersion, tt.cipherSuites, tt.fips)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, policy)
+		})
+	}
+}
+
+func TestTLSPolicyApply(t *testing.T) {
+	t.Parallel()
+
+	policy := &TLSPolicy{MinVersion: tls.VersionTLS12, MaxVersion: versionTLS13, CipherSuites: FIPSTLSCipherSuites}
+	opts := &SecureOptions{UseTLS: true}
+	policy.Apply(opts)
+	assert.Equal(t, &SecureOptions{UseTLS: true, MinVersion: tls.VersionTLS12, MaxVersion: versionTLS13, CipherSuites: FIPSTLSCipherSuites}, opts)
+
+	config := &tls.Config{ServerName: "peer0"}
+	policy.Configure(config)
+	assert.Equal(t, "peer0", config.ServerName)
+	assert.Equal(t, tls.VersionTLS12, int(config.MinVersion))
+	assert.Equal(t, versionTLS13, config.MaxVersion)
+	assert.Equal(t, FIPSTLSCipherSuites, config.CipherSuites)
+}
+
+func TestTLSPolicyServer(t *testing.T) {
+	t.Parallel()
+
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverKeyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AppendCertsFromPEM(ca.CertBytes())
+
+	policy, err := NewTLSPolicy("1.3", "", nil, false)
+	require.NoError(t, err)
+	secOpts := &SecureOptions{UseTLS: true, Certificate: serverKeyPair.Cert, Key: serverKeyPair.Key}
+	policy.Apply(secOpts)
+	srv, err := NewGRPCServer("127.0.0.1:0", ServerConfig{SecOpts: secOpts})
+	require.NoError(t, err)
+	go srv.Start()
+	defer srv.Stop()
+
+	// a TLS 1.2 client is refused by a TLS 1.3 only server
+	_, err = tls.Dial("tcp", srv.Address(), &tls.Config{RootCAs: certPool, MaxVersion: tls.VersionTLS12})
+	assert.Error(t, err)
+
+	conn, err := tls.Dial("tcp", srv.Address(), &tls.Config{RootCAs: certPool, NextProtos: []string{"h2"}})
+	require.NoError(t, err)
+	defer conn.Close()
+	assert.Equal(t, versionTLS13, conn.ConnectionState().Version)
+}
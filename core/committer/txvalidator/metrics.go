@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	pluginDurationOpts = metrics.HistogramOpts{
+		Namespace:    "validation",
+		Name:         "plugin_duration",
+		Help:         "The time taken by a validation plugin to validate a transaction.",
+		LabelNames:   []string{"channel", "plugin"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{plugin}",
+	}
+
+	pluginTimeoutsOpts = metrics.CounterOpts{
+		Namespace:    "validation",
+		Name:         "plugin_timeouts",
+		Help:         "The number of validations of transactions that a validation plugin did not complete within the timeout.",
+		LabelNames:   []string{"channel", "plugin"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{plugin}",
+	}
+
+	retriesOpts = metrics.CounterOpts{
+		Namespace:    "validation",
+		Name:         "retries",
+		Help:         "The number of validations of transactions retried after a transient failure.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+)
+
+// Metrics are the metrics of the validation of the transactions
+type Metrics struct {
+	PluginDuration metrics.Histogram
+	PluginTimeouts metrics.Counter
+	Retries        metrics.Counter
+}
+
+// NewMetrics creates the metrics of the validation of the transactions
+func NewMetrics(p metrics.Provider) *Metrics {
+	return &Metrics{
+		PluginDuration: p.NewHistogram(pluginDurationOpts),
+		PluginTimeouts: p.NewCounter(pluginTimeoutsOpts),
+		Retries:        p.NewCounter(retriesOpts),
+	}
+}
@@ -9,11 +9,14 @@ package txvalidator
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/capabilities"
+	configvalidation "github.com/hyperledger/fabric/core/handlers/validation/api/config"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/identities"
 	. "github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -73,6 +76,11 @@ type PluginValidator struct {
 	QueryExecutorCreator
 	msp.IdentityDeserializer
 	capabilities Capabilities
+	// customConfigGroups gives the plugins access to the custom groups of the
+	// application config of the channel, if set
+	customConfigGroups configvalidation.CustomConfigGroups
+	timeout            time.Duration
+	metrics            *Metrics
 }
 
 //go:generate mockery -dir ../../handlers/validation/api/capabilities/ -name Capabilities -case underscore -output mocks/
@@ -86,6 +94,7 @@ func NewPluginValidator(pm PluginMapper, qec QueryExecutorCreator, deserializer
 		PluginMapper:         pm,
 		QueryExecutorCreator: qec,
 		IdentityDeserializer: deserializer,
+		metrics:              NewMetrics(&disabled.Provider{}),
 	}
 }
 
@@ -96,7 +105,9 @@ func (pv *PluginValidator) ValidateWithPlugin(ctx *Context) error {
 			Reason: fmt.Sprintf("plugin with name %s couldn't be used: %v", ctx.VSCCName, err),
 		}
 	}
-	err = plugin.Validate(ctx.Block, ctx.Namespace, ctx.Seq, 0, SerializedPolicy(ctx.Policy))
+	startTime := time.Now()
+	err = pv.validate(plugin, ctx)
+	pv.metrics.PluginDuration.With("channel", ctx.Channel, "plugin", ctx.VSCCName).Observe(time.Since(startTime).Seconds())
 	validityStatus := "valid"
 	if err != nil {
 		validityStatus = fmt.Sprintf("invalid: %v", err)
@@ -105,6 +116,30 @@ func (pv *PluginValidator) ValidateWithPlugin(ctx *Context) error {
 	return err
 }
 
+// validate executes the plugin, and fails with an execution failure
+// if the plugin does not complete the validation within the timeout
+func (pv *PluginValidator) validate(plugin validation.Plugin, ctx *Context) error {
+	if pv.timeout <= 0 {
+		return plugin.Validate(ctx.Block, ctx.Namespace, ctx.Seq, 0, SerializedPolicy(ctx.Policy))
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- plugin.Validate(ctx.Block, ctx.Namespace, ctx.Seq, 0, SerializedPolicy(ctx.Policy))
+	}()
+	timer := time.NewTimer(pv.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		pv.metrics.PluginTimeouts.With("channel", ctx.Channel, "plugin", ctx.VSCCName).Add(1)
+		return &validation.ExecutionFailureError{
+			Reason: fmt.Sprintf("plugin with name %s didn't complete the validation within %s", ctx.VSCCName, pv.timeout),
+		}
+	}
+}
+
 func (pv *PluginValidator) getOrCreatePlugin(ctx *Context) (validation.Plugin, error) {
 	pluginFactory := pv.PluginFactoryByName(PluginName(ctx.VSCCName))
 	if pluginFactory == nil {
@@ -168,7 +203,11 @@ func (pbc *pluginsByChannel) createPluginIfAbsent(channel string) (validation.Pl
 func (pbc *pluginsByChannel) initPlugin(plugin validation.Plugin, channel string) (validation.Plugin, error) {
 	pe := &PolicyEvaluator{IdentityDeserializer: pbc.pv.IdentityDeserializer}
 	sf := &StateFetcherImpl{QueryExecutorCreator: pbc.pv}
-	if err := plugin.Init(pe, sf, pbc.pv.capabilities); err != nil {
+	dependencies := []validation.Dependency{pe, sf, pbc.pv.capabilities}
+	if pbc.pv.customConfigGroups != nil {
+		dependencies = append(dependencies, pbc.pv.customConfigGroups)
+	}
+	if err := plugin.Init(dependencies...); err != nil {
 		return nil, errors.Wrap(err, "failed initializing plugin")
 	}
 	return plugin, nil
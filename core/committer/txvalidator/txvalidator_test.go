@@ -7,15 +7,24 @@ SPDX-License-Identifier: Apache-2.0
 package txvalidator
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx/test"
+	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/mocks/scc"
 	util2 "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	configvalidation "github.com/hyperledger/fabric/core/handlers/validation/api/config"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
@@ -25,6 +34,7 @@ import (
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -52,7 +62,7 @@ func testValidationWithNTXes(t *testing.T, ledger ledger2.PeerLedger, gbHash []b
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, mockVsccValidator}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: mockVsccValidator}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	assert.Equal(t, &common.BlockchainInfo{
@@ -128,7 +138,7 @@ func TestBlockValidationDuplicateTXId(t *testing.T) {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, mockVsccValidator}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: mockVsccValidator}
 
 	bcInfo, _ := ledger.GetBlockchainInfo()
 	assert.Equal(t, &common.BlockchainInfo{
@@ -158,6 +168,172 @@ func TestBlockValidationDuplicateTXId(t *testing.T) {
 	assert.True(t, txsfltr.IsSetTo(1, peer.TxValidationCode_DUPLICATE_TXID))
 }
 
+func TestBlockValidationExpiredTransaction(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	acv := &config.MockApplicationCapabilities{}
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}, semaphore.NewWeighted(10)}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: &validator.MockVsccValidator{}}
+
+	// the transaction expires at block 2
+	signer := mspmgmt.GetLocalSigningIdentityOrPanic()
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+	ccid := &peer.ChaincodeID{Name: "foo", Version: "v1"}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util2.GetTestChainID(), &peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: ccid}}, creator)
+	assert.NoError(t, err)
+	assert.NoError(t, utils.SetProposalExpiration(prop, 2, time.Time{}))
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, []byte("results"), nil, ccid, nil, signer)
+	assert.NoError(t, err)
+	env, err := utils.CreateSignedTx(prop, signer, presp)
+	assert.NoError(t, err)
+
+	validate := func(blockNumber uint64) peer.TxValidationCode {
+		block := testutil.NewBlock([]*common.Envelope{env}, blockNumber, gbHash)
+		tValidator.Validate(block)
+		txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		return txsfltr.Flag(0)
+	}
+
+	// the expiration height is ignored without the capability
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(2))
+
+	acv.TxExpirationRv = true
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(1))
+	assert.Equal(t, peer.TxValidationCode_EXPIRED_TRANSACTION, validate(2))
+	assert.Equal(t, peer.TxValidationCode_EXPIRED_TRANSACTION, validate(3))
+}
+
+func TestBlockValidationPrematureTransaction(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	acv := &config.MockApplicationCapabilities{}
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}, semaphore.NewWeighted(10)}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: &validator.MockVsccValidator{}}
+
+	// the transaction is scheduled at block 2
+	signer := mspmgmt.GetLocalSigningIdentityOrPanic()
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+	ccid := &peer.ChaincodeID{Name: "foo", Version: "v1"}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util2.GetTestChainID(), &peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: ccid}}, creator)
+	assert.NoError(t, err)
+	assert.NoError(t, utils.SetProposalSchedule(prop, 2, time.Time{}))
+	sProp, err := utils.GetSignedProposal(prop, signer)
+	assert.NoError(t, err)
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, []byte("results"), nil, ccid, nil, signer)
+	assert.NoError(t, err)
+	env, err := utils.CreateScheduledTx(sProp, presp)
+	assert.NoError(t, err)
+
+	validate := func(blockNumber uint64) peer.TxValidationCode {
+		block := testutil.NewBlock([]*common.Envelope{env}, blockNumber, gbHash)
+		tValidator.Validate(block)
+		txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		return txsfltr.Flag(0)
+	}
+
+	// the envelope is not signed over its payload without the capability
+	assert.Equal(t, peer.TxValidationCode_BAD_CREATOR_SIGNATURE, validate(2))
+
+	acv.ScheduledTransactionsRv = true
+	assert.Equal(t, peer.TxValidationCode_PREMATURE_TRANSACTION, validate(1))
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(2))
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(3))
+}
+
+func TestBlockValidationResourceBudget(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
+	ledgermgmt.InitializeTestEnv()
+	defer ledgermgmt.CleanupTestEnv()
+
+	gb, _ := test.MakeGenesisBlock("TestLedger")
+	gbHash := gb.Header.Hash()
+	ledger, _ := ledgermgmt.CreateLedger(gb)
+	defer ledger.Close()
+
+	acv := &config.MockApplicationCapabilities{}
+	support := &mocktxvalidator.Support{LedgerVal: ledger, ACVal: acv}
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{support, semaphore.NewWeighted(10)}
+	tValidator := &TxValidator{
+		ChainID:     "",
+		Support:     vcs,
+		Vscc:        &validator.MockVsccValidator{},
+		sccprovider: (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider(),
+	}
+
+	// the transaction writes two keys
+	txRWSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: "foo",
+				KvRwSet: &kvrwset.KVRWSet{
+					Writes: []*kvrwset.KVWrite{{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}},
+				},
+			},
+		},
+	}
+	results, err := txRWSet.ToProtoBytes()
+	assert.NoError(t, err)
+	signer := mspmgmt.GetLocalSigningIdentityOrPanic()
+	creator, err := signer.Serialize()
+	assert.NoError(t, err)
+	envelope := func(ccName string) *common.Envelope {
+		ccid := &peer.ChaincodeID{Name: ccName, Version: "v1"}
+		prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util2.GetTestChainID(), &peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: ccid}}, creator)
+		assert.NoError(t, err)
+		presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, results, nil, ccid, nil, signer)
+		assert.NoError(t, err)
+		env, err := utils.CreateSignedTx(prop, signer, presp)
+		assert.NoError(t, err)
+		return env
+	}
+
+	validate := func(env *common.Envelope) peer.TxValidationCode {
+		block := testutil.NewBlock([]*common.Envelope{env}, 1, gbHash)
+		tValidator.Validate(block)
+		txsfltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+		return txsfltr.Flag(0)
+	}
+
+	// the budget is ignored without the capability
+	support.BudgetVal = &peer.ResourceBudget{MaxStateWrites: 1}
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(envelope("foo")))
+
+	acv.ResourceBudgetsRv = true
+	assert.Equal(t, peer.TxValidationCode_RESOURCE_BUDGET_EXCEEDED, validate(envelope("foo")))
+	// the simulation time is not checked by the committers
+	support.BudgetVal = &peer.ResourceBudget{MaxSimulationTimeMs: 1, MaxStateWrites: 2}
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(envelope("foo")))
+
+	// the system chaincodes have no budget
+	support.BudgetVal = &peer.ResourceBudget{MaxStateWrites: 1}
+	assert.Equal(t, peer.TxValidationCode_VALID, validate(envelope("escc")))
+}
+
 func TestBlockValidation(t *testing.T) {
 	viper.Set("peer.fileSystemPath", "/tmp/fabric/txvalidatortest")
 	ledgermgmt.InitializeTestEnv()
@@ -216,7 +392,7 @@ func TestTxValidationFailure_InvalidTxid(t *testing.T) {
 		*mocktxvalidator.Support
 		*semaphore.Weighted
 	}{&mocktxvalidator.Support{LedgerVal: ledger, ACVal: &config.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
-	tValidator := &TxValidator{"", vcs, &validator.MockVsccValidator{}}
+	tValidator := &TxValidator{ChainID: "", Support: vcs, Vscc: &validator.MockVsccValidator{}}
 
 	mockSigner, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
 	assert.NoError(t, err)
@@ -406,3 +582,146 @@ func TestInvalidTXsForUpgradeCC(t *testing.T) {
 
 	assert.EqualValues(t, expectTxsFltr, txsfltr)
 }
+
+type flakyVsccValidator struct {
+	failures int
+	calls    int
+}
+
+func (v *flakyVsccValidator) VSCCValidateTx(seq int, payload *common.Payload, envBytes []byte, block *common.Block) (error, peer.TxValidationCode) {
+	v.calls++
+	if v.calls <= v.failures {
+		return &commonerrors.VSCCExecutionFailureError{Err: errors.New("leveldb: closed")}, peer.TxValidationCode_INVALID_OTHER_REASON
+	}
+	return nil, peer.TxValidationCode_VALID
+}
+
+func TestVSCCValidateTxRetry(t *testing.T) {
+	block := &common.Block{Header: &common.BlockHeader{Number: 1}}
+	retries := &metricsfakes.Counter{}
+	retries.WithReturns(retries)
+	tValidator := &TxValidator{
+		ChainID: "mychannel",
+		config:  Config{MaxAttempts: 3, RetryBackoff: time.Millisecond},
+		metrics: &Metrics{Retries: retries},
+	}
+
+	// the validation succeeds at the last attempt
+	vscc := &flakyVsccValidator{failures: 2}
+	tValidator.Vscc = vscc
+	err, cde := tValidator.vsccValidateTx(0, nil, nil, block)
+	assert.NoError(t, err)
+	assert.Equal(t, peer.TxValidationCode_VALID, cde)
+	assert.Equal(t, 3, vscc.calls)
+	assert.Equal(t, 2, retries.AddCallCount())
+	assert.Equal(t, []string{"channel", "mychannel"}, retries.WithArgsForCall(0))
+
+	// the attempts are exhausted
+	vscc = &flakyVsccValidator{failures: 3}
+	tValidator.Vscc = vscc
+	err, _ = tValidator.vsccValidateTx(0, nil, nil, block)
+	assert.IsType(t, &commonerrors.VSCCExecutionFailureError{}, err)
+	assert.Equal(t, 3, vscc.calls)
+
+	// the retries are disabled by default
+	vscc = &flakyVsccValidator{failures: 1}
+	tValidator = &TxValidator{Vscc: vscc}
+	err, _ = tValidator.vsccValidateTx(0, nil, nil, block)
+	assert.IsType(t, &commonerrors.VSCCExecutionFailureError{}, err)
+	assert.Equal(t, 1, vscc.calls)
+}
+
+type blockingPlugin struct {
+	release chan struct{}
+}
+
+func (p *blockingPlugin) Validate(block *common.Block, namespace string, txPosition int, actionPosition int, contextData ...validation.ContextDatum) error {
+	<-p.release
+	return nil
+}
+
+func (p *blockingPlugin) Init(dependencies ...validation.Dependency) error {
+	return nil
+}
+
+type blockingPluginFactory struct {
+	plugin *blockingPlugin
+}
+
+func (f *blockingPluginFactory) New() validation.Plugin {
+	return f.plugin
+}
+
+func TestValidateWithPluginTimeout(t *testing.T) {
+	plugin := &blockingPlugin{release: make(chan struct{})}
+	defer close(plugin.release)
+	pm := MapBasedPluginMapper{"vscc": &blockingPluginFactory{plugin: plugin}}
+	timeouts := &metricsfakes.Counter{}
+	timeouts.WithReturns(timeouts)
+
+	v := NewPluginValidator(pm, nil, nil, nil)
+	v.timeout = 10 * time.Millisecond
+	v.metrics.PluginTimeouts = timeouts
+	err := v.ValidateWithPlugin(&Context{Channel: "mychannel", Namespace: "mycc", VSCCName: "vscc"})
+	assert.IsType(t, &validation.ExecutionFailureError{}, err)
+	assert.EqualError(t, err, "plugin with name vscc didn't complete the validation within 10ms")
+	assert.Equal(t, 1, timeouts.AddCallCount())
+	assert.Equal(t, []string{"channel", "mychannel", "plugin", "vscc"}, timeouts.WithArgsForCall(0))
+}
+
+type dependenciesPlugin struct {
+	customConfigGroups configvalidation.CustomConfigGroups
+}
+
+func (p *dependenciesPlugin) Validate(block *common.Block, namespace string, txPosition int, actionPosition int, contextData ...validation.ContextDatum) error {
+	return nil
+}
+
+func (p *dependenciesPlugin) Init(dependencies ...validation.Dependency) error {
+	for _, dep := range dependencies {
+		if customConfigGroups, ok := dep.(configvalidation.CustomConfigGroups); ok {
+			p.customConfigGroups = customConfigGroups
+		}
+	}
+	return nil
+}
+
+type dependenciesPluginFactory struct {
+	plugin *dependenciesPlugin
+}
+
+func (f *dependenciesPluginFactory) New() validation.Plugin {
+	return f.plugin
+}
+
+func TestValidateWithPluginCustomConfigGroups(t *testing.T) {
+	fees, err := channelconfig.NewCustomGroupConfig("FeeSchedule", &common.ConfigGroup{
+		Values: map[string]*common.ConfigValue{
+			"Fee": {Value: []byte("10")},
+		},
+	})
+	assert.NoError(t, err)
+	support := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{
+		CustomGroupsVal: map[string]channelconfig.CustomGroup{"FeeSchedule": fees},
+	}, semaphore.NewWeighted(10)}
+
+	plugin := &dependenciesPlugin{}
+	pm := MapBasedPluginMapper{"vscc": &dependenciesPluginFactory{plugin: plugin}}
+	v := NewPluginValidator(pm, nil, nil, nil)
+	v.customConfigGroups = &dynamicCustomConfigGroups{support: support}
+	err = v.ValidateWithPlugin(&Context{Channel: "mychannel", Namespace: "mycc", VSCCName: "vscc"})
+	assert.NoError(t, err)
+	assert.NotNil(t, plugin.customConfigGroups)
+
+	group, ok := plugin.customConfigGroups.CustomGroup("FeeSchedule")
+	assert.True(t, ok)
+	value, ok := group.Value("Fee")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("10"), value)
+
+	_, ok = plugin.customConfigGroups.CustomGroup("NetworkParameters")
+	assert.False(t, ok)
+}
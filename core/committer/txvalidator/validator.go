@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	"github.com/hyperledger/fabric/core/common/validation"
+	configvalidation "github.com/hyperledger/fabric/core/handlers/validation/api/config"
 	"github.com/hyperledger/fabric/core/ledger"
 	ledgerUtil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/msp"
@@ -53,6 +54,17 @@ type Support interface {
 
 	// Capabilities defines the capabilities for the application portion of this channel
 	Capabilities() channelconfig.ApplicationCapabilities
+
+	// CustomGroups returns the custom groups of the application config of this channel
+	CustomGroups() map[string]channelconfig.CustomGroup
+
+	// ResourceBudget returns the budget of the resources used by the simulation of each
+	// proposal to the application chaincodes of this channel, or nil if there is none
+	ResourceBudget() *peer.ResourceBudget
+
+	// SystemChaincodePolicies returns the endorsement policies of the namespaces of the
+	// system chaincodes of this channel, or nil if there is none
+	SystemChaincodePolicies() *peer.SystemChaincodePolicies
 }
 
 //Validator interface which defines API to validate block transactions
@@ -73,9 +85,27 @@ type vsccValidator interface {
 // reference to the ledger to enable tx simulation
 // and execution of vscc
 type TxValidator struct {
-	ChainID string
-	Support Support
-	Vscc    vsccValidator
+	ChainID     string
+	Support     Support
+	Vscc        vsccValidator
+	sccprovider sysccprovider.SystemChaincodeProvider
+	config      Config
+	metrics     *Metrics
+}
+
+// Config defines the tunables of the validation of the transactions
+type Config struct {
+	// PluginTimeout is the maximum duration of the execution of a validation
+	// plugin for a transaction, or zero if the execution is not bounded
+	PluginTimeout time.Duration
+
+	// MaxAttempts is the number of times the validation of a transaction is
+	// attempted when it fails due to a transient error, such as a failure to
+	// read the state database, before the validation of the block fails
+	MaxAttempts int
+
+	// RetryBackoff is the duration waited between two attempts
+	RetryBackoff time.Duration
 }
 
 var logger = flogging.MustGetLogger("committer.txvalidator")
@@ -96,13 +126,20 @@ type blockValidationResult struct {
 }
 
 // NewTxValidator creates new transactions validator
-func NewTxValidator(chainID string, support Support, sccp sysccprovider.SystemChaincodeProvider, pm PluginMapper) *TxValidator {
+func NewTxValidator(chainID string, support Support, sccp sysccprovider.SystemChaincodeProvider, pm PluginMapper, config Config, metrics *Metrics) *TxValidator {
 	// Encapsulates interface implementation
 	pluginValidator := NewPluginValidator(pm, support.Ledger(), &dynamicDeserializer{support: support}, &dynamicCapabilities{support: support})
+	pluginValidator.customConfigGroups = &dynamicCustomConfigGroups{support: support}
+	pluginValidator.timeout = config.PluginTimeout
+	pluginValidator.metrics = metrics
 	return &TxValidator{
-		ChainID: chainID,
-		Support: support,
-		Vscc:    newVSCCValidator(chainID, support, sccp, pluginValidator)}
+		ChainID:     chainID,
+		Support:     support,
+		Vscc:        newVSCCValidator(chainID, support, sccp, pluginValidator),
+		sccprovider: sccp,
+		config:      config,
+		metrics:     metrics,
+	}
 }
 
 func (v *TxValidator) chainExists(chain string) bool {
@@ -329,6 +366,32 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 			return
 		}
 
+		// the expiration height binds the endorsements to the blocks they
+		// may be committed in, time is not checked as the peers would not agree
+		txType := common.HeaderType(chdr.Type)
+		if v.Support.Capabilities().TxExpiration() && (txType == common.HeaderType_ENDORSER_TRANSACTION || txType == common.HeaderType_TOKEN_TRANSACTION) {
+			if err := validation.CheckExpiration(chdr, block.Header.Number, time.Time{}); err != nil {
+				logger.Warningf("[%s] Invalidating transaction %d of block %d: %s", v.ChainID, tIdx, block.Header.Number, err)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_EXPIRED_TRANSACTION,
+				}
+				return
+			}
+		}
+
+		// likewise, a scheduled transaction is only valid from its scheduled height
+		if v.Support.Capabilities().ScheduledTransactions() && txType == common.HeaderType_ENDORSER_TRANSACTION {
+			if err := validation.CheckSchedule(chdr, block.Header.Number, time.Time{}); err != nil {
+				logger.Warningf("[%s] Invalidating transaction %d of block %d: %s", v.ChainID, tIdx, block.Header.Number, err)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_PREMATURE_TRANSACTION,
+				}
+				return
+			}
+		}
+
 		if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
 
 			txID = chdr.TxId
@@ -342,7 +405,7 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 
 			// Validate tx with vscc and policy
 			logger.Debug("Validating transaction vscc tx validate")
-			err, cde := v.Vscc.VSCCValidateTx(tIdx, payload, d, block)
+			err, cde := v.vsccValidateTx(tIdx, payload, d, block)
 			if err != nil {
 				logger.Errorf("VSCCValidateTx for transaction txId = %s returned error: %s", txID, err)
 				switch err.(type) {
@@ -376,40 +439,47 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 				}
 				return
 			}
+			// the committers enforce the resource budget of the channel as well, save
+			// for the simulation time which only the endorsers can measure
+			if err := v.checkResourceBudget(env, invokeCC.ChaincodeName); err != nil {
+				logger.Warningf("[%s] Invalidating transaction %d of block %d: %s", v.ChainID, tIdx, block.Header.Number, err)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_RESOURCE_BUDGET_EXCEEDED,
+				}
+				return
+			}
+
 			txsChaincodeName = invokeCC
 			if upgradeCC != nil {
 				logger.Infof("Find chaincode upgrade transaction for chaincode %s on channel %s with new version %s", upgradeCC.ChaincodeName, upgradeCC.ChainID, upgradeCC.ChaincodeVersion)
 				txsUpgradedChaincode = upgradeCC
 			}
-			// FAB-12971 comment out below block before v1.4 cut. Will uncomment after v1.4.
-			/*
-				} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
-
-					txID = chdr.TxId
-					if !v.Support.Capabilities().FabToken() {
-						logger.Errorf("FabToken capability is not enabled. Unsupported transaction type [%s] in block [%d] transaction [%d]",
-							common.HeaderType(chdr.Type), block.Header.Number, tIdx)
-						results <- &blockValidationResult{
-							tIdx:           tIdx,
-							validationCode: peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD,
-						}
-						return
-					}
+		} else if common.HeaderType(chdr.Type) == common.HeaderType_TOKEN_TRANSACTION {
+			txID = chdr.TxId
+			if !v.Support.Capabilities().FabToken() {
+				logger.Errorf("FabToken capability is not enabled. Unsupported transaction type [%s] in block [%d] transaction [%d]",
+					common.HeaderType(chdr.Type), block.Header.Number, tIdx)
+				results <- &blockValidationResult{
+					tIdx:           tIdx,
+					validationCode: peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD,
+				}
+				return
+			}
 
-					// Check if there is a duplicate of such transaction in the ledger and
-					// obtain the corresponding result that acknowledges the error type
-					erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
-					if erroneousResultEntry != nil {
-						results <- erroneousResultEntry
-						return
-					}
+			// Check if there is a duplicate of such transaction in the ledger and
+			// obtain the corresponding result that acknowledges the error type
+			erroneousResultEntry := v.checkTxIdDupsLedger(tIdx, chdr, v.Support.Ledger())
+			if erroneousResultEntry != nil {
+				results <- erroneousResultEntry
+				return
+			}
 
-					// Set the namespace of the invocation field
-					txsChaincodeName = &sysccprovider.ChaincodeInstance{
-						ChainID:          channel,
-						ChaincodeName:    "Token",
-						ChaincodeVersion: ""}
-			*/
+			// Set the namespace of the invocation field
+			txsChaincodeName = &sysccprovider.ChaincodeInstance{
+				ChainID:          channel,
+				ChaincodeName:    "Token",
+				ChaincodeVersion: ""}
 		} else if common.HeaderType(chdr.Type) == common.HeaderType_CONFIG {
 			configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
 			if err != nil {
@@ -474,6 +544,32 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 // in the ledger or no decision can be made for whether such transaction exists;
 // the function returns nil if it has ensured that there is no such duplicate, such
 // that its consumer can proceed with the transaction processing
+// vsccValidateTx validates a transaction with VSCC, and attempts the validation again
+// as configured when it fails due to an error that may be transient
+func (v *TxValidator) vsccValidateTx(seq int, payload *common.Payload, envBytes []byte, block *common.Block) (error, peer.TxValidationCode) {
+	for attempt := 1; ; attempt++ {
+		err, cde := v.Vscc.VSCCValidateTx(seq, payload, envBytes, block)
+		if !isTransient(err) || attempt >= v.config.MaxAttempts {
+			return err, cde
+		}
+		logger.Warningf("[%s] Attempt %d out of %d to validate transaction %d of block [%d] failed, retrying in %s: %s",
+			v.ChainID, attempt, v.config.MaxAttempts, seq, block.Header.Number, v.config.RetryBackoff, err)
+		v.metrics.Retries.With("channel", v.ChainID).Add(1)
+		time.Sleep(v.config.RetryBackoff)
+	}
+}
+
+// isTransient returns whether the given error of VSCC is not related to
+// the transaction itself, so that the validation may succeed if retried
+func isTransient(err error) bool {
+	switch err.(type) {
+	case *commonerrors.VSCCExecutionFailureError, *commonerrors.VSCCInfoLookupFailureError:
+		return true
+	default:
+		return false
+	}
+}
+
 func (v *TxValidator) checkTxIdDupsLedger(tIdx int, chdr *common.ChannelHeader, ldgr ledger.PeerLedger) (errorTuple *blockValidationResult) {
 
 	// Retrieve the transaction identifier of the input header
@@ -559,6 +655,25 @@ func (v *TxValidator) invalidTXsForUpgradeCC(txsChaincodeNames map[int]*sysccpro
 	}
 }
 
+// checkResourceBudget checks that the simulation of the given transaction does not
+// exceed the resource budget of the channel, if the invoked chaincode is an
+// application chaincode and the channel has a budget
+func (v *TxValidator) checkResourceBudget(env *common.Envelope, ccName string) error {
+	budget := v.Support.ResourceBudget()
+	if budget == nil || !v.Support.Capabilities().ResourceBudgets() || v.sccprovider.IsSysCC(ccName) {
+		return nil
+	}
+	action, err := utils.GetActionFromEnvelopeMsg(env)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get the chaincode action")
+	}
+	usage, err := validation.MeasureUsage(action.Results, action.Events, action.Response)
+	if err != nil {
+		return err
+	}
+	return validation.CheckResourceBudget(budget, usage)
+}
+
 func (v *TxValidator) getTxCCInstance(payload *common.Payload) (invokeCCIns, upgradeCCIns *sysccprovider.ChaincodeInstance, err error) {
 	// This is duplicated unpacking work, but make test easier.
 	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
@@ -644,6 +759,15 @@ func (ds *dynamicDeserializer) IsWellFormed(identity *mspprotos.SerializedIdenti
 	return ds.support.MSPManager().IsWellFormed(identity)
 }
 
+type dynamicCustomConfigGroups struct {
+	support Support
+}
+
+func (dc *dynamicCustomConfigGroups) CustomGroup(name string) (configvalidation.CustomGroup, bool) {
+	group, ok := dc.support.CustomGroups()[name]
+	return group, ok
+}
+
 type dynamicCapabilities struct {
 	support Support
 }
@@ -652,10 +776,54 @@ func (ds *dynamicCapabilities) ACLs() bool {
 	return ds.support.Capabilities().ACLs()
 }
 
+func (ds *dynamicCapabilities) ChaincodeBatches() bool {
+	return ds.support.Capabilities().ChaincodeBatches()
+}
+
+func (ds *dynamicCapabilities) ScheduledTransactions() bool {
+	return ds.support.Capabilities().ScheduledTransactions()
+}
+
+func (ds *dynamicCapabilities) CustomConfigGroups() bool {
+	return ds.support.Capabilities().CustomConfigGroups()
+}
+
+func (ds *dynamicCapabilities) ResourceBudgets() bool {
+	return ds.support.Capabilities().ResourceBudgets()
+}
+
+func (ds *dynamicCapabilities) ChaincodeContracts() bool {
+	return ds.support.Capabilities().ChaincodeContracts()
+}
+
+func (ds *dynamicCapabilities) BinaryChaincodes() bool {
+	return ds.support.Capabilities().BinaryChaincodes()
+}
+
+func (ds *dynamicCapabilities) SystemChaincodePolicies() bool {
+	return ds.support.Capabilities().SystemChaincodePolicies()
+}
+
+func (ds *dynamicCapabilities) RangeDeletes() bool {
+	return ds.support.Capabilities().RangeDeletes()
+}
+
+func (ds *dynamicCapabilities) ChaincodeMigration() bool {
+	return ds.support.Capabilities().ChaincodeMigration()
+}
+
 func (ds *dynamicCapabilities) CollectionUpgrade() bool {
 	return ds.support.Capabilities().CollectionUpgrade()
 }
 
+func (ds *dynamicCapabilities) CommutativeUpdates() bool {
+	return ds.support.Capabilities().CommutativeUpdates()
+}
+
+func (ds *dynamicCapabilities) CommitHash() bool {
+	return ds.support.Capabilities().CommitHash()
+}
+
 // FabToken returns true if fabric token function is supported.
 func (ds *dynamicCapabilities) FabToken() bool {
 	return ds.support.Capabilities().FabToken()
@@ -681,6 +849,10 @@ func (ds *dynamicCapabilities) Supported() error {
 	return ds.support.Capabilities().Supported()
 }
 
+func (ds *dynamicCapabilities) TxExpiration() bool {
+	return ds.support.Capabilities().TxExpiration()
+}
+
 func (ds *dynamicCapabilities) V1_1Validation() bool {
 	return ds.support.Capabilities().V1_1Validation()
 }
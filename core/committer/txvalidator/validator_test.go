@@ -20,6 +20,7 @@ import (
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	ledger2 "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/common/util"
@@ -39,6 +40,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	mb "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -57,7 +59,7 @@ func signedByAnyMember(ids []string) []byte {
 
 func setupLedgerAndValidator(t *testing.T) (ledger.PeerLedger, txvalidator.Validator) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	return setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{}, plugin)
 }
@@ -125,7 +127,7 @@ func setupLedgerAndValidatorExplicitWithMSP(t *testing.T, cpb *mockconfig.MockAp
 	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
 	factory.On("New").Return(plugin)
 
-	theValidator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	theValidator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	return theLedger, theValidator
 }
@@ -425,9 +427,93 @@ func testInvokeOK(t *testing.T, l ledger.PeerLedger, v txvalidator.Validator) {
 	assertValid(b, t)
 }
 
+func TestInvokeIncrements(t *testing.T) {
+	ccID := "mycc"
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	assert.NoError(t, rwsetBuilder.AddToIncrementSet(ccID, "counter", 1))
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	t.Run("CommutativeUpdatesDisabled", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV13Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		tx := getEnv(ccID, nil, rwsetBytes, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+
+	t.Run("CommutativeUpdatesEnabled", func(t *testing.T) {
+		capabilities := v13Capabilities()
+		capabilities.CommutativeUpdatesRv = true
+		l, v := setupLedgerAndValidatorWithCapabilities(t, capabilities)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		tx := getEnv(ccID, nil, rwsetBytes, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertValid(b, t)
+	})
+}
+
+func TestInvokeRangeDeletes(t *testing.T) {
+	ccID := "mycc"
+	rwsetBuilder := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder.AddToRangeDeleteSet(ccID, "key1", "key9", rwsetutil.NewRangeDeleteHasher().Sum())
+	simRes, err := rwsetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+	rwsetBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+
+	t.Run("RangeDeletesDisabled", func(t *testing.T) {
+		l, v := setupLedgerAndValidatorWithV13Capabilities(t)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		tx := getEnv(ccID, nil, rwsetBytes, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+
+	t.Run("RangeDeletesEnabled", func(t *testing.T) {
+		capabilities := v13Capabilities()
+		capabilities.RangeDeletesRv = true
+		l, v := setupLedgerAndValidatorWithCapabilities(t, capabilities)
+		defer ledgermgmt.CleanupTestEnv()
+		defer l.Close()
+
+		putCCInfo(l, ccID, signedByAnyMember([]string{"SampleOrg"}), t)
+
+		tx := getEnv(ccID, nil, rwsetBytes, t)
+		b := &common.Block{Data: &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(tx)}}, Header: &common.BlockHeader{Number: 2}}
+
+		err := v.Validate(b)
+		assert.NoError(t, err)
+		assertValid(b, t)
+	})
+}
+
 func TestInvokeNoRWSet(t *testing.T) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	t.Run("Pre-1.2Capability", func(t *testing.T) {
 		l, v := setupLedgerAndValidatorExplicit(t, preV12Capabilities(), plugin)
@@ -1455,7 +1541,6 @@ func validateTxWithStateBasedEndorsement(t *testing.T, l ledger.PeerLedger, v tx
 }
 
 func TestTokenValidTransaction(t *testing.T) {
-	t.Skip("Skipping TestTokenValidTransaction until token transaction is enabled after v1.4")
 	l, v := setupLedgerAndValidatorWithFabTokenCapabilities(t)
 	defer ledgermgmt.CleanupTestEnv()
 	defer l.Close()
@@ -1482,14 +1567,13 @@ func TestTokenCapabilityNotEnabled(t *testing.T) {
 	// We expect no validation error because we simply mark the tx as invalid
 	assertion.NoError(err)
 
-	// We expect the tx to be invalid because of a duplicate txid
+	// We expect the tx to be invalid because the token transactions are not enabled on the channel
 	txsfltr := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 	assertion.True(txsfltr.IsInvalid(0))
-	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_UNKNOWN_TX_TYPE)
+	assertion.True(txsfltr.Flag(0) == peer.TxValidationCode_UNSUPPORTED_TX_PAYLOAD)
 }
 
 func TestTokenDuplicateTxId(t *testing.T) {
-	t.Skip("Skipping TestTokenDuplicateTxId until token transaction is enabled after v1.4")
 	theLedger := new(mockLedger)
 	vcs := struct {
 		*mocktxvalidator.Support
@@ -1497,7 +1581,7 @@ func TestTokenDuplicateTxId(t *testing.T) {
 	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: fabTokenCapabilities()}, semaphore.NewWeighted(10)}
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	pm := &mocks.PluginMapper{}
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	tx := getTokenTx(t)
 	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, nil)
@@ -1583,6 +1667,16 @@ func (m *mockLedger) CommitWithPvtData(pvtDataAndBlock *ledger.BlockAndPvtData)
 	return nil
 }
 
+// EnableCommitHash sets whether the commit hash is recorded in the blocks
+func (m *mockLedger) EnableCommitHash(enabled bool) {
+}
+
+// GetStateProof returns a proof of the state of a key
+func (m *mockLedger) GetStateProof(namespace, key string) (*queryresult.StateProof, error) {
+	args := m.Called(namespace, key)
+	return args.Get(0).(*queryresult.StateProof), args.Error(1)
+}
+
 // PurgePrivateData purges the private data
 func (m *mockLedger) PurgePrivateData(maxBlockNumToRetain uint64) error {
 	return nil
@@ -1687,6 +1781,11 @@ func (exec *mockQueryExecutor) GetPrivateDataMetadataByHash(namespace, collectio
 	return args.Get(0).(map[string][]byte), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	args := exec.Called(namespace, collection, key)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	args := exec.Called(namespace, collection, keys)
 	return args.Get(0).([][]byte), args.Error(1)
@@ -1747,7 +1846,7 @@ func TestDynamicCapabilitiesAndMSP(t *testing.T) {
 	}{support, semaphore.NewWeighted(10)}
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 
-	v := txvalidator.NewTxValidator("", vcs, mp, pm)
+	v := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	ccID := "mycc"
 
@@ -1795,7 +1894,7 @@ func TestLedgerIsNoAvailable(t *testing.T) {
 	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	pm := &mocks.PluginMapper{}
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
@@ -1828,7 +1927,7 @@ func TestLedgerIsNotAvailableForCheckingTxidDuplicate(t *testing.T) {
 	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	pm := &mocks.PluginMapper{}
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
@@ -1855,7 +1954,7 @@ func TestDuplicateTxId(t *testing.T) {
 	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{}}, semaphore.NewWeighted(10)}
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
 	pm := &mocks.PluginMapper{}
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
@@ -1890,10 +1989,10 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	factory := &mocks.PluginFactory{}
 	plugin := &mocks.Plugin{}
 	factory.On("New").Return(plugin)
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("invalid tx"))
 	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 
 	ccID := "mycc"
 	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
@@ -1925,6 +2024,232 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestValidationWithContractPolicy(t *testing.T) {
+	ccID := "mycc"
+	chaincodePolicy := signedByAnyMember([]string{"SampleOrg"})
+	transfersPolicy := signedByAnyMember([]string{"SampleOrg", "OtherOrg"})
+	cd := &ccp.ChaincodeData{
+		Name:      ccID,
+		Version:   ccVersion,
+		Vscc:      "vscc",
+		Policy:    chaincodePolicy,
+		Contracts: map[string][]byte{"transfers": transfersPolicy},
+	}
+
+	envWithFunction := func(function string) *common.Envelope {
+		cis := &peer.ChaincodeInvocationSpec{
+			ChaincodeSpec: &peer.ChaincodeSpec{
+				ChaincodeId: &peer.ChaincodeID{Name: ccID, Version: ccVersion},
+				Input:       &peer.ChaincodeInput{Args: [][]byte{[]byte(function), []byte("a")}},
+				Type:        peer.ChaincodeSpec_GOLANG,
+			},
+		}
+		prop, _, err := utils.CreateProposalFromCIS(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, signerSerialized)
+		assert.NoError(t, err)
+		presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, createRWset(t, ccID), nil, &peer.ChaincodeID{Name: ccID, Version: ccVersion}, nil, signer)
+		assert.NoError(t, err)
+		tx, err := utils.CreateSignedTx(prop, signer, presp)
+		assert.NoError(t, err)
+		return tx
+	}
+
+	// validate validates the invocations of a function of the transfers contract, of a function
+	// of no contract and of a function of an undefined contract, the transactions validated with
+	// the valid policy only being valid
+	validate := func(chaincodeContracts bool, validPolicy, invalidPolicy []byte) lutils.TxValidationFlags {
+		theLedger := new(mockLedger)
+		vcs := struct {
+			*mocktxvalidator.Support
+			*semaphore.Weighted
+		}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: &mockconfig.MockApplicationCapabilities{ChaincodeContractsRv: chaincodeContracts}}, semaphore.NewWeighted(10)}
+		mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+		pm := &mocks.PluginMapper{}
+		factory := &mocks.PluginFactory{}
+		plugin := &mocks.Plugin{}
+		factory.On("New").Return(plugin)
+		plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
+		validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
+
+		queryExecutor := new(mockQueryExecutor)
+		queryExecutor.On("GetState", "lscc", ccID).Return(utils.MarshalOrPanic(cd), nil)
+		theLedger.On("NewQueryExecutor", mock.Anything).Return(queryExecutor, nil)
+		theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+
+		plugin.On("Validate", mock.Anything, ccID, mock.Anything, mock.Anything, txvalidator.SerializedPolicy(validPolicy)).Return(nil)
+		plugin.On("Validate", mock.Anything, ccID, mock.Anything, mock.Anything, txvalidator.SerializedPolicy(invalidPolicy)).Return(errors.New("invalid tx"))
+
+		b := &common.Block{
+			Data: &common.BlockData{Data: [][]byte{
+				utils.MarshalOrPanic(envWithFunction("transfers:move")),
+				utils.MarshalOrPanic(envWithFunction("move")),
+				utils.MarshalOrPanic(envWithFunction("audit:report")),
+			}},
+			Header: &common.BlockHeader{},
+		}
+		err := validator.Validate(b)
+		assert.NoError(t, err)
+		return lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+
+	t.Run("ChaincodeContractsEnabled", func(t *testing.T) {
+		// only the transactions validated with the policy of the transfers contract are valid
+		txsFilter := validate(true, transfersPolicy, chaincodePolicy)
+		assert.True(t, txsFilter.IsValid(0))
+		assert.True(t, txsFilter.IsSetTo(1, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE))
+		assert.True(t, txsFilter.IsSetTo(2, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE))
+	})
+
+	t.Run("ChaincodeContractsDisabled", func(t *testing.T) {
+		// the contracts are ignored, all the transactions being validated with the policy of the chaincode
+		txsFilter := validate(false, chaincodePolicy, transfersPolicy)
+		assert.True(t, txsFilter.IsValid(0))
+		assert.True(t, txsFilter.IsValid(1))
+		assert.True(t, txsFilter.IsValid(2))
+	})
+}
+
+func TestValidationOfChaincodeBatches(t *testing.T) {
+	theLedger := new(mockLedger)
+	capabilities := &mockconfig.MockApplicationCapabilities{V1_2ValidationRv: true}
+	vcs := struct {
+		*mocktxvalidator.Support
+		*semaphore.Weighted
+	}{&mocktxvalidator.Support{LedgerVal: theLedger, ACVal: capabilities}, semaphore.NewWeighted(10)}
+	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
+	mp.(*scc.MocksccProviderImpl).SysCCMap = map[string]bool{"lscc": true, "batchscc": true, "othscc": true}
+	pm := &mocks.PluginMapper{}
+	factory := &mocks.PluginFactory{}
+	plugin := &mocks.Plugin{}
+	factory.On("New").Return(plugin)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
+
+	queryExecutor := new(mockQueryExecutor)
+	for _, ccID := range []string{"cc1", "cc2"} {
+		cd := &ccp.ChaincodeData{
+			Name:    ccID,
+			Version: ccVersion,
+			Vscc:    "vscc",
+			Policy:  signedByAnyMember([]string{ccID + "Org"}),
+		}
+		queryExecutor.On("GetState", "lscc", ccID).Return(utils.MarshalOrPanic(cd), nil)
+	}
+	theLedger.On("NewQueryExecutor", mock.Anything).Return(queryExecutor, nil)
+	theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+
+	// the transactions satisfy the policy of cc1 only
+	plugin.On("Validate", mock.Anything, "batchscc", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Validate", mock.Anything, "othscc", mock.Anything, mock.Anything, txvalidator.SerializedPolicy(signedByAnyMember([]string{"SampleOrg"}))).Return(errors.New("invalid tx"))
+	plugin.On("Validate", mock.Anything, "cc1", mock.Anything, mock.Anything, txvalidator.SerializedPolicy(signedByAnyMember([]string{"cc1Org"}))).Return(nil)
+	plugin.On("Validate", mock.Anything, "cc2", mock.Anything, mock.Anything, txvalidator.SerializedPolicy(signedByAnyMember([]string{"cc2Org"}))).Return(errors.New("invalid tx"))
+
+	newBlock := func() *common.Block {
+		return &common.Block{
+			Data: &common.BlockData{Data: [][]byte{
+				utils.MarshalOrPanic(getEnv("batchscc", nil, createRWset(t, "cc1"), t)),
+				utils.MarshalOrPanic(getEnv("batchscc", nil, createRWset(t, "cc1", "cc2"), t)),
+				utils.MarshalOrPanic(getEnv("batchscc", nil, createRWset(t, "cc1", "lscc"), t)),
+				utils.MarshalOrPanic(getEnv("batchscc", nil, createRWset(t, "cc1", "othscc"), t)),
+			}},
+			Header: &common.BlockHeader{},
+		}
+	}
+
+	// without the capability, the namespaces written by the system chaincodes are not validated
+	b := newBlock()
+	err := validator.Validate(b)
+	assert.NoError(t, err)
+	txsFilter := lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsFilter.IsValid(0))
+	assert.True(t, txsFilter.IsValid(1))
+	assert.True(t, txsFilter.IsValid(2))
+	assert.True(t, txsFilter.IsValid(3))
+
+	// with the capability, each namespace, including the namespaces of the other
+	// system chaincodes, is validated with the policy of its chaincode
+	capabilities.ChaincodeBatchesRv = true
+	b = newBlock()
+	err = validator.Validate(b)
+	assert.NoError(t, err)
+	txsFilter = lutils.TxValidationFlags(b.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsFilter.IsValid(0))
+	assert.True(t, txsFilter.IsSetTo(1, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE))
+	assert.True(t, txsFilter.IsSetTo(2, peer.TxValidationCode_ILLEGAL_WRITESET))
+	assert.True(t, txsFilter.IsSetTo(3, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE))
+}
+
+func TestValidationWithSystemChaincodePolicy(t *testing.T) {
+	ccID := "assets"
+	anyMemberPolicy := signedByAnyMember([]string{"SampleOrg"})
+	sccPolicy := cauthdsl.SignedByAnyMember([]string{"SampleOrg", "OtherOrg"})
+
+	// validate validates an invocation of the assets system chaincode writing to its
+	// own namespace, the transactions validated with the valid policy only being valid
+	validate := func(systemChaincodePolicies, requiresPolicy bool, policies map[string]*common.SignaturePolicyEnvelope, validPolicy, invalidPolicy []byte) *common.Block {
+		theLedger := new(mockLedger)
+		vcs := struct {
+			*mocktxvalidator.Support
+			*semaphore.Weighted
+		}{&mocktxvalidator.Support{
+			LedgerVal:      theLedger,
+			ACVal:          &mockconfig.MockApplicationCapabilities{SystemChaincodePoliciesRv: systemChaincodePolicies},
+			SCCPoliciesVal: &peer.SystemChaincodePolicies{Policies: policies},
+		}, semaphore.NewWeighted(10)}
+		mp := &scc.MocksccProviderImpl{SysCCMap: map[string]bool{ccID: true}, RequiresPolicyMap: map[string]bool{ccID: requiresPolicy}}
+		pm := &mocks.PluginMapper{}
+		factory := &mocks.PluginFactory{}
+		plugin := &mocks.Plugin{}
+		factory.On("New").Return(plugin)
+		plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(factory)
+		validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
+
+		theLedger.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
+
+		plugin.On("Validate", mock.Anything, ccID, mock.Anything, mock.Anything, txvalidator.SerializedPolicy(validPolicy)).Return(nil)
+		plugin.On("Validate", mock.Anything, ccID, mock.Anything, mock.Anything, txvalidator.SerializedPolicy(invalidPolicy)).Return(errors.New("invalid tx"))
+
+		b := &common.Block{
+			Data:   &common.BlockData{Data: [][]byte{utils.MarshalOrPanic(getEnv(ccID, nil, createRWset(t, ccID), t))}},
+			Header: &common.BlockHeader{},
+		}
+		err := validator.Validate(b)
+		assert.NoError(t, err)
+		return b
+	}
+	policies := map[string]*common.SignaturePolicyEnvelope{ccID: sccPolicy}
+
+	t.Run("SystemChaincodePoliciesEnabled", func(t *testing.T) {
+		// the transaction is validated with the policy of the system chaincode defined by the channel config
+		b := validate(true, false, policies, anyMemberPolicy, utils.MarshalOrPanic(sccPolicy))
+		assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	})
+
+	t.Run("SystemChaincodePoliciesDisabled", func(t *testing.T) {
+		// the policy defined by the channel config is ignored, the transaction
+		// being validated with the endorsement of any member of the channel
+		b := validate(false, false, policies, anyMemberPolicy, utils.MarshalOrPanic(sccPolicy))
+		assertValid(b, t)
+	})
+
+	t.Run("RequiredPolicy", func(t *testing.T) {
+		// the transaction is validated with the required policy defined by the channel config
+		b := validate(true, true, policies, utils.MarshalOrPanic(sccPolicy), anyMemberPolicy)
+		assertValid(b, t)
+	})
+
+	t.Run("MissingRequiredPolicy", func(t *testing.T) {
+		// the namespace may not be written without a policy, which is
+		// ignored as well when the capability is not enabled
+		b := validate(true, true, nil, anyMemberPolicy, utils.MarshalOrPanic(sccPolicy))
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+		b = validate(false, true, policies, anyMemberPolicy, utils.MarshalOrPanic(sccPolicy))
+		assertInvalid(b, t, peer.TxValidationCode_ILLEGAL_WRITESET)
+	})
+}
+
 func createMockLedger(t *testing.T, ccID string) *mockLedger {
 	l := new(mockLedger)
 	l.On("GetTransactionByID", mock.Anything).Return(&peer.ProcessedTransaction{}, ledger.NotFoundInIndexErr(""))
@@ -1944,7 +2269,7 @@ func createMockLedger(t *testing.T, ccID string) *mockLedger {
 
 func TestValidationPluginExecutionError(t *testing.T) {
 	plugin := &mocks.Plugin{}
-	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	l, v := setupLedgerAndValidatorExplicit(t, &mockconfig.MockApplicationCapabilities{}, plugin)
 	defer ledgermgmt.CleanupTestEnv()
@@ -1985,7 +2310,7 @@ func TestValidationPluginNotFound(t *testing.T) {
 	pm := &mocks.PluginMapper{}
 	pm.On("PluginFactoryByName", txvalidator.PluginName("vscc")).Return(nil)
 	mp := (&scc.MocksccProviderFactory{}).NewSystemChaincodeProvider()
-	validator := txvalidator.NewTxValidator("", vcs, mp, pm)
+	validator := txvalidator.NewTxValidator("", vcs, mp, pm, txvalidator.Config{}, txvalidator.NewMetrics(&disabled.Provider{}))
 	err := validator.Validate(b)
 	executionErr := err.(*commonerrors.VSCCExecutionFailureError)
 	assert.Contains(t, executionErr.Error(), "plugin with name vscc wasn't found")
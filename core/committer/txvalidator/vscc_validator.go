@@ -109,6 +109,18 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		return err, peer.TxValidationCode_INVALID_OTHER_REASON
 	}
 
+	// the increments are applied by the committers that support the commutative updates only
+	if containsIncrements(txRWSet) && !v.support.Capabilities().CommutativeUpdates() {
+		return errors.Errorf("transaction increments keys but commutative updates are not enabled on channel %s", chainID),
+			peer.TxValidationCode_ILLEGAL_WRITESET
+	}
+
+	// the range deletes are expanded by the committers that support them only
+	if containsRangeDeletes(txRWSet) && !v.support.Capabilities().RangeDeletes() {
+		return errors.Errorf("transaction deletes ranges of keys but range deletes are not enabled on channel %s", chainID),
+			peer.TxValidationCode_ILLEGAL_WRITESET
+	}
+
 	var wrNamespace []string
 	alwaysEnforceOriginalNamespace := v.support.Capabilities().V1_2Validation()
 	if alwaysEnforceOriginalNamespace {
@@ -148,6 +160,17 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 		}
 	}
 
+	// the namespaces of the system chaincodes requiring an endorsement policy,
+	// like the asset system chaincodes, may only be written when the channel
+	// config defines an endorsement policy for them, since the endorsement of
+	// any member of the channel does not protect their state
+	for _, ns := range wrNamespace {
+		if v.sccprovider.IsSysCCAndRequiresEndorsementPolicy(ns) && v.systemChaincodePolicy(ns) == nil {
+			return errors.Errorf("chaincode %s attempted to write to the namespace of system chaincode %s, which has no endorsement policy in the channel config", ccID, ns),
+				peer.TxValidationCode_ILLEGAL_WRITESET
+		}
+	}
+
 	// we've gathered all the info required to proceed to validation;
 	// validation will behave differently depending on the type of
 	// chaincode (system vs. application)
@@ -176,10 +199,24 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 				peer.TxValidationCode_ILLEGAL_WRITESET
 		}
 
+		// the contract whose function was invoked determines the endorsement
+		// policy of the namespace of the invoked chaincode, on the channels
+		// supporting the contracts of the chaincode definitions
+		function := ""
+		if v.support.Capabilities().ChaincodeContracts() {
+			if function, err = invokedFunction(payload); err != nil {
+				return errors.WithMessage(err, "could not determine the invoked function"), peer.TxValidationCode_BAD_PAYLOAD
+			}
+		}
+
 		// validate *EACH* read write set according to its chaincode's endorsement policy
 		for _, ns := range wrNamespace {
+			nsFunction := ""
+			if ns == ccID {
+				nsFunction = function
+			}
 			// Get latest chaincode version, vscc and validate policy
-			txcc, vscc, policy, err := v.GetInfoForValidate(chdr, ns)
+			txcc, vscc, policy, err := v.getInfoForValidate(chdr, ns, nsFunction)
 			if err != nil {
 				logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
@@ -254,6 +291,52 @@ func (v *VsccValidatorImpl) VSCCValidateTx(seq int, payload *common.Payload, env
 				return err, peer.TxValidationCode_INVALID_OTHER_REASON
 			}
 		}
+
+		// the system chaincodes invoking other chaincodes, like the batch system
+		// chaincode, write to the namespaces of these chaincodes; when the
+		// chaincode batches are enabled, each of these namespaces, including
+		// those of other system chaincodes, is validated with the endorsement
+		// policy of its chaincode, so that a transaction invoking several
+		// chaincodes atomically is valid only if it satisfies the policies of
+		// all of them
+		if v.support.Capabilities().ChaincodeBatches() {
+			if writesToLSCC && ccID != "lscc" {
+				return errors.Errorf("chaincode %s attempted to write to the namespace of LSCC", ccID),
+					peer.TxValidationCode_ILLEGAL_WRITESET
+			}
+			if writesToNonInvokableSCC {
+				return errors.Errorf("chaincode %s attempted to write to the namespace of a system chaincode that cannot be invoked", ccID),
+					peer.TxValidationCode_ILLEGAL_WRITESET
+			}
+			for _, ns := range wrNamespace {
+				if ns == ccID {
+					continue
+				}
+				_, vscc, policy, err := v.GetInfoForValidate(chdr, ns)
+				if err != nil {
+					logger.Errorf("GetInfoForValidate for txId = %s returned error: %+v", chdr.TxId, err)
+					return err, peer.TxValidationCode_INVALID_OTHER_REASON
+				}
+				ctx := &Context{
+					Seq:       seq,
+					Envelope:  envBytes,
+					Block:     block,
+					TxID:      chdr.TxId,
+					Channel:   chdr.ChannelId,
+					Namespace: ns,
+					Policy:    policy,
+					VSCCName:  vscc.ChaincodeName,
+				}
+				if err = v.VSCCValidateTxForCC(ctx); err != nil {
+					switch err.(type) {
+					case *commonerrors.VSCCEndorsementPolicyError:
+						return err, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE
+					default:
+						return err, peer.TxValidationCode_INVALID_OTHER_REASON
+					}
+				}
+			}
+		}
 	}
 	logger.Debugf("[%s] VSCCValidateTx completes env bytes %p", chainID, envBytes)
 	return nil, peer.TxValidationCode_VALID
@@ -315,6 +398,12 @@ func (v *VsccValidatorImpl) getCDataForCC(chid, ccid string) (ccprovider.Chainco
 
 // GetInfoForValidate gets the ChaincodeInstance(with latest version) of tx, vscc and policy from lscc
 func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID string) (*sysccprovider.ChaincodeInstance, *sysccprovider.ChaincodeInstance, []byte, error) {
+	return v.getInfoForValidate(chdr, ccID, "")
+}
+
+// getInfoForValidate is GetInfoForValidate for an invocation of the given function,
+// whose contract, if defined by the chaincode, provides the endorsement policy
+func (v *VsccValidatorImpl) getInfoForValidate(chdr *common.ChannelHeader, ccID, function string) (*sysccprovider.ChaincodeInstance, *sysccprovider.ChaincodeInstance, []byte, error) {
 	cc := &sysccprovider.ChaincodeInstance{
 		ChainID:          chdr.ChannelId,
 		ChaincodeName:    ccID,
@@ -342,11 +431,23 @@ func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID
 		cc.ChaincodeName = cd.CCName()
 		cc.ChaincodeVersion = cd.CCVersion()
 		vscc.ChaincodeName, policy = cd.Validation()
+		if ccData, ok := cd.(*ccprovider.ChaincodeData); ok && v.support.Capabilities().ChaincodeContracts() {
+			var contract string
+			if contract, policy = ccData.ContractPolicy(function); contract != "" {
+				logger.Debugf("Validating txid %s with the endorsement policy of contract %s of chaincode %s", chdr.TxId, contract, ccID)
+			}
+		}
 	} else {
 		// when we are validating a system CC, we use the default
-		// VSCC and a default policy that requires one signature
-		// from any of the members of the channel
+		// VSCC and the endorsement policy of its namespace defined
+		// by the channel config, if the channel supports it, or
+		// else a default policy that requires one signature from
+		// any of the members of the channel
 		p := cauthdsl.SignedByAnyMember(v.support.GetMSPIDs(chdr.ChannelId))
+		if sccPolicy := v.systemChaincodePolicy(ccID); sccPolicy != nil {
+			logger.Debugf("Validating txid %s with the endorsement policy of system chaincode %s defined by the channel config", chdr.TxId, ccID)
+			p = sccPolicy
+		}
 		policy, err = utils.Marshal(p)
 		if err != nil {
 			return nil, nil, nil, err
@@ -356,11 +457,49 @@ func (v *VsccValidatorImpl) GetInfoForValidate(chdr *common.ChannelHeader, ccID
 	return cc, vscc, policy, nil
 }
 
+// systemChaincodePolicy returns the endorsement policy of the namespace of the given
+// system chaincode defined by the channel config, or nil if the channel defines none
+func (v *VsccValidatorImpl) systemChaincodePolicy(ccID string) *common.SignaturePolicyEnvelope {
+	if !v.support.Capabilities().SystemChaincodePolicies() {
+		return nil
+	}
+	return v.support.SystemChaincodePolicies().GetPolicies()[ccID]
+}
+
+// invokedFunction returns the function invoked by an endorser transaction,
+// which is the first argument of its chaincode invocation spec
+func invokedFunction(payload *common.Payload) (string, error) {
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return "", err
+	}
+	if len(tx.Actions) == 0 {
+		return "", errors.New("the transaction has no action")
+	}
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return "", err
+	}
+	cpp, err := utils.GetChaincodeProposalPayload(cap.ChaincodeProposalPayload)
+	if err != nil {
+		return "", err
+	}
+	cis := &peer.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(cpp.Input, cis); err != nil {
+		return "", errors.Wrap(err, "error unmarshaling ChaincodeInvocationSpec")
+	}
+	args := cis.GetChaincodeSpec().GetInput().GetArgs()
+	if len(args) == 0 {
+		return "", nil
+	}
+	return string(args[0]), nil
+}
+
 // txWritesToNamespace returns true if the supplied NsRwSet
 // performs a ledger write
 func (v *VsccValidatorImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
 	// check for public writes first
-	if ns.KvRwSet != nil && len(ns.KvRwSet.Writes) > 0 {
+	if ns.KvRwSet != nil && (len(ns.KvRwSet.Writes) > 0 || len(ns.KvRwSet.Increments) > 0 || len(ns.KvRwSet.RangeDeletes) > 0) {
 		return true
 	}
 
@@ -392,3 +531,25 @@ func (v *VsccValidatorImpl) txWritesToNamespace(ns *rwsetutil.NsRwSet) bool {
 
 	return false
 }
+
+// containsIncrements returns true if the supplied TxRwSet
+// increments any key
+func containsIncrements(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.KvRwSet != nil && len(ns.KvRwSet.Increments) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// containsRangeDeletes returns true if the supplied TxRwSet
+// deletes any range of keys
+func containsRangeDeletes(txRWSet *rwsetutil.TxRwSet) bool {
+	for _, ns := range txRWSet.NsRwSets {
+		if ns.KvRwSet != nil && len(ns.KvRwSet.RangeDeletes) > 0 {
+			return true
+		}
+	}
+	return false
+}
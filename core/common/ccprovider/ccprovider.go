@@ -447,6 +447,31 @@ type ChaincodeData struct {
 
 	// InstantiationPolicy for the chaincode
 	InstantiationPolicy []byte `protobuf:"bytes,8,opt,name=instantiation_policy,proto3"`
+
+	// Annotations attached to the chaincode definition, which are opaque to the peer
+	Annotations map[string]string `protobuf:"bytes,9,rep,name=annotations" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+
+	// Contracts maps the contracts exposed by the chaincode to their endorsement policy
+	Contracts map[string][]byte `protobuf:"bytes,10,rep,name=contracts" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+
+	// InitRequired is true when the chaincode must be initialized by an
+	// invocation of its Init function before any other invocation
+	InitRequired bool `protobuf:"varint,11,opt,name=init_required,proto3"`
+}
+
+// ContractSeparator separates the name of a contract from the name of its
+// function in the function argument of an invocation
+const ContractSeparator = ":"
+
+// SplitContractFunction splits a function invoked as <contract>:<function>
+// into the name of the contract and the name of the function. The contract
+// is empty when the function is not qualified by a contract
+func SplitContractFunction(function string) (contract, name string) {
+	i := strings.Index(function, ContractSeparator)
+	if i < 0 {
+		return "", function
+	}
+	return function[:i], function[i+len(ContractSeparator):]
 }
 
 // CCName returns the name of this chaincode (the name it was put in the ChaincodeRegistry with).
@@ -472,6 +497,18 @@ func (cd *ChaincodeData) Validation() (string, []byte) {
 	return cd.Vscc, cd.Policy
 }
 
+// ContractPolicy returns the contract invoked by a function along with the
+// endorsement policy to validate the invocation with. When the function is
+// not qualified by a contract of the chaincode definition, the contract is
+// empty and the policy is the endorsement policy of the chaincode
+func (cd *ChaincodeData) ContractPolicy(function string) (string, []byte) {
+	contract, _ := SplitContractFunction(function)
+	if policy, ok := cd.Contracts[contract]; ok && contract != "" {
+		return contract, policy
+	}
+	return "", cd.Policy
+}
+
 // Endorsement returns how to endorse proposals for this chaincode.
 // The string returns is the name of the endorsement method (usually 'escc').
 func (cd *ChaincodeData) Endorsement() string {
@@ -162,3 +162,32 @@ func setupDirectoryStructure(t *testing.T) (string, map[string][]byte) {
 
 	return tmp, hashes
 }
+
+func TestChaincodeDataContractPolicy(t *testing.T) {
+	cd := &ccprovider.ChaincodeData{
+		Name:      "mycc",
+		Policy:    []byte("chaincode policy"),
+		Contracts: map[string][]byte{"transfers": []byte("transfers policy")},
+	}
+
+	for _, tc := range []struct {
+		function         string
+		expectedContract string
+		expectedPolicy   string
+	}{
+		{"transfers:move", "transfers", "transfers policy"},
+		{"transfers:", "transfers", "transfers policy"},
+		{"audit:report", "", "chaincode policy"},
+		{"move", "", "chaincode policy"},
+		{":move", "", "chaincode policy"},
+		{"", "", "chaincode policy"},
+	} {
+		contract, policy := cd.ContractPolicy(tc.function)
+		assert.Equal(t, tc.expectedContract, contract, tc.function)
+		assert.Equal(t, tc.expectedPolicy, string(policy), tc.function)
+	}
+
+	contract, name := ccprovider.SplitContractFunction("transfers:move:all")
+	assert.Equal(t, "transfers", contract)
+	assert.Equal(t, "move:all", name)
+}
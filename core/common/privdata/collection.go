@@ -55,6 +55,14 @@ type CollectionAccessPolicy interface {
 	IsMemberOnlyRead() bool
 }
 
+// CollectionResidencyPolicy is implemented by the access policies of the
+// collections which restrict the regions their private data may be persisted in
+type CollectionResidencyPolicy interface {
+	// AllowsRegion returns whether a peer of the organization with the given
+	// MSP ID located in the given region may persist the private data
+	AllowsRegion(mspID, region string) bool
+}
+
 // CollectionPersistenceConfigs encapsulates configurations related to persistece of a collection
 type CollectionPersistenceConfigs interface {
 	// BlockToLive returns the number of blocks after which the collection data expires.
@@ -0,0 +1,68 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+)
+
+// RegionAllowed returns whether a peer of the organization with the given MSP
+// ID located in the given region may persist the private data of the
+// collection with the given config. The peers of the organizations the
+// collection declares no regions for may persist it wherever they are
+func RegionAllowed(config *common.StaticCollectionConfig, mspID, region string) bool {
+	regions, ok := config.GetAllowedRegions()[mspID]
+	if !ok {
+		return true
+	}
+	for _, r := range regions.GetRegions() {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterResidency returns the private write set without the collections that
+// a peer of the organization with the given MSP ID located in the given region
+// may not persist according to the given configs of the collections, along
+// with the names of these collections by namespace
+func FilterResidency(pvtRWSet *rwset.TxPvtReadWriteSet, configs map[string]*common.CollectionConfigPackage, mspID, region string) (*rwset.TxPvtReadWriteSet, map[string][]string) {
+	var refused map[string][]string
+	filtered := &rwset.TxPvtReadWriteSet{DataModel: pvtRWSet.GetDataModel()}
+	for _, ns := range pvtRWSet.GetNsPvtRwset() {
+		nsRWSet := &rwset.NsPvtReadWriteSet{Namespace: ns.Namespace}
+		for _, col := range ns.CollectionPvtRwset {
+			config := staticCollectionConfig(configs[ns.Namespace], col.CollectionName)
+			if config != nil && !RegionAllowed(config, mspID, region) {
+				if refused == nil {
+					refused = map[string][]string{}
+				}
+				refused[ns.Namespace] = append(refused[ns.Namespace], col.CollectionName)
+				continue
+			}
+			nsRWSet.CollectionPvtRwset = append(nsRWSet.CollectionPvtRwset, col)
+		}
+		if len(nsRWSet.CollectionPvtRwset) > 0 {
+			filtered.NsPvtRwset = append(filtered.NsPvtRwset, nsRWSet)
+		}
+	}
+	if refused == nil {
+		return pvtRWSet, nil
+	}
+	return filtered, refused
+}
+
+func staticCollectionConfig(pkg *common.CollectionConfigPackage, name string) *common.StaticCollectionConfig {
+	for _, config := range pkg.GetConfig() {
+		if static := config.GetStaticCollectionConfig(); static != nil && static.Name == name {
+			return static
+		}
+	}
+	return nil
+}
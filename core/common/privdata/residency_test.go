@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegionAllowed(t *testing.T) {
+	config := &common.StaticCollectionConfig{
+		Name: "mycollection",
+		AllowedRegions: map[string]*common.CollectionRegions{
+			"Org1MSP": {Regions: []string{"eu-west", "eu-central"}},
+			"Org2MSP": {},
+		},
+	}
+
+	assert.True(t, RegionAllowed(config, "Org1MSP", "eu-west"))
+	assert.True(t, RegionAllowed(config, "Org1MSP", "eu-central"))
+	assert.False(t, RegionAllowed(config, "Org1MSP", "us-east"))
+	assert.False(t, RegionAllowed(config, "Org1MSP", ""))
+	// no region is allowed to Org2MSP
+	assert.False(t, RegionAllowed(config, "Org2MSP", "eu-west"))
+	// the regions of Org3MSP are not restricted
+	assert.True(t, RegionAllowed(config, "Org3MSP", "us-east"))
+	assert.True(t, RegionAllowed(config, "Org3MSP", ""))
+	assert.True(t, RegionAllowed(&common.StaticCollectionConfig{}, "Org1MSP", "us-east"))
+
+	sc := &SimpleCollection{conf: *config}
+	assert.True(t, sc.AllowsRegion("Org1MSP", "eu-west"))
+	assert.False(t, sc.AllowsRegion("Org1MSP", "us-east"))
+}
+
+func TestFilterResidency(t *testing.T) {
+	collectionConfig := func(name string, regions ...string) *common.CollectionConfig {
+		config := &common.StaticCollectionConfig{Name: name}
+		if len(regions) > 0 {
+			config.AllowedRegions = map[string]*common.CollectionRegions{"Org1MSP": {Regions: regions}}
+		}
+		return &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: config},
+		}
+	}
+	configs := map[string]*common.CollectionConfigPackage{
+		"ns1": {Config: []*common.CollectionConfig{collectionConfig("c1", "eu"), collectionConfig("c2")}},
+		"ns2": {Config: []*common.CollectionConfig{collectionConfig("c1", "us")}},
+	}
+	pvtRWSet := &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: "ns1",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{CollectionName: "c1", Rwset: []byte("ns1-c1")},
+					{CollectionName: "c2", Rwset: []byte("ns1-c2")},
+				},
+			},
+			{
+				Namespace: "ns2",
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{CollectionName: "c1", Rwset: []byte("ns2-c1")},
+				},
+			},
+		},
+	}
+
+	// the private write set is returned as is when all its collections are allowed
+	filtered, refused := FilterResidency(pvtRWSet, configs, "Org2MSP", "eu")
+	assert.True(t, filtered == pvtRWSet)
+	assert.Nil(t, refused)
+
+	// the collections that are not allowed are removed, along with the namespaces left empty
+	filtered, refused = FilterResidency(pvtRWSet, configs, "Org1MSP", "eu")
+	assert.Equal(t, map[string][]string{"ns2": {"c1"}}, refused)
+	assert.Equal(t, &rwset.TxPvtReadWriteSet{
+		DataModel:  rwset.TxReadWriteSet_KV,
+		NsPvtRwset: pvtRWSet.NsPvtRwset[:1],
+	}, filtered)
+
+	filtered, refused = FilterResidency(pvtRWSet, configs, "Org1MSP", "us")
+	assert.Equal(t, map[string][]string{"ns1": {"c1"}}, refused)
+	assert.Len(t, filtered.NsPvtRwset, 2)
+	assert.Equal(t, []*rwset.CollectionPvtReadWriteSet{pvtRWSet.NsPvtRwset[0].CollectionPvtRwset[1]}, filtered.NsPvtRwset[0].CollectionPvtRwset)
+	assert.Equal(t, pvtRWSet.NsPvtRwset[1], filtered.NsPvtRwset[1])
+	// the original private write set is left untouched
+	assert.Len(t, pvtRWSet.NsPvtRwset[0].CollectionPvtRwset, 2)
+
+	// the collections without a config are not restricted
+	filtered, refused = FilterResidency(pvtRWSet, nil, "Org1MSP", "us")
+	assert.True(t, filtered == pvtRWSet)
+	assert.Nil(t, refused)
+}
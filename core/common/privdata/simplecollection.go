@@ -65,6 +65,12 @@ func (sc *SimpleCollection) IsMemberOnlyRead() bool {
 	return sc.conf.MemberOnlyRead
 }
 
+// AllowsRegion returns whether a peer of the organization with the given MSP ID
+// located in the given region may persist the private data of the collection
+func (sc *SimpleCollection) AllowsRegion(mspID, region string) bool {
+	return RegionAllowed(&sc.conf, mspID, region)
+}
+
 // Setup configures a simple collection object based on a given
 // StaticCollectionConfig proto that has all the necessary information
 func (sc *SimpleCollection) Setup(collectionConfig *common.StaticCollectionConfig, deserializer msp.IdentityDeserializer) error {
@@ -0,0 +1,424 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proposalresponse
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// Absent is the value of a difference for what one of the proposal responses
+// does not have, such as the write of a key or a namespace of the rwset
+const Absent = "<absent>"
+
+// Difference is a difference between a proposal response and the first one
+// of the compared proposal responses, which is the reference
+type Difference struct {
+	// Response is the index of the proposal response differing from the reference
+	Response int
+	// Field is the differing part of the proposal responses, such as
+	// response.status, event.payload or rwset.writes
+	Field string
+	// Namespace, Collection and Key locate the differing entry of the rwset.
+	// The keys of the hashed rwsets of the collections are hex encoded hashes
+	Namespace  string
+	Collection string
+	Key        string
+	// Reference and Value are the values of the reference proposal response
+	// and of the differing one
+	Reference string
+	Value     string
+}
+
+func (d Difference) String() string {
+	var location []string
+	if d.Namespace != "" {
+		location = append(location, fmt.Sprintf("namespace %s", d.Namespace))
+	}
+	if d.Collection != "" {
+		location = append(location, fmt.Sprintf("collection %s", d.Collection))
+	}
+	if d.Key != "" {
+		location = append(location, fmt.Sprintf("key %s", strconv.Quote(d.Key)))
+	}
+	field := d.Field
+	if len(location) > 0 {
+		field = fmt.Sprintf("%s of %s", d.Field, strings.Join(location, ", "))
+	}
+	return fmt.Sprintf("%s: %s != %s", field, d.Reference, d.Value)
+}
+
+// Compare compares the proposal responses of several endorsers for the same
+// proposal with the first one, and returns their differences down to the
+// namespaces, collections and keys of the rwsets responsible for them. The
+// proposal responses match when there is no difference
+func Compare(responses ...*pb.ProposalResponse) ([]Difference, error) {
+	if len(responses) == 0 {
+		return nil, nil
+	}
+	ref, err := parse(responses[0])
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse proposal response 0")
+	}
+
+	var diffs []Difference
+	for i := 1; i < len(responses); i++ {
+		resp, err := parse(responses[i])
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to parse proposal response %d", i))
+		}
+		c := &comparison{template: Difference{Response: i}, diffs: &diffs}
+		c.compare(ref, resp)
+	}
+	return diffs, nil
+}
+
+// parsedResponse is a proposal response with its nested messages unmarshaled
+type parsedResponse struct {
+	*pb.ProposalResponse
+	payload *pb.ProposalResponsePayload
+	action  *pb.ChaincodeAction
+	event   *pb.ChaincodeEvent
+	rwset   *rwsetutil.TxRwSet
+}
+
+func parse(resp *pb.ProposalResponse) (*parsedResponse, error) {
+	if resp == nil {
+		return nil, errors.New("nil proposal response")
+	}
+	parsed := &parsedResponse{ProposalResponse: resp}
+	if len(resp.Payload) == 0 {
+		// a failed endorsement has no payload
+		return parsed, nil
+	}
+
+	var err error
+	if parsed.payload, err = utils.GetProposalResponsePayload(resp.Payload); err != nil {
+		return nil, err
+	}
+	if parsed.action, err = utils.GetChaincodeAction(parsed.payload.Extension); err != nil {
+		return nil, err
+	}
+	if len(parsed.action.Events) > 0 {
+		if parsed.event, err = utils.GetChaincodeEvents(parsed.action.Events); err != nil {
+			return nil, err
+		}
+	}
+	if len(parsed.action.Results) > 0 {
+		txRWSet := &rwset.TxReadWriteSet{}
+		if err := proto.Unmarshal(parsed.action.Results, txRWSet); err != nil {
+			return nil, errors.Wrap(err, "error unmarshaling TxReadWriteSet")
+		}
+		if parsed.rwset, err = rwsetutil.TxRwSetFromProtoMsg(txRWSet); err != nil {
+			return nil, errors.WithMessage(err, "error unmarshaling the rwsets of the namespaces")
+		}
+	}
+	return parsed, nil
+}
+
+// comparison accumulates the differences of a proposal response with the
+// reference one, located by its template
+type comparison struct {
+	template Difference
+	diffs    *[]Difference
+}
+
+func (c *comparison) add(field, reference, value string) {
+	if reference == value {
+		return
+	}
+	d := c.template
+	d.Field, d.Reference, d.Value = field, reference, value
+	*c.diffs = append(*c.diffs, d)
+}
+
+// in returns a comparison adding the differences located by the given fields
+func (c *comparison) in(namespace, collection, key string) *comparison {
+	loc := &comparison{template: c.template, diffs: c.diffs}
+	if namespace != "" {
+		loc.template.Namespace = namespace
+	}
+	if collection != "" {
+		loc.template.Collection = collection
+	}
+	if key != "" {
+		loc.template.Key = key
+	}
+	return loc
+}
+
+func (c *comparison) compare(ref, resp *parsedResponse) {
+	found := len(*c.diffs)
+	c.add("response.status", strconv.Itoa(int(ref.GetResponse().GetStatus())), strconv.Itoa(int(resp.GetResponse().GetStatus())))
+	c.add("response.message", strconv.Quote(ref.GetResponse().GetMessage()), strconv.Quote(resp.GetResponse().GetMessage()))
+	if ref.payload == nil || resp.payload == nil {
+		c.add("payload", present(ref.payload != nil), present(resp.payload != nil))
+		return
+	}
+
+	c.add("proposal_hash", hex.EncodeToString(ref.payload.ProposalHash), hex.EncodeToString(resp.payload.ProposalHash))
+	c.add("chaincode_id", chaincodeID(ref.action.ChaincodeId), chaincodeID(resp.action.ChaincodeId))
+	c.add("action.response.status", strconv.Itoa(int(ref.action.GetResponse().GetStatus())), strconv.Itoa(int(resp.action.GetResponse().GetStatus())))
+	c.add("action.response.message", strconv.Quote(ref.action.GetResponse().GetMessage()), strconv.Quote(resp.action.GetResponse().GetMessage()))
+	c.add("action.response.payload", printable(ref.action.GetResponse().GetPayload()), printable(resp.action.GetResponse().GetPayload()))
+	if !proto.Equal(ref.action.TokenExpectation, resp.action.TokenExpectation) {
+		c.add("token_expectation", proto.CompactTextString(ref.action.TokenExpectation), proto.CompactTextString(resp.action.TokenExpectation))
+	}
+	c.compareEvents(ref.event, resp.event)
+	c.compareRWSets(ref.rwset, resp.rwset)
+
+	if len(*c.diffs) == found && !bytes.Equal(ref.Payload, resp.Payload) {
+		// the payloads differ in a way the comparison does not inspect, such
+		// as the encoding of the same messages
+		c.add("payload", hex.EncodeToString(ref.Payload), hex.EncodeToString(resp.Payload))
+	}
+}
+
+func (c *comparison) compareEvents(ref, event *pb.ChaincodeEvent) {
+	if ref == nil || event == nil {
+		c.add("event", present(ref != nil), present(event != nil))
+		return
+	}
+	c.add("event.chaincode_id", ref.ChaincodeId, event.ChaincodeId)
+	c.add("event.tx_id", ref.TxId, event.TxId)
+	c.add("event.event_name", strconv.Quote(ref.EventName), strconv.Quote(event.EventName))
+	c.add("event.payload", printable(ref.Payload), printable(event.Payload))
+}
+
+func (c *comparison) compareRWSets(ref, txRWSet *rwsetutil.TxRwSet) {
+	refNs, nsRWSets := namespaces(ref), namespaces(txRWSet)
+	for _, ns := range union(refNs, nsRWSets) {
+		loc := c.in(ns, "", "")
+		refNsRWSet, nsRWSet := refNs[ns], nsRWSets[ns]
+		if refNsRWSet == nil || nsRWSet == nil {
+			loc.add("rwset", present(refNsRWSet != nil), present(nsRWSet != nil))
+			continue
+		}
+		loc.compareKVRWSets(refNsRWSet.KvRwSet, nsRWSet.KvRwSet)
+
+		refColls, colls := collections(refNsRWSet), collections(nsRWSet)
+		for _, coll := range union(refColls, colls) {
+			collLoc := loc.in("", coll, "")
+			refColl, collRWSet := refColls[coll], colls[coll]
+			if refColl == nil || collRWSet == nil {
+				collLoc.add("rwset", present(refColl != nil), present(collRWSet != nil))
+				continue
+			}
+			collLoc.compareHashedRWSets(refColl.HashedRwSet, collRWSet.HashedRwSet)
+			collLoc.add("pvt_rwset_hash", hex.EncodeToString(refColl.PvtRwSetHash), hex.EncodeToString(collRWSet.PvtRwSetHash))
+		}
+	}
+}
+
+func (c *comparison) compareKVRWSets(ref, kvRWSet *kvrwset.KVRWSet) {
+	if ref == nil {
+		ref = &kvrwset.KVRWSet{}
+	}
+	if kvRWSet == nil {
+		kvRWSet = &kvrwset.KVRWSet{}
+	}
+
+	reads := func(s *kvrwset.KVRWSet) map[string]string {
+		m := map[string]string{}
+		for _, r := range s.Reads {
+			m[r.Key] = versionString(r.Version)
+		}
+		return m
+	}
+	c.compareEntries("rwset.reads", reads(ref), reads(kvRWSet))
+
+	writes := func(s *kvrwset.KVRWSet) map[string]string {
+		m := map[string]string{}
+		for _, w := range s.Writes {
+			m[w.Key] = writeValue(w.IsDelete, w.Value, printable)
+		}
+		return m
+	}
+	c.compareEntries("rwset.writes", writes(ref), writes(kvRWSet))
+
+	increments := func(s *kvrwset.KVRWSet) map[string]string {
+		m := map[string]string{}
+		for _, inc := range s.Increments {
+			m[inc.Key] = strconv.FormatInt(inc.Delta, 10)
+		}
+		return m
+	}
+	c.compareEntries("rwset.increments", increments(ref), increments(kvRWSet))
+
+	rangeDeletes := func(s *kvrwset.KVRWSet) map[string]string {
+		m := map[string]string{}
+		for _, rd := range s.RangeDeletes {
+			m[fmt.Sprintf("[%s, %s)", rd.StartKey, rd.EndKey)] = fmt.Sprintf("%x", rd.KeysHash)
+		}
+		return m
+	}
+	c.compareEntries("rwset.range_deletes", rangeDeletes(ref), rangeDeletes(kvRWSet))
+
+	metadataWrites := func(s *kvrwset.KVRWSet) map[string]string {
+		m := map[string]string{}
+		for _, w := range s.MetadataWrites {
+			m[w.Key] = metadata(w.Entries)
+		}
+		return m
+	}
+	c.compareEntries("rwset.metadata_writes", metadataWrites(ref), metadataWrites(kvRWSet))
+
+	rangeQueries := func(s *kvrwset.KVRWSet) map[string]string {
+		m := map[string]string{}
+		for _, rqi := range s.RangeQueriesInfo {
+			m[fmt.Sprintf("[%s, %s)", rqi.StartKey, rqi.EndKey)] = proto.CompactTextString(rqi)
+		}
+		return m
+	}
+	c.compareEntries("rwset.range_queries_info", rangeQueries(ref), rangeQueries(kvRWSet))
+}
+
+func (c *comparison) compareHashedRWSets(ref, hashedRWSet *kvrwset.HashedRWSet) {
+	if ref == nil {
+		ref = &kvrwset.HashedRWSet{}
+	}
+	if hashedRWSet == nil {
+		hashedRWSet = &kvrwset.HashedRWSet{}
+	}
+
+	reads := func(s *kvrwset.HashedRWSet) map[string]string {
+		m := map[string]string{}
+		for _, r := range s.HashedReads {
+			m[hex.EncodeToString(r.KeyHash)] = versionString(r.Version)
+		}
+		return m
+	}
+	c.compareEntries("rwset.hashed_reads", reads(ref), reads(hashedRWSet))
+
+	writes := func(s *kvrwset.HashedRWSet) map[string]string {
+		m := map[string]string{}
+		for _, w := range s.HashedWrites {
+			m[hex.EncodeToString(w.KeyHash)] = writeValue(w.IsDelete, w.ValueHash, hex.EncodeToString)
+		}
+		return m
+	}
+	c.compareEntries("rwset.hashed_writes", writes(ref), writes(hashedRWSet))
+
+	metadataWrites := func(s *kvrwset.HashedRWSet) map[string]string {
+		m := map[string]string{}
+		for _, w := range s.MetadataWrites {
+			m[hex.EncodeToString(w.KeyHash)] = metadata(w.Entries)
+		}
+		return m
+	}
+	c.compareEntries("rwset.metadata_writes", metadataWrites(ref), metadataWrites(hashedRWSet))
+}
+
+// compareEntries compares the entries of the rwsets by key, in the order of the keys
+func (c *comparison) compareEntries(field string, ref, entries map[string]string) {
+	for _, key := range union(ref, entries) {
+		refValue, ok := ref[key]
+		if !ok {
+			refValue = Absent
+		}
+		value, ok := entries[key]
+		if !ok {
+			value = Absent
+		}
+		c.in("", "", key).add(field, refValue, value)
+	}
+}
+
+func namespaces(txRWSet *rwsetutil.TxRwSet) map[string]*rwsetutil.NsRwSet {
+	m := map[string]*rwsetutil.NsRwSet{}
+	if txRWSet == nil {
+		return m
+	}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		m[nsRWSet.NameSpace] = nsRWSet
+	}
+	return m
+}
+
+func collections(nsRWSet *rwsetutil.NsRwSet) map[string]*rwsetutil.CollHashedRwSet {
+	m := map[string]*rwsetutil.CollHashedRwSet{}
+	for _, collRWSet := range nsRWSet.CollHashedRwSets {
+		m[collRWSet.CollectionName] = collRWSet
+	}
+	return m
+}
+
+// union returns the sorted keys of the given maps, whose keys are strings
+func union(maps ...interface{}) []string {
+	set := map[string]struct{}{}
+	for _, m := range maps {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			set[k.String()] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func present(ok bool) string {
+	if ok {
+		return "<present>"
+	}
+	return Absent
+}
+
+func chaincodeID(id *pb.ChaincodeID) string {
+	if id == nil {
+		return Absent
+	}
+	return fmt.Sprintf("%s:%s", id.Name, id.Version)
+}
+
+func versionString(v *kvrwset.Version) string {
+	if v == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%d:%d", v.BlockNum, v.TxNum)
+}
+
+func writeValue(isDelete bool, value []byte, format func([]byte) string) string {
+	if isDelete {
+		return "<deleted>"
+	}
+	return format(value)
+}
+
+func metadata(entries []*kvrwset.KVMetadataEntry) string {
+	var s []string
+	for _, e := range entries {
+		s = append(s, fmt.Sprintf("%s=%s", e.Name, printable(e.Value)))
+	}
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// printable returns the quoted value when it is printable text, or its hex
+// encoding otherwise
+func printable(value []byte) string {
+	if utf8.Valid(value) && strings.IndexFunc(string(value), func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return strconv.Quote(string(value))
+	}
+	return "0x" + hex.EncodeToString(value)
+}
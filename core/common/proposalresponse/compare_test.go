@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package proposalresponse
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func proposalResponse(t *testing.T, build func(*rwsetutil.RWSetBuilder), event *pb.ChaincodeEvent, payload string) *pb.ProposalResponse {
+	b := rwsetutil.NewRWSetBuilder()
+	build(b)
+	simRes, err := b.GetTxSimulationResults()
+	require.NoError(t, err)
+	results, err := simRes.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	var events []byte
+	if event != nil {
+		events = putils.MarshalOrPanic(event)
+	}
+	action := &pb.ChaincodeAction{
+		Results:     results,
+		Events:      events,
+		Response:    &pb.Response{Status: 200, Payload: []byte(payload)},
+		ChaincodeId: &pb.ChaincodeID{Name: "mycc", Version: "1.0"},
+	}
+	return &pb.ProposalResponse{
+		Response: &pb.Response{Status: 200},
+		Payload: putils.MarshalOrPanic(&pb.ProposalResponsePayload{
+			ProposalHash: []byte("hash"),
+			Extension:    putils.MarshalOrPanic(action),
+		}),
+	}
+}
+
+func TestCompareMatchingResponses(t *testing.T) {
+	build := func(b *rwsetutil.RWSetBuilder) {
+		b.AddToReadSet("mycc", "a", version.NewHeight(1, 0))
+		b.AddToWriteSet("mycc", "b", []byte("value"))
+		b.AddToPvtAndHashedWriteSet("mycc", "coll", "c", []byte("secret"))
+	}
+	event := &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx", EventName: "moved", Payload: []byte("a")}
+
+	diffs, err := Compare(
+		proposalResponse(t, build, event, "ok"),
+		proposalResponse(t, build, event, "ok"),
+		proposalResponse(t, build, event, "ok"),
+	)
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	diffs, err = Compare()
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+}
+
+func TestCompareRWSets(t *testing.T) {
+	ref := proposalResponse(t, func(b *rwsetutil.RWSetBuilder) {
+		b.AddToReadSet("mycc", "a", version.NewHeight(1, 0))
+		b.AddToWriteSet("mycc", "b", []byte("value"))
+		b.AddToWriteSet("mycc", "d", []byte("value"))
+		b.AddToPvtAndHashedWriteSet("mycc", "coll", "c", []byte("secret"))
+		b.AddToReadSet("lscc", "mycc", version.NewHeight(1, 0))
+	}, nil, "ok")
+	resp := proposalResponse(t, func(b *rwsetutil.RWSetBuilder) {
+		b.AddToReadSet("mycc", "a", version.NewHeight(2, 3))
+		b.AddToWriteSet("mycc", "b", []byte{0xff})
+		b.AddToWriteSet("mycc", "d", nil)
+		b.AddToWriteSet("mycc", "e", []byte("new"))
+		b.AddToPvtAndHashedWriteSet("mycc", "coll", "c", []byte("other secret"))
+		b.AddToWriteSet("othercc", "x", []byte("y"))
+	}, nil, "ok")
+
+	diffs, err := Compare(ref, resp)
+	require.NoError(t, err)
+	keyHash := hex.EncodeToString(util.ComputeStringHash("c"))
+	assert.Equal(t, []Difference{
+		{Response: 1, Field: "rwset", Namespace: "lscc", Reference: "<present>", Value: Absent},
+		{Response: 1, Field: "rwset.reads", Namespace: "mycc", Key: "a", Reference: "1:0", Value: "2:3"},
+		{Response: 1, Field: "rwset.writes", Namespace: "mycc", Key: "b", Reference: `"value"`, Value: "0xff"},
+		{Response: 1, Field: "rwset.writes", Namespace: "mycc", Key: "d", Reference: `"value"`, Value: "<deleted>"},
+		{Response: 1, Field: "rwset.writes", Namespace: "mycc", Key: "e", Reference: Absent, Value: `"new"`},
+		{Response: 1, Field: "rwset.hashed_writes", Namespace: "mycc", Collection: "coll", Key: keyHash,
+			Reference: hex.EncodeToString(util.ComputeHash([]byte("secret"))), Value: hex.EncodeToString(util.ComputeHash([]byte("other secret")))},
+		diffs[6],
+		{Response: 1, Field: "rwset", Namespace: "othercc", Reference: Absent, Value: "<present>"},
+	}, diffs)
+	assert.Equal(t, "pvt_rwset_hash", diffs[6].Field)
+	assert.Equal(t, "coll", diffs[6].Collection)
+
+	assert.Equal(t, `rwset.reads of namespace mycc, key "a": 1:0 != 2:3`, diffs[1].String())
+	assert.Equal(t, `rwset of namespace lscc: <present> != <absent>`, diffs[0].String())
+}
+
+func TestCompareResponsesAndEvents(t *testing.T) {
+	build := func(b *rwsetutil.RWSetBuilder) {
+		b.AddToWriteSet("mycc", "a", []byte("1"))
+	}
+	ref := proposalResponse(t, build, &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx", EventName: "moved", Payload: []byte("a")}, "ok")
+	sameEvent := proposalResponse(t, build, &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx", EventName: "moved", Payload: []byte("a")}, "ok")
+	otherEvent := proposalResponse(t, build, &pb.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx", EventName: "moved", Payload: []byte("b")}, "ko")
+	noEvent := proposalResponse(t, build, nil, "ok")
+	failed := &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "chaincode failed"}}
+
+	diffs, err := Compare(ref, sameEvent, otherEvent, noEvent, failed)
+	require.NoError(t, err)
+	assert.Equal(t, []Difference{
+		{Response: 2, Field: "action.response.payload", Reference: `"ok"`, Value: `"ko"`},
+		{Response: 2, Field: "event.payload", Reference: `"a"`, Value: `"b"`},
+		{Response: 3, Field: "event", Reference: "<present>", Value: Absent},
+		{Response: 4, Field: "response.status", Reference: "200", Value: "500"},
+		{Response: 4, Field: "response.message", Reference: `""`, Value: `"chaincode failed"`},
+		{Response: 4, Field: "payload", Reference: "<present>", Value: Absent},
+	}, diffs)
+	assert.Equal(t, `response.status: 200 != 500`, diffs[3].String())
+}
+
+func TestCompareBadResponses(t *testing.T) {
+	ok := proposalResponse(t, func(b *rwsetutil.RWSetBuilder) {}, nil, "ok")
+
+	_, err := Compare(ok, nil)
+	assert.EqualError(t, err, "failed to parse proposal response 1: nil proposal response")
+
+	_, err = Compare(&pb.ProposalResponse{Payload: []byte("garbage")}, ok)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse proposal response 0: error unmarshaling ProposalResponsePayload")
+
+	bad := &pb.ProposalResponse{Payload: putils.MarshalOrPanic(&pb.ProposalResponsePayload{
+		Extension: putils.MarshalOrPanic(&pb.ChaincodeAction{Results: []byte("garbage")}),
+	})}
+	_, err = Compare(ok, bad)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse proposal response 1: error unmarshaling TxReadWriteSet")
+}
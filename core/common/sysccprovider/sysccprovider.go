@@ -38,6 +38,12 @@ type SystemChaincodeProvider interface {
 	// is a system chaincode and is not invokable through a proposal
 	IsSysCCAndNotInvokableExternal(name string) bool
 
+	// IsSysCCAndRequiresEndorsementPolicy returns true if the supplied
+	// chaincode is a system chaincode whose namespace may only be written
+	// by the transactions satisfying the endorsement policy defined for it
+	// by the channel config
+	IsSysCCAndRequiresEndorsementPolicy(name string) bool
+
 	// GetQueryExecutorForLedger returns a query executor for the
 	// ledger of the supplied channel.
 	// That's useful for system chaincodes that require unfettered
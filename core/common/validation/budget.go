@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// MeasureUsage measures the state reads, the state writes and the bytes produced by
+// the simulation of a proposal, from its public read-write set, its chaincode event
+// and its chaincode response. The endorsers and the committers measure the same
+// usage of a transaction, as it only depends on the content of the transaction. The
+// simulation time is not measured
+func MeasureUsage(results, events []byte, response *pb.Response) (*pb.ResourceUsage, error) {
+	usage := &pb.ResourceUsage{
+		BytesProduced: uint64(len(results) + len(events) + proto.Size(response)),
+	}
+
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(results); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the read-write set")
+	}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		kvRWSet := nsRWSet.KvRwSet
+		usage.StateReads += uint64(len(kvRWSet.Reads))
+		for _, rqi := range kvRWSet.RangeQueriesInfo {
+			usage.StateReads += rangeQueryReads(rqi)
+		}
+		// a range delete is a single write, the keys of the range being deleted at commit time
+		usage.StateWrites += uint64(len(kvRWSet.Writes) + len(kvRWSet.MetadataWrites) + len(kvRWSet.Increments) + len(kvRWSet.RangeDeletes))
+		for _, collRWSet := range nsRWSet.CollHashedRwSets {
+			hashedRWSet := collRWSet.HashedRwSet
+			usage.StateReads += uint64(len(hashedRWSet.HashedReads))
+			usage.StateWrites += uint64(len(hashedRWSet.HashedWrites) + len(hashedRWSet.MetadataWrites))
+		}
+	}
+	return usage, nil
+}
+
+// rangeQueryReads returns the number of keys read by the given range query. The reads
+// summarized by merkle hashes are counted by the least number of reads the hashes may
+// summarize, as each hash but the last one of the top level summarizes a full subtree
+func rangeQueryReads(rqi *kvrwset.RangeQueryInfo) uint64 {
+	if rawReads := rqi.GetRawReads(); rawReads != nil {
+		return uint64(len(rawReads.KvReads))
+	}
+	summary := rqi.GetReadsMerkleHashes()
+	if summary == nil || len(summary.MaxLevelHashes) == 0 {
+		return 0
+	}
+	subtree := uint64(1)
+	for i := uint32(0); i < summary.MaxLevel; i++ {
+		subtree *= uint64(summary.MaxDegree)
+	}
+	return uint64(len(summary.MaxLevelHashes)-1)*subtree + 1
+}
+
+// CheckResourceBudget checks that the given usage does not exceed the given budget, the
+// limits of the budget which are zero being unlimited. The simulation time is only
+// checked if the usage holds it, which is the case on the endorsers
+func CheckResourceBudget(budget *pb.ResourceBudget, usage *pb.ResourceUsage) error {
+	if budget == nil {
+		return nil
+	}
+	if budget.MaxSimulationTimeMs > 0 && usage.SimulationTimeUs > budget.MaxSimulationTimeMs*1000 {
+		return errors.Errorf("the simulation took %s, exceeding the budget of %s",
+			time.Duration(usage.SimulationTimeUs)*time.Microsecond, time.Duration(budget.MaxSimulationTimeMs)*time.Millisecond)
+	}
+	if budget.MaxStateReads > 0 && usage.StateReads > budget.MaxStateReads {
+		return errors.Errorf("the simulation read %d keys, exceeding the budget of %d reads", usage.StateReads, budget.MaxStateReads)
+	}
+	if budget.MaxStateWrites > 0 && usage.StateWrites > budget.MaxStateWrites {
+		return errors.Errorf("the simulation wrote %d keys, exceeding the budget of %d writes", usage.StateWrites, budget.MaxStateWrites)
+	}
+	if budget.MaxBytesProduced > 0 && usage.BytesProduced > budget.MaxBytesProduced {
+		return errors.Errorf("the simulation produced %d bytes, exceeding the budget of %d bytes", usage.BytesProduced, budget.MaxBytesProduced)
+	}
+	return nil
+}
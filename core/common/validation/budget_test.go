@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureUsage(t *testing.T) {
+	txRWSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: "mycc",
+				KvRwSet: &kvrwset.KVRWSet{
+					Reads: []*kvrwset.KVRead{{Key: "a"}, {Key: "b"}},
+					RangeQueriesInfo: []*kvrwset.RangeQueryInfo{
+						{
+							StartKey: "c",
+							EndKey:   "f",
+							ReadsInfo: &kvrwset.RangeQueryInfo_RawReads{
+								RawReads: &kvrwset.QueryReads{KvReads: []*kvrwset.KVRead{{Key: "c"}, {Key: "d"}, {Key: "e"}}},
+							},
+						},
+						{
+							StartKey: "g",
+							ReadsInfo: &kvrwset.RangeQueryInfo_ReadsMerkleHashes{
+								ReadsMerkleHashes: &kvrwset.QueryReadsMerkleSummary{
+									MaxDegree:      4,
+									MaxLevel:       2,
+									MaxLevelHashes: [][]byte{[]byte("h1"), []byte("h2"), []byte("h3")},
+								},
+							},
+						},
+					},
+					Writes:         []*kvrwset.KVWrite{{Key: "a", Value: []byte("1")}},
+					MetadataWrites: []*kvrwset.KVMetadataWrite{{Key: "b"}},
+					Increments:     []*kvrwset.KVIncrement{{Key: "counter"}},
+				},
+				CollHashedRwSets: []*rwsetutil.CollHashedRwSet{
+					{
+						CollectionName: "coll",
+						HashedRwSet: &kvrwset.HashedRWSet{
+							HashedReads:  []*kvrwset.KVReadHash{{KeyHash: []byte("x")}},
+							HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: []byte("x")}, {KeyHash: []byte("y")}},
+						},
+					},
+				},
+			},
+		},
+	}
+	results, err := txRWSet.ToProtoBytes()
+	assert.NoError(t, err)
+	events := []byte("event")
+	response := &pb.Response{Status: 200, Payload: []byte("payload")}
+
+	usage, err := MeasureUsage(results, events, response)
+	assert.NoError(t, err)
+	// 2 reads, 3 raw range query reads, at least 2*4^2+1 summarized reads and 1 hashed read
+	assert.Equal(t, uint64(39), usage.StateReads)
+	assert.Equal(t, uint64(5), usage.StateWrites)
+	assert.Equal(t, uint64(len(results)+len(events)+proto.Size(response)), usage.BytesProduced)
+	assert.Zero(t, usage.SimulationTimeUs)
+
+	usage, err = MeasureUsage(nil, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.ResourceUsage{}, usage)
+
+	_, err = MeasureUsage([]byte("garbage"), nil, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unmarshal the read-write set")
+}
+
+func TestCheckResourceBudget(t *testing.T) {
+	usage := &pb.ResourceUsage{SimulationTimeUs: 1500, StateReads: 10, StateWrites: 5, BytesProduced: 1000}
+
+	tests := []struct {
+		name          string
+		budget        *pb.ResourceBudget
+		usage         *pb.ResourceUsage
+		expectedError string
+	}{
+		{name: "no budget", usage: usage},
+		{name: "unlimited", budget: &pb.ResourceBudget{}, usage: usage},
+		{name: "within the budget", budget: &pb.ResourceBudget{MaxSimulationTimeMs: 2, MaxStateReads: 10, MaxStateWrites: 5, MaxBytesProduced: 1000}, usage: usage},
+		{name: "too long", budget: &pb.ResourceBudget{MaxSimulationTimeMs: 1}, usage: usage, expectedError: "the simulation took 1.5ms, exceeding the budget of 1ms"},
+		{name: "time not measured", budget: &pb.ResourceBudget{MaxSimulationTimeMs: 1}, usage: &pb.ResourceUsage{StateReads: 10}},
+		{name: "too many reads", budget: &pb.ResourceBudget{MaxStateReads: 9}, usage: usage, expectedError: "the simulation read 10 keys, exceeding the budget of 9 reads"},
+		{name: "too many writes", budget: &pb.ResourceBudget{MaxStateWrites: 4}, usage: usage, expectedError: "the simulation wrote 5 keys, exceeding the budget of 4 writes"},
+		{name: "too many bytes", budget: &pb.ResourceBudget{MaxBytesProduced: 999}, usage: usage, expectedError: "the simulation produced 1000 bytes, exceeding the budget of 999 bytes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResourceBudget(tt.budget, tt.usage)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
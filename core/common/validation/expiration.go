@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// CheckExpiration checks that the transaction with the given channel header
+// has not expired in the block with the given number and, unless now is zero,
+// at the given time. The committers only check the expiration height, as the
+// peers do not share a clock
+func CheckExpiration(chdr *common.ChannelHeader, blockNumber uint64, now time.Time) error {
+	if chdr.ExpirationHeight > 0 && blockNumber >= chdr.ExpirationHeight {
+		return errors.Errorf("transaction %s expired at block %d", chdr.TxId, chdr.ExpirationHeight)
+	}
+
+	if now.IsZero() || chdr.ExpirationTime == nil {
+		return nil
+	}
+	expirationTime, err := ptypes.Timestamp(chdr.ExpirationTime)
+	if err != nil {
+		return errors.Wrapf(err, "invalid expiration time of transaction %s", chdr.TxId)
+	}
+	if !now.Before(expirationTime) {
+		return errors.Errorf("transaction %s expired at %s", chdr.TxId, expirationTime.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
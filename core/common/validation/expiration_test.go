@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckExpiration(t *testing.T) {
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	expirationTime, err := ptypes.TimestampProto(now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		chdr          *common.ChannelHeader
+		blockNumber   uint64
+		now           time.Time
+		expectedError string
+	}{
+		{name: "no expiration", chdr: &common.ChannelHeader{}, blockNumber: 100, now: now},
+		{name: "before the expiration height", chdr: &common.ChannelHeader{ExpirationHeight: 10}, blockNumber: 9, now: now},
+		{name: "at the expiration height", chdr: &common.ChannelHeader{TxId: "tx", ExpirationHeight: 10}, blockNumber: 10, now: now, expectedError: "transaction tx expired at block 10"},
+		{name: "beyond the expiration height", chdr: &common.ChannelHeader{TxId: "tx", ExpirationHeight: 10}, blockNumber: 11, expectedError: "transaction tx expired at block 10"},
+		{name: "before the expiration time", chdr: &common.ChannelHeader{ExpirationTime: expirationTime}, blockNumber: 9, now: now},
+		{name: "at the expiration time", chdr: &common.ChannelHeader{TxId: "tx", ExpirationTime: expirationTime}, now: now.Add(time.Minute), expectedError: "transaction tx expired at 2019-03-01T12:01:00Z"},
+		{name: "time not checked", chdr: &common.ChannelHeader{ExpirationTime: expirationTime}, blockNumber: 9},
+		{name: "invalid expiration time", chdr: &common.ChannelHeader{TxId: "tx", ExpirationTime: &timestamp.Timestamp{Nanos: -1}}, now: now, expectedError: "invalid expiration time of transaction tx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckExpiration(tt.chdr, tt.blockNumber, tt.now)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
@@ -192,6 +192,43 @@ func TestGoodPath(t *testing.T) {
 	}
 }
 
+func TestScheduledTransaction(t *testing.T) {
+	prop, err := getProposal(util.GetTestChainID())
+	assert.NoError(t, err)
+	assert.NoError(t, utils.SetProposalSchedule(prop, 10, time.Time{}))
+
+	sProp, err := utils.GetSignedProposal(prop, signer)
+	assert.NoError(t, err)
+	_, _, _, err = ValidateProposalMessage(sProp)
+	assert.NoError(t, err)
+
+	presp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, &peer.Response{Status: 200}, []byte("simulation_result"), nil, getChaincodeID(), nil, signer)
+	assert.NoError(t, err)
+
+	// the envelope holds the signature of the proposal
+	tx, err := utils.CreateScheduledTx(sProp, presp)
+	assert.NoError(t, err)
+	_, txResult := ValidateTransaction(tx, &config.MockApplicationCapabilities{ScheduledTransactionsRv: true})
+	assert.Equal(t, peer.TxValidationCode_VALID, txResult)
+
+	// which only the capability allows
+	_, txResult = ValidateTransaction(tx, &config.MockApplicationCapabilities{})
+	assert.Equal(t, peer.TxValidationCode_BAD_CREATOR_SIGNATURE, txResult)
+
+	// the transaction of another proposal does not match the signature
+	cpp, err := utils.GetChaincodeProposalPayload(prop.Payload)
+	assert.NoError(t, err)
+	cpp.Input = []byte("another input")
+	prop.Payload, err = utils.GetBytesChaincodeProposalPayload(cpp)
+	assert.NoError(t, err)
+	propBytes, err := utils.GetBytesProposal(prop)
+	assert.NoError(t, err)
+	tx, err = utils.CreateScheduledTx(&peer.SignedProposal{ProposalBytes: propBytes, Signature: sProp.Signature}, presp)
+	assert.NoError(t, err)
+	_, txResult = ValidateTransaction(tx, &config.MockApplicationCapabilities{ScheduledTransactionsRv: true})
+	assert.Equal(t, peer.TxValidationCode_BAD_CREATOR_SIGNATURE, txResult)
+}
+
 func TestTXWithTwoActionsRejected(t *testing.T) {
 	// get a toy proposal
 	prop, err := getProposal(util.GetTestChainID())
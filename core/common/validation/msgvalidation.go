@@ -396,8 +396,17 @@ func ValidateTransaction(e *common.Envelope, c channelconfig.ApplicationCapabili
 		return nil, pb.TxValidationCode_BAD_COMMON_HEADER
 	}
 
-	// validate the signature in the envelope
-	err = checkSignatureFromCreator(shdr.Creator, e.Signature, e.Payload, chdr.ChannelId)
+	// validate the signature in the envelope, which is over the proposal
+	// for the scheduled transactions
+	signedBytes := e.Payload
+	if c.ScheduledTransactions() && common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION && utils.IsScheduled(chdr) {
+		signedBytes, err = utils.GetScheduledProposalBytes(payload)
+		if err != nil {
+			putilsLogger.Errorf("GetScheduledProposalBytes returns err %s", err)
+			return nil, pb.TxValidationCode_BAD_PAYLOAD
+		}
+	}
+	err = checkSignatureFromCreator(shdr.Creator, e.Signature, signedBytes, chdr.ChannelId)
 	if err != nil {
 		putilsLogger.Errorf("checkSignatureFromCreator returns err %s", err)
 		return nil, pb.TxValidationCode_BAD_CREATOR_SIGNATURE
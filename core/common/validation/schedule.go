@@ -0,0 +1,37 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// CheckSchedule checks that the transaction with the given channel header is
+// not premature in the block with the given number and, unless now is zero, at
+// the given time. As for the expiration, the committers only check the
+// scheduled height
+func CheckSchedule(chdr *common.ChannelHeader, blockNumber uint64, now time.Time) error {
+	if blockNumber < chdr.ScheduledHeight {
+		return errors.Errorf("transaction %s is scheduled at block %d", chdr.TxId, chdr.ScheduledHeight)
+	}
+
+	if now.IsZero() || chdr.ScheduledTime == nil {
+		return nil
+	}
+	scheduledTime, err := ptypes.Timestamp(chdr.ScheduledTime)
+	if err != nil {
+		return errors.Wrapf(err, "invalid scheduled time of transaction %s", chdr.TxId)
+	}
+	if now.Before(scheduledTime) {
+		return errors.Errorf("transaction %s is scheduled at %s", chdr.TxId, scheduledTime.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckSchedule(t *testing.T) {
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	scheduledTime, err := ptypes.TimestampProto(now.Add(time.Minute))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		chdr          *common.ChannelHeader
+		blockNumber   uint64
+		now           time.Time
+		expectedError string
+	}{
+		{name: "not scheduled", chdr: &common.ChannelHeader{}, blockNumber: 0, now: now},
+		{name: "before the scheduled height", chdr: &common.ChannelHeader{TxId: "tx", ScheduledHeight: 10}, blockNumber: 9, now: now, expectedError: "transaction tx is scheduled at block 10"},
+		{name: "at the scheduled height", chdr: &common.ChannelHeader{ScheduledHeight: 10}, blockNumber: 10, now: now},
+		{name: "beyond the scheduled height", chdr: &common.ChannelHeader{ScheduledHeight: 10}, blockNumber: 11},
+		{name: "before the scheduled time", chdr: &common.ChannelHeader{TxId: "tx", ScheduledTime: scheduledTime}, now: now, expectedError: "transaction tx is scheduled at 2019-03-01T12:01:00Z"},
+		{name: "at the scheduled time", chdr: &common.ChannelHeader{ScheduledTime: scheduledTime}, now: now.Add(time.Minute)},
+		{name: "time not checked", chdr: &common.ChannelHeader{ScheduledTime: scheduledTime}, blockNumber: 9},
+		{name: "invalid scheduled time", chdr: &common.ChannelHeader{TxId: "tx", ScheduledTime: &timestamp.Timestamp{Nanos: -1}}, now: now, expectedError: "invalid scheduled time of transaction tx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckSchedule(tt.chdr, tt.blockNumber, tt.now)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
@@ -242,6 +242,32 @@ func (klv *KeyLevelValidator) Validate(cc string, blockNum, txNum uint64, rwsetB
 				return err
 			}
 		}
+		// public increments
+		// we validate increments against key-level validation parameters
+		// if any are present or the chaincode-wide endorsement policy
+		for _, pubIncrement := range nsRWSet.KvRwSet.Increments {
+			err := policyChecker.checkSBAndCCEP(cc, "", pubIncrement.Key, blockNum, txNum)
+			if err != nil {
+				return err
+			}
+		}
+		// public range deletes
+		// we validate the deletes of the keys of the range against key-level
+		// validation parameters if any are present or the chaincode-wide endorsement policy
+		for _, pubRangeDelete := range nsRWSet.KvRwSet.RangeDeletes {
+			keys, err := klv.vpmgr.GetRangeKeys(cc, pubRangeDelete.StartKey, pubRangeDelete.EndKey)
+			if err != nil {
+				return &commonerrors.VSCCExecutionFailureError{
+					Err: err,
+				}
+			}
+			for _, key := range keys {
+				err := policyChecker.checkSBAndCCEP(cc, "", key, blockNum, txNum)
+				if err != nil {
+					return err
+				}
+			}
+		}
 		// public metadata writes
 		// we validate writes against key-level validation parameters
 		// if any are present or the chaincode-wide endorsement policy
@@ -121,6 +121,93 @@ func TestKeylevelValidation(t *testing.T) {
 	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
 }
 
+func TestKeylevelValidationIncrements(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: we validate a transaction that increments
+	// a key that contains key-level validation params.
+	// We simulate policy check success and failure
+
+	vpMetadataKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	mr := &mockState{GetStateMetadataRv: map[string][]byte{vpMetadataKey: []byte("EP")}}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
+	pe := &mockPolicyEvaluator{}
+	validator := NewKeyLevelValidator(pe, pm)
+
+	rwsbu := rwsetutil.NewRWSetBuilder()
+	assert.NoError(t, rwsbu.AddToIncrementSet("cc", "key", 1))
+	rws := rwsbu.GetTxReadWriteSet()
+	rwsb, err := rws.ToProtoBytes()
+	assert.NoError(t, err)
+	prp := []byte("barf")
+	block := buildBlockWithTxs(buildTXWithRwset(rwsetUpdatingMetadataFor("cc", "key")), buildTXWithRwset(rwsetUpdatingMetadataFor("cc", "key")))
+
+	validator.PreValidate(1, block)
+
+	go func() {
+		validator.PostValidate("cc", 1, 0, fmt.Errorf(""))
+	}()
+
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.NoError(t, err)
+
+	// only the key-level policy fails, which is checked for the increment
+	pe.EvaluateResByPolicy = map[string]error{
+		"EP": fmt.Errorf("policy evaluation error"),
+	}
+
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.Error(t, err)
+	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+}
+
+func TestKeylevelValidationRangeDeletes(t *testing.T) {
+	t.Parallel()
+
+	// Scenario: we validate a transaction that deletes a range
+	// whose keys contain key-level validation params.
+	// We simulate policy check success and failure
+
+	vpMetadataKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	mr := &mockState{GetStateMetadataRv: map[string][]byte{vpMetadataKey: []byte("EP")}, GetStateRangeScanIteratorRv: []string{"key1", "key2"}}
+	ms := &mockStateFetcher{FetchStateRv: mr}
+	pm := &KeyLevelValidationParameterManagerImpl{StateFetcher: ms}
+	pe := &mockPolicyEvaluator{}
+	validator := NewKeyLevelValidator(pe, pm)
+
+	rwsbu := rwsetutil.NewRWSetBuilder()
+	rwsbu.AddToRangeDeleteSet("cc", "key1", "key3", []byte("hash"))
+	rws := rwsbu.GetTxReadWriteSet()
+	rwsb, err := rws.ToProtoBytes()
+	assert.NoError(t, err)
+	prp := []byte("barf")
+	block := buildBlockWithTxs(buildTXWithRwset(rwsetUpdatingMetadataFor("cc", "key1")), buildTXWithRwset(rwsetUpdatingMetadataFor("cc", "key1")))
+
+	validator.PreValidate(1, block)
+
+	go func() {
+		validator.PostValidate("cc", 1, 0, fmt.Errorf(""))
+	}()
+
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.NoError(t, err)
+
+	// only the key-level policy fails, which is checked for the keys of the range
+	pe.EvaluateResByPolicy = map[string]error{
+		"EP": fmt.Errorf("policy evaluation error"),
+	}
+
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.Error(t, err)
+	assert.IsType(t, &errors.VSCCEndorsementPolicyError{}, err)
+
+	// with no key in the range, only the chaincode-level policy is checked
+	mr.GetStateRangeScanIteratorRv = nil
+	err = validator.Validate("cc", 1, 1, rwsb, prp, []byte("CCEP"), []*pb.Endorsement{})
+	assert.NoError(t, err)
+}
+
 func TestKeylevelValidationPvtData(t *testing.T) {
 	t.Parallel()
 
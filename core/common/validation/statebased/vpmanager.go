@@ -48,6 +48,14 @@ type KeyLevelValidationParameterManager interface {
 	// all txes with txNum smaller than the one supplied by the caller.
 	GetValidationParameterForKey(cc, coll, key string, blockNum, txNum uint64) ([]byte, error)
 
+	// GetRangeKeys returns the keys of the range [startKey, endKey) of the public state
+	// of the chaincode cc as of the last committed block. These are the keys deleted by
+	// a range delete of a transaction, since the ledger invalidates the transaction if
+	// the keys of the range have been changed by a preceding transaction of the block.
+	// The scan of the range is subject to the query limits of the peer (see the
+	// ledger.state.queryLimits configuration).
+	GetRangeKeys(cc, startKey, endKey string) ([]string, error)
+
 	// ExtractValidationParameterDependency is used to determine which validation parameters are
 	// updated by transaction at height `blockNum, txNum`. This is needed
 	// to determine which txes have dependencies for specific validation parameters and will
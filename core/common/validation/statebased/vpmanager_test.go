@@ -17,6 +17,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/core/handlers/validation/api/state"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -30,6 +31,7 @@ type mockState struct {
 	GetStateMetadataErr             error
 	GetPrivateDataMetadataByHashRv  map[string][]byte
 	GetPrivateDataMetadataByHashErr error
+	GetStateRangeScanIteratorRv     []string
 	DoneCalled                      bool
 }
 
@@ -38,9 +40,24 @@ func (ms *mockState) GetStateMultipleKeys(namespace string, keys []string) ([][]
 }
 
 func (ms *mockState) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (validation.ResultsIterator, error) {
-	return nil, nil
+	return &mockRangeItr{keys: ms.GetStateRangeScanIteratorRv}, nil
+}
+
+type mockRangeItr struct {
+	keys []string
 }
 
+func (mi *mockRangeItr) Next() (validation.QueryResult, error) {
+	if len(mi.keys) == 0 {
+		return nil, nil
+	}
+	key := mi.keys[0]
+	mi.keys = mi.keys[1:]
+	return &queryresult.KV{Namespace: "cc", Key: key}, nil
+}
+
+func (mi *mockRangeItr) Close() {}
+
 func (ms *mockState) GetStateMetadata(namespace, key string) (map[string][]byte, error) {
 	return ms.GetStateMetadataRv, ms.GetStateMetadataErr
 }
@@ -77,6 +94,7 @@ func (ms *mockStateFetcher) FetchState() (validation.State, error) {
 			GetStateMetadataErr:             ms.FetchStateRv.GetStateMetadataErr,
 			GetPrivateDataMetadataByHashRv:  ms.FetchStateRv.GetPrivateDataMetadataByHashRv,
 			GetStateMetadataRv:              ms.FetchStateRv.GetStateMetadataRv,
+			GetStateRangeScanIteratorRv:     ms.FetchStateRv.GetStateRangeScanIteratorRv,
 		}
 		ms.mutex.Lock()
 		if ms.returnedStates != nil {
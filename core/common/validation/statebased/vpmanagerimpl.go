@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/handlers/validation/api/state"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -329,6 +330,34 @@ func (m *KeyLevelValidationParameterManagerImpl) GetValidationParameterForKey(cc
 	return mdMap[pb.MetaDataKeys_VALIDATION_PARAMETER.String()], nil
 }
 
+// GetRangeKeys implements the method of the same name
+// of the KeyLevelValidationParameterManager interface
+func (m *KeyLevelValidationParameterManagerImpl) GetRangeKeys(cc, startKey, endKey string) ([]string, error) {
+	state, err := m.StateFetcher.FetchState()
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not retrieve ledger")
+	}
+	defer state.Done()
+
+	itr, err := state.GetStateRangeScanIterator(cc, startKey, endKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve the keys of range [%s, %s) of %s", startKey, endKey, cc))
+	}
+	defer itr.Close()
+
+	var keys []string
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve the keys of range [%s, %s) of %s", startKey, endKey, cc))
+		}
+		if res == nil {
+			return keys, nil
+		}
+		keys = append(keys, res.(*queryresult.KV).Key)
+	}
+}
+
 // SetTxValidationCode implements the method of the same name of
 // the KeyLevelValidationParameterManager interface. Note that
 // this function receives a namespace argument so that it records
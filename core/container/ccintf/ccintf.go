@@ -37,6 +37,10 @@ func GetCCHandlerKey() string {
 type CCID struct {
 	Name    string
 	Version string
+	// PackageID identifies the code package the chaincode is built from, if
+	// known. The chaincode versions built from the same package, on any
+	// channel, share the image of the package
+	PackageID string
 }
 
 //GetName returns canonical chaincode name based on the fields of CCID
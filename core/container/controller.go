@@ -134,15 +134,24 @@ type PlatformBuilder struct {
 	Version          string
 	CodePackage      []byte
 	PlatformRegistry *platforms.Registry
+	// PackageID identifies the code package, if known. The build context of a
+	// package is then labeled with the package ID instead of the name and the
+	// version of the chaincode, so that it is the same for all the chaincode
+	// versions built from the package
+	PackageID string
 }
 
 // Build a tar stream based on the CDS
 func (b *PlatformBuilder) Build() (io.Reader, error) {
+	name, version := b.Name, b.Version
+	if b.PackageID != "" {
+		name, version = b.PackageID, ""
+	}
 	return b.PlatformRegistry.GenerateDockerBuild(
 		b.Type,
 		b.Path,
-		b.Name,
-		b.Version,
+		name,
+		version,
 		b.CodePackage,
 	)
 }
@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// BuildLog is the output of the build of the image of a chaincode package
+type BuildLog struct {
+	PackageID string    `json:"package_id"`
+	Time      time.Time `json:"time"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+	Output    string    `json:"output,omitempty"`
+
+	file string
+}
+
+// BuildLogStore keeps the logs of the last builds of the chaincode images in
+// a directory, so that the output of the builds which failed can be retrieved
+// after the fact, including after a restart of the peer
+type BuildLogStore struct {
+	dir string
+	max int
+
+	mutex sync.Mutex
+	seq   int64
+	logs  []*BuildLog // oldest first
+	now   func() time.Time
+}
+
+// NewBuildLogStore returns a store keeping the last max build logs in the
+// directory, which it creates if needed, and loads the logs already in it
+func NewBuildLogStore(dir string, max int) (*BuildLogStore, error) {
+	if max <= 0 {
+		return nil, errors.Errorf("invalid number of build logs: %d", max)
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, errors.Wrapf(err, "failed to create the build log directory %s", dir)
+	}
+	s := &BuildLogStore{dir: dir, max: max, now: time.Now}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	s.prune()
+	return s, nil
+}
+
+func (s *BuildLogStore) load() error {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read the build log directory %s", s.dir)
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		var seq int64
+		if _, err := fmt.Sscanf(f.Name(), "%d.json", &seq); err != nil {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(s.dir, f.Name()))
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the build log %s", f.Name())
+		}
+		log := &BuildLog{}
+		if err := json.Unmarshal(content, log); err != nil {
+			dockerLogger.Warningf("Ignoring the corrupted build log %s: %s", f.Name(), err)
+			continue
+		}
+		log.file = f.Name()
+		s.logs = append(s.logs, log)
+		if seq > s.seq {
+			s.seq = seq
+		}
+	}
+	sort.SliceStable(s.logs, func(i, j int) bool { return s.logs[i].file < s.logs[j].file })
+	return nil
+}
+
+// prune removes the oldest logs beyond the maximum
+func (s *BuildLogStore) prune() {
+	for len(s.logs) > s.max {
+		if err := os.Remove(filepath.Join(s.dir, s.logs[0].file)); err != nil && !os.IsNotExist(err) {
+			dockerLogger.Warningf("Failed removing the build log %s: %s", s.logs[0].file, err)
+		}
+		s.logs = s.logs[1:]
+	}
+}
+
+// Record stores the output of a build of the image of a chaincode package. A
+// nil store records nothing, and the failure to persist a log is only logged
+// as it must not fail the build
+func (s *BuildLogStore) Record(packageID, output string, buildErr error) {
+	if s == nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.seq++
+	log := &BuildLog{
+		PackageID: packageID,
+		Time:      s.now().UTC(),
+		Success:   buildErr == nil,
+		Output:    output,
+		// files are named after a zero padded sequence so that their names
+		// sort in the order of the builds
+		file: fmt.Sprintf("%020d.json", s.seq),
+	}
+	if buildErr != nil {
+		log.Error = buildErr.Error()
+	}
+
+	content, err := json.Marshal(log)
+	if err == nil {
+		err = ioutil.WriteFile(filepath.Join(s.dir, log.file), content, 0640)
+	}
+	if err != nil {
+		dockerLogger.Warningf("Failed persisting the build log of %s: %s", packageID, err)
+	}
+
+	s.logs = append(s.logs, log)
+	s.prune()
+}
+
+// Logs returns the stored build logs, the most recent first
+func (s *BuildLogStore) Logs() []BuildLog {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	logs := make([]BuildLog, 0, len(s.logs))
+	for i := len(s.logs) - 1; i >= 0; i-- {
+		logs = append(logs, *s.logs[i])
+	}
+	return logs
+}
+
+// Latest returns the most recent build log of a chaincode package
+func (s *BuildLogStore) Latest(packageID string) (BuildLog, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for i := len(s.logs) - 1; i >= 0; i-- {
+		if s.logs[i].PackageID == packageID {
+			return *s.logs[i], true
+		}
+	}
+	return BuildLog{}, false
+}
+
+// ServeHTTP lists the stored build logs without their output in JSON, the most
+// recent first. With the package_id query parameter, it returns the most recent
+// build log of the chaincode package, output included
+func (s *BuildLogStore) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(resp, fmt.Sprintf("invalid request method: %s", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body interface{}
+	if packageID := strings.TrimSpace(req.URL.Query().Get("package_id")); packageID != "" {
+		log, ok := s.Latest(packageID)
+		if !ok {
+			http.Error(resp, fmt.Sprintf("no build log for package %s", packageID), http.StatusNotFound)
+			return
+		}
+		body = log
+	} else {
+		logs := s.Logs()
+		for i := range logs {
+			logs[i].Output = ""
+		}
+		body = logs
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(body); err != nil {
+		dockerLogger.Errorf("failed to encode the build logs: %s", err)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLogStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildlogs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = NewBuildLogStore(dir, 0)
+	assert.EqualError(t, err, "invalid number of build logs: 0")
+
+	store, err := NewBuildLogStore(dir, 2)
+	require.NoError(t, err)
+
+	store.Record("mycc:1.0", "Step 1/2", errors.New("build failed"))
+	store.Record("mycc:1.1", "Step 1/2\nStep 2/2", nil)
+	store.Record("mycc:1.0", "Step 1/2\nStep 2/2", nil)
+
+	logs := store.Logs()
+	require.Len(t, logs, 2)
+	assert.Equal(t, "mycc:1.0", logs[0].PackageID)
+	assert.True(t, logs[0].Success)
+	assert.Equal(t, "mycc:1.1", logs[1].PackageID)
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	// the logs survive a restart, and the retention applies to them
+	ioutil.WriteFile(filepath.Join(dir, "corrupted.json"), []byte("{"), 0640)
+	ioutil.WriteFile(filepath.Join(dir, "00000000000000000000.json"), []byte("{"), 0640)
+	store, err = NewBuildLogStore(dir, 1)
+	require.NoError(t, err)
+	logs = store.Logs()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "mycc:1.0", logs[0].PackageID)
+	assert.Equal(t, "Step 1/2\nStep 2/2", logs[0].Output)
+
+	store.Record("othercc:1.0", "Step 1/2", errors.New("build failed"))
+	log, ok := store.Latest("othercc:1.0")
+	assert.True(t, ok)
+	assert.False(t, log.Success)
+	assert.Equal(t, "build failed", log.Error)
+	_, ok = store.Latest("mycc:1.0")
+	assert.False(t, ok)
+
+	// a nil store records nothing
+	var nilStore *BuildLogStore
+	nilStore.Record("mycc:1.0", "", nil)
+}
+
+func TestBuildLogStoreServeHTTP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildlogs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewBuildLogStore(dir, 10)
+	require.NoError(t, err)
+	store.Record("mycc:1.0", "go: cannot find main module", errors.New("Error returned from build: 1"))
+
+	rec := httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/buildlogs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var logs []BuildLog
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &logs))
+	require.Len(t, logs, 1)
+	assert.Equal(t, "mycc:1.0", logs[0].PackageID)
+	assert.Equal(t, "Error returned from build: 1", logs[0].Error)
+	assert.Empty(t, logs[0].Output)
+
+	rec = httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/buildlogs?package_id=mycc:1.0", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var log BuildLog
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &log))
+	assert.Equal(t, "go: cannot find main module", log.Output)
+
+	rec = httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/buildlogs?package_id=mycc:2.0", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "no build log for package mycc:2.0")
+
+	rec = httptest.NewRecorder()
+	store.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/buildlogs", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestDeployImageRecordsBuildLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildlogs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewBuildLogStore(dir, 10)
+	require.NoError(t, err)
+	dvm := DockerVM{
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+		BuildLogs:    store,
+	}
+
+	buildErr = true
+	defer func() { buildErr = false }()
+	err = dvm.deployImage(&mockClient{}, ccintf.CCID{Name: "mycc", Version: "1.0"}, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	log, ok := store.Latest("mycc:1.0")
+	require.True(t, ok)
+	assert.False(t, log.Success)
+	assert.Equal(t, "Error building image", log.Error)
+}
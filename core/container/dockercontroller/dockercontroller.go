@@ -18,6 +18,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	docker "github.com/fsouza/go-dockerclient"
@@ -51,6 +52,8 @@ type DockerVM struct {
 	PeerID       string
 	NetworkID    string
 	BuildMetrics *BuildMetrics
+	LogSink      LogSink
+	BuildLogs    *BuildLogStore
 }
 
 // dockerClient represents a docker client
@@ -71,6 +74,9 @@ type dockerClient interface {
 	// RemoveImageExtended removes a docker image by its name or ID, returns an
 	// error in case of failure
 	RemoveImageExtended(id string, opts docker.RemoveImageOptions) error
+	// TagImage adds a tag to the image identified by the given name, returns an
+	// error in case of failure
+	TagImage(name string, opts docker.TagImageOptions) error
 	// StopContainer stops a docker container, killing it after the given timeout
 	// (in seconds). Returns an error in case of failure
 	StopContainer(id string, timeout uint) error
@@ -85,6 +91,12 @@ type dockerClient interface {
 	// WaitContainer blocks until the given container stops, and returns the exit
 	// code of the container status.
 	WaitContainer(containerID string) (int, error)
+	// ListImages returns the docker images matching the filters of the options,
+	// returns an error in case of failure
+	ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error)
+	// ListContainers returns the docker containers matching the filters of the
+	// options, returns an error in case of failure
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
 }
 
 // Provider implements container.VMProvider
@@ -92,6 +104,10 @@ type Provider struct {
 	PeerID       string
 	NetworkID    string
 	BuildMetrics *BuildMetrics
+	// LogSink, if set, receives the output of the chaincode containers
+	LogSink LogSink
+	// BuildLogs, if set, records the output of the builds of the chaincode images
+	BuildLogs *BuildLogStore
 }
 
 // NewProvider creates a new instance of Provider
@@ -105,7 +121,10 @@ func NewProvider(peerID, networkID string, metricsProvider metrics.Provider) *Pr
 
 // NewVM creates a new DockerVM instance
 func (p *Provider) NewVM() container.VM {
-	return NewDockerVM(p.PeerID, p.NetworkID, p.BuildMetrics)
+	vm := NewDockerVM(p.PeerID, p.NetworkID, p.BuildMetrics)
+	vm.LogSink = p.LogSink
+	vm.BuildLogs = p.BuildLogs
+	return vm
 }
 
 // NewDockerVM returns a new DockerVM instance
@@ -171,19 +190,26 @@ func getDockerHostConfig() *docker.HostConfig {
 	}
 }
 
-func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID string, args, env []string, attachStdout bool) error {
+func (vm *DockerVM) createContainer(client dockerClient, ccid ccintf.CCID, imageID, containerID string, args, env []string, attachStdout bool, sb *sandbox, filesToUpload map[string][]byte) error {
 	logger := dockerLogger.With("imageID", imageID, "containerID", containerID)
 	logger.Debugw("create container")
+	config := &docker.Config{
+		Cmd:          args,
+		Image:        imageID,
+		Env:          env,
+		AttachStdout: attachStdout,
+		AttachStderr: attachStdout,
+		Labels:       vm.labels(ccid),
+	}
+	hostConfig := getDockerHostConfig()
+	if sb != nil {
+		hostConfig = sb.apply(hostConfig)
+		config.Volumes = sb.volumes(filesToUpload)
+	}
 	_, err := client.CreateContainer(docker.CreateContainerOptions{
-		Name: containerID,
-		Config: &docker.Config{
-			Cmd:          args,
-			Image:        imageID,
-			Env:          env,
-			AttachStdout: attachStdout,
-			AttachStderr: attachStdout,
-		},
-		HostConfig: getDockerHostConfig(),
+		Name:       containerID,
+		Config:     config,
+		HostConfig: hostConfig,
 	})
 	if err != nil {
 		return err
@@ -193,7 +219,7 @@ func (vm *DockerVM) createContainer(client dockerClient, imageID, containerID st
 }
 
 func (vm *DockerVM) deployImage(client dockerClient, ccid ccintf.CCID, reader io.Reader) error {
-	id, err := vm.GetVMNameForDocker(ccid)
+	id, err := vm.imageNameForDocker(ccid)
 	if err != nil {
 		return err
 	}
@@ -204,15 +230,23 @@ func (vm *DockerVM) deployImage(client dockerClient, ccid ccintf.CCID, reader io
 		Pull:         viper.GetBool("chaincode.pull"),
 		InputStream:  reader,
 		OutputStream: outputbuf,
+		Labels:       vm.imageLabels(ccid),
+	}
+	// the proxies are predefined build args, which the RUN instructions of
+	// the Dockerfile templates see without leaking into the image
+	for _, env := range cutil.BuildProxyEnvFromConfig() {
+		kv := strings.SplitN(env, "=", 2)
+		opts.BuildArgs = append(opts.BuildArgs, docker.BuildArg{Name: kv[0], Value: kv[1]})
 	}
 
 	startTime := time.Now()
 	err = client.BuildImage(opts)
 
 	vm.BuildMetrics.ChaincodeImageBuildDuration.With(
-		"chaincode", ccid.Name+":"+ccid.Version,
+		"chaincode", packageID(ccid),
 		"success", strconv.FormatBool(err == nil),
 	).Observe(time.Since(startTime).Seconds())
+	vm.BuildLogs.Record(packageID(ccid), outputbuf.String(), err)
 
 	if err != nil {
 		dockerLogger.Errorf("Error building image: %s", err)
@@ -224,9 +258,74 @@ func (vm *DockerVM) deployImage(client dockerClient, ccid ccintf.CCID, reader io
 	return nil
 }
 
+// packageID identifies the chaincode version an image is built for in the
+// build metrics and logs
+func packageID(ccid ccintf.CCID) string {
+	return ccid.Name + ":" + ccid.Version
+}
+
+type imageLock struct {
+	sync.Mutex
+	refs int
+}
+
+// imageLocks serializes the builds of each image, so that the chaincode
+// versions of a package which start at the same time build its image once
+var imageLocks = struct {
+	sync.Mutex
+	locks map[string]*imageLock
+}{locks: map[string]*imageLock{}}
+
+// lockImage locks the build of the image, and returns the function unlocking it
+func lockImage(imageName string) func() {
+	imageLocks.Lock()
+	l, ok := imageLocks.locks[imageName]
+	if !ok {
+		l = &imageLock{}
+		imageLocks.locks[imageName] = l
+	}
+	l.refs++
+	imageLocks.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+		imageLocks.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(imageLocks.locks, imageName)
+		}
+		imageLocks.Unlock()
+	}
+}
+
+// buildImage builds the image of the chaincode with the builder
+func (vm *DockerVM) buildImage(client dockerClient, ccid ccintf.CCID, containerName string, builder container.Builder) error {
+	reader, err := builder.Build()
+	if err != nil {
+		vm.BuildLogs.Record(packageID(ccid), "", err)
+		return errors.Wrapf(err, "failed to generate Dockerfile to build %s", containerName)
+	}
+	return vm.deployImage(client, ccid, reader)
+}
+
+// tagVersionImage tags the image of the package of the chaincode with the name
+// of the image of the chaincode version, which records for the janitor that
+// the chaincode version uses the image of the package
+func (vm *DockerVM) tagVersionImage(client dockerClient, ccid ccintf.CCID, imageName string) {
+	versionImageName, err := vm.GetVMNameForDocker(ccid)
+	if err != nil {
+		dockerLogger.Warningf("Failed tagging the image %s: %s", imageName, err)
+		return
+	}
+	err = client.TagImage(imageName, docker.TagImageOptions{Repo: versionImageName, Tag: "latest", Force: true})
+	if err != nil {
+		dockerLogger.Warningf("Failed tagging the image %s as %s: %s", imageName, versionImageName, err)
+	}
+}
+
 // Start starts a container using a previously created docker image
 func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload map[string][]byte, builder container.Builder) error {
-	imageName, err := vm.GetVMNameForDocker(ccid)
+	imageName, err := vm.imageNameForDocker(ccid)
 	if err != nil {
 		return err
 	}
@@ -241,23 +340,30 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 		return err
 	}
 
+	sb, err := getSandbox(platformOf(builder))
+	if err != nil {
+		logger.Errorf("failed to load the sandbox configuration: %s", err)
+		return err
+	}
+
 	vm.stopInternal(client, containerName, 0, false, false)
 
-	err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+	err = vm.createContainer(client, ccid, imageName, containerName, args, env, attachStdout, sb, filesToUpload)
 	if err == docker.ErrNoSuchImage {
-		reader, err := builder.Build()
-		if err != nil {
-			return errors.Wrapf(err, "failed to generate Dockerfile to build %s", containerName)
-		}
-
-		err = vm.deployImage(client, ccid, reader)
-		if err != nil {
-			return err
+		unlock := lockImage(imageName)
+		// the image may have been built by another version of the package
+		err = vm.createContainer(client, ccid, imageName, containerName, args, env, attachStdout, sb, filesToUpload)
+		if err == docker.ErrNoSuchImage {
+			err = vm.buildImage(client, ccid, containerName, builder)
+			if err == nil {
+				err = vm.createContainer(client, ccid, imageName, containerName, args, env, attachStdout, sb, filesToUpload)
+				if err != nil {
+					logger.Errorf("failed to create container: %s", err)
+				}
+			}
 		}
-
-		err = vm.createContainer(client, imageName, containerName, args, env, attachStdout)
+		unlock()
 		if err != nil {
-			logger.Errorf("failed to create container: %s", err)
 			return err
 		}
 	} else if err != nil {
@@ -265,15 +371,52 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 		return err
 	}
 
+	if ccid.PackageID != "" {
+		vm.tagVersionImage(client, ccid, imageName)
+	}
+
 	// stream stdout and stderr to chaincode logger
 	if attachStdout {
-		containerLogger := flogging.MustGetLogger("peer.chaincode." + containerName)
-		streamOutput(dockerLogger, client, containerName, containerLogger)
+		streamOutput(dockerLogger, client, containerName, &chaincodeOutput{
+			logger:  flogging.MustGetLogger(chaincodeLoggerPrefix+ccid.Name).With("version", ccid.Version),
+			sink:    vm.LogSink,
+			peerID:  vm.PeerID,
+			name:    ccid.Name,
+			version: ccid.Version,
+		})
 	}
 
 	// upload specified files to the container before starting it
 	// this can be used for configurations such as TLS key and certs
 	if len(filesToUpload) != 0 {
+		// a read-only root filesystem only accepts uploads to the volumes
+		// backing the directories of the files
+		readOnly := sb != nil && sb.ReadOnlyRootfs
+		if err := uploadFiles(client, containerName, filesToUpload, readOnly); err != nil {
+			return err
+		}
+	}
+
+	// start container with HostConfig was deprecated since v1.10 and removed in v1.2
+	err = client.StartContainer(containerName, nil)
+	if err != nil {
+		dockerLogger.Errorf("start-could not start container: %s", err)
+		return err
+	}
+
+	dockerLogger.Debugf("Started container %s", containerName)
+	return nil
+}
+
+// uploadFiles uploads the files to the container, either at once to the root
+// directory or, if perDir is set, to each of the directories of the files
+func uploadFiles(client dockerClient, containerName string, filesToUpload map[string][]byte, perDir bool) error {
+	dirs := []string{"/"}
+	if perDir {
+		dirs = uploadDirs(filesToUpload)
+	}
+
+	for _, dir := range dirs {
 		// the docker upload API takes a tar file, so we need to first
 		// consolidate the file entries to a tar
 		payload := bytes.NewBuffer(nil)
@@ -281,7 +424,13 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 		tw := tar.NewWriter(gw)
 
 		for path, fileToUpload := range filesToUpload {
-			cutil.WriteBytesToPackage(path, fileToUpload, tw)
+			if !perDir {
+				cutil.WriteBytesToPackage(path, fileToUpload, tw)
+				continue
+			}
+			if uploadDir(path) == dir {
+				cutil.WriteBytesToPackage(path[strings.LastIndex(path, "/")+1:], fileToUpload, tw)
+			}
 		}
 
 		// Write the tar file out
@@ -293,7 +442,7 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 
 		err := client.UploadToContainer(containerName, docker.UploadToContainerOptions{
 			InputStream:          bytes.NewReader(payload.Bytes()),
-			Path:                 "/",
+			Path:                 dir,
 			NoOverwriteDirNonDir: false,
 		})
 		if err != nil {
@@ -301,23 +450,17 @@ func (vm *DockerVM) Start(ccid ccintf.CCID, args, env []string, filesToUpload ma
 		}
 	}
 
-	// start container with HostConfig was deprecated since v1.10 and removed in v1.2
-	err = client.StartContainer(containerName, nil)
-	if err != nil {
-		dockerLogger.Errorf("start-could not start container: %s", err)
-		return err
-	}
-
-	dockerLogger.Debugf("Started container %s", containerName)
 	return nil
 }
 
-// streamOutput mirrors output from the named container to a fabric logger.
-func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerName string, containerLogger *flogging.FabricLogger) {
+// streamOutput mirrors the standard output and error of the named container
+// to the chaincode output.
+func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerName string, output *chaincodeOutput) {
 	// Launch a few go routines to manage output streams from the container.
 	// They will be automatically destroyed when the container exits
 	attached := make(chan struct{})
-	r, w := io.Pipe()
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderrReader, stderrWriter := io.Pipe()
 
 	go func() {
 		// AttachToContainer will fire off a message on the "attached" channel once the
@@ -326,8 +469,8 @@ func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerN
 		// error to a local variable to prevent clobbering the function variable 'err'.
 		err := client.AttachToContainer(docker.AttachToContainerOptions{
 			Container:    containerName,
-			OutputStream: w,
-			ErrorStream:  w,
+			OutputStream: stdoutWriter,
+			ErrorStream:  stderrWriter,
 			Logs:         true,
 			Stdout:       true,
 			Stderr:       true,
@@ -335,13 +478,15 @@ func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerN
 			Success:      attached,
 		})
 
-		// If we get here, the container has terminated.  Send a signal on the pipe
+		// If we get here, the container has terminated.  Send a signal on the pipes
 		// so that downstream may clean up appropriately
-		_ = w.CloseWithError(err)
+		_ = stdoutWriter.CloseWithError(err)
+		_ = stderrWriter.CloseWithError(err)
 	}()
 
 	go func() {
-		defer r.Close() // ensure the pipe reader gets closed
+		defer stdoutReader.Close() // ensure the pipe readers get closed
+		defer stderrReader.Close()
 
 		// Block here until the attachment completes or we timeout
 		select {
@@ -353,25 +498,38 @@ func streamOutput(logger *flogging.FabricLogger, client dockerClient, containerN
 			return
 		}
 
-		is := bufio.NewReader(r)
-		for {
-			// Loop forever dumping lines of text into the containerLogger
-			// until the pipe is closed
-			line, err := is.ReadString('\n')
-			switch err {
-			case nil:
-				containerLogger.Info(line)
-			case io.EOF:
-				logger.Infof("Container %s has closed its IO channel", containerName)
-				return
-			default:
-				logger.Errorf("Error reading container output: %s", err)
-				return
-			}
-		}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			copyOutput(logger, stderrReader, "stderr", output)
+		}()
+		copyOutput(logger, stdoutReader, "stdout", output)
+		wg.Wait()
+		logger.Infof("Container %s has closed its IO channel", containerName)
 	}()
 }
 
+// copyOutput writes the lines of a stream of a container to the chaincode
+// output until the stream is closed
+func copyOutput(logger *flogging.FabricLogger, r io.Reader, stream string, output *chaincodeOutput) {
+	is := bufio.NewReader(r)
+	for {
+		// Loop forever dumping lines of text into the chaincode output
+		// until the pipe is closed
+		line, err := is.ReadString('\n')
+		switch err {
+		case nil:
+			output.write(stream, line)
+		case io.EOF:
+			return
+		default:
+			logger.Errorf("Error reading container output: %s", err)
+			return
+		}
+	}
+}
+
 // Stop stops a running chaincode
 func (vm *DockerVM) Stop(ccid ccintf.CCID, timeout uint, dontkill bool, dontremove bool) error {
 	client, err := vm.getClientFnc()
@@ -428,7 +586,7 @@ func (vm *DockerVM) stopInternal(client dockerClient, id string, timeout uint, d
 
 	if !dontremove {
 		logger.Debugw("removing container")
-		err = client.RemoveContainer(docker.RemoveContainerOptions{ID: id, Force: true})
+		err = client.RemoveContainer(docker.RemoveContainerOptions{ID: id, Force: true, RemoveVolumes: true})
 		logger.Debugw("remove container result", "error", err)
 	}
 
@@ -464,6 +622,16 @@ func (vm *DockerVM) GetVMNameForDocker(ccid ccintf.CCID) (string, error) {
 	return imageName, nil
 }
 
+// imageNameForDocker returns the name of the image the chaincode runs: the
+// image of its code package if known, shared by the chaincode versions built
+// from the package, or else the image of the chaincode version
+func (vm *DockerVM) imageNameForDocker(ccid ccintf.CCID) (string, error) {
+	if ccid.PackageID == "" {
+		return vm.GetVMNameForDocker(ccid)
+	}
+	return vm.GetVMNameForDocker(ccintf.CCID{Name: ccid.PackageID})
+}
+
 func (vm *DockerVM) preFormatImageName(ccid ccintf.CCID) string {
 	name := ccid.GetName()
 
@@ -181,6 +181,53 @@ func Test_Start(t *testing.T) {
 	gt.Expect(err).NotTo(HaveOccurred())
 }
 
+func TestStartPackageImage(t *testing.T) {
+	noSuchImgErr = true
+	defer func() { noSuchImgErr = false }()
+
+	client := &mockClient{}
+	dvm := DockerVM{
+		PeerID:       "peer0",
+		NetworkID:    "dev",
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+		getClientFnc: func() (dockerClient, error) { return client, nil },
+	}
+	builder := &mockBuilder{buildFunc: func() (io.Reader, error) { return &bytes.Buffer{}, nil }}
+
+	v1 := ccintf.CCID{Name: "mycc", Version: "1.0", PackageID: "golang:1234"}
+	v2 := ccintf.CCID{Name: "othercc", Version: "2.0", PackageID: "golang:1234"}
+	require.NoError(t, dvm.Start(v1, nil, nil, nil, builder))
+	require.NoError(t, dvm.Start(v2, nil, nil, nil, builder))
+
+	// the image of the package is built once, labeled with the package ID
+	imageName, err := dvm.GetVMNameForDocker(ccintf.CCID{Name: "golang:1234"})
+	require.NoError(t, err)
+	assert.Regexp(t, "^dev-peer0-golang-1234-[0-9a-f]{64}$", imageName)
+	require.Len(t, client.builds, 1)
+	assert.Equal(t, imageName, client.builds[0].Name)
+	assert.Equal(t, map[string]string{
+		PackageIDLabel: "golang:1234",
+		PeerIDLabel:    "peer0",
+		NetworkIDLabel: "dev",
+	}, client.builds[0].Labels)
+
+	// both versions run the image of the package in containers of their own
+	require.Len(t, client.createOpts, 2)
+	assert.Equal(t, "dev-peer0-mycc-1.0", client.createOpts[0].Name)
+	assert.Equal(t, imageName, client.createOpts[0].Config.Image)
+	assert.Equal(t, "dev-peer0-othercc-2.0", client.createOpts[1].Name)
+	assert.Equal(t, imageName, client.createOpts[1].Config.Image)
+	assert.Equal(t, "golang:1234", client.createOpts[1].Config.Labels[PackageIDLabel])
+	assert.Equal(t, "othercc", client.createOpts[1].Config.Labels[ChaincodeNameLabel])
+
+	// the image is tagged for each version using it
+	v1Image, err := dvm.GetVMNameForDocker(v1)
+	require.NoError(t, err)
+	v2Image, err := dvm.GetVMNameForDocker(v2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{imageName + " " + v1Image + ":latest", imageName + " " + v2Image + ":latest"}, client.tags)
+}
+
 func Test_streamOutput(t *testing.T) {
 	gt := NewGomegaWithT(t)
 
@@ -195,7 +242,8 @@ func Test_streamOutput(t *testing.T) {
 		return <-errCh
 	}
 
-	streamOutput(logger, client, "container-name", containerLogger)
+	sink := &recordingSink{}
+	streamOutput(logger, client, "container-name", &chaincodeOutput{logger: containerLogger, sink: sink, name: "mycc", version: "1.0"})
 
 	var opts docker.AttachToContainerOptions
 	gt.Eventually(optsCh).Should(Receive(&opts))
@@ -207,10 +255,17 @@ func Test_streamOutput(t *testing.T) {
 	gt.Eventually(containerRecorder).Should(gbytes.Say("message-one"))
 	gt.Consistently(containerRecorder.Entries).Should(HaveLen(1))
 
+	fmt.Fprintf(opts.ErrorStream, "2019-01-01 00:00:00.000 UTC [mycc] Invoke -> WARN 001 message-three\n")
+	gt.Eventually(containerRecorder).Should(gbytes.Say("message-three"))
+	gt.Expect(containerRecorder.EntriesMatching("WARN.*message-three")).To(HaveLen(1))
+	gt.Eventually(sink.Entries).Should(HaveLen(2))
+	gt.Expect(sink.Entries()[1].Stream).To(Equal("stderr"))
+	gt.Expect(sink.Entries()[1].Level).To(Equal("WARN"))
+
 	close(errCh)
 	gt.Eventually(recorder).Should(gbytes.Say("Container container-name has closed its IO channel"))
 	gt.Consistently(recorder.Entries).Should(HaveLen(1))
-	gt.Consistently(containerRecorder.Entries).Should(HaveLen(1))
+	gt.Consistently(containerRecorder.Entries).Should(HaveLen(2))
 }
 
 func Test_BuildMetric(t *testing.T) {
@@ -249,6 +304,21 @@ func Test_BuildMetric(t *testing.T) {
 	buildErr = false
 }
 
+func TestDeployImageBuildProxies(t *testing.T) {
+	viper.Set("chaincode.build.httpsProxy", "http://proxy.example.com:3128")
+	defer viper.Set("chaincode.build.httpsProxy", "")
+	client := &mockClient{}
+	dvm := DockerVM{BuildMetrics: NewBuildMetrics(&disabled.Provider{})}
+
+	err := dvm.deployImage(client, ccintf.CCID{Name: "mycc", Version: "1.0"}, &bytes.Buffer{})
+	require.NoError(t, err)
+	require.Len(t, client.builds, 1)
+	assert.Equal(t, []docker.BuildArg{
+		{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+		{Name: "https_proxy", Value: "http://proxy.example.com:3128"},
+	}, client.builds[0].BuildArgs)
+}
+
 func Test_Stop(t *testing.T) {
 	dvm := DockerVM{}
 	ccid := ccintf.CCID{Name: "simple"}
@@ -419,7 +489,7 @@ func getMockClient() (dockerClient, error) {
 	if getClientErr {
 		return nil, errors.New("Failed to get client")
 	}
-	return &mockClient{noSuchImgErrReturned: false}, nil
+	return &mockClient{}, nil
 }
 
 type mockBuilder struct {
@@ -431,14 +501,25 @@ func (m *mockBuilder) Build() (io.Reader, error) {
 }
 
 type mockClient struct {
-	noSuchImgErrReturned bool
-	pingErr              bool
+	imageBuilt bool
+	pingErr    bool
 
 	containerID string
 	exitCode    int
 	waitErr     error
 
 	attachToContainerStub func(docker.AttachToContainerOptions) error
+
+	createOpts []docker.CreateContainerOptions
+	uploads    []docker.UploadToContainerOptions
+
+	images            []docker.APIImages
+	containers        []docker.APIContainers
+	listErr           error
+	removedImages     []string
+	removedContainers []string
+	tags              []string
+	builds            []docker.BuildImageOptions
 }
 
 var getClientErr, createErr, uploadErr, noSuchImgErr, buildErr, removeImgErr,
@@ -448,10 +529,10 @@ func (c *mockClient) CreateContainer(options docker.CreateContainerOptions) (*do
 	if createErr {
 		return nil, errors.New("Error creating the container")
 	}
-	if noSuchImgErr && !c.noSuchImgErrReturned {
-		c.noSuchImgErrReturned = true
+	if noSuchImgErr && !c.imageBuilt {
 		return nil, docker.ErrNoSuchImage
 	}
+	c.createOpts = append(c.createOpts, options)
 	return &docker.Container{}, nil
 }
 
@@ -466,6 +547,7 @@ func (c *mockClient) UploadToContainer(id string, opts docker.UploadToContainerO
 	if uploadErr {
 		return errors.New("Error uploading archive to the container")
 	}
+	c.uploads = append(c.uploads, opts)
 	return nil
 }
 
@@ -483,6 +565,8 @@ func (c *mockClient) BuildImage(opts docker.BuildImageOptions) error {
 	if buildErr {
 		return errors.New("Error building image")
 	}
+	c.imageBuilt = true
+	c.builds = append(c.builds, opts)
 	return nil
 }
 
@@ -490,6 +574,12 @@ func (c *mockClient) RemoveImageExtended(id string, opts docker.RemoveImageOptio
 	if removeImgErr {
 		return errors.New("Error removing extended image")
 	}
+	c.removedImages = append(c.removedImages, id)
+	return nil
+}
+
+func (c *mockClient) TagImage(name string, opts docker.TagImageOptions) error {
+	c.tags = append(c.tags, name+" "+opts.Repo+":"+opts.Tag)
 	return nil
 }
 
@@ -511,6 +601,7 @@ func (c *mockClient) RemoveContainer(opts docker.RemoveContainerOptions) error {
 	if removeErr {
 		return errors.New("Error removing container")
 	}
+	c.removedContainers = append(c.removedContainers, opts.ID)
 	return nil
 }
 
@@ -525,3 +616,11 @@ func (c *mockClient) WaitContainer(id string) (int, error) {
 	c.containerID = id
 	return c.exitCode, c.waitErr
 }
+
+func (c *mockClient) ListImages(opts docker.ListImagesOptions) ([]docker.APIImages, error) {
+	return c.images, c.listErr
+}
+
+func (c *mockClient) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	return c.containers, c.listErr
+}
@@ -0,0 +1,217 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+)
+
+// The labels set on the images and the containers of the chaincodes, which
+// identify the chaincode version they were built for and the peer that built them
+const (
+	ChaincodeNameLabel    = "org.hyperledger.fabric.chaincode.name"
+	ChaincodeVersionLabel = "org.hyperledger.fabric.chaincode.version"
+	PeerIDLabel           = "org.hyperledger.fabric.peer.id"
+	NetworkIDLabel        = "org.hyperledger.fabric.network.id"
+	// PackageIDLabel identifies the code package of the images shared by the
+	// chaincode versions built from the same package, which are tagged with
+	// the image names of the chaincode versions using them
+	PackageIDLabel = "org.hyperledger.fabric.chaincode.package.id"
+)
+
+func (vm *DockerVM) labels(ccid ccintf.CCID) map[string]string {
+	labels := map[string]string{
+		ChaincodeNameLabel:    ccid.Name,
+		ChaincodeVersionLabel: ccid.Version,
+		PeerIDLabel:           vm.PeerID,
+		NetworkIDLabel:        vm.NetworkID,
+	}
+	if ccid.PackageID != "" {
+		labels[PackageIDLabel] = ccid.PackageID
+	}
+	return labels
+}
+
+// imageLabels returns the labels of the image of the chaincode. The image of a
+// code package is shared by several chaincode versions, it is labeled with the
+// package ID instead of the name and the version of the chaincode
+func (vm *DockerVM) imageLabels(ccid ccintf.CCID) map[string]string {
+	labels := vm.labels(ccid)
+	if ccid.PackageID != "" {
+		delete(labels, ChaincodeNameLabel)
+		delete(labels, ChaincodeVersionLabel)
+	}
+	return labels
+}
+
+// ChaincodeVersion identifies a version of a chaincode
+type ChaincodeVersion struct {
+	Name    string
+	Version string
+}
+
+// DefinedVersions returns the set of chaincode versions that are defined on
+// at least one of the channels of the peer
+type DefinedVersions func() (map[ChaincodeVersion]struct{}, error)
+
+// Janitor removes the docker images and containers built by the peer for the
+// chaincode versions that are no longer defined on any channel. Only the images
+// and the containers that carry the labels of the peer are considered, and
+// those created less than the retention ago are kept so that a version being
+// installed or upgraded is not collected before its definition is committed.
+// Running containers are never removed
+type Janitor struct {
+	PeerID          string
+	NetworkID       string
+	Retention       time.Duration
+	DefinedVersions DefinedVersions
+
+	getClientFnc getClient
+	now          func() time.Time
+}
+
+// NewJanitor returns a Janitor for the images and containers of the given peer
+func NewJanitor(peerID, networkID string, retention time.Duration, definedVersions DefinedVersions) *Janitor {
+	return &Janitor{
+		PeerID:          peerID,
+		NetworkID:       networkID,
+		Retention:       retention,
+		DefinedVersions: definedVersions,
+		getClientFnc:    getDockerClient,
+		now:             time.Now,
+	}
+}
+
+// Run collects the images and containers of the dead chaincode versions every
+// interval, until the done channel is closed
+func (j *Janitor) Run(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := j.Collect(); err != nil {
+			dockerLogger.Warningf("Failed collecting the images of the dead chaincode versions: %s", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+	}
+}
+
+// Collect removes the containers, then the images, of the chaincode versions
+// that are not defined on any channel and that are older than the retention.
+// A failure to remove one of them is logged and does not stop the collection
+func (j *Janitor) Collect() error {
+	defined, err := j.DefinedVersions()
+	if err != nil {
+		return errors.WithMessage(err, "failed to retrieve the defined chaincode versions")
+	}
+	client, err := j.getClientFnc()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to Docker daemon")
+	}
+
+	filters := map[string][]string{
+		"label": {
+			PeerIDLabel + "=" + j.PeerID,
+			NetworkIDLabel + "=" + j.NetworkID,
+		},
+	}
+	cutoff := j.now().Add(-j.Retention).Unix()
+
+	containers, err := client.ListContainers(docker.ListContainersOptions{All: true, Filters: filters})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the chaincode containers")
+	}
+	for _, c := range containers {
+		if !j.collectable(c.Labels, c.Created, cutoff, defined) {
+			continue
+		}
+		if c.State == "running" {
+			dockerLogger.Debugf("Keeping the running container %s of a dead chaincode version", c.ID)
+			continue
+		}
+		err := client.RemoveContainer(docker.RemoveContainerOptions{ID: c.ID, RemoveVolumes: true})
+		if err != nil {
+			dockerLogger.Warningf("Failed removing the container %s: %s", c.ID, err)
+			continue
+		}
+		dockerLogger.Infof("Removed the container %s of the dead chaincode version %s:%s", c.ID, c.Labels[ChaincodeNameLabel], c.Labels[ChaincodeVersionLabel])
+	}
+
+	images, err := client.ListImages(docker.ListImagesOptions{Filters: filters})
+	if err != nil {
+		return errors.Wrap(err, "failed to list the chaincode images")
+	}
+	definedTags := j.definedTags(defined)
+	for _, image := range images {
+		if packageID, ok := image.Labels[PackageIDLabel]; ok {
+			j.collectPackageImage(client, image, packageID, cutoff, definedTags)
+			continue
+		}
+		if !j.collectable(image.Labels, image.Created, cutoff, defined) {
+			continue
+		}
+		err := client.RemoveImageExtended(image.ID, docker.RemoveImageOptions{})
+		if err != nil {
+			dockerLogger.Warningf("Failed removing the image %s: %s", image.ID, err)
+			continue
+		}
+		dockerLogger.Infof("Removed the image %s of the dead chaincode version %s:%s", image.ID, image.Labels[ChaincodeNameLabel], image.Labels[ChaincodeVersionLabel])
+	}
+
+	return nil
+}
+
+// definedTags returns the tags of the image names of the defined chaincode
+// versions, which tag the images of the packages they use
+func (j *Janitor) definedTags(defined map[ChaincodeVersion]struct{}) map[string]struct{} {
+	vm := &DockerVM{PeerID: j.PeerID, NetworkID: j.NetworkID}
+	tags := map[string]struct{}{}
+	for cv := range defined {
+		name, err := vm.GetVMNameForDocker(ccintf.CCID{Name: cv.Name, Version: cv.Version})
+		if err != nil {
+			continue
+		}
+		tags[name+":latest"] = struct{}{}
+	}
+	return tags
+}
+
+// collectPackageImage removes the image of a code package older than the
+// retention when none of its tags belongs to a defined chaincode version
+func (j *Janitor) collectPackageImage(client dockerClient, image docker.APIImages, packageID string, cutoff int64, definedTags map[string]struct{}) {
+	if image.Created > cutoff {
+		return
+	}
+	for _, tag := range image.RepoTags {
+		if _, ok := definedTags[tag]; ok {
+			return
+		}
+	}
+	// the image carries a tag per chaincode version which used it
+	err := client.RemoveImageExtended(image.ID, docker.RemoveImageOptions{Force: true})
+	if err != nil {
+		dockerLogger.Warningf("Failed removing the image %s: %s", image.ID, err)
+		return
+	}
+	dockerLogger.Infof("Removed the image %s of the chaincode package %s", image.ID, packageID)
+}
+
+func (j *Janitor) collectable(labels map[string]string, created, cutoff int64, defined map[ChaincodeVersion]struct{}) bool {
+	name, ok := labels[ChaincodeNameLabel]
+	if !ok || created > cutoff {
+		return false
+	}
+	_, ok = defined[ChaincodeVersion{Name: name, Version: labels[ChaincodeVersionLabel]}]
+	return !ok
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func ccLabels(name, version string) map[string]string {
+	return map[string]string{
+		ChaincodeNameLabel:    name,
+		ChaincodeVersionLabel: version,
+		PeerIDLabel:           "peer0",
+		NetworkIDLabel:        "dev",
+	}
+}
+
+func TestJanitorCollect(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	old := now.Add(-2 * time.Hour).Unix()
+	recent := now.Add(-time.Minute).Unix()
+
+	client := &mockClient{
+		containers: []docker.APIContainers{
+			{ID: "c-defined", Created: old, State: "exited", Labels: ccLabels("mycc", "2.0")},
+			{ID: "c-dead", Created: old, State: "exited", Labels: ccLabels("mycc", "1.0")},
+			{ID: "c-running", Created: old, State: "running", Labels: ccLabels("othercc", "1.0")},
+			{ID: "c-recent", Created: recent, State: "exited", Labels: ccLabels("newcc", "1.0")},
+		},
+		images: []docker.APIImages{
+			{ID: "i-defined", Created: old, Labels: ccLabels("mycc", "2.0")},
+			{ID: "i-dead", Created: old, Labels: ccLabels("mycc", "1.0")},
+			{ID: "i-recent", Created: recent, Labels: ccLabels("newcc", "1.0")},
+			{ID: "i-unlabeled", Created: old},
+		},
+	}
+	j := NewJanitor("peer0", "dev", time.Hour, func() (map[ChaincodeVersion]struct{}, error) {
+		return map[ChaincodeVersion]struct{}{{Name: "mycc", Version: "2.0"}: {}}, nil
+	})
+	j.getClientFnc = func() (dockerClient, error) { return client, nil }
+	j.now = func() time.Time { return now }
+
+	err := j.Collect()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c-dead"}, client.removedContainers)
+	assert.Equal(t, []string{"i-dead"}, client.removedImages)
+}
+
+func TestJanitorCollectPackageImages(t *testing.T) {
+	now := time.Unix(1000000, 0)
+	old := now.Add(-2 * time.Hour).Unix()
+	recent := now.Add(-time.Minute).Unix()
+
+	vm := &DockerVM{PeerID: "peer0", NetworkID: "dev"}
+	tag := func(name, version string) string {
+		imageName, err := vm.GetVMNameForDocker(ccintf.CCID{Name: name, Version: version})
+		assert.NoError(t, err)
+		return imageName + ":latest"
+	}
+	packageLabels := func(packageID string) map[string]string {
+		return map[string]string{PackageIDLabel: packageID, PeerIDLabel: "peer0", NetworkIDLabel: "dev"}
+	}
+
+	client := &mockClient{
+		images: []docker.APIImages{
+			{ID: "i-shared", Created: old, Labels: packageLabels("golang:1"), RepoTags: []string{"pkg1:latest", tag("mycc", "1.0"), tag("othercc", "1.0")}},
+			{ID: "i-dead", Created: old, Labels: packageLabels("golang:2"), RepoTags: []string{"pkg2:latest", tag("mycc", "0.9")}},
+			{ID: "i-recent", Created: recent, Labels: packageLabels("golang:3"), RepoTags: []string{"pkg3:latest"}},
+		},
+	}
+	j := NewJanitor("peer0", "dev", time.Hour, func() (map[ChaincodeVersion]struct{}, error) {
+		return map[ChaincodeVersion]struct{}{{Name: "othercc", Version: "1.0"}: {}}, nil
+	})
+	j.getClientFnc = func() (dockerClient, error) { return client, nil }
+	j.now = func() time.Time { return now }
+
+	err := j.Collect()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"i-dead"}, client.removedImages)
+}
+
+func TestJanitorCollectErrors(t *testing.T) {
+	client := &mockClient{}
+	j := NewJanitor("peer0", "dev", time.Hour, func() (map[ChaincodeVersion]struct{}, error) {
+		return nil, errors.New("ledger unavailable")
+	})
+	j.getClientFnc = func() (dockerClient, error) { return client, nil }
+
+	err := j.Collect()
+	assert.EqualError(t, err, "failed to retrieve the defined chaincode versions: ledger unavailable")
+
+	j.DefinedVersions = func() (map[ChaincodeVersion]struct{}, error) { return nil, nil }
+	client.listErr = errors.New("daemon unavailable")
+	err = j.Collect()
+	assert.EqualError(t, err, "failed to list the chaincode containers: daemon unavailable")
+
+	j.getClientFnc = func() (dockerClient, error) { return nil, errors.New("no daemon") }
+	err = j.Collect()
+	assert.EqualError(t, err, "failed to connect to Docker daemon: no daemon")
+}
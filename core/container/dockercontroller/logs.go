@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"encoding/json"
+	"net"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"go.uber.org/zap/zapcore"
+)
+
+// The output of the chaincode containers is logged by the peer with the logger
+// named chaincodeLoggerPrefix followed by the name of the chaincode, so that the
+// level of each chaincode can be set with the logging spec of the peer, e.g.
+// "peer.chaincode.mycc=debug"
+const chaincodeLoggerPrefix = "peer.chaincode."
+
+// levelRegExp matches the level of a line written by the chaincode logger of a shim
+var levelRegExp = regexp.MustCompile(`\b(DEBUG|DEBU|INFO|WARNING|WARN|ERROR|ERRO|CRITICAL|CRIT|FATAL|FATA|PANIC|PANI)\b`)
+
+// lineLevel returns the level of a line written by a chaincode container. Lines
+// that do not carry a level are logged at the info level
+func lineLevel(line string) zapcore.Level {
+	switch levelRegExp.FindString(line) {
+	case "DEBUG", "DEBU":
+		return zapcore.DebugLevel
+	case "WARNING", "WARN":
+		return zapcore.WarnLevel
+	case "ERROR", "ERRO", "CRITICAL", "CRIT", "FATAL", "FATA", "PANIC", "PANI":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
+// LogEntry is a line written by a chaincode container on its standard output
+// or error
+type LogEntry struct {
+	Time      time.Time `json:"time"`
+	PeerID    string    `json:"peer"`
+	Chaincode string    `json:"chaincode"`
+	Version   string    `json:"version"`
+	Stream    string    `json:"stream"`
+	Level     string    `json:"level"`
+	Line      string    `json:"line"`
+}
+
+// LogSink receives the lines written by the chaincode containers
+type LogSink interface {
+	Forward(entry *LogEntry)
+}
+
+// chaincodeOutput logs the lines of a chaincode container at their level and
+// forwards the ones enabled by that level to the sink, if any
+type chaincodeOutput struct {
+	logger  *flogging.FabricLogger
+	sink    LogSink
+	peerID  string
+	name    string
+	version string
+}
+
+func (o *chaincodeOutput) write(stream, line string) {
+	line = strings.TrimRight(line, "\r\n")
+	level := lineLevel(line)
+	if !o.logger.IsEnabledFor(level) {
+		return
+	}
+	switch level {
+	case zapcore.DebugLevel:
+		o.logger.Debug(line)
+	case zapcore.WarnLevel:
+		o.logger.Warn(line)
+	case zapcore.ErrorLevel:
+		o.logger.Error(line)
+	default:
+		o.logger.Info(line)
+	}
+	if o.sink != nil {
+		o.sink.Forward(&LogEntry{
+			Time:      time.Now(),
+			PeerID:    o.peerID,
+			Chaincode: o.name,
+			Version:   o.version,
+			Stream:    stream,
+			Level:     level.CapitalString(),
+			Line:      line,
+		})
+	}
+}
+
+// RemoteLogSink forwards the lines of the chaincode containers to a remote
+// endpoint, one JSON encoded LogEntry per line. The connection is established
+// lazily and re-established after a failure. Entries are buffered, and dropped
+// while the buffer is full, so that a slow or unavailable endpoint never blocks
+// the chaincodes
+type RemoteLogSink struct {
+	network     string
+	address     string
+	dialTimeout time.Duration
+	entries     chan *LogEntry
+	dial        func(network, address string, timeout time.Duration) (net.Conn, error)
+
+	lock    sync.Mutex
+	dropped int
+}
+
+// NewRemoteLogSink returns a RemoteLogSink that forwards the entries to the
+// address, on the network "tcp" or "udp", buffering at most bufferSize entries
+func NewRemoteLogSink(network, address string, bufferSize int) (*RemoteLogSink, error) {
+	switch network {
+	case "tcp", "udp":
+	default:
+		return nil, errors.Errorf("unsupported network [%s] for the forwarding of the chaincode logs", network)
+	}
+	if address == "" {
+		return nil, errors.New("the address of the forwarding of the chaincode logs is not set")
+	}
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	s := &RemoteLogSink{
+		network:     network,
+		address:     address,
+		dialTimeout: 5 * time.Second,
+		entries:     make(chan *LogEntry, bufferSize),
+		dial:        net.DialTimeout,
+	}
+	go s.run()
+	return s, nil
+}
+
+// Forward queues the entry to be sent to the remote endpoint
+func (s *RemoteLogSink) Forward(entry *LogEntry) {
+	select {
+	case s.entries <- entry:
+	default:
+		s.lock.Lock()
+		s.dropped++
+		s.lock.Unlock()
+	}
+}
+
+func (s *RemoteLogSink) run() {
+	var conn net.Conn
+	for entry := range s.entries {
+		if dropped := s.resetDropped(); dropped > 0 {
+			dockerLogger.Warningf("Dropped %d lines of the chaincode logs forwarded to %s", dropped, s.address)
+		}
+		line, err := json.Marshal(entry)
+		if err != nil {
+			dockerLogger.Errorf("Failed encoding a line of the chaincode logs: %s", err)
+			continue
+		}
+		if conn == nil {
+			conn, err = s.dial(s.network, s.address, s.dialTimeout)
+			if err != nil {
+				dockerLogger.Warningf("Failed connecting to %s to forward the chaincode logs: %s", s.address, err)
+				conn = nil
+				continue
+			}
+		}
+		if _, err := conn.Write(append(line, '\n')); err != nil {
+			dockerLogger.Warningf("Failed forwarding the chaincode logs to %s: %s", s.address, err)
+			conn.Close()
+			conn = nil
+		}
+	}
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+func (s *RemoteLogSink) resetDropped() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	dropped := s.dropped
+	s.dropped = 0
+	return dropped
+}
@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging/floggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type recordingSink struct {
+	lock    sync.Mutex
+	entries []*LogEntry
+}
+
+func (s *recordingSink) Forward(entry *LogEntry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.entries = append(s.entries, entry)
+}
+
+func (s *recordingSink) Entries() []*LogEntry {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]*LogEntry(nil), s.entries...)
+}
+
+func TestLineLevel(t *testing.T) {
+	tests := map[string]zapcore.Level{
+		"2019-01-01 00:00:00.000 UTC [shim] SetupChaincodeLogging -> DEBU 001 message": zapcore.DebugLevel,
+		"2019-01-01 00:00:00.000 UTC [mycc] Invoke -> WARN 002 message":                zapcore.WarnLevel,
+		"2019-01-01 00:00:00.000 UTC [mycc] Invoke -> ERRO 003 message":                zapcore.ErrorLevel,
+		"[ERROR] something failed":            zapcore.ErrorLevel,
+		"plain line written with fmt.Println": zapcore.InfoLevel,
+		"INFORMATION is not a level":          zapcore.InfoLevel,
+	}
+	for line, level := range tests {
+		assert.Equal(t, level, lineLevel(line), line)
+	}
+}
+
+func TestChaincodeOutputLevel(t *testing.T) {
+	logger, recorder := floggingtest.NewTestLogger(t, floggingtest.AtLevel(zapcore.WarnLevel))
+	sink := &recordingSink{}
+	output := &chaincodeOutput{logger: logger, sink: sink, peerID: "peer0", name: "mycc", version: "1.0"}
+
+	output.write("stdout", "an informational line\n")
+	output.write("stderr", "mycc -> ERRO 001 an error\n")
+
+	assert.Equal(t, []string{"mycc -> ERRO 001 an error"}, recorder.Messages())
+	entries := sink.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "peer0", entries[0].PeerID)
+	assert.Equal(t, "mycc", entries[0].Chaincode)
+	assert.Equal(t, "1.0", entries[0].Version)
+	assert.Equal(t, "stderr", entries[0].Stream)
+	assert.Equal(t, "ERROR", entries[0].Level)
+	assert.Equal(t, "mycc -> ERRO 001 an error", entries[0].Line)
+}
+
+func TestRemoteLogSink(t *testing.T) {
+	_, err := NewRemoteLogSink("unix", "/tmp/sock", 10)
+	assert.EqualError(t, err, "unsupported network [unix] for the forwarding of the chaincode logs")
+	_, err = NewRemoteLogSink("tcp", "", 10)
+	assert.EqualError(t, err, "the address of the forwarding of the chaincode logs is not set")
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	sink, err := NewRemoteLogSink("tcp", listener.Addr().String(), 10)
+	require.NoError(t, err)
+	sink.Forward(&LogEntry{Chaincode: "mycc", Version: "1.0", Stream: "stdout", Level: "INFO", Line: "hello"})
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(t, err)
+
+	entry := &LogEntry{}
+	require.NoError(t, json.Unmarshal(line, entry))
+	assert.Equal(t, "mycc", entry.Chaincode)
+	assert.Equal(t, "hello", entry.Line)
+}
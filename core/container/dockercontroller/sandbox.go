@@ -0,0 +1,181 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// sandbox restricts what a chaincode container can do, on top of the
+// docker host configuration
+type sandbox struct {
+	ReadOnlyRootfs  bool
+	Tmpfs           map[string]string
+	CapDrop         []string
+	NoNewPrivileges bool
+	// SeccompProfile is the content of the seccomp profile, empty for the
+	// default profile of the docker daemon
+	SeccompProfile string
+}
+
+// getSandbox returns the sandbox of the containers of the given chaincode
+// platform, or nil if sandboxing is disabled. The settings under
+// vm.docker.sandbox.platforms.<platform> override the default ones
+func getSandbox(platform string) (*sandbox, error) {
+	sandboxKey := func(key string) string {
+		if platform != "" {
+			platformKey := "vm.docker.sandbox.platforms." + platform + "." + key
+			if viper.IsSet(platformKey) {
+				return platformKey
+			}
+		}
+		return "vm.docker.sandbox." + key
+	}
+
+	if !viper.GetBool(sandboxKey("enabled")) {
+		return nil, nil
+	}
+
+	s := &sandbox{
+		ReadOnlyRootfs:  viper.GetBool(sandboxKey("readOnlyRootfs")),
+		Tmpfs:           viper.GetStringMapString(sandboxKey("tmpfs")),
+		CapDrop:         viper.GetStringSlice(sandboxKey("capDrop")),
+		NoNewPrivileges: viper.GetBool(sandboxKey("noNewPrivileges")),
+	}
+
+	if profilePath := config.GetPath(sandboxKey("seccompProfile")); profilePath != "" {
+		profile, err := ioutil.ReadFile(profilePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading the seccomp profile of the %s platform", platformName(platform))
+		}
+		// the docker API takes the content of the profile on a single line
+		compacted := &bytes.Buffer{}
+		if err := json.Compact(compacted, profile); err != nil {
+			return nil, errors.Wrapf(err, "invalid seccomp profile %s", profilePath)
+		}
+		s.SeccompProfile = compacted.String()
+	}
+
+	return s, nil
+}
+
+// apply returns a copy of the host configuration restricted by the sandbox.
+// The capabilities and the security options of the host configuration are
+// kept, unless the sandbox overrides them
+func (s *sandbox) apply(hostConfig *docker.HostConfig) *docker.HostConfig {
+	hc := *hostConfig
+	hc.ReadonlyRootfs = hostConfig.ReadonlyRootfs || s.ReadOnlyRootfs
+
+	if len(s.Tmpfs) != 0 {
+		hc.Tmpfs = map[string]string{}
+		for path, options := range hostConfig.Tmpfs {
+			hc.Tmpfs[path] = options
+		}
+		for path, options := range s.Tmpfs {
+			hc.Tmpfs[path] = options
+		}
+	}
+
+	hc.CapDrop = append([]string(nil), hostConfig.CapDrop...)
+	for _, capability := range s.CapDrop {
+		if !contains(hc.CapDrop, capability) {
+			hc.CapDrop = append(hc.CapDrop, capability)
+		}
+	}
+
+	hc.SecurityOpt = nil
+	for _, opt := range hostConfig.SecurityOpt {
+		if s.NoNewPrivileges && strings.HasPrefix(opt, "no-new-privileges") {
+			continue
+		}
+		if s.SeccompProfile != "" && strings.HasPrefix(opt, "seccomp") {
+			continue
+		}
+		hc.SecurityOpt = append(hc.SecurityOpt, opt)
+	}
+	if s.NoNewPrivileges {
+		hc.SecurityOpt = append(hc.SecurityOpt, "no-new-privileges")
+	}
+	if s.SeccompProfile != "" {
+		hc.SecurityOpt = append(hc.SecurityOpt, "seccomp="+s.SeccompProfile)
+	}
+
+	return &hc
+}
+
+// volumes returns the directories the files are uploaded to, which have to
+// be backed by volumes when the root filesystem is read-only
+func (s *sandbox) volumes(filesToUpload map[string][]byte) map[string]struct{} {
+	if !s.ReadOnlyRootfs || len(filesToUpload) == 0 {
+		return nil
+	}
+	volumes := map[string]struct{}{}
+	for _, dir := range uploadDirs(filesToUpload) {
+		volumes[dir] = struct{}{}
+	}
+	return volumes
+}
+
+// uploadDirs returns the sorted directories the files are uploaded to
+func uploadDirs(filesToUpload map[string][]byte) []string {
+	var dirs []string
+	for path := range filesToUpload {
+		dir := uploadDir(path)
+		if !contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// uploadDir returns the absolute directory a file is uploaded to
+func uploadDir(path string) string {
+	i := strings.LastIndex(path, "/")
+	if i <= 0 {
+		return "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return "/" + path[:i]
+	}
+	return path[:i]
+}
+
+// platformOf returns the lowercase type of the chaincode built by the
+// builder, or an empty string if unknown
+func platformOf(builder container.Builder) string {
+	if pb, ok := builder.(*container.PlatformBuilder); ok {
+		return strings.ToLower(pb.Type)
+	}
+	return ""
+}
+
+func platformName(platform string) string {
+	if platform == "" {
+		return "default"
+	}
+	return platform
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dockercontroller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	docker "github.com/fsouza/go-dockerclient"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	coreutil "github.com/hyperledger/fabric/core/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSandbox(t *testing.T) {
+	coreutil.SetupTestConfig()
+
+	sb, err := getSandbox("golang")
+	require.NoError(t, err)
+	assert.Equal(t, &sandbox{
+		ReadOnlyRootfs:  true,
+		Tmpfs:           map[string]string{"/tmp": "rw,noexec,nosuid,size=64m"},
+		CapDrop:         []string{"ALL"},
+		NoNewPrivileges: true,
+	}, sb)
+
+	sb, err = getSandbox("java")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"/tmp": "rw,exec,nosuid,size=256m"}, sb.Tmpfs)
+	assert.True(t, sb.ReadOnlyRootfs)
+
+	sb, err = getSandbox("node")
+	require.NoError(t, err)
+	assert.Equal(t, "rw,noexec,nosuid,size=64m", sb.Tmpfs["/root/.npm"])
+
+	viper.Set("vm.docker.sandbox.platforms.car.enabled", false)
+	defer viper.Set("vm.docker.sandbox.platforms.car.enabled", nil)
+	sb, err = getSandbox("car")
+	assert.NoError(t, err)
+	assert.Nil(t, sb)
+}
+
+func TestGetSandboxSeccompProfile(t *testing.T) {
+	coreutil.SetupTestConfig()
+	defer viper.Set("vm.docker.sandbox.seccompProfile", "")
+
+	tempDir, err := ioutil.TempDir("", "sandbox")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	profile := filepath.Join(tempDir, "seccomp.json")
+	err = ioutil.WriteFile(profile, []byte("{\n  \"defaultAction\": \"SCMP_ACT_ERRNO\"\n}\n"), 0644)
+	require.NoError(t, err)
+
+	viper.Set("vm.docker.sandbox.seccompProfile", profile)
+	sb, err := getSandbox("golang")
+	require.NoError(t, err)
+	assert.Equal(t, `{"defaultAction":"SCMP_ACT_ERRNO"}`, sb.SeccompProfile)
+
+	viper.Set("vm.docker.sandbox.seccompProfile", filepath.Join(tempDir, "missing.json"))
+	_, err = getSandbox("golang")
+	assert.Contains(t, err.Error(), "failed reading the seccomp profile of the golang platform")
+
+	err = ioutil.WriteFile(profile, []byte("not json"), 0644)
+	require.NoError(t, err)
+	viper.Set("vm.docker.sandbox.seccompProfile", profile)
+	_, err = getSandbox("golang")
+	assert.Contains(t, err.Error(), "invalid seccomp profile")
+}
+
+func TestSandboxApply(t *testing.T) {
+	hostConfig := &docker.HostConfig{
+		NetworkMode: "host",
+		CapAdd:      []string{"NET_BIND_SERVICE"},
+		CapDrop:     []string{"MKNOD"},
+		SecurityOpt: []string{"seccomp=unconfined", "label=disable", "no-new-privileges:false"},
+		Tmpfs:       map[string]string{"/run": "rw"},
+	}
+	sb := &sandbox{
+		ReadOnlyRootfs:  true,
+		Tmpfs:           map[string]string{"/tmp": "rw,noexec"},
+		CapDrop:         []string{"ALL", "MKNOD"},
+		NoNewPrivileges: true,
+		SeccompProfile:  `{"defaultAction":"SCMP_ACT_ERRNO"}`,
+	}
+
+	hc := sb.apply(hostConfig)
+	assert.Equal(t, "host", hc.NetworkMode)
+	assert.True(t, hc.ReadonlyRootfs)
+	assert.Equal(t, map[string]string{"/run": "rw", "/tmp": "rw,noexec"}, hc.Tmpfs)
+	assert.Equal(t, []string{"NET_BIND_SERVICE"}, hc.CapAdd)
+	assert.Equal(t, []string{"MKNOD", "ALL"}, hc.CapDrop)
+	assert.Equal(t, []string{"label=disable", "no-new-privileges", `seccomp={"defaultAction":"SCMP_ACT_ERRNO"}`}, hc.SecurityOpt)
+
+	// the host configuration is left untouched
+	assert.False(t, hostConfig.ReadonlyRootfs)
+	assert.Equal(t, []string{"MKNOD"}, hostConfig.CapDrop)
+	assert.Equal(t, map[string]string{"/run": "rw"}, hostConfig.Tmpfs)
+
+	// the daemon profile is kept if the sandbox does not set one
+	hc = (&sandbox{}).apply(hostConfig)
+	assert.False(t, hc.ReadonlyRootfs)
+	assert.Equal(t, hostConfig.SecurityOpt, hc.SecurityOpt)
+}
+
+func TestSandboxVolumes(t *testing.T) {
+	files := map[string][]byte{
+		"/etc/hyperledger/fabric/client.key": nil,
+		"/etc/hyperledger/fabric/client.crt": nil,
+		"var/config.yaml":                    nil,
+		"root.txt":                           nil,
+	}
+	assert.Equal(t, []string{"/", "/etc/hyperledger/fabric", "/var"}, uploadDirs(files))
+	assert.Equal(t, map[string]struct{}{"/": {}, "/etc/hyperledger/fabric": {}, "/var": {}}, (&sandbox{ReadOnlyRootfs: true}).volumes(files))
+	assert.Nil(t, (&sandbox{}).volumes(files))
+	assert.Nil(t, (&sandbox{ReadOnlyRootfs: true}).volumes(nil))
+}
+
+func TestStartSandboxed(t *testing.T) {
+	coreutil.SetupTestConfig()
+	client := &mockClient{}
+	dvm := DockerVM{
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+		getClientFnc: func() (dockerClient, error) { return client, nil },
+	}
+	files := map[string][]byte{
+		"/etc/hyperledger/fabric/client.key": []byte("key"),
+		"/etc/hyperledger/fabric/peer.crt":   []byte("cert"),
+	}
+	builder := &container.PlatformBuilder{Type: "JAVA", PlatformRegistry: platforms.NewRegistry()}
+
+	err := dvm.Start(ccintf.CCID{Name: "simple", Version: "1.0"}, nil, nil, files, builder)
+	require.NoError(t, err)
+
+	require.Len(t, client.createOpts, 1)
+	opts := client.createOpts[0]
+	assert.True(t, opts.HostConfig.ReadonlyRootfs)
+	assert.Equal(t, []string{"ALL"}, opts.HostConfig.CapDrop)
+	assert.Equal(t, []string{"no-new-privileges"}, opts.HostConfig.SecurityOpt)
+	assert.Equal(t, map[string]string{"/tmp": "rw,exec,nosuid,size=256m"}, opts.HostConfig.Tmpfs)
+	assert.Equal(t, map[string]struct{}{"/etc/hyperledger/fabric": {}}, opts.Config.Volumes)
+
+	require.Len(t, client.uploads, 1)
+	assert.Equal(t, "/etc/hyperledger/fabric", client.uploads[0].Path)
+	assert.Equal(t, map[string]string{"client.key": "key", "peer.crt": "cert"}, untar(t, client.uploads[0].InputStream))
+
+	// without sandbox, the files are uploaded at once to the root directory
+	viper.Set("vm.docker.sandbox.enabled", false)
+	defer viper.Set("vm.docker.sandbox.enabled", true)
+	client.createOpts, client.uploads = nil, nil
+	err = dvm.Start(ccintf.CCID{Name: "simple", Version: "1.0"}, nil, nil, files, builder)
+	require.NoError(t, err)
+	assert.Nil(t, client.createOpts[0].Config.Volumes)
+	assert.False(t, client.createOpts[0].HostConfig.ReadonlyRootfs)
+	require.Len(t, client.uploads, 1)
+	assert.Equal(t, "/", client.uploads[0].Path)
+	assert.Equal(t, map[string]string{
+		"/etc/hyperledger/fabric/client.key": "key",
+		"/etc/hyperledger/fabric/peer.crt":   "cert",
+	}, untar(t, client.uploads[0].InputStream))
+}
+
+func untar(t *testing.T, r io.Reader) map[string]string {
+	gr, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
@@ -7,12 +7,16 @@ SPDX-License-Identifier: Apache-2.0
 package util
 
 import (
+	"encoding/pem"
+	"io/ioutil"
+	"path/filepath"
 	"runtime"
 	"strings"
 
 	docker "github.com/fsouza/go-dockerclient"
 	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -45,3 +49,57 @@ func ParseDockerfileTemplate(template string) string {
 func GetDockerfileFromConfig(path string) string {
 	return ParseDockerfileTemplate(viper.GetString(path))
 }
+
+// BuildCACertsFile is the name of the bundle of the CA certificates of the
+// chaincode builds, in the build contexts and in the builder containers
+const BuildCACertsFile = "fabric-build-ca.crt"
+
+// BuildProxyEnvFromConfig returns the proxy environment variables of the
+// chaincode builds configured under chaincode.build, in upper and lower case
+// as the tools of the builders read either
+func BuildProxyEnvFromConfig() []string {
+	var env []string
+	for _, proxy := range []struct{ key, name string }{
+		{"chaincode.build.httpProxy", "HTTP_PROXY"},
+		{"chaincode.build.httpsProxy", "HTTPS_PROXY"},
+		{"chaincode.build.noProxy", "NO_PROXY"},
+	} {
+		if value := viper.GetString(proxy.key); value != "" {
+			env = append(env, proxy.name+"="+value, strings.ToLower(proxy.name)+"="+value)
+		}
+	}
+	return env
+}
+
+// BuildCACertsFromConfig returns the PEM bundle of the CA certificates which
+// the chaincode builds trust in addition to the CAs of their images, e.g. the
+// CAs of the TLS inspecting proxies of a corporate network. The certificates
+// are read from the files configured under chaincode.build.caCerts, relative
+// to the configuration file
+func BuildCACertsFromConfig() ([]byte, error) {
+	var bundle []byte
+	base := filepath.Dir(viper.ConfigFileUsed())
+	for _, file := range viper.GetStringSlice("chaincode.build.caCerts") {
+		pemBytes, err := ioutil.ReadFile(config.TranslatePath(base, file))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read the CA certificates of the chaincode builds")
+		}
+		rest, found := pemBytes, false
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" {
+				return nil, errors.Errorf("%s holds a PEM block of type %s, only certificates are expected", file, block.Type)
+			}
+			bundle = append(bundle, pem.EncodeToMemory(block)...)
+			found = true
+		}
+		if !found {
+			return nil, errors.Errorf("%s holds no PEM certificate", file)
+		}
+	}
+	return bundle, nil
+}
@@ -7,12 +7,18 @@ SPDX-License-Identifier: Apache-2.0
 package util
 
 import (
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
 	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUtil_DockerfileTemplateParser(t *testing.T) {
@@ -36,3 +42,53 @@ func TestUtil_GetDockertClient(t *testing.T) {
 	_, err := NewDockerClient()
 	assert.NoError(t, err, "Error getting docker client")
 }
+
+func TestBuildProxyEnvFromConfig(t *testing.T) {
+	defer viper.Reset()
+	assert.Empty(t, BuildProxyEnvFromConfig())
+
+	viper.Set("chaincode.build.httpProxy", "http://proxy.example.com:3128")
+	viper.Set("chaincode.build.noProxy", "localhost,.example.com")
+	assert.Equal(t, []string{
+		"HTTP_PROXY=http://proxy.example.com:3128",
+		"http_proxy=http://proxy.example.com:3128",
+		"NO_PROXY=localhost,.example.com",
+		"no_proxy=localhost,.example.com",
+	}, BuildProxyEnvFromConfig())
+}
+
+func TestBuildCACertsFromConfig(t *testing.T) {
+	defer viper.Reset()
+	bundle, err := BuildCACertsFromConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, bundle)
+
+	dir, err := ioutil.TempDir("", "buildcacerts")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	ca1, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	ca2, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ca1.pem"), ca1.CertBytes(), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "ca2.pem"), ca2.CertBytes(), 0644))
+
+	viper.Set("chaincode.build.caCerts", []string{filepath.Join(dir, "ca1.pem"), filepath.Join(dir, "ca2.pem")})
+	bundle, err = BuildCACertsFromConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, append(ca1.CertBytes(), ca2.CertBytes()...), bundle)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}), 0600))
+	viper.Set("chaincode.build.caCerts", []string{filepath.Join(dir, "key.pem")})
+	_, err = BuildCACertsFromConfig()
+	assert.EqualError(t, err, filepath.Join(dir, "key.pem")+" holds a PEM block of type EC PRIVATE KEY, only certificates are expected")
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "empty.pem"), nil, 0644))
+	viper.Set("chaincode.build.caCerts", []string{filepath.Join(dir, "empty.pem")})
+	_, err = BuildCACertsFromConfig()
+	assert.EqualError(t, err, filepath.Join(dir, "empty.pem")+" holds no PEM certificate")
+
+	viper.Set("chaincode.build.caCerts", []string{filepath.Join(dir, "missing.pem")})
+	_, err = BuildCACertsFromConfig()
+	assert.Contains(t, err.Error(), "could not read the CA certificates of the chaincode builds")
+}
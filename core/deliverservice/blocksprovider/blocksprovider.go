@@ -133,7 +133,11 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 				logger.Warningf("[%s] ERROR! Received success for a seek that should never complete", b.chainID)
 				return
 			}
-			if t.Status == common.Status_BAD_REQUEST || t.Status == common.Status_FORBIDDEN {
+			if t.Status == common.Status_REQUEST_ENTITY_TOO_LARGE {
+				logger.Errorf("[%s] The ordering service refused to send the next block as it exceeds the maximum message size "+
+					"this peer can receive, the peer.maxRecvMsgSize of the peer must be raised above the maximum block size of the channel", b.chainID)
+			}
+			if t.Status == common.Status_BAD_REQUEST || t.Status == common.Status_FORBIDDEN || t.Status == common.Status_REQUEST_ENTITY_TOO_LARGE {
 				logger.Errorf("[%s] Got error %v", b.chainID, t)
 				errorStatusCounter++
 				if errorStatusCounter > b.wrongStatusThreshold {
@@ -371,6 +371,41 @@ func TestBlocksProvider_DeliveryWrongStatusClose(t *testing.T) {
 	})
 }
 
+func TestBlocksProvider_DeliveryBlockTooLarge(t *testing.T) {
+	// Test emulates an orderer refusing to deliver a block exceeding the maximum message
+	// size of the peer. The status counts as a wrong status, as the block will never fit
+	orgEndpointDisableInterval := comm.EndpointDisableInterval
+	comm.EndpointDisableInterval = 0
+	defer func() { comm.EndpointDisableInterval = orgEndpointDisableInterval }()
+
+	bd := mocks.MockBlocksDeliverer{
+		DisconnectCalled:           make(chan struct{}, 10),
+		DisconnectAndDisableCalled: make(chan struct{}, 10),
+		CloseCalled:                make(chan struct{}, 1),
+	}
+	provider := &blocksProviderImpl{
+		chainID:              "***TEST_CHAINID***",
+		gossip:               &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64, 2)},
+		client:               &bd,
+		mcs:                  &mockMCS{},
+		wrongStatusThreshold: 2,
+	}
+
+	bd.MockRecv = func(mock *mocks.MockBlocksDeliverer) (*orderer.DeliverResponse, error) {
+		return &orderer.DeliverResponse{
+			Type: &orderer.DeliverResponse_Status{Status: common.Status_REQUEST_ENTITY_TOO_LARGE},
+		}, nil
+	}
+
+	go provider.DeliverBlocks()
+
+	waitUntilOrFail(t, func() bool {
+		return len(bd.CloseCalled) == 1
+	})
+	assert.Len(t, bd.DisconnectAndDisableCalled, 2)
+	assert.Len(t, bd.DisconnectCalled, 0)
+}
+
 func TestBlocksProvider_DeliveryServiceDisableEndpoints(t *testing.T) {
 	sendStatus := func(status common.Status) *orderer.DeliverResponse {
 		return &orderer.DeliverResponse{
@@ -166,7 +166,9 @@ func (bc *broadcastClient) connect() error {
 		logger.Error("Failed obtaining connection:", err)
 		return err
 	}
-	ctx, cf := context.WithCancel(context.Background())
+	// advertise the maximum size of the blocks we can receive, so that the orderer
+	// rejects explicitly the blocks that exceed it
+	ctx, cf := context.WithCancel(comm.WithMaxRecvMsgSize(context.Background(), comm.MaxRecvMsgSize))
 	logger.Debug("Establishing gRPC stream with", endpoint, "...")
 	abc, err := bc.createClient(conn).Deliver(ctx)
 	if err != nil {
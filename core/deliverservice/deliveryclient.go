@@ -15,6 +15,8 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -72,6 +74,7 @@ type DeliverService interface {
 // blocks providers
 type deliverServiceImpl struct {
 	conf           *Config
+	metrics        *comm.ProducerMetrics
 	blockProviders map[string]blocksprovider.BlocksProvider
 	lock           sync.RWMutex
 	stopping       bool
@@ -94,6 +97,9 @@ type Config struct {
 	Gossip blocksprovider.GossipServiceAdapter
 	// Endpoints specifies the endpoints of the ordering service
 	Endpoints []string
+	// MetricsProvider provides the metrics of the failovers between the
+	// endpoints of the ordering service
+	MetricsProvider metrics.Provider
 }
 
 // NewDeliverService construction function to create and initialize
@@ -108,6 +114,11 @@ func NewDeliverService(conf *Config) (DeliverService, error) {
 	if err := ds.validateConfiguration(); err != nil {
 		return nil, err
 	}
+	metricsProvider := conf.MetricsProvider
+	if metricsProvider == nil {
+		metricsProvider = &disabled.Provider{}
+	}
+	ds.metrics = comm.NewProducerMetrics(metricsProvider)
 	return ds, nil
 }
 
@@ -230,7 +241,10 @@ func (d *deliverServiceImpl) newClient(chainID string, ledgerInfoProvider blocks
 		attempt := float64(attemptNum)
 		return time.Duration(math.Min(math.Pow(2, attempt)*sleepIncrement, reconnectBackoffThreshold)), true
 	}
-	connProd := comm.NewConnectionProducer(d.conf.ConnFactory(chainID), d.conf.Endpoints)
+	connProd := comm.NewConnectionProducerWithConfig(d.conf.ConnFactory(chainID), d.conf.Endpoints, comm.ProducerConfig{
+		Channel: chainID,
+		Metrics: d.metrics,
+	})
 	bClient := NewBroadcastClient(connProd, d.conf.ABCFactory, broadcastSetup, backoffPolicy)
 	requester.client = bClient
 	return bClient
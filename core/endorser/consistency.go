@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// ChannelLedger is the part of the ledger of a channel the LedgerHeightGate
+// waits on
+type ChannelLedger interface {
+	GetBlockchainInfo() (*common.BlockchainInfo, error)
+	GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error)
+}
+
+// StaleLedgerError is returned for a proposal that requires the ledger of the
+// channel to have reached a height that it did not reach in time
+type StaleLedgerError struct {
+	ChannelID string
+	Height    uint64
+	MinHeight uint64
+	// Peers are the endpoints of the peers of the channel which advertise a
+	// ledger at the minimum height, which the client can send the proposal to
+	Peers []string
+}
+
+func (e *StaleLedgerError) Error() string {
+	msg := fmt.Sprintf("the ledger of channel %s is at height %d, below the minimum ledger height %d of the proposal", e.ChannelID, e.Height, e.MinHeight)
+	if len(e.Peers) > 0 {
+		msg += fmt.Sprintf(", the proposal can be sent to the peers at that height: %s", strings.Join(e.Peers, ", "))
+	}
+	return msg
+}
+
+// LedgerHeightGate holds the proposals that carry a minimum ledger height, the
+// commit token of an earlier transaction of the client, until the ledger of the
+// channel reaches it, so that the clients reading through different peers get a
+// monotonic view of the ledger
+type LedgerHeightGate struct {
+	// WaitTimeout is how long a proposal waits for the ledger to reach its
+	// minimum height before being rejected. Zero rejects the proposal right away
+	WaitTimeout time.Duration
+	// Ledger returns the ledger of a channel, nil if the peer did not join it
+	Ledger func(channelID string) ChannelLedger
+	// PeersAtHeight returns the endpoints of the peers of a channel which
+	// advertise a ledger of at least the given height, if any
+	PeersAtHeight func(channelID string, height uint64) []string
+
+	mutex sync.RWMutex
+}
+
+// SetWaitTimeout changes how long the proposals wait for the ledger to reach
+// their minimum height, while proposals are being endorsed
+func (g *LedgerHeightGate) SetWaitTimeout(waitTimeout time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.WaitTimeout = waitTimeout
+}
+
+func (g *LedgerHeightGate) waitTimeout() time.Duration {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.WaitTimeout
+}
+
+// Wait returns when the ledger of the channel reached the given height, or
+// returns a StaleLedgerError if it did not within the wait timeout
+func (g *LedgerHeightGate) Wait(channelID string, minHeight uint64) error {
+	lgr := g.Ledger(channelID)
+	if lgr == nil {
+		return errors.Errorf("channel %s doesn't exist", channelID)
+	}
+	height, err := ledgerHeight(lgr)
+	if err != nil {
+		return err
+	}
+	if waitTimeout := g.waitTimeout(); height < minHeight && waitTimeout > 0 {
+		if height, err = g.waitForHeight(lgr, minHeight, waitTimeout); err != nil {
+			return err
+		}
+	}
+	if height >= minHeight {
+		return nil
+	}
+
+	staleErr := &StaleLedgerError{ChannelID: channelID, Height: height, MinHeight: minHeight}
+	if g.PeersAtHeight != nil {
+		staleErr.Peers = g.PeersAtHeight(channelID, minHeight)
+	}
+	return staleErr
+}
+
+// waitForHeight waits for the block that brings the ledger to the given height
+// to be committed, up to the wait timeout, and returns the height of the ledger
+func (g *LedgerHeightGate) waitForHeight(lgr ChannelLedger, minHeight uint64, waitTimeout time.Duration) (uint64, error) {
+	itr, err := lgr.GetBlocksIterator(minHeight - 1)
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to wait for the ledger height")
+	}
+	committed := make(chan struct{})
+	go func() {
+		// Next blocks until the block is committed or the iterator is closed
+		itr.Next()
+		close(committed)
+	}()
+
+	timer := time.NewTimer(waitTimeout)
+	defer timer.Stop()
+	select {
+	case <-committed:
+	case <-timer.C:
+	}
+	itr.Close()
+	return ledgerHeight(lgr)
+}
+
+func ledgerHeight(lgr ChannelLedger) (uint64, error) {
+	info, err := lgr.GetBlockchainInfo()
+	if err != nil {
+		return 0, errors.WithMessage(err, "failed to get the ledger height")
+	}
+	return info.Height, nil
+}
@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// heightLedger is a ledger whose height grows by the commits of the test
+type heightLedger struct {
+	mutex   sync.Mutex
+	cond    *sync.Cond
+	height  uint64
+	infoErr error
+}
+
+func newHeightLedger(height uint64) *heightLedger {
+	l := &heightLedger{height: height}
+	l.cond = sync.NewCond(&l.mutex)
+	return l
+}
+
+func (l *heightLedger) commit() {
+	l.mutex.Lock()
+	l.height++
+	l.mutex.Unlock()
+	l.cond.Broadcast()
+}
+
+func (l *heightLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return &common.BlockchainInfo{Height: l.height}, l.infoErr
+}
+
+func (l *heightLedger) GetBlocksIterator(startBlockNumber uint64) (commonledger.ResultsIterator, error) {
+	return &heightItr{ledger: l, blockNum: startBlockNumber}, nil
+}
+
+type heightItr struct {
+	ledger   *heightLedger
+	blockNum uint64
+	closed   bool
+}
+
+func (itr *heightItr) Next() (commonledger.QueryResult, error) {
+	l := itr.ledger
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for l.height <= itr.blockNum && !itr.closed {
+		l.cond.Wait()
+	}
+	if itr.closed {
+		return nil, nil
+	}
+	return &common.Block{Header: &common.BlockHeader{Number: itr.blockNum}}, nil
+}
+
+func (itr *heightItr) Close() {
+	itr.ledger.mutex.Lock()
+	itr.closed = true
+	itr.ledger.mutex.Unlock()
+	itr.ledger.cond.Broadcast()
+}
+
+func TestLedgerHeightGate(t *testing.T) {
+	lgr := newHeightLedger(5)
+	gate := &LedgerHeightGate{
+		WaitTimeout: 10 * time.Second,
+		Ledger: func(channelID string) ChannelLedger {
+			if channelID != "mychannel" {
+				return nil
+			}
+			return lgr
+		},
+		PeersAtHeight: func(channelID string, height uint64) []string {
+			return []string{"peer1.org1:7051", "peer0.org2:7051"}
+		},
+	}
+
+	assert.NoError(t, gate.Wait("mychannel", 5))
+	assert.EqualError(t, gate.Wait("otherchannel", 5), "channel otherchannel doesn't exist")
+
+	// the proposal waits for the commit of the block bringing the ledger to its height
+	done := make(chan error)
+	go func() {
+		done <- gate.Wait("mychannel", 7)
+	}()
+	lgr.commit()
+	lgr.commit()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the proposal was not released by the commits")
+	}
+
+	// the proposal is rejected when the ledger does not reach its height in time
+	gate.SetWaitTimeout(10 * time.Millisecond)
+	err := gate.Wait("mychannel", 9)
+	assert.EqualError(t, err, "the ledger of channel mychannel is at height 7, below the minimum ledger height 9 of the proposal, "+
+		"the proposal can be sent to the peers at that height: peer1.org1:7051, peer0.org2:7051")
+	assert.Equal(t, &StaleLedgerError{
+		ChannelID: "mychannel",
+		Height:    7,
+		MinHeight: 9,
+		Peers:     []string{"peer1.org1:7051", "peer0.org2:7051"},
+	}, err)
+
+	gate.SetWaitTimeout(0)
+	gate.PeersAtHeight = nil
+	assert.EqualError(t, gate.Wait("mychannel", 8), "the ledger of channel mychannel is at height 7, below the minimum ledger height 8 of the proposal")
+
+	lgr.infoErr = errors.New("boom")
+	assert.EqualError(t, gate.Wait("mychannel", 8), "failed to get the ledger height: boom")
+}
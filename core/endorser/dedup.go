@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// DuplicateTxIDError is returned for a proposal that carries the transaction ID
+// of a proposal that is either being endorsed or was endorsed with a different content
+type DuplicateTxIDError struct {
+	TxID   string
+	Reason string
+}
+
+func (e *DuplicateTxIDError) Error() string {
+	return pb.TxValidationCode_DUPLICATE_TXID.String() + ": transaction " + e.TxID + " " + e.Reason
+}
+
+// DedupCache remembers, for a configured window of time, the transaction IDs of the
+// proposals endorsed by the peer, along with the proposal responses. It allows the
+// endorser to answer a client that retries a proposal with the response that was
+// already produced, instead of simulating the proposal again, and to reject the
+// proposals that reuse the transaction ID of a proposal being endorsed.
+// Failed endorsements are not remembered so that they can be retried
+type DedupCache struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	lock    sync.Mutex
+	entries map[dedupKey]*list.Element
+	order   *list.List
+}
+
+type dedupKey struct {
+	chainID string
+	txID    string
+}
+
+type dedupEntry struct {
+	key          dedupKey
+	proposalHash []byte
+	// resp is nil while the proposal is being endorsed
+	resp   *pb.ProposalResponse
+	expiry time.Time
+}
+
+// NewDedupCache constructs a DedupCache that remembers each transaction ID for the given
+// window and holds at most maxEntries of them. A nil DedupCache is returned if the window
+// is zero, which the endorser treats as a disabled deduplication
+func NewDedupCache(window time.Duration, maxEntries int) *DedupCache {
+	if window <= 0 || maxEntries <= 0 {
+		return nil
+	}
+	return &DedupCache{
+		window:     window,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[dedupKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// SetLimits changes the window and the maximum number of entries of the cache. The
+// entries already remembered keep their expiry, and the oldest ones are removed if
+// the cache holds more than maxEntries of them. The deduplication cannot be disabled
+// without restarting the peer
+func (c *DedupCache) SetLimits(window time.Duration, maxEntries int) error {
+	if window <= 0 || maxEntries <= 0 {
+		return errors.New("the deduplication of the transaction IDs cannot be disabled without a restart")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.window = window
+	c.maxEntries = maxEntries
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Front())
+	}
+	return nil
+}
+
+// Acquire registers the proposal as being endorsed. If the same proposal was already
+// endorsed within the window, its proposal response is returned instead and the proposal
+// is not registered. A DuplicateTxIDError is returned if the transaction ID is in use by
+// another proposal. A registered proposal must be completed with the function Complete
+func (c *DedupCache) Acquire(chainID, txID string, proposalBytes []byte) (*pb.ProposalResponse, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.purge()
+
+	key := dedupKey{chainID: chainID, txID: txID}
+	proposalHash := util.ComputeSHA256(proposalBytes)
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*dedupEntry)
+		switch {
+		case !bytes.Equal(entry.proposalHash, proposalHash):
+			return nil, &DuplicateTxIDError{TxID: txID, Reason: "was already used by a different proposal"}
+		case entry.resp == nil:
+			return nil, &DuplicateTxIDError{TxID: txID, Reason: "is being endorsed"}
+		default:
+			return entry.resp, nil
+		}
+	}
+
+	for c.order.Len() >= c.maxEntries {
+		c.remove(c.order.Front())
+	}
+	c.entries[key] = c.order.PushBack(&dedupEntry{
+		key:          key,
+		proposalHash: proposalHash,
+		expiry:       c.now().Add(c.window),
+	})
+	return nil, nil
+}
+
+// Complete records the outcome of the endorsement of a proposal registered by the function
+// Acquire. The proposal response is kept for the rest of the window if the endorsement
+// succeeded, otherwise the transaction ID is forgotten so that the proposal can be retried
+func (c *DedupCache) Complete(chainID, txID string, resp *pb.ProposalResponse) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, exists := c.entries[dedupKey{chainID: chainID, txID: txID}]
+	if !exists {
+		return
+	}
+	entry := element.Value.(*dedupEntry)
+	if entry.resp != nil {
+		return
+	}
+	if resp == nil || resp.Endorsement == nil || resp.Response == nil || resp.Response.Status >= shim.ERRORTHRESHOLD {
+		c.remove(element)
+		return
+	}
+	entry.resp = resp
+}
+
+// purge removes the expired entries. The caller is expected to hold the lock
+func (c *DedupCache) purge() {
+	now := c.now()
+	for element := c.order.Front(); element != nil; element = c.order.Front() {
+		if element.Value.(*dedupEntry).expiry.After(now) {
+			return
+		}
+		c.remove(element)
+	}
+}
+
+func (c *DedupCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*dedupEntry)
+	delete(c.entries, entry.key)
+}
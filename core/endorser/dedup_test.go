@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupCacheDisabled(t *testing.T) {
+	assert.Nil(t, NewDedupCache(0, 10))
+	assert.Nil(t, NewDedupCache(time.Minute, 0))
+}
+
+func TestDedupCache(t *testing.T) {
+	now := time.Now()
+	cache := NewDedupCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+
+	successResp := &pb.ProposalResponse{Response: &pb.Response{Status: 200}, Endorsement: &pb.Endorsement{}}
+
+	resp, err := cache.Acquire("ch1", "tx1", []byte("prop1"))
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	// the transaction ID is in use while the proposal is being endorsed
+	_, err = cache.Acquire("ch1", "tx1", []byte("prop1"))
+	assert.EqualError(t, err, "DUPLICATE_TXID: transaction tx1 is being endorsed")
+	// transaction IDs are specific to a channel
+	resp, err = cache.Acquire("ch2", "tx1", []byte("prop1"))
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	cache.Complete("ch1", "tx1", successResp)
+	resp, err = cache.Acquire("ch1", "tx1", []byte("prop1"))
+	assert.NoError(t, err)
+	assert.Equal(t, successResp, resp)
+	// the response is not served for a different proposal
+	_, err = cache.Acquire("ch1", "tx1", []byte("prop2"))
+	assert.EqualError(t, err, "DUPLICATE_TXID: transaction tx1 was already used by a different proposal")
+
+	// a failed endorsement can be retried
+	cache.Complete("ch2", "tx1", &pb.ProposalResponse{Response: &pb.Response{Status: 500}})
+	resp, err = cache.Acquire("ch2", "tx1", []byte("prop1"))
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+
+	// the transaction ID is forgotten at the end of the window
+	now = now.Add(time.Minute)
+	resp, err = cache.Acquire("ch1", "tx1", []byte("prop2"))
+	assert.NoError(t, err)
+	assert.Nil(t, resp)
+	assert.Len(t, cache.entries, 1)
+}
+
+func TestDedupCacheMaxEntries(t *testing.T) {
+	cache := NewDedupCache(time.Minute, 2)
+	for _, txID := range []string{"tx1", "tx2", "tx3"} {
+		_, err := cache.Acquire("ch1", txID, []byte(txID))
+		assert.NoError(t, err)
+	}
+	assert.Len(t, cache.entries, 2)
+	// the oldest transaction ID was forgotten
+	_, err := cache.Acquire("ch1", "tx1", []byte("tx1"))
+	assert.NoError(t, err)
+	_, err = cache.Acquire("ch1", "tx3", []byte("tx3"))
+	assert.Error(t, err)
+
+	// completing a forgotten proposal is a no-op
+	cache.Complete("ch1", "tx2", &pb.ProposalResponse{})
+	assert.Len(t, cache.entries, 2)
+}
+
+func TestDedupCacheSetLimits(t *testing.T) {
+	now := time.Now()
+	cache := NewDedupCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+	for _, txID := range []string{"tx1", "tx2", "tx3"} {
+		_, err := cache.Acquire("ch1", txID, []byte(txID))
+		assert.NoError(t, err)
+	}
+
+	assert.EqualError(t, cache.SetLimits(0, 10), "the deduplication of the transaction IDs cannot be disabled without a restart")
+	assert.EqualError(t, cache.SetLimits(time.Minute, 0), "the deduplication of the transaction IDs cannot be disabled without a restart")
+
+	// the oldest transaction IDs are forgotten past the new maximum
+	assert.NoError(t, cache.SetLimits(time.Hour, 2))
+	assert.Len(t, cache.entries, 2)
+	_, err := cache.Acquire("ch1", "tx1", []byte("other"))
+	assert.NoError(t, err)
+
+	// the new transaction IDs are remembered for the new window
+	now = now.Add(30 * time.Minute)
+	_, err = cache.Acquire("ch1", "tx1", []byte("other"))
+	assert.EqualError(t, err, "DUPLICATE_TXID: transaction tx1 is being endorsed")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -106,6 +107,16 @@ type Endorser struct {
 	PlatformRegistry      *platforms.Registry
 	PvtRWSetAssembler
 	Metrics *EndorserMetrics
+	// DedupCache, if set, remembers the transaction IDs of the recently endorsed
+	// proposals so that the retries of a proposal are not endorsed twice
+	DedupCache *DedupCache
+	// HeightGate, if set, holds the proposals that carry a minimum ledger
+	// height until the ledger of the channel reaches it
+	HeightGate *LedgerHeightGate
+	// QueryCache, if set, remembers the results of the simulations of the
+	// read-only proposals so that identical proposals are not simulated again
+	// until the state of the channel changes
+	QueryCache *QueryCache
 }
 
 // validateResult provides the result of endorseProposal verification
@@ -205,6 +216,8 @@ func (e *Endorser) SanitizeUserCDS(userCDS *pb.ChaincodeDeploymentSpec) (*pb.Cha
 	sanitizedCDS := proto.Clone(fsCDS).(*pb.ChaincodeDeploymentSpec)
 	sanitizedCDS.CodePackage = nil
 	sanitizedCDS.ChaincodeSpec.Input = userCDS.ChaincodeSpec.Input
+	sanitizedCDS.Migrate = userCDS.Migrate
+	sanitizedCDS.InitRequired = userCDS.InitRequired
 
 	return sanitizedCDS, nil
 }
@@ -393,17 +406,43 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 			// increment failure due to duplicate transactions. Useful for catching replay attacks in
 			// addition to benign retries
 			e.Metrics.DuplicateTxsFailure.With(meterLabels...).Add(1)
-			err = errors.Errorf("duplicate transaction found [%s]. Creator [%x]", txid, shdr.Creator)
+			e.recordFailure(chainID, hdrExt.ChaincodeId, FailureDuplicateTxID)
+			err = errors.Errorf("%s: duplicate transaction found [%s]. Creator [%x]", pb.TxValidationCode_DUPLICATE_TXID, txid, shdr.Creator)
 			vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
 			return vr, err
 		}
 
+		// an expired or a premature proposal could only yield a transaction
+		// the committers reject
+		if chdr.ExpirationHeight > 0 || chdr.ExpirationTime != nil || putils.IsScheduled(chdr) {
+			height, err := e.s.GetLedgerHeight(chainID)
+			if err != nil {
+				e.recordFailure(chainID, hdrExt.ChaincodeId, FailureInternal)
+				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+				return vr, err
+			}
+			now := time.Now()
+			if err = validation.CheckExpiration(chdr, height, now); err != nil {
+				e.recordFailure(chainID, hdrExt.ChaincodeId, FailureExpired)
+				err = errors.Errorf("%s: %s", pb.TxValidationCode_EXPIRED_TRANSACTION, err)
+				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+				return vr, err
+			}
+			if err = validation.CheckSchedule(chdr, height, now); err != nil {
+				e.recordFailure(chainID, hdrExt.ChaincodeId, FailurePremature)
+				err = errors.Errorf("%s: %s", pb.TxValidationCode_PREMATURE_TRANSACTION, err)
+				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
+				return vr, err
+			}
+		}
+
 		// check ACL only for application chaincodes; ACLs
 		// for system chaincodes are checked elsewhere
 		if !e.s.IsSysCC(hdrExt.ChaincodeId.Name) {
 			// check that the proposal complies with the Channel's writers
 			if err = e.s.CheckACL(signedProp, chdr, shdr, hdrExt); err != nil {
 				e.Metrics.ProposalACLCheckFailed.With(meterLabels...).Add(1)
+				e.recordFailure(chainID, hdrExt.ChaincodeId, FailureACLDenied)
 				vr.resp = &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}
 				return vr, err
 			}
@@ -420,7 +459,7 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 }
 
 // ProcessProposal process the Proposal
-func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (resp *pb.ProposalResponse, err error) {
 	// start time for computing elapsed time metric for successfully endorsed proposals
 	startTime := time.Now()
 	e.Metrics.ProposalsReceived.Add(1)
@@ -431,7 +470,9 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	// variables to capture proposal duration metric
 	var chainID string
 	var hdrExt *pb.ChaincodeHeaderExtension
-	var success bool
+	var function string
+	var success, replayed bool
+	var failure string
 	defer func() {
 		// capture proposal duration metric. hdrExt == nil indicates early failure
 		// where we don't capture latency metric. But the ProposalValidationFailed
@@ -443,6 +484,25 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 				"success", strconv.FormatBool(success),
 			}
 			e.Metrics.ProposalDuration.With(meterLabels...).Observe(time.Since(startTime).Seconds())
+
+			result := resultSuccess
+			switch {
+			case replayed:
+				result = resultReplayed
+			case !success:
+				if failure == "" {
+					failure = FailureInternal
+				}
+				result = failure
+				e.recordFailure(chainID, hdrExt.ChaincodeId, failure)
+			}
+			ccName := hdrExt.ChaincodeId.Name + ":" + hdrExt.ChaincodeId.Version
+			e.Metrics.FunctionDuration.With(
+				"channel", chainID,
+				"chaincode", ccName,
+				"function", e.Metrics.functionLabels.label(ccName, function),
+				"result", result,
+			).Observe(time.Since(startTime).Seconds())
 		}
 
 		endorserLogger.Debug("Exit: request from", addr)
@@ -456,13 +516,58 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	}
 
 	prop, hdrExt, chainID, txid := vr.prop, vr.hdrExt, vr.chainID, vr.txid
+	function = proposalFunction(prop)
+
+	// a client that read or wrote at a given height through another peer must
+	// not read an older state through this one
+	if e.HeightGate != nil && chainID != "" && hdrExt.MinLedgerHeight > 0 {
+		if err := e.HeightGate.Wait(chainID, hdrExt.MinLedgerHeight); err != nil {
+			endorserLogger.Debugf("[%s][%s] rejecting the proposal: %s", chainID, shorttxid(txid), err)
+			failure = FailureStaleLedger
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+		}
+	}
+
+	// a retried proposal is answered with the response of the earlier endorsement,
+	// if any, rather than being simulated and endorsed again
+	if e.DedupCache != nil && chainID != "" {
+		meterLabels := []string{
+			"channel", chainID,
+			"chaincode", hdrExt.ChaincodeId.Name + ":" + hdrExt.ChaincodeId.Version,
+		}
+		cachedResp, err := e.DedupCache.Acquire(chainID, txid, signedProp.ProposalBytes)
+		if err != nil {
+			e.Metrics.DuplicateTxsFailure.With(meterLabels...).Add(1)
+			failure = FailureDuplicateTxID
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+		if cachedResp != nil {
+			endorserLogger.Debugf("[%s][%s] returning the response of the earlier endorsement of txid: %s", chainID, shorttxid(txid), txid)
+			e.Metrics.DuplicateTxsReplayed.With(meterLabels...).Add(1)
+			replayed = true
+			return cachedResp, nil
+		}
+		defer func() {
+			e.DedupCache.Complete(chainID, txid, resp)
+		}()
+	}
+
+	// an application chaincode invoked with the same input by the same creator
+	// since the last commit is answered with the cached result of its simulation
+	var query *Query
+	var cachedResult *QueryResult
+	if e.QueryCache != nil && chainID != "" && !e.s.IsSysCC(hdrExt.ChaincodeId.Name) {
+		if query = e.QueryCache.NewQuery(chainID, hdrExt.ChaincodeId.Name, prop); query != nil {
+			cachedResult = query.Result()
+		}
+	}
 
 	// obtaining once the tx simulator for this proposal. This will be nil
 	// for chainless proposals
 	// Also obtain a history query executor for history queries, since tx simulator does not cover history
 	var txsim ledger.TxSimulator
 	var historyQueryExecutor ledger.HistoryQueryExecutor
-	if acquireTxSimulator(chainID, vr.hdrExt.ChaincodeId) {
+	if cachedResult == nil && acquireTxSimulator(chainID, vr.hdrExt.ChaincodeId) {
 		if txsim, err = e.s.GetTxSimulator(chainID, txid); err != nil {
 			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 		}
@@ -497,13 +602,34 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	//       to validate the supplied action before endorsing it
 
 	// 1 -- simulate
-	cd, res, simulationResult, ccevent, err := e.SimulateProposal(txParams, hdrExt.ChaincodeId)
-	if err != nil {
-		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	var cd ccprovider.ChaincodeDefinition
+	var res *pb.Response
+	var simulationResult []byte
+	var ccevent *pb.ChaincodeEvent
+	var simulationTime time.Duration
+	if cachedResult != nil {
+		endorserLogger.Debugf("[%s][%s] endorsing the cached result of an identical proposal for txid: %s", chainID, shorttxid(txid), txid)
+		e.Metrics.QueryCacheHits.With(
+			"channel", chainID,
+			"chaincode", hdrExt.ChaincodeId.Name+":"+hdrExt.ChaincodeId.Version,
+		).Add(1)
+		cd, res, simulationResult = cachedResult.ChaincodeDefinition, cachedResult.Response, cachedResult.SimulationResult
+	} else {
+		simulationStart := time.Now()
+		cd, res, simulationResult, ccevent, err = e.SimulateProposal(txParams, hdrExt.ChaincodeId)
+		simulationTime = time.Since(simulationStart)
+		if err != nil {
+			failure = classifySimulationError(err)
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+		}
+		if query != nil {
+			query.Store(&QueryResult{ChaincodeDefinition: cd, Response: res, SimulationResult: simulationResult}, ccevent)
+		}
 	}
 	if res != nil {
 		if res.Status >= shim.ERROR {
 			endorserLogger.Errorf("[%s][%s] simulateProposal() resulted in chaincode %s response status %d for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, res.Status, txid)
+			failure = FailureChaincodeError
 			var cceventBytes []byte
 			if ccevent != nil {
 				cceventBytes, err = putils.GetBytesChaincodeEvent(ccevent)
@@ -520,6 +646,20 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		}
 	}
 
+	// the resources used by the simulation of a proposal to an application
+	// chaincode are reported to the client, and must fit the budget of the channel
+	var usage *pb.ResourceUsage
+	if chainID != "" && cd != nil {
+		if usage, err = measureUsage(simulationResult, ccevent, res, simulationTime); err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+		}
+		if err = e.checkResourceBudget(chainID, usage); err != nil {
+			endorserLogger.Warningf("[%s][%s] rejecting the proposal to chaincode %s: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId.Name, err)
+			failure = FailureResourceBudget
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: "RESOURCE_BUDGET_EXCEEDED: " + err.Error()}}, nil
+		}
+	}
+
 	// 2 -- endorse and get a marshalled ProposalResponse message
 	var pResp *pb.ProposalResponse
 
@@ -540,6 +680,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		if err != nil {
 			meterLabels = append(meterLabels, "chaincodeerror", strconv.FormatBool(false))
 			e.Metrics.EndorsementsFailed.With(meterLabels...).Add(1)
+			failure = FailureEndorsement
 			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
 		}
 		if pResp.Response.Status >= shim.ERRORTHRESHOLD {
@@ -547,6 +688,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 			// useful to track this as a separate metric
 			meterLabels = append(meterLabels, "chaincodeerror", strconv.FormatBool(true))
 			e.Metrics.EndorsementsFailed.With(meterLabels...).Add(1)
+			failure = FailureChaincodeError
 			endorserLogger.Debugf("[%s][%s] endorseProposal() resulted in chaincode %s error for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, txid)
 			return pResp, nil
 		}
@@ -556,6 +698,7 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	// contains the "return value" from the
 	// chaincode invocation
 	pResp.Response = res
+	pResp.ResourceUsage = usage
 
 	// total failed proposals = ProposalsReceived-SuccessfulProposals
 	e.Metrics.SuccessfulProposals.Add(1)
@@ -564,6 +707,65 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	return pResp, nil
 }
 
+// recordFailure counts a failed proposal by the reason of the failure
+func (e *Endorser) recordFailure(chainID string, ccid *pb.ChaincodeID, reason string) {
+	e.Metrics.ProposalFailures.With(
+		"channel", chainID,
+		"chaincode", ccid.Name+":"+ccid.Version,
+		"reason", reason,
+	).Add(1)
+}
+
+// measureUsage measures the resources used by the simulation of a proposal. The
+// simulation time is the wall time, as the chaincode runs in another process
+func measureUsage(simulationResult []byte, event *pb.ChaincodeEvent, response *pb.Response, simulationTime time.Duration) (*pb.ResourceUsage, error) {
+	var eventBytes []byte
+	if event != nil {
+		var err error
+		if eventBytes, err = putils.GetBytesChaincodeEvent(event); err != nil {
+			return nil, errors.Wrap(err, "failed to marshal event bytes")
+		}
+	}
+	usage, err := validation.MeasureUsage(simulationResult, eventBytes, response)
+	if err != nil {
+		return nil, err
+	}
+	usage.SimulationTimeUs = uint64(simulationTime / time.Microsecond)
+	return usage, nil
+}
+
+// checkResourceBudget checks that the given usage does not exceed the resource
+// budget of the given channel, if the channel has one
+func (e *Endorser) checkResourceBudget(chainID string, usage *pb.ResourceUsage) error {
+	ac, ok := e.s.GetApplicationConfig(chainID)
+	if !ok || !ac.Capabilities().ResourceBudgets() {
+		return nil
+	}
+	return validation.CheckResourceBudget(ac.ResourceBudget(), usage)
+}
+
+// classifySimulationError returns the reason of the failure of a simulation
+func classifySimulationError(err error) string {
+	switch errors.Cause(err) {
+	case chaincode.ErrExecutionTimeout:
+		return FailureTimeout
+	case chaincode.ErrChaincodeDisconnected:
+		return FailureShimDisconnect
+	default:
+		return FailureSimulation
+	}
+}
+
+// proposalFunction returns the function invoked by a proposal, that is the first
+// argument of the chaincode input
+func proposalFunction(prop *pb.Proposal) string {
+	cis, err := putils.GetChaincodeInvocationSpec(prop)
+	if err != nil || cis.ChaincodeSpec == nil || cis.ChaincodeSpec.Input == nil || len(cis.ChaincodeSpec.Input.Args) == 0 {
+		return ""
+	}
+	return string(cis.ChaincodeSpec.Input.Args[0])
+}
+
 // determine whether or not a transaction simulator should be
 // obtained for a proposal.
 func acquireTxSimulator(chainID string, ccid *pb.ChaincodeID) bool {
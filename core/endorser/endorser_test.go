@@ -11,14 +11,17 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	mc "github.com/hyperledger/fabric/common/mocks/config"
 	resourceconfig "github.com/hyperledger/fabric/common/mocks/resourcesconfig"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
@@ -91,6 +94,10 @@ type fakeEndorserMetrics struct {
 	initFailed               *metricsfakes.Counter
 	endorsementsFailed       *metricsfakes.Counter
 	duplicateTxsFailure      *metricsfakes.Counter
+	duplicateTxsReplayed     *metricsfakes.Counter
+	queryCacheHits           *metricsfakes.Counter
+	functionDuration         *metricsfakes.Histogram
+	proposalFailures         *metricsfakes.Counter
 }
 
 // initalize Endorser with fake metrics
@@ -104,6 +111,10 @@ func initFakeMetrics(es *endorser.Endorser) *fakeEndorserMetrics {
 		initFailed:               &metricsfakes.Counter{},
 		endorsementsFailed:       &metricsfakes.Counter{},
 		duplicateTxsFailure:      &metricsfakes.Counter{},
+		duplicateTxsReplayed:     &metricsfakes.Counter{},
+		queryCacheHits:           &metricsfakes.Counter{},
+		functionDuration:         &metricsfakes.Histogram{},
+		proposalFailures:         &metricsfakes.Counter{},
 	}
 
 	fakeMetrics.proposalDuration.WithReturns(fakeMetrics.proposalDuration)
@@ -111,6 +122,10 @@ func initFakeMetrics(es *endorser.Endorser) *fakeEndorserMetrics {
 	fakeMetrics.initFailed.WithReturns(fakeMetrics.initFailed)
 	fakeMetrics.endorsementsFailed.WithReturns(fakeMetrics.endorsementsFailed)
 	fakeMetrics.duplicateTxsFailure.WithReturns(fakeMetrics.duplicateTxsFailure)
+	fakeMetrics.duplicateTxsReplayed.WithReturns(fakeMetrics.duplicateTxsReplayed)
+	fakeMetrics.queryCacheHits.WithReturns(fakeMetrics.queryCacheHits)
+	fakeMetrics.functionDuration.WithReturns(fakeMetrics.functionDuration)
+	fakeMetrics.proposalFailures.WithReturns(fakeMetrics.proposalFailures)
 
 	es.Metrics.ProposalDuration = fakeMetrics.proposalDuration
 	es.Metrics.ProposalsReceived = fakeMetrics.proposalsReceived
@@ -120,6 +135,10 @@ func initFakeMetrics(es *endorser.Endorser) *fakeEndorserMetrics {
 	es.Metrics.InitFailed = fakeMetrics.initFailed
 	es.Metrics.EndorsementsFailed = fakeMetrics.endorsementsFailed
 	es.Metrics.DuplicateTxsFailure = fakeMetrics.duplicateTxsFailure
+	es.Metrics.DuplicateTxsReplayed = fakeMetrics.duplicateTxsReplayed
+	es.Metrics.QueryCacheHits = fakeMetrics.queryCacheHits
+	es.Metrics.FunctionDuration = fakeMetrics.functionDuration
+	es.Metrics.ProposalFailures = fakeMetrics.proposalFailures
 
 	return fakeMetrics
 }
@@ -556,6 +575,321 @@ func TestEndorserGoodPath(t *testing.T) {
 	// test for triggering of successful proposal metric
 	assert.EqualValues(t, 1, fakeMetrics.successfulProposals.AddCallCount())
 	assert.EqualValues(t, 1, fakeMetrics.successfulProposals.AddArgsForCall(0))
+
+	// test for triggering of the function duration metric
+	assert.EqualValues(t, 1, fakeMetrics.functionDuration.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "function", "args", "result", "success"}, fakeMetrics.functionDuration.WithArgsForCall(0))
+	assert.EqualValues(t, 1, fakeMetrics.functionDuration.ObserveCallCount())
+	assert.EqualValues(t, 0, fakeMetrics.proposalFailures.WithCallCount())
+}
+
+func TestEndorserDedupCache(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	es.DedupCache = endorser.NewDedupCache(time.Minute, 10)
+
+	fakeMetrics := initFakeMetrics(es)
+
+	signedProp := getSignedProp("ccid", "0", t)
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	// the retry of the proposal is answered with the earlier response
+	replayedResp, err := es.ProcessProposal(context.Background(), signedProp)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(pResp, replayedResp))
+	assert.EqualValues(t, 1, fakeMetrics.successfulProposals.AddCallCount())
+	assert.EqualValues(t, 1, fakeMetrics.duplicateTxsReplayed.AddCallCount())
+	assert.EqualValues(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0"}, fakeMetrics.duplicateTxsReplayed.WithArgsForCall(0))
+	assert.EqualValues(t, 0, fakeMetrics.duplicateTxsFailure.AddCallCount())
+}
+
+func TestEndorserQueryCache(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: []byte("result")},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	es.QueryCache = endorser.NewQueryCache(time.Minute, 10)
+
+	fakeMetrics := initFakeMetrics(es)
+
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+	m.AssertNumberOfCalls(t, "GetTxSimulator", 1)
+
+	// an identical proposal is endorsed with the result of the first one,
+	// without being simulated
+	support.ExecuteResp = &pb.Response{Status: 200, Payload: []byte("another result")}
+	cachedResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, cachedResp.Response.Status)
+	assert.Equal(t, []byte("result"), cachedResp.Response.Payload)
+	assert.NotNil(t, cachedResp.Endorsement)
+	m.AssertNumberOfCalls(t, "GetTxSimulator", 1)
+	assert.EqualValues(t, 1, fakeMetrics.queryCacheHits.AddCallCount())
+	assert.EqualValues(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0"}, fakeMetrics.queryCacheHits.WithArgsForCall(0))
+	assert.EqualValues(t, 2, fakeMetrics.successfulProposals.AddCallCount())
+
+	// a proposal with a different input is simulated
+	pResp, err = es.ProcessProposal(context.Background(), getSignedPropWithCHIdAndArgs(util.GetTestChainID(), "ccid", "0", [][]byte{[]byte("other")}, t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("another result"), pResp.Response.Payload)
+	m.AssertNumberOfCalls(t, "GetTxSimulator", 2)
+
+	// a commit invalidates the results of the channel
+	es.QueryCache.Invalidate(util.GetTestChainID())
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("another result"), pResp.Response.Payload)
+	m.AssertNumberOfCalls(t, "GetTxSimulator", 3)
+	assert.EqualValues(t, 1, fakeMetrics.queryCacheHits.AddCallCount())
+}
+
+type staticHeightLedger struct {
+	height uint64
+}
+
+func (l *staticHeightLedger) GetBlockchainInfo() (*common.BlockchainInfo, error) {
+	return &common.BlockchainInfo{Height: l.height}, nil
+}
+
+func (l *staticHeightLedger) GetBlocksIterator(uint64) (commonledger.ResultsIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestEndorserMinLedgerHeight(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	es.HeightGate = &endorser.LedgerHeightGate{
+		Ledger: func(string) endorser.ChannelLedger {
+			return &staticHeightLedger{height: 1}
+		},
+	}
+	fakeMetrics := initFakeMetrics(es)
+
+	signedProp := func(minHeight uint64) *pb.SignedProposal {
+		cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        1,
+			ChaincodeId: &pb.ChaincodeID{Name: "ccid", Version: "0"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("args")}},
+		}}
+		creator, err := signer.Serialize()
+		assert.NoError(t, err)
+		prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, creator)
+		assert.NoError(t, err)
+		assert.NoError(t, utils.SetProposalMinLedgerHeight(prop, minHeight))
+		propBytes, err := utils.GetBytesProposal(prop)
+		assert.NoError(t, err)
+		signature, err := signer.Sign(propBytes)
+		assert.NoError(t, err)
+		return &pb.SignedProposal{ProposalBytes: propBytes, Signature: signature}
+	}
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp(0))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	pResp, err = es.ProcessProposal(context.Background(), signedProp(3))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Equal(t, "the ledger of channel testchainid is at height 1, below the minimum ledger height 3 of the proposal", pResp.Response.Message)
+	assert.EqualValues(t, 1, fakeMetrics.proposalFailures.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "reason", endorser.FailureStaleLedger}, fakeMetrics.proposalFailures.WithArgsForCall(0))
+}
+
+func TestEndorserExpiredProposal(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	m.On("GetLedgerHeight", util.GetTestChainID()).Return(uint64(5), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	fakeMetrics := initFakeMetrics(es)
+
+	signedProp := func(height uint64, expirationTime time.Time) *pb.SignedProposal {
+		cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        1,
+			ChaincodeId: &pb.ChaincodeID{Name: "ccid", Version: "0"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("args")}},
+		}}
+		creator, err := signer.Serialize()
+		assert.NoError(t, err)
+		prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, creator)
+		assert.NoError(t, err)
+		assert.NoError(t, utils.SetProposalExpiration(prop, height, expirationTime))
+		propBytes, err := utils.GetBytesProposal(prop)
+		assert.NoError(t, err)
+		signature, err := signer.Sign(propBytes)
+		assert.NoError(t, err)
+		return &pb.SignedProposal{ProposalBytes: propBytes, Signature: signature}
+	}
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp(6, time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	pResp, err = es.ProcessProposal(context.Background(), signedProp(5, time.Time{}))
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "EXPIRED_TRANSACTION: transaction")
+	assert.Contains(t, pResp.Response.Message, "expired at block 5")
+
+	pResp, err = es.ProcessProposal(context.Background(), signedProp(0, time.Now().Add(-time.Minute)))
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "EXPIRED_TRANSACTION: transaction")
+
+	assert.EqualValues(t, 2, fakeMetrics.proposalFailures.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "reason", endorser.FailureExpired}, fakeMetrics.proposalFailures.WithArgsForCall(1))
+}
+
+func TestEndorserPrematureProposal(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	m.On("GetLedgerHeight", util.GetTestChainID()).Return(uint64(5), nil)
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	fakeMetrics := initFakeMetrics(es)
+
+	signedProp := func(height uint64, scheduledTime time.Time) *pb.SignedProposal {
+		cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        1,
+			ChaincodeId: &pb.ChaincodeID{Name: "ccid", Version: "0"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("args")}},
+		}}
+		creator, err := signer.Serialize()
+		assert.NoError(t, err)
+		prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, util.GetTestChainID(), cis, creator)
+		assert.NoError(t, err)
+		assert.NoError(t, utils.SetProposalSchedule(prop, height, scheduledTime))
+		propBytes, err := utils.GetBytesProposal(prop)
+		assert.NoError(t, err)
+		signature, err := signer.Sign(propBytes)
+		assert.NoError(t, err)
+		return &pb.SignedProposal{ProposalBytes: propBytes, Signature: signature}
+	}
+
+	pResp, err := es.ProcessProposal(context.Background(), signedProp(5, time.Now().Add(-time.Minute)))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	pResp, err = es.ProcessProposal(context.Background(), signedProp(6, time.Time{}))
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "PREMATURE_TRANSACTION: transaction")
+	assert.Contains(t, pResp.Response.Message, "is scheduled at block 6")
+
+	pResp, err = es.ProcessProposal(context.Background(), signedProp(0, time.Now().Add(time.Hour)))
+	assert.Error(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "PREMATURE_TRANSACTION: transaction")
+
+	assert.EqualValues(t, 2, fakeMetrics.proposalFailures.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "reason", endorser.FailurePremature}, fakeMetrics.proposalFailures.WithArgsForCall(1))
+}
+
+func TestEndorserResourceBudget(t *testing.T) {
+	m := &mock.Mock{}
+	m.On("Sign", mock.Anything).Return([]byte{1, 2, 3, 4, 5}, nil)
+	m.On("Serialize").Return([]byte{1, 1, 1}, nil)
+	m.On("GetTxSimulator", mock.Anything, mock.Anything).Return(newMockTxSim(), nil)
+	appConfig := &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}}
+	support := &em.MockSupport{
+		Mock:                       m,
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     appConfig,
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Name: "ccid", Version: "0", Escc: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: []byte("payload")},
+	}
+	attachPluginEndorser(support, nil)
+	es := endorser.NewEndorserServer(pvtEmptyDistributor, support, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+	fakeMetrics := initFakeMetrics(es)
+
+	// the usage is reported whether the channel has a budget or not
+	pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+	assert.NotNil(t, pResp.ResourceUsage)
+	assert.Zero(t, pResp.ResourceUsage.StateReads)
+	assert.Zero(t, pResp.ResourceUsage.StateWrites)
+	assert.Equal(t, uint64(proto.Size(support.ExecuteResp)), pResp.ResourceUsage.BytesProduced)
+
+	// the budget is ignored without the capability
+	appConfig.ResourceBudgetRv = &pb.ResourceBudget{MaxBytesProduced: 1}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
+
+	appConfig.CapabilitiesRv = &mc.MockApplicationCapabilities{ResourceBudgetsRv: true}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 500, pResp.Response.Status)
+	assert.Contains(t, pResp.Response.Message, "RESOURCE_BUDGET_EXCEEDED: the simulation produced")
+	assert.Nil(t, pResp.ResourceUsage)
+	assert.EqualValues(t, 1, fakeMetrics.proposalFailures.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "reason", endorser.FailureResourceBudget}, fakeMetrics.proposalFailures.WithArgsForCall(0))
+
+	appConfig.ResourceBudgetRv = &pb.ResourceBudget{MaxStateReads: 1, MaxStateWrites: 1, MaxBytesProduced: 1024}
+	pResp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 200, pResp.Response.Status)
 }
 
 func TestEndorserChaincodeCallLogging(t *testing.T) {
@@ -728,10 +1062,45 @@ func TestEndorseEndorsementFailureDueToCCError(t *testing.T) {
 	assert.EqualValues(t, 1, fakeMetrics.endorsementsFailed.AddCallCount())
 	assert.EqualValues(t, 1, fakeMetrics.endorsementsFailed.AddArgsForCall(0))
 
+	// test for triggering of the proposal failure metric
+	assert.EqualValues(t, 1, fakeMetrics.proposalFailures.WithCallCount())
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "reason", "chaincode_error"}, fakeMetrics.proposalFailures.WithArgsForCall(0))
+	assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "function", "args", "result", "chaincode_error"}, fakeMetrics.functionDuration.WithArgsForCall(0))
+
 	// test for triggering of failed TX metric
 	testEndorsementCompletedMetric(t, fakeMetrics, 1, util.GetTestChainID(), "ccid:0", "false")
 }
 
+func TestEndorserSimulationFailureReasons(t *testing.T) {
+	for _, test := range []struct {
+		err    error
+		reason string
+	}{
+		{errors.Wrap(chaincode.ErrExecutionTimeout, "failed to execute transaction"), endorser.FailureTimeout},
+		{errors.Wrap(chaincode.ErrChaincodeDisconnected, "failed to execute transaction"), endorser.FailureShimDisconnect},
+		{errors.New("failed to get state"), endorser.FailureSimulation},
+	} {
+		es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
+			GetApplicationConfigBoolRv: true,
+			GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+			GetTransactionByIDErr:      errors.New(""),
+			ChaincodeDefinitionRv:      &ccprovider.ChaincodeData{Escc: "ESCC"},
+			ExecuteError:               test.err,
+			GetTxSimulatorRv:           newMockTxSim(),
+		}, platforms.NewRegistry(&golang.Platform{}), &disabled.Provider{})
+		fakeMetrics := initFakeMetrics(es)
+
+		pResp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+		assert.NoError(t, err)
+		assert.EqualValues(t, 500, pResp.Response.Status)
+
+		assert.EqualValues(t, 1, fakeMetrics.proposalFailures.WithCallCount())
+		assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "reason", test.reason}, fakeMetrics.proposalFailures.WithArgsForCall(0))
+		assert.EqualValues(t, 1, fakeMetrics.proposalFailures.AddCallCount())
+		assert.Equal(t, []string{"channel", util.GetTestChainID(), "chaincode", "ccid:0", "function", "args", "result", test.reason}, fakeMetrics.functionDuration.WithArgsForCall(0))
+	}
+}
+
 func TestSimulateProposal(t *testing.T) {
 	es := endorser.NewEndorserServer(pvtEmptyDistributor, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
@@ -847,7 +1216,9 @@ func TestUserCDSSanitization(t *testing.T) {
 			},
 			Type: pb.ChaincodeSpec_GOLANG,
 		},
-		CodePackage: []byte("user-code"),
+		CodePackage:  []byte("user-code"),
+		Migrate:      true,
+		InitRequired: true,
 	}
 
 	fsCDS := &pb.ChaincodeDeploymentSpec{
@@ -869,6 +1240,8 @@ func TestUserCDSSanitization(t *testing.T) {
 	assert.Nil(t, sanitizedCDS.CodePackage)
 	assert.True(t, proto.Equal(userCDS.ChaincodeSpec.Input, sanitizedCDS.ChaincodeSpec.Input))
 	assert.True(t, proto.Equal(fsCDS.ChaincodeSpec.ChaincodeId, sanitizedCDS.ChaincodeSpec.ChaincodeId))
+	assert.True(t, sanitizedCDS.Migrate)
+	assert.True(t, sanitizedCDS.InitRequired)
 
 	t.Run("BadPath", func(t *testing.T) {
 		fakeSupport.GetChaincodeDeploymentSpecFSReturns(nil, fmt.Errorf("fake-error"))
@@ -6,7 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package endorser
 
-import "github.com/hyperledger/fabric/common/metrics"
+import (
+	"regexp"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
 
 var (
 	proposalDurationHistogramOpts = metrics.HistogramOpts{
@@ -66,6 +71,38 @@ var (
 		LabelNames:   []string{"channel", "chaincode"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
 	}
+
+	functionDurationHistogramOpts = metrics.HistogramOpts{
+		Namespace:    "endorser",
+		Name:         "function_duration",
+		Help:         "The time to complete a proposal, by chaincode function.",
+		LabelNames:   []string{"channel", "chaincode", "function", "result"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{function}.%{result}",
+	}
+
+	proposalFailuresCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "proposal_failures",
+		Help:         "The number of failed proposals, by reason of the failure.",
+		LabelNames:   []string{"channel", "chaincode", "reason"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{reason}",
+	}
+
+	duplicateTxsReplayedCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "duplicate_transactions_replayed",
+		Help:         "The number of proposals answered with the response of an earlier endorsement of the same transaction ID.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	queryCacheHitsCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "query_cache_hits",
+		Help:         "The number of proposals endorsed with the cached result of an identical read-only proposal.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
 )
 
 type EndorserMetrics struct {
@@ -77,6 +114,12 @@ type EndorserMetrics struct {
 	InitFailed               metrics.Counter
 	EndorsementsFailed       metrics.Counter
 	DuplicateTxsFailure      metrics.Counter
+	DuplicateTxsReplayed     metrics.Counter
+	QueryCacheHits           metrics.Counter
+	FunctionDuration         metrics.Histogram
+	ProposalFailures         metrics.Counter
+
+	functionLabels *functionLabels
 }
 
 func NewEndorserMetrics(p metrics.Provider) *EndorserMetrics {
@@ -89,5 +132,72 @@ func NewEndorserMetrics(p metrics.Provider) *EndorserMetrics {
 		InitFailed:               p.NewCounter(initFailureCounterOpts),
 		EndorsementsFailed:       p.NewCounter(endorsementFailureCounterOpts),
 		DuplicateTxsFailure:      p.NewCounter(duplicateTxsFailureCounterOpts),
+		DuplicateTxsReplayed:     p.NewCounter(duplicateTxsReplayedCounterOpts),
+		QueryCacheHits:           p.NewCounter(queryCacheHitsCounterOpts),
+		FunctionDuration:         p.NewHistogram(functionDurationHistogramOpts),
+		ProposalFailures:         p.NewCounter(proposalFailuresCounterOpts),
+		functionLabels:           &functionLabels{functions: map[string]map[string]struct{}{}},
+	}
+}
+
+// The reasons of the failures of the proposals
+const (
+	FailureACLDenied      = "acl_denied"
+	FailureDuplicateTxID  = "duplicate_txid"
+	FailureStaleLedger    = "stale_ledger"
+	FailureExpired        = "expired"
+	FailurePremature      = "premature"
+	FailureTimeout        = "timeout"
+	FailureShimDisconnect = "shim_disconnect"
+	FailureSimulation     = "simulation_failure"
+	FailureChaincodeError = "chaincode_error"
+	FailureEndorsement    = "endorsement_failure"
+	FailureResourceBudget = "resource_budget_exceeded"
+	FailureInternal       = "internal_error"
+)
+
+const (
+	// the results of the proposals, besides the reasons of the failures
+	resultSuccess  = "success"
+	resultReplayed = "replayed"
+
+	noFunctionLabel        = "none"
+	otherFunctionLabel     = "other"
+	maxFunctionLabelsPerCC = 64
+	maxFunctionLabelLength = 64
+)
+
+var functionLabelRegexp = regexp.MustCompile("^[A-Za-z0-9_.-]+$")
+
+// functionLabels bounds the values of the function label of a chaincode. As the
+// functions are chosen by the clients, the functions beyond the first ones of a
+// chaincode, and the ones which are not plain names, are labeled as other
+type functionLabels struct {
+	mutex     sync.Mutex
+	functions map[string]map[string]struct{}
+}
+
+func (fl *functionLabels) label(chaincode, function string) string {
+	if function == "" {
+		return noFunctionLabel
+	}
+	if len(function) > maxFunctionLabelLength || !functionLabelRegexp.MatchString(function) {
+		return otherFunctionLabel
+	}
+
+	fl.mutex.Lock()
+	defer fl.mutex.Unlock()
+	functions, ok := fl.functions[chaincode]
+	if !ok {
+		functions = map[string]struct{}{}
+		fl.functions[chaincode] = functions
+	}
+	if _, ok := functions[function]; ok {
+		return function
+	}
+	if len(functions) >= maxFunctionLabelsPerCC {
+		return otherFunctionLabel
 	}
+	functions[function] = struct{}{}
+	return function
 }
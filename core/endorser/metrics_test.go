@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package endorser
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
@@ -30,14 +32,20 @@ func TestNewEndorserMetrics(t *testing.T) {
 		InitFailed:               &metricsfakes.Counter{},
 		EndorsementsFailed:       &metricsfakes.Counter{},
 		DuplicateTxsFailure:      &metricsfakes.Counter{},
+		DuplicateTxsReplayed:     &metricsfakes.Counter{},
+		QueryCacheHits:           &metricsfakes.Counter{},
+		FunctionDuration:         &metricsfakes.Histogram{},
+		ProposalFailures:         &metricsfakes.Counter{},
+		functionLabels:           &functionLabels{functions: map[string]map[string]struct{}{}},
 	}))
 
-	gt.Expect(provider.NewHistogramCallCount()).To(Equal(1))
+	gt.Expect(provider.NewHistogramCallCount()).To(Equal(2))
 	gt.Expect(provider.Invocations()["NewHistogram"]).To(ConsistOf([][]interface{}{
 		{proposalDurationHistogramOpts},
+		{functionDurationHistogramOpts},
 	}))
 
-	gt.Expect(provider.NewCounterCallCount()).To(Equal(7))
+	gt.Expect(provider.NewCounterCallCount()).To(Equal(10))
 	gt.Expect(provider.Invocations()["NewCounter"]).To(ConsistOf([][]interface{}{
 		{receivedProposalsCounterOpts},
 		{successfulProposalsCounterOpts},
@@ -46,5 +54,27 @@ func TestNewEndorserMetrics(t *testing.T) {
 		{initFailureCounterOpts},
 		{endorsementFailureCounterOpts},
 		{duplicateTxsFailureCounterOpts},
+		{duplicateTxsReplayedCounterOpts},
+		{queryCacheHitsCounterOpts},
+		{proposalFailuresCounterOpts},
 	}))
 }
+
+func TestFunctionLabels(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	fl := &functionLabels{functions: map[string]map[string]struct{}{}}
+	gt.Expect(fl.label("cc:1", "invoke")).To(Equal("invoke"))
+	gt.Expect(fl.label("cc:1", "")).To(Equal("none"))
+	gt.Expect(fl.label("cc:1", "drop table; --")).To(Equal("other"))
+	gt.Expect(fl.label("cc:1", strings.Repeat("a", maxFunctionLabelLength+1))).To(Equal("other"))
+
+	for i := 1; i < maxFunctionLabelsPerCC; i++ {
+		gt.Expect(fl.label("cc:1", fmt.Sprintf("fn%d", i))).To(Equal(fmt.Sprintf("fn%d", i)))
+	}
+	// the functions beyond the maximum are labeled as other, the known ones keep their label
+	gt.Expect(fl.label("cc:1", "query")).To(Equal("other"))
+	gt.Expect(fl.label("cc:1", "invoke")).To(Equal("invoke"))
+	// the functions are bounded per chaincode
+	gt.Expect(fl.label("cc:2", "query")).To(Equal("query"))
+}
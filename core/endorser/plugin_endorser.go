@@ -13,6 +13,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
+	endorsement4 "github.com/hyperledger/fabric/core/handlers/endorsement/api/transient"
 	"github.com/hyperledger/fabric/core/transientstore"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
@@ -51,6 +52,55 @@ func (m MapBasedPluginMapper) PluginFactoryByName(name PluginName) endorsement.P
 	return m[string(name)]
 }
 
+// PluginCapabilities maps plugin names to the capabilities that are granted to the plugins
+type PluginCapabilities map[PluginName][]endorsement.Capability
+
+// NewPluginCapabilities constructs PluginCapabilities out of the capability names
+// configured for the plugins, and returns an error if a name is not recognized
+func NewPluginCapabilities(capabilityNames map[string][]string) (PluginCapabilities, error) {
+	pc := make(PluginCapabilities)
+	for plugin, names := range capabilityNames {
+		for _, name := range names {
+			c := endorsement.Capability(name)
+			switch c {
+			case endorsement.TransientDataCapability, endorsement.ResponseTransformCapability:
+				pc[PluginName(plugin)] = append(pc[PluginName(plugin)], c)
+			default:
+				return nil, errors.Errorf("unknown capability %s configured for endorsement plugin %s", name, plugin)
+			}
+		}
+	}
+	return pc, nil
+}
+
+func (pc PluginCapabilities) has(plugin PluginName, capability endorsement.Capability) bool {
+	for _, c := range pc[plugin] {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// TransientDataFetcher retrieves the transient data of the proposals
+type TransientDataFetcher struct{}
+
+// TransientData returns the transient map of the given signed proposal
+func (TransientDataFetcher) TransientData(sp *pb.SignedProposal) (map[string][]byte, error) {
+	if sp == nil {
+		return nil, errors.New("signed proposal is nil")
+	}
+	prop, err := putils.GetProposal(sp.ProposalBytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing proposal")
+	}
+	cpp, err := putils.GetChaincodeProposalPayload(prop.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed parsing chaincode proposal payload")
+	}
+	return cpp.TransientMap, nil
+}
+
 // Context defines the data that is related to an in-flight endorsement
 type Context struct {
 	PluginName     string
@@ -77,6 +127,7 @@ type PluginSupport struct {
 	endorsement3.SigningIdentityFetcher
 	PluginMapper
 	TransientStoreRetriever
+	PluginCapabilities
 }
 
 // NewPluginEndorser endorses with using a plugin
@@ -87,6 +138,7 @@ func NewPluginEndorser(ps *PluginSupport) *PluginEndorser {
 		pluginChannelMapping:    make(map[PluginName]*pluginsByChannel),
 		ChannelStateRetriever:   ps.ChannelStateRetriever,
 		TransientStoreRetriever: ps.TransientStoreRetriever,
+		PluginCapabilities:      ps.PluginCapabilities,
 	}
 }
 
@@ -95,6 +147,7 @@ type PluginName string
 
 type pluginsByChannel struct {
 	sync.RWMutex
+	name             PluginName
 	pluginFactory    endorsement.PluginFactory
 	channels2Plugins map[string]endorsement.Plugin
 	pe               *PluginEndorser
@@ -141,6 +194,15 @@ func (pbc *pluginsByChannel) initPlugin(plugin endorsement.Plugin, channel strin
 	}
 	// Add the SigningIdentityFetcher as a dependency
 	dependencies = append(dependencies, pbc.pe.SigningIdentityFetcher)
+	// Add the TransientDataFetcher as a dependency only if the plugin is granted the access to the transient data
+	if pbc.pe.PluginCapabilities.has(pbc.name, endorsement.TransientDataCapability) {
+		dependencies = append(dependencies, endorsement4.TransientDataFetcher(TransientDataFetcher{}))
+	}
+	if _, isTransformer := plugin.(endorsement.ResponseTransformer); isTransformer &&
+		!pbc.pe.PluginCapabilities.has(pbc.name, endorsement.ResponseTransformCapability) {
+		endorserLogger.Warningf("Endorsement plugin %s transforms proposal responses but is not granted the %s capability. "+
+			"The transformation will be skipped", pbc.name, endorsement.ResponseTransformCapability)
+	}
 	err = plugin.Init(dependencies...)
 	if err != nil {
 		return nil, err
@@ -156,6 +218,7 @@ type PluginEndorser struct {
 	ChannelStateRetriever
 	endorsement3.SigningIdentityFetcher
 	TransientStoreRetriever
+	PluginCapabilities
 }
 
 // EndorseWithPlugin endorses the response with a plugin
@@ -194,10 +257,33 @@ func (pe *PluginEndorser) EndorseWithPlugin(ctx Context) (*pb.ProposalResponse,
 		Payload:     prpBytes,
 		Response:    ctx.Response,
 	}
+
+	resp, err = pe.transformResponse(plugin, ctx, resp)
+	if err != nil {
+		endorserLogger.Warning("Transformation of the proposal response with plugin for", ctx, " failed:", err)
+		return nil, err
+	}
 	endorserLogger.Debug("Exiting", ctx)
 	return resp, nil
 }
 
+// transformResponse lets the plugin transform the proposal response, if the plugin
+// implements the ResponseTransformer interface and is granted the corresponding capability
+func (pe *PluginEndorser) transformResponse(plugin endorsement.Plugin, ctx Context, resp *pb.ProposalResponse) (*pb.ProposalResponse, error) {
+	transformer, isTransformer := plugin.(endorsement.ResponseTransformer)
+	if !isTransformer || !pe.PluginCapabilities.has(PluginName(ctx.PluginName), endorsement.ResponseTransformCapability) {
+		return resp, nil
+	}
+	transformedResp, err := transformer.TransformResponse(resp, ctx.SignedProposal)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if transformedResp == nil || transformedResp.Endorsement == nil {
+		return nil, errors.Errorf("plugin with name %s returned a proposal response without an endorsement", ctx.PluginName)
+	}
+	return transformedResp, nil
+}
+
 // getAndStorePlugin returns a plugin instance for the given plugin name and channel
 func (pe *PluginEndorser) getOrCreatePlugin(plugin PluginName, channel string) (endorsement.Plugin, error) {
 	pluginFactory := pe.PluginFactoryByName(plugin)
@@ -215,6 +301,7 @@ func (pe *PluginEndorser) getOrCreatePluginChannelMapping(plugin PluginName, pf
 	endorserChannelMapping, exists := pe.pluginChannelMapping[PluginName(plugin)]
 	if !exists {
 		endorserChannelMapping = &pluginsByChannel{
+			name:             plugin,
 			pluginFactory:    pf,
 			channels2Plugins: make(map[string]endorsement.Plugin),
 			pe:               pe,
@@ -325,3 +325,130 @@ func TestTransientStore(t *testing.T) {
 	assert.True(t, proto.Equal(rws, txrws))
 	scanner.AssertCalled(t, "Close")
 }
+
+type transformingPlugin struct {
+	*mocks.Plugin
+}
+
+func (tp *transformingPlugin) TransformResponse(resp *peer.ProposalResponse, sp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	args := tp.Called(resp, sp)
+	transformedResp, _ := args.Get(0).(*peer.ProposalResponse)
+	return transformedResp, args.Error(1)
+}
+
+func TestNewPluginCapabilities(t *testing.T) {
+	pc, err := endorser.NewPluginCapabilities(map[string][]string{
+		"plugin1": {"TransientData", "ResponseTransform"},
+		"plugin2": nil,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, endorser.PluginCapabilities{
+		"plugin1": {endorsement.TransientDataCapability, endorsement.ResponseTransformCapability},
+	}, pc)
+
+	_, err = endorser.NewPluginCapabilities(map[string][]string{"plugin1": {"PrivateKeys"}})
+	assert.EqualError(t, err, "unknown capability PrivateKeys configured for endorsement plugin plugin1")
+}
+
+func TestPluginEndorserCapabilities(t *testing.T) {
+	proposal, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		},
+	}, []byte{1, 2, 3})
+	assert.NoError(t, err)
+	sif := &mocks.SigningIdentityFetcher{}
+	cs := &mocks.ChannelStateRetriever{}
+	cs.On("NewQueryCreator", "mychannel").Return(&mocks.QueryCreator{}, nil)
+	transformedResp := &peer.ProposalResponse{Endorsement: &peer.Endorsement{Signature: []byte{9}}, Payload: []byte{9}}
+
+	newPlugin := func() *transformingPlugin {
+		plugin := &transformingPlugin{Plugin: &mocks.Plugin{}}
+		plugin.On("Init", mock.Anything, mock.Anything).Return(nil)
+		plugin.On("Init", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		plugin.On("Endorse", mock.Anything, mock.Anything).Return(&peer.Endorsement{Signature: []byte{1}}, []byte{1}, nil)
+		return plugin
+	}
+	endorseWith := func(plugin *transformingPlugin, capabilities ...endorsement.Capability) (*peer.ProposalResponse, error) {
+		factory := &mocks.PluginFactory{}
+		factory.On("New").Return(plugin)
+		pluginEndorser := endorser.NewPluginEndorser(&endorser.PluginSupport{
+			ChannelStateRetriever:   cs,
+			SigningIdentityFetcher:  sif,
+			PluginMapper:            endorser.MapBasedPluginMapper{"plugin": factory},
+			TransientStoreRetriever: mockTransientStoreRetriever,
+			PluginCapabilities:      endorser.PluginCapabilities{"plugin": capabilities},
+		})
+		return pluginEndorser.EndorseWithPlugin(endorser.Context{
+			Response:    &peer.Response{},
+			PluginName:  "plugin",
+			Proposal:    proposal,
+			ChaincodeID: &peer.ChaincodeID{Name: "mycc"},
+			Channel:     "mychannel",
+		})
+	}
+
+	t.Run("no capabilities", func(t *testing.T) {
+		plugin := newPlugin()
+		resp, err := endorseWith(plugin)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{1}, resp.Payload)
+		plugin.AssertCalled(t, "Init", mock.Anything, sif)
+		plugin.AssertNotCalled(t, "TransformResponse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("transient data", func(t *testing.T) {
+		plugin := newPlugin()
+		_, err := endorseWith(plugin, endorsement.TransientDataCapability)
+		assert.NoError(t, err)
+		plugin.AssertCalled(t, "Init", mock.Anything, sif, endorser.TransientDataFetcher{})
+		plugin.AssertNotCalled(t, "TransformResponse", mock.Anything, mock.Anything)
+	})
+
+	t.Run("response transform", func(t *testing.T) {
+		plugin := newPlugin()
+		plugin.On("TransformResponse", mock.Anything, mock.Anything).Return(transformedResp, nil)
+		resp, err := endorseWith(plugin, endorsement.ResponseTransformCapability)
+		assert.NoError(t, err)
+		assert.Equal(t, transformedResp, resp)
+		plugin.AssertCalled(t, "Init", mock.Anything, sif)
+	})
+
+	t.Run("response transform failure", func(t *testing.T) {
+		plugin := newPlugin()
+		plugin.On("TransformResponse", mock.Anything, mock.Anything).Return(nil, errors.New("failed computing proof"))
+		resp, err := endorseWith(plugin, endorsement.ResponseTransformCapability)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "failed computing proof")
+	})
+
+	t.Run("response transform without endorsement", func(t *testing.T) {
+		plugin := newPlugin()
+		plugin.On("TransformResponse", mock.Anything, mock.Anything).Return(&peer.ProposalResponse{}, nil)
+		resp, err := endorseWith(plugin, endorsement.ResponseTransformCapability)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "plugin with name plugin returned a proposal response without an endorsement")
+	})
+}
+
+func TestTransientDataFetcher(t *testing.T) {
+	transientMap := map[string][]byte{"key": []byte("value")}
+	proposal, _, err := utils.CreateChaincodeProposalWithTransient(common.HeaderType_ENDORSER_TRANSACTION, "mychannel", &peer.ChaincodeInvocationSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: "mycc"},
+		},
+	}, []byte{1, 2, 3}, transientMap)
+	assert.NoError(t, err)
+	proposalBytes, err := proto.Marshal(proposal)
+	assert.NoError(t, err)
+
+	fetcher := endorser.TransientDataFetcher{}
+	data, err := fetcher.TransientData(&peer.SignedProposal{ProposalBytes: proposalBytes})
+	assert.NoError(t, err)
+	assert.Equal(t, transientMap, data)
+
+	_, err = fetcher.TransientData(nil)
+	assert.EqualError(t, err, "signed proposal is nil")
+	_, err = fetcher.TransientData(&peer.SignedProposal{ProposalBytes: []byte{1, 2, 3}})
+	assert.Contains(t, err.Error(), "failed parsing proposal")
+}
@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// QueryCache remembers, for a configured time to live, the results of the simulations
+// of the read-only proposals, so that the identical queries, such as the ones polled
+// by the dashboards, are endorsed without invoking the chaincode again as long as the
+// state of the channel is unchanged. Two proposals are identical if they invoke the
+// same chaincode of the same channel with the same input and the same creator. The
+// results of a channel are forgotten each time the peer commits a block or private
+// data of the channel, as notified through the function Invalidate
+type QueryCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	lock     sync.Mutex
+	channels map[string]*channelQueries
+}
+
+// channelQueries are the results cached for a channel, the oldest ones first
+type channelQueries struct {
+	entries map[queryKey]*list.Element
+	order   *list.List
+	// generation counts the invalidations of the channel, so that the results
+	// of the simulations started before an invalidation are not cached
+	generation uint64
+}
+
+type queryKey struct {
+	chaincode   string
+	inputHash   string
+	creatorHash string
+}
+
+type queryEntry struct {
+	key    queryKey
+	result *QueryResult
+	expiry time.Time
+}
+
+// QueryResult is the result of the simulation of a read-only proposal
+type QueryResult struct {
+	// ChaincodeDefinition is the definition of the invoked chaincode, nil for
+	// a system chaincode
+	ChaincodeDefinition ccprovider.ChaincodeDefinition
+	Response            *pb.Response
+	SimulationResult    []byte
+}
+
+// NewQueryCache constructs a QueryCache that remembers each result for the given time
+// to live and holds at most maxEntries results per channel. A nil QueryCache is returned
+// if the time to live is zero, which the endorser treats as a disabled cache
+func NewQueryCache(ttl time.Duration, maxEntries int) *QueryCache {
+	if ttl <= 0 || maxEntries <= 0 {
+		return nil
+	}
+	return &QueryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		channels:   make(map[string]*channelQueries),
+	}
+}
+
+// SetLimits changes the time to live and the maximum number of results per channel of
+// the cache. The results already cached keep their expiry, and the oldest ones are
+// removed if a channel holds more than maxEntries of them. The cache cannot be disabled
+// without restarting the peer
+func (c *QueryCache) SetLimits(ttl time.Duration, maxEntries int) error {
+	if ttl <= 0 || maxEntries <= 0 {
+		return errors.New("the query cache cannot be disabled without a restart")
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ttl = ttl
+	c.maxEntries = maxEntries
+	for _, queries := range c.channels {
+		for queries.order.Len() > c.maxEntries {
+			queries.remove(queries.order.Front())
+		}
+	}
+	return nil
+}
+
+// Query is a proposal whose result may be served from, or stored in, the cache
+type Query struct {
+	cache      *QueryCache
+	chainID    string
+	key        queryKey
+	generation uint64
+}
+
+// NewQuery returns the query of the given proposal to the given chaincode of the given
+// channel, or nil if the result of the proposal may not be cached, as the proposal
+// carries transient data. It must be called before the simulator of the proposal is
+// acquired, so that a commit that happens meanwhile prevents its result from being cached
+func (c *QueryCache) NewQuery(chainID, chaincode string, prop *pb.Proposal) *Query {
+	hdr, err := putils.GetHeader(prop.Header)
+	if err != nil {
+		return nil
+	}
+	shdr, err := putils.GetSignatureHeader(hdr.SignatureHeader)
+	if err != nil {
+		return nil
+	}
+	cpp, err := putils.GetChaincodeProposalPayload(prop.Payload)
+	if err != nil || len(cpp.TransientMap) > 0 {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return &Query{
+		cache:   c,
+		chainID: chainID,
+		key: queryKey{
+			chaincode:   chaincode,
+			inputHash:   string(util.ComputeSHA256(cpp.Input)),
+			creatorHash: string(util.ComputeSHA256(shdr.Creator)),
+		},
+		generation: c.channel(chainID).generation,
+	}
+}
+
+// Result returns the cached result of the query, or nil if there is none
+func (q *Query) Result() *QueryResult {
+	c := q.cache
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	queries, exists := c.channels[q.chainID]
+	if !exists {
+		return nil
+	}
+	element, exists := queries.entries[q.key]
+	if !exists {
+		return nil
+	}
+	entry := element.Value.(*queryEntry)
+	if !entry.expiry.After(c.now()) {
+		queries.remove(element)
+		return nil
+	}
+	return entry.result
+}
+
+// Store caches the result of the simulation of the query, if the proposal is read-only
+// and succeeded, and if the channel was not invalidated since the query was created
+func (q *Query) Store(result *QueryResult, event *pb.ChaincodeEvent) {
+	if event != nil || result.Response == nil || result.Response.Status >= shim.ERRORTHRESHOLD || !isReadOnly(result.SimulationResult) {
+		return
+	}
+
+	c := q.cache
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	queries := c.channel(q.chainID)
+	if queries.generation != q.generation {
+		return
+	}
+	if element, exists := queries.entries[q.key]; exists {
+		queries.remove(element)
+	}
+	for queries.order.Len() >= c.maxEntries {
+		queries.remove(queries.order.Front())
+	}
+	queries.entries[q.key] = queries.order.PushBack(&queryEntry{
+		key:    q.key,
+		result: result,
+		expiry: c.now().Add(c.ttl),
+	})
+}
+
+// Invalidate forgets the results cached for the given channel. It is called each time
+// the peer commits a block or private data of the channel, once its state is updated
+func (c *QueryCache) Invalidate(chainID string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	queries, exists := c.channels[chainID]
+	if !exists {
+		return
+	}
+	queries.entries = make(map[queryKey]*list.Element)
+	queries.order.Init()
+	queries.generation++
+}
+
+// channel returns the results cached for the given channel. The caller is expected
+// to hold the lock
+func (c *QueryCache) channel(chainID string) *channelQueries {
+	queries, exists := c.channels[chainID]
+	if !exists {
+		queries = &channelQueries{
+			entries: make(map[queryKey]*list.Element),
+			order:   list.New(),
+		}
+		c.channels[chainID] = queries
+	}
+	return queries
+}
+
+func (cq *channelQueries) remove(element *list.Element) {
+	entry := cq.order.Remove(element).(*queryEntry)
+	delete(cq.entries, entry.key)
+}
+
+// isReadOnly returns whether the given simulation results hold no writes
+func isReadOnly(simulationResult []byte) bool {
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(simulationResult); err != nil {
+		return false
+	}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		kvRWSet := nsRWSet.KvRwSet
+		if len(kvRWSet.Writes) > 0 || len(kvRWSet.MetadataWrites) > 0 || len(kvRWSet.Increments) > 0 || len(kvRWSet.RangeDeletes) > 0 {
+			return false
+		}
+		for _, collRWSet := range nsRWSet.CollHashedRwSets {
+			if len(collRWSet.HashedRwSet.HashedWrites) > 0 || len(collRWSet.HashedRwSet.MetadataWrites) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
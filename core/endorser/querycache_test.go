@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func queryProposal(t *testing.T, creator []byte, arg string, transientMap map[string][]byte) *pb.Proposal {
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(arg)}},
+		},
+	}
+	prop, _, err := putils.CreateChaincodeProposalWithTransient(common.HeaderType_ENDORSER_TRANSACTION, "ch1", cis, creator, transientMap)
+	assert.NoError(t, err)
+	return prop
+}
+
+func readSet(t *testing.T, writes ...*kvrwset.KVWrite) []byte {
+	txRWSet := &rwsetutil.TxRwSet{
+		NsRwSets: []*rwsetutil.NsRwSet{
+			{
+				NameSpace: "mycc",
+				KvRwSet: &kvrwset.KVRWSet{
+					Reads:  []*kvrwset.KVRead{{Key: "key", Version: &kvrwset.Version{BlockNum: 1}}},
+					Writes: writes,
+				},
+			},
+		},
+	}
+	simRes, err := txRWSet.ToProtoBytes()
+	assert.NoError(t, err)
+	return simRes
+}
+
+func TestQueryCacheDisabled(t *testing.T) {
+	assert.Nil(t, NewQueryCache(0, 10))
+	assert.Nil(t, NewQueryCache(time.Minute, 0))
+}
+
+func TestQueryCache(t *testing.T) {
+	now := time.Now()
+	cache := NewQueryCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+
+	result := &QueryResult{Response: &pb.Response{Status: 200, Payload: []byte("result")}, SimulationResult: readSet(t)}
+
+	query := cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "get", nil))
+	assert.NotNil(t, query)
+	assert.Nil(t, query.Result())
+	query.Store(result, nil)
+
+	// identical proposals get the result, whatever their transaction ID
+	assert.Equal(t, result, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "get", nil)).Result())
+	// the result is specific to the channel, the chaincode, the input and the creator
+	assert.Nil(t, cache.NewQuery("ch2", "mycc", queryProposal(t, []byte("alice"), "get", nil)).Result())
+	assert.Nil(t, cache.NewQuery("ch1", "othercc", queryProposal(t, []byte("alice"), "get", nil)).Result())
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "list", nil)).Result())
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("bob"), "get", nil)).Result())
+
+	// the result expires after its time to live
+	now = now.Add(time.Minute)
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "get", nil)).Result())
+	assert.Empty(t, cache.channels["ch1"].entries)
+}
+
+func TestQueryCacheTransientData(t *testing.T) {
+	cache := NewQueryCache(time.Minute, 10)
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "get", map[string][]byte{"secret": []byte("s")})))
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", &pb.Proposal{Header: []byte("garbage")}))
+}
+
+func TestQueryCacheStore(t *testing.T) {
+	cache := NewQueryCache(time.Minute, 10)
+	newQuery := func() *Query {
+		return cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "get", nil))
+	}
+
+	// the results of the failed proposals, of the proposals that wrote or that set
+	// an event are not cached
+	newQuery().Store(&QueryResult{Response: &pb.Response{Status: 500}, SimulationResult: readSet(t)}, nil)
+	newQuery().Store(&QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: readSet(t, &kvrwset.KVWrite{Key: "key"})}, nil)
+	newQuery().Store(&QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: []byte("garbage")}, nil)
+	newQuery().Store(&QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: readSet(t)}, &pb.ChaincodeEvent{EventName: "event"})
+	assert.Nil(t, newQuery().Result())
+
+	// the result of a query created before an invalidation is not cached
+	query := newQuery()
+	cache.Invalidate("ch1")
+	query.Store(&QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: readSet(t)}, nil)
+	assert.Nil(t, newQuery().Result())
+
+	result := &QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: readSet(t)}
+	newQuery().Store(result, nil)
+	assert.Equal(t, result, newQuery().Result())
+	// an invalidation forgets the results of the channel
+	cache.Invalidate("ch2")
+	assert.Equal(t, result, newQuery().Result())
+	cache.Invalidate("ch1")
+	assert.Nil(t, newQuery().Result())
+}
+
+func TestQueryCacheMaxEntries(t *testing.T) {
+	cache := NewQueryCache(time.Minute, 2)
+	for _, arg := range []string{"a", "b", "c"} {
+		cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), arg, nil)).Store(&QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: readSet(t)}, nil)
+	}
+	assert.Len(t, cache.channels["ch1"].entries, 2)
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "a", nil)).Result())
+	assert.NotNil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "c", nil)).Result())
+}
+
+func TestQueryCacheSetLimits(t *testing.T) {
+	now := time.Now()
+	cache := NewQueryCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+	store := func(arg string) {
+		cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), arg, nil)).Store(&QueryResult{Response: &pb.Response{Status: 200}, SimulationResult: readSet(t)}, nil)
+	}
+	for _, arg := range []string{"a", "b", "c"} {
+		store(arg)
+	}
+
+	assert.EqualError(t, cache.SetLimits(0, 10), "the query cache cannot be disabled without a restart")
+	assert.EqualError(t, cache.SetLimits(time.Minute, 0), "the query cache cannot be disabled without a restart")
+
+	// the oldest results are forgotten past the new maximum
+	assert.NoError(t, cache.SetLimits(time.Hour, 2))
+	assert.Len(t, cache.channels["ch1"].entries, 2)
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "a", nil)).Result())
+
+	// the new results are cached for the new time to live
+	store("d")
+	now = now.Add(30 * time.Minute)
+	assert.NotNil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "d", nil)).Result())
+	assert.Nil(t, cache.NewQuery("ch1", "mycc", queryProposal(t, []byte("alice"), "c", nil)).Result())
+}
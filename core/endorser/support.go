@@ -36,6 +36,10 @@ type SupportImpl struct {
 	ChaincodeSupport *chaincode.ChaincodeSupport
 	SysCCProvider    *scc.Provider
 	ACLProvider      aclmgmt.ACLProvider
+	// LocalSigningIdentity returns the current signing identity of the peer, so
+	// that the endorsements follow the rotations of its signing identities. The
+	// SignerSupport signs the endorsements when it is not set
+	LocalSigningIdentity func() (SigningIdentity, error)
 }
 
 func (s *SupportImpl) NewQueryCreator(channel string) (QueryCreator, error) {
@@ -47,6 +51,9 @@ func (s *SupportImpl) NewQueryCreator(channel string) (QueryCreator, error) {
 }
 
 func (s *SupportImpl) SigningIdentityForRequest(*pb.SignedProposal) (SigningIdentity, error) {
+	if s.LocalSigningIdentity != nil {
+		return s.LocalSigningIdentity()
+	}
 	return s.SignerSupport, nil
 }
 
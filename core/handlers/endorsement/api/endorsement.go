@@ -34,6 +34,28 @@ type Plugin interface {
 	Init(dependencies ...Dependency) error
 }
 
+// ResponseTransformer is an optional interface that a Plugin implements in order to transform the
+// proposal response after the endorsement, for instance to attach proofs that are specific to the
+// application to the response returned to the client.
+// The peer invokes it only if the plugin is granted the ResponseTransformCapability
+type ResponseTransformer interface {
+	// TransformResponse returns the proposal response to be sent to the client in place of the given one.
+	// If the payload is modified, the plugin is responsible for endorsing the modified payload
+	TransformResponse(resp *peer.ProposalResponse, sp *peer.SignedProposal) (*peer.ProposalResponse, error)
+}
+
+// Capability is a privileged facility of the peer that is made available to a Plugin
+// only if the plugin is explicitly granted the capability in the configuration of the peer
+type Capability string
+
+const (
+	// TransientDataCapability grants the Plugin the TransientDataFetcher dependency
+	TransientDataCapability Capability = "TransientData"
+	// ResponseTransformCapability allows the Plugin to transform the proposal response
+	// by implementing the ResponseTransformer interface
+	ResponseTransformCapability Capability = "ResponseTransform"
+)
+
 // PluginFactory creates a new instance of a Plugin
 type PluginFactory interface {
 	New() Plugin
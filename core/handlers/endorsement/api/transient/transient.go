@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorsement
+
+import (
+	"github.com/hyperledger/fabric/core/handlers/endorsement/api"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// TransientDataFetcher retrieves the transient data of the proposals.
+// It is passed to the Init() method only if the plugin is granted the TransientDataCapability
+type TransientDataFetcher interface {
+	endorsement.Dependency
+
+	// TransientData returns the transient map of the given signed proposal
+	TransientData(sp *peer.SignedProposal) (map[string][]byte, error)
+}
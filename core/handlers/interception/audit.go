@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interception
+
+import (
+	"context"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AuditLogName is the name of the compiled interceptor which records an audit
+// log entry for each call
+const AuditLogName = "AuditLog"
+
+func init() {
+	Register(AuditLogName, NewAuditLog)
+}
+
+type auditLog struct {
+	logger *flogging.FabricLogger
+}
+
+// NewAuditLog returns an interceptor which records, for each call once it
+// completes, the method, the address and the TLS identity of the client,
+// the duration and the status code of the call
+func NewAuditLog() Interceptor {
+	return &auditLog{logger: flogging.MustGetLogger("audit")}
+}
+
+func (a *auditLog) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		a.record(ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func (a *auditLog) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		a.record(ss.Context(), info.FullMethod, start, err)
+		return err
+	}
+}
+
+func (a *auditLog) record(ctx context.Context, method string, start time.Time, err error) {
+	address, identity := "unknown", "none"
+	if p, ok := peer.FromContext(ctx); ok {
+		if p.Addr != nil {
+			address = p.Addr.String()
+		}
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			identity = tlsInfo.State.PeerCertificates[0].Subject.String()
+		}
+	}
+	a.logger.Infow("Served call",
+		"method", method,
+		"client", address,
+		"identity", identity,
+		"duration", time.Since(start),
+		"code", status.Code(err).String(),
+	)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interception
+
+import (
+	"os"
+	"plugin"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+var logger = flogging.MustGetLogger("core.handlers.interception")
+
+// Interceptor intercepts the gRPC calls served by a peer or an orderer before
+// they reach the services, to authenticate, audit or rate limit them
+type Interceptor interface {
+	// UnaryServerInterceptor returns the interceptor of the unary calls, or
+	// nil if the unary calls are not intercepted
+	UnaryServerInterceptor() grpc.UnaryServerInterceptor
+	// StreamServerInterceptor returns the interceptor of the streams, or nil
+	// if the streams are not intercepted
+	StreamServerInterceptor() grpc.StreamServerInterceptor
+}
+
+// Factory creates an interceptor
+type Factory func() Interceptor
+
+// pluginFactory is the name of the constructor of the interceptor of a
+// plugin, of type func() Interceptor
+const pluginFactory = "NewInterceptor"
+
+// Config configures an interceptor, either compiled in the binary and
+// registered under a name, or loaded from a Go plugin
+type Config struct {
+	// Name is the name under which a compiled interceptor is registered
+	Name string
+	// Library is the path to the plugin of the interceptor
+	Library string
+}
+
+var registry = struct {
+	sync.Mutex
+	factories map[string]Factory
+}{
+	factories: map[string]Factory{},
+}
+
+// Register registers a compiled interceptor under a name, so that the
+// configuration of a peer or an orderer can refer to it. It is meant to be
+// called from the init function of the package of the interceptor.
+func Register(name string, factory Factory) {
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.factories[name]; ok {
+		logger.Panicf("Interceptor %s is already registered", name)
+	}
+	registry.factories[name] = factory
+}
+
+// Registered returns the sorted names of the compiled interceptors
+func Registered() []string {
+	registry.Lock()
+	defer registry.Unlock()
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load creates the configured interceptors, in the order of the configuration
+func Load(configs []Config) ([]Interceptor, error) {
+	var interceptors []Interceptor
+	for _, config := range configs {
+		var interceptor Interceptor
+		var err error
+		if config.Library != "" {
+			interceptor, err = loadPlugin(config.Library)
+		} else {
+			interceptor, err = loadCompiled(config.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return nil, errors.Errorf("interceptor %s%s is nil", config.Name, config.Library)
+		}
+		logger.Infof("Loaded interceptor %s%s", config.Name, config.Library)
+		interceptors = append(interceptors, interceptor)
+	}
+	return interceptors, nil
+}
+
+func loadCompiled(name string) (Interceptor, error) {
+	registry.Lock()
+	factory, ok := registry.factories[name]
+	registry.Unlock()
+	if !ok {
+		return nil, errors.Errorf("interceptor %s is not registered, the registered interceptors are %v", name, Registered())
+	}
+	return factory(), nil
+}
+
+func loadPlugin(path string) (Interceptor, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, errors.Wrapf(err, "could not find interceptor plugin at path %s", path)
+	}
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening interceptor plugin at path %s", path)
+	}
+	constructorSymbol, err := p.Lookup(pluginFactory)
+	if err != nil {
+		return nil, errors.Wrapf(err, "interceptor plugin %s must contain constructor with name %s", path, pluginFactory)
+	}
+	constructor, ok := constructorSymbol.(func() Interceptor)
+	if !ok {
+		return nil, errors.Errorf("constructor %s of interceptor plugin %s does not match expected definition", pluginFactory, path)
+	}
+	return constructor(), nil
+}
+
+// ServerInterceptors returns the unary and stream server interceptors of the
+// interceptors, in order
+func ServerInterceptors(interceptors []Interceptor) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor) {
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	for _, interceptor := range interceptors {
+		if u := interceptor.UnaryServerInterceptor(); u != nil {
+			unary = append(unary, u)
+		}
+		if s := interceptor.StreamServerInterceptor(); s != nil {
+			stream = append(stream, s)
+		}
+	}
+	return unary, stream
+}
@@ -0,0 +1,97 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interception
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/flogging/floggingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type unaryOnly struct{}
+
+func (unaryOnly) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(ctx, req)
+	}
+}
+
+func (unaryOnly) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return nil
+}
+
+func TestRegisterAndLoad(t *testing.T) {
+	Register("UnaryOnly", func() Interceptor { return unaryOnly{} })
+	Register("Nil", func() Interceptor { return nil })
+	defer func() {
+		registry.Lock()
+		delete(registry.factories, "UnaryOnly")
+		delete(registry.factories, "Nil")
+		registry.Unlock()
+	}()
+
+	assert.Equal(t, []string{AuditLogName, "Nil", "UnaryOnly"}, Registered())
+	assert.Panics(t, func() { Register("UnaryOnly", func() Interceptor { return unaryOnly{} }) })
+
+	interceptors, err := Load([]Config{{Name: "UnaryOnly"}, {Name: AuditLogName}})
+	require.NoError(t, err)
+	require.Len(t, interceptors, 2)
+	assert.Equal(t, unaryOnly{}, interceptors[0])
+
+	unary, stream := ServerInterceptors(interceptors)
+	assert.Len(t, unary, 2)
+	assert.Len(t, stream, 1)
+
+	interceptors, err = Load(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, interceptors)
+
+	_, err = Load([]Config{{Name: "Unknown"}})
+	assert.EqualError(t, err, "interceptor Unknown is not registered, the registered interceptors are [AuditLog Nil UnaryOnly]")
+
+	_, err = Load([]Config{{Name: "Nil"}})
+	assert.EqualError(t, err, "interceptor Nil is nil")
+
+	_, err = Load([]Config{{Library: "testdata/missing.so"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not find interceptor plugin at path testdata/missing.so")
+}
+
+func TestAuditLog(t *testing.T) {
+	logger, recorder := floggingtest.NewTestLogger(t)
+	audit := &auditLog{logger: logger}
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 7051}})
+	info := &grpc.UnaryServerInfo{FullMethod: "/protos.Endorser/ProcessProposal"}
+	resp, err := audit.UnaryServerInterceptor()(ctx, "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "response", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+
+	_, err = audit.UnaryServerInterceptor()(context.Background(), "request", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	})
+	assert.Error(t, err)
+
+	entries := recorder.EntriesContaining("Served call")
+	require.Len(t, entries, 2)
+	assert.Contains(t, entries[0], "method=/protos.Endorser/ProcessProposal")
+	assert.Contains(t, entries[0], "client=10.0.0.1:7051")
+	assert.Contains(t, entries[0], "identity=none")
+	assert.Contains(t, entries[0], "code=OK")
+	assert.Contains(t, entries[1], "client=unknown")
+	assert.Contains(t, entries[1], "code=PermissionDenied")
+}
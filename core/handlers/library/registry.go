@@ -65,6 +65,9 @@ type Config struct {
 	Decorators  []*HandlerConfig `mapstructure:"decorators" yaml:"decorators"`
 	Endorsers   PluginMapping    `mapstructure:"endorsers" yaml:"endorsers"`
 	Validators  PluginMapping    `mapstructure:"validators" yaml:"validators"`
+	// Interceptors are the interceptors of the gRPC servers of the peer,
+	// loaded by the interception package
+	Interceptors []*HandlerConfig `mapstructure:"interceptors" yaml:"interceptors"`
 }
 
 type PluginMapping map[string]*HandlerConfig
@@ -73,6 +76,8 @@ type PluginMapping map[string]*HandlerConfig
 type HandlerConfig struct {
 	Name    string `mapstructure:"name" yaml:"name"`
 	Library string `mapstructure:"library" yaml:"library"`
+	// Capabilities lists the privileged facilities granted to an endorsement plugin
+	Capabilities []string `mapstructure:"capabilities" yaml:"capabilities"`
 }
 
 // InitRegistry creates the (only) instance
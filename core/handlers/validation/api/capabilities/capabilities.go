@@ -54,4 +54,40 @@ type Capabilities interface {
 
 	// FabToken returns true if fabric token function is supported.
 	FabToken() bool
+
+	// CommutativeUpdates returns true if the increments of keys are supported.
+	CommutativeUpdates() bool
+
+	// CommitHash returns true if the commit hashes are recorded in the block metadata.
+	CommitHash() bool
+
+	// ChaincodeMigration returns true if the upgrades of chaincodes with a migration are supported.
+	ChaincodeMigration() bool
+
+	// TxExpiration returns true if the expiration heights of the transactions are enforced.
+	TxExpiration() bool
+
+	// ChaincodeBatches returns true if the transactions invoking several chaincodes atomically are supported.
+	ChaincodeBatches() bool
+
+	// ScheduledTransactions returns true if the transactions submitted on behalf of their creator are supported.
+	ScheduledTransactions() bool
+
+	// CustomConfigGroups returns true if the custom groups of the application config are supported.
+	CustomConfigGroups() bool
+
+	// ResourceBudgets returns true if the budgets of the resources used by the simulation of the proposals are supported.
+	ResourceBudgets() bool
+
+	// ChaincodeContracts returns true if the endorsement policies of the contracts of the chaincodes are supported.
+	ChaincodeContracts() bool
+
+	// BinaryChaincodes returns true if the chaincodes packaged as prebuilt binaries are supported.
+	BinaryChaincodes() bool
+
+	// SystemChaincodePolicies returns true if the endorsement policies of the namespaces of the system chaincodes are supported.
+	SystemChaincodePolicies() bool
+
+	// RangeDeletes returns true if the deletions of ranges of keys recorded as a single entry of the write set are supported.
+	RangeDeletes() bool
 }
@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package validation
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/handlers/validation/api"
+)
+
+// CustomConfigGroups gives access to the custom groups of the application config
+// of the channel, such as the fee schedules or the network parameters defined by
+// the consortium
+type CustomConfigGroups interface {
+	validation.Dependency
+	// CustomGroup returns the custom group of the application config with the given
+	// name, as of the config of the channel when the transactions are validated, or
+	// false if there is no such group
+	CustomGroup(name string) (CustomGroup, bool)
+}
+
+// CustomGroup is a custom group of the application config, whose values are
+// opaque to the channel config
+type CustomGroup interface {
+	// Name returns the name of the group in the application config
+	Name() string
+
+	// Keys returns the sorted keys of the values of the group
+	Keys() []string
+
+	// Value returns the value of the group with the given key, or false if the
+	// group has no such value
+	Value(key string) ([]byte, bool)
+
+	// UnmarshalValue unmarshals the value of the group with the given key into
+	// the given message
+	UnmarshalValue(key string, msg proto.Message) error
+}
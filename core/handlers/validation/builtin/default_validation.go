@@ -64,7 +64,8 @@ func (v *DefaultValidation) Validate(block *common.Block, namespace string, txPo
 
 	var err error
 	switch {
-	case v.Capabilities.V1_3Validation():
+	case v.Capabilities.V1_3Validation(), v.Capabilities.KeyLevelEndorsement():
+		// the v1.3 validation enforces the key level endorsement policies
 		err = v.TxValidatorV1_3.Validate(block, namespace, txPosition, actionPosition, serializedPolicy.Bytes())
 
 	case v.Capabilities.V1_2Validation():
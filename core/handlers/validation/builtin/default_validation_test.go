@@ -53,6 +53,7 @@ func TestErrorConversion(t *testing.T) {
 	}
 
 	capabilities.On("V1_3Validation").Return(false)
+	capabilities.On("KeyLevelEndorsement").Return(false)
 	capabilities.On("V1_2Validation").Return(true)
 
 	// Scenario I: An error that isn't *commonerrors.ExecutionFailureError or *commonerrors.VSCCEndorsementPolicyError
@@ -77,6 +78,31 @@ func TestErrorConversion(t *testing.T) {
 	assert.NoError(t, validation.Validate(block, "", 0, 0, txvalidator.SerializedPolicy("policy")))
 }
 
+func TestValidateKeyLevelEndorsement(t *testing.T) {
+	validatorV12 := &vmocks.TransactionValidator{}
+	validatorV13 := &vmocks.TransactionValidator{}
+	capabilities := &mocks.Capabilities{}
+	validation := &DefaultValidation{
+		TxValidatorV1_2: validatorV12,
+		TxValidatorV1_3: validatorV13,
+		Capabilities:    capabilities,
+	}
+	block := &common.Block{
+		Header: &common.BlockHeader{},
+		Data: &common.BlockData{
+			Data: [][]byte{{}},
+		},
+	}
+
+	// the state-based endorsement capability alone selects the v1.3 validation
+	capabilities.On("V1_3Validation").Return(false)
+	capabilities.On("KeyLevelEndorsement").Return(true)
+	validatorV13.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	assert.NoError(t, validation.Validate(block, "", 0, 0, txvalidator.SerializedPolicy("policy")))
+	validatorV13.AssertNumberOfCalls(t, "Validate", 1)
+	validatorV12.AssertNotCalled(t, "Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestValidateBadInput(t *testing.T) {
 	validator := &vmocks.TransactionValidator{}
 	validation := &DefaultValidation{
@@ -22,6 +22,62 @@ func (_m *Capabilities) ACLs() bool {
 	return r0
 }
 
+// BinaryChaincodes provides a mock function with given fields:
+func (_m *Capabilities) BinaryChaincodes() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ChaincodeBatches provides a mock function with given fields:
+func (_m *Capabilities) ChaincodeBatches() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ChaincodeContracts provides a mock function with given fields:
+func (_m *Capabilities) ChaincodeContracts() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ChaincodeMigration provides a mock function with given fields:
+func (_m *Capabilities) ChaincodeMigration() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // CollectionUpgrade provides a mock function with given fields:
 func (_m *Capabilities) CollectionUpgrade() bool {
 	ret := _m.Called()
@@ -36,6 +92,48 @@ func (_m *Capabilities) CollectionUpgrade() bool {
 	return r0
 }
 
+// CommitHash provides a mock function with given fields:
+func (_m *Capabilities) CommitHash() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// CommutativeUpdates provides a mock function with given fields:
+func (_m *Capabilities) CommutativeUpdates() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// CustomConfigGroups provides a mock function with given fields:
+func (_m *Capabilities) CustomConfigGroups() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // FabToken provides a mock function with given fields:
 func (_m *Capabilities) FabToken() bool {
 	ret := _m.Called()
@@ -106,6 +204,48 @@ func (_m *Capabilities) PrivateChannelData() bool {
 	return r0
 }
 
+// RangeDeletes provides a mock function with given fields:
+func (_m *Capabilities) RangeDeletes() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ResourceBudgets provides a mock function with given fields:
+func (_m *Capabilities) ResourceBudgets() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// ScheduledTransactions provides a mock function with given fields:
+func (_m *Capabilities) ScheduledTransactions() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Supported provides a mock function with given fields:
 func (_m *Capabilities) Supported() error {
 	ret := _m.Called()
@@ -120,6 +260,34 @@ func (_m *Capabilities) Supported() error {
 	return r0
 }
 
+// SystemChaincodePolicies provides a mock function with given fields:
+func (_m *Capabilities) SystemChaincodePolicies() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// TxExpiration provides a mock function with given fields:
+func (_m *Capabilities) TxExpiration() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // V1_1Validation provides a mock function with given fields:
 func (_m *Capabilities) V1_1Validation() bool {
 	ret := _m.Called()
@@ -16,6 +16,7 @@ import (
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/binary"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
@@ -496,16 +497,22 @@ func (vscc *Validator) ValidateLSCCInvocation(
 			return policyErr(fmt.Errorf("Wrong number of arguments for invocation lscc(%s): received %d", lsccFunc, len(lsccArgs)))
 		}
 
-		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(
-			// XXX We should definitely _not_ have this external dependency in VSCC
-			// as adding a platform could cause non-determinism.  This is yet another
-			// reason why all of this custom LSCC validation at commit time has no
-			// long term hope of staying deterministic and needs to be removed.
+		// XXX We should definitely _not_ have this external dependency in VSCC
+		// as adding a platform could cause non-determinism.  This is yet another
+		// reason why all of this custom LSCC validation at commit time has no
+		// long term hope of staying deterministic and needs to be removed.
+		validPlatforms := []platforms.Platform{
 			&golang.Platform{},
 			&node.Platform{},
 			&java.Platform{},
 			&car.Platform{},
-		))
+		}
+		// the binary chaincodes are only deployed on the channels whose committers
+		// all support them, as the earlier committers reject their deployments
+		if ac.BinaryChaincodes() {
+			validPlatforms = append(validPlatforms, &binary.Platform{})
+		}
+		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(validPlatforms...))
 
 		if err != nil {
 			return policyErr(fmt.Errorf("GetChaincodeDeploymentSpec error %s", err))
@@ -580,7 +587,7 @@ func (vscc *Validator) ValidateLSCCInvocation(
 		}
 		// it must only write to 2 namespaces: LSCC's and the cc that we are deploying/upgrading
 		for _, ns := range txRWSet.NsRwSets {
-			if ns.NameSpace != "lscc" && ns.NameSpace != cdRWSet.Name && len(ns.KvRwSet.Writes) > 0 {
+			if ns.NameSpace != "lscc" && ns.NameSpace != cdRWSet.Name && (len(ns.KvRwSet.Writes) > 0 || len(ns.KvRwSet.RangeDeletes) > 0) {
 				return policyErr(fmt.Errorf("LSCC invocation is attempting to write to namespace %s", ns.NameSpace))
 			}
 		}
@@ -927,6 +927,45 @@ func TestValidateDeployOK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateDeployBinaryChaincode(t *testing.T) {
+	state := make(map[string]map[string][]byte)
+	state["lscc"] = make(map[string][]byte)
+	qec := &mocks2.QueryExecutorCreator{}
+	qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(state), nil)
+
+	ccname := "mycc"
+	ccver := "1"
+
+	defaultPolicy, err := getSignedByMSPAdminPolicy(mspid)
+	assert.NoError(t, err)
+	res, err := createCCDataRWset(ccname, ccname, ccver, defaultPolicy)
+	assert.NoError(t, err)
+
+	cds := &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: ccname, Version: ccver},
+			Type:        peer.ChaincodeSpec_BINARY,
+		},
+	}
+	tx, err := createLSCCTxPutCds(ccname, ccver, lscc.DEPLOY, res, utils.MarshalOrPanic(cds), true)
+	assert.NoError(t, err)
+	envBytes, err := utils.GetBytesEnvelope(tx)
+	assert.NoError(t, err)
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{envBytes}}, Header: &common.BlockHeader{}}
+
+	// the binary platform is unknown to the channels not supporting the binary chaincodes
+	v := newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{})
+	err = v.Validate(b, "lscc", 0, 0, policy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unknown chaincodeType: BINARY")
+
+	v = newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{BinaryChaincodesRv: true})
+	err = v.Validate(b, "lscc", 0, 0, policy)
+	assert.NoError(t, err)
+}
+
 func TestValidateDeployWithCollection(t *testing.T) {
 	state := make(map[string]map[string][]byte)
 	mp := (&scc.MocksccProviderFactory{
@@ -1741,6 +1780,10 @@ func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *
 	return nil
 }
 
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return nil
+}
+
 func createCollectionConfig(collectionName string, signaturePolicyEnvelope *common.SignaturePolicyEnvelope,
 	requiredPeerCount int32, maximumPeerCount int32, blockToLive uint64,
 ) *common.CollectionConfig {
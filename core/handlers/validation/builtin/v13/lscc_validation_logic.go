@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/binary"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/ccmetadata"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
@@ -52,6 +53,30 @@ func (vscc *Validator) checkInstantiationPolicy(chainName string, env *common.En
 	return nil
 }
 
+// checkMigrationEndorsementPolicy evaluates the endorsement policy of the upgraded chaincode
+// against the endorsements of an upgrade with a migration, whose Migrate function writes to
+// the namespace of the chaincode on behalf of the organizations endorsing the upgrade
+func (vscc *Validator) checkMigrationEndorsementPolicy(cap *pb.ChaincodeActionPayload, endorsementPolicy []byte) commonerrors.TxValidationError {
+	prp := cap.Action.ProposalResponsePayload
+	sd := make([]*common.SignedData, 0, len(cap.Action.Endorsements))
+	for _, endorsement := range cap.Action.Endorsements {
+		data := make([]byte, len(prp)+len(endorsement.Endorser))
+		copy(data, prp)
+		copy(data[len(prp):], endorsement.Endorser)
+
+		sd = append(sd, &common.SignedData{
+			Data:      data,
+			Identity:  endorsement.Endorser,
+			Signature: endorsement.Signature,
+		})
+	}
+	err := vscc.policyEvaluator.Evaluate(endorsementPolicy, sd)
+	if err != nil {
+		return policyErr(fmt.Errorf("endorsement policy of the migrated chaincode violated, error %s", err))
+	}
+	return nil
+}
+
 func validateNewCollectionConfigs(newCollectionConfigs []*common.CollectionConfig) error {
 	newCollectionsMap := make(map[string]bool, len(newCollectionConfigs))
 	// Process each collection config from a set of collection configs
@@ -379,16 +404,22 @@ func (vscc *Validator) ValidateLSCCInvocation(
 			return policyErr(fmt.Errorf("Wrong number of arguments for invocation lscc(%s): received %d", lsccFunc, len(lsccArgs)))
 		}
 
-		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(
-			// XXX We should definitely _not_ have this external dependency in VSCC
-			// as adding a platform could cause non-determinism.  This is yet another
-			// reason why all of this custom LSCC validation at commit time has no
-			// long term hope of staying deterministic and needs to be removed.
+		// XXX We should definitely _not_ have this external dependency in VSCC
+		// as adding a platform could cause non-determinism.  This is yet another
+		// reason why all of this custom LSCC validation at commit time has no
+		// long term hope of staying deterministic and needs to be removed.
+		validPlatforms := []platforms.Platform{
 			&golang.Platform{},
 			&node.Platform{},
 			&java.Platform{},
 			&car.Platform{},
-		))
+		}
+		// the binary chaincodes are only deployed on the channels whose committers
+		// all support them, as the earlier committers reject their deployments
+		if ac.BinaryChaincodes() {
+			validPlatforms = append(validPlatforms, &binary.Platform{})
+		}
+		cdsArgs, err := utils.GetChaincodeDeploymentSpec(lsccArgs[1], platforms.NewRegistry(validPlatforms...))
 
 		if err != nil {
 			return policyErr(fmt.Errorf("GetChaincodeDeploymentSpec error %s", err))
@@ -463,13 +494,17 @@ func (vscc *Validator) ValidateLSCCInvocation(
 		}
 		// it must only write to 2 namespaces: LSCC's and the cc that we are deploying/upgrading
 		for _, ns := range txRWSet.NsRwSets {
-			if ns.NameSpace != "lscc" && ns.NameSpace != cdRWSet.Name && len(ns.KvRwSet.Writes) > 0 {
+			if ns.NameSpace != "lscc" && ns.NameSpace != cdRWSet.Name && (len(ns.KvRwSet.Writes) > 0 || len(ns.KvRwSet.RangeDeletes) > 0) {
 				return policyErr(fmt.Errorf("LSCC invocation is attempting to write to namespace %s", ns.NameSpace))
 			}
 		}
 
 		logger.Debugf("Validating %s for cc %s version %s", lsccFunc, cdRWSet.Name, cdRWSet.Version)
 
+		// the migrations are ignored unless the capability is enabled, so that
+		// the peers which do not support them reach the same result
+		migrate := ac.ChaincodeMigration() && cdsArgs.Migrate
+
 		switch lsccFunc {
 		case lscc.DEPLOY:
 
@@ -480,6 +515,10 @@ func (vscc *Validator) ValidateLSCCInvocation(
 				return policyErr(fmt.Errorf("Chaincode %s is already instantiated", cdsArgs.ChaincodeSpec.ChaincodeId.Name))
 			}
 
+			if migrate {
+				return policyErr(fmt.Errorf("Chaincode %s cannot be instantiated with a migration", cdsArgs.ChaincodeSpec.ChaincodeId.Name))
+			}
+
 			/****************************************************************************/
 			/* security check 2 - validation of rwset (and of collections if enabled) */
 			/****************************************************************************/
@@ -579,6 +618,16 @@ func (vscc *Validator) ValidateLSCCInvocation(
 					}
 				}
 			}
+
+			/*************************************************************************/
+			/* security check 6 - the migration satisfies the new endorsement policy */
+			/*************************************************************************/
+			if migrate {
+				err = vscc.checkMigrationEndorsementPolicy(cap, cdRWSet.Policy)
+				if err != nil {
+					return err
+				}
+			}
 		}
 
 		// all is good!
@@ -901,6 +901,45 @@ func TestValidateDeployOK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateDeployBinaryChaincode(t *testing.T) {
+	state := make(map[string]map[string][]byte)
+	state["lscc"] = make(map[string][]byte)
+	qec := &mocks2.QueryExecutorCreator{}
+	qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(state), nil)
+
+	ccname := "mycc"
+	ccver := "1"
+
+	defaultPolicy, err := getSignedByMSPAdminPolicy(mspid)
+	assert.NoError(t, err)
+	res, err := createCCDataRWset(ccname, ccname, ccver, defaultPolicy)
+	assert.NoError(t, err)
+
+	cds := &peer.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: ccname, Version: ccver},
+			Type:        peer.ChaincodeSpec_BINARY,
+		},
+	}
+	tx, err := createLSCCTxPutCds(ccname, ccver, lscc.DEPLOY, res, utils.MarshalOrPanic(cds), true)
+	assert.NoError(t, err)
+	envBytes, err := utils.GetBytesEnvelope(tx)
+	assert.NoError(t, err)
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+	b := &common.Block{Data: &common.BlockData{Data: [][]byte{envBytes}}, Header: &common.BlockHeader{}}
+
+	// the binary platform is unknown to the channels not supporting the binary chaincodes
+	v := newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{})
+	err = v.Validate(b, "lscc", 0, 0, policy)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unknown chaincodeType: BINARY")
+
+	v = newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{BinaryChaincodesRv: true})
+	err = v.Validate(b, "lscc", 0, 0, policy)
+	assert.NoError(t, err)
+}
+
 func TestValidateDeployWithCollection(t *testing.T) {
 	state := make(map[string]map[string][]byte)
 	mp := (&scc.MocksccProviderFactory{
@@ -1203,6 +1242,86 @@ func TestValidateUpgradeOK(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateUpgradeWithMigration(t *testing.T) {
+	state := make(map[string]map[string][]byte)
+	mp := (&scc.MocksccProviderFactory{
+		Qe:                    lm.NewMockQueryExecutor(state),
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+	}).NewSystemChaincodeProvider().(*scc.MocksccProviderImpl)
+
+	qec := &mocks2.QueryExecutorCreator{}
+	qec.On("NewQueryExecutor").Return(lm.NewMockQueryExecutor(state), nil)
+	v := newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{ChaincodeMigrationRv: true})
+	vNoMigration := newCustomValidationInstance(qec, &mc.MockApplicationCapabilities{})
+
+	mockAclProvider := &aclmocks.MockACLProvider{}
+	lccc := lscc.New(mp, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	stublccc := shim.NewMockStub("lscc", lccc)
+	state["lscc"] = stublccc.State
+
+	ccname := "mycc"
+	ccver := "upgradewithmigration"
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+
+	cds, err := constructDeploymentSpec(ccname, path, ccver, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, true)
+	assert.NoError(t, err)
+	b := utils.MarshalOrPanic(cds)
+
+	sProp2, _ := utils.MockSignedEndorserProposal2OrPanic(chainId, &peer.ChaincodeSpec{}, id)
+	args := [][]byte{[]byte("deploy"), []byte(ccname), b}
+	res := stublccc.MockInvokeWithSignedProposal("1", args, sProp2)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	policy, err := getSignedByMSPMemberPolicy(mspid)
+	assert.NoError(t, err)
+
+	validate := func(v *Validator, ccname, f string, endorsementPolicy []byte) error {
+		cd := &ccprovider.ChaincodeData{
+			Name:                ccname,
+			Version:             "2",
+			Policy:              endorsementPolicy,
+			InstantiationPolicy: policy,
+		}
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		rwsetBuilder.AddToWriteSet("lscc", ccname, utils.MarshalOrPanic(cd))
+		sr, err := rwsetBuilder.GetTxSimulationResults()
+		assert.NoError(t, err)
+		simresres, err := sr.GetPubSimulationBytes()
+		assert.NoError(t, err)
+
+		migratingCds := &peer.ChaincodeDeploymentSpec{
+			ChaincodeSpec: &peer.ChaincodeSpec{
+				ChaincodeId: &peer.ChaincodeID{Name: ccname, Version: "2"},
+				Type:        peer.ChaincodeSpec_GOLANG,
+			},
+			Migrate: true,
+		}
+		tx, err := createLSCCTxPutCds(ccname, "2", f, simresres, utils.MarshalOrPanic(migratingCds), true)
+		assert.NoError(t, err)
+		envBytes, err := utils.GetBytesEnvelope(tx)
+		assert.NoError(t, err)
+
+		bl := &common.Block{Data: &common.BlockData{Data: [][]byte{envBytes}}, Header: &common.BlockHeader{}}
+		return v.Validate(bl, "lscc", 0, 0, policy)
+	}
+
+	// the migration is endorsed according to the new endorsement policy
+	err = validate(v, ccname, lscc.UPGRADE, policy)
+	assert.NoError(t, err)
+
+	rejectAll := utils.MarshalOrPanic(cauthdsl.RejectAllPolicy)
+	err = validate(v, ccname, lscc.UPGRADE, rejectAll)
+	assert.EqualError(t, err, "endorsement policy of the migrated chaincode violated, error signature set did not satisfy policy")
+
+	// the migration is ignored when the capability is not enabled
+	err = validate(vNoMigration, ccname, lscc.UPGRADE, rejectAll)
+	assert.NoError(t, err)
+
+	err = validate(v, "othercc", lscc.DEPLOY, policy)
+	assert.EqualError(t, err, "Chaincode othercc cannot be instantiated with a migration")
+}
+
 func TestInvalidateUpgradeBadVersion(t *testing.T) {
 	state := make(map[string]map[string][]byte)
 	mp := (&scc.MocksccProviderFactory{
@@ -1715,6 +1834,10 @@ func (c *mockPolicyChecker) CheckPolicyNoChannel(policyName string, signedProp *
 	return nil
 }
 
+func (c *mockPolicyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	return nil
+}
+
 func createCollectionConfig(collectionName string, signaturePolicyEnvelope *common.SignaturePolicyEnvelope,
 	requiredPeerCount int32, maximumPeerCount int32, blockToLive uint64,
 ) *common.CollectionConfig {
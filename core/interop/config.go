@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interop
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// NetworkConfig is the configuration of the relays of another network
+type NetworkConfig struct {
+	ID string
+	// Relays are the addresses of the relays of the network
+	Relays []string
+	// TLSRootCertFiles are the files of the TLS root certificates of the
+	// relays of the network
+	TLSRootCertFiles []string
+}
+
+// Config is the configuration of the relay of the peer
+type Config struct {
+	Enabled bool
+	// NetworkID is the ID of the network of the peer
+	NetworkID string
+	// ExposedChaincodes are the chaincodes whose views are served to the other
+	// networks, as <channel>/<chaincode>
+	ExposedChaincodes []string
+	// Networks are the configurations of the other networks
+	Networks []NetworkConfig
+	// Timeout is the time to wait for the views requested from the relays of
+	// the other networks
+	Timeout time.Duration
+}
+
+// GlobalConfig returns the configuration of the relay of the peer
+func GlobalConfig() (*Config, error) {
+	c := &Config{
+		Enabled:           viper.GetBool("peer.interop.enabled"),
+		NetworkID:         viper.GetString("peer.networkId"),
+		ExposedChaincodes: viper.GetStringSlice("peer.interop.exposedChaincodes"),
+		Timeout:           viper.GetDuration("peer.interop.timeout"),
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if err := viper.UnmarshalKey("peer.interop.networks", &c.Networks); err != nil {
+		return nil, errors.Wrap(err, "invalid peer.interop.networks")
+	}
+	if c.Enabled && c.NetworkID == "" {
+		return nil, errors.New("peer.networkId must be set to enable the interop relay")
+	}
+	ids := map[string]bool{}
+	for _, network := range c.Networks {
+		switch {
+		case network.ID == "":
+			return nil, errors.New("a network of peer.interop.networks has no ID")
+		case network.ID == c.NetworkID:
+			return nil, errors.Errorf("network %s of peer.interop.networks is the network of the peer", network.ID)
+		case ids[network.ID]:
+			return nil, errors.Errorf("network %s of peer.interop.networks is configured twice", network.ID)
+		case len(network.Relays) == 0:
+			return nil, errors.Errorf("network %s of peer.interop.networks has no relays", network.ID)
+		}
+		ids[network.ID] = true
+	}
+	return c, nil
+}
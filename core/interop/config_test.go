@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interop
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalConfig(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.networkId", "network1")
+	viper.Set("peer.interop.enabled", true)
+	viper.Set("peer.interop.exposedChaincodes", []string{"channel1/ftscc"})
+	viper.Set("peer.interop.networks", []map[string]interface{}{
+		{"id": "network2", "relays": []string{"relay.network2:7051"}, "tlsRootCertFiles": []string{"tlsca.pem"}},
+	})
+
+	config, err := GlobalConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &Config{
+		Enabled:           true,
+		NetworkID:         "network1",
+		ExposedChaincodes: []string{"channel1/ftscc"},
+		Networks: []NetworkConfig{
+			{ID: "network2", Relays: []string{"relay.network2:7051"}, TLSRootCertFiles: []string{"tlsca.pem"}},
+		},
+		Timeout: 30 * time.Second,
+	}, config)
+
+	for _, tc := range []struct {
+		name        string
+		networks    []map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:        "no ID",
+			networks:    []map[string]interface{}{{"relays": []string{"relay.network2:7051"}}},
+			expectedErr: "a network of peer.interop.networks has no ID",
+		},
+		{
+			name:        "network of the peer",
+			networks:    []map[string]interface{}{{"id": "network1", "relays": []string{"relay.network2:7051"}}},
+			expectedErr: "network network1 of peer.interop.networks is the network of the peer",
+		},
+		{
+			name: "duplicate",
+			networks: []map[string]interface{}{
+				{"id": "network2", "relays": []string{"relay1.network2:7051"}},
+				{"id": "network2", "relays": []string{"relay2.network2:7051"}},
+			},
+			expectedErr: "network network2 of peer.interop.networks is configured twice",
+		},
+		{
+			name:        "no relays",
+			networks:    []map[string]interface{}{{"id": "network2"}},
+			expectedErr: "network network2 of peer.interop.networks has no relays",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			viper.Set("peer.interop.networks", tc.networks)
+			_, err := GlobalConfig()
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	viper.Set("peer.interop.networks", nil)
+	viper.Set("peer.networkId", "")
+	_, err = GlobalConfig()
+	assert.EqualError(t, err, "peer.networkId must be set to enable the interop relay")
+}
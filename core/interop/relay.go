@@ -0,0 +1,209 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package interop provides the relay of the views of the state between the
+// network of the peer and other Fabric networks. The views are verified by
+// the interop system chaincode of the channels trusting the other networks.
+package interop
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var logger = flogging.MustGetLogger("interop")
+
+// forwardedKey is the gRPC metadata key marking the requests forwarded by a
+// relay, which are not forwarded again
+const forwardedKey = "interop-forwarded"
+
+// Endorser endorses the proposals of the queries of the views
+type Endorser interface {
+	ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error)
+}
+
+// Relay serves the views of the exposed chaincodes of the network of the peer,
+// endorsed by the peer, and requests the views of the other networks from all
+// their relays, merging their endorsements.
+type Relay struct {
+	networkID string
+	exposed   map[string]bool
+	networks  map[string]*network
+	timeout   time.Duration
+	endorser  Endorser
+	signer    msp.SigningIdentity
+}
+
+type network struct {
+	relays []string
+	client *comm.GRPCClient
+}
+
+// NewRelay returns the relay with the given configuration, which queries the
+// endorser with proposals signed by the signer and connects to the relays of
+// the other networks with the given client configuration
+func NewRelay(config *Config, endorser Endorser, signer msp.SigningIdentity, clientConfig comm.ClientConfig) (*Relay, error) {
+	r := &Relay{
+		networkID: config.NetworkID,
+		exposed:   map[string]bool{},
+		networks:  map[string]*network{},
+		timeout:   config.Timeout,
+		endorser:  endorser,
+		signer:    signer,
+	}
+	for _, chaincode := range config.ExposedChaincodes {
+		if len(strings.Split(chaincode, "/")) != 2 {
+			return nil, errors.Errorf("invalid exposed chaincode %q, it must be <channel>/<chaincode>", chaincode)
+		}
+		r.exposed[chaincode] = true
+	}
+
+	for _, networkConfig := range config.Networks {
+		var secOpts comm.SecureOptions
+		if clientConfig.SecOpts != nil {
+			secOpts = *clientConfig.SecOpts
+		}
+		secOpts.ServerRootCAs = nil
+		for _, file := range networkConfig.TLSRootCertFiles {
+			rootCert, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read the TLS root certificate of network %s", networkConfig.ID)
+			}
+			secOpts.ServerRootCAs = append(secOpts.ServerRootCAs, rootCert)
+		}
+		networkClientConfig := clientConfig
+		networkClientConfig.SecOpts = &secOpts
+		client, err := comm.NewGRPCClient(networkClientConfig)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("could not create the client of network %s", networkConfig.ID))
+		}
+		r.networks[networkConfig.ID] = &network{relays: networkConfig.Relays, client: client}
+	}
+	return r, nil
+}
+
+// RequestView serves the view of the request if it is a view of the network of
+// the peer, and requests it from the relays of its network otherwise
+func (r *Relay) RequestView(ctx context.Context, req *pb.ViewRequest) (*pb.View, error) {
+	if req.Network == r.networkID {
+		return r.serveView(ctx, req)
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(forwardedKey)) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "the view of network %s was forwarded to a relay of network %s", req.Network, r.networkID)
+	}
+	network, ok := r.networks[req.Network]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "network %s is unknown", req.Network)
+	}
+	return r.forward(ctx, req, network)
+}
+
+func (r *Relay) serveView(ctx context.Context, req *pb.ViewRequest) (*pb.View, error) {
+	if !r.exposed[req.Channel+"/"+req.Chaincode] {
+		return nil, status.Errorf(codes.PermissionDenied, "chaincode %s of channel %s is not exposed to the other networks", req.Chaincode, req.Channel)
+	}
+
+	creator, err := r.signer.Serialize()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not serialize the identity of the relay: %s", err)
+	}
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+		ChaincodeId: &pb.ChaincodeID{Name: req.Chaincode},
+		Input:       &pb.ChaincodeInput{Args: req.Args},
+	}}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, req.Channel, cis, creator)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "could not create the proposal of the view: %s", err)
+	}
+	signedProp, err := utils.GetSignedProposal(prop, r.signer)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not sign the proposal of the view: %s", err)
+	}
+	resp, err := r.endorser.ProcessProposal(ctx, signedProp)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "could not endorse the view: %s", err)
+	}
+	if resp.GetResponse().GetStatus() >= shim.ERRORTHRESHOLD || resp.Endorsement == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the query of the view failed: %s", resp.GetResponse().GetMessage())
+	}
+	propBytes, err := proto.Marshal(prop)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not marshal the proposal of the view: %s", err)
+	}
+
+	logger.Debugf("Served the view of chaincode %s of channel %s", req.Chaincode, req.Channel)
+	return &pb.View{
+		Request:      req,
+		Endorsements: []*pb.ViewEndorsement{{Proposal: propBytes, Response: resp}},
+	}, nil
+}
+
+// forward requests the view from all the relays of its network and merges the
+// endorsements they served
+func (r *Relay) forward(ctx context.Context, req *pb.ViewRequest, network *network) (*pb.View, error) {
+	ctx, cancel := context.WithTimeout(metadata.AppendToOutgoingContext(ctx, forwardedKey, r.networkID), r.timeout)
+	defer cancel()
+
+	views := make([]*pb.View, len(network.relays))
+	errs := make([]error, len(network.relays))
+	var wg sync.WaitGroup
+	for i, address := range network.relays {
+		wg.Add(1)
+		go func(i int, address string) {
+			defer wg.Done()
+			views[i], errs[i] = r.requestView(ctx, req, network.client, address)
+		}(i, address)
+	}
+	wg.Wait()
+
+	view := &pb.View{Request: req}
+	var failures []string
+	for i, address := range network.relays {
+		if errs[i] != nil {
+			logger.Warningf("Relay %s of network %s did not serve the view: %s", address, req.Network, errs[i])
+			failures = append(failures, fmt.Sprintf("%s: %s", address, errs[i]))
+			continue
+		}
+		view.Endorsements = append(view.Endorsements, views[i].Endorsements...)
+	}
+	if len(view.Endorsements) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "no relay of network %s served the view: %s", req.Network, strings.Join(failures, "; "))
+	}
+	return view, nil
+}
+
+func (r *Relay) requestView(ctx context.Context, req *pb.ViewRequest, client *comm.GRPCClient, address string) (*pb.View, error) {
+	conn, err := client.NewConnection(address, "")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	view, err := pb.NewRelayClient(conn).RequestView(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !proto.Equal(view.Request, req) {
+		return nil, errors.New("the relay served the view of another request")
+	}
+	return view, nil
+}
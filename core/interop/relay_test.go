@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interop
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/core/scc/interopscc"
+	"github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// endorser endorses the proposals with the response of its chaincode
+type endorser struct {
+	signer   msp.SigningIdentity
+	response *pb.Response
+	err      error
+}
+
+func (e *endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	if e.response.Status >= shim.ERRORTHRESHOLD {
+		return &pb.ProposalResponse{Response: e.response}, nil
+	}
+	return utils.CreateProposalResponse(prop.Header, prop.Payload, e.response, nil, nil, &pb.ChaincodeID{Name: "ftscc"}, nil, e.signer)
+}
+
+func sampleOrg(t *testing.T) (*pb.InteropNetwork, msp.SigningIdentity) {
+	dir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	conf, err := msp.GetLocalMspConfig(dir, nil, "SampleOrg")
+	require.NoError(t, err)
+	localMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_3}})
+	require.NoError(t, err)
+	require.NoError(t, localMSP.Setup(conf))
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	verifyingConf, err := msp.GetVerifyingMspConfig(dir, "SampleOrg", "bccsp")
+	require.NoError(t, err)
+	policy, err := proto.Marshal(cauthdsl.SignedByMspMember("SampleOrg"))
+	require.NoError(t, err)
+	return &pb.InteropNetwork{Id: "network2", Msps: []*mspprotos.MSPConfig{verifyingConf}, Policy: policy}, signer
+}
+
+// startRelay starts a relay of network2 and returns its address along with
+// the function stopping it
+func startRelay(t *testing.T, e Endorser, signer msp.SigningIdentity, exposed ...string) (string, func()) {
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	relay, err := NewRelay(&Config{NetworkID: "network2", ExposedChaincodes: exposed}, e, signer, comm.ClientConfig{})
+	require.NoError(t, err)
+	pb.RegisterRelayServer(srv.Server(), relay)
+	go srv.Start()
+	return srv.Address(), srv.Stop
+}
+
+func TestServeView(t *testing.T) {
+	_, signer := sampleOrg(t)
+	e := &endorser{signer: signer, response: &pb.Response{Status: shim.OK, Payload: []byte("lock")}}
+	relay, err := NewRelay(&Config{NetworkID: "network2", ExposedChaincodes: []string{"channel1/ftscc"}}, e, signer, comm.ClientConfig{})
+	require.NoError(t, err)
+
+	req := &pb.ViewRequest{Network: "network2", Channel: "channel1", Chaincode: "ftscc", Args: [][]byte{[]byte("GetLock"), []byte("tx1")}}
+	view, err := relay.RequestView(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(req, view.Request))
+	require.Len(t, view.Endorsements, 1)
+	prop, err := utils.GetProposal(view.Endorsements[0].Proposal)
+	require.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	require.NoError(t, err)
+	assert.Equal(t, req.Args, cis.ChaincodeSpec.Input.Args)
+
+	_, err = relay.RequestView(context.Background(), &pb.ViewRequest{Network: "network2", Channel: "channel1", Chaincode: "nftscc"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Contains(t, err.Error(), "chaincode nftscc of channel channel1 is not exposed to the other networks")
+
+	e.response = &pb.Response{Status: shim.ERROR, Message: "lock tx1 does not exist"}
+	_, err = relay.RequestView(context.Background(), req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "the query of the view failed: lock tx1 does not exist")
+
+	e.err = errors.New("endorser unavailable")
+	_, err = relay.RequestView(context.Background(), req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	_, err = NewRelay(&Config{ExposedChaincodes: []string{"ftscc"}}, e, signer, comm.ClientConfig{})
+	assert.EqualError(t, err, `invalid exposed chaincode "ftscc", it must be <channel>/<chaincode>`)
+}
+
+func TestForwardView(t *testing.T) {
+	network, signer := sampleOrg(t)
+	e := &endorser{signer: signer, response: &pb.Response{Status: shim.OK, Payload: []byte("lock")}}
+	exposing1, stop := startRelay(t, e, signer, "channel1/ftscc")
+	defer stop()
+	exposing2, stop := startRelay(t, e, signer, "channel1/ftscc")
+	defer stop()
+	notExposing, stop := startRelay(t, e, signer)
+	defer stop()
+
+	config := &Config{
+		NetworkID: "network1",
+		Networks: []NetworkConfig{
+			{ID: "network2", Relays: []string{exposing1, notExposing, exposing2}},
+			{ID: "network3", Relays: []string{notExposing}},
+		},
+		Timeout: 10 * time.Second,
+	}
+	relay, err := NewRelay(config, e, signer, comm.ClientConfig{Timeout: time.Second})
+	require.NoError(t, err)
+
+	// the endorsements of the relays serving the view are merged
+	req := &pb.ViewRequest{Network: "network2", Channel: "channel1", Chaincode: "ftscc", Args: [][]byte{[]byte("GetLock"), []byte("tx1")}}
+	view, err := relay.RequestView(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, view.Endorsements, 2)
+	payload, err := interopscc.Verify(view, network)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("lock"), payload)
+
+	_, err = relay.RequestView(context.Background(), &pb.ViewRequest{Network: "network2", Channel: "channel2", Chaincode: "ftscc"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Contains(t, err.Error(), "no relay of network network2 served the view")
+
+	_, err = relay.RequestView(context.Background(), &pb.ViewRequest{Network: "network4"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// the forwarded requests are not forwarded again
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(forwardedKey, "network0"))
+	_, err = relay.RequestView(ctx, &pb.ViewRequest{Network: "network3"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -41,6 +41,12 @@ func NewMgr(ccInfoProvider ledger.DeployedChaincodeInfoProvider) Mgr {
 	return newMgr(ccInfoProvider, dbPath())
 }
 
+// NewMgrWithPath constructs an instance that implements interface `Mgr` and keeps the
+// history in the db at the given path
+func NewMgrWithPath(ccInfoProvider ledger.DeployedChaincodeInfoProvider, dbPath string) Mgr {
+	return newMgr(ccInfoProvider, dbPath)
+}
+
 func newMgr(ccInfoProvider ledger.DeployedChaincodeInfoProvider, dbPath string) Mgr {
 	return &mgr{ccInfoProvider, newDBProvider(dbPath)}
 }
@@ -0,0 +1,488 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/transientstore"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+const (
+	backupManifestName     = "manifest.json"
+	backupGenesisBlockName = "genesisblock"
+	backupBlocksDir        = "blocks/"
+	backupStoresDir        = "stores/"
+	backupStateDBStore     = "statedb"
+	// restoreBatchSize is the number of keys that are written in one batch upon restore
+	restoreBatchSize = 1000
+)
+
+// BackupManifest describes a backup archive of a ledger. It is the last entry of the archive
+// and holds the size and the SHA-256 hash of every other entry, so that a corrupted or
+// truncated archive is detected before anything is restored
+type BackupManifest struct {
+	LedgerID string `json:"ledgerID"`
+	// Height is the number of blocks in the backup
+	Height uint64 `json:"height"`
+	// StateDB is set if the state database is in the backup. The state database is not
+	// backed up when it is CouchDB, and is then rebuilt from the blocks upon restore
+	StateDB bool          `json:"stateDB"`
+	Created time.Time     `json:"created"`
+	Entries []BackupEntry `json:"entries"`
+}
+
+// BackupEntry is an entry of a backup archive
+type BackupEntry struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// backupStore is a LevelDB database shared across the ledgers, in which the data of a
+// ledger is held under a dedicated db name
+type backupStore struct {
+	name   string
+	path   string
+	dbName string
+}
+
+// backupStores returns the LevelDB databases at the given paths holding the data of the given ledger
+func backupStores(paths *ledgerconfig.StorePaths, ledgerID string, includeStateDB bool) []*backupStore {
+	stores := []*backupStore{
+		{"index", filepath.Join(paths.BlockStore, fsblkstorage.IndexDir), ledgerID},
+		{"pvtdata", paths.PvtdataStore, ledgerID},
+		{"transient", transientstore.GetTransientStorePath(), ledgerID},
+		{"history", paths.HistoryLevelDB, ledgerID},
+		{"confighistory", paths.ConfigHistory, ledgerID},
+		{"bookkeeping-pvtdataexpiry", paths.InternalBookkeeper, bookkeeping.DBName(ledgerID, bookkeeping.PvtdataExpiry)},
+		{"bookkeeping-metadatapresence", paths.InternalBookkeeper, bookkeeping.DBName(ledgerID, bookkeeping.MetadataPresenceIndicator)},
+		{"bookkeeping-stateproofs", paths.InternalBookkeeper, bookkeeping.DBName(ledgerID, bookkeeping.StateProofs)},
+	}
+	if includeStateDB {
+		stores = append(stores, &backupStore{backupStateDBStore, paths.StateLevelDB, ledgerID})
+	}
+	return stores
+}
+
+// Backup writes a gzipped tar archive of the block files, the block index, the state, private
+// data, transient, history and internal databases of the given ledger to the given writer.
+// This function is expected to be invoked only when the peer is not running, so that no block
+// is committed while the stores are read and the backup is consistent
+func Backup(ledgerID string, w io.Writer) (*BackupManifest, error) {
+	paths, err := ledgerconfig.GetLedgerStorePaths(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	idStore := openIDStore(paths.LedgerProvider)
+	genesisBlock, err := idStore.db.Get(idStore.encodeLedgerKey(ledgerID))
+	idStore.close()
+	if err != nil {
+		return nil, err
+	}
+	if genesisBlock == nil {
+		return nil, ErrNonExistingLedgerID
+	}
+
+	blockStorePath := paths.BlockStore
+	height, err := fsblkstorage.GetHeight(blockStorePath, ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BackupManifest{
+		LedgerID: ledgerID,
+		Height:   height,
+		StateDB:  !ledgerconfig.IsCouchDBEnabled(),
+		Created:  time.Now().UTC(),
+	}
+	logger.Infof("Backing up ledger [%s] at height [%d]", ledgerID, height)
+
+	bw := newBackupWriter(w)
+	if err := bw.writeEntry(backupGenesisBlockName, int64(len(genesisBlock)), strings.NewReader(string(genesisBlock))); err != nil {
+		return nil, err
+	}
+	if err := bw.writeBlockFiles(filepath.Join(blockStorePath, fsblkstorage.ChainsDir, ledgerID)); err != nil {
+		return nil, err
+	}
+	for _, store := range backupStores(paths, ledgerID, manifest.StateDB) {
+		if err := bw.writeStore(store); err != nil {
+			return nil, err
+		}
+	}
+	if err := bw.close(manifest); err != nil {
+		return nil, err
+	}
+	logger.Infof("Ledger [%s] has been backed up", ledgerID)
+	return manifest, nil
+}
+
+// Restore restores a ledger from a backup archive created by Backup. The integrity of the
+// whole archive is verified first, and the ledger must not exist. If the restore is interrupted,
+// the partially restored ledger is removed by the next start of the peer and the restore can be
+// retried. This function is expected to be invoked only when the peer is not running
+func Restore(archivePath string) (*BackupManifest, error) {
+	manifest, err := VerifyBackup(archivePath)
+	if err != nil {
+		return nil, err
+	}
+	ledgerID := manifest.LedgerID
+	paths, err := ledgerconfig.GetLedgerStorePaths(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+
+	idStore := openIDStore(paths.LedgerProvider)
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrLedgerIDExists
+	}
+	pendingRemoval, err := idStore.isPendingRemoval(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if pendingRemoval {
+		return nil, ErrLedgerPendingRemoval
+	}
+
+	logger.Infof("Restoring ledger [%s] at height [%d]", ledgerID, manifest.Height)
+	if err := idStore.db.Put(encodePendingRemovalKey(ledgerID), []byte{}, true); err != nil {
+		return nil, err
+	}
+	// start from a clean slate, in case of leftovers of the ledger
+	if err := removeLedgerData(paths, ledgerID); err != nil {
+		return nil, err
+	}
+	if err := clearLevelDB(transientstore.GetTransientStorePath(), ledgerID); err != nil {
+		return nil, err
+	}
+
+	restoreStateDB := manifest.StateDB && !ledgerconfig.IsCouchDBEnabled()
+	if !restoreStateDB {
+		logger.Infof("The state database of ledger [%s] will be rebuilt from the blocks upon the next start of the peer", ledgerID)
+	}
+	stores := map[string]*backupStore{}
+	for _, store := range backupStores(paths, ledgerID, restoreStateDB) {
+		stores[store.name] = store
+	}
+	blockFilesDir := filepath.Join(paths.BlockStore, fsblkstorage.ChainsDir, ledgerID)
+	if err := os.MkdirAll(blockFilesDir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "failed creating the block files directory of ledger [%s]", ledgerID)
+	}
+
+	var genesisBlock []byte
+	err = readBackup(archivePath, func(name string, r io.Reader) error {
+		switch {
+		case name == backupGenesisBlockName:
+			genesisBlock, err = ioutil.ReadAll(r)
+			return errors.Wrap(err, "failed reading the genesis block")
+		case strings.HasPrefix(name, backupBlocksDir):
+			return restoreBlockFile(filepath.Join(blockFilesDir, strings.TrimPrefix(name, backupBlocksDir)), r)
+		case strings.HasPrefix(name, backupStoresDir):
+			if store, ok := stores[strings.TrimPrefix(name, backupStoresDir)]; ok {
+				return restoreStore(store, r)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &leveldb.Batch{}
+	batch.Put(idStore.encodeLedgerKey(ledgerID), genesisBlock)
+	batch.Delete(encodePendingRemovalKey(ledgerID))
+	if err := idStore.db.WriteBatch(batch, true); err != nil {
+		return nil, err
+	}
+	logger.Infof("Ledger [%s] has been restored", ledgerID)
+	return manifest, nil
+}
+
+// VerifyBackup checks that the entries of a backup archive match its manifest, and returns it
+func VerifyBackup(archivePath string) (*BackupManifest, error) {
+	var manifest *BackupManifest
+	var genesisBlock []byte
+	found := map[string]BackupEntry{}
+	err := readBackup(archivePath, func(name string, r io.Reader) error {
+		if name == backupManifestName {
+			manifest = &BackupManifest{}
+			return errors.Wrap(json.NewDecoder(r).Decode(manifest), "invalid backup manifest")
+		}
+		if name == backupGenesisBlockName {
+			var err error
+			if genesisBlock, err = ioutil.ReadAll(r); err != nil {
+				return errors.Wrap(err, "failed reading the genesis block")
+			}
+			r = strings.NewReader(string(genesisBlock))
+		}
+		h := sha256.New()
+		size, err := io.Copy(h, r)
+		if err != nil {
+			return errors.Wrapf(err, "failed reading entry %s", name)
+		}
+		found[name] = BackupEntry{Name: name, Size: size, SHA256: hex.EncodeToString(h.Sum(nil))}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, errors.New("the backup has no manifest")
+	}
+
+	for _, entry := range manifest.Entries {
+		actual, ok := found[entry.Name]
+		if !ok {
+			return nil, errors.Errorf("entry %s of the manifest is missing from the backup", entry.Name)
+		}
+		if actual != entry {
+			return nil, errors.Errorf("entry %s does not match the manifest: expected %d bytes with hash %s, got %d bytes with hash %s",
+				entry.Name, entry.Size, entry.SHA256, actual.Size, actual.SHA256)
+		}
+		delete(found, entry.Name)
+	}
+	for name := range found {
+		return nil, errors.Errorf("entry %s of the backup is not in the manifest", name)
+	}
+
+	if genesisBlock == nil {
+		return nil, errors.New("the backup has no genesis block")
+	}
+	ledgerID, err := utils.GetChainIDFromBlockBytes(genesisBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid genesis block")
+	}
+	if ledgerID != manifest.LedgerID {
+		return nil, errors.Errorf("the genesis block is the one of ledger [%s] instead of [%s]", ledgerID, manifest.LedgerID)
+	}
+	return manifest, nil
+}
+
+// backupWriter writes the entries of a backup archive, recording them in the manifest
+type backupWriter struct {
+	gw      *gzip.Writer
+	tw      *tar.Writer
+	entries []BackupEntry
+}
+
+func newBackupWriter(w io.Writer) *backupWriter {
+	gw := gzip.NewWriter(w)
+	return &backupWriter{gw: gw, tw: tar.NewWriter(gw)}
+}
+
+func (bw *backupWriter) writeEntry(name string, size int64, r io.Reader) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: size, ModTime: time.Now()}
+	if err := bw.tw.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "failed writing entry %s", name)
+	}
+	h := sha256.New()
+	if _, err := io.CopyN(io.MultiWriter(bw.tw, h), r, size); err != nil {
+		return errors.Wrapf(err, "failed writing entry %s", name)
+	}
+	bw.entries = append(bw.entries, BackupEntry{Name: name, Size: size, SHA256: hex.EncodeToString(h.Sum(nil))})
+	return nil
+}
+
+func (bw *backupWriter) writeBlockFiles(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Wrap(err, "failed listing the block files")
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name() < files[j].Name() })
+	for _, info := range files {
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		if err := bw.writeFile(backupBlocksDir+info.Name(), filepath.Join(dir, info.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bw *backupWriter) writeFile(name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed opening %s", path)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "failed opening %s", path)
+	}
+	return bw.writeEntry(name, info.Size(), f)
+}
+
+// writeStore writes the keys and values of the ledger in the given store, as a sequence of
+// length-prefixed keys and values. The size of a tar entry has to be known upfront, hence
+// the keys are first written to a temporary file
+func (bw *backupWriter) writeStore(store *backupStore) error {
+	tempFile, err := ioutil.TempFile("", "backup-"+store.name)
+	if err != nil {
+		return errors.Wrap(err, "failed creating a temporary file")
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: store.path})
+	err = exportStore(p.GetDBHandle(store.dbName), tempFile)
+	p.Close()
+	if err != nil {
+		return errors.WithMessage(err, "failed backing up store "+store.name)
+	}
+	return bw.writeFile(backupStoresDir+store.name, tempFile.Name())
+}
+
+func (bw *backupWriter) close(manifest *BackupManifest) error {
+	manifest.Entries = bw.entries
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the backup manifest")
+	}
+	header := &tar.Header{Name: backupManifestName, Mode: 0644, Size: int64(len(manifestBytes)), ModTime: time.Now()}
+	if err := bw.tw.WriteHeader(header); err != nil {
+		return errors.Wrap(err, "failed writing the backup manifest")
+	}
+	if _, err := bw.tw.Write(manifestBytes); err != nil {
+		return errors.Wrap(err, "failed writing the backup manifest")
+	}
+	if err := bw.tw.Close(); err != nil {
+		return errors.Wrap(err, "failed writing the backup")
+	}
+	return errors.Wrap(bw.gw.Close(), "failed writing the backup")
+}
+
+// readBackup calls the given function with each entry of a backup archive
+func readBackup(archivePath string, f func(name string, r io.Reader) error) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return errors.Wrap(err, "failed opening the backup")
+	}
+	defer file.Close()
+	gr, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return errors.Wrap(err, "invalid backup")
+	}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "invalid backup")
+		}
+		if err := f(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func exportStore(db *leveldbhelper.DBHandle, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	itr := db.GetIterator(nil, nil)
+	defer itr.Release()
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	writeBytes := func(b []byte) error {
+		n := binary.PutUvarint(lenBuf, uint64(len(b)))
+		if _, err := bw.Write(lenBuf[:n]); err != nil {
+			return err
+		}
+		_, err := bw.Write(b)
+		return err
+	}
+	for itr.Next() {
+		if err := writeBytes(itr.Key()); err != nil {
+			return err
+		}
+		if err := writeBytes(itr.Value()); err != nil {
+			return err
+		}
+	}
+	if err := itr.Error(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func restoreStore(store *backupStore, r io.Reader) error {
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: store.path})
+	defer p.Close()
+	db := p.GetDBHandle(store.dbName)
+
+	br := bufio.NewReader(r)
+	readBytes := func() ([]byte, error) {
+		size, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, size)
+		_, err = io.ReadFull(br, b)
+		return b, err
+	}
+	batch := leveldbhelper.NewUpdateBatch()
+	for {
+		key, err := readBytes()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed restoring store "+store.name)
+		}
+		value, err := readBytes()
+		if err != nil {
+			return errors.Wrap(err, "failed restoring store "+store.name)
+		}
+		batch.Put(key, value)
+		if batch.Len() >= restoreBatchSize {
+			if err := db.WriteBatch(batch, false); err != nil {
+				return errors.Wrap(err, "failed restoring store "+store.name)
+			}
+			batch = leveldbhelper.NewUpdateBatch()
+		}
+	}
+	return errors.Wrap(db.WriteBatch(batch, true), "failed restoring store "+store.name)
+}
+
+func restoreBlockFile(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Wrap(err, "failed restoring block file")
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed restoring block file %s", path)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed restoring block file %s", path)
+	}
+	return errors.Wrapf(f.Close(), "failed restoring block file %s", path)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRestore(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+
+	backupDir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+	archivePath := filepath.Join(backupDir, "testLedger.tar.gz")
+
+	ledgerID := "testLedger"
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	for i := 1; i <= 10; i++ {
+		commitTestBlock(t, l, bg, "key", fmt.Sprintf("value_%d", i))
+	}
+	l.Close()
+	provider.Close()
+
+	_, err = Backup("nonExistingLedger", ioutil.Discard)
+	assert.Equal(t, ErrNonExistingLedgerID, err)
+
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	manifest, err := Backup(ledgerID, f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, ledgerID, manifest.LedgerID)
+	assert.Equal(t, uint64(11), manifest.Height)
+	assert.True(t, manifest.StateDB)
+
+	_, err = Restore(archivePath)
+	assert.Equal(t, ErrLedgerIDExists, err)
+
+	// restore the ledger into a new peer
+	env.cleanup()
+	restored, err := Restore(archivePath)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Entries, restored.Entries)
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	l, err = provider.Open(ledgerID)
+	require.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(11), bcInfo.Height)
+
+	qe, err := l.NewQueryExecutor()
+	require.NoError(t, err)
+	val, err := qe.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value_10"), val)
+	qe.Done()
+
+	hqe, err := l.NewHistoryQueryExecutor()
+	require.NoError(t, err)
+	itr, err := hqe.GetHistoryForKey("ns", "key")
+	require.NoError(t, err)
+	count := 0
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		count++
+	}
+	itr.Close()
+	assert.Equal(t, 10, count)
+
+	// the restored ledger keeps on committing blocks
+	commitTestBlock(t, l, bg, "key", "value_11")
+	bcInfo, err = l.GetBlockchainInfo()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), bcInfo.Height)
+}
+
+func TestVerifyBackup(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	backupDir, err := ioutil.TempDir("", "backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+	archivePath := filepath.Join(backupDir, "testLedger.tar.gz")
+
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, "testLedger", false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	commitTestBlock(t, l, bg, "key", "value")
+	l.Close()
+	provider.Close()
+
+	f, err := os.Create(archivePath)
+	require.NoError(t, err)
+	_, err = Backup("testLedger", f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	_, err = VerifyBackup(archivePath)
+	assert.NoError(t, err)
+
+	_, err = VerifyBackup(filepath.Join(backupDir, "missing.tar.gz"))
+	assert.Contains(t, err.Error(), "failed opening the backup")
+
+	// a truncated archive is detected
+	archive, err := ioutil.ReadFile(archivePath)
+	require.NoError(t, err)
+	truncatedPath := filepath.Join(backupDir, "truncated.tar.gz")
+	require.NoError(t, ioutil.WriteFile(truncatedPath, archive[:len(archive)/2], 0644))
+	_, err = VerifyBackup(truncatedPath)
+	assert.Error(t, err)
+
+	env.cleanup()
+	_, err = Restore(truncatedPath)
+	assert.Error(t, err)
+	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
+	defer idStore.close()
+	exists, err := idStore.ledgerIDExists("testLedger")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -21,6 +21,9 @@ const (
 	PvtdataExpiry Category = iota
 	// MetadataPresenceIndicator maintains the bookkeeping about whether metadata is ever set for a namespace
 	MetadataPresenceIndicator
+	// StateProofs maintains the digests of the updates of the state by the blocks, from which the proofs
+	// of the reads of the state are built
+	StateProofs
 )
 
 // Provider provides handle to different bookkeepers for the given ledger
@@ -37,13 +40,23 @@ type provider struct {
 
 // NewProvider instantiates a new provider
 func NewProvider() Provider {
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: getInternalBookkeeperPath()})
+	return NewProviderWithPath(getInternalBookkeeperPath())
+}
+
+// NewProviderWithPath instantiates a new provider of the bookkeeping db at the given path
+func NewProviderWithPath(dbPath string) Provider {
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Compaction: ledgerconfig.GetLevelDBCompactionConf()})
 	return &provider{dbProvider: dbProvider}
 }
 
 // GetDBHandle implements the function in the interface 'BookkeeperProvider'
 func (provider *provider) GetDBHandle(ledgerID string, cat Category) *leveldbhelper.DBHandle {
-	return provider.dbProvider.GetDBHandle(fmt.Sprintf(ledgerID+"/%d", cat))
+	return provider.dbProvider.GetDBHandle(DBName(ledgerID, cat))
+}
+
+// DBName returns the name of the db that is used for maintaining the bookkeeping of a given category
+func DBName(ledgerID string, cat Category) string {
+	return fmt.Sprintf(ledgerID+"/%d", cat)
 }
 
 // Close implements the function in the interface 'BookKeeperProvider'
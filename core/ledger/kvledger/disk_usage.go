@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+)
+
+// diskUsageMonitor periodically measures the disk space used by the stores of a ledger,
+// reports it through the metrics and warns when the ledger exceeds its soft quota
+type diskUsageMonitor struct {
+	ledgerID   string
+	blockStore *ledgerstorage.Store
+	// stateDB is nil if the state database does not report its disk usage
+	stateDB   privacyenabledstate.DiskUsageReporter
+	quota     uint64
+	stats     *ledgerStats
+	overQuota bool
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newDiskUsageMonitor(ledgerID string, blockStore *ledgerstorage.Store, versionedDB privacyenabledstate.DB,
+	quota uint64, stats *ledgerStats) *diskUsageMonitor {
+	m := &diskUsageMonitor{
+		ledgerID:   ledgerID,
+		blockStore: blockStore,
+		quota:      quota,
+		stats:      stats,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	if reporter, ok := versionedDB.(privacyenabledstate.DiskUsageReporter); ok && reporter.ReportsDiskUsage() {
+		m.stateDB = reporter
+	}
+	return m
+}
+
+// start measures the disk usage right away and then at every interval, until close is called
+func (m *diskUsageMonitor) start(interval time.Duration) {
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			m.report()
+			select {
+			case <-ticker.C:
+			case <-m.stop:
+				return
+			}
+		}
+	}()
+}
+
+// close stops the measurements and waits for the ongoing one to complete
+func (m *diskUsageMonitor) close() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+// diskUsage returns the disk space used by each of the stores of the ledger
+func (m *diskUsageMonitor) diskUsage() (map[string]int64, error) {
+	usage, err := m.blockStore.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+	if m.stateDB != nil {
+		if usage["statedb"], err = m.stateDB.DiskUsage(); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// report measures the disk usage and updates the metrics
+func (m *diskUsageMonitor) report() {
+	usage, err := m.diskUsage()
+	if err != nil {
+		logger.Warningf("Failed measuring the disk usage of ledger [%s]: %s", m.ledgerID, err)
+		return
+	}
+
+	var total int64
+	for _, size := range usage {
+		total += size
+	}
+	overQuota := m.quota > 0 && uint64(total) > m.quota
+	switch {
+	case overQuota && !m.overQuota:
+		logger.Warningf("Ledger [%s] uses %d bytes of disk space, exceeding its soft quota of %d bytes", m.ledgerID, total, m.quota)
+	case !overQuota && m.overQuota:
+		logger.Infof("Ledger [%s] uses %d bytes of disk space, back within its soft quota of %d bytes", m.ledgerID, total, m.quota)
+	}
+	m.overQuota = overQuota
+	logger.Debugf("Disk usage of ledger [%s]: %v", m.ledgerID, usage)
+
+	m.stats.updateDiskUsage(usage, overQuota)
+}
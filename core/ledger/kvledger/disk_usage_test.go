@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskUsageMonitor(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	// the ledger does not start its own monitor
+	viper.Set("ledger.diskUsage.interval", 0)
+	fakeProvider := &metricsfakes.Provider{}
+	fakeDiskUsage := testutilConstructGuage()
+	fakeQuotaExceeded := testutilConstructGuage()
+	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
+		switch opts.Name {
+		case diskUsageOpts.Name:
+			return fakeDiskUsage
+		case diskQuotaExceededOpts.Name:
+			return fakeQuotaExceeded
+		}
+		return testutilConstructGuage()
+	}
+	fakeProvider.NewHistogramReturns(testutilConstructHist())
+	fakeProvider.NewCounterReturns(testutilConstructCounter())
+
+	p, err := NewProvider()
+	require.NoError(t, err)
+	provider := p.(*Provider)
+	provider.Initialize(&lgr.Initializer{
+		DeployedChaincodeInfoProvider: &mock.DeployedChaincodeInfoProvider{},
+		MetricsProvider:               fakeProvider,
+	})
+	defer provider.Close()
+
+	_, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+	defer l.Close()
+	ledger := l.(*kvLedger)
+	versionedDB, err := provider.vdbProvider.GetDBHandle("ledger1")
+	require.NoError(t, err)
+
+	m := newDiskUsageMonitor("ledger1", ledger.blockStore, versionedDB, 1, ledger.stats)
+	usage, err := m.diskUsage()
+	require.NoError(t, err)
+	assert.Len(t, usage, 4)
+	assert.True(t, usage["blockstore"] > 0)
+
+	m.report()
+	assert.True(t, m.overQuota)
+	stores := map[string]bool{}
+	for i := 0; i < fakeDiskUsage.WithCallCount(); i++ {
+		labels := fakeDiskUsage.WithArgsForCall(i)
+		assert.Equal(t, []string{"channel", "ledger1"}, labels[:2])
+		stores[labels[3]] = true
+	}
+	assert.Equal(t, map[string]bool{"blockstore": true, "index": true, "statedb": true, "pvtdata": true}, stores)
+	assert.Equal(t, []string{"channel", "ledger1"}, fakeQuotaExceeded.WithArgsForCall(0))
+	assert.Equal(t, float64(1), fakeQuotaExceeded.SetArgsForCall(0))
+
+	m.quota = 1 << 40
+	m.report()
+	assert.False(t, m.overQuota)
+	assert.Equal(t, float64(0), fakeQuotaExceeded.SetArgsForCall(1))
+}
+
+func TestDiskUsageMonitorLifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.diskUsage.interval", "1ms")
+	defer viper.Set("ledger.diskUsage.interval", 0)
+
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+	_, gb := testutil.NewBlockGenerator(t, "ledger1", false)
+	l, err := provider.Create(gb)
+	require.NoError(t, err)
+
+	ledger := l.(*kvLedger)
+	require.NotNil(t, ledger.diskUsageMonitor)
+	ledger.Close()
+	<-ledger.diskUsageMonitor.done
+}
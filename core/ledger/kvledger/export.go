@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"io"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/pkg/errors"
+)
+
+// ExportNamespace writes the state of the given namespace of the given channel to the given writer,
+// along with the hashes of the private data of the collections of the chaincode and, optionally,
+// the private data. This function is expected to be invoked only when the peer is not running
+func ExportNamespace(ledgerID, ns string, includePrivateData bool, w io.Writer) (*privacyenabledstate.NamespaceSummary, error) {
+	var summary *privacyenabledstate.NamespaceSummary
+	err := withStateDB(ledgerID, func(db privacyenabledstate.DB) error {
+		namespaces, err := stateNamespaces(db)
+		if err != nil {
+			return err
+		}
+		collections, exists := namespaces[ns]
+		if !exists {
+			return errors.Errorf("chaincode %s is not defined on channel %s", ns, ledgerID)
+		}
+		summary, err = privacyenabledstate.ExportNamespace(db, ns, privacyenabledstate.NamespaceExportOptions{
+			Collections:        collections,
+			IncludePrivateData: includePrivateData,
+		}, w)
+		return err
+	})
+	return summary, err
+}
+
+// ImportNamespace reads an export of a namespace from the given reader and writes it in the given
+// namespace of the given channel, or in the exported namespace if ns is empty. All the peers of the
+// channel are expected to import the same export at the same block number. This function is expected
+// to be invoked only when the peer is not running
+func ImportNamespace(ledgerID, ns string, r io.Reader) (*privacyenabledstate.NamespaceSummary, error) {
+	var summary *privacyenabledstate.NamespaceSummary
+	err := withStateDB(ledgerID, func(db privacyenabledstate.DB) error {
+		var err error
+		summary, err = privacyenabledstate.ImportNamespace(db, ns, r)
+		return err
+	})
+	return summary, err
+}
@@ -32,9 +32,13 @@ type HistoryDBProvider struct {
 
 // NewHistoryDBProvider instantiates HistoryDBProvider
 func NewHistoryDBProvider() *HistoryDBProvider {
-	dbPath := ledgerconfig.GetHistoryLevelDBPath()
+	return NewHistoryDBProviderWithPath(ledgerconfig.GetHistoryLevelDBPath())
+}
+
+// NewHistoryDBProviderWithPath instantiates HistoryDBProvider for the history db at the given path
+func NewHistoryDBProviderWithPath(dbPath string) *HistoryDBProvider {
 	logger.Debugf("constructing HistoryDBProvider dbPath=%s", dbPath)
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Compaction: ledgerconfig.GetLevelDBCompactionConf()})
 	return &HistoryDBProvider{dbProvider}
 }
 
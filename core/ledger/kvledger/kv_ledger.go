@@ -8,10 +8,14 @@ package kvledger
 
 import (
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	commonledgerutil "github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -24,7 +28,9 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 )
@@ -41,6 +47,11 @@ type kvLedger struct {
 	configHistoryRetriever ledger.ConfigHistoryRetriever
 	blockAPIsRWLock        *sync.RWMutex
 	stats                  *ledgerStats
+	commitHashEnabled      int32
+	commitHash             []byte
+	versionedDB            privacyenabledstate.DB
+	stateProofs            *leveldbhelper.DBHandle
+	diskUsageMonitor       *diskUsageMonitor
 }
 
 // NewKVLedger constructs new `KVLedger`
@@ -59,6 +70,8 @@ func newKVLedger(
 	// Create a kvLedger for this chain/ledger, which encasulates the underlying
 	// id store, blockstore, txmgr (state database), history database
 	l := &kvLedger{ledgerID: ledgerID, blockStore: blockStore, historyDB: historyDB, blockAPIsRWLock: &sync.RWMutex{}}
+	l.versionedDB = versionedDB
+	l.stateProofs = bookkeeperProvider.GetDBHandle(ledgerID, bookkeeping.StateProofs)
 
 	// TODO Move the function `GetChaincodeEventListener` to ledger interface and
 	// this functionality of regiserting for events to ledgermgmt package so that this
@@ -83,9 +96,16 @@ func newKVLedger(
 	if err != nil {
 		return nil, err
 	}
+	if err := l.initCommitHash(info.Height); err != nil {
+		return nil, err
+	}
 	// initialize stat with the current height
 	stats.updateBlockchainHeight(info.Height)
 	l.stats = stats
+	if interval := ledgerconfig.GetDiskUsageInterval(); interval > 0 {
+		l.diskUsageMonitor = newDiskUsageMonitor(ledgerID, blockStore, versionedDB, ledgerconfig.GetDiskSoftQuota(ledgerID), stats)
+		l.diskUsageMonitor.start(interval)
+	}
 	return l, nil
 }
 
@@ -96,6 +116,20 @@ func (l *kvLedger) initTxMgr(versionedDB privacyenabledstate.DB, stateListeners
 	return err
 }
 
+// initCommitHash retrieves the commit hash of the last block, which is chained
+// to the commit hash of the next block
+func (l *kvLedger) initCommitHash(height uint64) error {
+	if height == 0 {
+		return nil
+	}
+	block, err := l.blockStore.RetrieveBlockByNumber(height - 1)
+	if err != nil {
+		return err
+	}
+	l.commitHash, err = lutil.GetCommitHash(block)
+	return err
+}
+
 func (l *kvLedger) initBlockStore(btlPolicy pvtdatapolicy.BTLPolicy) {
 	l.blockStore.Init(btlPolicy)
 }
@@ -179,12 +213,16 @@ func (l *kvLedger) syncStateDBWithPvtdatastore() error {
 	return nil
 }
 
+// recommitProgressInterval is the minimum interval between two progress reports while recommitting blocks
+var recommitProgressInterval = 5 * time.Second
+
 //recommitLostBlocks retrieves blocks in specified range and commit the write set to either
 //state DB or history DB or both
 func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64, recoverables ...recoverable) error {
 	logger.Infof("Recommitting lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	var err error
 	var blockAndPvtdata *ledger.BlockAndPvtData
+	lastProgressReport := time.Now()
 	for blockNumber := firstBlockNum; blockNumber <= lastBlockNum; blockNumber++ {
 		if blockAndPvtdata, err = l.GetPvtDataAndBlockByNum(blockNumber, nil); err != nil {
 			return err
@@ -194,6 +232,10 @@ func (l *kvLedger) recommitLostBlocks(firstBlockNum uint64, lastBlockNum uint64,
 				return err
 			}
 		}
+		if time.Since(lastProgressReport) >= recommitProgressInterval {
+			logger.Infof("Recommitted block [%d] of [%d] for channel [%s]", blockNumber, lastBlockNum, l.ledgerID)
+			lastProgressReport = time.Now()
+		}
 	}
 	logger.Infof("Recommitted lost blocks - firstBlockNum=%d, lastBlockNum=%d, recoverables=%#v", firstBlockNum, lastBlockNum, recoverables)
 	return nil
@@ -299,10 +341,19 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 
 	startBlockProcessing := time.Now()
 	logger.Debugf("[%s] Validating state for block [%d]", l.ledgerID, blockNo)
-	txstatsInfo, err := l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
+	txstatsInfo, updates, err := l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
 	if err != nil {
 		return err
 	}
+	commitHash, err := l.addBlockCommitHash(block, lutil.UpdatesHash(updates))
+	if err != nil {
+		return err
+	}
+	if commitHash != nil && ledgerconfig.IsStateProofsEnabled() {
+		if err := l.recordStateUpdates(blockNo, updates); err != nil {
+			return err
+		}
+	}
 	elapsedBlockProcessing := time.Since(startBlockProcessing)
 
 	startCommitBlockStorage := time.Now()
@@ -312,6 +363,7 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
 		return err
 	}
+	l.commitHash = commitHash
 	elapsedCommitBlockStorage := time.Since(startCommitBlockStorage)
 
 	startCommitState := time.Now()
@@ -348,6 +400,97 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	return nil
 }
 
+// EnableCommitHash implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) EnableCommitHash(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&l.commitHashEnabled, value)
+}
+
+// addBlockCommitHash records in the metadata of the block, if enabled, the hash of its validation flags, of
+// the given hash of the updates of the state by its valid transactions and of the commit hash of the previous
+// block. The hashes are chained from the first block committed after the commit hashes have been enabled
+func (l *kvLedger) addBlockCommitHash(block *common.Block, updatesHash []byte) ([]byte, error) {
+	if atomic.LoadInt32(&l.commitHashEnabled) == 0 {
+		return nil, nil
+	}
+	txsFilter := block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+	commitHash := lutil.CommitHash(txsFilter, updatesHash, l.commitHash)
+	if err := lutil.SetCommitHash(block, commitHash); err != nil {
+		return nil, err
+	}
+	logger.Debugf("[%s] Recorded commit hash [%x] in block [%d]", l.ledgerID, commitHash, block.Header.Number)
+	return commitHash, nil
+}
+
+// recordStateUpdates records the digest of the updates of the state by the given block, from which the
+// proofs of the keys it updated are built. The digest is recorded before the block is committed, so that
+// it is recorded again if the peer fails before the block is committed
+func (l *kvLedger) recordStateUpdates(blockNum uint64, updates *queryresult.StateUpdates) error {
+	updatesBytes, err := proto.Marshal(updates)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling the digest of the updates of block %d", blockNum)
+	}
+	return l.stateProofs.Put(commonledgerutil.EncodeOrderPreservingVarUint64(blockNum), updatesBytes, true)
+}
+
+// GetStateProof implements the corresponding method from interface ledger.PeerLedger
+func (l *kvLedger) GetStateProof(namespace, key string) (*queryresult.StateProof, error) {
+	vv, err := l.versionedDB.GetState(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	if vv == nil {
+		return nil, errors.Errorf("key [%s] of namespace [%s] does not exist, the absence of a key cannot be proven", key, namespace)
+	}
+	blockNum := vv.Version.BlockNum
+	updatesBytes, err := l.stateProofs.Get(commonledgerutil.EncodeOrderPreservingVarUint64(blockNum))
+	if err != nil {
+		return nil, err
+	}
+	if updatesBytes == nil {
+		return nil, errors.Errorf("key [%s] of namespace [%s] was last updated by block %d, for which no digest of the updates was recorded",
+			key, namespace, blockNum)
+	}
+	updates := &queryresult.StateUpdates{}
+	if err := proto.Unmarshal(updatesBytes, updates); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the digest of the updates of block %d", blockNum)
+	}
+
+	block, err := l.GetBlockByNumber(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	commitHash, err := lutil.GetCommitHash(block)
+	if err != nil {
+		return nil, err
+	}
+	var previousCommitHash []byte
+	if blockNum > 0 {
+		previousBlock, err := l.GetBlockByNumber(blockNum - 1)
+		if err != nil {
+			return nil, err
+		}
+		if previousCommitHash, err = lutil.GetCommitHash(previousBlock); err != nil {
+			return nil, err
+		}
+	}
+	return &queryresult.StateProof{
+		Namespace:          namespace,
+		Key:                key,
+		Value:              vv.Value,
+		Metadata:           vv.Metadata,
+		BlockNum:           blockNum,
+		TxNum:              vv.Version.TxNum,
+		Updates:            updates,
+		TransactionsFilter: block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER],
+		PreviousCommitHash: previousCommitHash,
+		CommitHash:         commitHash,
+	}, nil
+}
+
 func (l *kvLedger) updateBlockStats(
 	blockNum uint64,
 	blockProcessingTime time.Duration,
@@ -436,6 +579,9 @@ func (l *kvLedger) GetMissingPvtDataTracker() (ledger.MissingPvtDataTracker, err
 
 // Close closes `KVLedger`
 func (l *kvLedger) Close() {
+	if l.diskUsageMonitor != nil {
+		l.diskUsageMonitor.close()
+	}
 	l.blockStore.Shutdown()
 	l.txtmgmt.Shutdown()
 }
@@ -7,7 +7,6 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
-	"bytes"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -50,27 +49,43 @@ type Provider struct {
 	initializer         *ledger.Initializer
 	collElgNotifier     *collElgNotifier
 	stats               *stats
+	paths               *ledgerconfig.StorePaths
 }
 
 // NewProvider instantiates a new Provider.
 // This is not thread-safe and assumed to be synchronized be the caller
 func NewProvider() (ledger.PeerLedgerProvider, error) {
-	logger.Info("Initializing ledger provider")
+	return NewProviderWithRootPath(ledgerconfig.GetRootPath())
+}
+
+// NewProviderWithRootPath instantiates a new Provider of the ledgers stored under the given
+// root path, such as the ledgers of a tenant.
+// This is not thread-safe and assumed to be synchronized be the caller
+func NewProviderWithRootPath(rootPath string) (*Provider, error) {
+	logger.Infof("Initializing ledger provider at [%s]", rootPath)
+	paths := ledgerconfig.GetStorePaths(rootPath)
 	// Initialize the ID store (inventory of chainIds/ledgerIds)
-	idStore := openIDStore(ledgerconfig.GetLedgerProviderPath())
-	ledgerStoreProvider := ledgerstorage.NewProvider()
+	idStore := openIDStore(paths.LedgerProvider)
+	// Complete the removal of the ledgers removed during the previous run
+	if err := removePendingLedgers(idStore, paths); err != nil {
+		idStore.close()
+		return nil, err
+	}
 	// Initialize the history database (index for history of values by key)
-	historydbProvider := historyleveldb.NewHistoryDBProvider()
+	historydbProvider := historyleveldb.NewHistoryDBProviderWithPath(paths.HistoryLevelDB)
 	logger.Info("ledger provider Initialized")
-	provider := &Provider{idStore, ledgerStoreProvider,
-		nil, historydbProvider, nil, nil, nil, nil, nil, nil}
+	provider := &Provider{
+		idStore:           idStore,
+		historydbProvider: historydbProvider,
+		paths:             paths,
+	}
 	return provider, nil
 }
 
 // Initialize implements the corresponding method from interface ledger.PeerLedgerProvider
 func (provider *Provider) Initialize(initializer *ledger.Initializer) error {
 	var err error
-	configHistoryMgr := confighistory.NewMgr(initializer.DeployedChaincodeInfoProvider)
+	configHistoryMgr := confighistory.NewMgrWithPath(initializer.DeployedChaincodeInfoProvider, provider.paths.ConfigHistory)
 	collElgNotifier := &collElgNotifier{
 		initializer.DeployedChaincodeInfoProvider,
 		initializer.MembershipInfoProvider,
@@ -84,8 +99,9 @@ func (provider *Provider) Initialize(initializer *ledger.Initializer) error {
 	provider.configHistoryMgr = configHistoryMgr
 	provider.stateListeners = stateListeners
 	provider.collElgNotifier = collElgNotifier
-	provider.bookkeepingProvider = bookkeeping.NewProvider()
-	provider.vdbProvider, err = privacyenabledstate.NewCommonStorageDBProvider(provider.bookkeepingProvider, initializer.MetricsProvider, initializer.HealthCheckRegistry)
+	provider.ledgerStoreProvider = ledgerstorage.NewProviderWithPaths(provider.paths.BlockStore, provider.paths.PvtdataStore, initializer.MetricsProvider)
+	provider.bookkeepingProvider = bookkeeping.NewProviderWithPath(provider.paths.InternalBookkeeper)
+	provider.vdbProvider, err = privacyenabledstate.NewCommonStorageDBProviderWithPath(provider.paths.StateLevelDB, provider.bookkeepingProvider, initializer.MetricsProvider, initializer.HealthCheckRegistry)
 	if err != nil {
 		return err
 	}
@@ -111,6 +127,13 @@ func (provider *Provider) Create(genesisBlock *common.Block) (ledger.PeerLedger,
 	if exists {
 		return nil, ErrLedgerIDExists
 	}
+	pendingRemoval, err := provider.idStore.isPendingRemoval(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if pendingRemoval {
+		return nil, ErrLedgerPendingRemoval
+	}
 	if err = provider.idStore.setUnderConstructionFlag(ledgerID); err != nil {
 		return nil, err
 	}
@@ -315,13 +338,10 @@ func (s *idStore) ledgerIDExists(ledgerID string) (bool, error) {
 
 func (s *idStore) getAllLedgerIds() ([]string, error) {
 	var ids []string
-	itr := s.db.GetIterator(nil, nil)
+	itr := s.db.GetIterator(ledgerKeyPrefix, []byte{ledgerKeyPrefix[0] + 1})
 	defer itr.Release()
 	itr.First()
 	for itr.Valid() {
-		if bytes.Equal(itr.Key(), underConstructionLedgerKey) {
-			continue
-		}
 		id := string(s.decodeLedgerID(itr.Key()))
 		ids = append(ids, id)
 		itr.Next()
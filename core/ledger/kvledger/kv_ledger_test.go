@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -229,7 +230,7 @@ func testSyncStateAndHistoryDBWithBlockstore(t *testing.T) {
 		map[string]string{"key1": "value1.2", "key2": "value2.2", "key3": "value3.2"},
 		map[string]string{"key1": "pvtValue1.2", "key2": "pvtValue2.2", "key3": "pvtValue3.2"})
 
-	_, err := ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata2, true)
+	_, _, err := ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata2, true)
 	assert.NoError(t, err)
 	assert.NoError(t, ledger.(*kvLedger).blockStore.CommitWithPvtData(blockAndPvtdata2))
 
@@ -279,7 +280,7 @@ func testSyncStateAndHistoryDBWithBlockstore(t *testing.T) {
 		map[string]string{"key1": "value1.3", "key2": "value2.3", "key3": "value3.3"},
 		map[string]string{"key1": "pvtValue1.3", "key2": "pvtValue2.3", "key3": "pvtValue3.3"},
 	)
-	_, err = ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata3, true)
+	_, _, err = ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata3, true)
 	assert.NoError(t, err)
 	assert.NoError(t, ledger.(*kvLedger).blockStore.CommitWithPvtData(blockAndPvtdata3))
 	// committing the transaction to state DB
@@ -332,7 +333,7 @@ func testSyncStateAndHistoryDBWithBlockstore(t *testing.T) {
 		map[string]string{"key1": "value1.4", "key2": "value2.4", "key3": "value3.4"},
 		map[string]string{"key1": "pvtValue1.4", "key2": "pvtValue2.4", "key3": "pvtValue3.4"},
 	)
-	_, err = ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata4, true)
+	_, _, err = ledger.(*kvLedger).txtmgmt.ValidateAndPrepare(blockAndPvtdata4, true)
 	assert.NoError(t, err)
 	assert.NoError(t, ledger.(*kvLedger).blockStore.CommitWithPvtData(blockAndPvtdata4))
 	assert.NoError(t, ledger.(*kvLedger).historyDB.Commit(blockAndPvtdata4.Block))
@@ -624,6 +625,125 @@ func checkStateDBForTest(t *testing.T, l lgr.PeerLedger, expectedKVs map[string]
 	}
 }
 
+func TestKVLedgerCommitHash(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	testLedgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, testLedgerid, false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+
+	commitBlock := func(value string) *common.Block {
+		simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+		simulator.SetState("ns1", "key1", []byte(value))
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+		committedBlock, err := ledger.GetBlockByNumber(block.Header.Number)
+		assert.NoError(t, err)
+		return committedBlock
+	}
+	commitHash := func(block *common.Block) []byte {
+		hash, err := lutil.GetCommitHash(block)
+		assert.NoError(t, err)
+		return hash
+	}
+
+	// no commit hash is recorded until enabled
+	assert.Nil(t, commitHash(commitBlock("value1")))
+
+	ledger.EnableCommitHash(true)
+	hash2 := commitHash(commitBlock("value2"))
+	assert.NotNil(t, hash2)
+	hash3 := commitHash(commitBlock("value3"))
+	assert.NotNil(t, hash3)
+	assert.NotEqual(t, hash2, hash3)
+
+	// the commit hashes are chained across the restart of the peer
+	ledger.Close()
+	provider.Close()
+	provider = testutilNewProvider(t)
+	ledger, _ = provider.Open(testLedgerid)
+	assert.Equal(t, hash3, ledger.(*kvLedger).commitHash)
+
+	// the chain is reset when the commit hashes are disabled
+	assert.Nil(t, commitHash(commitBlock("value4")))
+	assert.Nil(t, ledger.(*kvLedger).commitHash)
+	ledger.EnableCommitHash(true)
+	hash5 := commitHash(commitBlock("value5"))
+	assert.NotNil(t, hash5)
+}
+
+func TestKVLedgerStateProof(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.state.proofs.enabled", true)
+	defer viper.Set("ledger.state.proofs.enabled", false)
+	provider := testutilNewProvider(t)
+	defer provider.Close()
+
+	testLedgerid := "testLedger"
+	bg, gb := testutil.NewBlockGenerator(t, testLedgerid, false)
+	ledger, _ := provider.Create(gb)
+	defer ledger.Close()
+
+	commitBlock := func(kvs ...string) *common.Block {
+		simulator, _ := ledger.NewTxSimulator(util.GenerateUUID())
+		for i := 0; i < len(kvs); i += 2 {
+			simulator.SetState("ns1", kvs[i], []byte(kvs[i+1]))
+		}
+		simulator.Done()
+		simRes, _ := simulator.GetTxSimulationResults()
+		pubSimBytes, _ := simRes.GetPubSimulationBytes()
+		block := bg.NextBlock([][]byte{pubSimBytes})
+		assert.NoError(t, ledger.CommitWithPvtData(&lgr.BlockAndPvtData{Block: block}))
+		committedBlock, err := ledger.GetBlockByNumber(block.Header.Number)
+		assert.NoError(t, err)
+		return committedBlock
+	}
+
+	// no digest of the updates is recorded without the commit hashes
+	commitBlock("key1", "value1", "key2", "value1")
+	ledger.EnableCommitHash(true)
+	block2 := commitBlock("key1", "value2", "key3", "value2")
+	hash2, err := lutil.GetCommitHash(block2)
+	assert.NoError(t, err)
+
+	proof, err := ledger.GetStateProof("ns1", "key1")
+	assert.NoError(t, err)
+	assert.NoError(t, lutil.VerifyStateProof(proof))
+	assert.Equal(t, []byte("value2"), proof.Value)
+	assert.Equal(t, uint64(2), proof.BlockNum)
+	assert.Equal(t, hash2, proof.CommitHash)
+	assert.Nil(t, proof.PreviousCommitHash)
+
+	block3 := commitBlock("key3", "value3")
+	proof, err = ledger.GetStateProof("ns1", "key3")
+	assert.NoError(t, err)
+	assert.NoError(t, lutil.VerifyStateProof(proof))
+	assert.Equal(t, []byte("value3"), proof.Value)
+	assert.Equal(t, hash2, proof.PreviousCommitHash)
+	hash3, err := lutil.GetCommitHash(block3)
+	assert.NoError(t, err)
+	assert.Equal(t, hash3, proof.CommitHash)
+
+	_, err = ledger.GetStateProof("ns1", "key2")
+	assert.EqualError(t, err, "key [key2] of namespace [ns1] was last updated by block 1, for which no digest of the updates was recorded")
+	_, err = ledger.GetStateProof("ns1", "key4")
+	assert.EqualError(t, err, "key [key4] of namespace [ns1] does not exist, the absence of a key cannot be proven")
+
+	// the digests of the updates are not recorded once the proofs are disabled
+	viper.Set("ledger.state.proofs.enabled", false)
+	commitBlock("key3", "value4")
+	_, err = ledger.GetStateProof("ns1", "key3")
+	assert.EqualError(t, err, "key [key3] of namespace [ns1] was last updated by block 4, for which no digest of the updates was recorded")
+}
+
 func checkHistoryDBForTest(t *testing.T, l lgr.PeerLedger, key string, expectedVals []string) {
 	qhistory, _ := l.NewHistoryQueryExecutor()
 	itr, _ := qhistory.GetHistoryForKey("ns", key)
@@ -18,6 +18,8 @@ type stats struct {
 	blockstorageCommitTime metrics.Histogram
 	statedbCommitTime      metrics.Histogram
 	transactionsCount      metrics.Counter
+	diskUsage              metrics.Gauge
+	diskQuotaExceeded      metrics.Gauge
 }
 
 func newStats(metricsProvider metrics.Provider) *stats {
@@ -27,6 +29,8 @@ func newStats(metricsProvider metrics.Provider) *stats {
 	stats.blockstorageCommitTime = metricsProvider.NewHistogram(blockstorageCommitTimeOpts)
 	stats.statedbCommitTime = metricsProvider.NewHistogram(statedbCommitTimeOpts)
 	stats.transactionsCount = metricsProvider.NewCounter(transactionCountOpts)
+	stats.diskUsage = metricsProvider.NewGauge(diskUsageOpts)
+	stats.diskQuotaExceeded = metricsProvider.NewGauge(diskQuotaExceededOpts)
 	return stats
 }
 
@@ -82,6 +86,17 @@ func (s *ledgerStats) updateTransactionsStats(
 	}
 }
 
+func (s *ledgerStats) updateDiskUsage(usage map[string]int64, quotaExceeded bool) {
+	for store, size := range usage {
+		s.stats.diskUsage.With("channel", s.ledgerid, "store", store).Set(float64(size))
+	}
+	exceeded := float64(0)
+	if quotaExceeded {
+		exceeded = 1
+	}
+	s.stats.diskQuotaExceeded.With("channel", s.ledgerid).Set(exceeded)
+}
+
 var (
 	blockchainHeightOpts = metrics.GaugeOpts{
 		Namespace:    "ledger",
@@ -130,4 +145,22 @@ var (
 		LabelNames:   []string{"channel", "transaction_type", "chaincode", "validation_code"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{transaction_type}.%{chaincode}.%{validation_code}",
 	}
+
+	diskUsageOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "disk_usage_bytes",
+		Help:         "Approximate disk space used by the stores of a channel: blockstore, index, statedb and pvtdata.",
+		LabelNames:   []string{"channel", "store"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{store}",
+	}
+
+	diskQuotaExceededOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "",
+		Name:         "disk_quota_exceeded",
+		Help:         "Whether the disk space used by a channel exceeds its soft quota (1) or not (0).",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
@@ -196,7 +196,7 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		case blockchainHeightOpts.Name:
 			return fakeBlockchainHeightGauge
 		}
-		return nil
+		return testutilConstructGuage()
 	}
 	fakeProvider.NewHistogramStub = func(opts metrics.HistogramOpts) metrics.Histogram {
 		switch opts.Name {
@@ -207,7 +207,7 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		case statedbCommitTimeOpts.Name:
 			return fakeStatedbCommitTimeHist
 		}
-		return nil
+		return testutilConstructHist()
 	}
 
 	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
@@ -215,7 +215,7 @@ func testutilConstructMetricProvider() *testMetricProvider {
 		case transactionCountOpts.Name:
 			return fakeTransactionsCount
 		}
-		return nil
+		return testutilConstructCounter()
 	}
 	return &testMetricProvider{
 		fakeProvider,
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// ErrLedgerPendingRemoval is returned by a Create call for a ledger that was removed while
+// the peer is running, until the peer is restarted and completes the removal
+var ErrLedgerPendingRemoval = errors.New("ledger is pending removal, the peer must be restarted to complete it")
+
+var pendingRemovalKeyPrefix = []byte("r")
+
+// Remove removes the ledger with the given id, which is expected to be closed. The ledger is
+// immediately removed from the list of the created ledgers and its data is deleted from the
+// stores when the provider is next instantiated, as the stores are shared across the ledgers
+// and are held open by the running provider
+func (provider *Provider) Remove(ledgerID string) error {
+	exists, err := provider.idStore.ledgerIDExists(ledgerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNonExistingLedgerID
+	}
+	logger.Infof("Marking the ledger [%s] for removal", ledgerID)
+	return provider.idStore.markForRemoval(ledgerID)
+}
+
+// removePendingLedgers deletes the data of the ledgers marked for removal. It is invoked
+// before the stores are opened by the provider
+func removePendingLedgers(s *idStore, paths *ledgerconfig.StorePaths) error {
+	ledgerIDs, err := s.getLedgerIDsPendingRemoval()
+	if err != nil {
+		return err
+	}
+	for _, ledgerID := range ledgerIDs {
+		logger.Infof("Removing the data of the ledger [%s]", ledgerID)
+		if err := removeLedgerData(paths, ledgerID); err != nil {
+			return errors.WithMessage(err, "error while removing the data of the ledger ["+ledgerID+"]")
+		}
+		if err := s.unmarkForRemoval(ledgerID); err != nil {
+			return err
+		}
+		logger.Infof("The ledger [%s] has been removed", ledgerID)
+	}
+	return nil
+}
+
+// removeLedgerData deletes the data of the given ledger from all the stores at the given paths. The
+// derived databases are dropped first so that an interrupted removal can safely be run again
+func removeLedgerData(paths *ledgerconfig.StorePaths, ledgerID string) error {
+	if err := dropDerivedDBs(paths, ledgerID); err != nil {
+		return err
+	}
+	if err := clearLevelDB(paths.PvtdataStore, ledgerID); err != nil {
+		return err
+	}
+	// unlike the other bookkeeping, the digests of the updates cannot be derived from the block store
+	// and are not cleared by the rollbacks
+	if err := clearLevelDB(paths.InternalBookkeeper, bookkeeping.DBName(ledgerID, bookkeeping.StateProofs)); err != nil {
+		return err
+	}
+	blockStorePath := paths.BlockStore
+	if err := clearLevelDB(filepath.Join(blockStorePath, fsblkstorage.IndexDir), ledgerID); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(blockStorePath, fsblkstorage.ChainsDir, ledgerID)); err != nil {
+		return errors.Wrapf(err, "error while removing the block files of the ledger [%s]", ledgerID)
+	}
+	return nil
+}
+
+func (s *idStore) markForRemoval(ledgerID string) error {
+	batch := &leveldb.Batch{}
+	batch.Delete(s.encodeLedgerKey(ledgerID))
+	batch.Put(encodePendingRemovalKey(ledgerID), []byte{})
+	return s.db.WriteBatch(batch, true)
+}
+
+func (s *idStore) unmarkForRemoval(ledgerID string) error {
+	return s.db.Delete(encodePendingRemovalKey(ledgerID), true)
+}
+
+func (s *idStore) isPendingRemoval(ledgerID string) (bool, error) {
+	val, err := s.db.Get(encodePendingRemovalKey(ledgerID))
+	if err != nil {
+		return false, err
+	}
+	return val != nil, nil
+}
+
+func (s *idStore) getLedgerIDsPendingRemoval() ([]string, error) {
+	var ids []string
+	itr := s.db.GetIterator(pendingRemovalKeyPrefix, []byte{pendingRemovalKeyPrefix[0] + 1})
+	defer itr.Release()
+	for itr.Next() {
+		ids = append(ids, string(itr.Key()[len(pendingRemovalKeyPrefix):]))
+	}
+	return ids, errors.Wrap(itr.Error(), "error while listing the ledgers pending removal")
+}
+
+func encodePendingRemovalKey(ledgerID string) []byte {
+	return append(append([]byte{}, pendingRemovalKeyPrefix...), ledgerID...)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	provider := testutilNewProvider(t)
+
+	for i := 0; i < 2; i++ {
+		genesisBlock, _ := configtxtest.MakeGenesisBlock(constructTestLedgerID(i))
+		l, err := provider.Create(genesisBlock)
+		assert.NoError(t, err)
+		l.Close()
+	}
+	removedID := constructTestLedgerID(0)
+	blockFilesDir := filepath.Join(ledgerconfig.GetBlockStorePath(), fsblkstorage.ChainsDir, removedID)
+	_, err := os.Stat(blockFilesDir)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ErrNonExistingLedgerID, provider.Remove("non-existing-ledger"))
+	assert.NoError(t, provider.Remove(removedID))
+	ledgerIDs, err := provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{constructTestLedgerID(1)}, ledgerIDs)
+	exists, err := provider.Exists(removedID)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// the ledger cannot be created again until the removal is completed by a restart
+	genesisBlock, _ := configtxtest.MakeGenesisBlock(removedID)
+	_, err = provider.Create(genesisBlock)
+	assert.Equal(t, ErrLedgerPendingRemoval, err)
+	provider.Close()
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	_, err = os.Stat(blockFilesDir)
+	assert.True(t, os.IsNotExist(err))
+
+	l, err := provider.Create(genesisBlock)
+	assert.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	ledgerIDs, err = provider.List()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{constructTestLedgerID(0), constructTestLedgerID(1)}, ledgerIDs)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb/statecouchdb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	"github.com/pkg/errors"
+)
+
+// RollbackKVLedger rolls back the ledger of the given channel to the given block number.
+// The block store is truncated so that the block `blockNum` becomes the last block, and
+// all the databases derived from the blocks (i.e., statedb, historydb, bookkeeping, and
+// config history) are cleared for the channel. The derived databases are rebuilt from the
+// block store during the next peer start. This function is expected to be invoked only
+// when the peer is not running
+func RollbackKVLedger(ledgerID string, blockNum uint64) error {
+	paths, err := ledgerconfig.GetLedgerStorePaths(ledgerID)
+	if err != nil {
+		return err
+	}
+	blockstorePath := paths.BlockStore
+	if err := ledgerstorage.ValidateRollbackParams(blockstorePath, ledgerID, blockNum); err != nil {
+		return err
+	}
+
+	logger.Infof("Dropping the derived databases of the channel [%s]", ledgerID)
+	if err := dropDerivedDBs(paths, ledgerID); err != nil {
+		return err
+	}
+
+	logger.Infof("Rolling back the block store of the channel [%s]", ledgerID)
+	if err := ledgerstorage.Rollback(blockstorePath, paths.PvtdataStore, ledgerID, blockNum); err != nil {
+		return err
+	}
+	logger.Infof("The channel [%s] has been successfully rolled back to the block number [%d]", ledgerID, blockNum)
+	return nil
+}
+
+// dropDerivedDBs clears the data of the given ledger from the databases at the given paths that are
+// derived from the block store. The statedb is cleared first so that, if this function fails midway,
+// the peer still rebuilds all the derived data (as the savepoint is missing) once the rollback is retried
+func dropDerivedDBs(paths *ledgerconfig.StorePaths, ledgerID string) error {
+	if err := dropStateDB(paths, ledgerID); err != nil {
+		return err
+	}
+	if err := clearLevelDB(paths.ConfigHistory, ledgerID); err != nil {
+		return err
+	}
+	for _, cat := range []bookkeeping.Category{bookkeeping.PvtdataExpiry, bookkeeping.MetadataPresenceIndicator} {
+		if err := clearLevelDB(paths.InternalBookkeeper, bookkeeping.DBName(ledgerID, cat)); err != nil {
+			return err
+		}
+	}
+	return clearLevelDB(paths.HistoryLevelDB, ledgerID)
+}
+
+func clearLevelDB(dbPath, dbName string) error {
+	logger.Debugf("Clearing db [%s] at path [%s]", dbName, dbPath)
+	p := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	defer p.Close()
+	if err := p.GetDBHandle(dbName).DeleteAll(); err != nil {
+		return errors.WithMessage(err, "error while clearing db ["+dbName+"] at path ["+dbPath+"]")
+	}
+	return nil
+}
+
+// DropStateDB clears the data of the given ledger from the state database of the configured backend,
+// which is rebuilt from the block store the next time the ledger is opened. Unlike a rollback, the other
+// databases derived from the blocks are left intact. This function is expected to be invoked only when
+// the peer is not running
+func DropStateDB(ledgerID string) error {
+	paths, err := ledgerconfig.GetLedgerStorePaths(ledgerID)
+	if err != nil {
+		return err
+	}
+	if _, err := fsblkstorage.GetHeight(paths.BlockStore, ledgerID); err != nil {
+		return err
+	}
+	logger.Infof("Dropping the state database of the channel [%s]", ledgerID)
+	return dropStateDB(paths, ledgerID)
+}
+
+func dropStateDB(paths *ledgerconfig.StorePaths, ledgerID string) error {
+	if ledgerconfig.IsCouchDBEnabled() {
+		return statecouchdb.DropChannelDBs(&disabled.Provider{}, ledgerID)
+	}
+	return clearLevelDB(paths.StateLevelDB, ledgerID)
+}
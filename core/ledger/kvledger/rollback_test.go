@@ -0,0 +1,145 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	lgr "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackKVLedger(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+	viper.Set("ledger.history.enableHistoryDatabase", true)
+	defer viper.Set("ledger.history.enableHistoryDatabase", false)
+
+	ledgerID := "testLedger"
+	otherLedgerID := "otherLedger"
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	for i := 1; i <= 10; i++ {
+		commitTestBlock(t, l, bg, "key", fmt.Sprintf("value_%d", i))
+	}
+	l.Close()
+
+	otherBG, otherGB := testutil.NewBlockGenerator(t, otherLedgerID, false)
+	otherLedger, err := provider.Create(otherGB)
+	assert.NoError(t, err)
+	commitTestBlock(t, otherLedger, otherBG, "key", "otherValue")
+	otherLedger.Close()
+	provider.Close()
+
+	assert.EqualError(t, RollbackKVLedger(ledgerID, 10),
+		"target block number [10] should be less than the biggest block number [10]")
+	assert.EqualError(t, RollbackKVLedger("nonExistingLedger", 1),
+		"ledgerID [nonExistingLedger] does not exist")
+	assert.NoError(t, RollbackKVLedger(ledgerID, 4))
+
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	l, err = provider.Open(ledgerID)
+	assert.NoError(t, err)
+	defer l.Close()
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), bcInfo.Height)
+
+	qe, err := l.NewQueryExecutor()
+	assert.NoError(t, err)
+	val, err := qe.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value_4"), val)
+	qe.Done()
+
+	hqe, err := l.NewHistoryQueryExecutor()
+	assert.NoError(t, err)
+	itr, err := hqe.GetHistoryForKey("ns", "key")
+	assert.NoError(t, err)
+	count := 0
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			break
+		}
+		count++
+	}
+	itr.Close()
+	assert.Equal(t, 4, count)
+
+	// the ledger of the other channel remains intact
+	otherLedger, err = provider.Open(otherLedgerID)
+	assert.NoError(t, err)
+	defer otherLedger.Close()
+	qe, err = otherLedger.NewQueryExecutor()
+	assert.NoError(t, err)
+	val, err = qe.GetState("ns", "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("otherValue"), val)
+	qe.Done()
+}
+
+func commitTestBlock(t *testing.T, l lgr.PeerLedger, bg *testutil.BlockGenerator, key, value string) {
+	s, err := l.NewTxSimulator(util.GenerateUUID())
+	assert.NoError(t, err)
+	assert.NoError(t, s.SetState("ns", key, []byte(value)))
+	s.Done()
+	res, err := s.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimBytes, err := res.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	assert.NoError(t, l.CommitWithPvtData(&lgr.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimBytes})}))
+}
+
+func TestDropStateDB(t *testing.T) {
+	env := newTestEnv(t)
+	defer env.cleanup()
+
+	ledgerID := "testLedger"
+	provider := testutilNewProvider(t)
+	bg, gb := testutil.NewBlockGenerator(t, ledgerID, false)
+	l, err := provider.Create(gb)
+	assert.NoError(t, err)
+	for i := 1; i <= 10; i++ {
+		commitTestBlock(t, l, bg, fmt.Sprintf("key_%d", i), fmt.Sprintf("value_%d", i))
+	}
+	l.Close()
+	provider.Close()
+
+	assert.EqualError(t, DropStateDB("nonExistingLedger"), "ledgerID [nonExistingLedger] does not exist")
+	assert.NoError(t, DropStateDB(ledgerID))
+	assert.NoError(t, withStateDB(ledgerID, func(db privacyenabledstate.DB) error {
+		savepoint, err := db.GetLatestSavePoint()
+		assert.NoError(t, err)
+		assert.Nil(t, savepoint)
+		return nil
+	}))
+
+	// the state is rebuilt from the block store when the ledger is opened
+	provider = testutilNewProvider(t)
+	defer provider.Close()
+	l, err = provider.Open(ledgerID)
+	assert.NoError(t, err)
+	defer l.Close()
+	qe, err := l.NewQueryExecutor()
+	assert.NoError(t, err)
+	defer qe.Done()
+	for i := 1; i <= 10; i++ {
+		val, err := qe.GetState("ns", fmt.Sprintf("key_%d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("value_%d", i)), val)
+	}
+}
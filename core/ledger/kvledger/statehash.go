@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kvledger
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/bookkeeping"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// lsccNamespace is the namespace in which the chaincode definitions are stored
+const lsccNamespace = "lscc"
+
+// ComputeStateHash computes the canonical hash of the state of the given channel,
+// which covers the namespaces of the chaincodes defined on the channel and the
+// hashes of the private data of their collections. This function is expected
+// to be invoked only when the peer is not running
+func ComputeStateHash(ledgerID string, opts privacyenabledstate.StateHashOptions) (*privacyenabledstate.StateHash, error) {
+	var stateHash *privacyenabledstate.StateHash
+	err := withStateDB(ledgerID, func(db privacyenabledstate.DB) error {
+		namespaces, err := stateNamespaces(db)
+		if err != nil {
+			return err
+		}
+		stateHash, err = privacyenabledstate.ComputeStateHash(db, namespaces, opts)
+		return err
+	})
+	return stateHash, err
+}
+
+// withStateDB opens the state database of the given ledger for the duration
+// of the given function, while the peer is not running
+func withStateDB(ledgerID string, f func(db privacyenabledstate.DB) error) error {
+	paths, err := ledgerconfig.GetLedgerStorePaths(ledgerID)
+	if err != nil {
+		return err
+	}
+	bookkeepingProvider := bookkeeping.NewProviderWithPath(paths.InternalBookkeeper)
+	defer bookkeepingProvider.Close()
+	dbProvider, err := privacyenabledstate.NewCommonStorageDBProviderWithPath(paths.StateLevelDB, bookkeepingProvider, &disabled.Provider{}, nil)
+	if err != nil {
+		return err
+	}
+	defer dbProvider.Close()
+
+	db, err := dbProvider.GetDBHandle(ledgerID)
+	if err != nil {
+		return err
+	}
+	return f(db)
+}
+
+// stateNamespaces returns the chaincodes defined in the state, mapped to the
+// names of their collections
+func stateNamespaces(db statedb.VersionedDB) (map[string][]string, error) {
+	itr, err := db.GetStateRangeScanIterator(lsccNamespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	namespaces := map[string][]string{lsccNamespace: nil}
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			return namespaces, nil
+		}
+		kv := result.(*statedb.VersionedKV)
+		if !privdata.IsCollectionConfigKey(kv.Key) {
+			if _, exists := namespaces[kv.Key]; !exists {
+				namespaces[kv.Key] = nil
+			}
+			continue
+		}
+		ccName := privdata.GetCCNameFromCollectionConfigKey(kv.Key)
+		collections := &common.CollectionConfigPackage{}
+		if err := proto.Unmarshal(kv.Value, collections); err != nil {
+			return nil, errors.Wrapf(err, "invalid collection configuration of chaincode %s", ccName)
+		}
+		for _, config := range collections.Config {
+			if staticConfig := config.GetStaticCollectionConfig(); staticConfig != nil {
+				namespaces[ccName] = append(namespaces[ccName], staticConfig.Name)
+			}
+		}
+	}
+}
@@ -42,6 +42,12 @@ type CommonStorageDBProvider struct {
 
 // NewCommonStorageDBProvider constructs an instance of DBProvider
 func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider, healthCheckRegistry ledger.HealthCheckRegistry) (DBProvider, error) {
+	return NewCommonStorageDBProviderWithPath(ledgerconfig.GetStateLevelDBPath(), bookkeeperProvider, metricsProvider, healthCheckRegistry)
+}
+
+// NewCommonStorageDBProviderWithPath constructs an instance of DBProvider whose goleveldb state
+// database is at the given path. The path is not used when the state database is CouchDB
+func NewCommonStorageDBProviderWithPath(stateDBPath string, bookkeeperProvider bookkeeping.Provider, metricsProvider metrics.Provider, healthCheckRegistry ledger.HealthCheckRegistry) (DBProvider, error) {
 	var vdbProvider statedb.VersionedDBProvider
 	var err error
 	if ledgerconfig.IsCouchDBEnabled() {
@@ -49,7 +55,7 @@ func NewCommonStorageDBProvider(bookkeeperProvider bookkeeping.Provider, metrics
 			return nil, err
 		}
 	} else {
-		vdbProvider = stateleveldb.NewVersionedDBProvider()
+		vdbProvider = stateleveldb.NewVersionedDBProviderWithPath(stateDBPath)
 	}
 
 	dbProvider := &CommonStorageDBProvider{vdbProvider, healthCheckRegistry, bookkeeperProvider}
@@ -104,6 +110,40 @@ func (s *CommonStorageDB) IsBulkOptimizable() bool {
 	return ok
 }
 
+// IsSnapshotCapable implements corresponding function in interface SnapshotCapable
+func (s *CommonStorageDB) IsSnapshotCapable() bool {
+	_, ok := s.VersionedDB.(statedb.SnapshotCapable)
+	return ok
+}
+
+// GetSnapshot implements corresponding function in interface SnapshotCapable
+func (s *CommonStorageDB) GetSnapshot() (DB, func(), error) {
+	snapshotCapable, ok := s.VersionedDB.(statedb.SnapshotCapable)
+	if !ok {
+		return nil, nil, errors.New("the state database does not support snapshots")
+	}
+	snapshot, err := snapshotCapable.GetSnapshot()
+	if err != nil {
+		return nil, nil, err
+	}
+	return &CommonStorageDB{snapshot, s.metadataHint}, snapshot.Release, nil
+}
+
+// ReportsDiskUsage implements corresponding function in interface DiskUsageReporter
+func (s *CommonStorageDB) ReportsDiskUsage() bool {
+	_, ok := s.VersionedDB.(statedb.DiskUsageReporter)
+	return ok
+}
+
+// DiskUsage implements corresponding function in interface DiskUsageReporter
+func (s *CommonStorageDB) DiskUsage() (int64, error) {
+	reporter, ok := s.VersionedDB.(statedb.DiskUsageReporter)
+	if !ok {
+		return 0, errors.New("the state database does not report its disk usage")
+	}
+	return reporter.DiskUsage()
+}
+
 // LoadCommittedVersionsOfPubAndHashedKeys implements corresponding function in interface DB
 func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey,
 	hashedKeys []*HashedCompositeKey) error {
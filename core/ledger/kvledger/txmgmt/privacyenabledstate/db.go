@@ -41,6 +41,22 @@ type DB interface {
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
 }
 
+// SnapshotCapable is implemented by the DBs capable of serving a stable view of their state
+type SnapshotCapable interface {
+	// IsSnapshotCapable returns true if the underlying VersionedDB can take snapshots
+	IsSnapshotCapable() bool
+	// GetSnapshot returns a read-only DB serving the state as of now, unaffected by the updates
+	// applied later, along with the function that releases the snapshot
+	GetSnapshot() (DB, func(), error)
+}
+
+// DiskUsageReporter is implemented by the DBs able to report the disk space they use
+type DiskUsageReporter interface {
+	// ReportsDiskUsage returns true if the underlying VersionedDB reports its disk usage
+	ReportsDiskUsage() bool
+	statedb.DiskUsageReporter
+}
+
 // PvtdataCompositeKey encloses Namespace, CollectionName and Key components
 type PvtdataCompositeKey struct {
 	Namespace      string
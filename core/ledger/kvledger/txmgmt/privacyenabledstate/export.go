@@ -0,0 +1,216 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/pkg/errors"
+)
+
+// NamespaceExportHeader is the first entry of the export of a namespace, which
+// is followed by one ExportedKV entry per key, each encoded as a line of JSON
+type NamespaceExportHeader struct {
+	Namespace   string   `json:"namespace"`
+	BlockNumber uint64   `json:"block_number"`
+	Collections []string `json:"collections,omitempty"`
+	PrivateData bool     `json:"private_data"`
+}
+
+// ExportedKV is a key of the export of a namespace. The entries of the public
+// state have no collection, the entries of the private data of a collection
+// have a key, and the entries of the hashes of the private data have a key hash
+type ExportedKV struct {
+	Collection string `json:"collection,omitempty"`
+	Key        string `json:"key,omitempty"`
+	KeyHash    []byte `json:"key_hash,omitempty"`
+	Value      []byte `json:"value"`
+	Metadata   []byte `json:"metadata,omitempty"`
+}
+
+// NamespaceExportOptions defines the collections of the exported namespace, and
+// whether the private data is exported along with the hashes of the private data
+type NamespaceExportOptions struct {
+	Collections        []string
+	IncludePrivateData bool
+}
+
+// NamespaceSummary summarizes the export or the import of a namespace
+type NamespaceSummary struct {
+	Namespace   string
+	BlockNumber uint64
+	Keys        int
+}
+
+// ExportNamespace writes the state of the given namespace to the given writer, along
+// with the hashes of the private data of its collections and, optionally, the private
+// data. The versions of the keys are not exported. The state must not be modified
+// during the export
+func ExportNamespace(db DB, ns string, opts NamespaceExportOptions, w io.Writer) (*NamespaceSummary, error) {
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.New("the state database is empty")
+	}
+
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+	header := &NamespaceExportHeader{
+		Namespace:   ns,
+		BlockNumber: savepoint.BlockNum,
+		Collections: opts.Collections,
+		PrivateData: opts.IncludePrivateData,
+	}
+	if err := encoder.Encode(header); err != nil {
+		return nil, errors.Wrap(err, "failed writing the export")
+	}
+
+	summary := &NamespaceSummary{Namespace: ns, BlockNumber: savepoint.BlockNum}
+	export := func(stateNs, coll string, hashed bool) error {
+		itr, err := db.GetStateRangeScanIterator(stateNs, "", "")
+		if err != nil {
+			return errors.WithMessage(err, "failed iterating namespace "+stateNs)
+		}
+		defer itr.Close()
+		for {
+			result, err := itr.Next()
+			if err != nil {
+				return errors.WithMessage(err, "failed iterating namespace "+stateNs)
+			}
+			if result == nil {
+				return nil
+			}
+			kv := result.(*statedb.VersionedKV)
+			entry := &ExportedKV{Collection: coll, Value: kv.Value, Metadata: kv.Metadata}
+			if !hashed {
+				entry.Key = kv.Key
+			} else if db.BytesKeySupported() {
+				entry.KeyHash = []byte(kv.Key)
+			} else if entry.KeyHash, err = base64.StdEncoding.DecodeString(kv.Key); err != nil {
+				return errors.Wrapf(err, "invalid key hash in namespace %s", stateNs)
+			}
+			if err := encoder.Encode(entry); err != nil {
+				return errors.Wrap(err, "failed writing the export")
+			}
+			summary.Keys++
+		}
+	}
+
+	if err := export(ns, "", false); err != nil {
+		return nil, err
+	}
+	for _, coll := range opts.Collections {
+		if err := export(deriveHashedDataNs(ns, coll), coll, true); err != nil {
+			return nil, err
+		}
+		if !opts.IncludePrivateData {
+			continue
+		}
+		if err := export(derivePvtDataNs(ns, coll), coll, false); err != nil {
+			return nil, err
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, errors.Wrap(err, "failed writing the export")
+	}
+	return summary, nil
+}
+
+// ImportNamespace reads an export of a namespace from the given reader and writes its keys in
+// the given namespace, or in the exported namespace if ns is empty. The keys are written at the
+// version of the savepoint of the state database, so that the peers that import the same export
+// at the same block number end up with the same state. The namespace and the private data of
+// its collections must be empty. The imported private data is never purged
+func ImportNamespace(db DB, ns string, r io.Reader) (*NamespaceSummary, error) {
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.New("the state database is empty")
+	}
+
+	decoder := json.NewDecoder(bufio.NewReader(r))
+	header := &NamespaceExportHeader{}
+	if err := decoder.Decode(header); err != nil {
+		return nil, errors.Wrap(err, "invalid export header")
+	}
+	if header.Namespace == "" {
+		return nil, errors.New("invalid export header: the namespace is missing")
+	}
+	if ns == "" {
+		ns = header.Namespace
+	}
+
+	collections := map[string]bool{}
+	stateNamespaces := []string{ns}
+	for _, coll := range header.Collections {
+		collections[coll] = true
+		stateNamespaces = append(stateNamespaces, deriveHashedDataNs(ns, coll), derivePvtDataNs(ns, coll))
+	}
+	for _, stateNs := range stateNamespaces {
+		empty, err := isNamespaceEmpty(db, stateNs)
+		if err != nil {
+			return nil, err
+		}
+		if !empty {
+			return nil, errors.Errorf("namespace %s is not empty", stateNs)
+		}
+	}
+
+	summary := &NamespaceSummary{Namespace: ns, BlockNumber: savepoint.BlockNum}
+	batch := NewUpdateBatch()
+	for {
+		entry := &ExportedKV{}
+		err := decoder.Decode(entry)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entry %d of the export", summary.Keys+1)
+		}
+		if entry.Value == nil {
+			return nil, errors.Errorf("invalid entry %d of the export: the value is missing", summary.Keys+1)
+		}
+		if entry.Collection != "" && !collections[entry.Collection] {
+			return nil, errors.Errorf("invalid entry %d of the export: unknown collection %s", summary.Keys+1, entry.Collection)
+		}
+		switch {
+		case entry.Collection == "":
+			batch.PubUpdates.PutValAndMetadata(ns, entry.Key, entry.Value, entry.Metadata, savepoint)
+		case entry.KeyHash != nil:
+			batch.HashUpdates.PutValHashAndMetadata(ns, entry.Collection, entry.KeyHash, entry.Value, entry.Metadata, savepoint)
+		default:
+			batch.PvtUpdates.PutValAndMetadata(ns, entry.Collection, entry.Key, entry.Value, entry.Metadata, savepoint)
+		}
+		summary.Keys++
+	}
+
+	if err := db.ApplyPrivacyAwareUpdates(batch, savepoint); err != nil {
+		return nil, errors.WithMessage(err, "failed writing the imported keys")
+	}
+	return summary, nil
+}
+
+func isNamespaceEmpty(db DB, ns string) (bool, error) {
+	itr, err := db.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return false, errors.WithMessage(err, "failed iterating namespace "+ns)
+	}
+	defer itr.Close()
+	result, err := itr.Next()
+	if err != nil {
+		return false, errors.WithMessage(err, "failed iterating namespace "+ns)
+	}
+	return result == nil, nil
+}
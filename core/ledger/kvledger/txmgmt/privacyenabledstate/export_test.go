@@ -0,0 +1,92 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportNamespace(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+
+	source := env.GetDBHandle("source")
+	batch := NewUpdateBatch()
+	batch.PubUpdates.PutValAndMetadata("cc1", "key1", []byte("value1"), []byte("metadata1"), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("cc1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	batch.PubUpdates.Put("cc2", "key1", []byte("other"), version.NewHeight(1, 2))
+	batch.HashUpdates.Put("cc1", "coll1", []byte("keyhash1"), []byte("valuehash1"), version.NewHeight(1, 3))
+	batch.PvtUpdates.Put("cc1", "coll1", "key1", []byte("private1"), version.NewHeight(1, 3))
+	require.NoError(t, source.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 3)))
+
+	_, err := ExportNamespace(env.GetDBHandle("empty"), "cc1", NamespaceExportOptions{}, &bytes.Buffer{})
+	assert.EqualError(t, err, "the state database is empty")
+
+	opts := NamespaceExportOptions{Collections: []string{"coll1"}}
+	export := &bytes.Buffer{}
+	summary, err := ExportNamespace(source, "cc1", opts, export)
+	require.NoError(t, err)
+	assert.Equal(t, &NamespaceSummary{Namespace: "cc1", BlockNumber: 1, Keys: 3}, summary)
+
+	opts.IncludePrivateData = true
+	exportWithPvtData := &bytes.Buffer{}
+	summary, err = ExportNamespace(source, "cc1", opts, exportWithPvtData)
+	require.NoError(t, err)
+	assert.Equal(t, 4, summary.Keys)
+
+	target := env.GetDBHandle("target")
+	_, err = ImportNamespace(target, "", bytes.NewReader(export.Bytes()))
+	assert.EqualError(t, err, "the state database is empty")
+
+	batch = NewUpdateBatch()
+	batch.PubUpdates.Put("cc3", "key1", []byte("value1"), version.NewHeight(5, 0))
+	require.NoError(t, target.ApplyPrivacyAwareUpdates(batch, version.NewHeight(5, 0)))
+
+	// the namespace is imported under another name
+	_, err = ImportNamespace(target, "cc3", bytes.NewReader(export.Bytes()))
+	assert.EqualError(t, err, "namespace cc3 is not empty")
+	summary, err = ImportNamespace(target, "cc4", bytes.NewReader(exportWithPvtData.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, &NamespaceSummary{Namespace: "cc4", BlockNumber: 5, Keys: 4}, summary)
+
+	vv, err := target.GetState("cc4", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), vv.Value)
+	assert.Equal(t, []byte("metadata1"), vv.Metadata)
+	assert.Equal(t, version.NewHeight(5, 0), vv.Version)
+	vv, err = target.GetValueHash("cc4", "coll1", []byte("keyhash1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("valuehash1"), vv.Value)
+	vv, err = target.GetPrivateData("cc4", "coll1", "key1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("private1"), vv.Value)
+	vv, err = target.GetState("cc4", "key3")
+	require.NoError(t, err)
+	assert.Nil(t, vv)
+
+	// the namespace is imported under its own name
+	summary, err = ImportNamespace(target, "", bytes.NewReader(export.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, "cc1", summary.Namespace)
+	vv, err = target.GetPrivateData("cc1", "coll1", "key1")
+	require.NoError(t, err)
+	assert.Nil(t, vv)
+
+	_, err = ImportNamespace(target, "cc5", strings.NewReader(`{"block_number":1}`))
+	assert.EqualError(t, err, "invalid export header: the namespace is missing")
+	_, err = ImportNamespace(target, "cc5", strings.NewReader(`{"namespace":"cc1"}`+"\n"+`{"key":"key1"}`))
+	assert.EqualError(t, err, "invalid entry 1 of the export: the value is missing")
+	_, err = ImportNamespace(target, "cc5", strings.NewReader(`{"namespace":"cc1"}`+"\n"+`{"collection":"coll1","key":"key1","value":""}`))
+	assert.EqualError(t, err, "invalid entry 1 of the export: unknown collection coll1")
+}
@@ -0,0 +1,270 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+)
+
+// StateHashBuckets is the number of buckets in which the keys of a namespace are
+// distributed, according to the first byte of the hash of the key
+const StateHashBuckets = 256
+
+// StateHash is a canonical hash of the public state and of the hashes of the
+// private data of a channel, which does not depend on the state database in
+// use, so that the peers of different organizations can compare their states.
+// The hash of the state is computed over the hashes of the namespaces, and the
+// hash of a namespace over the hashes of its buckets, so that a divergence can
+// be narrowed down to a namespace, to a bucket and to a key
+type StateHash struct {
+	BlockNumber uint64           `json:"block_number"`
+	Hash        string           `json:"hash"`
+	Namespaces  []*NamespaceHash `json:"namespaces"`
+}
+
+// NamespaceHash is the hash of the state of a namespace
+type NamespaceHash struct {
+	Namespace string        `json:"namespace"`
+	Keys      int           `json:"keys"`
+	Hash      string        `json:"hash"`
+	Buckets   []*BucketHash `json:"buckets,omitempty"`
+}
+
+// BucketHash is the hash of the keys of a namespace that belong to a bucket
+type BucketHash struct {
+	Bucket int        `json:"bucket"`
+	Keys   int        `json:"keys"`
+	Hash   string     `json:"hash"`
+	Leaves []*KeyHash `json:"leaves,omitempty"`
+}
+
+// KeyHash is the hash of a key, its value, metadata and version. The key is
+// hex encoded for the namespaces of the hashes of the private data
+type KeyHash struct {
+	Key  string `json:"key"`
+	Hash string `json:"hash"`
+}
+
+// StateHashOptions selects the namespace for which the hashes of the buckets
+// are reported, and the bucket of this namespace for which the hashes of the
+// keys are reported, if Bucket is not negative
+type StateHashOptions struct {
+	Namespace string
+	Bucket    int
+}
+
+type leaf struct {
+	key     string
+	keyHash []byte
+	hash    []byte
+}
+
+// ComputeStateHash computes the hash of the state of the given namespaces,
+// which map the chaincodes to their collections. The state must not be
+// modified during the computation
+func ComputeStateHash(db DB, namespaces map[string][]string, opts StateHashOptions) (*StateHash, error) {
+	savepoint, err := db.GetLatestSavePoint()
+	if err != nil {
+		return nil, err
+	}
+	if savepoint == nil {
+		return nil, errors.New("the state database is empty")
+	}
+
+	var nsNames []string
+	hashedNamespaces := map[string]bool{}
+	for ns, collections := range namespaces {
+		nsNames = append(nsNames, ns)
+		for _, coll := range collections {
+			hashedNs := deriveHashedDataNs(ns, coll)
+			nsNames = append(nsNames, hashedNs)
+			hashedNamespaces[hashedNs] = true
+		}
+	}
+	sort.Strings(nsNames)
+
+	stateHash := &StateHash{BlockNumber: savepoint.BlockNum}
+	stateHasher := sha256.New()
+	for _, ns := range nsNames {
+		nsHash, err := computeNamespaceHash(db, ns, hashedNamespaces[ns], opts)
+		if err != nil {
+			return nil, err
+		}
+		stateHash.Namespaces = append(stateHash.Namespaces, nsHash)
+		nsHashBytes, _ := hex.DecodeString(nsHash.Hash)
+		writeLengthPrefixed(stateHasher, []byte(ns))
+		stateHasher.Write(nsHashBytes)
+	}
+	stateHash.Hash = hex.EncodeToString(stateHasher.Sum(nil))
+	return stateHash, nil
+}
+
+func computeNamespaceHash(db DB, ns string, hashed bool, opts StateHashOptions) (*NamespaceHash, error) {
+	itr, err := db.GetStateRangeScanIterator(ns, "", "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed iterating namespace "+ns)
+	}
+	defer itr.Close()
+
+	buckets := make([][]*leaf, StateHashBuckets)
+	keys := 0
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed iterating namespace "+ns)
+		}
+		if result == nil {
+			break
+		}
+		kv := result.(*statedb.VersionedKV)
+		key := kv.Key
+		if hashed && !db.BytesKeySupported() {
+			decoded, err := base64.StdEncoding.DecodeString(key)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid key hash in namespace %s", ns)
+			}
+			key = string(decoded)
+		}
+		l := &leaf{key: key, hash: leafHash(key, kv.VersionedValue)}
+		keyHash := sha256.Sum256([]byte(key))
+		l.keyHash = keyHash[:]
+		buckets[keyHash[0]] = append(buckets[keyHash[0]], l)
+		keys++
+	}
+
+	nsHash := &NamespaceHash{Namespace: ns, Keys: keys}
+	nsHasher := sha256.New()
+	for i, bucket := range buckets {
+		// the keys are ordered by their hash, since the order of the iteration
+		// depends on the encoding of the keys in the state database
+		sort.Slice(bucket, func(a, b int) bool {
+			return bytes.Compare(bucket[a].keyHash, bucket[b].keyHash) < 0
+		})
+		bucketHasher := sha256.New()
+		for _, l := range bucket {
+			bucketHasher.Write(l.hash)
+		}
+		bucketHash := bucketHasher.Sum(nil)
+		nsHasher.Write(bucketHash)
+
+		if opts.Namespace != ns {
+			continue
+		}
+		bh := &BucketHash{Bucket: i, Keys: len(bucket), Hash: hex.EncodeToString(bucketHash)}
+		if opts.Bucket == i {
+			for _, l := range bucket {
+				key := l.key
+				if hashed {
+					key = hex.EncodeToString([]byte(key))
+				}
+				bh.Leaves = append(bh.Leaves, &KeyHash{Key: key, Hash: hex.EncodeToString(l.hash)})
+			}
+		}
+		nsHash.Buckets = append(nsHash.Buckets, bh)
+	}
+	nsHash.Hash = hex.EncodeToString(nsHasher.Sum(nil))
+	return nsHash, nil
+}
+
+// leafHash hashes the key, the canonical form of the value, the metadata and the version
+func leafHash(key string, vv statedb.VersionedValue) []byte {
+	return kvHash(key, canonicalValue(vv.Value), vv.Metadata, vv.Version)
+}
+
+func kvHash(key string, value, metadata []byte, ver *version.Height) []byte {
+	if ver == nil {
+		return lutil.KVHash(key, value, metadata, 0, 0)
+	}
+	return lutil.KVHash(key, value, metadata, ver.BlockNum, ver.TxNum)
+}
+
+// canonicalValue returns JSON values with sorted keys and without insignificant
+// white space, since CouchDB does not preserve the bytes of the JSON values
+func canonicalValue(value []byte) []byte {
+	if !json.Valid(value) {
+		return value
+	}
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return value
+	}
+	if _, isObject := v.(map[string]interface{}); !isObject {
+		return value
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return value
+	}
+	return canonical
+}
+
+func writeLengthPrefixed(w io.Writer, b []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(b)))
+	w.Write(length[:])
+	w.Write(b)
+}
+
+// ComputeUpdatesHash computes a hash of the updates of the public state and of the hashes of the private
+// data in the given batch, which does not depend on the order in which the updates were added. The updates
+// of the private data are left out, since a peer is not expected to have the private data of all collections
+func ComputeUpdatesHash(batch *UpdateBatch) []byte {
+	return lutil.UpdatesHash(ComputeUpdatesDigest(batch))
+}
+
+// ComputeUpdatesDigest computes the digest of the updates in the given batch from which ComputeUpdatesHash
+// computes their hash, which retains the hash of the update of each key so that the inclusion of an update
+// in the hash can be proven
+func ComputeUpdatesDigest(batch *UpdateBatch) *queryresult.StateUpdates {
+	updates := map[string]map[string]*statedb.VersionedValue{}
+	for _, ns := range batch.PubUpdates.GetUpdatedNamespaces() {
+		updates[ns] = batch.PubUpdates.GetUpdates(ns)
+	}
+	for ns, nsBatch := range batch.HashUpdates.UpdateMap {
+		for _, coll := range nsBatch.GetCollectionNames() {
+			updates[deriveHashedDataNs(ns, coll)] = nsBatch.GetUpdates(coll)
+		}
+	}
+
+	var nsNames []string
+	for ns := range updates {
+		nsNames = append(nsNames, ns)
+	}
+	sort.Strings(nsNames)
+
+	digest := &queryresult.StateUpdates{}
+	for _, ns := range nsNames {
+		var keys []string
+		for key := range updates[ns] {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		nsUpdates := &queryresult.NamespaceStateUpdates{Namespace: ns}
+		for _, key := range keys {
+			vv := updates[ns][key]
+			entry := lutil.StateUpdateEntry(vv.IsDelete(), kvHash(key, vv.Value, vv.Metadata, vv.Version))
+			nsUpdates.Entries = append(nsUpdates.Entries, entry)
+		}
+		digest.Namespaces = append(digest.Namespaces, nsUpdates)
+	}
+	return digest
+}
@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privacyenabledstate
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStateHash(t *testing.T) {
+	env := &LevelDBCommonStorageTestEnv{}
+	env.Init(t)
+	defer env.Cleanup()
+
+	populate := func(id string, value []byte) DB {
+		db := env.GetDBHandle(id)
+		batch := NewUpdateBatch()
+		batch.PubUpdates.Put("lscc", "cc1", []byte("definition"), version.NewHeight(1, 0))
+		batch.PubUpdates.Put("cc1", "key1", value, version.NewHeight(1, 1))
+		batch.PubUpdates.Put("cc1", "key2", []byte("value2"), version.NewHeight(1, 2))
+		batch.HashUpdates.Put("cc1", "coll1", []byte("keyhash1"), []byte("valuehash1"), version.NewHeight(1, 3))
+		batch.PvtUpdates.Put("cc1", "coll1", "key1", []byte("private"), version.NewHeight(1, 3))
+		require.NoError(t, db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 3)))
+		return db
+	}
+	namespaces := map[string][]string{"lscc": nil, "cc1": {"coll1"}}
+	noDetail := StateHashOptions{Bucket: -1}
+
+	db1 := populate("ch1", []byte(`{"a": 1, "b": "x"}`))
+	hash1, err := ComputeStateHash(db1, namespaces, noDetail)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), hash1.BlockNumber)
+	require.Len(t, hash1.Namespaces, 3)
+	assert.Equal(t, "cc1", hash1.Namespaces[0].Namespace)
+	assert.Equal(t, 2, hash1.Namespaces[0].Keys)
+	assert.Equal(t, "cc1$$hcoll1", hash1.Namespaces[1].Namespace)
+	assert.Equal(t, 1, hash1.Namespaces[1].Keys)
+	assert.Equal(t, "lscc", hash1.Namespaces[2].Namespace)
+	assert.Empty(t, hash1.Namespaces[0].Buckets)
+
+	// the JSON values are hashed in their canonical form
+	db2 := populate("ch2", []byte(`{"b":"x","a":1}`))
+	hash2, err := ComputeStateHash(db2, namespaces, noDetail)
+	require.NoError(t, err)
+	assert.Equal(t, hash1, hash2)
+
+	db3 := populate("ch3", []byte(`{"b":"y","a":1}`))
+	hash3, err := ComputeStateHash(db3, namespaces, StateHashOptions{Namespace: "cc1", Bucket: -1})
+	require.NoError(t, err)
+	assert.NotEqual(t, hash1.Hash, hash3.Hash)
+	assert.NotEqual(t, hash1.Namespaces[0].Hash, hash3.Namespaces[0].Hash)
+	assert.Equal(t, hash1.Namespaces[1].Hash, hash3.Namespaces[1].Hash)
+	assert.Equal(t, hash1.Namespaces[2].Hash, hash3.Namespaces[2].Hash)
+
+	// the divergence is narrowed down to a bucket and to a key
+	require.Len(t, hash3.Namespaces[0].Buckets, StateHashBuckets)
+	hash1, err = ComputeStateHash(db1, namespaces, StateHashOptions{Namespace: "cc1", Bucket: -1})
+	require.NoError(t, err)
+	var diverging []int
+	for i, bucket := range hash3.Namespaces[0].Buckets {
+		if bucket.Hash != hash1.Namespaces[0].Buckets[i].Hash {
+			diverging = append(diverging, i)
+		}
+	}
+	require.Len(t, diverging, 1)
+
+	hash3, err = ComputeStateHash(db3, namespaces, StateHashOptions{Namespace: "cc1", Bucket: diverging[0]})
+	require.NoError(t, err)
+	leaves := hash3.Namespaces[0].Buckets[diverging[0]].Leaves
+	require.Len(t, leaves, 1)
+	assert.Equal(t, "key1", leaves[0].Key)
+
+	_, err = ComputeStateHash(env.GetDBHandle("empty"), namespaces, noDetail)
+	assert.EqualError(t, err, "the state database is empty")
+}
+
+func TestCanonicalValue(t *testing.T) {
+	assert.Equal(t, []byte(`{"a":1.50,"b":[2,1]}`), canonicalValue([]byte(`{ "b": [2, 1], "a": 1.50 }`)))
+	assert.Equal(t, []byte(`[2, 1]`), canonicalValue([]byte(`[2, 1]`)))
+	assert.Equal(t, []byte("not json"), canonicalValue([]byte("not json")))
+}
+
+func TestComputeUpdatesHash(t *testing.T) {
+	batch1 := NewUpdateBatch()
+	batch1.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch1.PubUpdates.Delete("ns1", "key2", version.NewHeight(1, 2))
+	batch1.HashUpdates.Put("ns1", "coll1", []byte("keyhash1"), []byte("valuehash1"), version.NewHeight(1, 3))
+	batch1.PvtUpdates.Put("ns1", "coll1", "key1", []byte("private1"), version.NewHeight(1, 3))
+
+	// the order of the updates and the private data do not matter
+	batch2 := NewUpdateBatch()
+	batch2.HashUpdates.Put("ns1", "coll1", []byte("keyhash1"), []byte("valuehash1"), version.NewHeight(1, 3))
+	batch2.PubUpdates.Delete("ns1", "key2", version.NewHeight(1, 2))
+	batch2.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	assert.Equal(t, ComputeUpdatesHash(batch1), ComputeUpdatesHash(batch2))
+
+	// a delete differs from an empty value
+	batch2.PubUpdates.Put("ns1", "key2", []byte{}, version.NewHeight(1, 2))
+	assert.NotEqual(t, ComputeUpdatesHash(batch1), ComputeUpdatesHash(batch2))
+
+	batch2 = NewUpdateBatch()
+	batch2.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch2.PubUpdates.Delete("ns1", "key2", version.NewHeight(1, 2))
+	batch2.HashUpdates.Put("ns1", "coll1", []byte("keyhash1"), []byte("valuehash2"), version.NewHeight(1, 3))
+	assert.NotEqual(t, ComputeUpdatesHash(batch1), ComputeUpdatesHash(batch2))
+}
+
+func TestComputeUpdatesDigest(t *testing.T) {
+	batch := NewUpdateBatch()
+	batch.PubUpdates.Put("ns2", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.PubUpdates.Delete("ns1", "key2", version.NewHeight(1, 2))
+	batch.PubUpdates.Put("ns1", "key1", []byte("value2"), version.NewHeight(1, 0))
+	batch.HashUpdates.Put("ns1", "coll1", []byte("keyhash1"), []byte("valuehash1"), version.NewHeight(1, 3))
+
+	digest := ComputeUpdatesDigest(batch)
+	require.Len(t, digest.Namespaces, 3)
+	assert.Equal(t, "ns1", digest.Namespaces[0].Namespace)
+	assert.Equal(t, [][]byte{
+		lutil.StateUpdateEntry(false, lutil.KVHash("key1", []byte("value2"), nil, 1, 0)),
+		lutil.StateUpdateEntry(true, lutil.KVHash("key2", nil, nil, 1, 2)),
+	}, digest.Namespaces[0].Entries)
+	assert.Equal(t, deriveHashedDataNs("ns1", "coll1"), digest.Namespaces[1].Namespace)
+	assert.Equal(t, [][]byte{
+		lutil.StateUpdateEntry(false, lutil.KVHash("keyhash1", []byte("valuehash1"), nil, 1, 3)),
+	}, digest.Namespaces[1].Entries)
+	assert.Equal(t, "ns2", digest.Namespaces[2].Namespace)
+	assert.Equal(t, ComputeUpdatesHash(batch), lutil.UpdatesHash(digest))
+}
@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// The value of a key updated by increments (see kvrwset.KVIncrement) is a counter encoded as
+// a signed 64-bit integer in its base 10 textual representation, so that it can be read by
+// the chaincodes as any other value. A key that does not exist is a counter of value zero
+
+// DecodeCounter returns the counter encoded in the given value
+func DecodeCounter(value []byte) (int64, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	counter, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("value [%s] is not a counter", value)
+	}
+	return counter, nil
+}
+
+// EncodeCounter returns the value that encodes the given counter
+func EncodeCounter(counter int64) []byte {
+	return []byte(strconv.FormatInt(counter, 10))
+}
+
+// IncrementCounter adds the delta to the counter encoded in the given value and returns the encoded
+// result. An error is returned if the value is not a counter or if the addition overflows
+func IncrementCounter(value []byte, delta int64) ([]byte, error) {
+	counter, err := DecodeCounter(value)
+	if err != nil {
+		return nil, err
+	}
+	sum, ok := addDeltas(counter, delta)
+	if !ok {
+		return nil, errors.Errorf("incrementing counter %d by %d overflows", counter, delta)
+	}
+	return EncodeCounter(sum), nil
+}
+
+// addDeltas returns the sum of the given integers and false if the sum overflows
+func addDeltas(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+	return a + b, true
+}
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterEncoding(t *testing.T) {
+	counter, err := DecodeCounter(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), counter)
+
+	counter, err = DecodeCounter(EncodeCounter(-42))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-42), counter)
+
+	_, err = DecodeCounter([]byte("4.2"))
+	assert.EqualError(t, err, "value [4.2] is not a counter")
+}
+
+func TestIncrementCounter(t *testing.T) {
+	value, err := IncrementCounter(nil, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("7"), value)
+
+	value, err = IncrementCounter(value, -10)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("-3"), value)
+
+	_, err = IncrementCounter([]byte("abc"), 1)
+	assert.EqualError(t, err, "value [abc] is not a counter")
+
+	_, err = IncrementCounter(EncodeCounter(math.MaxInt64), 1)
+	assert.EqualError(t, err, "incrementing counter 9223372036854775807 by 1 overflows")
+
+	_, err = IncrementCounter(EncodeCounter(math.MinInt64), -1)
+	assert.EqualError(t, err, "incrementing counter -9223372036854775808 by -1 overflows")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rwsetutil
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+)
+
+// The keys of a range deleted by a transaction (see kvrwset.KVRangeDelete) are not recorded in its
+// rwset. The range delete carries instead the fingerprint of the keys found in the range during the
+// simulation, which the committers compute again over the keys of the range at commit time
+
+// RangeDeleteHasher computes the fingerprint of the keys of a range deleted by a transaction, which is
+// the hash of the keys of the range and of their versions, added in the order of the keys
+type RangeDeleteHasher struct {
+	hash hash.Hash
+}
+
+// NewRangeDeleteHasher constructs a new instance of RangeDeleteHasher
+func NewRangeDeleteHasher() *RangeDeleteHasher {
+	return &RangeDeleteHasher{sha256.New()}
+}
+
+// Add adds a key of the range and its version to the fingerprint
+func (h *RangeDeleteHasher) Add(key string, version *version.Height) {
+	h.hash.Write(proto.EncodeVarint(uint64(len(key))))
+	h.hash.Write([]byte(key))
+	h.hash.Write(version.ToBytes())
+}
+
+// Sum returns the fingerprint of the keys added so far
+func (h *RangeDeleteHasher) Sum() []byte {
+	return h.hash.Sum(nil)
+}
+
+// InRange returns true if the key is in the range [startKey, endKey), an empty endKey
+// denoting the end of the namespace
+func InRange(key, startKey, endKey string) bool {
+	return key >= startKey && (endKey == "" || key < endKey)
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package rwsetutil
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("rwsetutil")
@@ -38,6 +41,8 @@ type nsPubRwBuilder struct {
 	readMap           map[string]*kvrwset.KVRead //for mvcc validation
 	writeMap          map[string]*kvrwset.KVWrite
 	metadataWriteMap  map[string]*kvrwset.KVMetadataWrite
+	incrementMap      map[string]*kvrwset.KVIncrement
+	rangeDeletes      []*kvrwset.KVRangeDelete
 	rangeQueriesMap   map[rangeQueryKey]*kvrwset.RangeQueryInfo //for phantom read validation
 	rangeQueriesKeys  []rangeQueryKey
 	collHashRwBuilder map[string]*collHashRwBuilder
@@ -83,6 +88,38 @@ func (b *RWSetBuilder) AddToReadSet(ns string, key string, version *version.Heig
 func (b *RWSetBuilder) AddToWriteSet(ns string, key string, value []byte) {
 	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
 	nsPubRwBuilder.writeMap[key] = newKVWrite(key, value)
+	// the write overrides the increments of the key performed earlier by the transaction
+	delete(nsPubRwBuilder.incrementMap, key)
+}
+
+// AddToIncrementSet adds a delta to the counter stored at a key. The deltas added to a key are
+// accumulated into a single increment, unless the key is in the write-set or in a range deleted
+// earlier by the transaction, in which case the delta is added to the value of the write
+func (b *RWSetBuilder) AddToIncrementSet(ns string, key string, delta int64) error {
+	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
+	kvWrite, ok := nsPubRwBuilder.writeMap[key]
+	if !ok && nsPubRwBuilder.isRangeDeleted(key) {
+		kvWrite, ok = newKVWrite(key, nil), true
+	}
+	if ok {
+		value, err := IncrementCounter(kvWrite.Value, delta)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to increment key [%s] in namespace [%s]", key, ns))
+		}
+		nsPubRwBuilder.writeMap[key] = newKVWrite(key, value)
+		return nil
+	}
+	kvIncrement, ok := nsPubRwBuilder.incrementMap[key]
+	if !ok {
+		nsPubRwBuilder.incrementMap[key] = &kvrwset.KVIncrement{Key: key, Delta: delta}
+		return nil
+	}
+	accumulated, ok := addDeltas(kvIncrement.Delta, delta)
+	if !ok {
+		return errors.Errorf("failed to increment key [%s] in namespace [%s]: accumulated delta overflows", key, ns)
+	}
+	kvIncrement.Delta = accumulated
+	return nil
 }
 
 // AddToMetadataWriteSet adds a metadata to a key in the write-set
@@ -92,6 +129,30 @@ func (b *RWSetBuilder) AddToMetadataWriteSet(ns, key string, metadata map[string
 		metadataWriteMap[key] = mapToMetadataWrite(key, metadata)
 }
 
+// AddToRangeDeleteSet adds the deletion of the keys of the range [startKey, endKey) along with the
+// fingerprint of the keys found in the range (see RangeDeleteHasher). The range delete overrides
+// the writes, metadata writes and increments of the keys of the range performed earlier by the transaction
+func (b *RWSetBuilder) AddToRangeDeleteSet(ns string, startKey string, endKey string, keysHash []byte) {
+	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
+	for key := range nsPubRwBuilder.writeMap {
+		if InRange(key, startKey, endKey) {
+			delete(nsPubRwBuilder.writeMap, key)
+		}
+	}
+	for key := range nsPubRwBuilder.metadataWriteMap {
+		if InRange(key, startKey, endKey) {
+			delete(nsPubRwBuilder.metadataWriteMap, key)
+		}
+	}
+	for key := range nsPubRwBuilder.incrementMap {
+		if InRange(key, startKey, endKey) {
+			delete(nsPubRwBuilder.incrementMap, key)
+		}
+	}
+	nsPubRwBuilder.rangeDeletes = append(nsPubRwBuilder.rangeDeletes,
+		&kvrwset.KVRangeDelete{StartKey: startKey, EndKey: endKey, KeysHash: keysHash})
+}
+
 // AddToRangeQuerySet adds a range query info for performing phantom read validation
 func (b *RWSetBuilder) AddToRangeQuerySet(ns string, rqi *kvrwset.RangeQueryInfo) {
 	nsPubRwBuilder := b.getOrCreateNsPubRwBuilder(ns)
@@ -197,6 +258,7 @@ func (b *nsPubRwBuilder) build() *NsRwSet {
 	var readSet []*kvrwset.KVRead
 	var writeSet []*kvrwset.KVWrite
 	var metadataWriteSet []*kvrwset.KVMetadataWrite
+	var incrementSet []*kvrwset.KVIncrement
 	var rangeQueriesInfo []*kvrwset.RangeQueryInfo
 	var collHashedRwSet []*CollHashedRwSet
 	//add read set
@@ -204,6 +266,7 @@ func (b *nsPubRwBuilder) build() *NsRwSet {
 	//add write set
 	util.GetValuesBySortedKeys(&(b.writeMap), &writeSet)
 	util.GetValuesBySortedKeys(&(b.metadataWriteMap), &metadataWriteSet)
+	util.GetValuesBySortedKeys(&(b.incrementMap), &incrementSet)
 	//add range query info
 	for _, key := range b.rangeQueriesKeys {
 		rangeQueriesInfo = append(rangeQueriesInfo, b.rangeQueriesMap[key])
@@ -220,12 +283,23 @@ func (b *nsPubRwBuilder) build() *NsRwSet {
 			Reads:            readSet,
 			Writes:           writeSet,
 			MetadataWrites:   metadataWriteSet,
+			Increments:       incrementSet,
+			RangeDeletes:     b.rangeDeletes,
 			RangeQueriesInfo: rangeQueriesInfo,
 		},
 		CollHashedRwSets: collHashedRwSet,
 	}
 }
 
+func (b *nsPubRwBuilder) isRangeDeleted(key string) bool {
+	for _, rangeDelete := range b.rangeDeletes {
+		if InRange(key, rangeDelete.StartKey, rangeDelete.EndKey) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *nsPvtRwBuilder) build() *NsPvtRwSet {
 	sortedCollBuilders := []*collPvtRwBuilder{}
 	util.GetValuesBySortedKeys(&(b.collPvtRwBuilders), &sortedCollBuilders)
@@ -314,6 +388,8 @@ func newNsPubRwBuilder(namespace string) *nsPubRwBuilder {
 		make(map[string]*kvrwset.KVRead),
 		make(map[string]*kvrwset.KVWrite),
 		make(map[string]*kvrwset.KVMetadataWrite),
+		make(map[string]*kvrwset.KVIncrement),
+		nil,
 		make(map[rangeQueryKey]*kvrwset.RangeQueryInfo),
 		nil,
 		make(map[string]*collHashRwBuilder),
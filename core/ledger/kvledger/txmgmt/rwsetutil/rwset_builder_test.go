@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package rwsetutil
 
 import (
+	"math"
 	"os"
 	"testing"
 
@@ -357,3 +358,110 @@ func serializeTestProtoMsg(t *testing.T, protoMsg proto.Message) []byte {
 	assert.NoError(t, err)
 	return msgBytes
 }
+
+func TestTxSimulationResultWithIncrements(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "counter1", 5))
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "counter1", -2))
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "counter2", 1))
+	// the increment of a key in the write-set updates the value of the write
+	rwSetBuilder.AddToWriteSet("ns1", "key1", []byte("10"))
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "key1", 3))
+	// the write of a key overrides its increments
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "key2", 3))
+	rwSetBuilder.AddToWriteSet("ns1", "key2", []byte("value2"))
+
+	actualSimRes, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	expectedKVRWSet := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			newKVWrite("key1", []byte("13")),
+			newKVWrite("key2", []byte("value2")),
+		},
+		Increments: []*kvrwset.KVIncrement{
+			{Key: "counter1", Delta: 3},
+			{Key: "counter2", Delta: 1},
+		},
+	}
+	expectedSimRes := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{
+			{Namespace: "ns1", Rwset: serializeTestProtoMsg(t, expectedKVRWSet)},
+		},
+	}
+	assert.Equal(t, expectedSimRes, actualSimRes.PubSimulationResults)
+}
+
+func TestIncrementSetErrors(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	rwSetBuilder.AddToWriteSet("ns1", "key1", []byte("value1"))
+	err := rwSetBuilder.AddToIncrementSet("ns1", "key1", 1)
+	assert.EqualError(t, err, "failed to increment key [key1] in namespace [ns1]: value [value1] is not a counter")
+
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "key2", math.MaxInt64))
+	err = rwSetBuilder.AddToIncrementSet("ns1", "key2", 1)
+	assert.EqualError(t, err, "failed to increment key [key2] in namespace [ns1]: accumulated delta overflows")
+}
+
+func TestTxSimulationResultWithRangeDeletes(t *testing.T) {
+	rwSetBuilder := NewRWSetBuilder()
+	// the range delete overrides the writes, metadata writes and increments of the keys of the range
+	rwSetBuilder.AddToWriteSet("ns1", "key1", []byte("value1"))
+	rwSetBuilder.AddToWriteSet("ns1", "key5", []byte("value5"))
+	rwSetBuilder.AddToMetadataWriteSet("ns1", "key2", map[string][]byte{"metadata": []byte("value")})
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "key3", 1))
+	rwSetBuilder.AddToRangeDeleteSet("ns1", "key1", "key5", []byte("keysHash"))
+	// the write of a key of the range after the range delete is kept
+	rwSetBuilder.AddToWriteSet("ns1", "key2", []byte("value2"))
+	// the increment of a key of the range after the range delete increments a deleted counter
+	assert.NoError(t, rwSetBuilder.AddToIncrementSet("ns1", "key4", 2))
+	rwSetBuilder.AddToRangeDeleteSet("ns1", "key9", "", []byte("keysHash2"))
+
+	actualSimRes, err := rwSetBuilder.GetTxSimulationResults()
+	assert.NoError(t, err)
+
+	expectedKVRWSet := &kvrwset.KVRWSet{
+		Writes: []*kvrwset.KVWrite{
+			newKVWrite("key2", []byte("value2")),
+			newKVWrite("key4", []byte("2")),
+			newKVWrite("key5", []byte("value5")),
+		},
+		RangeDeletes: []*kvrwset.KVRangeDelete{
+			{StartKey: "key1", EndKey: "key5", KeysHash: []byte("keysHash")},
+			{StartKey: "key9", EndKey: "", KeysHash: []byte("keysHash2")},
+		},
+	}
+	expectedSimRes := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{
+			{Namespace: "ns1", Rwset: serializeTestProtoMsg(t, expectedKVRWSet)},
+		},
+	}
+	assert.Equal(t, expectedSimRes, actualSimRes.PubSimulationResults)
+}
+
+func TestRangeDeleteHasher(t *testing.T) {
+	hasher := NewRangeDeleteHasher()
+	hasher.Add("key1", version.NewHeight(1, 1))
+	hasher.Add("key2", version.NewHeight(1, 2))
+	keysHash := hasher.Sum()
+
+	// the fingerprint depends on the keys of the range and on their versions
+	for _, keys := range [][]string{{"key1"}, {"key1", "key3"}, {"key", "1key2"}} {
+		hasher = NewRangeDeleteHasher()
+		for _, key := range keys {
+			hasher.Add(key, version.NewHeight(1, 1))
+		}
+		assert.NotEqual(t, keysHash, hasher.Sum())
+	}
+	hasher = NewRangeDeleteHasher()
+	hasher.Add("key1", version.NewHeight(1, 1))
+	hasher.Add("key2", version.NewHeight(2, 0))
+	assert.NotEqual(t, keysHash, hasher.Sum())
+
+	hasher = NewRangeDeleteHasher()
+	hasher.Add("key1", version.NewHeight(1, 1))
+	hasher.Add("key2", version.NewHeight(1, 2))
+	assert.Equal(t, keysHash, hasher.Sum())
+}
@@ -0,0 +1,197 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+const (
+	// cacheEntryOverhead approximates the memory consumed by an entry in addition to its key and value
+	cacheEntryOverhead = 128
+	// maxEntryFraction limits the size of a single entry to a fraction of the budget of the cache,
+	// so that a few large values do not evict all the other entries
+	maxEntryFraction = 8
+)
+
+// Cache is an in-memory read-through cache of the committed state that a VersionedDB implementation
+// can maintain in front of the underlying db. A single instance is shared by all the channels and the
+// entries are keyed by the channel, namespace, and key. The total size of the entries is limited to the
+// configured budget and the least recently used entries are evicted first.
+// The VersionedDB is expected to add an entry only for the value that it reads from the db and to invoke
+// the function `UpdateStates` with every batch that it commits, so that a cached entry always carries the
+// latest committed version of the key
+type Cache struct {
+	maxBytes int
+	stats    *cacheStats
+
+	lock      sync.Mutex
+	entries   map[cacheKey]*list.Element
+	lru       *list.List
+	usedBytes int
+}
+
+type cacheKey struct {
+	chainID string
+	ns      string
+	key     string
+}
+
+type cacheEntry struct {
+	key  cacheKey
+	vv   *VersionedValue
+	size int
+}
+
+// NewCache constructs a Cache with the given budget (in bytes). A nil Cache is returned
+// if the budget is zero, which the callers treat as a disabled cache
+func NewCache(maxBytes int, metricsProvider metrics.Provider) *Cache {
+	if maxBytes <= 0 {
+		return nil
+	}
+	return &Cache{
+		maxBytes: maxBytes,
+		stats:    newCacheStats(metricsProvider),
+		entries:  make(map[cacheKey]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// GetState returns the cached value of the given key and marks the entry as the most recently used one.
+// The second return value is false if the key is not present in the cache
+func (c *Cache) GetState(chainID, ns, key string) (*VersionedValue, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	element, ok := c.entries[cacheKey{chainID, ns, key}]
+	if !ok {
+		c.stats.misses.With("channel", chainID).Add(1)
+		return nil, false
+	}
+	c.stats.hits.With("channel", chainID).Add(1)
+	c.lru.MoveToFront(element)
+	return element.Value.(*cacheEntry).vv, true
+}
+
+// PutState adds the given value to the cache. The value is ignored if the cache already holds
+// a higher version of the key or if the value is too large for the budget of the cache
+func (c *Cache) PutState(chainID, ns, key string, vv *VersionedValue) {
+	if vv == nil || vv.IsDelete() {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	k := cacheKey{chainID, ns, key}
+	if element, ok := c.entries[k]; ok {
+		cachedVersion := element.Value.(*cacheEntry).vv.Version
+		if cachedVersion != nil && vv.Version != nil && cachedVersion.Compare(vv.Version) > 0 {
+			return
+		}
+	}
+	c.put(k, vv)
+}
+
+// UpdateStates brings the cached entries of the channel in line with the given batch of committed updates.
+// The entries of the updated keys carry the new values and the entries of the deleted keys are removed.
+// The keys that are not present in the cache are not added
+func (c *Cache) UpdateStates(chainID string, batch *UpdateBatch) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, ns := range batch.GetUpdatedNamespaces() {
+		for key, vv := range batch.GetUpdates(ns) {
+			k := cacheKey{chainID, ns, key}
+			element, ok := c.entries[k]
+			if !ok {
+				continue
+			}
+			if vv.IsDelete() {
+				c.remove(element)
+				continue
+			}
+			c.put(k, vv)
+		}
+	}
+	c.stats.size.Set(float64(c.usedBytes))
+}
+
+// put adds or replaces the entry and evicts the least recently used entries if the budget is exceeded.
+// The caller is expected to hold the lock
+func (c *Cache) put(k cacheKey, vv *VersionedValue) {
+	if element, ok := c.entries[k]; ok {
+		c.remove(element)
+	}
+	size := len(k.chainID) + len(k.ns) + len(k.key) + len(vv.Value) + len(vv.Metadata) + cacheEntryOverhead
+	if size > c.maxBytes/maxEntryFraction {
+		return
+	}
+	c.entries[k] = c.lru.PushFront(&cacheEntry{key: k, vv: vv, size: size})
+	c.usedBytes += size
+	for c.usedBytes > c.maxBytes {
+		c.remove(c.lru.Back())
+		c.stats.evictions.Add(1)
+	}
+	c.stats.size.Set(float64(c.usedBytes))
+}
+
+func (c *Cache) remove(element *list.Element) {
+	entry := c.lru.Remove(element).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.usedBytes -= entry.size
+}
+
+var (
+	cacheHitsOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "hits",
+		Help:         "Number of reads of the state served by the state cache.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	cacheMissesOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "misses",
+		Help:         "Number of reads of the state not found in the state cache.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	cacheEvictionsOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "evictions",
+		Help:         "Number of entries evicted from the state cache to stay within its size.",
+		StatsdFormat: "%{#fqname}",
+	}
+
+	cacheSizeOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "statedb_cache",
+		Name:         "size_bytes",
+		Help:         "Approximate memory in bytes consumed by the entries in the state cache.",
+		StatsdFormat: "%{#fqname}",
+	}
+)
+
+type cacheStats struct {
+	hits      metrics.Counter
+	misses    metrics.Counter
+	evictions metrics.Counter
+	size      metrics.Gauge
+}
+
+func newCacheStats(metricsProvider metrics.Provider) *cacheStats {
+	return &cacheStats{
+		hits:      metricsProvider.NewCounter(cacheHitsOpts),
+		misses:    metricsProvider.NewCounter(cacheMissesOpts),
+		evictions: metricsProvider.NewCounter(cacheEvictionsOpts),
+		size:      metricsProvider.NewGauge(cacheSizeOpts),
+	}
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statedb
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDisabled(t *testing.T) {
+	assert.Nil(t, NewCache(0, &disabled.Provider{}))
+}
+
+func TestCacheGetAndPut(t *testing.T) {
+	cache := NewCache(10000, &disabled.Provider{})
+	_, ok := cache.GetState("ch1", "ns1", "key1")
+	assert.False(t, ok)
+
+	vv1 := &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}
+	cache.PutState("ch1", "ns1", "key1", vv1)
+	vv, ok := cache.GetState("ch1", "ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, vv1, vv)
+
+	// entries are specific to a channel and namespace
+	_, ok = cache.GetState("ch2", "ns1", "key1")
+	assert.False(t, ok)
+	_, ok = cache.GetState("ch1", "ns2", "key1")
+	assert.False(t, ok)
+
+	// a lower version does not replace the cached one
+	cache.PutState("ch1", "ns1", "key1", &VersionedValue{Value: []byte("value0"), Version: version.NewHeight(0, 1)})
+	vv, _ = cache.GetState("ch1", "ns1", "key1")
+	assert.Equal(t, vv1, vv)
+
+	// deletes and values larger than the allowed entry size are not cached
+	cache.PutState("ch1", "ns1", "key2", &VersionedValue{Value: nil, Version: version.NewHeight(1, 2)})
+	cache.PutState("ch1", "ns1", "key3", &VersionedValue{Value: bytes.Repeat([]byte("a"), 2000), Version: version.NewHeight(1, 3)})
+	_, ok = cache.GetState("ch1", "ns1", "key2")
+	assert.False(t, ok)
+	_, ok = cache.GetState("ch1", "ns1", "key3")
+	assert.False(t, ok)
+}
+
+func TestCacheEviction(t *testing.T) {
+	fakeProvider := &metricsfakes.Provider{}
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	fakeProvider.NewCounterReturns(fakeCounter)
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeProvider.NewGaugeReturns(fakeGauge)
+
+	// each entry below consumes 150 bytes, which allows eight entries within the budget
+	cache := NewCache(1200, fakeProvider)
+	value := bytes.Repeat([]byte("a"), 12)
+	for i := 1; i <= 8; i++ {
+		cache.PutState("ch1", "ns1", fmt.Sprintf("key%d", i), &VersionedValue{Value: value, Version: version.NewHeight(1, 1)})
+	}
+	assert.Equal(t, 1200, cache.usedBytes)
+	assert.Equal(t, float64(1200), fakeGauge.SetArgsForCall(fakeGauge.SetCallCount()-1))
+	assert.Equal(t, 0, fakeCounter.AddCallCount())
+
+	// reading key1 makes key2 the least recently used entry
+	_, ok := cache.GetState("ch1", "ns1", "key1")
+	assert.True(t, ok)
+	cache.PutState("ch1", "ns1", "key9", &VersionedValue{Value: value, Version: version.NewHeight(1, 1)})
+	_, ok = cache.GetState("ch1", "ns1", "key2")
+	assert.False(t, ok)
+	for _, key := range []string{"key1", "key3", "key9"} {
+		_, ok := cache.GetState("ch1", "ns1", key)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 1200, cache.usedBytes)
+	assert.Len(t, cache.entries, 8)
+}
+
+func TestCacheUpdateStates(t *testing.T) {
+	cache := NewCache(10000, &disabled.Provider{})
+	cache.PutState("ch1", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+	cache.PutState("ch1", "ns1", "key2", &VersionedValue{Value: []byte("value2"), Version: version.NewHeight(1, 2)})
+	cache.PutState("ch2", "ns1", "key1", &VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)})
+
+	batch := NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1_new"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	batch.Put("ns1", "key3", []byte("value3"), version.NewHeight(2, 3))
+	cache.UpdateStates("ch1", batch)
+
+	vv, ok := cache.GetState("ch1", "ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, &VersionedValue{Value: []byte("value1_new"), Version: version.NewHeight(2, 1)}, vv)
+	_, ok = cache.GetState("ch1", "ns1", "key2")
+	assert.False(t, ok)
+	// keys not already in the cache are not added
+	_, ok = cache.GetState("ch1", "ns1", "key3")
+	assert.False(t, ok)
+	// the other channels are not affected
+	vv, ok = cache.GetState("ch2", "ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value1"), vv.Value)
+}
@@ -20,6 +20,7 @@ type nsCommittersBuilder struct {
 	updates         map[string]*statedb.VersionedValue
 	db              *couchdb.CouchDatabase
 	revisions       map[string]string
+	chunksBuilder   *chunkCommittersBuilder
 	subNsCommitters []batch
 }
 
@@ -29,49 +30,75 @@ type subNsCommitter struct {
 	batchUpdateMap map[string]*batchableDocument
 }
 
-// buildCommitters build the batches of type subNsCommitter. This functions processes different namespaces in parallel
-func (vdb *VersionedDB) buildCommitters(updates *statedb.UpdateBatch) ([]batch, error) {
+// buildCommitters build the batches of type subNsCommitter. This functions processes different namespaces in parallel.
+// In addition to the batches, the namespaces of the chunk databases that the batches update are returned
+func (vdb *VersionedDB) buildCommitters(updates *statedb.UpdateBatch) ([]batch, []string, error) {
 	namespaces := updates.GetUpdatedNamespaces()
 	var nsCommitterBuilder []batch
 	for _, ns := range namespaces {
 		nsUpdates := updates.GetUpdates(ns)
 		db, err := vdb.getNamespaceDBHandle(ns)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		nsRevs := vdb.committedDataCache.revs[ns]
 		if nsRevs == nil {
 			nsRevs = make(nsRevisions)
 		}
+		nsChunks := vdb.committedDataCache.chunks[ns]
+		if nsChunks == nil {
+			nsChunks = make(nsChunkCounts)
+		}
 		// for each namespace, construct one builder with the corresponding couchdb handle and couch revisions
 		// that are already loaded into cache (during validation phase)
-		nsCommitterBuilder = append(nsCommitterBuilder, &nsCommittersBuilder{updates: nsUpdates, db: db, revisions: nsRevs})
+		nsCommitterBuilder = append(nsCommitterBuilder, &nsCommittersBuilder{
+			updates:       nsUpdates,
+			db:            db,
+			revisions:     nsRevs,
+			chunksBuilder: &chunkCommittersBuilder{vdb: vdb, ns: ns, chunkSize: valueChunkSize, chunkCounts: nsChunks},
+		})
 	}
 	if err := executeBatches(nsCommitterBuilder); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// accumulate results across namespaces (one or more batches of `subNsCommitter` for a namespace from each builder)
 	var combinedSubNsCommitters []batch
+	var chunkNamespaces []string
 	for _, b := range nsCommitterBuilder {
-		combinedSubNsCommitters = append(combinedSubNsCommitters, b.(*nsCommittersBuilder).subNsCommitters...)
+		builder := b.(*nsCommittersBuilder)
+		combinedSubNsCommitters = append(combinedSubNsCommitters, builder.subNsCommitters...)
+		if builder.chunksBuilder.chunkDB != nil {
+			chunkNamespaces = append(chunkNamespaces, chunkNamespace(builder.chunksBuilder.ns))
+		}
 	}
-	return combinedSubNsCommitters, nil
+	return combinedSubNsCommitters, chunkNamespaces, nil
 }
 
 // execute implements the function in `batch` interface. This function builds one or more `subNsCommitter`s that
 // cover the updates for a namespace
 func (builder *nsCommittersBuilder) execute() error {
-	if err := addRevisionsForMissingKeys(builder.revisions, builder.db, builder.updates); err != nil {
+	if err := addRevisionsForMissingKeys(builder.revisions, builder.chunksBuilder.chunkCounts, builder.db, builder.updates); err != nil {
+		return err
+	}
+	var kvs []*keyValue
+	for key, vv := range builder.updates {
+		kvs = append(kvs, &keyValue{key: key, VersionedValue: vv})
+	}
+	chunkCommitters, err := builder.chunksBuilder.build(kvs)
+	if err != nil {
 		return err
 	}
+	builder.subNsCommitters = append(builder.subNsCommitters, chunkCommitters...)
+
 	maxBacthSize := ledgerconfig.GetMaxBatchUpdateSize()
 	batchUpdateMap := make(map[string]*batchableDocument)
-	for key, vv := range builder.updates {
-		couchDoc, err := keyValToCouchDoc(&keyValue{key: key, VersionedValue: vv}, builder.revisions[key])
+	for _, kv := range kvs {
+		key := kv.key
+		couchDoc, err := keyValToCouchDoc(kv, builder.revisions[key])
 		if err != nil {
 			return err
 		}
-		batchUpdateMap[key] = &batchableDocument{CouchDoc: *couchDoc, Deleted: vv.Value == nil}
+		batchUpdateMap[key] = &batchableDocument{CouchDoc: *couchDoc, Deleted: kv.Value == nil}
 		if len(batchUpdateMap) == maxBacthSize {
 			builder.subNsCommitters = append(builder.subNsCommitters, &subNsCommitter{builder.db, batchUpdateMap})
 			batchUpdateMap = make(map[string]*batchableDocument)
@@ -167,7 +194,7 @@ func (f *nsFlusher) execute() error {
 	return nil
 }
 
-func addRevisionsForMissingKeys(revisions map[string]string, db *couchdb.CouchDatabase, nsUpdates map[string]*statedb.VersionedValue) error {
+func addRevisionsForMissingKeys(revisions map[string]string, chunkCounts map[string]int, db *couchdb.CouchDatabase, nsUpdates map[string]*statedb.VersionedValue) error {
 	var missingKeys []string
 	for key := range nsUpdates {
 		_, ok := revisions[key]
@@ -183,6 +210,13 @@ func addRevisionsForMissingKeys(revisions map[string]string, db *couchdb.CouchDa
 	for _, metadata := range retrievedMetadata {
 		revisions[metadata.ID] = metadata.Rev
 	}
+	retrievedChunkCounts, err := getChunkCounts(retrievedMetadata)
+	if err != nil {
+		return err
+	}
+	for key, count := range retrievedChunkCounts {
+		chunkCounts[key] = count
+	}
 	return nil
 }
 
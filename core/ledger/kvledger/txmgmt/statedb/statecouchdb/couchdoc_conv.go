@@ -7,6 +7,7 @@ package statecouchdb
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"strings"
 	"unicode/utf8"
@@ -23,11 +24,19 @@ const (
 	revField      = "_rev"
 	versionField  = "~version"
 	deletedField  = "_deleted"
+	binaryField   = "~valueBytes"
+	chunksField   = "~chunks"
 )
 
+// reservedFields are the fields, in addition to the fields that begin with "_",
+// that cannot be present in a JSON value
+var reservedFields = []string{versionField, binaryField, chunksField}
+
 type keyValue struct {
 	key string
 	*statedb.VersionedValue
+	// chunks is set if the value is stored in chunks (see value_chunks.go)
+	chunks *chunksInfo
 }
 
 type jsonValue map[string]interface{}
@@ -47,13 +56,22 @@ func castToJSON(b []byte) (jsonValue, error) {
 
 func (v jsonValue) checkReservedFieldsNotPresent() error {
 	for fieldName := range v {
-		if fieldName == versionField || strings.HasPrefix(fieldName, "_") {
+		if isReservedField(fieldName) || strings.HasPrefix(fieldName, "_") {
 			return errors.Errorf("field [%s] is not valid for the CouchDB state database", fieldName)
 		}
 	}
 	return nil
 }
 
+func isReservedField(fieldName string) bool {
+	for _, reservedField := range reservedFields {
+		if fieldName == reservedField {
+			return true
+		}
+	}
+	return false
+}
+
 func (v jsonValue) removeRevField() {
 	delete(v, revField)
 }
@@ -91,24 +109,44 @@ func couchDocToKeyValue(doc *couchdb.CouchDoc) (*keyValue, error) {
 	delete(jsonResult, revField)
 	delete(jsonResult, versionField)
 
-	// handle binary or json data
-	if doc.Attachments != nil { // binary attachment
-		// get binary data from attachment
+	// handle binary, chunked, or json data
+	var chunks *chunksInfo
+	binaryValue, isBinary := jsonResult[binaryField]
+	chunksValue, isChunked := jsonResult[chunksField]
+	switch {
+	case doc.Attachments != nil:
+		// binary attachment, as stored by the earlier versions
 		for _, attachment := range doc.Attachments {
 			if attachment.Name == binaryWrapper {
 				returnValue = attachment.AttachmentBytes
 			}
 		}
-	} else {
+	case isBinary:
+		encodedValue, ok := binaryValue.(string)
+		if !ok {
+			return nil, errors.Errorf("field %s of key [%s] is not a string", binaryField, key)
+		}
+		if returnValue, err = base64.StdEncoding.DecodeString(encodedValue); err != nil {
+			return nil, errors.Wrapf(err, "error decoding field %s of key [%s]", binaryField, key)
+		}
+	case isChunked:
+		// the value is to be populated from the chunks by the caller
+		if chunks, err = decodeChunksInfo(chunksValue); err != nil {
+			return nil, err
+		}
+	default:
 		// marshal the returned JSON data.
 		if returnValue, err = json.Marshal(jsonResult); err != nil {
 			return nil, err
 		}
 	}
-	return &keyValue{key, &statedb.VersionedValue{
-		Value:    returnValue,
-		Metadata: returnMetadata,
-		Version:  returnVersion},
+	return &keyValue{
+		key: key,
+		VersionedValue: &statedb.VersionedValue{
+			Value:    returnValue,
+			Metadata: returnMetadata,
+			Version:  returnVersion},
+		chunks: chunks,
 	}, nil
 }
 
@@ -117,7 +155,8 @@ func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error)
 	const (
 		kvTypeDelete = iota
 		kvTypeJSON
-		kvTypeAttachment
+		kvTypeBinary
+		kvTypeChunked
 	)
 	key, value, metadata, version := kv.key, kv.Value, kv.Metadata, kv.Version
 	jsonMap := make(jsonValue)
@@ -126,6 +165,22 @@ func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error)
 	switch {
 	case value == nil:
 		kvtype = kvTypeDelete
+	// a value stored in chunks is not stored within the document (the chunks are written
+	// to the chunks database by the caller), only the queryable fields of a JSON value are
+	case kv.chunks != nil:
+		kvtype = kvTypeChunked
+		if json.Unmarshal(value, &jsonMap) != nil || jsonMap == nil {
+			jsonMap = make(jsonValue)
+			break
+		}
+		if err := jsonMap.checkReservedFieldsNotPresent(); err != nil {
+			return nil, err
+		}
+		fields, err := queryableFields(jsonMap, valueChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		jsonMap = fields
 	// check for the case where the jsonMap is nil,  this will indicate
 	// a special case for the Unmarshal that results in a valid JSON returning nil
 	case json.Unmarshal(value, &jsonMap) == nil && jsonMap != nil:
@@ -138,7 +193,7 @@ func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error)
 		if jsonMap == nil {
 			jsonMap = make(jsonValue)
 		}
-		kvtype = kvTypeAttachment
+		kvtype = kvTypeBinary
 	}
 
 	verAndMetadata, err := encodeVersionAndMetadata(version, metadata)
@@ -151,23 +206,19 @@ func keyValToCouchDoc(kv *keyValue, revision string) (*couchdb.CouchDoc, error)
 	if revision != "" {
 		jsonMap[revField] = revision
 	}
-	if kvtype == kvTypeDelete {
+	switch {
+	case kvtype == kvTypeDelete:
 		jsonMap[deletedField] = true
+	case kvtype == kvTypeChunked:
+		jsonMap[chunksField] = kv.chunks
+	case kvtype == kvTypeBinary:
+		jsonMap[binaryField] = base64.StdEncoding.EncodeToString(value)
 	}
 	jsonBytes, err := jsonMap.toBytes()
 	if err != nil {
 		return nil, err
 	}
-	couchDoc := &couchdb.CouchDoc{JSONValue: jsonBytes}
-	if kvtype == kvTypeAttachment {
-		attachment := &couchdb.AttachmentInfo{}
-		attachment.AttachmentBytes = value
-		attachment.ContentType = "application/octet-stream"
-		attachment.Name = binaryWrapper
-		attachments := append([]*couchdb.AttachmentInfo{}, attachment)
-		couchDoc.Attachments = attachments
-	}
-	return couchDoc, nil
+	return &couchdb.CouchDoc{JSONValue: jsonBytes}, nil
 }
 
 // couchSavepointData data for couchdb
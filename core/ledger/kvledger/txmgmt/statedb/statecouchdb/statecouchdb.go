@@ -10,6 +10,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/flogging"
@@ -37,6 +38,7 @@ type VersionedDBProvider struct {
 	databases     map[string]*VersionedDB
 	mux           sync.Mutex
 	openCounts    uint64
+	cache         *statedb.Cache
 }
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
@@ -48,7 +50,8 @@ func NewVersionedDBProvider(metricsProvider metrics.Provider) (*VersionedDBProvi
 	if err != nil {
 		return nil, err
 	}
-	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0}, nil
+	cache := statedb.NewCache(ledgerconfig.GetStateCacheSize()*1024*1024, metricsProvider)
+	return &VersionedDBProvider{couchInstance, make(map[string]*VersionedDB), sync.Mutex{}, 0, cache}, nil
 }
 
 // GetDBHandle gets the handle to a named database
@@ -58,7 +61,7 @@ func (provider *VersionedDBProvider) GetDBHandle(dbName string) (statedb.Version
 	vdb := provider.databases[dbName]
 	if vdb == nil {
 		var err error
-		vdb, err = newVersionedDB(provider.couchInstance, dbName)
+		vdb, err = newVersionedDB(provider.couchInstance, dbName, provider.cache)
 		if err != nil {
 			return nil, err
 		}
@@ -77,6 +80,51 @@ func (provider *VersionedDBProvider) HealthCheck(ctx context.Context) error {
 	return provider.couchInstance.HealthCheck(ctx)
 }
 
+// DropChannelDBs drops all the databases that are maintained for the given channel. The namespace
+// databases are dropped first and the metadata database (that holds the savepoint) is dropped at the end
+func DropChannelDBs(metricsProvider metrics.Provider, chainName string) error {
+	couchDBDef := couchdb.GetCouchDBDefinition()
+	couchInstance, err := couchdb.CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB, metricsProvider)
+	if err != nil {
+		return err
+	}
+	dbNames, err := couchInstance.RetrieveApplicationDBNames()
+	if err != nil {
+		return err
+	}
+	metadataDBName := couchdb.ConstructMetadataDBName(chainName)
+	metadataDBExists := false
+	for _, dbName := range dbNames {
+		if dbName == metadataDBName {
+			metadataDBExists = true
+			continue
+		}
+		// the namespace databases are named as <chainName>_<namespace>. The names that are truncated
+		// because of the length limit (see function `couchdb.ConstructNamespaceDBName`) also begin with
+		// the chain name unless the chain name itself is longer than the allowed length
+		if !strings.HasPrefix(dbName, chainName+"_") {
+			continue
+		}
+		if err := dropDB(couchInstance, dbName); err != nil {
+			return err
+		}
+	}
+	if !metadataDBExists {
+		return nil
+	}
+	return dropDB(couchInstance, metadataDBName)
+}
+
+func dropDB(couchInstance *couchdb.CouchInstance, dbName string) error {
+	logger.Infof("Dropping CouchDB database [%s]", dbName)
+	db := &couchdb.CouchDatabase{CouchInstance: couchInstance, DBName: dbName}
+	if _, err := db.DropDatabase(); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("error while dropping database [%s]", dbName))
+	}
+	return nil
+}
+
 // VersionedDB implements VersionedDB interface
 type VersionedDB struct {
 	couchInstance      *couchdb.CouchInstance
@@ -87,6 +135,7 @@ type VersionedDB struct {
 	verCacheLock       sync.RWMutex
 	mux                sync.RWMutex
 	lsccStateCache     *lsccStateCache
+	cache              *statedb.Cache // Shared by all the channels. A nil value means that the cache is disabled
 }
 
 type lsccStateCache struct {
@@ -139,7 +188,7 @@ func (l *lsccStateCache) evictARandomEntry() {
 }
 
 // newVersionedDB constructs an instance of VersionedDB
-func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*VersionedDB, error) {
+func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string, cache *statedb.Cache) (*VersionedDB, error) {
 	// CreateCouchDatabase creates a CouchDB database object, as well as the underlying database if it does not exist
 	chainName := dbName
 	dbName = couchdb.ConstructMetadataDBName(dbName)
@@ -158,6 +207,7 @@ func newVersionedDB(couchInstance *couchdb.CouchInstance, dbName string) (*Versi
 		lsccStateCache: &lsccStateCache{
 			cache: make(map[string]*statedb.VersionedValue),
 		},
+		cache: cache,
 	}, nil
 }
 
@@ -237,6 +287,13 @@ func (vdb *VersionedDB) LoadCommittedVersions(keys []*statedb.CompositeKey) erro
 				committedDataCache.setVerAndRev(ns, keyMetadata.ID, version, keyMetadata.Rev)
 			}
 		}
+		nsChunkCounts, err := getChunkCounts(nsMetadata)
+		if err != nil {
+			return err
+		}
+		for key, count := range nsChunkCounts {
+			committedDataCache.setChunkCount(ns, key, count)
+		}
 	}
 	vdb.verCacheLock.Lock()
 	defer vdb.verCacheLock.Unlock()
@@ -289,6 +346,11 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 			return value, nil
 		}
 	}
+	if vdb.cache != nil {
+		if value, ok := vdb.cache.GetState(vdb.chainName, namespace, key); ok {
+			return value, nil
+		}
+	}
 
 	db, err := vdb.getNamespaceDBHandle(namespace)
 	if err != nil {
@@ -305,10 +367,16 @@ func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.Version
 	if err != nil {
 		return nil, err
 	}
+	if err := vdb.resolveChunks(namespace, kv); err != nil {
+		return nil, err
+	}
 
 	if namespace == "lscc" {
 		vdb.lsccStateCache.setState(key, kv.VersionedValue)
 	}
+	if vdb.cache != nil {
+		vdb.cache.PutState(vdb.chainName, namespace, key, kv.VersionedValue)
+	}
 
 	return kv.VersionedValue, nil
 }
@@ -361,7 +429,7 @@ func (vdb *VersionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 	if err != nil {
 		return nil, err
 	}
-	return newQueryScanner(namespace, db, "", internalQueryLimit, requestedLimit, "", startKey, endKey)
+	return newQueryScanner(vdb, namespace, db, "", internalQueryLimit, requestedLimit, "", startKey, endKey)
 }
 
 func (scanner *queryScanner) getNextStateRangeScanResults() error {
@@ -462,7 +530,7 @@ func (vdb *VersionedDB) ExecuteQueryWithMetadata(namespace, query string, metada
 	if err != nil {
 		return nil, err
 	}
-	return newQueryScanner(namespace, db, queryString, internalQueryLimit, requestedLimit, bookmark, "", "")
+	return newQueryScanner(vdb, namespace, db, queryString, internalQueryLimit, requestedLimit, bookmark, "", "")
 }
 
 // executeQueryWithBookmark executes a "paging" query with a bookmark, this method allows a
@@ -524,8 +592,9 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	// stage 1 - PrepareForUpdates - db transforms the given batch in the form of underlying db
 	// and keep it in memory
 	var updateBatches []batch
+	var chunkNamespaces []string
 	var err error
-	if updateBatches, err = vdb.buildCommitters(updates); err != nil {
+	if updateBatches, chunkNamespaces, err = vdb.buildCommitters(updates); err != nil {
 		return err
 	}
 	// stage 2 - ApplyUpdates push the changes to the DB
@@ -534,7 +603,7 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	}
 
 	// Stgae 3 - PostUpdateProcessing - flush and record savepoint.
-	namespaces := updates.GetUpdatedNamespaces()
+	namespaces := append(updates.GetUpdatedNamespaces(), chunkNamespaces...)
 	// Record a savepoint at a given height
 	if err = vdb.ensureFullCommitAndRecordSavepoint(height, namespaces); err != nil {
 		logger.Errorf("Error during recordSavepoint: %s", err.Error())
@@ -545,6 +614,9 @@ func (vdb *VersionedDB) ApplyUpdates(updates *statedb.UpdateBatch, height *versi
 	for key, value := range lsccUpdates {
 		vdb.lsccStateCache.updateState(key, value)
 	}
+	if vdb.cache != nil {
+		vdb.cache.UpdateStates(vdb.chainName, updates)
+	}
 
 	return nil
 }
@@ -648,9 +720,9 @@ func applyAdditionalQueryOptions(queryString string, queryLimit int32, queryBook
 	if fieldsJSONArray, ok := jsonQueryMap[jsonQueryFields]; ok {
 		switch fieldsJSONArray.(type) {
 		case []interface{}:
-			//Add the "_id", and "version" fields,  these are needed by default
+			//Add the "_id", "version", and binary value fields,  these are needed by default
 			jsonQueryMap[jsonQueryFields] = append(fieldsJSONArray.([]interface{}),
-				idField, versionField)
+				idField, versionField, binaryField, chunksField)
 		default:
 			return "", errors.New("fields definition must be an array")
 		}
@@ -673,6 +745,7 @@ func applyAdditionalQueryOptions(queryString string, queryLimit int32, queryBook
 }
 
 type queryScanner struct {
+	vdb             *VersionedDB
 	namespace       string
 	db              *couchdb.CouchDatabase
 	queryDefinition *queryDefinition
@@ -698,9 +771,9 @@ type resultsInfo struct {
 	results              []*couchdb.QueryResult
 }
 
-func newQueryScanner(namespace string, db *couchdb.CouchDatabase, query string, internalQueryLimit,
+func newQueryScanner(vdb *VersionedDB, namespace string, db *couchdb.CouchDatabase, query string, internalQueryLimit,
 	limit int32, bookmark, startKey, endKey string) (*queryScanner, error) {
-	scanner := &queryScanner{namespace, db, &queryDefinition{startKey, endKey, query, internalQueryLimit}, &paginationInfo{-1, limit, bookmark}, &resultsInfo{0, nil}}
+	scanner := &queryScanner{vdb, namespace, db, &queryDefinition{startKey, endKey, query, internalQueryLimit}, &paginationInfo{-1, limit, bookmark}, &resultsInfo{0, nil}}
 	var err error
 	// query is defined, then execute the query and return the records and bookmark
 	if scanner.queryDefinition.query != "" {
@@ -751,6 +824,9 @@ func (scanner *queryScanner) Next() (statedb.QueryResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := scanner.vdb.resolveChunks(scanner.namespace, kv); err != nil {
+		return nil, err
+	}
 	scanner.resultsInfo.totalRecordsReturned++
 	return &statedb.VersionedKV{
 		CompositeKey:   statedb.CompositeKey{Namespace: scanner.namespace, Key: key},
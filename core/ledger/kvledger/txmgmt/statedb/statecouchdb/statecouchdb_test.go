@@ -170,7 +170,7 @@ func TestUtilityFunctions(t *testing.T) {
 	assert.EqualError(t, db.ValidateKeyValue("", []byte("validValue")),
 		"invalid key. Empty string is not supported as a key by couchdb")
 
-	reservedFields := []string{"~version", "_id", "_test"}
+	reservedFields := []string{"~version", "~valueBytes", "~chunks", "_id", "_test"}
 
 	// ValidateKeyValue should return an error for a json value that contains one of the reserved fields
 	// at the top level
@@ -761,6 +761,49 @@ func TestLSCCStateCache(t *testing.T) {
 	assert.Equal(t, true, db.(*VersionedDB).lsccStateCache.isCacheFull())
 }
 
+func TestStateCache(t *testing.T) {
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 1)
+	defer viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testcache")
+	assert.NoError(t, err)
+	vdb := db.(*VersionedDB)
+	assert.NotNil(t, vdb.cache)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	// the commit does not add the keys to the cache
+	_, ok := vdb.cache.GetState("testcache", "ns1", "key1")
+	assert.False(t, ok)
+
+	// GetState() populates the cache
+	valueFromDB, err := db.GetState("ns1", "key1")
+	assert.NoError(t, err)
+	valueFromCache, ok := vdb.cache.GetState("testcache", "ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, valueFromDB, valueFromCache)
+	_, err = db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+
+	// the commit updates and removes the cached keys
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns1", "key1", []byte("new-value1"), version.NewHeight(2, 1))
+	batch.Delete("ns1", "key2", version.NewHeight(2, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 2)))
+	valueFromCache, ok = vdb.cache.GetState("testcache", "ns1", "key1")
+	assert.True(t, ok)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("new-value1"), Version: version.NewHeight(2, 1)}, valueFromCache)
+	_, ok = vdb.cache.GetState("testcache", "ns1", "key2")
+	assert.False(t, ok)
+	vv, err := db.GetState("ns1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+}
+
 func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	env := NewTestVDBEnv(t)
 	defer env.Cleanup()
@@ -806,17 +849,17 @@ func TestRangeScanWithCouchInternalDocsPresent(t *testing.T) {
 	// The Keys in db are in this order
 	// Key-1, Key-2, Key-3,_design/indexAssetNam, _design/indexAssetValue, key-1, key-2, key-3
 	// query different ranges and verify results
-	s, err := newQueryScanner("ns", couchDatabse, "", 3, 3, "", "", "")
+	s, err := newQueryScanner(nil, "ns", couchDatabse, "", 3, 3, "", "", "")
 	assert.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-1", "Key-2", "Key-3"})
 	assert.Equal(t, "key-1", s.queryDefinition.startKey)
 
-	s, err = newQueryScanner("ns", couchDatabse, "", 4, 4, "", "", "")
+	s, err = newQueryScanner(nil, "ns", couchDatabse, "", 4, 4, "", "", "")
 	assert.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-1", "Key-2", "Key-3", "key-1"})
 	assert.Equal(t, "key-2", s.queryDefinition.startKey)
 
-	s, err = newQueryScanner("ns", couchDatabse, "", 2, 2, "", "", "")
+	s, err = newQueryScanner(nil, "ns", couchDatabse, "", 2, 2, "", "", "")
 	assert.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-1", "Key-2"})
 	assert.Equal(t, "Key-3", s.queryDefinition.startKey)
@@ -824,7 +867,7 @@ func TestRangeScanWithCouchInternalDocsPresent(t *testing.T) {
 	assertQueryResults(t, s.resultsInfo.results, []string{"Key-3", "key-1"})
 	assert.Equal(t, "key-2", s.queryDefinition.startKey)
 
-	s, err = newQueryScanner("ns", couchDatabse, "", 2, 2, "", "_", "")
+	s, err = newQueryScanner(nil, "ns", couchDatabse, "", 2, 2, "", "_", "")
 	assert.NoError(t, err)
 	assertQueryResults(t, s.resultsInfo.results, []string{"key-1", "key-2"})
 	assert.Equal(t, "key-3", s.queryDefinition.startKey)
@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/pkg/errors"
+)
+
+// A value that is larger than the chunk size is split into chunks, so that no document exceeds the maximum
+// document size of CouchDB. The chunks are stored in a companion database of the namespace, as documents
+// with ids `<key>~<chunk index>`, and the document of the key in the namespace database carries the field
+// `~chunks` that records the number of chunks and the hash of the complete value. The chunks of a key are
+// overwritten when the key is updated, and the chunks that are no longer needed are deleted.
+//
+// The document of a JSON value stored in chunks keeps the top-level fields of the value that fit within
+// the chunk size (see `queryableFields`), so that the rich queries and the indexes on these fields still
+// match the value. The chunk size is the same on all the peers, hence the same fields are kept everywhere
+// and a query returns the same results on all the endorsers
+const (
+	chunkNamespaceSuffix = "$$c"
+	chunkDataField       = "data"
+	// maxChunkDocsPerBatch limits the size of a bulk update request that carries chunks
+	maxChunkDocsPerBatch = 4
+)
+
+// valueChunkSize is the size of the chunks of the values, which is not configurable so that it is the same
+// on all the peers of a channel. It is a variable only for the tests
+var valueChunkSize = 1024 * 1024
+
+// chunksInfo is the content of the field `~chunks` in the document of a key whose value is stored in chunks
+type chunksInfo struct {
+	Count int    `json:"count"`
+	Hash  []byte `json:"hash"`
+}
+
+func chunkNamespace(ns string) string {
+	return ns + chunkNamespaceSuffix
+}
+
+func chunkID(key string, index int) string {
+	return fmt.Sprintf("%s~%d", key, index)
+}
+
+// isChunkingRequired returns true if the given value is to be stored in chunks
+func isChunkingRequired(value []byte, chunkSize int) bool {
+	return value != nil && len(value) > chunkSize
+}
+
+// queryableFields returns the top-level fields of the given JSON value that are kept in the document of
+// the key when the value is stored in chunks. The fields are taken in the order of their names, as long
+// as their total size does not exceed the chunk size; a field too large to fit is skipped
+func queryableFields(jsonVal jsonValue, chunkSize int) (jsonValue, error) {
+	fields := make(jsonValue)
+	var names []string
+	for name := range jsonVal {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	size := 0
+	for _, name := range names {
+		fieldBytes, err := json.Marshal(jsonVal[name])
+		if err != nil {
+			return nil, errors.Wrapf(err, "error marshalling field %s", name)
+		}
+		fieldSize := len(name) + len(fieldBytes)
+		if size+fieldSize > chunkSize {
+			continue
+		}
+		fields[name] = jsonVal[name]
+		size += fieldSize
+	}
+	return fields, nil
+}
+
+func splitIntoChunks(value []byte, chunkSize int) [][]byte {
+	var chunks [][]byte
+	for len(value) > chunkSize {
+		chunks = append(chunks, value[:chunkSize])
+		value = value[chunkSize:]
+	}
+	return append(chunks, value)
+}
+
+func decodeChunksInfo(field interface{}) (*chunksInfo, error) {
+	fieldBytes, err := json.Marshal(field)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error marshalling field %s", chunksField)
+	}
+	return unmarshalChunksInfo(fieldBytes)
+}
+
+func unmarshalChunksInfo(b []byte) (*chunksInfo, error) {
+	if len(b) == 0 || string(b) == "null" {
+		return nil, nil
+	}
+	info := &chunksInfo{}
+	if err := json.Unmarshal(b, info); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshalling field %s", chunksField)
+	}
+	return info, nil
+}
+
+func chunkToCouchDoc(id string, chunk []byte, revision string) (*couchdb.CouchDoc, error) {
+	jsonMap := jsonValue{
+		idField:        id,
+		chunkDataField: base64.StdEncoding.EncodeToString(chunk),
+	}
+	if revision != "" {
+		jsonMap[revField] = revision
+	}
+	jsonBytes, err := jsonMap.toBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &couchdb.CouchDoc{JSONValue: jsonBytes}, nil
+}
+
+func chunkDeleteCouchDoc(id string, revision string) (*couchdb.CouchDoc, error) {
+	jsonBytes, err := jsonValue{idField: id, revField: revision, deletedField: true}.toBytes()
+	if err != nil {
+		return nil, err
+	}
+	return &couchdb.CouchDoc{JSONValue: jsonBytes}, nil
+}
+
+func couchDocToChunk(doc *couchdb.CouchDoc) ([]byte, error) {
+	chunkDoc := make(map[string]interface{})
+	if err := json.Unmarshal(doc.JSONValue, &chunkDoc); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling chunk document")
+	}
+	encodedChunk, ok := chunkDoc[chunkDataField].(string)
+	if !ok {
+		return nil, errors.Errorf("field %s is missing in the chunk document", chunkDataField)
+	}
+	return base64.StdEncoding.DecodeString(encodedChunk)
+}
+
+// resolveChunks populates the value of the given keyValue from its chunks, in case the value is stored in chunks
+func (vdb *VersionedDB) resolveChunks(ns string, kv *keyValue) error {
+	if kv.chunks == nil {
+		return nil
+	}
+	db, err := vdb.getNamespaceDBHandle(chunkNamespace(ns))
+	if err != nil {
+		return err
+	}
+	var value []byte
+	for i := 0; i < kv.chunks.Count; i++ {
+		id := chunkID(kv.key, i)
+		doc, _, err := db.ReadDoc(id)
+		if err != nil {
+			return err
+		}
+		if doc == nil {
+			return errors.Errorf("chunk [%s] of the value of key [%s] in namespace [%s] is missing", id, kv.key, ns)
+		}
+		chunk, err := couchDocToChunk(doc)
+		if err != nil {
+			return err
+		}
+		value = append(value, chunk...)
+	}
+	if !bytes.Equal(util.ComputeSHA256(value), kv.chunks.Hash) {
+		return errors.Errorf("hash of the chunks of key [%s] in namespace [%s] does not match the hash recorded for the value", kv.key, ns)
+	}
+	kv.Value = value
+	return nil
+}
+
+// chunkCommittersBuilder builds the batches that write the chunks of the large values in a namespace
+// and delete the chunks that are no longer needed
+type chunkCommittersBuilder struct {
+	vdb         *VersionedDB
+	ns          string
+	chunkSize   int
+	chunkCounts map[string]int
+	// chunkDB is set only if the builder produced any batch
+	chunkDB *couchdb.CouchDatabase
+}
+
+// build processes the given updates and returns the batches of chunk updates. It also sets the field
+// `chunks` in the given keyValues whose values are to be stored in chunks
+func (b *chunkCommittersBuilder) build(kvs []*keyValue) ([]batch, error) {
+	chunksByKey := make(map[string][][]byte)
+	var ids []string
+	for _, kv := range kvs {
+		var chunks [][]byte
+		if isChunkingRequired(kv.Value, b.chunkSize) {
+			chunks = splitIntoChunks(kv.Value, b.chunkSize)
+			chunksByKey[kv.key] = chunks
+			kv.chunks = &chunksInfo{Count: len(chunks), Hash: util.ComputeSHA256(kv.Value)}
+		}
+		for i := 0; i < maxInt(len(chunks), b.chunkCounts[kv.key]); i++ {
+			ids = append(ids, chunkID(kv.key, i))
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	db, err := b.vdb.getNamespaceDBHandle(chunkNamespace(b.ns))
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := retrieveRevisions(db, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var batches []batch
+	batchUpdateMap := make(map[string]*batchableDocument)
+	addToBatch := func(id string, doc *couchdb.CouchDoc, deleted bool) {
+		batchUpdateMap[id] = &batchableDocument{CouchDoc: *doc, Deleted: deleted}
+		if len(batchUpdateMap) == maxChunkDocsPerBatch {
+			batches = append(batches, &subNsCommitter{db, batchUpdateMap})
+			batchUpdateMap = make(map[string]*batchableDocument)
+		}
+	}
+	for _, kv := range kvs {
+		chunks := chunksByKey[kv.key]
+		for i, chunk := range chunks {
+			id := chunkID(kv.key, i)
+			doc, err := chunkToCouchDoc(id, chunk, revisions[id])
+			if err != nil {
+				return nil, err
+			}
+			addToBatch(id, doc, false)
+		}
+		for i := len(chunks); i < b.chunkCounts[kv.key]; i++ {
+			id := chunkID(kv.key, i)
+			rev, ok := revisions[id]
+			if !ok {
+				continue
+			}
+			doc, err := chunkDeleteCouchDoc(id, rev)
+			if err != nil {
+				return nil, err
+			}
+			addToBatch(id, doc, true)
+		}
+	}
+	if len(batchUpdateMap) > 0 {
+		batches = append(batches, &subNsCommitter{db, batchUpdateMap})
+	}
+	b.chunkDB = db
+	return batches, nil
+}
+
+// retrieveRevisions retrieves the revisions of the given documents in groups of size `ledgerconfig.GetMaxBatchUpdateSize()`
+func retrieveRevisions(db *couchdb.CouchDatabase, ids []string) (map[string]string, error) {
+	maxBatchSize := ledgerconfig.GetMaxBatchUpdateSize()
+	revisions := make(map[string]string)
+	for len(ids) > 0 {
+		numIDs := minimum(maxBatchSize, len(ids))
+		revs, err := db.BatchRetrieveDocumentRevisions(ids[:numIDs])
+		if err != nil {
+			return nil, err
+		}
+		for id, rev := range revs {
+			revisions[id] = rev
+		}
+		ids = ids[numIDs:]
+	}
+	return revisions, nil
+}
+
+// getChunkCounts returns the number of chunks of the committed values, as captured from the given metadata
+func getChunkCounts(metadata []*couchdb.DocMetadata) (map[string]int, error) {
+	chunkCounts := make(map[string]int)
+	for _, m := range metadata {
+		info, err := unmarshalChunksInfo(m.Chunks)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			chunkCounts[m.ID] = info.Count
+		}
+	}
+	return chunkCounts, nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
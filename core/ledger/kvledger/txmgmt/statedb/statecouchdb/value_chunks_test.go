@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statecouchdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitIntoChunks(t *testing.T) {
+	assert.Equal(t, [][]byte{[]byte("abc")}, splitIntoChunks([]byte("abc"), 3))
+	assert.Equal(t, [][]byte{[]byte("abc"), []byte("d")}, splitIntoChunks([]byte("abcd"), 3))
+	assert.Equal(t, [][]byte{[]byte("ab"), []byte("cd"), []byte("ef")}, splitIntoChunks([]byte("abcdef"), 2))
+}
+
+func TestIsChunkingRequired(t *testing.T) {
+	assert.False(t, isChunkingRequired(nil, 2))
+	assert.False(t, isChunkingRequired([]byte("ab"), 2))
+	assert.True(t, isChunkingRequired([]byte("abc"), 2))
+	assert.True(t, isChunkingRequired([]byte("null"), 2))
+	// JSON values are stored in chunks as well
+	assert.True(t, isChunkingRequired([]byte(`{"asset_name":"marble1"}`), 2))
+	assert.False(t, isChunkingRequired([]byte(`{"asset_name":"marble1"}`), 24))
+}
+
+func TestQueryableFields(t *testing.T) {
+	jsonVal := jsonValue{
+		"asset_name":  "marble1",
+		"color":       "blue",
+		"description": "a description longer than the chunk size",
+		"size":        35,
+	}
+	// the fields are taken in the order of their names, skipping those too large to fit
+	fields, err := queryableFields(jsonVal, 40)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonValue{"asset_name": "marble1", "color": "blue", "size": 35}, fields)
+	fields, err = queryableFields(jsonVal, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonValue{"asset_name": "marble1"}, fields)
+	fields, err = queryableFields(jsonVal, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonValue{"size": 35}, fields)
+	fields, err = queryableFields(jsonVal, 5)
+	assert.NoError(t, err)
+	assert.Empty(t, fields)
+}
+
+func TestBinaryValueEncoding(t *testing.T) {
+	ver := version.NewHeight(1, 1)
+
+	// small binary values are stored within the document without attachments
+	kv := &keyValue{key: "key1", VersionedValue: &statedb.VersionedValue{Value: []byte{0x01, 0x02}, Version: ver}}
+	doc, err := keyValToCouchDoc(kv, "")
+	assert.NoError(t, err)
+	assert.Nil(t, doc.Attachments)
+	decodedKV, err := couchDocToKeyValue(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, kv.VersionedValue, decodedKV.VersionedValue)
+	assert.Nil(t, decodedKV.chunks)
+
+	// large binary values carry only the chunks info within the document
+	largeValue := bytes.Repeat([]byte{0x01}, 10)
+	kv = &keyValue{key: "key2", VersionedValue: &statedb.VersionedValue{Value: largeValue, Version: ver},
+		chunks: &chunksInfo{Count: 4, Hash: util.ComputeSHA256(largeValue)}}
+	doc, err = keyValToCouchDoc(kv, "")
+	assert.NoError(t, err)
+	assert.Nil(t, doc.Attachments)
+	assert.NotContains(t, string(doc.JSONValue), binaryField)
+	decodedKV, err = couchDocToKeyValue(doc)
+	assert.NoError(t, err)
+	assert.Nil(t, decodedKV.Value)
+	assert.Equal(t, kv.chunks, decodedKV.chunks)
+
+	// large JSON values keep their queryable fields within the document
+	largeJSONValue := []byte(`{"asset_name":"marble1","description":"` + string(bytes.Repeat([]byte("a"), valueChunkSize)) + `"}`)
+	kv = &keyValue{key: "key4", VersionedValue: &statedb.VersionedValue{Value: largeJSONValue, Version: ver},
+		chunks: &chunksInfo{Count: 2, Hash: util.ComputeSHA256(largeJSONValue)}}
+	doc, err = keyValToCouchDoc(kv, "")
+	assert.NoError(t, err)
+	assert.Contains(t, string(doc.JSONValue), `"asset_name":"marble1"`)
+	assert.NotContains(t, string(doc.JSONValue), "description")
+	decodedKV, err = couchDocToKeyValue(doc)
+	assert.NoError(t, err)
+	assert.Nil(t, decodedKV.Value)
+	assert.Equal(t, kv.chunks, decodedKV.chunks)
+
+	// the binary values stored as attachments by the earlier versions can still be read
+	verAndMetadata, err := encodeVersionAndMetadata(ver, nil)
+	assert.NoError(t, err)
+	jsonBytes, err := jsonValue{idField: "key3", versionField: verAndMetadata}.toBytes()
+	assert.NoError(t, err)
+	doc = &couchdb.CouchDoc{
+		JSONValue:   jsonBytes,
+		Attachments: []*couchdb.AttachmentInfo{{Name: binaryWrapper, AttachmentBytes: []byte{0x03}}},
+	}
+	decodedKV, err = couchDocToKeyValue(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x03}, decodedKV.Value)
+}
+
+func TestChunkDocEncoding(t *testing.T) {
+	doc, err := chunkToCouchDoc(chunkID("key1", 2), []byte{0x01, 0x02}, "")
+	assert.NoError(t, err)
+	assert.Contains(t, string(doc.JSONValue), `"_id":"key1~2"`)
+	chunk, err := couchDocToChunk(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x02}, chunk)
+
+	_, err = couchDocToChunk(&couchdb.CouchDoc{JSONValue: []byte(`{"_id":"key1~2"}`)})
+	assert.EqualError(t, err, "field data is missing in the chunk document")
+}
+
+func TestLargeValueChunking(t *testing.T) {
+	valueChunkSize = 16
+	defer func() { valueChunkSize = 1024 * 1024 }()
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testlargevaluechunking")
+	assert.NoError(t, err)
+	vdb := db.(*VersionedDB)
+
+	largeValue := bytes.Repeat([]byte{0x01, 0x02, 0x03}, 20)
+	largeJSONValue := []byte(`{"asset_name":"marble1","description":"a description longer than the chunk size"}`)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", largeValue, version.NewHeight(1, 1))
+	batch.Put("ns", "key2", []byte("small"), version.NewHeight(1, 2))
+	batch.Put("ns", "key3", largeJSONValue, version.NewHeight(1, 3))
+	assert.NoError(t, vdb.ApplyUpdates(batch, version.NewHeight(1, 3)))
+
+	vv, err := vdb.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, largeValue, vv.Value)
+	vv, err = vdb.GetState("ns", "key3")
+	assert.NoError(t, err)
+	assert.Equal(t, largeJSONValue, vv.Value)
+	assert.Equal(t, 4, numChunkDocs(t, vdb, "ns", "key1"))
+	assert.Equal(t, 6, numChunkDocs(t, vdb, "ns", "key3"))
+
+	itr, err := vdb.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, largeValue, res.(*statedb.VersionedKV).Value)
+	itr.Close()
+
+	// overwriting with a smaller value removes the chunks that are no longer needed
+	smallerValue := bytes.Repeat([]byte{0x04}, 20)
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", smallerValue, version.NewHeight(2, 1))
+	assert.NoError(t, vdb.ApplyUpdates(batch, version.NewHeight(2, 1)))
+	vdb.ClearCachedVersions()
+	vv, err = vdb.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, smallerValue, vv.Value)
+	assert.Equal(t, 2, numChunkDocs(t, vdb, "ns", "key1"))
+
+	// deleting the key removes all its chunks
+	batch = statedb.NewUpdateBatch()
+	batch.Delete("ns", "key1", version.NewHeight(3, 1))
+	assert.NoError(t, vdb.ApplyUpdates(batch, version.NewHeight(3, 1)))
+	vv, err = vdb.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	assert.Equal(t, 0, numChunkDocs(t, vdb, "ns", "key1"))
+}
+
+func TestValueChunkingOverOneMB(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testvaluechunkingoveronemb")
+	assert.NoError(t, err)
+	vdb := db.(*VersionedDB)
+
+	// the values larger than the chunk size of 1MB, JSON or not, are stored in chunks
+	largeValue := bytes.Repeat([]byte{0x01, 0x02, 0x03}, 500*1024)
+	largeJSONValue := []byte(`{"asset_name":"marble1","description":"` + string(bytes.Repeat([]byte("a"), 1100*1024)) + `"}`)
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", largeValue, version.NewHeight(1, 1))
+	batch.Put("ns", "key2", largeJSONValue, version.NewHeight(1, 2))
+	assert.NoError(t, vdb.ApplyUpdates(batch, version.NewHeight(1, 2)))
+	assert.Equal(t, 2, numChunkDocs(t, vdb, "ns", "key1"))
+	assert.Equal(t, 2, numChunkDocs(t, vdb, "ns", "key2"))
+
+	// the documents of the keys carry the hashes of the values rather than the values
+	nsDB, err := vdb.getNamespaceDBHandle("ns")
+	assert.NoError(t, err)
+	for key, value := range map[string][]byte{"key1": largeValue, "key2": largeJSONValue} {
+		doc, _, err := nsDB.ReadDoc(key)
+		assert.NoError(t, err)
+		assert.True(t, len(doc.JSONValue) < 1024)
+		kv, err := couchDocToKeyValue(doc)
+		assert.NoError(t, err)
+		assert.Equal(t, &chunksInfo{Count: 2, Hash: util.ComputeSHA256(value)}, kv.chunks)
+	}
+
+	vdb.ClearCachedVersions()
+	vv, err := vdb.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, largeValue, vv.Value)
+	vv, err = vdb.GetState("ns", "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, largeJSONValue, vv.Value)
+
+	itr, err := vdb.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	defer itr.Close()
+	for _, value := range [][]byte{largeValue, largeJSONValue} {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, value, res.(*statedb.VersionedKV).Value)
+	}
+
+	// the JSON value stored in chunks is still matched by the queries on its queryable fields
+	queryItr, err := vdb.ExecuteQuery("ns", `{"selector":{"asset_name":"marble1"}}`)
+	assert.NoError(t, err)
+	defer queryItr.Close()
+	res, err := queryItr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "key2", res.(*statedb.VersionedKV).Key)
+	assert.Equal(t, largeJSONValue, res.(*statedb.VersionedKV).Value)
+	res, err = queryItr.Next()
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func numChunkDocs(t *testing.T, vdb *VersionedDB, ns, key string) int {
+	chunkDB, err := vdb.getNamespaceDBHandle(chunkNamespace(ns))
+	assert.NoError(t, err)
+	var ids []string
+	for i := 0; i < 10; i++ {
+		ids = append(ids, chunkID(key, i))
+	}
+	revs, err := chunkDB.BatchRetrieveDocumentRevisions(ids)
+	assert.NoError(t, err)
+	return len(revs)
+}
@@ -13,18 +13,23 @@ type versions map[string]nsVersions
 type revisions map[string]nsRevisions
 type nsRevisions map[string]string
 type nsVersions map[string]*version.Height
+type chunkCounts map[string]nsChunkCounts
+type nsChunkCounts map[string]int
 
 // versionsCache contains maps of versions and revisions.
 // Used as a local cache during bulk processing of a block.
 // versions - contains the committed versions and used for state validation of readsets
 // revisions - contains the committed revisions and used during commit phase for couchdb bulk updates
+// chunks - contains the number of chunks of the committed values that are stored in chunks and used during
+// commit phase for overwriting or deleting the chunks
 type versionsCache struct {
-	vers versions
-	revs revisions
+	vers   versions
+	revs   revisions
+	chunks chunkCounts
 }
 
 func newVersionCache() *versionsCache {
-	return &versionsCache{make(versions), make(revisions)}
+	return &versionsCache{make(versions), make(revisions), make(chunkCounts)}
 }
 
 func (c *versionsCache) getVersion(ns, key string) (*version.Height, bool) {
@@ -51,3 +56,12 @@ func (c *versionsCache) setVerAndRev(ns, key string, ver *version.Height, rev st
 	c.vers[ns][key] = ver
 	c.revs[ns][key] = rev
 }
+
+// setChunkCount sets the number of chunks of the committed value for given ns/key
+func (c *versionsCache) setChunkCount(ns, key string, count int) {
+	_, ok := c.chunks[ns]
+	if !ok {
+		c.chunks[ns] = make(nsChunkCounts)
+	}
+	c.chunks[ns][key] = count
+}
@@ -88,6 +88,29 @@ type IndexCapable interface {
 	ProcessIndexesForChaincodeDeploy(namespace string, fileEntries []*ccprovider.TarFileEntry) error
 }
 
+// SnapshotCapable interface provides additional functions for
+// databases capable of serving a stable view of their state
+type SnapshotCapable interface {
+	// GetSnapshot returns a read-only view of the state as of now, unaffected by the updates applied later
+	GetSnapshot() (Snapshot, error)
+}
+
+// Snapshot is a read-only VersionedDB serving the state as of the time it was taken.
+// The function `ApplyUpdates` of a snapshot returns an error
+type Snapshot interface {
+	VersionedDB
+	// Release releases the resources held by the snapshot. The iterators obtained from the snapshot
+	// remain usable until closed
+	Release()
+}
+
+// DiskUsageReporter interface provides additional functions for
+// databases able to report the disk space they use
+type DiskUsageReporter interface {
+	// DiskUsage returns the approximate disk space used by the database in bytes
+	DiskUsage() (int64, error)
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
@@ -30,9 +30,13 @@ type VersionedDBProvider struct {
 
 // NewVersionedDBProvider instantiates VersionedDBProvider
 func NewVersionedDBProvider() *VersionedDBProvider {
-	dbPath := ledgerconfig.GetStateLevelDBPath()
+	return NewVersionedDBProviderWithPath(ledgerconfig.GetStateLevelDBPath())
+}
+
+// NewVersionedDBProviderWithPath instantiates VersionedDBProvider for the state db at the given path
+func NewVersionedDBProviderWithPath(dbPath string) *VersionedDBProvider {
 	logger.Debugf("constructing VersionedDBProvider dbPath=%s", dbPath)
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Compaction: ledgerconfig.GetLevelDBCompactionConf()})
 	return &VersionedDBProvider{dbProvider}
 }
 
@@ -46,15 +50,22 @@ func (provider *VersionedDBProvider) Close() {
 	provider.dbProvider.Close()
 }
 
+// reader reads either the named db or a snapshot of it
+type reader interface {
+	Get(key []byte) ([]byte, error)
+	GetIterator(startKey []byte, endKey []byte) *leveldbhelper.Iterator
+}
+
 // VersionedDB implements VersionedDB interface
 type versionedDB struct {
 	db     *leveldbhelper.DBHandle
+	reader reader
 	dbName string
 }
 
 // newVersionedDB constructs an instance of VersionedDB
 func newVersionedDB(db *leveldbhelper.DBHandle, dbName string) *versionedDB {
-	return &versionedDB{db, dbName}
+	return &versionedDB{db, db, dbName}
 }
 
 // Open implements method in VersionedDB interface
@@ -82,7 +93,7 @@ func (vdb *versionedDB) BytesKeySupported() bool {
 func (vdb *versionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
 	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
 	compositeKey := constructCompositeKey(namespace, key)
-	dbVal, err := vdb.db.Get(compositeKey)
+	dbVal, err := vdb.reader.Get(compositeKey)
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +159,7 @@ func (vdb *versionedDB) GetStateRangeScanIteratorWithMetadata(namespace string,
 	if endKey == "" {
 		compositeEndKey[len(compositeEndKey)-1] = lastKeyIndicator
 	}
-	dbItr := vdb.db.GetIterator(compositeStartKey, compositeEndKey)
+	dbItr := vdb.reader.GetIterator(compositeStartKey, compositeEndKey)
 
 	return newKVScanner(namespace, dbItr, requestedLimit), nil
 
@@ -201,7 +212,7 @@ func (vdb *versionedDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version
 
 // GetLatestSavePoint implements method in VersionedDB interface
 func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
-	versionBytes, err := vdb.db.Get(savePointKey)
+	versionBytes, err := vdb.reader.Get(savePointKey)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +223,36 @@ func (vdb *versionedDB) GetLatestSavePoint() (*version.Height, error) {
 	return version, nil
 }
 
+// GetSnapshot implements method in statedb.SnapshotCapable interface
+func (vdb *versionedDB) GetSnapshot() (statedb.Snapshot, error) {
+	snapshot, err := vdb.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotDB{&versionedDB{vdb.db, snapshot, vdb.dbName}, snapshot}, nil
+}
+
+// DiskUsage implements method in DiskUsageReporter interface
+func (vdb *versionedDB) DiskUsage() (int64, error) {
+	return vdb.db.ApproximateSize()
+}
+
+// snapshotDB serves the state of a versionedDB as of the time the snapshot was taken
+type snapshotDB struct {
+	*versionedDB
+	snapshot *leveldbhelper.Snapshot
+}
+
+// ApplyUpdates implements method in VersionedDB interface
+func (s *snapshotDB) ApplyUpdates(batch *statedb.UpdateBatch, height *version.Height) error {
+	return errors.New("updates cannot be applied to a snapshot")
+}
+
+// Release implements method in statedb.Snapshot interface
+func (s *snapshotDB) Release() {
+	s.snapshot.Release()
+}
+
 func constructCompositeKey(ns string, key string) []byte {
 	return append(append([]byte(ns), compositeKeySep...), []byte(key)...)
 }
@@ -136,3 +136,55 @@ func TestApplyUpdatesWithNilHeight(t *testing.T) {
 	defer env.Cleanup()
 	commontests.TestApplyUpdatesWithNilHeight(t, env.DBProvider)
 }
+
+func TestSnapshot(t *testing.T) {
+	env := NewTestVDBEnv(t)
+	defer env.Cleanup()
+	db, err := env.DBProvider.GetDBHandle("testsnapshot")
+	assert.NoError(t, err)
+
+	batch := statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1"), version.NewHeight(1, 1))
+	batch.Put("ns", "key2", []byte("value2"), version.NewHeight(1, 2))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(1, 2)))
+
+	snapshot, err := db.(statedb.SnapshotCapable).GetSnapshot()
+	assert.NoError(t, err)
+
+	batch = statedb.NewUpdateBatch()
+	batch.Put("ns", "key1", []byte("value1_updated"), version.NewHeight(2, 1))
+	batch.Delete("ns", "key2", version.NewHeight(2, 2))
+	batch.Put("ns", "key3", []byte("value3"), version.NewHeight(2, 3))
+	assert.NoError(t, db.ApplyUpdates(batch, version.NewHeight(2, 3)))
+
+	vv, err := snapshot.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value1"), Version: version.NewHeight(1, 1)}, vv)
+	vv, err = snapshot.GetState("ns", "key3")
+	assert.NoError(t, err)
+	assert.Nil(t, vv)
+	savepoint, err := snapshot.GetLatestSavePoint()
+	assert.NoError(t, err)
+	assert.Equal(t, version.NewHeight(1, 2), savepoint)
+
+	itr, err := snapshot.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	defer itr.Close()
+	snapshot.Release()
+	var keys []string
+	for {
+		result, err := itr.Next()
+		assert.NoError(t, err)
+		if result == nil {
+			break
+		}
+		keys = append(keys, result.(*statedb.VersionedKV).Key)
+	}
+	assert.Equal(t, []string{"key1", "key2"}, keys)
+
+	assert.EqualError(t, snapshot.ApplyUpdates(statedb.NewUpdateBatch(), nil), "updates cannot be applied to a snapshot")
+
+	vv, err = db.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1_updated"), vv.Value)
+}
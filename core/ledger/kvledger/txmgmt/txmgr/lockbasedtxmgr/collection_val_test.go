@@ -56,7 +56,7 @@ func TestPvtGetNoCollection(t *testing.T) {
 	testEnv.init(t, "test-pvtdata-get-no-collection", nil)
 	defer testEnv.cleanup()
 	txMgr := testEnv.getTxMgr().(*LockBasedTxMgr)
-	queryHelper := newQueryHelper(txMgr, nil)
+	queryHelper := newQueryHelper(txMgr, "", nil)
 	valueHash, metadataBytes, err := queryHelper.getPrivateDataValueHash("cc", "coll", "key")
 	assert.Nil(t, valueHash)
 	assert.Nil(t, metadataBytes)
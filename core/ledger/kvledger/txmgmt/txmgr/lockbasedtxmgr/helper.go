@@ -10,6 +10,7 @@ import (
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	ledger "github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
@@ -24,15 +25,23 @@ import (
 
 type queryHelper struct {
 	txmgr             *LockBasedTxMgr
+	db                privacyenabledstate.DB
+	snapshot          *stateSnapshot
 	collNameValidator *collNameValidator
 	rwsetBuilder      *rwsetutil.RWSetBuilder
 	itrs              []*resultsItr
+	resourceTracker   *queryResourceTracker
 	err               error
 	doneInvoked       bool
 }
 
-func newQueryHelper(txmgr *LockBasedTxMgr, rwsetBuilder *rwsetutil.RWSetBuilder) *queryHelper {
-	helper := &queryHelper{txmgr: txmgr, rwsetBuilder: rwsetBuilder}
+func newQueryHelper(txmgr *LockBasedTxMgr, txid string, rwsetBuilder *rwsetutil.RWSetBuilder) *queryHelper {
+	helper := &queryHelper{
+		txmgr:           txmgr,
+		db:              txmgr.db,
+		rwsetBuilder:    rwsetBuilder,
+		resourceTracker: newQueryResourceTracker(txid, queryLimitsFromConfig()),
+	}
 	validator := newCollNameValidator(txmgr.ccInfoProvider, &lockBasedQueryExecutor{helper: helper})
 	helper.collNameValidator = validator
 	return helper
@@ -42,7 +51,7 @@ func (h *queryHelper) getState(ns string, key string) ([]byte, []byte, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, nil, err
 	}
-	versionedValue, err := h.txmgr.db.GetState(ns, key)
+	versionedValue, err := h.db.GetState(ns, key)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -57,9 +66,9 @@ func (h *queryHelper) getStateMultipleKeys(namespace string, keys []string) ([][
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	versionedValues, err := h.txmgr.db.GetStateMultipleKeys(namespace, keys)
+	versionedValues, err := h.db.GetStateMultipleKeys(namespace, keys)
 	if err != nil {
-		return nil, nil
+		return nil, err
 	}
 	values := make([][]byte, len(versionedValues))
 	for i, versionedValue := range versionedValues {
@@ -76,48 +85,73 @@ func (h *queryHelper) getStateRangeScanIterator(namespace string, startKey strin
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	itr, err := newResultsItr(namespace, startKey, endKey, nil, h.txmgr.db, h.rwsetBuilder,
+	itr, err := newResultsItr(namespace, startKey, endKey, nil, h.db, h.rwsetBuilder,
 		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
 	if err != nil {
 		return nil, err
 	}
 	h.itrs = append(h.itrs, itr)
-	return itr, nil
+	return h.resourceTracker.track(namespace, itr)
 }
 
 func (h *queryHelper) getStateRangeScanIteratorWithMetadata(namespace string, startKey string, endKey string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	itr, err := newResultsItr(namespace, startKey, endKey, metadata, h.txmgr.db, h.rwsetBuilder,
+	itr, err := newResultsItr(namespace, startKey, endKey, metadata, h.db, h.rwsetBuilder,
 		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
 	if err != nil {
 		return nil, err
 	}
 	h.itrs = append(h.itrs, itr)
-	return itr, nil
+	return h.resourceTracker.track(namespace, itr)
+}
+
+// getStateRangeKeysHash returns the fingerprint of the keys of the range [startKey, endKey) deleted by
+// the transaction, which is not recorded as a range query since the keys are not returned to the caller
+func (h *queryHelper) getStateRangeKeysHash(namespace string, startKey string, endKey string) ([]byte, error) {
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	dbItr, err := h.db.GetStateRangeScanIterator(namespace, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer dbItr.Close()
+	hasher := rwsetutil.NewRangeDeleteHasher()
+	for {
+		queryResult, err := dbItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			return hasher.Sum(), nil
+		}
+		versionedKV := queryResult.(*statedb.VersionedKV)
+		hasher.Add(versionedKV.Key, versionedKV.Version)
+	}
 }
 
 func (h *queryHelper) executeQuery(namespace, query string) (commonledger.ResultsIterator, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.ExecuteQuery(namespace, query)
+	dbItr, err := h.db.ExecuteQuery(namespace, query)
 	if err != nil {
 		return nil, err
 	}
-	return &queryResultsItr{DBItr: dbItr, RWSetBuilder: h.rwsetBuilder}, nil
+	return h.resourceTracker.track(namespace, &queryResultsItr{DBItr: dbItr, RWSetBuilder: h.rwsetBuilder})
 }
 
 func (h *queryHelper) executeQueryWithMetadata(namespace, query string, metadata map[string]interface{}) (ledger.QueryResultsIterator, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.ExecuteQueryWithMetadata(namespace, query, metadata)
+	dbItr, err := h.db.ExecuteQueryWithMetadata(namespace, query, metadata)
 	if err != nil {
 		return nil, err
 	}
-	return &queryResultsItr{DBItr: dbItr, RWSetBuilder: h.rwsetBuilder}, nil
+	return h.resourceTracker.track(namespace, &queryResultsItr{DBItr: dbItr, RWSetBuilder: h.rwsetBuilder})
 }
 
 func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
@@ -132,7 +166,7 @@ func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
 	var hashVersion *version.Height
 	var versionedValue *statedb.VersionedValue
 
-	if versionedValue, err = h.txmgr.db.GetPrivateData(ns, coll, key); err != nil {
+	if versionedValue, err = h.db.GetPrivateData(ns, coll, key); err != nil {
 		return nil, err
 	}
 
@@ -140,7 +174,7 @@ func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
 	val, _, ver := decomposeVersionedValue(versionedValue)
 
 	keyHash := util.ComputeStringHash(key)
-	if hashVersion, err = h.txmgr.db.GetKeyHashVersion(ns, coll, keyHash); err != nil {
+	if hashVersion, err = h.db.GetKeyHashVersion(ns, coll, keyHash); err != nil {
 		return nil, err
 	}
 	if !version.AreSame(hashVersion, ver) {
@@ -164,7 +198,7 @@ func (h *queryHelper) getPrivateDataValueHash(ns, coll, key string) (valueHash,
 	var versionedValue *statedb.VersionedValue
 
 	keyHash := util.ComputeStringHash(key)
-	if versionedValue, err = h.txmgr.db.GetValueHash(ns, coll, keyHash); err != nil {
+	if versionedValue, err = h.db.GetValueHash(ns, coll, keyHash); err != nil {
 		return nil, nil, err
 	}
 	valHash, metadata, ver := decomposeVersionedValue(versionedValue)
@@ -181,7 +215,7 @@ func (h *queryHelper) getPrivateDataMultipleKeys(ns, coll string, keys []string)
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	versionedValues, err := h.txmgr.db.GetPrivateDataMultipleKeys(ns, coll, keys)
+	versionedValues, err := h.db.GetPrivateDataMultipleKeys(ns, coll, keys)
 	if err != nil {
 		return nil, nil
 	}
@@ -203,11 +237,11 @@ func (h *queryHelper) getPrivateDataRangeScanIterator(namespace, collection, sta
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
+	dbItr, err := h.db.GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
 	if err != nil {
 		return nil, err
 	}
-	return &pvtdataResultsItr{namespace, collection, dbItr}, nil
+	return h.resourceTracker.track(namespace, &pvtdataResultsItr{namespace, collection, dbItr})
 }
 
 func (h *queryHelper) executeQueryOnPrivateData(namespace, collection, query string) (commonledger.ResultsIterator, error) {
@@ -217,11 +251,11 @@ func (h *queryHelper) executeQueryOnPrivateData(namespace, collection, query str
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.ExecuteQueryOnPrivateData(namespace, collection, query)
+	dbItr, err := h.db.ExecuteQueryOnPrivateData(namespace, collection, query)
 	if err != nil {
 		return nil, err
 	}
-	return &pvtdataResultsItr{namespace, collection, dbItr}, nil
+	return h.resourceTracker.track(namespace, &pvtdataResultsItr{namespace, collection, dbItr})
 }
 
 func (h *queryHelper) getStateMetadata(ns string, key string) (map[string][]byte, error) {
@@ -232,7 +266,7 @@ func (h *queryHelper) getStateMetadata(ns string, key string) (map[string][]byte
 	var err error
 	if h.rwsetBuilder == nil {
 		// reads versions are not getting recorded, retrieve metadata value via optimized path
-		if metadataBytes, err = h.txmgr.db.GetStateMetadata(ns, key); err != nil {
+		if metadataBytes, err = h.db.GetStateMetadata(ns, key); err != nil {
 			return nil, err
 		}
 	} else {
@@ -272,7 +306,7 @@ func (h *queryHelper) getPrivateDataMetadataByHash(ns, coll string, keyhash []by
 		// this requires to improve rwset builder to accept a keyhash
 		return nil, errors.New("retrieving private data metadata by keyhash is not supported in simulation. This function is only available for query as yet")
 	}
-	metadataBytes, err := h.txmgr.db.GetPrivateDataMetadataByHash(ns, coll, keyhash)
+	metadataBytes, err := h.db.GetPrivateDataMetadataByHash(ns, coll, keyhash)
 	if err != nil {
 		return nil, err
 	}
@@ -285,14 +319,28 @@ func (h *queryHelper) done() {
 	}
 
 	defer func() {
-		h.txmgr.commitRWLock.RUnlock()
+		if h.snapshot == nil {
+			h.txmgr.commitRWLock.RUnlock()
+		}
 		h.doneInvoked = true
+		h.resourceTracker.closeLeakedItrs()
 		for _, itr := range h.itrs {
 			itr.Close()
 		}
+		if h.snapshot != nil {
+			h.snapshot.close()
+		}
 	}()
 }
 
+// useSnapshot makes the queries read the supplied snapshot of the state database
+// instead of the state database, which they read while holding the commit lock
+func (h *queryHelper) useSnapshot(snapshot *stateSnapshot) {
+	h.db = snapshot.db
+	h.snapshot = snapshot
+	h.resourceTracker.snapshot = snapshot
+}
+
 func (h *queryHelper) addRangeQueryInfo() {
 	for _, itr := range h.itrs {
 		if h.rwsetBuilder != nil {
@@ -316,6 +364,9 @@ func (h *queryHelper) checkDone() error {
 	if h.doneInvoked {
 		return errors.New("this instance should not be used after calling Done()")
 	}
+	if h.snapshot != nil {
+		return h.snapshot.check()
+	}
 	return nil
 }
 
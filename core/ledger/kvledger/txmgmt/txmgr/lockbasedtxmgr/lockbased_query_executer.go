@@ -17,7 +17,7 @@ type lockBasedQueryExecutor struct {
 }
 
 func newQueryExecutor(txmgr *LockBasedTxMgr, txid string) *lockBasedQueryExecutor {
-	helper := newQueryHelper(txmgr, nil)
+	helper := newQueryHelper(txmgr, txid, nil)
 	logger.Debugf("constructing new query executor txid = [%s]", txid)
 	return &lockBasedQueryExecutor{helper, txid}
 }
@@ -80,6 +80,12 @@ func (q *lockBasedQueryExecutor) GetPrivateDataMetadataByHash(namespace, collect
 	return q.helper.getPrivateDataMetadataByHash(namespace, collection, keyhash)
 }
 
+// GetPrivateDataHash implements method in interface `ledger.QueryExecutor`
+func (q *lockBasedQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	valueHash, _, err := q.helper.getPrivateDataValueHash(namespace, collection, key)
+	return valueHash, err
+}
+
 // GetPrivateDataMultipleKeys implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	return q.helper.getPrivateDataMultipleKeys(namespace, collection, keys)
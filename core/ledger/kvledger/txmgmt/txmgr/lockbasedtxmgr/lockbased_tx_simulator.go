@@ -27,7 +27,7 @@ type lockBasedTxSimulator struct {
 
 func newLockBasedTxSimulator(txmgr *LockBasedTxMgr, txid string) (*lockBasedTxSimulator, error) {
 	rwsetBuilder := rwsetutil.NewRWSetBuilder()
-	helper := newQueryHelper(txmgr, rwsetBuilder)
+	helper := newQueryHelper(txmgr, txid, rwsetBuilder)
 	logger.Debugf("constructing new tx simulator txid = [%s]", txid)
 	return &lockBasedTxSimulator{lockBasedQueryExecutor{helper, txid}, rwsetBuilder, false, false, false, false}, nil
 }
@@ -46,6 +46,19 @@ func (s *lockBasedTxSimulator) DeleteState(ns string, key string) error {
 	return s.SetState(ns, key, nil)
 }
 
+// DeleteStateRange implements method in interface `ledger.TxSimulator`
+func (s *lockBasedTxSimulator) DeleteStateRange(ns string, startKey string, endKey string) error {
+	if err := s.checkWritePrecondition(startKey, nil); err != nil {
+		return err
+	}
+	keysHash, err := s.helper.getStateRangeKeysHash(ns, startKey, endKey)
+	if err != nil {
+		return err
+	}
+	s.rwsetBuilder.AddToRangeDeleteSet(ns, startKey, endKey, keysHash)
+	return nil
+}
+
 // SetStateMultipleKeys implements method in interface `ledger.TxSimulator`
 func (s *lockBasedTxSimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
 	for k, v := range kvs {
@@ -70,6 +83,14 @@ func (s *lockBasedTxSimulator) DeleteStateMetadata(namespace, key string) error
 	return s.SetStateMetadata(namespace, key, nil)
 }
 
+// IncrementState implements method in interface `ledger.TxSimulator`
+func (s *lockBasedTxSimulator) IncrementState(ns string, key string, delta int64) error {
+	if err := s.checkWritePrecondition(key, nil); err != nil {
+		return err
+	}
+	return s.rwsetBuilder.AddToIncrementSet(ns, key, delta)
+}
+
 // SetPrivateData implements method in interface `ledger.TxSimulator`
 func (s *lockBasedTxSimulator) SetPrivateData(ns, coll, key string, value []byte) error {
 	if err := s.helper.validateCollName(ns, coll); err != nil {
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"fmt"
+	"sync"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+)
+
+// queryLimits holds the per transaction limits on the resources consumed by the queries.
+// A zero value for a limit means that the resource is not limited
+type queryLimits struct {
+	maxOpenItrs     int
+	maxResults      int
+	maxBytesScanned int
+}
+
+func queryLimitsFromConfig() *queryLimits {
+	return &queryLimits{
+		maxOpenItrs:     ledgerconfig.GetMaxOpenIteratorsPerTx(),
+		maxResults:      ledgerconfig.GetMaxResultsPerTx(),
+		maxBytesScanned: ledgerconfig.GetMaxBytesScannedPerTx(),
+	}
+}
+
+// queryResourceTracker keeps track of the iterators opened by a single simulation (or query)
+// and of the results retrieved from them, and enforces the configured queryLimits
+type queryResourceTracker struct {
+	txid     string
+	limits   *queryLimits
+	snapshot *stateSnapshot
+
+	lock         sync.Mutex
+	openItrs     []*trackedItr
+	numResults   int
+	bytesScanned int
+}
+
+func newQueryResourceTracker(txid string, limits *queryLimits) *queryResourceTracker {
+	return &queryResourceTracker{txid: txid, limits: limits}
+}
+
+// track wraps the supplied iterator so that the results retrieved and the closure of the iterator
+// are accounted for. The iterator is closed and an error is returned if opening it exceeds the limit
+// on the open iterators
+func (t *queryResourceTracker) track(ns string, itr commonledger.ResultsIterator) (ledger.QueryResultsIterator, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.limits.maxOpenItrs > 0 && len(t.openItrs) >= t.limits.maxOpenItrs {
+		itr.Close()
+		return nil, &txmgr.ErrQueryLimitExceeded{Msg: fmt.Sprintf(
+			"txid [%s]: number of open query iterators exceeds the limit [%d]. Close the iterators that are no longer needed",
+			t.txid, t.limits.maxOpenItrs)}
+	}
+	tItr := &trackedItr{ResultsIterator: itr, ns: ns, tracker: t}
+	t.openItrs = append(t.openItrs, tItr)
+	return tItr, nil
+}
+
+func (t *queryResourceTracker) recordResult(kv *queryresult.KV) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.numResults++
+	t.bytesScanned += len(kv.Key) + len(kv.Value)
+	if t.limits.maxResults > 0 && t.numResults > t.limits.maxResults {
+		return &txmgr.ErrQueryLimitExceeded{Msg: fmt.Sprintf(
+			"txid [%s]: number of query results exceeds the limit [%d]", t.txid, t.limits.maxResults)}
+	}
+	if t.limits.maxBytesScanned > 0 && t.bytesScanned > t.limits.maxBytesScanned {
+		return &txmgr.ErrQueryLimitExceeded{Msg: fmt.Sprintf(
+			"txid [%s]: number of bytes scanned by the queries exceeds the limit [%d]", t.txid, t.limits.maxBytesScanned)}
+	}
+	return nil
+}
+
+func (t *queryResourceTracker) release(itr *trackedItr) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for i, openItr := range t.openItrs {
+		if openItr == itr {
+			t.openItrs = append(t.openItrs[:i], t.openItrs[i+1:]...)
+			return
+		}
+	}
+}
+
+// closeLeakedItrs closes the iterators that were not closed by the caller and
+// logs a warning for each of them, as they hold the resources of the state database
+func (t *queryResourceTracker) closeLeakedItrs() {
+	t.lock.Lock()
+	leaked := t.openItrs
+	t.openItrs = nil
+	t.lock.Unlock()
+	for _, itr := range leaked {
+		logger.Warningf("txid [%s]: query iterator on namespace [%s] was not closed before the completion of the transaction. Closing it",
+			t.txid, itr.ns)
+		itr.closeUnderlying()
+	}
+}
+
+// trackedItr wraps an iterator returned by the query helper and reports
+// the results retrieved and the closure of the iterator to the queryResourceTracker
+type trackedItr struct {
+	commonledger.ResultsIterator
+	ns      string
+	tracker *queryResourceTracker
+	closed  bool
+}
+
+// Next implements method in interface ledger.ResultsIterator
+func (itr *trackedItr) Next() (commonledger.QueryResult, error) {
+	queryResult, err := itr.ResultsIterator.Next()
+	// the snapshot is checked after reading from the underlying iterator: the snapshot is marked
+	// as expired before being released, so a result that may have been read from the released
+	// snapshot (e.g. the end of the results reported by an iterator opened after the release)
+	// is never returned
+	if itr.tracker.snapshot != nil {
+		if err := itr.tracker.snapshot.check(); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil || queryResult == nil {
+		return queryResult, err
+	}
+	if kv, ok := queryResult.(*queryresult.KV); ok {
+		if err := itr.tracker.recordResult(kv); err != nil {
+			return nil, err
+		}
+	}
+	return queryResult, nil
+}
+
+// Close implements method in interface ledger.ResultsIterator
+func (itr *trackedItr) Close() {
+	if itr.closed {
+		return
+	}
+	itr.tracker.release(itr)
+	itr.closeUnderlying()
+}
+
+// GetBookmarkAndClose implements method in interface ledger.QueryResultsIterator
+func (itr *trackedItr) GetBookmarkAndClose() string {
+	queryResultsItr, ok := itr.ResultsIterator.(ledger.QueryResultsIterator)
+	if !ok || itr.closed {
+		itr.Close()
+		return ""
+	}
+	itr.tracker.release(itr)
+	itr.closed = true
+	return queryResultsItr.GetBookmarkAndClose()
+}
+
+func (itr *trackedItr) closeUnderlying() {
+	if itr.closed {
+		return
+	}
+	itr.closed = true
+	itr.ResultsIterator.Close()
+}
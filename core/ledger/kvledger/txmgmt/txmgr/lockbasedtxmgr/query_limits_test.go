@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"fmt"
+	"testing"
+
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLimits(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr().(*LockBasedTxMgr)
+
+	var data []*queryresult.KV
+	for i := 0; i < 10; i++ {
+		data = append(data, &queryresult.KV{Key: fmt.Sprintf("key%d", i), Value: []byte("value")})
+	}
+	testutilPopulateDB(t, txMgr, "ns", data, version.NewHeight(1, 1))
+
+	t.Run("maxOpenIterators", func(t *testing.T) {
+		viper.Set("ledger.state.queryLimits.maxOpenIteratorsPerTx", 2)
+		defer viper.Set("ledger.state.queryLimits.maxOpenIteratorsPerTx", 0)
+		sim, err := txMgr.NewTxSimulator("txid1")
+		assert.NoError(t, err)
+		defer sim.Done()
+
+		itr1, err := sim.GetStateRangeScanIterator("ns", "", "")
+		assert.NoError(t, err)
+		_, err = sim.GetStateRangeScanIterator("ns", "", "")
+		assert.NoError(t, err)
+		itr, err := sim.GetStateRangeScanIterator("ns", "", "")
+		assert.Nil(t, itr)
+		assert.IsType(t, &txmgr.ErrQueryLimitExceeded{}, err)
+		assert.EqualError(t, err, "txid [txid1]: number of open query iterators exceeds the limit [2]. Close the iterators that are no longer needed")
+
+		// closing an iterator makes room for a new one
+		itr1.Close()
+		_, err = sim.GetStateRangeScanIterator("ns", "", "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("maxResults", func(t *testing.T) {
+		viper.Set("ledger.state.queryLimits.maxResultsPerTx", 15)
+		defer viper.Set("ledger.state.queryLimits.maxResultsPerTx", 0)
+		qe, err := txMgr.NewQueryExecutor("txid2")
+		assert.NoError(t, err)
+		defer qe.Done()
+
+		itr, err := qe.GetStateRangeScanIterator("ns", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, 10, countResults(t, itr))
+		itr.Close()
+
+		// the limit applies across the iterators of the transaction
+		itr, err = qe.GetStateRangeScanIterator("ns", "", "")
+		assert.NoError(t, err)
+		defer itr.Close()
+		for i := 0; i < 5; i++ {
+			res, err := itr.Next()
+			assert.NoError(t, err)
+			assert.NotNil(t, res)
+		}
+		_, err = itr.Next()
+		assert.EqualError(t, err, "txid [txid2]: number of query results exceeds the limit [15]")
+	})
+
+	t.Run("maxBytesScanned", func(t *testing.T) {
+		// each result accounts for 9 bytes ("keyN" and "value")
+		viper.Set("ledger.state.queryLimits.maxBytesScannedPerTx", 30)
+		defer viper.Set("ledger.state.queryLimits.maxBytesScannedPerTx", 0)
+		qe, err := txMgr.NewQueryExecutor("txid3")
+		assert.NoError(t, err)
+		defer qe.Done()
+
+		itr, err := qe.GetStateRangeScanIterator("ns", "", "")
+		assert.NoError(t, err)
+		defer itr.Close()
+		for i := 0; i < 3; i++ {
+			_, err := itr.Next()
+			assert.NoError(t, err)
+		}
+		_, err = itr.Next()
+		assert.EqualError(t, err, "txid [txid3]: number of bytes scanned by the queries exceeds the limit [30]")
+	})
+
+	t.Run("noLimits", func(t *testing.T) {
+		qe, err := txMgr.NewQueryExecutor("txid4")
+		assert.NoError(t, err)
+		defer qe.Done()
+		for i := 0; i < 20; i++ {
+			itr, err := qe.GetStateRangeScanIterator("ns", "", "")
+			assert.NoError(t, err)
+			assert.Equal(t, 10, countResults(t, itr))
+		}
+	})
+}
+
+func TestQueryResourceTrackerClosesLeakedIterators(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr().(*LockBasedTxMgr)
+	testutilPopulateDB(t, txMgr, "ns", []*queryresult.KV{{Key: "key1", Value: []byte("value1")}}, version.NewHeight(1, 1))
+
+	qe, err := txMgr.NewQueryExecutor("txid1")
+	assert.NoError(t, err)
+	closedItr, err := qe.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	_, err = qe.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	closedItr.Close()
+
+	tracker := qe.(*lockBasedQueryExecutor).helper.resourceTracker
+	assert.Len(t, tracker.openItrs, 1)
+	leakedItr := tracker.openItrs[0]
+	qe.Done()
+	assert.Len(t, tracker.openItrs, 0)
+	assert.True(t, leakedItr.closed)
+}
+
+func countResults(t *testing.T, itr commonledger.ResultsIterator) int {
+	count := 0
+	for {
+		res, err := itr.Next()
+		assert.NoError(t, err)
+		if res == nil {
+			return count
+		}
+		count++
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+)
+
+// stateSnapshot is the snapshot of the state database read by a query executor, so that the
+// query sees a stable state without blocking the commits. The snapshot is released when the
+// query executor is done or, if a maximum age is configured, when the snapshot gets older.
+// In the latter case, the reads made afterwards fail
+type stateSnapshot struct {
+	txid        string
+	db          privacyenabledstate.DB
+	release     func()
+	releaseOnce sync.Once
+	expiryTimer *time.Timer
+	expired     int32
+}
+
+// newStateSnapshot takes a snapshot of the state database. The commit lock is held while
+// taking the snapshot, so that it reflects the state as of the last committed block
+func (txmgr *LockBasedTxMgr) newStateSnapshot(txid string, snapshotCapable privacyenabledstate.SnapshotCapable) (*stateSnapshot, error) {
+	txmgr.commitRWLock.RLock()
+	db, release, err := snapshotCapable.GetSnapshot()
+	txmgr.commitRWLock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	s := &stateSnapshot{txid: txid, db: db, release: release}
+	if txmgr.maxSnapshotAge > 0 {
+		s.expiryTimer = time.AfterFunc(txmgr.maxSnapshotAge, s.expire)
+	}
+	return s, nil
+}
+
+// snapshotCapable returns the state database if it is capable of taking snapshots and
+// snapshot isolation is enabled
+func (txmgr *LockBasedTxMgr) snapshotCapable() (privacyenabledstate.SnapshotCapable, bool) {
+	if !txmgr.snapshotIsolation {
+		return nil, false
+	}
+	snapshotCapable, ok := txmgr.db.(privacyenabledstate.SnapshotCapable)
+	if !ok || !snapshotCapable.IsSnapshotCapable() {
+		return nil, false
+	}
+	return snapshotCapable, true
+}
+
+// expire marks the snapshot as expired before releasing it, so that a read failing or
+// returning no results because of the release is reported by check
+func (s *stateSnapshot) expire() {
+	atomic.StoreInt32(&s.expired, 1)
+	logger.Warningf("txid [%s]: the snapshot of the state database read by the query exceeded the maximum age. Releasing it", s.txid)
+	s.releaseOnce.Do(s.release)
+}
+
+// check returns an error if the snapshot has expired
+func (s *stateSnapshot) check() error {
+	if atomic.LoadInt32(&s.expired) == 0 {
+		return nil
+	}
+	return &txmgr.ErrSnapshotExpired{Msg: fmt.Sprintf(
+		"txid [%s]: the snapshot of the state database read by the query exceeded the maximum age", s.txid)}
+}
+
+// close releases the snapshot
+func (s *stateSnapshot) close() {
+	if s.expiryTimer != nil {
+		s.expiryTimer.Stop()
+	}
+	s.releaseOnce.Do(s.release)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lockbasedtxmgr
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotIsolation(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr().(*LockBasedTxMgr)
+	txMgr.snapshotIsolation = true
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+
+	var data []*queryresult.KV
+	for i := 0; i < 10; i++ {
+		data = append(data, &queryresult.KV{Key: fmt.Sprintf("key%d", i), Value: []byte("value")})
+	}
+	testutilPopulateDB(t, txMgr, "ns", data, version.NewHeight(1, 1))
+
+	qe, err := txMgr.NewQueryExecutor("txid1")
+	require.NoError(t, err)
+	defer qe.Done()
+	itr, err := qe.GetStateRangeScanIterator("ns", "", "")
+	require.NoError(t, err)
+	defer itr.Close()
+	res, err := itr.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "key0", res.(*queryresult.KV).Key)
+
+	// the open query executor does not block the commit
+	committed := make(chan struct{})
+	go func() {
+		s, _ := txMgr.NewTxSimulator("txid2")
+		s.SetState("ns", "key0", []byte("updated"))
+		s.DeleteState("ns", "key5")
+		s.SetState("ns", "key91", []byte("value"))
+		s.Done()
+		rwset, _ := s.GetTxSimulationResults()
+		txMgrHelper.validateAndCommitRWSet(rwset.PubSimulationResults)
+		close(committed)
+	}()
+	select {
+	case <-committed:
+	case <-time.After(time.Minute):
+		t.Fatal("the commit is blocked by the query executor")
+	}
+
+	// the query executor keeps reading the state as of its creation
+	val, err := qe.GetState("ns", "key0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value"), val)
+	vals, err := qe.GetStateMultipleKeys("ns", []string{"key5", "key91"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("value"), nil}, vals)
+	assert.Equal(t, 9, countResults(t, itr))
+
+	qe2, err := txMgr.NewQueryExecutor("txid3")
+	require.NoError(t, err)
+	defer qe2.Done()
+	val, err = qe2.GetState("ns", "key0")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("updated"), val)
+	itr2, err := qe2.GetStateRangeScanIterator("ns", "", "")
+	require.NoError(t, err)
+	defer itr2.Close()
+	assert.Equal(t, 10, countResults(t, itr2))
+
+	qe.Done()
+	_, err = qe.GetState("ns", "key0")
+	assert.EqualError(t, err, "this instance should not be used after calling Done()")
+}
+
+func TestSnapshotExpiry(t *testing.T) {
+	testEnv := testEnvsMap[levelDBtestEnvName]
+	testEnv.init(t, "testLedger", nil)
+	defer testEnv.cleanup()
+	txMgr := testEnv.getTxMgr().(*LockBasedTxMgr)
+	txMgr.snapshotIsolation = true
+	txMgr.maxSnapshotAge = 50 * time.Millisecond
+
+	testutilPopulateDB(t, txMgr, "ns", []*queryresult.KV{{Key: "key1", Value: []byte("value1")}}, version.NewHeight(1, 1))
+
+	qe, err := txMgr.NewQueryExecutor("txid1")
+	require.NoError(t, err)
+	defer qe.Done()
+	itr, err := qe.GetStateRangeScanIterator("ns", "", "")
+	require.NoError(t, err)
+	defer itr.Close()
+	val, err := qe.GetState("ns", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("value1"), val)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = qe.GetState("ns", "key1")
+	assert.IsType(t, &txmgr.ErrSnapshotExpired{}, err)
+	assert.EqualError(t, err, "txid [txid1]: the snapshot of the state database read by the query exceeded the maximum age")
+	_, err = itr.Next()
+	assert.IsType(t, &txmgr.ErrSnapshotExpired{}, err)
+}
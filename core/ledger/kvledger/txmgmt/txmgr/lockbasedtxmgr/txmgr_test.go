@@ -122,6 +122,49 @@ func TestTxSimulatorGetResults(t *testing.T) {
 	assert.Errorf(t, err, "An error is expected when using simulator to get/set data after calling `Done` function()")
 }
 
+func TestTxSimulatorIncrements(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Run(testEnv.getName(), func(t *testing.T) {
+			testLedgerID := "testtxsimulatorincrements"
+			testEnv.init(t, testLedgerID, nil)
+			testTxSimulatorIncrements(t, testEnv)
+			testEnv.cleanup()
+		})
+	}
+}
+
+func testTxSimulatorIncrements(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	s1.SetState("ns1", "counter1", []byte("10"))
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	// simulate tx2 and tx3 that concurrently increment counter1
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	assert.NoError(t, s2.IncrementState("ns1", "counter1", 5))
+	s2.Done()
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	assert.NoError(t, s3.IncrementState("ns1", "counter1", -2))
+	assert.NoError(t, s3.IncrementState("ns1", "counter2", 1))
+	s3.Done()
+
+	// both transactions are valid, as the increments do not read the counter
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet2.PubSimulationResults)
+	txRWSet3, _ := s3.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet3.PubSimulationResults)
+
+	qe, _ := txMgr.NewQueryExecutor("test_tx4")
+	defer qe.Done()
+	value, _ := qe.GetState("ns1", "counter1")
+	assert.Equal(t, []byte("13"), value)
+	value, _ = qe.GetState("ns1", "counter2")
+	assert.Equal(t, []byte("1"), value)
+}
+
 func TestTxSimulatorWithExistingData(t *testing.T) {
 	for _, testEnv := range testEnvs {
 		t.Run(testEnv.getName(), func(t *testing.T) {
@@ -331,6 +374,90 @@ func testTxPhantomValidation(t *testing.T, env testEnv) {
 	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
 }
 
+func TestTxSimulatorDeleteStateRange(t *testing.T) {
+	for _, testEnv := range testEnvs {
+		t.Run(testEnv.getName(), func(t *testing.T) {
+			testLedgerID := "testtxsimulatordeletestaterange"
+			testEnv.init(t, testLedgerID, nil)
+			testTxSimulatorDeleteStateRange(t, testEnv)
+			testEnv.cleanup()
+		})
+	}
+}
+
+func testTxSimulatorDeleteStateRange(t *testing.T, env testEnv) {
+	txMgr := env.getTxMgr()
+	txMgrHelper := newTxMgrTestHelper(t, txMgr)
+	s1, _ := txMgr.NewTxSimulator("test_tx1")
+	for i := 1; i <= 100; i++ {
+		s1.SetState("ns", createTestKey(i), createTestValue(i))
+	}
+	s1.Done()
+	txRWSet1, _ := s1.GetTxSimulationResults()
+	txMgrHelper.validateAndCommitRWSet(txRWSet1.PubSimulationResults)
+
+	// tx2 deletes a range, recorded as a single entry of its rwset
+	s2, _ := txMgr.NewTxSimulator("test_tx2")
+	s2.SetState("ns", createTestKey(20), []byte("overridden"))
+	assert.NoError(t, s2.DeleteStateRange("ns", createTestKey(10), createTestKey(80)))
+	s2.SetState("ns", createTestKey(30), []byte("rewritten"))
+	s2.Done()
+	txRWSet2, _ := s2.GetTxSimulationResults()
+	rwSet, err := rwsetutil.TxRwSetFromProtoMsg(txRWSet2.PubSimulationResults)
+	assert.NoError(t, err)
+	kvRWSet := rwSet.NsRwSets[0].KvRwSet
+	assert.Len(t, kvRWSet.Writes, 1)
+	assert.Equal(t, createTestKey(30), kvRWSet.Writes[0].Key)
+	assert.Empty(t, kvRWSet.RangeQueriesInfo)
+	assert.Len(t, kvRWSet.RangeDeletes, 1)
+	rangeDelete := kvRWSet.RangeDeletes[0]
+	assert.Equal(t, createTestKey(10), rangeDelete.StartKey)
+	assert.Equal(t, createTestKey(80), rangeDelete.EndKey)
+	hasher := rwsetutil.NewRangeDeleteHasher()
+	for i := 10; i < 80; i++ {
+		hasher.Add(createTestKey(i), version.NewHeight(1, 0))
+	}
+	assert.Equal(t, hasher.Sum(), rangeDelete.KeysHash)
+
+	// tx3 deletes another range
+	s3, _ := txMgr.NewTxSimulator("test_tx3")
+	assert.NoError(t, s3.DeleteStateRange("ns", createTestKey(90), createTestKey(95)))
+	s3.Done()
+	txRWSet3, _ := s3.GetTxSimulationResults()
+
+	// tx4 adds a key to the range deleted by tx3
+	s4, _ := txMgr.NewTxSimulator("test_tx4")
+	s4.SetState("ns", createTestKey(92)+"_new", []byte("value"))
+	s4.Done()
+	txRWSet4, _ := s4.GetTxSimulationResults()
+
+	txMgrHelper.validateAndCommitRWSet(txRWSet2.PubSimulationResults)
+	txMgrHelper.validateAndCommitRWSet(txRWSet4.PubSimulationResults)
+	// the key added by tx4 is a phantom for tx3
+	txMgrHelper.checkRWsetInvalid(txRWSet3.PubSimulationResults)
+
+	qe, _ := txMgr.NewQueryExecutor("test_tx5")
+	defer qe.Done()
+	itr, err := qe.GetStateRangeScanIterator("ns", "", "")
+	assert.NoError(t, err)
+	defer itr.Close()
+	count := 0
+	for {
+		queryResult, _ := itr.Next()
+		if queryResult == nil {
+			break
+		}
+		key := queryResult.(*queryresult.KV).Key
+		if key == createTestKey(30) {
+			assert.Equal(t, []byte("rewritten"), queryResult.(*queryresult.KV).Value)
+		} else {
+			assert.False(t, key >= createTestKey(10) && key < createTestKey(80), "key %s should be deleted", key)
+		}
+		count++
+	}
+	assert.Equal(t, 32, count)
+}
+
 func TestIterator(t *testing.T) {
 	for _, testEnv := range testEnvs {
 		t.Logf("Running test for TestEnv = %s", testEnv.getName())
@@ -1228,6 +1355,50 @@ func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	assert.True(t, testPvtValueEqual(t, txMgr, "ns1", "coll4", "key4", nil))
 }
 
+func TestTxSimulatorGetPrivateDataHash(t *testing.T) {
+	testEnv := testEnvs[0]
+	testEnv.init(t, "TestTxSimulatorGetPrivateDataHash", nil)
+	defer testEnv.cleanup()
+
+	txMgr := testEnv.getTxMgr()
+	populateCollConfigForTest(t, txMgr.(*LockBasedTxMgr),
+		[]collConfigkey{
+			{"ns1", "coll1"},
+		},
+		version.NewHeight(1, 1),
+	)
+
+	// the peer only has the hashes of the private data
+	db := testEnv.getVDB()
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1))
+
+	sim, err := txMgr.NewTxSimulator("txid-1")
+	assert.NoError(t, err)
+	valueHash, err := sim.GetPrivateDataHash("ns1", "coll1", "key1")
+	assert.NoError(t, err)
+	assert.Equal(t, util.ComputeStringHash("value1"), valueHash)
+	valueHash, err = sim.GetPrivateDataHash("ns1", "coll1", "key2")
+	assert.NoError(t, err)
+	assert.Nil(t, valueHash)
+	_, err = sim.GetPrivateDataHash("ns1", "coll2", "key1")
+	assert.IsType(t, &ledger.InvalidCollNameError{}, err)
+
+	// the reads of the hashes are recorded in the hashed read-set and there is no private read-write set
+	simRes, err := sim.GetTxSimulationResults()
+	assert.NoError(t, err)
+	assert.Nil(t, simRes.PvtSimulationResults)
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	assert.NoError(t, err)
+	assert.Len(t, txRWSet.NsRwSets, 1)
+	assert.Len(t, txRWSet.NsRwSets[0].CollHashedRwSets, 1)
+	collHashedRwSet := txRWSet.NsRwSets[0].CollHashedRwSets[0]
+	assert.Nil(t, collHashedRwSet.PvtRwSetHash)
+	assert.Empty(t, collHashedRwSet.HashedRwSet.HashedWrites)
+	assert.Len(t, collHashedRwSet.HashedRwSet.HashedReads, 2)
+}
+
 func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 	ledgerid := "TestTxSimulatorMissingPvtdataExpiry"
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -1253,7 +1424,7 @@ func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 	// stored pvt key would get expired and purged while committing block 3
 	blkAndPvtdata := prepareNextBlockForTest(t, txMgr, bg, "txid-1",
 		map[string]string{"pubkey1": "pub-value1"}, map[string]string{"pvtkey1": "pvt-value1"}, true)
-	_, err := txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err := txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	// committing block 1
 	assert.NoError(t, txMgr.Commit())
@@ -1279,7 +1450,7 @@ func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 	// stored pvt key would get expired and purged while committing block 4
 	blkAndPvtdata = prepareNextBlockForTest(t, txMgr, bg, "txid-2",
 		map[string]string{"pubkey2": "pub-value2"}, map[string]string{"pvtkey2": "pvt-value2"}, true)
-	_, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	// committing block 2
 	assert.NoError(t, txMgr.Commit())
@@ -1289,7 +1460,7 @@ func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 
 	blkAndPvtdata = prepareNextBlockForTest(t, txMgr, bg, "txid-3",
 		map[string]string{"pubkey3": "pub-value3"}, nil, false)
-	_, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	// committing block 3
 	assert.NoError(t, txMgr.Commit())
@@ -1314,7 +1485,7 @@ func TestRemoveStaleAndCommitPvtDataOfOldBlocksWithExpiry(t *testing.T) {
 
 	blkAndPvtdata = prepareNextBlockForTest(t, txMgr, bg, "txid-4",
 		map[string]string{"pubkey4": "pub-value4"}, nil, false)
-	_, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	// committing block 4 and should purge pvtkey2
 	assert.NoError(t, txMgr.Commit())
@@ -1408,7 +1579,7 @@ func TestTxSimulatorMissingPvtdataExpiry(t *testing.T) {
 
 	blkAndPvtdata := prepareNextBlockForTest(t, txMgr, bg, "txid-1",
 		map[string]string{"pubkey1": "pub-value1"}, map[string]string{"pvtkey1": "pvt-value1"}, false)
-	_, err := txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err := txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	assert.NoError(t, txMgr.Commit())
 
@@ -1417,7 +1588,7 @@ func TestTxSimulatorMissingPvtdataExpiry(t *testing.T) {
 	blkAndPvtdata = prepareNextBlockForTest(t, txMgr, bg, "txid-2",
 
 		map[string]string{"pubkey1": "pub-value2"}, map[string]string{"pvtkey2": "pvt-value2"}, false)
-	_, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	assert.NoError(t, txMgr.Commit())
 
@@ -1425,7 +1596,7 @@ func TestTxSimulatorMissingPvtdataExpiry(t *testing.T) {
 
 	blkAndPvtdata = prepareNextBlockForTest(t, txMgr, bg, "txid-2",
 		map[string]string{"pubkey1": "pub-value3"}, map[string]string{"pvtkey3": "pvt-value3"}, false)
-	_, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata, true)
 	assert.NoError(t, err)
 	assert.NoError(t, txMgr.Commit())
 
@@ -1520,7 +1691,7 @@ func testTxWithPvtdataMetadata(t *testing.T, env testEnv, ns, coll string) {
 	s1.Done()
 
 	blkAndPvtdata1 := prepareNextBlockForTestFromSimulator(t, bg, s1)
-	_, err := txMgr.ValidateAndPrepare(blkAndPvtdata1, true)
+	_, _, err := txMgr.ValidateAndPrepare(blkAndPvtdata1, true)
 	assert.NoError(t, err)
 	assert.NoError(t, txMgr.Commit())
 
@@ -1539,7 +1710,7 @@ func testTxWithPvtdataMetadata(t *testing.T, env testEnv, ns, coll string) {
 	s2.Done()
 
 	blkAndPvtdata2 := prepareNextBlockForTestFromSimulator(t, bg, s2)
-	_, err = txMgr.ValidateAndPrepare(blkAndPvtdata2, true)
+	_, _, err = txMgr.ValidateAndPrepare(blkAndPvtdata2, true)
 	assert.NoError(t, err)
 	assert.NoError(t, txMgr.Commit())
 
@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -27,7 +28,9 @@ import (
 type TxMgr interface {
 	NewQueryExecutor(txid string) (ledger.QueryExecutor, error)
 	NewTxSimulator(txid string) (ledger.TxSimulator, error)
-	ValidateAndPrepare(blockAndPvtdata *ledger.BlockAndPvtData, doMVCCValidation bool) ([]*TxStatInfo, error)
+	// ValidateAndPrepare validates the block and prepares the updates of the state, returning
+	// a digest of the updates of the public state and of the hashes of the private data
+	ValidateAndPrepare(blockAndPvtdata *ledger.BlockAndPvtData, doMVCCValidation bool) ([]*TxStatInfo, *queryresult.StateUpdates, error)
 	RemoveStaleAndCommitPvtDataOfOldBlocks(blocksPvtData map[uint64][]*ledger.TxPvtData) error
 	GetLastSavepoint() (*version.Height, error)
 	ShouldRecover(lastAvailableBlock uint64) (bool, uint64, error)
@@ -64,3 +67,23 @@ type ErrPvtdataNotAvailable struct {
 func (e *ErrPvtdataNotAvailable) Error() string {
 	return e.Msg
 }
+
+// ErrQueryLimitExceeded is to be thrown when the queries performed during a simulation (or query)
+// exceed one of the per transaction limits on open iterators, results, or bytes scanned
+type ErrQueryLimitExceeded struct {
+	Msg string
+}
+
+func (e *ErrQueryLimitExceeded) Error() string {
+	return e.Msg
+}
+
+// ErrSnapshotExpired is to be thrown when a query reads the snapshot of the state database
+// it was given after the snapshot exceeded the configured maximum age
+type ErrSnapshotExpired struct {
+	Msg string
+}
+
+func (e *ErrSnapshotExpired) Error() string {
+	return e.Msg
+}
@@ -7,11 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package internal
 
 import (
+	"fmt"
+
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/storageutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 )
 
@@ -19,8 +22,19 @@ func prepareTxOps(rwset *rwsetutil.TxRwSet, txht *version.Height,
 	precedingUpdates *PubAndHashUpdates, db privacyenabledstate.DB) (txOps, error) {
 	txops := txOps{}
 	txops.applyTxRwset(rwset)
+	if err := txops.applyRangeDeletes(rwset, precedingUpdates, db); err != nil {
+		return nil, err
+	}
 	//logger.Debugf("prepareTxOps() txops after applying raw rwset=%#v", spew.Sdump(txops))
 	for ck, keyop := range txops {
+		// the increments are turned into upserts of the incremented value, which then get
+		// the metadata merged as any other upsert
+		if keyop.isIncrement() {
+			if err := applyIncrement(ck, keyop, precedingUpdates, db); err != nil {
+				return nil, err
+			}
+		}
+
 		// check if the final state of the key, value and metadata, is already present in the transaction, then skip
 		// otherwise we need to retrieve latest state and merge in the current value or metadata update
 		if keyop.isDelete() || keyop.isUpsertAndMetadataUpdate() {
@@ -64,6 +78,9 @@ func (txops txOps) applyTxRwset(rwset *rwsetutil.TxRwSet) error {
 		for _, kvMetadataWrite := range nsRWSet.KvRwSet.MetadataWrites {
 			txops.applyMetadata(ns, "", kvMetadataWrite)
 		}
+		for _, kvIncrement := range nsRWSet.KvRwSet.Increments {
+			txops.increment(compositeKey{ns, "", kvIncrement.Key}, kvIncrement.Delta)
+		}
 
 		// apply collection level kvwrite and kvMetadataWrite
 		for _, collHashRWset := range nsRWSet.CollHashedRwSets {
@@ -91,6 +108,27 @@ func (txops txOps) applyTxRwset(rwset *rwsetutil.TxRwSet) error {
 	return nil
 }
 
+// applyRangeDeletes records the deletion of the keys of the ranges deleted by the transaction, except for the keys upserted
+// by the transaction, which the rwset builder only keeps when they were upserted after the deletion of the range
+func (txops txOps) applyRangeDeletes(rwset *rwsetutil.TxRwSet, precedingUpdates *PubAndHashUpdates, db privacyenabledstate.DB) error {
+	for _, nsRWSet := range rwset.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, rangeDelete := range nsRWSet.KvRwSet.RangeDeletes {
+			rangeKVs, err := RangeKeys(ns, rangeDelete.StartKey, rangeDelete.EndKey, precedingUpdates, db)
+			if err != nil {
+				return err
+			}
+			for _, rangeKV := range rangeKVs {
+				keyops := txops.getOrCreateKeyEntry(compositeKey{ns, "", rangeKV.Key})
+				if keyops.flag&(upsertVal|keyDelete) == 0 {
+					keyops.flag += keyDelete
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // applyKVWrite records upsertion/deletion of a kvwrite
 func (txops txOps) applyKVWrite(ns, coll string, kvWrite *kvrwset.KVWrite) {
 	if kvWrite.IsDelete {
@@ -114,6 +152,39 @@ func (txops txOps) applyMetadata(ns, coll string, metadataWrite *kvrwset.KVMetad
 	return nil
 }
 
+// applyIncrement adds the delta of the increment to the value of the key, that is, the value written by the
+// transaction if any, or else the latest value of the key. An InvalidIncrementError is returned if the value
+// is not a counter or if the increment overflows
+func applyIncrement(ck compositeKey, keyop *keyOps,
+	precedingUpdates *PubAndHashUpdates, db privacyenabledstate.DB) error {
+	var value []byte
+	switch {
+	case keyop.isDelete():
+		keyop.flag -= keyDelete
+	case keyop.flag&upsertVal == upsertVal:
+		value = keyop.value
+		keyop.flag -= upsertVal
+	default:
+		latestVal, err := retrieveLatestState(ck.ns, ck.coll, ck.key, precedingUpdates, db)
+		if err != nil {
+			return err
+		}
+		if latestVal != nil {
+			value = latestVal.Value
+		}
+	}
+	incremented, err := rwsetutil.IncrementCounter(value, keyop.delta)
+	if err != nil {
+		return &InvalidIncrementError{
+			Msg: fmt.Sprintf("failed to increment key [%s] in namespace [%s]: %s", ck.key, ck.ns, err),
+		}
+	}
+	keyop.flag -= incrementVal
+	keyop.flag += upsertVal
+	keyop.value = incremented
+	return nil
+}
+
 // retrieveLatestState returns the value of the key from the precedingUpdates (if the key was operated upon by a previous tran in the block).
 // If the key not present in the precedingUpdates, then this function, pulls the latest value from statedb
 // TODO FAB-11328, pulling from state for (especially for couchdb) will pay significant performance penalty so a bulkload would be helpful.
@@ -138,6 +209,48 @@ func retrieveLatestState(ns, coll, key string,
 	return vv, err
 }
 
+// RangeKeys returns the keys of the range [startKey, endKey) of the public state of the namespace along with their versions,
+// sorted by key, as of the statedb updated with the precedingUpdates (the keys operated upon by previous trans in the block)
+func RangeKeys(ns, startKey, endKey string,
+	precedingUpdates *PubAndHashUpdates, db privacyenabledstate.DB) ([]*statedb.VersionedKV, error) {
+	rangeKVs := make(map[string]*statedb.VersionedKV)
+	dbItr, err := db.GetStateRangeScanIterator(ns, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer dbItr.Close()
+	for {
+		queryResult, err := dbItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			break
+		}
+		versionedKV := queryResult.(*statedb.VersionedKV)
+		rangeKVs[versionedKV.Key] = versionedKV
+	}
+	updatesItr := precedingUpdates.PubUpdates.GetRangeScanIterator(ns, startKey, endKey)
+	for {
+		queryResult, err := updatesItr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if queryResult == nil {
+			break
+		}
+		versionedKV := queryResult.(*statedb.VersionedKV)
+		if versionedKV.Value == nil {
+			delete(rangeKVs, versionedKV.Key)
+		} else {
+			rangeKVs[versionedKV.Key] = versionedKV
+		}
+	}
+	var sortedKVs []*statedb.VersionedKV
+	util.GetValuesBySortedKeys(&rangeKVs, &sortedKVs)
+	return sortedKVs, nil
+}
+
 func retrieveLatestMetadata(ns, coll, key string,
 	precedingUpdates *PubAndHashUpdates, db privacyenabledstate.DB) ([]byte, error) {
 	if coll == "" {
@@ -157,10 +270,11 @@ func retrieveLatestMetadata(ns, coll, key string,
 type keyOpsFlag uint8
 
 const (
-	upsertVal      keyOpsFlag = 1 // 1 << 0
-	metadataUpdate            = 2 // 1 << 1
-	metadataDelete            = 4 // 1 << 2
-	keyDelete                 = 8 // 1 << 3
+	upsertVal      keyOpsFlag = 1  // 1 << 0
+	metadataUpdate            = 2  // 1 << 1
+	metadataDelete            = 4  // 1 << 2
+	keyDelete                 = 8  // 1 << 3
+	incrementVal              = 16 // 1 << 4
 )
 
 type compositeKey struct {
@@ -173,6 +287,17 @@ type keyOps struct {
 	flag     keyOpsFlag
 	value    []byte
 	metadata []byte
+	delta    int64
+}
+
+// InvalidIncrementError is returned when the increments of a transaction cannot be applied
+// to the committed state, which invalidates the transaction
+type InvalidIncrementError struct {
+	Msg string
+}
+
+func (e *InvalidIncrementError) Error() string {
+	return e.Msg
 }
 
 ////////////////// txOps functions
@@ -183,6 +308,12 @@ func (txops txOps) upsert(k compositeKey, val []byte) {
 	keyops.value = val
 }
 
+func (txops txOps) increment(k compositeKey, delta int64) {
+	keyops := txops.getOrCreateKeyEntry(k)
+	keyops.flag += incrementVal
+	keyops.delta = delta
+}
+
 func (txops txOps) delete(k compositeKey) {
 	keyops := txops.getOrCreateKeyEntry(k)
 	keyops.flag += keyDelete
@@ -210,6 +341,10 @@ func (txops txOps) getOrCreateKeyEntry(k compositeKey) *keyOps {
 
 ////////////////// keyOps functions
 
+func (keyops keyOps) isIncrement() bool {
+	return keyops.flag&(incrementVal) == incrementVal
+}
+
 func (keyops keyOps) isDelete() bool {
 	return keyops.flag&(keyDelete) == keyDelete
 }
@@ -385,6 +385,140 @@ func TestTxOpsPreparationPvtdataHashes(t *testing.T) {
 	assert.Equal(t, ck4ExpectedKeyOps, txOps[ck4Hash])
 }
 
+func TestTxOpsPreparationIncrements(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	ck1, ck2, ck3, ck4 :=
+		compositeKey{ns: "ns1", key: "key1"},
+		compositeKey{ns: "ns1", key: "key2"},
+		compositeKey{ns: "ns1", key: "key3"},
+		compositeKey{ns: "ns1", key: "key4"}
+
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.PubUpdates.PutValAndMetadata( // write key1 with value and metadata
+		ck1.ns, ck1.key,
+		[]byte("10"),
+		testutilSerializedMetadata(t, map[string][]byte{"metadata1": []byte("metadata1")}),
+		version.NewHeight(1, 1))
+	updateBatch.PubUpdates.Put(ck2.ns, ck2.key, []byte("20"), version.NewHeight(1, 2))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 2)) //write the above initial state to db
+
+	precedingUpdates := NewPubAndHashUpdates() // key2 updated by a preceding transaction of the block
+	precedingUpdates.PubUpdates.Put(ck2.ns, ck2.key, []byte("5"), version.NewHeight(2, 0))
+
+	rwset := testutilBuildRwset(t, nil, nil)
+	rwset.NsRwSets = append(rwset.NsRwSets, &rwsetutil.NsRwSet{
+		NameSpace: "ns1",
+		KvRwSet: &kvrwset.KVRWSet{
+			Writes: []*kvrwset.KVWrite{{Key: ck4.key, Value: []byte("2")}},
+			Increments: []*kvrwset.KVIncrement{
+				{Key: ck1.key, Delta: 5},
+				{Key: ck2.key, Delta: -7},
+				{Key: ck3.key, Delta: 1},
+				{Key: ck4.key, Delta: 3},
+			},
+		},
+	})
+
+	txOps, err := prepareTxOps(rwset, version.NewHeight(2, 1), precedingUpdates, db)
+	assert.NoError(t, err)
+	assert.Len(t, txOps, 4)
+
+	ck1ExpectedKeyOps := &keyOps{ // key1 should have the incremented committed value and existing metadata
+		flag:     upsertVal,
+		value:    []byte("15"),
+		metadata: testutilSerializedMetadata(t, map[string][]byte{"metadata1": []byte("metadata1")}),
+		delta:    5,
+	}
+	ck2ExpectedKeyOps := &keyOps{ // key2 should have the incremented value of the preceding update
+		flag:  upsertVal,
+		value: []byte("-2"),
+		delta: -7,
+	}
+	ck3ExpectedKeyOps := &keyOps{ // key3 does not exist and should be incremented from zero
+		flag:  upsertVal,
+		value: []byte("1"),
+		delta: 1,
+	}
+	ck4ExpectedKeyOps := &keyOps{ // key4 should have the incremented value written by the transaction
+		flag:  upsertVal,
+		value: []byte("5"),
+		delta: 3,
+	}
+
+	assert.Equal(t, ck1ExpectedKeyOps, txOps[ck1])
+	assert.Equal(t, ck2ExpectedKeyOps, txOps[ck2])
+	assert.Equal(t, ck3ExpectedKeyOps, txOps[ck3])
+	assert.Equal(t, ck4ExpectedKeyOps, txOps[ck4])
+
+	// increments of a value that is not a counter
+	precedingUpdates.PubUpdates.Put(ck3.ns, ck3.key, []byte("value3"), version.NewHeight(2, 0))
+	_, err = prepareTxOps(rwset, version.NewHeight(2, 1), precedingUpdates, db)
+	assert.IsType(t, &InvalidIncrementError{}, err)
+	assert.EqualError(t, err, "failed to increment key [key3] in namespace [ns1]: value [value3] is not a counter")
+}
+
+func TestTxOpsPreparationRangeDeletes(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	ck1, ck2, ck3, ck4, ck5, ck6 :=
+		compositeKey{ns: "ns1", key: "key1"},
+		compositeKey{ns: "ns1", key: "key2"},
+		compositeKey{ns: "ns1", key: "key3"},
+		compositeKey{ns: "ns1", key: "key4"},
+		compositeKey{ns: "ns1", key: "key5"},
+		compositeKey{ns: "ns1", key: "key6"}
+
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.PubUpdates.Put(ck1.ns, ck1.key, []byte("value1"), version.NewHeight(1, 1))
+	updateBatch.PubUpdates.Put(ck2.ns, ck2.key, []byte("value2"), version.NewHeight(1, 1))
+	updateBatch.PubUpdates.Put(ck3.ns, ck3.key, []byte("value3"), version.NewHeight(1, 1))
+	updateBatch.PubUpdates.Put(ck4.ns, ck4.key, []byte("value4"), version.NewHeight(1, 1))
+	updateBatch.PubUpdates.Put(ck6.ns, ck6.key, []byte("value6"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1)) //write the above initial state to db
+
+	precedingUpdates := NewPubAndHashUpdates() // key2 deleted and key5 added by preceding transactions of the block
+	precedingUpdates.PubUpdates.Delete(ck2.ns, ck2.key, version.NewHeight(2, 0))
+	precedingUpdates.PubUpdates.Put(ck5.ns, ck5.key, []byte("value5"), version.NewHeight(2, 0))
+
+	rangeKVs, err := RangeKeys("ns1", "key1", "key6", precedingUpdates, db)
+	assert.NoError(t, err)
+	var rangeKeys []string
+	for _, rangeKV := range rangeKVs {
+		rangeKeys = append(rangeKeys, rangeKV.Key)
+	}
+	assert.Equal(t, []string{"key1", "key3", "key4", "key5"}, rangeKeys)
+
+	rwset := testutilBuildRwset(t, nil, nil)
+	rwset.NsRwSets = append(rwset.NsRwSets, &rwsetutil.NsRwSet{
+		NameSpace: "ns1",
+		KvRwSet: &kvrwset.KVRWSet{
+			// the keys written after the deletion of the range are not deleted
+			Writes:         []*kvrwset.KVWrite{{Key: ck3.key, Value: []byte("value3-new")}},
+			MetadataWrites: []*kvrwset.KVMetadataWrite{{Key: ck4.key, Entries: []*kvrwset.KVMetadataEntry{{Name: "metadata4", Value: []byte("metadata4")}}}},
+			RangeDeletes:   []*kvrwset.KVRangeDelete{{StartKey: ck1.key, EndKey: ck6.key}},
+		},
+	})
+
+	txOps, err := prepareTxOps(rwset, version.NewHeight(2, 1), precedingUpdates, db)
+	assert.NoError(t, err)
+	assert.Len(t, txOps, 4)
+
+	assert.Equal(t, &keyOps{flag: keyDelete}, txOps[ck1])
+	assert.Equal(t, &keyOps{flag: upsertVal, value: []byte("value3-new")}, txOps[ck3])
+	assert.Equal(t, &keyOps{ // key4 is deleted along with its metadata
+		flag:     keyDelete + metadataUpdate,
+		metadata: testutilSerializedMetadata(t, map[string][]byte{"metadata4": []byte("metadata4")}),
+	}, txOps[ck4])
+	assert.Equal(t, &keyOps{flag: keyDelete}, txOps[ck5])
+}
+
 func testutilBuildRwset(t *testing.T,
 	kvWrites map[compositeKey][]byte,
 	metadataWrites map[compositeKey]map[string][]byte) *rwsetutil.TxRwSet {
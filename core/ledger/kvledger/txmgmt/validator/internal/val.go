@@ -36,6 +36,8 @@ type Transaction struct {
 	ID             string
 	RWSet          *rwsetutil.TxRwSet
 	ValidationCode peer.TxValidationCode
+	// MVCCConflict is the read that caused the validation code MVCC_READ_CONFLICT, if any
+	MVCCConflict *peer.MVCCConflict
 }
 
 // PubAndHashUpdates encapsulates public and hash updates. The intended use of this to hold the updates
@@ -6,6 +6,8 @@ SPDX-License-Identifier: Apache-2.0
 package statebasedval
 
 import (
+	"bytes"
+
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
@@ -100,16 +102,34 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 	updates := internal.NewPubAndHashUpdates()
 	for _, tx := range block.Txs {
 		var validationCode peer.TxValidationCode
+		var conflict *peer.MVCCConflict
 		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
+		if validationCode, conflict, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
 			return nil, err
 		}
 
 		tx.ValidationCode = validationCode
+		if conflict != nil {
+			conflict.TxIndex = uint64(tx.IndexInBlock)
+			tx.MVCCConflict = conflict
+		}
 		if validationCode == peer.TxValidationCode_VALID {
-			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator", block.Num, tx.IndexInBlock, tx.ID)
 			committingTxHeight := version.NewHeight(block.Num, uint64(tx.IndexInBlock))
-			updates.ApplyWriteSet(tx.RWSet, committingTxHeight, v.db)
+			err := updates.ApplyWriteSet(tx.RWSet, committingTxHeight, v.db)
+			if err == nil {
+				logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator", block.Num, tx.IndexInBlock, tx.ID)
+				continue
+			}
+			// the increments that cannot be applied to the committed state invalidate the transaction
+			if _, ok := err.(*internal.InvalidIncrementError); !ok {
+				return nil, err
+			}
+			tx.ValidationCode = peer.TxValidationCode_INVALID_WRITESET
+			logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]. %s",
+				block.Num, tx.IndexInBlock, tx.ID, tx.ValidationCode.String(), err)
+		} else if tx.MVCCConflict != nil {
+			logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]. Conflicting read [%s]",
+				block.Num, tx.IndexInBlock, tx.ID, validationCode.String(), tx.MVCCConflict)
 		} else {
 			logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]",
 				block.Num, tx.IndexInBlock, tx.ID, validationCode.String())
@@ -122,69 +142,85 @@ func (v *Validator) ValidateAndPrepareBatch(block *internal.Block, doMVCCValidat
 func (v *Validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
 	doMVCCValidation bool,
-	updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+	updates *internal.PubAndHashUpdates) (peer.TxValidationCode, *peer.MVCCConflict, error) {
 
 	var validationCode = peer.TxValidationCode_VALID
+	var conflict *peer.MVCCConflict
 	var err error
 	//mvccvalidation, may invalidate transaction
 	if doMVCCValidation {
-		validationCode, err = v.validateTx(txRWSet, updates)
+		validationCode, conflict, err = v.validateTx(txRWSet, updates)
 	}
-	return validationCode, err
+	return validationCode, conflict, err
 }
 
-func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *internal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+// validateTx returns, along with the validation code of the transaction, the read that caused the
+// invalidation of the transaction with the validation code MVCC_READ_CONFLICT
+func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *internal.PubAndHashUpdates) (peer.TxValidationCode, *peer.MVCCConflict, error) {
 	// Uncomment the following only for local debugging. Don't want to print data in the logs in production
 	//logger.Debugf("validateTx - validating txRWSet: %s", spew.Sdump(txRWSet))
 	for _, nsRWSet := range txRWSet.NsRwSets {
 		ns := nsRWSet.NameSpace
 		// Validate public reads
-		if valid, err := v.validateReadSet(ns, nsRWSet.KvRwSet.Reads, updates.PubUpdates); !valid || err != nil {
+		if conflict, err := v.validateReadSet(ns, nsRWSet.KvRwSet.Reads, updates.PubUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			return peer.TxValidationCode_MVCC_READ_CONFLICT, conflict, nil
 		}
 		// Validate range queries for phantom items
 		if valid, err := v.validateRangeQueries(ns, nsRWSet.KvRwSet.RangeQueriesInfo, updates.PubUpdates); !valid || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
+			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil, nil
+		}
+		// Validate range deletes for phantom items
+		if valid, err := v.validateRangeDeletes(ns, nsRWSet.KvRwSet.RangeDeletes, updates); !valid || err != nil {
+			if err != nil {
+				return peer.TxValidationCode(-1), nil, err
+			}
+			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil, nil
 		}
 		// Validate hashes for private reads
-		if valid, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.HashUpdates); !valid || err != nil {
+		if conflict, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.HashUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			return peer.TxValidationCode_MVCC_READ_CONFLICT, conflict, nil
 		}
 	}
-	return peer.TxValidationCode_VALID, nil
+	return peer.TxValidationCode_VALID, nil, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 /////                 Validation of public read-set
 ////////////////////////////////////////////////////////////////////////////////
-func (v *Validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+func (v *Validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, kvRead := range kvReads {
-		if valid, err := v.validateKVRead(ns, kvRead, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateKVRead(ns, kvRead, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateKVRead performs mvcc check for a key read during transaction simulation.
 // i.e., it checks whether a key/version combination is already updated in the statedb (by an already committed block)
-// or in the updates (by a preceding valid transaction in the current block)
-func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+// or in the updates (by a preceding valid transaction in the current block). The conflicting read, if any, is returned
+func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (*peer.MVCCConflict, error) {
 	if updates.Exists(ns, kvRead.Key) {
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Key:              kvRead.Key,
+			ReadVersion:      kvRead.Version,
+			CommittedVersion: protoVersion(updates.Get(ns, kvRead.Key).Version),
+			UpdatedInBlock:   true,
+		}, nil
 	}
 	committedVersion, err := v.db.GetVersion(ns, kvRead.Key)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	logger.Debugf("Comparing versions for key [%s]: committed version=%#v and read version=%#v",
@@ -192,9 +228,14 @@ func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *p
 	if !version.AreSame(committedVersion, rwsetutil.NewVersion(kvRead.Version)) {
 		logger.Debugf("Version mismatch for key [%s:%s]. Committed version = [%#v], Version in readSet [%#v]",
 			ns, kvRead.Key, committedVersion, kvRead.Version)
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Key:              kvRead.Key,
+			ReadVersion:      kvRead.Version,
+			CommittedVersion: protoVersion(committedVersion),
+		}, nil
 	}
-	return true, nil
+	return nil, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -239,46 +280,94 @@ func (v *Validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 	return validator.validate()
 }
 
+////////////////////////////////////////////////////////////////////////////////
+/////                 Validation of range deletes
+////////////////////////////////////////////////////////////////////////////////
+func (v *Validator) validateRangeDeletes(ns string, rangeDeletes []*kvrwset.KVRangeDelete, updates *internal.PubAndHashUpdates) (bool, error) {
+	for _, rangeDelete := range rangeDeletes {
+		if valid, err := v.validateRangeDelete(ns, rangeDelete, updates); !valid || err != nil {
+			return valid, err
+		}
+	}
+	return true, nil
+}
+
+// validateRangeDelete performs a phantom read check for a range deleted by the transaction i.e., it checks whether the
+// fingerprint of the keys of the range computed during simulation is still the same when computed on the statedb + updates,
+// since the keys that get deleted at commit time are the keys of the range as of the statedb + updates
+func (v *Validator) validateRangeDelete(ns string, rangeDelete *kvrwset.KVRangeDelete, updates *internal.PubAndHashUpdates) (bool, error) {
+	logger.Debugf("validateRangeDelete: ns=%s, rangeDelete=%s", ns, rangeDelete)
+	rangeKVs, err := internal.RangeKeys(ns, rangeDelete.StartKey, rangeDelete.EndKey, updates, v.db)
+	if err != nil {
+		return false, err
+	}
+	hasher := rwsetutil.NewRangeDeleteHasher()
+	for _, rangeKV := range rangeKVs {
+		hasher.Add(rangeKV.Key, rangeKV.Version)
+	}
+	return bytes.Equal(hasher.Sum(), rangeDelete.KeysHash), nil
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 /////                 Validation of hashed read-set
 ////////////////////////////////////////////////////////////////////////////////
 func (v *Validator) validateNsHashedReadSets(ns string, collHashedRWSets []*rwsetutil.CollHashedRwSet,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, collHashedRWSet := range collHashedRWSets {
-		if valid, err := v.validateCollHashedReadSet(ns, collHashedRWSet.CollectionName, collHashedRWSet.HashedRwSet.HashedReads, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateCollHashedReadSet(ns, collHashedRWSet.CollectionName, collHashedRWSet.HashedRwSet.HashedReads, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 func (v *Validator) validateCollHashedReadSet(ns, coll string, kvReadHashes []*kvrwset.KVReadHash,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, kvReadHash := range kvReadHashes {
-		if valid, err := v.validateKVReadHash(ns, coll, kvReadHash, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateKVReadHash(ns, coll, kvReadHash, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateKVReadHash performs mvcc check for a hash of a key that is present in the private data space
 // i.e., it checks whether a key/version combination is already updated in the statedb (by an already committed block)
-// or in the updates (by a preceding valid transaction in the current block)
+// or in the updates (by a preceding valid transaction in the current block). The conflicting read, if any, is returned
 func (v *Validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVReadHash,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*peer.MVCCConflict, error) {
 	if updates.Contains(ns, coll, kvReadHash.KeyHash) {
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Collection:       coll,
+			KeyHash:          kvReadHash.KeyHash,
+			ReadVersion:      kvReadHash.Version,
+			CommittedVersion: protoVersion(updates.Get(ns, coll, string(kvReadHash.KeyHash)).Version),
+			UpdatedInBlock:   true,
+		}, nil
 	}
 	committedVersion, err := v.db.GetKeyHashVersion(ns, coll, kvReadHash.KeyHash)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if !version.AreSame(committedVersion, rwsetutil.NewVersion(kvReadHash.Version)) {
 		logger.Debugf("Version mismatch for key hash [%s:%s:%#v]. Committed version = [%s], Version in hashedReadSet [%s]",
 			ns, coll, kvReadHash.KeyHash, committedVersion, kvReadHash.Version)
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Collection:       coll,
+			KeyHash:          kvReadHash.KeyHash,
+			ReadVersion:      kvReadHash.Version,
+			CommittedVersion: protoVersion(committedVersion),
+		}, nil
 	}
-	return true, nil
+	return nil, nil
+}
+
+func protoVersion(height *version.Height) *kvrwset.Version {
+	if height == nil {
+		return nil
+	}
+	return &kvrwset.Version{BlockNum: height.BlockNum, TxNum: height.TxNum}
 }
@@ -219,6 +219,127 @@ func TestValidator(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder4, rwsetBuilder5), []int{1})
 }
 
+func TestMVCCConflictDiagnostics(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("pvtKey1"), []byte("value1"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 1))
+
+	validator := NewValidator(db)
+
+	// stale read of a committed key
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key1", version.NewHeight(0, 1))
+	// read of a key that does not exist anymore
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key2", version.NewHeight(0, 2))
+	// valid transaction that updates key1
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	rwsetBuilder3.AddToWriteSet("ns1", "key1", []byte("value1_new"))
+	// read of key1 updated by the preceding transaction
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToReadSet("ns1", "key1", version.NewHeight(1, 0))
+	// stale read of a private key
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder5.AddToHashedReadSet("ns1", "coll1", "pvtKey1", nil)
+
+	var trans []*internal.Transaction
+	for i, tranRWSet := range getTestPubSimulationRWSet(t, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4, rwsetBuilder5) {
+		trans = append(trans, &internal.Transaction{
+			ID:             fmt.Sprintf("txid-%d", i),
+			IndexInBlock:   i,
+			ValidationCode: peer.TxValidationCode_VALID,
+			RWSet:          tranRWSet,
+		})
+	}
+	_, err := validator.ValidateAndPrepareBatch(&internal.Block{Num: 2, Txs: trans}, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &peer.MVCCConflict{
+		TxIndex:          0,
+		Namespace:        "ns1",
+		Key:              "key1",
+		ReadVersion:      &kvrwset.Version{BlockNum: 0, TxNum: 1},
+		CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 0},
+	}, trans[0].MVCCConflict)
+	assert.Equal(t, &peer.MVCCConflict{
+		TxIndex:     1,
+		Namespace:   "ns1",
+		Key:         "key2",
+		ReadVersion: &kvrwset.Version{BlockNum: 0, TxNum: 2},
+	}, trans[1].MVCCConflict)
+	assert.Equal(t, peer.TxValidationCode_VALID, trans[2].ValidationCode)
+	assert.Nil(t, trans[2].MVCCConflict)
+	assert.Equal(t, &peer.MVCCConflict{
+		TxIndex:          3,
+		Namespace:        "ns1",
+		Key:              "key1",
+		ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 0},
+		CommittedVersion: &kvrwset.Version{BlockNum: 2, TxNum: 2},
+		UpdatedInBlock:   true,
+	}, trans[3].MVCCConflict)
+	assert.Equal(t, &peer.MVCCConflict{
+		TxIndex:          4,
+		Namespace:        "ns1",
+		Collection:       "coll1",
+		KeyHash:          util.ComputeStringHash("pvtKey1"),
+		CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 1},
+	}, trans[4].MVCCConflict)
+}
+
+func TestIncrementsValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "counter1", []byte("10"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 1))
+
+	validator := NewValidator(db)
+
+	// concurrent increments of the same counter do not conflict
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	assert.NoError(t, rwsetBuilder1.AddToIncrementSet("ns1", "counter1", 5))
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	assert.NoError(t, rwsetBuilder2.AddToIncrementSet("ns1", "counter1", -3))
+	assert.NoError(t, rwsetBuilder2.AddToIncrementSet("ns1", "counter2", 1))
+	// increment of a key that does not hold a counter
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	assert.NoError(t, rwsetBuilder3.AddToIncrementSet("ns1", "key1", 1))
+	assert.NoError(t, rwsetBuilder3.AddToIncrementSet("ns1", "counter1", 100))
+
+	var trans []*internal.Transaction
+	for i, tranRWSet := range getTestPubSimulationRWSet(t, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3) {
+		trans = append(trans, &internal.Transaction{
+			ID:             fmt.Sprintf("txid-%d", i),
+			IndexInBlock:   i,
+			ValidationCode: peer.TxValidationCode_VALID,
+			RWSet:          tranRWSet,
+		})
+	}
+	updates, err := validator.ValidateAndPrepareBatch(&internal.Block{Num: 2, Txs: trans}, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, peer.TxValidationCode_VALID, trans[0].ValidationCode)
+	assert.Equal(t, peer.TxValidationCode_VALID, trans[1].ValidationCode)
+	assert.Equal(t, peer.TxValidationCode_INVALID_WRITESET, trans[2].ValidationCode)
+
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("12"), Version: version.NewHeight(2, 1)},
+		updates.PubUpdates.Get("ns1", "counter1"))
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("1"), Version: version.NewHeight(2, 1)},
+		updates.PubUpdates.Get("ns1", "counter2"))
+	assert.Nil(t, updates.PubUpdates.Get("ns1", "key1"))
+}
+
 func TestPhantomValidation(t *testing.T) {
 	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
 	testDBEnv.Init(t)
@@ -290,6 +411,63 @@ func TestPhantomValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder6, rwsetBuilder7), []int{1})
 }
 
+func TestRangeDeletesValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.PubUpdates.Put("ns1", "key3", []byte("value3"), version.NewHeight(1, 2))
+	batch.PubUpdates.Put("ns1", "key4", []byte("value4"), version.NewHeight(1, 3))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 3))
+
+	validator := NewValidator(db)
+	keysHash := func(keys ...string) []byte {
+		hasher := rwsetutil.NewRangeDeleteHasher()
+		for i, key := range keys {
+			hasher.Add(key, version.NewHeight(1, uint64(i+1)))
+		}
+		return hasher.Sum()
+	}
+
+	// rwset1 is valid and deletes the keys of the range but the key it writes
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToRangeDeleteSet("ns1", "key2", "key4", keysHash("key2", "key3"))
+	rwsetBuilder1.AddToWriteSet("ns1", "key3", []byte("value3-new"))
+	// rwset2 is not valid - the fingerprint of an empty range does not match the keys of the range
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToRangeDeleteSet("ns1", "key4", "", keysHash())
+	// rwset3 is not valid - the keys of the range have been changed by rwset1
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToRangeDeleteSet("ns1", "key2", "key4", keysHash("key2", "key3"))
+
+	var trans []*internal.Transaction
+	for i, tranRWSet := range getTestPubSimulationRWSet(t, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3) {
+		trans = append(trans, &internal.Transaction{
+			ID:             fmt.Sprintf("txid-%d", i),
+			IndexInBlock:   i,
+			ValidationCode: peer.TxValidationCode_VALID,
+			RWSet:          tranRWSet,
+		})
+	}
+	updates, err := validator.ValidateAndPrepareBatch(&internal.Block{Num: 2, Txs: trans}, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, peer.TxValidationCode_VALID, trans[0].ValidationCode)
+	assert.Equal(t, peer.TxValidationCode_PHANTOM_READ_CONFLICT, trans[1].ValidationCode)
+	assert.Equal(t, peer.TxValidationCode_PHANTOM_READ_CONFLICT, trans[2].ValidationCode)
+
+	assert.Equal(t, &statedb.VersionedValue{Value: nil, Version: version.NewHeight(2, 0)},
+		updates.PubUpdates.Get("ns1", "key2"))
+	assert.Equal(t, &statedb.VersionedValue{Value: []byte("value3-new"), Version: version.NewHeight(2, 0)},
+		updates.PubUpdates.Get("ns1", "key3"))
+	assert.Nil(t, updates.PubUpdates.Get("ns1", "key1"))
+	assert.Nil(t, updates.PubUpdates.Get("ns1", "key4"))
+}
+
 func TestPhantomHashBasedValidation(t *testing.T) {
 	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
 	testDBEnv.Init(t)
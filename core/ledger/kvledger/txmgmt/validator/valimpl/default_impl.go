@@ -60,7 +60,9 @@ func (impl *DefaultImpl) ValidateAndPrepareBatch(blockAndPvtdata *ledger.BlockAn
 		return nil, nil, err
 	}
 	logger.Debug("postprocessing ProtoBlock...")
-	postprocessProtoBlock(block, internalBlock)
+	if err = postprocessProtoBlock(block, internalBlock); err != nil {
+		return nil, nil, err
+	}
 	logger.Debug("ValidateAndPrepareBatch() complete")
 
 	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
@@ -214,12 +214,18 @@ func validateWriteset(txRWSet *rwsetutil.TxRwSet, validateKVFunc func(key string
 }
 
 // postprocessProtoBlock updates the proto block's validation flags (in metadata) by the results of validation process
-func postprocessProtoBlock(block *common.Block, validatedBlock *internal.Block) {
+// and records the conflicting reads of the transactions invalidated with the validation code MVCC_READ_CONFLICT
+func postprocessProtoBlock(block *common.Block, validatedBlock *internal.Block) error {
 	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	diagnostics := &peer.CommitDiagnostics{}
 	for _, tx := range validatedBlock.Txs {
 		txsFilter.SetFlag(tx.IndexInBlock, tx.ValidationCode)
+		if tx.ValidationCode == peer.TxValidationCode_MVCC_READ_CONFLICT && tx.MVCCConflict != nil {
+			diagnostics.MvccConflicts = append(diagnostics.MvccConflicts, tx.MVCCConflict)
+		}
 	}
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+	return util.SetCommitDiagnostics(block, diagnostics)
 }
 
 func addPvtRWSetToPvtUpdateBatch(pvtRWSet *rwsetutil.TxPvtRwSet, pvtUpdateBatch *privacyenabledstate.PvtUpdateBatch, ver *version.Height) {
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/flogging/floggingtest"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
@@ -25,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	lutils "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	logging "github.com/op/go-logging"
@@ -136,10 +138,42 @@ func TestValidateAndPreparePvtBatch(t *testing.T) {
 
 	expectedtxsFilter := []uint8{uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_INVALID_OTHER_REASON)}
 
-	postprocessProtoBlock(block, mvccValidatedBlock)
+	assert.NoError(t, postprocessProtoBlock(block, mvccValidatedBlock))
 	assert.Equal(t, expectedtxsFilter, block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 }
 
+func TestPostprocessProtoBlockDiagnostics(t *testing.T) {
+	block := testutil.ConstructTestBlock(t, 10, 3, 1)
+	block.Metadata.Metadata = block.Metadata.Metadata[:common.BlockMetadataIndex_COMMIT_DIAGNOSTICS]
+	conflict := &peer.MVCCConflict{TxIndex: 1, Namespace: "ns1", Key: "key1", ReadVersion: &kvrwset.Version{BlockNum: 1}}
+	validatedBlock := &internal.Block{
+		Num: 10,
+		Txs: []*internal.Transaction{
+			{IndexInBlock: 0, ValidationCode: peer.TxValidationCode_VALID},
+			{IndexInBlock: 1, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, MVCCConflict: conflict},
+			{IndexInBlock: 2, ValidationCode: peer.TxValidationCode_PHANTOM_READ_CONFLICT},
+		},
+	}
+
+	// the metadata of the block is extended to hold the diagnostics
+	assert.NoError(t, postprocessProtoBlock(block, validatedBlock))
+	diagnostics, err := lutils.GetCommitDiagnostics(block)
+	assert.NoError(t, err)
+	assert.Len(t, diagnostics.MvccConflicts, 1)
+	assert.True(t, proto.Equal(conflict, diagnostics.MVCCConflict(1)))
+	assert.Nil(t, diagnostics.MVCCConflict(2))
+
+	// the metadata is left untouched for a block without conflicts
+	block = testutil.ConstructTestBlock(t, 11, 3, 1)
+	expectedMetadata := proto.Clone(block.Metadata)
+	validatedBlock.Txs[1].ValidationCode = peer.TxValidationCode_VALID
+	assert.NoError(t, postprocessProtoBlock(block, validatedBlock))
+	assert.Equal(t, expectedMetadata.(*common.BlockMetadata).Metadata[common.BlockMetadataIndex_COMMIT_DIAGNOSTICS], block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_DIAGNOSTICS])
+	diagnostics, err = lutils.GetCommitDiagnostics(block)
+	assert.NoError(t, err)
+	assert.Empty(t, diagnostics.MvccConflicts)
+}
+
 func TestPreprocessProtoBlock(t *testing.T) {
 	allwaysValidKVfunc := func(key string, value []byte) error {
 		return nil
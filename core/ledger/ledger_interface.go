@@ -14,6 +14,7 @@ import (
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -41,6 +42,8 @@ type PeerLedgerProvider interface {
 	Exists(ledgerID string) (bool, error)
 	// List lists the ids of the existing ledgers
 	List() ([]string, error)
+	// Remove removes the ledger with the given id, which is expected to be closed
+	Remove(ledgerID string) error
 	// Close closes the PeerLedgerProvider
 	Close()
 }
@@ -79,6 +82,15 @@ type PeerLedger interface {
 	GetPvtDataByNum(blockNum uint64, filter PvtNsCollFilter) ([]*TxPvtData, error)
 	// CommitWithPvtData commits the block and the corresponding pvt data in an atomic operation
 	CommitWithPvtData(blockAndPvtdata *BlockAndPvtData) error
+	// EnableCommitHash sets whether the commit hash is recorded in the metadata of the blocks committed subsequently.
+	// The commit hash of a block is a hash of its validation flags, of the updates of the state by its valid
+	// transactions and of the commit hash of the previous block, which the peers of a channel are expected to agree on
+	EnableCommitHash(enabled bool)
+	// GetStateProof returns a proof that the given key of the given namespace was set to its current value by a valid
+	// transaction of the block it was last updated by, which is anchored to the commit hash of this block. A proof is
+	// available only if the digest of the updates of the state by this block was recorded (see the configuration
+	// ledger.state.proofs.enabled). It proves neither that the key was not updated since, nor the absence of a key
+	GetStateProof(namespace, key string) (*queryresult.StateProof, error)
 	// Purge removes private read-writes set generated by endorsers at block height lesser than
 	// a given maxBlockNumToRetain. In other words, Purge only retains private read-write sets
 	// that were generated at block height of maxBlockNumToRetain or higher.
@@ -151,6 +163,10 @@ type QueryExecutor interface {
 	GetPrivateDataMetadata(namespace, collection, key string) (map[string][]byte, error)
 	// GetPrivateDataMetadataByHash gets the metadata of a private data item identified by a tuple <namespace, collection, keyhash>
 	GetPrivateDataMetadataByHash(namespace, collection string, keyhash []byte) (map[string][]byte, error)
+	// GetPrivateDataHash gets the hash of the value of a private data item identified by a tuple <namespace, collection, key>.
+	// Unlike GetPrivateData, it can be invoked on any peer of the channel, whether or not the peer is eligible
+	// for the private data of the collection, and it records a read of the hashed key in the read-write set
+	GetPrivateDataHash(namespace, collection, key string) ([]byte, error)
 	// GetPrivateDataMultipleKeys gets the values for the multiple private data items in a single call
 	GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error)
 	// GetPrivateDataRangeScanIterator returns an iterator that contains all the key-values between given key ranges.
@@ -183,12 +199,21 @@ type TxSimulator interface {
 	SetState(namespace string, key string, value []byte) error
 	// DeleteState deletes the given namespace and key
 	DeleteState(namespace string, key string) error
+	// DeleteStateRange deletes the keys in the range [startKey, endKey) of the given namespace. The range is recorded
+	// in the writeset as a single range delete, along with the fingerprint of the keys of the range and of their versions,
+	// and the keys are deleted at commit time. The transaction is invalidated (phantom read conflict) if the keys of the
+	// range have changed before the transaction is committed
+	DeleteStateRange(namespace, startKey, endKey string) error
 	// SetMultipleKeys sets the values for multiple keys in a single call
 	SetStateMultipleKeys(namespace string, kvs map[string][]byte) error
 	// SetStateMetadata sets the metadata associated with an existing key-tuple <namespace, key>
 	SetStateMetadata(namespace, key string, metadata map[string][]byte) error
 	// DeleteStateMetadata deletes the metadata (if any) associated with an existing key-tuple <namespace, key>
 	DeleteStateMetadata(namespace, key string) error
+	// IncrementState adds the given delta to the counter stored at the given namespace and key. The delta is
+	// applied to the value committed at the time the transaction is committed, hence, unlike a read followed
+	// by a SetState, concurrent increments of the same key do not invalidate each other
+	IncrementState(namespace string, key string, delta int64) error
 	// ExecuteUpdate for supporting rich data model (see comments on QueryExecutor above)
 	ExecuteUpdate(query string) error
 	// SetPrivateData sets the given value to a key in the private data state represented by the tuple <namespace, collection, key>
@@ -8,7 +8,10 @@ package ledgerconfig
 
 import (
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/spf13/viper"
 )
@@ -35,11 +38,32 @@ const confTotalQueryLimit = "ledger.state.totalQueryLimit"
 const confInternalQueryLimit = "ledger.state.couchDBConfig.internalQueryLimit"
 const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
+const confStateCacheSize = "ledger.state.couchDBConfig.cacheSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confMaxOpenIteratorsPerTx = "ledger.state.queryLimits.maxOpenIteratorsPerTx"
+const confMaxResultsPerTx = "ledger.state.queryLimits.maxResultsPerTx"
+const confMaxBytesScannedPerTx = "ledger.state.queryLimits.maxBytesScannedPerTx"
+const confSnapshotIsolationEnabled = "ledger.state.snapshotIsolation.enabled"
+const confMaxSnapshotAge = "ledger.state.snapshotIsolation.maxSnapshotAge"
+const confStateProofsEnabled = "ledger.state.proofs.enabled"
+const confDiskUsageInterval = "ledger.diskUsage.interval"
+const confDiskSoftQuota = "ledger.diskUsage.softQuota"
+const confDiskChannelQuotas = "ledger.diskUsage.channelQuotas"
+const confCompactionL0Trigger = "ledger.compaction.l0Trigger"
+const confCompactionWriteL0SlowdownTrigger = "ledger.compaction.writeL0SlowdownTrigger"
+const confCompactionWriteL0PauseTrigger = "ledger.compaction.writeL0PauseTrigger"
+const confCompactionTableSize = "ledger.compaction.tableSize"
+const confCompactionTotalSize = "ledger.compaction.totalSize"
+const confCompactionManualRateLimit = "ledger.compaction.manualRateLimit"
 
 var confCollElgProcMaxDbBatchSize = &conf{"ledger.pvtdataStore.collElgProcMaxDbBatchSize", 5000}
 var confCollElgProcDbBatchesInterval = &conf{"ledger.pvtdataStore.collElgProcDbBatchesInterval", 1000}
+var confPurgeBatchSize = &conf{"ledger.pvtdataStore.purgeBatchSize", 5000}
+var confExpiryReportHorizon = &conf{"ledger.pvtdataStore.expiryReportHorizon", 1000}
+
+const confPurgeBatchesInterval = "ledger.pvtdataStore.purgeBatchesInterval"
+const confPurgeWindow = "ledger.pvtdataStore.purgeWindow"
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -118,6 +142,12 @@ func GetMaxBatchUpdateSize() int {
 	return maxBatchUpdateSize
 }
 
+// GetStateCacheSize returns the memory budget (in megabytes) of the cache that is maintained
+// in front of the state database. A value of 0 (the default) disables the cache
+func GetStateCacheSize() int {
+	return nonNegativeInt(confStateCacheSize)
+}
+
 // GetPvtdataStorePurgeInterval returns the interval in the terms of number of blocks
 // when the purge for the expired data would be performed
 func GetPvtdataStorePurgeInterval() uint64 {
@@ -128,6 +158,40 @@ func GetPvtdataStorePurgeInterval() uint64 {
 	return uint64(purgeInterval)
 }
 
+// GetPvtdataStorePurgeBatchSize returns the maximum number of keys deleted in a single db batch
+// when the expired data is purged
+func GetPvtdataStorePurgeBatchSize() int {
+	purgeBatchSize := viper.GetInt(confPurgeBatchSize.Name)
+	if purgeBatchSize <= 0 {
+		purgeBatchSize = confPurgeBatchSize.DefaultVal
+	}
+	return purgeBatchSize
+}
+
+// GetPvtdataStorePurgeBatchesInterval returns the minimum duration (in milliseconds) between writing
+// two consecutive db batches when the expired data is purged. A value of 0 (the default) writes the
+// batches without pause
+func GetPvtdataStorePurgeBatchesInterval() int {
+	return nonNegativeInt(confPurgeBatchesInterval)
+}
+
+// GetPvtdataStorePurgeWindow returns the daily window, in the HH:MM-HH:MM format and in the local time
+// of the peer, out of which the purges of the expired data are deferred. An empty window (the default)
+// lets the purges run as soon as they are due
+func GetPvtdataStorePurgeWindow() string {
+	return viper.GetString(confPurgeWindow)
+}
+
+// GetPvtdataStoreExpiryReportHorizon returns the number of blocks ahead of the last committed block
+// for which the upcoming expirations of the private data are reported after each purge
+func GetPvtdataStoreExpiryReportHorizon() uint64 {
+	expiryReportHorizon := viper.GetInt(confExpiryReportHorizon.Name)
+	if expiryReportHorizon <= 0 {
+		expiryReportHorizon = confExpiryReportHorizon.DefaultVal
+	}
+	return uint64(expiryReportHorizon)
+}
+
 // GetPvtdataStoreCollElgProcMaxDbBatchSize returns the maximum db batch size for converting
 // the ineligible missing data entries to eligible missing data entries
 func GetPvtdataStoreCollElgProcMaxDbBatchSize() int {
@@ -186,6 +250,98 @@ func GetWarmIndexesAfterNBlocks() int {
 	return warmAfterNBlocks
 }
 
+// GetMaxOpenIteratorsPerTx returns the maximum number of query iterators that a single
+// transaction simulation (or query) is allowed to keep open at the same time.
+// A value of 0 (the default) means no limit
+func GetMaxOpenIteratorsPerTx() int {
+	return nonNegativeInt(confMaxOpenIteratorsPerTx)
+}
+
+// GetMaxResultsPerTx returns the maximum number of query results that a single
+// transaction simulation (or query) is allowed to retrieve across all its iterators.
+// A value of 0 (the default) means no limit
+func GetMaxResultsPerTx() int {
+	return nonNegativeInt(confMaxResultsPerTx)
+}
+
+// GetMaxBytesScannedPerTx returns the maximum number of bytes (keys and values) that a single
+// transaction simulation (or query) is allowed to retrieve across all its iterators.
+// A value of 0 (the default) means no limit
+func GetMaxBytesScannedPerTx() int {
+	return nonNegativeInt(confMaxBytesScannedPerTx)
+}
+
+// IsSnapshotIsolationEnabled returns true if the query executors read a snapshot of the state
+// database rather than blocking the commits until they are done. Only the goleveldb state
+// database supports snapshots, the peer refusing to start if it is enabled with CouchDB
+func IsSnapshotIsolationEnabled() bool {
+	return viper.GetBool(confSnapshotIsolationEnabled)
+}
+
+// IsStateProofsEnabled returns true if the digests of the updates of the state by the blocks are
+// recorded along with their commit hashes, so that proofs of the reads of the state can be provided
+func IsStateProofsEnabled() bool {
+	return viper.GetBool(confStateProofsEnabled)
+}
+
+// GetMaxSnapshotAge returns the maximum age of the snapshot read by a query executor, past which
+// its reads fail and the snapshot is released. A value of 0 means no limit
+func GetMaxSnapshotAge() time.Duration {
+	maxAge := viper.GetDuration(confMaxSnapshotAge)
+	if maxAge < 0 {
+		return 0
+	}
+	return maxAge
+}
+
+// GetDiskUsageInterval returns how often the disk space used by each channel is measured.
+// A value of 0 disables the measurements
+func GetDiskUsageInterval() time.Duration {
+	interval := viper.GetDuration(confDiskUsageInterval)
+	if interval < 0 {
+		return 0
+	}
+	return interval
+}
+
+// GetDiskSoftQuota returns the soft quota, in bytes, of the disk space used by the given channel,
+// which is its own quota if it has one, else the quota of its tenant if set, else the quota of the
+// peer. A value of 0 means no quota
+func GetDiskSoftQuota(ledgerID string) uint64 {
+	// the channel names may contain dots, which viper interprets as nested keys
+	channelQuotas := viper.GetStringMapString(confDiskChannelQuotas)
+	if quota, ok := channelQuotas[strings.ToLower(ledgerID)]; ok {
+		v := viper.New()
+		v.Set("quota", quota)
+		return uint64(v.GetSizeInBytes("quota"))
+	}
+	if tenant, err := GetTenant(ledgerID); err == nil && tenant != nil && tenant.DiskQuota > 0 {
+		return tenant.DiskQuota
+	}
+	return uint64(viper.GetSizeInBytes(confDiskSoftQuota))
+}
+
+// GetLevelDBCompactionConf returns the tuning of the compactions of the goleveldb databases of the
+// ledger. The values left to 0 keep the defaults of goleveldb
+func GetLevelDBCompactionConf() *leveldbhelper.CompactionConf {
+	return &leveldbhelper.CompactionConf{
+		L0Trigger:              nonNegativeInt(confCompactionL0Trigger),
+		WriteL0SlowdownTrigger: nonNegativeInt(confCompactionWriteL0SlowdownTrigger),
+		WriteL0PauseTrigger:    nonNegativeInt(confCompactionWriteL0PauseTrigger),
+		TableSize:              int(viper.GetSizeInBytes(confCompactionTableSize)),
+		TotalSize:              int(viper.GetSizeInBytes(confCompactionTotalSize)),
+		ManualRateLimit:        int(viper.GetSizeInBytes(confCompactionManualRateLimit)),
+	}
+}
+
+func nonNegativeInt(key string) int {
+	val := viper.GetInt(key)
+	if val < 0 {
+		return 0
+	}
+	return val
+}
+
 type conf struct {
 	Name       string
 	DefaultVal int
@@ -18,7 +18,9 @@ package ledgerconfig
 
 import (
 	"testing"
+	"time"
 
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -174,6 +176,39 @@ func TestCollElgProcDbBatchesInterval(t *testing.T) {
 	assert.Equal(t, testVal, GetPvtdataStoreCollElgProcDbBatchesInterval())
 }
 
+func TestPvtdataStorePurgeBatchSize(t *testing.T) {
+	defer viper.Set("ledger.pvtdataStore.purgeBatchSize", nil)
+	defaultVal := confPurgeBatchSize.DefaultVal
+	assert.Equal(t, defaultVal, GetPvtdataStorePurgeBatchSize())
+	viper.Set("ledger.pvtdataStore.purgeBatchSize", -1)
+	assert.Equal(t, defaultVal, GetPvtdataStorePurgeBatchSize())
+	viper.Set("ledger.pvtdataStore.purgeBatchSize", 100)
+	assert.Equal(t, 100, GetPvtdataStorePurgeBatchSize())
+}
+
+func TestPvtdataStorePurgeBatchesInterval(t *testing.T) {
+	defer viper.Set("ledger.pvtdataStore.purgeBatchesInterval", nil)
+	assert.Equal(t, 0, GetPvtdataStorePurgeBatchesInterval())
+	viper.Set("ledger.pvtdataStore.purgeBatchesInterval", -1)
+	assert.Equal(t, 0, GetPvtdataStorePurgeBatchesInterval())
+	viper.Set("ledger.pvtdataStore.purgeBatchesInterval", 50)
+	assert.Equal(t, 50, GetPvtdataStorePurgeBatchesInterval())
+}
+
+func TestPvtdataStorePurgeWindow(t *testing.T) {
+	defer viper.Set("ledger.pvtdataStore.purgeWindow", nil)
+	assert.Equal(t, "", GetPvtdataStorePurgeWindow())
+	viper.Set("ledger.pvtdataStore.purgeWindow", "23:00-05:00")
+	assert.Equal(t, "23:00-05:00", GetPvtdataStorePurgeWindow())
+}
+
+func TestPvtdataStoreExpiryReportHorizon(t *testing.T) {
+	defer viper.Set("ledger.pvtdataStore.expiryReportHorizon", nil)
+	assert.Equal(t, uint64(confExpiryReportHorizon.DefaultVal), GetPvtdataStoreExpiryReportHorizon())
+	viper.Set("ledger.pvtdataStore.expiryReportHorizon", 10)
+	assert.Equal(t, uint64(10), GetPvtdataStoreExpiryReportHorizon())
+}
+
 func TestIsHistoryDBEnabledDefault(t *testing.T) {
 	setUpCoreYAMLConfig()
 	defaultValue := IsHistoryDBEnabled()
@@ -248,6 +283,87 @@ func TestGetMaxBlockfileSize(t *testing.T) {
 	assert.Equal(t, 67108864, GetMaxBlockfileSize())
 }
 
+func TestGetStateCacheSize(t *testing.T) {
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Reset()
+	assert.Equal(t, 0, GetStateCacheSize())
+	setUpCoreYAMLConfig()
+	assert.Equal(t, 64, GetStateCacheSize())
+	viper.Set("ledger.state.couchDBConfig.cacheSize", -1)
+	assert.Equal(t, 0, GetStateCacheSize())
+}
+
+func TestGetQueryLimitsPerTx(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, 0, GetMaxOpenIteratorsPerTx())
+	assert.Equal(t, 0, GetMaxResultsPerTx())
+	assert.Equal(t, 0, GetMaxBytesScannedPerTx())
+
+	viper.Set("ledger.state.queryLimits.maxOpenIteratorsPerTx", 5)
+	viper.Set("ledger.state.queryLimits.maxResultsPerTx", 1000)
+	viper.Set("ledger.state.queryLimits.maxBytesScannedPerTx", -1)
+	assert.Equal(t, 5, GetMaxOpenIteratorsPerTx())
+	assert.Equal(t, 1000, GetMaxResultsPerTx())
+	assert.Equal(t, 0, GetMaxBytesScannedPerTx())
+}
+
+func TestSnapshotIsolation(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsSnapshotIsolationEnabled())
+	assert.Equal(t, 5*time.Minute, GetMaxSnapshotAge())
+
+	viper.Set("ledger.state.snapshotIsolation.enabled", true)
+	viper.Set("ledger.state.snapshotIsolation.maxSnapshotAge", "-1s")
+	assert.True(t, IsSnapshotIsolationEnabled())
+	assert.Equal(t, time.Duration(0), GetMaxSnapshotAge())
+}
+
+func TestStateProofs(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.False(t, IsStateProofsEnabled())
+
+	viper.Set("ledger.state.proofs.enabled", true)
+	assert.True(t, IsStateProofsEnabled())
+}
+
+func TestDiskUsage(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, 5*time.Minute, GetDiskUsageInterval())
+	assert.Equal(t, uint64(0), GetDiskSoftQuota("mychannel"))
+
+	viper.Set("ledger.diskUsage.interval", "-1s")
+	viper.Set("ledger.diskUsage.softQuota", "2 GB")
+	viper.Set("ledger.diskUsage.channelQuotas", map[string]interface{}{"my.channel": "10 MB", "other": 1000})
+	assert.Equal(t, time.Duration(0), GetDiskUsageInterval())
+	assert.Equal(t, uint64(2<<30), GetDiskSoftQuota("mychannel"))
+	assert.Equal(t, uint64(10<<20), GetDiskSoftQuota("my.channel"))
+	assert.Equal(t, uint64(1000), GetDiskSoftQuota("other"))
+}
+
+func TestGetLevelDBCompactionConf(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	assert.Equal(t, &leveldbhelper.CompactionConf{}, GetLevelDBCompactionConf())
+
+	viper.Set("ledger.compaction.l0Trigger", 8)
+	viper.Set("ledger.compaction.writeL0SlowdownTrigger", 16)
+	viper.Set("ledger.compaction.writeL0PauseTrigger", -1)
+	viper.Set("ledger.compaction.tableSize", "8 MB")
+	viper.Set("ledger.compaction.totalSize", 1000)
+	viper.Set("ledger.compaction.manualRateLimit", "4 MB")
+	assert.Equal(t, &leveldbhelper.CompactionConf{
+		L0Trigger:              8,
+		WriteL0SlowdownTrigger: 16,
+		TableSize:              8 << 20,
+		TotalSize:              1000,
+		ManualRateLimit:        4 << 20,
+	}, GetLevelDBCompactionConf())
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
@@ -0,0 +1,174 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerconfig
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const confTenants = "ledger.tenants"
+const confTenantsDir = "tenants"
+
+var tenantNamePattern = regexp.MustCompile("^[a-z0-9][a-z0-9_-]*$")
+
+// Tenant is a group of channels whose ledgers are kept apart from the ledgers of the
+// other channels of the peer, in a storage root of their own and with a budget of their own
+type Tenant struct {
+	// Name is the name of the tenant, which labels the metrics of its ledgers
+	Name string
+	// Channels are the names of the channels of the tenant, or patterns of names as
+	// matched by path.Match
+	Channels []string
+	// RootPath is the directory holding the ledgers of the tenant
+	RootPath string
+	// IOPSBudget is the number of blocks the channels of the tenant commit per second,
+	// each block commit being a burst of writes to the stores. A value of 0 means no limit
+	IOPSBudget int
+	// DiskQuota is the soft quota, in bytes, of the disk space used by each channel of the
+	// tenant which has no quota of its own. A value of 0 falls back to the quota of the peer
+	DiskQuota uint64
+}
+
+// StorePaths are the filesystem paths of the stores of the ledgers kept under a root path
+type StorePaths struct {
+	Root               string
+	LedgerProvider     string
+	StateLevelDB       string
+	HistoryLevelDB     string
+	BlockStore         string
+	PvtdataStore       string
+	InternalBookkeeper string
+	ConfigHistory      string
+}
+
+// GetStorePaths returns the filesystem paths of the stores of the ledgers kept under the
+// given root path. The paths under GetRootPath() are the ones of the ledgers of no tenant
+func GetStorePaths(rootPath string) *StorePaths {
+	return &StorePaths{
+		Root:               rootPath,
+		LedgerProvider:     filepath.Join(rootPath, confLedgerProvider),
+		StateLevelDB:       filepath.Join(rootPath, confStateleveldb),
+		HistoryLevelDB:     filepath.Join(rootPath, confHistoryLeveldb),
+		BlockStore:         filepath.Join(rootPath, confChains),
+		PvtdataStore:       filepath.Join(rootPath, confPvtdataStore),
+		InternalBookkeeper: filepath.Join(rootPath, confBookkeeper),
+		ConfigHistory:      filepath.Join(rootPath, confConfigHistory),
+	}
+}
+
+// GetTenants returns the tenants of the peer, ordered by their names
+func GetTenants() ([]*Tenant, error) {
+	var tenantsConf map[string]struct {
+		Channels       []string
+		FileSystemPath string
+		IOPSBudget     int
+		DiskQuota      string
+	}
+	if err := viper.UnmarshalKey(confTenants, &tenantsConf); err != nil {
+		return nil, errors.Wrapf(err, "error parsing %s", confTenants)
+	}
+
+	// the tenants are processed in the order of their names, so that the errors are reported consistently
+	var names []string
+	for name := range tenantsConf {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tenants []*Tenant
+	rootPaths := map[string]string{filepath.Clean(GetRootPath()): ""}
+	for _, name := range names {
+		conf := tenantsConf[name]
+		if !tenantNamePattern.MatchString(name) {
+			return nil, errors.Errorf("invalid tenant name [%s], it must match %s", name, tenantNamePattern)
+		}
+		if len(conf.Channels) == 0 {
+			return nil, errors.Errorf("tenant [%s] has no channels", name)
+		}
+		for _, pattern := range conf.Channels {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, errors.Errorf("invalid channel pattern [%s] of tenant [%s]", pattern, name)
+			}
+		}
+		if conf.IOPSBudget < 0 {
+			return nil, errors.Errorf("invalid iopsBudget [%d] of tenant [%s], it must not be negative", conf.IOPSBudget, name)
+		}
+
+		rootPath := filepath.Join(GetRootPath(), confTenantsDir, name)
+		if conf.FileSystemPath != "" {
+			rootPath = config.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), conf.FileSystemPath)
+		}
+		rootPath = filepath.Clean(rootPath)
+		if other, ok := rootPaths[rootPath]; ok {
+			return nil, errors.Errorf("tenant [%s] shares its fileSystemPath %s with %s", name, rootPath, tenantDescription(other))
+		}
+		rootPaths[rootPath] = name
+
+		v := viper.New()
+		v.Set("quota", conf.DiskQuota)
+		tenants = append(tenants, &Tenant{
+			Name:       name,
+			Channels:   conf.Channels,
+			RootPath:   rootPath,
+			IOPSBudget: conf.IOPSBudget,
+			DiskQuota:  uint64(v.GetSizeInBytes("quota")),
+		})
+	}
+	return tenants, nil
+}
+
+// GetTenant returns the tenant of the given channel, that is the first tenant in the order
+// of their names with a pattern matching the channel, or nil if the channel belongs to no tenant
+func GetTenant(ledgerID string) (*Tenant, error) {
+	tenants, err := GetTenants()
+	if err != nil {
+		return nil, err
+	}
+	for _, tenant := range tenants {
+		if tenant.Owns(ledgerID) {
+			return tenant, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetLedgerStorePaths returns the filesystem paths of the stores of the given ledger, which are
+// under the root path of its tenant if it has one
+func GetLedgerStorePaths(ledgerID string) (*StorePaths, error) {
+	tenant, err := GetTenant(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return GetStorePaths(GetRootPath()), nil
+	}
+	return GetStorePaths(tenant.RootPath), nil
+}
+
+// Owns returns true if the given channel matches one of the patterns of the tenant
+func (t *Tenant) Owns(ledgerID string) bool {
+	for _, pattern := range t.Channels {
+		if matched, _ := path.Match(pattern, ledgerID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func tenantDescription(name string) string {
+	if name == "" {
+		return "the ledgers of no tenant"
+	}
+	return "tenant [" + name + "]"
+}
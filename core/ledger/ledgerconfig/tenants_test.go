@@ -0,0 +1,135 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgerconfig
+
+import (
+	"testing"
+
+	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTenants(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	tenants, err := GetTenants()
+	assert.NoError(t, err)
+	assert.Empty(t, tenants)
+
+	viper.Set("ledger.tenants", map[string]interface{}{
+		"globex": map[string]interface{}{
+			"channels":       []string{"globex"},
+			"fileSystemPath": "/data/globex",
+			"iopsBudget":     20,
+		},
+		"acme": map[string]interface{}{
+			"channels":  []interface{}{"acme-*", "shared?"},
+			"diskQuota": "10 MB",
+		},
+	})
+	tenants, err = GetTenants()
+	assert.NoError(t, err)
+	assert.Equal(t, []*Tenant{
+		{
+			Name:      "acme",
+			Channels:  []string{"acme-*", "shared?"},
+			RootPath:  "/var/hyperledger/production/ledgersData/tenants/acme",
+			DiskQuota: 10 << 20,
+		},
+		{
+			Name:       "globex",
+			Channels:   []string{"globex"},
+			RootPath:   "/data/globex",
+			IOPSBudget: 20,
+		},
+	}, tenants)
+
+	for _, ledgerID := range []string{"acme-1", "shared1"} {
+		tenant, err := GetTenant(ledgerID)
+		assert.NoError(t, err)
+		assert.Equal(t, "acme", tenant.Name)
+	}
+	tenant, err := GetTenant("globex-1")
+	assert.NoError(t, err)
+	assert.Nil(t, tenant)
+
+	paths, err := GetLedgerStorePaths("globex")
+	assert.NoError(t, err)
+	assert.Equal(t, GetStorePaths("/data/globex"), paths)
+	assert.Equal(t, "/data/globex/chains", paths.BlockStore)
+	paths, err = GetLedgerStorePaths("mychannel")
+	assert.NoError(t, err)
+	assert.Equal(t, &StorePaths{
+		Root:               GetRootPath(),
+		LedgerProvider:     GetLedgerProviderPath(),
+		StateLevelDB:       GetStateLevelDBPath(),
+		HistoryLevelDB:     GetHistoryLevelDBPath(),
+		BlockStore:         GetBlockStorePath(),
+		PvtdataStore:       GetPvtdataStorePath(),
+		InternalBookkeeper: GetInternalBookkeeperPath(),
+		ConfigHistory:      GetConfigHistoryPath(),
+	}, paths)
+
+	viper.Set("ledger.diskUsage.softQuota", "2 GB")
+	viper.Set("ledger.diskUsage.channelQuotas", map[string]interface{}{"acme-2": "1 MB"})
+	assert.Equal(t, uint64(10<<20), GetDiskSoftQuota("acme-1"))
+	assert.Equal(t, uint64(1<<20), GetDiskSoftQuota("acme-2"))
+	assert.Equal(t, uint64(2<<30), GetDiskSoftQuota("globex"))
+}
+
+func TestGetTenantsInvalid(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+
+	tests := []struct {
+		name        string
+		tenants     map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name:        "invalid name",
+			tenants:     map[string]interface{}{"acme.corp": map[string]interface{}{"channels": []string{"acme"}}},
+			expectedErr: "invalid tenant name [acme.corp], it must match ^[a-z0-9][a-z0-9_-]*$",
+		},
+		{
+			name:        "no channels",
+			tenants:     map[string]interface{}{"acme": map[string]interface{}{"iopsBudget": 10}},
+			expectedErr: "tenant [acme] has no channels",
+		},
+		{
+			name:        "invalid pattern",
+			tenants:     map[string]interface{}{"acme": map[string]interface{}{"channels": []string{"acme-["}}},
+			expectedErr: "invalid channel pattern [acme-[] of tenant [acme]",
+		},
+		{
+			name:        "negative budget",
+			tenants:     map[string]interface{}{"acme": map[string]interface{}{"channels": []string{"acme"}, "iopsBudget": -1}},
+			expectedErr: "invalid iopsBudget [-1] of tenant [acme], it must not be negative",
+		},
+		{
+			name: "shared root path",
+			tenants: map[string]interface{}{
+				"acme":   map[string]interface{}{"channels": []string{"acme"}, "fileSystemPath": "/data/shared"},
+				"globex": map[string]interface{}{"channels": []string{"globex"}, "fileSystemPath": "/data/shared/"},
+			},
+			expectedErr: "tenant [globex] shares its fileSystemPath /data/shared with tenant [acme]",
+		},
+		{
+			name:        "root path of no tenant",
+			tenants:     map[string]interface{}{"acme": map[string]interface{}{"channels": []string{"acme"}, "fileSystemPath": "/var/hyperledger/production/ledgersData"}},
+			expectedErr: "tenant [acme] shares its fileSystemPath /var/hyperledger/production/ledgersData with the ledgers of no tenant",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Set("ledger.tenants", test.tenants)
+			_, err := GetTenants()
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
@@ -17,7 +17,6 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
-	"github.com/hyperledger/fabric/core/ledger/kvledger"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -66,7 +65,7 @@ func initialize(initializer *Initializer) {
 		initializer.DeployedChaincodeInfoProvider,
 	})
 	finalStateListeners := addListenerForCCEventsHandler(initializer.DeployedChaincodeInfoProvider, []ledger.StateListener{})
-	provider, err := kvledger.NewProvider()
+	provider, err := newLedgerProvider()
 	if err != nil {
 		panic(errors.WithMessage(err, "Error in instantiating ledger provider"))
 	}
@@ -131,6 +130,24 @@ func OpenLedger(id string) (ledger.PeerLedger, error) {
 	return l, nil
 }
 
+// RemoveLedger closes the ledger with the given id, if opened, and removes it
+func RemoveLedger(id string) error {
+	logger.Infof("Removing ledger with id = %s", id)
+	lock.Lock()
+	defer lock.Unlock()
+	if !initialized {
+		return ErrLedgerMgmtNotInitialized
+	}
+	if l, ok := openedLedgers[id]; ok {
+		l.(*closableLedger).closeWithoutLock()
+	}
+	if err := ledgerProvider.Remove(id); err != nil {
+		return err
+	}
+	logger.Infof("Removed ledger with id = %s", id)
+	return nil
+}
+
 // GetLedgerIDs returns the ids of the ledgers created
 func GetLedgerIDs() ([]string, error) {
 	lock.Lock()
@@ -0,0 +1,417 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgermgmt
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// newLedgerProvider returns the provider of the ledgers of the peer, which routes the ledgers to
+// a provider per tenant when tenants are configured
+func newLedgerProvider() (ledger.PeerLedgerProvider, error) {
+	tenants, err := ledgerconfig.GetTenants()
+	if err != nil {
+		return nil, err
+	}
+	if len(tenants) == 0 {
+		return kvledger.NewProvider()
+	}
+	return newTenantsProvider(tenants)
+}
+
+// tenantProvider is the provider of the ledgers stored under the root path of a tenant
+type tenantProvider struct {
+	ledger.PeerLedgerProvider
+	// name is empty for the provider of the ledgers of no tenant
+	name   string
+	budget *commitBudget
+}
+
+// tenantsProvider implements interface ledger.PeerLedgerProvider on top of a ledger provider per
+// tenant, each with its own storage root, and of a provider of the ledgers of no tenant
+type tenantsProvider struct {
+	defaultProvider *tenantProvider
+	tenants         []*ledgerconfig.Tenant
+	providers       map[string]*tenantProvider
+}
+
+func newTenantsProvider(tenants []*ledgerconfig.Tenant) (*tenantsProvider, error) {
+	p := &tenantsProvider{
+		tenants:   tenants,
+		providers: map[string]*tenantProvider{},
+	}
+	defaultProvider, err := kvledger.NewProviderWithRootPath(ledgerconfig.GetRootPath())
+	if err != nil {
+		return nil, err
+	}
+	p.defaultProvider = &tenantProvider{PeerLedgerProvider: defaultProvider}
+	for _, tenant := range tenants {
+		logger.Infof("Initializing the ledger provider of tenant [%s] for channels %v", tenant.Name, tenant.Channels)
+		provider, err := kvledger.NewProviderWithRootPath(tenant.RootPath)
+		if err != nil {
+			return nil, err
+		}
+		p.providers[tenant.Name] = &tenantProvider{
+			PeerLedgerProvider: provider,
+			name:               tenant.Name,
+			budget:             newCommitBudget(tenant.IOPSBudget),
+		}
+	}
+	return p, nil
+}
+
+// all returns the provider of the ledgers of no tenant followed by the providers of the tenants
+func (p *tenantsProvider) all() []*tenantProvider {
+	providers := []*tenantProvider{p.defaultProvider}
+	for _, tenant := range p.tenants {
+		if provider, ok := p.providers[tenant.Name]; ok {
+			providers = append(providers, provider)
+		}
+	}
+	return providers
+}
+
+// configuredProvider returns the provider of the tenant the given ledger is configured for
+func (p *tenantsProvider) configuredProvider(ledgerID string) *tenantProvider {
+	for _, tenant := range p.tenants {
+		if tenant.Owns(ledgerID) {
+			return p.providers[tenant.Name]
+		}
+	}
+	return p.defaultProvider
+}
+
+// holder returns the provider holding the given ledger, or the provider of the tenant the
+// ledger is configured for if no provider holds it. A ledger created before its channel was
+// moved to another tenant stays with the provider of its former tenant, until it is backed up
+// and restored
+func (p *tenantsProvider) holder(ledgerID string) (*tenantProvider, error) {
+	configured := p.configuredProvider(ledgerID)
+	for _, provider := range append([]*tenantProvider{configured}, p.all()...) {
+		exists, err := provider.Exists(ledgerID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			if provider != configured {
+				logger.Warningf("Ledger [%s] is stored with the ledgers of %s while its channel belongs to %s",
+					ledgerID, provider.description(), configured.description())
+			}
+			return provider, nil
+		}
+	}
+	return configured, nil
+}
+
+// Initialize implements the corresponding method from interface ledger.PeerLedgerProvider.
+// The metrics of the ledgers are created once, with a tenant label, for all the providers
+func (p *tenantsProvider) Initialize(initializer *ledger.Initializer) error {
+	tenantMetrics := newTenantMetrics(initializer.MetricsProvider)
+	var healthCheckRegistry ledger.HealthCheckRegistry
+	if initializer.HealthCheckRegistry != nil {
+		healthCheckRegistry = &sharedHealthCheckRegistry{
+			registry:   initializer.HealthCheckRegistry,
+			registered: map[string]bool{},
+		}
+	}
+	for _, provider := range p.all() {
+		tenantInitializer := *initializer
+		tenantInitializer.MetricsProvider = tenantMetrics.forTenant(provider.name)
+		tenantInitializer.HealthCheckRegistry = healthCheckRegistry
+		if err := provider.Initialize(&tenantInitializer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Create implements the corresponding method from interface ledger.PeerLedgerProvider
+func (p *tenantsProvider) Create(genesisBlock *common.Block) (ledger.PeerLedger, error) {
+	ledgerID, err := utils.GetChainIDFromBlock(genesisBlock)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := p.holder(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	l, err := provider.Create(genesisBlock)
+	if err != nil {
+		return nil, err
+	}
+	return provider.wrap(l), nil
+}
+
+// Open implements the corresponding method from interface ledger.PeerLedgerProvider
+func (p *tenantsProvider) Open(ledgerID string) (ledger.PeerLedger, error) {
+	provider, err := p.holder(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	l, err := provider.Open(ledgerID)
+	if err != nil {
+		return nil, err
+	}
+	return provider.wrap(l), nil
+}
+
+// Exists implements the corresponding method from interface ledger.PeerLedgerProvider
+func (p *tenantsProvider) Exists(ledgerID string) (bool, error) {
+	for _, provider := range p.all() {
+		exists, err := provider.Exists(ledgerID)
+		if err != nil || exists {
+			return exists, err
+		}
+	}
+	return false, nil
+}
+
+// List implements the corresponding method from interface ledger.PeerLedgerProvider
+func (p *tenantsProvider) List() ([]string, error) {
+	var ledgerIDs []string
+	for _, provider := range p.all() {
+		ids, err := provider.List()
+		if err != nil {
+			return nil, err
+		}
+		ledgerIDs = append(ledgerIDs, ids...)
+	}
+	sort.Strings(ledgerIDs)
+	return ledgerIDs, nil
+}
+
+// Remove implements the corresponding method from interface ledger.PeerLedgerProvider
+func (p *tenantsProvider) Remove(ledgerID string) error {
+	provider, err := p.holder(ledgerID)
+	if err != nil {
+		return err
+	}
+	return provider.Remove(ledgerID)
+}
+
+// Close implements the corresponding method from interface ledger.PeerLedgerProvider
+func (p *tenantsProvider) Close() {
+	p.defaultProvider.Close()
+	for _, provider := range p.providers {
+		provider.Close()
+	}
+}
+
+func (p *tenantProvider) description() string {
+	if p.name == "" {
+		return "no tenant"
+	}
+	return "tenant [" + p.name + "]"
+}
+
+// wrap returns the given ledger of the tenant, with its commits held to the budget of the tenant
+func (p *tenantProvider) wrap(l ledger.PeerLedger) ledger.PeerLedger {
+	if p.budget == nil {
+		return l
+	}
+	return &budgetedLedger{PeerLedger: l, tenant: p.name, budget: p.budget}
+}
+
+// budgetedLedger delays the commits of the blocks of a ledger which exceed the budget of its tenant
+type budgetedLedger struct {
+	ledger.PeerLedger
+	tenant string
+	budget *commitBudget
+}
+
+// CommitWithPvtData waits for the budget of the tenant to allow a commit before committing the block
+func (l *budgetedLedger) CommitWithPvtData(blockAndPvtdata *ledger.BlockAndPvtData) error {
+	if delay := l.budget.reserve(time.Now()); delay > 0 {
+		logger.Debugf("Delaying the commit of block [%d] by %s to keep tenant [%s] within its iops budget",
+			blockAndPvtdata.Block.Header.Number, delay, l.tenant)
+		time.Sleep(delay)
+	}
+	return l.PeerLedger.CommitWithPvtData(blockAndPvtdata)
+}
+
+// commitBudget spreads the commits of the blocks of the channels of a tenant evenly over time,
+// so that they do not exceed the number of commits per second allowed to the tenant
+type commitBudget struct {
+	interval time.Duration
+	mutex    sync.Mutex
+	next     time.Time
+}
+
+// newCommitBudget returns the budget allowing the given number of commits per second, or nil
+// if the number is 0, which means no limit
+func newCommitBudget(commitsPerSecond int) *commitBudget {
+	if commitsPerSecond <= 0 {
+		return nil
+	}
+	return &commitBudget{interval: time.Second / time.Duration(commitsPerSecond)}
+}
+
+// reserve reserves the next commit allowed by the budget and returns how long the commit
+// must wait from now
+func (b *commitBudget) reserve(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.next.Before(now) {
+		b.next = now
+	}
+	delay := b.next.Sub(now)
+	b.next = b.next.Add(b.interval)
+	return delay
+}
+
+// sharedHealthCheckRegistry registers the health checkers of the ledger providers of all the
+// tenants once per component, as they all check the same CouchDB instance
+type sharedHealthCheckRegistry struct {
+	registry   ledger.HealthCheckRegistry
+	mutex      sync.Mutex
+	registered map[string]bool
+}
+
+func (r *sharedHealthCheckRegistry) RegisterChecker(component string, checker healthz.HealthChecker) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.registered[component] {
+		return nil
+	}
+	if err := r.registry.RegisterChecker(component, checker); err != nil {
+		return err
+	}
+	r.registered[component] = true
+	return nil
+}
+
+// tenantMetrics creates each metric of the ledgers once, with an additional tenant label, and
+// hands out to the ledger provider of each tenant the metrics labelled with the tenant
+type tenantMetrics struct {
+	provider   metrics.Provider
+	mutex      sync.Mutex
+	counters   map[string]metrics.Counter
+	gauges     map[string]metrics.Gauge
+	histograms map[string]metrics.Histogram
+}
+
+func newTenantMetrics(provider metrics.Provider) *tenantMetrics {
+	return &tenantMetrics{
+		provider:   provider,
+		counters:   map[string]metrics.Counter{},
+		gauges:     map[string]metrics.Gauge{},
+		histograms: map[string]metrics.Histogram{},
+	}
+}
+
+// forTenant returns the metrics provider of the ledger provider of the given tenant
+func (m *tenantMetrics) forTenant(tenant string) metrics.Provider {
+	return &tenantMetricsProvider{tenantMetrics: m, labelValues: []string{"tenant", tenant}}
+}
+
+func withTenantLabel(labelNames []string) []string {
+	return append([]string{"tenant"}, labelNames...)
+}
+
+type tenantMetricsProvider struct {
+	*tenantMetrics
+	labelValues []string
+}
+
+func (p *tenantMetricsProvider) NewCounter(o metrics.CounterOpts) metrics.Counter {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	key := o.Namespace + "." + o.Subsystem + "." + o.Name
+	counter, ok := p.counters[key]
+	if !ok {
+		o.LabelNames = withTenantLabel(o.LabelNames)
+		counter = p.provider.NewCounter(o)
+		p.counters[key] = counter
+	}
+	return &tenantCounter{counter: counter, labelValues: p.labelValues}
+}
+
+func (p *tenantMetricsProvider) NewGauge(o metrics.GaugeOpts) metrics.Gauge {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	key := o.Namespace + "." + o.Subsystem + "." + o.Name
+	gauge, ok := p.gauges[key]
+	if !ok {
+		o.LabelNames = withTenantLabel(o.LabelNames)
+		gauge = p.provider.NewGauge(o)
+		p.gauges[key] = gauge
+	}
+	return &tenantGauge{gauge: gauge, labelValues: p.labelValues}
+}
+
+func (p *tenantMetricsProvider) NewHistogram(o metrics.HistogramOpts) metrics.Histogram {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	key := o.Namespace + "." + o.Subsystem + "." + o.Name
+	histogram, ok := p.histograms[key]
+	if !ok {
+		o.LabelNames = withTenantLabel(o.LabelNames)
+		histogram = p.provider.NewHistogram(o)
+		p.histograms[key] = histogram
+	}
+	return &tenantHistogram{histogram: histogram, labelValues: p.labelValues}
+}
+
+// The metrics labelled with a tenant accumulate the label values and pass them all at once
+// to the underlying metric, as the metrics of some providers do not accumulate them
+
+type tenantCounter struct {
+	counter     metrics.Counter
+	labelValues []string
+}
+
+func (c *tenantCounter) With(labelValues ...string) metrics.Counter {
+	return &tenantCounter{counter: c.counter, labelValues: appendLabelValues(c.labelValues, labelValues)}
+}
+
+func (c *tenantCounter) Add(delta float64) {
+	c.counter.With(c.labelValues...).Add(delta)
+}
+
+type tenantGauge struct {
+	gauge       metrics.Gauge
+	labelValues []string
+}
+
+func (g *tenantGauge) With(labelValues ...string) metrics.Gauge {
+	return &tenantGauge{gauge: g.gauge, labelValues: appendLabelValues(g.labelValues, labelValues)}
+}
+
+func (g *tenantGauge) Add(delta float64) {
+	g.gauge.With(g.labelValues...).Add(delta)
+}
+
+func (g *tenantGauge) Set(value float64) {
+	g.gauge.With(g.labelValues...).Set(value)
+}
+
+type tenantHistogram struct {
+	histogram   metrics.Histogram
+	labelValues []string
+}
+
+func (h *tenantHistogram) With(labelValues ...string) metrics.Histogram {
+	return &tenantHistogram{histogram: h.histogram, labelValues: appendLabelValues(h.labelValues, labelValues)}
+}
+
+func (h *tenantHistogram) Observe(value float64) {
+	h.histogram.With(h.labelValues...).Observe(value)
+}
+
+func appendLabelValues(labelValues, more []string) []string {
+	return append(append([]string{}, labelValues...), more...)
+}
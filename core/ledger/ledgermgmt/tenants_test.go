@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ledgermgmt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenants(t *testing.T) {
+	viper.Set("ledger.tenants", map[string]interface{}{
+		"acme": map[string]interface{}{"channels": []string{"acme-*"}, "iopsBudget": 1000},
+	})
+	defer viper.Set("ledger.tenants", nil)
+	InitializeTestEnv()
+	defer CleanupTestEnv()
+
+	for _, ledgerID := range []string{"acme-1", "mychannel"} {
+		gb, err := test.MakeGenesisBlock(ledgerID)
+		require.NoError(t, err)
+		_, err = CreateLedger(gb)
+		require.NoError(t, err)
+	}
+	tenantRootPath := filepath.Join(ledgerconfig.GetRootPath(), "tenants", "acme")
+	assert.True(t, dirExists(filepath.Join(tenantRootPath, "chains", "chains", "acme-1")))
+	assert.False(t, dirExists(filepath.Join(tenantRootPath, "chains", "chains", "mychannel")))
+	assert.True(t, dirExists(filepath.Join(ledgerconfig.GetBlockStorePath(), "chains", "mychannel")))
+	assert.False(t, dirExists(filepath.Join(ledgerconfig.GetBlockStorePath(), "chains", "acme-1")))
+
+	assert.IsType(t, &budgetedLedger{}, openedLedgers["acme-1"].(*closableLedger).PeerLedger)
+	_, budgeted := openedLedgers["mychannel"].(*closableLedger).PeerLedger.(*budgetedLedger)
+	assert.False(t, budgeted)
+	ids, err := GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme-1", "mychannel"}, ids)
+
+	// a channel moved out of the tenant is still opened from the storage root of the tenant
+	Close()
+	viper.Set("ledger.tenants", map[string]interface{}{
+		"acme": map[string]interface{}{"channels": []string{"acme-2*"}},
+	})
+	InitializeExistingTestEnvWithInitializer(nil)
+	l, err := OpenLedger("acme-1")
+	assert.NoError(t, err)
+	bcInfo, err := l.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), bcInfo.Height)
+	_, err = OpenLedger("mychannel")
+	assert.NoError(t, err)
+
+	// once removed, the channel is created again in the storage root of its new tenant
+	gb, err := test.MakeGenesisBlock("acme-1")
+	require.NoError(t, err)
+	assert.NoError(t, RemoveLedger("acme-1"))
+	_, err = CreateLedger(gb)
+	assert.NoError(t, err)
+	assert.True(t, dirExists(filepath.Join(ledgerconfig.GetBlockStorePath(), "chains", "acme-1")))
+}
+
+func TestCommitBudget(t *testing.T) {
+	assert.Nil(t, newCommitBudget(0))
+
+	budget := newCommitBudget(4)
+	now := time.Now()
+	assert.Equal(t, time.Duration(0), budget.reserve(now))
+	assert.Equal(t, 250*time.Millisecond, budget.reserve(now))
+	assert.Equal(t, 400*time.Millisecond, budget.reserve(now.Add(100*time.Millisecond)))
+	assert.Equal(t, time.Duration(0), budget.reserve(now.Add(10*time.Second)))
+	assert.Equal(t, 250*time.Millisecond, budget.reserve(now.Add(10*time.Second)))
+}
+
+func TestTenantMetrics(t *testing.T) {
+	fakeProvider := &metricsfakes.Provider{}
+	fakeCounter := &metricsfakes.Counter{}
+	fakeCounter.WithReturns(fakeCounter)
+	fakeProvider.NewCounterReturns(fakeCounter)
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	fakeProvider.NewGaugeReturns(fakeGauge)
+	fakeHistogram := &metricsfakes.Histogram{}
+	fakeHistogram.WithReturns(fakeHistogram)
+	fakeProvider.NewHistogramReturns(fakeHistogram)
+
+	tenantMetrics := newTenantMetrics(fakeProvider)
+	acme := tenantMetrics.forTenant("acme")
+	noTenant := tenantMetrics.forTenant("")
+
+	counterOpts := metrics.CounterOpts{Namespace: "ledger", Name: "transaction_count", LabelNames: []string{"channel"}}
+	acme.NewCounter(counterOpts).With("channel", "acme-1").Add(2)
+	noTenant.NewCounter(counterOpts).With("channel", "mychannel").Add(1)
+	require.Equal(t, 1, fakeProvider.NewCounterCallCount())
+	assert.Equal(t, []string{"tenant", "channel"}, fakeProvider.NewCounterArgsForCall(0).LabelNames)
+	assert.Equal(t, []string{"channel"}, counterOpts.LabelNames)
+	assert.Equal(t, []string{"tenant", "acme", "channel", "acme-1"}, fakeCounter.WithArgsForCall(0))
+	assert.Equal(t, float64(2), fakeCounter.AddArgsForCall(0))
+	assert.Equal(t, []string{"tenant", "", "channel", "mychannel"}, fakeCounter.WithArgsForCall(1))
+
+	gaugeOpts := metrics.GaugeOpts{Namespace: "ledger", Name: "blockchain_height", LabelNames: []string{"channel"}}
+	acme.NewGauge(gaugeOpts).With("channel", "acme-1").Set(3)
+	noTenant.NewGauge(gaugeOpts).With("channel", "mychannel").Add(1)
+	require.Equal(t, 1, fakeProvider.NewGaugeCallCount())
+	assert.Equal(t, []string{"tenant", "acme", "channel", "acme-1"}, fakeGauge.WithArgsForCall(0))
+	assert.Equal(t, float64(3), fakeGauge.SetArgsForCall(0))
+	assert.Equal(t, []string{"tenant", "", "channel", "mychannel"}, fakeGauge.WithArgsForCall(1))
+
+	histogramOpts := metrics.HistogramOpts{Namespace: "ledger", Name: "block_processing_time"}
+	acme.NewHistogram(histogramOpts).Observe(0.5)
+	noTenant.NewHistogram(histogramOpts)
+	require.Equal(t, 1, fakeProvider.NewHistogramCallCount())
+	assert.Equal(t, []string{"tenant"}, fakeProvider.NewHistogramArgsForCall(0).LabelNames)
+	assert.Equal(t, []string{"tenant", "acme"}, fakeHistogram.WithArgsForCall(0))
+	assert.Equal(t, 0.5, fakeHistogram.ObserveArgsForCall(0))
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
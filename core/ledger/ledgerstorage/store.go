@@ -12,6 +12,8 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -23,6 +25,15 @@ import (
 
 var logger = flogging.MustGetLogger("ledgerstorage")
 
+var attrsToIndex = []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockHash,
+	blkstorage.IndexableAttrBlockNum,
+	blkstorage.IndexableAttrTxID,
+	blkstorage.IndexableAttrBlockNumTranNum,
+	blkstorage.IndexableAttrBlockTxID,
+	blkstorage.IndexableAttrTxValidationCode,
+}
+
 // Provider encapusaltes two providers 1) block store provider and 2) and pvt data store provider
 type Provider struct {
 	blkStoreProvider     blkstorage.BlockStoreProvider
@@ -37,22 +48,20 @@ type Store struct {
 }
 
 // NewProvider returns the handle to the provider
-func NewProvider() *Provider {
+func NewProvider(metricsProvider metrics.Provider) *Provider {
+	return NewProviderWithPaths(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetPvtdataStorePath(), metricsProvider)
+}
+
+// NewProviderWithPaths returns the handle to the provider of the block stores and the private data
+// stores at the given paths
+func NewProviderWithPaths(blockStorePath, pvtdataStorePath string, metricsProvider metrics.Provider) *Provider {
 	// Initialize the block storage
-	attrsToIndex := []blkstorage.IndexableAttr{
-		blkstorage.IndexableAttrBlockHash,
-		blkstorage.IndexableAttrBlockNum,
-		blkstorage.IndexableAttrTxID,
-		blkstorage.IndexableAttrBlockNumTranNum,
-		blkstorage.IndexableAttrBlockTxID,
-		blkstorage.IndexableAttrTxValidationCode,
-	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blockStoreProvider := fsblkstorage.NewProvider(
-		fsblkstorage.NewConf(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetMaxBlockfileSize()),
+		fsblkstorage.NewConf(blockStorePath, ledgerconfig.GetMaxBlockfileSize()),
 		indexConfig)
 
-	pvtStoreProvider := pvtdatastorage.NewProvider()
+	pvtStoreProvider := pvtdatastorage.NewProviderWithPath(pvtdataStorePath, metricsProvider)
 	return &Provider{blockStoreProvider, pvtStoreProvider}
 }
 
@@ -81,6 +90,58 @@ func (p *Provider) Close() {
 	p.pvtdataStoreProvider.Close()
 }
 
+// ValidateRollbackParams performs necessary validation on the input given for
+// the rollback operation
+func ValidateRollbackParams(blockStorageDir, ledgerID string, blockNum uint64) error {
+	return fsblkstorage.ValidateRollbackParams(blockStorageDir, ledgerID, blockNum)
+}
+
+// Rollback reverts the block store of the given ledger to the given block number.
+// The pvtdata store is not rolled back as it does not allow rewriting of pvt data; when the
+// removed blocks are received again, their pvt data is retained from the earlier commit.
+// However, a batch left pending in the pvtdata store by a crash is committed or discarded
+// beforehand, as when the store is opened, since this is decided by comparing the heights
+// of the two stores, which no longer match once the block store is truncated
+func Rollback(blockStorageDir, pvtdataStorageDir, ledgerID string, blockNum uint64) error {
+	if err := syncPendingPvtdataBatch(blockStorageDir, pvtdataStorageDir, ledgerID); err != nil {
+		return err
+	}
+	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
+	return fsblkstorage.Rollback(blockStorageDir, ledgerID, blockNum, indexConfig)
+}
+
+// syncPendingPvtdataBatch opens the stores of the given ledger, which brings the pvtdata
+// store in sync with the block store (see syncPvtdataStoreWithBlockStore), and closes them
+func syncPendingPvtdataBatch(blockStorageDir, pvtdataStorageDir, ledgerID string) error {
+	p := NewProviderWithPaths(blockStorageDir, pvtdataStorageDir, &disabled.Provider{})
+	defer p.Close()
+	_, err := p.Open(ledgerID)
+	return err
+}
+
+// DiskUsage returns the disk space used by the stores of the ledger in bytes, by store: "blockstore"
+// for the block files, "index" for the block index and "pvtdata" for the pvt data store. The stores
+// that are not able to report their disk usage are omitted
+func (s *Store) DiskUsage() (map[string]int64, error) {
+	usage := map[string]int64{}
+	if reporter, ok := s.BlockStore.(blkstorage.DiskUsageReporter); ok {
+		blocks, index, err := reporter.DiskUsage()
+		if err != nil {
+			return nil, err
+		}
+		usage["blockstore"] = blocks
+		usage["index"] = index
+	}
+	if reporter, ok := s.pvtdataStore.(pvtdatastorage.DiskUsageReporter); ok {
+		pvtdata, err := reporter.DiskUsage()
+		if err != nil {
+			return nil, err
+		}
+		usage["pvtdata"] = pvtdata
+	}
+	return usage, nil
+}
+
 // Init initializes store with essential configurations
 func (s *Store) Init(btlPolicy pvtdatapolicy.BTLPolicy) {
 	s.pvtdataStore.Init(btlPolicy)
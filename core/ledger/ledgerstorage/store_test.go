@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage/fsblkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
@@ -37,7 +38,7 @@ func TestMain(m *testing.M) {
 func TestStore(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
-	provider := NewProvider()
+	provider := NewProvider(&disabled.Provider{})
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -107,6 +108,28 @@ func TestStore(t *testing.T) {
 	assert.Equal(t, expectedMissingDataInfo, missingDataInfo)
 }
 
+func TestDiskUsage(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	provider := NewProvider(&disabled.Provider{})
+	defer provider.Close()
+	store, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+	defer store.Shutdown()
+
+	for _, sampleDatum := range sampleDataWithPvtdataForSelectiveTx(t) {
+		assert.NoError(t, store.CommitWithPvtData(sampleDatum))
+	}
+
+	usage, err := store.DiskUsage()
+	assert.NoError(t, err)
+	assert.Len(t, usage, 3)
+	assert.Contains(t, usage, "index")
+	assert.Contains(t, usage, "pvtdata")
+	assert.True(t, usage["blockstore"] > 0)
+}
+
 func TestStoreWithExistingBlockchain(t *testing.T) {
 	testLedgerid := "test-ledger"
 	testEnv := newTestEnv(t)
@@ -141,7 +164,7 @@ func TestStoreWithExistingBlockchain(t *testing.T) {
 
 	// Simulating the upgrade from 1.0 situation:
 	// Open the ledger storage - pvtdata store is opened for the first time with an existing block storage
-	provider := NewProvider()
+	provider := NewProvider(&disabled.Provider{})
 	defer provider.Close()
 	store, err := provider.Open(testLedgerid)
 	store.Init(btlPolicyForSampleData())
@@ -163,7 +186,7 @@ func TestStoreWithExistingBlockchain(t *testing.T) {
 func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
-	provider := NewProvider()
+	provider := NewProvider(&disabled.Provider{})
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -186,7 +209,7 @@ func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 	store.pvtdataStore.Prepare(blokNumAtCrash, pvtdataAtCrash, nil)
 	store.Shutdown()
 	provider.Close()
-	provider = NewProvider()
+	provider = NewProvider(&disabled.Provider{})
 	store, err = provider.Open("testLedger")
 	assert.NoError(t, err)
 	store.Init(btlPolicyForSampleData())
@@ -218,7 +241,7 @@ func TestCrashAfterPvtdataStorePreparation(t *testing.T) {
 func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
-	provider := NewProvider()
+	provider := NewProvider(&disabled.Provider{})
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -244,7 +267,7 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	store.BlockStore.AddBlock(dataAtCrash.Block)
 	store.Shutdown()
 	provider.Close()
-	provider = NewProvider()
+	provider = NewProvider(&disabled.Provider{})
 	store, err = provider.Open("testLedger")
 	assert.NoError(t, err)
 	store.Init(btlPolicyForSampleData())
@@ -254,10 +277,54 @@ func TestCrashBeforePvtdataStoreCommit(t *testing.T) {
 	assert.True(t, proto.Equal(dataAtCrash.Block, blkAndPvtdata.Block))
 }
 
+func TestRollbackWithPendingPvtdataBatch(t *testing.T) {
+	testEnv := newTestEnv(t)
+	defer testEnv.cleanup()
+	provider := NewProvider(&disabled.Provider{})
+	defer provider.Close()
+	store, err := provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+
+	sampleData := sampleDataWithPvtdataForAllTxs(t)
+	for _, sampleDatum := range sampleData[0:3] {
+		assert.NoError(t, store.CommitWithPvtData(sampleDatum))
+	}
+	dataAtCrash := sampleData[3]
+	var pvtdataAtCrash []*ledger.TxPvtData
+	for _, p := range dataAtCrash.PvtData {
+		pvtdataAtCrash = append(pvtdataAtCrash, p)
+	}
+
+	// Mimic a crash just short of calling the final commit on pvtdata store,
+	// and roll back the block store while the pvtdata store has a pending batch
+	assert.NoError(t, store.pvtdataStore.Prepare(dataAtCrash.Block.Header.Number, pvtdataAtCrash, nil))
+	assert.NoError(t, store.BlockStore.AddBlock(dataAtCrash.Block))
+	store.Shutdown()
+	provider.Close()
+	assert.NoError(t, Rollback(ledgerconfig.GetBlockStorePath(), ledgerconfig.GetPvtdataStorePath(), "testLedger", 1))
+
+	// the stores can be opened again, the pending batch having been committed before the rollback
+	provider = NewProvider(&disabled.Provider{})
+	store, err = provider.Open("testLedger")
+	assert.NoError(t, err)
+	store.Init(btlPolicyForSampleData())
+	defer store.Shutdown()
+	bcInfo, err := store.GetBlockchainInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), bcInfo.Height)
+	pendingBatch, err := store.pvtdataStore.HasPendingBatch()
+	assert.NoError(t, err)
+	assert.False(t, pendingBatch)
+	pvtdataStoreHt, err := store.pvtdataStore.LastCommittedBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), pvtdataStoreHt)
+}
+
 func TestAddAfterPvtdataStoreError(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
-	provider := NewProvider()
+	provider := NewProvider(&disabled.Provider{})
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -294,7 +361,7 @@ func TestAddAfterPvtdataStoreError(t *testing.T) {
 func TestAddAfterBlkStoreError(t *testing.T) {
 	testEnv := newTestEnv(t)
 	defer testEnv.cleanup()
-	provider := NewProvider()
+	provider := NewProvider(&disabled.Provider{})
 	defer provider.Close()
 	store, err := provider.Open("testLedger")
 	store.Init(btlPolicyForSampleData())
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+type stats struct {
+	purgeDuration    metrics.Histogram
+	purgedKeys       metrics.Counter
+	purgeDeferred    metrics.Gauge
+	upcomingExpiries metrics.Gauge
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	stats := &stats{}
+	stats.purgeDuration = metricsProvider.NewHistogram(purgeDurationOpts)
+	stats.purgedKeys = metricsProvider.NewCounter(purgedKeysOpts)
+	stats.purgeDeferred = metricsProvider.NewGauge(purgeDeferredOpts)
+	stats.upcomingExpiries = metricsProvider.NewGauge(upcomingExpiriesOpts)
+	return stats
+}
+
+type ledgerStats struct {
+	stats    *stats
+	ledgerid string
+	// reportedColls are the collections whose upcoming expiries were last
+	// reported, so that they are reset once they have none
+	reportedColls map[nsColl]struct{}
+}
+
+type nsColl struct {
+	ns, coll string
+}
+
+func (s *stats) ledgerStats(ledgerid string) *ledgerStats {
+	return &ledgerStats{
+		stats:         s,
+		ledgerid:      ledgerid,
+		reportedColls: map[nsColl]struct{}{},
+	}
+}
+
+func (s *ledgerStats) updatePurgeDuration(timeTaken time.Duration) {
+	s.stats.purgeDuration.With("channel", s.ledgerid).Observe(timeTaken.Seconds())
+}
+
+func (s *ledgerStats) updatePurgedKeys(numKeys int) {
+	s.stats.purgedKeys.With("channel", s.ledgerid).Add(float64(numKeys))
+}
+
+func (s *ledgerStats) updatePurgeDeferred(deferred bool) {
+	val := 0.0
+	if deferred {
+		val = 1
+	}
+	s.stats.purgeDeferred.With("channel", s.ledgerid).Set(val)
+}
+
+func (s *ledgerStats) updateUpcomingExpiries(expiries []*UpcomingExpiry) {
+	colls := map[nsColl]struct{}{}
+	for _, expiry := range expiries {
+		s.stats.upcomingExpiries.With(
+			"channel", s.ledgerid,
+			"chaincode", expiry.Namespace,
+			"collection", expiry.Collection,
+		).Set(float64(expiry.NumTxs))
+		colls[nsColl{expiry.Namespace, expiry.Collection}] = struct{}{}
+	}
+	for coll := range s.reportedColls {
+		if _, ok := colls[coll]; !ok {
+			s.stats.upcomingExpiries.With("channel", s.ledgerid, "chaincode", coll.ns, "collection", coll.coll).Set(0)
+		}
+	}
+	s.reportedColls = colls
+}
+
+var (
+	purgeDurationOpts = metrics.HistogramOpts{
+		Namespace:    "ledger",
+		Subsystem:    "pvtdata",
+		Name:         "purge_duration",
+		Help:         "Time taken in seconds for purging the expired private data from the private data store.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+		Buckets:      []float64{0.01, 0.1, 1, 10, 60, 600},
+	}
+
+	purgedKeysOpts = metrics.CounterOpts{
+		Namespace:    "ledger",
+		Subsystem:    "pvtdata",
+		Name:         "purged_keys",
+		Help:         "Number of keys of expired private data deleted from the private data store.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	purgeDeferredOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "pvtdata",
+		Name:         "purge_deferred",
+		Help:         "Whether a due purge of the private data store waits for the purge window (1) or not (0).",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	upcomingExpiriesOpts = metrics.GaugeOpts{
+		Namespace:    "ledger",
+		Subsystem:    "pvtdata",
+		Name:         "upcoming_expiries",
+		Help:         "Number of transactions whose private data of the collection expires within the expiry report horizon.",
+		LabelNames:   []string{"channel", "chaincode", "collection"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{collection}",
+	}
+)
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// purgeWindow is a daily window of the local time, which wraps around
+// midnight when it ends before it starts
+type purgeWindow struct {
+	start, end time.Duration // since midnight
+}
+
+// parsePurgeWindow parses a HH:MM-HH:MM window. An empty window returns nil,
+// which lets the purges run at any time
+func parsePurgeWindow(window string) (*purgeWindow, error) {
+	if window == "" {
+		return nil, nil
+	}
+	var startH, startM, endH, endM int
+	var rest string
+	n, _ := fmt.Sscanf(window, "%d:%d-%d:%d%s", &startH, &startM, &endH, &endM, &rest)
+	if n != 4 || !validTimeOfDay(startH, startM) || !validTimeOfDay(endH, endM) {
+		return nil, errors.Errorf("invalid purge window %s, expected HH:MM-HH:MM", window)
+	}
+	w := &purgeWindow{
+		start: time.Duration(startH)*time.Hour + time.Duration(startM)*time.Minute,
+		end:   time.Duration(endH)*time.Hour + time.Duration(endM)*time.Minute,
+	}
+	if w.start == w.end {
+		return nil, errors.Errorf("invalid purge window %s, the window is empty", window)
+	}
+	return w, nil
+}
+
+func validTimeOfDay(h, m int) bool {
+	return h >= 0 && h <= 24 && m >= 0 && m < 60 && (h < 24 || m == 0)
+}
+
+// wait returns how long to wait from now for the window to open, 0 when now
+// is within the window
+func (w *purgeWindow) wait(now time.Time) time.Duration {
+	if w == nil {
+		return 0
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sinceMidnight := now.Sub(midnight)
+
+	inWindow := sinceMidnight >= w.start && sinceMidnight < w.end
+	if w.end < w.start {
+		inWindow = sinceMidnight >= w.start || sinceMidnight < w.end
+	}
+	if inWindow {
+		return 0
+	}
+	if sinceMidnight < w.start {
+		return w.start - sinceMidnight
+	}
+	return midnight.AddDate(0, 0, 1).Add(w.start).Sub(now)
+}
+
+func (w *purgeWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d",
+		int(w.start.Hours()), int(w.start.Minutes())%60, int(w.end.Hours()), int(w.end.Minutes())%60)
+}
+
+// purgeSchedule tracks the purge due at the committed blocks multiple of the
+// purge interval. The purges due while a purge runs or waits for the purge
+// window are coalesced into a single purge up to the last of them
+type purgeSchedule struct {
+	lock       sync.Mutex
+	pending    bool
+	pendingBlk uint64
+	running    bool
+}
+
+// schedule records a purge due up to the block and returns whether the caller
+// has to launch the purger
+func (p *purgeSchedule) schedule(maxBlkNum uint64) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.pending = true
+	p.pendingBlk = maxBlkNum
+	if p.running {
+		return false
+	}
+	p.running = true
+	return true
+}
+
+// next returns the block up to which the next purge is due, or false when no
+// purge is due anymore, in which case the purger has to stop
+func (p *purgeSchedule) next() (uint64, bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if !p.pending {
+		p.running = false
+		return 0, false
+	}
+	p.pending = false
+	return p.pendingBlk, true
+}
@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package pvtdatastorage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePurgeWindow(t *testing.T) {
+	w, err := parsePurgeWindow("")
+	assert.NoError(t, err)
+	assert.Nil(t, w)
+
+	w, err = parsePurgeWindow("01:30-05:00")
+	assert.NoError(t, err)
+	assert.Equal(t, &purgeWindow{start: 90 * time.Minute, end: 5 * time.Hour}, w)
+	assert.Equal(t, "01:30-05:00", w.String())
+
+	w, err = parsePurgeWindow("22:00-24:00")
+	assert.NoError(t, err)
+	assert.Equal(t, &purgeWindow{start: 22 * time.Hour, end: 24 * time.Hour}, w)
+
+	for _, window := range []string{"1:00", "01:00-25:00", "01:60-02:00", "24:30-01:00", "01:00-02:00x", "03:00-03:00", "night"} {
+		_, err := parsePurgeWindow(window)
+		assert.Error(t, err, window)
+	}
+}
+
+func TestPurgeWindowWait(t *testing.T) {
+	at := func(h, m int) time.Time {
+		return time.Date(2019, 3, 12, h, m, 0, 0, time.Local)
+	}
+
+	var noWindow *purgeWindow
+	assert.Equal(t, time.Duration(0), noWindow.wait(at(12, 0)))
+
+	w, err := parsePurgeWindow("01:00-05:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), w.wait(at(1, 0)))
+	assert.Equal(t, time.Duration(0), w.wait(at(4, 59)))
+	assert.Equal(t, 30*time.Minute, w.wait(at(0, 30)))
+	assert.Equal(t, 20*time.Hour, w.wait(at(5, 0)))
+
+	// the window wraps around midnight
+	w, err = parsePurgeWindow("22:00-02:00")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), w.wait(at(23, 0)))
+	assert.Equal(t, time.Duration(0), w.wait(at(1, 59)))
+	assert.Equal(t, 20*time.Hour, w.wait(at(2, 0)))
+	assert.Equal(t, time.Hour, w.wait(at(21, 0)))
+}
+
+func TestPurgeSchedule(t *testing.T) {
+	p := &purgeSchedule{}
+
+	_, ok := p.next()
+	assert.False(t, ok)
+
+	// the first due purge launches the purger, the following ones are
+	// coalesced while it runs
+	assert.True(t, p.schedule(100))
+	assert.False(t, p.schedule(200))
+	assert.False(t, p.schedule(300))
+	blkNum, ok := p.next()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(300), blkNum)
+	_, ok = p.next()
+	assert.False(t, ok)
+
+	// the purger stopped, the next due purge launches it again
+	assert.True(t, p.schedule(400))
+}
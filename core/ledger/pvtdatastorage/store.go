@@ -82,10 +82,32 @@ type Store interface {
 	LastCommittedBlockHeight() (uint64, error)
 	// HasPendingBatch returns if the store has a pending batch
 	HasPendingBatch() (bool, error)
+	// GetUpcomingExpiries returns, for each collection, the private data expiring within the next `numBlocks`
+	// blocks, including the expired private data not purged yet
+	GetUpcomingExpiries(numBlocks uint64) ([]*UpcomingExpiry, error)
 	// Shutdown stops the store
 	Shutdown()
 }
 
+// DiskUsageReporter is implemented by the stores that are able to report the disk space they use
+type DiskUsageReporter interface {
+	// DiskUsage returns the approximate disk space used by the pvt data of the ledger, in bytes
+	DiskUsage() (int64, error)
+}
+
+// UpcomingExpiry summarizes the private data of a collection expiring within a number of blocks
+type UpcomingExpiry struct {
+	Namespace  string
+	Collection string
+	// NumTxs is the number of transactions whose private data of the collection expires
+	NumTxs int
+	// NextExpiringBlock is the block number at which private data of the collection expires next
+	NextExpiringBlock uint64
+	// LastExpiringBlock is the last block number, within the number of blocks, at which private
+	// data of the collection expires
+	LastExpiringBlock uint64
+}
+
 // ErrIllegalCall is to be thrown by a store impl if the store does not expect a call to Prepare/Commit/Rollback/InitLastCommittedBlock
 type ErrIllegalCall struct {
 	msg string
@@ -16,10 +16,12 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/pvtdatapolicy"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
 	"github.com/willf/bitset"
 )
 
@@ -27,6 +29,7 @@ var logger = flogging.MustGetLogger("pvtdatastorage")
 
 type provider struct {
 	dbProvider *leveldbhelper.Provider
+	stats      *stats
 }
 
 type store struct {
@@ -39,6 +42,9 @@ type store struct {
 	batchPending       bool
 	purgerLock         sync.Mutex
 	collElgProcSync    *collElgProcSync
+	purgeSchedule      purgeSchedule
+	purgeConf          *purgeConf
+	stats              *ledgerStats
 	// After committing the pvtdata of old blocks,
 	// the `isLastUpdatedOldBlocksSet` is set to true.
 	// Once the stateDB is updated with these pvtdata,
@@ -51,6 +57,15 @@ type store struct {
 	isLastUpdatedOldBlocksSet bool
 }
 
+// purgeConf is the configuration of the purges of the expired data
+type purgeConf struct {
+	interval            uint64
+	batchSize           int
+	batchesInterval     time.Duration
+	window              *purgeWindow
+	expiryReportHorizon uint64
+}
+
 type blkTranNumKey []byte
 
 type dataEntry struct {
@@ -106,20 +121,36 @@ type entriesForPvtDataOfOldBlocks struct {
 //////////////////////////////////////////
 
 // NewProvider instantiates a StoreProvider
-func NewProvider() Provider {
-	dbPath := ledgerconfig.GetPvtdataStorePath()
-	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath})
-	return &provider{dbProvider: dbProvider}
+func NewProvider(metricsProvider metrics.Provider) Provider {
+	return NewProviderWithPath(ledgerconfig.GetPvtdataStorePath(), metricsProvider)
+}
+
+// NewProviderWithPath instantiates a StoreProvider for the stores at the given path
+func NewProviderWithPath(dbPath string, metricsProvider metrics.Provider) Provider {
+	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: dbPath, Compaction: ledgerconfig.GetLevelDBCompactionConf()})
+	return &provider{dbProvider: dbProvider, stats: newStats(metricsProvider)}
 }
 
 // OpenStore returns a handle to a store
 func (p *provider) OpenStore(ledgerid string) (Store, error) {
+	purgeWindow, err := parsePurgeWindow(ledgerconfig.GetPvtdataStorePurgeWindow())
+	if err != nil {
+		return nil, err
+	}
 	dbHandle := p.dbProvider.GetDBHandle(ledgerid)
 	s := &store{db: dbHandle, ledgerid: ledgerid,
 		collElgProcSync: &collElgProcSync{
 			notification: make(chan bool, 1),
 			procComplete: make(chan bool, 1),
 		},
+		purgeConf: &purgeConf{
+			interval:            ledgerconfig.GetPvtdataStorePurgeInterval(),
+			batchSize:           ledgerconfig.GetPvtdataStorePurgeBatchSize(),
+			batchesInterval:     time.Duration(ledgerconfig.GetPvtdataStorePurgeBatchesInterval()) * time.Millisecond,
+			window:              purgeWindow,
+			expiryReportHorizon: ledgerconfig.GetPvtdataStoreExpiryReportHorizon(),
+		},
+		stats: p.stats.ledgerStats(ledgerid),
 	}
 	if err := s.initState(); err != nil {
 		return nil, err
@@ -772,44 +803,164 @@ func (s *store) ProcessCollsEligibilityEnabled(committingBlk uint64, nsCollMap m
 }
 
 func (s *store) performPurgeIfScheduled(latestCommittedBlk uint64) {
-	if latestCommittedBlk%ledgerconfig.GetPvtdataStorePurgeInterval() != 0 {
+	if latestCommittedBlk%s.purgeConf.interval != 0 {
 		return
 	}
-	go func() {
+	if s.purgeSchedule.schedule(latestCommittedBlk) {
+		go s.runPurger()
+	}
+}
+
+// runPurger performs the due purges, deferring them until the purge window
+// opens, and reports the upcoming expiries after each of them
+func (s *store) runPurger() {
+	for {
+		if wait := s.purgeConf.window.wait(time.Now()); wait > 0 {
+			logger.Infof("[%s] Deferring the purge of the expired private data by %s, until the purge window %s", s.ledgerid, wait, s.purgeConf.window)
+			s.stats.updatePurgeDeferred(true)
+			time.Sleep(wait)
+			s.stats.updatePurgeDeferred(false)
+		}
+		maxBlkNum, ok := s.purgeSchedule.next()
+		if !ok {
+			return
+		}
+
 		s.purgerLock.Lock()
-		logger.Debugf("Purger started: Purging expired private data till block number [%d]", latestCommittedBlk)
-		defer s.purgerLock.Unlock()
-		err := s.purgeExpiredData(0, latestCommittedBlk)
+		logger.Debugf("Purger started: Purging expired private data till block number [%d]", maxBlkNum)
+		startTime := time.Now()
+		err := s.purgeExpiredData(0, maxBlkNum)
+		s.stats.updatePurgeDuration(time.Since(startTime))
 		if err != nil {
 			logger.Warningf("Could not purge data from pvtdata store:%s", err)
 		}
+		s.purgerLock.Unlock()
 		logger.Debug("Purger finished")
-	}()
+
+		s.reportUpcomingExpiries()
+	}
 }
 
+// purgeExpiredData deletes the data expired up to maxBlkNum in db batches of at most the purge
+// batch size, pausing for the purge batches interval between the batches. It is expected to be
+// invoked with the purgerLock held, which is released during the pauses
 func (s *store) purgeExpiredData(minBlkNum, maxBlkNum uint64) error {
-	batch := leveldbhelper.NewUpdateBatch()
 	expiryEntries, err := s.retrieveExpiryEntries(minBlkNum, maxBlkNum)
 	if err != nil || len(expiryEntries) == 0 {
 		return err
 	}
+	batch := leveldbhelper.NewUpdateBatch()
+	purgedKeys := 0
+	writeBatch := func() error {
+		if err := s.db.WriteBatch(batch, false); err != nil {
+			return err
+		}
+		s.stats.updatePurgedKeys(batch.Len())
+		purgedKeys += batch.Len()
+		batch = leveldbhelper.NewUpdateBatch()
+		return nil
+	}
+	deleteKey := func(key []byte) error {
+		batch.Delete(key)
+		if batch.Len() < s.purgeConf.batchSize {
+			return nil
+		}
+		if err := writeBatch(); err != nil {
+			return err
+		}
+		if s.purgeConf.batchesInterval > 0 {
+			logger.Debugf("[%s] Going to sleep for %s between purge batches, keys purged so far = %d", s.ledgerid, s.purgeConf.batchesInterval, purgedKeys)
+			s.purgerLock.Unlock()
+			time.Sleep(s.purgeConf.batchesInterval)
+			s.purgerLock.Lock()
+		}
+		return nil
+	}
+
 	for _, expiryEntry := range expiryEntries {
-		// this encoding could have been saved if the function retrieveExpiryEntries also returns the encoded expiry keys.
-		// However, keeping it for better readability
-		batch.Delete(encodeExpiryKey(expiryEntry.key))
+		// the expiry key is deleted last, so that the keys of an entry purged
+		// partially are purged again by the next purge
+		keys := [][]byte{}
 		dataKeys, missingDataKeys := deriveKeys(expiryEntry)
 		for _, dataKey := range dataKeys {
-			batch.Delete(encodeDataKey(dataKey))
+			keys = append(keys, encodeDataKey(dataKey))
 		}
 		for _, missingDataKey := range missingDataKeys {
-			batch.Delete(encodeMissingDataKey(missingDataKey))
+			keys = append(keys, encodeMissingDataKey(missingDataKey))
+		}
+		// this encoding could have been saved if the function retrieveExpiryEntries also returns the encoded expiry keys.
+		// However, keeping it for better readability
+		keys = append(keys, encodeExpiryKey(expiryEntry.key))
+		for _, key := range keys {
+			if err := deleteKey(key); err != nil {
+				return err
+			}
+		}
+	}
+	if batch.Len() > 0 {
+		if err := writeBatch(); err != nil {
+			return err
 		}
-		s.db.WriteBatch(batch, false)
 	}
 	logger.Infof("[%s] - [%d] Entries purged from private data storage till block number [%d]", s.ledgerid, len(expiryEntries), maxBlkNum)
 	return nil
 }
 
+// reportUpcomingExpiries logs and exports the expiries of the private data of each collection
+// within the expiry report horizon
+func (s *store) reportUpcomingExpiries() {
+	expiries, err := s.GetUpcomingExpiries(s.purgeConf.expiryReportHorizon)
+	if err != nil {
+		logger.Warningf("[%s] Could not report the upcoming expiries of the private data: %s", s.ledgerid, err)
+		return
+	}
+	s.stats.updateUpcomingExpiries(expiries)
+	for _, expiry := range expiries {
+		logger.Infof("[%s] Private data of [%d] transactions of [ns=%s, coll=%s] expiring by block number [%d], next at block number [%d]",
+			s.ledgerid, expiry.NumTxs, expiry.Namespace, expiry.Collection, expiry.LastExpiringBlock, expiry.NextExpiringBlock)
+	}
+}
+
+// GetUpcomingExpiries implements the function in the interface `Store`
+func (s *store) GetUpcomingExpiries(numBlocks uint64) ([]*UpcomingExpiry, error) {
+	if s.isEmpty {
+		return nil, nil
+	}
+	maxBlkNum := atomic.LoadUint64(&s.lastCommittedBlock) + numBlocks
+	expiryEntries, err := s.retrieveExpiryEntries(0, maxBlkNum)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not retrieve the expiry entries")
+	}
+
+	expiries := map[nsColl]*UpcomingExpiry{}
+	for _, expiryEntry := range expiryEntries {
+		for ns, colls := range expiryEntry.value.Map {
+			for coll, txNums := range colls.Map {
+				expiry, ok := expiries[nsColl{ns, coll}]
+				if !ok {
+					expiry = &UpcomingExpiry{Namespace: ns, Collection: coll, NextExpiringBlock: expiryEntry.key.expiringBlk}
+					expiries[nsColl{ns, coll}] = expiry
+				}
+				// the entries are sorted by expiring block
+				expiry.LastExpiringBlock = expiryEntry.key.expiringBlk
+				expiry.NumTxs += len(txNums.List)
+			}
+		}
+	}
+
+	result := make([]*UpcomingExpiry, 0, len(expiries))
+	for _, expiry := range expiries {
+		result = append(result, expiry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Collection < result[j].Collection
+	})
+	return result, nil
+}
+
 func (s *store) retrieveExpiryEntries(minBlkNum, maxBlkNum uint64) ([]*expiryEntry, error) {
 	startKey, endKey := getExpiryKeysForRangeScan(minBlkNum, maxBlkNum)
 	logger.Debugf("retrieveExpiryEntries(): startKey=%#v, endKey=%#v", startKey, endKey)
@@ -922,6 +1073,11 @@ func (s *store) IsEmpty() (bool, error) {
 	return s.isEmpty, nil
 }
 
+// DiskUsage implements the function in the interface `DiskUsageReporter`
+func (s *store) DiskUsage() (int64, error) {
+	return s.db.ApproximateSize()
+}
+
 // Shutdown implements the function in the interface `Store`
 func (s *store) Shutdown() {
 	// do nothing
@@ -15,6 +15,8 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
@@ -627,6 +629,81 @@ func TestStorePurge(t *testing.T) {
 	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-2", blkNum: 1}, txNum: 2}))
 }
 
+func TestStorePurgeInBatches(t *testing.T) {
+	ledgerid := "TestStorePurgeInBatches"
+	viper.Set("ledger.pvtdataStore.purgeInterval", 2)
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 1,
+			{"ns-1", "coll-2"}: 0,
+			{"ns-2", "coll-1"}: 4,
+		},
+	)
+	env := NewTestStoreEnv(t, ledgerid, btlPolicy)
+	defer env.Cleanup()
+	assert := assert.New(t)
+	s := env.TestStore
+
+	fakeProvider := &metricsfakes.Provider{}
+	fakePurgedKeys := &metricsfakes.Counter{}
+	fakePurgedKeys.WithReturns(fakePurgedKeys)
+	fakeProvider.NewCounterReturns(fakePurgedKeys)
+	fakeUpcomingExpiries := &metricsfakes.Gauge{}
+	fakeUpcomingExpiries.WithReturns(fakeUpcomingExpiries)
+	fakeProvider.NewGaugeReturns(fakeUpcomingExpiries)
+	fakeProvider.NewHistogramReturns(&metricsfakes.Histogram{WithStub: func(...string) metrics.Histogram { return &metricsfakes.Histogram{} }})
+	s.(*store).stats = newStats(fakeProvider).ledgerStats(ledgerid)
+	s.(*store).purgeConf.batchSize = 1
+
+	assert.NoError(s.Prepare(0, nil, nil))
+	assert.NoError(s.Commit())
+	testDataForBlk1 := []*ledger.TxPvtData{
+		produceSamplePvtdata(t, 2, []string{"ns-1:coll-1", "ns-1:coll-2", "ns-2:coll-1"}),
+		produceSamplePvtdata(t, 4, []string{"ns-1:coll-1", "ns-2:coll-1"}),
+	}
+	assert.NoError(s.Prepare(1, testDataForBlk1, nil))
+	assert.NoError(s.Commit())
+	assert.NoError(s.Prepare(2, nil, nil))
+	assert.NoError(s.Commit())
+	testWaitForPurgerRoutineToFinish(s)
+
+	ns1Coll1Expiry, err := btlPolicy.GetExpiringBlock("ns-1", "coll-1", 1)
+	assert.NoError(err)
+	ns2Coll1Expiry, err := btlPolicy.GetExpiringBlock("ns-2", "coll-1", 1)
+	assert.NoError(err)
+	expiries, err := s.GetUpcomingExpiries(ns2Coll1Expiry - 2)
+	assert.NoError(err)
+	assert.Equal([]*UpcomingExpiry{
+		{Namespace: "ns-1", Collection: "coll-1", NumTxs: 2, NextExpiringBlock: ns1Coll1Expiry, LastExpiringBlock: ns1Coll1Expiry},
+		{Namespace: "ns-2", Collection: "coll-1", NumTxs: 2, NextExpiringBlock: ns2Coll1Expiry, LastExpiringBlock: ns2Coll1Expiry},
+	}, expiries)
+	expiries, err = s.GetUpcomingExpiries(ns1Coll1Expiry - 2)
+	assert.NoError(err)
+	assert.Len(expiries, 1)
+
+	for blkNum := uint64(3); blkNum <= ns1Coll1Expiry+1; blkNum++ {
+		assert.NoError(s.Prepare(blkNum, nil, nil))
+		assert.NoError(s.Commit())
+	}
+	testWaitForPurgerRoutineToFinish(s)
+	assert.False(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 2}))
+	assert.False(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-1", coll: "coll-1", blkNum: 1}, txNum: 4}))
+	assert.True(testDataKeyExists(t, s, &dataKey{nsCollBlk: nsCollBlk{ns: "ns-2", coll: "coll-1", blkNum: 1}, txNum: 2}))
+
+	// the expiry key and the two data keys were deleted one per batch
+	assert.Equal(3, fakePurgedKeys.AddCallCount())
+	for i := 0; i < 3; i++ {
+		assert.Equal(float64(1), fakePurgedKeys.AddArgsForCall(i))
+	}
+
+	// the collections are reported until their private data is purged
+	expiries, err = s.GetUpcomingExpiries(1000)
+	assert.NoError(err)
+	assert.Equal([]*UpcomingExpiry{
+		{Namespace: "ns-2", Collection: "coll-1", NumTxs: 2, NextExpiringBlock: ns2Coll1Expiry, LastExpiringBlock: ns2Coll1Expiry},
+	}, expiries)
+}
+
 func TestStoreState(t *testing.T) {
 	btlPolicy := btltestutil.SampleBTLPolicy(
 		map[[2]string]uint64{
@@ -80,6 +80,10 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.state.couchDBConfig.cacheSize", 0)
+	viper.Set("ledger.state.queryLimits.maxOpenIteratorsPerTx", 0)
+	viper.Set("ledger.state.queryLimits.maxResultsPerTx", 0)
+	viper.Set("ledger.state.queryLimits.maxBytesScannedPerTx", 0)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
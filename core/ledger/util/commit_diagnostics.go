@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// GetCommitDiagnostics returns the diagnostics recorded in the metadata of the given block by the committer.
+// Empty diagnostics are returned for a block that does not carry any
+func GetCommitDiagnostics(block *common.Block) (*peer.CommitDiagnostics, error) {
+	diagnostics := &peer.CommitDiagnostics{}
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_COMMIT_DIAGNOSTICS) {
+		return diagnostics, nil
+	}
+	if err := proto.Unmarshal(block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_DIAGNOSTICS], diagnostics); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the commit diagnostics of block %d", block.Header.Number)
+	}
+	return diagnostics, nil
+}
+
+// SetCommitDiagnostics records the given diagnostics in the metadata of the block, extending the metadata of
+// the blocks assembled by the orderers that do not reserve the index COMMIT_DIAGNOSTICS. The metadata is left
+// untouched if the diagnostics are empty
+func SetCommitDiagnostics(block *common.Block, diagnostics *peer.CommitDiagnostics) error {
+	if len(diagnostics.MvccConflicts) == 0 {
+		return nil
+	}
+	diagnosticsBytes, err := proto.Marshal(diagnostics)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling the commit diagnostics of block %d", block.Header.Number)
+	}
+	for len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_COMMIT_DIAGNOSTICS) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, []byte{})
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_DIAGNOSTICS] = diagnosticsBytes
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitDiagnostics(t *testing.T) {
+	// a block assembled by an orderer that does not reserve the index of the diagnostics
+	block := common.NewBlock(5, nil)
+	block.Metadata.Metadata = block.Metadata.Metadata[:common.BlockMetadataIndex_COMMIT_DIAGNOSTICS]
+
+	diagnostics, err := GetCommitDiagnostics(block)
+	assert.NoError(t, err)
+	assert.Empty(t, diagnostics.MvccConflicts)
+
+	assert.NoError(t, SetCommitDiagnostics(block, &peer.CommitDiagnostics{}))
+	assert.Len(t, block.Metadata.Metadata, int(common.BlockMetadataIndex_COMMIT_DIAGNOSTICS))
+
+	conflict := &peer.MVCCConflict{
+		TxIndex:          2,
+		Namespace:        "ns1",
+		Key:              "key1",
+		ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 1},
+		CommittedVersion: &kvrwset.Version{BlockNum: 4, TxNum: 0},
+	}
+	assert.NoError(t, SetCommitDiagnostics(block, &peer.CommitDiagnostics{MvccConflicts: []*peer.MVCCConflict{conflict}}))
+	diagnostics, err = GetCommitDiagnostics(block)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(conflict, diagnostics.MVCCConflict(2)))
+	assert.Nil(t, diagnostics.MVCCConflict(1))
+
+	block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_DIAGNOSTICS] = []byte("garbage")
+	_, err = GetCommitDiagnostics(block)
+	assert.Contains(t, err.Error(), "error unmarshaling the commit diagnostics of block 5")
+}
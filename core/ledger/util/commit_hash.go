@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+)
+
+// GetCommitHash returns the commit hash recorded in the metadata of the given block by the committer,
+// which is encoded as a common.Metadata message at the index COMMIT_HASH. A nil hash is returned for
+// a block that does not carry any
+func GetCommitHash(block *common.Block) ([]byte, error) {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_COMMIT_HASH) {
+		return nil, nil
+	}
+	metadataBytes := block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_HASH]
+	if len(metadataBytes) == 0 {
+		return nil, nil
+	}
+	metadata := &common.Metadata{}
+	if err := proto.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the commit hash of block %d", block.Header.Number)
+	}
+	return metadata.Value, nil
+}
+
+// SetCommitHash records the given commit hash in the metadata of the block, extending the metadata of
+// the blocks assembled by the orderers that do not reserve the index COMMIT_HASH
+func SetCommitHash(block *common.Block, commitHash []byte) error {
+	metadataBytes, err := proto.Marshal(&common.Metadata{Value: commitHash})
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling the commit hash of block %d", block.Header.Number)
+	}
+	for len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_COMMIT_HASH) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, []byte{})
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_HASH] = metadataBytes
+	return nil
+}
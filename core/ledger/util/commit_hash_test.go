@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitHash(t *testing.T) {
+	// a block assembled by an orderer that does not reserve the index of the commit hash
+	block := common.NewBlock(5, nil)
+	block.Metadata.Metadata = block.Metadata.Metadata[:common.BlockMetadataIndex_COMMIT_HASH]
+
+	commitHash, err := GetCommitHash(block)
+	assert.NoError(t, err)
+	assert.Nil(t, commitHash)
+
+	assert.NoError(t, SetCommitHash(block, []byte("hash")))
+	assert.Len(t, block.Metadata.Metadata, int(common.BlockMetadataIndex_COMMIT_HASH)+1)
+	commitHash, err = GetCommitHash(block)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hash"), commitHash)
+
+	block.Metadata.Metadata[common.BlockMetadataIndex_COMMIT_HASH] = []byte("garbage")
+	_, err = GetCommitHash(block)
+	assert.Contains(t, err.Error(), "error unmarshaling the commit hash of block 5")
+}
@@ -101,6 +101,7 @@ type DocMetadata struct {
 	ID              string                     `json:"_id"`
 	Rev             string                     `json:"_rev"`
 	Version         string                     `json:"~version"`
+	Chunks          json.RawMessage            `json:"~chunks"`
 	AttachmentsInfo map[string]*AttachmentInfo `json:"_attachments"`
 }
 
@@ -177,14 +178,27 @@ type CouchDoc struct {
 }
 
 //BatchRetrieveDocMetadataResponse is used for processing REST batch responses from CouchDB
+//for the requests projecting the documents to their metadata fields
 type BatchRetrieveDocMetadataResponse struct {
+	Docs []struct {
+		ID      string          `json:"_id"`
+		Rev     string          `json:"_rev"`
+		Version string          `json:"~version"`
+		Chunks  json.RawMessage `json:"~chunks"`
+	} `json:"docs"`
+	Warning string `json:"warning"`
+}
+
+//BatchRetrieveDocRevisionsResponse is used for processing REST batch responses from CouchDB
+//for the requests that do not include the documents
+type BatchRetrieveDocRevisionsResponse struct {
 	Rows []struct {
-		ID          string `json:"id"`
-		DocMetadata struct {
-			ID      string `json:"_id"`
-			Rev     string `json:"_rev"`
-			Version string `json:"~version"`
-		} `json:"doc"`
+		ID    string `json:"id"`
+		Error string `json:"error"`
+		Value struct {
+			Rev     string `json:"rev"`
+			Deleted bool   `json:"deleted"`
+		} `json:"value"`
 	} `json:"rows"`
 }
 
@@ -437,6 +451,36 @@ func (couchInstance *CouchInstance) VerifyCouchConfig() (*ConnectionInfo, *DBRet
 	return dbResponse, couchDBReturn, nil
 }
 
+// RetrieveApplicationDBNames returns the names of all the databases in the CouchDB instance,
+// except for the system databases (the names of which start with an underscore)
+func (couchInstance *CouchInstance) RetrieveApplicationDBNames() ([]string, error) {
+	connectURL, err := url.Parse(couchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing couch instance URL: %s", couchInstance.conf.URL)
+	}
+	connectURL.Path = "/_all_dbs"
+	maxRetries := couchInstance.conf.MaxRetries
+	resp, _, err := couchInstance.handleRequest(context.Background(), http.MethodGet, "", "RetrieveApplicationDBNames", connectURL, nil,
+		couchInstance.conf.Username, couchInstance.conf.Password, maxRetries, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	var dbNames []string
+	if err := json.NewDecoder(resp.Body).Decode(&dbNames); err != nil {
+		return nil, errors.Wrap(err, "error decoding response body")
+	}
+	var applicationDBNames []string
+	for _, dbName := range dbNames {
+		if !strings.HasPrefix(dbName, "_") {
+			applicationDBNames = append(applicationDBNames, dbName)
+		}
+	}
+	return applicationDBNames, nil
+}
+
 // HealthCheck checks if the peer is able to communicate with CouchDB
 func (couchInstance *CouchInstance) HealthCheck(ctx context.Context) error {
 	connectURL, err := url.Parse(couchInstance.conf.URL)
@@ -1433,7 +1477,8 @@ func (dbclient *CouchDatabase) ApplyDatabaseSecurity(databaseSecurity *DatabaseS
 }
 
 //BatchRetrieveDocumentMetadata - batch method to retrieve document metadata for  a set of keys,
-// including ID, couchdb revision number, and ledger version
+// including ID, couchdb revision number, and ledger version. The keys that do not exist
+// are not present in the returned metadata
 func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*DocMetadata, error) {
 
 	logger.Debugf("[%s] Entering BatchRetrieveDocumentMetadata()  keys=%s", dbclient.DBName, keys)
@@ -1444,21 +1489,17 @@ func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*
 		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", dbclient.CouchInstance.conf.URL)
 	}
 
-	queryParms := batchRetrieveURL.Query()
-
-	// While BatchRetrieveDocumentMetadata() does not return the entire document,
-	// for reads/writes, we do need to get document so that we can get the ledger version of the key.
-	// TODO For blind writes we do not need to get the version, therefore when we bulk get
-	// the revision numbers for the write keys that were not represented in read set
-	// (the second time BatchRetrieveDocumentMetadata is called during block processing),
-	// we could set include_docs to false to optimize the response.
-	queryParms.Add("include_docs", "true")
-
-	keymap := make(map[string]interface{})
-
-	keymap["keys"] = keys
+	// The documents are projected to their metadata fields, so that their values,
+	// which may be large, are not retrieved along with the ledger versions of the keys
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"_id": map[string]interface{}{"$in": keys},
+		},
+		"fields": []string{"_id", "_rev", "~version", "~chunks"},
+		"limit":  len(keys),
+	}
 
-	jsonKeys, err := json.Marshal(keymap)
+	jsonQuery, err := json.Marshal(query)
 	if err != nil {
 		return nil, errors.Wrap(err, "error marshalling json data")
 	}
@@ -1466,7 +1507,7 @@ func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.handleRequest(http.MethodPost, "BatchRetrieveDocumentMetadata", batchRetrieveURL, jsonKeys, "", "", maxRetries, true, &queryParms, "_all_docs")
+	resp, _, err := dbclient.handleRequest(http.MethodPost, "BatchRetrieveDocumentMetadata", batchRetrieveURL, jsonQuery, "", "", maxRetries, true, nil, "_find")
 	if err != nil {
 		return nil, err
 	}
@@ -1493,8 +1534,12 @@ func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*
 
 	docMetadataArray := []*DocMetadata{}
 
-	for _, row := range jsonResponse.Rows {
-		docMetadata := &DocMetadata{ID: row.ID, Rev: row.DocMetadata.Rev, Version: row.DocMetadata.Version}
+	if jsonResponse.Warning != "" {
+		logger.Debugf("[%s] The metadata retrieval caused the following warning: [%s]", dbclient.DBName, jsonResponse.Warning)
+	}
+
+	for _, doc := range jsonResponse.Docs {
+		docMetadata := &DocMetadata{ID: doc.ID, Rev: doc.Rev, Version: doc.Version, Chunks: doc.Chunks}
 		docMetadataArray = append(docMetadataArray, docMetadata)
 	}
 
@@ -1504,6 +1549,50 @@ func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*
 
 }
 
+// BatchRetrieveDocumentRevisions retrieves the current revisions of the documents with the given ids.
+// Unlike BatchRetrieveDocumentMetadata, the documents themselves are not retrieved. The ids of the
+// documents that do not exist (or are deleted) are not present in the returned map
+func (dbclient *CouchDatabase) BatchRetrieveDocumentRevisions(keys []string) (map[string]string, error) {
+	logger.Debugf("[%s] Entering BatchRetrieveDocumentRevisions()  keys=%s", dbclient.DBName, keys)
+
+	batchRetrieveURL, err := url.Parse(dbclient.CouchInstance.conf.URL)
+	if err != nil {
+		logger.Errorf("URL parse error: %s", err)
+		return nil, errors.Wrapf(err, "error parsing CouchDB URL: %s", dbclient.CouchInstance.conf.URL)
+	}
+
+	jsonKeys, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshalling json data")
+	}
+
+	maxRetries := dbclient.CouchInstance.conf.MaxRetries
+	resp, _, err := dbclient.handleRequest(http.MethodPost, "BatchRetrieveDocumentRevisions", batchRetrieveURL, jsonKeys, "", "", maxRetries, true, nil, "_all_docs")
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponseBody(resp)
+
+	jsonResponseRaw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading response body")
+	}
+	jsonResponse := &BatchRetrieveDocRevisionsResponse{}
+	if err := json.Unmarshal(jsonResponseRaw, jsonResponse); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling json data")
+	}
+
+	revisions := make(map[string]string)
+	for _, row := range jsonResponse.Rows {
+		if row.Error != "" || row.Value.Deleted {
+			continue
+		}
+		revisions[row.ID] = row.Value.Rev
+	}
+	logger.Debugf("[%s] Exiting BatchRetrieveDocumentRevisions()", dbclient.DBName)
+	return revisions, nil
+}
+
 //BatchUpdateDocuments - batch method to batch update documents
 func (dbclient *CouchDatabase) BatchUpdateDocuments(documents []*CouchDoc) ([]*BatchUpdateResponse, error) {
 	dbName := dbclient.DBName
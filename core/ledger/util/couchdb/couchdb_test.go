@@ -852,6 +852,31 @@ func TestDBDeleteNonExistingDocument(t *testing.T) {
 	assert.NoError(t, deleteErr, "Error when trying to delete a non existing document")
 }
 
+func TestBatchRetrieveDocumentRevisions(t *testing.T) {
+	database := "testbatchretrievedocumentrevisions"
+	err := cleanup(database)
+	assert.NoError(t, err, "Error when trying to cleanup  Error: %s", err)
+	defer cleanup(database)
+
+	couchInstance, err := CreateCouchInstance(couchDBDef.URL, couchDBDef.Username, couchDBDef.Password,
+		couchDBDef.MaxRetries, couchDBDef.MaxRetriesOnStartup, couchDBDef.RequestTimeout, couchDBDef.CreateGlobalChangesDB, &disabled.Provider{})
+	assert.NoError(t, err, "Error when trying to create couch instance")
+	db := CouchDatabase{CouchInstance: couchInstance, DBName: database}
+	assert.NoError(t, db.CreateDatabaseIfNotExist())
+
+	rev1, err := db.SaveDoc("doc1", "", &CouchDoc{JSONValue: []byte(`{"field":"value1"}`)})
+	assert.NoError(t, err)
+	rev2, err := db.SaveDoc("doc2", "", &CouchDoc{JSONValue: []byte(`{"field":"value2"}`)})
+	assert.NoError(t, err)
+	_, err = db.SaveDoc("doc3", "", &CouchDoc{JSONValue: []byte(`{"field":"value3"}`)})
+	assert.NoError(t, err)
+	assert.NoError(t, db.DeleteDoc("doc3", ""))
+
+	revisions, err := db.BatchRetrieveDocumentRevisions([]string{"doc1", "doc2", "doc3", "doc4"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"doc1": rev1, "doc2": rev2}, revisions)
+}
+
 func TestCouchDBVersion(t *testing.T) {
 
 	err := checkCouchDBVersion("2.0.0")
@@ -1475,9 +1500,15 @@ func testBatchBatchOperations(t *testing.T, maxRetries int) {
 
 	keys = append(keys, "marble01")
 	keys = append(keys, "marble03")
+	keys = append(keys, "marble_missing")
 
 	batchRevs, err := db.BatchRetrieveDocumentMetadata(keys)
 	assert.NoError(t, err, "Error when attempting retrieve revisions")
+	//the keys that do not exist are not returned
+	assert.Len(t, batchRevs, 2)
+	for _, revdoc := range batchRevs {
+		assert.NotEmpty(t, revdoc.Rev)
+	}
 
 	batchUpdateDocs = []*CouchDoc{}
 
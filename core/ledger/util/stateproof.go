@@ -0,0 +1,114 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+)
+
+// KVHash returns the hash of a key, of its value, of its metadata and of the version at which they
+// were written, which is the height of the transaction that wrote them
+func KVHash(key string, value, metadata []byte, blockNum, txNum uint64) []byte {
+	h := sha256.New()
+	writeLengthPrefixed(h, []byte(key))
+	writeLengthPrefixed(h, value)
+	writeLengthPrefixed(h, metadata)
+	var height [16]byte
+	binary.BigEndian.PutUint64(height[:8], blockNum)
+	binary.BigEndian.PutUint64(height[8:], txNum)
+	h.Write(height[:])
+	return h.Sum(nil)
+}
+
+// StateUpdateEntry returns the entry of the digest of the updates of a block for the update of a key
+// with the given hash, computed by KVHash
+func StateUpdateEntry(isDelete bool, kvHash []byte) []byte {
+	entry := []byte{0}
+	if isDelete {
+		entry[0] = 1
+	}
+	return append(entry, kvHash...)
+}
+
+// UpdatesHash returns the hash of the updates of the state by a block from their digest, which is
+// combined with the transactions filter of the block in its commit hash
+func UpdatesHash(updates *queryresult.StateUpdates) []byte {
+	h := sha256.New()
+	for _, nsUpdates := range updates.GetNamespaces() {
+		writeLengthPrefixed(h, []byte(nsUpdates.Namespace))
+		binary.Write(h, binary.BigEndian, uint64(len(nsUpdates.Entries)))
+		for _, entry := range nsUpdates.Entries {
+			h.Write(entry)
+		}
+	}
+	return h.Sum(nil)
+}
+
+// CommitHash returns the commit hash of a block from its transactions filter, the hash of the updates
+// of the state by its valid transactions and the commit hash of the previous block
+func CommitHash(txsFilter, updatesHash, previousCommitHash []byte) []byte {
+	var valueBytes []byte
+	valueBytes = append(valueBytes, proto.EncodeVarint(uint64(len(txsFilter)))...)
+	valueBytes = append(valueBytes, txsFilter...)
+	valueBytes = append(valueBytes, updatesHash...)
+	valueBytes = append(valueBytes, previousCommitHash...)
+	return util.ComputeSHA256(valueBytes)
+}
+
+// VerifyStateProof checks that the key of the proof was set to its value by a valid transaction of the
+// block of the proof, and that the commit hash of the proof is the one of this block. The proof is not
+// trusted before the commit hash is compared to the commit hash of the block obtained from other peers,
+// and it does not prove that the key was not updated by the blocks committed after this block
+func VerifyStateProof(proof *queryresult.StateProof) error {
+	if proof.Updates == nil || len(proof.CommitHash) == 0 {
+		return errors.New("the proof has no updates or no commit hash")
+	}
+
+	entry := StateUpdateEntry(false, KVHash(proof.Key, proof.Value, proof.Metadata, proof.BlockNum, proof.TxNum))
+	found := false
+	for _, nsUpdates := range proof.Updates.Namespaces {
+		if nsUpdates.Namespace != proof.Namespace {
+			continue
+		}
+		for _, e := range nsUpdates.Entries {
+			if bytes.Equal(e, entry) {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return errors.Errorf("the updates of block %d do not set the key [%s] of namespace [%s] to the value of the proof",
+			proof.BlockNum, proof.Key, proof.Namespace)
+	}
+
+	txsFilter := TxValidationFlags(proof.TransactionsFilter)
+	if proof.TxNum >= uint64(len(txsFilter)) || !txsFilter.IsValid(int(proof.TxNum)) {
+		return errors.Errorf("transaction %d of block %d is not valid", proof.TxNum, proof.BlockNum)
+	}
+
+	commitHash := CommitHash(proof.TransactionsFilter, UpdatesHash(proof.Updates), proof.PreviousCommitHash)
+	if !bytes.Equal(commitHash, proof.CommitHash) {
+		return errors.Errorf("the commit hash of block %d does not match the proof", proof.BlockNum)
+	}
+	return nil
+}
+
+func writeLengthPrefixed(w io.Writer, b []byte) {
+	var length [8]byte
+	binary.BigEndian.PutUint64(length[:], uint64(len(b)))
+	w.Write(length[:])
+	w.Write(b)
+}
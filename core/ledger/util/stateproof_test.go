@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyStateProof(t *testing.T) {
+	txsFilter := NewTxValidationFlagsSetValue(3, peer.TxValidationCode_VALID)
+	txsFilter.SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	updates := &queryresult.StateUpdates{
+		Namespaces: []*queryresult.NamespaceStateUpdates{
+			{
+				Namespace: "ns1",
+				Entries: [][]byte{
+					StateUpdateEntry(false, KVHash("key1", []byte("value1"), nil, 5, 0)),
+					StateUpdateEntry(true, KVHash("key2", nil, nil, 5, 2)),
+				},
+			},
+			{
+				Namespace: "ns2",
+				Entries: [][]byte{
+					StateUpdateEntry(false, KVHash("key1", []byte("value2"), []byte("metadata"), 5, 2)),
+				},
+			},
+		},
+	}
+	validProof := &queryresult.StateProof{
+		Namespace:          "ns2",
+		Key:                "key1",
+		Value:              []byte("value2"),
+		Metadata:           []byte("metadata"),
+		BlockNum:           5,
+		TxNum:              2,
+		Updates:            updates,
+		TransactionsFilter: txsFilter,
+		PreviousCommitHash: []byte("previous-commit-hash"),
+		CommitHash:         CommitHash(txsFilter, UpdatesHash(updates), []byte("previous-commit-hash")),
+	}
+	assert.NoError(t, VerifyStateProof(validProof))
+
+	for _, tc := range []struct {
+		name        string
+		modify      func(proof *queryresult.StateProof)
+		expectedErr string
+	}{
+		{
+			name:        "no commit hash",
+			modify:      func(proof *queryresult.StateProof) { proof.CommitHash = nil },
+			expectedErr: "the proof has no updates or no commit hash",
+		},
+		{
+			name:        "other value",
+			modify:      func(proof *queryresult.StateProof) { proof.Value = []byte("value1") },
+			expectedErr: "the updates of block 5 do not set the key [key1] of namespace [ns2] to the value of the proof",
+		},
+		{
+			name:        "other namespace",
+			modify:      func(proof *queryresult.StateProof) { proof.Namespace = "ns1" },
+			expectedErr: "the updates of block 5 do not set the key [key1] of namespace [ns1] to the value of the proof",
+		},
+		{
+			name:        "other metadata",
+			modify:      func(proof *queryresult.StateProof) { proof.Metadata = nil },
+			expectedErr: "the updates of block 5 do not set the key [key1] of namespace [ns2] to the value of the proof",
+		},
+		{
+			name: "deleted key",
+			modify: func(proof *queryresult.StateProof) {
+				proof.Namespace, proof.Key, proof.Value, proof.Metadata = "ns1", "key2", nil, nil
+			},
+			expectedErr: "the updates of block 5 do not set the key [key2] of namespace [ns1] to the value of the proof",
+		},
+		{
+			name: "invalid transaction",
+			modify: func(proof *queryresult.StateProof) {
+				proof.Updates.Namespaces[1].Entries[0] = StateUpdateEntry(false, KVHash("key1", []byte("value2"), []byte("metadata"), 5, 1))
+				proof.TxNum = 1
+			},
+			expectedErr: "transaction 1 of block 5 is not valid",
+		},
+		{
+			name:        "transaction out of the block",
+			modify:      func(proof *queryresult.StateProof) { proof.TransactionsFilter = proof.TransactionsFilter[:2] },
+			expectedErr: "transaction 2 of block 5 is not valid",
+		},
+		{
+			name:        "other previous commit hash",
+			modify:      func(proof *queryresult.StateProof) { proof.PreviousCommitHash = []byte("other-commit-hash") },
+			expectedErr: "the commit hash of block 5 does not match the proof",
+		},
+		{
+			name: "other updates",
+			modify: func(proof *queryresult.StateProof) {
+				proof.Updates.Namespaces[0].Entries = proof.Updates.Namespaces[0].Entries[:1]
+			},
+			expectedErr: "the commit hash of block 5 does not match the proof",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			proof := proto.Clone(validProof).(*queryresult.StateProof)
+			tc.modify(proof)
+			assert.EqualError(t, VerifyStateProof(proof), tc.expectedErr)
+		})
+	}
+}
@@ -73,6 +73,10 @@ func (m *MockTxSim) DeleteState(namespace string, key string) error {
 	return nil
 }
 
+func (m *MockTxSim) DeleteStateRange(namespace, startKey, endKey string) error {
+	return nil
+}
+
 func (m *MockTxSim) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
 	return nil
 }
@@ -125,6 +129,10 @@ func (m *MockTxSim) GetPrivateDataMetadataByHash(namespace, collection string, k
 	return nil, nil
 }
 
+func (m *MockTxSim) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	return nil, nil
+}
+
 func (m *MockTxSim) SetStateMetadata(namespace, key string, metadata map[string][]byte) error {
 	return nil
 }
@@ -133,6 +141,10 @@ func (m *MockTxSim) DeleteStateMetadata(namespace, key string) error {
 	return nil
 }
 
+func (m *MockTxSim) IncrementState(namespace string, key string, delta int64) error {
+	return nil
+}
+
 func (m *MockTxSim) SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error {
 	return nil
 }
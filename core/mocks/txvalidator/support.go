@@ -25,13 +25,17 @@ import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
 type Support struct {
-	LedgerVal     ledger.PeerLedger
-	MSPManagerVal msp.MSPManager
-	ApplyVal      error
-	ACVal         channelconfig.ApplicationCapabilities
+	LedgerVal       ledger.PeerLedger
+	MSPManagerVal   msp.MSPManager
+	ApplyVal        error
+	ACVal           channelconfig.ApplicationCapabilities
+	CustomGroupsVal map[string]channelconfig.CustomGroup
+	BudgetVal       *peer.ResourceBudget
+	SCCPoliciesVal  *peer.SystemChaincodePolicies
 
 	sync.Mutex
 	capabilitiesInvokeCount int
@@ -67,6 +71,21 @@ func (ms *Support) PolicyManager() policies.Manager {
 	return &mockpolicies.Manager{}
 }
 
+// CustomGroups returns CustomGroupsVal
+func (ms *Support) CustomGroups() map[string]channelconfig.CustomGroup {
+	return ms.CustomGroupsVal
+}
+
+// ResourceBudget returns BudgetVal
+func (ms *Support) ResourceBudget() *peer.ResourceBudget {
+	return ms.BudgetVal
+}
+
+// SystemChaincodePolicies returns SCCPoliciesVal
+func (ms *Support) SystemChaincodePolicies() *peer.SystemChaincodePolicies {
+	return ms.SCCPoliciesVal
+}
+
 func (ms *Support) GetMSPIDs(cid string) []string {
 	return []string{"SampleOrg"}
 }
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operations
+
+import (
+	"crypto/x509"
+	"net/http"
+
+	"github.com/hyperledger/fabric/core/middleware"
+	"github.com/pkg/errors"
+)
+
+// Role is a role granted to the clients of the operations endpoints. Each role
+// grants the rights of the roles that precede it
+type Role string
+
+const (
+	// RoleNone is required by the endpoints open to all clients
+	RoleNone Role = "none"
+	// RoleViewer allows reading the state of the node
+	RoleViewer Role = "viewer"
+	// RoleOperator additionally allows changing the settings of the node, such as the log spec
+	RoleOperator Role = "operator"
+	// RoleAdmin additionally allows the administrative actions
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleNone:     0,
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// Grants returns true if the role grants the rights of the given role
+func (r Role) Grants(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// RoleMembers identifies the clients holding a role by the subject of their TLS certificate
+type RoleMembers struct {
+	CommonNames         []string
+	OrganizationalUnits []string
+}
+
+func (m RoleMembers) contains(cert *x509.Certificate) bool {
+	for _, cn := range m.CommonNames {
+		if cn != "" && cn == cert.Subject.CommonName {
+			return true
+		}
+	}
+	for _, ou := range m.OrganizationalUnits {
+		for _, certOU := range cert.Subject.OrganizationalUnit {
+			if ou != "" && ou == certOU {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Authorization maps the verified client TLS certificates to roles and defines the
+// role required by the endpoints. It requires TLS to be enabled
+type Authorization struct {
+	Enabled   bool
+	Admins    RoleMembers
+	Operators RoleMembers
+	Viewers   RoleMembers
+	// Endpoints overrides the role required by the endpoints, indexed by path.
+	// By default /healthz is open to all clients, /metrics and /logspec require
+	// the viewer role and the administrative endpoints require the admin role.
+	// Whatever the endpoint, the requests other than GET and HEAD require at
+	// least the operator role
+	Endpoints map[string]Role
+}
+
+// Validate checks that the roles required by the endpoints are known
+func (a Authorization) Validate() error {
+	for path, role := range a.Endpoints {
+		if _, ok := roleRanks[role]; !ok {
+			return errors.Errorf("unknown role [%s] required by the operations endpoint %s", role, path)
+		}
+	}
+	return nil
+}
+
+// RoleOf returns the highest role held by the owner of the given certificate
+func (a Authorization) RoleOf(cert *x509.Certificate) Role {
+	switch {
+	case a.Admins.contains(cert):
+		return RoleAdmin
+	case a.Operators.contains(cert):
+		return RoleOperator
+	case a.Viewers.contains(cert):
+		return RoleViewer
+	default:
+		return RoleNone
+	}
+}
+
+func (a Authorization) requiredRole(path string, defaultRole Role) Role {
+	if role, ok := a.Endpoints[path]; ok {
+		return role
+	}
+	return defaultRole
+}
+
+type requireRole struct {
+	authorization Authorization
+	required      Role
+	logger        Logger
+	next          http.Handler
+}
+
+// RequireRole is used to ensure that the client authenticated with a verified TLS
+// client certificate mapped to a role that grants the given one.
+func RequireRole(authorization Authorization, required Role, logger Logger) middleware.Middleware {
+	return func(next http.Handler) http.Handler {
+		return &requireRole{
+			authorization: authorization,
+			required:      required,
+			logger:        logger,
+			next:          next,
+		}
+	}
+}
+
+func (r *requireRole) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	required := r.required
+	if req.Method != http.MethodGet && req.Method != http.MethodHead && !required.Grants(RoleOperator) {
+		required = RoleOperator
+	}
+	if required == RoleNone {
+		r.next.ServeHTTP(w, req)
+		return
+	}
+
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	cert := req.TLS.VerifiedChains[0][0]
+	if role := r.authorization.RoleOf(cert); !role.Grants(required) {
+		r.logger.Warnf("Client [%s] with role %s is not authorized to %s %s, which requires the role %s",
+			cert.Subject, role, req.Method, req.URL.Path, required)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	r.next.ServeHTTP(w, req)
+}
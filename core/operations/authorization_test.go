@@ -0,0 +1,203 @@
+/*
+Copyright IBM Corp All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package operations_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/operations"
+	"github.com/hyperledger/fabric/core/operations/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Authorization", func() {
+	var authorization operations.Authorization
+
+	BeforeEach(func() {
+		authorization = operations.Authorization{
+			Enabled: true,
+			Admins: operations.RoleMembers{
+				CommonNames: []string{"alice"},
+			},
+			Operators: operations.RoleMembers{
+				CommonNames:         []string{"bob"},
+				OrganizationalUnits: []string{"ops"},
+			},
+			Viewers: operations.RoleMembers{
+				OrganizationalUnits: []string{"monitoring", "ops"},
+			},
+		}
+	})
+
+	certFor := func(cn string, ous ...string) *x509.Certificate {
+		return &x509.Certificate{Subject: pkix.Name{CommonName: cn, OrganizationalUnit: ous}}
+	}
+
+	Describe("RoleOf", func() {
+		It("maps the certificates to the highest role held", func() {
+			Expect(authorization.RoleOf(certFor("alice", "ops"))).To(Equal(operations.RoleAdmin))
+			Expect(authorization.RoleOf(certFor("bob"))).To(Equal(operations.RoleOperator))
+			Expect(authorization.RoleOf(certFor("carol", "ops"))).To(Equal(operations.RoleOperator))
+			Expect(authorization.RoleOf(certFor("dave", "monitoring"))).To(Equal(operations.RoleViewer))
+			Expect(authorization.RoleOf(certFor("eve", "sales"))).To(Equal(operations.RoleNone))
+			Expect(authorization.RoleOf(certFor(""))).To(Equal(operations.RoleNone))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("rejects unknown roles", func() {
+			authorization.Endpoints = map[string]operations.Role{"/metrics": "superuser"}
+			err := authorization.Validate()
+			Expect(err).To(MatchError("unknown role [superuser] required by the operations endpoint /metrics"))
+		})
+	})
+
+	Describe("RequireRole", func() {
+		var (
+			fakeLogger *fakes.Logger
+			handler    http.Handler
+			resp       *httptest.ResponseRecorder
+		)
+
+		BeforeEach(func() {
+			fakeLogger = &fakes.Logger{}
+			resp = httptest.NewRecorder()
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			})
+			handler = operations.RequireRole(authorization, operations.RoleViewer, fakeLogger)(next)
+		})
+
+		newRequest := func(method string, cert *x509.Certificate) *http.Request {
+			req := httptest.NewRequest(method, "https://localhost/logspec", strings.NewReader("{}"))
+			req.TLS = &tls.ConnectionState{}
+			if cert != nil {
+				req.TLS.VerifiedChains = [][]*x509.Certificate{{cert}}
+			}
+			return req
+		}
+
+		It("delegates to the next handler when the role is granted", func() {
+			handler.ServeHTTP(resp, newRequest(http.MethodGet, certFor("dave", "monitoring")))
+			Expect(resp.Code).To(Equal(http.StatusTeapot))
+		})
+
+		It("rejects the clients without a verified certificate", func() {
+			handler.ServeHTTP(resp, newRequest(http.MethodGet, nil))
+			Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("rejects the clients without the required role", func() {
+			handler.ServeHTTP(resp, newRequest(http.MethodGet, certFor("eve")))
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(fakeLogger.WarnfCallCount()).To(Equal(1))
+		})
+
+		It("requires the operator role to change the state", func() {
+			handler.ServeHTTP(resp, newRequest(http.MethodPut, certFor("dave", "monitoring")))
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+
+			resp = httptest.NewRecorder()
+			handler.ServeHTTP(resp, newRequest(http.MethodPut, certFor("bob")))
+			Expect(resp.Code).To(Equal(http.StatusTeapot))
+		})
+	})
+
+	Describe("the operations system", func() {
+		var (
+			tempDir      string
+			client       *http.Client
+			unauthClient *http.Client
+			options      operations.Options
+			system       *operations.System
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "opsauth")
+			Expect(err).NotTo(HaveOccurred())
+
+			generateCertificates(tempDir)
+			client = newHTTPClient(tempDir, true)
+			unauthClient = newHTTPClient(tempDir, false)
+
+			options = operations.Options{
+				Logger:        &fakes.Logger{},
+				ListenAddress: "127.0.0.1:0",
+				Metrics:       operations.MetricsOptions{Provider: "disabled"},
+				TLS: operations.TLS{
+					Enabled:           true,
+					CertFile:          filepath.Join(tempDir, "server-cert.pem"),
+					KeyFile:           filepath.Join(tempDir, "server-key.pem"),
+					ClientCACertFiles: []string{filepath.Join(tempDir, "client-ca.pem")},
+				},
+				Authorization: authorization,
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tempDir)
+			if system != nil {
+				system.Stop()
+			}
+		})
+
+		It("enforces the roles required by the endpoints", func() {
+			options.Authorization.Endpoints = map[string]operations.Role{"/admin": operations.RoleNone}
+			system = operations.NewSystem(options)
+			system.RegisterHandler("/admin", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			system.RegisterHandler("/restricted", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			err := system.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := unauthClient.Get(fmt.Sprintf("https://%s/healthz", system.Addr()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			resp.Body.Close()
+
+			resp, err = unauthClient.Get(fmt.Sprintf("https://%s/logspec", system.Addr()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+
+			resp, err = client.Get(fmt.Sprintf("https://%s/logspec", system.Addr()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+
+			resp, err = client.Get(fmt.Sprintf("https://%s/restricted", system.Addr()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusForbidden))
+
+			resp, err = unauthClient.Get(fmt.Sprintf("https://%s/admin", system.Addr()))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("requires TLS", func() {
+			options.TLS.Enabled = false
+			system = operations.NewSystem(options)
+			err := system.Start()
+			Expect(err).To(MatchError("authorization of the operations endpoints requires TLS to be enabled"))
+		})
+
+		It("rejects unknown roles", func() {
+			options.Authorization.Endpoints = map[string]operations.Role{"/logspec": "root"}
+			system = operations.NewSystem(options)
+			err := system.Start()
+			Expect(err).To(MatchError("unknown role [root] required by the operations endpoint /logspec"))
+		})
+	})
+})
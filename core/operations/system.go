@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/common/metrics/statsd/goruntime"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/middleware"
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
@@ -53,6 +54,7 @@ type Options struct {
 	ListenAddress string
 	Metrics       MetricsOptions
 	TLS           TLS
+	Authorization Authorization
 	Version       string
 }
 
@@ -105,6 +107,15 @@ func (s *System) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
 }
 
 func (s *System) Start() error {
+	if s.options.Authorization.Enabled {
+		if !s.options.TLS.Enabled {
+			return errors.New("authorization of the operations endpoints requires TLS to be enabled")
+		}
+		if err := s.options.Authorization.Validate(); err != nil {
+			return err
+		}
+	}
+
 	err := s.startMetricsTickers()
 	if err != nil {
 		return err
@@ -152,7 +163,18 @@ func (s *System) initializeServer() {
 	}
 }
 
-func (s *System) handlerChain(h http.Handler, secure bool) http.Handler {
+// RegisterHandler hosts an administrative endpoint at the given path. When authorization
+// is enabled, the endpoint requires the admin role unless configured otherwise, and a
+// verified client certificate is required when TLS is enabled otherwise
+func (s *System) RegisterHandler(path string, handler http.Handler) {
+	s.mux.Handle(path, s.handlerChain(path, handler, s.options.TLS.Enabled, RoleAdmin))
+}
+
+func (s *System) handlerChain(path string, h http.Handler, secure bool, role Role) http.Handler {
+	if s.options.Authorization.Enabled {
+		required := s.options.Authorization.requiredRole(path, role)
+		return middleware.NewChain(RequireRole(s.options.Authorization, required, s.logger), middleware.WithRequestID(util.GenerateUUID)).Handler(h)
+	}
 	if secure {
 		return middleware.NewChain(middleware.RequireCert(), middleware.WithRequestID(util.GenerateUUID)).Handler(h)
 	}
@@ -178,7 +200,7 @@ func (s *System) initializeMetricsProvider() error {
 	case "prometheus":
 		s.Provider = &prometheus.Provider{}
 		s.versionGauge = versionGauge(s.Provider)
-		s.mux.Handle("/metrics", s.handlerChain(promhttp.Handler(), s.options.TLS.Enabled))
+		s.mux.Handle("/metrics", s.handlerChain("/metrics", promhttp.Handler(), s.options.TLS.Enabled, RoleViewer))
 		return nil
 
 	default:
@@ -193,12 +215,12 @@ func (s *System) initializeMetricsProvider() error {
 }
 
 func (s *System) initializeLoggingHandler() {
-	s.mux.Handle("/logspec", s.handlerChain(httpadmin.NewSpecHandler(), s.options.TLS.Enabled))
+	s.mux.Handle("/logspec", s.handlerChain("/logspec", httpadmin.NewSpecHandler(), s.options.TLS.Enabled, RoleViewer))
 }
 
 func (s *System) initializeHealthCheckHandler() {
 	s.healthHandler = healthz.NewHealthHandler()
-	s.mux.Handle("/healthz", s.handlerChain(s.healthHandler, false))
+	s.mux.Handle("/healthz", s.handlerChain("/healthz", s.healthHandler, false, RoleNone))
 }
 
 func (s *System) startMetricsTickers() error {
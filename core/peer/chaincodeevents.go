@@ -0,0 +1,425 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// defaultMaxUnacknowledgedBlocks is the number of blocks of events delivered
+// to a consumer without its acknowledgement when the peer does not configure it
+const defaultMaxUnacknowledgedBlocks = 16
+
+// ChaincodeEventCheckpoints persists the last block of events acknowledged by
+// each consumer of chaincode events, and tracks the consumers being delivered
+// events so that the events are delivered to a consumer over a single stream.
+type ChaincodeEventCheckpoints struct {
+	db *leveldbhelper.DB
+
+	mutex     sync.Mutex
+	consumers map[string]struct{}
+}
+
+// NewChaincodeEventCheckpoints opens the checkpoints of the consumers of
+// chaincode events persisted in the given directory.
+func NewChaincodeEventCheckpoints(dbPath string) *ChaincodeEventCheckpoints {
+	db := leveldbhelper.CreateDB(&leveldbhelper.Conf{DBPath: dbPath})
+	db.Open()
+	return &ChaincodeEventCheckpoints{
+		db:        db,
+		consumers: map[string]struct{}{},
+	}
+}
+
+// Close closes the checkpoints.
+func (c *ChaincodeEventCheckpoints) Close() {
+	c.db.Close()
+}
+
+// checkpointKey returns the key of the checkpoint of the consumer with the
+// given ID of the events of the chaincode, run by the client with the given
+// serialized identity on the channel.
+func checkpointKey(channelID string, identity []byte, consumerID, chaincodeID string) string {
+	hash := sha256.Sum256(identity)
+	return strings.Join([]string{channelID, hex.EncodeToString(hash[:]), chaincodeID, consumerID}, "\x00")
+}
+
+// Acquire marks the consumer with the given key as being delivered events,
+// and returns the number of the first block the consumer has not
+// acknowledged. It returns an error if the consumer is already being
+// delivered events.
+func (c *ChaincodeEventCheckpoints) Acquire(key string) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, ok := c.consumers[key]; ok {
+		return 0, errors.New("the consumer is already being delivered events over another stream")
+	}
+	value, err := c.db.Get([]byte(key))
+	if err != nil {
+		return 0, err
+	}
+	var next uint64
+	if len(value) == 8 {
+		next = binary.BigEndian.Uint64(value)
+	}
+	c.consumers[key] = struct{}{}
+	return next, nil
+}
+
+// Release marks the consumer with the given key as no longer being delivered
+// events.
+func (c *ChaincodeEventCheckpoints) Release(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.consumers, key)
+}
+
+// Acknowledge persists the number of the first block the consumer with the
+// given key has not acknowledged.
+func (c *ChaincodeEventCheckpoints) Acknowledge(key string, next uint64) error {
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, next)
+	return c.db.Put([]byte(key), value, true)
+}
+
+// chaincodeEventsServer delivers the chaincode events to their consumers with
+// the deliver handler of the deliver events server
+type chaincodeEventsServer struct {
+	*server
+	checkpoints       *ChaincodeEventCheckpoints
+	maxUnacknowledged int
+}
+
+// NewChaincodeEventsServer creates a peer.ChaincodeEvents server which
+// delivers the chaincode events exactly once and in block order to each of
+// their consumers. It shares the deliver sessions and throttle of the server
+// created by NewDeliverEventsServer, and delivers at most maxUnacknowledged
+// blocks of events to a consumer without its acknowledgement.
+func NewChaincodeEventsServer(deliverEvents peer.DeliverServer, checkpoints *ChaincodeEventCheckpoints, maxUnacknowledged int) peer.ChaincodeEventsServer {
+	if maxUnacknowledged <= 0 {
+		logger.Warningf("`peer.chaincodeEvents.maxUnacknowledgedBlocks` not set; defaulting to %d", defaultMaxUnacknowledgedBlocks)
+		maxUnacknowledged = defaultMaxUnacknowledgedBlocks
+	}
+	return &chaincodeEventsServer{
+		server:            deliverEvents.(*server),
+		checkpoints:       checkpoints,
+		maxUnacknowledged: maxUnacknowledged,
+	}
+}
+
+// Deliver sends a stream of the chaincode events of the committed blocks to a
+// consumer, which acknowledges them
+func (s *chaincodeEventsServer) Deliver(srv peer.ChaincodeEvents_DeliverServer) error {
+	logger.Debugf("Starting new chaincode events handler")
+	defer dumpStacktraceOnPanic()
+
+	stream := newChaincodeEventsStream(srv, s.checkpoints, s.maxUnacknowledged)
+	defer stream.release()
+	go stream.receive()
+
+	// getting policy checker based on resources.Event_Block resource name
+	deliverServer := &deliver.Server{
+		PolicyChecker:  s.policyCheckerProvider(resources.Event_Block),
+		Receiver:       stream,
+		ResponseSender: stream,
+	}
+	return s.dh.Handle(srv.Context(), deliverServer)
+}
+
+// chaincodeEventsStream receives the subscriptions and the acks of a consumer,
+// and sends it the chaincode events of the blocks it has not acknowledged
+type chaincodeEventsStream struct {
+	srv               peer.ChaincodeEvents_DeliverServer
+	checkpoints       *ChaincodeEventCheckpoints
+	maxUnacknowledged int
+
+	// subscriptions holds the subscription received and not yet served
+	subscriptions chan *peer.ChaincodeEventsSubscription
+	// acked is signaled when the consumer acknowledges blocks
+	acked chan struct{}
+	// done is closed when the stream has no more requests, the error of the
+	// stream being set before
+	done    chan struct{}
+	recvErr error
+
+	mutex        sync.Mutex
+	subscription *peer.ChaincodeEventsSubscription
+	// key is the checkpoint key of the consumer the stream is bound to
+	key string
+	// next is the number of the first block the consumer has not acknowledged
+	next uint64
+	// unacknowledged are the numbers of the blocks of events sent to the
+	// consumer and not acknowledged, in order
+	unacknowledged []uint64
+}
+
+func newChaincodeEventsStream(srv peer.ChaincodeEvents_DeliverServer, checkpoints *ChaincodeEventCheckpoints, maxUnacknowledged int) *chaincodeEventsStream {
+	return &chaincodeEventsStream{
+		srv:               srv,
+		checkpoints:       checkpoints,
+		maxUnacknowledged: maxUnacknowledged,
+		subscriptions:     make(chan *peer.ChaincodeEventsSubscription, 1),
+		acked:             make(chan struct{}, 1),
+		done:              make(chan struct{}),
+	}
+}
+
+// receive reads the requests of the consumer until the stream ends, queueing
+// its subscriptions and applying its acks.
+func (s *chaincodeEventsStream) receive() {
+	defer close(s.done)
+	for {
+		req, err := s.srv.Recv()
+		if err != nil {
+			s.recvErr = err
+			return
+		}
+		switch {
+		case req.Subscription != nil:
+			if strings.Contains(req.Subscription.ConsumerId, "\x00") || strings.Contains(req.Subscription.ChaincodeId, "\x00") {
+				s.recvErr = errors.New("received a subscription with an invalid consumer or chaincode ID")
+				return
+			}
+			select {
+			case s.subscriptions <- req.Subscription:
+			default:
+				s.recvErr = errors.New("received a subscription while another one is pending")
+				return
+			}
+		case req.Ack != nil:
+			if err := s.acknowledge(req.Ack.BlockNumber); err != nil {
+				s.recvErr = err
+				return
+			}
+		default:
+			s.recvErr = errors.New("received a request with neither a subscription nor an ack")
+			return
+		}
+	}
+}
+
+// Recv returns the signed deliver request of the next subscription of the
+// consumer.
+func (s *chaincodeEventsStream) Recv() (*common.Envelope, error) {
+	var subscription *peer.ChaincodeEventsSubscription
+	select {
+	case subscription = <-s.subscriptions:
+	case <-s.done:
+		select {
+		case subscription = <-s.subscriptions:
+		default:
+			return nil, s.recvErr
+		}
+	}
+
+	s.mutex.Lock()
+	s.subscription = subscription
+	s.mutex.Unlock()
+
+	if subscription.SeekInfo == nil {
+		return &common.Envelope{}, nil
+	}
+	return subscription.SeekInfo, nil
+}
+
+// Resume binds the stream to the consumer of the current subscription, and
+// returns the number of the first block the consumer has not acknowledged.
+func (s *chaincodeEventsStream) Resume(channelID string, identity []byte) (uint64, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.releaseLocked()
+	key := checkpointKey(channelID, identity, s.subscription.ConsumerId, s.subscription.ChaincodeId)
+	next, err := s.checkpoints.Acquire(key)
+	if err != nil {
+		return 0, false, err
+	}
+	s.key, s.next, s.unacknowledged = key, next, nil
+	return next, next > 0, nil
+}
+
+// release unbinds the stream from its consumer.
+func (s *chaincodeEventsStream) release() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.releaseLocked()
+}
+
+func (s *chaincodeEventsStream) releaseLocked() {
+	if s.key != "" {
+		s.checkpoints.Release(s.key)
+		s.key = ""
+	}
+}
+
+// acknowledge records that the consumer processed the events of the block
+// with the given number and of all the blocks before it.
+func (s *chaincodeEventsStream) acknowledge(number uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.key == "" {
+		return errors.New("received an ack without a subscription")
+	}
+	if number < s.next {
+		return nil
+	}
+	if len(s.unacknowledged) == 0 || number > s.unacknowledged[len(s.unacknowledged)-1] {
+		return errors.Errorf("received an ack of block [%d] whose events were not delivered", number)
+	}
+	if err := s.checkpoints.Acknowledge(s.key, number+1); err != nil {
+		return errors.WithMessage(err, "could not persist the ack")
+	}
+	s.next = number + 1
+	for len(s.unacknowledged) > 0 && s.unacknowledged[0] <= number {
+		s.unacknowledged = s.unacknowledged[1:]
+	}
+
+	select {
+	case s.acked <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// SendStatusResponse sends the status terminating the current subscription.
+// The stream stays bound to its consumer, whose acks of the blocks delivered
+// are still applied.
+func (s *chaincodeEventsStream) SendStatusResponse(status common.Status) error {
+	return s.srv.Send(&peer.ChaincodeEventsResponse{Status: status})
+}
+
+// SendBlockResponse sends the chaincode events of the block, unless the
+// consumer already acknowledged it or it holds no events of the chaincode of
+// the subscription. It waits for the acks of the consumer while it has the
+// maximum number of blocks of events unacknowledged.
+func (s *chaincodeEventsStream) SendBlockResponse(block *common.Block) error {
+	s.mutex.Lock()
+	chaincodeID, next := s.subscription.ChaincodeId, s.next
+	s.mutex.Unlock()
+
+	number := block.Header.Number
+	if number < next {
+		logger.Debugf("Skipping block [%d] already acknowledged by the consumer", number)
+		return nil
+	}
+	events, err := chaincodeEvents(block, chaincodeID)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract the chaincode events of the block")
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	if err := s.waitForAcks(); err != nil {
+		return err
+	}
+	s.mutex.Lock()
+	s.unacknowledged = append(s.unacknowledged, number)
+	s.mutex.Unlock()
+
+	return s.srv.Send(&peer.ChaincodeEventsResponse{
+		Block: &peer.ChaincodeEventsBlock{
+			BlockNumber: number,
+			Events:      events,
+		},
+	})
+}
+
+// waitForAcks waits until the consumer has fewer blocks of events
+// unacknowledged than the maximum.
+func (s *chaincodeEventsStream) waitForAcks() error {
+	for {
+		s.mutex.Lock()
+		unacknowledged := len(s.unacknowledged)
+		s.mutex.Unlock()
+		if unacknowledged < s.maxUnacknowledged {
+			return nil
+		}
+
+		select {
+		case <-s.acked:
+		case <-s.done:
+			return errors.WithMessage(s.recvErr, "the stream ended with blocks of events unacknowledged")
+		case <-s.srv.Context().Done():
+			return errors.Wrap(s.srv.Context().Err(), "context finished before the blocks of events were acknowledged")
+		}
+	}
+}
+
+// chaincodeEvents returns the events of the valid transactions of the block
+// set by the chaincode with the given name, or by any chaincode if the name is
+// empty, in the order of the transactions.
+func chaincodeEvents(block *common.Block, chaincodeID string) ([]*peer.ChaincodeEvent, error) {
+	if block.Data == nil || block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil, errors.New("block has no data or no transactions filter")
+	}
+	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	if len(txsFltr) != len(block.Data.Data) {
+		return nil, errors.Errorf("block has %d transactions and %d validation flags", len(block.Data.Data), len(txsFltr))
+	}
+
+	var events []*peer.ChaincodeEvent
+	for txIndex, ebytes := range block.Data.Data {
+		if !txsFltr.IsValid(txIndex) {
+			continue
+		}
+		env, err := utils.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, err
+		}
+		if payload.Header == nil {
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+		tx, err := utils.GetTransaction(payload.Data)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range tx.Actions {
+			_, caPayload, err := utils.GetPayloads(action)
+			if err != nil {
+				return nil, err
+			}
+			event, err := utils.GetChaincodeEvents(caPayload.Events)
+			if err != nil {
+				return nil, err
+			}
+			if event.ChaincodeId == "" || (chaincodeID != "" && event.ChaincodeId != chaincodeID) {
+				continue
+			}
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
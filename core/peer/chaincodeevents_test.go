@@ -0,0 +1,279 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// blocksChain is a chain whose ledger holds the given blocks
+type blocksChain struct {
+	blocks []*common.Block
+}
+
+func (c *blocksChain) GetChain(chainID string) deliver.Chain { return c }
+func (*blocksChain) Sequence() uint64                        { return 0 }
+func (*blocksChain) PolicyManager() policies.Manager         { panic("implement me") }
+func (c *blocksChain) Reader() blockledger.Reader            { return c }
+func (*blocksChain) Errored() <-chan struct{}                { return nil }
+func (*blocksChain) MSPManager() msp.MSPManager              { panic("implement me") }
+func (c *blocksChain) Height() uint64                        { return uint64(len(c.blocks)) }
+
+func (c *blocksChain) Iterator(start *orderer.SeekPosition) (blockledger.Iterator, uint64) {
+	number := start.GetSpecified().GetNumber()
+	return &blocksIterator{blocks: c.blocks, next: number}, number
+}
+
+type blocksIterator struct {
+	blocks []*common.Block
+	next   uint64
+}
+
+func (it *blocksIterator) Next() (*common.Block, common.Status) {
+	if it.next >= uint64(len(it.blocks)) {
+		return nil, common.Status_NOT_FOUND
+	}
+	it.next++
+	return it.blocks[it.next-1], common.Status_SUCCESS
+}
+
+func (*blocksIterator) Close() {}
+
+// chaincodeEventsStreamServer is a peer.ChaincodeEvents_DeliverServer whose
+// requests and responses go through channels
+type chaincodeEventsStreamServer struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  chan *peer.ChaincodeEventsRequest
+	responses chan *peer.ChaincodeEventsResponse
+}
+
+func newChaincodeEventsStreamServer(ctx context.Context) *chaincodeEventsStreamServer {
+	return &chaincodeEventsStreamServer{
+		ctx:       ctx,
+		requests:  make(chan *peer.ChaincodeEventsRequest, 10),
+		responses: make(chan *peer.ChaincodeEventsResponse, 10),
+	}
+}
+
+func (s *chaincodeEventsStreamServer) Context() context.Context { return s.ctx }
+
+func (s *chaincodeEventsStreamServer) Recv() (*peer.ChaincodeEventsRequest, error) {
+	select {
+	case req, ok := <-s.requests:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *chaincodeEventsStreamServer) Send(response *peer.ChaincodeEventsResponse) error {
+	s.responses <- response
+	return nil
+}
+
+func (s *chaincodeEventsStreamServer) subscribe(t *testing.T, consumerID string, start *orderer.SeekPosition, resume bool) {
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+				ChannelId: "testChainID",
+				Timestamp: util.CreateUtcTimestamp(),
+			}),
+			SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{Creator: []byte("consumer")}),
+		},
+		Data: utils.MarshalOrPanic(&orderer.SeekInfo{
+			Start:  start,
+			Stop:   &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 4}}},
+			Resume: resume,
+		}),
+	}
+	s.requests <- &peer.ChaincodeEventsRequest{
+		Subscription: &peer.ChaincodeEventsSubscription{
+			SeekInfo:    &common.Envelope{Payload: utils.MarshalOrPanic(payload)},
+			ConsumerId:  consumerID,
+			ChaincodeId: "mycc",
+		},
+	}
+}
+
+func (s *chaincodeEventsStreamServer) ack(number uint64) {
+	s.requests <- &peer.ChaincodeEventsRequest{Ack: &peer.ChaincodeEventsAck{BlockNumber: number}}
+}
+
+func (s *chaincodeEventsStreamServer) receive(t *testing.T) *peer.ChaincodeEventsResponse {
+	select {
+	case response := <-s.responses:
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a response")
+		return nil
+	}
+}
+
+func (s *chaincodeEventsStreamServer) receiveBlock(t *testing.T, number uint64) {
+	response := s.receive(t)
+	require.NotNil(t, response.Block, "expected block [%d], got status %s", number, response.Status)
+	assert.Equal(t, number, response.Block.BlockNumber)
+	require.Len(t, response.Block.Events, 1)
+	assert.Equal(t, "mycc", response.Block.Events[0].ChaincodeId)
+}
+
+func (s *chaincodeEventsStreamServer) receiveStatus(t *testing.T, status common.Status) {
+	response := s.receive(t)
+	assert.Nil(t, response.Block)
+	assert.Equal(t, status, response.Status)
+}
+
+// createEventsBlock creates a block with the given number holding a valid
+// transaction which sets an event of the chaincode.
+func createEventsBlock(t *testing.T, number uint64, chaincodeName string) *common.Block {
+	action, err := createChaincodeAction(chaincodeName, "event", "txID")
+	require.NoError(t, err)
+	payload, err := createEndorsement("testChainID", "txID", action)
+	require.NoError(t, err)
+	block, err := createTestBlock([]*common.Envelope{{Payload: utils.MarshalOrPanic(payload)}})
+	require.NoError(t, err)
+	block.Header.Number = number
+	return block
+}
+
+func TestChaincodeEventsServer(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	defer viper.Reset()
+
+	dbPath, err := ioutil.TempDir("", "chaincodeevents")
+	require.NoError(t, err)
+	defer os.RemoveAll(dbPath)
+	checkpoints := NewChaincodeEventCheckpoints(dbPath)
+	defer checkpoints.Close()
+
+	// the blocks 0, 2 and 4 hold events of mycc
+	chain := &blocksChain{}
+	for number := uint64(0); number < 5; number++ {
+		chaincodeName := "mycc"
+		if number%2 == 1 {
+			chaincodeName = "othercc"
+		}
+		chain.blocks = append(chain.blocks, createEventsBlock(t, number, chaincodeName))
+	}
+
+	deliverEvents := NewDeliverEventsServer(false, defaultPolicyCheckerProvider, chain, &disabled.Provider{})
+	server := NewChaincodeEventsServer(deliverEvents, checkpoints, 2)
+	oldest := &orderer.SeekPosition{Type: &orderer.SeekPosition_Oldest{Oldest: &orderer.SeekOldest{}}}
+
+	deliver := func(stream *chaincodeEventsStreamServer) <-chan error {
+		errC := make(chan error, 1)
+		go func() { errC <- server.Deliver(stream) }()
+		return errC
+	}
+
+	// the consumer acknowledges block 0 and not block 2
+	stream := newChaincodeEventsStreamServer(context.Background())
+	errC := deliver(stream)
+	stream.subscribe(t, "consumer1", oldest, false)
+	stream.receiveBlock(t, 0)
+	stream.ack(0)
+	stream.receiveBlock(t, 2)
+	stream.receiveBlock(t, 4)
+	stream.receiveStatus(t, common.Status_SUCCESS)
+	stream.ack(2)
+
+	// the events are delivered to the consumer over a single stream
+	concurrent := newChaincodeEventsStreamServer(context.Background())
+	concurrentErrC := deliver(concurrent)
+	concurrent.subscribe(t, "consumer1", oldest, false)
+	concurrent.receiveStatus(t, common.Status_SERVICE_UNAVAILABLE)
+	close(concurrent.requests)
+	assert.NoError(t, <-concurrentErrC)
+
+	close(stream.requests)
+	assert.NoError(t, <-errC)
+
+	// the resumed consumer is delivered the block it did not acknowledge
+	stream = newChaincodeEventsStreamServer(context.Background())
+	errC = deliver(stream)
+	stream.subscribe(t, "consumer1", oldest, true)
+	stream.receiveBlock(t, 4)
+	stream.receiveStatus(t, common.Status_SUCCESS)
+	stream.ack(4)
+
+	// the blocks acknowledged are never delivered again
+	stream.subscribe(t, "consumer1", oldest, false)
+	stream.receiveStatus(t, common.Status_SUCCESS)
+
+	// the ack of a block not delivered ends the stream
+	stream.ack(5)
+	assert.EqualError(t, <-errC, "received an ack of block [5] whose events were not delivered")
+
+	// another consumer has its own acknowledged blocks, and is delivered at
+	// most two blocks of events without acknowledging them
+	stream = newChaincodeEventsStreamServer(context.Background())
+	errC = deliver(stream)
+	stream.subscribe(t, "consumer2", oldest, true)
+	stream.receiveBlock(t, 0)
+	stream.receiveBlock(t, 2)
+	select {
+	case response := <-stream.responses:
+		t.Fatalf("unexpected response %v before the acks", response)
+	case <-time.After(100 * time.Millisecond):
+	}
+	stream.ack(2)
+	stream.receiveBlock(t, 4)
+	stream.receiveStatus(t, common.Status_SUCCESS)
+	close(stream.requests)
+	assert.NoError(t, <-errC)
+}
+
+func TestChaincodeEvents(t *testing.T) {
+	block := createEventsBlock(t, 7, "mycc")
+
+	events, err := chaincodeEvents(block, "mycc")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.True(t, proto.Equal(&peer.ChaincodeEvent{ChaincodeId: "mycc", EventName: "event", TxId: "txID"}, events[0]))
+
+	events, err = chaincodeEvents(block, "")
+	require.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	events, err = chaincodeEvents(block, "othercc")
+	require.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][0] = uint8(peer.TxValidationCode_MVCC_READ_CONFLICT)
+	events, err = chaincodeEvents(block, "mycc")
+	require.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = nil
+	_, err = chaincodeEvents(block, "mycc")
+	assert.EqualError(t, err, "block has 1 transactions and 0 validation flags")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/core/committer"
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+var commitListeners = struct {
+	sync.RWMutex
+	listeners []func(channelID string)
+}{}
+
+// AddCommitListener registers a function invoked with the ID of a channel each time
+// the peer commits a block of the channel, or the private data of its older blocks,
+// once the state of the channel is updated
+func AddCommitListener(listener func(channelID string)) {
+	commitListeners.Lock()
+	defer commitListeners.Unlock()
+	commitListeners.listeners = append(commitListeners.listeners, listener)
+}
+
+func notifyCommitListeners(channelID string) {
+	commitListeners.RLock()
+	defer commitListeners.RUnlock()
+	for _, listener := range commitListeners.listeners {
+		listener(channelID)
+	}
+}
+
+// notifyingCommitter notifies the commit listeners of the commits of a channel
+type notifyingCommitter struct {
+	committer.Committer
+	channelID string
+}
+
+// CommitWithPvtData commits the block and its private data, and notifies the commit
+// listeners
+func (nc *notifyingCommitter) CommitWithPvtData(blockAndPvtData *ledger.BlockAndPvtData) error {
+	if err := nc.Committer.CommitWithPvtData(blockAndPvtData); err != nil {
+		return err
+	}
+	notifyCommitListeners(nc.channelID)
+	return nil
+}
+
+// CommitPvtDataOfOldBlocks commits the private data of already committed blocks, and
+// notifies the commit listeners
+func (nc *notifyingCommitter) CommitPvtDataOfOldBlocks(blockPvtData []*ledger.BlockPvtData) ([]*ledger.PvtdataHashMismatch, error) {
+	mismatches, err := nc.Committer.CommitPvtDataOfOldBlocks(blockPvtData)
+	if err != nil {
+		return nil, err
+	}
+	notifyCommitListeners(nc.channelID)
+	return mismatches, nil
+}
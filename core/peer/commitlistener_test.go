@@ -0,0 +1,48 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotifyingCommitter(t *testing.T) {
+	defer func(listeners []func(string)) {
+		commitListeners.listeners = listeners
+	}(commitListeners.listeners)
+
+	var notified []string
+	AddCommitListener(func(channelID string) {
+		notified = append(notified, channelID)
+	})
+
+	c := &mocks.Committer{}
+	c.On("CommitWithPvtData", mock.Anything).Return(nil).Once()
+	c.On("CommitWithPvtData", mock.Anything).Return(errors.New("commit failed")).Once()
+	c.On("CommitPvtDataOfOldBlocks", mock.Anything).Return([]*ledger.PvtdataHashMismatch{{BlockNum: 1}}, nil).Once()
+	c.On("CommitPvtDataOfOldBlocks", mock.Anything).Return(nil, errors.New("reconciliation failed")).Once()
+	nc := &notifyingCommitter{Committer: c, channelID: "testchannelid"}
+
+	assert.NoError(t, nc.CommitWithPvtData(&ledger.BlockAndPvtData{}))
+	assert.Equal(t, []string{"testchannelid"}, notified)
+	assert.EqualError(t, nc.CommitWithPvtData(&ledger.BlockAndPvtData{}), "commit failed")
+	assert.Equal(t, []string{"testchannelid"}, notified)
+
+	mismatches, err := nc.CommitPvtDataOfOldBlocks(nil)
+	assert.NoError(t, err)
+	assert.Len(t, mismatches, 1)
+	assert.Equal(t, []string{"testchannelid", "testchannelid"}, notified)
+	_, err = nc.CommitPvtDataOfOldBlocks(nil)
+	assert.EqualError(t, err, "reconciliation failed")
+	assert.Equal(t, []string{"testchannelid", "testchannelid"}, notified)
+}
@@ -142,6 +142,16 @@ func GetServerConfig() (comm.ServerConfig, error) {
 		}
 		secureOptions.Certificate = serverCert
 		secureOptions.Key = serverKey
+		sniCertificates, err := getSNICertificates()
+		if err != nil {
+			return serverConfig, err
+		}
+		secureOptions.SNICertificates = sniCertificates
+		tlsPolicy, err := GetTLSPolicy()
+		if err != nil {
+			return serverConfig, fmt.Errorf("error loading TLS policy (%s)", err)
+		}
+		tlsPolicy.Apply(secureOptions)
 		secureOptions.RequireClientCert = viper.GetBool("peer.tls.clientAuthRequired")
 		if secureOptions.RequireClientCert {
 			var clientRoots [][]byte
@@ -165,6 +175,7 @@ func GetServerConfig() (comm.ServerConfig, error) {
 			secureOptions.ServerRootCAs = [][]byte{rootCert}
 		}
 	}
+	serverConfig.ReusePort = viper.GetBool("peer.reusePort")
 	// get the default keepalive options
 	serverConfig.KaOpts = comm.DefaultKeepaliveOptions
 	// check to see if minInterval is set for the env
@@ -174,6 +185,48 @@ func GetServerConfig() (comm.ServerConfig, error) {
 	return serverConfig, nil
 }
 
+// GetTLSPolicy returns the TLS protocol versions and cipher suites allowed by the
+// peer, which apply to its gRPC servers and clients and to its chaincodes
+func GetTLSPolicy() (*comm.TLSPolicy, error) {
+	return comm.NewTLSPolicy(
+		viper.GetString("peer.tls.minVersion"),
+		viper.GetString("peer.tls.maxVersion"),
+		viper.GetStringSlice("peer.tls.cipherSuites"),
+		viper.GetBool("peer.tls.fips"),
+	)
+}
+
+// getSNICertificates loads the TLS certificates presented by the peer to the
+// clients that request a given server name, for instance to serve an endpoint
+// advertised to other organizations with a certificate of its own
+func getSNICertificates() ([]comm.SNICertificate, error) {
+	var sniConfig []struct {
+		ServerName string
+		Cert       struct{ File string }
+		Key        struct{ File string }
+	}
+	if err := viper.UnmarshalKey("peer.tls.sniCertificates", &sniConfig); err != nil {
+		return nil, fmt.Errorf("error parsing the SNI certificates (%s)", err)
+	}
+	var sniCertificates []comm.SNICertificate
+	for _, c := range sniConfig {
+		if c.ServerName == "" {
+			return nil, errors.New("the server name of an SNI certificate is not set")
+		}
+		baseDir := filepath.Dir(viper.ConfigFileUsed())
+		cert, err := ioutil.ReadFile(config.TranslatePath(baseDir, c.Cert.File))
+		if err != nil {
+			return nil, fmt.Errorf("error loading the TLS certificate of server name %s (%s)", c.ServerName, err)
+		}
+		key, err := ioutil.ReadFile(config.TranslatePath(baseDir, c.Key.File))
+		if err != nil {
+			return nil, fmt.Errorf("error loading the TLS key of server name %s (%s)", c.ServerName, err)
+		}
+		sniCertificates = append(sniCertificates, comm.SNICertificate{ServerName: c.ServerName, Certificate: cert, Key: key})
+	}
+	return sniCertificates, nil
+}
+
 // GetServerRootCAs returns the root certificates which will be trusted for
 // gRPC client connections to peers and orderers.
 func GetServerRootCAs() ([][]byte, error) {
@@ -66,6 +66,18 @@ func TestConfigTxCreateLedger(t *testing.T) {
 	retrievedchanConf, err := retrievePersistedChannelConfig(ledger)
 	assert.NoError(t, err)
 	assert.Equal(t, proto.CompactTextString(chanConf), proto.CompactTextString(retrievedchanConf))
+
+	sim, err := ledger.NewTxSimulator("tx1")
+	assert.NoError(t, err)
+	defer sim.Done()
+	readChanConf, err := ReadChannelConfig(sim)
+	assert.NoError(t, err)
+	assert.Equal(t, proto.CompactTextString(chanConf), proto.CompactTextString(readChanConf))
+	simRes, err := sim.GetTxSimulationResults()
+	assert.NoError(t, err)
+	nsRWSets := simRes.PubSimulationResults.NsRwset
+	assert.Len(t, nsRWSets, 1)
+	assert.Equal(t, "", nsRWSets[0].Namespace)
 }
 
 func TestConfigTxUpdateChanConfig(t *testing.T) {
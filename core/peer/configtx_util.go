@@ -44,3 +44,11 @@ func retrievePersistedChannelConfig(ledger ledger.PeerLedger) (*common.Config, e
 	defer qe.Done()
 	return retrievePersistedConf(qe, channelConfigKey)
 }
+
+// ReadChannelConfig reads the channel config persisted in the statedb with the given query
+// executor. When the query executor is a transaction simulator, the read is recorded in the
+// read set, so that the transaction is invalidated if the channel config is updated before
+// the transaction is committed
+func ReadChannelConfig(qe ledger.QueryExecutor) (*common.Config, error) {
+	return retrievePersistedConf(qe, channelConfigKey)
+}
@@ -137,12 +137,56 @@ func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerP
 		timeWindow = defaultTimeWindow
 	}
 	metrics := deliver.NewMetrics(metricsProvider)
+	dh := deliver.NewHandler(chainManager, timeWindow, mutualTLS, metrics)
+	dh.BindTLSIdentity = viper.GetBool("peer.authentication.bindTLSIdentity")
+	dh.ReevaluationInterval = viper.GetDuration("peer.authentication.reevaluationInterval")
+	if ttl := viper.GetDuration("peer.deliverSessions.ttl"); ttl > 0 {
+		dh.Sessions = deliver.NewSessions(deliver.SessionConfig{
+			TTL:               ttl,
+			MaxReplayedBlocks: uint64(viper.GetInt("peer.deliverSessions.maxReplayedBlocks")),
+			ReplayWindow:      viper.GetDuration("peer.deliverSessions.replayWindow"),
+		})
+	}
+	dh.Throttle = deliver.NewThrottle(deliver.ThrottleLimits{
+		MaxStreamsPerIdentity:        viper.GetInt("peer.deliverThrottle.maxStreamsPerIdentity"),
+		MaxStreamsPerOrg:             viper.GetInt("peer.deliverThrottle.maxStreamsPerOrg"),
+		MaxBytesPerSecondPerIdentity: int64(viper.GetSizeInBytes("peer.deliverThrottle.maxBytesPerSecondPerIdentity")),
+		MaxBytesPerSecondPerOrg:      int64(viper.GetSizeInBytes("peer.deliverThrottle.maxBytesPerSecondPerOrg")),
+	})
 	return &server{
-		dh:                    deliver.NewHandler(chainManager, timeWindow, mutualTLS, metrics),
+		dh:                    dh,
 		policyCheckerProvider: policyCheckerProvider,
 	}
 }
 
+// ThrottleLimits returns the limits of the deliver streams of the channel, the
+// non-zero limits set in the ACL of the event/Block or event/FilteredBlock API
+// overriding the defaults of the peer
+func (cs *chainSupport) ThrottleLimits(defaults deliver.ThrottleLimits, filtered bool) deliver.ThrottleLimits {
+	if cs.Application == nil {
+		return defaults
+	}
+	resource := resources.Event_Block
+	if filtered {
+		resource = resources.Event_FilteredBlock
+	}
+	limits := defaults
+	overrides := cs.Application.APIPolicyMapper().DeliverLimitsForAPI(resource)
+	if n := overrides.GetMaxStreamsPerIdentity(); n > 0 {
+		limits.MaxStreamsPerIdentity = int(n)
+	}
+	if n := overrides.GetMaxStreamsPerOrg(); n > 0 {
+		limits.MaxStreamsPerOrg = int(n)
+	}
+	if n := overrides.GetMaxBytesPerSecondPerIdentity(); n > 0 {
+		limits.MaxBytesPerSecondPerIdentity = int64(n)
+	}
+	if n := overrides.GetMaxBytesPerSecondPerOrg(); n > 0 {
+		limits.MaxBytesPerSecondPerOrg = int64(n)
+	}
+	return limits
+}
+
 func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg proto.Message) error {
 	return func(msg proto.Message) error {
 		response, ok := msg.(*peer.DeliverResponse)
@@ -156,10 +200,15 @@ func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg p
 
 func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
-		Number: block.Header.Number,
+		Number:    block.Header.Number,
+		BlockHash: block.Header.Hash(),
 	}
 
 	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	diagnostics, err := util.GetCommitDiagnostics((*common.Block)(block))
+	if err != nil {
+		return nil, err
+	}
 	for txIndex, ebytes := range block.Data.Data {
 		var env *common.Envelope
 		var err error
@@ -198,6 +247,7 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 			Txid:             chdr.TxId,
 			Type:             common.HeaderType(chdr.Type),
 			TxValidationCode: txsFltr.Flag(txIndex),
+			MvccConflict:     diagnostics.MVCCConflict(txIndex),
 		}
 
 		if filteredTransaction.Type == common.HeaderType_ENDORSER_TRANSACTION {
@@ -16,9 +16,14 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/orderer"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
@@ -94,6 +99,10 @@ func (*mockChainSupport) Errored() <-chan struct{} {
 	return make(chan struct{})
 }
 
+func (m *mockChainSupport) MSPManager() msp.MSPManager {
+	panic("implement me")
+}
+
 // mockChainManager mock implementation of the ChainManager interface
 type mockChainManager struct {
 	mock.Mock
@@ -167,6 +176,34 @@ func TestFilteredBlockResponseSenderIsFiltered(t *testing.T) {
 	assert.True(t, filtered.IsFiltered(), "should return true from IsFiltered")
 }
 
+func TestFilteredBlockMVCCConflict(t *testing.T) {
+	payload, err := createEndorsement("testChainID", "testID", nil)
+	assert.NoError(t, err)
+	block, err := createTestBlock([]*common.Envelope{{Payload: utils.MarshalOrPanic(payload)}})
+	assert.NoError(t, err)
+	txsFilter := ledgerutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	txsFilter.SetFlag(0, peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	filteredBlock, err := (*blockEvent)(block).toFilteredBlock()
+	assert.NoError(t, err)
+	assert.Nil(t, filteredBlock.FilteredTransactions[0].MvccConflict)
+
+	conflict := &peer.MVCCConflict{
+		Namespace:        "mycc",
+		Key:              "key1",
+		ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 0},
+		CommittedVersion: &kvrwset.Version{BlockNum: 2, TxNum: 3},
+	}
+	err = ledgerutil.SetCommitDiagnostics(block, &peer.CommitDiagnostics{MvccConflicts: []*peer.MVCCConflict{conflict}})
+	assert.NoError(t, err)
+
+	filteredBlock, err = (*blockEvent)(block).toFilteredBlock()
+	assert.NoError(t, err)
+	tx := filteredBlock.FilteredTransactions[0]
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, tx.TxValidationCode)
+	assert.True(t, proto.Equal(conflict, tx.MvccConflict))
+}
+
 func TestEventsServer_DeliverFiltered(t *testing.T) {
 	viper.Set("peer.authentication.timewindow", "1s")
 	tests := []testCase{
@@ -204,6 +241,7 @@ func TestEventsServer_DeliverFiltered(t *testing.T) {
 								block := response.GetFilteredBlock()
 								config.Equal(uint64(0), block.Number)
 								config.Equal(config.channelID, block.ChannelId)
+								config.Len(block.BlockHash, 32)
 								config.Equal(1, len(block.FilteredTransactions))
 								tx := block.FilteredTransactions[0]
 								config.Equal(config.txID, tx.Txid)
@@ -523,3 +561,21 @@ func createTestBlock(data []*common.Envelope) (*common.Block, error) {
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = make([]byte, len(data))
 	return block, nil
 }
+
+func TestChainSupportThrottleLimits(t *testing.T) {
+	defaults := deliver.ThrottleLimits{MaxStreamsPerIdentity: 4, MaxBytesPerSecondPerOrg: 1000}
+
+	cs := &chainSupport{}
+	assert.Equal(t, defaults, cs.ThrottleLimits(defaults, false))
+
+	cs.Application = &mockconfig.MockApplication{DeliverLimits: map[string]*peer.DeliverLimits{
+		resources.Event_Block: {MaxStreamsPerIdentity: 1, MaxStreamsPerOrg: 8, MaxBytesPerSecondPerIdentity: 100},
+	}}
+	assert.Equal(t, deliver.ThrottleLimits{
+		MaxStreamsPerIdentity:        1,
+		MaxStreamsPerOrg:             8,
+		MaxBytesPerSecondPerIdentity: 100,
+		MaxBytesPerSecondPerOrg:      1000,
+	}, cs.ThrottleLimits(defaults, false))
+	assert.Equal(t, defaults, cs.ThrottleLimits(defaults, true))
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net"
 	"runtime"
+	"sort"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
@@ -21,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	fileledger "github.com/hyperledger/fabric/common/ledger/blockledger/file"
 	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
@@ -67,6 +69,21 @@ type gossipSupport struct {
 	channelconfig.Application
 	configtx.Validator
 	channelconfig.Channel
+	mspManager msp.MSPManager
+}
+
+// TLSCACerts returns the TLS root and intermediate CA certificates of the given organization
+func (gs *gossipSupport) TLSCACerts(mspID string) ([][]byte, [][]byte) {
+	msps, err := gs.mspManager.GetMSPs()
+	if err != nil {
+		peerLogger.Warningf("Failed getting the MSPs of channel %s: %s", gs.ChainID(), err)
+		return nil, nil
+	}
+	m, exists := msps[mspID]
+	if !exists {
+		return nil, nil
+	}
+	return m.GetTLSRootCerts(), m.GetTLSIntermediateCerts()
 }
 
 type chainSupport struct {
@@ -81,6 +98,7 @@ var TransientStoreFactory = &storeProvider{stores: make(map[string]transientstor
 type storeProvider struct {
 	stores map[string]transientstore.Store
 	transientstore.StoreProvider
+	metricsProvider metrics.Provider
 	sync.RWMutex
 }
 
@@ -90,11 +108,21 @@ func (sp *storeProvider) StoreForChannel(channel string) transientstore.Store {
 	return sp.stores[channel]
 }
 
+func (sp *storeProvider) setMetricsProvider(metricsProvider metrics.Provider) {
+	sp.Lock()
+	defer sp.Unlock()
+	sp.metricsProvider = metricsProvider
+}
+
 func (sp *storeProvider) OpenStore(ledgerID string) (transientstore.Store, error) {
 	sp.Lock()
 	defer sp.Unlock()
 	if sp.StoreProvider == nil {
-		sp.StoreProvider = transientstore.NewStoreProvider()
+		metricsProvider := sp.metricsProvider
+		if metricsProvider == nil {
+			metricsProvider = &disabled.Provider{}
+		}
+		sp.StoreProvider = transientstore.NewStoreProvider(metricsProvider, transientstore.GetQuotaConfig())
 	}
 	store, err := sp.StoreProvider.OpenStore(ledgerID)
 	if err == nil {
@@ -123,26 +151,46 @@ func (cs *chainSupport) Apply(configtx *common.ConfigEnvelope) error {
 			return err
 		}
 
-		capabilitiesSupportedOrPanic(bundle)
+		if err := capabilitiesSupported(bundle); err != nil {
+			return err
+		}
 
 		cs.bundleSource.Update(bundle)
 	}
 	return nil
 }
 
-func capabilitiesSupportedOrPanic(res channelconfig.Resources) {
+// checkMaxBlockSize warns if the blocks of the channel may exceed the maximum
+// message size the peer can receive, as the ordering service refuses to deliver them
+func checkMaxBlockSize(bundle *channelconfig.Bundle) {
+	oc, ok := bundle.OrdererConfig()
+	if !ok {
+		return
+	}
+	if maxBytes := oc.BatchSize().AbsoluteMaxBytes; int64(maxBytes) > int64(comm.MaxRecvMsgSize) {
+		peerLogger.Warningf("[channel: %s] The absolute maximum block size of %d bytes exceeds the maximum message size of %d bytes "+
+			"the peer can receive (peer.maxRecvMsgSize), the blocks exceeding it will not be delivered to the peer",
+			bundle.ConfigtxValidator().ChainID(), maxBytes, comm.MaxRecvMsgSize)
+	}
+}
+
+// capabilitiesSupported returns an error if the config of the channel requires
+// capabilities this peer does not support, in which case the peer must be
+// upgraded before it processes the channel any further
+func capabilitiesSupported(res channelconfig.Resources) error {
 	ac, ok := res.ApplicationConfig()
 	if !ok {
-		peerLogger.Panicf("[channel %s] does not have application config so is incompatible", res.ConfigtxValidator().ChainID())
+		return errors.Errorf("[channel %s] does not have application config so is incompatible", res.ConfigtxValidator().ChainID())
 	}
 
 	if err := ac.Capabilities().Supported(); err != nil {
-		peerLogger.Panicf("[channel %s] incompatible: %s", res.ConfigtxValidator().ChainID(), err)
+		return errors.WithMessage(err, fmt.Sprintf("[channel %s] incompatible, the peer must be upgraded to a version supporting the capabilities of the channel", res.ConfigtxValidator().ChainID()))
 	}
 
 	if err := res.ChannelConfig().Capabilities().Supported(); err != nil {
-		peerLogger.Panicf("[channel %s] incompatible: %s", res.ConfigtxValidator().ChainID(), err)
+		return errors.WithMessage(err, fmt.Sprintf("[channel %s] incompatible, the peer must be upgraded to a version supporting the capabilities of the channel", res.ConfigtxValidator().ChainID()))
 	}
+	return nil
 }
 
 func (cs *chainSupport) Ledger() ledger.PeerLedger {
@@ -201,6 +249,13 @@ func MockSetMSPIDGetter(mspIDGetter func(string) []string) {
 // there are not too many concurrent tx validation goroutines
 var validationWorkersSemaphore *semaphore.Weighted
 
+// validatorConfig and validatorMetrics are the configuration and
+// the metrics of the transaction validators of the channels
+var (
+	validatorConfig  txvalidator.Config
+	validatorMetrics = txvalidator.NewMetrics(&disabled.Provider{})
+)
+
 // Initialize sets up any chains that the peer has from the persistence. This
 // function should be called at the start up when the ledger and gossip
 // ready
@@ -212,6 +267,13 @@ func Initialize(init func(string), ccp ccprovider.ChaincodeProvider, sccp sysccp
 		nWorkers = runtime.NumCPU()
 	}
 	validationWorkersSemaphore = semaphore.NewWeighted(int64(nWorkers))
+	validatorConfig = txvalidator.Config{
+		PluginTimeout: viper.GetDuration("peer.validation.pluginTimeout"),
+		MaxAttempts:   viper.GetInt("peer.validation.retry.maxAttempts"),
+		RetryBackoff:  viper.GetDuration("peer.validation.retry.backoff"),
+	}
+	validatorMetrics = txvalidator.NewMetrics(metricsProvider)
+	TransientStoreFactory.setMetricsProvider(metricsProvider)
 
 	pluginMapper = pm
 	chainInitializer = init
@@ -318,7 +380,9 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		}
 	}
 
-	capabilitiesSupportedOrPanic(bundle)
+	if err := capabilitiesSupported(bundle); err != nil {
+		return err
+	}
 
 	channelconfig.LogSanityChecks(bundle)
 
@@ -334,6 +398,7 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 			Validator:   bundle.ConfigtxValidator(),
 			Application: ac,
 			Channel:     bundle.ChannelConfig(),
+			mspManager:  bundle.MSPManager(),
 		})
 		service.GetGossipService().SuspectPeers(func(identity api.PeerIdentityType) bool {
 			// TODO: this is a place-holder that would somehow make the MSP layer suspect
@@ -353,6 +418,11 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		mspmgmt.XXXSetMSPManager(cid, bundle.MSPManager())
 	}
 
+	commitHashCallback := func(bundle *channelconfig.Bundle) {
+		ac, ok := bundle.ApplicationConfig()
+		ledger.EnableCommitHash(ok && ac.Capabilities().CommitHash())
+	}
+
 	ac, ok := bundle.ApplicationConfig()
 	if !ok {
 		ac = nil
@@ -378,20 +448,25 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block, ccp ccp
 		trustedRootsCallbackWrapper,
 		mspCallback,
 		peerSingletonCallback,
+		commitHashCallback,
+		checkMaxBlockSize,
 	)
 
 	vcs := struct {
 		*chainSupport
 		*semaphore.Weighted
 	}{cs, validationWorkersSemaphore}
-	validator := txvalidator.NewTxValidator(cid, vcs, sccp, pm)
-	c := committer.NewLedgerCommitterReactive(ledger, func(block *common.Block) error {
-		chainID, err := utils.GetChainIDFromBlock(block)
-		if err != nil {
-			return err
-		}
-		return SetCurrConfigBlock(block, chainID)
-	})
+	validator := txvalidator.NewTxValidator(cid, vcs, sccp, pm, validatorConfig, validatorMetrics)
+	c := &notifyingCommitter{
+		Committer: committer.NewLedgerCommitterReactive(ledger, func(block *common.Block) error {
+			chainID, err := utils.GetChainIDFromBlock(block)
+			if err != nil {
+				return err
+			}
+			return SetCurrConfigBlock(block, chainID)
+		}),
+		channelID: cid,
+	}
 
 	ordererAddresses := bundle.ChannelConfig().OrdererAddresses()
 	if len(ordererAddresses) == 0 {
@@ -442,6 +517,25 @@ func CreateChainFromBlock(cb *common.Block, ccp ccprovider.ChaincodeProvider, sc
 	return createChain(cid, l, cb, ccp, sccp, pluginMapper)
 }
 
+// LeaveChain stops the processing of the chain with the given chain ID by the peer,
+// including the dissemination and the pulling of its blocks, and removes its ledger
+func LeaveChain(cid string) error {
+	chains.Lock()
+	_, ok := chains.list[cid]
+	delete(chains.list, cid)
+	chains.Unlock()
+	if !ok {
+		return errors.Errorf("channel %s does not exist", cid)
+	}
+
+	service.GetGossipService().CloseChannel(cid)
+	if err := ledgermgmt.RemoveLedger(cid); err != nil {
+		return errors.WithMessage(err, "cannot remove the ledger of the channel")
+	}
+	peerLogger.Infof("Left channel %s", cid)
+	return nil
+}
+
 // GetLedger returns the ledger of the chain with chain ID. Note that this
 // call returns nil if chain cid has not been created.
 func GetLedger(cid string) ledger.PeerLedger {
@@ -681,6 +775,25 @@ func GetChannelsInfo() []*pb.ChannelInfo {
 	return channelInfoArray
 }
 
+// GetChannelHeights returns the height of the ledger of all the channels of this peer
+func GetChannelHeights() ([]*pb.ChannelHeight, error) {
+	chains.RLock()
+	defer chains.RUnlock()
+
+	var channels []*pb.ChannelHeight
+	for cid, c := range chains.list {
+		info, err := c.cs.ledger.GetBlockchainInfo()
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("cannot retrieve the height of the channel %s", cid))
+		}
+		channels = append(channels, &pb.ChannelHeight{ChannelId: cid, Height: info.Height})
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].ChannelId < channels[j].ChannelId
+	})
+	return channels, nil
+}
+
 // NewChannelPolicyManagerGetter returns a new instance of ChannelPolicyManagerGetter
 func NewChannelPolicyManagerGetter() policies.ChannelPolicyManagerGetter {
 	return &channelPolicyManagerGetter{}
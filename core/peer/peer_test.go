@@ -12,9 +12,12 @@ import (
 	"net"
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/metrics/disabled"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	mockconfigtx "github.com/hyperledger/fabric/common/mocks/configtx"
 	mscc "github.com/hyperledger/fabric/common/mocks/scc"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/comm"
@@ -22,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/deliverservice/blocksprovider"
 	"github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	ledgermocks "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/mocks/ccprovider"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -30,6 +34,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	peergossip "github.com/hyperledger/fabric/peer/gossip"
 	"github.com/hyperledger/fabric/peer/gossip/mocks"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -192,6 +197,19 @@ func TestCreateChainFromBlock(t *testing.T) {
 		t.Fatalf("incorrect number of channels")
 	}
 
+	heights, err := GetChannelHeights()
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.ChannelHeight{{ChannelId: testChainID, Height: 1}}, heights)
+
+	// Leave the channel
+	assert.NoError(t, LeaveChain(testChainID))
+	assert.Nil(t, GetLedger(testChainID))
+	assert.Empty(t, GetChannelsInfo())
+	ledgerIDs, err := ledgermgmt.GetLedgerIDs()
+	assert.NoError(t, err)
+	assert.NotContains(t, ledgerIDs, testChainID)
+	assert.EqualError(t, LeaveChain(testChainID), "channel "+testChainID+" does not exist")
+
 	// cleanup the chain referenes to enable execution with -count n
 	chains.Lock()
 	chains.list = map[string]*chain{}
@@ -204,8 +222,12 @@ func TestGetLocalIP(t *testing.T) {
 }
 
 func TestDeliverSupportManager(t *testing.T) {
+	cleanup := setupPeerFS(t)
+	defer cleanup()
+
 	// reset chains for testing
 	MockInitialize()
+	defer ledgermgmt.CleanupTestEnv()
 
 	manager := &DeliverChainManager{}
 	chainSupport := manager.GetChain("fake")
@@ -215,3 +237,25 @@ func TestDeliverSupportManager(t *testing.T) {
 	chainSupport = manager.GetChain("testchain")
 	assert.NotNil(t, chainSupport, "chain support should not be nil")
 }
+
+func TestCapabilitiesSupported(t *testing.T) {
+	applicationCapabilities := &mockconfig.MockApplicationCapabilities{}
+	channelCapabilities := &mockconfig.ChannelCapabilities{}
+	resources := &mockconfig.Resources{
+		ConfigtxValidatorVal: &mockconfigtx.Validator{ChainIDVal: "mychannel"},
+		ChannelConfigVal:     &mockconfig.Channel{CapabilitiesVal: channelCapabilities},
+	}
+	assert.EqualError(t, capabilitiesSupported(resources), "[channel mychannel] does not have application config so is incompatible")
+
+	resources.ApplicationConfigVal = &mockconfig.MockApplication{CapabilitiesRv: applicationCapabilities}
+	assert.NoError(t, capabilitiesSupported(resources))
+
+	applicationCapabilities.SupportedRv = &capabilities.UnsupportedError{Type: "Application", Capabilities: []string{"V9_9"}}
+	err := capabilitiesSupported(resources)
+	assert.EqualError(t, err, "[channel mychannel] incompatible, the peer must be upgraded to a version supporting the capabilities of the channel: Application capability V9_9 is required but not supported")
+	assert.True(t, capabilities.IsUnsupported(err))
+
+	applicationCapabilities.SupportedRv = nil
+	channelCapabilities.SupportedErr = &capabilities.UnsupportedError{Type: "Channel", Capabilities: []string{"V9_9"}}
+	assert.True(t, capabilities.IsUnsupported(capabilitiesSupported(resources)))
+}
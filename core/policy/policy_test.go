@@ -235,6 +235,36 @@ func TestPolicyChecker(t *testing.T) {
 	assert.Contains(t, err.Error(), "Failed deserializing proposal creator during channelless check policy with policy [Members]: [Invalid Identity]")
 }
 
+func TestPolicyCheckerNoChannelBySignedData(t *testing.T) {
+	identityDeserializer := &mocks.MockIdentityDeserializer{
+		Identity: []byte("Alice"),
+		Msg:      []byte("msg1"),
+	}
+	pc := NewPolicyChecker(
+		&mocks.MockChannelPolicyManagerGetter{},
+		identityDeserializer,
+		&mocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
+	)
+
+	err := pc.CheckPolicyNoChannelBySignedData("", nil)
+	assert.EqualError(t, err, "Invalid policy name during channelless check policy on signed data. Name must be different from nil.")
+
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, nil)
+	assert.EqualError(t, err, "Invalid signed data during channelless check policy with policy [Members]")
+
+	// Alice is a member of the local MSP, policy check must succeed
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*common.SignedData{{Data: []byte("msg1"), Identity: []byte("Alice"), Signature: []byte("msg1")}})
+	assert.NoError(t, err)
+
+	// The signature of Alice must be valid
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*common.SignedData{{Data: []byte("msg1"), Identity: []byte("Alice"), Signature: []byte("msg2")}})
+	assert.Error(t, err)
+
+	// Bob is not a member of the local MSP, policy check must fail
+	err = pc.CheckPolicyNoChannelBySignedData(mgmt.Members, []*common.SignedData{{Data: []byte("msg1"), Identity: []byte("Bob"), Signature: []byte("msg1")}})
+	assert.EqualError(t, err, "Failed deserializing signer during channelless check policy with policy [Members]: [Invalid Identity]")
+}
+
 type MockPolicyCheckerFactory struct {
 	mock.Mock
 }
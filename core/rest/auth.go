@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// The headers authenticating the requests. The signature covers the string
+// returned by SigningString, so that the requests can be signed by curl and
+// openssl based tooling as well as by the SDKs
+const (
+	// MSPIDHeader holds the ID of the MSP of the client
+	MSPIDHeader = "Fabric-Mspid"
+	// CertificateHeader holds the base64 encoding of the PEM certificate of the client
+	CertificateHeader = "Fabric-Certificate"
+	// TimestampHeader holds the time the request was signed at, in RFC 3339 format
+	TimestampHeader = "Fabric-Timestamp"
+	// SignatureHeader holds the base64 encoding of the ASN.1 DER ECDSA signature
+	// of the request by the client
+	SignatureHeader = "Fabric-Signature"
+)
+
+// SigningString returns the string signed by the client for a request with the
+// given method, URI (that is the path and the query of the request), timestamp
+// and MSP ID
+func SigningString(method, requestURI, timestamp, mspID string) string {
+	return fmt.Sprintf("%s\n%s\n%s\n%s", method, requestURI, timestamp, mspID)
+}
+
+// signedData returns the signed data of the request, checked against the
+// policies of the queries, after checking that the request was signed within
+// the given clock skew of the current time
+func signedData(r *http.Request, maxClockSkew time.Duration, now time.Time) (*common.SignedData, error) {
+	mspID := r.Header.Get(MSPIDHeader)
+	timestamp := r.Header.Get(TimestampHeader)
+	for _, header := range []string{MSPIDHeader, CertificateHeader, TimestampHeader, SignatureHeader} {
+		if r.Header.Get(header) == "" {
+			return nil, errors.Errorf("missing %s header", header)
+		}
+	}
+
+	signedAt, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return nil, errors.Errorf("invalid %s header, it must be in RFC 3339 format", TimestampHeader)
+	}
+	if skew := now.Sub(signedAt); skew > maxClockSkew || -skew > maxClockSkew {
+		return nil, errors.Errorf("the request was signed at %s, which is more than %s away from the time of the peer", timestamp, maxClockSkew)
+	}
+
+	certPEM, err := base64.StdEncoding.DecodeString(r.Header.Get(CertificateHeader))
+	if err != nil {
+		return nil, errors.Errorf("invalid %s header, it must be base64 encoded", CertificateHeader)
+	}
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.Errorf("invalid %s header, it must hold a PEM certificate", CertificateHeader)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s header", CertificateHeader)
+	}
+	publicKey, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("invalid %s header, the certificate must hold an ECDSA public key", CertificateHeader)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(r.Header.Get(SignatureHeader))
+	if err != nil {
+		return nil, errors.Errorf("invalid %s header, it must be base64 encoded", SignatureHeader)
+	}
+	// the MSPs only accept the signatures in their low-S form, which tools
+	// like openssl do not produce
+	signature, err = utils.SignatureToLowS(publicKey, signature)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s header", SignatureHeader)
+	}
+
+	identity, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: certPEM})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed marshaling the identity of the client")
+	}
+	return &common.SignedData{
+		Data:      []byte(SigningString(r.Method, r.URL.RequestURI(), timestamp, mspID)),
+		Identity:  identity,
+		Signature: signature,
+	}, nil
+}
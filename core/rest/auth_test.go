@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// client signs the requests the way curl and openssl based tooling would
+type client struct {
+	mspID   string
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+}
+
+func newClient(t *testing.T, mspID string) *client {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &client{mspID: mspID, key: key, certPEM: selfSignedCert(t, &key.PublicKey, key)}
+}
+
+func selfSignedCert(t *testing.T, pub, priv interface{}) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// sign signs the request at the given time, with a signature in its high-S
+// form if highS is true and in its low-S form otherwise
+func (c *client) sign(t *testing.T, r *http.Request, signedAt time.Time, highS bool) {
+	timestamp := signedAt.Format(time.RFC3339)
+	digest := sha256.Sum256([]byte(SigningString(r.Method, r.URL.RequestURI(), timestamp, c.mspID)))
+	sigR, sigS, err := ecdsa.Sign(rand.Reader, c.key, digest[:])
+	require.NoError(t, err)
+	lowS, err := utils.IsLowS(&c.key.PublicKey, sigS)
+	require.NoError(t, err)
+	if lowS == highS {
+		sigS.Sub(c.key.Params().N, sigS)
+	}
+	signature, err := utils.MarshalECDSASignature(sigR, sigS)
+	require.NoError(t, err)
+
+	r.Header.Set(MSPIDHeader, c.mspID)
+	r.Header.Set(CertificateHeader, base64.StdEncoding.EncodeToString(c.certPEM))
+	r.Header.Set(TimestampHeader, timestamp)
+	r.Header.Set(SignatureHeader, base64.StdEncoding.EncodeToString(signature))
+}
+
+func TestSignedData(t *testing.T) {
+	c := newClient(t, "Org1MSP")
+	now := time.Now().Truncate(time.Second)
+
+	for _, highS := range []bool{false, true} {
+		r := httptest.NewRequest(http.MethodGet, "/channels/testchannel/blocks/1?x=y", nil)
+		c.sign(t, r, now, highS)
+		sd, err := signedData(r, time.Minute, now)
+		require.NoError(t, err)
+
+		assert.Equal(t, "GET\n/channels/testchannel/blocks/1?x=y\n"+now.Format(time.RFC3339)+"\nOrg1MSP", string(sd.Data))
+		id := &msp.SerializedIdentity{}
+		require.NoError(t, proto.Unmarshal(sd.Identity, id))
+		assert.Equal(t, "Org1MSP", id.Mspid)
+		assert.Equal(t, c.certPEM, id.IdBytes)
+
+		// the signature is valid and in its low-S form
+		sigR, sigS, err := utils.UnmarshalECDSASignature(sd.Signature)
+		require.NoError(t, err)
+		digest := sha256.Sum256(sd.Data)
+		assert.True(t, ecdsa.Verify(&c.key.PublicKey, digest[:], sigR, sigS))
+		lowS, err := utils.IsLowS(&c.key.PublicKey, sigS)
+		require.NoError(t, err)
+		assert.True(t, lowS)
+	}
+
+	// the requests signed within the clock skew are accepted
+	r := httptest.NewRequest(http.MethodGet, "/channels", nil)
+	c.sign(t, r, now.Add(-time.Minute), false)
+	_, err := signedData(r, time.Minute, now)
+	assert.NoError(t, err)
+	c.sign(t, r, now.Add(time.Minute), false)
+	_, err = signedData(r, time.Minute, now)
+	assert.NoError(t, err)
+}
+
+func TestSignedDataInvalid(t *testing.T) {
+	c := newClient(t, "Org1MSP")
+	now := time.Now()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	rsaCert := base64.StdEncoding.EncodeToString(selfSignedCert(t, &rsaKey.PublicKey, rsaKey))
+	notPEM := base64.StdEncoding.EncodeToString([]byte("certificate"))
+	notCert := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")}))
+
+	tests := []struct {
+		name          string
+		header        string
+		value         string
+		signedAt      time.Time
+		expectedError string
+	}{
+		{name: "missing MSP ID", header: MSPIDHeader, expectedError: "missing Fabric-Mspid header"},
+		{name: "missing certificate", header: CertificateHeader, expectedError: "missing Fabric-Certificate header"},
+		{name: "missing timestamp", header: TimestampHeader, expectedError: "missing Fabric-Timestamp header"},
+		{name: "missing signature", header: SignatureHeader, expectedError: "missing Fabric-Signature header"},
+		{name: "invalid timestamp", header: TimestampHeader, value: "yesterday", expectedError: "invalid Fabric-Timestamp header, it must be in RFC 3339 format"},
+		{name: "signed too early", signedAt: now.Add(-2 * time.Minute), expectedError: "which is more than 1m0s away from the time of the peer"},
+		{name: "signed too late", signedAt: now.Add(2 * time.Minute), expectedError: "which is more than 1m0s away from the time of the peer"},
+		{name: "certificate not base64", header: CertificateHeader, value: "%", expectedError: "invalid Fabric-Certificate header, it must be base64 encoded"},
+		{name: "certificate not PEM", header: CertificateHeader, value: notPEM, expectedError: "invalid Fabric-Certificate header, it must hold a PEM certificate"},
+		{name: "invalid certificate", header: CertificateHeader, value: notCert, expectedError: "invalid Fabric-Certificate header: "},
+		{name: "RSA certificate", header: CertificateHeader, value: rsaCert, expectedError: "invalid Fabric-Certificate header, the certificate must hold an ECDSA public key"},
+		{name: "signature not base64", header: SignatureHeader, value: "%", expectedError: "invalid Fabric-Signature header, it must be base64 encoded"},
+		{name: "invalid signature", header: SignatureHeader, value: base64.StdEncoding.EncodeToString([]byte("signature")), expectedError: "invalid Fabric-Signature header"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/channels", nil)
+			signedAt := tt.signedAt
+			if signedAt.IsZero() {
+				signedAt = now
+			}
+			c.sign(t, r, signedAt, false)
+			if tt.header != "" {
+				r.Header.Set(tt.header, tt.value)
+			}
+			_, err := signedData(r, time.Minute, now)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
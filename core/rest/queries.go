@@ -0,0 +1,288 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/ledger/blkstorage"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// lsccNamespace is the namespace of the definitions of the instantiated chaincodes
+const lsccNamespace = "lscc"
+
+// newestBlock selects the last block of a channel in place of a block number
+const newestBlock = "newest"
+
+func badRequest(format string, args ...interface{}) error {
+	return &statusError{status: http.StatusBadRequest, err: errors.Errorf(format, args...)}
+}
+
+func forbidden(err error) error {
+	return &statusError{status: http.StatusForbidden, err: errors.WithMessage(err, "access denied")}
+}
+
+func notFound(format string, args ...interface{}) error {
+	return &statusError{status: http.StatusNotFound, err: errors.Errorf(format, args...)}
+}
+
+// channelLedger returns the ledger of the channel of the request, after checking
+// the access of the client to the given resource of the channel
+func (s *Server) channelLedger(r *http.Request, resource string, sd *common.SignedData) (string, ledger.PeerLedger, error) {
+	channelID := mux.Vars(r)["channel"]
+	l := s.options.Peer.GetLedger(channelID)
+	if l == nil {
+		return "", nil, notFound("channel %s not found", channelID)
+	}
+	if err := s.options.ACLProvider.CheckACL(resource, channelID, []*common.SignedData{sd}); err != nil {
+		return "", nil, forbidden(err)
+	}
+	return channelID, l, nil
+}
+
+// checkLocalPolicy checks the access of the client to the queries about the
+// peer itself, as the system chaincodes do
+func (s *Server) checkLocalPolicy(policyName string, sd *common.SignedData) error {
+	if err := s.options.PolicyChecker.CheckPolicyNoChannelBySignedData(policyName, []*common.SignedData{sd}); err != nil {
+		return forbidden(err)
+	}
+	return nil
+}
+
+// getChannels answers cscc/GetChannels
+func (s *Server) getChannels(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	if err := s.checkLocalPolicy(mgmt.Members, sd); err != nil {
+		return nil, err
+	}
+	return &pb.ChannelQueryResponse{Channels: s.options.Peer.GetChannelsInfo()}, nil
+}
+
+// getChainInfo answers qscc/GetChainInfo
+func (s *Server) getChainInfo(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	_, l, err := s.channelLedger(r, resources.Qscc_GetChainInfo, sd)
+	if err != nil {
+		return nil, err
+	}
+	return l.GetBlockchainInfo()
+}
+
+// getBlockByNumber answers qscc/GetBlockByNumber
+func (s *Server) getBlockByNumber(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	number := mux.Vars(r)["number"]
+	blockNumber := uint64(math.MaxUint64)
+	if number != newestBlock {
+		var err error
+		if blockNumber, err = strconv.ParseUint(number, 10, 64); err != nil {
+			return nil, badRequest("invalid block number %s, it must be a number or %s", number, newestBlock)
+		}
+	}
+
+	channelID, l, err := s.channelLedger(r, resources.Qscc_GetBlockByNumber, sd)
+	if err != nil {
+		return nil, err
+	}
+	block, err := l.GetBlockByNumber(blockNumber)
+	if errors.Cause(err) == blkstorage.ErrNotFoundInIndex {
+		return nil, notFound("block %s not found in channel %s", number, channelID)
+	}
+	return block, err
+}
+
+// getTransactionByID answers qscc/GetTransactionByID
+func (s *Server) getTransactionByID(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	channelID, l, err := s.channelLedger(r, resources.Qscc_GetTransactionByID, sd)
+	if err != nil {
+		return nil, err
+	}
+	txID := mux.Vars(r)["txid"]
+	tx, err := l.GetTransactionByID(txID)
+	if errors.Cause(err) == blkstorage.ErrNotFoundInIndex {
+		return nil, notFound("transaction %s not found in channel %s", txID, channelID)
+	}
+	return tx, err
+}
+
+// getConfigBlock answers cscc/GetConfigBlock
+func (s *Server) getConfigBlock(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	channelID, _, err := s.channelLedger(r, resources.Cscc_GetConfigBlock, sd)
+	if err != nil {
+		return nil, err
+	}
+	block := s.options.Peer.GetCurrConfigBlock(channelID)
+	if block == nil {
+		return nil, notFound("config block of channel %s not found", channelID)
+	}
+	return block, nil
+}
+
+// getInstantiatedChaincodes answers lscc/GetInstantiatedChaincodes
+func (s *Server) getInstantiatedChaincodes(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	_, l, err := s.channelLedger(r, resources.Lscc_GetInstantiatedChaincodes, sd)
+	if err != nil {
+		return nil, err
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, err
+	}
+	defer qe.Done()
+	itr, err := qe.GetStateRangeScanIterator(lsccNamespace, "", "")
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	resp := &pb.ChaincodeQueryResponse{}
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, err
+		}
+		if res == nil {
+			return resp, nil
+		}
+		kv := res.(*queryresult.KV)
+		// CollectionConfig isn't ChaincodeData
+		if privdata.IsCollectionConfigKey(kv.Key) {
+			continue
+		}
+		ccdata := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(kv.Value, ccdata); err != nil {
+			return nil, err
+		}
+		ccInfo := &pb.ChaincodeInfo{Name: ccdata.Name, Version: ccdata.Version, Escc: ccdata.Escc, Vscc: ccdata.Vscc, Annotations: ccdata.Annotations}
+		// the path and the input are only known for the chaincodes installed on the peer
+		if ccpack, err := ccprovider.GetChaincodeFromFS(ccdata.Name, ccdata.Version); err == nil {
+			ccInfo.Path = ccpack.GetDepSpec().GetChaincodeSpec().ChaincodeId.Path
+			ccInfo.Input = ccpack.GetDepSpec().GetChaincodeSpec().Input.String()
+		}
+		resp.Chaincodes = append(resp.Chaincodes, ccInfo)
+	}
+}
+
+// getInstalledChaincodes answers lscc/GetInstalledChaincodes
+func (s *Server) getInstalledChaincodes(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	if err := s.checkLocalPolicy(mgmt.Admins, sd); err != nil {
+		return nil, err
+	}
+	return s.options.InstalledChaincodes()
+}
+
+// discoverConfig answers the config queries of the discovery service
+func (s *Server) discoverConfig(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	res, err := s.discover(r, sd, &discovery.Query{
+		Channel: mux.Vars(r)["channel"],
+		Query:   &discovery.Query_ConfigQuery{ConfigQuery: &discovery.ConfigQuery{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetConfigResult(), nil
+}
+
+// discoverPeers answers the peer membership queries of the discovery service,
+// for the peers which have installed the chaincodes of the request if it has any
+func (s *Server) discoverPeers(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	query := &discovery.PeerMembershipQuery{}
+	if len(r.URL.Query()["chaincode"]) > 0 {
+		filter, err := chaincodeInterest(r)
+		if err != nil {
+			return nil, err
+		}
+		query.Filter = filter
+	}
+	res, err := s.discover(r, sd, &discovery.Query{
+		Channel: mux.Vars(r)["channel"],
+		Query:   &discovery.Query_PeerQuery{PeerQuery: query},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetMembers(), nil
+}
+
+// discoverEndorsers answers the chaincode queries of the discovery service, for
+// an invocation of the chaincodes of the request
+func (s *Server) discoverEndorsers(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	interest, err := chaincodeInterest(r)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.discover(r, sd, &discovery.Query{
+		Channel: mux.Vars(r)["channel"],
+		Query: &discovery.Query_CcQuery{
+			CcQuery: &discovery.ChaincodeQuery{Interests: []*discovery.ChaincodeInterest{interest}},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetCcQueryRes(), nil
+}
+
+// discoverLocalPeers answers the local membership queries of the discovery service
+func (s *Server) discoverLocalPeers(r *http.Request, sd *common.SignedData) (proto.Message, error) {
+	res, err := s.discover(r, sd, &discovery.Query{
+		Query: &discovery.Query_LocalPeers{LocalPeers: &discovery.LocalPeerQuery{}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.GetMembers(), nil
+}
+
+func (s *Server) discover(r *http.Request, sd *common.SignedData, query *discovery.Query) (*discovery.QueryResult, error) {
+	if s.options.Discovery == nil {
+		return nil, notFound("the discovery service is disabled")
+	}
+	res := s.options.Discovery.Query(query, *sd, r.RemoteAddr)
+	if e := res.GetError(); e != nil {
+		if e.Content == "access denied" {
+			return nil, &statusError{status: http.StatusForbidden, err: errors.New(e.Content)}
+		}
+		return nil, errors.New(e.Content)
+	}
+	return res, nil
+}
+
+// chaincodeInterest returns the invocation of the chaincodes of the chaincode
+// parameters of the request, each of them being the name of a chaincode
+// optionally followed by a colon and the comma separated names of the
+// collections it accesses
+func chaincodeInterest(r *http.Request) (*discovery.ChaincodeInterest, error) {
+	interest := &discovery.ChaincodeInterest{}
+	for _, chaincode := range r.URL.Query()["chaincode"] {
+		call := &discovery.ChaincodeCall{Name: chaincode}
+		if i := strings.Index(chaincode, ":"); i >= 0 {
+			call.Name = chaincode[:i]
+			call.CollectionNames = strings.Split(chaincode[i+1:], ",")
+		}
+		if call.Name == "" {
+			return nil, badRequest("invalid chaincode %s, it must be a chaincode name optionally followed by a colon and collection names", chaincode)
+		}
+		interest.Chaincodes = append(interest.Chaincodes, call)
+	}
+	if len(interest.Chaincodes) == 0 {
+		return nil, badRequest("missing chaincode parameter")
+	}
+	return interest, nil
+}
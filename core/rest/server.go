@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/operations"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+var logger = flogging.MustGetLogger("peer.rest")
+
+// DefaultMaxClockSkew is the default maximum difference between the time a request
+// was signed at and the time of the peer
+const DefaultMaxClockSkew = 5 * time.Minute
+
+// ACLProvider checks the access of the clients to the queries of a channel
+type ACLProvider interface {
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// PolicyChecker checks the access of the clients to the queries about the peer
+// itself against the local MSP
+type PolicyChecker interface {
+	CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error
+}
+
+// Peer gives access to the channels joined by the peer
+type Peer interface {
+	GetChannelsInfo() []*pb.ChannelInfo
+	GetCurrConfigBlock(cid string) *common.Block
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// Discovery answers the queries of the discovery service
+type Discovery interface {
+	Query(query *discovery.Query, data common.SignedData, addr string) *discovery.QueryResult
+}
+
+// InstalledChaincodes returns the chaincodes installed on the peer
+type InstalledChaincodes func() (*pb.ChaincodeQueryResponse, error)
+
+// Options are the options of the REST server
+type Options struct {
+	// ListenAddress is the address the server listens on
+	ListenAddress string
+	// TLS is the TLS configuration of the server
+	TLS operations.TLS
+	// AllowedOrigins are the origins allowed to query the server from browsers,
+	// which are not allowed to when it is empty
+	AllowedOrigins []string
+	// MaxClockSkew is the maximum difference between the time a request was
+	// signed at and the time of the peer, DefaultMaxClockSkew if 0
+	MaxClockSkew time.Duration
+
+	ACLProvider         ACLProvider
+	PolicyChecker       PolicyChecker
+	Peer                Peer
+	InstalledChaincodes InstalledChaincodes
+	// Discovery is the discovery service of the peer, nil if it is disabled
+	Discovery Discovery
+}
+
+// Server exposes the queries of the system chaincodes and of the discovery
+// service of the peer as JSON over HTTP, subject to the same access control as
+// the queries over gRPC. The requests are authenticated by the signature of
+// their method, URI and time by the client
+type Server struct {
+	options    Options
+	httpServer *http.Server
+	addr       string
+}
+
+// NewServer creates a REST server with the given options
+func NewServer(o Options) *Server {
+	if o.MaxClockSkew == 0 {
+		o.MaxClockSkew = DefaultMaxClockSkew
+	}
+	s := &Server{options: o}
+
+	var handler http.Handler = s.router()
+	if len(o.AllowedOrigins) > 0 {
+		handler = handlers.CORS(
+			handlers.AllowedOrigins(o.AllowedOrigins),
+			handlers.AllowedMethods([]string{http.MethodGet}),
+			handlers.AllowedHeaders([]string{MSPIDHeader, CertificateHeader, TimestampHeader, SignatureHeader}),
+		)(handler)
+	}
+	s.httpServer = &http.Server{
+		Addr:         o.ListenAddress,
+		Handler:      handler,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 2 * time.Minute,
+	}
+	return s
+}
+
+func (s *Server) router() *mux.Router {
+	router := mux.NewRouter().StrictSlash(true)
+	routes := map[string]queryFunc{
+		"/channels":                               s.getChannels,
+		"/channels/{channel}":                     s.getChainInfo,
+		"/channels/{channel}/blocks/{number}":     s.getBlockByNumber,
+		"/channels/{channel}/transactions/{txid}": s.getTransactionByID,
+		"/channels/{channel}/config":              s.getConfigBlock,
+		"/channels/{channel}/chaincodes":          s.getInstantiatedChaincodes,
+		"/channels/{channel}/discovery/config":    s.discoverConfig,
+		"/channels/{channel}/discovery/peers":     s.discoverPeers,
+		"/channels/{channel}/discovery/endorsers": s.discoverEndorsers,
+		"/chaincodes":                             s.getInstalledChaincodes,
+		"/discovery/peers":                        s.discoverLocalPeers,
+	}
+	for path, query := range routes {
+		router.Handle(path, s.handle(query)).Methods(http.MethodGet)
+	}
+	return router
+}
+
+// Start starts serving the requests
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.options.ListenAddress)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := s.options.TLS.Config()
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	s.addr = listener.Addr().String()
+
+	logger.Infof("Serving the queries of the peer over REST on %s", s.addr)
+	go s.httpServer.Serve(listener)
+	return nil
+}
+
+// Stop stops serving the requests
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return s.httpServer.Shutdown(ctx)
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// queryFunc answers a query authenticated by the given signed data
+type queryFunc func(r *http.Request, sd *common.SignedData) (proto.Message, error)
+
+// statusError is an error answered with an HTTP status code
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handle(query queryFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sd, err := signedData(r, s.options.MaxClockSkew, time.Now())
+		if err != nil {
+			s.writeError(w, r, &statusError{status: http.StatusUnauthorized, err: err})
+			return
+		}
+
+		resp, err := query(r, sd)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := protolator.DeepMarshalJSON(&buf, resp); err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		buf.WriteTo(w)
+	})
+}
+
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	if se, ok := err.(*statusError); ok {
+		status = se.status
+	}
+	logger.Warningf("Query %s from %s failed with status %d: %s", r.URL.Path, r.RemoteAddr, status, err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&errorResponse{Error: err.Error()})
+}
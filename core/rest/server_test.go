@@ -0,0 +1,394 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/discovery"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	// the transactions of the tests are signed by the local MSP
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		fmt.Printf("Failed loading the MSP setup: %s", err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func signerMSPID(sd *common.SignedData) string {
+	id := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(sd.Identity, id); err != nil {
+		return ""
+	}
+	return id.Mspid
+}
+
+// aclProvider grants the access to the resources of the channels to the
+// clients of the given MSPs, and records the resources it checked
+type aclProvider struct {
+	members map[string]string
+	checked []string
+}
+
+func (p *aclProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	p.checked = append(p.checked, resName)
+	if mspID := signerMSPID(idinfo.([]*common.SignedData)[0]); mspID != p.members[channelID] {
+		return errors.Errorf("%s is not a member of channel %s", mspID, channelID)
+	}
+	return nil
+}
+
+// policyChecker grants the access to the queries about the peer to the clients
+// of the given MSPs
+type policyChecker map[string]string
+
+func (pc policyChecker) CheckPolicyNoChannelBySignedData(policyName string, sd []*common.SignedData) error {
+	if mspID := signerMSPID(sd[0]); mspID != pc[policyName] {
+		return errors.Errorf("%s does not satisfy the %s policy", mspID, policyName)
+	}
+	return nil
+}
+
+// discoveryService answers the queries of the clients of the given MSPs
+type discoveryService map[string]string
+
+func (d discoveryService) Query(query *discovery.Query, data common.SignedData, addr string) *discovery.QueryResult {
+	if signerMSPID(&data) != d[query.Channel] {
+		return &discovery.QueryResult{Result: &discovery.QueryResult_Error{Error: &discovery.Error{Content: "access denied"}}}
+	}
+	switch q := query.Query.(type) {
+	case *discovery.Query_ConfigQuery:
+		return &discovery.QueryResult{Result: &discovery.QueryResult_ConfigResult{ConfigResult: &discovery.ConfigResult{
+			Orderers: map[string]*discovery.Endpoints{"OrdererMSP": {Endpoint: []*discovery.Endpoint{{Host: "orderer", Port: 7050}}}},
+		}}}
+	case *discovery.Query_PeerQuery:
+		org := "Org1MSP"
+		if q.PeerQuery.Filter != nil {
+			org = q.PeerQuery.Filter.Chaincodes[0].Name
+		}
+		return &discovery.QueryResult{Result: &discovery.QueryResult_Members{Members: &discovery.PeerMembershipResult{
+			PeersByOrg: map[string]*discovery.Peers{org: {}},
+		}}}
+	case *discovery.Query_LocalPeers:
+		return &discovery.QueryResult{Result: &discovery.QueryResult_Members{Members: &discovery.PeerMembershipResult{
+			PeersByOrg: map[string]*discovery.Peers{"local": {}},
+		}}}
+	case *discovery.Query_CcQuery:
+		var descriptors []*discovery.EndorsementDescriptor
+		for _, cc := range q.CcQuery.Interests[0].Chaincodes {
+			if cc.Name == "missing" {
+				return &discovery.QueryResult{Result: &discovery.QueryResult_Error{Error: &discovery.Error{Content: "failed constructing descriptor"}}}
+			}
+			descriptors = append(descriptors, &discovery.EndorsementDescriptor{Chaincode: fmt.Sprint(cc.Name, cc.CollectionNames)})
+		}
+		return &discovery.QueryResult{Result: &discovery.QueryResult_CcQueryRes{CcQueryRes: &discovery.ChaincodeQueryResult{Content: descriptors}}}
+	default:
+		return &discovery.QueryResult{Result: &discovery.QueryResult_Error{Error: &discovery.Error{Content: "unknown or missing request type"}}}
+	}
+}
+
+// testPeer is the peer of the tests, whose channels have a config block
+type testPeer struct {
+	peer.Operations
+	configBlock *common.Block
+}
+
+func (p *testPeer) GetCurrConfigBlock(cid string) *common.Block {
+	if p.GetLedger(cid) == nil {
+		return nil
+	}
+	return p.configBlock
+}
+
+type testEnv struct {
+	server      *Server
+	aclProvider *aclProvider
+	dir         string
+}
+
+func newTestEnv(t *testing.T, o Options) *testEnv {
+	dir, err := ioutil.TempDir("", "rest")
+	require.NoError(t, err)
+	viper.Set("peer.fileSystemPath", dir)
+	peer.MockInitialize()
+	require.NoError(t, peer.MockCreateChain("testchannel"))
+	configBlock, err := configtxtest.MakeGenesisBlock("testchannel")
+	require.NoError(t, err)
+
+	env := &testEnv{
+		aclProvider: &aclProvider{members: map[string]string{"testchannel": "Org1MSP"}},
+		dir:         dir,
+	}
+	o.ListenAddress = "127.0.0.1:0"
+	o.ACLProvider = env.aclProvider
+	o.PolicyChecker = policyChecker{mgmt.Members: "Org1MSP", mgmt.Admins: "Org1AdminMSP"}
+	o.Peer = &testPeer{Operations: peer.Default, configBlock: configBlock}
+	o.InstalledChaincodes = func() (*pb.ChaincodeQueryResponse, error) {
+		return &pb.ChaincodeQueryResponse{Chaincodes: []*pb.ChaincodeInfo{{Name: "installedcc", Version: "1.0"}}}, nil
+	}
+	env.server = NewServer(o)
+	require.NoError(t, env.server.Start())
+	return env
+}
+
+func (env *testEnv) cleanup() {
+	env.server.Stop()
+	ledgermgmt.CleanupTestEnv()
+	os.RemoveAll(env.dir)
+}
+
+// get sends a request signed by the given client and returns the status code
+// and the body of the response
+func (env *testEnv) get(t *testing.T, c *client, path string) (int, []byte) {
+	req, err := http.NewRequest(http.MethodGet, "http://"+env.server.Addr()+path, nil)
+	require.NoError(t, err)
+	if c != nil {
+		c.sign(t, req, time.Now(), true)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, body
+}
+
+// getMessage sends a request signed by the given client and unmarshals the
+// message of the response
+func (env *testEnv) getMessage(t *testing.T, c *client, path string, msg proto.Message) {
+	status, body := env.get(t, c, path)
+	require.Equal(t, http.StatusOK, status, string(body))
+	require.NoError(t, protolator.DeepUnmarshalJSON(bytes.NewReader(body), msg))
+}
+
+// getError sends a request signed by the given client and returns the status
+// code and the error of the response
+func (env *testEnv) getError(t *testing.T, c *client, path string) (int, string) {
+	status, body := env.get(t, c, path)
+	errResp := &errorResponse{}
+	require.NoError(t, json.Unmarshal(body, errResp), string(body))
+	return status, errResp.Error
+}
+
+// commitBlock commits a block with a transaction instantiating a chaincode
+func commitBlock(t *testing.T, txID string) *common.Block {
+	l := peer.GetLedger("testchannel")
+	sim, err := l.NewTxSimulator(txID)
+	require.NoError(t, err)
+	ccdata := &ccprovider.ChaincodeData{Name: "mycc", Version: "1.0", Escc: "escc", Vscc: "vscc", Annotations: map[string]string{"team": "a"}}
+	require.NoError(t, sim.SetState(lsccNamespace, "mycc", utils.MarshalOrPanic(ccdata)))
+	require.NoError(t, sim.SetState(lsccNamespace, "mycc~collection", []byte("collections")))
+	sim.Done()
+	results, err := sim.GetTxSimulationResults()
+	require.NoError(t, err)
+	pubResults, err := results.GetPubSimulationBytes()
+	require.NoError(t, err)
+
+	info, err := l.GetBlockchainInfo()
+	require.NoError(t, err)
+	block := testutil.ConstructBlockWithTxid(t, info.Height, info.CurrentBlockHash, [][]byte{pubResults}, []string{txID}, true)
+	require.NoError(t, l.CommitWithPvtData(&ledger.BlockAndPvtData{Block: block}))
+	return block
+}
+
+func TestChannelQueries(t *testing.T) {
+	env := newTestEnv(t, Options{})
+	defer env.cleanup()
+	member := newClient(t, "Org1MSP")
+	other := newClient(t, "Org2MSP")
+	block := commitBlock(t, "tx1")
+
+	info := &common.BlockchainInfo{}
+	env.getMessage(t, member, "/channels/testchannel", info)
+	assert.Equal(t, uint64(2), info.Height)
+
+	got := &common.Block{}
+	env.getMessage(t, member, "/channels/testchannel/blocks/1", got)
+	assert.True(t, proto.Equal(block.Header, got.Header))
+	env.getMessage(t, member, "/channels/testchannel/blocks/newest", got)
+	assert.Equal(t, uint64(1), got.Header.Number)
+	env.getMessage(t, member, "/channels/testchannel/blocks/0", got)
+	assert.Equal(t, uint64(0), got.Header.Number)
+
+	tx := &pb.ProcessedTransaction{}
+	env.getMessage(t, member, "/channels/testchannel/transactions/tx1", tx)
+	assert.Equal(t, int32(pb.TxValidationCode_VALID), tx.ValidationCode)
+
+	config := &common.Block{}
+	env.getMessage(t, member, "/channels/testchannel/config", config)
+	assert.Equal(t, uint64(0), config.Header.Number)
+
+	chaincodes := &pb.ChaincodeQueryResponse{}
+	env.getMessage(t, member, "/channels/testchannel/chaincodes", chaincodes)
+	require.Len(t, chaincodes.Chaincodes, 1)
+	assert.True(t, proto.Equal(&pb.ChaincodeInfo{Name: "mycc", Version: "1.0", Escc: "escc", Vscc: "vscc", Annotations: map[string]string{"team": "a"}}, chaincodes.Chaincodes[0]))
+
+	assert.Equal(t, []string{
+		resources.Qscc_GetChainInfo,
+		resources.Qscc_GetBlockByNumber,
+		resources.Qscc_GetBlockByNumber,
+		resources.Qscc_GetBlockByNumber,
+		resources.Qscc_GetTransactionByID,
+		resources.Cscc_GetConfigBlock,
+		resources.Lscc_GetInstantiatedChaincodes,
+	}, env.aclProvider.checked)
+
+	tests := []struct {
+		path           string
+		client         *client
+		expectedStatus int
+		expectedError  string
+	}{
+		{path: "/channels/testchannel", client: other, expectedStatus: http.StatusForbidden, expectedError: "access denied: Org2MSP is not a member of channel testchannel"},
+		{path: "/channels/testchannel/blocks/1", client: other, expectedStatus: http.StatusForbidden, expectedError: "access denied: Org2MSP is not a member of channel testchannel"},
+		{path: "/channels/testchannel/transactions/tx1", client: other, expectedStatus: http.StatusForbidden, expectedError: "access denied: Org2MSP is not a member of channel testchannel"},
+		{path: "/channels/testchannel/config", client: other, expectedStatus: http.StatusForbidden, expectedError: "access denied: Org2MSP is not a member of channel testchannel"},
+		{path: "/channels/testchannel/chaincodes", client: other, expectedStatus: http.StatusForbidden, expectedError: "access denied: Org2MSP is not a member of channel testchannel"},
+		{path: "/channels/testchannel", expectedStatus: http.StatusUnauthorized, expectedError: "missing Fabric-Mspid header"},
+		{path: "/channels/missing", client: member, expectedStatus: http.StatusNotFound, expectedError: "channel missing not found"},
+		{path: "/channels/missing/config", client: member, expectedStatus: http.StatusNotFound, expectedError: "channel missing not found"},
+		{path: "/channels/testchannel/blocks/2", client: member, expectedStatus: http.StatusNotFound, expectedError: "block 2 not found in channel testchannel"},
+		{path: "/channels/testchannel/blocks/last", client: member, expectedStatus: http.StatusBadRequest, expectedError: "invalid block number last, it must be a number or newest"},
+		{path: "/channels/testchannel/transactions/tx2", client: member, expectedStatus: http.StatusNotFound, expectedError: "transaction tx2 not found in channel testchannel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			status, err := env.getError(t, tt.client, tt.path)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestPeerQueries(t *testing.T) {
+	env := newTestEnv(t, Options{})
+	defer env.cleanup()
+
+	channels := &pb.ChannelQueryResponse{}
+	env.getMessage(t, newClient(t, "Org1MSP"), "/channels", channels)
+	require.Len(t, channels.Channels, 1)
+	assert.Equal(t, "testchannel", channels.Channels[0].ChannelId)
+
+	status, err := env.getError(t, newClient(t, "Org2MSP"), "/channels")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "access denied: Org2MSP does not satisfy the Members policy", err)
+
+	chaincodes := &pb.ChaincodeQueryResponse{}
+	env.getMessage(t, newClient(t, "Org1AdminMSP"), "/chaincodes", chaincodes)
+	require.Len(t, chaincodes.Chaincodes, 1)
+	assert.Equal(t, "installedcc", chaincodes.Chaincodes[0].Name)
+
+	status, err = env.getError(t, newClient(t, "Org1MSP"), "/chaincodes")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "access denied: Org1MSP does not satisfy the Admins policy", err)
+}
+
+func TestDiscoveryQueries(t *testing.T) {
+	env := newTestEnv(t, Options{Discovery: discoveryService{"testchannel": "Org1MSP", "": "Org1AdminMSP"}})
+	defer env.cleanup()
+	member := newClient(t, "Org1MSP")
+
+	config := &discovery.ConfigResult{}
+	env.getMessage(t, member, "/channels/testchannel/discovery/config", config)
+	assert.Equal(t, "orderer", config.Orderers["OrdererMSP"].Endpoint[0].Host)
+
+	peers := &discovery.PeerMembershipResult{}
+	env.getMessage(t, member, "/channels/testchannel/discovery/peers", peers)
+	assert.Contains(t, peers.PeersByOrg, "Org1MSP")
+	peers = &discovery.PeerMembershipResult{}
+	env.getMessage(t, member, "/channels/testchannel/discovery/peers?chaincode=mycc", peers)
+	assert.Contains(t, peers.PeersByOrg, "mycc")
+
+	endorsers := &discovery.ChaincodeQueryResult{}
+	env.getMessage(t, member, "/channels/testchannel/discovery/endorsers?chaincode=mycc&chaincode=othercc:coll1,coll2", endorsers)
+	require.Len(t, endorsers.Content, 2)
+	assert.Equal(t, "mycc[]", endorsers.Content[0].Chaincode)
+	assert.Equal(t, "othercc[coll1 coll2]", endorsers.Content[1].Chaincode)
+
+	peers = &discovery.PeerMembershipResult{}
+	env.getMessage(t, newClient(t, "Org1AdminMSP"), "/discovery/peers", peers)
+	assert.Contains(t, peers.PeersByOrg, "local")
+
+	tests := []struct {
+		path           string
+		client         *client
+		expectedStatus int
+		expectedError  string
+	}{
+		{path: "/channels/testchannel/discovery/config", client: newClient(t, "Org2MSP"), expectedStatus: http.StatusForbidden, expectedError: "access denied"},
+		{path: "/discovery/peers", client: member, expectedStatus: http.StatusForbidden, expectedError: "access denied"},
+		{path: "/channels/testchannel/discovery/endorsers", client: member, expectedStatus: http.StatusBadRequest, expectedError: "missing chaincode parameter"},
+		{path: "/channels/testchannel/discovery/endorsers?chaincode=:coll1", client: member, expectedStatus: http.StatusBadRequest, expectedError: "invalid chaincode :coll1, it must be a chaincode name optionally followed by a colon and collection names"},
+		{path: "/channels/testchannel/discovery/endorsers?chaincode=missing", client: member, expectedStatus: http.StatusInternalServerError, expectedError: "failed constructing descriptor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			status, err := env.getError(t, tt.client, tt.path)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestDiscoveryDisabled(t *testing.T) {
+	env := newTestEnv(t, Options{})
+	defer env.cleanup()
+
+	status, err := env.getError(t, newClient(t, "Org1MSP"), "/channels/testchannel/discovery/peers")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "the discovery service is disabled", err)
+}
+
+func TestCORS(t *testing.T) {
+	env := newTestEnv(t, Options{AllowedOrigins: []string{"https://dashboard.example.com"}})
+	defer env.cleanup()
+
+	req, err := http.NewRequest(http.MethodOptions, "http://"+env.server.Addr()+"/channels", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	req.Header.Set("Access-Control-Request-Headers", "Fabric-Mspid, Fabric-Certificate, Fabric-Timestamp, Fabric-Signature")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "https://dashboard.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+
+	req.Header.Set("Origin", "https://other.example.com")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
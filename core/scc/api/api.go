@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// FactoryName is the name of the function exported by the system chaincode
+// plugins, whose signature is func NewPlugin() api.Plugin. The plugins exporting
+// instead a New function returning a shim.Chaincode are still loaded, their name
+// and their invocation flags being configured in chaincode.systemPlugins only.
+const FactoryName = "NewPlugin"
+
+// Plugin is a system chaincode shipped as a Go plugin. The peer runs the
+// chaincode of a plugin in isolation: a panic of the chaincode fails the
+// invocation rather than crashing the peer, and the invocations are checked
+// against the ACLs declared by the plugin. A panic in a goroutine started by
+// the chaincode cannot be recovered, and still crashes the peer.
+type Plugin interface {
+	// Descriptor describes the system chaincode
+	Descriptor() Descriptor
+
+	// Chaincode returns the implementation of the system chaincode
+	Chaincode() shim.Chaincode
+}
+
+// Descriptor describes a system chaincode shipped as a plugin
+type Descriptor struct {
+	// Name is the name of the system chaincode, under which it is whitelisted
+	// in chaincode.system and invoked. It must not be the name of another
+	// system chaincode
+	Name string
+
+	// InitArgs are the arguments of the Init of the chaincode on each channel
+	InitArgs [][]byte
+
+	// InvokableExternal is whether the system chaincode can be invoked through
+	// a proposal sent to the peer
+	InvokableExternal bool
+
+	// InvokableCC2CC is whether the system chaincode can be invoked by other
+	// chaincodes
+	InvokableCC2CC bool
+
+	// ACLs map the functions of the system chaincode, which are the first
+	// arguments of its invocations, to the policies that the creators of the
+	// proposals invoking them must satisfy by default, such as
+	// /Channel/Application/Writers. The ACLs of the channel config override the
+	// policy of a function with the resource <name>/<function>. Once a plugin
+	// declares ACLs, the invocations of the functions without a policy are
+	// refused
+	ACLs map[string]string
+}
+
+// New returns the Plugin of the given system chaincode described by the given
+// descriptor
+func New(descriptor Descriptor, chaincode shim.Chaincode) Plugin {
+	return &plugin{descriptor: descriptor, chaincode: chaincode}
+}
+
+type plugin struct {
+	descriptor Descriptor
+	chaincode  shim.Chaincode
+}
+
+func (p *plugin) Descriptor() Descriptor    { return p.descriptor }
+func (p *plugin) Chaincode() shim.Chaincode { return p.chaincode }
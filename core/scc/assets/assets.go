@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package assets provides system chaincodes implementing standard interfaces
+// for fungible assets, in the style of ERC-20 tokens, and for non-fungible
+// assets, in the style of ERC-721 tokens, over the state of the channels,
+// so that the members of a channel share compatible asset contracts.
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("assets")
+
+// OperationType is the type of an operation on assets
+type OperationType string
+
+const (
+	// OperationMint creates assets owned by the To account
+	OperationMint OperationType = "Mint"
+	// OperationBurn destroys assets of the From account
+	OperationBurn OperationType = "Burn"
+	// OperationTransfer moves assets from the From account to the To account
+	OperationTransfer OperationType = "Transfer"
+	// OperationApprove allows the To account to transfer assets of the From account
+	OperationApprove OperationType = "Approve"
+	// OperationApproveAll allows the To account to transfer all the non-fungible
+	// assets of the From account if Approved is true, and revokes it otherwise
+	OperationApproveAll OperationType = "ApproveAll"
+)
+
+// Operation is an operation on assets performed by the client with the Caller
+// account, which is also the payload of the event it emits
+type Operation struct {
+	Type OperationType `json:"type"`
+	// Asset is the symbol of the fungible token or the ID of the collection
+	// of non-fungible tokens
+	Asset  string `json:"asset"`
+	Caller string `json:"caller"`
+	From   string `json:"from,omitempty"`
+	To     string `json:"to,omitempty"`
+	// Amount is the amount of the fungible token
+	Amount *big.Int `json:"amount,omitempty"`
+	// TokenID is the ID of the non-fungible token
+	TokenID  string `json:"tokenId,omitempty"`
+	Approved bool   `json:"approved,omitempty"`
+	// LockID is the ID of the hash time lock of the operation
+	LockID string `json:"lockId,omitempty"`
+}
+
+// Hook extends the asset system chaincodes, for instance to enforce the
+// compliance rules of a consortium or to record the operations in a registry
+type Hook interface {
+	// Before is called before the operation modifies the state, which is
+	// aborted if it returns an error
+	Before(stub shim.ChaincodeStubInterface, op *Operation) error
+	// After is called once the operation has modified the state, which is
+	// aborted if it returns an error
+	After(stub shim.ChaincodeStubInterface, op *Operation) error
+}
+
+// AccountOf returns the account of the client with the given serialized
+// identity, which is made of its MSP ID and of the SHA-256 hash of its
+// certificate in hexadecimal
+func AccountOf(creator []byte) (string, error) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, sID); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal the identity of the client")
+	}
+	if sID.Mspid == "" || len(sID.IdBytes) == 0 {
+		return "", errors.New("the identity of the client has no MSP ID or no certificate")
+	}
+	hash := sha256.Sum256(sID.IdBytes)
+	return sID.Mspid + ":" + hex.EncodeToString(hash[:]), nil
+}
+
+func clientAccount(stub shim.ChaincodeStubInterface) (string, error) {
+	creator, err := stub.GetCreator()
+	if err != nil {
+		return "", errors.Wrap(err, "could not get the identity of the client")
+	}
+	return AccountOf(creator)
+}
+
+// perform performs the operation with the given function, calling the hooks
+// before and after it, and emits the event of the operation
+func perform(stub shim.ChaincodeStubInterface, hooks []Hook, op *Operation, f func() error) pb.Response {
+	for _, hook := range hooks {
+		if err := hook.Before(stub, op); err != nil {
+			return shim.Error(fmt.Sprintf("%s of %s rejected: %s", op.Type, op.Asset, err))
+		}
+	}
+	if err := f(); err != nil {
+		return shim.Error(fmt.Sprintf("%s of %s failed: %s", op.Type, op.Asset, err))
+	}
+	for _, hook := range hooks {
+		if err := hook.After(stub, op); err != nil {
+			return shim.Error(fmt.Sprintf("%s of %s rejected: %s", op.Type, op.Asset, err))
+		}
+	}
+
+	payload, err := json.Marshal(op)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("could not marshal the %s event: %s", op.Type, err))
+	}
+	if err := stub.SetEvent(string(op.Type), payload); err != nil {
+		return shim.Error(fmt.Sprintf("could not set the %s event: %s", op.Type, err))
+	}
+	logger.Debugf("[%s] %s of %s by %s", stub.GetTxID(), op.Type, op.Asset, op.Caller)
+	return shim.Success(nil)
+}
+
+// parseAmount parses an amount of a fungible token, which is a non negative
+// integer in decimal
+func parseAmount(s string) (*big.Int, error) {
+	amount, ok := new(big.Int).SetString(s, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, errors.Errorf("invalid amount %q, it must be a non negative integer", s)
+	}
+	return amount, nil
+}
+
+func getJSON(stub shim.ChaincodeStubInterface, key string, v interface{}) (bool, error) {
+	value, err := stub.GetState(key)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get the state of %q", key)
+	}
+	if value == nil {
+		return false, nil
+	}
+	if err := json.Unmarshal(value, v); err != nil {
+		return false, errors.Wrapf(err, "could not unmarshal the state of %q", key)
+	}
+	return true, nil
+}
+
+func putJSON(stub shim.ChaincodeStubInterface, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "could not marshal the state of %q", key)
+	}
+	return stub.PutState(key, value)
+}
+
+func compositeKey(stub shim.ChaincodeStubInterface, objectType string, attributes ...string) (string, error) {
+	key, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid %s key", objectType)
+	}
+	return key, nil
+}
+
+// checkArgs checks that the function was invoked with the given number of
+// arguments, named after their meaning
+func checkArgs(function string, args []string, names ...string) error {
+	if len(args) != len(names) {
+		return errors.Errorf("%s expects %d arguments (%v), got %d", function, len(names), names, len(args))
+	}
+	for i, arg := range args {
+		if arg == "" {
+			return errors.Errorf("%s expects a non empty %s", function, names[i])
+		}
+	}
+	return nil
+}
+
+func success(v interface{}) pb.Response {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(payload)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// client invokes the asset system chaincodes with its identity
+type client struct {
+	creator []byte
+	account string
+}
+
+func newClient(t *testing.T, mspID, cert string) *client {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: []byte(cert)})
+	require.NoError(t, err)
+	account, err := AccountOf(creator)
+	require.NoError(t, err)
+	return &client{creator: creator, account: account}
+}
+
+func (c *client) invoke(stub *shim.MockStub, args ...string) pb.Response {
+	return c.invokeTx(stub, "txid", args...)
+}
+
+func (c *client) invokeTx(stub *shim.MockStub, txID string, args ...string) pb.Response {
+	stub.Creator = c.creator
+	var byteArgs [][]byte
+	for _, arg := range args {
+		byteArgs = append(byteArgs, []byte(arg))
+	}
+	return stub.MockInvoke(txID, byteArgs)
+}
+
+// lastEvent returns the last event set by the system chaincode
+func lastEvent(t *testing.T, stub *shim.MockStub) (string, *Operation) {
+	var event *pb.ChaincodeEvent
+	for len(stub.ChaincodeEventsChannel) > 0 {
+		event = <-stub.ChaincodeEventsChannel
+	}
+	require.NotNil(t, event)
+	op := &Operation{}
+	require.NoError(t, json.Unmarshal(event.Payload, op))
+	return event.EventName, op
+}
+
+type recordingHook struct {
+	before, after []OperationType
+	err           error
+}
+
+func (h *recordingHook) Before(stub shim.ChaincodeStubInterface, op *Operation) error {
+	h.before = append(h.before, op.Type)
+	return h.err
+}
+
+func (h *recordingHook) After(stub shim.ChaincodeStubInterface, op *Operation) error {
+	h.after = append(h.after, op.Type)
+	return nil
+}
+
+func TestAccountOf(t *testing.T) {
+	c := newClient(t, "Org1MSP", "cert")
+	hash := sha256.Sum256([]byte("cert"))
+	assert.Equal(t, "Org1MSP:"+hex.EncodeToString(hash[:]), c.account)
+	assert.NotEqual(t, c.account, newClient(t, "Org1MSP", "other cert").account)
+	assert.NotEqual(t, c.account, newClient(t, "Org2MSP", "cert").account)
+
+	_, err := AccountOf([]byte("garbage"))
+	assert.Contains(t, err.Error(), "could not unmarshal the identity of the client")
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP"})
+	require.NoError(t, err)
+	_, err = AccountOf(creator)
+	assert.EqualError(t, err, "the identity of the client has no MSP ID or no certificate")
+}
+
+func TestHooks(t *testing.T) {
+	hook := &recordingHook{}
+	stub := shim.NewMockStub("ftscc", NewFungibleSCC(hook))
+	minter := newClient(t, "Org1MSP", "minter")
+	require.EqualValues(t, shim.OK, minter.invoke(stub, CreateToken, "USD", "US Dollar", "2").Status)
+
+	resp := minter.invoke(stub, Mint, "USD", minter.account, "100")
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, []OperationType{OperationMint}, hook.before)
+	assert.Equal(t, []OperationType{OperationMint}, hook.after)
+
+	hook.err = errors.New("sanctioned account")
+	resp = minter.invoke(stub, Transfer, "USD", "Org2MSP:account", "10")
+	assert.EqualValues(t, shim.ERROR, resp.Status)
+	assert.Equal(t, "Transfer of USD rejected: sanctioned account", resp.Message)
+	assert.Equal(t, []OperationType{OperationMint, OperationTransfer}, hook.before)
+	assert.Equal(t, []OperationType{OperationMint}, hook.after)
+	assert.Equal(t, "100", string(minter.invoke(stub, BalanceOf, "USD", minter.account).Payload))
+}
@@ -0,0 +1,433 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// These are the functions of the fungible asset system chaincode, from the
+// first argument of the invocation
+const (
+	ClientAccount = "ClientAccount"
+
+	CreateToken  = "CreateToken"
+	GetToken     = "GetToken"
+	BalanceOf    = "BalanceOf"
+	Allowance    = "Allowance"
+	Transfer     = "Transfer"
+	TransferFrom = "TransferFrom"
+	Approve      = "Approve"
+	Mint         = "Mint"
+	Burn         = "Burn"
+)
+
+const (
+	tokenObjectType     = "token"
+	balanceObjectType   = "balance"
+	allowanceObjectType = "allowance"
+)
+
+// Token is a fungible token, whose amounts are integers in its smallest unit
+type Token struct {
+	Symbol   string `json:"symbol"`
+	Name     string `json:"name"`
+	Decimals uint8  `json:"decimals"`
+	// Minter is the account of the client which created the token and which
+	// is the only one allowed to mint it
+	Minter      string `json:"minter"`
+	TotalSupply string `json:"totalSupply"`
+}
+
+// FungibleSCC is the system chaincode of the fungible tokens of a channel,
+// with the interface of ERC-20 tokens:
+//   - CreateToken creates the token with symbol args[1], name args[2] and
+//     args[3] decimals, minted by the client
+//   - GetToken returns the token with symbol args[1]
+//   - ClientAccount returns the account of the client
+//   - BalanceOf returns the balance of token args[1] of account args[2]
+//   - Allowance returns the amount of token args[1] of account args[2] that
+//     account args[3] is allowed to transfer
+//   - Transfer transfers amount args[3] of token args[1] from the account of
+//     the client to account args[2]
+//   - TransferFrom transfers amount args[4] of token args[1] from account
+//     args[2] to account args[3] within the allowance of the client
+//   - Approve allows account args[2] to transfer amount args[3] of token args[1]
+//     of the account of the client
+//   - Mint mints amount args[3] of token args[1] to account args[2]
+//   - Burn burns amount args[2] of token args[1] of the account of the client
+//   - Lock locks amount args[3] of token args[1] of the account of the client
+//     for account args[2] with the hash lock args[4] until the expiry args[5],
+//     and returns the ID of the lock
+//   - Claim claims the amount of lock args[1] for its recipient with the
+//     preimage args[2] of its hash lock
+//   - Refund refunds the amount of the expired lock args[1] to its owner
+//   - GetLock returns the lock args[1]
+//
+// The amounts are integers in decimal.
+type FungibleSCC struct {
+	hooks []Hook
+}
+
+// NewFungibleSCC returns the fungible asset system chaincode, which calls the
+// given hooks around each of its operations
+func NewFungibleSCC(hooks ...Hook) *FungibleSCC {
+	return &FungibleSCC{hooks: hooks}
+}
+
+func (s *FungibleSCC) Name() string              { return "ftscc" }
+func (s *FungibleSCC) Path() string              { return "github.com/hyperledger/fabric/core/scc/assets" }
+func (s *FungibleSCC) InitArgs() [][]byte        { return nil }
+func (s *FungibleSCC) Chaincode() shim.Chaincode { return s }
+func (s *FungibleSCC) InvokableExternal() bool   { return true }
+func (s *FungibleSCC) InvokableCC2CC() bool      { return true }
+func (s *FungibleSCC) Enabled() bool             { return true }
+
+// RequiresEndorsementPolicy returns true as the writes to the state of the
+// assets must satisfy the endorsement policy defined by the channel config
+func (s *FungibleSCC) RequiresEndorsementPolicy() bool { return true }
+
+// Init is called once per channel when the system chaincode is deployed
+func (s *FungibleSCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke invokes the function named after the first argument
+func (s *FungibleSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	caller, err := clientAccount(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	switch function {
+	case ClientAccount:
+		return shim.Success([]byte(caller))
+	case CreateToken:
+		return s.createToken(stub, caller, args)
+	case GetToken:
+		if err := checkArgs(function, args, "symbol"); err != nil {
+			return shim.Error(err.Error())
+		}
+		token, err := s.token(stub, args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return success(token)
+	case BalanceOf:
+		if err := checkArgs(function, args, "symbol", "account"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.query(stub, args[0], balanceObjectType, args[1])
+	case Allowance:
+		if err := checkArgs(function, args, "symbol", "owner", "spender"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.query(stub, args[0], allowanceObjectType, args[1], args[2])
+	case Transfer:
+		if err := checkArgs(function, args, "symbol", "to", "amount"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.transfer(stub, &Operation{Type: OperationTransfer, Asset: args[0], Caller: caller, From: caller, To: args[1]}, args[2])
+	case TransferFrom:
+		if err := checkArgs(function, args, "symbol", "from", "to", "amount"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.transfer(stub, &Operation{Type: OperationTransfer, Asset: args[0], Caller: caller, From: args[1], To: args[2]}, args[3])
+	case Approve:
+		if err := checkArgs(function, args, "symbol", "spender", "amount"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.approve(stub, &Operation{Type: OperationApprove, Asset: args[0], Caller: caller, From: caller, To: args[1]}, args[2])
+	case Mint:
+		if err := checkArgs(function, args, "symbol", "to", "amount"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.mint(stub, &Operation{Type: OperationMint, Asset: args[0], Caller: caller, To: args[1]}, args[2])
+	case Burn:
+		if err := checkArgs(function, args, "symbol", "amount"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.burn(stub, &Operation{Type: OperationBurn, Asset: args[0], Caller: caller, From: caller}, args[1])
+	case Lock:
+		if err := checkArgs(function, args, "symbol", "recipient", "amount", "hash lock", "expiry"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.lock(stub, caller, args)
+	case Claim, Refund:
+		return s.settle(stub, function, caller, args)
+	case GetLock:
+		return getLock(stub, args)
+	}
+
+	return shim.Error(fmt.Sprintf("Requested function %s not found.", function))
+}
+
+func (s *FungibleSCC) createToken(stub shim.ChaincodeStubInterface, caller string, args []string) pb.Response {
+	if err := checkArgs(CreateToken, args, "symbol", "name", "decimals"); err != nil {
+		return shim.Error(err.Error())
+	}
+	decimals, err := strconv.ParseUint(args[2], 10, 8)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("invalid decimals %q, it must be an integer between 0 and 255", args[2]))
+	}
+	key, err := compositeKey(stub, tokenObjectType, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	exists, err := getJSON(stub, key, &Token{})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if exists {
+		return shim.Error(fmt.Sprintf("token %s already exists", args[0]))
+	}
+
+	token := &Token{
+		Symbol:      args[0],
+		Name:        args[1],
+		Decimals:    uint8(decimals),
+		Minter:      caller,
+		TotalSupply: "0",
+	}
+	if err := putJSON(stub, key, token); err != nil {
+		return shim.Error(err.Error())
+	}
+	return success(token)
+}
+
+func (s *FungibleSCC) token(stub shim.ChaincodeStubInterface, symbol string) (*Token, error) {
+	key, err := compositeKey(stub, tokenObjectType, symbol)
+	if err != nil {
+		return nil, err
+	}
+	token := &Token{}
+	exists, err := getJSON(stub, key, token)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("token %s does not exist", symbol)
+	}
+	return token, nil
+}
+
+// query returns the amount of the token stored under the given key
+func (s *FungibleSCC) query(stub shim.ChaincodeStubInterface, symbol, objectType string, attributes ...string) pb.Response {
+	if _, err := s.token(stub, symbol); err != nil {
+		return shim.Error(err.Error())
+	}
+	amount, _, err := getAmount(stub, objectType, append([]string{symbol}, attributes...)...)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success([]byte(amount.String()))
+}
+
+func (s *FungibleSCC) transfer(stub shim.ChaincodeStubInterface, op *Operation, amount string) pb.Response {
+	var err error
+	if op.Amount, err = parseAmount(amount); err != nil {
+		return shim.Error(err.Error())
+	}
+	if _, err := s.token(stub, op.Asset); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		if op.Caller != op.From {
+			allowance, key, err := getAmount(stub, allowanceObjectType, op.Asset, op.From, op.Caller)
+			if err != nil {
+				return err
+			}
+			if allowance.Cmp(op.Amount) < 0 {
+				return errors.Errorf("the allowance of %s is %s", op.Caller, allowance)
+			}
+			if err := putAmount(stub, key, allowance.Sub(allowance, op.Amount)); err != nil {
+				return err
+			}
+		}
+		if op.From == op.To {
+			// the state read by a transaction does not reflect its writes,
+			// so the balance is only checked
+			return addBalance(stub, op.Asset, op.From, new(big.Int))
+		}
+		return moveBalance(stub, op)
+	})
+}
+
+func (s *FungibleSCC) approve(stub shim.ChaincodeStubInterface, op *Operation, amount string) pb.Response {
+	var err error
+	if op.Amount, err = parseAmount(amount); err != nil {
+		return shim.Error(err.Error())
+	}
+	if _, err := s.token(stub, op.Asset); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		key, err := compositeKey(stub, allowanceObjectType, op.Asset, op.From, op.To)
+		if err != nil {
+			return err
+		}
+		return putAmount(stub, key, op.Amount)
+	})
+}
+
+func (s *FungibleSCC) mint(stub shim.ChaincodeStubInterface, op *Operation, amount string) pb.Response {
+	var err error
+	if op.Amount, err = parseAmount(amount); err != nil {
+		return shim.Error(err.Error())
+	}
+	token, err := s.token(stub, op.Asset)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if token.Minter != op.Caller {
+		return shim.Error(fmt.Sprintf("%s is not the minter of token %s", op.Caller, op.Asset))
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		if err := s.addSupply(stub, token, op.Amount); err != nil {
+			return err
+		}
+		return addBalance(stub, op.Asset, op.To, op.Amount)
+	})
+}
+
+func (s *FungibleSCC) burn(stub shim.ChaincodeStubInterface, op *Operation, amount string) pb.Response {
+	var err error
+	if op.Amount, err = parseAmount(amount); err != nil {
+		return shim.Error(err.Error())
+	}
+	token, err := s.token(stub, op.Asset)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		if err := addBalance(stub, op.Asset, op.From, new(big.Int).Neg(op.Amount)); err != nil {
+			return err
+		}
+		return s.addSupply(stub, token, new(big.Int).Neg(op.Amount))
+	})
+}
+
+func (s *FungibleSCC) lock(stub shim.ChaincodeStubInterface, caller string, args []string) pb.Response {
+	op := &Operation{Type: OperationLock, Asset: args[0], Caller: caller, From: caller}
+	var err error
+	if op.Amount, err = parseAmount(args[2]); err != nil {
+		return shim.Error(err.Error())
+	}
+	if _, err := s.token(stub, op.Asset); err != nil {
+		return shim.Error(err.Error())
+	}
+	lock, key, err := newHashTimeLock(stub, op.Asset, caller, args[1], args[3], args[4])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	lock.Amount = op.Amount.String()
+	op.To, op.LockID = EscrowAccount(lock.ID), lock.ID
+
+	return lockResponse(perform(stub, s.hooks, op, func() error {
+		if err := moveBalance(stub, op); err != nil {
+			return err
+		}
+		return putJSON(stub, key, lock)
+	}), lock)
+}
+
+// settle claims or refunds a lock, depending on the function
+func (s *FungibleSCC) settle(stub shim.ChaincodeStubInterface, function, caller string, args []string) pb.Response {
+	opType, preimage, err := claimOrRefund(function, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	op, lock, key, err := lockOperation(stub, opType, caller, args[0], preimage)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if op.Amount, err = parseAmount(lock.Amount); err != nil {
+		return shim.Error(fmt.Sprintf("invalid lock %s: %s", lock.ID, err))
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		if err := moveBalance(stub, op); err != nil {
+			return err
+		}
+		return putJSON(stub, key, lock)
+	})
+}
+
+func (s *FungibleSCC) addSupply(stub shim.ChaincodeStubInterface, token *Token, delta *big.Int) error {
+	supply, err := parseAmount(token.TotalSupply)
+	if err != nil {
+		return errors.WithMessage(err, "invalid total supply")
+	}
+	token.TotalSupply = supply.Add(supply, delta).String()
+	key, err := compositeKey(stub, tokenObjectType, token.Symbol)
+	if err != nil {
+		return err
+	}
+	return putJSON(stub, key, token)
+}
+
+// addBalance adds the given amount, which may be negative, to the balance of
+// the account, which may not become negative
+func addBalance(stub shim.ChaincodeStubInterface, symbol, account string, delta *big.Int) error {
+	balance, key, err := getAmount(stub, balanceObjectType, symbol, account)
+	if err != nil {
+		return err
+	}
+	if balance.Add(balance, delta).Sign() < 0 {
+		return errors.Errorf("the balance of %s is insufficient", account)
+	}
+	return putAmount(stub, key, balance)
+}
+
+// moveBalance moves the amount of the operation from the balance of its From
+// account to the balance of its To account
+func moveBalance(stub shim.ChaincodeStubInterface, op *Operation) error {
+	if err := addBalance(stub, op.Asset, op.From, new(big.Int).Neg(op.Amount)); err != nil {
+		return err
+	}
+	return addBalance(stub, op.Asset, op.To, op.Amount)
+}
+
+// getAmount returns the amount stored under the composite key with the given
+// object type and attributes, which is 0 if none is, along with the key
+func getAmount(stub shim.ChaincodeStubInterface, objectType string, attributes ...string) (*big.Int, string, error) {
+	key, err := compositeKey(stub, objectType, attributes...)
+	if err != nil {
+		return nil, "", err
+	}
+	value, err := stub.GetState(key)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not get the state of %q", key)
+	}
+	if value == nil {
+		return new(big.Int), key, nil
+	}
+	amount, err := parseAmount(string(value))
+	if err != nil {
+		return nil, "", errors.WithMessage(err, fmt.Sprintf("invalid state of %q", key))
+	}
+	return amount, key, nil
+}
+
+// putAmount stores the amount under the key, deleting it if the amount is 0
+func putAmount(stub shim.ChaincodeStubInterface, key string, amount *big.Int) error {
+	if amount.Sign() == 0 {
+		return stub.DelState(key)
+	}
+	return stub.PutState(key, []byte(amount.String()))
+}
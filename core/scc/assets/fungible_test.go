@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFungibleSCC(t *testing.T) {
+	scc := NewFungibleSCC()
+	assert.Equal(t, "ftscc", scc.Name())
+	stub := shim.NewMockStub("ftscc", scc)
+	minter := newClient(t, "Org1MSP", "minter")
+	alice := newClient(t, "Org1MSP", "alice")
+	bob := newClient(t, "Org2MSP", "bob")
+
+	balance := func(c *client) string {
+		resp := minter.invoke(stub, BalanceOf, "USD", c.account)
+		require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+		return string(resp.Payload)
+	}
+	succeeds := func(c *client, args ...string) {
+		resp := c.invoke(stub, args...)
+		require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	}
+	fails := func(expectedErr string, c *client, args ...string) {
+		resp := c.invoke(stub, args...)
+		require.EqualValues(t, shim.ERROR, resp.Status)
+		assert.Equal(t, expectedErr, resp.Message)
+	}
+
+	resp := alice.invoke(stub, ClientAccount)
+	assert.Equal(t, alice.account, string(resp.Payload))
+
+	// the token is created with no supply
+	resp = minter.invoke(stub, CreateToken, "USD", "US Dollar", "2")
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	token := &Token{}
+	require.NoError(t, json.Unmarshal(resp.Payload, token))
+	assert.Equal(t, &Token{Symbol: "USD", Name: "US Dollar", Decimals: 2, Minter: minter.account, TotalSupply: "0"}, token)
+	fails("token USD already exists", alice, CreateToken, "USD", "Dollar", "0")
+	fails(`invalid decimals "256", it must be an integer between 0 and 255`, alice, CreateToken, "EUR", "Euro", "256")
+	fails("token EUR does not exist", alice, BalanceOf, "EUR", alice.account)
+
+	// only the minter mints the token
+	succeeds(minter, Mint, "USD", alice.account, "1000000000000000000000")
+	fails(alice.account+" is not the minter of token USD", alice, Mint, "USD", alice.account, "1")
+	assert.Equal(t, "1000000000000000000000", balance(alice))
+	assert.Equal(t, "0", balance(bob))
+	name, op := lastEvent(t, stub)
+	assert.Equal(t, "Mint", name)
+	amount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	assert.Equal(t, &Operation{Type: OperationMint, Asset: "USD", Caller: minter.account, To: alice.account, Amount: amount}, op)
+
+	// the clients transfer their own balance
+	succeeds(alice, Transfer, "USD", bob.account, "400000000000000000000")
+	assert.Equal(t, "600000000000000000000", balance(alice))
+	assert.Equal(t, "400000000000000000000", balance(bob))
+	fails("Transfer of USD failed: the balance of "+bob.account+" is insufficient", bob, Transfer, "USD", alice.account, "400000000000000000001")
+	fails(`invalid amount "-1", it must be a non negative integer`, bob, Transfer, "USD", alice.account, "-1")
+	fails(`invalid amount "1.5", it must be a non negative integer`, bob, Transfer, "USD", alice.account, "1.5")
+	succeeds(bob, Transfer, "USD", bob.account, "400000000000000000000")
+	assert.Equal(t, "400000000000000000000", balance(bob))
+
+	// the approved clients transfer the balance of others within their allowance
+	succeeds(alice, Approve, "USD", bob.account, "100")
+	resp = bob.invoke(stub, Allowance, "USD", alice.account, bob.account)
+	assert.Equal(t, "100", string(resp.Payload))
+	succeeds(bob, TransferFrom, "USD", alice.account, minter.account, "60")
+	assert.Equal(t, "60", balance(minter))
+	resp = bob.invoke(stub, Allowance, "USD", alice.account, bob.account)
+	assert.Equal(t, "40", string(resp.Payload))
+	fails("Transfer of USD failed: the allowance of "+bob.account+" is 40", bob, TransferFrom, "USD", alice.account, bob.account, "41")
+	fails("Transfer of USD failed: the allowance of "+minter.account+" is 0", minter, TransferFrom, "USD", alice.account, minter.account, "1")
+
+	// the clients burn their own balance, which reduces the supply
+	succeeds(bob, Burn, "USD", "400000000000000000000")
+	assert.Equal(t, "0", balance(bob))
+	fails("Burn of USD failed: the balance of "+bob.account+" is insufficient", bob, Burn, "USD", "1")
+	resp = bob.invoke(stub, GetToken, "USD")
+	require.NoError(t, json.Unmarshal(resp.Payload, token))
+	assert.Equal(t, "600000000000000000000", token.TotalSupply)
+
+	fails("Transfer expects 3 arguments ([symbol to amount]), got 2", alice, Transfer, "USD", bob.account)
+	fails("Transfer expects a non empty to", alice, Transfer, "USD", "", "1")
+	fails("Requested function Swap not found.", alice, "Swap")
+
+	stub.Creator = nil
+	resp = stub.MockInvoke("txid", [][]byte{[]byte(ClientAccount)})
+	assert.EqualValues(t, shim.ERROR, resp.Status)
+}
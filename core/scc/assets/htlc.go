@@ -0,0 +1,226 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// These are the functions of the hash time locks of both asset system
+// chaincodes, from the first argument of the invocation
+const (
+	Lock    = "Lock"
+	Claim   = "Claim"
+	Refund  = "Refund"
+	GetLock = "GetLock"
+)
+
+const (
+	// OperationLock moves assets of the From account to the escrow account
+	// of a hash time lock
+	OperationLock OperationType = "Lock"
+	// OperationClaim moves the assets of a hash time lock to its recipient,
+	// given the preimage of its hash
+	OperationClaim OperationType = "Claim"
+	// OperationRefund moves the assets of an expired hash time lock back to
+	// its owner
+	OperationRefund OperationType = "Refund"
+)
+
+const lockObjectType = "htlc"
+
+// LockState is the state of a hash time lock
+type LockState string
+
+const (
+	LockStateLocked   LockState = "Locked"
+	LockStateClaimed  LockState = "Claimed"
+	LockStateRefunded LockState = "Refunded"
+)
+
+// HashTimeLock locks assets until either the recipient claims them with the
+// preimage of the hash lock before the expiry, or the owner is refunded after
+// it. Locking assets on two networks with the same hash lock exchanges them
+// atomically: claiming the assets of one network reveals the preimage with
+// which the assets of the other network are claimed, and the lock claimed
+// first must expire last so that its owner has the time to claim the other.
+type HashTimeLock struct {
+	// ID is the ID of the transaction which created the lock
+	ID    string `json:"id"`
+	Asset string `json:"asset"`
+	// Amount is the amount of the fungible token locked
+	Amount string `json:"amount,omitempty"`
+	// TokenID is the ID of the non-fungible token locked
+	TokenID   string `json:"tokenId,omitempty"`
+	Owner     string `json:"owner"`
+	Recipient string `json:"recipient"`
+	// HashLock is the SHA-256 hash of the preimage in hexadecimal
+	HashLock string    `json:"hashLock"`
+	Expiry   time.Time `json:"expiry"`
+	State    LockState `json:"state"`
+	// Preimage is the preimage in hexadecimal, revealed by the claim
+	Preimage string `json:"preimage,omitempty"`
+}
+
+// EscrowAccount returns the account holding the assets of the hash time lock
+// with the given ID, which no client owns
+func EscrowAccount(lockID string) string {
+	return "htlc:" + lockID
+}
+
+// newHashTimeLock returns a new hash time lock created by the transaction,
+// along with its key, from the arguments of the Lock function
+func newHashTimeLock(stub shim.ChaincodeStubInterface, asset, owner, recipient, hashLock, expiry string) (*HashTimeLock, string, error) {
+	if hash, err := hex.DecodeString(hashLock); err != nil || len(hash) != sha256.Size {
+		return nil, "", errors.Errorf("invalid hash lock %q, it must be a SHA-256 hash in hexadecimal", hashLock)
+	}
+	expiryTime, err := time.Parse(time.RFC3339, expiry)
+	if err != nil {
+		return nil, "", errors.Errorf("invalid expiry %q, it must be a time in RFC 3339 format", expiry)
+	}
+	now, err := stub.GetTxTime()
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not get the time of the transaction")
+	}
+	if !expiryTime.After(now) {
+		return nil, "", errors.Errorf("the expiry %s is not after the time of the transaction", expiry)
+	}
+
+	lock := &HashTimeLock{
+		ID:        stub.GetTxID(),
+		Asset:     asset,
+		Owner:     owner,
+		Recipient: recipient,
+		HashLock:  hashLock,
+		Expiry:    expiryTime.UTC(),
+		State:     LockStateLocked,
+	}
+	key, err := compositeKey(stub, lockObjectType, lock.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	exists, err := getJSON(stub, key, &HashTimeLock{})
+	if err != nil {
+		return nil, "", err
+	}
+	if exists {
+		return nil, "", errors.Errorf("transaction %s already created a lock", lock.ID)
+	}
+	return lock, key, nil
+}
+
+// hashTimeLock returns the hash time lock with the given ID along with its key
+func hashTimeLock(stub shim.ChaincodeStubInterface, id string) (*HashTimeLock, string, error) {
+	key, err := compositeKey(stub, lockObjectType, id)
+	if err != nil {
+		return nil, "", err
+	}
+	lock := &HashTimeLock{}
+	exists, err := getJSON(stub, key, lock)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		return nil, "", errors.Errorf("lock %s does not exist", id)
+	}
+	return lock, key, nil
+}
+
+// settle claims the lock with the preimage or refunds it, depending on the
+// type of the operation, and returns the account the assets are moved to
+func (l *HashTimeLock) settle(stub shim.ChaincodeStubInterface, opType OperationType, preimage string) (string, error) {
+	if l.State != LockStateLocked {
+		return "", errors.Errorf("lock %s is already %s", l.ID, l.State)
+	}
+	now, err := stub.GetTxTime()
+	if err != nil {
+		return "", errors.Wrap(err, "could not get the time of the transaction")
+	}
+
+	if opType == OperationRefund {
+		if now.Before(l.Expiry) {
+			return "", errors.Errorf("lock %s does not expire before %s", l.ID, l.Expiry.Format(time.RFC3339))
+		}
+		l.State = LockStateRefunded
+		return l.Owner, nil
+	}
+
+	if !now.Before(l.Expiry) {
+		return "", errors.Errorf("lock %s expired at %s", l.ID, l.Expiry.Format(time.RFC3339))
+	}
+	preimageBytes, err := hex.DecodeString(preimage)
+	if err != nil {
+		return "", errors.Errorf("invalid preimage %q, it must be in hexadecimal", preimage)
+	}
+	hash := sha256.Sum256(preimageBytes)
+	if hex.EncodeToString(hash[:]) != l.HashLock {
+		return "", errors.Errorf("the preimage does not match the hash lock of lock %s", l.ID)
+	}
+	l.State = LockStateClaimed
+	l.Preimage = preimage
+	return l.Recipient, nil
+}
+
+// lockOperation returns the claim or refund operation of the lock with the
+// given ID, along with the lock and its key
+func lockOperation(stub shim.ChaincodeStubInterface, opType OperationType, caller, lockID, preimage string) (*Operation, *HashTimeLock, string, error) {
+	lock, key, err := hashTimeLock(stub, lockID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	to, err := lock.settle(stub, opType, preimage)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return &Operation{
+		Type:    opType,
+		Asset:   lock.Asset,
+		Caller:  caller,
+		From:    EscrowAccount(lock.ID),
+		To:      to,
+		TokenID: lock.TokenID,
+		LockID:  lock.ID,
+	}, lock, key, nil
+}
+
+func getLock(stub shim.ChaincodeStubInterface, args []string) pb.Response {
+	if err := checkArgs(GetLock, args, "lock ID"); err != nil {
+		return shim.Error(err.Error())
+	}
+	lock, _, err := hashTimeLock(stub, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return success(lock)
+}
+
+// lockResponse returns the response of the Lock function, which is the ID of
+// the lock, once the operation is performed
+func lockResponse(resp pb.Response, lock *HashTimeLock) pb.Response {
+	if resp.Status != shim.OK {
+		return resp
+	}
+	return shim.Success([]byte(lock.ID))
+}
+
+// claimOrRefund returns the type of the operation of the Claim or Refund
+// function along with the preimage of the claim
+func claimOrRefund(function string, args []string) (OperationType, string, error) {
+	if function == Refund {
+		return OperationRefund, "", checkArgs(function, args, "lock ID")
+	}
+	if err := checkArgs(function, args, "lock ID", "preimage"); err != nil {
+		return "", "", err
+	}
+	return OperationClaim, args[1], nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	preimage = hex.EncodeToString([]byte("secret"))
+	hashLock = func() string {
+		hash := sha256.Sum256([]byte("secret"))
+		return hex.EncodeToString(hash[:])
+	}()
+)
+
+func inOneHour() string {
+	return time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+}
+
+// expire moves the expiry of the lock to the past
+func expire(t *testing.T, stub *shim.MockStub, lockID string) {
+	key, err := stub.CreateCompositeKey(lockObjectType, []string{lockID})
+	require.NoError(t, err)
+	lock := &HashTimeLock{}
+	require.NoError(t, json.Unmarshal(stub.State[key], lock))
+	lock.Expiry = time.Now().Add(-time.Second)
+	stub.State[key], err = json.Marshal(lock)
+	require.NoError(t, err)
+}
+
+func getHashTimeLock(t *testing.T, stub *shim.MockStub, c *client, lockID string) *HashTimeLock {
+	resp := c.invoke(stub, GetLock, lockID)
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	lock := &HashTimeLock{}
+	require.NoError(t, json.Unmarshal(resp.Payload, lock))
+	return lock
+}
+
+func TestFungibleHashTimeLocks(t *testing.T) {
+	stub := shim.NewMockStub("ftscc", NewFungibleSCC())
+	alice := newClient(t, "Org1MSP", "alice")
+	bob := newClient(t, "Org2MSP", "bob")
+	require.EqualValues(t, shim.OK, alice.invoke(stub, CreateToken, "USD", "US Dollar", "2").Status)
+	require.EqualValues(t, shim.OK, alice.invoke(stub, Mint, "USD", alice.account, "100").Status)
+
+	balance := func(account string) string {
+		return string(alice.invoke(stub, BalanceOf, "USD", account).Payload)
+	}
+	fails := func(expectedErr string, c *client, txID string, args ...string) {
+		resp := c.invokeTx(stub, txID, args...)
+		require.EqualValues(t, shim.ERROR, resp.Status)
+		assert.Equal(t, expectedErr, resp.Message)
+	}
+
+	// the locked amount is held by the escrow account of the lock
+	resp := alice.invokeTx(stub, "tx1", Lock, "USD", bob.account, "60", hashLock, inOneHour())
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, "tx1", string(resp.Payload))
+	assert.Equal(t, "40", balance(alice.account))
+	assert.Equal(t, "60", balance(EscrowAccount("tx1")))
+	name, op := lastEvent(t, stub)
+	assert.Equal(t, "Lock", name)
+	assert.Equal(t, &Operation{Type: OperationLock, Asset: "USD", Caller: alice.account, From: alice.account, To: EscrowAccount("tx1"), Amount: big.NewInt(60), LockID: "tx1"}, op)
+	lock := getHashTimeLock(t, stub, bob, "tx1")
+	assert.Equal(t, "60", lock.Amount)
+	assert.Equal(t, alice.account, lock.Owner)
+	assert.Equal(t, bob.account, lock.Recipient)
+	assert.Equal(t, LockStateLocked, lock.State)
+
+	fails("transaction tx1 already created a lock", alice, "tx1", Lock, "USD", bob.account, "10", hashLock, inOneHour())
+	fails("Lock of USD failed: the balance of "+alice.account+" is insufficient", alice, "tx2", Lock, "USD", bob.account, "41", hashLock, inOneHour())
+	fails(`invalid hash lock "abcd", it must be a SHA-256 hash in hexadecimal`, alice, "tx2", Lock, "USD", bob.account, "1", "abcd", inOneHour())
+	fails(`invalid expiry "tomorrow", it must be a time in RFC 3339 format`, alice, "tx2", Lock, "USD", bob.account, "1", hashLock, "tomorrow")
+	fails("the expiry 2000-01-01T00:00:00Z is not after the time of the transaction", alice, "tx2", Lock, "USD", bob.account, "1", hashLock, "2000-01-01T00:00:00Z")
+
+	// the lock is claimed for its recipient with the preimage, by anyone
+	fails("the preimage does not match the hash lock of lock tx1", alice, "tx3", Claim, "tx1", hex.EncodeToString([]byte("guess")))
+	fails(`invalid preimage "secret", it must be in hexadecimal`, alice, "tx3", Claim, "tx1", "secret")
+	fails("lock tx1 does not expire before "+lock.Expiry.Format(time.RFC3339), alice, "tx3", Refund, "tx1")
+	resp = alice.invokeTx(stub, "tx3", Claim, "tx1", preimage)
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, "60", balance(bob.account))
+	assert.Equal(t, "0", balance(EscrowAccount("tx1")))
+	name, op = lastEvent(t, stub)
+	assert.Equal(t, "Claim", name)
+	assert.Equal(t, &Operation{Type: OperationClaim, Asset: "USD", Caller: alice.account, From: EscrowAccount("tx1"), To: bob.account, Amount: big.NewInt(60), LockID: "tx1"}, op)
+	lock = getHashTimeLock(t, stub, bob, "tx1")
+	assert.Equal(t, LockStateClaimed, lock.State)
+	assert.Equal(t, preimage, lock.Preimage)
+	fails("lock tx1 is already Claimed", bob, "tx4", Claim, "tx1", preimage)
+
+	// the expired lock is refunded to its owner, and may no longer be claimed
+	require.EqualValues(t, shim.OK, alice.invokeTx(stub, "tx5", Lock, "USD", bob.account, "40", hashLock, inOneHour()).Status)
+	expire(t, stub, "tx5")
+	fails("lock tx5 expired at "+getHashTimeLock(t, stub, bob, "tx5").Expiry.Format(time.RFC3339), bob, "tx6", Claim, "tx5", preimage)
+	resp = bob.invokeTx(stub, "tx6", Refund, "tx5")
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, "40", balance(alice.account))
+	assert.Equal(t, LockStateRefunded, getHashTimeLock(t, stub, bob, "tx5").State)
+	fails("lock tx5 is already Refunded", bob, "tx7", Refund, "tx5")
+
+	fails("lock tx8 does not exist", bob, "tx8", GetLock, "tx8")
+	fails("Claim expects 2 arguments ([lock ID preimage]), got 1", bob, "tx8", Claim, "tx1")
+}
+
+func TestNonFungibleHashTimeLocks(t *testing.T) {
+	stub := shim.NewMockStub("nftscc", NewNonFungibleSCC())
+	alice := newClient(t, "Org1MSP", "alice")
+	bob := newClient(t, "Org2MSP", "bob")
+	require.EqualValues(t, shim.OK, alice.invoke(stub, CreateCollection, "deeds", "Land deeds").Status)
+	require.EqualValues(t, shim.OK, alice.invoke(stub, Mint, "deeds", "1", alice.account).Status)
+
+	owner := func() string {
+		return string(alice.invoke(stub, OwnerOf, "deeds", "1").Payload)
+	}
+
+	// only the spenders of the token lock it
+	resp := bob.invokeTx(stub, "tx1", Lock, "deeds", bob.account, "1", hashLock, inOneHour())
+	assert.Equal(t, bob.account+" is neither the owner of token 1 of collection deeds, nor approved for it, nor an operator of its owner", resp.Message)
+	resp = alice.invokeTx(stub, "tx1", Lock, "deeds", bob.account, "1", hashLock, inOneHour())
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, EscrowAccount("tx1"), owner())
+	assert.Equal(t, "0", string(alice.invoke(stub, BalanceOf, "deeds", alice.account).Payload))
+	resp = alice.invokeTx(stub, "tx2", TransferFrom, "deeds", alice.account, bob.account, "1")
+	assert.Equal(t, "token 1 of collection deeds is not owned by "+alice.account, resp.Message)
+
+	// the expired lock is refunded to the owner of the token
+	expire(t, stub, "tx1")
+	resp = bob.invokeTx(stub, "tx2", Refund, "tx1")
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, alice.account, owner())
+	assert.Equal(t, "1", string(alice.invoke(stub, BalanceOf, "deeds", alice.account).Payload))
+
+	// the lock is claimed for its recipient with the preimage
+	require.EqualValues(t, shim.OK, alice.invokeTx(stub, "tx3", Lock, "deeds", bob.account, "1", hashLock, inOneHour()).Status)
+	resp = bob.invokeTx(stub, "tx4", Claim, "tx3", preimage)
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, bob.account, owner())
+	name, op := lastEvent(t, stub)
+	assert.Equal(t, "Claim", name)
+	assert.Equal(t, &Operation{Type: OperationClaim, Asset: "deeds", Caller: bob.account, From: EscrowAccount("tx3"), To: bob.account, TokenID: "1", LockID: "tx3"}, op)
+	lock := getHashTimeLock(t, stub, alice, "tx3")
+	assert.Equal(t, "1", lock.TokenID)
+	assert.Equal(t, LockStateClaimed, lock.State)
+}
@@ -0,0 +1,491 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// These are the functions of the non-fungible asset system chaincode which
+// it does not share with the fungible asset system chaincode
+const (
+	CreateCollection  = "CreateCollection"
+	GetCollection     = "GetCollection"
+	OwnerOf           = "OwnerOf"
+	TokenURI          = "TokenURI"
+	GetApproved       = "GetApproved"
+	SetApprovalForAll = "SetApprovalForAll"
+	IsApprovedForAll  = "IsApprovedForAll"
+)
+
+const (
+	collectionObjectType = "collection"
+	nftObjectType        = "nft"
+	nftBalanceObjectType = "nftbalance"
+	operatorObjectType   = "operator"
+)
+
+// Collection is a collection of non-fungible tokens
+type Collection struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Minter is the account of the client which created the collection and
+	// which is the only one allowed to mint its tokens
+	Minter string `json:"minter"`
+}
+
+// NFT is a non-fungible token of a collection
+type NFT struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+	// URI is the URI of the metadata of the token
+	URI string `json:"uri,omitempty"`
+	// Approved is the account allowed to transfer the token, if any
+	Approved string `json:"approved,omitempty"`
+}
+
+// NonFungibleSCC is the system chaincode of the non-fungible tokens of a
+// channel, grouped in collections, with the interface of ERC-721 tokens:
+//   - CreateCollection creates the collection with ID args[1] and name args[2],
+//     minted by the client
+//   - GetCollection returns the collection with ID args[1]
+//   - ClientAccount returns the account of the client
+//   - Mint mints token args[2] of collection args[1] to account args[3], with
+//     the metadata URI args[4] if any
+//   - Burn burns token args[2] of collection args[1]
+//   - OwnerOf returns the owner of token args[2] of collection args[1]
+//   - TokenURI returns the metadata URI of token args[2] of collection args[1]
+//   - BalanceOf returns the number of tokens of collection args[1] owned by
+//     account args[2]
+//   - TransferFrom transfers token args[4] of collection args[1] from account
+//     args[2] to account args[3]
+//   - Approve allows account args[2] to transfer token args[3] of collection
+//     args[1]
+//   - GetApproved returns the account allowed to transfer token args[2] of
+//     collection args[1]
+//   - SetApprovalForAll allows account args[2] to transfer all the tokens of
+//     collection args[1] of the client if args[3] is true, and revokes it otherwise
+//   - IsApprovedForAll returns whether account args[3] is allowed to transfer
+//     all the tokens of collection args[1] of account args[2]
+//   - Lock locks token args[3] of collection args[1] for account args[2] with
+//     the hash lock args[4] until the expiry args[5], and returns the ID of the
+//     lock
+//   - Claim claims the token of lock args[1] for its recipient with the
+//     preimage args[2] of its hash lock
+//   - Refund refunds the token of the expired lock args[1] to its owner
+//   - GetLock returns the lock args[1]
+//
+// A token may be transferred, burnt and locked by its owner, by the account approved
+// for it and by the operators of its owner, and approved by its owner and by
+// the operators of its owner.
+type NonFungibleSCC struct {
+	hooks []Hook
+}
+
+// NewNonFungibleSCC returns the non-fungible asset system chaincode, which
+// calls the given hooks around each of its operations
+func NewNonFungibleSCC(hooks ...Hook) *NonFungibleSCC {
+	return &NonFungibleSCC{hooks: hooks}
+}
+
+func (s *NonFungibleSCC) Name() string              { return "nftscc" }
+func (s *NonFungibleSCC) Path() string              { return "github.com/hyperledger/fabric/core/scc/assets" }
+func (s *NonFungibleSCC) InitArgs() [][]byte        { return nil }
+func (s *NonFungibleSCC) Chaincode() shim.Chaincode { return s }
+func (s *NonFungibleSCC) InvokableExternal() bool   { return true }
+func (s *NonFungibleSCC) InvokableCC2CC() bool      { return true }
+func (s *NonFungibleSCC) Enabled() bool             { return true }
+
+// RequiresEndorsementPolicy returns true as the writes to the state of the
+// assets must satisfy the endorsement policy defined by the channel config
+func (s *NonFungibleSCC) RequiresEndorsementPolicy() bool { return true }
+
+// Init is called once per channel when the system chaincode is deployed
+func (s *NonFungibleSCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke invokes the function named after the first argument
+func (s *NonFungibleSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	caller, err := clientAccount(stub)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	switch function {
+	case ClientAccount:
+		return shim.Success([]byte(caller))
+	case CreateCollection:
+		return s.createCollection(stub, caller, args)
+	case GetCollection:
+		if err := checkArgs(function, args, "collection"); err != nil {
+			return shim.Error(err.Error())
+		}
+		collection, err := s.collection(stub, args[0])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return success(collection)
+	case Mint:
+		uri := ""
+		if len(args) == 4 {
+			uri, args = args[3], args[:3]
+		}
+		if err := checkArgs(function, args, "collection", "token", "to"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.mint(stub, &Operation{Type: OperationMint, Asset: args[0], Caller: caller, To: args[2], TokenID: args[1]}, uri)
+	case Burn:
+		if err := checkArgs(function, args, "collection", "token"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.burn(stub, &Operation{Type: OperationBurn, Asset: args[0], Caller: caller, TokenID: args[1]})
+	case OwnerOf, TokenURI, GetApproved:
+		if err := checkArgs(function, args, "collection", "token"); err != nil {
+			return shim.Error(err.Error())
+		}
+		nft, _, err := s.nft(stub, args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		switch function {
+		case OwnerOf:
+			return shim.Success([]byte(nft.Owner))
+		case TokenURI:
+			return shim.Success([]byte(nft.URI))
+		}
+		return shim.Success([]byte(nft.Approved))
+	case BalanceOf:
+		if err := checkArgs(function, args, "collection", "owner"); err != nil {
+			return shim.Error(err.Error())
+		}
+		if _, err := s.collection(stub, args[0]); err != nil {
+			return shim.Error(err.Error())
+		}
+		balance, _, err := getAmount(stub, nftBalanceObjectType, args[0], args[1])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(balance.String()))
+	case TransferFrom:
+		if err := checkArgs(function, args, "collection", "from", "to", "token"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.transfer(stub, &Operation{Type: OperationTransfer, Asset: args[0], Caller: caller, From: args[1], To: args[2], TokenID: args[3]})
+	case Approve:
+		if err := checkArgs(function, args, "collection", "approved", "token"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.approve(stub, &Operation{Type: OperationApprove, Asset: args[0], Caller: caller, To: args[1], TokenID: args[2]})
+	case SetApprovalForAll:
+		if err := checkArgs(function, args, "collection", "operator", "approved"); err != nil {
+			return shim.Error(err.Error())
+		}
+		approved, err := strconv.ParseBool(args[2])
+		if err != nil {
+			return shim.Error(fmt.Sprintf("invalid approved %q, it must be true or false", args[2]))
+		}
+		return s.approveAll(stub, &Operation{Type: OperationApproveAll, Asset: args[0], Caller: caller, From: caller, To: args[1], Approved: approved})
+	case IsApprovedForAll:
+		if err := checkArgs(function, args, "collection", "owner", "operator"); err != nil {
+			return shim.Error(err.Error())
+		}
+		if _, err := s.collection(stub, args[0]); err != nil {
+			return shim.Error(err.Error())
+		}
+		approved, err := isOperator(stub, args[0], args[1], args[2])
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success([]byte(strconv.FormatBool(approved)))
+	case Lock:
+		if err := checkArgs(function, args, "collection", "recipient", "token", "hash lock", "expiry"); err != nil {
+			return shim.Error(err.Error())
+		}
+		return s.lock(stub, caller, args)
+	case Claim, Refund:
+		return s.settle(stub, function, caller, args)
+	case GetLock:
+		return getLock(stub, args)
+	}
+
+	return shim.Error(fmt.Sprintf("Requested function %s not found.", function))
+}
+
+func (s *NonFungibleSCC) createCollection(stub shim.ChaincodeStubInterface, caller string, args []string) pb.Response {
+	if err := checkArgs(CreateCollection, args, "collection", "name"); err != nil {
+		return shim.Error(err.Error())
+	}
+	key, err := compositeKey(stub, collectionObjectType, args[0])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	exists, err := getJSON(stub, key, &Collection{})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if exists {
+		return shim.Error(fmt.Sprintf("collection %s already exists", args[0]))
+	}
+
+	collection := &Collection{ID: args[0], Name: args[1], Minter: caller}
+	if err := putJSON(stub, key, collection); err != nil {
+		return shim.Error(err.Error())
+	}
+	return success(collection)
+}
+
+func (s *NonFungibleSCC) collection(stub shim.ChaincodeStubInterface, id string) (*Collection, error) {
+	key, err := compositeKey(stub, collectionObjectType, id)
+	if err != nil {
+		return nil, err
+	}
+	collection := &Collection{}
+	exists, err := getJSON(stub, key, collection)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.Errorf("collection %s does not exist", id)
+	}
+	return collection, nil
+}
+
+// nft returns the token of the collection along with its key
+func (s *NonFungibleSCC) nft(stub shim.ChaincodeStubInterface, collection, id string) (*NFT, string, error) {
+	if _, err := s.collection(stub, collection); err != nil {
+		return nil, "", err
+	}
+	key, err := compositeKey(stub, nftObjectType, collection, id)
+	if err != nil {
+		return nil, "", err
+	}
+	nft := &NFT{}
+	exists, err := getJSON(stub, key, nft)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		return nil, "", errors.Errorf("token %s of collection %s does not exist", id, collection)
+	}
+	return nft, key, nil
+}
+
+func (s *NonFungibleSCC) mint(stub shim.ChaincodeStubInterface, op *Operation, uri string) pb.Response {
+	collection, err := s.collection(stub, op.Asset)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if collection.Minter != op.Caller {
+		return shim.Error(fmt.Sprintf("%s is not the minter of collection %s", op.Caller, op.Asset))
+	}
+	key, err := compositeKey(stub, nftObjectType, op.Asset, op.TokenID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	exists, err := getJSON(stub, key, &NFT{})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if exists {
+		return shim.Error(fmt.Sprintf("token %s of collection %s already exists", op.TokenID, op.Asset))
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		if err := putJSON(stub, key, &NFT{ID: op.TokenID, Owner: op.To, URI: uri}); err != nil {
+			return err
+		}
+		return addNFTBalance(stub, op.Asset, op.To, 1)
+	})
+}
+
+func (s *NonFungibleSCC) burn(stub shim.ChaincodeStubInterface, op *Operation) pb.Response {
+	nft, key, err := s.nft(stub, op.Asset, op.TokenID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if err := checkSpender(stub, op.Asset, nft, op.Caller); err != nil {
+		return shim.Error(err.Error())
+	}
+	op.From = nft.Owner
+
+	return perform(stub, s.hooks, op, func() error {
+		if err := stub.DelState(key); err != nil {
+			return err
+		}
+		return addNFTBalance(stub, op.Asset, op.From, -1)
+	})
+}
+
+func (s *NonFungibleSCC) transfer(stub shim.ChaincodeStubInterface, op *Operation) pb.Response {
+	nft, key, err := s.nft(stub, op.Asset, op.TokenID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if nft.Owner != op.From {
+		return shim.Error(fmt.Sprintf("token %s of collection %s is not owned by %s", op.TokenID, op.Asset, op.From))
+	}
+	if err := checkSpender(stub, op.Asset, nft, op.Caller); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		return moveNFT(stub, op, nft, key)
+	})
+}
+
+func (s *NonFungibleSCC) lock(stub shim.ChaincodeStubInterface, caller string, args []string) pb.Response {
+	op := &Operation{Type: OperationLock, Asset: args[0], Caller: caller, TokenID: args[2]}
+	nft, nftKey, err := s.nft(stub, op.Asset, op.TokenID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if err := checkSpender(stub, op.Asset, nft, op.Caller); err != nil {
+		return shim.Error(err.Error())
+	}
+	op.From = nft.Owner
+	lock, key, err := newHashTimeLock(stub, op.Asset, nft.Owner, args[1], args[3], args[4])
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	lock.TokenID = op.TokenID
+	op.To, op.LockID = EscrowAccount(lock.ID), lock.ID
+
+	return lockResponse(perform(stub, s.hooks, op, func() error {
+		if err := moveNFT(stub, op, nft, nftKey); err != nil {
+			return err
+		}
+		return putJSON(stub, key, lock)
+	}), lock)
+}
+
+// settle claims or refunds a lock, depending on the function
+func (s *NonFungibleSCC) settle(stub shim.ChaincodeStubInterface, function, caller string, args []string) pb.Response {
+	opType, preimage, err := claimOrRefund(function, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	op, lock, key, err := lockOperation(stub, opType, caller, args[0], preimage)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	nft, nftKey, err := s.nft(stub, op.Asset, op.TokenID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if nft.Owner != op.From {
+		return shim.Error(fmt.Sprintf("token %s of collection %s is not held by lock %s", op.TokenID, op.Asset, lock.ID))
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		if err := moveNFT(stub, op, nft, nftKey); err != nil {
+			return err
+		}
+		return putJSON(stub, key, lock)
+	})
+}
+
+func (s *NonFungibleSCC) approve(stub shim.ChaincodeStubInterface, op *Operation) pb.Response {
+	nft, key, err := s.nft(stub, op.Asset, op.TokenID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if nft.Owner != op.Caller {
+		operator, err := isOperator(stub, op.Asset, nft.Owner, op.Caller)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		if !operator {
+			return shim.Error(fmt.Sprintf("%s is neither the owner of token %s of collection %s nor an operator of its owner", op.Caller, op.TokenID, op.Asset))
+		}
+	}
+	op.From = nft.Owner
+
+	return perform(stub, s.hooks, op, func() error {
+		nft.Approved = op.To
+		return putJSON(stub, key, nft)
+	})
+}
+
+func (s *NonFungibleSCC) approveAll(stub shim.ChaincodeStubInterface, op *Operation) pb.Response {
+	if _, err := s.collection(stub, op.Asset); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return perform(stub, s.hooks, op, func() error {
+		key, err := compositeKey(stub, operatorObjectType, op.Asset, op.From, op.To)
+		if err != nil {
+			return err
+		}
+		if !op.Approved {
+			return stub.DelState(key)
+		}
+		return stub.PutState(key, []byte(strconv.FormatBool(true)))
+	})
+}
+
+// checkSpender checks that the account may transfer and burn the token
+func checkSpender(stub shim.ChaincodeStubInterface, collection string, nft *NFT, account string) error {
+	if nft.Owner == account || nft.Approved == account {
+		return nil
+	}
+	operator, err := isOperator(stub, collection, nft.Owner, account)
+	if err != nil {
+		return err
+	}
+	if !operator {
+		return errors.Errorf("%s is neither the owner of token %s of collection %s, nor approved for it, nor an operator of its owner", account, nft.ID, collection)
+	}
+	return nil
+}
+
+func isOperator(stub shim.ChaincodeStubInterface, collection, owner, operator string) (bool, error) {
+	key, err := compositeKey(stub, operatorObjectType, collection, owner, operator)
+	if err != nil {
+		return false, err
+	}
+	value, err := stub.GetState(key)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not get the state of %q", key)
+	}
+	return value != nil, nil
+}
+
+// moveNFT moves the token stored under the key from the From account of the
+// operation to its To account, revoking its approval
+func moveNFT(stub shim.ChaincodeStubInterface, op *Operation, nft *NFT, key string) error {
+	nft.Owner = op.To
+	nft.Approved = ""
+	if err := putJSON(stub, key, nft); err != nil {
+		return err
+	}
+	if op.From == op.To {
+		return nil
+	}
+	if err := addNFTBalance(stub, op.Asset, op.From, -1); err != nil {
+		return err
+	}
+	return addNFTBalance(stub, op.Asset, op.To, 1)
+}
+
+func addNFTBalance(stub shim.ChaincodeStubInterface, collection, owner string, delta int64) error {
+	balance, key, err := getAmount(stub, nftBalanceObjectType, collection, owner)
+	if err != nil {
+		return err
+	}
+	if balance.Add(balance, big.NewInt(delta)).Sign() < 0 {
+		return errors.Errorf("the balance of %s is inconsistent", owner)
+	}
+	return putAmount(stub, key, balance)
+}
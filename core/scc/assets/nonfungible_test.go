@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package assets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonFungibleSCC(t *testing.T) {
+	scc := NewNonFungibleSCC()
+	assert.Equal(t, "nftscc", scc.Name())
+	stub := shim.NewMockStub("nftscc", scc)
+	minter := newClient(t, "Org1MSP", "minter")
+	alice := newClient(t, "Org1MSP", "alice")
+	bob := newClient(t, "Org2MSP", "bob")
+	carol := newClient(t, "Org2MSP", "carol")
+
+	query := func(args ...string) string {
+		resp := minter.invoke(stub, args...)
+		require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+		return string(resp.Payload)
+	}
+	succeeds := func(c *client, args ...string) {
+		resp := c.invoke(stub, args...)
+		require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	}
+	fails := func(expectedErr string, c *client, args ...string) {
+		resp := c.invoke(stub, args...)
+		require.EqualValues(t, shim.ERROR, resp.Status)
+		assert.Equal(t, expectedErr, resp.Message)
+	}
+
+	resp := minter.invoke(stub, CreateCollection, "deeds", "Land deeds")
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	collection := &Collection{}
+	require.NoError(t, json.Unmarshal(resp.Payload, collection))
+	assert.Equal(t, &Collection{ID: "deeds", Name: "Land deeds", Minter: minter.account}, collection)
+	fails("collection deeds already exists", alice, CreateCollection, "deeds", "Deeds")
+	fails("collection cars does not exist", alice, OwnerOf, "cars", "1")
+
+	// only the minter mints the tokens, which are unique
+	succeeds(minter, Mint, "deeds", "1", alice.account, "https://deeds.example.com/1")
+	succeeds(minter, Mint, "deeds", "2", alice.account)
+	fails("token 1 of collection deeds already exists", minter, Mint, "deeds", "1", bob.account)
+	fails(alice.account+" is not the minter of collection deeds", alice, Mint, "deeds", "3", alice.account)
+	assert.Equal(t, alice.account, query(OwnerOf, "deeds", "1"))
+	assert.Equal(t, "https://deeds.example.com/1", query(TokenURI, "deeds", "1"))
+	assert.Equal(t, "", query(TokenURI, "deeds", "2"))
+	assert.Equal(t, "2", query(BalanceOf, "deeds", alice.account))
+	assert.Equal(t, "0", query(BalanceOf, "deeds", bob.account))
+	fails("token 3 of collection deeds does not exist", alice, OwnerOf, "deeds", "3")
+	name, op := lastEvent(t, stub)
+	assert.Equal(t, "Mint", name)
+	assert.Equal(t, &Operation{Type: OperationMint, Asset: "deeds", Caller: minter.account, To: alice.account, TokenID: "2"}, op)
+
+	// the owner transfers its tokens
+	succeeds(alice, TransferFrom, "deeds", alice.account, bob.account, "1")
+	assert.Equal(t, bob.account, query(OwnerOf, "deeds", "1"))
+	assert.Equal(t, "1", query(BalanceOf, "deeds", alice.account))
+	assert.Equal(t, "1", query(BalanceOf, "deeds", bob.account))
+	fails("token 1 of collection deeds is not owned by "+alice.account, alice, TransferFrom, "deeds", alice.account, carol.account, "1")
+	fails(alice.account+" is neither the owner of token 1 of collection deeds, nor approved for it, nor an operator of its owner",
+		alice, TransferFrom, "deeds", bob.account, alice.account, "1")
+
+	// the account approved for a token transfers it once
+	succeeds(bob, Approve, "deeds", carol.account, "1")
+	assert.Equal(t, carol.account, query(GetApproved, "deeds", "1"))
+	fails(carol.account+" is neither the owner of token 1 of collection deeds nor an operator of its owner", carol, Approve, "deeds", carol.account, "1")
+	succeeds(carol, TransferFrom, "deeds", bob.account, carol.account, "1")
+	assert.Equal(t, carol.account, query(OwnerOf, "deeds", "1"))
+	assert.Equal(t, "", query(GetApproved, "deeds", "1"))
+
+	// the operators of an owner transfer, approve and burn all its tokens
+	succeeds(alice, SetApprovalForAll, "deeds", bob.account, "true")
+	assert.Equal(t, "true", query(IsApprovedForAll, "deeds", alice.account, bob.account))
+	assert.Equal(t, "false", query(IsApprovedForAll, "deeds", alice.account, carol.account))
+	name, op = lastEvent(t, stub)
+	assert.Equal(t, "ApproveAll", name)
+	assert.Equal(t, &Operation{Type: OperationApproveAll, Asset: "deeds", Caller: alice.account, From: alice.account, To: bob.account, Approved: true}, op)
+	succeeds(bob, Approve, "deeds", carol.account, "2")
+	assert.Equal(t, carol.account, query(GetApproved, "deeds", "2"))
+	succeeds(bob, Burn, "deeds", "2")
+	fails("token 2 of collection deeds does not exist", alice, OwnerOf, "deeds", "2")
+	assert.Equal(t, "0", query(BalanceOf, "deeds", alice.account))
+	succeeds(alice, SetApprovalForAll, "deeds", bob.account, "false")
+	assert.Equal(t, "false", query(IsApprovedForAll, "deeds", alice.account, bob.account))
+	fails(`invalid approved "maybe", it must be true or false`, alice, SetApprovalForAll, "deeds", bob.account, "maybe")
+
+	fails(alice.account+" is neither the owner of token 1 of collection deeds, nor approved for it, nor an operator of its owner", alice, Burn, "deeds", "1")
+	succeeds(carol, Burn, "deeds", "1")
+	assert.Equal(t, "0", query(BalanceOf, "deeds", carol.account))
+
+	fails("Mint expects 3 arguments ([collection token to]), got 2", minter, Mint, "deeds", "3")
+	fails("Requested function Swap not found.", alice, "Swap")
+}
@@ -0,0 +1,138 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package batchscc provides the batch system chaincode, which invokes several
+// application chaincodes of a channel in a single transaction.
+package batchscc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+var logger = flogging.MustGetLogger("batchscc")
+
+// InvokeBatch is the function of the batch system chaincode, from the first
+// argument of the invocation
+const InvokeBatch = "Invoke"
+
+// BatchSCC is the batch system chaincode. Its Invoke function invokes in
+// order the chaincodes of the marshaled ChaincodeBatch args[1] on the channel
+// of the transaction and returns their responses in a marshaled
+// ChaincodeBatchResponse. The invocations share the simulation of the
+// transaction, whose read-write set therefore combines their reads and
+// writes: the transaction commits the updates of all the chaincodes or of
+// none of them. If one of the invocations fails, the whole batch fails.
+//
+// The committers validate the namespace of each chaincode written by the
+// batch with the endorsement policy of that chaincode, so the transaction
+// must be endorsed according to the policies of all the chaincodes it
+// writes to. The batches require the CHAINCODE_BATCHES application
+// capability, without which these namespaces would not be validated.
+type BatchSCC struct {
+	sccProvider sysccprovider.SystemChaincodeProvider
+	aclProvider aclmgmt.ACLProvider
+}
+
+// New returns the batch system chaincode
+func New(sccProvider sysccprovider.SystemChaincodeProvider, aclProvider aclmgmt.ACLProvider) *BatchSCC {
+	return &BatchSCC{sccProvider: sccProvider, aclProvider: aclProvider}
+}
+
+func (s *BatchSCC) Name() string              { return "batchscc" }
+func (s *BatchSCC) Path() string              { return "github.com/hyperledger/fabric/core/scc/batchscc" }
+func (s *BatchSCC) InitArgs() [][]byte        { return nil }
+func (s *BatchSCC) Chaincode() shim.Chaincode { return s }
+func (s *BatchSCC) InvokableExternal() bool   { return true }
+func (s *BatchSCC) InvokableCC2CC() bool      { return false }
+func (s *BatchSCC) Enabled() bool             { return true }
+
+// Init is called once per channel when the system chaincode is deployed
+func (s *BatchSCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke invokes the chaincodes of the batch
+func (s *BatchSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) != 2 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+	}
+	if function := string(args[0]); function != InvokeBatch {
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", function))
+	}
+	channelID := stub.GetChannelID()
+	sp, err := stub.GetSignedProposal()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed getting signed proposal from stub: %s", err))
+	}
+	if err := s.aclProvider.CheckACL(resources.Batchscc_Invoke, channelID, sp); err != nil {
+		return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", InvokeBatch, channelID, err))
+	}
+
+	ac, exists := s.sccProvider.GetApplicationConfig(channelID)
+	if !exists {
+		return shim.Error(fmt.Sprintf("could not find the application configuration of channel %s", channelID))
+	}
+	if !ac.Capabilities().ChaincodeBatches() {
+		return shim.Error(fmt.Sprintf("the chaincode batches are not enabled on channel %s", channelID))
+	}
+
+	batch := &pb.ChaincodeBatch{}
+	if err := proto.Unmarshal(args[1], batch); err != nil {
+		return shim.Error(fmt.Sprintf("could not unmarshal the batch: %s", err))
+	}
+	if len(batch.Invocations) == 0 {
+		return shim.Error("the batch has no invocation")
+	}
+	if err := s.checkInvocations(batch.Invocations); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	batchResponse := &pb.ChaincodeBatchResponse{}
+	for i, spec := range batch.Invocations {
+		name := spec.ChaincodeId.Name
+		logger.Debugf("[%s] invoking chaincode %s, invocation %d of the batch", channelID, name, i)
+		resp := stub.InvokeChaincode(name, spec.GetInput().GetArgs(), "")
+		if resp.Status >= shim.ERRORTHRESHOLD {
+			return shim.Error(fmt.Sprintf("invocation %d of chaincode %s failed: %s", i, name, resp.Message))
+		}
+		batchResponse.Responses = append(batchResponse.Responses, &resp)
+	}
+	payload, err := proto.Marshal(batchResponse)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(payload)
+}
+
+// checkInvocations checks that the invocations of the batch invoke the
+// application chaincodes of the channel of the batch, so that the updates of
+// the chaincodes are validated and committed together
+func (s *BatchSCC) checkInvocations(invocations []*pb.ChaincodeSpec) error {
+	for i, spec := range invocations {
+		name := spec.GetChaincodeId().GetName()
+		switch {
+		case name == "":
+			return fmt.Errorf("invocation %d has no chaincode name", i)
+		case strings.ContainsAny(name, ":/"):
+			return fmt.Errorf("invocation %d targets chaincode %s, the batches invoke the latest version of the chaincodes of their channel", i, name)
+		case s.sccProvider.IsSysCC(name):
+			return fmt.Errorf("invocation %d targets system chaincode %s, the batches invoke application chaincodes only", i, name)
+		case spec.GetInput().GetIsInit():
+			return fmt.Errorf("invocation %d initializes chaincode %s, the batches cannot initialize chaincodes", i, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package batchscc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/mocks/scc"
+	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// putChaincode puts the value args[2] at the key args[1], and fails when
+// args[0] is "fail"
+type putChaincode struct{}
+
+func (putChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (putChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if string(args[0]) == "fail" {
+		return shim.Error("insufficient funds")
+	}
+	if err := stub.PutState(string(args[1]), args[2]); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(args[2])
+}
+
+func invocation(name string, args ...string) *pb.ChaincodeSpec {
+	input := &pb.ChaincodeInput{}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
+	}
+	return &pb.ChaincodeSpec{ChaincodeId: &pb.ChaincodeID{Name: name}, Input: input}
+}
+
+func TestInvoke(t *testing.T) {
+	capabilities := &mockconfig.MockApplicationCapabilities{ChaincodeBatchesRv: true}
+	sccProvider := (&scc.MocksccProviderFactory{
+		ApplicationConfigRv:   &mockconfig.MockApplication{CapabilitiesRv: capabilities},
+		ApplicationConfigBool: true,
+	}).NewSystemChaincodeProvider()
+	aclProvider := &mocks.MockACLProvider{}
+	aclProvider.Reset()
+	aclProvider.On("CheckACL", resources.Batchscc_Invoke, "mychannel", mock.Anything).Return(nil)
+
+	stub := shim.NewMockStub("batchscc", New(sccProvider, aclProvider))
+	stub.ChannelID = "mychannel"
+	cc1, cc2 := shim.NewMockStub("cc1", putChaincode{}), shim.NewMockStub("cc2", putChaincode{})
+	stub.MockPeerChaincode("cc1", cc1)
+	stub.MockPeerChaincode("cc2", cc2)
+	invoke := func(invocations ...*pb.ChaincodeSpec) pb.Response {
+		batch, err := proto.Marshal(&pb.ChaincodeBatch{Invocations: invocations})
+		require.NoError(t, err)
+		return stub.MockInvoke("tx1", [][]byte{[]byte(InvokeBatch), batch})
+	}
+
+	// the chaincodes are invoked in order and their responses are returned
+	resp := invoke(invocation("cc1", "put", "a", "1"), invocation("cc2", "put", "b", "2"))
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	batchResponse := &pb.ChaincodeBatchResponse{}
+	require.NoError(t, proto.Unmarshal(resp.Payload, batchResponse))
+	require.Len(t, batchResponse.Responses, 2)
+	assert.Equal(t, []byte("1"), batchResponse.Responses[0].Payload)
+	assert.Equal(t, []byte("2"), batchResponse.Responses[1].Payload)
+	assert.Equal(t, []byte("1"), cc1.State["a"])
+	assert.Equal(t, []byte("2"), cc2.State["b"])
+
+	// the batch fails with any of its invocations
+	resp = invoke(invocation("cc1", "put", "a", "1"), invocation("cc2", "fail"))
+	assert.EqualValues(t, shim.ERROR, resp.Status)
+	assert.Equal(t, "invocation 1 of chaincode cc2 failed: insufficient funds", resp.Message)
+
+	for _, tc := range []struct {
+		name        string
+		invocations []*pb.ChaincodeSpec
+		expected    string
+	}{
+		{"empty batch", nil, "the batch has no invocation"},
+		{"no chaincode name", []*pb.ChaincodeSpec{invocation("", "put")}, "invocation 0 has no chaincode name"},
+		{
+			"other channel",
+			[]*pb.ChaincodeSpec{invocation("cc1", "put", "a", "1"), invocation("cc2/yourchannel", "put")},
+			"invocation 1 targets chaincode cc2/yourchannel, the batches invoke the latest version of the chaincodes of their channel",
+		},
+		{
+			"system chaincode",
+			[]*pb.ChaincodeSpec{invocation("lscc", "deploy")},
+			"invocation 0 targets system chaincode lscc, the batches invoke application chaincodes only",
+		},
+		{
+			"initialization",
+			[]*pb.ChaincodeSpec{{ChaincodeId: &pb.ChaincodeID{Name: "cc1"}, Input: &pb.ChaincodeInput{IsInit: true}}},
+			"invocation 0 initializes chaincode cc1, the batches cannot initialize chaincodes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := invoke(tc.invocations...)
+			assert.EqualValues(t, shim.ERROR, resp.Status)
+			assert.Equal(t, tc.expected, resp.Message)
+		})
+	}
+
+	resp = stub.MockInvoke("tx1", [][]byte{[]byte(InvokeBatch), []byte("garbage")})
+	assert.EqualValues(t, shim.ERROR, resp.Status)
+	assert.Contains(t, resp.Message, "could not unmarshal the batch")
+	resp = stub.MockInvoke("tx1", [][]byte{[]byte("Query"), nil})
+	assert.Equal(t, "Requested function Query not found.", resp.Message)
+
+	// the batches require the capability
+	capabilities.ChaincodeBatchesRv = false
+	resp = invoke(invocation("cc1", "put", "a", "1"))
+	assert.Equal(t, "the chaincode batches are not enabled on channel mychannel", resp.Message)
+
+	aclProvider.Reset()
+	aclProvider.On("CheckACL", resources.Batchscc_Invoke, "mychannel", mock.Anything).Return(errors.New("not a writer"))
+	resp = invoke(invocation("cc1", "put", "a", "1"))
+	assert.Equal(t, "access denied for [Invoke][mychannel]: [not a writer]", resp.Message)
+}
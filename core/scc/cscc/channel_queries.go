@@ -0,0 +1,204 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cscc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ChannelOrg describes an organization of a channel, as returned by the function GetChannelOrgs
+type ChannelOrg struct {
+	// Name is the name of the organization's group in the channel configuration
+	Name string `json:"name"`
+	// MSPID is the identifier of the MSP of the organization
+	MSPID string `json:"msp_id"`
+	// Group is the group of the channel configuration that holds the organization,
+	// that is, either "Application" or "Orderer"
+	Group string `json:"group"`
+	// AnchorPeers are the anchor peers of an application organization
+	AnchorPeers []*AnchorPeer `json:"anchor_peers,omitempty"`
+}
+
+// AnchorPeer is an anchor peer of an application organization
+type AnchorPeer struct {
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+}
+
+// ChannelCapabilities lists the capabilities that are enabled at each level of the
+// channel configuration, as returned by the function GetChannelCapabilities
+type ChannelCapabilities struct {
+	Channel     []string `json:"channel"`
+	Orderer     []string `json:"orderer"`
+	Application []string `json:"application"`
+}
+
+// channelConfigProto returns the current configuration of the given channel
+func (e *PeerConfiger) channelConfigProto(chainID []byte) (*common.Config, error) {
+	if chainID == nil {
+		return nil, errors.New("Chain ID must not be nil")
+	}
+	channelCfg := e.configMgr.GetChannelConfig(string(chainID)).ConfigProto()
+	if channelCfg == nil || channelCfg.ChannelGroup == nil {
+		return nil, errors.Errorf("Unknown chain ID, %s", string(chainID))
+	}
+	return channelCfg, nil
+}
+
+// getChannelConfig returns the current configuration of the given channel, decoded into JSON
+func (e *PeerConfiger) getChannelConfig(chainID []byte) pb.Response {
+	channelCfg, err := e.channelConfigProto(chainID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	buf := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buf, channelCfg); err != nil {
+		return shim.Error(fmt.Sprintf("failed decoding the configuration of channel %s: %s", chainID, err))
+	}
+	return shim.Success(buf.Bytes())
+}
+
+// getChannelOrgs returns the application and orderer organizations of the given channel,
+// along with the anchor peers of the application organizations, encoded in JSON
+func (e *PeerConfiger) getChannelOrgs(chainID []byte) pb.Response {
+	channelCfg, err := e.channelConfigProto(chainID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	orgs := []*ChannelOrg{}
+	for _, groupKey := range []string{channelconfig.ApplicationGroupKey, channelconfig.OrdererGroupKey} {
+		group, exists := channelCfg.ChannelGroup.Groups[groupKey]
+		if !exists {
+			continue
+		}
+		groupOrgs, err := channelOrgs(groupKey, group)
+		if err != nil {
+			return shim.Error(fmt.Sprintf("failed decoding the organizations of channel %s: %s", chainID, err))
+		}
+		orgs = append(orgs, groupOrgs...)
+	}
+	return marshalJSON(orgs)
+}
+
+// getChannelCapabilities returns the capabilities enabled on the given channel, encoded in JSON
+func (e *PeerConfiger) getChannelCapabilities(chainID []byte) pb.Response {
+	channelCfg, err := e.channelConfigProto(chainID)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	capabilities := &ChannelCapabilities{}
+	if capabilities.Channel, err = capabilityNames(channelCfg.ChannelGroup); err != nil {
+		return shim.Error(err.Error())
+	}
+	if group, exists := channelCfg.ChannelGroup.Groups[channelconfig.OrdererGroupKey]; exists {
+		if capabilities.Orderer, err = capabilityNames(group); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	if group, exists := channelCfg.ChannelGroup.Groups[channelconfig.ApplicationGroupKey]; exists {
+		if capabilities.Application, err = capabilityNames(group); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	return marshalJSON(capabilities)
+}
+
+func channelOrgs(groupKey string, group *common.ConfigGroup) ([]*ChannelOrg, error) {
+	var orgs []*ChannelOrg
+	for _, name := range sortedGroupNames(group) {
+		orgGroup := group.Groups[name]
+		mspID, err := mspIDOf(orgGroup)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("organization %s", name))
+		}
+		org := &ChannelOrg{Name: name, MSPID: mspID, Group: groupKey}
+		if value, exists := orgGroup.Values[channelconfig.AnchorPeersKey]; exists {
+			anchorPeers := &pb.AnchorPeers{}
+			if err := proto.Unmarshal(value.Value, anchorPeers); err != nil {
+				return nil, errors.Wrapf(err, "failed unmarshaling anchor peers of organization %s", name)
+			}
+			for _, ap := range anchorPeers.AnchorPeers {
+				org.AnchorPeers = append(org.AnchorPeers, &AnchorPeer{Host: ap.Host, Port: ap.Port})
+			}
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, nil
+}
+
+func mspIDOf(orgGroup *common.ConfigGroup) (string, error) {
+	value, exists := orgGroup.Values[channelconfig.MSPKey]
+	if !exists {
+		return "", errors.New("MSP configuration is missing")
+	}
+	mspConfig := &mspprotos.MSPConfig{}
+	if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+		return "", errors.Wrap(err, "failed unmarshaling MSP configuration")
+	}
+	switch mspConfig.Type {
+	case 0: // FABRIC
+		fabricConfig := &mspprotos.FabricMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+			return "", errors.Wrap(err, "failed unmarshaling fabric MSP configuration")
+		}
+		return fabricConfig.Name, nil
+	case 1: // IDEMIX
+		idemixConfig := &mspprotos.IdemixMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, idemixConfig); err != nil {
+			return "", errors.Wrap(err, "failed unmarshaling idemix MSP configuration")
+		}
+		return idemixConfig.Name, nil
+	default:
+		return "", errors.Errorf("unsupported MSP type %d", mspConfig.Type)
+	}
+}
+
+func capabilityNames(group *common.ConfigGroup) ([]string, error) {
+	names := []string{}
+	value, exists := group.Values[channelconfig.CapabilitiesKey]
+	if !exists {
+		return names, nil
+	}
+	capabilities := &common.Capabilities{}
+	if err := proto.Unmarshal(value.Value, capabilities); err != nil {
+		return nil, errors.Wrap(err, "failed unmarshaling capabilities")
+	}
+	for name := range capabilities.Capabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func sortedGroupNames(group *common.ConfigGroup) []string {
+	var names []string
+	for name := range group.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func marshalJSON(v interface{}) pb.Response {
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(jsonBytes)
+}
@@ -77,6 +77,9 @@ const (
 	GetChannels              string = "GetChannels"
 	GetConfigTree            string = "GetConfigTree"
 	SimulateConfigTreeUpdate string = "SimulateConfigTreeUpdate"
+	GetChannelConfig         string = "GetChannelConfig"
+	GetChannelOrgs           string = "GetChannelOrgs"
+	GetChannelCapabilities   string = "GetChannelCapabilities"
 )
 
 // Init is mostly useless from an SCC perspective
@@ -182,6 +185,21 @@ func (e *PeerConfiger) InvokeNoShim(args [][]byte, sp *pb.SignedProposal) pb.Res
 			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
 		}
 		return e.simulateConfigTreeUpdate(args[1], args[2])
+	case GetChannelConfig:
+		if err = e.aclProvider.CheckACL(resources.Cscc_GetChannelConfig, string(args[1]), sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
+		}
+		return e.getChannelConfig(args[1])
+	case GetChannelOrgs:
+		if err = e.aclProvider.CheckACL(resources.Cscc_GetChannelOrgs, string(args[1]), sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
+		}
+		return e.getChannelOrgs(args[1])
+	case GetChannelCapabilities:
+		if err = e.aclProvider.CheckACL(resources.Cscc_GetChannelCapabilities, string(args[1]), sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: %s", fname, args[1], err))
+		}
+		return e.getChannelCapabilities(args[1])
 	case GetChannels:
 		// 2. check local MSP Members policy
 		// TODO: move to ACLProvider once it will support chainless ACLs
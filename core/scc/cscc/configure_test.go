@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package cscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
@@ -544,3 +545,79 @@ func mockConfigBlock() []byte {
 	}
 	return blockBytes
 }
+
+func TestChannelQueries(t *testing.T) {
+	aclProvider := &mock.ACLProvider{}
+	configMgr := &mock.ConfigManager{}
+	pc := &PeerConfiger{
+		aclProvider: aclProvider,
+		configMgr:   configMgr,
+	}
+
+	conf := configtxgentest.Load(genesisconfig.SampleSingleMSPChannelProfile)
+	conf.Orderer = configtxgentest.Load(genesisconfig.SampleDevModeSoloProfile).Orderer
+	cg, err := encoder.NewChannelGroup(conf)
+	require.NoError(t, err)
+	ctxv := &mock.ConfigtxValidator{}
+	ctxv.ConfigProtoReturns(&cb.Config{ChannelGroup: cg})
+	configMgr.GetChannelConfigReturns(ctxv)
+
+	t.Run("GetChannelConfig", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("GetChannelConfig"), []byte("testchan")}, nil)
+		require.Equal(t, int32(shim.OK), res.Status, res.Message)
+		decoded := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(res.Payload, &decoded))
+		assert.Contains(t, string(res.Payload), `"name": "SampleOrg"`)
+		assert.Contains(t, decoded, "channel_group")
+	})
+
+	t.Run("GetChannelOrgs", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("GetChannelOrgs"), []byte("testchan")}, nil)
+		require.Equal(t, int32(shim.OK), res.Status, res.Message)
+		var orgs []*ChannelOrg
+		require.NoError(t, json.Unmarshal(res.Payload, &orgs))
+		assert.Equal(t, []*ChannelOrg{
+			{Name: "SampleOrg", MSPID: "SampleOrg", Group: "Application", AnchorPeers: []*AnchorPeer{{Host: "127.0.0.1", Port: 7051}}},
+			{Name: "SampleOrg", MSPID: "SampleOrg", Group: "Orderer"},
+		}, orgs)
+	})
+
+	t.Run("GetChannelCapabilities", func(t *testing.T) {
+		res := pc.InvokeNoShim([][]byte{[]byte("GetChannelCapabilities"), []byte("testchan")}, nil)
+		require.Equal(t, int32(shim.OK), res.Status, res.Message)
+		capabilities := &ChannelCapabilities{}
+		require.NoError(t, json.Unmarshal(res.Payload, capabilities))
+		assert.Equal(t, &ChannelCapabilities{
+			Channel:     []string{},
+			Orderer:     []string{"V1_1"},
+			Application: []string{"V1_3"},
+		}, capabilities)
+	})
+
+	t.Run("UnknownChannel", func(t *testing.T) {
+		configMgr.GetChannelConfigReturns(&mock.ConfigtxValidator{})
+		defer configMgr.GetChannelConfigReturns(ctxv)
+		for _, fname := range []string{"GetChannelConfig", "GetChannelOrgs", "GetChannelCapabilities"} {
+			res := pc.InvokeNoShim([][]byte{[]byte(fname), []byte("testchan")}, nil)
+			assert.Equal(t, "Unknown chain ID, testchan", res.Message)
+			res = pc.InvokeNoShim([][]byte{[]byte(fname), nil}, nil)
+			assert.Equal(t, "Chain ID must not be nil", res.Message)
+		}
+	})
+
+	t.Run("BadACL", func(t *testing.T) {
+		aclProvider.CheckACLReturns(fmt.Errorf("fake-error"))
+		defer aclProvider.CheckACLReturns(nil)
+		for fname, resource := range map[string]string{
+			"GetChannelConfig":       resources.Cscc_GetChannelConfig,
+			"GetChannelOrgs":         resources.Cscc_GetChannelOrgs,
+			"GetChannelCapabilities": resources.Cscc_GetChannelCapabilities,
+		} {
+			res := pc.InvokeNoShim([][]byte{[]byte(fname), []byte("testchan")}, nil)
+			assert.Equal(t, fmt.Sprintf("access denied for [%s][testchan]: fake-error", fname), res.Message)
+			calledResource, channel, _ := aclProvider.CheckACLArgsForCall(aclProvider.CheckACLCallCount() - 1)
+			assert.Equal(t, resource, calledResource)
+			assert.Equal(t, "testchan", channel)
+		}
+	})
+}
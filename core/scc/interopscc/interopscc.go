@@ -0,0 +1,317 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package interopscc provides the interop system chaincode, which records the
+// configuration of the other networks a channel trusts and verifies the views
+// of their state relayed to it.
+package interopscc
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("interopscc")
+
+// These are the functions of the interop system chaincode, from the first
+// argument of the invocation
+const (
+	RegisterNetwork = "RegisterNetwork"
+	GetNetwork      = "GetNetwork"
+	VerifyView      = "VerifyView"
+)
+
+const networkObjectType = "network"
+
+// InteropSCC is the interop system chaincode:
+//   - RegisterNetwork records the network with ID args[1], whose views must be
+//     endorsed according to the policy args[2] by the peers of the MSPs with
+//     the marshaled MSPConfigs args[3:], replacing its previous configuration
+//   - GetNetwork returns the marshaled InteropNetwork with ID args[1]
+//   - VerifyView verifies the marshaled View args[1] against the configuration
+//     of its network and returns the payload of the response of its query
+//
+// The chaincodes exchanging assets with other networks invoke VerifyView to
+// check the state of the other networks, for instance that the assets of a
+// counterparty are locked.
+type InteropSCC struct {
+	aclProvider aclmgmt.ACLProvider
+}
+
+// New returns the interop system chaincode
+func New(aclProvider aclmgmt.ACLProvider) *InteropSCC {
+	return &InteropSCC{aclProvider: aclProvider}
+}
+
+func (s *InteropSCC) Name() string              { return "interopscc" }
+func (s *InteropSCC) Path() string              { return "github.com/hyperledger/fabric/core/scc/interopscc" }
+func (s *InteropSCC) InitArgs() [][]byte        { return nil }
+func (s *InteropSCC) Chaincode() shim.Chaincode { return s }
+func (s *InteropSCC) InvokableExternal() bool   { return true }
+func (s *InteropSCC) InvokableCC2CC() bool      { return true }
+func (s *InteropSCC) Enabled() bool             { return true }
+
+// Init is called once per channel when the system chaincode is deployed
+func (s *InteropSCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+// Invoke invokes the function named after the first argument
+func (s *InteropSCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	args := stub.GetArgs()
+	if len(args) < 2 {
+		return shim.Error(fmt.Sprintf("Incorrect number of arguments, %d", len(args)))
+	}
+	function := string(args[0])
+
+	var resource string
+	switch function {
+	case RegisterNetwork:
+		resource = resources.Interopscc_RegisterNetwork
+	case GetNetwork:
+		resource = resources.Interopscc_GetNetwork
+	case VerifyView:
+		resource = resources.Interopscc_VerifyView
+	default:
+		return shim.Error(fmt.Sprintf("Requested function %s not found.", function))
+	}
+	sp, err := stub.GetSignedProposal()
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed getting signed proposal from stub: %s", err))
+	}
+	if err := s.aclProvider.CheckACL(resource, stub.GetChannelID(), sp); err != nil {
+		return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", function, stub.GetChannelID(), err))
+	}
+
+	switch function {
+	case RegisterNetwork:
+		return registerNetwork(stub, args[1:])
+	case GetNetwork:
+		network, err := getNetwork(stub, string(args[1]))
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		networkBytes, err := proto.Marshal(network)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(networkBytes)
+	}
+
+	view := &pb.View{}
+	if err := proto.Unmarshal(args[1], view); err != nil {
+		return shim.Error(fmt.Sprintf("could not unmarshal the view: %s", err))
+	}
+	network, err := getNetwork(stub, view.GetRequest().GetNetwork())
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	payload, err := Verify(view, network)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(payload)
+}
+
+func registerNetwork(stub shim.ChaincodeStubInterface, args [][]byte) pb.Response {
+	if len(args) < 3 {
+		return shim.Error(fmt.Sprintf("%s expects a network ID, a policy and at least one MSP configuration, got %d arguments", RegisterNetwork, len(args)))
+	}
+	network := &pb.InteropNetwork{Id: string(args[0])}
+	if network.Id == "" {
+		return shim.Error("the network ID must not be empty")
+	}
+	policy, err := cauthdsl.Compile(string(args[1]))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("invalid policy: %s", err))
+	}
+	if network.Policy, err = proto.Marshal(policy); err != nil {
+		return shim.Error(err.Error())
+	}
+	for _, mspConfigBytes := range args[2:] {
+		mspConfig := &mspprotos.MSPConfig{}
+		if err := proto.Unmarshal(mspConfigBytes, mspConfig); err != nil {
+			return shim.Error(fmt.Sprintf("could not unmarshal the MSP configuration: %s", err))
+		}
+		network.Msps = append(network.Msps, mspConfig)
+	}
+	// the MSPs are set up once, to reject the invalid configurations
+	if _, err := newMSPManager(network); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	key, err := stub.CreateCompositeKey(networkObjectType, []string{network.Id})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	networkBytes, err := proto.Marshal(network)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if err := stub.PutState(key, networkBytes); err != nil {
+		return shim.Error(err.Error())
+	}
+	logger.Infof("[%s] Registered network %s with %d MSPs", stub.GetChannelID(), network.Id, len(network.Msps))
+	return shim.Success(nil)
+}
+
+func getNetwork(stub shim.ChaincodeStubInterface, id string) (*pb.InteropNetwork, error) {
+	key, err := stub.CreateCompositeKey(networkObjectType, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	networkBytes, err := stub.GetState(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get network %s", id)
+	}
+	if networkBytes == nil {
+		return nil, errors.Errorf("network %s is not registered", id)
+	}
+	network := &pb.InteropNetwork{}
+	if err := proto.Unmarshal(networkBytes, network); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal network %s", id)
+	}
+	return network, nil
+}
+
+func newMSPManager(network *pb.InteropNetwork) (msp.MSPManager, error) {
+	mspHandler := channelconfig.NewMSPConfigHandler(msp.MSPv1_3)
+	for _, mspConfig := range network.Msps {
+		if _, err := mspHandler.ProposeMSP(mspConfig); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid MSP of network %s", network.Id))
+		}
+	}
+	return mspHandler.CreateMSPManager()
+}
+
+// Verify verifies that the endorsements of the view are responses of the peers
+// of its network, according to the configuration of the network, to the query
+// of the request of the view, and returns the payload of their response
+func Verify(view *pb.View, network *pb.InteropNetwork) ([]byte, error) {
+	request := view.GetRequest()
+	if request == nil || len(view.Endorsements) == 0 {
+		return nil, errors.New("the view has no request or no endorsements")
+	}
+	if request.Network != network.Id {
+		return nil, errors.Errorf("the view is a view of network %s, not of network %s", request.Network, network.Id)
+	}
+
+	var payload []byte
+	var signedData []*common.SignedData
+	for i, endorsement := range view.Endorsements {
+		p, err := verifyEndorsement(request, endorsement)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid endorsement %d of the view", i))
+		}
+		if i > 0 && !bytes.Equal(p, payload) {
+			return nil, errors.Errorf("the responses of endorsements 0 and %d of the view differ", i)
+		}
+		payload = p
+		resp := endorsement.Response
+		signedData = append(signedData, &common.SignedData{
+			Data:      append(resp.Payload, resp.Endorsement.Endorser...),
+			Identity:  resp.Endorsement.Endorser,
+			Signature: resp.Endorsement.Signature,
+		})
+	}
+
+	mspManager, err := newMSPManager(network)
+	if err != nil {
+		return nil, err
+	}
+	policy, _, err := cauthdsl.NewPolicyProvider(mspManager).NewPolicy(network.Policy)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid policy of network %s", network.Id))
+	}
+	if err := policy.Evaluate(signedData); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("the endorsements of the view do not satisfy the policy of network %s", network.Id))
+	}
+	return payload, nil
+}
+
+// verifyEndorsement checks that the endorsement is the response to a query of
+// the request and returns the payload of the response
+func verifyEndorsement(request *pb.ViewRequest, endorsement *pb.ViewEndorsement) ([]byte, error) {
+	resp := endorsement.GetResponse()
+	if resp.GetEndorsement() == nil {
+		return nil, errors.New("the response is not endorsed")
+	}
+	prop, err := utils.GetProposal(endorsement.Proposal)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if chdr.ChannelId != request.Channel {
+		return nil, errors.Errorf("the query is a query of channel %s, not of channel %s", chdr.ChannelId, request.Channel)
+	}
+	hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return nil, err
+	}
+	if hdrExt.GetChaincodeId().GetName() != request.Chaincode {
+		return nil, errors.Errorf("the query is a query of chaincode %s, not of chaincode %s", hdrExt.GetChaincodeId().GetName(), request.Chaincode)
+	}
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return nil, err
+	}
+	if !equalArgs(cis.GetChaincodeSpec().GetInput().GetArgs(), request.Args) {
+		return nil, errors.New("the arguments of the query are not the arguments of the request")
+	}
+
+	prp, err := utils.GetProposalResponsePayload(resp.Payload)
+	if err != nil {
+		return nil, err
+	}
+	proposalHash, err := utils.GetProposalHash1(hdr, prop.Payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(prp.ProposalHash, proposalHash) {
+		return nil, errors.New("the response is not a response to the query")
+	}
+	action, err := utils.GetChaincodeAction(prp.Extension)
+	if err != nil {
+		return nil, err
+	}
+	if action.GetResponse().GetStatus() != shim.OK {
+		return nil, errors.Errorf("the query failed with status %d: %s", action.GetResponse().GetStatus(), action.GetResponse().GetMessage())
+	}
+	return action.Response.Payload, nil
+}
+
+func equalArgs(args1, args2 [][]byte) bool {
+	if len(args1) != len(args2) {
+		return false
+	}
+	for i := range args1 {
+		if !bytes.Equal(args1[i], args2[i]) {
+			return false
+		}
+	}
+	return true
+}
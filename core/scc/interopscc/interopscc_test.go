@@ -0,0 +1,234 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package interopscc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sampleOrg returns the verifying MSP configuration and a signing identity of
+// the sample organization
+func sampleOrg(t *testing.T) ([]byte, msp.SigningIdentity) {
+	dir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	conf, err := msp.GetLocalMspConfig(dir, nil, "SampleOrg")
+	require.NoError(t, err)
+	localMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_3}})
+	require.NoError(t, err)
+	require.NoError(t, localMSP.Setup(conf))
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+
+	verifyingConf, err := msp.GetVerifyingMspConfig(dir, "SampleOrg", "bccsp")
+	require.NoError(t, err)
+	verifyingConfBytes, err := proto.Marshal(verifyingConf)
+	require.NoError(t, err)
+	return verifyingConfBytes, signer
+}
+
+// endorse returns the endorsement by the signer of the response to the query
+// of the request
+func endorse(t *testing.T, request *pb.ViewRequest, signer msp.SigningIdentity, response *pb.Response) *pb.ViewEndorsement {
+	creator, err := signer.Serialize()
+	require.NoError(t, err)
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+		ChaincodeId: &pb.ChaincodeID{Name: request.Chaincode},
+		Input:       &pb.ChaincodeInput{Args: request.Args},
+	}}
+	prop, _, err := utils.CreateChaincodeProposal(common.HeaderType_ENDORSER_TRANSACTION, request.Channel, cis, creator)
+	require.NoError(t, err)
+	resp, err := utils.CreateProposalResponse(prop.Header, prop.Payload, response, nil, nil, &pb.ChaincodeID{Name: request.Chaincode}, nil, signer)
+	require.NoError(t, err)
+	propBytes, err := proto.Marshal(prop)
+	require.NoError(t, err)
+	return &pb.ViewEndorsement{Proposal: propBytes, Response: resp}
+}
+
+func TestVerify(t *testing.T) {
+	mspConfig, signer := sampleOrg(t)
+	stub := shim.NewMockStub("interopscc", New(allowAll()))
+	resp := stub.MockInvoke("tx1", [][]byte{[]byte(RegisterNetwork), []byte("network2"), []byte("SampleOrg.member"), mspConfig})
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	network, err := getNetwork(stub, "network2")
+	require.NoError(t, err)
+
+	request := &pb.ViewRequest{Network: "network2", Channel: "channel1", Chaincode: "ftscc", Args: [][]byte{[]byte("GetLock"), []byte("tx1")}}
+	view := &pb.View{Request: request, Endorsements: []*pb.ViewEndorsement{endorse(t, request, signer, &pb.Response{Status: shim.OK, Payload: []byte("lock")})}}
+	payload, err := Verify(view, network)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("lock"), payload)
+
+	for _, tc := range []struct {
+		name        string
+		modify      func(view *pb.View)
+		expectedErr string
+	}{
+		{
+			name:        "no endorsements",
+			modify:      func(view *pb.View) { view.Endorsements = nil },
+			expectedErr: "the view has no request or no endorsements",
+		},
+		{
+			name:        "other network",
+			modify:      func(view *pb.View) { view.Request.Network = "network3" },
+			expectedErr: "the view is a view of network network3, not of network network2",
+		},
+		{
+			name:        "other channel",
+			modify:      func(view *pb.View) { view.Request.Channel = "channel2" },
+			expectedErr: "invalid endorsement 0 of the view: the query is a query of channel channel1, not of channel channel2",
+		},
+		{
+			name:        "other chaincode",
+			modify:      func(view *pb.View) { view.Request.Chaincode = "nftscc" },
+			expectedErr: "invalid endorsement 0 of the view: the query is a query of chaincode ftscc, not of chaincode nftscc",
+		},
+		{
+			name:        "other arguments",
+			modify:      func(view *pb.View) { view.Request.Args[1] = []byte("tx2") },
+			expectedErr: "invalid endorsement 0 of the view: the arguments of the query are not the arguments of the request",
+		},
+		{
+			name: "other proposal",
+			modify: func(view *pb.View) {
+				view.Endorsements[0].Proposal = endorse(t, view.Request, signer, &pb.Response{Status: shim.OK}).Proposal
+			},
+			expectedErr: "invalid endorsement 0 of the view: the response is not a response to the query",
+		},
+		{
+			name: "failed query",
+			modify: func(view *pb.View) {
+				view.Endorsements[0] = endorse(t, view.Request, signer, &pb.Response{Status: shim.ERROR, Message: "lock tx1 does not exist"})
+			},
+			expectedErr: "invalid endorsement 0 of the view: the query failed with status 500: lock tx1 does not exist",
+		},
+		{
+			name: "different responses",
+			modify: func(view *pb.View) {
+				view.Endorsements = append(view.Endorsements, endorse(t, view.Request, signer, &pb.Response{Status: shim.OK, Payload: []byte("other lock")}))
+			},
+			expectedErr: "the responses of endorsements 0 and 1 of the view differ",
+		},
+		{
+			name:        "not endorsed",
+			modify:      func(view *pb.View) { view.Endorsements[0].Response.Endorsement = nil },
+			expectedErr: "invalid endorsement 0 of the view: the response is not endorsed",
+		},
+		{
+			name:        "forged signature",
+			modify:      func(view *pb.View) { view.Endorsements[0].Response.Endorsement.Signature = []byte("forged") },
+			expectedErr: "the endorsements of the view do not satisfy the policy of network network2: signature set did not satisfy policy",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			view := proto.Clone(view).(*pb.View)
+			tc.modify(view)
+			_, err := Verify(view, network)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+
+	// the view is verified by the system chaincode against its network
+	viewBytes, err := proto.Marshal(view)
+	require.NoError(t, err)
+	resp = stub.MockInvoke("tx2", [][]byte{[]byte(VerifyView), viewBytes})
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	assert.Equal(t, []byte("lock"), resp.Payload)
+	view.Request.Network = "network3"
+	viewBytes, err = proto.Marshal(view)
+	require.NoError(t, err)
+	resp = stub.MockInvoke("tx3", [][]byte{[]byte(VerifyView), viewBytes})
+	assert.Equal(t, "network network3 is not registered", resp.Message)
+}
+
+func TestInvoke(t *testing.T) {
+	mspConfig, _ := sampleOrg(t)
+	aclProvider := allowAll()
+	stub := shim.NewMockStub("interopscc", New(aclProvider))
+
+	resp := stub.MockInvoke("tx1", [][]byte{[]byte(RegisterNetwork), []byte("network2"), []byte("SampleOrg.peer"), mspConfig})
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	resp = stub.MockInvoke("tx2", [][]byte{[]byte(GetNetwork), []byte("network2")})
+	require.EqualValues(t, shim.OK, resp.Status, resp.Message)
+	network := &pb.InteropNetwork{}
+	require.NoError(t, proto.Unmarshal(resp.Payload, network))
+	assert.Equal(t, "network2", network.Id)
+	assert.Len(t, network.Msps, 1)
+
+	for _, tc := range []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "no arguments",
+			args:        []string{RegisterNetwork},
+			expectedErr: "Incorrect number of arguments, 1",
+		},
+		{
+			name:        "unknown function",
+			args:        []string{"DeleteNetwork", "network2"},
+			expectedErr: "Requested function DeleteNetwork not found.",
+		},
+		{
+			name:        "no MSP",
+			args:        []string{RegisterNetwork, "network2", "SampleOrg.peer"},
+			expectedErr: "RegisterNetwork expects a network ID, a policy and at least one MSP configuration, got 2 arguments",
+		},
+		{
+			name:        "invalid policy",
+			args:        []string{RegisterNetwork, "network2", "SampleOrg", string(mspConfig)},
+			expectedErr: "invalid policy: ",
+		},
+		{
+			name:        "invalid MSP",
+			args:        []string{RegisterNetwork, "network2", "SampleOrg.peer", "garbage"},
+			expectedErr: "could not unmarshal the MSP configuration: ",
+		},
+		{
+			name:        "unregistered network",
+			args:        []string{GetNetwork, "network3"},
+			expectedErr: "network network3 is not registered",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var args [][]byte
+			for _, arg := range tc.args {
+				args = append(args, []byte(arg))
+			}
+			resp := stub.MockInvoke("tx3", args)
+			assert.EqualValues(t, shim.ERROR, resp.Status)
+			assert.Contains(t, resp.Message, tc.expectedErr)
+		})
+	}
+
+	aclProvider.Reset()
+	aclProvider.On("CheckACL", resources.Interopscc_RegisterNetwork, mock.Anything, mock.Anything).Return(errors.New("not a writer"))
+	resp = stub.MockInvoke("tx4", [][]byte{[]byte(RegisterNetwork), []byte("network2"), []byte("SampleOrg.peer"), mspConfig})
+	assert.Equal(t, "access denied for [RegisterNetwork][]: [not a writer]", resp.Message)
+}
+
+func allowAll() *mocks.MockACLProvider {
+	aclProvider := &mocks.MockACLProvider{}
+	aclProvider.Reset()
+	aclProvider.On("CheckACL", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return aclProvider
+}
@@ -7,84 +7,94 @@ SPDX-License-Identifier: Apache-2.0
 package scc
 
 import (
-	"fmt"
 	"os"
 	"plugin"
 	"sync"
 
 	"github.com/hyperledger/fabric/common/viperutil"
+	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/scc/api"
 	"github.com/pkg/errors"
 )
 
 const (
+	// sccFactoryMethod is the factory of the legacy plugins, which only
+	// return their chaincode
 	sccFactoryMethod = "New"
 )
 
 // PluginConfig SCC plugin configuration
 type PluginConfig struct {
-	Enabled           bool   `mapstructure:"enabled" yaml:"enabled"`
-	Name              string `mapstructure:"name" yaml:"name"`
-	Path              string `mapstructure:"path" yaml:"path"`
-	InvokableExternal bool   `mapstructure:"invokableExternal" yaml:"invokableExternal"`
-	InvokableCC2CC    bool   `mapstructure:"invokableCC2CC" yaml:"invokableCC2CC"`
+	Enabled           bool              `mapstructure:"enabled" yaml:"enabled"`
+	Name              string            `mapstructure:"name" yaml:"name"`
+	Path              string            `mapstructure:"path" yaml:"path"`
+	InvokableExternal bool              `mapstructure:"invokableExternal" yaml:"invokableExternal"`
+	InvokableCC2CC    bool              `mapstructure:"invokableCC2CC" yaml:"invokableCC2CC"`
+	ACLs              map[string]string `mapstructure:"acls" yaml:"acls"`
 }
 
 var once sync.Once
 var sccPlugins []*SystemChaincode
 
 // loadSysCCs reads system chaincode plugin configuration and loads them
-func loadSysCCs(p *Provider) []*SystemChaincode {
+func loadSysCCs(p *Provider, aclProvider aclmgmt.ACLProvider) []*SystemChaincode {
 	once.Do(func() {
 		var config []*PluginConfig
 		err := viperutil.EnhancedExactUnmarshalKey("chaincode.systemPlugins", &config)
 		if err != nil {
 			panic(errors.WithMessage(err, "could not load YAML config"))
 		}
-		loadSysCCsWithConfig(config)
+		loadSysCCsWithConfig(config, aclProvider)
 	})
 	return sccPlugins
 }
 
-func loadSysCCsWithConfig(configs []*PluginConfig) {
+func loadSysCCsWithConfig(configs []*PluginConfig, aclProvider aclmgmt.ACLProvider) {
 	for _, conf := range configs {
-		plugin := loadPlugin(conf.Path)
-		chaincode := &SystemChaincode{
-			Enabled:           conf.Enabled,
-			Name:              conf.Name,
-			Path:              conf.Path,
-			Chaincode:         *plugin,
-			InvokableExternal: conf.InvokableExternal,
-			InvokableCC2CC:    conf.InvokableCC2CC,
+		plugin, err := loadPlugin(conf.Path)
+		if err != nil {
+			panic(err)
+		}
+		chaincode, err := newPluginSysCC(conf, plugin, aclProvider)
+		if err != nil {
+			panic(err)
 		}
 		sccPlugins = append(sccPlugins, chaincode)
 		sysccLogger.Infof("Successfully loaded SCC %s from path %s", chaincode.Name, chaincode.Path)
 	}
 }
 
-func loadPlugin(path string) *shim.Chaincode {
+// loadPlugin opens the plugin at the given path, and creates the system chaincode
+// it exports with its api.FactoryName function, or with its legacy New function
+func loadPlugin(path string) (api.Plugin, error) {
 	if _, err := os.Stat(path); err != nil {
-		panic(fmt.Errorf("Could not find plugin at path %s: %s", path, err))
+		return nil, errors.Errorf("Could not find plugin at path %s: %s", path, err)
 	}
 
 	p, err := plugin.Open(path)
 	if err != nil {
-		panic(fmt.Errorf("Error opening plugin at path %s: %s", path, err))
+		return nil, errors.Errorf("Error opening plugin at path %s: %s", path, err)
+	}
+
+	if pluginFactorySymbol, err := p.Lookup(api.FactoryName); err == nil {
+		pluginFactory, ok := pluginFactorySymbol.(func() api.Plugin)
+		if !ok {
+			return nil, errors.Errorf("Function %s does not match expected definition func() api.Plugin", api.FactoryName)
+		}
+		return pluginFactory(), nil
 	}
 
 	sccFactorySymbol, err := p.Lookup(sccFactoryMethod)
 	if err != nil {
-		panic(fmt.Errorf(
-			"Could not find symbol %s. Plugin must export this method", sccFactoryMethod))
+		return nil, errors.Errorf("Could not find symbol %s or %s. Plugin must export one of these methods", api.FactoryName, sccFactoryMethod)
 	}
 
 	sccFactory, ok := sccFactorySymbol.(func() shim.Chaincode)
 	if !ok {
-		panic(fmt.Errorf("Function %s does not match expected definition func() shim.Chaincode",
-			sccFactoryMethod))
+		return nil, errors.Errorf("Function %s does not match expected definition func() shim.Chaincode", sccFactoryMethod)
 	}
 
-	scc := sccFactory()
-
-	return &scc
+	sysccLogger.Warningf("The plugin at path %s exports the legacy %s function, it should export %s", path, sccFactoryMethod, api.FactoryName)
+	return api.New(api.Descriptor{}, sccFactory()), nil
 }
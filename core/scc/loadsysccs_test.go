@@ -49,14 +49,18 @@ func TestLoadSCCPlugin(t *testing.T) {
 	viper.SetConfigType("yaml")
 	viper.ReadConfig(bytes.NewBuffer([]byte(testConfig)))
 
-	sccs := loadSysCCs(&Provider{})
+	sccs := loadSysCCs(&Provider{}, nil)
 	assert.Len(t, sccs, 1, "expected one SCC to be loaded")
-	resp := sccs[0].Chaincode.Invoke(nil)
+	stub := shim.NewMockStub(pluginName, sccs[0].Chaincode)
+	resp := stub.MockInvoke("1", [][]byte{[]byte("ping")})
 	assert.Equal(t, int32(shim.OK), resp.Status, "expected success response from scc")
+	assert.Equal(t, []byte("pong"), resp.Payload)
 }
 
 func TestLoadSCCPluginInvalid(t *testing.T) {
-	assert.Panics(t, func() { loadPlugin("missing.so") }, "expected panic with invalid path")
+	_, err := loadPlugin("missing.so")
+	assert.Error(t, err, "expected error with invalid path")
+	assert.Panics(t, func() { loadSysCCsWithConfig([]*PluginConfig{{Name: "missing", Path: "missing.so"}}, nil) }, "expected panic with invalid path")
 }
 
 // raceEnabled is set to true when the race build tag is enabled.
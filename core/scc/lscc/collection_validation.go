@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lscc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/common"
+	mb "github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// ChannelMembership provides the number of peers of each organization known
+// to be alive in a channel, the local peer excluded
+type ChannelMembership interface {
+	PeersByOrg(channelID string) (map[string]int, error)
+}
+
+// validateCollectionConfigs checks, when a chaincode is defined, that the
+// private data of its collections can be disseminated in the channel: their
+// member orgs must be members of the channel, their member orgs policy must
+// be satisfiable, and enough peers of the member orgs must be known in the
+// channel to satisfy their requiredPeerCount. The errors name the collection
+// and what to change, so that a definition doomed to fail at runtime is
+// rejected at its endorsement
+func (lscc *LifeCycleSysCC) validateCollectionConfigs(channelID string, collections *common.CollectionConfigPackage, mspmgr msp.MSPManager) error {
+	msps, err := mspmgr.GetMSPs()
+	if err != nil {
+		return errors.Wrapf(err, "error getting channel msp")
+	}
+
+	var peersByOrg map[string]int
+	if lscc.ChannelMembership != nil {
+		peersByOrg, err = lscc.ChannelMembership.PeersByOrg(channelID)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("could not retrieve the peers of channel %s", channelID))
+		}
+	}
+
+	names := map[string]struct{}{}
+	for _, collectionConfig := range collections.Config {
+		coll := collectionConfig.GetStaticCollectionConfig()
+		if coll == nil {
+			return errors.New("collection configuration is empty")
+		}
+		if _, ok := names[coll.Name]; ok {
+			return errors.Errorf("collection-name: %s -- the collection is defined more than once", coll.Name)
+		}
+		names[coll.Name] = struct{}{}
+
+		if coll.RequiredPeerCount < 0 {
+			return errors.Errorf("collection-name: %s -- requiredPeerCount (%d) cannot be negative", coll.Name, coll.RequiredPeerCount)
+		}
+		if coll.MaximumPeerCount < coll.RequiredPeerCount {
+			return errors.Errorf("collection-name: %s -- maximumPeerCount (%d) cannot be less than requiredPeerCount (%d)",
+				coll.Name, coll.MaximumPeerCount, coll.RequiredPeerCount)
+		}
+
+		policy := coll.GetMemberOrgsPolicy().GetSignaturePolicy()
+		if policy == nil {
+			return errors.Errorf("collection-name: %s -- the member orgs policy is not set", coll.Name)
+		}
+		if err := checkSignaturePolicyRule(policy.Rule, len(policy.Identities)); err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("collection-name: %s -- the member orgs policy can never be satisfied", coll.Name))
+		}
+
+		orgs, err := collectionMemberOrgs(policy, msps)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("collection-name: %s", coll.Name))
+		}
+		for _, org := range orgs {
+			if _, ok := msps[org]; !ok {
+				return errors.Errorf("collection-name: %s -- member org %s is not a member of channel %s, whose orgs are %s",
+					coll.Name, org, channelID, strings.Join(sortedMSPIDs(msps), ", "))
+			}
+		}
+		if err := checkAllowedRegions(coll.AllowedRegions, orgs); err != nil {
+			return errors.Errorf("collection-name: %s -- %s", coll.Name, err)
+		}
+
+		if peersByOrg == nil {
+			continue
+		}
+		peers := 0
+		for _, org := range orgs {
+			peers += peersByOrg[org]
+		}
+		if int(coll.RequiredPeerCount) > peers {
+			return errors.Errorf("collection-name: %s -- requiredPeerCount is %d but only %d peers of the member orgs %s are known in channel %s: "+
+				"the private data could not be disseminated, lower requiredPeerCount or add peers of the member orgs to the channel",
+				coll.Name, coll.RequiredPeerCount, peers, strings.Join(orgs, ", "), channelID)
+		}
+		if int(coll.MaximumPeerCount) > peers {
+			logger.Warningf("collection-name: %s -- maximumPeerCount is %d but only %d peers of the member orgs are known in channel %s",
+				coll.Name, coll.MaximumPeerCount, peers, channelID)
+		}
+	}
+	return nil
+}
+
+// checkAllowedRegions checks that the regions are allowed to member orgs of
+// the collection and that none of them is empty, as the peers of an org
+// without any region could not persist the private data
+func checkAllowedRegions(allowedRegions map[string]*common.CollectionRegions, orgs []string) error {
+	mspIDs := make([]string, 0, len(allowedRegions))
+	for mspID := range allowedRegions {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+
+	for _, mspID := range mspIDs {
+		member := false
+		for _, org := range orgs {
+			member = member || org == mspID
+		}
+		if !member {
+			return errors.Errorf("regions are allowed to org %s, which is not a member org of the collection", mspID)
+		}
+		regions := allowedRegions[mspID].GetRegions()
+		if len(regions) == 0 {
+			return errors.Errorf("no region is allowed to org %s, whose peers could not persist the private data", mspID)
+		}
+		for _, region := range regions {
+			if region == "" {
+				return errors.Errorf("an empty region is allowed to org %s", mspID)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSignaturePolicyRule checks that the rule only refers to existing
+// identities and requires no more of its sub-rules than it has
+func checkSignaturePolicyRule(rule *common.SignaturePolicy, identities int) error {
+	if rule == nil {
+		return errors.New("the policy has no rule")
+	}
+	switch t := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= identities {
+			return errors.Errorf("the rule refers to identity %d but the policy has %d identities", t.SignedBy, identities)
+		}
+	case *common.SignaturePolicy_NOutOf_:
+		if t.NOutOf == nil {
+			return errors.New("the policy has an empty n-out-of rule")
+		}
+		if int(t.NOutOf.N) > len(t.NOutOf.Rules) {
+			return errors.Errorf("a rule requires %d out of %d rules", t.NOutOf.N, len(t.NOutOf.Rules))
+		}
+		for _, sub := range t.NOutOf.Rules {
+			if err := checkSignaturePolicyRule(sub, identities); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.Errorf("the policy has a rule of unknown type %T", rule.Type)
+	}
+	return nil
+}
+
+// collectionMemberOrgs returns the sorted MSP IDs of the principals of the
+// member orgs policy of a collection
+func collectionMemberOrgs(policy *common.SignaturePolicyEnvelope, msps map[string]msp.MSP) ([]string, error) {
+	orgs := map[string]struct{}{}
+	for _, principal := range policy.Identities {
+		var orgID string
+		switch principal.PrincipalClassification {
+		case mb.MSPPrincipal_ROLE:
+			msprole := &mb.MSPRole{}
+			if err := proto.Unmarshal(principal.Principal, msprole); err != nil {
+				return nil, errors.Wrap(err, "cannot unmarshal identities")
+			}
+			orgID = msprole.MspIdentifier
+		case mb.MSPPrincipal_ORGANIZATION_UNIT:
+			mspou := &mb.OrganizationUnit{}
+			if err := proto.Unmarshal(principal.Principal, mspou); err != nil {
+				return nil, errors.Wrap(err, "cannot unmarshal identities")
+			}
+			orgID = mspou.MspIdentifier
+		case mb.MSPPrincipal_IDENTITY:
+			for mspID, m := range msps {
+				if _, err := m.DeserializeIdentity(principal.Principal); err == nil {
+					orgID = mspID
+					break
+				}
+			}
+			if orgID == "" {
+				return nil, errors.New("an identity of the member orgs policy belongs to no org of the channel")
+			}
+		default:
+			return nil, errors.Errorf("principal type %v is not supported", principal.PrincipalClassification)
+		}
+		orgs[orgID] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(orgs))
+	for org := range orgs {
+		sorted = append(sorted, org)
+	}
+	sort.Strings(sorted)
+	return sorted, nil
+}
+
+func sortedMSPIDs(msps map[string]msp.MSP) []string {
+	ids := make([]string, 0, len(msps))
+	for id := range msps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lscc
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockChannelMembership struct {
+	peersByOrg map[string]int
+	err        error
+}
+
+func (m *mockChannelMembership) PeersByOrg(channelID string) (map[string]int, error) {
+	return m.peersByOrg, m.err
+}
+
+func TestValidateCollectionConfigs(t *testing.T) {
+	mspmgr := mspmgmt.GetManagerForChain(chainid)
+	mspID, err := mspmgmt.GetLocalMSP().GetIdentifier()
+	assert.NoError(t, err)
+	policy := localMemberOrgsPolicy(t)
+
+	collections := func(configs ...*common.CollectionConfig) *common.CollectionConfigPackage {
+		return &common.CollectionConfigPackage{Config: configs}
+	}
+	withRegions := func(config *common.CollectionConfig, mspID string, regions ...string) *common.CollectionConfig {
+		config.GetStaticCollectionConfig().AllowedRegions = map[string]*common.CollectionRegions{
+			mspID: {Regions: regions},
+		}
+		return config
+	}
+
+	tests := []struct {
+		name        string
+		collections *common.CollectionConfigPackage
+		membership  ChannelMembership
+		expectedErr string
+	}{
+		{
+			name:        "valid",
+			collections: collections(createCollectionConfig("coll1", policy, 1, 3), createCollectionConfig("coll2", policy, 0, 0)),
+			membership:  &mockChannelMembership{peersByOrg: map[string]int{mspID: 2}},
+		},
+		{
+			name:        "valid without membership",
+			collections: collections(createCollectionConfig("coll1", policy, 5, 5)),
+		},
+		{
+			name:        "duplicate collection",
+			collections: collections(createCollectionConfig("coll1", policy, 0, 1), createCollectionConfig("coll1", policy, 0, 1)),
+			expectedErr: "collection-name: coll1 -- the collection is defined more than once",
+		},
+		{
+			name:        "negative requiredPeerCount",
+			collections: collections(createCollectionConfig("coll1", policy, -1, 1)),
+			expectedErr: "collection-name: coll1 -- requiredPeerCount (-1) cannot be negative",
+		},
+		{
+			name:        "maximumPeerCount less than requiredPeerCount",
+			collections: collections(createCollectionConfig("coll1", policy, 2, 1)),
+			expectedErr: "collection-name: coll1 -- maximumPeerCount (1) cannot be less than requiredPeerCount (2)",
+		},
+		{
+			name:        "no policy",
+			collections: collections(createCollectionConfig("coll1", nil, 0, 1)),
+			expectedErr: "collection-name: coll1 -- the member orgs policy is not set",
+		},
+		{
+			name:        "policy without rule",
+			collections: collections(createCollectionConfig("coll1", &common.SignaturePolicyEnvelope{}, 0, 1)),
+			expectedErr: "collection-name: coll1 -- the member orgs policy can never be satisfied: the policy has no rule",
+		},
+		{
+			name: "policy referring to a missing identity",
+			collections: collections(createCollectionConfig("coll1", &common.SignaturePolicyEnvelope{
+				Rule:       cauthdsl.SignedBy(1),
+				Identities: policy.Identities,
+			}, 0, 1)),
+			expectedErr: "collection-name: coll1 -- the member orgs policy can never be satisfied: the rule refers to identity 1 but the policy has 1 identities",
+		},
+		{
+			name: "policy requiring too many rules",
+			collections: collections(createCollectionConfig("coll1", &common.SignaturePolicyEnvelope{
+				Rule:       cauthdsl.NOutOf(2, []*common.SignaturePolicy{cauthdsl.SignedBy(0)}),
+				Identities: policy.Identities,
+			}, 0, 1)),
+			expectedErr: "collection-name: coll1 -- the member orgs policy can never be satisfied: a rule requires 2 out of 1 rules",
+		},
+		{
+			name:        "valid allowed regions",
+			collections: collections(withRegions(createCollectionConfig("coll1", policy, 0, 1), mspID, "eu-west", "eu-central")),
+		},
+		{
+			name:        "regions allowed to a non member org",
+			collections: collections(withRegions(createCollectionConfig("coll1", policy, 0, 1), "Org2MSP", "eu-west")),
+			expectedErr: "collection-name: coll1 -- regions are allowed to org Org2MSP, which is not a member org of the collection",
+		},
+		{
+			name:        "no region allowed",
+			collections: collections(withRegions(createCollectionConfig("coll1", policy, 0, 1), mspID)),
+			expectedErr: "collection-name: coll1 -- no region is allowed to org " + mspID + ", whose peers could not persist the private data",
+		},
+		{
+			name:        "empty region allowed",
+			collections: collections(withRegions(createCollectionConfig("coll1", policy, 0, 1), mspID, "eu-west", "")),
+			expectedErr: "collection-name: coll1 -- an empty region is allowed to org " + mspID,
+		},
+		{
+			name:        "member org not in the channel",
+			collections: collections(createCollectionConfig("coll1", cauthdsl.SignedByAnyMember([]string{mspID, "Org2MSP"}), 0, 1)),
+			expectedErr: "collection-name: coll1 -- member org Org2MSP is not a member of channel " + chainid + ", whose orgs are " + mspID,
+		},
+		{
+			name:        "not enough peers",
+			collections: collections(createCollectionConfig("coll1", policy, 2, 3)),
+			membership:  &mockChannelMembership{peersByOrg: map[string]int{mspID: 1, "Org2MSP": 4}},
+			expectedErr: "collection-name: coll1 -- requiredPeerCount is 2 but only 1 peers of the member orgs " + mspID + " are known in channel " + chainid +
+				": the private data could not be disseminated, lower requiredPeerCount or add peers of the member orgs to the channel",
+		},
+		{
+			name:        "membership failure",
+			collections: collections(createCollectionConfig("coll1", policy, 0, 1)),
+			membership:  &mockChannelMembership{err: errors.New("gossip is not initialized")},
+			expectedErr: "could not retrieve the peers of channel " + chainid + ": gossip is not initialized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scc := &LifeCycleSysCC{ChannelMembership: tt.membership}
+			err := scc.validateCollectionConfigs(chainid, tt.collections, mspmgr)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -140,3 +140,52 @@ type PrivateChannelDataNotAvailable string
 func (f PrivateChannelDataNotAvailable) Error() string {
 	return "as V1_2 or later capability is not enabled, private channel collections and data are not available"
 }
+
+// ChaincodeMigrationNotAllowed when the V1_4_CHAINCODE_MIGRATION_EXPERIMENTAL capability is not enabled
+type ChaincodeMigrationNotAllowed string
+
+func (f ChaincodeMigrationNotAllowed) Error() string {
+	return "as V1_4_CHAINCODE_MIGRATION_EXPERIMENTAL capability is not enabled, chaincode migrations are not allowed"
+}
+
+// InvalidAnnotationErr invalid annotation of a chaincode definition
+type InvalidAnnotationErr string
+
+func (f InvalidAnnotationErr) Error() string {
+	return fmt.Sprintf("invalid chaincode definition annotations: %s", string(f))
+}
+
+// ChaincodeContractsNotAllowed when the CHAINCODE_CONTRACTS capability is not enabled
+type ChaincodeContractsNotAllowed string
+
+func (f ChaincodeContractsNotAllowed) Error() string {
+	return "as CHAINCODE_CONTRACTS capability is not enabled, chaincode contracts are not allowed"
+}
+
+// BinaryChaincodesNotAllowed when the BINARY_CHAINCODES capability is not enabled
+type BinaryChaincodesNotAllowed string
+
+func (f BinaryChaincodesNotAllowed) Error() string {
+	return "as BINARY_CHAINCODES capability is not enabled, binary chaincodes are not allowed"
+}
+
+// InvalidContractErr invalid contract of a chaincode definition
+type InvalidContractErr string
+
+func (f InvalidContractErr) Error() string {
+	return fmt.Sprintf("invalid chaincode definition contracts: %s", string(f))
+}
+
+// MigrationWithInitRequiredErr when a chaincode requiring initialization is upgraded with a migration
+type MigrationWithInitRequiredErr string
+
+func (f MigrationWithInitRequiredErr) Error() string {
+	return fmt.Sprintf("chaincode %s requires initialization and cannot be migrated upon upgrade", string(f))
+}
+
+// MigrationOnDeployErr when a chaincode is instantiated with a migration
+type MigrationOnDeployErr string
+
+func (f MigrationOnDeployErr) Error() string {
+	return fmt.Sprintf("cannot instantiate chaincode with name '%s' with a migration, migrations are only allowed upon upgrade", string(f))
+}
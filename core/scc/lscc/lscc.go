@@ -94,6 +94,16 @@ const (
 
 	allowedChaincodeName = "^[a-zA-Z0-9]+([-_][a-zA-Z0-9]+)*$"
 	allowedCharsVersion  = "[A-Za-z0-9_.+-]+"
+	allowedAnnotationKey = "^[a-zA-Z0-9]+([-_./][a-zA-Z0-9]+)*$"
+
+	// maxAnnotations is the maximum number of annotations of a chaincode definition
+	maxAnnotations = 32
+	// maxAnnotationKeyLength is the maximum length of the key of an annotation
+	maxAnnotationKeyLength = 128
+	// maxAnnotationValueLength is the maximum length of the value of an annotation
+	maxAnnotationValueLength = 1024
+	// maxContracts is the maximum number of contracts of a chaincode definition
+	maxContracts = 64
 )
 
 // FilesystemSupport contains functions that LSCC requires to execute its tasks
@@ -139,6 +149,10 @@ type LifeCycleSysCC struct {
 	Support FilesystemSupport
 
 	PlatformRegistry *platforms.Registry
+
+	// ChannelMembership provides the peers of the channels, against which
+	// the collections of the chaincodes are validated when set
+	ChannelMembership ChannelMembership
 }
 
 // New creates a new instance of the LSCC
@@ -203,7 +217,7 @@ func (lscc *LifeCycleSysCC) ChaincodeDefinition(chaincodeName string, qe ledger.
 
 //create the chaincode on the given chain
 func (lscc *LifeCycleSysCC) putChaincodeData(stub shim.ChaincodeStubInterface, cd *ccprovider.ChaincodeData) error {
-	cdbytes, err := proto.Marshal(cd)
+	cdbytes, err := marshalChaincodeData(cd)
 	if err != nil {
 		return err
 	}
@@ -325,6 +339,9 @@ func (lscc *LifeCycleSysCC) putChaincodeCollectionData(stub shim.ChaincodeStubIn
 			return errors.Wrapf(err, "collection member policy check failed")
 		}
 	}
+	if err := lscc.validateCollectionConfigs(stub.GetChannelID(), collections, mspmgr); err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("invalid collection configuration for chaincode %s:%s", cd.Name, cd.Version))
+	}
 
 	key := privdata.BuildCollectionKVSKey(cd.Name)
 
@@ -445,7 +462,7 @@ func (lscc *LifeCycleSysCC) getChaincodes(stub shim.ChaincodeStubInterface) pb.R
 		}
 
 		// add this specific chaincode's metadata to the array of all chaincodes
-		ccInfo := &pb.ChaincodeInfo{Name: ccdata.Name, Version: ccdata.Version, Path: path, Input: input, Escc: ccdata.Escc, Vscc: ccdata.Vscc}
+		ccInfo := &pb.ChaincodeInfo{Name: ccdata.Name, Version: ccdata.Version, Path: path, Input: input, Escc: ccdata.Escc, Vscc: ccdata.Vscc, Annotations: ccdata.Annotations}
 		ccInfoArray = append(ccInfoArray, ccInfo)
 	}
 	// add array with info about all instantiated chaincodes to the query
@@ -515,6 +532,58 @@ func (lscc *LifeCycleSysCC) isValidChaincodeVersion(chaincodeName string, versio
 	return nil
 }
 
+// isValidAnnotations checks the validity of the annotations of a chaincode
+// definition. Their keys should only consist of alphanumerics separated by
+// '-', '_', '.', or '/', and their number and size are limited
+func isValidAnnotations(annotations map[string]string) error {
+	if len(annotations) > maxAnnotations {
+		return InvalidAnnotationErr(fmt.Sprintf("%d annotations exceed the maximum of %d", len(annotations), maxAnnotations))
+	}
+	for key, value := range annotations {
+		if len(key) > maxAnnotationKeyLength || !isValidCCNameOrVersion(key, allowedAnnotationKey) {
+			return InvalidAnnotationErr(fmt.Sprintf("invalid key '%s'", key))
+		}
+		if len(value) > maxAnnotationValueLength {
+			return InvalidAnnotationErr(fmt.Sprintf("the value of '%s' exceeds the maximum length of %d", key, maxAnnotationValueLength))
+		}
+	}
+	return nil
+}
+
+// isValidContracts checks the validity of the contracts of a chaincode
+// definition. Their names follow the rules of the chaincode names and their
+// endorsement policies should be signature policies
+func isValidContracts(contracts map[string][]byte) error {
+	if len(contracts) > maxContracts {
+		return InvalidContractErr(fmt.Sprintf("%d contracts exceed the maximum of %d", len(contracts), maxContracts))
+	}
+	for name, policy := range contracts {
+		if !isValidCCNameOrVersion(name, allowedChaincodeName) {
+			return InvalidContractErr(fmt.Sprintf("invalid name '%s'", name))
+		}
+		if len(policy) == 0 {
+			return InvalidContractErr(fmt.Sprintf("contract '%s' has no endorsement policy", name))
+		}
+		env := &common.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policy, env); err != nil || env.Rule == nil {
+			return InvalidContractErr(fmt.Sprintf("the endorsement policy of contract '%s' is not a signature policy", name))
+		}
+	}
+	return nil
+}
+
+// marshalChaincodeData marshals the chaincode data deterministically, so that all the
+// endorsers of a deploy or upgrade transaction produce the same bytes whatever the
+// order in which the annotations are iterated over
+func marshalChaincodeData(cd *ccprovider.ChaincodeData) ([]byte, error) {
+	buf := proto.NewBuffer(nil)
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(cd); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 func isValidCCNameOrVersion(ccNameOrVersion string, regExp string) bool {
 	re, _ := regexp.Compile(regExp)
 
@@ -574,6 +643,11 @@ func (lscc *LifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccb
 		return errors.Errorf("cannot install: %s is the name of a system chaincode", cds.ChaincodeSpec.ChaincodeId.Name)
 	}
 
+	// the code package is checked before any of its content is extracted
+	if err = lscc.PlatformRegistry.CheckPackageLimits(cds.CodePackage); err != nil {
+		return err
+	}
+
 	// Get any statedb artifacts from the chaincode package, e.g. couchdb index definitions
 	statedbArtifactsTar, err := ccprovider.ExtractStatedbArtifactsFromCCPackage(ccpack, lscc.PlatformRegistry)
 	if err != nil {
@@ -632,6 +706,14 @@ func (lscc *LifeCycleSysCC) executeDeployOrUpgrade(
 		return nil, err
 	}
 
+	if err := isValidAnnotations(cds.Annotations); err != nil {
+		return nil, err
+	}
+
+	if err := isValidContracts(cds.Contracts); err != nil {
+		return nil, err
+	}
+
 	ccpack, err := lscc.Support.GetChaincodeFromLocalStorage(chaincodeName, chaincodeVersion)
 	if err != nil {
 		retErrMsg := fmt.Sprintf("cannot get package for chaincode (%s:%s)", chaincodeName, chaincodeVersion)
@@ -674,6 +756,9 @@ func (lscc *LifeCycleSysCC) executeDeploy(
 	cdfs.Escc = string(escc)
 	cdfs.Vscc = string(vscc)
 	cdfs.Policy = policy
+	cdfs.Annotations = cds.Annotations
+	cdfs.Contracts = cds.Contracts
+	cdfs.InitRequired = cds.InitRequired
 
 	// retrieve and evaluate instantiation policy
 	cdfs.InstantiationPolicy, err = lscc.Support.GetInstantiationPolicy(chainname, ccpackfs)
@@ -745,6 +830,12 @@ func (lscc *LifeCycleSysCC) executeUpgrade(stub shim.ChaincodeStubInterface, cha
 	cdfs.Escc = string(escc)
 	cdfs.Vscc = string(vscc)
 	cdfs.Policy = policy
+	// the annotations of the definition are replaced by the ones of the transaction
+	cdfs.Annotations = cds.Annotations
+	// so are the contracts, whose endorsement policies follow the new version
+	cdfs.Contracts = cds.Contracts
+	// a new version requiring initialization has to be initialized again
+	cdfs.InitRequired = cds.InitRequired
 
 	// retrieve and evaluate new instantiation policy
 	cdfs.InstantiationPolicy, err = lscc.Support.GetInstantiationPolicy(chainName, ccpackfs)
@@ -900,11 +991,39 @@ func (lscc *LifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 			collectionsConfig = args[6]
 		}
 
+		// a migration invokes the Migrate function of the upgraded chaincode
+		// instead of its Init function, which is only supported with the
+		// ChaincodeMigration capability
+		if cds.Migrate {
+			if !ac.Capabilities().ChaincodeMigration() {
+				return shim.Error(ChaincodeMigrationNotAllowed("").Error())
+			}
+			if function == DEPLOY {
+				return shim.Error(MigrationOnDeployErr(cds.ChaincodeSpec.ChaincodeId.Name).Error())
+			}
+			// a chaincode requiring initialization runs no function upon upgrade
+			if cds.InitRequired {
+				return shim.Error(MigrationWithInitRequiredErr(cds.ChaincodeSpec.ChaincodeId.Name).Error())
+			}
+		}
+
+		// the contracts of a chaincode definition are only validated with their own
+		// endorsement policy by the committers supporting the ChaincodeContracts capability
+		if len(cds.Contracts) > 0 && !ac.Capabilities().ChaincodeContracts() {
+			return shim.Error(ChaincodeContractsNotAllowed("").Error())
+		}
+
+		// the deployments of the binary chaincodes are only validated by the
+		// committers supporting the BinaryChaincodes capability
+		if cds.ChaincodeSpec.Type == pb.ChaincodeSpec_BINARY && !ac.Capabilities().BinaryChaincodes() {
+			return shim.Error(BinaryChaincodesNotAllowed("").Error())
+		}
+
 		cd, err := lscc.executeDeployOrUpgrade(stub, channel, cds, EP, escc, vscc, collectionsConfig, function)
 		if err != nil {
 			return shim.Error(err.Error())
 		}
-		cdbytes, err := proto.Marshal(cd)
+		cdbytes, err := marshalChaincodeData(cd)
 		if err != nil {
 			return shim.Error(err.Error())
 		}
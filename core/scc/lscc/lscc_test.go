@@ -44,6 +44,7 @@ import (
 	"github.com/hyperledger/fabric/protos/utils"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -131,6 +132,11 @@ func TestInstall(t *testing.T) {
 	testInstall(t, "example02-2", "1.0-alpha+001", path, false, "", "Alice", scc, stub)
 	testInstall(t, "example02-2", "1.0+sha.c0ffee", path, false, "", "Alice", scc, stub)
 
+	maxPackageSize := viper.Get("chaincode.packageValidation.maxPackageSize")
+	viper.Set("chaincode.packageValidation.maxPackageSize", 10)
+	testInstall(t, "example02-3", "0", path, false, "code package exceeds the maximum package size", "Alice", scc, stub)
+	viper.Set("chaincode.packageValidation.maxPackageSize", maxPackageSize)
+
 	scc.Support.(*lscc.MockSupport).PutChaincodeToLocalStorageErr = errors.New("barf")
 
 	testInstall(t, "example02", "0", path, false, "barf", "Alice", scc, stub)
@@ -303,6 +309,14 @@ func TestDeploy(t *testing.T) {
 	assert.Equal(t, true, ok)
 }
 
+// localMemberOrgsPolicy returns a member orgs policy of the org of the local
+// MSP, which is the member of the test channel
+func localMemberOrgsPolicy(t *testing.T) *common.SignaturePolicyEnvelope {
+	mspID, err := mspmgmt.GetLocalMSP().GetIdentifier()
+	assert.NoError(t, err)
+	return cauthdsl.SignedByAnyMember([]string{mspID})
+}
+
 func createCollectionConfig(collectionName string, signaturePolicyEnvelope *common.SignaturePolicyEnvelope,
 	requiredPeerCount int32, maximumPeerCount int32,
 ) *common.CollectionConfig {
@@ -500,7 +514,7 @@ func TestUpgrade(t *testing.T) {
 	scc.Support.(*lscc.MockSupport).GetInstantiationPolicyRv = []byte("instantiation policy")
 
 	collName1 := "mycollection1"
-	policyEnvelope := &common.SignaturePolicyEnvelope{}
+	policyEnvelope := localMemberOrgsPolicy(t)
 	var requiredPeerCount, maximumPeerCount int32
 	requiredPeerCount = 1
 	maximumPeerCount = 2
@@ -569,6 +583,7 @@ func testUpgrade(t *testing.T, ccname string, version string, newccname string,
 		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
 		scc.Support.(*lscc.MockSupport).GetInstantiationPolicyRv = []byte("instantiation policy")
 	}
+	stub.ChannelID = chainid
 
 	cds, err := constructDeploymentSpec(ccname, path, version, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, false, true, scc)
 	assert.NoError(t, err)
@@ -623,6 +638,248 @@ func testUpgrade(t *testing.T, ccname string, version string, newccname string,
 	}
 }
 
+func TestUpgradeWithMigration(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+	initArgs := [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}
+
+	setup := func(capabilities *config.MockApplicationCapabilities) (*LifeCycleSysCC, *shim.MockStub) {
+		mocksccProvider := (&mscc.MocksccProviderFactory{
+			ApplicationConfigBool: true,
+			ApplicationConfigRv:   &config.MockApplication{CapabilitiesRv: capabilities},
+		}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+		scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+		scc.Support = &lscc.MockSupport{GetInstantiationPolicyRv: []byte("instantiation policy")}
+		stub := shim.NewMockStub("lscc", scc)
+		res := stub.MockInit("1", nil)
+		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+		return scc, stub
+	}
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	invoke := func(scc *LifeCycleSysCC, stub *shim.MockStub, function, version string, migrate bool) pb.Response {
+		cds, err := constructDeploymentSpec("example02", path, version, initArgs, false, true, scc)
+		assert.NoError(t, err)
+		cds.Migrate = migrate
+		args := [][]byte{[]byte(function), []byte("test"), utils.MarshalOrPanic(cds)}
+		return stub.MockInvokeWithSignedProposal("1", args, sProp)
+	}
+
+	// the migrations are rejected when the capability is not enabled
+	scc, stub := setup(&config.MockApplicationCapabilities{})
+	res := invoke(scc, stub, "deploy", "0", false)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	res = invoke(scc, stub, "upgrade", "1", true)
+	assert.Equal(t, ChaincodeMigrationNotAllowed("").Error(), res.Message)
+
+	scc, stub = setup(&config.MockApplicationCapabilities{ChaincodeMigrationRv: true})
+	res = invoke(scc, stub, "deploy", "0", true)
+	assert.Equal(t, MigrationOnDeployErr("example02").Error(), res.Message)
+	res = invoke(scc, stub, "deploy", "0", false)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	res = invoke(scc, stub, "upgrade", "1", true)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+}
+
+func TestDeployAndUpgradeInitRequired(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+	initArgs := [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}
+
+	mocksccProvider := (&mscc.MocksccProviderFactory{
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &config.MockApplication{CapabilitiesRv: &config.MockApplicationCapabilities{ChaincodeMigrationRv: true}},
+	}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+	scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	scc.Support = &lscc.MockSupport{GetInstantiationPolicyRv: []byte("instantiation policy")}
+	stub := shim.NewMockStub("lscc", scc)
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	invoke := func(function, version string, initRequired, migrate bool) pb.Response {
+		cds, err := constructDeploymentSpec("example02", path, version, initArgs, false, true, scc)
+		assert.NoError(t, err)
+		cds.InitRequired = initRequired
+		cds.Migrate = migrate
+		args := [][]byte{[]byte(function), []byte("test"), utils.MarshalOrPanic(cds)}
+		return stub.MockInvokeWithSignedProposal("1", args, sProp)
+	}
+	definition := func() *ccprovider.ChaincodeData {
+		cd := &ccprovider.ChaincodeData{}
+		assert.NoError(t, proto.Unmarshal(stub.State["example02"], cd))
+		return cd
+	}
+
+	res = invoke("deploy", "0", true, false)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.True(t, definition().InitRequired)
+
+	res = invoke("upgrade", "1", true, true)
+	assert.Equal(t, MigrationWithInitRequiredErr("example02").Error(), res.Message)
+
+	res = invoke("upgrade", "1", false, true)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.False(t, definition().InitRequired)
+
+	res = invoke("upgrade", "2", true, false)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.True(t, definition().InitRequired)
+}
+
+func TestDeployAndUpgradeWithAnnotations(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+	initArgs := [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}
+
+	scc := New(NewMockProvider(), mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	scc.Support = &lscc.MockSupport{GetInstantiationPolicyRv: []byte("instantiation policy")}
+	stub := shim.NewMockStub("lscc", scc)
+	stub.ChannelID = chainid
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	invoke := func(function, version string, annotations map[string]string) pb.Response {
+		cds, err := constructDeploymentSpec("example02", path, version, initArgs, false, true, scc)
+		assert.NoError(t, err)
+		cds.Annotations = annotations
+		args := [][]byte{[]byte(function), []byte("test"), utils.MarshalOrPanic(cds)}
+		return stub.MockInvokeWithSignedProposal("1", args, sProp)
+	}
+	definition := func() *ccprovider.ChaincodeData {
+		cd := &ccprovider.ChaincodeData{}
+		assert.NoError(t, proto.Unmarshal(stub.State["example02"], cd))
+		return cd
+	}
+
+	res = invoke("deploy", "0", map[string]string{"owner/team": "payments", "bad key": "value"})
+	assert.Equal(t, "invalid chaincode definition annotations: invalid key 'bad key'", res.Message)
+	res = invoke("deploy", "0", map[string]string{"commit": strings.Repeat("a", maxAnnotationValueLength+1)})
+	assert.Equal(t, "invalid chaincode definition annotations: the value of 'commit' exceeds the maximum length of 1024", res.Message)
+
+	res = invoke("deploy", "0", map[string]string{"owner/team": "payments", "slo.tier": "gold"})
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, map[string]string{"owner/team": "payments", "slo.tier": "gold"}, definition().Annotations)
+
+	mockAclProvider.Reset()
+	mockAclProvider.On("CheckACL", resources.Lscc_GetInstantiatedChaincodes, chainid, sProp).Return(nil)
+	res = stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("getchaincodes")}, sProp)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	cqr := &pb.ChaincodeQueryResponse{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, cqr))
+	assert.Len(t, cqr.Chaincodes, 1)
+	assert.Equal(t, map[string]string{"owner/team": "payments", "slo.tier": "gold"}, cqr.Chaincodes[0].Annotations)
+
+	// the annotations are replaced upon upgrade
+	res = invoke("upgrade", "1", map[string]string{"commit": "8a3f2c1"})
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, map[string]string{"commit": "8a3f2c1"}, definition().Annotations)
+}
+
+func TestDeployBinaryChaincode(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+	initArgs := [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}
+
+	capabilities := &config.MockApplicationCapabilities{}
+	mocksccProvider := (&mscc.MocksccProviderFactory{
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &config.MockApplication{CapabilitiesRv: capabilities},
+	}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+	scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	scc.Support = &lscc.MockSupport{GetInstantiationPolicyRv: []byte("instantiation policy")}
+	stub := shim.NewMockStub("lscc", scc)
+	stub.ChannelID = chainid
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	cds, err := constructDeploymentSpec("example02", path, "0", initArgs, false, true, scc)
+	assert.NoError(t, err)
+	cds.ChaincodeSpec.Type = pb.ChaincodeSpec_BINARY
+	args := [][]byte{[]byte("deploy"), []byte("test"), utils.MarshalOrPanic(cds)}
+
+	res = stub.MockInvokeWithSignedProposal("1", args, sProp)
+	assert.Equal(t, BinaryChaincodesNotAllowed("").Error(), res.Message)
+
+	capabilities.BinaryChaincodesRv = true
+	res = stub.MockInvokeWithSignedProposal("1", args, sProp)
+	assert.NotEqual(t, BinaryChaincodesNotAllowed("").Error(), res.Message)
+}
+
+func TestDeployAndUpgradeWithContracts(t *testing.T) {
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd"
+	initArgs := [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}
+
+	capabilities := &config.MockApplicationCapabilities{}
+	mocksccProvider := (&mscc.MocksccProviderFactory{
+		ApplicationConfigBool: true,
+		ApplicationConfigRv:   &config.MockApplication{CapabilitiesRv: capabilities},
+	}).NewSystemChaincodeProvider().(*mscc.MocksccProviderImpl)
+	scc := New(mocksccProvider, mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
+	scc.Support = &lscc.MockSupport{GetInstantiationPolicyRv: []byte("instantiation policy")}
+	stub := shim.NewMockStub("lscc", scc)
+	stub.ChannelID = chainid
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+
+	sProp, _ := putils.MockSignedEndorserProposal2OrPanic(chainid, &pb.ChaincodeSpec{}, id)
+	invoke := func(function, version string, contracts map[string][]byte) pb.Response {
+		cds, err := constructDeploymentSpec("example02", path, version, initArgs, false, true, scc)
+		assert.NoError(t, err)
+		cds.Contracts = contracts
+		args := [][]byte{[]byte(function), []byte("test"), utils.MarshalOrPanic(cds)}
+		return stub.MockInvokeWithSignedProposal("1", args, sProp)
+	}
+	definition := func() *ccprovider.ChaincodeData {
+		cd := &ccprovider.ChaincodeData{}
+		assert.NoError(t, proto.Unmarshal(stub.State["example02"], cd))
+		return cd
+	}
+
+	transfersPolicy := utils.MarshalOrPanic(cauthdsl.SignedByAnyMember([]string{"Org1MSP", "Org2MSP"}))
+	auditPolicy := utils.MarshalOrPanic(cauthdsl.SignedByAnyMember([]string{"Org3MSP"}))
+
+	res = invoke("deploy", "0", map[string][]byte{"transfers": transfersPolicy})
+	assert.Equal(t, ChaincodeContractsNotAllowed("").Error(), res.Message)
+
+	capabilities.ChaincodeContractsRv = true
+	res = invoke("deploy", "0", map[string][]byte{"transfers:v2": transfersPolicy})
+	assert.Equal(t, "invalid chaincode definition contracts: invalid name 'transfers:v2'", res.Message)
+	res = invoke("deploy", "0", map[string][]byte{"transfers": nil})
+	assert.Equal(t, "invalid chaincode definition contracts: contract 'transfers' has no endorsement policy", res.Message)
+	res = invoke("deploy", "0", map[string][]byte{"transfers": []byte("policy")})
+	assert.Equal(t, "invalid chaincode definition contracts: the endorsement policy of contract 'transfers' is not a signature policy", res.Message)
+
+	res = invoke("deploy", "0", map[string][]byte{"transfers": transfersPolicy, "audit": auditPolicy})
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, map[string][]byte{"transfers": transfersPolicy, "audit": auditPolicy}, definition().Contracts)
+
+	// the contracts are replaced upon upgrade
+	res = invoke("upgrade", "1", map[string][]byte{"audit": transfersPolicy})
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	assert.Equal(t, map[string][]byte{"audit": transfersPolicy}, definition().Contracts)
+
+	contracts := map[string][]byte{}
+	for i := 0; i <= maxContracts; i++ {
+		contracts[fmt.Sprintf("contract%d", i)] = auditPolicy
+	}
+	assert.EqualError(t, isValidContracts(contracts), "invalid chaincode definition contracts: 65 contracts exceed the maximum of 64")
+}
+
+func TestMarshalChaincodeData(t *testing.T) {
+	cd := &ccprovider.ChaincodeData{Name: "mycc", Version: "1", Annotations: map[string]string{}}
+	for i := 0; i < maxAnnotations; i++ {
+		cd.Annotations[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	expected, err := marshalChaincodeData(cd)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		cdBytes, err := marshalChaincodeData(cd)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, cdBytes)
+	}
+
+	cd.Annotations["key"] = "value"
+	assert.EqualError(t, isValidAnnotations(cd.Annotations), "invalid chaincode definition annotations: 33 annotations exceed the maximum of 32")
+}
+
 func TestFunctionsWithAliases(t *testing.T) {
 	scc := New(NewMockProvider(), mockAclProvider, platforms.NewRegistry(&golang.Platform{}))
 	scc.Support = &lscc.MockSupport{}
@@ -846,6 +1103,7 @@ func TestErrors(t *testing.T) {
 func TestPutChaincodeCollectionData(t *testing.T) {
 	scc := new(LifeCycleSysCC)
 	stub := shim.NewMockStub("lscc", scc)
+	stub.ChannelID = chainid
 	scc.Support = &lscc.MockSupport{}
 
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
@@ -862,7 +1120,7 @@ func TestPutChaincodeCollectionData(t *testing.T) {
 	assert.NoError(t, err)
 
 	collName1 := "mycollection1"
-	policyEnvelope := &common.SignaturePolicyEnvelope{}
+	policyEnvelope := localMemberOrgsPolicy(t)
 	coll1 := createCollectionConfig(collName1, policyEnvelope, 1, 2)
 	ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{coll1}}
 	ccpBytes, err := proto.Marshal(ccp)
@@ -885,11 +1143,12 @@ func TestGetChaincodeCollectionData(t *testing.T) {
 	stub := shim.NewMockStub("lscc", scc)
 	stub.ChannelID = "test"
 	scc.Support = &lscc.MockSupport{}
+	mspmgmt.GetManagerForChain("test").Setup([]msp.MSP{mspmgmt.GetLocalMSP()})
 
 	cd := &ccprovider.ChaincodeData{Name: "foo"}
 
 	collName1 := "mycollection1"
-	policyEnvelope := &common.SignaturePolicyEnvelope{}
+	policyEnvelope := localMemberOrgsPolicy(t)
 	coll1 := createCollectionConfig(collName1, policyEnvelope, 1, 2)
 	ccp := &common.CollectionConfigPackage{Config: []*common.CollectionConfig{coll1}}
 	ccpBytes, err := proto.Marshal(ccp)
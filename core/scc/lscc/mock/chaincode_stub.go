@@ -2,10 +2,13 @@
 package mock
 
 import (
+	rand "math/rand"
 	sync "sync"
+	time "time"
 
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	shim "github.com/hyperledger/fabric/core/chaincode/shim"
+	queryresult "github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer "github.com/hyperledger/fabric/protos/peer"
 )
 
@@ -47,6 +50,18 @@ type ChaincodeStub struct {
 	delStateReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DelStateRangeStub        func(string, string) error
+	delStateRangeMutex       sync.RWMutex
+	delStateRangeArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	delStateRangeReturns struct {
+		result1 error
+	}
+	delStateRangeReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetArgsStub        func() [][]byte
 	getArgsMutex       sync.RWMutex
 	getArgsArgsForCall []struct {
@@ -81,6 +96,18 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetChannelConfigStub        func() (*peer.ChannelConfigSnapshot, error)
+	getChannelConfigMutex       sync.RWMutex
+	getChannelConfigArgsForCall []struct {
+	}
+	getChannelConfigReturns struct {
+		result1 *peer.ChannelConfigSnapshot
+		result2 error
+	}
+	getChannelConfigReturnsOnCall map[int]struct {
+		result1 *peer.ChannelConfigSnapshot
+		result2 error
+	}
 	GetChannelIDStub        func() string
 	getChannelIDMutex       sync.RWMutex
 	getChannelIDArgsForCall []struct {
@@ -152,6 +179,20 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetPrivateDataHashStub        func(string, string) ([]byte, error)
+	getPrivateDataHashMutex       sync.RWMutex
+	getPrivateDataHashArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getPrivateDataHashReturns struct {
+		result1 []byte
+		result2 error
+	}
+	getPrivateDataHashReturnsOnCall map[int]struct {
+		result1 []byte
+		result2 error
+	}
 	GetPrivateDataByPartialCompositeKeyStub        func(string, string, []string) (shim.StateQueryIteratorInterface, error)
 	getPrivateDataByPartialCompositeKeyMutex       sync.RWMutex
 	getPrivateDataByPartialCompositeKeyArgsForCall []struct {
@@ -279,6 +320,40 @@ type ChaincodeStub struct {
 		result1 shim.StateQueryIteratorInterface
 		result2 error
 	}
+	GetStateByPartialCompositeKeyWithFiltersStub        func(string, []string, []*peer.CompositeKeyFilter) (shim.StateQueryIteratorInterface, error)
+	getStateByPartialCompositeKeyWithFiltersMutex       sync.RWMutex
+	getStateByPartialCompositeKeyWithFiltersArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 []*peer.CompositeKeyFilter
+	}
+	getStateByPartialCompositeKeyWithFiltersReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	getStateByPartialCompositeKeyWithFiltersReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}
+	GetStateByPartialCompositeKeyWithFiltersAndPaginationStub        func(string, []string, []*peer.CompositeKeyFilter, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
+	getStateByPartialCompositeKeyWithFiltersAndPaginationMutex       sync.RWMutex
+	getStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 []*peer.CompositeKeyFilter
+		arg4 int32
+		arg5 string
+	}
+	getStateByPartialCompositeKeyWithFiltersAndPaginationReturns struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
+	getStateByPartialCompositeKeyWithFiltersAndPaginationReturnsOnCall map[int]struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}
 	GetStateByPartialCompositeKeyWithPaginationStub        func(string, []string, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)
 	getStateByPartialCompositeKeyWithPaginationMutex       sync.RWMutex
 	getStateByPartialCompositeKeyWithPaginationArgsForCall []struct {
@@ -342,6 +417,19 @@ type ChaincodeStub struct {
 		result1 []byte
 		result2 error
 	}
+	GetStateWithProofStub        func(string) (*queryresult.StateProof, error)
+	getStateWithProofMutex       sync.RWMutex
+	getStateWithProofArgsForCall []struct {
+		arg1 string
+	}
+	getStateWithProofReturns struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}
+	getStateWithProofReturnsOnCall map[int]struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}
 	GetStringArgsStub        func() []string
 	getStringArgsMutex       sync.RWMutex
 	getStringArgsArgsForCall []struct {
@@ -374,6 +462,28 @@ type ChaincodeStub struct {
 	getTxIDReturnsOnCall map[int]struct {
 		result1 string
 	}
+	GetTxRandStub        func() *rand.Rand
+	getTxRandMutex       sync.RWMutex
+	getTxRandArgsForCall []struct {
+	}
+	getTxRandReturns struct {
+		result1 *rand.Rand
+	}
+	getTxRandReturnsOnCall map[int]struct {
+		result1 *rand.Rand
+	}
+	GetTxTimeStub        func() (time.Time, error)
+	getTxTimeMutex       sync.RWMutex
+	getTxTimeArgsForCall []struct {
+	}
+	getTxTimeReturns struct {
+		result1 time.Time
+		result2 error
+	}
+	getTxTimeReturnsOnCall map[int]struct {
+		result1 time.Time
+		result2 error
+	}
 	GetTxTimestampStub        func() (*timestamp.Timestamp, error)
 	getTxTimestampMutex       sync.RWMutex
 	getTxTimestampArgsForCall []struct {
@@ -386,6 +496,18 @@ type ChaincodeStub struct {
 		result1 *timestamp.Timestamp
 		result2 error
 	}
+	IncrementStateStub        func(string, int64) error
+	incrementStateMutex       sync.RWMutex
+	incrementStateArgsForCall []struct {
+		arg1 string
+		arg2 int64
+	}
+	incrementStateReturns struct {
+		result1 error
+	}
+	incrementStateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InvokeChaincodeStub        func(string, [][]byte, string) peer.Response
 	invokeChaincodeMutex       sync.RWMutex
 	invokeChaincodeArgsForCall []struct {
@@ -670,6 +792,67 @@ func (fake *ChaincodeStub) DelStateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ChaincodeStub) DelStateRange(arg1 string, arg2 string) error {
+	fake.delStateRangeMutex.Lock()
+	ret, specificReturn := fake.delStateRangeReturnsOnCall[len(fake.delStateRangeArgsForCall)]
+	fake.delStateRangeArgsForCall = append(fake.delStateRangeArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DelStateRange", []interface{}{arg1, arg2})
+	fake.delStateRangeMutex.Unlock()
+	if fake.DelStateRangeStub != nil {
+		return fake.DelStateRangeStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.delStateRangeReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) DelStateRangeCallCount() int {
+	fake.delStateRangeMutex.RLock()
+	defer fake.delStateRangeMutex.RUnlock()
+	return len(fake.delStateRangeArgsForCall)
+}
+
+func (fake *ChaincodeStub) DelStateRangeCalls(stub func(string, string) error) {
+	fake.delStateRangeMutex.Lock()
+	defer fake.delStateRangeMutex.Unlock()
+	fake.DelStateRangeStub = stub
+}
+
+func (fake *ChaincodeStub) DelStateRangeArgsForCall(i int) (string, string) {
+	fake.delStateRangeMutex.RLock()
+	defer fake.delStateRangeMutex.RUnlock()
+	argsForCall := fake.delStateRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) DelStateRangeReturns(result1 error) {
+	fake.delStateRangeMutex.Lock()
+	defer fake.delStateRangeMutex.Unlock()
+	fake.DelStateRangeStub = nil
+	fake.delStateRangeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) DelStateRangeReturnsOnCall(i int, result1 error) {
+	fake.delStateRangeMutex.Lock()
+	defer fake.delStateRangeMutex.Unlock()
+	fake.DelStateRangeStub = nil
+	if fake.delStateRangeReturnsOnCall == nil {
+		fake.delStateRangeReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.delStateRangeReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChaincodeStub) GetArgs() [][]byte {
 	fake.getArgsMutex.Lock()
 	ret, specificReturn := fake.getArgsReturnsOnCall[len(fake.getArgsArgsForCall)]
@@ -832,6 +1015,61 @@ func (fake *ChaincodeStub) GetBindingReturnsOnCall(i int, result1 []byte, result
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetChannelConfig() (*peer.ChannelConfigSnapshot, error) {
+	fake.getChannelConfigMutex.Lock()
+	ret, specificReturn := fake.getChannelConfigReturnsOnCall[len(fake.getChannelConfigArgsForCall)]
+	fake.getChannelConfigArgsForCall = append(fake.getChannelConfigArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetChannelConfig", []interface{}{})
+	fake.getChannelConfigMutex.Unlock()
+	if fake.GetChannelConfigStub != nil {
+		return fake.GetChannelConfigStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getChannelConfigReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetChannelConfigCallCount() int {
+	fake.getChannelConfigMutex.RLock()
+	defer fake.getChannelConfigMutex.RUnlock()
+	return len(fake.getChannelConfigArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetChannelConfigCalls(stub func() (*peer.ChannelConfigSnapshot, error)) {
+	fake.getChannelConfigMutex.Lock()
+	defer fake.getChannelConfigMutex.Unlock()
+	fake.GetChannelConfigStub = stub
+}
+
+func (fake *ChaincodeStub) GetChannelConfigReturns(result1 *peer.ChannelConfigSnapshot, result2 error) {
+	fake.getChannelConfigMutex.Lock()
+	defer fake.getChannelConfigMutex.Unlock()
+	fake.GetChannelConfigStub = nil
+	fake.getChannelConfigReturns = struct {
+		result1 *peer.ChannelConfigSnapshot
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetChannelConfigReturnsOnCall(i int, result1 *peer.ChannelConfigSnapshot, result2 error) {
+	fake.getChannelConfigMutex.Lock()
+	defer fake.getChannelConfigMutex.Unlock()
+	fake.GetChannelConfigStub = nil
+	if fake.getChannelConfigReturnsOnCall == nil {
+		fake.getChannelConfigReturnsOnCall = make(map[int]struct {
+			result1 *peer.ChannelConfigSnapshot
+			result2 error
+		})
+	}
+	fake.getChannelConfigReturnsOnCall[i] = struct {
+		result1 *peer.ChannelConfigSnapshot
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetChannelID() string {
 	fake.getChannelIDMutex.Lock()
 	ret, specificReturn := fake.getChannelIDReturnsOnCall[len(fake.getChannelIDArgsForCall)]
@@ -1173,6 +1411,70 @@ func (fake *ChaincodeStub) GetPrivateDataReturnsOnCall(i int, result1 []byte, re
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetPrivateDataHash(arg1 string, arg2 string) ([]byte, error) {
+	fake.getPrivateDataHashMutex.Lock()
+	ret, specificReturn := fake.getPrivateDataHashReturnsOnCall[len(fake.getPrivateDataHashArgsForCall)]
+	fake.getPrivateDataHashArgsForCall = append(fake.getPrivateDataHashArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetPrivateDataHash", []interface{}{arg1, arg2})
+	fake.getPrivateDataHashMutex.Unlock()
+	if fake.GetPrivateDataHashStub != nil {
+		return fake.GetPrivateDataHashStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getPrivateDataHashReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashCallCount() int {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	return len(fake.getPrivateDataHashArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashCalls(stub func(string, string) ([]byte, error)) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = stub
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashArgsForCall(i int) (string, string) {
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
+	argsForCall := fake.getPrivateDataHashArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashReturns(result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	fake.getPrivateDataHashReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetPrivateDataHashReturnsOnCall(i int, result1 []byte, result2 error) {
+	fake.getPrivateDataHashMutex.Lock()
+	defer fake.getPrivateDataHashMutex.Unlock()
+	fake.GetPrivateDataHashStub = nil
+	if fake.getPrivateDataHashReturnsOnCall == nil {
+		fake.getPrivateDataHashReturnsOnCall = make(map[int]struct {
+			result1 []byte
+			result2 error
+		})
+	}
+	fake.getPrivateDataHashReturnsOnCall[i] = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetPrivateDataByPartialCompositeKey(arg1 string, arg2 string, arg3 []string) (shim.StateQueryIteratorInterface, error) {
 	var arg3Copy []string
 	if arg3 != nil {
@@ -1754,6 +2056,161 @@ func (fake *ChaincodeStub) GetStateByPartialCompositeKeyReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFilters(arg1 string, arg2 []string, arg3 []*peer.CompositeKeyFilter) (shim.StateQueryIteratorInterface, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []*peer.CompositeKeyFilter
+	if arg3 != nil {
+		arg3Copy = make([]*peer.CompositeKeyFilter, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.Lock()
+	ret, specificReturn := fake.getStateByPartialCompositeKeyWithFiltersReturnsOnCall[len(fake.getStateByPartialCompositeKeyWithFiltersArgsForCall)]
+	fake.getStateByPartialCompositeKeyWithFiltersArgsForCall = append(fake.getStateByPartialCompositeKeyWithFiltersArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 []*peer.CompositeKeyFilter
+	}{arg1, arg2Copy, arg3Copy})
+	fake.recordInvocation("GetStateByPartialCompositeKeyWithFilters", []interface{}{arg1, arg2Copy, arg3Copy})
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.Unlock()
+	if fake.GetStateByPartialCompositeKeyWithFiltersStub != nil {
+		return fake.GetStateByPartialCompositeKeyWithFiltersStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateByPartialCompositeKeyWithFiltersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersCallCount() int {
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersMutex.RUnlock()
+	return len(fake.getStateByPartialCompositeKeyWithFiltersArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersCalls(stub func(string, []string, []*peer.CompositeKeyFilter) (shim.StateQueryIteratorInterface, error)) {
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.Lock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersMutex.Unlock()
+	fake.GetStateByPartialCompositeKeyWithFiltersStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersArgsForCall(i int) (string, []string, []*peer.CompositeKeyFilter) {
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersMutex.RUnlock()
+	argsForCall := fake.getStateByPartialCompositeKeyWithFiltersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersReturns(result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.Lock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersMutex.Unlock()
+	fake.GetStateByPartialCompositeKeyWithFiltersStub = nil
+	fake.getStateByPartialCompositeKeyWithFiltersReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 error) {
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.Lock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersMutex.Unlock()
+	fake.GetStateByPartialCompositeKeyWithFiltersStub = nil
+	if fake.getStateByPartialCompositeKeyWithFiltersReturnsOnCall == nil {
+		fake.getStateByPartialCompositeKeyWithFiltersReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 error
+		})
+	}
+	fake.getStateByPartialCompositeKeyWithFiltersReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPagination(arg1 string, arg2 []string, arg3 []*peer.CompositeKeyFilter, arg4 int32, arg5 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []*peer.CompositeKeyFilter
+	if arg3 != nil {
+		arg3Copy = make([]*peer.CompositeKeyFilter, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Lock()
+	ret, specificReturn := fake.getStateByPartialCompositeKeyWithFiltersAndPaginationReturnsOnCall[len(fake.getStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall)]
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall = append(fake.getStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 []*peer.CompositeKeyFilter
+		arg4 int32
+		arg5 string
+	}{arg1, arg2Copy, arg3Copy, arg4, arg5})
+	fake.recordInvocation("GetStateByPartialCompositeKeyWithFiltersAndPagination", []interface{}{arg1, arg2Copy, arg3Copy, arg4, arg5})
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Unlock()
+	if fake.GetStateByPartialCompositeKeyWithFiltersAndPaginationStub != nil {
+		return fake.GetStateByPartialCompositeKeyWithFiltersAndPaginationStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.getStateByPartialCompositeKeyWithFiltersAndPaginationReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPaginationCallCount() int {
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.RUnlock()
+	return len(fake.getStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPaginationCalls(stub func(string, []string, []*peer.CompositeKeyFilter, int32, string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error)) {
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Lock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Unlock()
+	fake.GetStateByPartialCompositeKeyWithFiltersAndPaginationStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall(i int) (string, []string, []*peer.CompositeKeyFilter, int32, string) {
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.RUnlock()
+	argsForCall := fake.getStateByPartialCompositeKeyWithFiltersAndPaginationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPaginationReturns(result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Lock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Unlock()
+	fake.GetStateByPartialCompositeKeyWithFiltersAndPaginationStub = nil
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationReturns = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithFiltersAndPaginationReturnsOnCall(i int, result1 shim.StateQueryIteratorInterface, result2 *peer.QueryResponseMetadata, result3 error) {
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Lock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.Unlock()
+	fake.GetStateByPartialCompositeKeyWithFiltersAndPaginationStub = nil
+	if fake.getStateByPartialCompositeKeyWithFiltersAndPaginationReturnsOnCall == nil {
+		fake.getStateByPartialCompositeKeyWithFiltersAndPaginationReturnsOnCall = make(map[int]struct {
+			result1 shim.StateQueryIteratorInterface
+			result2 *peer.QueryResponseMetadata
+			result3 error
+		})
+	}
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationReturnsOnCall[i] = struct {
+		result1 shim.StateQueryIteratorInterface
+		result2 *peer.QueryResponseMetadata
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *ChaincodeStub) GetStateByPartialCompositeKeyWithPagination(arg1 string, arg2 []string, arg3 int32, arg4 string) (shim.StateQueryIteratorInterface, *peer.QueryResponseMetadata, error) {
 	var arg2Copy []string
 	if arg2 != nil {
@@ -2024,6 +2481,69 @@ func (fake *ChaincodeStub) GetStateValidationParameterReturnsOnCall(i int, resul
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) GetStateWithProof(arg1 string) (*queryresult.StateProof, error) {
+	fake.getStateWithProofMutex.Lock()
+	ret, specificReturn := fake.getStateWithProofReturnsOnCall[len(fake.getStateWithProofArgsForCall)]
+	fake.getStateWithProofArgsForCall = append(fake.getStateWithProofArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetStateWithProof", []interface{}{arg1})
+	fake.getStateWithProofMutex.Unlock()
+	if fake.GetStateWithProofStub != nil {
+		return fake.GetStateWithProofStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getStateWithProofReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetStateWithProofCallCount() int {
+	fake.getStateWithProofMutex.RLock()
+	defer fake.getStateWithProofMutex.RUnlock()
+	return len(fake.getStateWithProofArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetStateWithProofCalls(stub func(string) (*queryresult.StateProof, error)) {
+	fake.getStateWithProofMutex.Lock()
+	defer fake.getStateWithProofMutex.Unlock()
+	fake.GetStateWithProofStub = stub
+}
+
+func (fake *ChaincodeStub) GetStateWithProofArgsForCall(i int) string {
+	fake.getStateWithProofMutex.RLock()
+	defer fake.getStateWithProofMutex.RUnlock()
+	argsForCall := fake.getStateWithProofArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *ChaincodeStub) GetStateWithProofReturns(result1 *queryresult.StateProof, result2 error) {
+	fake.getStateWithProofMutex.Lock()
+	defer fake.getStateWithProofMutex.Unlock()
+	fake.GetStateWithProofStub = nil
+	fake.getStateWithProofReturns = struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetStateWithProofReturnsOnCall(i int, result1 *queryresult.StateProof, result2 error) {
+	fake.getStateWithProofMutex.Lock()
+	defer fake.getStateWithProofMutex.Unlock()
+	fake.GetStateWithProofStub = nil
+	if fake.getStateWithProofReturnsOnCall == nil {
+		fake.getStateWithProofReturnsOnCall = make(map[int]struct {
+			result1 *queryresult.StateProof
+			result2 error
+		})
+	}
+	fake.getStateWithProofReturnsOnCall[i] = struct {
+		result1 *queryresult.StateProof
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetStringArgs() []string {
 	fake.getStringArgsMutex.Lock()
 	ret, specificReturn := fake.getStringArgsReturnsOnCall[len(fake.getStringArgsArgsForCall)]
@@ -2183,6 +2703,113 @@ func (fake *ChaincodeStub) GetTxIDReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *ChaincodeStub) GetTxRand() *rand.Rand {
+	fake.getTxRandMutex.Lock()
+	ret, specificReturn := fake.getTxRandReturnsOnCall[len(fake.getTxRandArgsForCall)]
+	fake.getTxRandArgsForCall = append(fake.getTxRandArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetTxRand", []interface{}{})
+	fake.getTxRandMutex.Unlock()
+	if fake.GetTxRandStub != nil {
+		return fake.GetTxRandStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.getTxRandReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) GetTxRandCallCount() int {
+	fake.getTxRandMutex.RLock()
+	defer fake.getTxRandMutex.RUnlock()
+	return len(fake.getTxRandArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetTxRandCalls(stub func() *rand.Rand) {
+	fake.getTxRandMutex.Lock()
+	defer fake.getTxRandMutex.Unlock()
+	fake.GetTxRandStub = stub
+}
+
+func (fake *ChaincodeStub) GetTxRandReturns(result1 *rand.Rand) {
+	fake.getTxRandMutex.Lock()
+	defer fake.getTxRandMutex.Unlock()
+	fake.GetTxRandStub = nil
+	fake.getTxRandReturns = struct {
+		result1 *rand.Rand
+	}{result1}
+}
+
+func (fake *ChaincodeStub) GetTxRandReturnsOnCall(i int, result1 *rand.Rand) {
+	fake.getTxRandMutex.Lock()
+	defer fake.getTxRandMutex.Unlock()
+	fake.GetTxRandStub = nil
+	if fake.getTxRandReturnsOnCall == nil {
+		fake.getTxRandReturnsOnCall = make(map[int]struct {
+			result1 *rand.Rand
+		})
+	}
+	fake.getTxRandReturnsOnCall[i] = struct {
+		result1 *rand.Rand
+	}{result1}
+}
+
+func (fake *ChaincodeStub) GetTxTime() (time.Time, error) {
+	fake.getTxTimeMutex.Lock()
+	ret, specificReturn := fake.getTxTimeReturnsOnCall[len(fake.getTxTimeArgsForCall)]
+	fake.getTxTimeArgsForCall = append(fake.getTxTimeArgsForCall, struct {
+	}{})
+	fake.recordInvocation("GetTxTime", []interface{}{})
+	fake.getTxTimeMutex.Unlock()
+	if fake.GetTxTimeStub != nil {
+		return fake.GetTxTimeStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getTxTimeReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ChaincodeStub) GetTxTimeCallCount() int {
+	fake.getTxTimeMutex.RLock()
+	defer fake.getTxTimeMutex.RUnlock()
+	return len(fake.getTxTimeArgsForCall)
+}
+
+func (fake *ChaincodeStub) GetTxTimeCalls(stub func() (time.Time, error)) {
+	fake.getTxTimeMutex.Lock()
+	defer fake.getTxTimeMutex.Unlock()
+	fake.GetTxTimeStub = stub
+}
+
+func (fake *ChaincodeStub) GetTxTimeReturns(result1 time.Time, result2 error) {
+	fake.getTxTimeMutex.Lock()
+	defer fake.getTxTimeMutex.Unlock()
+	fake.GetTxTimeStub = nil
+	fake.getTxTimeReturns = struct {
+		result1 time.Time
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ChaincodeStub) GetTxTimeReturnsOnCall(i int, result1 time.Time, result2 error) {
+	fake.getTxTimeMutex.Lock()
+	defer fake.getTxTimeMutex.Unlock()
+	fake.GetTxTimeStub = nil
+	if fake.getTxTimeReturnsOnCall == nil {
+		fake.getTxTimeReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+			result2 error
+		})
+	}
+	fake.getTxTimeReturnsOnCall[i] = struct {
+		result1 time.Time
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ChaincodeStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	fake.getTxTimestampMutex.Lock()
 	ret, specificReturn := fake.getTxTimestampReturnsOnCall[len(fake.getTxTimestampArgsForCall)]
@@ -2201,6 +2828,10 @@ func (fake *ChaincodeStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 }
 
 func (fake *ChaincodeStub) GetTxTimestampCallCount() int {
+	fake.getTxRandMutex.RLock()
+	defer fake.getTxRandMutex.RUnlock()
+	fake.getTxTimeMutex.RLock()
+	defer fake.getTxTimeMutex.RUnlock()
 	fake.getTxTimestampMutex.RLock()
 	defer fake.getTxTimestampMutex.RUnlock()
 	return len(fake.getTxTimestampArgsForCall)
@@ -2238,6 +2869,67 @@ func (fake *ChaincodeStub) GetTxTimestampReturnsOnCall(i int, result1 *timestamp
 	}{result1, result2}
 }
 
+func (fake *ChaincodeStub) IncrementState(arg1 string, arg2 int64) error {
+	fake.incrementStateMutex.Lock()
+	ret, specificReturn := fake.incrementStateReturnsOnCall[len(fake.incrementStateArgsForCall)]
+	fake.incrementStateArgsForCall = append(fake.incrementStateArgsForCall, struct {
+		arg1 string
+		arg2 int64
+	}{arg1, arg2})
+	fake.recordInvocation("IncrementState", []interface{}{arg1, arg2})
+	fake.incrementStateMutex.Unlock()
+	if fake.IncrementStateStub != nil {
+		return fake.IncrementStateStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.incrementStateReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChaincodeStub) IncrementStateCallCount() int {
+	fake.incrementStateMutex.RLock()
+	defer fake.incrementStateMutex.RUnlock()
+	return len(fake.incrementStateArgsForCall)
+}
+
+func (fake *ChaincodeStub) IncrementStateCalls(stub func(string, int64) error) {
+	fake.incrementStateMutex.Lock()
+	defer fake.incrementStateMutex.Unlock()
+	fake.IncrementStateStub = stub
+}
+
+func (fake *ChaincodeStub) IncrementStateArgsForCall(i int) (string, int64) {
+	fake.incrementStateMutex.RLock()
+	defer fake.incrementStateMutex.RUnlock()
+	argsForCall := fake.incrementStateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ChaincodeStub) IncrementStateReturns(result1 error) {
+	fake.incrementStateMutex.Lock()
+	defer fake.incrementStateMutex.Unlock()
+	fake.IncrementStateStub = nil
+	fake.incrementStateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ChaincodeStub) IncrementStateReturnsOnCall(i int, result1 error) {
+	fake.incrementStateMutex.Lock()
+	defer fake.incrementStateMutex.Unlock()
+	fake.IncrementStateStub = nil
+	if fake.incrementStateReturnsOnCall == nil {
+		fake.incrementStateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.incrementStateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *ChaincodeStub) InvokeChaincode(arg1 string, arg2 [][]byte, arg3 string) peer.Response {
 	var arg2Copy [][]byte
 	if arg2 != nil {
@@ -2712,12 +3404,16 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.delPrivateDataMutex.RUnlock()
 	fake.delStateMutex.RLock()
 	defer fake.delStateMutex.RUnlock()
+	fake.delStateRangeMutex.RLock()
+	defer fake.delStateRangeMutex.RUnlock()
 	fake.getArgsMutex.RLock()
 	defer fake.getArgsMutex.RUnlock()
 	fake.getArgsSliceMutex.RLock()
 	defer fake.getArgsSliceMutex.RUnlock()
 	fake.getBindingMutex.RLock()
 	defer fake.getBindingMutex.RUnlock()
+	fake.getChannelConfigMutex.RLock()
+	defer fake.getChannelConfigMutex.RUnlock()
 	fake.getChannelIDMutex.RLock()
 	defer fake.getChannelIDMutex.RUnlock()
 	fake.getCreatorMutex.RLock()
@@ -2730,6 +3426,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getHistoryForKeyMutex.RUnlock()
 	fake.getPrivateDataMutex.RLock()
 	defer fake.getPrivateDataMutex.RUnlock()
+	fake.getPrivateDataHashMutex.RLock()
+	defer fake.getPrivateDataHashMutex.RUnlock()
 	fake.getPrivateDataByPartialCompositeKeyMutex.RLock()
 	defer fake.getPrivateDataByPartialCompositeKeyMutex.RUnlock()
 	fake.getPrivateDataByRangeMutex.RLock()
@@ -2748,6 +3446,10 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getStateMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyMutex.RUnlock()
+	fake.getStateByPartialCompositeKeyWithFiltersMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersMutex.RUnlock()
+	fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.RLock()
+	defer fake.getStateByPartialCompositeKeyWithFiltersAndPaginationMutex.RUnlock()
 	fake.getStateByPartialCompositeKeyWithPaginationMutex.RLock()
 	defer fake.getStateByPartialCompositeKeyWithPaginationMutex.RUnlock()
 	fake.getStateByRangeMutex.RLock()
@@ -2756,6 +3458,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getStateByRangeWithPaginationMutex.RUnlock()
 	fake.getStateValidationParameterMutex.RLock()
 	defer fake.getStateValidationParameterMutex.RUnlock()
+	fake.getStateWithProofMutex.RLock()
+	defer fake.getStateWithProofMutex.RUnlock()
 	fake.getStringArgsMutex.RLock()
 	defer fake.getStringArgsMutex.RUnlock()
 	fake.getTransientMutex.RLock()
@@ -2764,6 +3468,8 @@ func (fake *ChaincodeStub) Invocations() map[string][][]interface{} {
 	defer fake.getTxIDMutex.RUnlock()
 	fake.getTxTimestampMutex.RLock()
 	defer fake.getTxTimestampMutex.RUnlock()
+	fake.incrementStateMutex.RLock()
+	defer fake.incrementStateMutex.RUnlock()
 	fake.invokeChaincodeMutex.RLock()
 	defer fake.invokeChaincodeMutex.RUnlock()
 	fake.putPrivateDataMutex.RLock()
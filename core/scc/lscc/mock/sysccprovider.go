@@ -69,6 +69,17 @@ type SystemChaincodeProvider struct {
 	isSysCCAndNotInvokableExternalReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IsSysCCAndRequiresEndorsementPolicyStub        func(string) bool
+	isSysCCAndRequiresEndorsementPolicyMutex       sync.RWMutex
+	isSysCCAndRequiresEndorsementPolicyArgsForCall []struct {
+		arg1 string
+	}
+	isSysCCAndRequiresEndorsementPolicyReturns struct {
+		result1 bool
+	}
+	isSysCCAndRequiresEndorsementPolicyReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	PolicyManagerStub        func(string) (policies.Manager, bool)
 	policyManagerMutex       sync.RWMutex
 	policyManagerArgsForCall []struct {
@@ -353,6 +364,8 @@ func (fake *SystemChaincodeProvider) IsSysCCAndNotInvokableExternal(arg1 string)
 func (fake *SystemChaincodeProvider) IsSysCCAndNotInvokableExternalCallCount() int {
 	fake.isSysCCAndNotInvokableExternalMutex.RLock()
 	defer fake.isSysCCAndNotInvokableExternalMutex.RUnlock()
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.RLock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.RUnlock()
 	return len(fake.isSysCCAndNotInvokableExternalArgsForCall)
 }
 
@@ -365,6 +378,8 @@ func (fake *SystemChaincodeProvider) IsSysCCAndNotInvokableExternalCalls(stub fu
 func (fake *SystemChaincodeProvider) IsSysCCAndNotInvokableExternalArgsForCall(i int) string {
 	fake.isSysCCAndNotInvokableExternalMutex.RLock()
 	defer fake.isSysCCAndNotInvokableExternalMutex.RUnlock()
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.RLock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.RUnlock()
 	argsForCall := fake.isSysCCAndNotInvokableExternalArgsForCall[i]
 	return argsForCall.arg1
 }
@@ -392,6 +407,66 @@ func (fake *SystemChaincodeProvider) IsSysCCAndNotInvokableExternalReturnsOnCall
 	}{result1}
 }
 
+func (fake *SystemChaincodeProvider) IsSysCCAndRequiresEndorsementPolicy(arg1 string) bool {
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.Lock()
+	ret, specificReturn := fake.isSysCCAndRequiresEndorsementPolicyReturnsOnCall[len(fake.isSysCCAndRequiresEndorsementPolicyArgsForCall)]
+	fake.isSysCCAndRequiresEndorsementPolicyArgsForCall = append(fake.isSysCCAndRequiresEndorsementPolicyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("IsSysCCAndRequiresEndorsementPolicy", []interface{}{arg1})
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.Unlock()
+	if fake.IsSysCCAndRequiresEndorsementPolicyStub != nil {
+		return fake.IsSysCCAndRequiresEndorsementPolicyStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.isSysCCAndRequiresEndorsementPolicyReturns
+	return fakeReturns.result1
+}
+
+func (fake *SystemChaincodeProvider) IsSysCCAndRequiresEndorsementPolicyCallCount() int {
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.RLock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.RUnlock()
+	return len(fake.isSysCCAndRequiresEndorsementPolicyArgsForCall)
+}
+
+func (fake *SystemChaincodeProvider) IsSysCCAndRequiresEndorsementPolicyCalls(stub func(string) bool) {
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.Lock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.Unlock()
+	fake.IsSysCCAndRequiresEndorsementPolicyStub = stub
+}
+
+func (fake *SystemChaincodeProvider) IsSysCCAndRequiresEndorsementPolicyArgsForCall(i int) string {
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.RLock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.RUnlock()
+	argsForCall := fake.isSysCCAndRequiresEndorsementPolicyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *SystemChaincodeProvider) IsSysCCAndRequiresEndorsementPolicyReturns(result1 bool) {
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.Lock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.Unlock()
+	fake.IsSysCCAndRequiresEndorsementPolicyStub = nil
+	fake.isSysCCAndRequiresEndorsementPolicyReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *SystemChaincodeProvider) IsSysCCAndRequiresEndorsementPolicyReturnsOnCall(i int, result1 bool) {
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.Lock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.Unlock()
+	fake.IsSysCCAndRequiresEndorsementPolicyStub = nil
+	if fake.isSysCCAndRequiresEndorsementPolicyReturnsOnCall == nil {
+		fake.isSysCCAndRequiresEndorsementPolicyReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.isSysCCAndRequiresEndorsementPolicyReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *SystemChaincodeProvider) PolicyManager(arg1 string) (policies.Manager, bool) {
 	fake.policyManagerMutex.Lock()
 	ret, specificReturn := fake.policyManagerReturnsOnCall[len(fake.policyManagerArgsForCall)]
@@ -468,6 +543,8 @@ func (fake *SystemChaincodeProvider) Invocations() map[string][][]interface{} {
 	defer fake.isSysCCAndNotInvokableCC2CCMutex.RUnlock()
 	fake.isSysCCAndNotInvokableExternalMutex.RLock()
 	defer fake.isSysCCAndNotInvokableExternalMutex.RUnlock()
+	fake.isSysCCAndRequiresEndorsementPolicyMutex.RLock()
+	defer fake.isSysCCAndRequiresEndorsementPolicyMutex.RUnlock()
 	fake.policyManagerMutex.RLock()
 	defer fake.policyManagerMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
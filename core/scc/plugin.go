@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scc
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/scc/api"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// newPluginSysCC creates the system chaincode of the given plugin loaded with the
+// given configuration. The name and the ACLs configured for the plugin take
+// precedence over the ones of its descriptor, and the plugin may be invoked if
+// either the configuration or the descriptor allows it
+func newPluginSysCC(conf *PluginConfig, plugin api.Plugin, aclProvider aclmgmt.ACLProvider) (*SystemChaincode, error) {
+	descriptor := plugin.Descriptor()
+	name := conf.Name
+	if name == "" {
+		name = descriptor.Name
+	}
+	if name == "" {
+		return nil, errors.Errorf("no name configured for the system chaincode plugin at path %s", conf.Path)
+	}
+	if descriptor.Name != "" && descriptor.Name != name {
+		return nil, errors.Errorf("the plugin at path %s is system chaincode %s, not %s", conf.Path, descriptor.Name, name)
+	}
+
+	acls := map[string]string{}
+	for function, policyRef := range descriptor.ACLs {
+		acls[function] = policyRef
+	}
+	for function, policyRef := range conf.ACLs {
+		acls[function] = policyRef
+	}
+	functions := make([]string, 0, len(acls))
+	for function, policyRef := range acls {
+		if !strings.HasPrefix(policyRef, "/") {
+			return nil, errors.Errorf("the policy %s of function %s of system chaincode %s is not an absolute policy path", policyRef, function, name)
+		}
+		functions = append(functions, function)
+	}
+	sort.Strings(functions)
+
+	chaincode := &pluginChaincode{name: name, chaincode: plugin.Chaincode()}
+	if len(functions) > 0 {
+		for _, function := range functions {
+			aclmgmt.RegisterResourcePolicy(name+"/"+function, acls[function])
+		}
+		chaincode.aclProvider = aclProvider
+		sysccLogger.Infof("System chaincode %s checks the ACLs of functions %s", name, strings.Join(functions, ", "))
+	} else {
+		sysccLogger.Warningf("System chaincode %s declares no ACL, its functions may be invoked by any creator allowed to propose", name)
+	}
+
+	return &SystemChaincode{
+		Enabled:           conf.Enabled,
+		Name:              name,
+		Path:              conf.Path,
+		InitArgs:          descriptor.InitArgs,
+		Chaincode:         chaincode,
+		InvokableExternal: conf.InvokableExternal || descriptor.InvokableExternal,
+		InvokableCC2CC:    conf.InvokableCC2CC || descriptor.InvokableCC2CC,
+	}, nil
+}
+
+// pluginChaincode runs the chaincode of a system chaincode plugin in isolation: a
+// panic of the chaincode fails the invocation rather than crashing the peer, and
+// the invocations are checked against the ACLs of the functions of the plugin,
+// if it declares any
+type pluginChaincode struct {
+	name        string
+	chaincode   shim.Chaincode
+	aclProvider aclmgmt.ACLProvider
+}
+
+// Init initializes the chaincode of the plugin
+func (c *pluginChaincode) Init(stub shim.ChaincodeStubInterface) (resp pb.Response) {
+	defer c.recoverPanic("Init", &resp)
+	return c.chaincode.Init(stub)
+}
+
+// Invoke checks the ACL of the invoked function, if the plugin declares ACLs,
+// and invokes the chaincode of the plugin
+func (c *pluginChaincode) Invoke(stub shim.ChaincodeStubInterface) (resp pb.Response) {
+	defer c.recoverPanic("Invoke", &resp)
+	if c.aclProvider != nil {
+		function, _ := stub.GetFunctionAndParameters()
+		channelID := stub.GetChannelID()
+		sp, err := stub.GetSignedProposal()
+		if err != nil {
+			return shim.Error(fmt.Sprintf("failed retrieving signed proposal on executing %s with error %s", function, err))
+		}
+		if err := c.aclProvider.CheckACL(c.name+"/"+function, channelID, sp); err != nil {
+			return shim.Error(fmt.Sprintf("access denied for [%s][%s]: [%s]", function, channelID, err))
+		}
+	}
+	return c.chaincode.Invoke(stub)
+}
+
+func (c *pluginChaincode) recoverPanic(method string, resp *pb.Response) {
+	if r := recover(); r != nil {
+		sysccLogger.Errorf("System chaincode %s panicked in %s: %v\n%s", c.name, method, r, debug.Stack())
+		*resp = shim.Error(fmt.Sprintf("system chaincode %s panicked: %v", c.name, r))
+	}
+}
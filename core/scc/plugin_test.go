@@ -0,0 +1,129 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/aclmgmt"
+	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/scc/api"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testPluginCC struct{}
+
+func (cc *testPluginCC) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	if len(stub.GetArgs()) > 0 {
+		panic("init failed")
+	}
+	return shim.Success(nil)
+}
+
+func (cc *testPluginCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, _ := stub.GetFunctionAndParameters()
+	if function == "panic" {
+		panic("invoke failed")
+	}
+	return shim.Success([]byte(function))
+}
+
+func TestNewPluginSysCC(t *testing.T) {
+	descriptor := api.Descriptor{
+		Name:           "testplugin",
+		InitArgs:       [][]byte{[]byte("init")},
+		InvokableCC2CC: true,
+		ACLs:           map[string]string{"get": "/Channel/Application/Readers", "put": "/Channel/Application/Writers"},
+	}
+	conf := &PluginConfig{
+		Enabled:           true,
+		Path:              "testplugin.so",
+		InvokableExternal: true,
+		ACLs:              map[string]string{"put": "/Channel/Application/Admins"},
+	}
+	acl := &mocks.MockACLProvider{}
+	scc, err := newPluginSysCC(conf, api.New(descriptor, &testPluginCC{}), acl)
+	assert.NoError(t, err)
+	assert.Equal(t, "testplugin", scc.Name)
+	assert.Equal(t, "testplugin.so", scc.Path)
+	assert.True(t, scc.Enabled)
+	assert.True(t, scc.InvokableExternal)
+	assert.True(t, scc.InvokableCC2CC)
+	assert.Equal(t, descriptor.InitArgs, scc.InitArgs)
+	assert.Equal(t, acl, scc.Chaincode.(*pluginChaincode).aclProvider)
+
+	// the functions of the plugin are now resources known to the ACL provider
+	p := aclmgmt.NewDefaultACLProvider()
+	for _, resName := range []string{"testplugin/get", "testplugin/put"} {
+		assert.EqualError(t, p.CheckACL(resName, "", nil), "Unknown id on checkACL "+resName)
+	}
+	assert.EqualError(t, p.CheckACL("testplugin/delete", "", nil), "Unmapped policy for testplugin/delete")
+
+	t.Run("NoACL", func(t *testing.T) {
+		scc, err := newPluginSysCC(&PluginConfig{Name: "noacl"}, api.New(api.Descriptor{}, &testPluginCC{}), acl)
+		assert.NoError(t, err)
+		assert.Equal(t, "noacl", scc.Name)
+		assert.Nil(t, scc.Chaincode.(*pluginChaincode).aclProvider)
+	})
+
+	t.Run("NoName", func(t *testing.T) {
+		_, err := newPluginSysCC(&PluginConfig{Path: "noname.so"}, api.New(api.Descriptor{}, &testPluginCC{}), acl)
+		assert.EqualError(t, err, "no name configured for the system chaincode plugin at path noname.so")
+	})
+
+	t.Run("NameMismatch", func(t *testing.T) {
+		_, err := newPluginSysCC(&PluginConfig{Name: "other", Path: "testplugin.so"}, api.New(descriptor, &testPluginCC{}), acl)
+		assert.EqualError(t, err, "the plugin at path testplugin.so is system chaincode testplugin, not other")
+	})
+
+	t.Run("RelativePolicy", func(t *testing.T) {
+		conf := &PluginConfig{Name: "relative", ACLs: map[string]string{"get": "Readers"}}
+		_, err := newPluginSysCC(conf, api.New(api.Descriptor{}, &testPluginCC{}), acl)
+		assert.EqualError(t, err, "the policy Readers of function get of system chaincode relative is not an absolute policy path")
+	})
+}
+
+func TestPluginChaincodeIsolation(t *testing.T) {
+	cc := &pluginChaincode{name: "testplugin", chaincode: &testPluginCC{}}
+	stub := shim.NewMockStub("testplugin", cc)
+
+	resp := stub.MockInit("1", nil)
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	resp = stub.MockInit("2", [][]byte{[]byte("arg")})
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode testplugin panicked: init failed", resp.Message)
+
+	resp = stub.MockInvoke("3", [][]byte{[]byte("get")})
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	assert.Equal(t, []byte("get"), resp.Payload)
+	resp = stub.MockInvoke("4", [][]byte{[]byte("panic")})
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "system chaincode testplugin panicked: invoke failed", resp.Message)
+}
+
+func TestPluginChaincodeACL(t *testing.T) {
+	acl := &mocks.MockACLProvider{}
+	acl.Reset()
+	acl.On("CheckACL", "testplugin/get", "mychannel", mock.Anything).Return(nil)
+	acl.On("CheckACL", "testplugin/put", "mychannel", mock.Anything).Return(errors.New("policy not satisfied"))
+	cc := &pluginChaincode{name: "testplugin", chaincode: &testPluginCC{}, aclProvider: acl}
+	stub := shim.NewMockStub("testplugin", cc)
+	stub.ChannelID = "mychannel"
+	sp := &pb.SignedProposal{}
+
+	resp := stub.MockInvokeWithSignedProposal("1", [][]byte{[]byte("get")}, sp)
+	assert.Equal(t, int32(shim.OK), resp.Status)
+
+	resp = stub.MockInvokeWithSignedProposal("2", [][]byte{[]byte("put")}, sp)
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "access denied for [put][mychannel]: [policy not satisfied]", resp.Message)
+	acl.AssertExpectations(t)
+}
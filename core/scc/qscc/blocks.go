@@ -0,0 +1,286 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package qscc
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// MaxBlocksPerRange is the maximum number of blocks returned by a single
+// invocation of GetBlocksByRange, regardless of the requested limit
+const MaxBlocksPerRange = 100
+
+// These are the encodings of the responses of GetBlocksByRange and
+// GetTransactionsByBlockNumber, the protobuf encoding being the default
+const (
+	FormatProto string = "proto"
+	FormatJSON  string = "json"
+)
+
+// blockRangeArgs are the arguments of the queries of a range of blocks
+type blockRangeArgs struct {
+	start  uint64
+	end    uint64
+	limit  uint64
+	format string
+}
+
+// parseBlockRangeArgs expects the start and end block numbers, followed by the
+// optional limit on the number of blocks and the optional format of the response.
+// The end of the range is capped to the last committed block
+func parseBlockRangeArgs(vledger ledger.PeerLedger, args [][]byte) (*blockRangeArgs, error) {
+	if len(args) < 2 {
+		return nil, errors.New("Start and end block numbers must not be nil.")
+	}
+	start, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("Failed to parse start block number with error %s", err)
+	}
+	end, err := strconv.ParseUint(string(args[1]), 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("Failed to parse end block number with error %s", err)
+	}
+	if start > end {
+		return nil, errors.Errorf("Start block number %d is greater than end block number %d", start, end)
+	}
+	limit := uint64(MaxBlocksPerRange)
+	if len(args) > 2 && len(args[2]) != 0 {
+		requested, err := strconv.ParseUint(string(args[2]), 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("Failed to parse limit with error %s", err)
+		}
+		if requested != 0 && requested < limit {
+			limit = requested
+		}
+	}
+	format, err := responseFormat(args, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	binfo, err := vledger.GetBlockchainInfo()
+	if err != nil {
+		return nil, errors.Errorf("Failed to get block info with error %s", err)
+	}
+	if start >= binfo.Height {
+		return nil, errors.Errorf("Start block number %d is not lower than the height of the ledger %d", start, binfo.Height)
+	}
+	// the blocks iterator of the ledger waits for the blocks yet to be committed,
+	// hence the end of the range is capped to the last committed block
+	if end >= binfo.Height {
+		end = binfo.Height - 1
+	}
+	return &blockRangeArgs{start: start, end: end, limit: limit, format: format}, nil
+}
+
+// getBlocksByRange expects the arguments parsed by parseBlockRangeArgs
+func getBlocksByRange(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	rangeArgs, err := parseBlockRangeArgs(vledger, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	blockRange, err := blocksInRange(vledger, rangeArgs.start, rangeArgs.end, rangeArgs.limit)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return marshalResponse(blockRange, rangeArgs.format)
+}
+
+// getBlockStatsByRange expects the arguments parsed by parseBlockRangeArgs
+func getBlockStatsByRange(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	rangeArgs, err := parseBlockRangeArgs(vledger, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	blockRange, err := blocksInRange(vledger, rangeArgs.start, rangeArgs.end, rangeArgs.limit)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	statsRange := &pb.BlockStatsRange{
+		HasMore:         blockRange.HasMore,
+		NextBlockNumber: blockRange.NextBlockNumber,
+	}
+	for _, block := range blockRange.Blocks {
+		statsRange.Blocks = append(statsRange.Blocks, blockStats(block))
+	}
+	return marshalResponse(statsRange, rangeArgs.format)
+}
+
+func blockStats(block *common.Block) *pb.BlockStats {
+	txsFilter := transactionsFilter(block)
+
+	stats := &pb.BlockStats{
+		BlockNumber: block.Header.Number,
+		Size:        uint64(proto.Size(block)),
+		TxCount:     uint64(len(block.Data.Data)),
+	}
+	for txIndex, envBytes := range block.Data.Data {
+		txStats := &pb.TransactionStats{
+			TxIndex: uint64(txIndex),
+			Size:    uint64(len(envBytes)),
+		}
+		if txIndex < len(txsFilter) {
+			txStats.ValidationCode = txsFilter.Flag(txIndex).String()
+			if txsFilter.IsValid(txIndex) {
+				stats.ValidTxCount++
+			} else {
+				stats.InvalidTxCount++
+			}
+		}
+		stats.TxBytes += txStats.Size
+		if txStats.Size > stats.MaxTxSize {
+			stats.MaxTxSize = txStats.Size
+		}
+		stats.Transactions = append(stats.Transactions, txStats)
+	}
+	return stats
+}
+
+func blocksInRange(vledger ledger.PeerLedger, start, end, limit uint64) (*pb.BlockRange, error) {
+	itr, err := vledger.GetBlocksIterator(start)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("failed to get blocks iterator from block number %d", start))
+	}
+	defer itr.Close()
+
+	blockRange := &pb.BlockRange{}
+	for num := start; num <= end; num++ {
+		if uint64(len(blockRange.Blocks)) == limit {
+			blockRange.HasMore = true
+			blockRange.NextBlockNumber = num
+			break
+		}
+		res, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("failed to get block number %d", num))
+		}
+		blockRange.Blocks = append(blockRange.Blocks, res.(*common.Block))
+	}
+	return blockRange, nil
+}
+
+// getTransactionsByBlockNumber expects the block number, followed by the optional
+// format of the response
+func getTransactionsByBlockNumber(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args[0]) == 0 {
+		return shim.Error("Block number must not be nil.")
+	}
+	bnum, err := strconv.ParseUint(string(args[0]), 10, 64)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse block number with error %s", err))
+	}
+	format, err := responseFormat(args, 1)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	block, err := vledger.GetBlockByNumber(bnum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block number %d, error %s", bnum, err))
+	}
+	txs, err := summarizeTransactions(block)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to decode the transactions of block number %d, error %s", bnum, err))
+	}
+	return marshalResponse(txs, format)
+}
+
+// transactionsFilter returns the validation flags of the transactions of a
+// block, which are empty if the block carries none
+func transactionsFilter(block *common.Block) util.TxValidationFlags {
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	return nil
+}
+
+func summarizeTransactions(block *common.Block) (*pb.BlockTransactions, error) {
+	txsFilter := transactionsFilter(block)
+
+	txs := &pb.BlockTransactions{BlockNumber: block.Header.Number}
+	for txIndex, envBytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("transaction %d", txIndex))
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("transaction %d", txIndex))
+		}
+		if payload.Header == nil {
+			return nil, errors.Errorf("transaction %d: header is missing", txIndex)
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("transaction %d", txIndex))
+		}
+		shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("transaction %d", txIndex))
+		}
+
+		summary := &pb.TransactionSummary{
+			TxIndex:   uint64(txIndex),
+			TxId:      chdr.TxId,
+			Type:      common.HeaderType(chdr.Type).String(),
+			ChannelId: chdr.ChannelId,
+			Timestamp: chdr.Timestamp,
+		}
+		creator := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(shdr.Creator, creator); err == nil {
+			summary.CreatorMspId = creator.Mspid
+		}
+		if txIndex < len(txsFilter) {
+			summary.ValidationCode = txsFilter.Flag(txIndex).String()
+		}
+		txs.Transactions = append(txs.Transactions, summary)
+	}
+	return txs, nil
+}
+
+// responseFormat returns the format found at the given position of the
+// arguments, or the protobuf format if the arguments do not carry one
+func responseFormat(args [][]byte, i int) (string, error) {
+	if len(args) <= i || len(args[i]) == 0 {
+		return FormatProto, nil
+	}
+	switch format := string(args[i]); format {
+	case FormatProto, FormatJSON:
+		return format, nil
+	default:
+		return "", errors.Errorf("Unsupported response format %s", format)
+	}
+}
+
+func marshalResponse(msg proto.Message, format string) pb.Response {
+	if format == FormatJSON {
+		buf := &bytes.Buffer{}
+		if err := protolator.DeepMarshalJSON(buf, msg); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(buf.Bytes())
+	}
+
+	bytes, err := utils.Marshal(msg)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(bytes)
+}
@@ -40,6 +40,10 @@ func (e *LedgerQuerier) Enabled() bool             { return true }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetBlocksByRange returns a range of blocks
+// - GetTransactionsByBlockNumber returns the decoded headers of the transactions of a block
+// - GetBlockStatsByRange returns the sizes and validation codes of a range of blocks
+// - GetStateProof returns a proof of the value of a key
 type LedgerQuerier struct {
 	aclProvider aclmgmt.ACLProvider
 }
@@ -53,6 +57,11 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetBlocksByRange             string = "GetBlocksByRange"
+	GetTransactionsByBlockNumber string = "GetTransactionsByBlockNumber"
+	GetBlockStatsByRange         string = "GetBlockStatsByRange"
+	GetStateProof                string = "GetStateProof"
 )
 
 // Init is called once per chain when the chain is created.
@@ -71,6 +80,14 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetBlocksByRange: Return the blocks from number args[2] to number args[3] (inclusive),
+// at most args[4] of them if specified, encoded in the format specified by args[5] if any
+// # GetTransactionsByBlockNumber: Return the transactions of the block specified by number
+// in args[2], encoded in the format specified by args[3] if any
+// # GetBlockStatsByRange: Return the statistics of the blocks from number args[2] to number
+// args[3] (inclusive), with the same optional arguments as GetBlocksByRange
+// # GetStateProof: Return a StateProof of the value of the key args[3] of the namespace args[2],
+// which is verified against the commit hash of its block obtained from other peers
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -115,6 +132,14 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetBlocksByRange:
+		return getBlocksByRange(targetLedger, args[2:])
+	case GetTransactionsByBlockNumber:
+		return getTransactionsByBlockNumber(targetLedger, args[2:])
+	case GetBlockStatsByRange:
+		return getBlockStatsByRange(targetLedger, args[2:])
+	case GetStateProof:
+		return getStateProof(targetLedger, args[2:])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -214,6 +239,24 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getStateProof(vledger ledger.PeerLedger, args [][]byte) pb.Response {
+	if len(args) < 2 || len(args[0]) == 0 || len(args[1]) == 0 {
+		return shim.Error("Namespace and key must not be nil.")
+	}
+	namespace, key := string(args[0]), string(args[1])
+	proof, err := vledger.GetStateProof(namespace, key)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get the proof of key %s of namespace %s, error %s", key, namespace, err))
+	}
+
+	bytes, err := utils.Marshal(proof)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -16,19 +16,23 @@ limitations under the License.
 package qscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	ledger2 "github.com/hyperledger/fabric/core/ledger"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	peer2 "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -365,3 +369,265 @@ func TestMain(m *testing.M) {
 
 	os.Exit(m.Run())
 }
+
+func TestQueryGetBlocksByRange(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	block1 := addBlockForTesting(t, chainid)
+
+	invoke := func(args ...string) peer2.Response {
+		argsBytes := [][]byte{[]byte(GetBlocksByRange), []byte(chainid)}
+		for _, arg := range args {
+			argsBytes = append(argsBytes, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetBlocksByRange, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", argsBytes, prop)
+	}
+
+	// the end of the range is capped to the height of the ledger
+	res := invoke("0", "10")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	blockRange := &peer2.BlockRange{}
+	require.NoError(t, proto.Unmarshal(res.Payload, blockRange))
+	require.Len(t, blockRange.Blocks, 2)
+	assert.Equal(t, uint64(0), blockRange.Blocks[0].Header.Number)
+	assert.True(t, proto.Equal(block1, blockRange.Blocks[1]))
+	assert.False(t, blockRange.HasMore)
+
+	// the limit truncates the range and points at the next block
+	res = invoke("0", "1", "1")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	blockRange = &peer2.BlockRange{}
+	require.NoError(t, proto.Unmarshal(res.Payload, blockRange))
+	require.Len(t, blockRange.Blocks, 1)
+	assert.Equal(t, uint64(0), blockRange.Blocks[0].Header.Number)
+	assert.True(t, blockRange.HasMore)
+	assert.Equal(t, uint64(1), blockRange.NextBlockNumber)
+
+	res = invoke("0", "0", "", "json")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	jsonRange := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(res.Payload, &jsonRange))
+	assert.Len(t, jsonRange["blocks"], 1)
+
+	for _, tc := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{[]string{"0"}, "Start and end block numbers must not be nil."},
+		{[]string{"a", "1"}, "Failed to parse start block number with error"},
+		{[]string{"0", "b"}, "Failed to parse end block number with error"},
+		{[]string{"1", "0"}, "Start block number 1 is greater than end block number 0"},
+		{[]string{"0", "1", "c"}, "Failed to parse limit with error"},
+		{[]string{"0", "1", "", "xml"}, "Unsupported response format xml"},
+		{[]string{"2", "3"}, "Start block number 2 is not lower than the height of the ledger 2"},
+	} {
+		res := invoke(tc.args...)
+		assert.Equal(t, int32(shim.ERROR), res.Status)
+		assert.Contains(t, res.Message, tc.expectedErr)
+	}
+}
+
+func TestQueryGetTransactionsByBlockNumber(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := tempDir(t, "test10")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	block1 := addBlockForTesting(t, chainid)
+
+	invoke := func(args ...string) peer2.Response {
+		argsBytes := [][]byte{[]byte(GetTransactionsByBlockNumber), []byte(chainid)}
+		for _, arg := range args {
+			argsBytes = append(argsBytes, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetTransactionsByBlockNumber, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", argsBytes, prop)
+	}
+
+	res := invoke("1")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	txs := &peer2.BlockTransactions{}
+	require.NoError(t, proto.Unmarshal(res.Payload, txs))
+	assert.Equal(t, uint64(1), txs.BlockNumber)
+	require.Len(t, txs.Transactions, 2)
+	for i, tx := range txs.Transactions {
+		env, err := utils.GetEnvelopeFromBlock(block1.Data.Data[i])
+		require.NoError(t, err)
+		payload, err := utils.GetPayload(env)
+		require.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(i), tx.TxIndex)
+		assert.Equal(t, chdr.TxId, tx.TxId)
+		assert.Equal(t, common.HeaderType_ENDORSER_TRANSACTION.String(), tx.Type)
+		assert.Equal(t, peer2.TxValidationCode_VALID.String(), tx.ValidationCode)
+	}
+
+	res = invoke("1", "json")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	jsonTxs := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(res.Payload, &jsonTxs))
+	assert.Len(t, jsonTxs["transactions"], 2)
+
+	for _, tc := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{[]string{""}, "Block number must not be nil."},
+		{[]string{"a"}, "Failed to parse block number with error"},
+		{[]string{"1", "xml"}, "Unsupported response format xml"},
+		{[]string{"5"}, "Failed to get block number 5"},
+	} {
+		res := invoke(tc.args...)
+		assert.Equal(t, int32(shim.ERROR), res.Status)
+		assert.Contains(t, res.Message, tc.expectedErr)
+	}
+}
+
+func TestQueryGetBlockStatsByRange(t *testing.T) {
+	chainid := "mytestchainid11"
+	path := tempDir(t, "test11")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	block1 := addBlockForTesting(t, chainid)
+
+	invoke := func(args ...string) peer2.Response {
+		argsBytes := [][]byte{[]byte(GetBlockStatsByRange), []byte(chainid)}
+		for _, arg := range args {
+			argsBytes = append(argsBytes, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetBlockStatsByRange, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", argsBytes, prop)
+	}
+
+	res := invoke("0", "10")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	statsRange := &peer2.BlockStatsRange{}
+	require.NoError(t, proto.Unmarshal(res.Payload, statsRange))
+	require.Len(t, statsRange.Blocks, 2)
+	assert.False(t, statsRange.HasMore)
+	assert.Equal(t, uint64(0), statsRange.Blocks[0].BlockNumber)
+
+	stats := statsRange.Blocks[1]
+	assert.Equal(t, uint64(1), stats.BlockNumber)
+	assert.Equal(t, uint64(proto.Size(block1)), stats.Size)
+	assert.Equal(t, uint64(2), stats.TxCount)
+	assert.Equal(t, uint64(2), stats.ValidTxCount)
+	assert.Equal(t, uint64(0), stats.InvalidTxCount)
+	require.Len(t, stats.Transactions, 2)
+	var txBytes, maxTxSize uint64
+	for i, tx := range stats.Transactions {
+		size := uint64(len(block1.Data.Data[i]))
+		assert.Equal(t, uint64(i), tx.TxIndex)
+		assert.Equal(t, size, tx.Size)
+		assert.Equal(t, peer2.TxValidationCode_VALID.String(), tx.ValidationCode)
+		txBytes += size
+		if size > maxTxSize {
+			maxTxSize = size
+		}
+	}
+	assert.Equal(t, txBytes, stats.TxBytes)
+	assert.Equal(t, maxTxSize, stats.MaxTxSize)
+
+	res = invoke("1", "1", "", "json")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	jsonRange := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(res.Payload, &jsonRange))
+	require.Len(t, jsonRange["blocks"], 1)
+	jsonStats := jsonRange["blocks"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "2", jsonStats["valid_tx_count"])
+	assert.Len(t, jsonStats["transactions"], 2)
+
+	for _, tc := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{[]string{"0"}, "Start and end block numbers must not be nil."},
+		{[]string{"1", "0"}, "Start block number 1 is greater than end block number 0"},
+		{[]string{"0", "1", "", "xml"}, "Unsupported response format xml"},
+		{[]string{"2", "3"}, "Start block number 2 is not lower than the height of the ledger 2"},
+	} {
+		res := invoke(tc.args...)
+		assert.Equal(t, int32(shim.ERROR), res.Status)
+		assert.Contains(t, res.Message, tc.expectedErr)
+	}
+}
+
+func TestBlockStatsInvalidTransactions(t *testing.T) {
+	block := common.NewBlock(3, []byte("previous-hash"))
+	block.Data.Data = [][]byte{[]byte("tx-0"), []byte("transaction-1"), []byte("tx-2")}
+	txsFilter := ledgerutil.NewTxValidationFlags(3)
+	txsFilter.SetFlag(0, peer2.TxValidationCode_VALID)
+	txsFilter.SetFlag(1, peer2.TxValidationCode_MVCC_READ_CONFLICT)
+	txsFilter.SetFlag(2, peer2.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	stats := blockStats(block)
+	assert.Equal(t, uint64(3), stats.TxCount)
+	assert.Equal(t, uint64(1), stats.ValidTxCount)
+	assert.Equal(t, uint64(2), stats.InvalidTxCount)
+	assert.Equal(t, uint64(21), stats.TxBytes)
+	assert.Equal(t, uint64(13), stats.MaxTxSize)
+	assert.Equal(t, []*peer2.TransactionStats{
+		{TxIndex: 0, Size: 4, ValidationCode: "VALID"},
+		{TxIndex: 1, Size: 13, ValidationCode: "MVCC_READ_CONFLICT"},
+		{TxIndex: 2, Size: 4, ValidationCode: "ENDORSEMENT_POLICY_FAILURE"},
+	}, stats.Transactions)
+
+	// the blocks without validation flags have no validation codes
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = nil
+	stats = blockStats(block)
+	assert.Equal(t, uint64(0), stats.ValidTxCount)
+	assert.Equal(t, uint64(0), stats.InvalidTxCount)
+	assert.Empty(t, stats.Transactions[0].ValidationCode)
+}
+
+func TestQueryGetStateProof(t *testing.T) {
+	chainid := "mytestchainid12"
+	path := tempDir(t, "test12")
+	defer os.RemoveAll(path)
+
+	stub, err := setupTestLedger(chainid, path)
+	require.NoError(t, err)
+	viper.Set("ledger.state.proofs.enabled", true)
+	defer viper.Set("ledger.state.proofs.enabled", false)
+	peer.GetLedger(chainid).EnableCommitHash(true)
+	block1 := addBlockForTesting(t, chainid)
+
+	invoke := func(args ...string) peer2.Response {
+		argsBytes := [][]byte{[]byte(GetStateProof), []byte(chainid)}
+		for _, arg := range args {
+			argsBytes = append(argsBytes, []byte(arg))
+		}
+		prop := resetProvider(resources.Qscc_GetStateProof, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", argsBytes, prop)
+	}
+
+	res := invoke("ns2", "key5")
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	proof := &queryresult.StateProof{}
+	require.NoError(t, proto.Unmarshal(res.Payload, proof))
+	assert.NoError(t, ledgerutil.VerifyStateProof(proof))
+	assert.Equal(t, []byte("value5"), proof.Value)
+	assert.Equal(t, uint64(1), proof.BlockNum)
+	assert.Equal(t, uint64(1), proof.TxNum)
+	commitHash, err := ledgerutil.GetCommitHash(block1)
+	require.NoError(t, err)
+	assert.Equal(t, commitHash, proof.CommitHash)
+
+	res = invoke("ns2", "missing")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Failed to get the proof of key missing of namespace ns2")
+
+	res = invoke("ns2")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Equal(t, "Namespace and key must not be nil.", res.Message)
+}
@@ -8,7 +8,9 @@ SPDX-License-Identifier: Apache-2.0
 
 package scc
 
+import "github.com/hyperledger/fabric/core/aclmgmt"
+
 // CreatePluginSysCCs creates all of the system chaincodes which are compiled into fabric
-func CreatePluginSysCCs(p *Provider) []SelfDescribingSysCC {
+func CreatePluginSysCCs(p *Provider, aclProvider aclmgmt.ACLProvider) []SelfDescribingSysCC {
 	return nil
 }
@@ -9,10 +9,12 @@ SPDX-License-Identifier: Apache-2.0
 
 package scc
 
+import "github.com/hyperledger/fabric/core/aclmgmt"
+
 // CreatePluginSysCCs creates all of the system chaincodes which are loaded by plugin
-func CreatePluginSysCCs(p *Provider) []SelfDescribingSysCC {
+func CreatePluginSysCCs(p *Provider, aclProvider aclmgmt.ACLProvider) []SelfDescribingSysCC {
 	var sdscs []SelfDescribingSysCC
-	for _, pscc := range loadSysCCs(p) {
+	for _, pscc := range loadSysCCs(p, aclProvider) {
 		sdscs = append(sdscs, &SysCCWrapper{SCC: pscc})
 	}
 	return sdscs
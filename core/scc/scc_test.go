@@ -20,7 +20,7 @@ import (
 )
 
 func init() {
-	viper.Set("chaincode.system", map[string]string{"invokableExternalButNotCC2CC": "enable", "invokableCC2CCButNotExternal": "enable", "disabled": "enable"})
+	viper.Set("chaincode.system", map[string]string{"invokableExternalButNotCC2CC": "enable", "invokableCC2CCButNotExternal": "enable", "disabled": "enable", "requiresEndorsementPolicy": "enable"})
 	viper.Set("peer.fileSystemPath", os.TempDir())
 }
 
@@ -49,6 +49,15 @@ func newTestProvider() *Provider {
 				Enabled: false,
 			},
 		},
+		&SysCCWrapper{
+			SCC: &SystemChaincode{
+				Name:                      "requiresEndorsementPolicy",
+				InvokableExternal:         true,
+				InvokableCC2CC:            true,
+				Enabled:                   true,
+				RequiresEndorsementPolicy: true,
+			},
+		},
 	} {
 		p.RegisterSysCC(cc)
 	}
@@ -100,6 +109,12 @@ func TestIsSysCCAndNotInvokableExternal(t *testing.T) {
 	assert.True(t, (newTestProvider()).IsSysCCAndNotInvokableCC2CC("invokableExternalButNotCC2CC"))
 }
 
+func TestIsSysCCAndRequiresEndorsementPolicy(t *testing.T) {
+	assert.True(t, (newTestProvider()).IsSysCCAndRequiresEndorsementPolicy("requiresEndorsementPolicy"))
+	assert.False(t, (newTestProvider()).IsSysCCAndRequiresEndorsementPolicy("invokableExternalButNotCC2CC"))
+	assert.False(t, (newTestProvider()).IsSysCCAndRequiresEndorsementPolicy("noSCC"))
+}
+
 func TestSccProviderImpl_GetQueryExecutorForLedger(t *testing.T) {
 	p := NewProvider(peer.Default, peer.DefaultSupport, inproccontroller.NewRegistry())
 	qe, err := p.GetQueryExecutorForLedger("")
@@ -108,7 +123,7 @@ func TestSccProviderImpl_GetQueryExecutorForLedger(t *testing.T) {
 }
 
 func TestCreatePluginSysCCs(t *testing.T) {
-	assert.NotPanics(t, func() { CreatePluginSysCCs(nil) }, "expected successful init")
+	assert.NotPanics(t, func() { CreatePluginSysCCs(nil, nil) }, "expected successful init")
 }
 
 func TestRegisterSysCC(t *testing.T) {
@@ -135,3 +150,19 @@ func TestRegisterSysCC(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, "invokableExternalButNotCC2CC-latest already registered", err)
 }
+
+func TestRegisterSysCCDuplicate(t *testing.T) {
+	p := &Provider{
+		Registrar: inproccontroller.NewRegistry(),
+	}
+	cc := &SysCCWrapper{
+		SCC: &SystemChaincode{
+			Name:    "duplicate",
+			Path:    "path",
+			Enabled: true,
+		},
+	}
+	assert.NotPanics(t, func() { p.RegisterSysCC(cc) })
+	assert.Panics(t, func() { p.RegisterSysCC(cc) }, "expected panic when registering a system chaincode twice")
+	assert.Len(t, p.SysCCs, 1)
+}
@@ -33,7 +33,14 @@ type Provider struct {
 }
 
 // RegisterSysCC registers a system chaincode with the syscc provider.
+// It panics if a system chaincode with the same name is already registered,
+// so that a plugin cannot shadow a built-in system chaincode.
 func (p *Provider) RegisterSysCC(scc SelfDescribingSysCC) {
+	for _, sysCC := range p.SysCCs {
+		if sysCC.Name() == scc.Name() {
+			sysccLogger.Panicf("Could not register system chaincode: system chaincode %s is already registered", scc.Name())
+		}
+	}
 	p.SysCCs = append(p.SysCCs, scc)
 	_, err := p.registerSysCC(scc)
 	if err != nil {
@@ -98,6 +105,21 @@ func (p *Provider) IsSysCCAndNotInvokableExternal(name string) bool {
 	return false
 }
 
+// IsSysCCAndRequiresEndorsementPolicy returns true if the chaincode
+// is a system chaincode whose namespace may only be written by the
+// transactions satisfying the endorsement policy defined for it by
+// the channel config
+func (p *Provider) IsSysCCAndRequiresEndorsementPolicy(name string) bool {
+	for _, sysCC := range p.SysCCs {
+		if sysCC.Name() == name {
+			r, ok := sysCC.(EndorsementPolicyRequirer)
+			return ok && r.RequiresEndorsementPolicy()
+		}
+	}
+
+	return false
+}
+
 // GetApplicationConfig returns the configtxapplication.SharedConfig for the channel
 // and whether the Application config exists
 func (p *Provider) GetApplicationConfig(cid string) (channelconfig.Application, bool) {
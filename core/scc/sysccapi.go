@@ -59,6 +59,13 @@ type SystemChaincode struct {
 	// Enabled a convenient switch to enable/disable system chaincode without
 	// having to remove entry from importsysccs.go
 	Enabled bool
+
+	// RequiresEndorsementPolicy keeps track of whether
+	// the namespace of this system chaincode may only
+	// be written by the transactions satisfying the
+	// endorsement policy defined for it by the channel
+	// config
+	RequiresEndorsementPolicy bool
 }
 
 type SysCCWrapper struct {
@@ -72,6 +79,9 @@ func (sccw *SysCCWrapper) Chaincode() shim.Chaincode { return sccw.SCC.Chaincode
 func (sccw *SysCCWrapper) InvokableExternal() bool   { return sccw.SCC.InvokableExternal }
 func (sccw *SysCCWrapper) InvokableCC2CC() bool      { return sccw.SCC.InvokableCC2CC }
 func (sccw *SysCCWrapper) Enabled() bool             { return sccw.SCC.Enabled }
+func (sccw *SysCCWrapper) RequiresEndorsementPolicy() bool {
+	return sccw.SCC.RequiresEndorsementPolicy
+}
 
 type SelfDescribingSysCC interface {
 	//Unique name of the system chaincode
@@ -102,6 +112,19 @@ type SelfDescribingSysCC interface {
 	Enabled() bool
 }
 
+// EndorsementPolicyRequirer is implemented by the system chaincodes which may
+// require the writes to their namespace to satisfy the endorsement policy
+// defined for them by the channel config, rather than the endorsement of any
+// member of the channel
+type EndorsementPolicyRequirer interface {
+	// RequiresEndorsementPolicy keeps track of whether
+	// the namespace of this system chaincode may only
+	// be written by the transactions satisfying the
+	// endorsement policy defined for it by the channel
+	// config
+	RequiresEndorsementPolicy() bool
+}
+
 // registerSysCC registers the given system chaincode with the peer
 func (p *Provider) registerSysCC(syscc SelfDescribingSysCC) (bool, error) {
 	if !syscc.Enabled() || !isWhitelisted(syscc) {
@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"context"
+	"io"
+	"math/rand"
+
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+)
+
+// OrdererBroadcaster submits the transactions to one of the orderers of their
+// channel, trying the others when an orderer does not accept a transaction
+type OrdererBroadcaster struct {
+	// Addresses returns the addresses of the orderers of the channel
+	Addresses func(channelID string) []string
+	// ConnFactory returns the function connecting to the orderers of the
+	// channel
+	ConnFactory func(channelID string) func(endpoint string) (*grpc.ClientConn, error)
+}
+
+// Broadcast submits the transaction to the orderers of the channel until one
+// of them accepts it
+func (b *OrdererBroadcaster) Broadcast(ctx context.Context, channelID string, env *common.Envelope) error {
+	addresses := b.Addresses(channelID)
+	if len(addresses) == 0 {
+		return errors.Errorf("channel %s has no orderers", channelID)
+	}
+
+	var err error
+	for _, i := range rand.Perm(len(addresses)) {
+		if err = b.broadcastTo(ctx, channelID, addresses[i], env); err == nil {
+			return nil
+		}
+		logger.Warningf("[%s] Orderer %s did not accept the transaction: %s", channelID, addresses[i], err)
+	}
+	return errors.WithMessage(err, "no orderer accepted the transaction")
+}
+
+func (b *OrdererBroadcaster) broadcastTo(ctx context.Context, channelID, address string, env *common.Envelope) error {
+	conn, err := b.ConnFactory(channelID)(address)
+	if err != nil {
+		return errors.WithMessage(err, "could not connect")
+	}
+	defer conn.Close()
+
+	stream, err := orderer.NewAtomicBroadcastClient(conn).Broadcast(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+	if err := stream.Send(env); err != nil {
+		return err
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if resp.Status != common.Status_SUCCESS {
+		return errors.Errorf("status %s: %s", resp.Status, resp.Info)
+	}
+	return nil
+}
+
+// endorserClient is the endorser of a remote peer
+type endorserClient struct {
+	client pb.EndorserClient
+}
+
+func (e *endorserClient) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	return e.client.ProcessProposal(ctx, signedProp)
+}
+
+// NewEndorserDialer returns the EndorserDialer connecting to the endorsers of
+// the peers with the given dial options
+func NewEndorserDialer(dialOpts ...grpc.DialOption) EndorserDialer {
+	return func(ctx context.Context, address string) (Endorser, io.Closer, error) {
+		conn, err := grpc.DialContext(ctx, address, append(dialOpts, grpc.WithBlock())...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &endorserClient{client: pb.NewEndorserClient(conn)}, conn, nil
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// ordererServer responds to the broadcasts with its status
+type ordererServer struct {
+	status    common.Status
+	envelopes chan *common.Envelope
+}
+
+func (o *ordererServer) Broadcast(stream orderer.AtomicBroadcast_BroadcastServer) error {
+	for {
+		env, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		o.envelopes <- env
+		if err := stream.Send(&orderer.BroadcastResponse{Status: o.status}); err != nil {
+			return err
+		}
+	}
+}
+
+func (o *ordererServer) Deliver(orderer.AtomicBroadcast_DeliverServer) error {
+	panic("implement me")
+}
+
+func startOrderer(t *testing.T, status common.Status) (string, *ordererServer, func()) {
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	o := &ordererServer{status: status, envelopes: make(chan *common.Envelope, 10)}
+	orderer.RegisterAtomicBroadcastServer(srv.Server(), o)
+	go srv.Start()
+	return srv.Address(), o, srv.Stop
+}
+
+func TestOrdererBroadcaster(t *testing.T) {
+	accepting, acceptingServer, stop := startOrderer(t, common.Status_SUCCESS)
+	defer stop()
+	rejecting, rejectingServer, stop := startOrderer(t, common.Status_SERVICE_UNAVAILABLE)
+	defer stop()
+
+	var addresses []string
+	b := &OrdererBroadcaster{
+		Addresses: func(channelID string) []string { return addresses },
+		ConnFactory: func(channelID string) func(endpoint string) (*grpc.ClientConn, error) {
+			return func(endpoint string) (*grpc.ClientConn, error) {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				return grpc.DialContext(ctx, endpoint, grpc.WithInsecure(), grpc.WithBlock())
+			}
+		},
+	}
+	env := &common.Envelope{Payload: []byte("payload"), Signature: []byte("signature")}
+
+	err := b.Broadcast(context.Background(), "testchainid", env)
+	assert.EqualError(t, err, "channel testchainid has no orderers")
+
+	// the other orderers are tried when one does not accept the transaction
+	addresses = []string{rejecting, accepting}
+	require.NoError(t, b.Broadcast(context.Background(), "testchainid", env))
+	assert.True(t, proto.Equal(env, <-acceptingServer.envelopes))
+
+	addresses = []string{rejecting}
+	err = b.Broadcast(context.Background(), "testchainid", env)
+	assert.EqualError(t, err, "no orderer accepted the transaction: status SERVICE_UNAVAILABLE: ")
+	assert.True(t, proto.Equal(env, <-rejectingServer.envelopes))
+}
+
+func TestEndorserDialer(t *testing.T) {
+	srv, err := comm.NewGRPCServer("127.0.0.1:0", comm.ServerConfig{})
+	require.NoError(t, err)
+	pb.RegisterEndorserServer(srv.Server(), &endorser{})
+	go srv.Start()
+	defer srv.Stop()
+
+	signedProp := scheduledProposal(t, 5, 0, nil)
+	dial := NewEndorserDialer(grpc.WithInsecure())
+	e, closer, err := dial(context.Background(), srv.Address())
+	require.NoError(t, err)
+	defer closer.Close()
+	resp, err := e.ProcessProposal(context.Background(), signedProp)
+	require.NoError(t, err)
+	assert.EqualValues(t, 200, resp.Response.Status)
+}
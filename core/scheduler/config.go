@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultInterval = 5 * time.Second
+	defaultTimeout  = 30 * time.Second
+)
+
+// Config is the configuration of the scheduler of the peer
+type Config struct {
+	Enabled bool
+	// Interval is the period at which the scheduled proposals are checked to
+	// submit the transactions of those which are due
+	Interval time.Duration
+	// Timeout is the time to wait for the endorsements and the submission of
+	// the transaction of a scheduled proposal
+	Timeout time.Duration
+}
+
+// GlobalConfig returns the configuration of the scheduler of the peer
+func GlobalConfig() *Config {
+	c := &Config{
+		Enabled:  viper.GetBool("peer.scheduler.enabled"),
+		Interval: viper.GetDuration("peer.scheduler.interval"),
+		Timeout:  viper.GetDuration("peer.scheduler.timeout"),
+	}
+	if c.Interval <= 0 {
+		c.Interval = defaultInterval
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	return c
+}
@@ -0,0 +1,26 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalConfig(t *testing.T) {
+	defer viper.Reset()
+
+	assert.Equal(t, &Config{Interval: 5 * time.Second, Timeout: 30 * time.Second}, GlobalConfig())
+
+	viper.Set("peer.scheduler.enabled", true)
+	viper.Set("peer.scheduler.interval", "1m")
+	viper.Set("peer.scheduler.timeout", "10s")
+	assert.Equal(t, &Config{Enabled: true, Interval: time.Minute, Timeout: 10 * time.Second}, GlobalConfig())
+}
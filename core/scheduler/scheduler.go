@@ -0,0 +1,352 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package scheduler provides the scheduler of the peer, which submits the
+// transactions of the proposals scheduled at a height of the ledger of their
+// channel or at a time. The proposals are signed by their creators when they
+// are scheduled and endorsed as signed when they are due, the signature of a
+// proposal standing for the signature of its transaction, which carries the
+// signed proposal and requires the SCHEDULED_TRANSACTIONS application
+// capability.
+package scheduler
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/common/validation"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = flogging.MustGetLogger("scheduler")
+
+// Endorser endorses the scheduled proposals when they are due
+type Endorser interface {
+	ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error)
+}
+
+// EndorserDialer connects to the endorser of the peer with the given address,
+// returning the endorser along with the closer of the connection
+type EndorserDialer func(ctx context.Context, address string) (Endorser, io.Closer, error)
+
+// Broadcaster submits the transactions to the ordering service of their channel
+type Broadcaster interface {
+	Broadcast(ctx context.Context, channelID string, env *common.Envelope) error
+}
+
+// ACLProvider checks the access of the creators of the proposals to the
+// resources of their channel
+type ACLProvider interface {
+	CheckACL(resName string, channelID string, idinfo interface{}) error
+}
+
+// Support provides the ledgers and the configurations of the channels
+type Support interface {
+	// GetLedgerHeight returns the height of the ledger of the channel
+	GetLedgerHeight(channelID string) (uint64, error)
+	// GetTransactionByID returns the committed transaction with the given ID
+	GetTransactionByID(channelID, txID string) (*pb.ProcessedTransaction, error)
+	// GetApplicationConfig returns the application configuration of the channel
+	GetApplicationConfig(channelID string) (channelconfig.Application, bool)
+}
+
+// Scheduler persists the scheduled proposals and submits their transactions
+// once they are due, collecting the endorsements of the peer and of the
+// endorsers of the requests. A proposal whose transaction could not be
+// submitted is retried at the next check, until it expires.
+type Scheduler struct {
+	db          *leveldbhelper.DB
+	endorser    Endorser
+	dial        EndorserDialer
+	broadcaster Broadcaster
+	aclProvider ACLProvider
+	support     Support
+	interval    time.Duration
+	timeout     time.Duration
+	now         func() time.Time
+
+	// mutex keeps the scheduled proposals open while they are processed
+	mutex   sync.Mutex
+	stop    chan struct{}
+	stopped bool
+}
+
+// NewScheduler opens the scheduled proposals persisted in the given directory
+// and returns the scheduler of the peer, which endorses the proposals with the
+// endorser of the peer and the endorsers dialed by dial, and submits their
+// transactions with the broadcaster
+func NewScheduler(config *Config, dbPath string, endorser Endorser, dial EndorserDialer, broadcaster Broadcaster, aclProvider ACLProvider, support Support) *Scheduler {
+	db := leveldbhelper.CreateDB(&leveldbhelper.Conf{DBPath: dbPath})
+	db.Open()
+	return &Scheduler{
+		db:          db,
+		endorser:    endorser,
+		dial:        dial,
+		broadcaster: broadcaster,
+		aclProvider: aclProvider,
+		support:     support,
+		interval:    config.Interval,
+		timeout:     config.Timeout,
+		now:         time.Now,
+		stop:        make(chan struct{}),
+	}
+}
+
+// requestKey returns the key of the scheduled proposal of the transaction with
+// the given ID on the channel
+func requestKey(channelID, txID string) []byte {
+	return []byte(strings.Join([]string{channelID, txID}, "\x00"))
+}
+
+// Schedule validates and persists the scheduled proposal of the request
+func (s *Scheduler) Schedule(ctx context.Context, req *pb.ScheduleRequest) (*pb.ScheduleResponse, error) {
+	if req.SignedProposal == nil {
+		return nil, status.Error(codes.InvalidArgument, "the request has no signed proposal")
+	}
+	_, hdr, _, err := validation.ValidateProposalMessage(req.SignedProposal)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal: %s", err)
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid proposal: %s", err)
+	}
+	if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION || chdr.ChannelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "only the chaincode proposals of a channel can be scheduled")
+	}
+	if !utils.IsScheduled(chdr) {
+		return nil, status.Errorf(codes.InvalidArgument, "proposal %s has no scheduled height or time", chdr.TxId)
+	}
+
+	appConfig, ok := s.support.GetApplicationConfig(chdr.ChannelId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "channel %s not found", chdr.ChannelId)
+	}
+	if !appConfig.Capabilities().ScheduledTransactions() {
+		return nil, status.Errorf(codes.FailedPrecondition, "channel %s does not support scheduled transactions", chdr.ChannelId)
+	}
+	if err := s.aclProvider.CheckACL(resources.Peer_Schedule, chdr.ChannelId, req.SignedProposal); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "access denied: %s", err)
+	}
+
+	height, err := s.support.GetLedgerHeight(chdr.ChannelId)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "could not get the height of the ledger of channel %s: %s", chdr.ChannelId, err)
+	}
+	if err := validation.CheckExpiration(chdr, height, s.now()); err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if _, err := s.support.GetTransactionByID(chdr.ChannelId, chdr.TxId); err == nil {
+		return nil, status.Errorf(codes.AlreadyExists, "transaction %s is already committed", chdr.TxId)
+	}
+
+	key := requestKey(chdr.ChannelId, chdr.TxId)
+	existing, err := s.db.Get(key)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not read the scheduled proposals: %s", err)
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "proposal %s is already scheduled", chdr.TxId)
+	}
+	value, err := proto.Marshal(req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not marshal the request: %s", err)
+	}
+	if err := s.db.Put(key, value, true); err != nil {
+		return nil, status.Errorf(codes.Internal, "could not persist the scheduled proposal: %s", err)
+	}
+
+	logger.Infof("[%s] Scheduled proposal %s at block %d and at %s", chdr.ChannelId, chdr.TxId, chdr.ScheduledHeight, scheduledTime(chdr))
+	return &pb.ScheduleResponse{TxId: chdr.TxId}, nil
+}
+
+func scheduledTime(chdr *common.ChannelHeader) string {
+	if chdr.ScheduledTime == nil {
+		return "any time"
+	}
+	return time.Unix(chdr.ScheduledTime.Seconds, int64(chdr.ScheduledTime.Nanos)).UTC().Format(time.RFC3339)
+}
+
+// Run checks the scheduled proposals at every interval until the scheduler is
+// stopped, submitting the transactions of those which are due
+func (s *Scheduler) Run() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.submitDue()
+		}
+	}
+}
+
+// Stop stops the scheduler and closes the scheduled proposals
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		return
+	}
+	s.stopped = true
+	close(s.stop)
+	s.db.Close()
+}
+
+type scheduledRequest struct {
+	key []byte
+	req *pb.ScheduleRequest
+}
+
+// submitDue submits the transactions of the scheduled proposals which are due,
+// and drops those which are committed or expired
+func (s *Scheduler) submitDue() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stopped {
+		return
+	}
+
+	var requests []scheduledRequest
+	it := s.db.GetIterator(nil, nil)
+	for it.Next() {
+		req := &pb.ScheduleRequest{}
+		if err := proto.Unmarshal(it.Value(), req); err != nil {
+			logger.Errorf("Dropping the malformed scheduled proposal %q: %s", it.Key(), err)
+			s.delete(it.Key())
+			continue
+		}
+		requests = append(requests, scheduledRequest{key: append([]byte(nil), it.Key()...), req: req})
+	}
+	it.Release()
+
+	for _, r := range requests {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+		if done := s.process(r.req); done {
+			s.delete(r.key)
+		}
+	}
+}
+
+func (s *Scheduler) delete(key []byte) {
+	if err := s.db.Delete(key, true); err != nil {
+		logger.Errorf("Failed deleting the scheduled proposal %q: %s", key, err)
+	}
+}
+
+// process submits the transaction of the scheduled proposal if it is due, and
+// returns whether the proposal is done with
+func (s *Scheduler) process(req *pb.ScheduleRequest) bool {
+	prop, err := utils.GetProposal(req.SignedProposal.ProposalBytes)
+	if err != nil {
+		logger.Errorf("Dropping the malformed scheduled proposal: %s", err)
+		return true
+	}
+	hdr, err := utils.GetHeader(prop.Header)
+	if err != nil {
+		logger.Errorf("Dropping the malformed scheduled proposal: %s", err)
+		return true
+	}
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		logger.Errorf("Dropping the malformed scheduled proposal: %s", err)
+		return true
+	}
+
+	height, err := s.support.GetLedgerHeight(chdr.ChannelId)
+	if err != nil {
+		logger.Warningf("[%s] Could not get the height of the ledger for proposal %s: %s", chdr.ChannelId, chdr.TxId, err)
+		return false
+	}
+	if _, err := s.support.GetTransactionByID(chdr.ChannelId, chdr.TxId); err == nil {
+		logger.Infof("[%s] Dropping proposal %s, its transaction is committed", chdr.ChannelId, chdr.TxId)
+		return true
+	}
+	now := s.now()
+	if err := validation.CheckExpiration(chdr, height, now); err != nil {
+		logger.Warningf("[%s] Dropping scheduled proposal: %s", chdr.ChannelId, err)
+		return true
+	}
+	if err := validation.CheckSchedule(chdr, height, now); err != nil {
+		logger.Debugf("[%s] Proposal %s is not due: %s", chdr.ChannelId, chdr.TxId, err)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.submit(ctx, chdr.ChannelId, req); err != nil {
+		logger.Warningf("[%s] Failed submitting the transaction of scheduled proposal %s, retrying at the next check: %s", chdr.ChannelId, chdr.TxId, err)
+		return false
+	}
+	logger.Infof("[%s] Submitted the transaction of scheduled proposal %s at height %d", chdr.ChannelId, chdr.TxId, height)
+	return true
+}
+
+// submit endorses the proposal with the endorser of the peer and the endorsers
+// of the request, and broadcasts its transaction
+func (s *Scheduler) submit(ctx context.Context, channelID string, req *pb.ScheduleRequest) error {
+	responses := make([]*pb.ProposalResponse, len(req.Endorsers)+1)
+	errs := make([]error, len(req.Endorsers)+1)
+	var wg sync.WaitGroup
+	wg.Add(len(req.Endorsers) + 1)
+	go func() {
+		defer wg.Done()
+		responses[0], errs[0] = s.endorser.ProcessProposal(ctx, req.SignedProposal)
+	}()
+	for i, address := range req.Endorsers {
+		go func(i int, address string) {
+			defer wg.Done()
+			responses[i+1], errs[i+1] = s.endorseRemotely(ctx, address, req.SignedProposal)
+		}(i, address)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		endorser := "the peer"
+		if i > 0 {
+			endorser = req.Endorsers[i-1]
+		}
+		if err != nil {
+			return errors.WithMessage(err, "endorsement by "+endorser+" failed")
+		}
+		if responses[i].Response == nil || responses[i].Response.Status >= 400 {
+			return errors.Errorf("endorsement by %s failed: %s", endorser, responses[i].GetResponse().GetMessage())
+		}
+	}
+
+	env, err := utils.CreateScheduledTx(req.SignedProposal, responses...)
+	if err != nil {
+		return errors.WithMessage(err, "could not assemble the transaction")
+	}
+	return s.broadcaster.Broadcast(ctx, channelID, env)
+}
+
+func (s *Scheduler) endorseRemotely(ctx context.Context, address string, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	endorser, closer, err := s.dial(ctx, address)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not connect")
+	}
+	defer closer.Close()
+	return endorser.ProcessProposal(ctx, signedProp)
+}
@@ -0,0 +1,370 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mc "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/common/validation"
+	"github.com/hyperledger/fabric/msp"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var signer msp.SigningIdentity
+
+func TestMain(m *testing.M) {
+	if err := msptesttools.LoadMSPSetupForTesting(); err != nil {
+		fmt.Printf("Could not initialize msp, err %s", err)
+		os.Exit(-1)
+	}
+	signer = mspmgmt.GetLocalSigningIdentityOrPanic()
+	os.Exit(m.Run())
+}
+
+// endorser endorses the proposals with a successful response
+type endorser struct {
+	mutex    sync.Mutex
+	err      error
+	endorsed int
+}
+
+func (e *endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.err != nil {
+		return nil, e.err
+	}
+	e.endorsed++
+	prop, err := utils.GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	return utils.CreateProposalResponse(prop.Header, prop.Payload, &pb.Response{Status: 200}, []byte("results"), nil, &pb.ChaincodeID{Name: "mycc"}, nil, signer)
+}
+
+type closer struct{}
+
+func (closer) Close() error { return nil }
+
+// broadcaster records the transactions it broadcasts
+type broadcaster struct {
+	err       error
+	envelopes []*common.Envelope
+}
+
+func (b *broadcaster) Broadcast(ctx context.Context, channelID string, env *common.Envelope) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.envelopes = append(b.envelopes, env)
+	return nil
+}
+
+type aclProvider struct {
+	err error
+}
+
+func (a *aclProvider) CheckACL(resName string, channelID string, idinfo interface{}) error {
+	if resName != resources.Peer_Schedule {
+		return fmt.Errorf("unexpected resource %s", resName)
+	}
+	return a.err
+}
+
+// support serves the channel testchainid
+type support struct {
+	mutex        sync.Mutex
+	height       uint64
+	committed    map[string]bool
+	capabilities *mc.MockApplicationCapabilities
+}
+
+func (s *support) GetLedgerHeight(channelID string) (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if channelID != "testchainid" {
+		return 0, errors.New("channel not found")
+	}
+	return s.height, nil
+}
+
+func (s *support) GetTransactionByID(channelID, txID string) (*pb.ProcessedTransaction, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.committed[txID] {
+		return nil, errors.New("transaction not found")
+	}
+	return &pb.ProcessedTransaction{}, nil
+}
+
+func (s *support) GetApplicationConfig(channelID string) (channelconfig.Application, bool) {
+	if channelID != "testchainid" {
+		return nil, false
+	}
+	return &mc.MockApplication{CapabilitiesRv: s.capabilities}, true
+}
+
+func (s *support) setHeight(height uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.height = height
+}
+
+// scheduledProposal returns the signed proposal scheduled at the given height,
+// which expires at the given height unless it is zero
+func scheduledProposal(t *testing.T, height, expirationHeight uint64, transientMap map[string][]byte) *pb.SignedProposal {
+	creator, err := signer.Serialize()
+	require.NoError(t, err)
+	cis := &pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+		ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+		Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("settle")}},
+	}}
+	prop, _, err := utils.CreateChaincodeProposalWithTransient(common.HeaderType_ENDORSER_TRANSACTION, "testchainid", cis, creator, transientMap)
+	require.NoError(t, err)
+	require.NoError(t, utils.SetProposalSchedule(prop, height, time.Time{}))
+	require.NoError(t, utils.SetProposalExpiration(prop, expirationHeight, time.Time{}))
+	signedProp, err := utils.GetSignedProposal(prop, signer)
+	require.NoError(t, err)
+	return signedProp
+}
+
+type testScheduler struct {
+	*Scheduler
+	endorser    *endorser
+	remote      *endorser
+	broadcaster *broadcaster
+	aclProvider *aclProvider
+	support     *support
+}
+
+func newTestScheduler(t *testing.T) (*testScheduler, func()) {
+	dbPath, err := ioutil.TempDir("", "scheduler")
+	require.NoError(t, err)
+
+	ts := &testScheduler{
+		endorser:    &endorser{},
+		remote:      &endorser{},
+		broadcaster: &broadcaster{},
+		aclProvider: &aclProvider{},
+		support: &support{
+			height:       1,
+			committed:    map[string]bool{},
+			capabilities: &mc.MockApplicationCapabilities{ScheduledTransactionsRv: true},
+		},
+	}
+	dial := func(ctx context.Context, address string) (Endorser, io.Closer, error) {
+		if address != "peer1:7051" {
+			return nil, nil, errors.New("connection refused")
+		}
+		return ts.remote, closer{}, nil
+	}
+	config := &Config{Interval: 10 * time.Millisecond, Timeout: time.Second}
+	ts.Scheduler = NewScheduler(config, dbPath, ts.endorser, dial, ts.broadcaster, ts.aclProvider, ts.support)
+	return ts, func() {
+		ts.Stop()
+		os.RemoveAll(dbPath)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	s, cleanup := newTestScheduler(t)
+	defer cleanup()
+
+	assertCode := func(t *testing.T, expected codes.Code, err error) {
+		require.Error(t, err)
+		assert.Equal(t, expected, status.Code(err), err.Error())
+	}
+
+	t.Run("NoProposal", func(t *testing.T) {
+		_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{})
+		assertCode(t, codes.InvalidArgument, err)
+	})
+
+	t.Run("BadSignature", func(t *testing.T) {
+		signedProp := scheduledProposal(t, 5, 0, nil)
+		signedProp.Signature = []byte("forged")
+		_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: signedProp})
+		assertCode(t, codes.InvalidArgument, err)
+	})
+
+	t.Run("NotScheduled", func(t *testing.T) {
+		_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 0, 0, nil)})
+		assertCode(t, codes.InvalidArgument, err)
+		assert.Contains(t, err.Error(), "has no scheduled height or time")
+	})
+
+	t.Run("NoCapability", func(t *testing.T) {
+		s.support.capabilities.ScheduledTransactionsRv = false
+		defer func() { s.support.capabilities.ScheduledTransactionsRv = true }()
+		_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil)})
+		assertCode(t, codes.FailedPrecondition, err)
+		assert.Contains(t, err.Error(), "channel testchainid does not support scheduled transactions")
+	})
+
+	t.Run("AccessDenied", func(t *testing.T) {
+		s.aclProvider.err = errors.New("not a writer")
+		defer func() { s.aclProvider.err = nil }()
+		_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil)})
+		assertCode(t, codes.PermissionDenied, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 1, nil)})
+		assertCode(t, codes.FailedPrecondition, err)
+		assert.Contains(t, err.Error(), "expired at block 1")
+	})
+
+	t.Run("Scheduled", func(t *testing.T) {
+		req := &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil)}
+		resp, err := s.Schedule(context.Background(), req)
+		require.NoError(t, err)
+		prop, err := utils.GetProposal(req.SignedProposal.ProposalBytes)
+		require.NoError(t, err)
+		hdr, err := utils.GetHeader(prop.Header)
+		require.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+		require.NoError(t, err)
+		assert.Equal(t, chdr.TxId, resp.TxId)
+
+		_, err = s.Schedule(context.Background(), req)
+		assertCode(t, codes.AlreadyExists, err)
+
+		s.support.committed[resp.TxId] = true
+		defer delete(s.support.committed, resp.TxId)
+		_, err = s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil)})
+		require.NoError(t, err)
+		_, err = s.Schedule(context.Background(), req)
+		assertCode(t, codes.AlreadyExists, err)
+		assert.Contains(t, err.Error(), "is already committed")
+	})
+}
+
+func TestSubmitDue(t *testing.T) {
+	s, cleanup := newTestScheduler(t)
+	defer cleanup()
+
+	req := &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, map[string][]byte{"secret": []byte("value")}), Endorsers: []string{"peer1:7051"}}
+	resp, err := s.Schedule(context.Background(), req)
+	require.NoError(t, err)
+
+	// the proposal is not endorsed before its scheduled height
+	s.submitDue()
+	assert.Equal(t, 0, s.endorser.endorsed)
+	assert.Empty(t, s.broadcaster.envelopes)
+
+	// the failures are retried at the next check
+	s.support.setHeight(5)
+	s.remote.err = errors.New("chaincode unavailable")
+	s.submitDue()
+	assert.Empty(t, s.broadcaster.envelopes)
+	s.remote.err = nil
+	s.broadcaster.err = errors.New("service unavailable")
+	s.submitDue()
+	assert.Empty(t, s.broadcaster.envelopes)
+	s.broadcaster.err = nil
+
+	// the transaction holds the endorsements of the peer and of the endorsers
+	// of the request and the signed proposal, transient map included, and is
+	// signed by the creator of the proposal
+	s.submitDue()
+	require.Len(t, s.broadcaster.envelopes, 1)
+	env := s.broadcaster.envelopes[0]
+	payload, code := validation.ValidateTransaction(env, &mc.MockApplicationCapabilities{ScheduledTransactionsRv: true})
+	require.Equal(t, pb.TxValidationCode_VALID, code)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	assert.Equal(t, resp.TxId, chdr.TxId)
+	tx, err := utils.GetTransaction(payload.Data)
+	require.NoError(t, err)
+	cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+	require.NoError(t, err)
+	assert.Len(t, cap.Action.Endorsements, 2)
+	assert.Equal(t, req.SignedProposal.ProposalBytes, cap.ScheduledProposal)
+
+	// the proposal is submitted once
+	s.submitDue()
+	assert.Len(t, s.broadcaster.envelopes, 1)
+}
+
+func TestSubmitDueDrops(t *testing.T) {
+	s, cleanup := newTestScheduler(t)
+	defer cleanup()
+
+	expiring, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 6, nil)})
+	require.NoError(t, err)
+	committed, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil)})
+	require.NoError(t, err)
+	unreachable, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil), Endorsers: []string{"peer2:7051"}})
+	require.NoError(t, err)
+
+	s.support.setHeight(6)
+	s.support.committed[committed.TxId] = true
+	s.submitDue()
+	assert.Equal(t, 1, s.endorser.endorsed, "only the proposal due is endorsed")
+	assert.Empty(t, s.broadcaster.envelopes)
+
+	// only the proposal which could not be endorsed is kept
+	for _, txID := range []string{expiring.TxId, committed.TxId} {
+		value, err := s.db.Get(requestKey("testchainid", txID))
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	}
+	value, err := s.db.Get(requestKey("testchainid", unreachable.TxId))
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+}
+
+func TestRun(t *testing.T) {
+	s, cleanup := newTestScheduler(t)
+	defer cleanup()
+
+	_, err := s.Schedule(context.Background(), &pb.ScheduleRequest{SignedProposal: scheduledProposal(t, 5, 0, nil)})
+	require.NoError(t, err)
+	s.support.setHeight(5)
+
+	done := make(chan struct{})
+	go func() {
+		s.Run()
+		close(done)
+	}()
+	submitted := func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		return len(s.broadcaster.envelopes) == 1
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !submitted() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(t, submitted(), "the transaction was not submitted")
+
+	s.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the scheduler did not stop")
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = flogging.MustGetLogger("standby")
+
+const proposalMethod = "/protos.Endorser/ProcessProposal"
+
+// Mode tracks whether the peer is a warm standby peer. A standby peer replicates
+// the ledgers of its channels from the ordering service, pulling the private data
+// from the authorized peers, but neither endorses nor disseminates the blocks to
+// the other peers. It keeps doing so until it is promoted, typically when the
+// primary peer it stands in for fails.
+type Mode struct {
+	mutex       sync.Mutex
+	standby     bool
+	onPromotion []func()
+}
+
+// Status is the status of the peer reported by the operations endpoint
+type Status struct {
+	Standby bool `json:"standby"`
+}
+
+// NewMode creates the mode of the peer
+func NewMode(standby bool) *Mode {
+	if standby {
+		logger.Info("The peer is a standby peer, it does not endorse until promoted")
+	}
+	return &Mode{standby: standby}
+}
+
+// Standby returns true if the peer is a standby peer which has not been promoted yet
+func (m *Mode) Standby() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.standby
+}
+
+// OnPromotion registers a function called upon the promotion of the peer
+func (m *Mode) OnPromotion(f func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.onPromotion = append(m.onPromotion, f)
+}
+
+// Promote promotes a standby peer to a regular peer. It returns false if the
+// peer is not a standby peer
+func (m *Mode) Promote() bool {
+	m.mutex.Lock()
+	if !m.standby {
+		m.mutex.Unlock()
+		return false
+	}
+	m.standby = false
+	onPromotion := m.onPromotion
+	m.mutex.Unlock()
+
+	logger.Info("Promoting the standby peer")
+	for _, f := range onPromotion {
+		f()
+	}
+	logger.Info("The standby peer has been promoted")
+	return true
+}
+
+// UnaryServerInterceptor rejects the proposals with an Unavailable status while
+// the peer is a standby peer, so that the clients send them to another peer
+func (m *Mode) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if info.FullMethod == proposalMethod && m.Standby() {
+		return nil, status.Error(codes.Unavailable, "the peer is a standby peer and does not endorse")
+	}
+	return handler(ctx, req)
+}
+
+// ServeHTTP reports whether the peer is a standby peer in JSON on GET requests, and
+// promotes the peer on POST requests
+func (m *Mode) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if !m.Promote() {
+			http.Error(resp, "the peer is not a standby peer", http.StatusConflict)
+			return
+		}
+	default:
+		resp.Header().Set("Allow", "GET, POST")
+		http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(&Status{Standby: m.Standby()}); err != nil {
+		logger.Errorf("failed to encode the standby status: %s", err)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package standby
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPromote(t *testing.T) {
+	m := NewMode(true)
+	assert.True(t, m.Standby())
+
+	var promotions int
+	m.OnPromotion(func() { promotions++ })
+	m.OnPromotion(func() {
+		// the peer is no longer a standby peer when the functions are called
+		assert.False(t, m.Standby())
+		promotions++
+	})
+
+	assert.True(t, m.Promote())
+	assert.False(t, m.Standby())
+	assert.Equal(t, 2, promotions)
+
+	assert.False(t, m.Promote())
+	assert.Equal(t, 2, promotions)
+
+	assert.False(t, NewMode(false).Promote())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	m := NewMode(true)
+	var calls int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return "response", nil
+	}
+
+	_, err := m.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: proposalMethod}, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 0, calls)
+
+	resp, err := m.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/protos.Admin/GetStatus"}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+
+	m.Promote()
+	resp, err = m.UnaryServerInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: proposalMethod}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+	assert.Equal(t, 2, calls)
+}
+
+func TestServeHTTP(t *testing.T) {
+	m := NewMode(true)
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/standby", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"standby":true}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/standby", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"standby":false}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/standby", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/standby", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, POST", rec.Header().Get("Allow"))
+}
@@ -0,0 +1,224 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startup
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("startup")
+
+// Config holds the retry settings of the Manager
+type Config struct {
+	// Timeout is the time the Manager waits for all the dependencies to be
+	// reachable before giving up
+	Timeout time.Duration
+	// InitialBackoff is the delay before the first retry of a dependency, which
+	// is doubled after each failed attempt up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// AttemptTimeout bounds the duration of each attempt
+	AttemptTimeout time.Duration
+}
+
+// DependencyStatus reports the reachability of a dependency
+type DependencyStatus struct {
+	Name      string    `json:"name"`
+	Ready     bool      `json:"ready"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	ReadyAt   time.Time `json:"ready_at,omitempty"`
+}
+
+// Manager waits, at startup, for the external services the peer depends on
+// (databases, container runtime, ordering service...) to be reachable. Each
+// dependency is checked concurrently and retried with an exponential backoff,
+// so that a peer started along with its dependencies does not fail because
+// one of them is not up yet
+type Manager struct {
+	config Config
+
+	lock         sync.Mutex
+	names        []string
+	checkers     map[string]healthz.HealthChecker
+	dependencies map[string]*DependencyStatus
+}
+
+// NewManager returns a Manager with the given retry settings
+func NewManager(config Config) *Manager {
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = time.Second
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+	if config.AttemptTimeout <= 0 {
+		config.AttemptTimeout = 10 * time.Second
+	}
+	return &Manager{
+		config:       config,
+		checkers:     map[string]healthz.HealthChecker{},
+		dependencies: map[string]*DependencyStatus{},
+	}
+}
+
+// Register adds a dependency, which is reachable when its checker succeeds
+func (m *Manager) Register(name string, checker healthz.HealthChecker) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, exists := m.checkers[name]; exists {
+		return errors.Errorf("dependency %s is already registered", name)
+	}
+	m.names = append(m.names, name)
+	m.checkers[name] = checker
+	m.dependencies[name] = &DependencyStatus{Name: name}
+	return nil
+}
+
+// WaitForDependencies blocks until all the dependencies are reachable, in which
+// case nil is returned, or until the timeout expires or the context is done, in
+// which case the error lists the dependencies that are not reachable
+func (m *Manager) WaitForDependencies(ctx context.Context) error {
+	if m.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.config.Timeout)
+		defer cancel()
+	}
+
+	m.lock.Lock()
+	names := append([]string(nil), m.names...)
+	m.lock.Unlock()
+
+	var wg sync.WaitGroup
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			m.wait(ctx, name)
+		}(name)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, status := range m.Status() {
+		if !status.Ready {
+			failed = append(failed, status.Name+": "+status.LastError)
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("dependencies not reachable at startup: [%s]", strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (m *Manager) wait(ctx context.Context, name string) {
+	m.lock.Lock()
+	checker := m.checkers[name]
+	m.lock.Unlock()
+
+	backoff := m.config.InitialBackoff
+	for {
+		attemptCtx, cancel := context.WithTimeout(ctx, m.config.AttemptTimeout)
+		err := checker.HealthCheck(attemptCtx)
+		cancel()
+		if m.record(name, err) {
+			logger.Infof("Dependency %s is reachable", name)
+			return
+		}
+		logger.Warningf("Dependency %s is not reachable, retrying in %s: %s", name, backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			logger.Errorf("Gave up waiting for dependency %s: %s", name, ctx.Err())
+			return
+		}
+		backoff *= 2
+		if backoff > m.config.MaxBackoff {
+			backoff = m.config.MaxBackoff
+		}
+	}
+}
+
+// record updates the status of the dependency with the outcome of an attempt
+// and returns whether it succeeded
+func (m *Manager) record(name string, err error) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	status := m.dependencies[name]
+	status.Attempts++
+	if err != nil {
+		status.LastError = err.Error()
+		return false
+	}
+	status.Ready = true
+	status.LastError = ""
+	status.ReadyAt = time.Now()
+	return true
+}
+
+// Status returns the status of the dependencies, sorted by name
+func (m *Manager) Status() []DependencyStatus {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var statuses []DependencyStatus
+	for _, status := range m.dependencies {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// ServeHTTP reports the status of the dependencies in JSON, with the status
+// code 503 while any of them is not reachable
+func (m *Manager) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	statuses := m.Status()
+	code := http.StatusOK
+	for _, status := range statuses {
+		if !status.Ready {
+			code = http.StatusServiceUnavailable
+		}
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(statuses); err != nil {
+		logger.Errorf("failed to encode the status of the dependencies: %s", err)
+	}
+}
+
+// CheckerFunc adapts a function to a healthz.HealthChecker
+type CheckerFunc func(ctx context.Context) error
+
+// HealthCheck calls the function
+func (f CheckerFunc) HealthCheck(ctx context.Context) error {
+	return f(ctx)
+}
+
+// TCPChecker returns a checker that succeeds when a TCP connection to the
+// address can be established
+func TCPChecker(address string) healthz.HealthChecker {
+	return CheckerFunc(func(ctx context.Context) error {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
@@ -0,0 +1,86 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package startup
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingChecker fails the given number of attempts before succeeding
+func failingChecker(failures int32) CheckerFunc {
+	var attempts int32
+	return func(ctx context.Context) error {
+		if atomic.AddInt32(&attempts, 1) <= failures {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+}
+
+func TestWaitForDependencies(t *testing.T) {
+	m := NewManager(Config{Timeout: 5 * time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond})
+	require.NoError(t, m.Register("couchdb", failingChecker(3)))
+	require.NoError(t, m.Register("docker", failingChecker(0)))
+	assert.EqualError(t, m.Register("docker", failingChecker(0)), "dependency docker is already registered")
+
+	rec := httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/startupz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	err := m.WaitForDependencies(context.Background())
+	assert.NoError(t, err)
+
+	statuses := m.Status()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "couchdb", statuses[0].Name)
+	assert.True(t, statuses[0].Ready)
+	assert.Equal(t, 4, statuses[0].Attempts)
+	assert.Equal(t, "docker", statuses[1].Name)
+	assert.Equal(t, 1, statuses[1].Attempts)
+
+	rec = httptest.NewRecorder()
+	m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/startupz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var reported []DependencyStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &reported))
+	assert.Len(t, reported, 2)
+}
+
+func TestWaitForDependenciesTimeout(t *testing.T) {
+	m := NewManager(Config{Timeout: 50 * time.Millisecond, InitialBackoff: 10 * time.Millisecond})
+	require.NoError(t, m.Register("orderer", failingChecker(1000)))
+	require.NoError(t, m.Register("docker", failingChecker(0)))
+
+	err := m.WaitForDependencies(context.Background())
+	assert.EqualError(t, err, "dependencies not reachable at startup: [orderer: connection refused]")
+	statuses := m.Status()
+	assert.False(t, statuses[1].Ready)
+	assert.True(t, statuses[1].Attempts > 1)
+}
+
+func TestTCPChecker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	checker := TCPChecker(address)
+	assert.NoError(t, checker.HealthCheck(context.Background()))
+
+	listener.Close()
+	assert.Error(t, checker.HealthCheck(context.Background()))
+}
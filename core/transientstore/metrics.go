@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstore
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+type stats struct {
+	chaincodeBytes   metrics.Gauge
+	chaincodeEntries metrics.Gauge
+	evictions        metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		chaincodeBytes:   metricsProvider.NewGauge(chaincodeBytesOpts),
+		chaincodeEntries: metricsProvider.NewGauge(chaincodeEntriesOpts),
+		evictions:        metricsProvider.NewCounter(evictionsOpts),
+	}
+}
+
+func (s *stats) updateUsage(ledgerID, namespace string, usage nsUsage) {
+	s.chaincodeBytes.With("channel", ledgerID, "chaincode", namespace).Set(float64(usage.bytes))
+	s.chaincodeEntries.With("channel", ledgerID, "chaincode", namespace).Set(float64(usage.entries))
+}
+
+func (s *stats) addEviction(ledgerID, namespace string) {
+	s.evictions.With("channel", ledgerID, "chaincode", namespace).Add(1)
+}
+
+var (
+	chaincodeBytesOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Name:         "chaincode_bytes",
+		Help:         "The size in bytes of the private write sets of the chaincode in the transient store.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	chaincodeEntriesOpts = metrics.GaugeOpts{
+		Namespace:    "transientstore",
+		Name:         "chaincode_entries",
+		Help:         "The number of private write sets of the chaincode in the transient store.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	evictionsOpts = metrics.CounterOpts{
+		Namespace:    "transientstore",
+		Name:         "evictions",
+		Help:         "The number of private write sets of the chaincode evicted from the transient store to respect its quota.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+)
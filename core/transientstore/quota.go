@@ -0,0 +1,325 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstore
+
+import (
+	"bytes"
+	"container/list"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/transientstore"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	maxBytesPerChaincodeConfigKey   = "peer.gossip.pvtData.transientstoreMaxBytesPerChaincode"
+	maxEntriesPerChaincodeConfigKey = "peer.gossip.pvtData.transientstoreMaxEntriesPerChaincode"
+)
+
+// QuotaConfig bounds the private write sets kept in the transient store of a
+// channel for each chaincode. When persisting a private write set would exceed
+// the quota of one of its chaincodes, the least recently used private write
+// sets of the chaincode are evicted. A zero limit means no limit.
+type QuotaConfig struct {
+	// MaxBytesPerChaincode is the maximum size in bytes of the private write
+	// sets of a chaincode
+	MaxBytesPerChaincode int64
+	// MaxEntriesPerChaincode is the maximum number of private write sets of a
+	// chaincode
+	MaxEntriesPerChaincode int
+}
+
+// GetQuotaConfig returns the quota of the transient stores from the peer
+// configuration
+func GetQuotaConfig() QuotaConfig {
+	quota := QuotaConfig{
+		MaxBytesPerChaincode:   int64(viper.GetInt(maxBytesPerChaincodeConfigKey)),
+		MaxEntriesPerChaincode: viper.GetInt(maxEntriesPerChaincodeConfigKey),
+	}
+	if quota.MaxBytesPerChaincode < 0 {
+		logger.Warningf("Configuration key %s is negative, the size of the transient store is not limited", maxBytesPerChaincodeConfigKey)
+		quota.MaxBytesPerChaincode = 0
+	}
+	if quota.MaxEntriesPerChaincode < 0 {
+		logger.Warningf("Configuration key %s is negative, the entries of the transient store are not limited", maxEntriesPerChaincodeConfigKey)
+		quota.MaxEntriesPerChaincode = 0
+	}
+	return quota
+}
+
+// exceeded returns whether the usage of a chaincode is above the quota
+func (q QuotaConfig) exceeded(usage nsUsage) bool {
+	return (q.MaxBytesPerChaincode > 0 && usage.bytes > q.MaxBytesPerChaincode) ||
+		(q.MaxEntriesPerChaincode > 0 && usage.entries > q.MaxEntriesPerChaincode)
+}
+
+// entryID identifies a private write set in the transient store
+type entryID struct {
+	txid        string
+	uuid        string
+	blockHeight uint64
+}
+
+// usageEntry is the size of a private write set for each of its chaincodes
+type usageEntry struct {
+	id      entryID
+	nsSizes map[string]int64
+}
+
+// nsUsage is the usage of the transient store by a chaincode
+type nsUsage struct {
+	bytes   int64
+	entries int
+}
+
+// usageTracker keeps the usage of the transient store of a channel by each
+// chaincode, and the order in which its private write sets were last
+// persisted or read
+type usageTracker struct {
+	// lru holds the *usageEntry from the most recently used to the least
+	// recently used
+	lru     *list.List
+	entries map[entryID]*list.Element
+	usage   map[string]nsUsage
+}
+
+func newUsageTracker() *usageTracker {
+	return &usageTracker{
+		lru:     list.New(),
+		entries: map[entryID]*list.Element{},
+		usage:   map[string]nsUsage{},
+	}
+}
+
+// add records a private write set as the most recently used one
+func (t *usageTracker) add(entry *usageEntry) {
+	if _, ok := t.entries[entry.id]; ok {
+		return
+	}
+	t.entries[entry.id] = t.lru.PushFront(entry)
+	for ns, size := range entry.nsSizes {
+		usage := t.usage[ns]
+		usage.bytes += size
+		usage.entries++
+		t.usage[ns] = usage
+	}
+}
+
+// remove forgets a private write set and returns it, or nil if it is unknown
+func (t *usageTracker) remove(id entryID) *usageEntry {
+	element, ok := t.entries[id]
+	if !ok {
+		return nil
+	}
+	delete(t.entries, id)
+	entry := t.lru.Remove(element).(*usageEntry)
+	for ns, size := range entry.nsSizes {
+		usage := t.usage[ns]
+		usage.bytes -= size
+		usage.entries--
+		if usage.entries <= 0 {
+			delete(t.usage, ns)
+			continue
+		}
+		t.usage[ns] = usage
+	}
+	return entry
+}
+
+// touch marks a private write set as the most recently used one
+func (t *usageTracker) touch(id entryID) {
+	if element, ok := t.entries[id]; ok {
+		t.lru.MoveToFront(element)
+	}
+}
+
+// victims returns the least recently used private write sets to evict so
+// that the chaincodes of a new private write set remain within the quota
+// once it is added
+func (t *usageTracker) victims(entry *usageEntry, quota QuotaConfig) ([]*usageEntry, error) {
+	projected := map[string]nsUsage{}
+	for ns, size := range entry.nsSizes {
+		if quota.MaxBytesPerChaincode > 0 && size > quota.MaxBytesPerChaincode {
+			return nil, errors.Errorf("the private write set of chaincode %s of transaction %s is %d bytes, more than the transient store quota of %d bytes per chaincode",
+				ns, entry.id.txid, size, quota.MaxBytesPerChaincode)
+		}
+		usage := t.usage[ns]
+		usage.bytes += size
+		usage.entries++
+		projected[ns] = usage
+	}
+
+	exceeded := func(nsSizes map[string]int64) bool {
+		for ns := range nsSizes {
+			if usage, ok := projected[ns]; ok && quota.exceeded(usage) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var victims []*usageEntry
+	for element := t.lru.Back(); element != nil && exceeded(entry.nsSizes); element = element.Prev() {
+		candidate := element.Value.(*usageEntry)
+		if !exceeded(candidate.nsSizes) {
+			continue
+		}
+		victims = append(victims, candidate)
+		for ns, size := range candidate.nsSizes {
+			if usage, ok := projected[ns]; ok {
+				usage.bytes -= size
+				usage.entries--
+				projected[ns] = usage
+			}
+		}
+	}
+	return victims, nil
+}
+
+// namespaces returns the sorted chaincodes of the private write sets
+func namespaces(entries ...*usageEntry) []string {
+	set := map[string]struct{}{}
+	for _, entry := range entries {
+		for ns := range entry.nsSizes {
+			set[ns] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(set))
+	for ns := range set {
+		sorted = append(sorted, ns)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// nsSizesOfPvtRWSet returns the size of the private write set of each
+// chaincode, along with the size of its collection configs if any
+func nsSizesOfPvtRWSet(pvtRWSet *rwset.TxPvtReadWriteSet, configs map[string]*common.CollectionConfigPackage) map[string]int64 {
+	nsSizes := map[string]int64{}
+	for _, nsPvtRWSet := range pvtRWSet.GetNsPvtRwset() {
+		nsSizes[nsPvtRWSet.Namespace] += int64(proto.Size(nsPvtRWSet))
+	}
+	for ns, config := range configs {
+		if _, ok := nsSizes[ns]; ok {
+			nsSizes[ns] += int64(proto.Size(config))
+		}
+	}
+	return nsSizes
+}
+
+// nsSizesOfValue returns the size of each chaincode of a private write set
+// as stored in the transient store
+func nsSizesOfValue(value []byte) (map[string]int64, error) {
+	if len(value) > 0 && value[0] == nilByte {
+		pvtRWSetWithConfig := &transientstore.TxPvtReadWriteSetWithConfigInfo{}
+		if err := proto.Unmarshal(value[1:], pvtRWSetWithConfig); err != nil {
+			return nil, err
+		}
+		return nsSizesOfPvtRWSet(pvtRWSetWithConfig.PvtRwset, pvtRWSetWithConfig.CollectionConfigs), nil
+	}
+	pvtRWSet := &rwset.TxPvtReadWriteSet{}
+	if err := proto.Unmarshal(value, pvtRWSet); err != nil {
+		return nil, err
+	}
+	return nsSizesOfPvtRWSet(pvtRWSet, nil), nil
+}
+
+// loadUsage rebuilds the usage of the transient store from the private write
+// sets it holds, the ones received at the lowest block heights being the
+// least recently used
+func (s *store) loadUsage() error {
+	startKey := []byte{prwsetPrefix, compositeKeySep}
+	endKey := []byte{prwsetPrefix, byte(0xff)}
+	iter := s.db.GetIterator(startKey, endKey)
+	defer iter.Release()
+
+	var entries []*usageEntry
+	for iter.Next() {
+		key := iter.Key()
+		txid := string(key[2 : 2+bytes.IndexByte(key[2:], compositeKeySep)])
+		uuid, blockHeight := splitCompositeKeyOfPvtRWSet(key)
+		nsSizes, err := nsSizesOfValue(iter.Value())
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the private write set of transaction %s in the transient store of %s", txid, s.ledgerID)
+		}
+		entries = append(entries, &usageEntry{
+			id:      entryID{txid: txid, uuid: uuid, blockHeight: blockHeight},
+			nsSizes: nsSizes,
+		})
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Wrapf(err, "failed to iterate the transient store of %s", s.ledgerID)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].id.blockHeight < entries[j].id.blockHeight })
+	for _, entry := range entries {
+		s.usage.add(entry)
+	}
+	s.reportUsage(namespaces(entries...))
+	return nil
+}
+
+// persistWithinQuota writes the batch persisting a private write set along
+// with the eviction of the private write sets needed for its chaincodes to
+// remain within the quota
+func (s *store) persistWithinQuota(dbBatch *leveldbhelper.UpdateBatch, entry *usageEntry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	victims, err := s.usage.victims(entry, s.quota)
+	if err != nil {
+		return err
+	}
+	for _, victim := range victims {
+		deleteEntry(dbBatch, victim.id)
+	}
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+
+	for _, victim := range victims {
+		s.usage.remove(victim.id)
+		for _, ns := range namespaces(victim) {
+			s.stats.addEviction(s.ledgerID, ns)
+		}
+		logger.Warningf("Evicted the private write set of transaction [%s] received at block height [%d] from the transient store of [%s] to respect the quota of chaincodes %v",
+			victim.id.txid, victim.id.blockHeight, s.ledgerID, namespaces(victim))
+	}
+	s.usage.add(entry)
+	s.reportUsage(namespaces(append(victims, entry)...))
+	return nil
+}
+
+// forget removes purged private write sets from the usage
+func (s *store) forget(ids []entryID) {
+	var removed []*usageEntry
+	for _, id := range ids {
+		if entry := s.usage.remove(id); entry != nil {
+			removed = append(removed, entry)
+		}
+	}
+	s.reportUsage(namespaces(removed...))
+}
+
+func (s *store) reportUsage(namespaces []string) {
+	for _, ns := range namespaces {
+		s.stats.updateUsage(s.ledgerID, ns, s.usage.usage[ns])
+	}
+}
+
+// deleteEntry adds to the batch the deletion of a private write set and of
+// its indexes
+func deleteEntry(dbBatch *leveldbhelper.UpdateBatch, id entryID) {
+	dbBatch.Delete(createCompositeKeyForPvtRWSet(id.txid, id.uuid, id.blockHeight))
+	dbBatch.Delete(createCompositeKeyForPurgeIndexByHeight(id.blockHeight, id.txid, id.uuid))
+	dbBatch.Delete(createCompositeKeyForPurgeIndexByTxid(id.txid, id.uuid, id.blockHeight))
+}
@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package transientstore
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQuotaConfig(t *testing.T) {
+	defer viper.Set(maxBytesPerChaincodeConfigKey, nil)
+	defer viper.Set(maxEntriesPerChaincodeConfigKey, nil)
+
+	assert.Equal(t, QuotaConfig{}, GetQuotaConfig())
+
+	viper.Set(maxBytesPerChaincodeConfigKey, 1048576)
+	viper.Set(maxEntriesPerChaincodeConfigKey, 100)
+	assert.Equal(t, QuotaConfig{MaxBytesPerChaincode: 1048576, MaxEntriesPerChaincode: 100}, GetQuotaConfig())
+
+	viper.Set(maxBytesPerChaincodeConfigKey, -1)
+	viper.Set(maxEntriesPerChaincodeConfigKey, -1)
+	assert.Equal(t, QuotaConfig{}, GetQuotaConfig())
+}
+
+func TestTransientStoreEntriesQuota(t *testing.T) {
+	removeStorePath(t)
+	defer removeStorePath(t)
+
+	fakeProvider := &metricsfakes.Provider{}
+	fakeEvictions := &metricsfakes.Counter{}
+	fakeEvictions.WithReturns(fakeEvictions)
+	fakeGauge := &metricsfakes.Gauge{}
+	fakeGauge.WithReturns(fakeGauge)
+	fakeProvider.NewCounterReturns(fakeEvictions)
+	fakeProvider.NewGaugeReturns(fakeGauge)
+
+	provider := NewStoreProvider(fakeProvider, QuotaConfig{MaxEntriesPerChaincode: 2})
+	defer provider.Close()
+	s, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	require.NoError(t, s.Persist("txid-1", 10, pvtDataOfSize("ns-1", 10)))
+	require.NoError(t, s.Persist("txid-2", 11, pvtDataOfSize("ns-1", 10)))
+	require.NoError(t, s.Persist("txid-3", 11, pvtDataOfSize("ns-2", 10)))
+
+	// reading txid-1 makes txid-2 the least recently used entry of ns-1
+	assert.Len(t, retrieveAll(t, s, "txid-1"), 1)
+	require.NoError(t, s.Persist("txid-4", 12, pvtDataOfSize("ns-1", 10)))
+
+	assert.Len(t, retrieveAll(t, s, "txid-1"), 1)
+	assert.Empty(t, retrieveAll(t, s, "txid-2"))
+	assert.Len(t, retrieveAll(t, s, "txid-3"), 1)
+	assert.Len(t, retrieveAll(t, s, "txid-4"), 1)
+
+	require.Equal(t, 1, fakeEvictions.WithCallCount())
+	assert.Equal(t, []string{"channel", "TestStore", "chaincode", "ns-1"}, fakeEvictions.WithArgsForCall(0))
+	assert.Equal(t, float64(1), fakeEvictions.AddArgsForCall(0))
+
+	// the entries purged are not counted anymore
+	require.NoError(t, s.PurgeByTxids([]string{"txid-1"}))
+	require.NoError(t, s.Persist("txid-5", 12, pvtDataOfSize("ns-1", 10)))
+	assert.Len(t, retrieveAll(t, s, "txid-4"), 1)
+	assert.Equal(t, 1, fakeEvictions.WithCallCount())
+}
+
+func TestTransientStoreBytesQuota(t *testing.T) {
+	removeStorePath(t)
+	defer removeStorePath(t)
+
+	entrySize := nsSizesOfPvtRWSet(pvtDataOfSize("ns-1", 100), nil)["ns-1"]
+	provider := NewStoreProvider(&disabled.Provider{}, QuotaConfig{MaxBytesPerChaincode: 3 * entrySize})
+	defer provider.Close()
+	s, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+
+	for i, txid := range []string{"txid-1", "txid-2", "txid-3", "txid-4"} {
+		require.NoError(t, s.Persist(txid, uint64(10+i), pvtDataOfSize("ns-1", 100)))
+	}
+	assert.Empty(t, retrieveAll(t, s, "txid-1"))
+	for _, txid := range []string{"txid-2", "txid-3", "txid-4"} {
+		assert.Len(t, retrieveAll(t, s, txid), 1)
+	}
+
+	// a private write set larger than the quota is rejected
+	err = s.Persist("txid-5", 14, pvtDataOfSize("ns-1", 400))
+	assert.Contains(t, err.Error(), "more than the transient store quota")
+	assert.Empty(t, retrieveAll(t, s, "txid-5"))
+	assert.Len(t, retrieveAll(t, s, "txid-2"), 1)
+}
+
+func TestTransientStoreUsageReload(t *testing.T) {
+	removeStorePath(t)
+	defer removeStorePath(t)
+
+	provider := NewStoreProvider(&disabled.Provider{}, QuotaConfig{})
+	s, err := provider.OpenStore("TestStore")
+	require.NoError(t, err)
+	require.NoError(t, s.Persist("txid-1", 12, pvtDataOfSize("ns-1", 10)))
+	require.NoError(t, s.PersistWithConfig("txid-2", 10, samplePvtDataWithConfigInfo(t)))
+	usage := s.(*store).usage.usage
+	provider.Close()
+
+	provider = NewStoreProvider(&disabled.Provider{}, QuotaConfig{MaxEntriesPerChaincode: 1})
+	defer provider.Close()
+	s, err = provider.OpenStore("TestStore")
+	require.NoError(t, err)
+	assert.Equal(t, usage, s.(*store).usage.usage)
+	assert.Equal(t, 2, usage["ns-1"].entries)
+	assert.Equal(t, 1, usage["ns-2"].entries)
+
+	// the entries received at the lowest heights are the least recently used
+	require.NoError(t, s.Persist("txid-3", 13, pvtDataOfSize("ns-1", 10)))
+	assert.Empty(t, retrieveAll(t, s, "txid-1"))
+	assert.Empty(t, retrieveAll(t, s, "txid-2"))
+	assert.Len(t, retrieveAll(t, s, "txid-3"), 1)
+	assert.Equal(t, 1, s.(*store).usage.usage["ns-1"].entries)
+	assert.NotContains(t, s.(*store).usage.usage, "ns-2")
+}
+
+func pvtDataOfSize(ns string, size int) *rwset.TxPvtReadWriteSet {
+	return &rwset.TxPvtReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsPvtRwset: []*rwset.NsPvtReadWriteSet{
+			{
+				Namespace: ns,
+				CollectionPvtRwset: []*rwset.CollectionPvtReadWriteSet{
+					{CollectionName: "coll-1", Rwset: make([]byte, size)},
+				},
+			},
+		},
+	}
+}
+
+func retrieveAll(t *testing.T, s Store, txid string) []*EndorserPvtSimulationResults {
+	iter, err := s.GetTxPvtRWSetByTxid(txid, nil)
+	require.NoError(t, err)
+	defer iter.Close()
+	var results []*EndorserPvtSimulationResults
+	for {
+		result, err := iter.Next()
+		require.NoError(t, err)
+		if result == nil {
+			return results
+		}
+		results = append(results, result)
+	}
+}
@@ -8,10 +8,12 @@ package transientstore
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
@@ -105,30 +107,50 @@ type EndorserPvtSimulationResultsWithConfig struct {
 // interface.
 type storeProvider struct {
 	dbProvider *leveldbhelper.Provider
+	quota      QuotaConfig
+	stats      *stats
 }
 
 // store holds an instance of a levelDB.
 type store struct {
 	db       *leveldbhelper.DBHandle
 	ledgerID string
+	quota    QuotaConfig
+	stats    *stats
+	// mutex guards the usage, which must reflect the private write sets
+	// persisted and purged
+	mutex sync.Mutex
+	usage *usageTracker
 }
 
 type RwsetScanner struct {
 	txid   string
 	dbItr  iterator.Iterator
 	filter ledger.PvtNsCollFilter
+	store  *store
 }
 
-// NewStoreProvider instantiates TransientStoreProvider
-func NewStoreProvider() StoreProvider {
+// NewStoreProvider instantiates TransientStoreProvider, whose stores keep the
+// private write sets of each chaincode within the quota
+func NewStoreProvider(metricsProvider metrics.Provider, quota QuotaConfig) StoreProvider {
 	dbProvider := leveldbhelper.NewProvider(&leveldbhelper.Conf{DBPath: GetTransientStorePath()})
-	return &storeProvider{dbProvider: dbProvider}
+	return &storeProvider{dbProvider: dbProvider, quota: quota, stats: newStats(metricsProvider)}
 }
 
 // OpenStore returns a handle to a ledgerId in Store
 func (provider *storeProvider) OpenStore(ledgerID string) (Store, error) {
 	dbHandle := provider.dbProvider.GetDBHandle(ledgerID)
-	return &store{db: dbHandle, ledgerID: ledgerID}, nil
+	s := &store{
+		db:       dbHandle,
+		ledgerID: ledgerID,
+		quota:    provider.quota,
+		stats:    provider.stats,
+		usage:    newUsageTracker(),
+	}
+	if err := s.loadUsage(); err != nil {
+		return nil, err
+	}
+	return s, nil
 }
 
 // Close closes the TransientStoreProvider
@@ -180,7 +202,10 @@ func (s *store) Persist(txid string, blockHeight uint64,
 	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
 	dbBatch.Put(compositeKeyPurgeIndexByTxid, emptyValue)
 
-	return s.db.WriteBatch(dbBatch, true)
+	return s.persistWithinQuota(dbBatch, &usageEntry{
+		id:      entryID{txid: txid, uuid: uuid, blockHeight: blockHeight},
+		nsSizes: nsSizesOfPvtRWSet(privateSimulationResults, nil),
+	})
 }
 
 // PersistWithConfig stores the private write set of a transaction along with the collection config
@@ -235,7 +260,11 @@ func (s *store) PersistWithConfig(txid string, blockHeight uint64,
 	compositeKeyPurgeIndexByTxid := createCompositeKeyForPurgeIndexByTxid(txid, uuid, blockHeight)
 	dbBatch.Put(compositeKeyPurgeIndexByTxid, emptyValue)
 
-	return s.db.WriteBatch(dbBatch, true)
+	return s.persistWithinQuota(dbBatch, &usageEntry{
+		id: entryID{txid: txid, uuid: uuid, blockHeight: blockHeight},
+		nsSizes: nsSizesOfPvtRWSet(privateSimulationResultsWithConfig.GetPvtRwset(),
+			privateSimulationResultsWithConfig.GetCollectionConfigs()),
+	})
 }
 
 // GetTxPvtRWSetByTxid returns an iterator due to the fact that the txid may have multiple private
@@ -249,7 +278,7 @@ func (s *store) GetTxPvtRWSetByTxid(txid string, filter ledger.PvtNsCollFilter)
 	endKey := createTxidRangeEndKey(txid)
 
 	iter := s.db.GetIterator(startKey, endKey)
-	return &RwsetScanner{txid, iter, filter}, nil
+	return &RwsetScanner{txid: txid, dbItr: iter, filter: filter, store: s}, nil
 }
 
 // PurgeByTxids removes private write sets of a given set of transactions from the
@@ -259,7 +288,11 @@ func (s *store) PurgeByTxids(txids []string) error {
 
 	logger.Debug("Purging private data from transient store for committed txids")
 
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	dbBatch := leveldbhelper.NewUpdateBatch()
+	var purged []entryID
 
 	for _, txid := range txids {
 		// Construct startKey and endKey to do an range query
@@ -287,12 +320,18 @@ func (s *store) PurgeByTxids(txids []string) error {
 
 			// Remove purge index -- purgeIndexByTxid
 			dbBatch.Delete(compositeKeyPurgeIndexByTxid)
+
+			purged = append(purged, entryID{txid: txid, uuid: uuid, blockHeight: blockHeight})
 		}
 		iter.Release()
 	}
 	// If peer fails before/while writing the batch to golevelDB, these entries will be
 	// removed as per BTL policy later by PurgeByHeight()
-	return s.db.WriteBatch(dbBatch, true)
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.forget(purged)
+	return nil
 }
 
 // PurgeByHeight removes private write sets at block height lesser than
@@ -305,12 +344,16 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 
 	logger.Debugf("Purging orphaned private data from transient store received prior to block [%d]", maxBlockNumToRetain)
 
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
 	// Do a range query with 0 as startKey and maxBlockNumToRetain-1 as endKey
 	startKey := createPurgeIndexByHeightRangeStartKey(0)
 	endKey := createPurgeIndexByHeightRangeEndKey(maxBlockNumToRetain - 1)
 	iter := s.db.GetIterator(startKey, endKey)
 
 	dbBatch := leveldbhelper.NewUpdateBatch()
+	var purged []entryID
 
 	// Get all txid and uuid from above result and remove it from transient store (both
 	// write set and the corresponding index.
@@ -331,10 +374,16 @@ func (s *store) PurgeByHeight(maxBlockNumToRetain uint64) error {
 
 		// Remove purge index -- purgeIndexByHeight
 		dbBatch.Delete(compositeKeyPurgeIndexByHeight)
+
+		purged = append(purged, entryID{txid: txid, uuid: uuid, blockHeight: blockHeight})
 	}
 	iter.Release()
 
-	return s.db.WriteBatch(dbBatch, true)
+	if err := s.db.WriteBatch(dbBatch, true); err != nil {
+		return err
+	}
+	s.forget(purged)
+	return nil
 }
 
 // GetMinTransientBlkHt returns the lowest block height remaining in transient store
@@ -371,7 +420,8 @@ func (scanner *RwsetScanner) Next() (*EndorserPvtSimulationResults, error) {
 	}
 	dbKey := scanner.dbItr.Key()
 	dbVal := scanner.dbItr.Value()
-	_, blockHeight := splitCompositeKeyOfPvtRWSet(dbKey)
+	uuid, blockHeight := splitCompositeKeyOfPvtRWSet(dbKey)
+	scanner.touch(uuid, blockHeight)
 
 	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
 	if err := proto.Unmarshal(dbVal, txPvtRWSet); err != nil {
@@ -394,7 +444,8 @@ func (scanner *RwsetScanner) NextWithConfig() (*EndorserPvtSimulationResultsWith
 	}
 	dbKey := scanner.dbItr.Key()
 	dbVal := scanner.dbItr.Value()
-	_, blockHeight := splitCompositeKeyOfPvtRWSet(dbKey)
+	uuid, blockHeight := splitCompositeKeyOfPvtRWSet(dbKey)
+	scanner.touch(uuid, blockHeight)
 
 	txPvtRWSet := &rwset.TxPvtReadWriteSet{}
 	filteredTxPvtRWSet := &rwset.TxPvtReadWriteSet{}
@@ -428,6 +479,16 @@ func (scanner *RwsetScanner) NextWithConfig() (*EndorserPvtSimulationResultsWith
 	}, nil
 }
 
+// touch marks the private write set read as the most recently used one
+func (scanner *RwsetScanner) touch(uuid string, blockHeight uint64) {
+	if scanner.store == nil {
+		return
+	}
+	scanner.store.mutex.Lock()
+	defer scanner.store.mutex.Unlock()
+	scanner.store.usage.touch(entryID{txid: scanner.txid, uuid: uuid, blockHeight: blockHeight})
+}
+
 // Close releases resource held by the iterator
 func (scanner *RwsetScanner) Close() {
 	scanner.dbItr.Release()
@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchdog
+
+import (
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+type stats struct {
+	memoryBytes       metrics.Gauge
+	pressureLevel     metrics.Gauge
+	rejectedProposals metrics.Counter
+	delayedMessages   metrics.Counter
+}
+
+func newStats(metricsProvider metrics.Provider) *stats {
+	return &stats{
+		memoryBytes:       metricsProvider.NewGauge(memoryBytesOpts),
+		pressureLevel:     metricsProvider.NewGauge(pressureLevelOpts),
+		rejectedProposals: metricsProvider.NewCounter(rejectedProposalsOpts),
+		delayedMessages:   metricsProvider.NewCounter(delayedMessagesOpts),
+	}
+}
+
+func (s *stats) updateUsage(usage uint64, level Level) {
+	s.memoryBytes.Set(float64(usage))
+	s.pressureLevel.Set(float64(level))
+}
+
+var (
+	memoryBytesOpts = metrics.GaugeOpts{
+		Namespace:    "watchdog",
+		Name:         "memory_bytes",
+		Help:         "The memory in use by the peer, the larger of its heap and its resident set size.",
+		StatsdFormat: "%{#fqname}",
+	}
+
+	pressureLevelOpts = metrics.GaugeOpts{
+		Namespace:    "watchdog",
+		Name:         "pressure_level",
+		Help:         "The memory pressure: 0 below the soft limit, 1 past the soft limit and 2 past the hard limit.",
+		StatsdFormat: "%{#fqname}",
+	}
+
+	rejectedProposalsOpts = metrics.CounterOpts{
+		Namespace:    "watchdog",
+		Name:         "rejected_proposals",
+		Help:         "The number of proposals rejected past the hard memory limit.",
+		StatsdFormat: "%{#fqname}",
+	}
+
+	delayedMessagesOpts = metrics.CounterOpts{
+		Namespace:    "watchdog",
+		Name:         "delayed_messages",
+		Help:         "The number of deliver messages delayed past the soft memory limit.",
+		StatsdFormat: "%{#fqname}",
+	}
+)
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchdog
+
+import (
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// memoryUsage returns the memory in use by the process in bytes, the larger of
+// its heap and of its resident set size, which also accounts for the memory
+// of the C libraries and the memory the runtime has not returned yet
+func memoryUsage() uint64 {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	usage := memStats.HeapAlloc
+	if rss := residentSetSize(); rss > usage {
+		usage = rss
+	}
+	return usage
+}
+
+// residentSetSize returns the resident set size of the process in bytes, or
+// zero where /proc is not available
+func residentSetSize() uint64 {
+	statm, err := ioutil.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * uint64(os.Getpagesize())
+}
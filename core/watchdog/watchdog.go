@@ -0,0 +1,225 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchdog
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = flogging.MustGetLogger("watchdog")
+
+const (
+	proposalMethod = "/protos.Endorser/ProcessProposal"
+	deliverService = "/protos.Deliver/"
+)
+
+// Level is the memory pressure of the peer
+type Level int32
+
+const (
+	// LevelNormal is the level below the soft limit
+	LevelNormal Level = iota
+	// LevelPressure is the level past the soft limit, at which the deliver
+	// streams are slowed down
+	LevelPressure
+	// LevelCritical is the level past the hard limit, at which the proposals
+	// are also rejected
+	LevelCritical
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelNormal:
+		return "normal"
+	case LevelPressure:
+		return "pressure"
+	case LevelCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Config configures the watchdog
+type Config struct {
+	// CheckInterval is the interval between two measures of the memory
+	CheckInterval time.Duration
+	// SoftLimit is the memory in bytes past which the deliver streams are
+	// slowed down, zero meaning no limit
+	SoftLimit uint64
+	// HardLimit is the memory in bytes past which the proposals are rejected,
+	// zero meaning no limit
+	HardLimit uint64
+	// DeliverDelay is the delay before each message sent on a deliver stream
+	// under memory pressure
+	DeliverDelay time.Duration
+}
+
+// Watchdog measures the memory in use by the peer and, past the limits, sheds
+// load rather than letting the peer get killed for running out of memory. It
+// slows down the deliver streams past the soft limit, and rejects the
+// proposals with a retryable status past the hard limit.
+type Watchdog struct {
+	configLock sync.RWMutex
+	config     Config
+	usage      func() uint64
+	stats      *stats
+	level      int32
+
+	stopOnce sync.Once
+	stop     chan struct{}
+}
+
+// New creates a watchdog, which sheds no load until it is started
+func New(config Config, metricsProvider metrics.Provider) (*Watchdog, error) {
+	if err := validateLimits(config.SoftLimit, config.HardLimit); err != nil {
+		return nil, err
+	}
+	if config.CheckInterval <= 0 {
+		return nil, errors.Errorf("invalid check interval %s", config.CheckInterval)
+	}
+	return &Watchdog{
+		config: config,
+		usage:  memoryUsage,
+		stats:  newStats(metricsProvider),
+		stop:   make(chan struct{}),
+	}, nil
+}
+
+func validateLimits(softLimit, hardLimit uint64) error {
+	if hardLimit != 0 && softLimit > hardLimit {
+		return errors.Errorf("the soft limit %d exceeds the hard limit %d", softLimit, hardLimit)
+	}
+	return nil
+}
+
+// SetLimits changes the limits and the deliver delay of the watchdog while it
+// runs, the new limits applying from the next measure of the memory
+func (w *Watchdog) SetLimits(softLimit, hardLimit uint64, deliverDelay time.Duration) error {
+	if err := validateLimits(softLimit, hardLimit); err != nil {
+		return err
+	}
+	w.configLock.Lock()
+	defer w.configLock.Unlock()
+	w.config.SoftLimit = softLimit
+	w.config.HardLimit = hardLimit
+	w.config.DeliverDelay = deliverDelay
+	logger.Infof("Changed the limits of the memory watchdog to a soft limit of %d bytes and a hard limit of %d bytes", softLimit, hardLimit)
+	return nil
+}
+
+func (w *Watchdog) currentConfig() Config {
+	w.configLock.RLock()
+	defer w.configLock.RUnlock()
+	return w.config
+}
+
+// Start starts measuring the memory periodically
+func (w *Watchdog) Start() {
+	logger.Infof("Starting the memory watchdog with a soft limit of %d bytes and a hard limit of %d bytes", w.config.SoftLimit, w.config.HardLimit)
+	go func() {
+		ticker := time.NewTicker(w.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			w.check()
+			select {
+			case <-ticker.C:
+			case <-w.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops measuring the memory
+func (w *Watchdog) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+// Level returns the memory pressure as of the last measure
+func (w *Watchdog) Level() Level {
+	return Level(atomic.LoadInt32(&w.level))
+}
+
+func (w *Watchdog) check() {
+	usage := w.usage()
+	level := w.levelOf(usage)
+	w.stats.updateUsage(usage, level)
+
+	previous := Level(atomic.SwapInt32(&w.level, int32(level)))
+	if level == previous {
+		return
+	}
+	if level < previous {
+		logger.Infof("Memory pressure decreased from %s to %s, %d bytes in use", previous, level, usage)
+		return
+	}
+	logger.Warningf("Memory pressure increased from %s to %s, %d bytes in use, shedding load", previous, level, usage)
+	// Give the freed memory back to the operating system before the next measure
+	debug.FreeOSMemory()
+}
+
+func (w *Watchdog) levelOf(usage uint64) Level {
+	config := w.currentConfig()
+	switch {
+	case config.HardLimit != 0 && usage >= config.HardLimit:
+		return LevelCritical
+	case config.SoftLimit != 0 && usage >= config.SoftLimit:
+		return LevelPressure
+	default:
+		return LevelNormal
+	}
+}
+
+// UnaryServerInterceptor rejects the proposals with an Unavailable status past
+// the hard limit, so that the clients retry them later or on another peer
+func (w *Watchdog) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if info.FullMethod == proposalMethod && w.Level() >= LevelCritical {
+		w.stats.rejectedProposals.Add(1)
+		return nil, status.Error(codes.Unavailable, "the peer is under memory pressure, retry later")
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor slows down the deliver streams past the soft limit
+func (w *Watchdog) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !strings.HasPrefix(info.FullMethod, deliverService) {
+		return handler(srv, ss)
+	}
+	return handler(srv, &throttledStream{ServerStream: ss, watchdog: w})
+}
+
+type throttledStream struct {
+	grpc.ServerStream
+	watchdog *Watchdog
+}
+
+func (t *throttledStream) SendMsg(m interface{}) error {
+	if deliverDelay := t.watchdog.currentConfig().DeliverDelay; t.watchdog.Level() >= LevelPressure && deliverDelay > 0 {
+		t.watchdog.stats.delayedMessages.Add(1)
+		timer := time.NewTimer(deliverDelay)
+		select {
+		case <-timer.C:
+		case <-t.Context().Done():
+			timer.Stop()
+			return t.Context().Err()
+		}
+	}
+	return t.ServerStream.SendMsg(m)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package watchdog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent int
+}
+
+func (f *fakeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeStream) SendMsg(m interface{}) error {
+	f.sent++
+	return nil
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{CheckInterval: time.Second, SoftLimit: 200, HardLimit: 100}, &disabled.Provider{})
+	assert.EqualError(t, err, "the soft limit 200 exceeds the hard limit 100")
+
+	_, err = New(Config{SoftLimit: 100}, &disabled.Provider{})
+	assert.EqualError(t, err, "invalid check interval 0s")
+
+	_, err = New(Config{CheckInterval: time.Second, SoftLimit: 200}, &disabled.Provider{})
+	assert.NoError(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	fakeProvider := &metricsfakes.Provider{}
+	fakeMemory := &metricsfakes.Gauge{}
+	fakeMemory.WithReturns(fakeMemory)
+	fakeLevel := &metricsfakes.Gauge{}
+	fakeLevel.WithReturns(fakeLevel)
+	fakeProvider.NewGaugeStub = func(opts metrics.GaugeOpts) metrics.Gauge {
+		if opts.Name == "memory_bytes" {
+			return fakeMemory
+		}
+		return fakeLevel
+	}
+	fakeProvider.NewCounterReturns(&metricsfakes.Counter{})
+
+	w, err := New(Config{CheckInterval: time.Second, SoftLimit: 100, HardLimit: 200}, fakeProvider)
+	require.NoError(t, err)
+	var usage uint64
+	w.usage = func() uint64 { return usage }
+
+	tests := []struct {
+		usage uint64
+		level Level
+	}{
+		{50, LevelNormal},
+		{100, LevelPressure},
+		{250, LevelCritical},
+		{150, LevelPressure},
+		{0, LevelNormal},
+	}
+	for i, test := range tests {
+		usage = test.usage
+		w.check()
+		assert.Equal(t, test.level, w.Level())
+		assert.Equal(t, float64(test.usage), fakeMemory.SetArgsForCall(i))
+		assert.Equal(t, float64(test.level), fakeLevel.SetArgsForCall(i))
+	}
+}
+
+func TestSetLimits(t *testing.T) {
+	w, err := New(Config{CheckInterval: time.Second, SoftLimit: 100, HardLimit: 200}, &disabled.Provider{})
+	require.NoError(t, err)
+	w.usage = func() uint64 { return 150 }
+	w.check()
+	assert.Equal(t, LevelPressure, w.Level())
+
+	err = w.SetLimits(300, 200, time.Second)
+	assert.EqualError(t, err, "the soft limit 300 exceeds the hard limit 200")
+
+	require.NoError(t, w.SetLimits(50, 100, time.Second))
+	w.check()
+	assert.Equal(t, LevelCritical, w.Level())
+	assert.Equal(t, time.Second, w.currentConfig().DeliverDelay)
+
+	require.NoError(t, w.SetLimits(0, 0, 0))
+	w.check()
+	assert.Equal(t, LevelNormal, w.Level())
+}
+
+func TestStartStop(t *testing.T) {
+	w, err := New(Config{CheckInterval: time.Millisecond, SoftLimit: 100}, &disabled.Provider{})
+	require.NoError(t, err)
+	w.usage = func() uint64 { return 100 }
+	w.Start()
+	defer w.Stop()
+	for w.Level() != LevelPressure {
+		time.Sleep(time.Millisecond)
+	}
+	w.Stop()
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	fakeProvider := &metricsfakes.Provider{}
+	fakeProvider.NewGaugeReturns(&metricsfakes.Gauge{})
+	fakeRejected := &metricsfakes.Counter{}
+	fakeProvider.NewCounterReturns(fakeRejected)
+	w, err := New(Config{CheckInterval: time.Second, SoftLimit: 100, HardLimit: 200}, fakeProvider)
+	require.NoError(t, err)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "response", nil }
+	proposal := &grpc.UnaryServerInfo{FullMethod: "/protos.Endorser/ProcessProposal"}
+	other := &grpc.UnaryServerInfo{FullMethod: "/discovery.Discovery/Discover"}
+
+	for _, level := range []Level{LevelNormal, LevelPressure} {
+		w.level = int32(level)
+		resp, err := w.UnaryServerInterceptor(context.Background(), "request", proposal, handler)
+		assert.NoError(t, err)
+		assert.Equal(t, "response", resp)
+	}
+
+	w.level = int32(LevelCritical)
+	_, err = w.UnaryServerInterceptor(context.Background(), "request", proposal, handler)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, fakeRejected.AddCallCount())
+
+	resp, err := w.UnaryServerInterceptor(context.Background(), "request", other, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	w, err := New(Config{CheckInterval: time.Second, SoftLimit: 100, DeliverDelay: 50 * time.Millisecond}, &disabled.Provider{})
+	require.NoError(t, err)
+
+	send := func(method string) (time.Duration, error) {
+		stream := &fakeStream{ctx: context.Background()}
+		start := time.Now()
+		err := w.StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, ss grpc.ServerStream) error {
+			return ss.SendMsg("block")
+		})
+		assert.Equal(t, 1, stream.sent)
+		return time.Since(start), err
+	}
+
+	elapsed, err := send("/protos.Deliver/Deliver")
+	assert.NoError(t, err)
+	assert.True(t, elapsed < 50*time.Millisecond)
+
+	w.level = int32(LevelPressure)
+	elapsed, err = send("/protos.Deliver/DeliverFiltered")
+	assert.NoError(t, err)
+	assert.True(t, elapsed >= 50*time.Millisecond)
+
+	elapsed, err = send("/gossip.Gossip/GossipStream")
+	assert.NoError(t, err)
+	assert.True(t, elapsed < 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream := &fakeStream{ctx: ctx}
+	err = w.StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: "/protos.Deliver/Deliver"}, func(srv interface{}, ss grpc.ServerStream) error {
+		return ss.SendMsg("block")
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, stream.sent)
+}
+
+func TestMemoryUsage(t *testing.T) {
+	assert.NotZero(t, memoryUsage())
+}
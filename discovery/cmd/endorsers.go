@@ -182,6 +182,7 @@ func parseEndorsementDescriptors(descriptors []*EndorsementDescriptor) []endorse
 			Chaincode:         desc.Chaincode,
 			Layouts:           desc.Layouts,
 			EndorsersByGroups: endorsersByGroups,
+			Annotations:       desc.Annotations,
 		})
 	}
 	return res
@@ -198,6 +199,7 @@ type endorsermentDescriptor struct {
 	Chaincode         string
 	EndorsersByGroups map[string][]endorser
 	Layouts           []*Layout
+	Annotations       map[string]string `json:",omitempty"`
 }
 
 func endorserFromRaw(p *Peer) endorser {
@@ -77,7 +77,7 @@ type peerPrincipalEvaluator func(member NetworkMember, principal *msp.MSPPrincip
 
 // PeersForEndorsement returns an EndorsementDescriptor for a given set of peers, channel, and chaincode
 func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error) {
-	chanMembership, err := ea.PeersAuthorizedByCriteria(chainID, interest)
+	chanMembership, metadata, err := ea.peersAuthorizedByCriteria(chainID, interest)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
@@ -95,6 +95,7 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, inter
 
 	return ea.computeEndorsementResponse(&context{
 		chaincode:           interest.Chaincodes[0].Name,
+		annotations:         metadata[0].Annotations,
 		channel:             string(chainID),
 		principalsSets:      principalsSets,
 		channelMembersById:  channelMembersById,
@@ -104,9 +105,16 @@ func (ea *endorsementAnalyzer) PeersForEndorsement(chainID common.ChainID, inter
 }
 
 func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(chainID common.ChainID, interest *discovery.ChaincodeInterest) (Members, error) {
+	peers, _, err := ea.peersAuthorizedByCriteria(chainID, interest)
+	return peers, err
+}
+
+// peersAuthorizedByCriteria returns the peers authorized by the given criteria, along with
+// the metadata of the chaincodes of the interest
+func (ea *endorsementAnalyzer) peersAuthorizedByCriteria(chainID common.ChainID, interest *discovery.ChaincodeInterest) (Members, []*chaincode.Metadata, error) {
 	peersOfChannel := ea.PeersOfChannel(chainID)
 	if interest == nil || len(interest.Chaincodes) == 0 {
-		return peersOfChannel, nil
+		return peersOfChannel, nil, nil
 	}
 	identities := ea.IdentityInfo()
 	identitiesByID := identities.ByID()
@@ -118,17 +126,18 @@ func (ea *endorsementAnalyzer) PeersAuthorizedByCriteria(chainID common.ChainID,
 		fetch:            ea,
 	})
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, nil, errors.WithStack(err)
 	}
 	metadata := metadataAndCollectionFilters.md
 	// Filter out peers that don't have the chaincode installed on them
 	chanMembership := peersOfChannel.Filter(peersWithChaincode(metadata...))
 	// Filter out peers that aren't authorized by the collection configs of the chaincode invocation chain
-	return chanMembership.Filter(metadataAndCollectionFilters.isMemberAuthorized), nil
+	return chanMembership.Filter(metadataAndCollectionFilters.isMemberAuthorized), metadata, nil
 }
 
 type context struct {
 	chaincode           string
+	annotations         map[string]string
 	channel             string
 	aliveMembership     Members
 	principalsSets      []policies.PrincipalSet
@@ -164,6 +173,7 @@ func (ea *endorsementAnalyzer) computeEndorsementResponse(ctx *context) (*discov
 		Chaincode:         ctx.chaincode,
 		Layouts:           layouts,
 		EndorsersByGroups: endorsersByGroup(criteria),
+		Annotations:       ctx.annotations,
 	}, nil
 }
 
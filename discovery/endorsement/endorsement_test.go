@@ -124,7 +124,7 @@ func TestPeersForEndorsement(t *testing.T) {
 		policy := pb.newSet().addPrincipal(peerRole("p0")).addPrincipal(peerRole("p6")).
 			newSet().addPrincipal(peerRole("p10")).addPrincipal(peerRole("p12")).buildPolicy()
 		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Once()
-		mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"}).Once()
+		mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0", Annotations: map[string]string{"owner": "payments"}}).Once()
 		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
 		pf.On("PolicyByChaincode", cc).Return(policy).Once()
 		desc, err := analyzer.PeersForEndorsement(channel, &discoveryprotos.ChaincodeInterest{Chaincodes: []*discoveryprotos.ChaincodeCall{{Name: cc}}})
@@ -136,6 +136,7 @@ func TestPeersForEndorsement(t *testing.T) {
 			peerIdentityString("p0"): {},
 			peerIdentityString("p6"): {},
 		}, extractPeers(desc))
+		assert.Equal(t, map[string]string{"owner": "payments"}, desc.Annotations)
 	})
 
 	t.Run("MultipleCombinations", func(t *testing.T) {
@@ -103,15 +103,21 @@ func (s *service) Discover(ctx context.Context, request *discovery.SignedRequest
 }
 
 func (s *service) processQuery(query *discovery.Query, request *discovery.SignedRequest, identity []byte, addr string) *discovery.QueryResult {
+	return s.Query(query, common.SignedData{
+		Data:      request.Payload,
+		Signature: request.Signature,
+		Identity:  identity,
+	}, addr)
+}
+
+// Query processes a query on behalf of the signer of the given data, sent from the
+// given address by a client which reaches the service through another API than gRPC
+func (s *service) Query(query *discovery.Query, data common.SignedData, addr string) *discovery.QueryResult {
 	if query.Channel != "" && !s.ChannelExists(query.Channel) {
 		logger.Warning("got query for channel", query.Channel, "from", addr, "but it doesn't exist")
 		return accessDenied
 	}
-	if err := s.auth.EligibleForService(query.Channel, common.SignedData{
-		Data:      request.Payload,
-		Signature: request.Signature,
-		Identity:  identity,
-	}); err != nil {
+	if err := s.auth.EligibleForService(query.Channel, data); err != nil {
 		logger.Warning("got query for channel", query.Channel, "from", addr, "but it isn't eligible:", err)
 		return accessDenied
 	}
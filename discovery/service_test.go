@@ -320,6 +320,19 @@ func TestService(t *testing.T) {
 	resp, err = service.Discover(ctx, toSignedRequest(req))
 	assert.NoError(t, err)
 	assert.Contains(t, resp.Results[0].GetError().Content, "unknown or missing request type")
+
+	// Scenario XIV: The client queries the service through another API than gRPC
+	res := service.Query(&discovery.Query{
+		Channel: "channelWithAccessGranted",
+		Query: &discovery.Query_CcQuery{
+			CcQuery: &discovery.ChaincodeQuery{
+				Interests: []*discovery.ChaincodeInterest{{Chaincodes: []*discovery.ChaincodeCall{{Name: "cc1"}}}},
+			},
+		},
+	}, common.SignedData{Identity: []byte{1, 2, 3}}, "")
+	assert.Equal(t, ed1, res.GetCcQueryRes().Content[0])
+	res = service.Query(&discovery.Query{Channel: "channelWithAccessDenied"}, common.SignedData{Identity: []byte{1, 2, 3}}, "")
+	assert.Equal(t, "access denied", res.GetError().Content)
 }
 
 func TestValidateStructure(t *testing.T) {
@@ -83,6 +83,13 @@ type Gossip struct {
 		height  uint64
 		chainID common.ChainID
 	}
+	UpdateCommitHashStub        func(height uint64, commitHash []byte, chainID common.ChainID)
+	updateCommitHashMutex       sync.RWMutex
+	updateCommitHashArgsForCall []struct {
+		height     uint64
+		commitHash []byte
+		chainID    common.ChainID
+	}
 	UpdateChaincodesStub        func(chaincode []*proto.Chaincode, chainID common.ChainID)
 	updateChaincodesMutex       sync.RWMutex
 	updateChaincodesArgsForCall []struct {
@@ -458,6 +465,37 @@ func (fake *Gossip) UpdateLedgerHeightArgsForCall(i int) (uint64, common.ChainID
 	return fake.updateLedgerHeightArgsForCall[i].height, fake.updateLedgerHeightArgsForCall[i].chainID
 }
 
+func (fake *Gossip) UpdateCommitHash(height uint64, commitHash []byte, chainID common.ChainID) {
+	var commitHashCopy []byte
+	if commitHash != nil {
+		commitHashCopy = make([]byte, len(commitHash))
+		copy(commitHashCopy, commitHash)
+	}
+	fake.updateCommitHashMutex.Lock()
+	fake.updateCommitHashArgsForCall = append(fake.updateCommitHashArgsForCall, struct {
+		height     uint64
+		commitHash []byte
+		chainID    common.ChainID
+	}{height, commitHashCopy, chainID})
+	fake.recordInvocation("UpdateCommitHash", []interface{}{height, commitHashCopy, chainID})
+	fake.updateCommitHashMutex.Unlock()
+	if fake.UpdateCommitHashStub != nil {
+		fake.UpdateCommitHashStub(height, commitHash, chainID)
+	}
+}
+
+func (fake *Gossip) UpdateCommitHashCallCount() int {
+	fake.updateCommitHashMutex.RLock()
+	defer fake.updateCommitHashMutex.RUnlock()
+	return len(fake.updateCommitHashArgsForCall)
+}
+
+func (fake *Gossip) UpdateCommitHashArgsForCall(i int) (uint64, []byte, common.ChainID) {
+	fake.updateCommitHashMutex.RLock()
+	defer fake.updateCommitHashMutex.RUnlock()
+	return fake.updateCommitHashArgsForCall[i].height, fake.updateCommitHashArgsForCall[i].commitHash, fake.updateCommitHashArgsForCall[i].chainID
+}
+
 func (fake *Gossip) UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID) {
 	var chaincodeCopy []*proto.Chaincode
 	if chaincode != nil {
@@ -764,6 +802,8 @@ func (fake *Gossip) Invocations() map[string][][]interface{} {
 	defer fake.updateMetadataMutex.RUnlock()
 	fake.updateLedgerHeightMutex.RLock()
 	defer fake.updateLedgerHeightMutex.RUnlock()
+	fake.updateCommitHashMutex.RLock()
+	defer fake.updateCommitHashMutex.RUnlock()
 	fake.updateChaincodesMutex.RLock()
 	defer fake.updateChaincodesMutex.RUnlock()
 	fake.gossipMutex.RLock()
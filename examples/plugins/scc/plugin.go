@@ -8,12 +8,21 @@ package main
 
 import (
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/scc/api"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
-// New returns an implementation of the chaincode interface
-func New() shim.Chaincode {
-	return &scc{}
+// NewPlugin returns the system chaincode plugin, described by its descriptor
+func NewPlugin() api.Plugin {
+	return api.New(api.Descriptor{
+		Name:              "testscc",
+		InvokableExternal: true,
+		InvokableCC2CC:    true,
+		ACLs: map[string]string{
+			"ping": "/Channel/Application/Readers",
+			"echo": "/Channel/Application/Writers",
+		},
+	}, &scc{})
 }
 
 type scc struct{}
@@ -25,7 +34,18 @@ func (s *scc) Init(stub shim.ChaincodeStubInterface) pb.Response {
 
 // Invoke implements the chaincode shim interface
 func (s *scc) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
-	return shim.Success(nil)
+	function, args := stub.GetFunctionAndParameters()
+	switch function {
+	case "ping":
+		return shim.Success([]byte("pong"))
+	case "echo":
+		if len(args) != 1 {
+			return shim.Error("echo expects one argument")
+		}
+		return shim.Success([]byte(args[0]))
+	default:
+		return shim.Error("unknown function " + function)
+	}
 }
 
 func main() {}
@@ -408,7 +408,12 @@ func (c *commImpl) authenticateRemotePeer(stream stream, initiator bool) (*proto
 		if initiator {
 			certReference = c.tlsCerts.TLSClientCert
 		}
-		selfCertHash = certHashFromRawCert(certReference.Load().(*tls.Certificate).Certificate[0])
+		selfCert := certReference.Load().(*tls.Certificate)
+		// The server certificate presented to the remote peer depends on the server name it requested
+		if sniCert, exists := c.tlsCerts.TLSServerCertsByName[extractServerNameFromContext(ctx)]; exists && !initiator {
+			selfCert = sniCert
+		}
+		selfCertHash = certHashFromRawCert(selfCert.Certificate[0])
 	}
 
 	signer := func(msg []byte) ([]byte, error) {
@@ -15,6 +15,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"math/big"
+	"strings"
 
 	"github.com/hyperledger/fabric/common/util"
 	"google.golang.org/grpc/credentials"
@@ -87,3 +88,17 @@ func extractCertificateHashFromContext(ctx context.Context) []byte {
 	raw := certs[0].Raw
 	return certHashFromRawCert(raw)
 }
+
+// extractServerNameFromContext extracts the server name requested by the remote
+// peer of the stream, if any
+func extractServerNameFromContext(ctx context.Context) string {
+	pr, extracted := peer.FromContext(ctx)
+	if !extracted || pr.AuthInfo == nil {
+		return ""
+	}
+	tlsInfo, isTLSConn := pr.AuthInfo.(credentials.TLSInfo)
+	if !isTLSConn {
+		return ""
+	}
+	return strings.ToLower(tlsInfo.State.ServerName)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 type gossipTestServer struct {
@@ -108,3 +109,12 @@ func TestCertificateExtraction(t *testing.T) {
 	assert.Equal(t, clientSideCertHash, srv.selfCertHash, "Server self hash isn't equal to client side hash")
 	assert.Equal(t, clientCertHash, srv.remoteCertHash, "Server side and client hash aren't equal")
 }
+
+func TestExtractServerNameFromContext(t *testing.T) {
+	assert.Empty(t, extractServerNameFromContext(context.Background()))
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{ServerName: "Peer0.External.Org1.com"}},
+	})
+	assert.Equal(t, "peer0.external.org1.com", extractServerNameFromContext(ctx))
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"crypto/tls"
 	"sync/atomic"
 )
 
@@ -14,4 +15,8 @@ import (
 type TLSCertificates struct {
 	TLSServerCert atomic.Value // *tls.Certificate server certificate of the peer
 	TLSClientCert atomic.Value // *tls.Certificate client certificate of the peer
+	// TLSServerCertsByName holds the server certificates presented, instead of
+	// TLSServerCert, to the peers that request a given server name (SNI),
+	// indexed by the lower-cased server name
+	TLSServerCertsByName map[string]*tls.Certificate
 }
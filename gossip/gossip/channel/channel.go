@@ -70,6 +70,10 @@ type GossipChannel interface {
 	// publishes to other peers in the channel
 	UpdateLedgerHeight(height uint64)
 
+	// UpdateCommitHash updates the ledger height and the commit hash
+	// of the last block the peer publishes to other peers in the channel
+	UpdateCommitHash(height uint64, commitHash []byte)
+
 	// UpdateChaincodes updates the chaincodes the peer publishes
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode)
@@ -336,11 +340,13 @@ func (gc *gossipChannel) LeaveChannel() {
 
 	var chaincodes []*proto.Chaincode
 	var height uint64
+	var commitHash []byte
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 		height = prevMsg.GetStateInfo().Properties.LedgerHeight
+		commitHash = prevMsg.GetStateInfo().Properties.CommitHash
 	}
-	gc.updateProperties(height, chaincodes, true)
+	gc.updateProperties(height, commitHash, chaincodes, true)
 }
 
 func (gc *gossipChannel) hasLeftChannel() bool {
@@ -867,7 +873,22 @@ func (gc *gossipChannel) UpdateLedgerHeight(height uint64) {
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
 		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
 	}
-	gc.updateProperties(height, chaincodes, leftChannel)
+	gc.updateProperties(height, nil, chaincodes, leftChannel)
+}
+
+// UpdateCommitHash updates the ledger height and the commit hash
+// of the last block the peer publishes to other peers in the channel
+func (gc *gossipChannel) UpdateCommitHash(height uint64, commitHash []byte) {
+	gc.Lock()
+	defer gc.Unlock()
+
+	var chaincodes []*proto.Chaincode
+	var leftChannel bool
+	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
+		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
+		chaincodes = prevMsg.GetStateInfo().Properties.Chaincodes
+	}
+	gc.updateProperties(height, commitHash, chaincodes, leftChannel)
 }
 
 // UpdateChaincodes updates the chaincodes the peer publishes
@@ -877,12 +898,14 @@ func (gc *gossipChannel) UpdateChaincodes(chaincodes []*proto.Chaincode) {
 	defer gc.Unlock()
 
 	var ledgerHeight uint64 = 1
+	var commitHash []byte
 	var leftChannel bool
 	if prevMsg := gc.stateInfoMsg; prevMsg != nil {
 		ledgerHeight = prevMsg.GetStateInfo().Properties.LedgerHeight
+		commitHash = prevMsg.GetStateInfo().Properties.CommitHash
 		leftChannel = prevMsg.GetStateInfo().Properties.LeftChannel
 	}
-	gc.updateProperties(ledgerHeight, chaincodes, leftChannel)
+	gc.updateProperties(ledgerHeight, commitHash, chaincodes, leftChannel)
 }
 
 // UpdateStateInfo updates this channel's StateInfo message
@@ -894,7 +917,7 @@ func (gc *gossipChannel) updateStateInfo(msg *proto.SignedGossipMessage) {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
-func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*proto.Chaincode, leftChannel bool) {
+func (gc *gossipChannel) updateProperties(ledgerHeight uint64, commitHash []byte, chaincodes []*proto.Chaincode, leftChannel bool) {
 	stateInfMsg := &proto.StateInfo{
 		Channel_MAC: GenerateMAC(gc.pkiID, gc.chainID),
 		PkiId:       gc.pkiID,
@@ -905,6 +928,7 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 		Properties: &proto.Properties{
 			LeftChannel:  leftChannel,
 			LedgerHeight: ledgerHeight,
+			CommitHash:   commitHash,
 			Chaincodes:   chaincodes,
 		},
 	}
@@ -316,6 +316,16 @@ func TestSelf(t *testing.T) {
 	assert.True(t, gproto.Equal(gMsg, sMsg.GossipMessage))
 	assert.Equal(t, gMsg.GetStateInfo().Properties.LedgerHeight, uint64(1))
 	assert.Equal(t, gMsg.GetStateInfo().PkiId, []byte("1"))
+
+	gc.UpdateCommitHash(2, []byte("hash"))
+	props := gc.Self().GossipMessage.GetStateInfo().Properties
+	assert.Equal(t, uint64(2), props.LedgerHeight)
+	assert.Equal(t, []byte("hash"), props.CommitHash)
+
+	gc.UpdateLedgerHeight(3)
+	props = gc.Self().GossipMessage.GetStateInfo().Properties
+	assert.Equal(t, uint64(3), props.LedgerHeight)
+	assert.Empty(t, props.CommitHash)
 }
 
 func TestMsgStoreNotExpire(t *testing.T) {
@@ -104,6 +104,9 @@ func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID comm
 	if gc, exists := cs.channels[string(chainID)]; !exists {
 		pkiID := cs.g.comm.GetPKIid()
 		ga := &gossipAdapterImpl{gossipServiceImpl: cs.g, Discovery: cs.g.disc}
+		if ga.profile = cs.g.conf.channelProfile(string(chainID)); ga.profile != nil {
+			cs.g.logger.Infof("Channel %s uses the gossip profile %s", chainID, ga.profile.Name)
+		}
 		gc := channel.NewGossipChannel(pkiID, cs.g.selfOrg, cs.g.mcs, chainID, ga, joinMsg, metrics)
 		cs.channels[string(chainID)] = gc
 	} else {
@@ -114,10 +117,13 @@ func (cs *channelState) joinChannel(joinMsg api.JoinChannelMessage, chainID comm
 type gossipAdapterImpl struct {
 	*gossipServiceImpl
 	discovery.Discovery
+	// profile tunes the anti-entropy of the channel, nil if the channel
+	// uses the settings of the gossip config
+	profile *ChannelProfile
 }
 
 func (ga *gossipAdapterImpl) GetConf() channel.Config {
-	return channel.Config{
+	conf := channel.Config{
 		ID:                          ga.conf.ID,
 		MaxBlockCountToStore:        ga.conf.MaxBlockCountToStore,
 		PublishStateInfoInterval:    ga.conf.PublishStateInfoInterval,
@@ -132,6 +138,10 @@ func (ga *gossipAdapterImpl) GetConf() channel.Config {
 		ResponseWaitTime:            ga.conf.ResponseWaitTime,
 		MsgExpirationTimeout:        ga.conf.MsgExpirationTimeout,
 	}
+	if ga.profile != nil {
+		return ga.profile.apply(conf)
+	}
+	return conf
 }
 
 func (ga *gossipAdapterImpl) Sign(msg *proto.GossipMessage) (*proto.SignedGossipMessage, error) {
@@ -48,6 +48,10 @@ type Gossip interface {
 	// publishes to other peers in the channel
 	UpdateLedgerHeight(height uint64, chainID common.ChainID)
 
+	// UpdateCommitHash updates the ledger height and the commit hash
+	// of the last block the peer publishes to other peers in the channel
+	UpdateCommitHash(height uint64, commitHash []byte, chainID common.ChainID)
+
 	// UpdateChaincodes updates the chaincodes the peer publishes
 	// to other peers in the channel
 	UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID)
@@ -151,4 +155,5 @@ type Config struct {
 	AliveExpirationCheckInterval time.Duration // Alive expiration check interval
 	ReconnectInterval            time.Duration // Reconnect interval
 
+	ChannelProfiles []ChannelProfile // Anti-entropy profiles of the channels, the first one applying to a channel is used
 }
@@ -785,6 +785,17 @@ func (g *gossipServiceImpl) UpdateLedgerHeight(height uint64, chainID common.Cha
 	gc.UpdateLedgerHeight(height)
 }
 
+// UpdateCommitHash updates the ledger height and the commit hash
+// of the last block the peer publishes to other peers in the channel
+func (g *gossipServiceImpl) UpdateCommitHash(height uint64, commitHash []byte, chainID common.ChainID) {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		g.logger.Warning("No such channel", chainID)
+		return
+	}
+	gc.UpdateCommitHash(height, commitHash)
+}
+
 // UpdateChaincodes updates the chaincodes the peer publishes
 // to other peers in the channel
 func (g *gossipServiceImpl) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
@@ -0,0 +1,154 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"path"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	"github.com/pkg/errors"
+)
+
+// ChannelProfile tunes the anti-entropy of the channels it applies to, overriding the
+// settings of the Config, so that the peer pulls the blocks of a channel of a few peers
+// aggressively while sparing the bandwidth of a channel of hundreds of peers. The zero
+// settings of the profile keep the settings of the Config
+type ChannelProfile struct {
+	Name     string   // Name of the profile
+	Channels []string // Channels the profile applies to, as patterns such as mychannel or lab-*
+
+	PullInterval time.Duration // Determines frequency of pull phases
+	PullPeerNum  int           // Number of peers to pull from
+
+	DigestWaitTime   time.Duration // Time to wait before pull engine processes incoming digests
+	RequestWaitTime  time.Duration // Time to wait before pull engine removes incoming nonce
+	ResponseWaitTime time.Duration // Time to wait before pull engine ends pull
+
+	PublishStateInfoInterval time.Duration // Determines frequency of pushing state info messages to peers
+	RequestStateInfoInterval time.Duration // Determines frequency of pulling state info messages from peers
+
+	MaxBlockCountToStore int // Maximum count of blocks of the channel we store in memory
+}
+
+// ChannelProfilePresets returns the predefined channel profiles by name, which the
+// configured profiles of the same name start from. The aggressive profile pulls often
+// from many peers, for the channels of a few peers. The lowBandwidth profile pulls
+// rarely from few peers, for the channels of hundreds of peers. The lab profile
+// converges as fast as possible, for the test and development networks
+func ChannelProfilePresets() map[string]ChannelProfile {
+	return map[string]ChannelProfile{
+		"aggressive": {
+			Name:                     "aggressive",
+			PullInterval:             2 * time.Second,
+			PullPeerNum:              5,
+			DigestWaitTime:           500 * time.Millisecond,
+			RequestWaitTime:          750 * time.Millisecond,
+			ResponseWaitTime:         time.Second,
+			PublishStateInfoInterval: 2 * time.Second,
+			RequestStateInfoInterval: 2 * time.Second,
+			MaxBlockCountToStore:     200,
+		},
+		"lowBandwidth": {
+			Name:                     "lowBandwidth",
+			PullInterval:             10 * time.Second,
+			PullPeerNum:              2,
+			DigestWaitTime:           2 * time.Second,
+			RequestWaitTime:          3 * time.Second,
+			ResponseWaitTime:         4 * time.Second,
+			PublishStateInfoInterval: 10 * time.Second,
+			RequestStateInfoInterval: 10 * time.Second,
+			MaxBlockCountToStore:     50,
+		},
+		"lab": {
+			Name:                     "lab",
+			PullInterval:             time.Second,
+			PullPeerNum:              3,
+			DigestWaitTime:           200 * time.Millisecond,
+			RequestWaitTime:          300 * time.Millisecond,
+			ResponseWaitTime:         500 * time.Millisecond,
+			PublishStateInfoInterval: time.Second,
+			RequestStateInfoInterval: time.Second,
+			MaxBlockCountToStore:     20,
+		},
+	}
+}
+
+// Validate checks that the profile has no negative settings and that its channel
+// patterns are well formed
+func (p *ChannelProfile) Validate() error {
+	for _, pattern := range p.Channels {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Errorf("invalid channel pattern [%s] of gossip profile [%s]", pattern, p.Name)
+		}
+	}
+	for _, d := range []time.Duration{p.PullInterval, p.DigestWaitTime, p.RequestWaitTime, p.ResponseWaitTime, p.PublishStateInfoInterval, p.RequestStateInfoInterval} {
+		if d < 0 {
+			return errors.Errorf("gossip profile [%s] has a negative duration %s", p.Name, d)
+		}
+	}
+	if p.PullPeerNum < 0 || p.MaxBlockCountToStore < 0 {
+		return errors.Errorf("gossip profile [%s] has a negative count", p.Name)
+	}
+	if p.PullInterval != 0 && p.DigestWaitTime != 0 && p.ResponseWaitTime != 0 && p.PullInterval <= p.DigestWaitTime+p.ResponseWaitTime {
+		return errors.Errorf("the pull interval %s of gossip profile [%s] must be greater than the digest wait time %s plus the response wait time %s",
+			p.PullInterval, p.Name, p.DigestWaitTime, p.ResponseWaitTime)
+	}
+	return nil
+}
+
+// appliesTo returns whether the profile applies to the given channel
+func (p *ChannelProfile) appliesTo(chainID string) bool {
+	for _, pattern := range p.Channels {
+		if matched, _ := path.Match(pattern, chainID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the given channel config overridden by the settings of the profile
+func (p *ChannelProfile) apply(conf channel.Config) channel.Config {
+	if p.PullInterval != 0 {
+		conf.PullInterval = p.PullInterval
+		conf.BlockExpirationInterval = p.PullInterval * 100
+		conf.StateInfoCacheSweepInterval = p.PullInterval * 5
+	}
+	if p.PullPeerNum != 0 {
+		conf.PullPeerNum = p.PullPeerNum
+	}
+	if p.DigestWaitTime != 0 {
+		conf.DigestWaitTime = p.DigestWaitTime
+	}
+	if p.RequestWaitTime != 0 {
+		conf.RequestWaitTime = p.RequestWaitTime
+	}
+	if p.ResponseWaitTime != 0 {
+		conf.ResponseWaitTime = p.ResponseWaitTime
+	}
+	if p.PublishStateInfoInterval != 0 {
+		conf.PublishStateInfoInterval = p.PublishStateInfoInterval
+	}
+	if p.RequestStateInfoInterval != 0 {
+		conf.RequestStateInfoInterval = p.RequestStateInfoInterval
+	}
+	if p.MaxBlockCountToStore != 0 {
+		conf.MaxBlockCountToStore = p.MaxBlockCountToStore
+	}
+	return conf
+}
+
+// channelProfile returns the first profile of the config that applies to the given
+// channel, or nil if none does
+func (c *Config) channelProfile(chainID string) *ChannelProfile {
+	for i := range c.ChannelProfiles {
+		if c.ChannelProfiles[i].appliesTo(chainID) {
+			return &c.ChannelProfiles[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelProfilePresets(t *testing.T) {
+	presets := ChannelProfilePresets()
+	assert.Len(t, presets, 3)
+	for name, preset := range presets {
+		assert.Equal(t, name, preset.Name)
+		assert.NoError(t, preset.Validate())
+	}
+}
+
+func TestChannelProfileValidate(t *testing.T) {
+	profile := &ChannelProfile{Name: "p", Channels: []string{"[a"}}
+	assert.EqualError(t, profile.Validate(), "invalid channel pattern [[a] of gossip profile [p]")
+
+	profile = &ChannelProfile{Name: "p", DigestWaitTime: -time.Second}
+	assert.EqualError(t, profile.Validate(), "gossip profile [p] has a negative duration -1s")
+
+	profile = &ChannelProfile{Name: "p", PullPeerNum: -1}
+	assert.EqualError(t, profile.Validate(), "gossip profile [p] has a negative count")
+
+	profile = &ChannelProfile{Name: "p", PullInterval: 2 * time.Second, DigestWaitTime: time.Second, ResponseWaitTime: time.Second}
+	assert.EqualError(t, profile.Validate(), "the pull interval 2s of gossip profile [p] must be greater than the digest wait time 1s plus the response wait time 1s")
+
+	profile.PullInterval = 3 * time.Second
+	assert.NoError(t, profile.Validate())
+}
+
+func TestChannelProfileApply(t *testing.T) {
+	conf := channel.Config{
+		ID:                          "peer0",
+		PullInterval:                4 * time.Second,
+		PullPeerNum:                 3,
+		BlockExpirationInterval:     400 * time.Second,
+		StateInfoCacheSweepInterval: 20 * time.Second,
+		DigestWaitTime:              time.Second,
+		RequestWaitTime:             1500 * time.Millisecond,
+		ResponseWaitTime:            2 * time.Second,
+		PublishStateInfoInterval:    4 * time.Second,
+		RequestStateInfoInterval:    4 * time.Second,
+		MaxBlockCountToStore:        100,
+	}
+
+	// the zero settings of the profile keep the settings of the config
+	assert.Equal(t, conf, (&ChannelProfile{Name: "empty"}).apply(conf))
+
+	profile := &ChannelProfile{Name: "tuned", PullInterval: 10 * time.Second, MaxBlockCountToStore: 50}
+	expected := conf
+	expected.PullInterval = 10 * time.Second
+	expected.BlockExpirationInterval = 1000 * time.Second
+	expected.StateInfoCacheSweepInterval = 50 * time.Second
+	expected.MaxBlockCountToStore = 50
+	assert.Equal(t, expected, profile.apply(conf))
+
+	lab := ChannelProfilePresets()["lab"]
+	applied := lab.apply(conf)
+	assert.Equal(t, "peer0", applied.ID)
+	assert.Equal(t, lab.PullInterval, applied.PullInterval)
+	assert.Equal(t, lab.PullPeerNum, applied.PullPeerNum)
+	assert.Equal(t, lab.DigestWaitTime, applied.DigestWaitTime)
+	assert.Equal(t, lab.RequestWaitTime, applied.RequestWaitTime)
+	assert.Equal(t, lab.ResponseWaitTime, applied.ResponseWaitTime)
+	assert.Equal(t, lab.PublishStateInfoInterval, applied.PublishStateInfoInterval)
+	assert.Equal(t, lab.RequestStateInfoInterval, applied.RequestStateInfoInterval)
+	assert.Equal(t, lab.MaxBlockCountToStore, applied.MaxBlockCountToStore)
+}
+
+func TestChannelProfileOfChannel(t *testing.T) {
+	conf := &Config{
+		ID:                   "peer0",
+		PullInterval:         4 * time.Second,
+		MaxBlockCountToStore: 100,
+		ChannelProfiles: []ChannelProfile{
+			{Name: "aggressive", Channels: []string{"ops-*", "small"}, PullInterval: 2 * time.Second},
+			{Name: "lowBandwidth", Channels: []string{"ops-large", "large"}, MaxBlockCountToStore: 50},
+		},
+	}
+	assert.Nil(t, conf.channelProfile("other"))
+	assert.Equal(t, "aggressive", conf.channelProfile("small").Name)
+	assert.Equal(t, "lowBandwidth", conf.channelProfile("large").Name)
+	// the first profile applying to a channel is used
+	assert.Equal(t, "aggressive", conf.channelProfile("ops-large").Name)
+
+	ga := &gossipAdapterImpl{gossipServiceImpl: &gossipServiceImpl{conf: conf}}
+	assert.Equal(t, 4*time.Second, ga.GetConf().PullInterval)
+	assert.Equal(t, 100, ga.GetConf().MaxBlockCountToStore)
+	ga.profile = conf.channelProfile("large")
+	assert.Equal(t, 4*time.Second, ga.GetConf().PullInterval)
+	assert.Equal(t, 50, ga.GetConf().MaxBlockCountToStore)
+}
@@ -8,7 +8,9 @@ package integration
 
 import (
 	"net"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric/gossip/api"
@@ -73,9 +75,50 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 	conf.AliveExpirationCheckInterval = conf.AliveExpirationTimeout / 10
 	conf.ReconnectInterval = util.GetDurationOrDefault("peer.gossip.reconnectInterval", conf.AliveExpirationTimeout)
 
+	if conf.ChannelProfiles, err = channelProfiles(); err != nil {
+		return nil, err
+	}
+
 	return conf, nil
 }
 
+// channelProfiles returns the gossip profiles of the channels ordered by their names.
+// A profile named after a preset starts from the settings of the preset
+func channelProfiles() ([]gossip.ChannelProfile, error) {
+	var names []string
+	for name := range viper.GetStringMap("peer.gossip.profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	presets := gossip.ChannelProfilePresets()
+	var profiles []gossip.ChannelProfile
+	for _, name := range names {
+		profile := gossip.ChannelProfile{Name: name}
+		for presetName, preset := range presets {
+			if strings.EqualFold(presetName, name) {
+				profile = preset
+			}
+		}
+
+		key := "peer.gossip.profiles." + name
+		profile.Channels = viper.GetStringSlice(key + ".channels")
+		profile.PullInterval = util.GetDurationOrDefault(key+".pullInterval", profile.PullInterval)
+		profile.PullPeerNum = util.GetIntOrDefault(key+".pullPeerNum", profile.PullPeerNum)
+		profile.DigestWaitTime = util.GetDurationOrDefault(key+".digestWaitTime", profile.DigestWaitTime)
+		profile.RequestWaitTime = util.GetDurationOrDefault(key+".requestWaitTime", profile.RequestWaitTime)
+		profile.ResponseWaitTime = util.GetDurationOrDefault(key+".responseWaitTime", profile.ResponseWaitTime)
+		profile.PublishStateInfoInterval = util.GetDurationOrDefault(key+".publishStateInfoInterval", profile.PublishStateInfoInterval)
+		profile.RequestStateInfoInterval = util.GetDurationOrDefault(key+".requestStateInfoInterval", profile.RequestStateInfoInterval)
+		profile.MaxBlockCountToStore = util.GetIntOrDefault(key+".maxBlockCountToStore", profile.MaxBlockCountToStore)
+		if err := profile.Validate(); err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
 // NewGossipComponent creates a gossip component that attaches itself to the given gRPC server
 func NewGossipComponent(peerIdentity []byte, endpoint string, s *grpc.Server,
 	secAdv api.SecurityAdvisor, cryptSvc api.MessageCryptoService,
@@ -8,7 +8,10 @@ package integration
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -17,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/gossip"
 	"github.com/hyperledger/fabric/gossip/metrics"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -72,6 +76,51 @@ func TestNewGossipCryptoService(t *testing.T) {
 	go s3.Serve(ll3)
 }
 
+func TestChannelProfiles(t *testing.T) {
+	defer viper.Reset()
+	tempDir, err := ioutil.TempDir("", "gossipprofiles")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	configFile := filepath.Join(tempDir, "core.yaml")
+	writeConfig := func(profiles string) {
+		assert.NoError(t, ioutil.WriteFile(configFile, []byte("peer:\n  gossip:\n    profiles:\n"+profiles), 0644))
+		viper.SetConfigFile(configFile)
+		assert.NoError(t, viper.ReadInConfig())
+	}
+
+	writeConfig(`
+      lowBandwidth:
+        channels: [settlement, large-*]
+        pullPeerNum: 1
+      custom:
+        channels: [lab]
+        maxBlockCountToStore: 10
+`)
+	conf, err := newConfig("127.0.0.1:7051", "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, conf.ChannelProfiles, 2)
+
+	custom := conf.ChannelProfiles[0]
+	assert.Equal(t, gossip.ChannelProfile{Name: "custom", Channels: []string{"lab"}, MaxBlockCountToStore: 10}, custom)
+
+	// the profile named after a preset starts from its settings
+	lowBandwidth := gossip.ChannelProfilePresets()["lowBandwidth"]
+	lowBandwidth.Channels = []string{"settlement", "large-*"}
+	lowBandwidth.PullPeerNum = 1
+	assert.Equal(t, lowBandwidth, conf.ChannelProfiles[1])
+
+	writeConfig(`
+      custom:
+        channels: [lab]
+        pullInterval: 1s
+        digestWaitTime: 1s
+        responseWaitTime: 1s
+`)
+	_, err = newConfig("127.0.0.1:7051", "", nil)
+	assert.EqualError(t, err, "the pull interval 1s of gossip profile [custom] must be greater than the digest wait time 1s plus the response wait time 1s")
+}
+
 func setupTestEnv() {
 	viper.SetConfigName("core")
 	viper.SetEnvPrefix("CORE")
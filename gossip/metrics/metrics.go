@@ -29,16 +29,20 @@ func NewGossipMetrics(p metrics.Provider) *GossipMetrics {
 
 // StateMetrics encapsulates gossip state related metrics
 type StateMetrics struct {
-	Height            metrics.Gauge
-	CommitDuration    metrics.Histogram
-	PayloadBufferSize metrics.Gauge
+	Height               metrics.Gauge
+	CommitDuration       metrics.Histogram
+	PayloadBufferSize    metrics.Gauge
+	CommitHashMismatches metrics.Counter
+	DuplicateBlocks      metrics.Counter
 }
 
 func newStateMetrics(p metrics.Provider) *StateMetrics {
 	return &StateMetrics{
-		Height:            p.NewGauge(HeightOpts),
-		CommitDuration:    p.NewHistogram(CommitDurationOpts),
-		PayloadBufferSize: p.NewGauge(PayloadBufferSizeOpts),
+		Height:               p.NewGauge(HeightOpts),
+		CommitDuration:       p.NewHistogram(CommitDurationOpts),
+		PayloadBufferSize:    p.NewGauge(PayloadBufferSizeOpts),
+		CommitHashMismatches: p.NewCounter(CommitHashMismatchesOpts),
+		DuplicateBlocks:      p.NewCounter(DuplicateBlocksOpts),
 	}
 }
 
@@ -69,6 +73,24 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	CommitHashMismatchesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "commit_hash_mismatches",
+		Help:         "Commit hashes of peers diverging from the local ones",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	DuplicateBlocksOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "duplicate_blocks",
+		Help:         "Blocks received again after being verified, whose verification was skipped",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 // ElectionMetrics encapsulates gossip leader election related metrics
@@ -136,12 +158,14 @@ var (
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
 type MembershipMetrics struct {
-	Total metrics.Gauge
+	Total                metrics.Gauge
+	UnhealthyAnchorPeers metrics.Gauge
 }
 
 func newMembershipMetrics(p metrics.Provider) *MembershipMetrics {
 	return &MembershipMetrics{
-		Total: p.NewGauge(TotalOpts),
+		Total:                p.NewGauge(TotalOpts),
+		UnhealthyAnchorPeers: p.NewGauge(UnhealthyAnchorPeersOpts),
 	}
 }
 
@@ -154,6 +178,15 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	UnhealthyAnchorPeersOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "membership",
+		Name:         "unhealthy_anchor_peers",
+		Help:         "Anchor peers unreachable or with incompatible certificates",
+		LabelNames:   []string{"channel", "mspid"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{mspid}",
+	}
 )
 
 // PrivdataMetrics encapsulates gossip private data related metrics
@@ -167,6 +200,9 @@ type PrivdataMetrics struct {
 	ReconciliationDuration         metrics.Histogram
 	PullDuration                   metrics.Histogram
 	RetrieveDuration               metrics.Histogram
+	PushAcknowledgements           metrics.Counter
+	PushFailures                   metrics.Counter
+	PushRetries                    metrics.Counter
 }
 
 func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
@@ -180,6 +216,9 @@ func newPrivdataMetrics(p metrics.Provider) *PrivdataMetrics {
 		ReconciliationDuration:         p.NewHistogram(ReconciliationDurationOpts),
 		PullDuration:                   p.NewHistogram(PullDurationOpts),
 		RetrieveDuration:               p.NewHistogram(RetrieveDurationOpts),
+		PushAcknowledgements:           p.NewCounter(PushAcknowledgementsOpts),
+		PushFailures:                   p.NewCounter(PushFailuresOpts),
+		PushRetries:                    p.NewCounter(PushRetriesOpts),
 	}
 }
 
@@ -264,4 +303,31 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	PushAcknowledgementsOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "push_acknowledgements",
+		Help:         "Number of private data pushes at endorsement acknowledged by the required number of peers",
+		LabelNames:   []string{"channel", "chaincode", "collection"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{collection}",
+	}
+
+	PushFailuresOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "push_failures",
+		Help:         "Number of private data pushes at endorsement not acknowledged by the required number of peers after all the retries",
+		LabelNames:   []string{"channel", "chaincode", "collection"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{collection}",
+	}
+
+	PushRetriesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "privdata",
+		Name:         "push_retries",
+		Help:         "Number of retries of the private data pushes at endorsement",
+		LabelNames:   []string{"channel", "chaincode", "collection"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{collection}",
+	}
 )
@@ -34,6 +34,8 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.StateMetrics.Height)
 	assert.NotNil(t, gossipMetrics.StateMetrics.CommitDuration)
 	assert.NotNil(t, gossipMetrics.StateMetrics.PayloadBufferSize)
+	assert.NotNil(t, gossipMetrics.StateMetrics.CommitHashMismatches)
+	assert.NotNil(t, gossipMetrics.StateMetrics.DuplicateBlocks)
 
 	assert.NotNil(t, gossipMetrics.ElectionMetrics)
 	assert.NotNil(t, gossipMetrics.ElectionMetrics.Declaration)
@@ -56,4 +58,7 @@ func TestMetrics(t *testing.T) {
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.ReconciliationDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PullDuration)
 	assert.NotNil(t, gossipMetrics.PrivdataMetrics.RetrieveDuration)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PushAcknowledgements)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PushFailures)
+	assert.NotNil(t, gossipMetrics.PrivdataMetrics.PushRetries)
 }
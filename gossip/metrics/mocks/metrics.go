@@ -18,6 +18,7 @@ type TestMetricProvider struct {
 	FakeHeightGauge            *metricsfakes.Gauge
 	FakeCommitDurationHist     *metricsfakes.Histogram
 	FakePayloadBufferSizeGauge *metricsfakes.Gauge
+	FakeCommitHashMismatches   *metricsfakes.Counter
 
 	FakeDeclarationGauge *metricsfakes.Gauge
 
@@ -36,6 +37,9 @@ type TestMetricProvider struct {
 	FakeReconciliationDuration         *metricsfakes.Histogram
 	FakePullDuration                   *metricsfakes.Histogram
 	FakeRetrieveDuration               *metricsfakes.Histogram
+	FakePushAcknowledgements           *metricsfakes.Counter
+	FakePushFailures                   *metricsfakes.Counter
+	FakePushRetries                    *metricsfakes.Counter
 }
 
 func TestUtilConstructMetricProvider() *TestMetricProvider {
@@ -44,6 +48,7 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeHeightGauge := testUtilConstructGauge()
 	fakeCommitDurationHist := testUtilConstructHist()
 	fakePayloadBufferSizeGauge := testUtilConstructGauge()
+	fakeCommitHashMismatches := testUtilConstructCounter()
 
 	fakeDeclarationGauge := testUtilConstructGauge()
 
@@ -62,6 +67,9 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeReconciliationDuration := testUtilConstructHist()
 	fakePullDuration := testUtilConstructHist()
 	fakeRetrieveDuration := testUtilConstructHist()
+	fakePushAcknowledgements := testUtilConstructCounter()
+	fakePushFailures := testUtilConstructCounter()
+	fakePushRetries := testUtilConstructCounter()
 
 	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
 		switch opts.Name {
@@ -71,6 +79,14 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.CommitHashMismatchesOpts.Name:
+			return fakeCommitHashMismatches
+		case gmetrics.PushAcknowledgementsOpts.Name:
+			return fakePushAcknowledgements
+		case gmetrics.PushFailuresOpts.Name:
+			return fakePushFailures
+		case gmetrics.PushRetriesOpts.Name:
+			return fakePushRetries
 		}
 		return nil
 	}
@@ -118,6 +134,7 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeHeightGauge,
 		fakeCommitDurationHist,
 		fakePayloadBufferSizeGauge,
+		fakeCommitHashMismatches,
 		fakeDeclarationGauge,
 		fakeSentMessages,
 		fakeBufferOverflow,
@@ -132,6 +149,9 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeReconciliationDuration,
 		fakePullDuration,
 		fakeRetrieveDuration,
+		fakePushAcknowledgements,
+		fakePushFailures,
+		fakePushRetries,
 	}
 }
 
@@ -122,23 +122,46 @@ type coordinator struct {
 	transientBlockRetention uint64
 	metrics                 *metrics.PrivdataMetrics
 	pullRetryThreshold      time.Duration
+	mspID                   string
+	region                  string
 }
 
 type CoordinatorConfig struct {
 	TransientBlockRetention uint64
 	PullRetryThreshold      time.Duration
+	// Region is the region the peer is located in, which restricts the
+	// collections whose private data it persists
+	Region string
 }
 
 // NewCoordinator creates a new instance of coordinator
 func NewCoordinator(support Support, selfSignedData common.SignedData, metrics *metrics.PrivdataMetrics,
 	config CoordinatorConfig) Coordinator {
+	var mspID string
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(selfSignedData.Identity, sID); err == nil {
+		mspID = sID.Mspid
+	}
 	return &coordinator{Support: support, selfSignedData: selfSignedData,
 		transientBlockRetention: config.TransientBlockRetention, metrics: metrics,
-		pullRetryThreshold: config.PullRetryThreshold}
+		pullRetryThreshold: config.PullRetryThreshold, mspID: mspID, region: config.Region}
 }
 
 // StorePvtData used to persist private date into transient store
 func (c *coordinator) StorePvtData(txID string, privData *transientstore2.TxPvtReadWriteSetWithConfigInfo, blkHeight uint64) error {
+	pvtRWSet, refused := privdata.FilterResidency(privData.PvtRwset, privData.CollectionConfigs, c.mspID, c.region)
+	if len(refused) > 0 {
+		logger.Warningf("[%s] Not persisting the private data of collections %v of txID %s, which may not be persisted in region %q",
+			c.ChainID, refused, txID, c.region)
+		if len(pvtRWSet.NsPvtRwset) == 0 {
+			return nil
+		}
+		privData = &transientstore2.TxPvtReadWriteSetWithConfigInfo{
+			EndorsedAt:        privData.EndorsedAt,
+			PvtRwset:          pvtRWSet,
+			CollectionConfigs: privData.CollectionConfigs,
+		}
+	}
 	return c.TransientStore.PersistWithConfig(txID, blkHeight, privData)
 }
 
@@ -788,8 +811,13 @@ func (c *coordinator) isEligible(ap privdata.CollectionAccessPolicy, namespace s
 	eligible := filt(c.selfSignedData)
 	if !eligible {
 		logger.Debug("Skipping namespace", namespace, "collection", col, "because we're not eligible for the private data")
+		return false
+	}
+	if rp, ok := ap.(privdata.CollectionResidencyPolicy); ok && !rp.AllowsRegion(c.mspID, c.region) {
+		logger.Debugf("Skipping namespace %s collection %s because its private data may not be persisted in region %q", namespace, col, c.region)
+		return false
 	}
-	return eligible
+	return true
 }
 
 type seqAndDataModel struct {
@@ -1373,6 +1373,138 @@ func TestCoordinatorStorePvtData(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCoordinatorStorePvtDataResidency(t *testing.T) {
+	// Scenario: the peer of org0 is located in region us, and the private data it is
+	// given to persist belongs to collection c1, which is only allowed in region eu
+	// for org0, and to collection c2, which is unrestricted.
+	// Only the private data of c2 is persisted.
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	identity, err := pb.Marshal(&msp.SerializedIdentity{Mspid: "org0"})
+	assert.NoError(t, err)
+	peerSelfSignedData := common.SignedData{Identity: identity}
+	config := testConfig
+	config.Region = "us"
+
+	collectionConfig := func(name string, allowedRegions map[string]*common.CollectionRegions) *common.CollectionConfig {
+		return &common.CollectionConfig{
+			Payload: &common.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &common.StaticCollectionConfig{
+					Name:           name,
+					AllowedRegions: allowedRegions,
+				},
+			},
+		}
+	}
+	collectionConfigs := map[string]*common.CollectionConfigPackage{
+		"ns1": {
+			Config: []*common.CollectionConfig{
+				collectionConfig("c1", map[string]*common.CollectionRegions{"org0": {Regions: []string{"eu"}}}),
+				collectionConfig("c2", nil),
+			},
+		},
+	}
+
+	store := &mockTransientStore{t: t}
+	store.On("PersistWithConfig", "tx1", uint64(5), mock.Anything).
+		expectRWSet("ns1", "c2", []byte("rws-pre-image")).Return(nil)
+	coordinator := NewCoordinator(Support{
+		CollectionStore: createcollectionStore(peerSelfSignedData).thatAcceptsAll(),
+		Committer:       &mocks.Committer{},
+		Fetcher:         &fetcherMock{t: t},
+		TransientStore:  store,
+		Validator:       &validatorMock{},
+	}, peerSelfSignedData, metrics, config)
+
+	pvtData := (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1", "c2").create()
+	err = coordinator.StorePvtData("tx1", &transientstore2.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset:          pvtData[0].WriteSet,
+		CollectionConfigs: collectionConfigs,
+	}, uint64(5))
+	assert.NoError(t, err)
+	store.AssertNumberOfCalls(t, "PersistWithConfig", 1)
+	persisted := store.Calls[0].Arguments.Get(2).(*transientstore2.TxPvtReadWriteSetWithConfigInfo)
+	assert.Len(t, persisted.PvtRwset.NsPvtRwset, 1)
+	assert.Len(t, persisted.PvtRwset.NsPvtRwset[0].CollectionPvtRwset, 1)
+	assert.Equal(t, "c2", persisted.PvtRwset.NsPvtRwset[0].CollectionPvtRwset[0].CollectionName)
+
+	// Nothing is persisted when none of the collections may be persisted in the region
+	pvtData = (&pvtDataFactory{}).addRWSet().addNSRWSet("ns1", "c1").create()
+	err = coordinator.StorePvtData("tx2", &transientstore2.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset:          pvtData[0].WriteSet,
+		CollectionConfigs: collectionConfigs,
+	}, uint64(5))
+	assert.NoError(t, err)
+	store.AssertNumberOfCalls(t, "PersistWithConfig", 1)
+}
+
+type residentCollectionStore struct {
+	*collectionStore
+	region string
+}
+
+func (cs *residentCollectionStore) RetrieveCollectionAccessPolicy(cc common.CollectionCriteria) (privdata.CollectionAccessPolicy, error) {
+	ap, err := cs.collectionStore.RetrieveCollectionAccessPolicy(cc)
+	if err != nil {
+		return nil, err
+	}
+	return &residentAccessPolicy{CollectionAccessPolicy: ap, region: cs.region}, nil
+}
+
+type residentAccessPolicy struct {
+	privdata.CollectionAccessPolicy
+	region string
+}
+
+func (ap *residentAccessPolicy) AllowsRegion(mspID, region string) bool {
+	return region == ap.region
+}
+
+func TestProceedWithPrivateDataOutsideOfRegion(t *testing.T) {
+	// Scenario: the peer is eligible for the private data of c2 in ns3, but is located
+	// in a region the private data may not be persisted in.
+	// The private data is not fetched, and the block is committed with it missing.
+	peerSelfSignedData := common.SignedData{
+		Identity:  []byte{0, 1, 2},
+		Signature: []byte{3, 4, 5},
+		Data:      []byte{6, 7, 8},
+	}
+	cs := &residentCollectionStore{
+		collectionStore: createcollectionStore(peerSelfSignedData).thatAcceptsAll(),
+		region:          "eu",
+	}
+	config := testConfig
+	config.Region = "us"
+
+	var commitHappened bool
+	committer := &mocks.Committer{}
+	committer.On("CommitWithPvtData", mock.Anything).Run(func(args mock.Arguments) {
+		blockAndPrivateData := args.Get(0).(*ledger.BlockAndPvtData)
+		assert.Empty(t, blockAndPrivateData.PvtData)
+		expectedMissingPvtData := make(ledger.TxMissingPvtDataMap)
+		expectedMissingPvtData.Add(0, "ns3", "c2", false)
+		assert.Equal(t, expectedMissingPvtData, blockAndPrivateData.MissingPvtData)
+		commitHappened = true
+	}).Return(nil)
+
+	hash := util2.ComputeSHA256([]byte("rws-pre-image"))
+	bf := &blockFactory{
+		channelID: "test",
+	}
+	block := bf.AddTxn("tx1", "ns3", hash, "c2").create()
+
+	metrics := metrics.NewGossipMetrics(&disabled.Provider{}).PrivdataMetrics
+	coordinator := NewCoordinator(Support{
+		CollectionStore: cs,
+		Committer:       committer,
+		Fetcher:         nil,
+		TransientStore:  nil,
+		Validator:       &validatorMock{},
+	}, peerSelfSignedData, metrics, config)
+	err := coordinator.StoreBlock(block, nil)
+	assert.NoError(t, err)
+	assert.True(t, commitHappened)
+}
+
 func TestContainsWrites(t *testing.T) {
 	// Scenario I: Nil HashedRwSet in collection
 	col := &rwsetutil.CollHashedRwSet{
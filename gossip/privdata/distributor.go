@@ -67,8 +67,22 @@ type distributorImpl struct {
 	chainID string
 	gossipAdapter
 	CollectionAccessFactory
-	pushAckTimeout time.Duration
-	metrics        *metrics.PrivdataMetrics
+	config  DistributorConfig
+	metrics *metrics.PrivdataMetrics
+}
+
+// DistributorConfig is the configuration of the push of the private data to
+// the eligible peers at endorsement time
+type DistributorConfig struct {
+	// PushAckTimeout is the maximum time to wait for the acknowledgement of
+	// each peer
+	PushAckTimeout time.Duration
+	// PushAckRetries is the number of times a push which is not acknowledged
+	// by the required number of peers is retried
+	PushAckRetries int
+	// PushAckRetryInterval is the time to wait before the first retry of a
+	// push, doubled at each following retry
+	PushAckRetryInterval time.Duration
 }
 
 // CollectionAccessFactory an interface to generate collection access policy
@@ -106,12 +120,12 @@ func NewCollectionAccessFactory(factory IdentityDeserializerFactory) CollectionA
 // NewDistributor a constructor for private data distributor capable to send
 // private read write sets for underlying collection
 func NewDistributor(chainID string, gossip gossipAdapter, factory CollectionAccessFactory,
-	metrics *metrics.PrivdataMetrics, pushAckTimeout time.Duration) PvtDataDistributor {
+	metrics *metrics.PrivdataMetrics, config DistributorConfig) PvtDataDistributor {
 	return &distributorImpl{
 		chainID:                 chainID,
 		gossipAdapter:           gossip,
 		CollectionAccessFactory: factory,
-		pushAckTimeout:          pushAckTimeout,
+		config:                  config,
 		metrics:                 metrics,
 	}
 }
@@ -128,6 +142,9 @@ func (d *distributorImpl) Distribute(txID string, privData *transientstore.TxPvt
 type dissemination struct {
 	msg      *proto.SignedGossipMessage
 	criteria gossip2.SendCriteria
+	// retryCriteria selects the peers of the retries of the push, which may
+	// differ from the ones of the first push
+	retryCriteria gossip2.SendCriteria
 }
 
 func (d *distributorImpl) computeDisseminationPlan(txID string,
@@ -214,13 +231,14 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 
 	if maximumPeerCount > 0 {
 		for _, selectionPeers := range identitySets {
+			orgPeers := selectionPeers
 			required := 1
 			if requiredPeerCount == 0 {
 				required = 0
 			}
 			peer2SendPerOrg := selectionPeers[rand.Intn(len(selectionPeers))]
 			sc := gossip2.SendCriteria{
-				Timeout:  d.pushAckTimeout,
+				Timeout:  d.config.PushAckTimeout,
 				Channel:  gossipCommon.ChainID(d.chainID),
 				MaxPeers: 1,
 				MinAck:   required,
@@ -228,8 +246,19 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 					return bytes.Equal(member.PKIid, peer2SendPerOrg.PKIId)
 				},
 			}
+			// a retry may pick another peer of the org
+			retryCriteria := sc
+			retryCriteria.IsEligible = func(member discovery.NetworkMember) bool {
+				for _, peer := range orgPeers {
+					if bytes.Equal(member.PKIid, peer.PKIId) {
+						return true
+					}
+				}
+				return false
+			}
 			disseminationPlan = append(disseminationPlan, &dissemination{
-				criteria: sc,
+				criteria:      sc,
+				retryCriteria: retryCriteria,
 				msg: &proto.SignedGossipMessage{
 					Envelope:      proto2.Clone(pvtDataMsg.Envelope).(*proto.Envelope),
 					GossipMessage: proto2.Clone(pvtDataMsg.GossipMessage).(*proto.GossipMessage),
@@ -250,7 +279,7 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 	// criteria to select remaining peers to satisfy colAP.MaximumPeerCount()
 	// collection policy parameters
 	sc := gossip2.SendCriteria{
-		Timeout:  d.pushAckTimeout,
+		Timeout:  d.config.PushAckTimeout,
 		Channel:  gossipCommon.ChainID(d.chainID),
 		MaxPeers: maximumPeerCount,
 		MinAck:   requiredPeerCount,
@@ -260,8 +289,9 @@ func (d *distributorImpl) disseminationPlanForMsg(colAP privdata.CollectionAcces
 	}
 
 	disseminationPlan = append(disseminationPlan, &dissemination{
-		criteria: sc,
-		msg:      pvtDataMsg,
+		criteria:      sc,
+		retryCriteria: sc,
+		msg:           pvtDataMsg,
 	})
 
 	return disseminationPlan, nil
@@ -307,12 +337,15 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 		go func(dis *dissemination) {
 			defer wg.Done()
 			defer d.reportSendDuration(start)
-			err := d.SendByCriteria(dis.msg, dis.criteria)
+			m := dis.msg.GetPrivateData().Payload
+			err := d.send(dis)
 			if err != nil {
 				atomic.AddUint32(&failures, 1)
-				m := dis.msg.GetPrivateData().Payload
 				logger.Error("Failed disseminating private RWSet for TxID", m.TxId, ", namespace", m.Namespace, "collection", m.CollectionName, ":", err)
+				d.metrics.PushFailures.With("channel", d.chainID, "chaincode", m.Namespace, "collection", m.CollectionName).Add(1)
+				return
 			}
+			d.metrics.PushAcknowledgements.With("channel", d.chainID, "chaincode", m.Namespace, "collection", m.CollectionName).Add(1)
 		}(dis)
 	}
 	wg.Wait()
@@ -323,6 +356,27 @@ func (d *distributorImpl) disseminate(disseminationPlan []*dissemination) error
 	return nil
 }
 
+// send pushes the private data of the dissemination, and retries the push with
+// an exponential backoff until it is acknowledged by the required number of
+// peers or the retries are exhausted
+func (d *distributorImpl) send(dis *dissemination) error {
+	criteria := dis.criteria
+	interval := d.config.PushAckRetryInterval
+	for attempt := 0; ; attempt++ {
+		err := d.SendByCriteria(dis.msg, criteria)
+		if err == nil || attempt >= d.config.PushAckRetries {
+			return err
+		}
+		m := dis.msg.GetPrivateData().Payload
+		logger.Warningf("Failed disseminating private RWSet for TxID %s, namespace %s collection %s, retrying in %s: %s",
+			m.TxId, m.Namespace, m.CollectionName, interval, err)
+		d.metrics.PushRetries.With("channel", d.chainID, "chaincode", m.Namespace, "collection", m.CollectionName).Add(1)
+		time.Sleep(interval)
+		interval *= 2
+		criteria = dis.retryCriteria
+	}
+}
+
 func (d *distributorImpl) reportSendDuration(startTime time.Time) {
 	d.metrics.SendDuration.With("channel", d.chainID).Observe(time.Since(startTime).Seconds())
 }
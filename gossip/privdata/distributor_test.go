@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -179,7 +180,7 @@ func TestDistributor(t *testing.T) {
 	testMetricProvider := mocks.TestUtilConstructMetricProvider()
 	metrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics
 
-	d := NewDistributor(channelID, g, accessFactoryMock, metrics, 0)
+	d := NewDistributor(channelID, g, accessFactoryMock, metrics, DistributorConfig{})
 	pdFactory := &pvtDataFactory{}
 	pvtData := pdFactory.addRWSet().addNSRWSet("ns1", "c1", "c2").addRWSet().addNSRWSet("ns2", "c1", "c2").create()
 	err := d.Distribute("tx1", &transientstore.TxPvtReadWriteSetWithConfigInfo{
@@ -271,3 +272,93 @@ func TestDistributor(t *testing.T) {
 	)
 	assert.True(t, testMetricProvider.FakeSendDuration.ObserveArgsForCall(0) > 0)
 }
+
+func TestDistributorRetries(t *testing.T) {
+	channelID := "test"
+
+	g := &gossipMock{
+		Mock: mock.Mock{},
+		PeerSignature: api.PeerSignature{
+			Signature:    []byte{3, 4, 5},
+			Message:      []byte{6, 7, 8},
+			PeerIdentity: []byte{0, 1, 2},
+		},
+	}
+	g.On("PeersOfChannel", gcommon.ChainID(channelID)).Return([]discovery.NetworkMember{
+		{PKIid: gcommon.PKIidType{1}},
+		{PKIid: gcommon.PKIidType{2}},
+	})
+	g.On("IdentityInfo").Return(api.PeerIdentitySet{
+		{
+			PKIId:        gcommon.PKIidType{1},
+			Organization: api.OrgIdentityType("org1"),
+		},
+		{
+			PKIId:        gcommon.PKIidType{2},
+			Organization: api.OrgIdentityType("org1"),
+		},
+	})
+
+	var retryCriteria gossip2.SendCriteria
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(errors.New("not acknowledged")).Once()
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		retryCriteria = args.Get(1).(gossip2.SendCriteria)
+	}).Return(nil).Once()
+
+	colConfig := &common.CollectionConfig{
+		Payload: &common.CollectionConfig_StaticCollectionConfig{
+			StaticCollectionConfig: &common.StaticCollectionConfig{
+				Name:              "c1",
+				RequiredPeerCount: 1,
+				MaximumPeerCount:  1,
+			},
+		},
+	}
+	policyMock := &collectionAccessPolicyMock{}
+	policyMock.Setup(1, 1, func(_ common.SignedData) bool {
+		return true
+	}, []string{"org1"}, false)
+	accessFactoryMock := &collectionAccessFactoryMock{}
+	accessFactoryMock.On("AccessPolicy", colConfig, channelID).Return(policyMock, nil)
+
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	metrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics
+
+	d := NewDistributor(channelID, g, accessFactoryMock, metrics, DistributorConfig{
+		PushAckTimeout:       time.Second,
+		PushAckRetries:       1,
+		PushAckRetryInterval: time.Millisecond,
+	})
+	pdFactory := &pvtDataFactory{}
+	pvtData := pdFactory.addRWSet().addNSRWSet("ns1", "c1").create()
+	txPvtData := &transientstore.TxPvtReadWriteSetWithConfigInfo{
+		PvtRwset: pvtData[0].WriteSet,
+		CollectionConfigs: map[string]*common.CollectionConfigPackage{
+			"ns1": {
+				Config: []*common.CollectionConfig{colConfig},
+			},
+		},
+	}
+
+	// the push is acknowledged at its retry, which may pick any peer of the org
+	err := d.Distribute("tx1", txPvtData, 0)
+	assert.NoError(t, err)
+	g.AssertNumberOfCalls(t, "SendByCriteria", 2)
+	assert.True(t, retryCriteria.IsEligible(discovery.NetworkMember{PKIid: gcommon.PKIidType{1}}))
+	assert.True(t, retryCriteria.IsEligible(discovery.NetworkMember{PKIid: gcommon.PKIidType{2}}))
+	assert.False(t, retryCriteria.IsEligible(discovery.NetworkMember{PKIid: gcommon.PKIidType{3}}))
+	assert.Equal(t, 1, testMetricProvider.FakePushRetries.AddCallCount())
+	assert.Equal(t, []string{"channel", channelID, "chaincode", "ns1", "collection", "c1"}, testMetricProvider.FakePushRetries.WithArgsForCall(0))
+	assert.Equal(t, 1, testMetricProvider.FakePushAcknowledgements.AddCallCount())
+	assert.Equal(t, []string{"channel", channelID, "chaincode", "ns1", "collection", "c1"}, testMetricProvider.FakePushAcknowledgements.WithArgsForCall(0))
+	assert.Equal(t, 0, testMetricProvider.FakePushFailures.AddCallCount())
+
+	// the push fails once the retries are exhausted
+	g.On("SendByCriteria", mock.Anything, mock.Anything).Return(errors.New("not acknowledged"))
+	err = d.Distribute("tx2", txPvtData, 0)
+	assert.EqualError(t, err, "Failed disseminating 1 out of 1 private dissemination plans")
+	g.AssertNumberOfCalls(t, "SendByCriteria", 4)
+	assert.Equal(t, 2, testMetricProvider.FakePushRetries.AddCallCount())
+	assert.Equal(t, 1, testMetricProvider.FakePushFailures.AddCallCount())
+	assert.Equal(t, []string{"channel", channelID, "chaincode", "ns1", "collection", "c1"}, testMetricProvider.FakePushFailures.WithArgsForCall(0))
+}
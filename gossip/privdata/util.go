@@ -358,3 +358,33 @@ func GetTransientBlockRetention() uint64 {
 	}
 	return transientBlockRetention
 }
+
+const (
+	pushAckTimeoutConfigKey       = "peer.gossip.pvtData.pushAckTimeout"
+	pushAckRetriesConfigKey       = "peer.gossip.pvtData.pushAckRetries"
+	pushAckRetriesDefault         = 2
+	pushAckRetryIntervalConfigKey = "peer.gossip.pvtData.pushAckRetryInterval"
+	pushAckRetryIntervalDefault   = 500 * time.Millisecond
+)
+
+// GetDistributorConfig reads the configuration of the push of the private
+// data at endorsement time from core.yaml and returns DistributorConfig
+func GetDistributorConfig() DistributorConfig {
+	pushAckRetries := pushAckRetriesDefault
+	if viper.IsSet(pushAckRetriesConfigKey) {
+		pushAckRetries = viper.GetInt(pushAckRetriesConfigKey)
+		if pushAckRetries < 0 {
+			logger.Warning("Configuration key", pushAckRetriesConfigKey, "is negative, defaulting to", pushAckRetriesDefault)
+			pushAckRetries = pushAckRetriesDefault
+		}
+	}
+	pushAckRetryInterval := viper.GetDuration(pushAckRetryIntervalConfigKey)
+	if pushAckRetryInterval <= 0 {
+		pushAckRetryInterval = pushAckRetryIntervalDefault
+	}
+	return DistributorConfig{
+		PushAckTimeout:       viper.GetDuration(pushAckTimeoutConfigKey),
+		PushAckRetries:       pushAckRetries,
+		PushAckRetryInterval: pushAckRetryInterval,
+	}
+}
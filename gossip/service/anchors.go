@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// anchorPeerValidator checks that the anchor peers of a channel configuration
+// are reachable and, when TLS is enabled, that they present a certificate that
+// is issued by the TLS CAs of their organization for their host name, so that
+// mistakes in the anchor peers configuration, which silently break the gossip
+// across organizations, are reported
+type anchorPeerValidator struct {
+	timeout time.Duration
+	// certs holds the TLS certificates of the peer, and is nil when TLS is disabled
+	certs     *gossipCommon.TLSCertificates
+	unhealthy metrics.Gauge
+
+	lock sync.Mutex
+	// reported holds, per channel, the organizations for which the gauge has been set
+	reported map[string]map[string]struct{}
+}
+
+func newAnchorPeerValidator(timeout time.Duration, certs *gossipCommon.TLSCertificates, unhealthy metrics.Gauge) *anchorPeerValidator {
+	return &anchorPeerValidator{
+		timeout:   timeout,
+		certs:     certs,
+		unhealthy: unhealthy,
+		reported:  map[string]map[string]struct{}{},
+	}
+}
+
+// validate checks the anchor peers of all the organizations of the given
+// configuration, logs a warning for each unhealthy anchor peer and reports
+// the number of unhealthy anchor peers of each organization
+func (v *anchorPeerValidator) validate(config Config) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	channel := config.ChainID()
+	orgs := map[string]struct{}{}
+	for _, org := range config.Organizations() {
+		mspID := org.MSPID()
+		orgs[mspID] = struct{}{}
+		roots, intermediates := config.TLSCACerts(mspID)
+		unhealthy := 0
+		for _, ap := range org.AnchorPeers() {
+			if err := v.check(ap, roots, intermediates); err != nil {
+				logger.Warningf("Anchor peer of %s in channel %s: %s", mspID, channel, err)
+				unhealthy++
+				continue
+			}
+			logger.Debugf("Anchor peer %s:%d of %s in channel %s is healthy", ap.Host, ap.Port, mspID, channel)
+		}
+		v.unhealthy.With("channel", channel, "mspid", mspID).Set(float64(unhealthy))
+	}
+
+	// organizations removed from the channel no longer have unhealthy anchor peers
+	for mspID := range v.reported[channel] {
+		if _, exists := orgs[mspID]; !exists {
+			v.unhealthy.With("channel", channel, "mspid", mspID).Set(0)
+		}
+	}
+	v.reported[channel] = orgs
+}
+
+// check connects to the given anchor peer and, when TLS is enabled, verifies
+// its certificate against the given CA certificates
+func (v *anchorPeerValidator) check(ap *peer.AnchorPeer, roots, intermediates [][]byte) error {
+	if ap.Host == "" || ap.Port <= 0 || ap.Port > 65535 {
+		return errors.Errorf("invalid endpoint %s:%d", ap.Host, ap.Port)
+	}
+	address := net.JoinHostPort(ap.Host, strconv.Itoa(int(ap.Port)))
+
+	dialer := &net.Dialer{Timeout: v.timeout}
+	conn, err := dialer.Dial("tcp", address)
+	if err != nil {
+		return errors.Wrapf(err, "%s is unreachable", address)
+	}
+	defer conn.Close()
+	if v.certs == nil {
+		return nil
+	}
+
+	if len(roots) == 0 {
+		return errors.Errorf("%s cannot be verified, its organization has no TLS root certificates", address)
+	}
+	verifyOpts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		DNSName:       ap.Host,
+	}
+	for _, root := range roots {
+		verifyOpts.Roots.AppendCertsFromPEM(root)
+	}
+	for _, intermediate := range intermediates {
+		verifyOpts.Intermediates.AppendCertsFromPEM(intermediate)
+	}
+
+	var certErr error
+	tlsConn := tls.Client(conn, &tls.Config{
+		// the certificate is verified by VerifyPeerCertificate, against the CAs of the organization of the anchor peer
+		InsecureSkipVerify: true,
+		ServerName:         ap.Host,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert, ok := v.certs.TLSClientCert.Load().(*tls.Certificate); ok {
+				return cert, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			certErr = verifyCertificate(rawCerts, verifyOpts)
+			return certErr
+		},
+	})
+	tlsConn.SetDeadline(time.Now().Add(v.timeout))
+	if err := tlsConn.Handshake(); err != nil {
+		if certErr != nil {
+			return errors.Wrapf(certErr, "%s presents an incompatible certificate", address)
+		}
+		return errors.Wrapf(err, "TLS handshake with %s failed", address)
+	}
+	return nil
+}
+
+func verifyCertificate(rawCerts [][]byte, opts x509.VerifyOptions) error {
+	if len(rawCerts) == 0 {
+		return errors.New("no certificate presented")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return errors.Wrap(err, "invalid certificate")
+		}
+		certs[i] = cert
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
@@ -0,0 +1,139 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"crypto/tls"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type anchorsConfig struct {
+	mockConfig
+	roots map[string][]byte
+}
+
+func (ac *anchorsConfig) TLSCACerts(mspID string) ([][]byte, [][]byte) {
+	if root, exists := ac.roots[mspID]; exists {
+		return [][]byte{root}, nil
+	}
+	return nil, nil
+}
+
+// tlsListener serves TLS connections with a certificate for 127.0.0.1 issued by the given CA
+func tlsListener(t *testing.T, ca tlsgen.CA) net.Listener {
+	keyPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(keyPair.Cert, keyPair.Key)
+	require.NoError(t, err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	require.NoError(t, err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.(*tls.Conn).Handshake()
+				conn.Close()
+			}()
+		}
+	}()
+	return listener
+}
+
+func anchorPeer(t *testing.T, address string) *peer.AnchorPeer {
+	host, port, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return &peer.AnchorPeer{Host: host, Port: int32(p)}
+}
+
+func TestAnchorPeerValidation(t *testing.T) {
+	orgCA, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	otherCA, err := tlsgen.NewCA()
+	require.NoError(t, err)
+
+	healthy := tlsListener(t, orgCA)
+	defer healthy.Close()
+	wrongCA := tlsListener(t, otherCA)
+	defer wrongCA.Close()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed.Close()
+
+	config := &anchorsConfig{
+		mockConfig: mockConfig{
+			orgs: map[string]channelconfig.ApplicationOrg{
+				"Org1MSP": &appGrp{
+					mspID: "Org1MSP",
+					anchorPeers: []*peer.AnchorPeer{
+						anchorPeer(t, healthy.Addr().String()),
+						anchorPeer(t, wrongCA.Addr().String()),
+						anchorPeer(t, closed.Addr().String()),
+						{Host: "", Port: 7051},
+					},
+				},
+				"Org2MSP": &appGrp{
+					mspID:       "Org2MSP",
+					anchorPeers: []*peer.AnchorPeer{anchorPeer(t, healthy.Addr().String())},
+				},
+			},
+		},
+		roots: map[string][]byte{"Org1MSP": orgCA.CertBytes()},
+	}
+
+	gauge := &metricsfakes.Gauge{}
+	gauge.WithReturns(gauge)
+	v := newAnchorPeerValidator(time.Second, &gossipCommon.TLSCertificates{}, gauge)
+
+	root := [][]byte{orgCA.CertBytes()}
+	assert.NoError(t, v.check(anchorPeer(t, healthy.Addr().String()), root, nil))
+	assert.Contains(t, v.check(anchorPeer(t, wrongCA.Addr().String()), root, nil).Error(), "presents an incompatible certificate")
+	assert.Contains(t, v.check(anchorPeer(t, closed.Addr().String()), root, nil).Error(), "is unreachable")
+	assert.EqualError(t, v.check(&peer.AnchorPeer{Port: 7051}, root, nil), "invalid endpoint :7051")
+	assert.Contains(t, v.check(anchorPeer(t, healthy.Addr().String()), nil, nil).Error(), "has no TLS root certificates")
+
+	v.validate(config)
+	require.Equal(t, 2, gauge.SetCallCount())
+	unhealthy := map[string]float64{}
+	for i := 0; i < 2; i++ {
+		labels := gauge.WithArgsForCall(i)
+		assert.Equal(t, []string{"channel", testChainID, "mspid"}, labels[:3])
+		unhealthy[labels[3]] = gauge.SetArgsForCall(i)
+	}
+	assert.Equal(t, map[string]float64{"Org1MSP": 3, "Org2MSP": 1}, unhealthy)
+
+	// an organization removed from the channel is reset
+	delete(config.orgs, "Org2MSP")
+	v.validate(config)
+	require.Equal(t, 4, gauge.SetCallCount())
+	assert.Equal(t, []string{"channel", testChainID, "mspid", "Org2MSP"}, gauge.WithArgsForCall(3))
+	assert.Equal(t, float64(0), gauge.SetArgsForCall(3))
+}
+
+func TestAnchorPeerValidationWithoutTLS(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	v := newAnchorPeerValidator(time.Second, nil, &metricsfakes.Gauge{})
+	assert.NoError(t, v.check(anchorPeer(t, listener.Addr().String()), nil, nil))
+}
@@ -26,6 +26,9 @@ type Config interface {
 
 	// OrdererAddresses returns the list of valid orderer addresses to connect to to invoke Broadcast/Deliver
 	OrdererAddresses() []string
+
+	// TLSCACerts returns the TLS root and intermediate CA certificates of the given organization
+	TLSCACerts(mspID string) (roots [][]byte, intermediates [][]byte)
 }
 
 // ConfigProcessor receives config updates
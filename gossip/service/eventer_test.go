@@ -41,6 +41,10 @@ func (mc *mockConfig) OrdererAddresses() []string {
 	return []string{"localhost:7050"}
 }
 
+func (mc *mockConfig) TLSCACerts(mspID string) ([][]byte, [][]byte) {
+	return nil, nil
+}
+
 func (mc *mockConfig) Sequence() uint64 {
 	return mc.sequence
 }
@@ -50,6 +50,9 @@ type GossipService interface {
 	NewConfigEventer() ConfigProcessor
 	// InitializeChannel allocates the state provider and should be invoked once per channel per execution
 	InitializeChannel(chainID string, endpoints []string, support Support)
+	// CloseChannel stops the state provider, the private data handlers and the delivery
+	// of the blocks of the given channel, and leaves the channel
+	CloseChannel(chainID string)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
 }
@@ -61,16 +64,18 @@ type DeliveryServiceFactory interface {
 }
 
 type deliveryFactoryImpl struct {
+	metricsProvider metrics.Provider
 }
 
 // Returns an instance of delivery client
-func (*deliveryFactoryImpl) Service(g GossipService, endpoints []string, mcs api.MessageCryptoService) (deliverclient.DeliverService, error) {
+func (df *deliveryFactoryImpl) Service(g GossipService, endpoints []string, mcs api.MessageCryptoService) (deliverclient.DeliverService, error) {
 	return deliverclient.NewDeliverService(&deliverclient.Config{
-		CryptoSvc:   mcs,
-		Gossip:      g,
-		Endpoints:   endpoints,
-		ConnFactory: deliverclient.DefaultConnectionFactory,
-		ABCFactory:  deliverclient.DefaultABCFactory,
+		CryptoSvc:       mcs,
+		Gossip:          g,
+		Endpoints:       endpoints,
+		ConnFactory:     deliverclient.DefaultConnectionFactory,
+		ABCFactory:      deliverclient.DefaultABCFactory,
+		MetricsProvider: df.metricsProvider,
 	})
 }
 
@@ -98,6 +103,9 @@ type gossipServiceImpl struct {
 	peerIdentity    []byte
 	secAdv          api.SecurityAdvisor
 	metrics         *gossipMetrics.GossipMetrics
+	anchorValidator *anchorPeerValidator
+	// standby is 1 while the peer is a standby peer
+	standby int32
 }
 
 // This is an implementation of api.JoinChannelMessage.
@@ -136,7 +144,7 @@ func InitGossipService(peerIdentity []byte, metricsProvider metrics.Provider, en
 	// TODO: This is a temporary work-around to make the gossip leader election module load its logger at startup
 	// TODO: in order for the flogging package to register this logger in time so it can set the log levels as requested in the config
 	util.GetLogger(util.ElectionLogger, "")
-	return InitGossipServiceCustomDeliveryFactory(peerIdentity, metricsProvider, endpoint, s, certs, &deliveryFactoryImpl{},
+	return InitGossipServiceCustomDeliveryFactory(peerIdentity, metricsProvider, endpoint, s, certs, &deliveryFactoryImpl{metricsProvider: metricsProvider},
 		mcs, secAdv, secureDialOpts, bootPeers...)
 }
 
@@ -156,6 +164,12 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, metricsProvider
 
 		gossipMetrics := gossipMetrics.NewGossipMetrics(metricsProvider)
 
+		verifiedBlocksCacheSize := defaultVerifiedBlocksCacheSize
+		if viper.IsSet("peer.gossip.state.verifiedBlocksCacheSize") {
+			verifiedBlocksCacheSize = viper.GetInt("peer.gossip.state.verifiedBlocksCacheSize")
+		}
+		mcs = newVerifiedBlocksCache(mcs, verifiedBlocksCacheSize, gossipMetrics.StateMetrics.DuplicateBlocks)
+
 		gossip, err = integration.NewGossipComponent(peerIdentity, endpoint, s, secAdv,
 			mcs, secureDialOpts, certs, gossipMetrics, bootPeers...)
 		gossipServiceInstance = &gossipServiceImpl{
@@ -170,6 +184,16 @@ func InitGossipServiceCustomDeliveryFactory(peerIdentity []byte, metricsProvider
 			secAdv:          secAdv,
 			metrics:         gossipMetrics,
 		}
+		if viper.GetBool("peer.gossip.anchorPeerValidation.enabled") {
+			gossipServiceInstance.anchorValidator = newAnchorPeerValidator(
+				viper.GetDuration("peer.gossip.anchorPeerValidation.timeout"),
+				certs,
+				gossipMetrics.MembershipMetrics.UnhealthyAnchorPeers,
+			)
+		}
+		if viper.GetBool("peer.standby.enabled") {
+			gossipServiceInstance.standby = 1
+		}
 	})
 	return errors.WithStack(err)
 }
@@ -247,6 +271,7 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 	coordinatorConfig := privdata2.CoordinatorConfig{
 		TransientBlockRetention: privdata2.GetTransientBlockRetention(),
 		PullRetryThreshold:      viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold"),
+		Region:                  viper.GetString("peer.region"),
 	}
 	coordinator := privdata2.NewCoordinator(privdata2.Support{
 		ChainID:         chainID,
@@ -267,11 +292,10 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 		reconciler = &privdata2.NoOpReconciler{}
 	}
 
-	pushAckTimeout := viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	g.privateHandlers[chainID] = privateHandler{
 		support:     support,
 		coordinator: coordinator,
-		distributor: privdata2.NewDistributor(chainID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout),
+		distributor: privdata2.NewDistributor(chainID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, privdata2.GetDistributorConfig()),
 		reconciler:  reconciler,
 	}
 	g.privateHandlers[chainID].reconciler.Start()
@@ -289,34 +313,45 @@ func (g *gossipServiceImpl) InitializeChannel(chainID string, endpoints []string
 	// Delivery service might be nil only if it was not able to get connected
 	// to the ordering service
 	if g.deliveryService[chainID] != nil {
-		// Parameters:
-		//              - peer.gossip.useLeaderElection
-		//              - peer.gossip.orgLeader
-		//
-		// are mutual exclusive, setting both to true is not defined, hence
-		// peer will panic and terminate
-		leaderElection := viper.GetBool("peer.gossip.useLeaderElection")
-		isStaticOrgLeader := viper.GetBool("peer.gossip.orgLeader")
-
-		if leaderElection && isStaticOrgLeader {
-			logger.Panic("Setting both orgLeader and useLeaderElection to true isn't supported, aborting execution")
-		}
-
-		if leaderElection {
-			logger.Debug("Delivery uses dynamic leader election mechanism, channel", chainID)
-			g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID,
-				support.Committer), g.metrics.ElectionMetrics)
-		} else if isStaticOrgLeader {
-			logger.Debug("This peer is configured to connect to ordering service for blocks delivery, channel", chainID)
+		if g.isStandby() {
+			logger.Info("This peer is a standby peer, it connects to ordering service for blocks delivery, channel", chainID)
 			g.deliveryService[chainID].StartDeliverForChannel(chainID, support.Committer, func() {})
 		} else {
-			logger.Debug("This peer is not configured to connect to ordering service for blocks delivery, channel", chainID)
+			g.startDelivery(chainID, support.Committer)
 		}
 	} else {
 		logger.Warning("Delivery client is down won't be able to pull blocks for chain", chainID)
 	}
 }
 
+// startDelivery starts the delivery of the blocks of the channel, or the leader election
+// deciding whether to deliver them, according to the configuration
+func (g *gossipServiceImpl) startDelivery(chainID string, ledgerInfo blocksprovider.LedgerInfo) {
+	// Parameters:
+	//              - peer.gossip.useLeaderElection
+	//              - peer.gossip.orgLeader
+	//
+	// are mutual exclusive, setting both to true is not defined, hence
+	// peer will panic and terminate
+	leaderElection := viper.GetBool("peer.gossip.useLeaderElection")
+	isStaticOrgLeader := viper.GetBool("peer.gossip.orgLeader")
+
+	if leaderElection && isStaticOrgLeader {
+		logger.Panic("Setting both orgLeader and useLeaderElection to true isn't supported, aborting execution")
+	}
+
+	if leaderElection {
+		logger.Debug("Delivery uses dynamic leader election mechanism, channel", chainID)
+		g.leaderElection[chainID] = g.newLeaderElectionComponent(chainID, g.onStatusChangeFactory(chainID,
+			ledgerInfo), g.metrics.ElectionMetrics)
+	} else if isStaticOrgLeader {
+		logger.Debug("This peer is configured to connect to ordering service for blocks delivery, channel", chainID)
+		g.deliveryService[chainID].StartDeliverForChannel(chainID, ledgerInfo, func() {})
+	} else {
+		logger.Debug("This peer is not configured to connect to ordering service for blocks delivery, channel", chainID)
+	}
+}
+
 func (g *gossipServiceImpl) createSelfSignedData() common.SignedData {
 	msg := make([]byte, 32)
 	sig, err := g.mcs.Sign(msg)
@@ -354,6 +389,10 @@ func (g *gossipServiceImpl) updateAnchors(config Config) {
 	// Initialize new state provider for given committer
 	logger.Debug("Creating state provider for chainID", config.ChainID())
 	g.JoinChan(jcm, gossipCommon.ChainID(config.ChainID()))
+
+	if g.anchorValidator != nil {
+		go g.anchorValidator.validate(config)
+	}
 }
 
 func (g *gossipServiceImpl) updateEndpoints(chainID string, endpoints []string) {
@@ -374,6 +413,34 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// CloseChannel stops the state provider, the private data handlers and the delivery
+// of the blocks of the given channel, and leaves the channel
+func (g *gossipServiceImpl) CloseChannel(chainID string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	logger.Info("Closing chain", chainID)
+	if le, exists := g.leaderElection[chainID]; exists {
+		le.Stop()
+		delete(g.leaderElection, chainID)
+	}
+	if chain, exists := g.chains[chainID]; exists {
+		chain.Stop()
+		delete(g.chains, chainID)
+	}
+	if handler, exists := g.privateHandlers[chainID]; exists {
+		handler.close()
+		delete(g.privateHandlers, chainID)
+	}
+	if ds, exists := g.deliveryService[chainID]; exists {
+		if ds != nil {
+			ds.Stop()
+		}
+		delete(g.deliveryService, chainID)
+	}
+	g.LeaveChan(gossipCommon.ChainID(chainID))
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
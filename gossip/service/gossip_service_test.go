@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -309,6 +310,44 @@ func TestWithStaticDeliverClientBothStaticAndLeaderElection(t *testing.T) {
 	stopPeers(gossips)
 }
 
+func TestStandbyDeliverClient(t *testing.T) {
+	util.SetVal("peer.gossip.useLeaderElection", true)
+	util.SetVal("peer.gossip.orgLeader", false)
+
+	n := 2
+	gossips := startPeers(t, n, 0, 1)
+
+	channelName := "chanA"
+	peerIndexes := make([]int, n)
+	for i := 0; i < n; i++ {
+		peerIndexes[i] = i
+	}
+
+	addPeersToChannel(t, n, channelName, gossips, peerIndexes)
+
+	waitForFullMembership(t, gossips, n, time.Second*30, time.Second*2)
+
+	// the standby peer connects to the ordering service and takes no part in the leader election
+	standby := gossips[0].(*gossipGRPC).gossipServiceImpl
+	atomic.StoreInt32(&standby.standby, 1)
+	deliverService := &mockDeliverService{running: make(map[string]bool)}
+	standby.deliveryFactory = &mockDeliverServiceFactory{service: deliverService}
+	standby.InitializeChannel(channelName, []string{"endpoint"}, Support{
+		Committer: &mockLedgerInfo{1},
+		Store:     &mockTransientStore{},
+	})
+	assert.True(t, deliverService.running[channelName])
+	assert.Empty(t, standby.leaderElection)
+
+	// once promoted, it follows the leader election configuration
+	standby.Promote()
+	assert.False(t, standby.isStandby())
+	assert.False(t, deliverService.running[channelName])
+	assert.NotNil(t, standby.leaderElection[channelName])
+
+	stopPeers(gossips)
+}
+
 type mockDeliverServiceFactory struct {
 	service *mockDeliverService
 }
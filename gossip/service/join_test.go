@@ -78,6 +78,12 @@ func (*gossipMock) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
 	panic("implement me")
 }
 
+// UpdateCommitHash updates the ledger height and the commit hash
+// of the last block the peer publishes to other peers in the channel
+func (*gossipMock) UpdateCommitHash(height uint64, commitHash []byte, chainID common.ChainID) {
+	panic("implement me")
+}
+
 // UpdateChaincodes updates the chaincodes the peer publishes
 // to other peers in the channel
 func (*gossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID) {
@@ -144,6 +150,10 @@ func (c *configMock) Organizations() map[string]channelconfig.ApplicationOrg {
 	return c.orgs2AppOrgs
 }
 
+func (*configMock) TLSCACerts(mspID string) ([][]byte, [][]byte) {
+	return nil, nil
+}
+
 func (*configMock) Sequence() uint64 {
 	return 0
 }
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	cb "github.com/hyperledger/fabric/protos/common"
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// ChannelMembership is the gossip view of a channel the peer joined
+type ChannelMembership struct {
+	Channel string           `json:"channel"`
+	Self    PeerMembership   `json:"self"`
+	Peers   []PeerMembership `json:"peers"`
+}
+
+// PeerMembership describes a peer of a channel as seen through gossip
+type PeerMembership struct {
+	PKIID            string                `json:"pki_id"`
+	Endpoint         string                `json:"endpoint"`
+	InternalEndpoint string                `json:"internal_endpoint,omitempty"`
+	MSPID            string                `json:"mspid"`
+	LedgerHeight     uint64                `json:"ledger_height"`
+	LeftChannel      bool                  `json:"left_channel,omitempty"`
+	Chaincodes       []ChaincodeMembership `json:"chaincodes,omitempty"`
+	// EligibleCollections maps the chaincodes to the collections whose private
+	// data the peer is eligible to receive, according to its organization
+	EligibleCollections map[string][]string `json:"eligible_collections,omitempty"`
+}
+
+// ChaincodeMembership is a chaincode a peer advertises on a channel
+type ChaincodeMembership struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// MembershipReporter is implemented by the gossip service to report the
+// gossip view of the channels the peer joined
+type MembershipReporter interface {
+	// Membership returns the gossip view of the channels the peer joined,
+	// sorted by channel
+	Membership() []ChannelMembership
+}
+
+// Membership returns the gossip view of the channels the peer joined
+func (g *gossipServiceImpl) Membership() []ChannelMembership {
+	g.lock.RLock()
+	stores := make(map[string]privdata.CollectionStore, len(g.chains))
+	for chainID := range g.chains {
+		stores[chainID] = g.privateHandlers[chainID].support.Cs
+	}
+	g.lock.RUnlock()
+
+	orgs := make(map[string]string)
+	for _, id := range g.IdentityInfo() {
+		orgs[string(id.PKIId)] = string(id.Organization)
+	}
+	selfOrg := string(g.secAdv.OrgByPeerIdentity(g.peerIdentity))
+
+	var channels []ChannelMembership
+	for chainID, cs := range stores {
+		self := g.SelfMembershipInfo()
+		if msg := g.SelfChannelInfo(common.ChainID(chainID)); msg != nil && msg.GetStateInfo() != nil {
+			self.Properties = msg.GetStateInfo().Properties
+		}
+		collections := collectionMembers(chainID, cs, self.Properties)
+
+		cm := ChannelMembership{
+			Channel: chainID,
+			Self:    newPeerMembership(self, selfOrg, collections),
+			Peers:   []PeerMembership{},
+		}
+		for _, member := range g.PeersOfChannel(common.ChainID(chainID)) {
+			cm.Peers = append(cm.Peers, newPeerMembership(member, orgs[string(member.PKIid)], collections))
+		}
+		sort.Slice(cm.Peers, func(i, j int) bool {
+			return cm.Peers[i].Endpoint < cm.Peers[j].Endpoint
+		})
+		channels = append(channels, cm)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Channel < channels[j].Channel
+	})
+	return channels
+}
+
+// collectionMembers returns the member organizations of the collections of the
+// chaincodes the peer advertises on the channel, by chaincode and collection
+func collectionMembers(chainID string, cs privdata.CollectionStore, props *gproto.Properties) map[string]map[string][]string {
+	members := make(map[string]map[string][]string)
+	if cs == nil || props == nil {
+		return members
+	}
+	for _, cc := range props.Chaincodes {
+		ccp, err := cs.RetrieveCollectionConfigPackage(cb.CollectionCriteria{Channel: chainID, Namespace: cc.Name})
+		if err != nil {
+			if _, ok := err.(privdata.NoSuchCollectionError); !ok {
+				logger.Debugf("Failed retrieving the collections of chaincode %s on channel %s: %s", cc.Name, chainID, err)
+			}
+			continue
+		}
+		for _, conf := range ccp.Config {
+			staticConf := conf.GetStaticCollectionConfig()
+			if staticConf == nil {
+				continue
+			}
+			ap, err := cs.RetrieveCollectionAccessPolicy(cb.CollectionCriteria{Channel: chainID, Namespace: cc.Name, Collection: staticConf.Name})
+			if err != nil {
+				logger.Debugf("Failed retrieving the access policy of collection %s of chaincode %s on channel %s: %s", staticConf.Name, cc.Name, chainID, err)
+				continue
+			}
+			if members[cc.Name] == nil {
+				members[cc.Name] = make(map[string][]string)
+			}
+			members[cc.Name][staticConf.Name] = ap.MemberOrgs()
+		}
+	}
+	return members
+}
+
+func newPeerMembership(member discovery.NetworkMember, mspID string, collections map[string]map[string][]string) PeerMembership {
+	pm := PeerMembership{
+		PKIID:            member.PKIid.String(),
+		Endpoint:         member.Endpoint,
+		InternalEndpoint: member.InternalEndpoint,
+		MSPID:            mspID,
+	}
+	if props := member.Properties; props != nil {
+		pm.LedgerHeight = props.LedgerHeight
+		pm.LeftChannel = props.LeftChannel
+		for _, cc := range props.Chaincodes {
+			pm.Chaincodes = append(pm.Chaincodes, ChaincodeMembership{Name: cc.Name, Version: cc.Version})
+		}
+	}
+
+	for cc, colls := range collections {
+		for coll, memberOrgs := range colls {
+			for _, org := range memberOrgs {
+				if org != mspID {
+					continue
+				}
+				if pm.EligibleCollections == nil {
+					pm.EligibleCollections = make(map[string][]string)
+				}
+				pm.EligibleCollections[cc] = append(pm.EligibleCollections[cc], coll)
+				break
+			}
+		}
+	}
+	for _, colls := range pm.EligibleCollections {
+		sort.Strings(colls)
+	}
+	return pm
+}
+
+// MembershipHandler serves the gossip view of the channels of the peer
+type MembershipHandler struct {
+	Reporter MembershipReporter
+}
+
+// ServeHTTP returns the gossip view of the channels of the peer in JSON. With
+// the channel query parameter, it returns the gossip view of that channel only
+func (h *MembershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		resp.Header().Set("Allow", "GET")
+		http.Error(resp, fmt.Sprintf("invalid request method: %s", req.Method), http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body interface{}
+	channels := h.Reporter.Membership()
+	if channel := strings.TrimSpace(req.URL.Query().Get("channel")); channel != "" {
+		for _, cm := range channels {
+			if cm.Channel == channel {
+				body = cm
+			}
+		}
+		if body == nil {
+			http.Error(resp, fmt.Sprintf("the peer has not joined channel %s", channel), http.StatusNotFound)
+			return
+		}
+	} else {
+		if channels == nil {
+			channels = []ChannelMembership{}
+		}
+		body = channels
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(resp).Encode(body); err != nil {
+		logger.Errorf("failed to encode the gossip membership: %s", err)
+	}
+}
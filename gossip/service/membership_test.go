@@ -0,0 +1,210 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/state"
+	cb "github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+type membershipGossipMock struct {
+	gossipMock
+	self     discovery.NetworkMember
+	selfInfo map[string]*proto.Properties
+	peers    map[string][]discovery.NetworkMember
+	ids      api.PeerIdentitySet
+}
+
+func (g *membershipGossipMock) SelfMembershipInfo() discovery.NetworkMember {
+	return g.self
+}
+
+func (g *membershipGossipMock) SelfChannelInfo(chainID common.ChainID) *proto.SignedGossipMessage {
+	props, ok := g.selfInfo[string(chainID)]
+	if !ok {
+		return nil
+	}
+	return &proto.SignedGossipMessage{GossipMessage: &proto.GossipMessage{
+		Content: &proto.GossipMessage_StateInfo{StateInfo: &proto.StateInfo{Properties: props}},
+	}}
+}
+
+func (g *membershipGossipMock) PeersOfChannel(chainID common.ChainID) []discovery.NetworkMember {
+	return g.peers[string(chainID)]
+}
+
+func (g *membershipGossipMock) IdentityInfo() api.PeerIdentitySet {
+	return g.ids
+}
+
+type accessPolicyMock struct {
+	privdata.CollectionAccessPolicy
+	memberOrgs []string
+}
+
+func (ap *accessPolicyMock) MemberOrgs() []string {
+	return ap.memberOrgs
+}
+
+type collectionStoreMock struct {
+	privdata.CollectionStore
+	collections map[string]map[string][]string
+}
+
+func (cs *collectionStoreMock) RetrieveCollectionConfigPackage(cc cb.CollectionCriteria) (*cb.CollectionConfigPackage, error) {
+	colls, ok := cs.collections[cc.Namespace]
+	if !ok {
+		return nil, privdata.NoSuchCollectionError(cc)
+	}
+	ccp := &cb.CollectionConfigPackage{}
+	for name := range colls {
+		ccp.Config = append(ccp.Config, &cb.CollectionConfig{
+			Payload: &cb.CollectionConfig_StaticCollectionConfig{
+				StaticCollectionConfig: &cb.StaticCollectionConfig{Name: name},
+			},
+		})
+	}
+	return ccp, nil
+}
+
+func (cs *collectionStoreMock) RetrieveCollectionAccessPolicy(cc cb.CollectionCriteria) (privdata.CollectionAccessPolicy, error) {
+	return &accessPolicyMock{memberOrgs: cs.collections[cc.Namespace][cc.Collection]}, nil
+}
+
+func newMembershipGossipService() *gossipServiceImpl {
+	chaincodes := []*proto.Chaincode{{Name: "mycc", Version: "1.0"}, {Name: "othercc", Version: "2.0"}}
+	gMock := &membershipGossipMock{
+		self: discovery.NetworkMember{PKIid: common.PKIidType("p0"), Endpoint: "peer0.org1:7051", InternalEndpoint: "peer0.org1:7051"},
+		selfInfo: map[string]*proto.Properties{
+			"A": {LedgerHeight: 10, Chaincodes: chaincodes},
+		},
+		peers: map[string][]discovery.NetworkMember{
+			"A": {
+				{PKIid: common.PKIidType("p2"), Endpoint: "peer0.org2:7051", Properties: &proto.Properties{LedgerHeight: 8, Chaincodes: chaincodes}},
+				{PKIid: common.PKIidType("p1"), Endpoint: "peer1.org1:7051", Properties: &proto.Properties{LedgerHeight: 9}},
+			},
+		},
+		ids: api.PeerIdentitySet{
+			{PKIId: common.PKIidType("p1"), Organization: api.OrgIdentityType("Org1MSP")},
+			{PKIId: common.PKIidType("p2"), Organization: api.OrgIdentityType("Org2MSP")},
+		},
+	}
+	cs := &collectionStoreMock{collections: map[string]map[string][]string{
+		"mycc": {
+			"shared":  {"Org1MSP", "Org2MSP"},
+			"private": {"Org1MSP"},
+		},
+	}}
+	return &gossipServiceImpl{
+		gossipSvc:    gMock,
+		secAdv:       &secAdvMock{},
+		peerIdentity: api.PeerIdentityType("Org1MSP"),
+		chains:       map[string]state.GossipStateProvider{"A": nil, "B": nil},
+		privateHandlers: map[string]privateHandler{
+			"A": {support: Support{Cs: cs}},
+			"B": {},
+		},
+	}
+}
+
+func TestMembership(t *testing.T) {
+	g := newMembershipGossipService()
+
+	channels := g.Membership()
+	assert.Equal(t, []ChannelMembership{
+		{
+			Channel: "A",
+			Self: PeerMembership{
+				PKIID:            "7030",
+				Endpoint:         "peer0.org1:7051",
+				InternalEndpoint: "peer0.org1:7051",
+				MSPID:            "Org1MSP",
+				LedgerHeight:     10,
+				Chaincodes:       []ChaincodeMembership{{Name: "mycc", Version: "1.0"}, {Name: "othercc", Version: "2.0"}},
+				EligibleCollections: map[string][]string{
+					"mycc": {"private", "shared"},
+				},
+			},
+			Peers: []PeerMembership{
+				{
+					PKIID:        "7032",
+					Endpoint:     "peer0.org2:7051",
+					MSPID:        "Org2MSP",
+					LedgerHeight: 8,
+					Chaincodes:   []ChaincodeMembership{{Name: "mycc", Version: "1.0"}, {Name: "othercc", Version: "2.0"}},
+					EligibleCollections: map[string][]string{
+						"mycc": {"shared"},
+					},
+				},
+				{
+					PKIID:        "7031",
+					Endpoint:     "peer1.org1:7051",
+					MSPID:        "Org1MSP",
+					LedgerHeight: 9,
+					EligibleCollections: map[string][]string{
+						"mycc": {"private", "shared"},
+					},
+				},
+			},
+		},
+		{
+			Channel: "B",
+			Self: PeerMembership{
+				PKIID:            "7030",
+				Endpoint:         "peer0.org1:7051",
+				InternalEndpoint: "peer0.org1:7051",
+				MSPID:            "Org1MSP",
+			},
+			Peers: []PeerMembership{},
+		},
+	}, channels)
+}
+
+func TestMembershipHandler(t *testing.T) {
+	h := &MembershipHandler{Reporter: newMembershipGossipService()}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gossip/membership?channel=B", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"channel": "B",
+		"self": {"pki_id": "7030", "endpoint": "peer0.org1:7051", "internal_endpoint": "peer0.org1:7051", "mspid": "Org1MSP", "ledger_height": 0},
+		"peers": []
+	}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gossip/membership", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"eligible_collections":{"mycc":["shared"]}`)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gossip/membership?channel=C", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "the peer has not joined channel C")
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/gossip/membership", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET", rec.Header().Get("Allow"))
+
+	h = &MembershipHandler{Reporter: &gossipServiceImpl{gossipSvc: &membershipGossipMock{}, secAdv: &secAdvMock{}}}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/gossip/membership", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+}
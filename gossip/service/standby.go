@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"sync/atomic"
+
+	gproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// Promoter is implemented by the gossip service of a standby peer. A standby peer
+// pulls the blocks of all its channels from the ordering service, whatever the
+// leader election configuration, and does not disseminate them to the other peers
+type Promoter interface {
+	// Promote ends the standby mode: the blocks are disseminated again and their
+	// delivery follows the leader election configuration
+	Promote()
+}
+
+func (g *gossipServiceImpl) isStandby() bool {
+	return atomic.LoadInt32(&g.standby) == 1
+}
+
+// Gossip disseminates the message to the other peers, unless the peer is a standby peer
+func (g *gossipServiceImpl) Gossip(msg *gproto.GossipMessage) {
+	if g.isStandby() {
+		return
+	}
+	g.gossipSvc.Gossip(msg)
+}
+
+// Promote ends the standby mode
+func (g *gossipServiceImpl) Promote() {
+	if !atomic.CompareAndSwapInt32(&g.standby, 1, 0) {
+		return
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if viper.GetBool("peer.gossip.orgLeader") {
+		// the delivery of the blocks goes on as is
+		return
+	}
+	for chainID, handler := range g.privateHandlers {
+		ds := g.deliveryService[chainID]
+		if ds == nil {
+			continue
+		}
+		if err := ds.StopDeliverForChannel(chainID); err != nil {
+			logger.Warningf("Failed stopping the delivery of the blocks of channel %s: %+v", chainID, errors.WithStack(err))
+		}
+		g.startDelivery(chainID, handler.support.Committer)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+)
+
+// defaultVerifiedBlocksCacheSize is the number of verified blocks remembered
+// when peer.gossip.state.verifiedBlocksCacheSize is not set
+const defaultVerifiedBlocksCacheSize = 100
+
+// verifiedBlocksCache is a MessageCryptoService remembering the blocks it
+// verified, keyed by the hash of the signed blocks, so that the blocks received
+// again, for instance from both the ordering service and gossip, or from several
+// peers in dense gossip topologies, are not verified again. Only the blocks that
+// were successfully verified are remembered, and the least recently received
+// ones are evicted first
+type verifiedBlocksCache struct {
+	api.MessageCryptoService
+	maxEntries int
+	duplicates metrics.Counter
+
+	lock    sync.Mutex
+	entries map[verifiedBlock]*list.Element
+	order   *list.List
+}
+
+type verifiedBlock struct {
+	chainID string
+	seqNum  uint64
+	hash    string
+}
+
+// newVerifiedBlocksCache returns the message crypto service remembering at
+// most maxEntries blocks verified by the given service, or the given service
+// itself if maxEntries is not positive
+func newVerifiedBlocksCache(mcs api.MessageCryptoService, maxEntries int, duplicates metrics.Counter) api.MessageCryptoService {
+	if maxEntries <= 0 {
+		return mcs
+	}
+	return &verifiedBlocksCache{
+		MessageCryptoService: mcs,
+		maxEntries:           maxEntries,
+		duplicates:           duplicates,
+		entries:              map[verifiedBlock]*list.Element{},
+		order:                list.New(),
+	}
+}
+
+// VerifyBlock returns nil if the block was already verified, and verifies it
+// otherwise
+func (c *verifiedBlocksCache) VerifyBlock(chainID gossipCommon.ChainID, seqNum uint64, signedBlock []byte) error {
+	key := verifiedBlock{
+		chainID: string(chainID),
+		seqNum:  seqNum,
+		hash:    string(util.ComputeSHA256(signedBlock)),
+	}
+	if c.verified(key) {
+		logger.Debugf("[%s] Block [%d] was already verified", chainID, seqNum)
+		c.duplicates.With("channel", key.chainID).Add(1)
+		return nil
+	}
+
+	if err := c.MessageCryptoService.VerifyBlock(chainID, seqNum, signedBlock); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, exists := c.entries[key]; exists {
+		return nil
+	}
+	for c.order.Len() >= c.maxEntries {
+		delete(c.entries, c.order.Remove(c.order.Front()).(verifiedBlock))
+	}
+	c.entries[key] = c.order.PushBack(key)
+	return nil
+}
+
+func (c *verifiedBlocksCache) verified(key verifiedBlock) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	element, exists := c.entries[key]
+	if exists {
+		c.order.MoveToBack(element)
+	}
+	return exists
+}
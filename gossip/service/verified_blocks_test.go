@@ -0,0 +1,67 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	gossipCommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingCryptoService counts the blocks it verifies, and rejects the blocks
+// with the content "fabricated"
+type countingCryptoService struct {
+	naiveCryptoService
+	verifications int
+}
+
+func (cs *countingCryptoService) VerifyBlock(chainID gossipCommon.ChainID, seqNum uint64, signedBlock []byte) error {
+	cs.verifications++
+	if string(signedBlock) == "fabricated" {
+		return errors.New("fabricated block")
+	}
+	return nil
+}
+
+func TestVerifiedBlocksCache(t *testing.T) {
+	mcs := &countingCryptoService{}
+	duplicates := &metricsfakes.Counter{}
+	duplicates.WithReturns(duplicates)
+	cache := newVerifiedBlocksCache(mcs, 2, duplicates)
+
+	// the blocks received again are not verified again
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 1, []byte("block1")))
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 1, []byte("block1")))
+	assert.Equal(t, 1, mcs.verifications)
+	assert.Equal(t, 1, duplicates.AddCallCount())
+	assert.Equal(t, []string{"channel", "A"}, duplicates.WithArgsForCall(0))
+
+	// the blocks are keyed by channel, sequence number and content
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("B"), 1, []byte("block1")))
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 2, []byte("block1")))
+	assert.Equal(t, 3, mcs.verifications)
+
+	// the blocks failing the verification are not remembered
+	assert.EqualError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 3, []byte("fabricated")), "fabricated block")
+	assert.EqualError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 3, []byte("fabricated")), "fabricated block")
+	assert.Equal(t, 5, mcs.verifications)
+
+	// the least recently received blocks are evicted
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("B"), 1, []byte("block1")))
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 3, []byte("block3")))
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 2, []byte("block1")))
+	assert.Equal(t, 7, mcs.verifications)
+	assert.NoError(t, cache.VerifyBlock(gossipCommon.ChainID("A"), 3, []byte("block3")))
+	assert.Equal(t, 7, mcs.verifications)
+	assert.Equal(t, 3, duplicates.AddCallCount())
+
+	// a zero size disables the cache
+	assert.Equal(t, mcs, newVerifiedBlocksCache(mcs, 0, duplicates))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"bytes"
+	"sync"
+
+	commonmetrics "github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/gossip/discovery"
+)
+
+// commitHashHistory is the number of recent blocks for which the
+// commit hashes are kept to be compared with those of other peers
+const commitHashHistory = 100
+
+// commitHashVerifier compares the commit hashes of the recent blocks committed
+// by the peer with the commit hashes that the other peers of the channel
+// publish along with their ledger height, in order to detect a divergence of
+// the state of the peers as soon as it occurs
+type commitHashVerifier struct {
+	chainID    string
+	mismatches commonmetrics.Counter
+
+	lock   sync.Mutex
+	hashes map[uint64][]byte
+	blocks []uint64
+	// verified maps the PKI-ID of the peers to the
+	// ledger height at which they were last verified
+	verified map[string]uint64
+}
+
+func newCommitHashVerifier(chainID string, mismatches commonmetrics.Counter) *commitHashVerifier {
+	return &commitHashVerifier{
+		chainID:    chainID,
+		mismatches: mismatches.With("channel", chainID),
+		hashes:     make(map[uint64][]byte),
+		verified:   make(map[string]uint64),
+	}
+}
+
+// record keeps the commit hash of a block committed by the peer
+func (v *commitHashVerifier) record(blockNum uint64, commitHash []byte) {
+	if len(commitHash) == 0 {
+		return
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.hashes[blockNum] = commitHash
+	v.blocks = append(v.blocks, blockNum)
+	if len(v.blocks) > commitHashHistory {
+		delete(v.hashes, v.blocks[0])
+		v.blocks = v.blocks[1:]
+	}
+}
+
+// verify compares the commit hashes published by the given peers with the commit
+// hashes of the same blocks committed by the peer, and reports each mismatch once
+func (v *commitHashVerifier) verify(peers []discovery.NetworkMember) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	verified := make(map[string]uint64, len(peers))
+	for _, p := range peers {
+		if p.Properties == nil || len(p.Properties.CommitHash) == 0 || p.Properties.LedgerHeight == 0 {
+			continue
+		}
+		height := p.Properties.LedgerHeight
+		if lastHeight, exists := v.verified[string(p.PKIid)]; exists {
+			verified[string(p.PKIid)] = lastHeight
+		}
+		if verified[string(p.PKIid)] == height {
+			continue
+		}
+		blockNum := height - 1
+		commitHash, exists := v.hashes[blockNum]
+		if !exists {
+			continue
+		}
+		verified[string(p.PKIid)] = height
+		if bytes.Equal(commitHash, p.Properties.CommitHash) {
+			continue
+		}
+		logger.Errorf("[%s] The state of peer %s diverges from the state of this peer: "+
+			"the commit hash of block [%d] is [%x] instead of [%x]",
+			v.chainID, p.PreferredEndpoint(), blockNum, p.Properties.CommitHash, commitHash)
+		v.mismatches.Add(1)
+	}
+	v.verified = verified
+}
@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitHashVerifier(t *testing.T) {
+	mismatches := &metricsfakes.Counter{}
+	mismatches.WithReturns(mismatches)
+	v := newCommitHashVerifier("testchannel", mismatches)
+	assert.Equal(t, []string{"channel", "testchannel"}, mismatches.WithArgsForCall(0))
+
+	peer := func(id string, height uint64, commitHash []byte) discovery.NetworkMember {
+		return discovery.NetworkMember{
+			PKIid:      common.PKIidType(id),
+			Endpoint:   id,
+			Properties: &proto.Properties{LedgerHeight: height, CommitHash: commitHash},
+		}
+	}
+
+	v.record(5, []byte("hash5"))
+	v.record(6, []byte("hash6"))
+	v.record(7, nil)
+
+	v.verify([]discovery.NetworkMember{
+		peer("p1", 6, []byte("hash5")),
+		peer("p2", 7, []byte("hash6")),
+		// the commit hashes of the blocks that the peer did not commit are not compared
+		peer("p3", 8, []byte("hash7")),
+		peer("p4", 10, []byte("hash9")),
+		peer("p5", 7, nil),
+	})
+	assert.Equal(t, 0, mismatches.AddCallCount())
+
+	v.verify([]discovery.NetworkMember{
+		peer("p1", 7, []byte("divergent")),
+		peer("p2", 7, []byte("hash6")),
+	})
+	assert.Equal(t, 1, mismatches.AddCallCount())
+	assert.Equal(t, float64(1), mismatches.AddArgsForCall(0))
+
+	// a mismatch is reported once
+	v.verify([]discovery.NetworkMember{
+		peer("p1", 7, []byte("divergent")),
+	})
+	assert.Equal(t, 1, mismatches.AddCallCount())
+
+	// only the recent commit hashes are kept
+	for i := uint64(8); i < 8+commitHashHistory; i++ {
+		v.record(i, []byte("hash"))
+	}
+	assert.Len(t, v.hashes, commitHashHistory)
+	v.verify([]discovery.NetworkMember{
+		peer("p6", 7, []byte("divergent")),
+	})
+	assert.Equal(t, 1, mismatches.AddCallCount())
+}
@@ -43,6 +43,12 @@ func (g *GossipMock) UpdateLedgerHeight(height uint64, chainID common.ChainID) {
 
 }
 
+// UpdateCommitHash updates the ledger height and the commit hash
+// of the last block the peer publishes to other peers in the channel
+func (g *GossipMock) UpdateCommitHash(height uint64, commitHash []byte, chainID common.ChainID) {
+
+}
+
 // UpdateChaincodes updates the chaincodes the peer publishes
 // to other peers in the channel
 func (g *GossipMock) UpdateChaincodes(chaincode []*proto.Chaincode, chainID common.ChainID) {
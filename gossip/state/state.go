@@ -13,7 +13,9 @@ import (
 	"time"
 
 	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
 	vsccErrors "github.com/hyperledger/fabric/common/errors"
+	ledgerutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
@@ -80,6 +82,10 @@ type GossipAdapter interface {
 	// publishes to other peers in the channel
 	UpdateLedgerHeight(height uint64, chainID common2.ChainID)
 
+	// UpdateCommitHash updates the ledger height and the commit hash
+	// of the last block the peer publishes to other peers in the channel
+	UpdateCommitHash(height uint64, commitHash []byte, chainID common2.ChainID)
+
 	// PeersOfChannel returns the NetworkMembers considered alive
 	// and also subscribed to the channel given
 	PeersOfChannel(common2.ChainID) []discovery.NetworkMember
@@ -165,6 +171,8 @@ type GossipStateProviderImpl struct {
 	config *Configuration
 
 	stateMetrics *metrics.StateMetrics
+
+	commitHashes *commitHashVerifier
 }
 
 var logger = util.GetLogger(util.StateLogger, "")
@@ -269,6 +277,8 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		config: config,
 
 		stateMetrics: stateMetrics,
+
+		commitHashes: newCommitHashVerifier(chainID, stateMetrics.CommitHashMismatches),
 	}
 
 	logger.Infof("Updating metadata information, "+
@@ -600,6 +610,11 @@ func (s *GossipStateProviderImpl) deliverPayloads() {
 						logger.Errorf("Failed executing VSCC due to %v. Aborting chain processing", executionErr)
 						return
 					}
+					if capabilities.IsUnsupported(err) {
+						logger.Errorf("[%s] Block [%d] requires capabilities this peer does not support: %s. Aborting chain processing, "+
+							"the peer must be upgraded and restarted to process the channel", s.chainID, payload.SeqNum, err)
+						return
+					}
 					logger.Panicf("Cannot commit block to the ledger due to %+v", errors.WithStack(err))
 				}
 			}
@@ -621,6 +636,7 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 			s.stopCh <- struct{}{}
 			return
 		case <-time.After(s.config.AntiEntropyInterval):
+			s.commitHashes.verify(s.mediator.PeersOfChannel(common2.ChainID(s.chainID)))
 			ourHeight, err := s.ledger.LedgerHeight()
 			if err != nil {
 				// Unable to read from ledger continue to the next round
@@ -815,8 +831,19 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 	sinceT1 := time.Since(t1)
 	s.stateMetrics.CommitDuration.With("channel", s.chainID).Observe(sinceT1.Seconds())
 
-	// Update ledger height
-	s.mediator.UpdateLedgerHeight(block.Header.Number+1, common2.ChainID(s.chainID))
+	// Update ledger height, along with the commit hash
+	// of the block if the committer recorded one
+	commitHash, err := ledgerutil.GetCommitHash(block)
+	if err != nil {
+		logger.Warningf("[%s] Failed reading the commit hash of block [%d]: %+v", s.chainID, block.Header.Number, err)
+	}
+	if len(commitHash) != 0 {
+		s.commitHashes.record(block.Header.Number, commitHash)
+		s.mediator.UpdateCommitHash(block.Header.Number+1, commitHash, common2.ChainID(s.chainID))
+		s.commitHashes.verify(s.mediator.PeersOfChannel(common2.ChainID(s.chainID)))
+	} else {
+		s.mediator.UpdateLedgerHeight(block.Header.Number+1, common2.ChainID(s.chainID))
+	}
 	logger.Debugf("[%s] Committed block [%d] with %d transaction(s)",
 		s.chainID, block.Header.Number, len(block.Data.Data))
 
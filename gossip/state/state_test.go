@@ -8,7 +8,6 @@ package state
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 	"math/rand"
 	"net"
@@ -19,6 +18,7 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/configtx/test"
 	errors2 "github.com/hyperledger/fabric/common/errors"
 	"github.com/hyperledger/fabric/common/flogging/floggingtest"
@@ -46,6 +46,7 @@ import (
 	proto "github.com/hyperledger/fabric/protos/gossip"
 	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	transientstore2 "github.com/hyperledger/fabric/protos/transientstore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -760,29 +761,47 @@ func TestHaltChainProcessing(t *testing.T) {
 		}
 	}
 
-	l, recorder := floggingtest.NewTestLogger(t)
-	logger = l
-
-	mc := &mockCommitter{Mock: &mock.Mock{}}
-	mc.On("CommitWithPvtData", mock.Anything)
-	mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
-	g := &mocks.GossipMock{}
-	gossipMsgs := make(chan *proto.GossipMessage)
-
-	g.On("Accept", mock.Anything, false).Return(gossipChannel(gossipMsgs), nil)
-	g.On("Accept", mock.Anything, true).Return(nil, make(chan proto.ReceivedMessage))
-	g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{})
-
-	v := &validator.MockValidator{}
-	v.On("Validate").Return(&errors2.VSCCExecutionFailureError{
-		Err: errors.New("foobar"),
-	}).Once()
-	peerNode := newPeerNodeWithGossipWithValidator(0, mc, noopPeerIdentityAcceptor, g, v)
-	defer peerNode.shutdown()
-	gossipMsgs <- newBlockMsg(1)
-	assertLogged(t, recorder, "Got error while committing")
-	assertLogged(t, recorder, "Aborting chain processing")
-	assertLogged(t, recorder, "foobar")
+	for _, test := range []struct {
+		name string
+		err  error
+		logs []string
+	}{
+		{
+			name: "VSCC execution failure",
+			err:  &errors2.VSCCExecutionFailureError{Err: errors.New("foobar")},
+			logs: []string{"Got error while committing", "Aborting chain processing", "foobar"},
+		},
+		{
+			name: "unsupported capabilities",
+			err: errors.WithMessage(&capabilities.UnsupportedError{Type: "Application", Capabilities: []string{"V9_9"}},
+				"error validating config which passed initial validity checks"),
+			logs: []string{"Block [1] requires capabilities this peer does not support", "Application capability V9_9 is required but not supported", "Aborting chain processing"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			l, recorder := floggingtest.NewTestLogger(t)
+			logger = l
+
+			mc := &mockCommitter{Mock: &mock.Mock{}}
+			mc.On("CommitWithPvtData", mock.Anything)
+			mc.On("LedgerHeight", mock.Anything).Return(uint64(1), nil)
+			g := &mocks.GossipMock{}
+			gossipMsgs := make(chan *proto.GossipMessage)
+
+			g.On("Accept", mock.Anything, false).Return(gossipChannel(gossipMsgs), nil)
+			g.On("Accept", mock.Anything, true).Return(nil, make(chan proto.ReceivedMessage))
+			g.On("PeersOfChannel", mock.Anything).Return([]discovery.NetworkMember{})
+
+			v := &validator.MockValidator{}
+			v.On("Validate").Return(test.err).Once()
+			peerNode := newPeerNodeWithGossipWithValidator(0, mc, noopPeerIdentityAcceptor, g, v)
+			defer peerNode.shutdown()
+			gossipMsgs <- newBlockMsg(1)
+			for _, log := range test.logs {
+				assertLogged(t, recorder, log)
+			}
+		})
+	}
 }
 
 func TestFailures(t *testing.T) {
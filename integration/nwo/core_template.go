@@ -61,7 +61,11 @@ peer:
     pvtData:
       pullRetryThreshold: 60s
       transientstoreMaxBlockRetention: 1000
+      transientstoreMaxBytesPerChaincode: 0
+      transientstoreMaxEntriesPerChaincode: 0
       pushAckTimeout: 3s
+      pushAckRetries: 2
+      pushAckRetryInterval: 500ms
       reconcileBatchSize: 10
       reconcileSleepInterval: 10s
       reconciliationEnabled: true
@@ -116,6 +120,11 @@ peer:
       vscc:
         name: DefaultValidation
   validatorPoolSize:
+  validation:
+    pluginTimeout: 0s
+    retry:
+      maxAttempts: 1
+      backoff: 500ms
   discovery:
     enabled: true
     authCacheEnabled: true
@@ -143,6 +152,22 @@ vm:
           max-size: "50m"
           max-file: "5"
       Memory: 2147483648
+    sandbox:
+      enabled: true
+      readOnlyRootfs: true
+      tmpfs:
+        /tmp: rw,noexec,nosuid,size=64m
+      capDrop:
+      - ALL
+      noNewPrivileges: true
+      platforms:
+        java:
+          tmpfs:
+            /tmp: rw,exec,nosuid,size=256m
+        node:
+          tmpfs:
+            /tmp: rw,noexec,nosuid,size=64m
+            /root/.npm: rw,noexec,nosuid,size=64m
 
 chaincode:
   builder: $(DOCKER_NS)/fabric-ccenv:$(ARCH)-$(PROJECT_VERSION)
@@ -158,6 +183,7 @@ chaincode:
       runtime: $(BASE_DOCKER_NS)/fabric-baseimage:$(ARCH)-$(BASE_VERSION)
   startuptimeout: 300s
   executetimeout: 30s
+  maxConcurrency: 0
   mode: net
   keepalive: 0
   system:
@@ -49,6 +49,7 @@ type Peer struct {
 	AdminService           *Service        `yaml:"adminService,omitempty"`
 	Handlers               *Handlers       `yaml:"handlers,omitempty"`
 	ValidatorPoolSize      int             `yaml:"validatorPoolSize,omitempty"`
+	Validation             *Validation     `yaml:"validation,omitempty"`
 	Discovery              *Discovery      `yaml:"discovery,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
@@ -104,9 +105,13 @@ type GossipElection struct {
 }
 
 type GossipPvtData struct {
-	PullRetryThreshold              time.Duration `yaml:"pullRetryThreshold,omitempty"`
-	TransientstoreMaxBlockRetention int           `yaml:"transientstoreMaxBlockRetention,omitempty"`
-	PushAckTimeout                  time.Duration `yaml:"pushAckTimeout,omitempty"`
+	PullRetryThreshold                   time.Duration `yaml:"pullRetryThreshold,omitempty"`
+	TransientstoreMaxBlockRetention      int           `yaml:"transientstoreMaxBlockRetention,omitempty"`
+	TransientstoreMaxBytesPerChaincode   int64         `yaml:"transientstoreMaxBytesPerChaincode,omitempty"`
+	TransientstoreMaxEntriesPerChaincode int           `yaml:"transientstoreMaxEntriesPerChaincode,omitempty"`
+	PushAckTimeout                       time.Duration `yaml:"pushAckTimeout,omitempty"`
+	PushAckRetries                       int           `yaml:"pushAckRetries,omitempty"`
+	PushAckRetryInterval                 time.Duration `yaml:"pushAckRetryInterval,omitempty"`
 }
 
 type Events struct {
@@ -161,10 +166,11 @@ type Service struct {
 }
 
 type Handlers struct {
-	AuthFilters []Handler  `yaml:"authFilters,omitempty"`
-	Decorators  []Handler  `yaml:"decorators,omitempty"`
-	Endorsers   HandlerMap `yaml:"endorsers,omitempty"`
-	Validators  HandlerMap `yaml:"validators,omitempty"`
+	AuthFilters  []Handler  `yaml:"authFilters,omitempty"`
+	Decorators   []Handler  `yaml:"decorators,omitempty"`
+	Endorsers    HandlerMap `yaml:"endorsers,omitempty"`
+	Validators   HandlerMap `yaml:"validators,omitempty"`
+	Interceptors []Handler  `yaml:"interceptors,omitempty"`
 }
 
 type Handler struct {
@@ -174,6 +180,16 @@ type Handler struct {
 
 type HandlerMap map[string]Handler
 
+type Validation struct {
+	PluginTimeout time.Duration    `yaml:"pluginTimeout,omitempty"`
+	Retry         *ValidationRetry `yaml:"retry,omitempty"`
+}
+
+type ValidationRetry struct {
+	MaxAttempts int           `yaml:"maxAttempts,omitempty"`
+	Backoff     time.Duration `yaml:"backoff,omitempty"`
+}
+
 type Discovery struct {
 	Enabled                      bool    `yaml:"enabled"`
 	AuthCacheEnabled             bool    `yaml:"authCacheEnabled"`
@@ -191,6 +207,26 @@ type Docker struct {
 	TLS          *TLS               `yaml:"tls,omitempty"`
 	AttachStdout bool               `yaml:"attachStdout"`
 	HostConfig   *docker.HostConfig `yaml:"hostConfig,omitempty"`
+	Sandbox      *Sandbox           `yaml:"sandbox,omitempty"`
+}
+
+type Sandbox struct {
+	Enabled         bool                        `yaml:"enabled"`
+	ReadOnlyRootfs  bool                        `yaml:"readOnlyRootfs"`
+	Tmpfs           map[string]string           `yaml:"tmpfs,omitempty"`
+	CapDrop         []string                    `yaml:"capDrop,omitempty"`
+	NoNewPrivileges bool                        `yaml:"noNewPrivileges"`
+	SeccompProfile  string                      `yaml:"seccompProfile,omitempty"`
+	Platforms       map[string]*SandboxPlatform `yaml:"platforms,omitempty"`
+}
+
+type SandboxPlatform struct {
+	Enabled         *bool             `yaml:"enabled,omitempty"`
+	ReadOnlyRootfs  *bool             `yaml:"readOnlyRootfs,omitempty"`
+	Tmpfs           map[string]string `yaml:"tmpfs,omitempty"`
+	CapDrop         []string          `yaml:"capDrop,omitempty"`
+	NoNewPrivileges *bool             `yaml:"noNewPrivileges,omitempty"`
+	SeccompProfile  string            `yaml:"seccompProfile,omitempty"`
 }
 
 type Chaincode struct {
@@ -202,6 +238,7 @@ type Chaincode struct {
 	Node           *Node         `yaml:"node,omitempty"`
 	StartupTimeout time.Duration `yaml:"startupTimeout,omitempty"`
 	ExecuteTimeout time.Duration `yaml:"executeTimeout,omitempty"`
+	MaxConcurrency int           `yaml:"maxConcurrency,omitempty"`
 	Mode           string        `yaml:"mode,omitempty"`
 	Keepalive      int           `yaml:"keepalive,omitempty"`
 	System         SystemFlags   `yaml:"system,omitempty"`
@@ -211,8 +248,9 @@ type Chaincode struct {
 }
 
 type Golang struct {
-	Runtime     string `yaml:"runtime,omitempty"`
-	DynamicLink bool   `yaml:"dynamicLink"`
+	Runtime          string `yaml:"runtime,omitempty"`
+	DynamicLink      bool   `yaml:"dynamicLink"`
+	DeterminismCheck string `yaml:"determinismCheck,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
@@ -32,10 +32,17 @@ type General struct {
 	Profile        *OrdererProfile        `yaml:"Profile,omitempty"`
 	BCCSP          *BCCSP                 `yaml:"BCCSP,omitempty"`
 	Authentication *OrdererAuthentication `yaml:"Authentication,omitempty"`
+	Broadcast      *OrdererBroadcast      `yaml:"Broadcast,omitempty"`
+	Interceptors   []OrdererInterceptor   `yaml:"Interceptors,omitempty"`
 
 	ExtraProperties map[string]interface{} `yaml:",inline,omitempty"`
 }
 
+type OrdererInterceptor struct {
+	Name    string `yaml:"Name,omitempty"`
+	Library string `yaml:"Library,omitempty"`
+}
+
 type OrdererTLS struct {
 	Enabled            bool     `yaml:"Enabled"`
 	PrivateKey         string   `yaml:"PrivateKey,omitempty"`
@@ -66,6 +73,19 @@ type OrdererAuthentication struct {
 	TimeWindow time.Duration `yaml:"TimeWindow,omitempty"`
 }
 
+type OrdererBroadcast struct {
+	ValidateStructure bool                  `yaml:"ValidateStructure"`
+	MaxMessageBytes   uint32                `yaml:"MaxMessageBytes,omitempty"`
+	MaxClockSkew      time.Duration         `yaml:"MaxClockSkew,omitempty"`
+	ConfigUpdates     *OrdererConfigUpdates `yaml:"ConfigUpdates,omitempty"`
+}
+
+type OrdererConfigUpdates struct {
+	MaxInflight   int           `yaml:"MaxInflight,omitempty"`
+	MaxQueued     int           `yaml:"MaxQueued,omitempty"`
+	CommitTimeout time.Duration `yaml:"CommitTimeout,omitempty"`
+}
+
 type OrdererTopic struct {
 	ReplicationFactor int16
 }
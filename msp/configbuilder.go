@@ -179,15 +179,19 @@ func GetLocalMspConfig(dir string, bccspConfig *factory.FactoryOpts, ID string)
 		return nil, errors.Wrapf(err, "could not load a valid signer certificate from directory %s", signcertDir)
 	}
 
-	/* FIXME: for now we're making the following assumptions
-	1) there is exactly one signing cert
-	2) BCCSP's KeyStore has the private key that matches SKI of
-	   signing cert
+	/* FIXME: for now we're making the following assumption:
+	BCCSP's KeyStore has the private keys that match the SKIs of
+	the signing certs. When there are several signing certs, such as
+	the current and the next one of a certificate rotation, the MSP
+	signs with the valid one issued last
 	*/
 
-	sigid := &msp.SigningIdentityInfo{PublicSigner: signcert[0], PrivateSigner: nil}
+	var sigids []*msp.SigningIdentityInfo
+	for _, cert := range signcert {
+		sigids = append(sigids, &msp.SigningIdentityInfo{PublicSigner: cert, PrivateSigner: nil})
+	}
 
-	return getMspConfig(dir, ID, sigid)
+	return getMspConfig(dir, ID, sigids)
 }
 
 // GetVerifyingMspConfig returns an MSP config given directory, ID and type
@@ -202,7 +206,7 @@ func GetVerifyingMspConfig(dir, ID, mspType string) (*msp.MSPConfig, error) {
 	}
 }
 
-func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.MSPConfig, error) {
+func getMspConfig(dir string, ID string, sigids []*msp.SigningIdentityInfo) (*msp.MSPConfig, error) {
 	cacertDir := filepath.Join(dir, cacerts)
 	admincertDir := filepath.Join(dir, admincerts)
 	intermediatecertsDir := filepath.Join(dir, intermediatecerts)
@@ -335,6 +339,11 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		IdentityIdentifierHashFunction: bccsp.SHA256,
 	}
 
+	var sigid *msp.SigningIdentityInfo
+	if len(sigids) > 0 {
+		sigid = sigids[0]
+	}
+
 	// Compose FabricMSPConfig
 	fmspconf := &msp.FabricMSPConfig{
 		Admins:                        admincert,
@@ -349,6 +358,9 @@ func getMspConfig(dir string, ID string, sigid *msp.SigningIdentityInfo) (*msp.M
 		TlsIntermediateCerts:          tlsIntermediateCerts,
 		FabricNodeOus:                 nodeOUs,
 	}
+	if len(sigids) > 1 {
+		fmspconf.AdditionalSigningIdentities = sigids[1:]
+	}
 
 	fmpsjs, _ := proto.Marshal(fmspconf)
 
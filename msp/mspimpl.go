@@ -13,6 +13,7 @@ import (
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
@@ -65,9 +66,14 @@ type bccspmsp struct {
 	// False means that the certificate corresponds to a leaf of the certification tree.
 	certificationTreeInternalNodesMap map[string]bool
 
-	// list of signing identities
+	// the default signing identity
 	signer SigningIdentity
 
+	// list of signing identities, the default one first. The MSP signs with the
+	// valid one issued last, so that a certificate can be rotated by adding the
+	// next certificate before it becomes valid
+	signers []SigningIdentity
+
 	// list of admin identities
 	admins []Identity
 
@@ -258,14 +264,41 @@ func (msp *bccspmsp) GetDefaultSigningIdentity() (SigningIdentity, error) {
 	if msp.signer == nil {
 		return nil, errors.New("this MSP does not possess a valid default signing identity")
 	}
+	if len(msp.signers) > 1 {
+		return currentSigningIdentity(msp.signers, time.Now()), nil
+	}
 
 	return msp.signer, nil
 }
 
+// currentSigningIdentity returns the signing identity valid at the given time
+// which was issued last, or the first one if none is valid
+func currentSigningIdentity(signers []SigningIdentity, now time.Time) SigningIdentity {
+	var current SigningIdentity
+	var issuedAt time.Time
+	for _, sid := range signers {
+		cert := sid.(*signingidentity).cert
+		if now.Before(cert.NotBefore) || !now.Before(cert.NotAfter) {
+			continue
+		}
+		if current == nil || cert.NotBefore.After(issuedAt) {
+			current, issuedAt = sid, cert.NotBefore
+		}
+	}
+	if current == nil {
+		return signers[0]
+	}
+	return current
+}
+
 // GetSigningIdentity returns a specific signing
 // identity identified by the supplied identifier
 func (msp *bccspmsp) GetSigningIdentity(identifier *IdentityIdentifier) (SigningIdentity, error) {
-	// TODO
+	for _, sid := range msp.signers {
+		if id := sid.GetIdentifier(); identifier != nil && *id == *identifier {
+			return sid, nil
+		}
+	}
 	return nil, errors.Errorf("no signing identity for %#v", identifier)
 }
 
@@ -263,8 +263,14 @@ func (msp *bccspmsp) setupNodeOUs(config *m.FabricMSPConfig) error {
 }
 
 func (msp *bccspmsp) setupSigningIdentity(conf *m.FabricMSPConfig) error {
-	if conf.SigningIdentity != nil {
-		sid, err := msp.getSigningIdentityFromConf(conf.SigningIdentity)
+	if conf.SigningIdentity == nil {
+		return nil
+	}
+
+	sidInfos := append([]*m.SigningIdentityInfo{conf.SigningIdentity}, conf.AdditionalSigningIdentities...)
+	var expiredErr error
+	for _, sidInfo := range sidInfos {
+		sid, err := msp.getSigningIdentityFromConf(sidInfo)
 		if err != nil {
 			return err
 		}
@@ -276,12 +282,21 @@ func (msp *bccspmsp) setupSigningIdentity(conf *m.FabricMSPConfig) error {
 		} else if expirationTime.IsZero() {
 			mspLogger.Debug("Signing identity has no known expiration time")
 		} else {
-			return errors.Errorf("signing identity expired %v ago", now.Sub(expirationTime))
+			// an expired signing identity is left out as long as another one remains
+			expiredErr = errors.Errorf("signing identity expired %v ago", now.Sub(expirationTime))
+			continue
 		}
 
-		msp.signer = sid
+		msp.signers = append(msp.signers, sid)
+	}
+	if len(msp.signers) == 0 {
+		return expiredErr
+	}
+	if expiredErr != nil {
+		mspLogger.Warningf("Ignoring an expired signing identity: %s", expiredErr)
 	}
 
+	msp.signer = msp.signers[0]
 	return nil
 }
 
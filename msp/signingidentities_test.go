@@ -0,0 +1,133 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca.org1.example.com"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// signingIdentityInfo issues a certificate valid in the given window, and
+// returns it with its private key
+func (ca *testCA) signingIdentityInfo(t *testing.T, serial int64, notBefore, notAfter time.Time) *msp.SigningIdentityInfo {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(serial),
+		Subject:        pkix.Name{CommonName: "peer0.org1.example.com"},
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		AuthorityKeyId: ca.cert.SubjectKeyId,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &msp.SigningIdentityInfo{
+		PublicSigner: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		PrivateSigner: &msp.KeyInfo{
+			KeyIdentifier: "key",
+			KeyMaterial:   pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+func setupRotationMSP(t *testing.T, ca *testCA, sigid *msp.SigningIdentityInfo, additional ...*msp.SigningIdentityInfo) (MSP, error) {
+	conf := &msp.FabricMSPConfig{
+		Name:                        "Org1MSP",
+		RootCerts:                   [][]byte{ca.pem},
+		SigningIdentity:             sigid,
+		AdditionalSigningIdentities: additional,
+		CryptoConfig: &msp.FabricCryptoConfig{
+			SignatureHashFamily:            bccsp.SHA2,
+			IdentityIdentifierHashFunction: bccsp.SHA256,
+		},
+	}
+	raw, err := proto.Marshal(conf)
+	require.NoError(t, err)
+	newmsp, err := newBccspMsp(MSPv1_3)
+	require.NoError(t, err)
+	return newmsp, newmsp.Setup(&msp.MSPConfig{Config: raw, Type: int32(FABRIC)})
+}
+
+func TestSigningIdentityRotation(t *testing.T) {
+	ca := newTestCA(t)
+	now := time.Now()
+	current := ca.signingIdentityInfo(t, 2, now.Add(-time.Hour), now.Add(time.Hour))
+	next := ca.signingIdentityInfo(t, 3, now.Add(30*time.Minute), now.Add(24*time.Hour))
+	expired := ca.signingIdentityInfo(t, 4, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	mspInst, err := setupRotationMSP(t, ca, expired, current, next)
+	require.NoError(t, err)
+	bmsp := mspInst.(*bccspmsp)
+	require.Len(t, bmsp.signers, 2)
+	currentID, nextID := bmsp.signers[0], bmsp.signers[1]
+
+	// the next identity is not valid yet
+	sid, err := mspInst.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.Equal(t, currentID, sid)
+
+	// both identities are valid, the next one was issued last
+	assert.Equal(t, nextID, currentSigningIdentity(bmsp.signers, now.Add(45*time.Minute)))
+	// the current identity expired
+	assert.Equal(t, nextID, currentSigningIdentity(bmsp.signers, now.Add(2*time.Hour)))
+	// no identity is valid
+	assert.Equal(t, currentID, currentSigningIdentity(bmsp.signers, now.Add(48*time.Hour)))
+
+	// the identities can be retrieved by identifier
+	sid, err = mspInst.GetSigningIdentity(nextID.GetIdentifier())
+	assert.NoError(t, err)
+	assert.Equal(t, nextID, sid)
+	_, err = mspInst.GetSigningIdentity(&IdentityIdentifier{Mspid: "Org1MSP", Id: "unknown"})
+	assert.Error(t, err)
+
+	// an MSP whose identities all expired cannot be set up
+	_, err = setupRotationMSP(t, ca, expired)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "signing identity expired")
+}
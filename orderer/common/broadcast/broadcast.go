@@ -60,6 +60,11 @@ type Consenter interface {
 type Handler struct {
 	SupportRegistrar ChannelSupportRegistrar
 	Metrics          *Metrics
+	// Validator checks the structure of the normal messages before they are
+	// processed by the channel, if set
+	Validator *StructureValidator
+	// ConfigUpdates serializes the config updates of each channel, if set
+	ConfigUpdates *ConfigUpdateQueue
 }
 
 // Handle reads requests from a Broadcast stream, processes them, and returns the responses to the stream
@@ -160,6 +165,13 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	if !isConfig {
 		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
+		if bh.Validator != nil {
+			if err := bh.Validator.Validate(msg, chdr); err != nil {
+				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with txid '%s': %s", chdr.ChannelId, addr, chdr.TxId, err)
+				return &ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: err.Error()}
+			}
+		}
+
 		configSeq, err := processor.ProcessNormalMsg(msg)
 		if err != nil {
 			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
@@ -181,8 +193,19 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 	} else { // isConfig
 		logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
 
+		var turn *configUpdateTurn
+		if bh.ConfigUpdates != nil {
+			var status cb.Status
+			turn, status, err = bh.ConfigUpdates.wait(msg, chdr, addr)
+			if err != nil {
+				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with %s: %s", chdr.ChannelId, addr, status, err)
+				return &ab.BroadcastResponse{Status: status, Info: err.Error()}
+			}
+		}
+
 		config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
 		if err != nil {
+			err = turn.rejected(err)
 			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
@@ -190,15 +213,18 @@ func (bh *Handler) ProcessMessage(msg *cb.Envelope, addr string) (resp *ab.Broad
 
 		tracker.BeginEnqueue()
 		if err = processor.WaitReady(); err != nil {
+			turn.failed()
 			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 
 		err = processor.Configure(config, configSeq)
 		if err != nil {
+			turn.failed()
 			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
 			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
+		turn.ordered(processor, configSeq)
 	}
 
 	logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
@@ -225,6 +225,25 @@ var _ = Describe("Broadcast", func() {
 			})
 		})
 
+		Context("when the structure of the message is validated", func() {
+			BeforeEach(func() {
+				handler.Validator = &broadcast.StructureValidator{}
+			})
+
+			It("rejects the malformed message before processing it", func() {
+				err := handler.Handle(fakeABServer)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSupport.ProcessNormalMsgCallCount()).To(Equal(0))
+				Expect(fakeSupport.OrderCallCount()).To(Equal(0))
+				Expect(fakeABServer.SendCallCount()).To(Equal(1))
+				Expect(proto.Equal(
+					fakeABServer.SendArgsForCall(0),
+					&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "malformed message: the envelope is not signed"},
+				)).To(BeTrue())
+			})
+		})
+
 		Context("when the message processor returns an error", func() {
 			BeforeEach(func() {
 				fakeSupport.ProcessNormalMsgReturns(0, fmt.Errorf("normal-messsage-processing-error"))
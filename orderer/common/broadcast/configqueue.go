@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// ConfigUpdateQueue serializes the config updates of each channel. At most
+// MaxInflight config updates of a channel are validated and ordered at a time,
+// and an ordered config update keeps its turn until its config is committed,
+// so that the next config updates are validated against the config resulting
+// from it. A config update which depends on the config elements modified by a
+// config update in flight is rejected with the reason of the conflict, instead
+// of being ordered and dropped by the consenter when revalidated
+type ConfigUpdateQueue struct {
+	// MaxInflight is the maximum number of config updates of a channel which
+	// are validated and ordered at the same time
+	MaxInflight int
+	// MaxQueued is the maximum number of config updates of a channel waiting
+	// for their turn, the next ones are rejected. Zero means no limit
+	MaxQueued int
+	// CommitTimeout is how long an ordered config update keeps its turn while
+	// waiting for its config to be committed, zero releases the turn once the
+	// config update is ordered
+	CommitTimeout time.Duration
+
+	mutex  sync.Mutex
+	queues map[string]*channelConfigQueue
+}
+
+// channelConfigQueue holds the turns of the config updates of a channel
+type channelConfigQueue struct {
+	turns    chan struct{}
+	queued   int
+	inflight map[*configUpdateTurn]struct{}
+	// committed counts the config updates committed since the queue was
+	// created, and lastCommitted names the last one
+	committed     uint64
+	lastCommitted string
+}
+
+// configUpdateTurn is the turn of a config update to be validated and ordered
+type configUpdateTurn struct {
+	queue     *ConfigUpdateQueue
+	cq        *channelConfigQueue
+	channelID string
+	name      string
+	deps      []string
+	modified  []string
+	// committedBefore is the number of config updates committed when the
+	// config update entered the queue
+	committedBefore uint64
+}
+
+// configSequencer is implemented by the supports of the channels which expose
+// the sequence of their config
+type configSequencer interface {
+	ChainID() string
+	Sequence() uint64
+}
+
+// configCommitPollInterval is the interval at which the sequence of the config
+// of a channel is checked while waiting for the commit of a config update
+var configCommitPollInterval = 10 * time.Millisecond
+
+// wait waits for the turn of the config update of the given channel header. On
+// failure, it returns the status of the rejection of the config update
+func (q *ConfigUpdateQueue) wait(msg *cb.Envelope, chdr *cb.ChannelHeader, addr string) (*configUpdateTurn, cb.Status, error) {
+	deps, modified, err := configUpdateKeys(msg)
+	if err != nil {
+		return nil, cb.Status_BAD_REQUEST, errors.WithMessage(err, "malformed config update")
+	}
+	turn := &configUpdateTurn{
+		queue:     q,
+		channelID: chdr.ChannelId,
+		name:      fmt.Sprintf("from %s", addr),
+		deps:      deps,
+		modified:  modified,
+	}
+	if chdr.TxId != "" {
+		turn.name = chdr.TxId
+	}
+
+	q.mutex.Lock()
+	if q.queues == nil {
+		q.queues = map[string]*channelConfigQueue{}
+	}
+	cq, ok := q.queues[chdr.ChannelId]
+	if !ok {
+		maxInflight := q.MaxInflight
+		if maxInflight < 1 {
+			maxInflight = 1
+		}
+		cq = &channelConfigQueue{
+			turns:    make(chan struct{}, maxInflight),
+			inflight: map[*configUpdateTurn]struct{}{},
+		}
+		q.queues[chdr.ChannelId] = cq
+	}
+	if q.MaxQueued > 0 && cq.queued >= q.MaxQueued {
+		q.mutex.Unlock()
+		return nil, cb.Status_SERVICE_UNAVAILABLE, errors.Errorf("%d config updates of channel %s are already queued, submit the config update again later", cq.queued, chdr.ChannelId)
+	}
+	cq.queued++
+	turn.cq = cq
+	turn.committedBefore = cq.committed
+	q.mutex.Unlock()
+
+	cq.turns <- struct{}{}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	cq.queued--
+	for other := range cq.inflight {
+		if conflicts := intersect(other.modified, turn.deps); len(conflicts) != 0 {
+			q.releaseLocked(turn, false)
+			return nil, cb.Status_BAD_REQUEST, errors.Errorf("config update %s conflicts with config update %s of channel %s which is being ordered, "+
+				"it depends on the config elements modified by that config update: %s; compute the config update again once that config update is committed",
+				turn.name, other.name, chdr.ChannelId, strings.Join(conflicts, ", "))
+		}
+	}
+	cq.inflight[turn] = struct{}{}
+	return turn, cb.Status_SUCCESS, nil
+}
+
+// rejected releases the turn of a config update which failed validation, and
+// explains the failure when the config of the channel was updated while the
+// config update was queued
+func (t *configUpdateTurn) rejected(err error) error {
+	if t == nil {
+		return err
+	}
+	t.queue.mutex.Lock()
+	defer t.queue.mutex.Unlock()
+	if t.cq.committed != t.committedBefore {
+		err = errors.WithMessage(err, fmt.Sprintf("the config of channel %s was updated by config update %s while config update %s was queued", t.channelID, t.cq.lastCommitted, t.name))
+	}
+	t.queue.releaseLocked(t, true)
+	return err
+}
+
+// failed releases the turn of a config update which could not be ordered
+func (t *configUpdateTurn) failed() {
+	if t == nil {
+		return
+	}
+	t.queue.mutex.Lock()
+	defer t.queue.mutex.Unlock()
+	t.queue.releaseLocked(t, true)
+}
+
+// ordered releases the turn of an ordered config update once its config is
+// committed by the channel, or after the commit timeout
+func (t *configUpdateTurn) ordered(support interface{}, configSeq uint64) {
+	if t == nil {
+		return
+	}
+	sequencer, ok := support.(configSequencer)
+	if !ok || sequencer.ChainID() != t.channelID {
+		// the channel creations are committed by the system channel
+		t.failed()
+		return
+	}
+	go func() {
+		deadline := time.Now().Add(t.queue.CommitTimeout)
+		for sequencer.Sequence() <= configSeq {
+			if time.Now().After(deadline) {
+				logger.Warningf("[channel: %s] Config update %s was not committed within %s, releasing its turn", t.channelID, t.name, t.queue.CommitTimeout)
+				t.failed()
+				return
+			}
+			time.Sleep(configCommitPollInterval)
+		}
+		t.queue.mutex.Lock()
+		defer t.queue.mutex.Unlock()
+		t.cq.committed++
+		t.cq.lastCommitted = t.name
+		t.queue.releaseLocked(t, true)
+	}()
+}
+
+// releaseLocked gives the turn of the config update to the next one, and
+// removes the queue of the channel once it is empty
+func (q *ConfigUpdateQueue) releaseLocked(t *configUpdateTurn, inflight bool) {
+	if inflight {
+		delete(t.cq.inflight, t)
+	}
+	<-t.cq.turns
+	if t.cq.queued == 0 && len(t.cq.inflight) == 0 && q.queues[t.channelID] == t.cq {
+		delete(q.queues, t.channelID)
+	}
+}
+
+// configUpdateKeys returns the keys of the config elements which the config
+// update of the envelope depends on and modifies
+func configUpdateKeys(msg *cb.Envelope) (deps, modified []string, err error) {
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return configtx.UpdateKeys(configUpdate, channelconfig.ChannelGroupKey)
+}
+
+// intersect returns the keys of the sorted slices a and b found in both
+func intersect(a, b []string) []string {
+	var result []string
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
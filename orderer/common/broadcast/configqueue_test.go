@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/broadcast/mock"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+// sequencedSupport is a channel support exposing the sequence of its config
+type sequencedSupport struct {
+	*mock.ChannelSupport
+	sequence uint64
+}
+
+func (s *sequencedSupport) ChainID() string {
+	return "fake-channel"
+}
+
+func (s *sequencedSupport) Sequence() uint64 {
+	return atomic.LoadUint64(&s.sequence)
+}
+
+func (s *sequencedSupport) commit() {
+	atomic.AddUint64(&s.sequence, 1)
+}
+
+// configUpdate returns a config update envelope modifying the given orgs of
+// the application group
+func configUpdate(txID string, orgs ...string) *cb.Envelope {
+	readSet := &cb.ConfigGroup{Groups: map[string]*cb.ConfigGroup{"Application": {Groups: map[string]*cb.ConfigGroup{}}}}
+	writeSet := &cb.ConfigGroup{Groups: map[string]*cb.ConfigGroup{"Application": {Groups: map[string]*cb.ConfigGroup{}}}}
+	for _, org := range orgs {
+		readSet.Groups["Application"].Groups[org] = &cb.ConfigGroup{}
+		writeSet.Groups["Application"].Groups[org] = &cb.ConfigGroup{
+			Values: map[string]*cb.ConfigValue{"AnchorPeers": {Version: 1}},
+		}
+	}
+	return &cb.Envelope{
+		Payload: utils.MarshalOrPanic(&cb.Payload{
+			Header: &cb.Header{
+				ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{
+					Type:      int32(cb.HeaderType_CONFIG_UPDATE),
+					ChannelId: "fake-channel",
+					TxId:      txID,
+				}),
+			},
+			Data: utils.MarshalOrPanic(&cb.ConfigUpdateEnvelope{
+				ConfigUpdate: utils.MarshalOrPanic(&cb.ConfigUpdate{
+					ChannelId: "fake-channel",
+					ReadSet:   readSet,
+					WriteSet:  writeSet,
+				}),
+			}),
+		}),
+	}
+}
+
+var _ = Describe("ConfigUpdateQueue", func() {
+	var (
+		fakeSupportRegistrar *mock.ChannelSupportRegistrar
+		support              *sequencedSupport
+		handler              *broadcast.Handler
+	)
+
+	BeforeEach(func() {
+		support = &sequencedSupport{ChannelSupport: &mock.ChannelSupport{}}
+		support.ProcessConfigUpdateMsgStub = func(*cb.Envelope) (*cb.Envelope, uint64, error) {
+			return &cb.Envelope{}, support.Sequence(), nil
+		}
+
+		fakeSupportRegistrar = &mock.ChannelSupportRegistrar{}
+		fakeSupportRegistrar.BroadcastChannelSupportStub = func(msg *cb.Envelope) (*cb.ChannelHeader, bool, broadcast.ChannelSupport, error) {
+			chdr, err := utils.ChannelHeader(msg)
+			Expect(err).NotTo(HaveOccurred())
+			return chdr, true, support, nil
+		}
+
+		fakeHistogram := &mock.MetricsHistogram{}
+		fakeHistogram.WithReturns(fakeHistogram)
+		fakeCounter := &mock.MetricsCounter{}
+		fakeCounter.WithReturns(fakeCounter)
+
+		handler = &broadcast.Handler{
+			SupportRegistrar: fakeSupportRegistrar,
+			Metrics: &broadcast.Metrics{
+				ValidateDuration: fakeHistogram,
+				EnqueueDuration:  fakeHistogram,
+				ProcessedCount:   fakeCounter,
+			},
+			ConfigUpdates: &broadcast.ConfigUpdateQueue{
+				MaxInflight:   1,
+				CommitTimeout: time.Minute,
+			},
+		}
+	})
+
+	// process broadcasts the message in the background
+	process := func(msg *cb.Envelope) <-chan *ab.BroadcastResponse {
+		resp := make(chan *ab.BroadcastResponse, 1)
+		go func() {
+			resp <- handler.ProcessMessage(msg, "client")
+		}()
+		return resp
+	}
+
+	It("validates a config update once the config update before it is committed", func() {
+		Eventually(process(configUpdate("tx1", "Org1MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})))
+
+		resp := process(configUpdate("tx2", "Org2MSP"))
+		Consistently(resp, 100*time.Millisecond).ShouldNot(Receive())
+		Expect(support.ProcessConfigUpdateMsgCallCount()).To(Equal(1))
+
+		support.commit()
+		Eventually(resp).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})))
+		Expect(support.ConfigureCallCount()).To(Equal(2))
+		_, seq := support.ConfigureArgsForCall(1)
+		Expect(seq).To(Equal(uint64(1)))
+	})
+
+	It("explains the rejection of a config update invalidated by the config update before it", func() {
+		Eventually(process(configUpdate("tx1", "Org1MSP"))).Should(Receive())
+
+		support.ProcessConfigUpdateMsgReturnsOnCall(1, nil, 0, errors.New("proposed update requires that key [Group]  /Channel/Application/Org1MSP be at version 0, but it is currently at version 1"))
+		resp := process(configUpdate("tx2", "Org1MSP"))
+		Consistently(resp, 100*time.Millisecond).ShouldNot(Receive())
+		support.commit()
+
+		var r *ab.BroadcastResponse
+		Eventually(resp).Should(Receive(&r))
+		Expect(r.Status).To(Equal(cb.Status_BAD_REQUEST))
+		Expect(r.Info).To(Equal("the config of channel fake-channel was updated by config update tx1 while config update tx2 was queued: " +
+			"proposed update requires that key [Group]  /Channel/Application/Org1MSP be at version 0, but it is currently at version 1"))
+	})
+
+	It("gives the turn to the next config update when the config is not committed in time", func() {
+		handler.ConfigUpdates.CommitTimeout = 50 * time.Millisecond
+
+		Eventually(process(configUpdate("tx1", "Org1MSP"))).Should(Receive())
+		Eventually(process(configUpdate("tx2", "Org2MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})))
+	})
+
+	It("releases the turn of the config updates which fail", func() {
+		support.ProcessConfigUpdateMsgReturnsOnCall(0, nil, 0, errors.New("boom"))
+		Eventually(process(configUpdate("tx1", "Org1MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_BAD_REQUEST, Info: "boom"})))
+
+		support.ConfigureReturnsOnCall(0, errors.New("crash"))
+		Eventually(process(configUpdate("tx2", "Org1MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: "crash"})))
+
+		Eventually(process(configUpdate("tx3", "Org1MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})))
+	})
+
+	It("rejects the malformed config updates", func() {
+		resp := handler.ProcessMessage(&cb.Envelope{
+			Payload: utils.MarshalOrPanic(&cb.Payload{
+				Header: &cb.Header{ChannelHeader: utils.MarshalOrPanic(&cb.ChannelHeader{ChannelId: "fake-channel"})},
+				Data:   []byte("garbage"),
+			}),
+		}, "client")
+		Expect(resp.Status).To(Equal(cb.Status_BAD_REQUEST))
+		Expect(resp.Info).To(HavePrefix("malformed config update: "))
+		Expect(support.ProcessConfigUpdateMsgCallCount()).To(Equal(0))
+	})
+
+	Context("when the queue of the channel is full", func() {
+		BeforeEach(func() {
+			handler.ConfigUpdates.MaxQueued = 1
+		})
+
+		It("rejects the config update with a service unavailable status", func() {
+			Eventually(process(configUpdate("tx1", "Org1MSP"))).Should(Receive())
+			queued := process(configUpdate("tx2", "Org2MSP"))
+			Consistently(queued, 100*time.Millisecond).ShouldNot(Receive())
+
+			Eventually(process(configUpdate("tx3", "Org3MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{
+				Status: cb.Status_SERVICE_UNAVAILABLE,
+				Info:   "1 config updates of channel fake-channel are already queued, submit the config update again later",
+			})))
+
+			support.commit()
+			Eventually(queued).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})))
+		})
+	})
+
+	Context("when several config updates are in flight", func() {
+		BeforeEach(func() {
+			handler.ConfigUpdates.MaxInflight = 2
+		})
+
+		It("rejects the config updates which conflict with the ones in flight", func() {
+			Eventually(process(configUpdate("tx1", "Org1MSP"))).Should(Receive())
+			Eventually(process(configUpdate("tx2", "Org2MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})))
+
+			support.commit()
+			support.commit()
+			Eventually(process(configUpdate("tx3", "Org3MSP"))).Should(Receive())
+			Eventually(process(configUpdate("tx4", "Org3MSP", "Org4MSP"))).Should(Receive(Equal(&ab.BroadcastResponse{
+				Status: cb.Status_BAD_REQUEST,
+				Info: "config update tx4 conflicts with config update tx3 of channel fake-channel which is being ordered, " +
+					"it depends on the config elements modified by that config update: [Value]  /Channel/Application/Org3MSP/AnchorPeers; " +
+					"compute the config update again once that config update is committed",
+			})))
+			Expect(support.ProcessConfigUpdateMsgCallCount()).To(Equal(3))
+		})
+	})
+})
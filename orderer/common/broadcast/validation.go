@@ -0,0 +1,182 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// StructureValidator checks the structure of the normal messages before they are
+// handed to the channel, so that the messages which the peers would invalidate
+// at commit because they are malformed are rejected synchronously instead
+type StructureValidator struct {
+	// MaxMessageBytes is the maximum size of a message, zero means that only the
+	// limit of the channel configuration applies
+	MaxMessageBytes uint32
+	// MaxClockSkew is the maximum difference between the timestamp of a message,
+	// or the scheduled time of a scheduled transaction, and the time of the
+	// orderer, zero means that the timestamp is not checked
+	MaxClockSkew time.Duration
+
+	mutex sync.RWMutex
+}
+
+// SetLimits changes the maximum size of the messages and the maximum clock skew
+// of their timestamps, while the messages are being validated
+func (sv *StructureValidator) SetLimits(maxMessageBytes uint32, maxClockSkew time.Duration) {
+	sv.mutex.Lock()
+	defer sv.mutex.Unlock()
+	sv.MaxMessageBytes = maxMessageBytes
+	sv.MaxClockSkew = maxClockSkew
+}
+
+func (sv *StructureValidator) limits() (uint32, time.Duration) {
+	sv.mutex.RLock()
+	defer sv.mutex.RUnlock()
+	return sv.MaxMessageBytes, sv.MaxClockSkew
+}
+
+// Validate returns an error describing the first structural problem of the
+// message, or nil if the message is well formed
+func (sv *StructureValidator) Validate(msg *cb.Envelope, chdr *cb.ChannelHeader) error {
+	maxMessageBytes, maxClockSkew := sv.limits()
+	if maxMessageBytes != 0 {
+		if size := proto.Size(msg); size > int(maxMessageBytes) {
+			return errors.Errorf("message of %d bytes exceeds the maximum size of %d bytes", size, maxMessageBytes)
+		}
+	}
+	if len(msg.Signature) == 0 {
+		return errors.New("malformed message: the envelope is not signed")
+	}
+
+	payload, err := utils.UnmarshalPayload(msg.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "malformed message")
+	}
+	if payload.Header == nil {
+		return errors.New("malformed message: the payload has no header")
+	}
+	shdr, err := utils.GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return errors.WithMessage(err, "malformed message")
+	}
+	if err := validateSignatureHeader(shdr); err != nil {
+		return errors.WithMessage(err, "malformed message: invalid signature header")
+	}
+	if err := validateChannelHeader(chdr, maxClockSkew); err != nil {
+		return errors.WithMessage(err, "malformed message: invalid channel header")
+	}
+
+	if cb.HeaderType(chdr.Type) != cb.HeaderType_ENDORSER_TRANSACTION {
+		return nil
+	}
+	expectedTxID, err := utils.ComputeTxID(shdr.Nonce, shdr.Creator)
+	if err != nil {
+		return errors.WithMessage(err, "failed computing the transaction ID")
+	}
+	if chdr.TxId != expectedTxID {
+		return errors.Errorf("malformed message: transaction ID %s does not match the nonce and the creator, expected %s", chdr.TxId, expectedTxID)
+	}
+	if err := validateEndorserTransaction(payload); err != nil {
+		return errors.WithMessage(err, "malformed message: invalid endorser transaction")
+	}
+	return nil
+}
+
+func validateChannelHeader(chdr *cb.ChannelHeader, maxClockSkew time.Duration) error {
+	if chdr.Epoch != 0 {
+		return errors.Errorf("epoch is %d, expected 0", chdr.Epoch)
+	}
+	if chdr.Timestamp == nil {
+		return errors.New("the timestamp is missing")
+	}
+	if maxClockSkew == 0 {
+		return nil
+	}
+	// a scheduled transaction keeps the timestamp of its proposal, which was
+	// signed ahead of its schedule, so that only its timestamp ahead of the
+	// time of the orderer is rejected and its scheduled time is checked instead
+	timestamp := time.Unix(chdr.Timestamp.Seconds, int64(chdr.Timestamp.Nanos))
+	if skew := time.Since(timestamp); -skew > maxClockSkew || (skew > maxClockSkew && !utils.IsScheduled(chdr)) {
+		return errors.Errorf("timestamp %s is more than %s away from the time of the orderer", timestamp.UTC(), maxClockSkew)
+	}
+	if chdr.ScheduledTime == nil {
+		return nil
+	}
+	scheduledTime, err := ptypes.Timestamp(chdr.ScheduledTime)
+	if err != nil {
+		return errors.Wrap(err, "invalid scheduled time")
+	}
+	if skew := time.Since(scheduledTime); skew > maxClockSkew || -skew > maxClockSkew {
+		return errors.Errorf("scheduled time %s is more than %s away from the time of the orderer", scheduledTime.UTC(), maxClockSkew)
+	}
+	return nil
+}
+
+func validateSignatureHeader(shdr *cb.SignatureHeader) error {
+	if len(shdr.Nonce) == 0 {
+		return errors.New("the nonce is missing")
+	}
+	if len(shdr.Creator) == 0 {
+		return errors.New("the creator is missing")
+	}
+	return nil
+}
+
+// validateEndorserTransaction performs the structural checks of the validation
+// of the endorser transactions by the peers
+func validateEndorserTransaction(payload *cb.Payload) error {
+	tx, err := utils.GetTransaction(payload.Data)
+	if err != nil {
+		return err
+	}
+	if len(tx.Actions) != 1 {
+		return errors.Errorf("only one action per transaction is supported, the transaction contains %d", len(tx.Actions))
+	}
+
+	action := tx.Actions[0]
+	shdr, err := utils.GetSignatureHeader(action.Header)
+	if err != nil {
+		return errors.WithMessage(err, "invalid action")
+	}
+	if err := validateSignatureHeader(shdr); err != nil {
+		return errors.WithMessage(err, "invalid signature header of the action")
+	}
+	ccActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "invalid action")
+	}
+	if ccActionPayload.Action == nil {
+		return errors.New("the action has no endorsed action")
+	}
+	if len(ccActionPayload.Action.Endorsements) == 0 {
+		return errors.New("the action has no endorsement")
+	}
+	prp, err := utils.GetProposalResponsePayload(ccActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return errors.WithMessage(err, "invalid action")
+	}
+	proposalHash, err := utils.GetProposalHash2(&cb.Header{
+		ChannelHeader:   payload.Header.ChannelHeader,
+		SignatureHeader: action.Header,
+	}, ccActionPayload.ChaincodeProposalPayload)
+	if err != nil {
+		return errors.WithMessage(err, "failed computing the proposal hash")
+	}
+	if !bytes.Equal(proposalHash, prp.ProposalHash) {
+		return errors.New("the proposal hash of the proposal response does not match the proposal")
+	}
+	return nil
+}
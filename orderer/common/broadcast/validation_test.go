@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package broadcast_test
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+var _ = Describe("StructureValidator", func() {
+	var (
+		validator *broadcast.StructureValidator
+		chdr      *cb.ChannelHeader
+		shdr      *cb.SignatureHeader
+		action    *pb.TransactionAction
+		ccPayload *pb.ChaincodeActionPayload
+	)
+
+	// envelope assembles the endorser transaction out of the headers and the action
+	envelope := func() *cb.Envelope {
+		hdr := &cb.Header{
+			ChannelHeader:   utils.MarshalOrPanic(chdr),
+			SignatureHeader: utils.MarshalOrPanic(shdr),
+		}
+		if ccPayload.Action != nil && ccPayload.Action.ProposalResponsePayload == nil {
+			proposalHash, err := utils.GetProposalHash2(&cb.Header{
+				ChannelHeader:   hdr.ChannelHeader,
+				SignatureHeader: action.Header,
+			}, ccPayload.ChaincodeProposalPayload)
+			Expect(err).NotTo(HaveOccurred())
+			ccPayload.Action.ProposalResponsePayload = utils.MarshalOrPanic(&pb.ProposalResponsePayload{ProposalHash: proposalHash})
+			defer func() { ccPayload.Action.ProposalResponsePayload = nil }()
+		}
+		action.Payload = utils.MarshalOrPanic(ccPayload)
+		return &cb.Envelope{
+			Payload: utils.MarshalOrPanic(&cb.Payload{
+				Header: hdr,
+				Data:   utils.MarshalOrPanic(&pb.Transaction{Actions: []*pb.TransactionAction{action}}),
+			}),
+			Signature: []byte("signature"),
+		}
+	}
+
+	BeforeEach(func() {
+		validator = &broadcast.StructureValidator{}
+
+		shdr = &cb.SignatureHeader{Creator: []byte("creator"), Nonce: []byte("nonce")}
+		txID, err := utils.ComputeTxID(shdr.Nonce, shdr.Creator)
+		Expect(err).NotTo(HaveOccurred())
+		chdr = &cb.ChannelHeader{
+			Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+			ChannelId: "fake-channel",
+			TxId:      txID,
+			Timestamp: &timestamp.Timestamp{Seconds: time.Now().Unix()},
+		}
+		action = &pb.TransactionAction{Header: utils.MarshalOrPanic(shdr)}
+		ccPayload = &pb.ChaincodeActionPayload{
+			ChaincodeProposalPayload: []byte("proposal-payload"),
+			Action: &pb.ChaincodeEndorsedAction{
+				Endorsements: []*pb.Endorsement{{Endorser: []byte("endorser"), Signature: []byte("signature")}},
+			},
+		}
+	})
+
+	It("accepts a well formed endorser transaction", func() {
+		Expect(validator.Validate(envelope(), chdr)).To(Succeed())
+	})
+
+	It("accepts other messages with sane headers", func() {
+		chdr.Type = int32(cb.HeaderType_MESSAGE)
+		chdr.TxId = ""
+		msg := &cb.Envelope{
+			Payload: utils.MarshalOrPanic(&cb.Payload{
+				Header: &cb.Header{
+					ChannelHeader:   utils.MarshalOrPanic(chdr),
+					SignatureHeader: utils.MarshalOrPanic(shdr),
+				},
+				Data: []byte("data"),
+			}),
+			Signature: []byte("signature"),
+		}
+		Expect(validator.Validate(msg, chdr)).To(Succeed())
+	})
+
+	It("rejects the messages larger than the maximum size", func() {
+		validator.MaxMessageBytes = 10
+		msg := envelope()
+		err := validator.Validate(msg, chdr)
+		Expect(err).To(MatchError(ContainSubstring("exceeds the maximum size of 10 bytes")))
+
+		validator.MaxMessageBytes = uint32(proto.Size(msg))
+		Expect(validator.Validate(msg, chdr)).To(Succeed())
+	})
+
+	It("rejects the unsigned messages", func() {
+		msg := envelope()
+		msg.Signature = nil
+		Expect(validator.Validate(msg, chdr)).To(MatchError("malformed message: the envelope is not signed"))
+	})
+
+	It("rejects the messages without header", func() {
+		msg := &cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{}), Signature: []byte("signature")}
+		Expect(validator.Validate(msg, chdr)).To(MatchError("malformed message: the payload has no header"))
+	})
+
+	It("rejects the messages without nonce or creator", func() {
+		shdr.Nonce = nil
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid signature header: the nonce is missing"))
+
+		shdr.Nonce = []byte("nonce")
+		shdr.Creator = nil
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid signature header: the creator is missing"))
+	})
+
+	It("rejects the messages with an invalid channel header", func() {
+		chdr.Epoch = 3
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid channel header: epoch is 3, expected 0"))
+
+		chdr.Epoch = 0
+		chdr.Timestamp = nil
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid channel header: the timestamp is missing"))
+	})
+
+	It("rejects the messages whose timestamp is too far from the time of the orderer", func() {
+		validator.MaxClockSkew = time.Minute
+		Expect(validator.Validate(envelope(), chdr)).To(Succeed())
+
+		chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(-time.Hour).Unix()}
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("is more than 1m0s away from the time of the orderer")))
+
+		chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(time.Hour).Unix()}
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("is more than 1m0s away from the time of the orderer")))
+	})
+
+	It("applies the limits changed while it validates the messages", func() {
+		msg := envelope()
+		validator.SetLimits(10, 0)
+		Expect(validator.Validate(msg, chdr)).To(MatchError(ContainSubstring("exceeds the maximum size of 10 bytes")))
+
+		chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(-time.Hour).Unix()}
+		validator.SetLimits(0, time.Minute)
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("is more than 1m0s away from the time of the orderer")))
+
+		validator.SetLimits(0, 2*time.Hour)
+		Expect(validator.Validate(envelope(), chdr)).To(Succeed())
+	})
+
+	It("checks the scheduled transactions against their scheduled time", func() {
+		validator.MaxClockSkew = time.Minute
+		chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(-time.Hour).Unix()}
+		chdr.ScheduledHeight = 10
+		Expect(validator.Validate(envelope(), chdr)).To(Succeed())
+
+		chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(time.Hour).Unix()}
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("is more than 1m0s away from the time of the orderer")))
+
+		chdr.Timestamp = &timestamp.Timestamp{Seconds: time.Now().Add(-time.Hour).Unix()}
+		chdr.ScheduledHeight = 0
+		chdr.ScheduledTime = &timestamp.Timestamp{Seconds: time.Now().Unix()}
+		Expect(validator.Validate(envelope(), chdr)).To(Succeed())
+
+		chdr.ScheduledTime = &timestamp.Timestamp{Seconds: time.Now().Add(-time.Hour).Unix()}
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("malformed message: invalid channel header: scheduled time")))
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("is more than 1m0s away from the time of the orderer")))
+	})
+
+	It("rejects the endorser transactions whose transaction ID does not match", func() {
+		chdr.TxId = "forged"
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError(ContainSubstring("malformed message: transaction ID forged does not match the nonce and the creator")))
+	})
+
+	It("rejects the endorser transactions with a malformed action", func() {
+		ccPayload.Action.Endorsements = nil
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid endorser transaction: the action has no endorsement"))
+
+		ccPayload.Action = nil
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid endorser transaction: the action has no endorsed action"))
+	})
+
+	It("rejects the endorser transactions with several actions", func() {
+		msg := envelope()
+		payload, err := utils.UnmarshalPayload(msg.Payload)
+		Expect(err).NotTo(HaveOccurred())
+		payload.Data = utils.MarshalOrPanic(&pb.Transaction{Actions: []*pb.TransactionAction{action, action}})
+		msg.Payload = utils.MarshalOrPanic(payload)
+		Expect(validator.Validate(msg, chdr)).To(MatchError("malformed message: invalid endorser transaction: only one action per transaction is supported, the transaction contains 2"))
+	})
+
+	It("rejects the endorser transactions whose proposal hash does not match", func() {
+		ccPayload.Action.ProposalResponsePayload = utils.MarshalOrPanic(&pb.ProposalResponsePayload{ProposalHash: []byte("hash")})
+		Expect(validator.Validate(envelope(), chdr)).To(MatchError("malformed message: invalid endorser transaction: the proposal hash of the proposal response does not match the proposal"))
+	})
+})
@@ -51,10 +51,30 @@ type General struct {
 	SystemChannel  string
 	GenesisFile    string
 	Profile        Profile
+	Logging        Logging
 	LocalMSPDir    string
 	LocalMSPID     string
 	BCCSP          *bccsp.FactoryOpts
 	Authentication Authentication
+	Broadcast      Broadcast
+	Deliver        Deliver
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	Interceptors   []Interceptor
+}
+
+// Logging contains configuration parameters related to the logging of the
+// orderer.
+type Logging struct {
+	Spec string
+}
+
+// Interceptor configures an interceptor of the gRPC calls served by the
+// orderer, either compiled in and referred to by its name, or loaded from the
+// Go plugin at the library path.
+type Interceptor struct {
+	Name    string
+	Library string
 }
 
 type Cluster struct {
@@ -91,6 +111,11 @@ type TLS struct {
 	RootCAs            []string
 	ClientAuthRequired bool
 	ClientRootCAs      []string
+	// The TLS policy of the orderer, only read from General.TLS
+	MinVersion   string
+	MaxVersion   string
+	CipherSuites []string
+	FIPS         bool
 }
 
 // SASLPlain contains configuration for SASL/PLAIN authentication
@@ -103,7 +128,41 @@ type SASLPlain struct {
 // Authentication contains configuration parameters related to authenticating
 // client messages.
 type Authentication struct {
-	TimeWindow time.Duration
+	TimeWindow           time.Duration
+	BindTLSIdentity      bool
+	ReevaluationInterval time.Duration
+}
+
+// Broadcast contains configuration parameters related to the validation of
+// the messages broadcast by the clients.
+type Broadcast struct {
+	ValidateStructure bool
+	MaxMessageBytes   uint32
+	MaxClockSkew      time.Duration
+	ConfigUpdates     ConfigUpdates
+}
+
+// Deliver contains configuration parameters related to the delivery of the
+// blocks to the clients.
+type Deliver struct {
+	Sessions DeliverSessions
+}
+
+// DeliverSessions contains configuration parameters related to the tracking
+// of the blocks delivered to each client, which lets the clients resume their
+// deliver and throttles the clients which stream the same blocks again.
+type DeliverSessions struct {
+	TTL               time.Duration
+	MaxReplayedBlocks uint64
+	ReplayWindow      time.Duration
+}
+
+// ConfigUpdates contains configuration parameters related to the queuing of
+// the config updates of each channel.
+type ConfigUpdates struct {
+	MaxInflight   int
+	MaxQueued     int
+	CommitTimeout time.Duration
 }
 
 // Profile contains configuration for Go pprof profiling.
@@ -191,6 +250,29 @@ type Debug struct {
 type Operations struct {
 	ListenAddress string
 	TLS           TLS
+	Authorization OperationsAuthorization
+}
+
+// OperationsAuthorization maps the client TLS certificates to the roles required
+// by the operations endpoints.
+type OperationsAuthorization struct {
+	Enabled   bool
+	Roles     OperationsRoles
+	Endpoints map[string]string
+}
+
+// OperationsRoles contains the members of each operations role.
+type OperationsRoles struct {
+	Admin    OperationsRoleMembers
+	Operator OperationsRoleMembers
+	Viewer   OperationsRoleMembers
+}
+
+// OperationsRoleMembers identifies the members of an operations role by the
+// subject of their TLS client certificate.
+type OperationsRoleMembers struct {
+	CommonNames         []string
+	OrganizationalUnits []string
 }
 
 // Operations confiures the metrics provider for the orderer.
@@ -232,9 +314,11 @@ var Defaults = TopLevel{
 			ReplicationPullTimeout:               time.Second * 5,
 			CertExpirationWarningThreshold:       time.Hour * 24 * 7,
 		},
-		LocalMSPDir: "msp",
-		LocalMSPID:  "SampleOrg",
-		BCCSP:       bccsp.GetDefaultOpts(),
+		LocalMSPDir:    "msp",
+		LocalMSPID:     "SampleOrg",
+		BCCSP:          bccsp.GetDefaultOpts(),
+		MaxRecvMsgSize: 100 * 1024 * 1024,
+		MaxSendMsgSize: 100 * 1024 * 1024,
 		Authentication: Authentication{
 			TimeWindow: time.Duration(15 * time.Minute),
 		},
@@ -371,6 +455,10 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			c.General.Cluster.ReplicationBackgroundRefreshInterval = Defaults.General.Cluster.ReplicationBackgroundRefreshInterval
 		case c.General.Cluster.CertExpirationWarningThreshold == 0:
 			c.General.Cluster.CertExpirationWarningThreshold = Defaults.General.Cluster.CertExpirationWarningThreshold
+		case c.General.MaxRecvMsgSize <= 0:
+			c.General.MaxRecvMsgSize = Defaults.General.MaxRecvMsgSize
+		case c.General.MaxSendMsgSize <= 0:
+			c.General.MaxSendMsgSize = Defaults.General.MaxSendMsgSize
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.Certificate == "":
 			logger.Panicf("General.Kafka.TLS.Certificate must be set if General.Kafka.TLS.Enabled is set to true.")
 		case c.Kafka.TLS.Enabled && c.Kafka.TLS.PrivateKey == "":
@@ -162,6 +162,18 @@ func TestClusterDefaults(t *testing.T) {
 	assert.Equal(t, cfg.General.Cluster.ReplicationMaxRetries, Defaults.General.Cluster.ReplicationMaxRetries)
 }
 
+func TestMsgSizeDefaults(t *testing.T) {
+	cfg := &TopLevel{}
+	cfg.completeInitialization("/dummy/path")
+	assert.Equal(t, 100*1024*1024, cfg.General.MaxRecvMsgSize)
+	assert.Equal(t, 100*1024*1024, cfg.General.MaxSendMsgSize)
+
+	cfg = &TopLevel{General: General{MaxRecvMsgSize: 1024, MaxSendMsgSize: 2048}}
+	cfg.completeInitialization("/dummy/path")
+	assert.Equal(t, 1024, cfg.General.MaxRecvMsgSize)
+	assert.Equal(t, 2048, cfg.General.MaxSendMsgSize)
+}
+
 func TestSystemChannel(t *testing.T) {
 	cleanup := configtest.SetDevFabricConfigPath(t)
 	defer cleanup()
@@ -9,8 +9,10 @@ package msgprocessor
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 )
 
@@ -18,6 +20,10 @@ import (
 type SigFilterSupport interface {
 	// PolicyManager returns a reference to the current policy manager
 	PolicyManager() policies.Manager
+
+	// ApplicationConfig returns the application config of the channel and
+	// whether the application config exists
+	ApplicationConfig() (channelconfig.Application, bool)
 }
 
 // SigFilter stores the name of the policy to apply to deliver requests to
@@ -38,7 +44,7 @@ func NewSigFilter(policyName string, support SigFilterSupport) *SigFilter {
 
 // Apply applies the policy given, resulting in Reject or Forward, never Accept
 func (sf *SigFilter) Apply(message *cb.Envelope) error {
-	signedData, err := message.AsSignedData()
+	signedData, err := sf.asSignedData(message)
 
 	if err != nil {
 		return fmt.Errorf("could not convert message to signedData: %s", err)
@@ -55,3 +61,21 @@ func (sf *SigFilter) Apply(message *cb.Envelope) error {
 	}
 	return nil
 }
+
+// asSignedData returns the signed data of the message, whose signature is over
+// the proposal for the scheduled transactions of the channels with the
+// SCHEDULED_TRANSACTIONS application capability
+func (sf *SigFilter) asSignedData(message *cb.Envelope) ([]*cb.SignedData, error) {
+	chdr, err := utils.ChannelHeader(message)
+	if err == nil && cb.HeaderType(chdr.Type) == cb.HeaderType_ENDORSER_TRANSACTION && utils.IsScheduled(chdr) && sf.scheduledTransactions() {
+		return utils.ScheduledSignedData(message)
+	}
+	return message.AsSignedData()
+}
+
+// scheduledTransactions returns whether the channel accepts the scheduled
+// transactions
+func (sf *SigFilter) scheduledTransactions() bool {
+	appConfig, ok := sf.support.ApplicationConfig()
+	return ok && appConfig.Capabilities().ScheduledTransactions()
+}
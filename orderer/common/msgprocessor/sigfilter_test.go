@@ -13,7 +13,9 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	mockchannelconfig "github.com/hyperledger/fabric/common/mocks/config"
 	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -66,3 +68,61 @@ func TestErrorOnPolicy(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, ErrPermissionDenied, errors.Cause(err))
 }
+
+// recordingPolicy records the signed data it evaluates
+type recordingPolicy struct {
+	signedData []*cb.SignedData
+}
+
+func (p *recordingPolicy) Evaluate(signedData []*cb.SignedData) error {
+	p.signedData = signedData
+	return nil
+}
+
+func TestScheduledTransaction(t *testing.T) {
+	policy := &recordingPolicy{}
+	mpm := &mockchannelconfig.Resources{
+		PolicyManagerVal: &mockpolicies.Manager{PolicyMap: map[string]policies.Policy{"foo": policy}},
+	}
+
+	header := &cb.Header{
+		ChannelHeader:   utils.MarshalOrPanic(&cb.ChannelHeader{Type: int32(cb.HeaderType_ENDORSER_TRANSACTION), ScheduledHeight: 10}),
+		SignatureHeader: utils.MarshalOrPanic(&cb.SignatureHeader{Creator: []byte("creator")}),
+	}
+	proposal := utils.MarshalOrPanic(&pb.Proposal{
+		Header:  utils.MarshalOrPanic(header),
+		Payload: utils.MarshalOrPanic(&pb.ChaincodeProposalPayload{Input: []byte("input")}),
+	})
+	tx := utils.MarshalOrPanic(&pb.Transaction{Actions: []*pb.TransactionAction{{
+		Payload: utils.MarshalOrPanic(&pb.ChaincodeActionPayload{
+			ChaincodeProposalPayload: utils.MarshalOrPanic(&pb.ChaincodeProposalPayload{Input: []byte("input")}),
+			ScheduledProposal:        proposal,
+		}),
+	}}})
+	payload := utils.MarshalOrPanic(&cb.Payload{Header: header, Data: tx})
+	env := &cb.Envelope{
+		Payload:   payload,
+		Signature: []byte("signature"),
+	}
+
+	// without the capability, the signature is over the payload
+	assert.NoError(t, NewSigFilter("foo", mpm).Apply(env))
+	assert.Equal(t, []*cb.SignedData{{
+		Data:      payload,
+		Identity:  []byte("creator"),
+		Signature: []byte("signature"),
+	}}, policy.signedData)
+
+	mpm.ApplicationConfigVal = &mockchannelconfig.MockApplication{CapabilitiesRv: &mockchannelconfig.MockApplicationCapabilities{}}
+	assert.NoError(t, NewSigFilter("foo", mpm).Apply(env))
+	assert.Equal(t, payload, policy.signedData[0].Data)
+
+	// with the capability, the signature is over the proposal of the transaction
+	mpm.ApplicationConfigVal = &mockchannelconfig.MockApplication{CapabilitiesRv: &mockchannelconfig.MockApplicationCapabilities{ScheduledTransactionsRv: true}}
+	assert.NoError(t, NewSigFilter("foo", mpm).Apply(env))
+	assert.Equal(t, []*cb.SignedData{{
+		Data:      proposal,
+		Identity:  []byte("creator"),
+		Signature: []byte("signature"),
+	}}, policy.signedData)
+}
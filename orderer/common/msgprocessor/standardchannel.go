@@ -57,6 +57,8 @@ func CreateStandardChannelFilters(filterSupport channelconfig.Resources) *RuleSe
 	return NewRuleSet([]Rule{
 		EmptyRejectRule,
 		NewExpirationRejectRule(filterSupport),
+		TxExpirationRejectRule,
+		TxScheduleRejectRule,
 		NewSizeFilter(ordererConfig),
 		NewSigFilter(policies.ChannelWriters, filterSupport),
 	})
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// TxExpirationRejectRule rejects the messages whose expiration time has passed.
+// The expiration heights are enforced by the committing peers, the orderers not
+// knowing the height the transactions will be committed at
+var TxExpirationRejectRule = Rule(txExpirationRejectRule{now: time.Now})
+
+type txExpirationRejectRule struct {
+	now func() time.Time
+}
+
+// Apply checks whether the expiration time of the message has passed
+func (r txExpirationRejectRule) Apply(message *common.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract the channel header")
+	}
+	if chdr.ExpirationTime == nil {
+		return nil
+	}
+	expirationTime, err := ptypes.Timestamp(chdr.ExpirationTime)
+	if err != nil {
+		return errors.Wrapf(err, "invalid expiration time of transaction %s", chdr.TxId)
+	}
+	if !r.now().Before(expirationTime) {
+		return errors.Errorf("transaction %s expired at %s", chdr.TxId, expirationTime.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
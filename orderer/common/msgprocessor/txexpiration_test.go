@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxExpirationRejectRule(t *testing.T) {
+	now := time.Now()
+	rule := txExpirationRejectRule{now: func() time.Time { return now }}
+
+	envelope := func(chdr *common.ChannelHeader) *common.Envelope {
+		return &common.Envelope{Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: utils.MakePayloadHeader(chdr, &common.SignatureHeader{}),
+		})}
+	}
+	timestamp := func(t time.Time) *common.ChannelHeader {
+		ts, _ := ptypes.TimestampProto(t)
+		return &common.ChannelHeader{TxId: "txid", ExpirationTime: ts}
+	}
+
+	t.Run("NoExpiration", func(t *testing.T) {
+		assert.NoError(t, rule.Apply(envelope(&common.ChannelHeader{TxId: "txid"})))
+	})
+
+	t.Run("ExpirationHeight", func(t *testing.T) {
+		// the heights are enforced by the peers
+		assert.NoError(t, rule.Apply(envelope(&common.ChannelHeader{TxId: "txid", ExpirationHeight: 1})))
+	})
+
+	t.Run("NotExpired", func(t *testing.T) {
+		assert.NoError(t, rule.Apply(envelope(timestamp(now.Add(time.Minute)))))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		err := rule.Apply(envelope(timestamp(now)))
+		assert.EqualError(t, err, "transaction txid expired at "+now.UTC().Format(time.RFC3339))
+	})
+
+	t.Run("BadPayload", func(t *testing.T) {
+		err := rule.Apply(&common.Envelope{Payload: []byte("garbage")})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "could not extract the channel header")
+	})
+}
@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msgprocessor
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// TxScheduleRejectRule rejects the messages whose scheduled time has not come.
+// As for the expiration, the scheduled heights are enforced by the committing
+// peers
+var TxScheduleRejectRule = Rule(txScheduleRejectRule{now: time.Now})
+
+type txScheduleRejectRule struct {
+	now func() time.Time
+}
+
+// Apply checks whether the scheduled time of the message has come
+func (r txScheduleRejectRule) Apply(message *common.Envelope) error {
+	chdr, err := utils.ChannelHeader(message)
+	if err != nil {
+		return errors.WithMessage(err, "could not extract the channel header")
+	}
+	if chdr.ScheduledTime == nil {
+		return nil
+	}
+	scheduledTime, err := ptypes.Timestamp(chdr.ScheduledTime)
+	if err != nil {
+		return errors.Wrapf(err, "invalid scheduled time of transaction %s", chdr.TxId)
+	}
+	if r.now().Before(scheduledTime) {
+		return errors.Errorf("transaction %s is scheduled at %s", chdr.TxId, scheduledTime.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
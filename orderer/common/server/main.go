@@ -36,6 +36,7 @@ import (
 	"github.com/hyperledger/fabric/common/tools/protolator"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/handlers/interception"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
@@ -85,6 +86,10 @@ func Main() {
 		os.Exit(1)
 	}
 	initializeLogging()
+	if err := activateLogSpec(conf.General.Logging.Spec); err != nil {
+		logger.Error("failed to initialize logging: ", err)
+		os.Exit(1)
+	}
 	initializeLocalMsp(conf)
 
 	prettyPrintStruct(conf)
@@ -93,6 +98,10 @@ func Main() {
 
 // Start provides a layer of abstraction for benchmark test
 func Start(cmd string, conf *localconfig.TopLevel) {
+	// the maximum message sizes must be set before any gRPC client or server is created
+	comm.MaxRecvMsgSize = conf.General.MaxRecvMsgSize
+	comm.MaxSendMsgSize = conf.General.MaxSendMsgSize
+
 	bootstrapBlock := extractBootstrapBlock(conf)
 	if err := ValidateBootstrapBlock(bootstrapBlock); err != nil {
 		logger.Panicf("Failed validating bootstrap block: %v", err)
@@ -156,8 +165,16 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 	}
 
 	manager := initializeMultichannelRegistrar(bootstrapBlock, r, clusterDialer, clusterServerConfig, clusterGRPCServer, conf, signer, metricsProvider, opsSystem, lf, tlsCallback)
+	if clusterType {
+		opsSystem.RegisterHandler("/consensus/etcdraft/rotation", etcdraft.NewRotationHandler(manager))
+	}
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
-	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication.TimeWindow, mutualTLS)
+	server := NewServer(manager, metricsProvider, &conf.Debug, conf.General.Authentication, conf.General.Broadcast, conf.General.Deliver, mutualTLS)
+	reloader, err := newReloader(broadcastValidator(server))
+	if err != nil {
+		logger.Panicf("failed to initialize the reloader: %s", err)
+	}
+	opsSystem.RegisterHandler("/reload", reloader)
 
 	logger.Infof("Starting %s", metadata.GetVersionInfo())
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
@@ -167,6 +184,7 @@ func Start(cmd string, conf *localconfig.TopLevel) {
 				clusterGRPCServer.Stop()
 			}
 		},
+		syscall.SIGHUP: func() { reloader.Reload() },
 	}))
 
 	if clusterGRPCServer != grpcServer {
@@ -317,7 +335,6 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 		Logger:             generalConf.Logger,
 		KaOpts:             generalConf.KaOpts,
 		SecOpts: &comm.SecureOptions{
-			CipherSuites:      comm.DefaultTLSCipherSuites,
 			ClientRootCAs:     clientRootCAs,
 			RequireClientCert: true,
 			Certificate:       cert,
@@ -326,6 +343,8 @@ func configureClusterListener(conf *localconfig.TopLevel, generalConf comm.Serve
 		},
 	}
 
+	initializeTLSPolicy(conf).Apply(serverConf.SecOpts)
+
 	srv, err := comm.NewGRPCServer(bindAddr, serverConf)
 	if err != nil {
 		logger.Panicf("Failed creating gRPC server on %s:%d due to %v", clusterConf.ListenAddress, clusterConf.ListenPort, err)
@@ -373,16 +392,27 @@ func initializeClusterClientConfig(conf *localconfig.TopLevel, clusterType bool,
 
 	cc.SecOpts = &comm.SecureOptions{
 		RequireClientCert: true,
-		CipherSuites:      comm.DefaultTLSCipherSuites,
 		ServerRootCAs:     serverRootCAs,
 		Certificate:       certBytes,
 		Key:               keyBytes,
 		UseTLS:            true,
 	}
+	initializeTLSPolicy(conf).Apply(cc.SecOpts)
 
 	return cc
 }
 
+// initializeTLSPolicy returns the TLS protocol versions and cipher suites allowed
+// by the orderer, which apply to its gRPC servers and to its cluster clients
+func initializeTLSPolicy(conf *localconfig.TopLevel) *comm.TLSPolicy {
+	tlsConf := conf.General.TLS
+	tlsPolicy, err := comm.NewTLSPolicy(tlsConf.MinVersion, tlsConf.MaxVersion, tlsConf.CipherSuites, tlsConf.FIPS)
+	if err != nil {
+		logger.Fatalf("Invalid TLS policy (%s)", err)
+	}
+	return tlsPolicy
+}
+
 func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.Provider) comm.ServerConfig {
 	// secure server config
 	secureOpts := &comm.SecureOptions{
@@ -426,6 +456,7 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 		secureOpts.Key = serverKey
 		secureOpts.Certificate = serverCertificate
 		secureOpts.ClientRootCAs = clientRootCAs
+		initializeTLSPolicy(conf).Apply(secureOpts)
 		logger.Infof("Starting orderer with %s enabled", msg)
 	}
 	kaOpts := comm.DefaultKeepaliveOptions
@@ -442,22 +473,32 @@ func initializeServerConfig(conf *localconfig.TopLevel, metricsProvider metrics.
 		metricsProvider = &disabled.Provider{}
 	}
 
+	var interceptorConfigs []interception.Config
+	for _, interceptor := range conf.General.Interceptors {
+		interceptorConfigs = append(interceptorConfigs, interception.Config{Name: interceptor.Name, Library: interceptor.Library})
+	}
+	interceptors, err := interception.Load(interceptorConfigs)
+	if err != nil {
+		logger.Fatalf("Failed to load the interceptors (%s)", err)
+	}
+	unaryInterceptors, streamInterceptors := interception.ServerInterceptors(interceptors)
+
 	return comm.ServerConfig{
 		SecOpts:         secureOpts,
 		KaOpts:          kaOpts,
 		Logger:          commLogger,
 		MetricsProvider: metricsProvider,
-		StreamInterceptors: []grpc.StreamServerInterceptor{
+		StreamInterceptors: append([]grpc.StreamServerInterceptor{
 			grpcmetrics.StreamServerInterceptor(grpcmetrics.NewStreamMetrics(metricsProvider)),
 			grpclogging.StreamServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
-		},
-		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+		}, streamInterceptors...),
+		UnaryInterceptors: append([]grpc.UnaryServerInterceptor{
 			grpcmetrics.UnaryServerInterceptor(grpcmetrics.NewUnaryMetrics(metricsProvider)),
 			grpclogging.UnaryServerInterceptor(
 				flogging.MustGetLogger("comm.grpc.server").Zap(),
 				grpclogging.WithLeveler(grpclogging.LevelerFunc(grpcLeveler)),
 			),
-		},
+		}, unaryInterceptors...),
 	}
 }
 
@@ -666,10 +707,34 @@ func newOperationsSystem(ops localconfig.Operations, metrics localconfig.Metrics
 			ClientCertRequired: ops.TLS.ClientAuthRequired,
 			ClientCACertFiles:  ops.TLS.ClientRootCAs,
 		},
-		Version: metadata.Version,
+		Authorization: operationsAuthorization(ops.Authorization),
+		Version:       metadata.Version,
 	})
 }
 
+func operationsAuthorization(auth localconfig.OperationsAuthorization) operations.Authorization {
+	endpoints := map[string]operations.Role{}
+	for path, role := range auth.Endpoints {
+		endpoints[path] = operations.Role(role)
+	}
+	return operations.Authorization{
+		Enabled: auth.Enabled,
+		Admins: operations.RoleMembers{
+			CommonNames:         auth.Roles.Admin.CommonNames,
+			OrganizationalUnits: auth.Roles.Admin.OrganizationalUnits,
+		},
+		Operators: operations.RoleMembers{
+			CommonNames:         auth.Roles.Operator.CommonNames,
+			OrganizationalUnits: auth.Roles.Operator.OrganizationalUnits,
+		},
+		Viewers: operations.RoleMembers{
+			CommonNames:         auth.Roles.Viewer.CommonNames,
+			OrganizationalUnits: auth.Roles.Viewer.OrganizationalUnits,
+		},
+		Endpoints: endpoints,
+	}
+}
+
 func updateTrustedRoots(rootCASupport *comm.CASupport, cm channelconfig.Resources, servers ...*comm.GRPCServer) {
 	rootCASupport.Lock()
 	defer rootCASupport.Unlock()
@@ -119,6 +119,12 @@ func TestInitializeServerConfig(t *testing.T) {
 	assert.Len(t, sc.UnaryInterceptors, 2)
 	assert.Len(t, sc.StreamInterceptors, 2)
 
+	conf.General.Interceptors = []localconfig.Interceptor{{Name: "AuditLog"}}
+	sc = initializeServerConfig(conf, nil)
+	assert.Len(t, sc.UnaryInterceptors, 3)
+	assert.Len(t, sc.StreamInterceptors, 3)
+	conf.General.Interceptors = nil
+
 	sc = initializeServerConfig(conf, &prometheus.Provider{})
 	assert.Equal(t, &prometheus.Provider{}, sc.MetricsProvider)
 
@@ -389,6 +395,33 @@ func TestUpdateTrustedRoots(t *testing.T) {
 	grpcServer.Listener().Close()
 }
 
+func TestInitializeTLSPolicy(t *testing.T) {
+	conf := &localconfig.TopLevel{
+		General: localconfig.General{
+			TLS: localconfig.TLS{
+				Enabled:     true,
+				Certificate: "main.go",
+				PrivateKey:  "main.go",
+				MinVersion:  "1.3",
+			},
+		},
+	}
+	sc := initializeServerConfig(conf, nil)
+	assert.Equal(t, uint16(0x0304), sc.SecOpts.MinVersion)
+	assert.Equal(t, uint16(0x0304), sc.SecOpts.MaxVersion)
+
+	conf.General.TLS = localconfig.TLS{FIPS: true}
+	tlsPolicy := initializeTLSPolicy(conf)
+	assert.Equal(t, comm.FIPSTLSCipherSuites, tlsPolicy.CipherSuites)
+
+	oldLogger := logger
+	defer func() { logger = oldLogger }()
+	logger, _ = floggingtest.NewTestLogger(t)
+
+	conf.General.TLS = localconfig.TLS{MinVersion: "1.0"}
+	assert.Panics(t, func() { initializeTLSPolicy(conf) })
+}
+
 func TestConfigureClusterListener(t *testing.T) {
 	logEntries := make(chan string, 100)
 
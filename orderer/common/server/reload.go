@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/reload"
+	"github.com/hyperledger/fabric/orderer/common/broadcast"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/pkg/errors"
+)
+
+// newReloader creates the reloader of the settings of orderer.yaml which the
+// orderer applies without restarting, upon a SIGHUP or a call to the operations
+// endpoint /reload. The validator of the broadcast messages is nil if the
+// structure of the messages is not validated
+func newReloader(validator *broadcast.StructureValidator) (*reload.Reloader, error) {
+	// conf is the configuration read by the last reload, which the registered
+	// functions apply while the reloader holds its lock
+	var conf *localconfig.TopLevel
+	reloader, err := reload.New(func() (map[string]interface{}, error) {
+		latest, err := localconfig.Load()
+		if err != nil {
+			return nil, err
+		}
+		conf = latest
+		return reload.StructSettings(latest), nil
+	})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load the reloadable settings")
+	}
+
+	reloader.Register(func() error {
+		return activateLogSpec(conf.General.Logging.Spec)
+	}, "General.Logging.Spec")
+
+	reloader.Register(func() error {
+		if validator == nil {
+			return errors.New("the structure of the broadcast messages is not validated, the validation cannot be enabled without a restart")
+		}
+		validator.SetLimits(conf.General.Broadcast.MaxMessageBytes, conf.General.Broadcast.MaxClockSkew)
+		return nil
+	}, "General.Broadcast.MaxMessageBytes", "General.Broadcast.MaxClockSkew")
+
+	return reloader, nil
+}
+
+// activateLogSpec activates the given logging spec of orderer.yaml, if any,
+// which otherwise leaves the logging spec of the environment in effect
+func activateLogSpec(spec string) error {
+	if spec == "" {
+		return nil
+	}
+	if err := flogging.Global.ActivateSpec(spec); err != nil {
+		return errors.WithMessage(err, "invalid logging spec")
+	}
+	return nil
+}
@@ -72,9 +72,19 @@ func (rs *responseSender) SendBlockResponse(block *cb.Block) error {
 }
 
 // NewServer creates an ab.AtomicBroadcastServer based on the broadcast target and ledger Reader
-func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, timeWindow time.Duration, mutualTLS bool) ab.AtomicBroadcastServer {
+func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debug *localconfig.Debug, authentication localconfig.Authentication, broadcastConf localconfig.Broadcast, deliverConf localconfig.Deliver, mutualTLS bool) ab.AtomicBroadcastServer {
+	dh := deliver.NewHandler(deliverSupport{Registrar: r}, authentication.TimeWindow, mutualTLS, deliver.NewMetrics(metricsProvider))
+	dh.BindTLSIdentity = authentication.BindTLSIdentity
+	dh.ReevaluationInterval = authentication.ReevaluationInterval
+	if sessions := deliverConf.Sessions; sessions.TTL > 0 {
+		dh.Sessions = deliver.NewSessions(deliver.SessionConfig{
+			TTL:               sessions.TTL,
+			MaxReplayedBlocks: sessions.MaxReplayedBlocks,
+			ReplayWindow:      sessions.ReplayWindow,
+		})
+	}
 	s := &server{
-		dh: deliver.NewHandler(deliverSupport{Registrar: r}, timeWindow, mutualTLS, deliver.NewMetrics(metricsProvider)),
+		dh: dh,
 		bh: &broadcast.Handler{
 			SupportRegistrar: broadcastSupport{Registrar: r},
 			Metrics:          broadcast.NewMetrics(metricsProvider),
@@ -82,9 +92,31 @@ func NewServer(r *multichannel.Registrar, metricsProvider metrics.Provider, debu
 		debug:     debug,
 		Registrar: r,
 	}
+	if broadcastConf.ValidateStructure {
+		s.bh.Validator = &broadcast.StructureValidator{
+			MaxMessageBytes: broadcastConf.MaxMessageBytes,
+			MaxClockSkew:    broadcastConf.MaxClockSkew,
+		}
+	}
+	if broadcastConf.ConfigUpdates.MaxInflight > 0 {
+		s.bh.ConfigUpdates = &broadcast.ConfigUpdateQueue{
+			MaxInflight:   broadcastConf.ConfigUpdates.MaxInflight,
+			MaxQueued:     broadcastConf.ConfigUpdates.MaxQueued,
+			CommitTimeout: broadcastConf.ConfigUpdates.CommitTimeout,
+		}
+	}
 	return s
 }
 
+// broadcastValidator returns the validator of the broadcast messages of the
+// given server, nil if the structure of the messages is not validated
+func broadcastValidator(abServer ab.AtomicBroadcastServer) *broadcast.StructureValidator {
+	if s, ok := abServer.(*server); ok {
+		return s.bh.Validator
+	}
+	return nil
+}
+
 type msgTracer struct {
 	function string
 	debug    *localconfig.Debug
@@ -268,6 +268,8 @@ func NewChain(
 			DataPersistDuration:     opts.Metrics.DataPersistDuration.With("channel", support.ChainID()),
 			NormalProposalsReceived: opts.Metrics.NormalProposalsReceived.With("channel", support.ChainID()),
 			ConfigProposalsReceived: opts.Metrics.ConfigProposalsReceived.With("channel", support.ChainID()),
+			CertRotations:           opts.Metrics.CertRotations.With("channel", support.ChainID()),
+			RejectedCertRotations:   opts.Metrics.RejectedCertRotations.With("channel", support.ChainID()),
 		},
 		logger:          lg,
 		opts:            opts,
@@ -1283,6 +1285,7 @@ func (c *Chain) writeConfigBlock(block *common.Block, index uint64) {
 
 			c.configInflight = true
 		} else if configMembership.Rotated() {
+			c.Metrics.CertRotations.Add(1)
 			lead := atomic.LoadUint64(&c.lastKnownLeader)
 			if configMembership.RotatedNode == lead {
 				c.logger.Infof("Certificate of Raft leader is being rotated, attempt leader transfer before reconfiguring communication")
@@ -227,6 +227,8 @@ var _ = Describe("Chain", func() {
 					fakeFields.fakeDataPersistDuration,
 					fakeFields.fakeNormalProposalsReceived,
 					fakeFields.fakeConfigProposalsReceived,
+					fakeFields.fakeCertRotations,
+					fakeFields.fakeRejectedCertRotations,
 				}
 				for _, m := range metricsList {
 					Expect(m.WithCallCount()).To(Equal(1))
@@ -1925,6 +1927,55 @@ var _ = Describe("Chain", func() {
 					})
 				})
 
+				It("checks the quorum implications of certificate rotations", func() {
+					rotate := func(rotatedID uint64) *raftprotos.ConfigMetadata {
+						metadata := &raftprotos.ConfigMetadata{Options: options}
+						for id, consenter := range consenters {
+							if id == rotatedID {
+								consenter = &raftprotos.Consenter{
+									Host:          consenter.Host,
+									Port:          consenter.Port,
+									ServerTlsCert: serverTLSCert(tlsCA),
+									ClientTlsCert: clientTLSCert(tlsCA),
+								}
+							}
+							metadata.Consenters = append(metadata.Consenters, consenter)
+						}
+						return metadata
+					}
+
+					By("checking the rotation on the leader")
+					status, err := c1.CheckRotation(rotate(2))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.RotatedNode).To(Equal(uint64(2)))
+					Expect(status.ClusterSize).To(Equal(3))
+					Expect(status.Quorum).To(Equal(2))
+					Expect(status.ActiveNodes).To(ConsistOf(uint64(1), uint64(2), uint64(3)))
+
+					By("checking the rotation on a follower")
+					_, err = c2.CheckRotation(rotate(3))
+					Expect(err).To(MatchError("node 2 is not the leader of channel multi-node-channel, the rotations are checked by leader 1"))
+
+					By("checking a consenter set not rotating certificates")
+					metadata := rotate(0)
+					metadata.Consenters = metadata.Consenters[1:]
+					_, err = c1.CheckRotation(metadata)
+					Expect(err).To(MatchError("the consenters do not rotate the certificates of exactly one consenter, requested changes: add 0 node(s), remove 1 node(s)"))
+
+					By("checking a rotation breaking the quorum")
+					network.disconnect(3)
+					Eventually(func() error {
+						c1.clock.Increment(interval)
+						_, err := c1.CheckRotation(rotate(2))
+						return err
+					}, LongEventualTimeout).Should(MatchError("rotating the certificates of node 2 would leave 1 active node(s) out of 3, less than the quorum of 2"))
+
+					By("checking the rotation of the inactive node")
+					status, err = c1.CheckRotation(rotate(3))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(status.ActiveNodes).To(ConsistOf(uint64(1), uint64(2)))
+				})
+
 				When("Leader is disconnected after cert rotation", func() {
 					It("still configures communication after failed leader transfer attempt", func() {
 						metadata := &raftprotos.ConfigMetadata{Options: options}
@@ -81,6 +81,22 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+	certRotationsOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "cert_rotations",
+		Help:         "The number of rotations of the TLS certificates of consenters committed.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+	rejectedCertRotationsOpts = metrics.CounterOpts{
+		Namespace:    "consensus",
+		Subsystem:    "etcdraft",
+		Name:         "rejected_cert_rotations",
+		Help:         "The number of rotations of the TLS certificates of consenters rejected by the rotation checks.",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 type Metrics struct {
@@ -93,6 +109,8 @@ type Metrics struct {
 	DataPersistDuration     metrics.Histogram
 	NormalProposalsReceived metrics.Counter
 	ConfigProposalsReceived metrics.Counter
+	CertRotations           metrics.Counter
+	RejectedCertRotations   metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -106,5 +124,7 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		DataPersistDuration:     p.NewHistogram(dataPersistDurationOpts),
 		NormalProposalsReceived: p.NewCounter(normalProposalsReceivedOpts),
 		ConfigProposalsReceived: p.NewCounter(configProposalsReceivedOpts),
+		CertRotations:           p.NewCounter(certRotationsOpts),
+		RejectedCertRotations:   p.NewCounter(rejectedCertRotationsOpts),
 	}
 }
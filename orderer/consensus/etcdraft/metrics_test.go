@@ -38,7 +38,7 @@ var _ = Describe("Metrics", func() {
 
 			Expect(metrics).NotTo(BeNil())
 			Expect(fakeProvider.NewGaugeCallCount()).To(Equal(4))
-			Expect(fakeProvider.NewCounterCallCount()).To(Equal(4))
+			Expect(fakeProvider.NewCounterCallCount()).To(Equal(6))
 			Expect(fakeProvider.NewHistogramCallCount()).To(Equal(1))
 
 			Expect(metrics.ClusterSize).To(Equal(fakeGauge))
@@ -50,6 +50,8 @@ var _ = Describe("Metrics", func() {
 			Expect(metrics.DataPersistDuration).To(Equal(fakeHistogram))
 			Expect(metrics.NormalProposalsReceived).To(Equal(fakeCounter))
 			Expect(metrics.ConfigProposalsReceived).To(Equal(fakeCounter))
+			Expect(metrics.CertRotations).To(Equal(fakeCounter))
+			Expect(metrics.RejectedCertRotations).To(Equal(fakeCounter))
 		})
 	})
 })
@@ -65,6 +67,8 @@ func newFakeMetrics(fakeFields *fakeMetricsFields) *etcdraft.Metrics {
 		DataPersistDuration:     fakeFields.fakeDataPersistDuration,
 		NormalProposalsReceived: fakeFields.fakeNormalProposalsReceived,
 		ConfigProposalsReceived: fakeFields.fakeConfigProposalsReceived,
+		CertRotations:           fakeFields.fakeCertRotations,
+		RejectedCertRotations:   fakeFields.fakeRejectedCertRotations,
 	}
 }
 
@@ -78,6 +82,8 @@ type fakeMetricsFields struct {
 	fakeDataPersistDuration     *metricsfakes.Histogram
 	fakeNormalProposalsReceived *metricsfakes.Counter
 	fakeConfigProposalsReceived *metricsfakes.Counter
+	fakeCertRotations           *metricsfakes.Counter
+	fakeRejectedCertRotations   *metricsfakes.Counter
 }
 
 func newFakeMetricsFields() *fakeMetricsFields {
@@ -91,6 +97,8 @@ func newFakeMetricsFields() *fakeMetricsFields {
 		fakeDataPersistDuration:     newFakeHistogram(),
 		fakeNormalProposalsReceived: newFakeCounter(),
 		fakeConfigProposalsReceived: newFakeCounter(),
+		fakeCertRotations:           newFakeCounter(),
+		fakeRejectedCertRotations:   newFakeCounter(),
 	}
 }
 
@@ -0,0 +1,359 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/raft"
+)
+
+// RotationStatus describes the implications on the quorum of a channel of the
+// rotation of the TLS certificates of one of its consenters
+type RotationStatus struct {
+	RotatedNode uint64   `json:"rotated_node"`
+	ClusterSize int      `json:"cluster_size"`
+	Quorum      int      `json:"quorum"`
+	ActiveNodes []uint64 `json:"active_nodes"`
+}
+
+// CheckRotation checks that the consenters of the metadata only differ from the
+// current consenters by the TLS certificates of one consenter, and that the
+// other active consenters keep a quorum while the rotated consenter reconnects
+// with its new certificates. Only the leader knows which consenters are active,
+// so the rotations are checked by the leader.
+func (c *Chain) CheckRotation(metadata *etcdraft.ConfigMetadata) (*RotationStatus, error) {
+	status, err := c.checkRotation(metadata)
+	if err != nil {
+		c.Metrics.RejectedCertRotations.Add(1)
+		return nil, err
+	}
+	return status, nil
+}
+
+func (c *Chain) checkRotation(metadata *etcdraft.ConfigMetadata) (*RotationStatus, error) {
+	if err := c.isRunning(); err != nil {
+		return nil, err
+	}
+	if err := CheckConfigMetadata(metadata); err != nil {
+		return nil, err
+	}
+
+	c.raftMetadataLock.RLock()
+	changes, err := ComputeMembershipChanges(c.opts.BlockMetadata, c.opts.Consenters, metadata.Consenters)
+	consenterIDs := c.opts.BlockMetadata.ConsenterIds
+	c.raftMetadataLock.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if !changes.Rotated() {
+		return nil, errors.Errorf("the consenters do not rotate the certificates of exactly one consenter, requested changes: %s", changes)
+	}
+	rotated := changes.AddedNodes[0]
+	if rotated.Host != changes.RemovedNodes[0].Host || rotated.Port != changes.RemovedNodes[0].Port {
+		return nil, errors.Errorf("the endpoint of node %d changes from %s:%d to %s:%d along with its certificates",
+			changes.RotatedNode, changes.RemovedNodes[0].Host, changes.RemovedNodes[0].Port, rotated.Host, rotated.Port)
+	}
+	for _, cert := range []struct {
+		role string
+		pem  []byte
+	}{{"client", rotated.ClientTlsCert}, {"server", rotated.ServerTlsCert}} {
+		if err := c.checkValidity(cert.pem, cert.role); err != nil {
+			return nil, err
+		}
+	}
+
+	raftStatus := c.Node.Status()
+	if raftStatus.RaftState != raft.StateLeader {
+		return nil, errors.Errorf("node %d is not the leader of channel %s, the rotations are checked by leader %d", c.raftID, c.channelID, raftStatus.Lead)
+	}
+	status := &RotationStatus{
+		RotatedNode: changes.RotatedNode,
+		ClusterSize: len(consenterIDs),
+		Quorum:      len(consenterIDs)/2 + 1,
+	}
+	var remaining int
+	for _, id := range consenterIDs {
+		if id != c.raftID && !raftStatus.Progress[id].RecentActive {
+			continue
+		}
+		status.ActiveNodes = append(status.ActiveNodes, id)
+		if id != changes.RotatedNode {
+			remaining++
+		}
+	}
+	if remaining < status.Quorum {
+		return nil, errors.Errorf("rotating the certificates of node %d would leave %d active node(s) out of %d, less than the quorum of %d",
+			changes.RotatedNode, remaining, status.ClusterSize, status.Quorum)
+	}
+	return status, nil
+}
+
+// checkValidity checks that the certificate is valid at the time of the clock
+// of the chain
+func (c *Chain) checkValidity(pemData []byte, certRole string) error {
+	bl, _ := pem.Decode(pemData)
+	cert, err := x509.ParseCertificate(bl.Bytes)
+	if err != nil {
+		return errors.Wrapf(err, "invalid %s TLS certificate", certRole)
+	}
+	now := c.clock.Now()
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return errors.Errorf("the new %s TLS certificate is only valid from %s to %s", certRole, cert.NotBefore, cert.NotAfter)
+	}
+	return nil
+}
+
+// RotationSupport provides the configuration of a channel and checks and
+// applies the config updates rotating the certificates of its consenters
+type RotationSupport interface {
+	ConfigProto() *common.Config
+	ProcessConfigUpdateMsg(env *common.Envelope) (config *common.Envelope, configSeq uint64, err error)
+	Configure(config *common.Envelope, configSeq uint64) error
+	CheckRotation(metadata *etcdraft.ConfigMetadata) (*RotationStatus, error)
+}
+
+// RotationRequest is the request of the rotation of the TLS certificates of
+// the consenter of a channel at the given endpoint
+type RotationRequest struct {
+	Channel       string `json:"channel"`
+	Host          string `json:"host"`
+	Port          uint32 `json:"port"`
+	ClientTLSCert string `json:"client_tls_cert"`
+	ServerTLSCert string `json:"server_tls_cert"`
+}
+
+// RotationResponse is the response of the rotation handler
+type RotationResponse struct {
+	Status *RotationStatus `json:"status"`
+	// Envelope is the unsigned CONFIG_UPDATE envelope of the rotation, for
+	// the admins to sign it
+	Envelope []byte `json:"envelope,omitempty"`
+}
+
+// RotationHandler is the administrative endpoint rotating the TLS certificates
+// of the consenters of the channels. The rotation is two-phased:
+//   - a POST of a RotationRequest checks the rotation and responds with the
+//     unsigned config update envelope of the rotation
+//   - a PUT of the marshaled config update envelope signed by the admins of
+//     the channel checks the rotation again and orders it
+//
+// The rotations are rejected with 409 Conflict when they would break the quorum
+// of the channel.
+type RotationHandler struct {
+	// Support returns the support of the Raft chain of the channel, or nil
+	// if the orderer does not run it
+	Support func(channelID string) RotationSupport
+	Logger  *flogging.FabricLogger
+}
+
+type chainRotationSupport struct {
+	*multichannel.ChainSupport
+	chain *Chain
+}
+
+func (s *chainRotationSupport) CheckRotation(metadata *etcdraft.ConfigMetadata) (*RotationStatus, error) {
+	return s.chain.CheckRotation(metadata)
+}
+
+// NewRotationHandler returns the rotation handler of the Raft chains of the
+// chain getter
+func NewRotationHandler(chains ChainGetter) *RotationHandler {
+	return &RotationHandler{
+		Support: func(channelID string) RotationSupport {
+			cs := chains.GetChain(channelID)
+			if cs == nil {
+				return nil
+			}
+			chain, ok := cs.Chain.(*Chain)
+			if !ok {
+				return nil
+			}
+			return &chainRotationSupport{ChainSupport: cs, chain: chain}
+		},
+		Logger: flogging.MustGetLogger("orderer.consensus.etcdraft.rotation"),
+	}
+}
+
+// ServeHTTP checks the rotations on POST requests and orders them on PUT
+// requests
+func (h *RotationHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	var (
+		response *RotationResponse
+		code     int
+		err      error
+	)
+	switch req.Method {
+	case http.MethodPost:
+		response, code, err = h.propose(req)
+	case http.MethodPut:
+		response, code, err = h.apply(req)
+	default:
+		resp.Header().Set("Allow", "POST, PUT")
+		http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		h.Logger.Warningf("Rejected the rotation: %s", err)
+		http.Error(resp, err.Error(), code)
+		return
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	if err := json.NewEncoder(resp).Encode(response); err != nil {
+		h.Logger.Errorf("Failed to write the response: %s", err)
+	}
+}
+
+func (h *RotationHandler) propose(req *http.Request) (*RotationResponse, int, error) {
+	rotation := &RotationRequest{}
+	if err := json.NewDecoder(req.Body).Decode(rotation); err != nil {
+		return nil, http.StatusBadRequest, errors.Wrap(err, "could not decode the rotation request")
+	}
+	support := h.Support(rotation.Channel)
+	if support == nil {
+		return nil, http.StatusNotFound, errors.Errorf("channel %s is not a Raft channel of this orderer", rotation.Channel)
+	}
+
+	original := support.ConfigProto()
+	updated := proto.Clone(original).(*common.Config)
+	consensusType, metadata, err := consensusMetadata(updated)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	var found bool
+	for _, consenter := range metadata.Consenters {
+		if consenter.Host == rotation.Host && consenter.Port == rotation.Port {
+			consenter.ClientTlsCert = []byte(rotation.ClientTLSCert)
+			consenter.ServerTlsCert = []byte(rotation.ServerTLSCert)
+			found = true
+		}
+	}
+	if !found {
+		return nil, http.StatusNotFound, errors.Errorf("channel %s has no consenter at %s:%d", rotation.Channel, rotation.Host, rotation.Port)
+	}
+
+	status, err := support.CheckRotation(metadata)
+	if err != nil {
+		return nil, http.StatusConflict, err
+	}
+
+	consensusType.Metadata = utils.MarshalOrPanic(metadata)
+	ordererGroup := updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	ordererGroup.Values[channelconfig.ConsensusTypeKey].Value = utils.MarshalOrPanic(consensusType)
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.WithMessage(err, "could not compute the config update")
+	}
+	configUpdate.ChannelId = rotation.Channel
+	env, err := utils.CreateSignedEnvelope(common.HeaderType_CONFIG_UPDATE, rotation.Channel, nil,
+		&common.ConfigUpdateEnvelope{ConfigUpdate: utils.MarshalOrPanic(configUpdate)}, 0, 0)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	h.Logger.Infof("Proposed the rotation of the certificates of node %d of channel %s", status.RotatedNode, rotation.Channel)
+	return &RotationResponse{Status: status, Envelope: utils.MarshalOrPanic(env)}, http.StatusOK, nil
+}
+
+func (h *RotationHandler) apply(req *http.Request) (*RotationResponse, int, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.Wrap(err, "could not read the config update envelope")
+	}
+	env, err := utils.UnmarshalEnvelope(body)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if payload.Header == nil {
+		return nil, http.StatusBadRequest, errors.New("the config update envelope has no header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if chdr.Type != int32(common.HeaderType_CONFIG_UPDATE) {
+		return nil, http.StatusBadRequest, errors.Errorf("the envelope is of type %s, not of type %s", common.HeaderType(chdr.Type), common.HeaderType_CONFIG_UPDATE)
+	}
+	support := h.Support(chdr.ChannelId)
+	if support == nil {
+		return nil, http.StatusNotFound, errors.Errorf("channel %s is not a Raft channel of this orderer", chdr.ChannelId)
+	}
+
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	metadata, err := MetadataFromConfigUpdate(configUpdate)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if metadata == nil {
+		return nil, http.StatusBadRequest, errors.New("the config update does not update the consenters")
+	}
+	status, err := support.CheckRotation(metadata)
+	if err != nil {
+		return nil, http.StatusConflict, err
+	}
+
+	config, configSeq, err := support.ProcessConfigUpdateMsg(env)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.WithMessage(err, "invalid config update")
+	}
+	if err := support.Configure(config, configSeq); err != nil {
+		return nil, http.StatusServiceUnavailable, errors.WithMessage(err, "could not order the config update")
+	}
+
+	h.Logger.Infof("Ordered the rotation of the certificates of node %d of channel %s", status.RotatedNode, chdr.ChannelId)
+	return &RotationResponse{Status: status}, http.StatusAccepted, nil
+}
+
+// consensusMetadata returns the consensus type of the config and its Raft
+// metadata
+func consensusMetadata(config *common.Config) (*orderer.ConsensusType, *etcdraft.ConfigMetadata, error) {
+	ordererGroup, ok := config.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, nil, errors.New("the config has no orderer group")
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return nil, nil, errors.New("the config has no consensus type")
+	}
+	consensusType := &orderer.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return nil, nil, errors.Wrap(err, "could not unmarshal the consensus type")
+	}
+	metadata := &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+		return nil, nil, errors.Wrap(err, "could not unmarshal the Raft metadata")
+	}
+	return consensusType, metadata, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package etcdraft_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	raftprotos "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotationSupport records the rotations checked and the configs ordered
+type rotationSupport struct {
+	config     *common.Config
+	checkErr   error
+	checked    []*raftprotos.ConfigMetadata
+	configured []*common.Envelope
+}
+
+func (s *rotationSupport) ConfigProto() *common.Config {
+	return s.config
+}
+
+func (s *rotationSupport) ProcessConfigUpdateMsg(env *common.Envelope) (*common.Envelope, uint64, error) {
+	return &common.Envelope{Payload: env.Payload}, 7, nil
+}
+
+func (s *rotationSupport) Configure(config *common.Envelope, configSeq uint64) error {
+	if configSeq != 7 {
+		return errors.New("unexpected config sequence")
+	}
+	s.configured = append(s.configured, config)
+	return nil
+}
+
+func (s *rotationSupport) CheckRotation(metadata *raftprotos.ConfigMetadata) (*etcdraft.RotationStatus, error) {
+	s.checked = append(s.checked, metadata)
+	if s.checkErr != nil {
+		return nil, s.checkErr
+	}
+	return &etcdraft.RotationStatus{RotatedNode: 2, ClusterSize: 3, Quorum: 2, ActiveNodes: []uint64{1, 2, 3}}, nil
+}
+
+func raftConfig(consenters ...*raftprotos.Consenter) *common.Config {
+	metadata := &raftprotos.ConfigMetadata{Consenters: consenters}
+	return &common.Config{
+		ChannelGroup: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				"Orderer": {
+					Values: map[string]*common.ConfigValue{
+						"ConsensusType": {
+							Value:     utils.MarshalOrPanic(&orderer.ConsensusType{Type: "etcdraft", Metadata: utils.MarshalOrPanic(metadata)}),
+							ModPolicy: "Admins",
+						},
+					},
+					ModPolicy: "Admins",
+				},
+			},
+		},
+	}
+}
+
+func TestRotationHandler(t *testing.T) {
+	tlsCA, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	consenter := func(port uint32) *raftprotos.Consenter {
+		return &raftprotos.Consenter{Host: "orderer", Port: port, ClientTlsCert: clientTLSCert(tlsCA), ServerTlsCert: serverTLSCert(tlsCA)}
+	}
+	support := &rotationSupport{config: raftConfig(consenter(7050), consenter(7051), consenter(7052))}
+	handler := &etcdraft.RotationHandler{
+		Support: func(channelID string) etcdraft.RotationSupport {
+			if channelID != "mychannel" {
+				return nil
+			}
+			return support
+		},
+		Logger: flogging.MustGetLogger("test"),
+	}
+	serve := func(method string, body []byte) *httptest.ResponseRecorder {
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(method, "/consensus/etcdraft/rotation", bytes.NewReader(body)))
+		return resp
+	}
+	propose := func(channel string, port uint32, clientCert, serverCert []byte) *httptest.ResponseRecorder {
+		body, err := json.Marshal(&etcdraft.RotationRequest{
+			Channel:       channel,
+			Host:          "orderer",
+			Port:          port,
+			ClientTLSCert: string(clientCert),
+			ServerTLSCert: string(serverCert),
+		})
+		require.NoError(t, err)
+		return serve(http.MethodPost, body)
+	}
+	newClientCert, newServerCert := clientTLSCert(tlsCA), serverTLSCert(tlsCA)
+
+	// the rotation is checked and its config update is returned
+	resp := propose("mychannel", 7051, newClientCert, newServerCert)
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+	response := &etcdraft.RotationResponse{}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), response))
+	assert.Equal(t, &etcdraft.RotationStatus{RotatedNode: 2, ClusterSize: 3, Quorum: 2, ActiveNodes: []uint64{1, 2, 3}}, response.Status)
+	require.Len(t, support.checked, 1)
+	assert.Equal(t, newClientCert, support.checked[0].Consenters[1].ClientTlsCert)
+	assert.Equal(t, newServerCert, support.checked[0].Consenters[1].ServerTlsCert)
+
+	env, err := utils.UnmarshalEnvelope(response.Envelope)
+	require.NoError(t, err)
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	require.NoError(t, err)
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, "mychannel", configUpdate.ChannelId)
+	metadata, err := etcdraft.MetadataFromConfigUpdate(configUpdate)
+	require.NoError(t, err)
+	assert.Equal(t, newClientCert, metadata.Consenters[1].ClientTlsCert)
+	assert.Equal(t, 0, len(support.configured))
+
+	// the signed config update is checked again and ordered
+	resp = serve(http.MethodPut, response.Envelope)
+	require.Equal(t, http.StatusAccepted, resp.Code, resp.Body.String())
+	assert.Len(t, support.checked, 2)
+	require.Len(t, support.configured, 1)
+	assert.Equal(t, env.Payload, support.configured[0].Payload)
+
+	// the rotations breaking the quorum are rejected
+	support.checkErr = errors.New("rotating the certificates of node 2 would leave 1 active node(s) out of 3, less than the quorum of 2")
+	resp = propose("mychannel", 7051, newClientCert, newServerCert)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Contains(t, resp.Body.String(), "less than the quorum of 2")
+	resp = serve(http.MethodPut, response.Envelope)
+	assert.Equal(t, http.StatusConflict, resp.Code)
+	assert.Len(t, support.configured, 1)
+
+	resp = propose("yourchannel", 7051, newClientCert, newServerCert)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "channel yourchannel is not a Raft channel of this orderer\n", resp.Body.String())
+	resp = propose("mychannel", 7053, newClientCert, newServerCert)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Equal(t, "channel mychannel has no consenter at orderer:7053\n", resp.Body.String())
+	resp = serve(http.MethodPost, []byte("{"))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = serve(http.MethodPut, []byte("garbage"))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	resp = serve(http.MethodGet, nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.Code)
+	assert.Equal(t, "POST, PUT", resp.Header().Get("Allow"))
+}
@@ -0,0 +1,341 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package kafka2raft drives the in-place migration of the channels of a
+// Kafka-based ordering service to the Raft consenter.
+//
+// The migration goes through the following steps, each one being a config
+// update submitted by the administrators of the ordering service:
+//
+//  1. StepStart, on the system channel, puts the ordering service in
+//     maintenance: the Kafka-based orderers stop ordering transactions and
+//     creating channels. The migration context is the number of the block
+//     of the system channel holding this config update.
+//  2. StepContext, on each standard channel, swaps the consensus type and
+//     metadata of the channel for the Raft ones, along with the context.
+//  3. StepCommit, on the system channel, swaps its consensus type and
+//     metadata for the Raft ones, once all the standard channels carry the
+//     context. The orderers are then restarted with the Raft consenter.
+//
+// Verify checks the config blocks of the channels at any point of the
+// migration and reports the problems which would prevent it from completing.
+package kafka2raft
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/orderer/consensus/etcdraft"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	raftproto "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	kafkaType = "kafka"
+	raftType  = "etcdraft"
+)
+
+// Step is a step of the migration
+type Step string
+
+const (
+	// StepStart puts the ordering service in maintenance, on the system channel
+	StepStart Step = "start"
+	// StepContext swaps the consensus metadata of a standard channel
+	StepContext Step = "context"
+	// StepCommit swaps the consensus metadata of the system channel and
+	// completes the migration
+	StepCommit Step = "commit"
+)
+
+// MigrateConfig returns a copy of the config of a channel whose consensus
+// type is set for a step of the migration. The Raft metadata and the context
+// are required by StepContext and StepCommit.
+func MigrateConfig(config *cb.Config, step Step, metadata *raftproto.ConfigMetadata, context uint64) (*cb.Config, error) {
+	consensusType, err := consensusTypeOf(config)
+	if err != nil {
+		return nil, err
+	}
+	if !kafka2RaftCapable(config) {
+		return nil, errors.Errorf("the orderer capability %s, required by the migration, is not enabled", capabilities.OrdererV2_0)
+	}
+	if consensusType.Type != kafkaType {
+		return nil, errors.Errorf("the consensus type is %s, only %s can be migrated", consensusType.Type, kafkaType)
+	}
+	system := isSystemChannel(config)
+
+	next := &ab.ConsensusType{}
+	switch step {
+	case StepStart:
+		if !system {
+			return nil, errors.New("the migration is started on the system channel")
+		}
+		if state := consensusType.MigrationState; state != ab.ConsensusType_MIG_STATE_NONE && state != ab.ConsensusType_MIG_STATE_ABORT {
+			return nil, errors.Errorf("the migration cannot be started from state %s", state)
+		}
+		next.Type = kafkaType
+		next.Metadata = consensusType.Metadata
+		next.MigrationState = ab.ConsensusType_MIG_STATE_START
+
+	case StepContext, StepCommit:
+		expectedState := ab.ConsensusType_MIG_STATE_NONE
+		next.MigrationState = ab.ConsensusType_MIG_STATE_CONTEXT
+		if step == StepCommit {
+			expectedState = ab.ConsensusType_MIG_STATE_START
+			next.MigrationState = ab.ConsensusType_MIG_STATE_COMMIT
+		}
+		if system != (step == StepCommit) {
+			return nil, errors.Errorf("the %s step applies to the %s", step, map[bool]string{true: "system channel", false: "standard channels"}[step == StepCommit])
+		}
+		if consensusType.MigrationState != expectedState {
+			return nil, errors.Errorf("the %s step applies to channels in state %s, not %s", step, expectedState, consensusType.MigrationState)
+		}
+		if context == 0 {
+			return nil, errors.New("the migration context is required, the number of the config block of the system channel which started the migration")
+		}
+		if err := checkMetadata(metadata); err != nil {
+			return nil, errors.WithMessage(err, "invalid Raft metadata")
+		}
+		next.Type = raftType
+		next.MigrationContext = context
+		if next.Metadata, err = proto.Marshal(metadata); err != nil {
+			return nil, errors.Wrap(err, "failed to marshal the Raft metadata")
+		}
+
+	default:
+		return nil, errors.Errorf("unknown migration step %s", step)
+	}
+
+	updated := proto.Clone(config).(*cb.Config)
+	updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value = utils.MarshalOrPanic(next)
+	return updated, nil
+}
+
+// MigrationContext returns the migration context of the config block of the
+// system channel which started the migration
+func MigrationContext(systemBlock *cb.Block) (uint64, error) {
+	config, _, err := ConfigFromBlock(systemBlock)
+	if err != nil {
+		return 0, err
+	}
+	consensusType, err := consensusTypeOf(config)
+	if err != nil {
+		return 0, err
+	}
+	if !isSystemChannel(config) || consensusType.MigrationState != ab.ConsensusType_MIG_STATE_START {
+		return 0, errors.New("the block is not the config block of the system channel which started the migration")
+	}
+	return systemBlock.Header.Number, nil
+}
+
+// Phase is the progress of the migration
+type Phase string
+
+const (
+	// PhaseNotStarted means that the ordering service is still Kafka-based
+	PhaseNotStarted Phase = "not started"
+	// PhaseStarted means that the ordering service is in maintenance, and
+	// that some standard channels lack their context
+	PhaseStarted Phase = "started"
+	// PhaseReadyToCommit means that all the standard channels carry their
+	// context, and that the migration can be committed on the system channel
+	PhaseReadyToCommit Phase = "ready to commit"
+	// PhaseCommitted means that the migration is committed, and that the
+	// orderers must be restarted with the Raft consenter
+	PhaseCommitted Phase = "committed"
+)
+
+// Report is the result of the verification of a migration
+type Report struct {
+	Phase Phase
+	// Context is the migration context, once the migration started
+	Context uint64
+	// Problems are the problems preventing the migration from completing
+	Problems []string
+}
+
+// OK returns whether the migration can proceed
+func (r *Report) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *Report) problem(format string, args ...interface{}) {
+	r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+}
+
+// Verify checks the latest config blocks of the system channel and of all the
+// standard channels of the ordering service, and reports the phase of the
+// migration and the problems preventing it from completing
+func Verify(systemBlock *cb.Block, channelBlocks []*cb.Block) (*Report, error) {
+	systemConfig, systemID, err := ConfigFromBlock(systemBlock)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config block of the system channel")
+	}
+	if !isSystemChannel(systemConfig) {
+		return nil, errors.Errorf("channel %s is not the system channel", systemID)
+	}
+	systemType, err := consensusTypeOf(systemConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	switch systemType.MigrationState {
+	case ab.ConsensusType_MIG_STATE_NONE, ab.ConsensusType_MIG_STATE_ABORT:
+		report.Phase = PhaseNotStarted
+		if systemType.Type != kafkaType {
+			report.problem("the system channel %s has consensus type %s", systemID, systemType.Type)
+		}
+	case ab.ConsensusType_MIG_STATE_START:
+		report.Phase = PhaseStarted
+		report.Context = systemBlock.Header.Number
+	case ab.ConsensusType_MIG_STATE_COMMIT:
+		report.Phase = PhaseCommitted
+		report.Context = systemType.MigrationContext
+		checkRaftMetadata(report, systemID, systemType)
+	default:
+		report.problem("the system channel %s is in unexpected state %s", systemID, systemType.MigrationState)
+	}
+	if !kafka2RaftCapable(systemConfig) {
+		report.problem("the orderer capability %s is not enabled on the system channel %s", capabilities.OrdererV2_0, systemID)
+	}
+
+	pending := 0
+	channelIDs := map[string]struct{}{}
+	for _, block := range channelBlocks {
+		config, channelID, err := ConfigFromBlock(block)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid config block of a standard channel")
+		}
+		if _, ok := channelIDs[channelID]; ok || isSystemChannel(config) {
+			return nil, errors.Errorf("channel %s is given twice, or is a system channel", channelID)
+		}
+		channelIDs[channelID] = struct{}{}
+		consensusType, err := consensusTypeOf(config)
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid config of channel %s", channelID))
+		}
+		if !kafka2RaftCapable(config) {
+			report.problem("the orderer capability %s is not enabled on channel %s", capabilities.OrdererV2_0, channelID)
+		}
+
+		switch consensusType.MigrationState {
+		case ab.ConsensusType_MIG_STATE_NONE:
+			if consensusType.Type != kafkaType {
+				report.problem("channel %s has consensus type %s", channelID, consensusType.Type)
+			}
+			if report.Phase != PhaseNotStarted {
+				pending++
+				report.problem("channel %s lacks its %s update", channelID, StepContext)
+			}
+		case ab.ConsensusType_MIG_STATE_CONTEXT:
+			if report.Phase == PhaseNotStarted {
+				report.problem("channel %s carries a migration context but the migration is not started", channelID)
+			} else if consensusType.MigrationContext != report.Context {
+				report.problem("channel %s carries the migration context %d instead of %d", channelID, consensusType.MigrationContext, report.Context)
+			}
+			checkRaftMetadata(report, channelID, consensusType)
+		default:
+			report.problem("channel %s is in unexpected state %s", channelID, consensusType.MigrationState)
+		}
+	}
+	if report.Phase == PhaseStarted && pending == 0 {
+		report.Phase = PhaseReadyToCommit
+	}
+	sort.Strings(report.Problems)
+	return report, nil
+}
+
+func checkRaftMetadata(report *Report, channelID string, consensusType *ab.ConsensusType) {
+	if consensusType.Type != raftType {
+		report.problem("channel %s has consensus type %s instead of %s", channelID, consensusType.Type, raftType)
+		return
+	}
+	metadata := &raftproto.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+		report.problem("channel %s has invalid Raft metadata: %s", channelID, err)
+		return
+	}
+	if err := checkMetadata(metadata); err != nil {
+		report.problem("channel %s has invalid Raft metadata: %s", channelID, err)
+	}
+}
+
+func checkMetadata(metadata *raftproto.ConfigMetadata) error {
+	if metadata.GetOptions() == nil {
+		return errors.New("the Raft options are not set")
+	}
+	return etcdraft.CheckConfigMetadata(metadata)
+}
+
+// ConfigFromBlock returns the config, and the ID of the channel, of a config
+// block
+func ConfigFromBlock(block *cb.Block) (*cb.Config, string, error) {
+	envelope, err := etcdraft.ConfigEnvelopeFromBlock(block)
+	if err != nil {
+		return nil, "", err
+	}
+	payload, err := utils.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, "", err
+	}
+	if payload.Header == nil {
+		return nil, "", errors.New("missing payload header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, "", err
+	}
+	configEnvelope, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, "", err
+	}
+	if configEnvelope.Config == nil || configEnvelope.Config.ChannelGroup == nil {
+		return nil, "", errors.New("missing config")
+	}
+	return configEnvelope.Config, chdr.ChannelId, nil
+}
+
+func consensusTypeOf(config *cb.Config) (*ab.ConsensusType, error) {
+	ordererGroup, ok := config.GetChannelGroup().GetGroups()[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.New("the config has no orderer group")
+	}
+	value, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+	if !ok {
+		return nil, errors.New("the config has no consensus type")
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := proto.Unmarshal(value.Value, consensusType); err != nil {
+		return nil, errors.Wrap(err, "invalid consensus type")
+	}
+	return consensusType, nil
+}
+
+func kafka2RaftCapable(config *cb.Config) bool {
+	value, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.CapabilitiesKey]
+	if !ok {
+		return false
+	}
+	caps := &cb.Capabilities{}
+	if err := proto.Unmarshal(value.Value, caps); err != nil {
+		return false
+	}
+	_, ok = caps.Capabilities[capabilities.OrdererV2_0]
+	return ok
+}
+
+func isSystemChannel(config *cb.Config) bool {
+	_, ok := config.ChannelGroup.Groups[channelconfig.ConsortiumsGroupKey]
+	return ok
+}
@@ -0,0 +1,206 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package kafka2raft
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	raftproto "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigration(t *testing.T) {
+	metadata := raftMetadata(t)
+	system := channelConfig(true, true)
+	channel := channelConfig(false, true)
+
+	report, err := Verify(configBlock(t, "system", 0, system), []*cb.Block{configBlock(t, "mychannel", 0, channel)})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Phase: PhaseNotStarted}, report)
+	assert.True(t, report.OK())
+
+	// maintenance
+	system, err = MigrateConfig(system, StepStart, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, &ab.ConsensusType{Type: "kafka", MigrationState: ab.ConsensusType_MIG_STATE_START}, consensusType(t, system))
+	startBlock := configBlock(t, "system", 5, system)
+	context, err := MigrationContext(startBlock)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), context)
+
+	report, err = Verify(startBlock, []*cb.Block{configBlock(t, "mychannel", 0, channel)})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseStarted, report.Phase)
+	assert.Equal(t, uint64(5), report.Context)
+	assert.Equal(t, []string{"channel mychannel lacks its context update"}, report.Problems)
+
+	// metadata swap of the standard channels
+	channel, err = MigrateConfig(channel, StepContext, metadata, context)
+	require.NoError(t, err)
+	assert.Equal(t, &ab.ConsensusType{
+		Type:             "etcdraft",
+		Metadata:         utils.MarshalOrPanic(metadata),
+		MigrationState:   ab.ConsensusType_MIG_STATE_CONTEXT,
+		MigrationContext: 5,
+	}, consensusType(t, channel))
+
+	report, err = Verify(startBlock, []*cb.Block{configBlock(t, "mychannel", 1, channel)})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Phase: PhaseReadyToCommit, Context: 5}, report)
+
+	// metadata swap of the system channel
+	system, err = MigrateConfig(system, StepCommit, metadata, context)
+	require.NoError(t, err)
+	assert.Equal(t, ab.ConsensusType_MIG_STATE_COMMIT, consensusType(t, system).MigrationState)
+	assert.Equal(t, "etcdraft", consensusType(t, system).Type)
+
+	report, err = Verify(configBlock(t, "system", 6, system), []*cb.Block{configBlock(t, "mychannel", 1, channel)})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Phase: PhaseCommitted, Context: 5}, report)
+}
+
+func TestMigrateConfigErrors(t *testing.T) {
+	metadata := raftMetadata(t)
+	started, err := MigrateConfig(channelConfig(true, true), StepStart, nil, 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		config   *cb.Config
+		step     Step
+		metadata *raftproto.ConfigMetadata
+		context  uint64
+		err      string
+	}{
+		{"no capability", channelConfig(true, false), StepStart, nil, 0, "the orderer capability V2_0, required by the migration, is not enabled"},
+		{"start on a standard channel", channelConfig(false, true), StepStart, nil, 0, "the migration is started on the system channel"},
+		{"start twice", started, StepStart, nil, 0, "the migration cannot be started from state MIG_STATE_START"},
+		{"context on the system channel", started, StepContext, metadata, 5, "the context step applies to the standard channels"},
+		{"commit on a standard channel", channelConfig(false, true), StepCommit, metadata, 5, "the commit step applies to the system channel"},
+		{"commit before start", channelConfig(true, true), StepCommit, metadata, 5, "the commit step applies to channels in state MIG_STATE_START, not MIG_STATE_NONE"},
+		{"no context", channelConfig(false, true), StepContext, metadata, 0, "the migration context is required"},
+		{"no metadata", channelConfig(false, true), StepContext, nil, 5, "invalid Raft metadata: the Raft options are not set"},
+		{"unknown step", started, Step("abort"), nil, 0, "unknown migration step abort"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := MigrateConfig(test.config, test.step, test.metadata, test.context)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+
+	migrated, err := MigrateConfig(channelConfig(false, true), StepContext, metadata, 5)
+	require.NoError(t, err)
+	_, err = MigrateConfig(migrated, StepContext, metadata, 5)
+	assert.EqualError(t, err, "the consensus type is etcdraft, only kafka can be migrated")
+
+	_, err = MigrationContext(configBlock(t, "system", 3, channelConfig(true, true)))
+	assert.EqualError(t, err, "the block is not the config block of the system channel which started the migration")
+}
+
+func TestVerifyProblems(t *testing.T) {
+	metadata := raftMetadata(t)
+	system, err := MigrateConfig(channelConfig(true, true), StepStart, nil, 0)
+	require.NoError(t, err)
+	wrongContext, err := MigrateConfig(channelConfig(false, true), StepContext, metadata, 4)
+	require.NoError(t, err)
+
+	report, err := Verify(configBlock(t, "system", 5, system), []*cb.Block{
+		configBlock(t, "wrongcontext", 1, wrongContext),
+		configBlock(t, "nocapability", 0, channelConfig(false, false)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, PhaseStarted, report.Phase)
+	assert.False(t, report.OK())
+	assert.Equal(t, []string{
+		"channel nocapability lacks its context update",
+		"channel wrongcontext carries the migration context 4 instead of 5",
+		"the orderer capability V2_0 is not enabled on channel nocapability",
+	}, report.Problems)
+
+	report, err = Verify(configBlock(t, "system", 0, channelConfig(true, true)), []*cb.Block{configBlock(t, "wrongcontext", 1, wrongContext)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"channel wrongcontext carries a migration context but the migration is not started"}, report.Problems)
+
+	_, err = Verify(configBlock(t, "mychannel", 0, channelConfig(false, true)), nil)
+	assert.EqualError(t, err, "channel mychannel is not the system channel")
+
+	channelBlock := configBlock(t, "mychannel", 0, channelConfig(false, true))
+	_, err = Verify(configBlock(t, "system", 0, channelConfig(true, true)), []*cb.Block{channelBlock, channelBlock})
+	assert.EqualError(t, err, "channel mychannel is given twice, or is a system channel")
+}
+
+func channelConfig(system, capable bool) *cb.Config {
+	ordererGroup := cb.NewConfigGroup()
+	ordererGroup.Values[channelconfig.ConsensusTypeKey] = &cb.ConfigValue{
+		Value: utils.MarshalOrPanic(&ab.ConsensusType{Type: "kafka"}),
+	}
+	if capable {
+		ordererGroup.Values[channelconfig.CapabilitiesKey] = &cb.ConfigValue{
+			Value: utils.MarshalOrPanic(&cb.Capabilities{
+				Capabilities: map[string]*cb.Capability{capabilities.OrdererV2_0: {}},
+			}),
+		}
+	}
+
+	channelGroup := cb.NewConfigGroup()
+	channelGroup.Groups[channelconfig.OrdererGroupKey] = ordererGroup
+	if system {
+		channelGroup.Groups[channelconfig.ConsortiumsGroupKey] = cb.NewConfigGroup()
+	} else {
+		channelGroup.Groups[channelconfig.ApplicationGroupKey] = cb.NewConfigGroup()
+	}
+	return &cb.Config{ChannelGroup: channelGroup}
+}
+
+func configBlock(t *testing.T, channelID string, number uint64, config *cb.Config) *cb.Block {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG, channelID, nil, &cb.ConfigEnvelope{Config: config}, 0, 0)
+	require.NoError(t, err)
+	block := cb.NewBlock(number, nil)
+	block.Data.Data = [][]byte{utils.MarshalOrPanic(env)}
+	return block
+}
+
+func consensusType(t *testing.T, config *cb.Config) *ab.ConsensusType {
+	consensusType := &ab.ConsensusType{}
+	value := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value
+	require.NoError(t, proto.Unmarshal(value, consensusType))
+	return consensusType
+}
+
+func raftMetadata(t *testing.T) *raftproto.ConfigMetadata {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	metadata := &raftproto.ConfigMetadata{
+		Options: &raftproto.Options{
+			TickInterval:      "500ms",
+			ElectionTick:      10,
+			HeartbeatTick:     1,
+			MaxInflightBlocks: 5,
+		},
+	}
+	for _, host := range []string{"orderer0", "orderer1", "orderer2"} {
+		pair, err := ca.NewServerCertKeyPair(host)
+		require.NoError(t, err)
+		metadata.Consenters = append(metadata.Consenters, &raftproto.Consenter{
+			Host:          host,
+			Port:          7050,
+			ServerTlsCert: pair.Cert,
+			ClientTlsCert: pair.Cert,
+		})
+	}
+	return metadata
+}
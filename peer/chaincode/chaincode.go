@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/binary"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
@@ -37,6 +38,7 @@ var platformRegistry = platforms.NewRegistry(
 	&car.Platform{},
 	&java.Platform{},
 	&node.Platform{},
+	&binary.Platform{},
 )
 
 func addFlags(cmd *cobra.Command) {
@@ -84,6 +86,17 @@ var (
 	connectionProfile     string
 	waitForEvent          bool
 	waitForEventTimeout   time.Duration
+	migrate               bool
+	runTests              bool
+	annotations           []string
+	annotationsMap        map[string]string
+	contracts             []string
+	contractsMap          map[string][]byte
+	initRequired          bool
+	isInit                bool
+	minLedgerHeight       uint64
+	expirationHeight      uint64
+	expiresIn             time.Duration
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -121,7 +134,7 @@ func resetFlags() {
 	flags.StringVarP(&channelID, "channelID", "C", "",
 		fmt.Sprint("The channel on which this command should be executed"))
 	flags.StringVarP(&policy, "policy", "P", common.UndefinedParamValue,
-		fmt.Sprint("The endorsement policy associated to this chaincode"))
+		fmt.Sprint("The endorsement policy associated to this chaincode, such as \"AND(Org1MSP.peer, OR(Org2MSP.admin, Org3MSP.peer))\""))
 	flags.StringVarP(&escc, "escc", "E", common.UndefinedParamValue,
 		fmt.Sprint("The name of the endorsement system chaincode to be used for this chaincode"))
 	flags.StringVarP(&vscc, "vscc", "V", common.UndefinedParamValue,
@@ -142,6 +155,24 @@ func resetFlags() {
 		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
 	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
 		fmt.Sprint("Time to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
+	flags.BoolVar(&migrate, "migrate", false,
+		fmt.Sprint("Whether to invoke the Migrate function of the upgraded chaincode instead of its Init function"))
+	flags.BoolVar(&runTests, "runTests", false,
+		fmt.Sprint("Whether to run the tests of the chaincode in the builder of its platform before packaging it"))
+	flags.StringArrayVar(&annotations, "annotation", nil,
+		fmt.Sprint("An annotation of the chaincode definition in the key=value format, which can be repeated. The annotations replace the ones of the previous definition upon upgrade"))
+	flags.StringArrayVar(&contracts, "contract", nil,
+		fmt.Sprint("A contract exposed by the chaincode along with its endorsement policy in the name=policy format, which can be repeated. The functions of the contract are invoked as <name>:<function>"))
+	flags.BoolVar(&initRequired, "init-required", false,
+		fmt.Sprint("Whether the chaincode must be initialized by an invocation of its Init function, with the isInit flag, before any other invocation, rather than upon instantiation or upgrade"))
+	flags.BoolVarP(&isInit, "isInit", "I", false,
+		fmt.Sprint("Whether the invocation initializes a chaincode whose definition requires initialization"))
+	flags.Uint64Var(&minLedgerHeight, "minLedgerHeight", 0,
+		fmt.Sprint("The minimum height the ledger of the channel must have reached on the peers, such as the ledger height of the commit token of an earlier transaction, for them to simulate the proposal"))
+	flags.Uint64Var(&expirationHeight, "expirationHeight", 0,
+		fmt.Sprint("The height of the ledger of the channel from which the transaction is no longer valid, 0 for no expiration height"))
+	flags.DurationVar(&expiresIn, "expiresIn", 0,
+		fmt.Sprint("The duration after which the endorsers and the orderers reject the transaction, 0 for no expiration time"))
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -15,6 +16,7 @@ import (
 	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
@@ -22,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/proposalresponse"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/msp"
 	ccapi "github.com/hyperledger/fabric/peer/chaincode/api"
@@ -60,6 +63,19 @@ func getChaincodeDeploymentSpec(spec *pb.ChaincodeSpec, crtPkg bool) (*pb.Chainc
 			err = errors.WithMessage(err, "error getting chaincode package bytes")
 			return nil, err
 		}
+
+		// the chaincode is built from the path of the spec, so local
+		// directories are recorded as the import path they resolve to
+		spec.ChaincodeId.Path, err = platformRegistry.NormalizePath(spec.Type.String(), spec.ChaincodeId.Path)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error normalizing chaincode path")
+		}
+
+		if runTests {
+			if err = platformRegistry.RunTests(spec.Type.String(), spec.ChaincodeId.Path, codePackageBytes); err != nil {
+				return nil, errors.WithMessage(err, "chaincode tests failed")
+			}
+		}
 	}
 	chaincodeDeploymentSpec := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: codePackageBytes}
 	return chaincodeDeploymentSpec, nil
@@ -79,6 +95,7 @@ func getChaincodeSpec(cmd *cobra.Command) (*pb.ChaincodeSpec, error) {
 	if err := json.Unmarshal([]byte(chaincodeCtorJSON), &input); err != nil {
 		return spec, errors.Wrap(err, "chaincode argument error")
 	}
+	input.IsInit = isInit
 
 	chaincodeLang = strings.ToUpper(chaincodeLang)
 	spec = &pb.ChaincodeSpec{
@@ -153,12 +170,13 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, invoke bool, cf *ChaincodeCmdFac
 }
 
 type collectionConfigJson struct {
-	Name           string `json:"name"`
-	Policy         string `json:"policy"`
-	RequiredCount  int32  `json:"requiredPeerCount"`
-	MaxPeerCount   int32  `json:"maxPeerCount"`
-	BlockToLive    uint64 `json:"blockToLive"`
-	MemberOnlyRead bool   `json:"memberOnlyRead"`
+	Name           string              `json:"name"`
+	Policy         string              `json:"policy"`
+	RequiredCount  int32               `json:"requiredPeerCount"`
+	MaxPeerCount   int32               `json:"maxPeerCount"`
+	BlockToLive    uint64              `json:"blockToLive"`
+	MemberOnlyRead bool                `json:"memberOnlyRead"`
+	AllowedRegions map[string][]string `json:"allowedRegions"`
 }
 
 // getCollectionConfig retrieves the collection configuration
@@ -185,7 +203,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 
 	ccarray := make([]*pcommon.CollectionConfig, 0, len(*cconf))
 	for _, cconfitem := range *cconf {
-		p, err := cauthdsl.FromString(cconfitem.Policy)
+		p, err := cauthdsl.Compile(cconfitem.Policy)
 		if err != nil {
 			return nil, errors.WithMessage(err, fmt.Sprintf("invalid policy %s", cconfitem.Policy))
 		}
@@ -196,6 +214,14 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 			},
 		}
 
+		var allowedRegions map[string]*pcommon.CollectionRegions
+		for mspID, regions := range cconfitem.AllowedRegions {
+			if allowedRegions == nil {
+				allowedRegions = map[string]*pcommon.CollectionRegions{}
+			}
+			allowedRegions[mspID] = &pcommon.CollectionRegions{Regions: regions}
+		}
+
 		cc := &pcommon.CollectionConfig{
 			Payload: &pcommon.CollectionConfig_StaticCollectionConfig{
 				StaticCollectionConfig: &pcommon.StaticCollectionConfig{
@@ -205,6 +231,7 @@ func getCollectionConfigFromBytes(cconfBytes []byte) ([]byte, error) {
 					MaximumPeerCount:  cconfitem.MaxPeerCount,
 					BlockToLive:       cconfitem.BlockToLive,
 					MemberOnlyRead:    cconfitem.MemberOnlyRead,
+					AllowedRegions:    allowedRegions,
 				},
 			},
 		}
@@ -243,9 +270,9 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 		}
 
 		if policy != common.UndefinedParamValue {
-			p, err := cauthdsl.FromString(policy)
+			p, err := cauthdsl.Compile(policy)
 			if err != nil {
-				return errors.Errorf("invalid policy %s", policy)
+				return errors.WithMessage(err, fmt.Sprintf("invalid policy %s", policy))
 			}
 			policyMarshalled = putils.MarshalOrPanic(p)
 		}
@@ -257,6 +284,17 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 				return errors.WithMessage(err, fmt.Sprintf("invalid collection configuration in file %s", collectionsConfigFile))
 			}
 		}
+
+		var err error
+		annotationsMap, err = parseAnnotations(annotations)
+		if err != nil {
+			return err
+		}
+
+		contractsMap, err = parseContracts(contracts)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Check that non-empty chaincode parameters contain only Args as a key.
@@ -460,6 +498,22 @@ func ChaincodeInvokeOrQuery(
 		return nil, errors.WithMessage(err, fmt.Sprintf("error creating proposal for %s", funcName))
 	}
 
+	if minLedgerHeight > 0 {
+		if err := putils.SetProposalMinLedgerHeight(prop, minLedgerHeight); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error setting the minimum ledger height of the proposal for %s", funcName))
+		}
+	}
+
+	if expirationHeight > 0 || expiresIn > 0 {
+		var expirationTime time.Time
+		if expiresIn > 0 {
+			expirationTime = time.Now().Add(expiresIn)
+		}
+		if err := putils.SetProposalExpiration(prop, expirationHeight, expirationTime); err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error setting the expiration of the proposal for %s", funcName))
+		}
+	}
+
 	signedProp, err := putils.GetSignedProposal(prop, signer)
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("error creating signed proposal for %s", funcName))
@@ -486,6 +540,9 @@ func ChaincodeInvokeOrQuery(
 			if proposalResp.Response.Status >= shim.ERRORTHRESHOLD {
 				return proposalResp, nil
 			}
+			if err := compareResponses(responses); err != nil {
+				return proposalResp, err
+			}
 			// assemble a signed transaction (it's an Envelope message)
 			env, err := putils.CreateSignedTx(prop, signer, responses...)
 			if err != nil {
@@ -524,6 +581,39 @@ func ChaincodeInvokeOrQuery(
 	return proposalResp, nil
 }
 
+// compareResponses returns an error listing the differences of the proposal
+// responses of the endorsers with the one of the first endorser, if any, so
+// that the namespaces and keys responsible for a mismatch are pinpointed
+func compareResponses(responses []*pb.ProposalResponse) error {
+	match := true
+	for _, r := range responses[1:] {
+		match = match && bytes.Equal(responses[0].Payload, r.Payload)
+	}
+	if match {
+		return nil
+	}
+
+	diffs, err := proposalresponse.Compare(responses...)
+	if err != nil || len(diffs) == 0 {
+		// leave it to the assembly of the transaction to report the mismatch
+		logger.Debugf("could not compare the proposal responses: %v", err)
+		return nil
+	}
+
+	endorsers := peerAddresses
+	if len(endorsers) != len(responses) {
+		endorsers = nil
+		for i := range responses {
+			endorsers = append(endorsers, fmt.Sprintf("endorser %d", i))
+		}
+	}
+	lines := []string{fmt.Sprintf("the proposal responses of the endorsers differ from the one of %s", endorsers[0])}
+	for _, d := range diffs {
+		lines = append(lines, fmt.Sprintf("  %s: %s", endorsers[d.Response], d))
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
+
 // deliverGroup holds all of the information needed to connect
 // to a set of peers to wait for the interested txid to be
 // committed to the ledgers of all peers. This functionality
@@ -663,7 +753,10 @@ func (dg *deliverGroup) ClientWait(dc *deliverClient) {
 			filteredTransactions := r.FilteredBlock.FilteredTransactions
 			for _, tx := range filteredTransactions {
 				if tx.Txid == dg.TxID {
-					logger.Infof("txid [%s] committed with status (%s) at %s", dg.TxID, tx.TxValidationCode, dc.Address)
+					// the height of the ledger is the minimum ledger height of the next
+					// proposals of the client to read the writes of the transaction
+					logger.Infof("txid [%s] committed with status (%s) at %s, commit token: ledger height %d, block hash %x",
+						dg.TxID, tx.TxValidationCode, dc.Address, r.FilteredBlock.Number+1, r.FilteredBlock.BlockHash)
 					return
 				}
 			}
@@ -730,3 +823,46 @@ func createDeliverEnvelope(channelID string, certificate tls.Certificate) *pcomm
 
 	return env
 }
+
+// parseAnnotations parses annotations in the key=value format
+func parseAnnotations(annotations []string) (map[string]string, error) {
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+	parsed := map[string]string{}
+	for _, annotation := range annotations {
+		kv := strings.SplitN(annotation, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid annotation %s, expected the key=value format", annotation)
+		}
+		if _, exists := parsed[kv[0]]; exists {
+			return nil, errors.Errorf("duplicate annotation %s", kv[0])
+		}
+		parsed[kv[0]] = kv[1]
+	}
+	return parsed, nil
+}
+
+// parseContracts parses contracts in the name=policy format and compiles
+// their endorsement policy
+func parseContracts(contracts []string) (map[string][]byte, error) {
+	if len(contracts) == 0 {
+		return nil, nil
+	}
+	parsed := map[string][]byte{}
+	for _, contract := range contracts {
+		kv := strings.SplitN(contract, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errors.Errorf("invalid contract %s, expected the name=policy format", contract)
+		}
+		if _, exists := parsed[kv[0]]; exists {
+			return nil, errors.Errorf("duplicate contract %s", kv[0])
+		}
+		p, err := cauthdsl.Compile(kv[1])
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("invalid policy %s of contract %s", kv[1], kv[0]))
+		}
+		parsed[kv[0]] = putils.MarshalOrPanic(p)
+	}
+	return parsed, nil
+}
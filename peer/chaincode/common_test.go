@@ -87,6 +87,29 @@ func TestCheckChaincodeCmdParamsEmptyCtor(t *testing.T) {
 	require.Error(result)
 }
 
+func TestCheckChaincodeCmdParamsPolicy(t *testing.T) {
+	chaincodeCtorJSON = `{ "Args":["func", "param"] }`
+	chaincodePath = "some/path"
+	chaincodeName = "somename"
+	chaincodeVersion = "1.0"
+	defer func() {
+		chaincodeVersion = common.UndefinedParamValue
+		policy = common.UndefinedParamValue
+		policyMarshalled = nil
+	}()
+	cmd := &cobra.Command{Use: instantiateCmdName}
+
+	policy = "AND(Org1MSP.peer, 2 OF (Org2MSP.admin, Org3MSP.peer[ou=ops], Org4MSP.peer))"
+	require.NoError(t, checkChaincodeCmdParams(cmd))
+	expected, err := cauthdsl.Compile(policy)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MarshalOrPanic(expected), policyMarshalled)
+
+	policy = "AND(Org1MSP.peer, Org2MSP.boss)"
+	err = checkChaincodeCmdParams(cmd)
+	assert.EqualError(t, err, "invalid policy AND(Org1MSP.peer, Org2MSP.boss): invalid role boss of principal Org2MSP.boss at position 18")
+}
+
 func TestCheckValidJSON(t *testing.T) {
 	validJSON := `{"Args":["a","b","c"]}`
 	input := &pb.ChaincodeInput{}
@@ -183,7 +206,10 @@ const sampleCollectionConfigGood = `[
 		"requiredPeerCount": 3,
 		"maxPeerCount": 483279847,
 		"blockToLive":10,
-		"memberOnlyRead": true
+		"memberOnlyRead": true,
+		"allowedRegions": {
+			"A": ["eu-west", "eu-central"]
+		}
 	}
 ]`
 
@@ -210,6 +236,7 @@ func TestCollectionParsing(t *testing.T) {
 	assert.Equal(t, pol, conf.MemberOrgsPolicy.GetSignaturePolicy())
 	assert.Equal(t, 10, int(conf.BlockToLive))
 	assert.Equal(t, true, conf.MemberOnlyRead)
+	assert.Equal(t, map[string]*common2.CollectionRegions{"A": {Regions: []string{"eu-west", "eu-central"}}}, conf.AllowedRegions)
 	t.Logf("conf=%s", conf)
 
 	cc, err = getCollectionConfigFromBytes([]byte(sampleCollectionConfigBad))
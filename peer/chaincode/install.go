@@ -50,6 +50,7 @@ func installCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"path",
 		"name",
 		"version",
+		"runTests",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -44,6 +44,9 @@ func instantiateCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"escc",
 		"vscc",
 		"collections-config",
+		"annotation",
+		"contract",
+		"init-required",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -64,6 +67,9 @@ func instantiate(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envel
 	if err != nil {
 		return nil, fmt.Errorf("error getting chaincode code %s: %s", chaincodeName, err)
 	}
+	cds.Annotations = annotationsMap
+	cds.Contracts = contractsMap
+	cds.InitRequired = initRequired
 
 	creator, err := cf.Signer.Serialize()
 	if err != nil {
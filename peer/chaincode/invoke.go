@@ -29,10 +29,14 @@ func invokeCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	flagList := []string{
 		"name",
 		"ctor",
+		"isInit",
 		"channelID",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
+		"minLedgerHeight",
+		"expirationHeight",
+		"expiresIn",
 		"waitForEvent",
 		"waitForEventTimeout",
 	}
@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/common/flogging/floggingtest"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/msp"
@@ -22,6 +23,8 @@ import (
 	"github.com/hyperledger/fabric/peer/common/api"
 	cmock "github.com/hyperledger/fabric/peer/common/mock"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -408,3 +411,160 @@ func createFilteredBlock(txIDs ...string) *pb.FilteredBlock {
 	}
 	return fb
 }
+
+func TestInvokeCmdIsInit(t *testing.T) {
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	invoke := func(args ...string) *pb.ChaincodeInput {
+		resetFlags()
+		cmd := invokeCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs(append([]string{"-n", "example02", "-C", "mychannel", "-c", "{\"Args\": [\"init\",\"a\",\"100\"]}"}, args...))
+		assert.NoError(t, cmd.Execute())
+
+		prop, err := utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+		assert.NoError(t, err)
+		cis, err := utils.GetChaincodeInvocationSpec(prop)
+		assert.NoError(t, err)
+		return cis.ChaincodeSpec.Input
+	}
+
+	assert.False(t, invoke().IsInit)
+	assert.True(t, invoke("--isInit").IsInit)
+	assert.True(t, invoke("-I").IsInit)
+}
+
+func TestInvokeCmdMismatchingResponses(t *testing.T) {
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	response := func(value string) *pb.ProposalResponse {
+		results := utils.MarshalOrPanic(&rwset.TxReadWriteSet{
+			DataModel: rwset.TxReadWriteSet_KV,
+			NsRwset: []*rwset.NsReadWriteSet{{
+				Namespace: "example02",
+				Rwset:     utils.MarshalOrPanic(&kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a", Value: []byte(value)}}}),
+			}},
+		})
+		return &pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200},
+			Endorsement: &pb.Endorsement{},
+			Payload: utils.MarshalOrPanic(&pb.ProposalResponsePayload{
+				Extension: utils.MarshalOrPanic(&pb.ChaincodeAction{Results: results, Response: &pb.Response{Status: 200}}),
+			}),
+		}
+	}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{
+			common.GetMockEndorserClient(response("90"), nil),
+			common.GetMockEndorserClient(response("90"), nil),
+			common.GetMockEndorserClient(response("91"), nil),
+		},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	cmd := invokeCmd(mockCF)
+	addFlags(cmd)
+	cmd.SetArgs([]string{"-n", "example02", "-C", "mychannel", "-c", "{\"Args\": [\"invoke\",\"a\",\"b\",\"10\"]}"})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the proposal responses of the endorsers differ from the one of endorser 0\n"+
+		`  endorser 2: rwset.writes of namespace example02, key "a": "90" != "91"`)
+}
+
+func TestInvokeCmdMinLedgerHeight(t *testing.T) {
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	minHeight := func(args ...string) uint64 {
+		resetFlags()
+		cmd := invokeCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs(append([]string{"-n", "example02", "-C", "mychannel", "-c", "{\"Args\": [\"invoke\",\"a\",\"b\",\"10\"]}"}, args...))
+		assert.NoError(t, cmd.Execute())
+
+		prop, err := utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+		assert.NoError(t, err)
+		hdr, err := utils.GetHeader(prop.Header)
+		assert.NoError(t, err)
+		hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+		assert.NoError(t, err)
+		assert.Equal(t, "example02", hdrExt.ChaincodeId.Name)
+		return hdrExt.MinLedgerHeight
+	}
+
+	assert.Equal(t, uint64(0), minHeight())
+	assert.Equal(t, uint64(12), minHeight("--minLedgerHeight", "12"))
+}
+
+func TestInvokeCmdExpiration(t *testing.T) {
+	defer resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	channelHeader := func(args ...string) *cb.ChannelHeader {
+		resetFlags()
+		cmd := invokeCmd(mockCF)
+		addFlags(cmd)
+		cmd.SetArgs(append([]string{"-n", "example02", "-C", "mychannel", "-c", "{\"Args\": [\"invoke\",\"a\",\"b\",\"10\"]}"}, args...))
+		assert.NoError(t, cmd.Execute())
+
+		prop, err := utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+		assert.NoError(t, err)
+		hdr, err := utils.GetHeader(prop.Header)
+		assert.NoError(t, err)
+		chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+		assert.NoError(t, err)
+		return chdr
+	}
+
+	chdr := channelHeader()
+	assert.Equal(t, uint64(0), chdr.ExpirationHeight)
+	assert.Nil(t, chdr.ExpirationTime)
+
+	before := time.Now()
+	chdr = channelHeader("--expirationHeight", "12", "--expiresIn", "1h")
+	assert.Equal(t, uint64(12), chdr.ExpirationHeight)
+	expirationTime, err := ptypes.Timestamp(chdr.ExpirationTime)
+	assert.NoError(t, err)
+	assert.False(t, expirationTime.Before(before.Add(time.Hour)))
+	assert.True(t, expirationTime.Before(time.Now().Add(time.Hour+time.Second)))
+}
@@ -12,6 +12,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -136,6 +137,9 @@ func (cci ccInfo) String() string {
 		if isBytes(f) {
 			val = hex.EncodeToString(f.Bytes())
 		}
+		if f.Kind() == reflect.Map {
+			val = formatMap(f)
+		}
 		if len(val) == 0 {
 			continue
 		}
@@ -152,3 +156,13 @@ func (cci ccInfo) String() string {
 func isBytes(v reflect.Value) bool {
 	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
 }
+
+// formatMap formats a map of strings as a sorted list of key=value pairs
+func formatMap(v reflect.Value) string {
+	var pairs []string
+	for _, key := range v.MapKeys() {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key.String(), v.MapIndex(key).String()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
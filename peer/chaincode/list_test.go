@@ -151,3 +151,14 @@ func TestString(t *testing.T) {
 	}
 	assert.Equal(t, "Name: ccName, Version: 1.0, Input: input, Escc: escc, Vscc: vscc, Id: 0102030405", ccInf.String())
 }
+
+func TestStringWithAnnotations(t *testing.T) {
+	ccInf := &ccInfo{
+		ChaincodeInfo: &pb.ChaincodeInfo{
+			Name:        "ccName",
+			Version:     "1.0",
+			Annotations: map[string]string{"slo.tier": "gold", "owner": "payments"},
+		},
+	}
+	assert.Equal(t, "Name: ccName, Version: 1.0, Annotations: owner=payments,slo.tier=gold", ccInf.String())
+}
@@ -69,6 +69,7 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 		"path",
 		"name",
 		"version",
+		"runTests",
 	}
 	attachFlags(chaincodePackageCmd, flagList)
 
@@ -80,7 +81,7 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 }
 
 func getInstantiationPolicy(policy string) (*pcommon.SignaturePolicyEnvelope, error) {
-	p, err := cauthdsl.FromString(policy)
+	p, err := cauthdsl.Compile(policy)
 	if err != nil {
 		return nil, fmt.Errorf("Invalid policy %s, err %s", policy, err)
 	}
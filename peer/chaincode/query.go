@@ -33,6 +33,7 @@ func queryCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
+		"minLedgerHeight",
 	}
 	attachFlags(chaincodeQueryCmd, flagList)
 
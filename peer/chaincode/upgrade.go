@@ -46,6 +46,10 @@ func upgradeCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"tlsRootCertFiles",
 		"connectionProfile",
 		"collections-config",
+		"migrate",
+		"annotation",
+		"contract",
+		"init-required",
 	}
 	attachFlags(chaincodeUpgradeCmd, flagList)
 
@@ -63,6 +67,10 @@ func upgrade(cmd *cobra.Command, cf *ChaincodeCmdFactory) (*protcommon.Envelope,
 	if err != nil {
 		return nil, fmt.Errorf("error getting chaincode code %s: %s", chaincodeName, err)
 	}
+	cds.Migrate = migrate
+	cds.Annotations = annotationsMap
+	cds.Contracts = contractsMap
+	cds.InitRequired = initRequired
 
 	creator, err := cf.Signer.Serialize()
 	if err != nil {
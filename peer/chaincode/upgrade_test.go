@@ -7,15 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 )
 
 func TestUpgradeCmd(t *testing.T) {
@@ -55,6 +60,173 @@ func TestUpgradeCmd(t *testing.T) {
 	assert.NoError(t, err, "'peer chaincode upgrade' command failed")
 }
 
+type capturingEndorserClient struct {
+	pb.EndorserClient
+	signedProp *pb.SignedProposal
+}
+
+func (c *capturingEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	c.signedProp = in
+	return c.EndorserClient.ProcessProposal(ctx, in, opts...)
+}
+
+func TestUpgradeCmdWithMigration(t *testing.T) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	resetFlags()
+	cmd := upgradeCmd(mockCF)
+	addFlags(cmd)
+
+	args := []string{"-C", "mychannel", "-n", "example02", "-p", "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd",
+		"-v", "anotherversion", "-c", "{\"Function\":\"init\",\"Args\": [\"param\",\"1\"]}", "--migrate"}
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+	assert.NoError(t, err, "'peer chaincode upgrade' command failed")
+
+	prop, err := utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	cds := &pb.ChaincodeDeploymentSpec{}
+	err = proto.Unmarshal(cis.ChaincodeSpec.Input.Args[2], cds)
+	assert.NoError(t, err)
+	assert.True(t, cds.Migrate)
+	assert.False(t, cds.InitRequired)
+
+	resetFlags()
+	cmd = upgradeCmd(mockCF)
+	addFlags(cmd)
+	args = []string{"-C", "mychannel", "-n", "example02", "-p", "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd",
+		"-v", "anotherversion", "-c", "{\"Function\":\"init\",\"Args\": [\"param\",\"1\"]}", "--init-required"}
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+	assert.NoError(t, err, "'peer chaincode upgrade' command failed")
+
+	prop, err = utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	cis, err = utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	cds = &pb.ChaincodeDeploymentSpec{}
+	err = proto.Unmarshal(cis.ChaincodeSpec.Input.Args[2], cds)
+	assert.NoError(t, err)
+	assert.False(t, cds.Migrate)
+	assert.True(t, cds.InitRequired)
+}
+
+func TestUpgradeCmdWithAnnotations(t *testing.T) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	resetFlags()
+	cmd := upgradeCmd(mockCF)
+	addFlags(cmd)
+	args := []string{"-C", "mychannel", "-n", "example02", "-p", "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd",
+		"-v", "anotherversion", "-c", "{\"Function\":\"init\",\"Args\": [\"param\",\"1\"]}", "--annotation", "invalid"}
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+	assert.EqualError(t, err, "invalid annotation invalid, expected the key=value format")
+
+	resetFlags()
+	cmd = upgradeCmd(mockCF)
+	addFlags(cmd)
+	args = []string{"-C", "mychannel", "-n", "example02", "-p", "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd",
+		"-v", "anotherversion", "-c", "{\"Function\":\"init\",\"Args\": [\"param\",\"1\"]}",
+		"--annotation", "owner=payments", "--annotation", "commit=8a3f2c1"}
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+	assert.NoError(t, err, "'peer chaincode upgrade' command failed")
+
+	prop, err := utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	cds := &pb.ChaincodeDeploymentSpec{}
+	err = proto.Unmarshal(cis.ChaincodeSpec.Input.Args[2], cds)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"owner": "payments", "commit": "8a3f2c1"}, cds.Annotations)
+}
+
+func TestUpgradeCmdWithContracts(t *testing.T) {
+	signer, err := common.GetDefaultSigner()
+	if err != nil {
+		t.Fatalf("Get default signer error: %v", err)
+	}
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClient := &capturingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF := &ChaincodeCmdFactory{
+		EndorserClients: []pb.EndorserClient{endorserClient},
+		Signer:          signer,
+		BroadcastClient: common.GetMockBroadcastClient(nil),
+	}
+
+	upgrade := func(contracts ...string) error {
+		resetFlags()
+		cmd := upgradeCmd(mockCF)
+		addFlags(cmd)
+		args := []string{"-C", "mychannel", "-n", "example02", "-p", "github.com/hyperledger/fabric/examples/chaincode/go/example02/cmd",
+			"-v", "anotherversion", "-c", "{\"Function\":\"init\",\"Args\": [\"param\",\"1\"]}"}
+		for _, contract := range contracts {
+			args = append(args, "--contract", contract)
+		}
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	err = upgrade("transfers")
+	assert.EqualError(t, err, "invalid contract transfers, expected the name=policy format")
+	err = upgrade("transfers=Org1MSP.member", "transfers=Org2MSP.member")
+	assert.EqualError(t, err, "duplicate contract transfers")
+	err = upgrade("transfers=AND(Org1MSP.member")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid policy AND(Org1MSP.member of contract transfers")
+
+	err = upgrade("transfers=AND(Org1MSP.member, Org2MSP.member)", "audit=Org3MSP.peer")
+	assert.NoError(t, err, "'peer chaincode upgrade' command failed")
+
+	prop, err := utils.GetProposal(endorserClient.signedProp.ProposalBytes)
+	assert.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	cds := &pb.ChaincodeDeploymentSpec{}
+	err = proto.Unmarshal(cis.ChaincodeSpec.Input.Args[2], cds)
+	assert.NoError(t, err)
+	assert.Len(t, cds.Contracts, 2)
+	transfersPolicy, err := cauthdsl.Compile("AND(Org1MSP.member, Org2MSP.member)")
+	assert.NoError(t, err)
+	assert.Equal(t, utils.MarshalOrPanic(transfersPolicy), cds.Contracts["transfers"])
+}
+
 func TestUpgradeCmdEndorseFail(t *testing.T) {
 	signer, err := common.GetDefaultSigner()
 	if err != nil {
@@ -40,6 +40,12 @@ var (
 	channelTxFile string
 	outputBlock   string
 	timeout       time.Duration
+
+	// provision related variables
+	profilePath string
+
+	// fetchconfig related variables
+	decodeConfig bool
 )
 
 // Cmd returns the cobra command for Node
@@ -48,11 +54,15 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 
 	channelCmd.AddCommand(createCmd(cf))
 	channelCmd.AddCommand(fetchCmd(cf))
+	channelCmd.AddCommand(fetchconfigCmd(cf))
+	channelCmd.AddCommand(diffconfigCmd())
 	channelCmd.AddCommand(joinCmd(cf))
+	channelCmd.AddCommand(leaveCmd(cf))
 	channelCmd.AddCommand(listCmd(cf))
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(provisionCmd(cf))
 
 	return channelCmd
 }
@@ -77,6 +87,8 @@ func resetFlags() {
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.StringVarP(&outputBlock, "outputBlock", "", common.UndefinedParamValue, `The path to write the genesis block for the channel. (default ./<channelID>.block)`)
 	flags.DurationVarP(&timeout, "timeout", "t", 10*time.Second, "Channel creation timeout")
+	flags.StringVarP(&profilePath, "profile", "", "", "Path to the YAML channel profile listing the organizations, policies and capabilities of the channel to provision")
+	flags.BoolVarP(&decodeConfig, "decode", "", false, "Write the config of the channel as JSON rather than its config block")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -92,8 +104,8 @@ func attachFlags(cmd *cobra.Command, names []string) {
 
 var channelCmd = &cobra.Command{
 	Use:   "channel",
-	Short: "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
-	Long:  "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo.",
+	Short: "Operate a channel: create|fetch|fetchconfig|diffconfig|join|list|update|signconfigtx|getinfo|provision.",
+	Long:  "Operate a channel: create|fetch|fetchconfig|diffconfig|join|list|update|signconfigtx|getinfo|provision.",
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		common.InitCmd(cmd, args)
 		common.SetOrdererEnv(cmd, args)
@@ -112,12 +124,32 @@ type deliverClientIntf interface {
 // ChannelCmdFactory holds the clients used by ChannelCmdFactory
 type ChannelCmdFactory struct {
 	EndorserClient   pb.EndorserClient
+	AdminClient      pb.AdminClient
 	Signer           msp.SigningIdentity
 	BroadcastClient  common.BroadcastClient
 	DeliverClient    deliverClientIntf
 	BroadcastFactory BroadcastClientFactory
 }
 
+// InitAdminCmdFactory init the ChannelCmdFactory with a client to the admin service of the peer
+func InitAdminCmdFactory() (*ChannelCmdFactory, error) {
+	var err error
+	cf := &ChannelCmdFactory{}
+
+	cf.Signer, err = common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting default signer")
+	}
+
+	// creating an AdminClient connects using the values of "peer.address"
+	// and "peer.tls.rootcert.file"
+	cf.AdminClient, err = common.GetAdminClient()
+	if err != nil {
+		return nil, errors.WithMessage(err, "error getting admin client for channel")
+	}
+	return cf, nil
+}
+
 // InitCmdFactory init the ChannelCmdFactory with clients to endorser and orderer according to params
 func InitCmdFactory(isEndorserRequired, isPeerDeliverRequired, isOrdererRequired bool) (*ChannelCmdFactory, error) {
 	if isPeerDeliverRequired && isOrdererRequired {
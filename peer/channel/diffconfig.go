@@ -0,0 +1,170 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func diffconfigCmd() *cobra.Command {
+	diffconfigCmd := &cobra.Command{
+		Use:   "diffconfig <blockA> <blockB>",
+		Short: "Compare the configs of two config blocks",
+		Long: "Compare the configs held by two config blocks, or output by fetchconfig --decode, and write the policies, " +
+			"MSPs and values added, removed or modified from the first to the second as JSON.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return diffconfig(cmd, args)
+		},
+	}
+
+	return diffconfigCmd
+}
+
+// configChange is a part of a config added, removed or modified from a config
+// to another, located by the path of its field in the JSON of the configs
+type configChange struct {
+	Path   string      `json:"path"`
+	Change string      `json:"change"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+}
+
+const (
+	changeAdded    = "added"
+	changeRemoved  = "removed"
+	changeModified = "modified"
+)
+
+func diffconfig(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("two config blocks must be provided")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var configs [2]interface{}
+	for i, file := range args {
+		config, err := readConfig(file)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to read the config of %s", file))
+		}
+		configs[i] = config
+	}
+
+	changes := diffJSON("", configs[0], configs[1], []configChange{})
+	output, err := json.MarshalIndent(changes, "", "\t")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(output))
+	return nil
+}
+
+// readConfig reads a config block, or the JSON of a config, from a file and
+// returns the JSON of its config, decoded as generic values
+func readConfig(file string) (interface{}, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &cb.Config{}
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := protolator.DeepUnmarshalJSON(bytes.NewReader(data), config); err != nil {
+			return nil, errors.Wrap(err, "failed to parse the JSON of the config")
+		}
+	} else {
+		block := &cb.Block{}
+		if err := proto.Unmarshal(data, block); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal the block")
+		}
+		if config, err = configFromBlock(block); err != nil {
+			return nil, err
+		}
+	}
+
+	// the JSON is marshaled again from the config so that both configs are
+	// compared in the same form
+	b, err := marshalConfigJSON(config)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the JSON of the config")
+	}
+	return value, nil
+}
+
+// diffJSON appends the changes from a JSON value to another to the given
+// changes, descending into the objects and arrays they both hold at a path
+func diffJSON(path string, before, after interface{}, changes []configChange) []configChange {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(b)+len(a))
+			for key := range b {
+				keys = append(keys, key)
+			}
+			for key := range a {
+				if _, ok := b[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				keyPath := key
+				if path != "" {
+					keyPath = path + "." + key
+				}
+				bv, inBefore := b[key]
+				av, inAfter := a[key]
+				switch {
+				case !inAfter:
+					changes = append(changes, configChange{Path: keyPath, Change: changeRemoved, Before: bv})
+				case !inBefore:
+					changes = append(changes, configChange{Path: keyPath, Change: changeAdded, After: av})
+				default:
+					changes = diffJSON(keyPath, bv, av, changes)
+				}
+			}
+			return changes
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			for i := 0; i < len(b) || i < len(a); i++ {
+				indexPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(a):
+					changes = append(changes, configChange{Path: indexPath, Change: changeRemoved, Before: b[i]})
+				case i >= len(b):
+					changes = append(changes, configChange{Path: indexPath, Change: changeAdded, After: a[i]})
+				default:
+					changes = diffJSON(indexPath, b[i], a[i], changes)
+				}
+			}
+			return changes
+		}
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		changes = append(changes, configChange{Path: path, Change: changeModified, Before: before, After: after})
+	}
+	return changes
+}
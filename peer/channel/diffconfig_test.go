@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfig(t *testing.T) {
+	block, err := configtxtest.MakeGenesisBlock("mychannel")
+	require.NoError(t, err)
+	config, err := configFromBlock(block)
+	require.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "diffconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	updated := proto.Clone(config).(*cb.Config)
+	updated.Sequence = 1
+	groups := updated.ChannelGroup.Groups
+	groups["Application"].Groups["Org2MSP"] = groups["Application"].Groups["SampleOrg"]
+	delete(groups, "Consortiums")
+	groups["Orderer"].Values["BatchTimeout"].Value = putils.MarshalOrPanic(&ab.BatchTimeout{Timeout: "5s"})
+
+	blockFile := filepath.Join(tempDir, "before.block")
+	require.NoError(t, ioutil.WriteFile(blockFile, putils.MarshalOrPanic(block), 0644))
+	jsonFile := filepath.Join(tempDir, "after.json")
+	b, err := marshalConfigJSON(updated)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(jsonFile, b, 0644))
+
+	var out bytes.Buffer
+	cmd := diffconfigCmd()
+	cmd.SetOutput(&out)
+	cmd.SetArgs([]string{blockFile, jsonFile})
+	require.NoError(t, cmd.Execute())
+
+	var changes []configChange
+	require.NoError(t, json.Unmarshal(out.Bytes(), &changes))
+	require.Len(t, changes, 4)
+	assert.Equal(t, "channel_group.groups.Application.groups.Org2MSP", changes[0].Path)
+	assert.Equal(t, changeAdded, changes[0].Change)
+	assert.Nil(t, changes[0].Before)
+	assert.NotNil(t, changes[0].After)
+	assert.Equal(t, "channel_group.groups.Consortiums", changes[1].Path)
+	assert.Equal(t, changeRemoved, changes[1].Change)
+	assert.NotNil(t, changes[1].Before)
+	assert.Nil(t, changes[1].After)
+	assert.Equal(t, configChange{
+		Path:   "channel_group.groups.Orderer.values.BatchTimeout.value.timeout",
+		Change: changeModified,
+		Before: "2s",
+		After:  "5s",
+	}, changes[2])
+	assert.Equal(t, configChange{Path: "sequence", Change: changeModified, Before: "0", After: "1"}, changes[3])
+
+	// the same configs do not differ
+	out.Reset()
+	cmd.SetArgs([]string{blockFile, blockFile})
+	require.NoError(t, cmd.Execute())
+	assert.Equal(t, "[]\n", out.String())
+}
+
+func TestDiffConfigErrors(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "diffconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	notConfig := filepath.Join(tempDir, "block")
+	require.NoError(t, ioutil.WriteFile(notConfig, putils.MarshalOrPanic(createTestBlock()), 0644))
+	badJSON := filepath.Join(tempDir, "json")
+	require.NoError(t, ioutil.WriteFile(badJSON, []byte(`{"sequence": true}`), 0644))
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "missing block", args: []string{notConfig}, expectedError: "two config blocks must be provided"},
+		{name: "missing file", args: []string{filepath.Join(tempDir, "missing"), notConfig}, expectedError: "no such file or directory"},
+		{name: "not a config block", args: []string{notConfig, notConfig}, expectedError: "failed to read the config of " + notConfig},
+		{name: "bad JSON", args: []string{badJSON, notConfig}, expectedError: "failed to parse the JSON of the config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := diffconfigCmd()
+			cmd.SetOutput(ioutil.Discard)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	before := map[string]interface{}{
+		"policy": map[string]interface{}{"rule": "ANY", "identities": []interface{}{"a", "b"}},
+		"value":  float64(1),
+	}
+	after := map[string]interface{}{
+		"policy": map[string]interface{}{"rule": "MAJORITY", "identities": []interface{}{"a", "c", "d"}},
+		"value":  "1",
+	}
+
+	changes := diffJSON("", before, after, []configChange{})
+	assert.Equal(t, []configChange{
+		{Path: "policy.identities[1]", Change: changeModified, Before: "b", After: "c"},
+		{Path: "policy.identities[2]", Change: changeAdded, After: "d"},
+		{Path: "policy.rule", Change: changeModified, Before: "ANY", After: "MAJORITY"},
+		{Path: "value", Change: changeModified, Before: float64(1), After: "1"},
+	}, changes)
+
+	changes = diffJSON("", after, before, []configChange{})
+	assert.Equal(t, configChange{Path: "policy.identities[2]", Change: changeRemoved, Before: "d"}, changes[1])
+}
@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func fetchconfigCmd(cf *ChannelCmdFactory) *cobra.Command {
+	fetchconfigCmd := &cobra.Command{
+		Use:   "fetchconfig [outputfile]",
+		Short: "Fetch the latest config block of a channel",
+		Long: "Fetch the latest config block of a channel, writing it to a file. With --decode, the config of the channel " +
+			"is written as JSON instead, with its policies, MSPs and values decoded.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fetchconfig(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"decode",
+	}
+	attachFlags(fetchconfigCmd, flagList)
+
+	return fetchconfigCmd
+}
+
+func fetchconfig(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if len(args) > 1 {
+		return fmt.Errorf("trailing args detected")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	// default to fetching from orderer
+	ordererRequired := OrdererRequired
+	peerDeliverRequired := PeerDeliverNotRequired
+	if len(strings.Split(common.OrderingEndpoint, ":")) != 2 {
+		// if no orderer endpoint supplied, connect to peer's deliver service
+		ordererRequired = OrdererNotRequired
+		peerDeliverRequired = PeerDeliverRequired
+	}
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserNotRequired, peerDeliverRequired, ordererRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	newest, err := cf.DeliverClient.GetNewestBlock()
+	if err != nil {
+		return err
+	}
+	lc, err := utils.GetLastConfigIndexFromBlock(newest)
+	if err != nil {
+		return err
+	}
+	block, err := cf.DeliverClient.GetSpecifiedBlock(lc)
+	if err != nil {
+		return err
+	}
+
+	file := channelID + "_config.block"
+	if decodeConfig {
+		file = channelID + "_config.json"
+	}
+	if len(args) == 1 {
+		file = args[0]
+	}
+
+	var b []byte
+	if decodeConfig {
+		config, err := configFromBlock(block)
+		if err != nil {
+			return err
+		}
+		b, err = marshalConfigJSON(config)
+		if err != nil {
+			return err
+		}
+	} else {
+		b, err = proto.Marshal(block)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ioutil.WriteFile(file, b, 0644)
+}
+
+// configFromBlock returns the config of the channel held by a config block
+func configFromBlock(block *cb.Block) (*cb.Config, error) {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the config block")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the config block")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("failed to read the config block: missing payload header")
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the config block")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return nil, errors.Errorf("block %d is not a config block", block.GetHeader().GetNumber())
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read the config block")
+	}
+	return configEnv.Config, nil
+}
+
+// marshalConfigJSON converts a config to JSON, decoding its policies, MSPs and
+// values
+func marshalConfigJSON(config *cb.Config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := protolator.DeepMarshalJSON(&buf, config); err != nil {
+		return nil, errors.Wrap(err, "failed to convert the config to JSON")
+	}
+	return buf.Bytes(), nil
+}
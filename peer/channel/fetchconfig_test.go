@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchConfig(t *testing.T) {
+	defer resetFlags()
+	InitMSP()
+	resetFlags()
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+	block, err := configtxtest.MakeGenesisBlock("mychannel")
+	require.NoError(t, err)
+	mockCF := &ChannelCmdFactory{
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+		DeliverClient:    getMockDeliverClientWithBlock("mychannel", block),
+	}
+
+	tempDir, err := ioutil.TempDir("", "fetchconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	t.Run("Block", func(t *testing.T) {
+		resetFlags()
+		cmd := fetchconfigCmd(mockCF)
+		AddFlags(cmd)
+		output := filepath.Join(tempDir, "config.block")
+		cmd.SetArgs([]string{"-c", "mychannel", output})
+		require.NoError(t, cmd.Execute())
+
+		data, err := ioutil.ReadFile(output)
+		require.NoError(t, err)
+		fetched := &cb.Block{}
+		require.NoError(t, proto.Unmarshal(data, fetched))
+		assert.True(t, proto.Equal(block, fetched))
+	})
+
+	t.Run("Decode", func(t *testing.T) {
+		resetFlags()
+		cmd := fetchconfigCmd(mockCF)
+		AddFlags(cmd)
+		output := filepath.Join(tempDir, "config.json")
+		cmd.SetArgs([]string{"-c", "mychannel", "--decode", output})
+		require.NoError(t, cmd.Execute())
+
+		data, err := ioutil.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"ConsensusType"`)
+		config, err := configFromBlock(block)
+		require.NoError(t, err)
+		expected, err := marshalConfigJSON(config)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(data))
+		require.NoError(t, protolator.DeepUnmarshalJSON(bytes.NewReader(data), &cb.Config{}))
+	})
+
+	t.Run("NotConfigBlock", func(t *testing.T) {
+		resetFlags()
+		mockCF := &ChannelCmdFactory{
+			BroadcastFactory: mockBroadcastClientFactory,
+			Signer:           signer,
+			DeliverClient:    getMockDeliverClient("mychannel"),
+		}
+		cmd := fetchconfigCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", "mychannel", "--decode", filepath.Join(tempDir, "bad.json")})
+		assert.Error(t, cmd.Execute())
+	})
+
+	t.Run("TrailingArgs", func(t *testing.T) {
+		resetFlags()
+		cmd := fetchconfigCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", "mychannel", "a", "b"})
+		assert.EqualError(t, cmd.Execute(), "trailing args detected")
+	})
+}
@@ -18,15 +18,15 @@ package channel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io/ioutil"
 
-	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -37,7 +37,7 @@ func joinCmd(cf *ChannelCmdFactory) *cobra.Command {
 	joinCmd := &cobra.Command{
 		Use:   "join",
 		Short: commandDescription,
-		Long:  commandDescription,
+		Long:  commandDescription + " The request is sent to the admin service of the peer and must be signed by an admin of the peer.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return join(cmd, args, cf)
 		},
@@ -57,14 +57,14 @@ func (e GBFileNotFoundErr) Error() string {
 	return fmt.Sprintf("genesis block file not found %s", string(e))
 }
 
-//ProposalFailedErr proposal failed
-type ProposalFailedErr string
+//AdminRequestFailedErr the request to the admin service failed
+type AdminRequestFailedErr string
 
-func (e ProposalFailedErr) Error() string {
-	return fmt.Sprintf("proposal failed (err: %s)", string(e))
+func (e AdminRequestFailedErr) Error() string {
+	return fmt.Sprintf("admin request failed (err: %s)", string(e))
 }
 
-func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
+func getJoinRequest() (*pb.JoinChannelRequest, error) {
 	if genesisBlockPath == common.UndefinedParamValue {
 		return nil, errors.New("Must supply genesis block file")
 	}
@@ -73,58 +73,36 @@ func getJoinCCSpec() (*pb.ChaincodeSpec, error) {
 	if err != nil {
 		return nil, GBFileNotFoundErr(err.Error())
 	}
-	// Build the spec
-	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(cscc.JoinChain), gb}}
+	return &pb.JoinChannelRequest{GenesisBlock: gb}, nil
+}
 
-	spec := &pb.ChaincodeSpec{
-		Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
-		ChaincodeId: &pb.ChaincodeID{Name: "cscc"},
-		Input:       input,
+// signAdminOperation wraps the given operation in an envelope signed by the
+// identity of the command, as expected by the admin service of the peer
+func signAdminOperation(cf *ChannelCmdFactory, op *pb.AdminOperation) (*pcommon.Envelope, error) {
+	env, err := putils.CreateSignedEnvelope(pcommon.HeaderType_PEER_ADMIN_OPERATION, "", crypto.NewSignatureHeaderCreator(cf.Signer), op, 0, 0)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error creating signed admin request")
 	}
-
-	return spec, nil
+	return env, nil
 }
 
 func executeJoin(cf *ChannelCmdFactory) (err error) {
-	spec, err := getJoinCCSpec()
+	request, err := getJoinRequest()
 	if err != nil {
 		return err
 	}
 
-	// Build the ChaincodeInvocationSpec message
-	invocation := &pb.ChaincodeInvocationSpec{ChaincodeSpec: spec}
-
-	creator, err := cf.Signer.Serialize()
-	if err != nil {
-		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
-	}
-
-	var prop *pb.Proposal
-	prop, _, err = putils.CreateProposalFromCIS(pcommon.HeaderType_CONFIG, "", invocation, creator)
-	if err != nil {
-		return fmt.Errorf("Error creating proposal for join %s", err)
-	}
-
-	var signedProp *pb.SignedProposal
-	signedProp, err = putils.GetSignedProposal(prop, cf.Signer)
-	if err != nil {
-		return fmt.Errorf("Error creating signed proposal %s", err)
-	}
-
-	var proposalResp *pb.ProposalResponse
-	proposalResp, err = cf.EndorserClient.ProcessProposal(context.Background(), signedProp)
+	env, err := signAdminOperation(cf, &pb.AdminOperation{
+		Content: &pb.AdminOperation_JoinChannelReq{JoinChannelReq: request},
+	})
 	if err != nil {
-		return ProposalFailedErr(err.Error())
-	}
-
-	if proposalResp == nil {
-		return ProposalFailedErr("nil proposal response")
+		return err
 	}
 
-	if proposalResp.Response.Status != 0 && proposalResp.Response.Status != 200 {
-		return ProposalFailedErr(fmt.Sprintf("bad proposal response %d: %s", proposalResp.Response.Status, proposalResp.Response.Message))
+	if _, err = cf.AdminClient.JoinChannel(context.Background(), env); err != nil {
+		return AdminRequestFailedErr(err.Error())
 	}
-	logger.Info("Successfully submitted proposal to join channel")
+	logger.Info("Successfully joined the channel")
 	return nil
 }
 
@@ -137,7 +115,7 @@ func join(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
 
 	var err error
 	if cf == nil {
-		cf, err = InitCmdFactory(EndorserRequired, PeerDeliverNotRequired, OrdererNotRequired)
+		cf, err = InitAdminCmdFactory()
 		if err != nil {
 			return err
 		}
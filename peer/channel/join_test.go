@@ -17,6 +17,7 @@
 package channel
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -24,7 +25,6 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/peer/common"
-	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -57,15 +57,8 @@ func TestJoin(t *testing.T) {
 	signer, err := common.GetDefaultSigner()
 	assert.NoError(t, err, "Get default signer error: %v", err)
 
-	mockResponse := &pb.ProposalResponse{
-		Response:    &pb.Response{Status: 200},
-		Endorsement: &pb.Endorsement{},
-	}
-
-	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
-
 	mockCF := &ChannelCmdFactory{
-		EndorserClient:   mockEndorserClient,
+		AdminClient:      common.GetMockAdminClient(nil),
 		BroadcastFactory: mockBroadcastClientFactory,
 		Signer:           signer,
 	}
@@ -90,15 +83,8 @@ func TestJoinNonExistentBlock(t *testing.T) {
 		t.Fatalf("Get default signer error: %v", err)
 	}
 
-	mockResponse := &pb.ProposalResponse{
-		Response:    &pb.Response{Status: 200},
-		Endorsement: &pb.Endorsement{},
-	}
-
-	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
-
 	mockCF := &ChannelCmdFactory{
-		EndorserClient:   mockEndorserClient,
+		AdminClient:      common.GetMockAdminClient(nil),
 		BroadcastFactory: mockBroadcastClientFactory,
 		Signer:           signer,
 	}
@@ -115,7 +101,7 @@ func TestJoinNonExistentBlock(t *testing.T) {
 	assert.IsType(t, GBFileNotFoundErr(err.Error()), err, "expected error type of GBFileNotFoundErr")
 }
 
-func TestBadProposalResponse(t *testing.T) {
+func TestJoinRejected(t *testing.T) {
 	defer resetFlags()
 
 	InitMSP()
@@ -127,15 +113,8 @@ func TestBadProposalResponse(t *testing.T) {
 	signer, err := common.GetDefaultSigner()
 	assert.NoError(t, err, "Get default signer error: %v", err)
 
-	mockResponse := &pb.ProposalResponse{
-		Response:    &pb.Response{Status: 500},
-		Endorsement: &pb.Endorsement{},
-	}
-
-	mockEndorserClient := common.GetMockEndorserClient(mockResponse, nil)
-
 	mockCF := &ChannelCmdFactory{
-		EndorserClient:   mockEndorserClient,
+		AdminClient:      common.GetMockAdminClient(errors.New("access denied")),
 		BroadcastFactory: mockBroadcastClientFactory,
 		Signer:           signer,
 	}
@@ -149,7 +128,7 @@ func TestBadProposalResponse(t *testing.T) {
 
 	err = cmd.Execute()
 	assert.Error(t, err, "expected join command to fail")
-	assert.IsType(t, ProposalFailedErr(err.Error()), err, "expected error type of ProposalFailedErr")
+	assert.IsType(t, AdminRequestFailedErr(err.Error()), err, "expected error type of AdminRequestFailedErr")
 }
 
 func TestJoinNilCF(t *testing.T) {
@@ -171,5 +150,34 @@ func TestJoinNilCF(t *testing.T) {
 
 	err = cmd.Execute()
 	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "endorser client failed to connect to")
+	assert.Contains(t, err.Error(), "admin client failed to connect to")
+}
+
+func TestLeave(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	resetFlags()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err, "Get default signer error: %v", err)
+
+	cmd := leaveCmd(&ChannelCmdFactory{AdminClient: common.GetMockAdminClient(nil), Signer: signer})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	resetFlags()
+	cmd = leaveCmd(&ChannelCmdFactory{AdminClient: common.GetMockAdminClient(nil), Signer: signer})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	assert.NoError(t, cmd.Execute(), "expected leave command to succeed")
+
+	resetFlags()
+	cmd = leaveCmd(&ChannelCmdFactory{AdminClient: common.GetMockAdminClient(errors.New("access denied")), Signer: signer})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel"})
+	err = cmd.Execute()
+	assert.IsType(t, AdminRequestFailedErr(""), err)
+	assert.Contains(t, err.Error(), "access denied")
 }
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"context"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func leaveCmd(cf *ChannelCmdFactory) *cobra.Command {
+	leaveCmd := &cobra.Command{
+		Use:   "leave",
+		Short: "Makes the peer leave a channel.",
+		Long:  "Makes the peer leave a channel and removes the ledger of the channel. The request is sent to the admin service of the peer and must be signed by an admin of the peer.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return leave(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+	}
+	attachFlags(leaveCmd, flagList)
+
+	return leaveCmd
+}
+
+func leave(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitAdminCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+
+	env, err := signAdminOperation(cf, &pb.AdminOperation{
+		Content: &pb.AdminOperation_LeaveChannelReq{LeaveChannelReq: &pb.LeaveChannelRequest{ChannelId: channelID}},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = cf.AdminClient.LeaveChannel(context.Background(), env); err != nil {
+		return AdminRequestFailedErr(err.Error())
+	}
+	logger.Infof("Successfully left channel %s", channelID)
+	return nil
+}
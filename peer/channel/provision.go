@@ -0,0 +1,239 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	localsigner "github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// channelProfile is the high-level description of a channel provisioned by the
+// provision command. Its sections mirror the ones of configtx.yaml, and the
+// ones left out are filled in by its template
+type channelProfile struct {
+	Template      string                           `yaml:"Template"`
+	Consortium    string                           `yaml:"Consortium"`
+	Organizations []*genesisconfig.Organization    `yaml:"Organizations"`
+	Capabilities  []string                         `yaml:"Capabilities"`
+	Policies      map[string]*genesisconfig.Policy `yaml:"Policies"`
+	ACLs          map[string]string                `yaml:"ACLs"`
+}
+
+func provisionCmd(cf *ChannelCmdFactory) *cobra.Command {
+	provisionCmd := &cobra.Command{
+		Use:   "provision",
+		Short: "Provision a channel from a channel profile",
+		Long: "Generate, sign and submit the creation transaction of a channel from a channel profile, and write the genesis block to a file. " +
+			"If the channel already exists with the organizations of the profile, its genesis block is written without creating it again. " +
+			"The templates of the channel profiles are: " + strings.Join(channelTemplateNames(), ", ") + ".",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return provision(cmd, args, cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"profile",
+		"outputBlock",
+		"timeout",
+	}
+	attachFlags(provisionCmd, flagList)
+
+	return provisionCmd
+}
+
+// loadChannelProfile reads a channel profile and completes it with its
+// template. The relative MSP directories are relative to the profile
+func loadChannelProfile(path string) (*genesisconfig.Profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the channel profile")
+	}
+	p := &channelProfile{}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the channel profile %s", path)
+	}
+
+	templateName := p.Template
+	if templateName == "" {
+		templateName = defaultChannelTemplate
+	}
+	template, ok := channelTemplates[templateName]
+	if !ok {
+		return nil, errors.Errorf("unknown channel template %s, the templates are: %s", templateName, strings.Join(channelTemplateNames(), ", "))
+	}
+	if p.Consortium == "" {
+		return nil, errors.New("the channel profile must name the consortium of the channel")
+	}
+	if len(p.Organizations) == 0 {
+		return nil, errors.New("the channel profile must list the organizations of the channel")
+	}
+
+	application := &genesisconfig.Application{
+		Capabilities: map[string]bool{},
+		Policies:     map[string]*genesisconfig.Policy{},
+		ACLs:         p.ACLs,
+	}
+	capabilities := p.Capabilities
+	if len(capabilities) == 0 {
+		capabilities = template.Capabilities
+	}
+	for _, capability := range capabilities {
+		application.Capabilities[capability] = true
+	}
+	for name, policy := range template.Policies {
+		application.Policies[name] = policy
+	}
+	for name, policy := range p.Policies {
+		application.Policies[name] = policy
+	}
+
+	for _, org := range p.Organizations {
+		if org.Name == "" || org.ID == "" || org.MSPDir == "" {
+			return nil, errors.New("the organizations of the channel profile must have a Name, an ID and an MSPDir")
+		}
+		if !filepath.IsAbs(org.MSPDir) {
+			org.MSPDir = filepath.Join(filepath.Dir(path), org.MSPDir)
+		}
+		if org.MSPType == "" {
+			org.MSPType = msp.ProviderTypeToString(msp.FABRIC)
+		}
+		if len(org.Policies) == 0 {
+			org.Policies = template.OrgPolicies(org.ID)
+		}
+		application.Organizations = append(application.Organizations, org)
+	}
+
+	return &genesisconfig.Profile{
+		Consortium:  p.Consortium,
+		Application: application,
+		// the channel group is inherited from the system channel, these only
+		// keep the template and the update of the channel consistent
+		Capabilities: map[string]bool{"V1_3": true},
+		Policies:     implicitMetaPolicies("MAJORITY"),
+	}, nil
+}
+
+// checkProvisioned checks that the channel of the given genesis block has the
+// organizations of the channel profile
+func checkProvisioned(block *cb.Block, profile *genesisconfig.Profile) error {
+	env, err := utils.ExtractEnvelope(block, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed to read the genesis block of the channel")
+	}
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return errors.WithMessage(err, "failed to read the genesis block of the channel")
+	}
+	configEnv, err := configtx.UnmarshalConfigEnvelope(payload.Data)
+	if err != nil {
+		return errors.WithMessage(err, "failed to read the genesis block of the channel")
+	}
+
+	var orgs, expectedOrgs []string
+	if app, ok := configEnv.GetConfig().GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey]; ok {
+		for name := range app.Groups {
+			orgs = append(orgs, name)
+		}
+	}
+	for _, org := range profile.Application.Organizations {
+		expectedOrgs = append(expectedOrgs, org.Name)
+	}
+	sort.Strings(orgs)
+	sort.Strings(expectedOrgs)
+	if !reflect.DeepEqual(orgs, expectedOrgs) {
+		return errors.Errorf("channel %s already exists with the organizations %s instead of the organizations %s of the channel profile",
+			channelID, orgs, expectedOrgs)
+	}
+	return nil
+}
+
+func executeProvision(cf *ChannelCmdFactory, profile *genesisconfig.Profile) error {
+	block, err := cf.DeliverClient.GetSpecifiedBlock(0)
+	if err == nil {
+		if err := checkProvisioned(block, profile); err != nil {
+			return err
+		}
+		logger.Infof("Channel %s already exists", channelID)
+	} else {
+		logger.Debugf("Creating channel %s, its genesis block could not be retrieved: %s", channelID, err)
+
+		chCrtEnv, err := encoder.MakeChannelCreationTransaction(channelID, localsigner.NewSigner(), profile)
+		if err != nil {
+			return errors.WithMessage(err, "failed to generate the channel creation transaction")
+		}
+		broadcastClient, err := cf.BroadcastFactory()
+		if err != nil {
+			return errors.WithMessage(err, "error getting broadcast client")
+		}
+		err = broadcastClient.Send(chCrtEnv)
+		broadcastClient.Close()
+		if err != nil {
+			return err
+		}
+
+		if block, err = getGenesisBlock(cf); err != nil {
+			return err
+		}
+		logger.Infof("Channel %s created", channelID)
+	}
+
+	b, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+	file := channelID + ".block"
+	if outputBlock != common.UndefinedParamValue {
+		file = outputBlock
+	}
+	return ioutil.WriteFile(file, b, 0644)
+}
+
+func provision(cmd *cobra.Command, args []string, cf *ChannelCmdFactory) error {
+	if len(args) != 0 {
+		return fmt.Errorf("trailing args detected: %s", args)
+	}
+	// the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("must supply channel ID")
+	}
+	if profilePath == "" {
+		return errors.New("must supply a channel profile")
+	}
+	profile, err := loadChannelProfile(profilePath)
+	if err != nil {
+		return err
+	}
+
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserNotRequired, PeerDeliverNotRequired, OrdererRequired)
+		if err != nil {
+			return err
+		}
+	}
+	return executeProvision(cf, profile)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingBroadcastClient struct {
+	envs []*cb.Envelope
+}
+
+func (r *recordingBroadcastClient) Send(env *cb.Envelope) error {
+	r.envs = append(r.envs, env)
+	return nil
+}
+
+func (r *recordingBroadcastClient) Close() error {
+	return nil
+}
+
+// genesisDeliverClient returns its error until the channel is created, and the
+// genesis block of the channel after
+type genesisDeliverClient struct {
+	mockDeliverClient
+	block *cb.Block
+}
+
+func (g *genesisDeliverClient) GetSpecifiedBlock(num uint64) (*cb.Block, error) {
+	if g.block == nil {
+		return nil, errors.New("can't read the block: &{NOT_FOUND}")
+	}
+	return g.block, nil
+}
+
+func writeChannelProfile(t *testing.T, dir, profile string) string {
+	path := filepath.Join(dir, "channel.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(profile), 0644))
+	return path
+}
+
+func TestLoadChannelProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "provision")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := writeChannelProfile(t, dir, `
+Template: any-admin
+Consortium: SampleConsortium
+Organizations:
+  - Name: Org1
+    ID: Org1MSP
+    MSPDir: org1/msp
+  - Name: Org2
+    ID: Org2MSP
+    MSPDir: /etc/org2/msp
+    Policies:
+      Readers:
+        Type: Signature
+        Rule: OR('Org2MSP.peer')
+Policies:
+  Writers:
+    Type: ImplicitMeta
+    Rule: MAJORITY Writers
+`)
+	profile, err := loadChannelProfile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "SampleConsortium", profile.Consortium)
+	app := profile.Application
+	assert.Equal(t, map[string]bool{"V1_3": true}, app.Capabilities)
+	assert.Equal(t, map[string]*genesisconfig.Policy{
+		"Readers": {Type: "ImplicitMeta", Rule: "ANY Readers"},
+		"Writers": {Type: "ImplicitMeta", Rule: "MAJORITY Writers"},
+		"Admins":  {Type: "ImplicitMeta", Rule: "ANY Admins"},
+	}, app.Policies)
+	require.Len(t, app.Organizations, 2)
+	assert.Equal(t, filepath.Join(dir, "org1/msp"), app.Organizations[0].MSPDir)
+	assert.Equal(t, "bccsp", app.Organizations[0].MSPType)
+	assert.Equal(t, memberOrgPolicies("Org1MSP"), app.Organizations[0].Policies)
+	assert.Equal(t, "/etc/org2/msp", app.Organizations[1].MSPDir)
+	assert.Equal(t, map[string]*genesisconfig.Policy{
+		"Readers": {Type: "Signature", Rule: "OR('Org2MSP.peer')"},
+	}, app.Organizations[1].Policies)
+
+	for profile, expectedErr := range map[string]string{
+		"Template: unknown\nConsortium: SampleConsortium": "unknown channel template unknown, the templates are: all-admins, any-admin, majority-admins, node-ous",
+		"Organizations:\n  - Name: Org1":                  "the channel profile must name the consortium of the channel",
+		"Consortium: SampleConsortium":                    "the channel profile must list the organizations of the channel",
+		"Consortium: C\nOrganizations:\n  - Name: Org1":   "the organizations of the channel profile must have a Name, an ID and an MSPDir",
+		"Consortium: SampleConsortium\nOrderer: {}":       "failed to parse the channel profile " + path,
+	} {
+		writeChannelProfile(t, dir, profile)
+		_, err := loadChannelProfile(path)
+		require.Error(t, err, profile)
+		assert.Contains(t, err.Error(), expectedErr)
+	}
+
+	_, err = loadChannelProfile(filepath.Join(dir, "missing.yaml"))
+	assert.Contains(t, err.Error(), "failed to read the channel profile")
+}
+
+func TestProvisionChannel(t *testing.T) {
+	defer resetFlags()
+
+	InitMSP()
+	cleanup := configtest.SetDevFabricConfigPath(t)
+	defer cleanup()
+	mspDir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "provision")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	profilePath := writeChannelProfile(t, dir, fmt.Sprintf(`
+Consortium: SampleConsortium
+Organizations:
+  - Name: SampleOrg
+    ID: SampleOrg
+    MSPDir: %s
+`, mspDir))
+	profile, err := loadChannelProfile(profilePath)
+	require.NoError(t, err)
+	genesisBlock := encoder.New(profile).GenesisBlockForChannel("mychannel")
+
+	signer, err := common.GetDefaultSigner()
+	require.NoError(t, err)
+	broadcastClient := &recordingBroadcastClient{}
+	deliverClient := &genesisDeliverClient{}
+	mockCF := &ChannelCmdFactory{
+		BroadcastFactory: func() (common.BroadcastClient, error) {
+			// the orderer creates the channel
+			deliverClient.block = genesisBlock
+			return broadcastClient, nil
+		},
+		Signer:        signer,
+		DeliverClient: deliverClient,
+	}
+	outputBlockPath := filepath.Join(dir, "mychannel.block")
+	defer func() { outputBlock = "" }()
+
+	provision := func() error {
+		cmd := provisionCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs([]string{"-c", "mychannel", "-o", "localhost:7050", "--profile", profilePath, "--outputBlock", outputBlockPath})
+		return cmd.Execute()
+	}
+
+	// the channel does not exist, it is created
+	require.NoError(t, provision())
+	require.Len(t, broadcastClient.envs, 1)
+	payload, err := utils.UnmarshalPayload(broadcastClient.envs[0].Payload)
+	require.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	assert.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	assert.Equal(t, "mychannel", chdr.ChannelId)
+	configUpdateEnv, err := configtx.UnmarshalConfigUpdateEnvelope(payload.Data)
+	require.NoError(t, err)
+	assert.Len(t, configUpdateEnv.Signatures, 1)
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	require.NoError(t, err)
+	assert.Contains(t, configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups, "SampleOrg")
+
+	written, err := ioutil.ReadFile(outputBlockPath)
+	require.NoError(t, err)
+	assert.Equal(t, utils.MarshalOrPanic(genesisBlock), written)
+
+	// the channel exists with the organizations of the profile, it is not created again
+	require.NoError(t, os.Remove(outputBlockPath))
+	require.NoError(t, provision())
+	assert.Len(t, broadcastClient.envs, 1)
+	written, err = ioutil.ReadFile(outputBlockPath)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(genesisBlock, utils.UnmarshalBlockOrPanic(written)))
+
+	// the channel exists with other organizations
+	writeChannelProfile(t, dir, fmt.Sprintf(`
+Consortium: SampleConsortium
+Organizations:
+  - Name: SampleOrg
+    ID: SampleOrg
+    MSPDir: %s
+  - Name: OtherOrg
+    ID: SampleOrg
+    MSPDir: %s
+`, mspDir, mspDir))
+	assert.EqualError(t, provision(), "channel mychannel already exists with the organizations [SampleOrg] instead of the organizations [OtherOrg SampleOrg] of the channel profile")
+	assert.Len(t, broadcastClient.envs, 1)
+}
+
+func TestProvisionChannelMissingFlags(t *testing.T) {
+	defer resetFlags()
+	InitMSP()
+
+	cmd := provisionCmd(&ChannelCmdFactory{})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-o", "localhost:7050", "--profile", "channel.yaml"})
+	assert.EqualError(t, cmd.Execute(), "must supply channel ID")
+
+	resetFlags()
+	cmd = provisionCmd(&ChannelCmdFactory{})
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", "mychannel", "-o", "localhost:7050"})
+	assert.EqualError(t, cmd.Execute(), "must supply a channel profile")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"fmt"
+	"sort"
+
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+)
+
+// defaultChannelTemplate is the template of the channel profiles which do not
+// name one
+const defaultChannelTemplate = "majority-admins"
+
+// channelTemplate holds the defaults of the channels provisioned from a
+// channel profile: the capabilities and policies of the application group, and
+// the policies of the application orgs. The channel profile overrides them
+type channelTemplate struct {
+	Description  string
+	Capabilities []string
+	Policies     map[string]*genesisconfig.Policy
+	OrgPolicies  func(mspID string) map[string]*genesisconfig.Policy
+}
+
+func implicitMetaPolicies(admins string) map[string]*genesisconfig.Policy {
+	return map[string]*genesisconfig.Policy{
+		"Readers": {Type: "ImplicitMeta", Rule: "ANY Readers"},
+		"Writers": {Type: "ImplicitMeta", Rule: "ANY Writers"},
+		"Admins":  {Type: "ImplicitMeta", Rule: admins + " Admins"},
+	}
+}
+
+func memberOrgPolicies(mspID string) map[string]*genesisconfig.Policy {
+	return map[string]*genesisconfig.Policy{
+		"Readers": {Type: "Signature", Rule: fmt.Sprintf("OR('%s.member')", mspID)},
+		"Writers": {Type: "Signature", Rule: fmt.Sprintf("OR('%s.member')", mspID)},
+		"Admins":  {Type: "Signature", Rule: fmt.Sprintf("OR('%s.admin')", mspID)},
+	}
+}
+
+func peerOrgPolicies(mspID string) map[string]*genesisconfig.Policy {
+	return map[string]*genesisconfig.Policy{
+		"Readers": {Type: "Signature", Rule: fmt.Sprintf("OR('%s.admin', '%s.peer', '%s.client')", mspID, mspID, mspID)},
+		"Writers": {Type: "Signature", Rule: fmt.Sprintf("OR('%s.admin', '%s.client')", mspID, mspID)},
+		"Admins":  {Type: "Signature", Rule: fmt.Sprintf("OR('%s.admin')", mspID)},
+	}
+}
+
+// channelTemplates is the library of the channel templates, by name
+var channelTemplates = map[string]*channelTemplate{
+	"majority-admins": {
+		Description:  "any member reads and writes, a majority of the org admins modifies the channel",
+		Capabilities: []string{"V1_3"},
+		Policies:     implicitMetaPolicies("MAJORITY"),
+		OrgPolicies:  memberOrgPolicies,
+	},
+	"any-admin": {
+		Description:  "any member reads and writes, the admins of any org modify the channel",
+		Capabilities: []string{"V1_3"},
+		Policies:     implicitMetaPolicies("ANY"),
+		OrgPolicies:  memberOrgPolicies,
+	},
+	"all-admins": {
+		Description:  "any member reads and writes, the admins of all the orgs modify the channel",
+		Capabilities: []string{"V1_3"},
+		Policies:     implicitMetaPolicies("ALL"),
+		OrgPolicies:  memberOrgPolicies,
+	},
+	"node-ous": {
+		Description:  "as majority-admins, with the peer and client roles of the orgs using NodeOUs",
+		Capabilities: []string{"V1_3"},
+		Policies:     implicitMetaPolicies("MAJORITY"),
+		OrgPolicies:  peerOrgPolicies,
+	},
+}
+
+// channelTemplateNames returns the sorted names of the channel templates
+func channelTemplateNames() []string {
+	var names []string
+	for name := range channelTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
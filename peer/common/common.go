@@ -250,6 +250,18 @@ func configFromEnv(prefix string) (address, override string, clientConfig comm.C
 			return
 		}
 		secOpts.ServerRootCAs = [][]byte{caPEM}
+		tlsPolicy, res := comm.NewTLSPolicy(
+			viper.GetString(prefix+".tls.minVersion"),
+			viper.GetString(prefix+".tls.maxVersion"),
+			viper.GetStringSlice(prefix+".tls.cipherSuites"),
+			viper.GetBool(prefix+".tls.fips"),
+		)
+		if res != nil {
+			err = errors.WithMessage(res,
+				fmt.Sprintf("invalid %s.tls policy", prefix))
+			return
+		}
+		tlsPolicy.Apply(secOpts)
 	}
 	if secOpts.RequireClientCert {
 		keyPEM, res := ioutil.ReadFile(config.GetPath(prefix + ".tls.clientKey.file"))
@@ -313,4 +325,12 @@ func InitCmd(cmd *cobra.Command, args []string) {
 	}
 
 	runtime.GOMAXPROCS(viper.GetInt("peer.gomaxprocs"))
+
+	// the maximum message sizes must be set before any gRPC client or server is created
+	if size := viper.GetInt("peer.maxRecvMsgSize"); size > 0 {
+		comm.MaxRecvMsgSize = size
+	}
+	if size := viper.GetInt("peer.maxSendMsgSize"); size > 0 {
+		comm.MaxSendMsgSize = size
+	}
 }
@@ -71,6 +71,9 @@ func (d *DeliverClient) readBlock() (*cb.Block, error) {
 	switch t := msg.Type.(type) {
 	case *ab.DeliverResponse_Status:
 		logger.Infof("Got status: %v", t)
+		if t.Status == cb.Status_REQUEST_ENTITY_TOO_LARGE {
+			return nil, errors.New("can't read the block: it exceeds the maximum message size this client can receive")
+		}
 		return nil, errors.Errorf("can't read the block: %v", t)
 	case *ab.DeliverResponse_Block:
 		logger.Infof("Received block: %v", t.Block.Header.Number)
@@ -57,7 +57,8 @@ func (oc *OrdererClient) Deliver() (ab.AtomicBroadcast_DeliverClient, error) {
 		return nil, errors.WithMessage(err, fmt.Sprintf("orderer client failed to connect to %s", oc.address))
 	}
 	// TODO: check to see if we should actually handle error before returning
-	return ab.NewAtomicBroadcastClient(conn).Deliver(context.TODO())
+	ctx := comm.WithMaxRecvMsgSize(context.TODO(), oc.MaxRecvMsgSize())
+	return ab.NewAtomicBroadcastClient(conn).Deliver(ctx)
 
 }
 
@@ -83,7 +83,8 @@ func (pc *PeerClient) Deliver() (pb.Deliver_DeliverClient, error) {
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("deliver client failed to connect to %s", pc.address))
 	}
-	return pb.NewDeliverClient(conn).Deliver(context.TODO())
+	ctx := comm.WithMaxRecvMsgSize(context.TODO(), pc.MaxRecvMsgSize())
+	return pb.NewDeliverClient(conn).Deliver(ctx)
 }
 
 // PeerDeliver returns a client for the Deliver service for peer-specific use
@@ -0,0 +1,173 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/cmd/common/comm"
+	"github.com/hyperledger/fabric/cmd/common/signer"
+	"github.com/hyperledger/fabric/core/config"
+	discovery "github.com/hyperledger/fabric/discovery/cmd"
+	peercommon "github.com/hyperledger/fabric/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	discoverFuncName = "discover"
+	discoverCmdDes   = "Query the discovery service of a peer: peers|config|endorsers."
+)
+
+var (
+	server      string
+	channelID   string
+	chaincodes  []string
+	collections map[string]string
+)
+
+// Cmd returns the cobra command for discovery. The commands send the requests
+// with the given stubs, or with the stubs of the discover tool if they are nil
+func Cmd(clientStub, rawStub discovery.Stub) *cobra.Command {
+	if clientStub == nil {
+		clientStub = &discovery.ClientStub{}
+	}
+	if rawStub == nil {
+		rawStub = &discovery.RawStub{}
+	}
+
+	flags := discoverCmd.PersistentFlags()
+	flags.StringVarP(&server, "server", "", "", "Endpoint of the peer to query, the address of the local peer by default")
+	flags.StringVarP(&channelID, "channelID", "C", "", "The channel the query is intended to")
+
+	discoverCmd.AddCommand(peersCmd(clientStub))
+	discoverCmd.AddCommand(configCmd(clientStub))
+	discoverCmd.AddCommand(endorsersCmd(rawStub))
+
+	return discoverCmd
+}
+
+var discoverCmd = &cobra.Command{
+	Use:              discoverFuncName,
+	Short:            fmt.Sprint(discoverCmdDes),
+	Long:             fmt.Sprint(discoverCmdDes),
+	PersistentPreRun: peercommon.InitCmd,
+}
+
+func peersCmd(stub discovery.Stub) *cobra.Command {
+	peerCmd := discovery.NewPeerCmd(stub, &discovery.PeerResponseParser{Writer: os.Stdout})
+	peerCmd.SetServer(&server)
+	peerCmd.SetChannel(&channelID)
+	return &cobra.Command{
+		Use:   discovery.PeersCommand,
+		Short: "Discover the peers of a channel, or the local peers if no channel is given.",
+		Long:  "Discover the peers of a channel, or the local peers if no channel is given, and print them as JSON.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execute(cmd, peerCmd.Execute)
+		},
+	}
+}
+
+func configCmd(stub discovery.Stub) *cobra.Command {
+	configCmd := discovery.NewConfigCmd(stub, &discovery.ConfigResponseParser{Writer: os.Stdout})
+	configCmd.SetServer(&server)
+	configCmd.SetChannel(&channelID)
+	return &cobra.Command{
+		Use:   discovery.ConfigCommand,
+		Short: "Discover the configuration of a channel.",
+		Long:  "Discover the MSPs and the orderers of a channel, and print them as JSON.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execute(cmd, configCmd.Execute)
+		},
+	}
+}
+
+func endorsersCmd(stub discovery.Stub) *cobra.Command {
+	endorserCmd := discovery.NewEndorsersCmd(stub, &discovery.EndorserResponseParser{Writer: os.Stdout})
+	endorserCmd.SetServer(&server)
+	endorserCmd.SetChannel(&channelID)
+	endorserCmd.SetChaincodes(&chaincodes)
+	endorserCmd.SetCollections(&collections)
+	cmd := &cobra.Command{
+		Use:   discovery.EndorsersCommand,
+		Short: "Discover the endorsers of chaincodes.",
+		Long:  "Discover the layouts of peers that satisfy the endorsement policies of the chaincodes and collections, and print them as JSON.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execute(cmd, endorserCmd.Execute)
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&chaincodes, "chaincode", "n", nil, "The name of a chaincode invoked by the transaction, can be repeated")
+	flags.StringToStringVarP(&collections, "collection", "", nil, "The collections of a chaincode, as a mapping from the chaincode to a comma separated list of collections: CC=C1,C2")
+	return cmd
+}
+
+// execute runs the given command with the configuration of the peer CLI
+func execute(cmd *cobra.Command, command common.CLICommand) error {
+	conf, err := configFromEnv()
+	if err != nil {
+		return err
+	}
+	if server == "" {
+		server = viper.GetString("peer.address")
+	}
+	// silence usage once the arguments are validated
+	cmd.SilenceUsage = true
+	return command(conf)
+}
+
+// configFromEnv creates the configuration of the discovery client from the
+// MSP and the TLS settings of the peer CLI
+func configFromEnv() (common.Config, error) {
+	mspDir := config.GetPath("peer.mspConfigPath")
+	identityPath, err := firstFile(filepath.Join(mspDir, "signcerts"))
+	if err != nil {
+		return common.Config{}, errors.WithMessage(err, "failed locating the signing certificate")
+	}
+	keyPath, err := firstFile(filepath.Join(mspDir, "keystore"))
+	if err != nil {
+		return common.Config{}, errors.WithMessage(err, "failed locating the signing key")
+	}
+
+	conf := common.Config{
+		SignerConfig: signer.Config{
+			MSPID:        viper.GetString("peer.localMspId"),
+			IdentityPath: identityPath,
+			KeyPath:      keyPath,
+		},
+		TLSConfig: comm.Config{
+			Timeout: viper.GetDuration("peer.client.connTimeout"),
+		},
+	}
+	if viper.GetBool("peer.tls.enabled") {
+		conf.TLSConfig.PeerCACertPath = config.GetPath("peer.tls.rootcert.file")
+		if viper.GetBool("peer.tls.clientAuthRequired") {
+			conf.TLSConfig.CertPath = config.GetPath("peer.tls.clientCert.file")
+			conf.TLSConfig.KeyPath = config.GetPath("peer.tls.clientKey.file")
+		}
+	}
+	return conf, nil
+}
+
+// firstFile returns the path of the first regular file of the given directory
+func firstFile(dir string) (string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed reading directory %s", dir)
+	}
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+	}
+	return "", errors.Errorf("no file found in %s", dir)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discover
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/discovery/cmd/mocks"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConfig(t *testing.T) string {
+	dir, err := configtest.GetDevMspDir()
+	require.NoError(t, err)
+	viper.Set("peer.mspConfigPath", dir)
+	viper.Set("peer.localMspId", "SampleOrg")
+	viper.Set("peer.address", "peer0:7051")
+	viper.Set("peer.client.connTimeout", 3*time.Second)
+	return dir
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	defer viper.Reset()
+	mspDir := setupConfig(t)
+
+	conf, err := configFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "SampleOrg", conf.SignerConfig.MSPID)
+	assert.Equal(t, filepath.Join(mspDir, "signcerts", "peer.pem"), conf.SignerConfig.IdentityPath)
+	assert.Equal(t, filepath.Join(mspDir, "keystore", "key.pem"), conf.SignerConfig.KeyPath)
+	assert.Equal(t, 3*time.Second, conf.TLSConfig.Timeout)
+	assert.Empty(t, conf.TLSConfig.PeerCACertPath)
+
+	viper.Set("peer.tls.enabled", true)
+	viper.Set("peer.tls.rootcert.file", "/tls/ca.crt")
+	viper.Set("peer.tls.clientAuthRequired", true)
+	viper.Set("peer.tls.clientCert.file", "/tls/client.crt")
+	viper.Set("peer.tls.clientKey.file", "/tls/client.key")
+	conf, err = configFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "/tls/ca.crt", conf.TLSConfig.PeerCACertPath)
+	assert.Equal(t, "/tls/client.crt", conf.TLSConfig.CertPath)
+	assert.Equal(t, "/tls/client.key", conf.TLSConfig.KeyPath)
+
+	viper.Set("peer.mspConfigPath", "/nonexistent")
+	_, err = configFromEnv()
+	assert.Contains(t, err.Error(), "failed locating the signing certificate")
+}
+
+func TestPeersCmd(t *testing.T) {
+	defer viper.Reset()
+	setupConfig(t)
+	defer func() { server, channelID = "", "" }()
+
+	stub := &mocks.Stub{}
+	stub.On("Send", "peer0:7051", mock.Anything, mock.Anything).Return(nil, errors.New("unavailable")).Run(func(args mock.Arguments) {
+		assert.Equal(t, "SampleOrg", args.Get(1).(common.Config).SignerConfig.MSPID)
+		req := args.Get(2).(*discovery.Request)
+		require.Len(t, req.Queries, 1)
+		assert.Equal(t, "mychannel", req.Queries[0].Channel)
+	})
+
+	channelID = "mychannel"
+	cmd := peersCmd(stub)
+	err := cmd.RunE(cmd, nil)
+	assert.EqualError(t, err, "unavailable")
+	stub.AssertNumberOfCalls(t, "Send", 1)
+}
+
+func TestEndorsersCmd(t *testing.T) {
+	defer viper.Reset()
+	setupConfig(t)
+	defer func() { server, channelID, chaincodes = "", "", nil }()
+
+	stub := &mocks.Stub{}
+	stub.On("Send", "peer1:7051", mock.Anything, mock.Anything).Return(nil, errors.New("unavailable"))
+
+	cmd := endorsersCmd(stub)
+	server, channelID = "peer1:7051", "mychannel"
+	err := cmd.RunE(cmd, nil)
+	assert.EqualError(t, err, "failed creating request: invocation chain should not be empty")
+	stub.AssertNumberOfCalls(t, "Send", 0)
+
+	require.NoError(t, cmd.Flags().Parse([]string{"--chaincode", "mycc", "--collection", "mycc=col1,col2"}))
+	err = cmd.RunE(cmd, nil)
+	assert.EqualError(t, err, "unavailable")
+	stub.AssertNumberOfCalls(t, "Send", 1)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// operationsClient retrieves resources from the operations endpoint of a peer
+type operationsClient struct {
+	address string
+	client  *http.Client
+	tls     bool
+}
+
+// newOperationsClient returns a client of the operations endpoint at the address,
+// or at the one of the peer configuration, over TLS when a root certificate is
+// given or TLS is enabled in the configuration
+func newOperationsClient(address, caFile, certFile, keyFile string) (*operationsClient, error) {
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	if address == "" {
+		return nil, errors.New("the address of the peer operations endpoint must be provided")
+	}
+
+	oc := &operationsClient{
+		address: address,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		tls:     caFile != "" || viper.GetBool("operations.tls.enabled"),
+	}
+	if !oc.tls {
+		return oc, nil
+	}
+
+	tlsConfig := &tls.Config{}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the root certificate %s", caFile)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificate found in %s", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	oc.client.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+	return oc, nil
+}
+
+// get decodes the JSON response to a GET of the path
+func (oc *operationsClient) get(path string, query url.Values, v interface{}) error {
+	scheme := "http"
+	if oc.tls {
+		scheme = "https"
+	}
+	u := url.URL{Scheme: scheme, Host: oc.address, Path: path, RawQuery: query.Encode()}
+
+	resp, err := oc.client.Get(u.String())
+	if err != nil {
+		return errors.Wrapf(err, "failed to reach the peer operations endpoint %s", oc.address)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+		return errors.Errorf("the peer answered with status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(v), "failed to decode the response of the peer")
+}
+
+func buildLogsCmd(oc *operationsClient) *cobra.Command {
+	var (
+		address  string
+		name     string
+		version  string
+		caFile   string
+		certFile string
+		keyFile  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "buildlogs",
+		Short: "Get the logs of the builds of the chaincode images.",
+		Long: `Lists the last builds of the chaincode images of a peer or, for a chaincode name and version, ` +
+			`prints the output of its most recent build. Requires the build logs to be enabled on the peer.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("trailing args detected: %s", args)
+			}
+			if version != "" && name == "" {
+				return errors.New("the name of the chaincode must be provided with its version")
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			if oc == nil {
+				var err error
+				oc, err = newOperationsClient(address, caFile, certFile, keyFile)
+				if err != nil {
+					return err
+				}
+			}
+			if name != "" {
+				return printBuildLog(oc, name+":"+version, os.Stdout)
+			}
+			return listBuildLogs(oc, os.Stdout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&address, "peerOperationsAddress", "", "The address of the operations endpoint of the peer, operations.listenAddress of the configuration by default")
+	flags.StringVarP(&name, "name", "n", "", "Name of the chaincode")
+	flags.StringVarP(&version, "version", "v", "", "Version of the chaincode")
+	flags.StringVar(&caFile, "cafile", "", "Path to the PEM encoded root certificate of the operations endpoint, which enables TLS")
+	flags.StringVar(&certFile, "certfile", "", "Path to the PEM encoded client certificate for the operations endpoint")
+	flags.StringVar(&keyFile, "keyfile", "", "Path to the PEM encoded client key for the operations endpoint")
+
+	return cmd
+}
+
+func listBuildLogs(oc *operationsClient, out io.Writer) error {
+	var logs []dockercontroller.BuildLog
+	if err := oc.get("/buildlogs", nil, &logs); err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		fmt.Fprintln(out, "No chaincode build recorded")
+		return nil
+	}
+	for _, log := range logs {
+		fmt.Fprintf(out, "%s %s %s\n", log.Time.Format(time.RFC3339), log.PackageID, buildStatus(log))
+	}
+	return nil
+}
+
+func printBuildLog(oc *operationsClient, packageID string, out io.Writer) error {
+	var log dockercontroller.BuildLog
+	if err := oc.get("/buildlogs", url.Values{"package_id": {packageID}}, &log); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Build of %s at %s: %s\n", log.PackageID, log.Time.Format(time.RFC3339), buildStatus(log))
+	if log.Error != "" {
+		fmt.Fprintf(out, "Error: %s\n", log.Error)
+	}
+	if log.Output != "" {
+		fmt.Fprintf(out, "Output:\n%s\n", strings.TrimRight(log.Output, "\n"))
+	}
+	return nil
+}
+
+func buildStatus(log dockercontroller.BuildLog) string {
+	if log.Success {
+		return "succeeded"
+	}
+	return "failed"
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOperationsServer(t *testing.T) (*httptest.Server, *operationsClient) {
+	built := time.Date(2019, 4, 1, 10, 0, 0, 0, time.UTC)
+	logs := []dockercontroller.BuildLog{
+		{PackageID: "mycc:1.1", Time: built.Add(time.Minute), Success: true},
+		{PackageID: "mycc:1.0", Time: built, Error: "Error returned from build: 1", Output: "main.go:3: undefined: shim\n"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/buildlogs", req.URL.Path)
+		packageID := req.URL.Query().Get("package_id")
+		if packageID == "" {
+			json.NewEncoder(resp).Encode(logs)
+			return
+		}
+		for _, log := range logs {
+			if log.PackageID == packageID {
+				json.NewEncoder(resp).Encode(log)
+				return
+			}
+		}
+		http.Error(resp, "no build log for package "+packageID, http.StatusNotFound)
+	}))
+
+	oc, err := newOperationsClient(strings.TrimPrefix(server.URL, "http://"), "", "", "")
+	require.NoError(t, err)
+	return server, oc
+}
+
+func TestListBuildLogs(t *testing.T) {
+	server, oc := newTestOperationsServer(t)
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	err := listBuildLogs(oc, out)
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-04-01T10:01:00Z mycc:1.1 succeeded\n2019-04-01T10:00:00Z mycc:1.0 failed\n", out.String())
+}
+
+func TestPrintBuildLog(t *testing.T) {
+	server, oc := newTestOperationsServer(t)
+	defer server.Close()
+
+	out := &bytes.Buffer{}
+	err := printBuildLog(oc, "mycc:1.0", out)
+	assert.NoError(t, err)
+	assert.Equal(t, "Build of mycc:1.0 at 2019-04-01T10:00:00Z: failed\n"+
+		"Error: Error returned from build: 1\n"+
+		"Output:\nmain.go:3: undefined: shim\n", out.String())
+
+	err = printBuildLog(oc, "mycc:2.0", out)
+	assert.EqualError(t, err, "the peer answered with status 404 Not Found: no build log for package mycc:2.0")
+}
+
+func TestBuildLogsCmd(t *testing.T) {
+	server, oc := newTestOperationsServer(t)
+	defer server.Close()
+
+	cmd := buildLogsCmd(oc)
+	cmd.SetArgs([]string{"-v", "1.0"})
+	assert.EqualError(t, cmd.Execute(), "the name of the chaincode must be provided with its version")
+
+	cmd = buildLogsCmd(oc)
+	cmd.SetArgs([]string{"extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [extra]")
+
+	cmd = buildLogsCmd(oc)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestNewOperationsClient(t *testing.T) {
+	_, err := newOperationsClient("", "", "", "")
+	assert.EqualError(t, err, "the address of the peer operations endpoint must be provided")
+
+	_, err = newOperationsClient("127.0.0.1:9443", "testdata/missing.pem", "", "")
+	assert.Contains(t, err.Error(), "failed to read the root certificate testdata/missing.pem")
+
+	oc, err := newOperationsClient("127.0.0.1:9443", "", "", "")
+	assert.NoError(t, err)
+	assert.False(t, oc.tls)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+const lifecycleName = "+lifecycle"
+
+// lifecycleClient invokes the functions of the lifecycle system chaincode of
+// a peer
+type lifecycleClient struct {
+	endorser pb.EndorserClient
+	signer   msp.SigningIdentity
+}
+
+// newLifecycleClient returns a client of the peer at the address or, if none
+// is given, of the peer of the configuration, signing with the default identity
+func newLifecycleClient(address, tlsRootCertFile string) (*lifecycleClient, error) {
+	endorser, err := common.GetEndorserClientFnc(address, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to connect to the peer")
+	}
+	signer, err := common.GetDefaultSignerFnc()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve the default signer")
+	}
+	return &lifecycleClient{endorser: endorser, signer: signer}, nil
+}
+
+// invoke calls the lifecycle function with its marshaled arguments and
+// unmarshals its result
+func (lc *lifecycleClient) invoke(funcName string, args, result proto.Message) error {
+	argsBytes, err := proto.Marshal(args)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the arguments of %s", funcName)
+	}
+
+	creator, err := lc.signer.Serialize()
+	if err != nil {
+		return errors.WithMessage(err, "failed to serialize the identity of the signer")
+	}
+
+	cis := &pb.ChaincodeInvocationSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type:        pb.ChaincodeSpec_GOLANG,
+			ChaincodeId: &pb.ChaincodeID{Name: lifecycleName},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}},
+		},
+	}
+	prop, _, err := utils.CreateProposalFromCIS(cb.HeaderType_ENDORSER_TRANSACTION, "", cis, creator)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the proposal")
+	}
+	signedProp, err := utils.GetSignedProposal(prop, lc.signer)
+	if err != nil {
+		return errors.WithMessage(err, "failed to sign the proposal")
+	}
+
+	resp, err := lc.endorser.ProcessProposal(context.Background(), signedProp)
+	if err != nil {
+		return errors.WithMessage(err, "failed to endorse the proposal")
+	}
+	if resp.Response == nil {
+		return errors.New("the proposal response had a nil response")
+	}
+	if resp.Response.Status != int32(cb.Status_SUCCESS) {
+		return errors.Errorf("bad response: %d - %s", resp.Response.Status, resp.Response.Message)
+	}
+	return errors.Wrapf(proto.Unmarshal(resp.Response.Payload, result), "failed to unmarshal the result of %s", funcName)
+}
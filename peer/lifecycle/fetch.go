@@ -0,0 +1,299 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// chaincodePackageMediaType identifies the layer holding the chaincode
+	// package in the manifests with several layers
+	chaincodePackageMediaType = "application/vnd.hyperledger.fabric.chaincode.package.v1.tar+gzip"
+)
+
+// packageFetcher retrieves the chaincode packages from the filesystem, from
+// HTTPS URLs and from OCI registries, retrying the requests which fail
+// transiently
+type packageFetcher struct {
+	client   *http.Client
+	retries  int
+	backoff  time.Duration
+	maxSize  int64
+	username string
+	password string
+}
+
+// newPackageFetcher returns a fetcher trusting the root certificate of the
+// file, if any, in addition to the ones of the system
+func newPackageFetcher(caFile string, retries int, maxSize int64) (*packageFetcher, error) {
+	if retries < 0 {
+		return nil, errors.Errorf("invalid number of retries: %d", retries)
+	}
+	pf := &packageFetcher{
+		client:  &http.Client{Timeout: 5 * time.Minute},
+		retries: retries,
+		backoff: time.Second,
+		maxSize: maxSize,
+	}
+	if caFile != "" {
+		caPEM, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the root certificate %s", caFile)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return nil, errors.Errorf("no certificate found in %s", caFile)
+		}
+		pf.client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots}}
+	}
+	return pf, nil
+}
+
+// fetch returns the package at the location, which is either the path of a
+// file, an https:// URL or an oci://registry/repository[:tag|@digest] reference
+func (pf *packageFetcher) fetch(location string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(location, "https://"):
+		return pf.fetchURL(location)
+	case strings.HasPrefix(location, "oci://"):
+		return pf.fetchOCI(strings.TrimPrefix(location, "oci://"))
+	case strings.HasPrefix(location, "http://"):
+		return nil, errors.Errorf("refusing to fetch the package over an insecure connection: %s", location)
+	default:
+		pkg, err := ioutil.ReadFile(location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read the package %s", location)
+		}
+		return pkg, nil
+	}
+}
+
+func (pf *packageFetcher) fetchURL(location string) ([]byte, error) {
+	pkg, err := pf.get(location, "", "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to download the package "+location)
+	}
+	return pkg, nil
+}
+
+func (pf *packageFetcher) fetchOCI(reference string) ([]byte, error) {
+	registry, repository, ref, err := parseOCIReference(reference)
+	if err != nil {
+		return nil, err
+	}
+	base := fmt.Sprintf("https://%s/v2/%s", registry, repository)
+
+	token, err := pf.authenticate(base + "/manifests/" + ref)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to authenticate to the registry "+registry)
+	}
+
+	content, err := pf.get(base+"/manifests/"+ref, ociManifestMediaType, token)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve the manifest of "+reference)
+	}
+	if strings.HasPrefix(ref, "sha256:") {
+		if err := verifyChecksum(content, ref); err != nil {
+			return nil, errors.WithMessage(err, "invalid manifest of "+reference)
+		}
+	}
+	manifest := &struct {
+		Layers []struct {
+			MediaType string `json:"mediaType"`
+			Digest    string `json:"digest"`
+		} `json:"layers"`
+	}{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest of %s", reference)
+	}
+
+	digest := ""
+	for _, layer := range manifest.Layers {
+		if len(manifest.Layers) == 1 || layer.MediaType == chaincodePackageMediaType {
+			digest = layer.Digest
+			break
+		}
+	}
+	if digest == "" {
+		return nil, errors.Errorf("no chaincode package layer in the manifest of %s", reference)
+	}
+
+	pkg, err := pf.get(base+"/blobs/"+digest, "", token)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to retrieve the package layer of "+reference)
+	}
+	if err := verifyChecksum(pkg, digest); err != nil {
+		return nil, errors.WithMessage(err, "invalid package layer of "+reference)
+	}
+	return pkg, nil
+}
+
+// parseOCIReference splits registry/repository[:tag|@digest], the tag
+// defaulting to latest
+func parseOCIReference(reference string) (registry, repository, ref string, err error) {
+	slash := strings.Index(reference, "/")
+	if slash <= 0 || slash == len(reference)-1 {
+		return "", "", "", errors.Errorf("invalid OCI reference %s, expected registry/repository[:tag|@digest]", reference)
+	}
+	registry, repository = reference[:slash], reference[slash+1:]
+
+	if at := strings.Index(repository, "@"); at >= 0 {
+		return registry, repository[:at], repository[at+1:], nil
+	}
+	if colon := strings.LastIndex(repository, ":"); colon > strings.LastIndex(repository, "/") {
+		return registry, repository[:colon], repository[colon+1:], nil
+	}
+	return registry, repository, "latest", nil
+}
+
+// authenticate returns the bearer token to present to the registry, if it
+// requires one, following the token authentication of the distribution API
+func (pf *packageFetcher) authenticate(location string) (string, error) {
+	req, err := http.NewRequest(http.MethodHead, location, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", ociManifestMediaType)
+	resp, err := pf.do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		return "", nil
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		// basic authentication is handled on each request
+		return "", nil
+	}
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme != "https" {
+		return "", errors.Errorf("invalid token realm in challenge %s", challenge)
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	content, err := pf.get(realm.String(), "", "")
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to retrieve a token")
+	}
+	token := &struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.Unmarshal(content, token); err != nil {
+		return "", errors.Wrap(err, "invalid token response")
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
+
+// get returns the content at the location, retrying on the network errors and
+// on the responses indicating a transient failure
+func (pf *packageFetcher) get(location, accept, token string) ([]byte, error) {
+	backoff := pf.backoff
+	for attempt := 0; ; attempt++ {
+		content, retry, err := pf.getOnce(location, accept, token)
+		if err == nil || !retry || attempt >= pf.retries {
+			return content, err
+		}
+		logger.Warningf("Failed to retrieve %s, retrying in %s: %s", location, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (pf *packageFetcher) getOnce(location, accept, token string) ([]byte, bool, error) {
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := pf.do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return nil, retry, errors.Errorf("unexpected status %s", resp.Status)
+	}
+
+	body := io.Reader(resp.Body)
+	if pf.maxSize > 0 {
+		body = io.LimitReader(resp.Body, pf.maxSize+1)
+	}
+	content, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, true, errors.Wrap(err, "failed to read the response")
+	}
+	if pf.maxSize > 0 && int64(len(content)) > pf.maxSize {
+		return nil, false, errors.Errorf("the content exceeds the maximum package size of %d bytes", pf.maxSize)
+	}
+	return content, false, nil
+}
+
+func (pf *packageFetcher) do(req *http.Request) (*http.Response, error) {
+	if pf.username != "" && req.Header.Get("Authorization") == "" {
+		req.SetBasicAuth(pf.username, pf.password)
+	}
+	return pf.client.Do(req)
+}
+
+// verifyChecksum checks the content against a SHA-256 checksum, in hex with
+// an optional sha256: prefix
+func verifyChecksum(content []byte, checksum string) error {
+	expected := strings.ToLower(strings.TrimPrefix(checksum, "sha256:"))
+	if _, err := hex.DecodeString(expected); err != nil || len(expected) != sha256.Size*2 {
+		return errors.Errorf("invalid SHA-256 checksum %s", checksum)
+	}
+	sum := sha256.Sum256(content)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return errors.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
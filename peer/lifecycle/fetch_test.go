@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha256Hex(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+func newTestFetcher(server *httptest.Server) *packageFetcher {
+	return &packageFetcher{client: server.Client(), retries: 2, backoff: time.Millisecond}
+}
+
+func TestFetchFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fetch")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mycc.tar.gz")
+	require.NoError(t, ioutil.WriteFile(path, []byte("package"), 0600))
+
+	pf := &packageFetcher{}
+	pkg, err := pf.fetch(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("package"), pkg)
+
+	_, err = pf.fetch(filepath.Join(dir, "missing.tar.gz"))
+	assert.Contains(t, err.Error(), "failed to read the package")
+
+	_, err = pf.fetch("http://example.com/mycc.tar.gz")
+	assert.EqualError(t, err, "refusing to fetch the package over an insecure connection: http://example.com/mycc.tar.gz")
+}
+
+func TestFetchURLRetries(t *testing.T) {
+	requests := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		requests++
+		switch req.URL.Path {
+		case "/flaky.tar.gz":
+			if requests < 3 {
+				resp.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			resp.Write([]byte("package"))
+		case "/big.tar.gz":
+			resp.Write([]byte("a package too big"))
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	pf := newTestFetcher(server)
+
+	pkg, err := pf.fetch(server.URL + "/flaky.tar.gz")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("package"), pkg)
+	assert.Equal(t, 3, requests)
+
+	requests = 0
+	_, err = pf.fetch(server.URL + "/missing.tar.gz")
+	assert.EqualError(t, err, fmt.Sprintf("failed to download the package %s/missing.tar.gz: unexpected status 404 Not Found", server.URL))
+	assert.Equal(t, 1, requests, "a missing package must not be retried")
+
+	pf.maxSize = 8
+	_, err = pf.fetch(server.URL + "/big.tar.gz")
+	assert.Contains(t, err.Error(), "the content exceeds the maximum package size of 8 bytes")
+}
+
+func TestFetchOCI(t *testing.T) {
+	pkg := []byte("chaincode package")
+	pkgDigest := "sha256:" + sha256Hex(pkg)
+	manifest := []byte(fmt.Sprintf(`{"schemaVersion":2,"layers":[`+
+		`{"mediaType":"application/vnd.oci.image.layer.v1.tar","digest":"sha256:%s"},`+
+		`{"mediaType":"%s","digest":"%s"}]}`, sha256Hex([]byte("other")), chaincodePackageMediaType, pkgDigest))
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/token" {
+			user, password, _ := req.BasicAuth()
+			assert.Equal(t, "admin", user)
+			assert.Equal(t, "secret", password)
+			assert.Equal(t, "repository:org1/mycc:pull", req.URL.Query().Get("scope"))
+			resp.Write([]byte(`{"token":"tok"}`))
+			return
+		}
+		if req.Header.Get("Authorization") != "Bearer tok" {
+			resp.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org1/mycc:pull"`, server.URL))
+			resp.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/v2/org1/mycc/manifests/1.0", "/v2/org1/mycc/manifests/sha256:" + sha256Hex(manifest):
+			assert.Equal(t, ociManifestMediaType, req.Header.Get("Accept"))
+			resp.Write(manifest)
+		case "/v2/org1/mycc/blobs/" + pkgDigest:
+			resp.Write(pkg)
+		default:
+			resp.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	pf := newTestFetcher(server)
+	pf.username, pf.password = "admin", "secret"
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	fetched, err := pf.fetch("oci://" + registry + "/org1/mycc:1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, pkg, fetched)
+
+	fetched, err = pf.fetch("oci://" + registry + "/org1/mycc@sha256:" + sha256Hex(manifest))
+	assert.NoError(t, err)
+	assert.Equal(t, pkg, fetched)
+
+	_, err = pf.fetch("oci://" + registry + "/org1/mycc:2.0")
+	assert.Contains(t, err.Error(), "failed to retrieve the manifest of "+registry+"/org1/mycc:2.0")
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		reference  string
+		registry   string
+		repository string
+		ref        string
+	}{
+		{"registry.example.com/org1/mycc:1.0", "registry.example.com", "org1/mycc", "1.0"},
+		{"registry.example.com:5000/mycc", "registry.example.com:5000", "mycc", "latest"},
+		{"registry.example.com/mycc@sha256:abcd", "registry.example.com", "mycc", "sha256:abcd"},
+	}
+	for _, tt := range tests {
+		registry, repository, ref, err := parseOCIReference(tt.reference)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.registry, registry)
+		assert.Equal(t, tt.repository, repository)
+		assert.Equal(t, tt.ref, ref)
+	}
+
+	_, _, _, err := parseOCIReference("mycc")
+	assert.EqualError(t, err, "invalid OCI reference mycc, expected registry/repository[:tag|@digest]")
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	sum := sha256Hex([]byte("package"))
+	assert.NoError(t, verifyChecksum([]byte("package"), sum))
+	assert.NoError(t, verifyChecksum([]byte("package"), "sha256:"+strings.ToUpper(sum)))
+	assert.EqualError(t, verifyChecksum([]byte("package"), "abcd"), "invalid SHA-256 checksum abcd")
+	assert.EqualError(t, verifyChecksum([]byte("other"), sum), fmt.Sprintf("checksum mismatch: expected %s, got %s", sum, sha256Hex([]byte("other"))))
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/chaincode/platforms/util"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func installCmd(lc *lifecycleClient, pf *packageFetcher) *cobra.Command {
+	var (
+		address         string
+		tlsRootCertFile string
+		name            string
+		version         string
+		checksum        string
+		retries         int
+		caFile          string
+		username        string
+		password        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "install <package file|https URL|oci://registry/repository[:tag|@digest]>",
+		Short: "Install a chaincode package on a peer.",
+		Long: `Installs a chaincode package on a peer. The package is read from a file, or downloaded from an ` +
+			`HTTPS URL or an OCI registry, with the retrieval retried on transient failures and the ` +
+			`package checked against its checksum when one is given.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("the location of the chaincode package must be provided")
+			}
+			if name == "" || version == "" {
+				return errors.New("the name and the version of the chaincode must be provided")
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			var err error
+			if pf == nil {
+				pf, err = newPackageFetcher(caFile, retries, util.PackageRulesFromConfig().MaxPackageSize)
+				if err != nil {
+					return err
+				}
+				pf.username, pf.password = username, password
+			}
+			if lc == nil {
+				lc, err = newLifecycleClient(address, tlsRootCertFile)
+				if err != nil {
+					return err
+				}
+			}
+			return install(lc, pf, name, version, args[0], checksum, os.Stdout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&address, "peerAddress", "", "The address of the peer, peer.address of the configuration by default")
+	flags.StringVar(&tlsRootCertFile, "tlsRootCertFile", "", "If TLS is enabled, the path to the TLS root cert file of the peer")
+	flags.StringVarP(&name, "name", "n", "", "Name of the chaincode")
+	flags.StringVarP(&version, "version", "v", "", "Version of the chaincode")
+	flags.StringVar(&checksum, "checksum", "", "The expected SHA-256 checksum of the package, in hex with an optional sha256: prefix")
+	flags.IntVar(&retries, "retries", 3, "The number of retries of the download of the package on transient failures")
+	flags.StringVar(&caFile, "cafile", "", "Path to a PEM encoded root certificate trusted for the download in addition to the system ones")
+	flags.StringVar(&username, "username", "", "The user name to authenticate to the repository of the package")
+	flags.StringVar(&password, "password", "", "The password to authenticate to the repository of the package")
+
+	return cmd
+}
+
+func install(lc *lifecycleClient, pf *packageFetcher, name, version, location, checksum string, out io.Writer) error {
+	pkg, err := pf.fetch(location)
+	if err != nil {
+		return err
+	}
+	if checksum != "" {
+		if err := verifyChecksum(pkg, checksum); err != nil {
+			return errors.WithMessage(err, "the package does not match its checksum")
+		}
+	}
+
+	result := &lb.InstallChaincodeResult{}
+	err = lc.invoke("InstallChaincode", &lb.InstallChaincodeArgs{
+		Name:                    name,
+		Version:                 version,
+		ChaincodeInstallPackage: pkg,
+	}, result)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Installed %s:%s with hash %s\n", name, version, hex.EncodeToString(result.Hash))
+	return nil
+}
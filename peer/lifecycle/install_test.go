@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstall(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		resp.Write([]byte("package"))
+	}))
+	defer server.Close()
+	pf := newTestFetcher(server)
+
+	payload, err := proto.Marshal(&lb.InstallChaincodeResult{Hash: []byte{0xab, 0xcd}})
+	require.NoError(t, err)
+	lc := newTestLifecycleClient(t, &pb.Response{Status: 200, Payload: payload}, nil)
+
+	out := &bytes.Buffer{}
+	err = install(lc, pf, "mycc", "1.0", server.URL+"/mycc.tar.gz", "sha256:"+sha256Hex([]byte("package")), out)
+	assert.NoError(t, err)
+	assert.Equal(t, "Installed mycc:1.0 with hash abcd\n", out.String())
+
+	err = install(lc, pf, "mycc", "1.0", server.URL+"/mycc.tar.gz", sha256Hex([]byte("other")), out)
+	assert.Contains(t, err.Error(), "the package does not match its checksum: checksum mismatch")
+
+	lc = newTestLifecycleClient(t, &pb.Response{Status: 500, Message: "could not parse as a chaincode install package"}, nil)
+	err = install(lc, pf, "mycc", "1.0", server.URL+"/mycc.tar.gz", "", out)
+	assert.EqualError(t, err, "bad response: 500 - could not parse as a chaincode install package")
+}
+
+func TestInstallCmd(t *testing.T) {
+	lc := newTestLifecycleClient(t, &pb.Response{Status: 200}, nil)
+	pf := &packageFetcher{}
+
+	cmd := installCmd(lc, pf)
+	cmd.SetArgs([]string{"-n", "mycc", "-v", "1.0"})
+	assert.EqualError(t, cmd.Execute(), "the location of the chaincode package must be provided")
+
+	cmd = installCmd(lc, pf)
+	cmd.SetArgs([]string{"-n", "mycc", "mycc.tar.gz"})
+	assert.EqualError(t, cmd.Execute(), "the name and the version of the chaincode must be provided")
+}
@@ -0,0 +1,50 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/peer/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	lifecycleFuncName = "lifecycle"
+	lifecycleCmdDes   = "Perform chaincode lifecycle operations: chaincode."
+	chaincodeFuncName = "chaincode"
+	chaincodeCmdDes   = "Operate the chaincodes of a peer: buildlogs|install|queryinstalled."
+)
+
+var logger = flogging.MustGetLogger("cli.lifecycle")
+
+// Cmd returns the cobra command for lifecycle
+func Cmd() *cobra.Command {
+	lifecycleCmd := &cobra.Command{
+		Use:              lifecycleFuncName,
+		Short:            fmt.Sprint(lifecycleCmdDes),
+		Long:             fmt.Sprint(lifecycleCmdDes),
+		PersistentPreRun: common.InitCmd,
+	}
+	lifecycleCmd.AddCommand(chaincodeCmd())
+
+	return lifecycleCmd
+}
+
+func chaincodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   chaincodeFuncName,
+		Short: fmt.Sprint(chaincodeCmdDes),
+		Long:  fmt.Sprint(chaincodeCmdDes),
+	}
+	cmd.AddCommand(buildLogsCmd(nil))
+	cmd.AddCommand(installCmd(nil, nil))
+	cmd.AddCommand(queryInstalledCmd(nil))
+
+	return cmd
+}
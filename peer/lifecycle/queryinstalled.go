@@ -0,0 +1,89 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/spf13/cobra"
+)
+
+func queryInstalledCmd(lc *lifecycleClient) *cobra.Command {
+	var (
+		address         string
+		tlsRootCertFile string
+		orphaned        bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "queryinstalled",
+		Short: "Query the chaincodes installed on a peer and the definitions referencing them.",
+		Long: `Lists the chaincode packages installed on a peer with the chaincode definitions of its channels ` +
+			`referencing each of them. The packages referenced by no definition can safely be removed from ` +
+			`the install directory of the peer.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("trailing args detected: %s", args)
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			if lc == nil {
+				var err error
+				lc, err = newLifecycleClient(address, tlsRootCertFile)
+				if err != nil {
+					return err
+				}
+			}
+			return queryInstalled(lc, orphaned, os.Stdout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&address, "peerAddress", "", "The address of the peer, peer.address of the configuration by default")
+	flags.StringVar(&tlsRootCertFile, "tlsRootCertFile", "", "If TLS is enabled, the path to the TLS root cert file of the peer")
+	flags.BoolVar(&orphaned, "orphaned", false, "Only list the packages referenced by no chaincode definition")
+
+	return cmd
+}
+
+func queryInstalled(lc *lifecycleClient, orphanedOnly bool, out io.Writer) error {
+	result := &lb.QueryInstalledChaincodesResult{}
+	if err := lc.invoke("QueryInstalledChaincodes", &lb.QueryInstalledChaincodesArgs{}, result); err != nil {
+		return err
+	}
+
+	listed := 0
+	for _, installed := range result.InstalledChaincodes {
+		if orphanedOnly && len(installed.References) != 0 {
+			continue
+		}
+		listed++
+		fmt.Fprintf(out, "%s:%s %s\n", installed.Name, installed.Version, hex.EncodeToString(installed.Hash))
+		if installed.PackageId != "" {
+			fmt.Fprintf(out, "  package ID %s\n", installed.PackageId)
+		}
+		if len(installed.References) == 0 {
+			fmt.Fprintln(out, "  orphaned")
+		}
+		for _, ref := range installed.References {
+			fmt.Fprintf(out, "  referenced by %s:%s on channel %s\n", ref.Name, ref.Version, ref.ChannelId)
+		}
+	}
+	if listed == 0 {
+		if orphanedOnly {
+			fmt.Fprintln(out, "No orphaned chaincode package installed")
+		} else {
+			fmt.Fprintln(out, "No chaincode package installed")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lifecycle
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	mockmsp "github.com/hyperledger/fabric/common/mocks/msp"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	lb "github.com/hyperledger/fabric/protos/peer/lifecycle"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLifecycleClient(t *testing.T, response *pb.Response, err error) *lifecycleClient {
+	signer, serr := mockmsp.NewNoopMsp().GetDefaultSigningIdentity()
+	require.NoError(t, serr)
+	return &lifecycleClient{
+		endorser: common.GetMockEndorserClient(&pb.ProposalResponse{Response: response}, err),
+		signer:   signer,
+	}
+}
+
+func TestQueryInstalled(t *testing.T) {
+	payload, err := proto.Marshal(&lb.QueryInstalledChaincodesResult{
+		InstalledChaincodes: []*lb.QueryInstalledChaincodesResult_InstalledChaincode{
+			{
+				Name:      "mycc",
+				Version:   "1.0",
+				Hash:      []byte{0x01, 0x02},
+				PackageId: "mycc_1.0:abcd",
+				References: []*lb.QueryInstalledChaincodesResult_Reference{
+					{ChannelId: "channel1", Name: "mycc", Version: "1.0"},
+				},
+			},
+			{Name: "mycc", Version: "0.9", Hash: []byte{0x03}},
+		},
+	})
+	require.NoError(t, err)
+	lc := newTestLifecycleClient(t, &pb.Response{Status: 200, Payload: payload}, nil)
+
+	out := &bytes.Buffer{}
+	err = queryInstalled(lc, false, out)
+	assert.NoError(t, err)
+	assert.Equal(t, "mycc:1.0 0102\n  package ID mycc_1.0:abcd\n  referenced by mycc:1.0 on channel channel1\nmycc:0.9 03\n  orphaned\n", out.String())
+
+	out.Reset()
+	err = queryInstalled(lc, true, out)
+	assert.NoError(t, err)
+	assert.Equal(t, "mycc:0.9 03\n  orphaned\n", out.String())
+
+	lc = newTestLifecycleClient(t, &pb.Response{Status: 200}, nil)
+	out.Reset()
+	err = queryInstalled(lc, true, out)
+	assert.NoError(t, err)
+	assert.Equal(t, "No orphaned chaincode package installed\n", out.String())
+}
+
+func TestQueryInstalledFailures(t *testing.T) {
+	lc := newTestLifecycleClient(t, &pb.Response{Status: 500, Message: "failed to invoke backing QueryInstalledChaincodes"}, nil)
+	err := queryInstalled(lc, false, &bytes.Buffer{})
+	assert.EqualError(t, err, "bad response: 500 - failed to invoke backing QueryInstalledChaincodes")
+
+	lc = newTestLifecycleClient(t, nil, errors.New("connection refused"))
+	err = queryInstalled(lc, false, &bytes.Buffer{})
+	assert.EqualError(t, err, "failed to endorse the proposal: connection refused")
+
+	lc = newTestLifecycleClient(t, &pb.Response{Status: 200, Payload: []byte("garbage")}, nil)
+	err = queryInstalled(lc, false, &bytes.Buffer{})
+	assert.Contains(t, err.Error(), "failed to unmarshal the result of QueryInstalledChaincodes")
+}
+
+func TestQueryInstalledCmd(t *testing.T) {
+	lc := newTestLifecycleClient(t, &pb.Response{Status: 200}, nil)
+
+	cmd := queryInstalledCmd(lc)
+	cmd.SetArgs([]string{"extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [extra]")
+
+	cmd = queryInstalledCmd(lc)
+	cmd.SetArgs([]string{"--orphaned"})
+	assert.NoError(t, cmd.Execute())
+}
@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/admin"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// blockFileSuffix is the suffix of the genesis block files in the auto-join directory
+const blockFileSuffix = ".block"
+
+// autoJoiner joins the channels of the genesis blocks found in a directory or
+// listed by a URL. The sources are polled, so that a fleet of peers joins a new channel as soon as its genesis
+// block is published. A channel that the peer failed to join from a given
+// source is not retried until the source changes
+type autoJoiner struct {
+	channelManager admin.ChannelManager
+	directory      string
+	url            string
+	httpClient     *http.Client
+
+	// failed holds the digests of the sources that could not be joined
+	failed map[string]struct{}
+}
+
+// startAutoJoin starts, if enabled, the automatic join of the channels
+func startAutoJoin(channelManager admin.ChannelManager) {
+	if !viper.GetBool("peer.autoJoin.enabled") {
+		return
+	}
+	interval := viper.GetDuration("peer.autoJoin.interval")
+	if interval <= 0 {
+		logger.Panicf("Invalid interval of the channel auto-join: %s", interval)
+	}
+	a := &autoJoiner{
+		channelManager: channelManager,
+		directory:      viper.GetString("peer.autoJoin.directory"),
+		url:            viper.GetString("peer.autoJoin.url"),
+		httpClient:     &http.Client{Timeout: viper.GetDuration("peer.autoJoin.requestTimeout")},
+		failed:         map[string]struct{}{},
+	}
+	if a.directory == "" && a.url == "" {
+		logger.Panic("The channel auto-join requires a directory or a URL")
+	}
+	go func() {
+		for {
+			a.scan()
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// scan joins the channels available in the sources that the peer has not joined yet
+func (a *autoJoiner) scan() {
+	channels, err := a.channelManager.Channels()
+	if err != nil {
+		logger.Errorf("Failed listing the channels of the peer: %s", err)
+		return
+	}
+	joined := map[string]struct{}{}
+	for _, c := range channels {
+		joined[c.ChannelId] = struct{}{}
+	}
+
+	if a.directory != "" {
+		a.scanDirectory(joined)
+	}
+	if a.url != "" {
+		a.scanURL(joined)
+	}
+}
+
+func (a *autoJoiner) scanDirectory(joined map[string]struct{}) {
+	entries, err := ioutil.ReadDir(a.directory)
+	if err != nil {
+		logger.Errorf("Failed reading the channel auto-join directory: %s", err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), blockFileSuffix) {
+			continue
+		}
+		path := filepath.Join(a.directory, entry.Name())
+		blockBytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			logger.Errorf("Failed reading the genesis block %s: %s", path, err)
+			continue
+		}
+		a.joinBlock(path, blockBytes, joined)
+	}
+}
+
+func (a *autoJoiner) scanURL(joined map[string]struct{}) {
+	listBytes, err := a.fetch(a.url)
+	if err != nil {
+		logger.Errorf("Failed fetching the list of genesis blocks: %s", err)
+		return
+	}
+	var blockURLs []string
+	if err := json.Unmarshal(listBytes, &blockURLs); err != nil {
+		logger.Errorf("Invalid list of genesis blocks at %s: %s", a.url, err)
+		return
+	}
+	base, err := url.Parse(a.url)
+	if err != nil {
+		logger.Errorf("Invalid channel auto-join URL %s: %s", a.url, err)
+		return
+	}
+	for _, blockURL := range blockURLs {
+		ref, err := url.Parse(blockURL)
+		if err != nil {
+			logger.Errorf("Invalid genesis block URL %s: %s", blockURL, err)
+			continue
+		}
+		resolved := base.ResolveReference(ref).String()
+		blockBytes, err := a.fetch(resolved)
+		if err != nil {
+			logger.Errorf("Failed fetching the genesis block %s: %s", resolved, err)
+			continue
+		}
+		a.joinBlock(resolved, blockBytes, joined)
+	}
+}
+
+func (a *autoJoiner) fetch(u string) ([]byte, error) {
+	resp, err := a.httpClient.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s answered with status %s", u, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func (a *autoJoiner) joinBlock(source string, blockBytes []byte, joined map[string]struct{}) {
+	digest := hex.EncodeToString(util.ComputeSHA256(blockBytes))
+	if _, ok := a.failed[digest]; ok {
+		return
+	}
+	block := &cb.Block{}
+	if err := proto.Unmarshal(blockBytes, block); err != nil {
+		logger.Errorf("Invalid genesis block %s: %s", source, err)
+		a.failed[digest] = struct{}{}
+		return
+	}
+	cid, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		logger.Errorf("Invalid genesis block %s: %s", source, err)
+		a.failed[digest] = struct{}{}
+		return
+	}
+	if _, ok := joined[cid]; ok {
+		return
+	}
+	a.join(digest, source, func() error {
+		return a.channelManager.JoinChannel(block)
+	})
+	joined[cid] = struct{}{}
+}
+
+func (a *autoJoiner) join(digest, source string, join func() error) {
+	if _, ok := a.failed[digest]; ok {
+		return
+	}
+	if err := join(); err != nil {
+		logger.Errorf("Failed joining the channel of %s: %s", source, err)
+		a.failed[digest] = struct{}{}
+		return
+	}
+	logger.Infof("Joined the channel of %s", source)
+}
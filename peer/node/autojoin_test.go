@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingChannelManager struct {
+	channels []*pb.ChannelHeight
+	joined   []string
+	joinErr  error
+}
+
+func (r *recordingChannelManager) JoinChannel(block *cb.Block) error {
+	cid, err := utils.GetChainIDFromBlock(block)
+	if err != nil {
+		return err
+	}
+	r.joined = append(r.joined, cid)
+	if r.joinErr != nil {
+		return r.joinErr
+	}
+	r.channels = append(r.channels, &pb.ChannelHeight{ChannelId: cid, Height: 1})
+	return nil
+}
+
+func (r *recordingChannelManager) LeaveChannel(cid string) error { return nil }
+
+func (r *recordingChannelManager) Channels() ([]*pb.ChannelHeight, error) { return r.channels, nil }
+
+func genesisBlockBytes(t *testing.T, cid string) []byte {
+	block, err := configtxtest.MakeGenesisBlock(cid)
+	require.NoError(t, err)
+	blockBytes, err := proto.Marshal(block)
+	require.NoError(t, err)
+	return blockBytes
+}
+
+func TestAutoJoinDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "autojoin")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "channel1.block"), genesisBlockBytes(t, "channel1"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "channel2.block"), genesisBlockBytes(t, "channel2"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "broken.block"), []byte("not a block"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "channel3"), 0755))
+
+	cm := &recordingChannelManager{channels: []*pb.ChannelHeight{{ChannelId: "channel1", Height: 10}}}
+	a := &autoJoiner{channelManager: cm, directory: dir, failed: map[string]struct{}{}}
+
+	a.scan()
+	assert.Equal(t, []string{"channel2"}, cm.joined)
+
+	// joined channels and failed sources are not attempted again
+	a.scan()
+	assert.Equal(t, []string{"channel2"}, cm.joined)
+}
+
+func TestAutoJoinURL(t *testing.T) {
+	blockBytes := genesisBlockBytes(t, "channel1")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/channels", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`["blocks/channel1.block", "blocks/missing.block"]`))
+	})
+	mux.HandleFunc("/blocks/channel1.block", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(blockBytes)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cm := &recordingChannelManager{joinErr: errors.New("ledger [channel1] already exists")}
+	a := &autoJoiner{channelManager: cm, url: server.URL + "/channels", httpClient: server.Client(), failed: map[string]struct{}{}}
+
+	a.scan()
+	assert.Equal(t, []string{"channel1"}, cm.joined)
+
+	// the block that failed to be joined is not retried
+	a.scan()
+	assert.Equal(t, []string{"channel1"}, cm.joined)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupFile  string
+	restoreFile string
+)
+
+func backupCmd() *cobra.Command {
+	nodeBackupCmd.ResetFlags()
+	flags := nodeBackupCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "Channel of which the ledger is backed up.")
+	flags.StringVarP(&backupFile, "outputFile", "o", "", "File to which the backup is written.")
+
+	return nodeBackupCmd
+}
+
+var nodeBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backs up the ledger of a channel.",
+	Long: `Backs up the block files, the block index, the state, private data, transient and history databases of ` +
+		`the ledger of a channel to a gzipped tar archive, along with a manifest holding the SHA-256 hash of each ` +
+		`entry of the archive. The state database is not backed up when it is CouchDB and is rebuilt from the blocks ` +
+		`upon restore. When the command is executed, the peer must be offline, so that the backup is consistent.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		if backupFile == "" {
+			return errors.New("Must supply the output file")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		file, err := os.Create(backupFile)
+		if err != nil {
+			return errors.Wrap(err, "failed creating the output file")
+		}
+		manifest, err := kvledger.Backup(channelID, file)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = errors.Wrap(closeErr, "failed writing the output file")
+		}
+		if err != nil {
+			os.Remove(backupFile)
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Backed up the ledger of channel %s at height %d to %s\n",
+			channelID, manifest.Height, backupFile)
+		return nil
+	},
+}
+
+func restoreCmd() *cobra.Command {
+	nodeRestoreCmd.ResetFlags()
+	flags := nodeRestoreCmd.Flags()
+	flags.StringVarP(&restoreFile, "inputFile", "i", "", "Backup from which the ledger is restored.")
+
+	return nodeRestoreCmd
+}
+
+var nodeRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores the ledger of a channel from a backup.",
+	Long: `Restores the ledger of a channel from a backup created with the backup command. The integrity of the ` +
+		`backup is verified against its manifest before anything is restored, and the ledger must not exist on the ` +
+		`peer. If the restore is interrupted, the partially restored ledger is removed upon the next start of the ` +
+		`peer. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if restoreFile == "" {
+			return errors.New("Must supply the input file")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		manifest, err := kvledger.Restore(restoreFile)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Restored the ledger of channel %s at height %d\n",
+			manifest.LedgerID, manifest.Height)
+		return nil
+	},
+}
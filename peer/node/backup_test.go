@@ -0,0 +1,58 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackupCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "backupcmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+	outputFile := filepath.Join(testPath, "ch1.tar.gz")
+
+	cmd := backupCmd()
+	cmd.SetArgs([]string{"-o", outputFile})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd = backupCmd()
+	cmd.SetArgs([]string{"-c", "ch1"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the output file")
+
+	cmd = backupCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-o", outputFile})
+	assert.EqualError(t, cmd.Execute(), "LedgerID does not exist")
+	_, err = os.Stat(outputFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRestoreCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "restorecmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+
+	cmd := restoreCmd()
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "Must supply the input file")
+
+	cmd = restoreCmd()
+	cmd.SetArgs([]string{"-i", filepath.Join(testPath, "ch1.tar.gz")})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed opening the backup")
+}
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/pkg/errors"
+)
+
+// channelMembers is the part of gossip providing the members of the channels
+type channelMembers interface {
+	PeersOfChannel(gossipcommon.ChainID) []discovery.NetworkMember
+	IdentityInfo() api.PeerIdentitySet
+}
+
+// gossipChannelMembership counts the peers of the channels by organization
+// from the alive members known to gossip
+type gossipChannelMembership struct {
+	gossip func() channelMembers
+}
+
+// PeersByOrg returns the number of peers of each organization alive in the
+// channel, the local peer excluded
+func (m *gossipChannelMembership) PeersByOrg(channelID string) (map[string]int, error) {
+	g := m.gossip()
+	if g == nil {
+		return nil, errors.New("gossip is not initialized")
+	}
+
+	identities := g.IdentityInfo().ByID()
+	peersByOrg := map[string]int{}
+	for _, member := range g.PeersOfChannel(gossipcommon.ChainID(channelID)) {
+		identity, ok := identities[string(member.PKIid)]
+		if !ok {
+			continue
+		}
+		peersByOrg[string(identity.Organization)]++
+	}
+	return peersByOrg, nil
+}
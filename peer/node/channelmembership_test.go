@@ -0,0 +1,55 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/stretchr/testify/assert"
+)
+
+type membershipGossip struct {
+	peers      map[string][]discovery.NetworkMember
+	identities api.PeerIdentitySet
+}
+
+func (g *membershipGossip) PeersOfChannel(channel gossipcommon.ChainID) []discovery.NetworkMember {
+	return g.peers[string(channel)]
+}
+
+func (g *membershipGossip) IdentityInfo() api.PeerIdentitySet {
+	return g.identities
+}
+
+func TestGossipChannelMembership(t *testing.T) {
+	g := &membershipGossip{
+		peers: map[string][]discovery.NetworkMember{
+			"mychannel": {{PKIid: gossipcommon.PKIidType("p1")}, {PKIid: gossipcommon.PKIidType("p2")}, {PKIid: gossipcommon.PKIidType("p3")}, {PKIid: gossipcommon.PKIidType("unknown")}},
+		},
+		identities: api.PeerIdentitySet{
+			{PKIId: gossipcommon.PKIidType("p1"), Organization: api.OrgIdentityType("Org1MSP")},
+			{PKIId: gossipcommon.PKIidType("p2"), Organization: api.OrgIdentityType("Org1MSP")},
+			{PKIId: gossipcommon.PKIidType("p3"), Organization: api.OrgIdentityType("Org2MSP")},
+		},
+	}
+	m := &gossipChannelMembership{gossip: func() channelMembers { return g }}
+
+	peersByOrg, err := m.PeersByOrg("mychannel")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"Org1MSP": 2, "Org2MSP": 1}, peersByOrg)
+
+	peersByOrg, err = m.PeersByOrg("otherchannel")
+	assert.NoError(t, err)
+	assert.Empty(t, peersByOrg)
+
+	m = &gossipChannelMembership{gossip: func() channelMembers { return nil }}
+	_, err = m.PeersByOrg("mychannel")
+	assert.EqualError(t, err, "gossip is not initialized")
+}
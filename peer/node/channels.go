@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	"github.com/hyperledger/fabric/core/peer"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// channelManager joins and leaves the channels on behalf of the admin service
+type channelManager struct {
+	ccp  ccprovider.ChaincodeProvider
+	sccp sysccprovider.SystemChaincodeProvider
+
+	createChainFromBlock func(cb *cb.Block, ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) error
+	initChain            func(cid string)
+	leaveChain           func(cid string) error
+	channelHeights       func() ([]*pb.ChannelHeight, error)
+}
+
+func newChannelManager(ccp ccprovider.ChaincodeProvider, sccp sysccprovider.SystemChaincodeProvider) *channelManager {
+	return &channelManager{
+		ccp:                  ccp,
+		sccp:                 sccp,
+		createChainFromBlock: peer.CreateChainFromBlock,
+		initChain:            peer.InitChain,
+		leaveChain:           peer.LeaveChain,
+		channelHeights:       peer.GetChannelHeights,
+	}
+}
+
+func (c *channelManager) JoinChannel(genesisBlock *cb.Block) error {
+	if genesisBlock.Header == nil || genesisBlock.Header.Number != 0 {
+		return errors.New("the block is not a genesis block")
+	}
+	if !utils.IsConfigBlock(genesisBlock) {
+		return errors.New("the block is not a config block")
+	}
+	cid, err := utils.GetChainIDFromBlock(genesisBlock)
+	if err != nil {
+		return err
+	}
+	if err := c.createChainFromBlock(genesisBlock, c.ccp, c.sccp); err != nil {
+		return err
+	}
+	c.initChain(cid)
+	logger.Infof("Joined channel %s", cid)
+	return nil
+}
+
+func (c *channelManager) LeaveChannel(channelID string) error {
+	return c.leaveChain(channelID)
+}
+
+func (c *channelManager) Channels() ([]*pb.ChannelHeight, error) {
+	return c.channelHeights()
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/sysccprovider"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelManagerJoinChannel(t *testing.T) {
+	var created *cb.Block
+	var initialized string
+	cm := &channelManager{
+		createChainFromBlock: func(block *cb.Block, _ ccprovider.ChaincodeProvider, _ sysccprovider.SystemChaincodeProvider) error {
+			created = block
+			return nil
+		},
+		initChain: func(cid string) { initialized = cid },
+	}
+
+	block, err := configtxtest.MakeGenesisBlock("mychannel")
+	assert.NoError(t, err)
+	assert.NoError(t, cm.JoinChannel(block))
+	assert.Equal(t, block, created)
+	assert.Equal(t, "mychannel", initialized)
+
+	block.Header.Number = 1
+	assert.EqualError(t, cm.JoinChannel(block), "the block is not a genesis block")
+
+	notConfig := &cb.Block{Header: &cb.BlockHeader{}, Data: &cb.BlockData{}}
+	assert.EqualError(t, cm.JoinChannel(notConfig), "the block is not a config block")
+}
+
+func TestChannelManagerLeaveChannel(t *testing.T) {
+	var left string
+	cm := &channelManager{
+		leaveChain: func(cid string) error {
+			left = cid
+			return nil
+		},
+	}
+	assert.NoError(t, cm.LeaveChannel("mychannel"))
+	assert.Equal(t, "mychannel", left)
+}
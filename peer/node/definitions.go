@@ -0,0 +1,41 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+)
+
+// channelDefinitions retrieves the chaincode definitions in the lscc namespace
+// of the ledgers of all the channels of the peer
+type channelDefinitions struct {
+	channels         func() []string
+	newQueryExecutor func(cid string) (ledger.QueryExecutor, error)
+}
+
+// ChaincodeDefinitions returns the chaincode definitions of each channel
+func (cd *channelDefinitions) ChaincodeDefinitions() (map[string]chaincode.MetadataSet, error) {
+	definitions := map[string]chaincode.MetadataSet{}
+	for _, cid := range cd.channels() {
+		var defs chaincode.MetadataSet
+		err := forEachChaincodeData(cid, cd.newQueryExecutor, func(data *ccprovider.ChaincodeData) {
+			defs = append(defs, chaincode.Metadata{
+				Name:    data.Name,
+				Version: data.Version,
+				Policy:  data.Policy,
+				Id:      data.Id,
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+		definitions[cid] = defs
+	}
+	return definitions, nil
+}
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/chaincode"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelDefinitions(t *testing.T) {
+	value, err := proto.Marshal(&ccprovider.ChaincodeData{Name: "mycc", Version: "1.0", Id: []byte("hash")})
+	assert.NoError(t, err)
+	ledgers := map[string][]*queryresult.KV{
+		"channel1": {
+			{Namespace: "lscc", Key: "mycc", Value: value},
+			{Namespace: "lscc", Key: "mycc~collection", Value: []byte("not chaincode data")},
+		},
+		"channel2": {},
+	}
+	cd := &channelDefinitions{
+		channels: func() []string { return []string{"channel1", "channel2"} },
+		newQueryExecutor: func(cid string) (ledger.QueryExecutor, error) {
+			kvs, ok := ledgers[cid]
+			if !ok {
+				return nil, errors.Errorf("channel %s does not exist", cid)
+			}
+			return &lsccQueryExecutor{kvs: kvs}, nil
+		},
+	}
+
+	definitions, err := cd.ChaincodeDefinitions()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]chaincode.MetadataSet{
+		"channel1": {{Name: "mycc", Version: "1.0", Id: []byte("hash")}},
+		"channel2": nil,
+	}, definitions)
+
+	cd.channels = func() []string { return []string{"channel3"} }
+	_, err = cd.ChaincodeDefinitions()
+	assert.EqualError(t, err, "failed to query the ledger of channel channel3: channel channel3 does not exist")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportNamespace   string
+	exportFile        string
+	exportPrivateData bool
+)
+
+func exportStateCmd() *cobra.Command {
+	nodeExportStateCmd.ResetFlags()
+	flags := nodeExportStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "Channel of which the state is exported.")
+	flags.StringVarP(&exportNamespace, "namespace", "n", "", "Namespace of the chaincode of which the state is exported.")
+	flags.StringVarP(&exportFile, "outputFile", "o", "", "File to which the state is exported.")
+	flags.BoolVarP(&exportPrivateData, "privateData", "", false, "Export the private data of the collections held by the peer along with their hashes.")
+
+	return nodeExportStateCmd
+}
+
+var nodeExportStateCmd = &cobra.Command{
+	Use:   "exportstate",
+	Short: "Exports the state of a chaincode namespace of a channel.",
+	Long: `Exports the state of a chaincode namespace of a channel to a file, along with the hashes of the private data ` +
+		`of the collections of the chaincode and, optionally, the private data, so that it can be imported in another ` +
+		`channel or network. The versions of the keys are not exported. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		if exportNamespace == "" {
+			return errors.New("Must supply the namespace")
+		}
+		if exportFile == "" {
+			return errors.New("Must supply the output file")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		file, err := os.Create(exportFile)
+		if err != nil {
+			return errors.Wrap(err, "failed creating the output file")
+		}
+		summary, err := kvledger.ExportNamespace(channelID, exportNamespace, exportPrivateData, file)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = errors.Wrap(closeErr, "failed writing the output file")
+		}
+		if err != nil {
+			os.Remove(exportFile)
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Exported %d keys of namespace %s of channel %s at block number %d\n",
+			summary.Keys, summary.Namespace, channelID, summary.BlockNumber)
+		return nil
+	},
+}
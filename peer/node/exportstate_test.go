@@ -0,0 +1,44 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportStateCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "exportstatecmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+	outputFile := filepath.Join(testPath, "mycc.json")
+
+	cmd := exportStateCmd()
+	cmd.SetArgs([]string{"-n", "mycc", "-o", outputFile})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd = exportStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-o", outputFile})
+	assert.EqualError(t, cmd.Execute(), "Must supply the namespace")
+
+	cmd = exportStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the output file")
+
+	cmd = exportStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-n", "mycc", "-o", outputFile})
+	assert.EqualError(t, cmd.Execute(), "chaincode mycc is not defined on channel ch1")
+	_, err = os.Stat(outputFile)
+	assert.True(t, os.IsNotExist(err))
+}
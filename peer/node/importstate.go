@@ -0,0 +1,66 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importNamespace string
+	importFile      string
+)
+
+func importStateCmd() *cobra.Command {
+	nodeImportStateCmd.ResetFlags()
+	flags := nodeImportStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "Channel in which the state is imported.")
+	flags.StringVarP(&importNamespace, "namespace", "n", "", "Namespace in which the state is imported, if not the exported namespace.")
+	flags.StringVarP(&importFile, "inputFile", "i", "", "File from which the state is imported.")
+
+	return nodeImportStateCmd
+}
+
+var nodeImportStateCmd = &cobra.Command{
+	Use:   "importstate",
+	Short: "Imports the state of a chaincode namespace in a channel.",
+	Long: `Imports the state of a chaincode namespace exported with the exportstate command in a channel, at the ` +
+		`block number of the state of the channel. The namespace and the private data of its collections must be ` +
+		`empty. All the peers of the channel are expected to import the same file at the same block number, which can ` +
+		`be verified with the statehash command. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		if importFile == "" {
+			return errors.New("Must supply the input file")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		file, err := os.Open(importFile)
+		if err != nil {
+			return errors.Wrap(err, "failed opening the input file")
+		}
+		defer file.Close()
+		summary, err := kvledger.ImportNamespace(channelID, importNamespace, file)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported %d keys in namespace %s of channel %s at block number %d\n",
+			summary.Keys, summary.Namespace, channelID, summary.BlockNumber)
+		return nil
+	},
+}
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportStateCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "importstatecmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+	inputFile := filepath.Join(testPath, "mycc.json")
+
+	cmd := importStateCmd()
+	cmd.SetArgs([]string{"-i", inputFile})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd = importStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the input file")
+
+	cmd = importStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-i", inputFile})
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed opening the input file")
+
+	err = ioutil.WriteFile(inputFile, []byte(`{"namespace":"mycc","block_number":5}`+"\n"), 0644)
+	assert.NoError(t, err)
+	cmd = importStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-i", inputFile})
+	assert.EqualError(t, cmd.Execute(), "the state database is empty")
+}
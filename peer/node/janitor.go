@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// startChaincodeJanitor starts, if enabled, the collection of the docker images
+// and containers of the chaincode versions no longer defined on any channel
+func startChaincodeJanitor() {
+	if !viper.GetBool("vm.docker.janitor.enabled") {
+		return
+	}
+	interval := viper.GetDuration("vm.docker.janitor.interval")
+	if interval <= 0 {
+		logger.Panicf("Invalid interval of the chaincode janitor: %s", interval)
+	}
+	janitor := dockercontroller.NewJanitor(
+		viper.GetString("peer.id"),
+		viper.GetString("peer.networkId"),
+		viper.GetDuration("vm.docker.janitor.retention"),
+		definedChaincodeVersions(channelIDs, newChannelQueryExecutor),
+	)
+	go janitor.Run(interval, nil)
+}
+
+func newChannelQueryExecutor(cid string) (ledger.QueryExecutor, error) {
+	l := peer.GetLedger(cid)
+	if l == nil {
+		return nil, errors.Errorf("channel %s does not exist", cid)
+	}
+	return l.NewQueryExecutor()
+}
+
+func channelIDs() []string {
+	var ids []string
+	for _, info := range peer.GetChannelsInfo() {
+		ids = append(ids, info.ChannelId)
+	}
+	return ids
+}
+
+// definedChaincodeVersions returns the function listing the chaincode versions
+// defined in the lscc namespace of the ledgers of all the channels of the peer
+func definedChaincodeVersions(channels func() []string, newQueryExecutor func(cid string) (ledger.QueryExecutor, error)) dockercontroller.DefinedVersions {
+	return func() (map[dockercontroller.ChaincodeVersion]struct{}, error) {
+		defined := map[dockercontroller.ChaincodeVersion]struct{}{}
+		for _, cid := range channels() {
+			err := forEachChaincodeData(cid, newQueryExecutor, func(cd *ccprovider.ChaincodeData) {
+				defined[dockercontroller.ChaincodeVersion{Name: cd.Name, Version: cd.Version}] = struct{}{}
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		return defined, nil
+	}
+}
+
+// forEachChaincodeData calls f with the definition of each chaincode in the
+// lscc namespace of the ledger of the channel
+func forEachChaincodeData(cid string, newQueryExecutor func(cid string) (ledger.QueryExecutor, error), f func(*ccprovider.ChaincodeData)) error {
+	qe, err := newQueryExecutor(cid)
+	if err != nil {
+		return errors.WithMessage(err, "failed to query the ledger of channel "+cid)
+	}
+	defer qe.Done()
+
+	itr, err := qe.GetStateRangeScanIterator("lscc", "", "")
+	if err != nil {
+		return errors.WithMessage(err, "failed to query the chaincodes of channel "+cid)
+	}
+	defer itr.Close()
+
+	for {
+		result, err := itr.Next()
+		if err != nil {
+			return errors.WithMessage(err, "failed to query the chaincodes of channel "+cid)
+		}
+		if result == nil {
+			return nil
+		}
+		kv := result.(*queryresult.KV)
+		if privdata.IsCollectionConfigKey(kv.Key) {
+			continue
+		}
+		cd := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(kv.Value, cd); err != nil {
+			return errors.Wrapf(err, "invalid definition of chaincode %s on channel %s", kv.Key, cid)
+		}
+		f(cd)
+	}
+}
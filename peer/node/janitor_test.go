@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type lsccQueryExecutor struct {
+	ledger.QueryExecutor
+	kvs []*queryresult.KV
+}
+
+func (qe *lsccQueryExecutor) GetStateRangeScanIterator(namespace, startKey, endKey string) (commonledger.ResultsIterator, error) {
+	return &kvIterator{kvs: qe.kvs}, nil
+}
+
+func (qe *lsccQueryExecutor) Done() {}
+
+type kvIterator struct {
+	kvs []*queryresult.KV
+}
+
+func (itr *kvIterator) Next() (commonledger.QueryResult, error) {
+	if len(itr.kvs) == 0 {
+		return nil, nil
+	}
+	kv := itr.kvs[0]
+	itr.kvs = itr.kvs[1:]
+	return kv, nil
+}
+
+func (itr *kvIterator) Close() {}
+
+func chaincodeDataKV(t *testing.T, name, version string) *queryresult.KV {
+	value, err := proto.Marshal(&ccprovider.ChaincodeData{Name: name, Version: version})
+	assert.NoError(t, err)
+	return &queryresult.KV{Namespace: "lscc", Key: name, Value: value}
+}
+
+func TestDefinedChaincodeVersions(t *testing.T) {
+	ledgers := map[string][]*queryresult.KV{
+		"channel1": {
+			chaincodeDataKV(t, "mycc", "1.0"),
+			{Namespace: "lscc", Key: "mycc~collection", Value: []byte("not chaincode data")},
+		},
+		"channel2": {
+			chaincodeDataKV(t, "mycc", "2.0"),
+			chaincodeDataKV(t, "othercc", "1.0"),
+		},
+	}
+	channels := func() []string { return []string{"channel1", "channel2"} }
+	newQueryExecutor := func(cid string) (ledger.QueryExecutor, error) {
+		kvs, ok := ledgers[cid]
+		if !ok {
+			return nil, errors.Errorf("channel %s does not exist", cid)
+		}
+		return &lsccQueryExecutor{kvs: kvs}, nil
+	}
+
+	defined, err := definedChaincodeVersions(channels, newQueryExecutor)()
+	assert.NoError(t, err)
+	assert.Equal(t, map[dockercontroller.ChaincodeVersion]struct{}{
+		{Name: "mycc", Version: "1.0"}:    {},
+		{Name: "mycc", Version: "2.0"}:    {},
+		{Name: "othercc", Version: "1.0"}: {},
+	}, defined)
+
+	channels = func() []string { return []string{"channel1", "channel3"} }
+	_, err = definedChaincodeVersions(channels, newQueryExecutor)()
+	assert.EqualError(t, err, "failed to query the ledger of channel channel3: channel channel3 does not exist")
+}
@@ -0,0 +1,146 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/binary"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const stateDatabaseKey = "ledger.state.stateDatabase"
+
+// stateDatabases are the backends to which the state database can be migrated
+var stateDatabases = []string{"goleveldb", "CouchDB"}
+
+var migrateTarget string
+
+func migrateStateCmd() *cobra.Command {
+	nodeMigrateStateCmd.ResetFlags()
+	flags := nodeMigrateStateCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "Channel of which the state database is migrated.")
+	flags.StringVarP(&migrateTarget, "target", "t", "", "State database to which the state is migrated, goleveldb or CouchDB.")
+
+	return nodeMigrateStateCmd
+}
+
+var nodeMigrateStateCmd = &cobra.Command{
+	Use:   "migratestate",
+	Short: "Migrates the state of a channel to another state database.",
+	Long: `Migrates the state of a channel from the configured state database to another state database. The state ` +
+		`is rebuilt in the target state database from the blocks and the private data of the ledger, including the ` +
+		`metadata of the keys and the hashes of the private data, and is verified against the canonical hash of the ` +
+		`state in the configured state database. The state database of the peer can be switched to the target state ` +
+		`database once all its channels are migrated. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		if !isStateDatabase(migrateTarget) {
+			return errors.Errorf("Must supply the target state database, one of %v", stateDatabases)
+		}
+		source := viper.GetString(stateDatabaseKey)
+		if source == migrateTarget {
+			return errors.Errorf("the state database of the peer is already %s", migrateTarget)
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Computing the hash of the state of channel %s in %s\n", channelID, source)
+		sourceHash, err := kvledger.ComputeStateHash(channelID, privacyenabledstate.StateHashOptions{Bucket: -1})
+		if err != nil {
+			return err
+		}
+
+		viper.Set(stateDatabaseKey, migrateTarget)
+		defer viper.Set(stateDatabaseKey, source)
+		fmt.Fprintf(cmd.OutOrStdout(), "Rebuilding the state of channel %s in %s from %d blocks\n",
+			channelID, migrateTarget, sourceHash.BlockNumber+1)
+		if err := rebuildStateDB(channelID); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Verifying the state of channel %s in %s\n", channelID, migrateTarget)
+		targetHash, err := kvledger.ComputeStateHash(channelID, privacyenabledstate.StateHashOptions{Bucket: -1})
+		if err != nil {
+			return err
+		}
+		if targetHash.BlockNumber != sourceHash.BlockNumber || targetHash.Hash != sourceHash.Hash {
+			return errors.Errorf("the state of channel [%s] in %s at block number [%d] has hash [%s], while the state in %s at block number [%d] has hash [%s]",
+				channelID, migrateTarget, targetHash.BlockNumber, targetHash.Hash, source, sourceHash.BlockNumber, sourceHash.Hash)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Migrated the state of channel %s to %s at block number %d with hash %s\n",
+			channelID, migrateTarget, targetHash.BlockNumber, targetHash.Hash)
+		fmt.Fprintf(cmd.OutOrStdout(), "Set %s to %s once all the channels of the peer are migrated\n", stateDatabaseKey, migrateTarget)
+		return nil
+	},
+}
+
+func isStateDatabase(name string) bool {
+	for _, stateDatabase := range stateDatabases {
+		if name == stateDatabase {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuildStateDB drops the state of the given channel from the configured
+// state database and commits again the blocks of the ledger, the same way
+// the peer recovers its state database on start
+func rebuildStateDB(ledgerID string) error {
+	if err := kvledger.DropStateDB(ledgerID); err != nil {
+		return err
+	}
+
+	identityDeserializerFactory := func(chainID string) msp.IdentityDeserializer {
+		return mgmt.GetManagerForChain(chainID)
+	}
+	ledgermgmt.Initialize(
+		&ledgermgmt.Initializer{
+			CustomTxProcessors: peer.ConfigTxProcessors,
+			PlatformRegistry: platforms.NewRegistry(
+				&golang.Platform{},
+				&node.Platform{},
+				&java.Platform{},
+				&car.Platform{},
+				&binary.Platform{},
+			),
+			DeployedChaincodeInfoProvider: &lscc.DeployedCCInfoProvider{},
+			MembershipInfoProvider:        privdata.NewMembershipInfoProvider(createSelfSignedData(), identityDeserializerFactory),
+			MetricsProvider:               &disabled.Provider{},
+		},
+	)
+	defer ledgermgmt.Close()
+
+	l, err := ledgermgmt.OpenLedger(ledgerID)
+	if err != nil {
+		return err
+	}
+	l.Close()
+	return nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateStateCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "migratestatecmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	viper.Set("ledger.state.stateDatabase", "goleveldb")
+	defer viper.Reset()
+
+	cmd := migrateStateCmd()
+	cmd.SetArgs([]string{"-t", "CouchDB"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd = migrateStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the target state database, one of [goleveldb CouchDB]")
+
+	cmd = migrateStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-t", "leveldb"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the target state database, one of [goleveldb CouchDB]")
+
+	cmd = migrateStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-t", "goleveldb"})
+	assert.EqualError(t, cmd.Execute(), "the state database of the peer is already goleveldb")
+
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	cmd = migrateStateCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-t", "goleveldb"})
+	assert.Error(t, cmd.Execute())
+	assert.Equal(t, "CouchDB", viper.GetString("ledger.state.stateDatabase"))
+}
@@ -26,7 +26,7 @@ import (
 
 const (
 	nodeFuncName = "node"
-	nodeCmdDes   = "Operate a peer node: start|status."
+	nodeCmdDes   = "Operate a peer node: start|status|rollback|statehash|exportstate|importstate|migratestate|backup|restore."
 )
 
 var logger = flogging.MustGetLogger("nodeCmd")
@@ -35,6 +35,13 @@ var logger = flogging.MustGetLogger("nodeCmd")
 func Cmd() *cobra.Command {
 	nodeCmd.AddCommand(startCmd())
 	nodeCmd.AddCommand(statusCmd())
+	nodeCmd.AddCommand(rollbackCmd())
+	nodeCmd.AddCommand(stateHashCmd())
+	nodeCmd.AddCommand(exportStateCmd())
+	nodeCmd.AddCommand(importStateCmd())
+	nodeCmd.AddCommand(migrateStateCmd())
+	nodeCmd.AddCommand(backupCmd())
+	nodeCmd.AddCommand(restoreCmd())
 
 	return nodeCmd
 }
@@ -0,0 +1,74 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/reload"
+	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/watchdog"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// newReloader creates the reloader of the settings of core.yaml which the peer
+// applies without restarting, upon a SIGHUP or a call to the operations endpoint
+// /reload
+func newReloader(serverEndorser *endorser.Endorser, memoryWatchdog *watchdog.Watchdog) (*reload.Reloader, error) {
+	reloader, err := reload.New(reload.ViperLoader())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to load the reloadable settings")
+	}
+
+	reloader.Register(func() error {
+		return activateLogSpec(viper.GetString("peer.logging.spec"))
+	}, "peer.logging.spec")
+
+	reloader.Register(func() error {
+		if serverEndorser.DedupCache == nil {
+			return errors.New("the deduplication of the transaction IDs was disabled at startup, it cannot be enabled without a restart")
+		}
+		return serverEndorser.DedupCache.SetLimits(
+			viper.GetDuration("peer.txIDDedup.window"),
+			viper.GetInt("peer.txIDDedup.maxEntries"),
+		)
+	}, "peer.txIDDedup")
+
+	reloader.Register(func() error {
+		if serverEndorser.QueryCache == nil {
+			return errors.New("the query cache was disabled at startup, it cannot be enabled without a restart")
+		}
+		return serverEndorser.QueryCache.SetLimits(
+			viper.GetDuration("peer.queryCache.ttl"),
+			viper.GetInt("peer.queryCache.maxEntries"),
+		)
+	}, "peer.queryCache")
+
+	reloader.Register(func() error {
+		serverEndorser.HeightGate.SetWaitTimeout(viper.GetDuration("peer.minLedgerHeight.waitTimeout"))
+		return nil
+	}, "peer.minLedgerHeight.waitTimeout")
+
+	reloader.Register(func() error {
+		config := memoryWatchdogConfig()
+		return memoryWatchdog.SetLimits(config.SoftLimit, config.HardLimit, config.DeliverDelay)
+	}, "peer.memoryWatchdog.softLimit", "peer.memoryWatchdog.hardLimit", "peer.memoryWatchdog.deliverDelay")
+
+	return reloader, nil
+}
+
+// activateLogSpec activates the given logging spec of core.yaml, if any, which
+// otherwise leaves the logging spec of the environment in effect
+func activateLogSpec(spec string) error {
+	if spec == "" {
+		return nil
+	}
+	if err := flogging.Global.ActivateSpec(spec); err != nil {
+		return errors.WithMessage(err, "invalid logging spec")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "rollbackcmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+
+	cmd := rollbackCmd()
+	cmd.SetArgs([]string{"-b", "10"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd = rollbackCmd()
+	cmd.SetArgs([]string{"-c", "ch1"})
+	assert.EqualError(t, cmd.Execute(), "Must supply block number")
+
+	cmd = rollbackCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "-b", "10"})
+	assert.EqualError(t, cmd.Execute(), "ledgerID [ch1] does not exist")
+}
@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package node
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
@@ -24,6 +26,7 @@ import (
 	floggingmetrics "github.com/hyperledger/fabric/common/flogging/metrics"
 	"github.com/hyperledger/fabric/common/grpclogging"
 	"github.com/hyperledger/fabric/common/grpcmetrics"
+	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
 	"github.com/hyperledger/fabric/common/localmsp"
 	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/common/metrics"
@@ -38,6 +41,7 @@ import (
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/binary"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/car"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
 	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
@@ -46,23 +50,36 @@ import (
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
+	coreconfig "github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/inproccontroller"
+	deliverclient "github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/endorser"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
+	"github.com/hyperledger/fabric/core/handlers/interception"
 	"github.com/hyperledger/fabric/core/handlers/library"
 	validation "github.com/hyperledger/fabric/core/handlers/validation/api"
+	"github.com/hyperledger/fabric/core/interop"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/policy"
+	"github.com/hyperledger/fabric/core/rest"
 	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/core/scc/assets"
+	"github.com/hyperledger/fabric/core/scc/batchscc"
 	"github.com/hyperledger/fabric/core/scc/cscc"
+	"github.com/hyperledger/fabric/core/scc/interopscc"
 	"github.com/hyperledger/fabric/core/scc/lscc"
 	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/core/scheduler"
+	"github.com/hyperledger/fabric/core/standby"
+	"github.com/hyperledger/fabric/core/watchdog"
 	"github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
 	discsupport "github.com/hyperledger/fabric/discovery/support"
@@ -152,6 +169,7 @@ func serve(args []string) error {
 		&node.Platform{},
 		&java.Platform{},
 		&car.Platform{},
+		&binary.Platform{},
 	)
 
 	deployedCCInfoProvider := &lscc.DeployedCCInfoProvider{}
@@ -170,6 +188,18 @@ func serve(args []string) error {
 	metricsProvider := opsSystem.Provider
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.Global.SetObserver(logObserver)
+	if err := activateLogSpec(viper.GetString("peer.logging.spec")); err != nil {
+		return err
+	}
+
+	devMode := chaincodeDevMode || viper.GetString("chaincode.mode") == chaincode.DevModeUserRunsChaincode
+	if err := waitForDependencies(opsSystem, devMode); err != nil {
+		return err
+	}
+
+	if err := validateSnapshotIsolationConfig(); err != nil {
+		return err
+	}
 
 	membershipInfoProvider := privdata.NewMembershipInfoProvider(createSelfSignedData(), identityDeserializerFactory)
 	//initialize resource management exit
@@ -208,12 +238,34 @@ func serve(args []string) error {
 		return fmt.Errorf("peer address is not in the format of host:port: %v", err)
 	}
 
+	libConf := library.Config{}
+	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
+		return errors.WithMessage(err, "could not load YAML config")
+	}
+	interceptors, err := loadInterceptors(libConf.Interceptors)
+	if err != nil {
+		return err
+	}
+
 	listenAddr := viper.GetString("peer.listenAddress")
 	serverConfig, err := peer.GetServerConfig()
 	if err != nil {
 		logger.Fatalf("Error loading secure config for peer (%s)", err)
 	}
 
+	memoryWatchdog, err := watchdog.New(memoryWatchdogConfig(), metricsProvider)
+	if err != nil {
+		return errors.WithMessage(err, "invalid memory watchdog configuration")
+	}
+	if viper.GetBool("peer.memoryWatchdog.enabled") {
+		memoryWatchdog.Start()
+		defer memoryWatchdog.Stop()
+	}
+
+	standbyMode := standby.NewMode(viper.GetBool("peer.standby.enabled"))
+	opsSystem.RegisterHandler("/standby", standbyMode)
+	opsSystem.RegisterHandler("/ledger/compaction", &leveldbhelper.CompactionHandler{RootDir: coreconfig.GetPath("peer.fileSystemPath")})
+
 	throttle := comm.NewThrottle(grpcMaxConcurrency)
 	serverConfig.Logger = flogging.MustGetLogger("core.comm").With("server", "PeerServer")
 	serverConfig.MetricsProvider = metricsProvider
@@ -221,20 +273,35 @@ func serve(args []string) error {
 		serverConfig.UnaryInterceptors,
 		grpcmetrics.UnaryServerInterceptor(grpcmetrics.NewUnaryMetrics(metricsProvider)),
 		grpclogging.UnaryServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
+		memoryWatchdog.UnaryServerInterceptor,
+		standbyMode.UnaryServerInterceptor,
 		throttle.UnaryServerIntercptor,
 	)
 	serverConfig.StreamInterceptors = append(
 		serverConfig.StreamInterceptors,
 		grpcmetrics.StreamServerInterceptor(grpcmetrics.NewStreamMetrics(metricsProvider)),
 		grpclogging.StreamServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
+		memoryWatchdog.StreamServerInterceptor,
 		throttle.StreamServerInterceptor,
 	)
+	addInterceptors(&serverConfig, interceptors)
 
 	peerServer, err := peer.NewPeerServer(listenAddr, serverConfig)
 	if err != nil {
 		logger.Fatalf("Failed to create peer server (%s)", err)
 	}
 
+	// The additional listeners serve the peer services on other interfaces, such as
+	// the one through which other organizations reach the peer for gossip
+	var additionalListeners []net.Listener
+	for _, address := range viper.GetStringSlice("peer.additionalListenAddresses") {
+		lis, err := comm.Listen(address, serverConfig.ReusePort)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to listen on the additional address %s", address))
+		}
+		additionalListeners = append(additionalListeners, lis)
+	}
+
 	if serverConfig.SecOpts.UseTLS {
 		logger.Info("Starting peer with TLS enabled")
 		// set up credential support
@@ -251,6 +318,14 @@ func serve(args []string) error {
 			logger.Fatalf("Failed to set TLS client certificate: %s", err)
 		}
 		comm.GetCredentialSupport().SetClientCertificate(clientCert)
+
+		// the connections to the other peers and to the orderers follow the
+		// TLS policy of the peer
+		tlsPolicy, err := peer.GetTLSPolicy()
+		if err != nil {
+			logger.Fatalf("Failed to set TLS policy: %s", err)
+		}
+		comm.GetCredentialSupport().SetTLSPolicy(tlsPolicy)
 	}
 
 	mutualTLS := serverConfig.SecOpts.UseTLS && serverConfig.SecOpts.RequireClientCert
@@ -263,13 +338,19 @@ func serve(args []string) error {
 	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverChainManager{}, metricsProvider)
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
+	chaincodeEventCheckpoints := peer.NewChaincodeEventCheckpoints(filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "chaincodeEvents"))
+	defer chaincodeEventCheckpoints.Close()
+	chaincodeEventsServer := peer.NewChaincodeEventsServer(abServer, chaincodeEventCheckpoints, viper.GetInt("peer.chaincodeEvents.maxUnacknowledgedBlocks"))
+	pb.RegisterChaincodeEventsServer(peerServer.Server(), chaincodeEventsServer)
+
 	// Initialize chaincode service
 	chaincodeSupport, ccp, sccp, packageProvider := startChaincodeServer(peerHost, aclProvider, pr, opsSystem)
 
 	logger.Debugf("Running peer")
 
 	// Start the Admin server
-	startAdminServer(listenAddr, peerServer.Server(), metricsProvider)
+	channelManager := newChannelManager(ccp, sccp)
+	startAdminServer(listenAddr, peerServer.Server(), metricsProvider, channelManager, interceptors)
 
 	privDataDist := func(channel string, txID string, privateData *transientstore.TxPvtReadWriteSetWithConfigInfo, blkHt uint64) error {
 		return service.GetGossipService().DistributePrivateData(channel, txID, privateData, blkHt)
@@ -281,10 +362,6 @@ func serve(args []string) error {
 		logger.Panicf("Failed serializing self identity: %v", err)
 	}
 
-	libConf := library.Config{}
-	if err = viperutil.EnhancedExactUnmarshalKey("peer.handlers", &libConf); err != nil {
-		return errors.WithMessage(err, "could not load YAML config")
-	}
 	reg := library.InitRegistry(libConf)
 
 	authFilters := reg.Lookup(library.Auth).([]authHandler.Filter)
@@ -295,20 +372,66 @@ func serve(args []string) error {
 		ChaincodeSupport: chaincodeSupport,
 		SysCCProvider:    sccp,
 		ACLProvider:      aclProvider,
+		LocalSigningIdentity: func() (endorsement3.SigningIdentity, error) {
+			return mgmt.GetLocalMSP().GetDefaultSigningIdentity()
+		},
 	}
 	endorsementPluginsByName := reg.Lookup(library.Endorsement).(map[string]endorsement2.PluginFactory)
 	validationPluginsByName := reg.Lookup(library.Validation).(map[string]validation.PluginFactory)
 	signingIdentityFetcher := (endorsement3.SigningIdentityFetcher)(endorserSupport)
 	channelStateRetriever := endorser.ChannelStateRetriever(endorserSupport)
 	pluginMapper := endorser.MapBasedPluginMapper(endorsementPluginsByName)
+	endorsementCapabilities := make(map[string][]string)
+	for name, handlerConfig := range libConf.Endorsers {
+		endorsementCapabilities[name] = handlerConfig.Capabilities
+	}
+	pluginCapabilities, err := endorser.NewPluginCapabilities(endorsementCapabilities)
+	if err != nil {
+		return errors.WithMessage(err, "invalid endorsement plugin configuration")
+	}
 	pluginEndorser := endorser.NewPluginEndorser(&endorser.PluginSupport{
 		ChannelStateRetriever:   channelStateRetriever,
 		TransientStoreRetriever: peer.TransientStoreFactory,
 		PluginMapper:            pluginMapper,
 		SigningIdentityFetcher:  signingIdentityFetcher,
+		PluginCapabilities:      pluginCapabilities,
 	})
 	endorserSupport.PluginEndorser = pluginEndorser
 	serverEndorser := endorser.NewEndorserServer(privDataDist, endorserSupport, pr, metricsProvider)
+	serverEndorser.DedupCache = endorser.NewDedupCache(
+		viper.GetDuration("peer.txIDDedup.window"),
+		viper.GetInt("peer.txIDDedup.maxEntries"),
+	)
+	if serverEndorser.QueryCache = endorser.NewQueryCache(
+		viper.GetDuration("peer.queryCache.ttl"),
+		viper.GetInt("peer.queryCache.maxEntries"),
+	); serverEndorser.QueryCache != nil {
+		peer.AddCommitListener(serverEndorser.QueryCache.Invalidate)
+	}
+	serverEndorser.HeightGate = &endorser.LedgerHeightGate{
+		WaitTimeout: viper.GetDuration("peer.minLedgerHeight.waitTimeout"),
+		Ledger: func(channelID string) endorser.ChannelLedger {
+			if lgr := peer.GetLedger(channelID); lgr != nil {
+				return lgr
+			}
+			return nil
+		},
+		PeersAtHeight: func(channelID string, height uint64) []string {
+			var endpoints []string
+			for _, member := range service.GetGossipService().PeersOfChannel(gossipcommon.ChainID(channelID)) {
+				if member.Properties != nil && member.Properties.LedgerHeight >= height && member.Endpoint != "" {
+					endpoints = append(endpoints, member.Endpoint)
+				}
+			}
+			return endpoints
+		},
+	}
+
+	reloader, err := newReloader(serverEndorser, memoryWatchdog)
+	if err != nil {
+		return err
+	}
+	opsSystem.RegisterHandler("/reload", reloader)
 	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
@@ -321,13 +444,27 @@ func serve(args []string) error {
 		return err
 	}
 	defer service.GetGossipService().Stop()
+	if promoter, ok := service.GetGossipService().(service.Promoter); ok {
+		standbyMode.OnPromotion(promoter.Promote)
+	}
+	if reporter, ok := service.GetGossipService().(service.MembershipReporter); ok {
+		opsSystem.RegisterHandler("/gossip/membership", &service.MembershipHandler{Reporter: reporter})
+	}
 
 	// register prover grpc service
-	// FAB-12971 disable prover service before v1.4 cut. Will uncomment after v1.4 cut
-	// err = registerProverService(peerServer, aclProvider, signingIdentity)
-	// if err != nil {
-	// 	return err
-	// }
+	err = registerProverService(peerServer, aclProvider, signingIdentity)
+	if err != nil {
+		return err
+	}
+
+	// register the interop relay grpc service
+	err = registerRelayService(peerServer, serverConfig, auth, signingIdentity)
+	if err != nil {
+		return err
+	}
+
+	// register the scheduler grpc service
+	registerSchedulerService(peerServer, auth, aclProvider, endorserSupport)
 
 	// initialize system chaincodes
 
@@ -361,10 +498,22 @@ func serve(args []string) error {
 	}, ccp, sccp, txvalidator.MapBasedPluginMapper(validationPluginsByName),
 		pr, deployedCCInfoProvider, membershipInfoProvider, metricsProvider)
 
+	var discoveryService rest.Discovery
 	if viper.GetBool("peer.discovery.enabled") {
-		registerDiscoveryService(peerServer, policyMgr, lifecycle)
+		discoveryService = registerDiscoveryService(peerServer, policyMgr, lifecycle)
 	}
 
+	if viper.GetBool("peer.rest.enabled") {
+		restServer := newRESTServer(aclProvider, discoveryService)
+		if err := restServer.Start(); err != nil {
+			logger.Panicf("Failed starting the REST server: %s", err)
+		}
+		defer restServer.Stop()
+	}
+
+	startChaincodeJanitor()
+	startAutoJoin(channelManager)
+
 	networkID := viper.GetString("peer.networkId")
 
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]", peerEndpoint.Id, networkID, peerEndpoint.Address)
@@ -387,6 +536,14 @@ func serve(args []string) error {
 		}
 		serve <- grpcErr
 	}()
+	for _, lis := range additionalListeners {
+		go func(lis net.Listener) {
+			logger.Infof("Serving the peer services on the additional address %s", lis.Addr())
+			if grpcErr := peerServer.Serve(lis); grpcErr != nil {
+				serve <- fmt.Errorf("grpc server exited with error on %s: %s", lis.Addr(), grpcErr)
+			}
+		}(lis)
+	}
 
 	// Start profiling http endpoint if enabled
 	if profileEnabled {
@@ -401,6 +558,7 @@ func serve(args []string) error {
 	go handleSignals(addPlatformSignals(map[os.Signal]func(){
 		syscall.SIGINT:  func() { serve <- nil },
 		syscall.SIGTERM: func() { serve <- nil },
+		syscall.SIGHUP:  func() { reloader.Reload() },
 	}))
 
 	logger.Infof("Started peer with ID=[%s], network ID=[%s], address=[%s]", peerEndpoint.Id, networkID, peerEndpoint.Address)
@@ -452,7 +610,7 @@ func createSelfSignedData() common2.SignedData {
 	}
 }
 
-func registerDiscoveryService(peerServer *comm.GRPCServer, polMgr policies.ChannelPolicyManagerGetter, lc *cc.Lifecycle) {
+func registerDiscoveryService(peerServer *comm.GRPCServer, polMgr policies.ChannelPolicyManagerGetter, lc *cc.Lifecycle) rest.Discovery {
 	mspID := viper.GetString("peer.localMspId")
 	localAccessPolicy := localPolicy(cauthdsl.SignedByAnyAdmin([]string{mspID}))
 	if viper.GetBool("peer.discovery.orgMembersAllowedAccess") {
@@ -473,6 +631,37 @@ func registerDiscoveryService(peerServer *comm.GRPCServer, polMgr policies.Chann
 	}, support)
 	logger.Info("Discovery service activated")
 	discprotos.RegisterDiscoveryServer(peerServer.Server(), svc)
+	return svc
+}
+
+// newRESTServer creates the server exposing the queries of the peer over REST,
+// secured by the TLS settings of the peer
+func newRESTServer(aclProvider aclmgmt.ACLProvider, discoveryService rest.Discovery) *rest.Server {
+	var clientCACertFiles []string
+	for _, file := range viper.GetStringSlice("peer.tls.clientRootCAs.files") {
+		clientCACertFiles = append(clientCACertFiles, coreconfig.TranslatePath(filepath.Dir(viper.ConfigFileUsed()), file))
+	}
+	return rest.NewServer(rest.Options{
+		ListenAddress:  viper.GetString("peer.rest.listenAddress"),
+		AllowedOrigins: viper.GetStringSlice("peer.rest.allowedOrigins"),
+		MaxClockSkew:   viper.GetDuration("peer.rest.maxClockSkew"),
+		TLS: operations.TLS{
+			Enabled:            viper.GetBool("peer.tls.enabled"),
+			CertFile:           coreconfig.GetPath("peer.tls.cert.file"),
+			KeyFile:            coreconfig.GetPath("peer.tls.key.file"),
+			ClientCertRequired: viper.GetBool("peer.tls.clientAuthRequired"),
+			ClientCACertFiles:  clientCACertFiles,
+		},
+		ACLProvider: aclProvider,
+		PolicyChecker: policy.NewPolicyChecker(
+			peer.NewChannelPolicyManagerGetter(),
+			mgmt.GetLocalMSP(),
+			mgmt.NewLocalMSPPrincipalGetter(),
+		),
+		Peer:                peer.Default,
+		InstalledChaincodes: ccprovider.GetInstalledChaincodes,
+		Discovery:           discoveryService,
+	})
 }
 
 //create a CC listener using peer.chaincodeListenAddress (and if that's not set use peer.peerAddress)
@@ -531,6 +720,10 @@ func createChaincodeServer(ca tlsgen.CA, peerHostname string) (srv *comm.GRPCSer
 			// No point in specifying server root CAs since this TLS config is only used for
 			// a gRPC server and not a client
 			ServerRootCAs: nil,
+			// Follow the TLS policy of the peer, which is passed to the chaincodes
+			CipherSuites: config.SecOpts.CipherSuites,
+			MinVersion:   config.SecOpts.MinVersion,
+			MaxVersion:   config.SecOpts.MaxVersion,
 		}
 	}
 
@@ -644,12 +837,38 @@ func registerChaincodeSupport(
 
 	sccp := scc.NewProvider(peer.Default, peer.DefaultSupport, ipRegistry)
 	lsccInst := lscc.New(sccp, aclProvider, pr)
+	// the gossip service is initialized after the system chaincodes
+	lsccInst.ChannelMembership = &gossipChannelMembership{
+		gossip: func() channelMembers { return service.GetGossipService() },
+	}
 
 	dockerProvider := dockercontroller.NewProvider(
 		viper.GetString("peer.id"),
 		viper.GetString("peer.networkId"),
 		ops.Provider,
 	)
+	if viper.GetBool("vm.docker.logForwarding.enabled") {
+		logSink, err := dockercontroller.NewRemoteLogSink(
+			viper.GetString("vm.docker.logForwarding.network"),
+			viper.GetString("vm.docker.logForwarding.address"),
+			viper.GetInt("vm.docker.logForwarding.bufferSize"),
+		)
+		if err != nil {
+			logger.Panicf("Failed to set up the forwarding of the chaincode logs: %s", err)
+		}
+		dockerProvider.LogSink = logSink
+	}
+	if maxBuildLogs := viper.GetInt("vm.docker.buildLogs.max"); maxBuildLogs > 0 {
+		buildLogs, err := dockercontroller.NewBuildLogStore(
+			filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "buildlogs"),
+			maxBuildLogs,
+		)
+		if err != nil {
+			logger.Panicf("Failed to set up the chaincode build logs: %s", err)
+		}
+		dockerProvider.BuildLogs = buildLogs
+		ops.RegisterHandler("/buildlogs", buildLogs)
+	}
 	dockerVM := dockercontroller.NewDockerVM(
 		dockerProvider.PeerID,
 		dockerProvider.NetworkID,
@@ -693,8 +912,12 @@ func registerChaincodeSupport(
 	qsccInst := qscc.New(aclProvider)
 
 	//Now that chaincode is initialized, register all system chaincodes.
-	sccs := scc.CreatePluginSysCCs(sccp)
-	for _, cc := range append([]scc.SelfDescribingSysCC{lsccInst, csccInst, qsccInst, lifecycleSCC}, sccs...) {
+	sccs := scc.CreatePluginSysCCs(sccp, aclProvider)
+	ftsccInst := assets.NewFungibleSCC()
+	nftsccInst := assets.NewNonFungibleSCC()
+	interopsccInst := interopscc.New(aclProvider)
+	batchsccInst := batchscc.New(sccp, aclProvider)
+	for _, cc := range append([]scc.SelfDescribingSysCC{lsccInst, csccInst, qsccInst, lifecycleSCC, ftsccInst, nftsccInst, interopsccInst, batchsccInst}, sccs...) {
 		sccp.RegisterSysCC(cc)
 	}
 	pb.RegisterChaincodeSupportServer(grpcServer.Server(), ccSrv)
@@ -722,6 +945,12 @@ func startChaincodeServer(
 		ReadWriter: &persistence.FilesystemIO{},
 	}
 
+	if migrated, err := ccStore.MigratePackageIDs(); err != nil {
+		logger.Warningf("Failed recording the IDs of the installed chaincode packages: %s", err)
+	} else if migrated > 0 {
+		logger.Infof("Recorded the IDs of %d installed chaincode packages", migrated)
+	}
+
 	packageProvider := &persistence.PackageProvider{
 		LegacyPP: &ccprovider.CCInfoFSImpl{},
 		Store:    ccStore,
@@ -730,8 +959,13 @@ func startChaincodeServer(
 	lifecycleSCC := &lifecycle.SCC{
 		Protobuf: &lifecycle.ProtobufImpl{},
 		Functions: &lifecycle.Lifecycle{
-			PackageParser:  ccPackageParser,
-			ChaincodeStore: ccStore,
+			PackageParser:       ccPackageParser,
+			ChaincodeStore:      ccStore,
+			InstalledChaincodes: packageProvider,
+			ChannelDefinitions: &channelDefinitions{
+				channels:         channelIDs,
+				newQueryExecutor: newChannelQueryExecutor,
+			},
 		},
 	}
 
@@ -777,7 +1011,51 @@ func adminHasSeparateListener(peerListenAddr string, adminListenAddress string)
 	return adminPort != peerPort
 }
 
-func startAdminServer(peerListenAddr string, peerServer *grpc.Server, metricsProvider metrics.Provider) {
+// memoryWatchdogConfig returns the configuration of the memory watchdog
+func memoryWatchdogConfig() watchdog.Config {
+	config := watchdog.Config{
+		CheckInterval: viper.GetDuration("peer.memoryWatchdog.checkInterval"),
+		SoftLimit:     uint64(viper.GetSizeInBytes("peer.memoryWatchdog.softLimit")),
+		HardLimit:     uint64(viper.GetSizeInBytes("peer.memoryWatchdog.hardLimit")),
+		DeliverDelay:  viper.GetDuration("peer.memoryWatchdog.deliverDelay"),
+	}
+	if config.CheckInterval == 0 {
+		config.CheckInterval = 5 * time.Second
+	}
+	return config
+}
+
+// validateSnapshotIsolationConfig checks that the state database can take the snapshots read by the
+// queries when snapshot isolation is enabled, which only goleveldb can
+func validateSnapshotIsolationConfig() error {
+	if ledgerconfig.IsSnapshotIsolationEnabled() && ledgerconfig.IsCouchDBEnabled() {
+		return errors.New("invalid ledger configuration: ledger.state.snapshotIsolation requires the goleveldb state database, CouchDB cannot take snapshots")
+	}
+	return nil
+}
+
+// loadInterceptors loads the interceptors of the gRPC servers of the peer
+func loadInterceptors(configs []*library.HandlerConfig) ([]interception.Interceptor, error) {
+	var interceptorConfigs []interception.Config
+	for _, config := range configs {
+		interceptorConfigs = append(interceptorConfigs, interception.Config{Name: config.Name, Library: config.Library})
+	}
+	interceptors, err := interception.Load(interceptorConfigs)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not load the interceptors")
+	}
+	return interceptors, nil
+}
+
+// addInterceptors appends the interceptors to the ones of the server, so that
+// the calls they reject are still logged and measured
+func addInterceptors(serverConfig *comm.ServerConfig, interceptors []interception.Interceptor) {
+	unary, stream := interception.ServerInterceptors(interceptors)
+	serverConfig.UnaryInterceptors = append(serverConfig.UnaryInterceptors, unary...)
+	serverConfig.StreamInterceptors = append(serverConfig.StreamInterceptors, stream...)
+}
+
+func startAdminServer(peerListenAddr string, peerServer *grpc.Server, metricsProvider metrics.Provider, channelManager admin.ChannelManager, interceptors []interception.Interceptor) {
 	adminListenAddress := viper.GetString("peer.adminService.listenAddress")
 	separateLsnrForAdmin := adminHasSeparateListener(peerListenAddr, adminListenAddress)
 	mspID := viper.GetString("peer.localMspId")
@@ -804,6 +1082,7 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server, metricsPro
 			grpclogging.StreamServerInterceptor(flogging.MustGetLogger("comm.grpc.server").Zap()),
 			throttle.StreamServerInterceptor,
 		)
+		addInterceptors(&serverConfig, interceptors)
 		adminServer, err := peer.NewPeerServer(adminListenAddress, serverConfig)
 		if err != nil {
 			logger.Fatalf("Failed to create admin server (%s)", err)
@@ -814,7 +1093,7 @@ func startAdminServer(peerListenAddr string, peerServer *grpc.Server, metricsPro
 		}()
 	}
 
-	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy))
+	pb.RegisterAdminServer(gRPCService, admin.NewAdminServer(adminPolicy, channelManager))
 }
 
 // secureDialOpts is the callback function for secure dial options for gossip service
@@ -858,9 +1137,13 @@ func initGossipService(policyMgr policies.ChannelPolicyManagerGetter, metricsPro
 		if err != nil {
 			return errors.Wrap(err, "failed obtaining client certificates")
 		}
-		certs = &gossipcommon.TLSCertificates{}
+		certs = &gossipcommon.TLSCertificates{TLSServerCertsByName: map[string]*tls.Certificate{}}
 		certs.TLSServerCert.Store(&serverCert)
 		certs.TLSClientCert.Store(&clientCert)
+		for serverName, sniCert := range peerServer.SNICertificates() {
+			sniCert := sniCert
+			certs.TLSServerCertsByName[serverName] = &sniCert
+		}
 	}
 
 	messageCryptoService := peergossip.NewMCS(
@@ -904,10 +1187,93 @@ func newOperationsSystem() *operations.System {
 			ClientCertRequired: viper.GetBool("operations.tls.clientAuthRequired"),
 			ClientCACertFiles:  viper.GetStringSlice("operations.tls.clientRootCAs.files"),
 		},
-		Version: metadata.Version,
+		Authorization: operationsAuthorization(),
+		Version:       metadata.Version,
 	})
 }
 
+func operationsAuthorization() operations.Authorization {
+	roleMembers := func(role string) operations.RoleMembers {
+		return operations.RoleMembers{
+			CommonNames:         viper.GetStringSlice("operations.authorization.roles." + role + ".commonNames"),
+			OrganizationalUnits: viper.GetStringSlice("operations.authorization.roles." + role + ".organizationalUnits"),
+		}
+	}
+	endpoints := map[string]operations.Role{}
+	for path, role := range viper.GetStringMapString("operations.authorization.endpoints") {
+		endpoints[path] = operations.Role(role)
+	}
+	return operations.Authorization{
+		Enabled:   viper.GetBool("operations.authorization.enabled"),
+		Admins:    roleMembers("admin"),
+		Operators: roleMembers("operator"),
+		Viewers:   roleMembers("viewer"),
+		Endpoints: endpoints,
+	}
+}
+
+// registerRelayService registers the interop relay, which serves the views
+// of the exposed chaincodes endorsed by the peer to the other networks, if it
+// is enabled
+func registerRelayService(peerServer *comm.GRPCServer, serverConfig comm.ServerConfig, endorser interop.Endorser, signingIdentity msp.SigningIdentity) error {
+	config, err := interop.GlobalConfig()
+	if err != nil {
+		return err
+	}
+	if !config.Enabled {
+		return nil
+	}
+
+	// the relays of the other networks are connected to with the TLS keypair
+	// of the peer
+	clientConfig := comm.ClientConfig{
+		SecOpts: &comm.SecureOptions{
+			UseTLS:       serverConfig.SecOpts.UseTLS,
+			Certificate:  serverConfig.SecOpts.Certificate,
+			Key:          serverConfig.SecOpts.Key,
+			CipherSuites: serverConfig.SecOpts.CipherSuites,
+			MinVersion:   serverConfig.SecOpts.MinVersion,
+			MaxVersion:   serverConfig.SecOpts.MaxVersion,
+		},
+		KaOpts:  comm.DefaultKeepaliveOptions,
+		Timeout: config.Timeout,
+	}
+	relay, err := interop.NewRelay(config, endorser, signingIdentity, clientConfig)
+	if err != nil {
+		return errors.WithMessage(err, "failed to create the interop relay")
+	}
+	pb.RegisterRelayServer(peerServer.Server(), relay)
+	logger.Infof("Started the interop relay of network %s, exposing %d chaincodes to %d networks",
+		config.NetworkID, len(config.ExposedChaincodes), len(config.Networks))
+	return nil
+}
+
+// registerSchedulerService registers the scheduler, which submits the
+// transactions of the scheduled proposals endorsed by the peer and the
+// endorsers of their requests, if it is enabled
+func registerSchedulerService(peerServer *comm.GRPCServer, localEndorser scheduler.Endorser, aclProvider aclmgmt.ACLProvider, endorserSupport *endorser.SupportImpl) {
+	config := scheduler.GlobalConfig()
+	if !config.Enabled {
+		return
+	}
+
+	broadcaster := &scheduler.OrdererBroadcaster{
+		Addresses: func(channelID string) []string {
+			resources := peer.GetChannelConfig(channelID)
+			if resources == nil {
+				return nil
+			}
+			return resources.ChannelConfig().OrdererAddresses()
+		},
+		ConnFactory: deliverclient.DefaultConnectionFactory,
+	}
+	dbPath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "scheduler")
+	s := scheduler.NewScheduler(config, dbPath, localEndorser, scheduler.NewEndorserDialer(secureDialOpts()...), broadcaster, aclProvider, endorserSupport)
+	pb.RegisterSchedulerServer(peerServer.Server(), s)
+	go s.Run()
+	logger.Infof("Started the scheduler, checking the scheduled proposals every %s", config.Interval)
+}
+
 func registerProverService(peerServer *comm.GRPCServer, aclProvider aclmgmt.ACLProvider, signingIdentity msp.SigningIdentity) error {
 	policyChecker := &server.PolicyBasedAccessControl{
 		ACLProvider: aclProvider,
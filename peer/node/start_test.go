@@ -173,3 +173,17 @@ func TestComputeChaincodeEndpoint(t *testing.T) {
 	/*** Scenario 4: set up both chaincodeAddress and chaincodeListenAddress ***/
 	// This scenario will be the same to scenarios 3: set up chaincodeAddress only.
 }
+
+func TestValidateSnapshotIsolationConfig(t *testing.T) {
+	viper.Set("ledger.state.snapshotIsolation.enabled", true)
+	defer viper.Set("ledger.state.snapshotIsolation.enabled", false)
+	viper.Set("ledger.state.stateDatabase", "goleveldb")
+	defer viper.Set("ledger.state.stateDatabase", "goleveldb")
+	assert.NoError(t, validateSnapshotIsolationConfig())
+
+	viper.Set("ledger.state.stateDatabase", "CouchDB")
+	assert.EqualError(t, validateSnapshotIsolationConfig(), "invalid ledger configuration: ledger.state.snapshotIsolation requires the goleveldb state database, CouchDB cannot take snapshots")
+
+	viper.Set("ledger.state.snapshotIsolation.enabled", false)
+	assert.NoError(t, validateSnapshotIsolationConfig())
+}
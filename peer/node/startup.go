@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/container/dockercontroller"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/hyperledger/fabric/core/operations"
+	"github.com/hyperledger/fabric/core/startup"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// waitForDependencies waits for CouchDB, when it is the state database, the
+// docker daemon, unless the chaincodes are run by the user, and the orderers
+// listed in the configuration to be reachable. The status of the dependencies
+// is reported by the operations endpoint /startupz
+func waitForDependencies(ops *operations.System, devMode bool) error {
+	manager := startup.NewManager(startup.Config{
+		Timeout:        viper.GetDuration("peer.startup.timeout"),
+		InitialBackoff: viper.GetDuration("peer.startup.initialBackoff"),
+		MaxBackoff:     viper.GetDuration("peer.startup.maxBackoff"),
+		AttemptTimeout: viper.GetDuration("peer.startup.attemptTimeout"),
+	})
+	ops.RegisterHandler("/startupz", manager)
+
+	if ledgerconfig.IsCouchDBEnabled() {
+		def := couchdb.GetCouchDBDefinition()
+		if err := manager.Register("couchdb", couchDBChecker(def)); err != nil {
+			return err
+		}
+	}
+	if !devMode {
+		dockerVM := dockercontroller.NewDockerVM(viper.GetString("peer.id"), viper.GetString("peer.networkId"), nil)
+		if err := manager.Register("docker", dockerVM); err != nil {
+			return err
+		}
+	}
+	for _, address := range viper.GetStringSlice("peer.startup.orderers") {
+		if err := manager.Register("orderer "+address, startup.TCPChecker(address)); err != nil {
+			return err
+		}
+	}
+
+	return manager.WaitForDependencies(context.Background())
+}
+
+// couchDBChecker returns a checker that succeeds when CouchDB answers to a
+// request on its root URL
+func couchDBChecker(def *couchdb.CouchDBDef) startup.CheckerFunc {
+	url := def.URL
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = "http://" + url
+	}
+	client := &http.Client{Timeout: def.RequestTimeout}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequest(http.MethodHead, url, nil)
+		if err != nil {
+			return errors.Wrapf(err, "invalid CouchDB address %s", def.URL)
+		}
+		if def.Username != "" {
+			req.SetBasicAuth(def.Username, def.Password)
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return errors.Errorf("CouchDB answered with status %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/ledger/util/couchdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCouchDBChecker(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := r.BasicAuth()
+		if user != "admin" || password != "adminpw" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	def := &couchdb.CouchDBDef{
+		URL:            strings.TrimPrefix(server.URL, "http://"),
+		Username:       "admin",
+		Password:       "adminpw",
+		RequestTimeout: time.Second,
+	}
+	assert.NoError(t, couchDBChecker(def).HealthCheck(context.Background()))
+
+	status = http.StatusServiceUnavailable
+	assert.EqualError(t, couchDBChecker(def).HealthCheck(context.Background()), "CouchDB answered with status 503 Service Unavailable")
+
+	def.Password = "wrong"
+	assert.EqualError(t, couchDBChecker(def).HealthCheck(context.Background()), "CouchDB answered with status 401 Unauthorized")
+}
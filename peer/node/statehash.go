@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateHashNamespace string
+	stateHashBucket    int
+)
+
+func stateHashCmd() *cobra.Command {
+	nodeStateHashCmd.ResetFlags()
+	flags := nodeStateHashCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", "", "Channel of which the state is hashed.")
+	flags.Uint64VarP(&blockNumber, "blockNumber", "b", 0, "Block number at which the state is expected to be.")
+	flags.StringVarP(&stateHashNamespace, "namespace", "n", "", "Namespace for which the hashes of the buckets are printed.")
+	flags.IntVarP(&stateHashBucket, "bucket", "", -1, "Bucket of the namespace for which the hashes of the keys are printed.")
+
+	return nodeStateHashCmd
+}
+
+var nodeStateHashCmd = &cobra.Command{
+	Use:   "statehash",
+	Short: "Computes a canonical hash of the state of a channel.",
+	Long: `Computes a canonical hash of the state of a channel, which does not depend on the state database, and prints ` +
+		`it along with the hashes of the namespaces as JSON. The peers of different organizations that are at the same ` +
+		`block number are expected to have the same hash. A divergence can be located by printing the hashes of the buckets ` +
+		`of a namespace, and then the hashes of the keys of a bucket. When the command is executed, the peer must be offline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return fmt.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == "" {
+			return errors.New("Must supply channel ID")
+		}
+		if stateHashBucket >= privacyenabledstate.StateHashBuckets {
+			return errors.Errorf("Bucket must be lower than %d", privacyenabledstate.StateHashBuckets)
+		}
+		if stateHashBucket >= 0 && stateHashNamespace == "" {
+			return errors.New("Must supply the namespace of the bucket")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		stateHash, err := kvledger.ComputeStateHash(channelID, privacyenabledstate.StateHashOptions{
+			Namespace: stateHashNamespace,
+			Bucket:    stateHashBucket,
+		})
+		if err != nil {
+			return err
+		}
+		if cmd.Flags().Changed("blockNumber") && stateHash.BlockNumber != blockNumber {
+			return errors.Errorf("the state of the channel [%s] is at block number [%d], not at block number [%d]",
+				channelID, stateHash.BlockNumber, blockNumber)
+		}
+		output, err := json.MarshalIndent(stateHash, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(output))
+		return nil
+	},
+}
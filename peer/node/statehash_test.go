@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateHashCmd(t *testing.T) {
+	testPath, err := ioutil.TempDir("", "statehashcmd")
+	assert.NoError(t, err)
+	defer os.RemoveAll(testPath)
+	viper.Set("peer.fileSystemPath", testPath)
+	defer viper.Reset()
+
+	cmd := stateHashCmd()
+	cmd.SetArgs([]string{"-n", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	cmd = stateHashCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "--bucket", "256"})
+	assert.EqualError(t, cmd.Execute(), "Bucket must be lower than 256")
+
+	cmd = stateHashCmd()
+	cmd.SetArgs([]string{"-c", "ch1", "--bucket", "3"})
+	assert.EqualError(t, cmd.Execute(), "Must supply the namespace of the bucket")
+
+	cmd = stateHashCmd()
+	cmd.SetArgs([]string{"-c", "ch1"})
+	assert.EqualError(t, cmd.Execute(), "the state database is empty")
+}
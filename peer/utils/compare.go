@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/hyperledger/fabric/core/common/proposalresponse"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// compare compares the proposal responses stored in the given files with the
+// first one and writes their differences. It fails when the proposal responses
+// do not match
+func compare(inputs []string, out io.Writer) error {
+	if len(inputs) < 2 {
+		return errors.New("at least two proposal responses must be provided")
+	}
+
+	var responses []*pb.ProposalResponse
+	for _, input := range inputs {
+		data, err := ioutil.ReadFile(input)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s", input)
+		}
+		resp, err := putils.GetProposalResponse(data)
+		if err != nil {
+			return errors.WithMessage(err, fmt.Sprintf("failed to read the proposal response of %s", input))
+		}
+		responses = append(responses, resp)
+	}
+
+	diffs, err := proposalresponse.Compare(responses...)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		fmt.Fprintln(out, "The proposal responses match")
+		return nil
+	}
+	for _, d := range diffs {
+		fmt.Fprintf(out, "%s: %s\n", inputs[d.Response], d)
+	}
+	return errors.Errorf("%d difference(s) found with the proposal response of %s", len(diffs), inputs[0])
+}
+
+func compareCmd() *cobra.Command {
+	var inputs []string
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the proposal responses of several endorsers.",
+		Long: "Compares the proposal responses of several endorsers for the same proposal with the first one, " +
+			"and reports the namespaces, collections and keys of the read-write sets, the events and the responses which differ.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("trailing args detected: %s", args)
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+			return compare(inputs, os.Stdout)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(&inputs, "input", "i", nil, "Path to a file holding a proposal response, which must be repeated for each endorser")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProposalResponse(t *testing.T, dir, name, value string) string {
+	results := utils.MarshalOrPanic(&rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{{
+			Namespace: "mycc",
+			Rwset:     utils.MarshalOrPanic(&kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a", Value: []byte(value)}}}),
+		}},
+	})
+	resp := &pb.ProposalResponse{
+		Response: &pb.Response{Status: 200},
+		Payload: utils.MarshalOrPanic(&pb.ProposalResponsePayload{
+			Extension: utils.MarshalOrPanic(&pb.ChaincodeAction{Results: results, Response: &pb.Response{Status: 200}}),
+		}),
+	}
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, utils.MarshalOrPanic(resp), 0644))
+	return path
+}
+
+func TestCompare(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compare")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	peer0 := writeProposalResponse(t, dir, "peer0", "90")
+	peer1 := writeProposalResponse(t, dir, "peer1", "90")
+	peer2 := writeProposalResponse(t, dir, "peer2", "91")
+
+	var out bytes.Buffer
+	assert.NoError(t, compare([]string{peer0, peer1}, &out))
+	assert.Equal(t, "The proposal responses match\n", out.String())
+
+	out.Reset()
+	err = compare([]string{peer0, peer1, peer2}, &out)
+	assert.EqualError(t, err, "1 difference(s) found with the proposal response of "+peer0)
+	assert.Equal(t, peer2+`: rwset.writes of namespace mycc, key "a": "90" != "91"`+"\n", out.String())
+}
+
+func TestCompareErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compare")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	peer0 := writeProposalResponse(t, dir, "peer0", "90")
+
+	err = compare([]string{peer0}, ioutil.Discard)
+	assert.EqualError(t, err, "at least two proposal responses must be provided")
+
+	err = compare([]string{peer0, filepath.Join(dir, "missing")}, ioutil.Discard)
+	assert.Contains(t, err.Error(), "failed to read "+filepath.Join(dir, "missing"))
+
+	garbage := filepath.Join(dir, "garbage")
+	require.NoError(t, ioutil.WriteFile(garbage, []byte("garbage"), 0644))
+	err = compare([]string{peer0, garbage}, ioutil.Discard)
+	assert.Contains(t, err.Error(), "failed to read the proposal response of "+garbage)
+
+	cmd := compareCmd()
+	cmd.SetArgs([]string{"-i", peer0, "extra"})
+	assert.EqualError(t, cmd.Execute(), "trailing args detected: [extra]")
+}
@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	// Import these to register the proto types
+	_ "github.com/hyperledger/fabric/protos/common"
+	_ "github.com/hyperledger/fabric/protos/ledger/rwset"
+	_ "github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	_ "github.com/hyperledger/fabric/protos/msp"
+	_ "github.com/hyperledger/fabric/protos/orderer"
+	_ "github.com/hyperledger/fabric/protos/orderer/etcdraft"
+	_ "github.com/hyperledger/fabric/protos/peer"
+)
+
+// messageTypes are the short names of the most common message types
+var messageTypes = map[string]string{
+	"block":        "common.Block",
+	"envelope":     "common.Envelope",
+	"config":       "common.Config",
+	"configupdate": "common.ConfigUpdate",
+	"response":     "protos.ProposalResponse",
+	"rwset":        "rwset.TxReadWriteSet",
+	"kvrwset":      "kvrwset.KVRWSet",
+}
+
+func shortTypes() string {
+	names := make([]string, 0, len(messageTypes))
+	for name := range messageTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "|")
+}
+
+// newMessage returns an empty message of a type, given by its short name or
+// by its full protobuf name such as common.ConfigEnvelope
+func newMessage(msgType string) (proto.Message, error) {
+	msgName := msgType
+	if name, ok := messageTypes[msgType]; ok {
+		msgName = name
+	}
+	t := proto.MessageType(msgName)
+	if t == nil {
+		return nil, errors.Errorf("unknown message type %s, expected %s or a protobuf message name", msgType, shortTypes())
+	}
+	return reflect.New(t.Elem()).Interface().(proto.Message), nil
+}
+
+// decode converts a protobuf message to JSON, decoding the nested messages
+// held as bytes so that the JSON is stable and readable
+func decode(msgType string, in io.Reader, out io.Writer) error {
+	msg, err := newMessage(msgType)
+	if err != nil {
+		return err
+	}
+
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the input")
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal the input as %s", proto.MessageName(msg))
+	}
+
+	var buf bytes.Buffer
+	if err := protolator.DeepMarshalJSON(&buf, msg); err != nil {
+		return errors.Wrapf(err, "failed to convert %s to JSON", proto.MessageName(msg))
+	}
+	_, err = out.Write(buf.Bytes())
+	return errors.Wrap(err, "failed to write the output")
+}
+
+// encode converts the JSON produced by decode back to a protobuf message
+func encode(msgType string, in io.Reader, out io.Writer) error {
+	msg, err := newMessage(msgType)
+	if err != nil {
+		return err
+	}
+
+	if err := protolator.DeepUnmarshalJSON(in, msg); err != nil {
+		return errors.Wrapf(err, "failed to convert the JSON input to %s", proto.MessageName(msg))
+	}
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", proto.MessageName(msg))
+	}
+	_, err = out.Write(data)
+	return errors.Wrap(err, "failed to write the output")
+}
+
+func decodeCmd() *cobra.Command {
+	return conversionCmd(
+		"decode",
+		"Convert a protobuf message to JSON.",
+		"Converts a block, an envelope, a config, a read-write set or any other protobuf message to JSON, "+
+			"decoding the nested messages it holds as bytes.",
+		decode,
+	)
+}
+
+func encodeCmd() *cobra.Command {
+	return conversionCmd(
+		"encode",
+		"Convert JSON to a protobuf message.",
+		"Converts the JSON of a block, an envelope, a config, a read-write set or any other protobuf message, "+
+			"as output by decode, back to the protobuf message.",
+		encode,
+	)
+}
+
+func conversionCmd(use, short, long string, convert func(string, io.Reader, io.Writer) error) *cobra.Command {
+	var (
+		msgType string
+		input   string
+		output  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long:  long,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("trailing args detected: %s", args)
+			}
+			if msgType == "" {
+				return errors.New("the type of the message must be provided")
+			}
+			// Parsing of the command line is done so silence cmd usage
+			cmd.SilenceUsage = true
+
+			in := io.Reader(os.Stdin)
+			if input != "" {
+				f, err := os.Open(input)
+				if err != nil {
+					return errors.Wrap(err, "failed to open the input")
+				}
+				defer f.Close()
+				in = f
+			}
+
+			if output == "" {
+				return convert(msgType, in, os.Stdout)
+			}
+			var buf bytes.Buffer
+			if err := convert(msgType, in, &buf); err != nil {
+				return err
+			}
+			return errors.Wrap(ioutil.WriteFile(output, buf.Bytes(), 0644), "failed to write the output")
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&msgType, "type", "t", "", fmt.Sprintf("Type of the message: %s, or a protobuf message name such as common.ConfigEnvelope", shortTypes()))
+	flags.StringVarP(&input, "input", "i", "", "Path to the input file, the standard input by default")
+	flags.StringVarP(&output, "output", "o", "", "Path to the output file, the standard output by default")
+
+	return cmd
+}
@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEncodeBlock(t *testing.T) {
+	block, err := configtxtest.MakeGenesisBlock("mychannel")
+	require.NoError(t, err)
+
+	var decoded bytes.Buffer
+	require.NoError(t, decode("block", bytes.NewReader(utils.MarshalOrPanic(block)), &decoded))
+	// the nested messages are decoded
+	assert.Contains(t, decoded.String(), `"channel_id": "mychannel"`)
+	assert.Contains(t, decoded.String(), `"ConsensusType"`)
+
+	var again bytes.Buffer
+	require.NoError(t, decode("common.Block", bytes.NewReader(utils.MarshalOrPanic(block)), &again))
+	assert.Equal(t, decoded.String(), again.String())
+
+	// the nested config maps are marshaled in no particular order, so that
+	// the round trip is compared on the JSON
+	var encoded, roundTrip bytes.Buffer
+	require.NoError(t, encode("block", bytes.NewReader(decoded.Bytes()), &encoded))
+	require.NoError(t, decode("block", &encoded, &roundTrip))
+	assert.Equal(t, decoded.String(), roundTrip.String())
+}
+
+func TestDecodeEncodeRWSet(t *testing.T) {
+	txRWSet := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{
+			{
+				Namespace: "mycc",
+				Rwset: utils.MarshalOrPanic(&kvrwset.KVRWSet{
+					Reads:  []*kvrwset.KVRead{{Key: "key1", Version: &kvrwset.Version{BlockNum: 3, TxNum: 1}}},
+					Writes: []*kvrwset.KVWrite{{Key: "key2", Value: []byte("value")}},
+				}),
+			},
+		},
+	}
+
+	var decoded bytes.Buffer
+	require.NoError(t, decode("rwset", bytes.NewReader(utils.MarshalOrPanic(txRWSet)), &decoded))
+	assert.Contains(t, decoded.String(), `"key": "key1"`)
+	assert.Contains(t, decoded.String(), `"block_num": "3"`)
+
+	var encoded bytes.Buffer
+	require.NoError(t, encode("rwset", &decoded, &encoded))
+	roundTrip := &rwset.TxReadWriteSet{}
+	require.NoError(t, proto.Unmarshal(encoded.Bytes(), roundTrip))
+	assert.True(t, proto.Equal(txRWSet, roundTrip))
+}
+
+func TestDecodeErrors(t *testing.T) {
+	err := decode("bogus", bytes.NewReader(nil), ioutil.Discard)
+	assert.EqualError(t, err, "unknown message type bogus, expected block|config|configupdate|envelope|kvrwset|response|rwset or a protobuf message name")
+
+	err = decode("block", bytes.NewReader([]byte("not a block")), ioutil.Discard)
+	assert.Contains(t, err.Error(), "failed to unmarshal the input as common.Block")
+
+	err = encode("envelope", bytes.NewReader([]byte("{")), ioutil.Discard)
+	assert.Contains(t, err.Error(), "failed to convert the JSON input to common.Envelope")
+}
+
+func TestDecodeCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "decode")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	block, err := configtxtest.MakeGenesisBlock("mychannel")
+	require.NoError(t, err)
+	blockFile := filepath.Join(dir, "block.pb")
+	require.NoError(t, ioutil.WriteFile(blockFile, utils.MarshalOrPanic(block), 0644))
+
+	cmd := Cmd()
+	cmd.SetArgs([]string{"decode", "-t", "block", "-i", blockFile, "-o", filepath.Join(dir, "block.json")})
+	require.NoError(t, cmd.Execute())
+	cmd = Cmd()
+	cmd.SetArgs([]string{"encode", "--type", "block", "--input", filepath.Join(dir, "block.json"), "--output", filepath.Join(dir, "block2.pb")})
+	require.NoError(t, cmd.Execute())
+
+	encoded, err := ioutil.ReadFile(filepath.Join(dir, "block2.pb"))
+	require.NoError(t, err)
+	roundTrip := &common.Block{}
+	require.NoError(t, proto.Unmarshal(encoded, roundTrip))
+	assert.True(t, proto.Equal(block.Header, roundTrip.Header))
+	assert.Len(t, roundTrip.Data.Data, 1)
+
+	cmd = Cmd()
+	cmd.SetArgs([]string{"decode", "-i", blockFile})
+	assert.EqualError(t, cmd.Execute(), "the type of the message must be provided")
+}
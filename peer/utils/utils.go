@@ -0,0 +1,32 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	utilsFuncName = "utils"
+	utilsCmdDes   = "Utilities which do not need a peer: compare|decode|encode."
+)
+
+// Cmd returns the cobra command for utils
+func Cmd() *cobra.Command {
+	utilsCmd := &cobra.Command{
+		Use:   utilsFuncName,
+		Short: fmt.Sprint(utilsCmdDes),
+		Long:  fmt.Sprint(utilsCmdDes),
+	}
+	utilsCmd.AddCommand(compareCmd())
+	utilsCmd.AddCommand(decodeCmd())
+	utilsCmd.AddCommand(encodeCmd())
+
+	return utilsCmd
+}
@@ -32,7 +32,7 @@ func (m *CollectionConfigPackage) Reset()         { *m = CollectionConfigPackage
 func (m *CollectionConfigPackage) String() string { return proto.CompactTextString(m) }
 func (*CollectionConfigPackage) ProtoMessage()    {}
 func (*CollectionConfigPackage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{0}
+	return fileDescriptor_collection_3355ab80352f3a96, []int{0}
 }
 func (m *CollectionConfigPackage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionConfigPackage.Unmarshal(m, b)
@@ -75,7 +75,7 @@ func (m *CollectionConfig) Reset()         { *m = CollectionConfig{} }
 func (m *CollectionConfig) String() string { return proto.CompactTextString(m) }
 func (*CollectionConfig) ProtoMessage()    {}
 func (*CollectionConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{1}
+	return fileDescriptor_collection_3355ab80352f3a96, []int{1}
 }
 func (m *CollectionConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionConfig.Unmarshal(m, b)
@@ -199,17 +199,22 @@ type StaticCollectionConfig struct {
 	// can read the private data (if set to true), or even non members can
 	// read the data (if set to false, for example if you want to implement more granular
 	// access logic in the chaincode)
-	MemberOnlyRead       bool     `protobuf:"varint,6,opt,name=member_only_read,json=memberOnlyRead,proto3" json:"member_only_read,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MemberOnlyRead bool `protobuf:"varint,6,opt,name=member_only_read,json=memberOnlyRead,proto3" json:"member_only_read,omitempty"`
+	// The regions the peers of each organization, keyed by its MSP ID, may
+	// persist the private data of the collection in. The peers whose region
+	// is not one of the regions of their organization refuse to persist the
+	// private data, and the organizations without regions are unrestricted
+	AllowedRegions       map[string]*CollectionRegions `protobuf:"bytes,7,rep,name=allowed_regions,json=allowedRegions,proto3" json:"allowed_regions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *StaticCollectionConfig) Reset()         { *m = StaticCollectionConfig{} }
 func (m *StaticCollectionConfig) String() string { return proto.CompactTextString(m) }
 func (*StaticCollectionConfig) ProtoMessage()    {}
 func (*StaticCollectionConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{2}
+	return fileDescriptor_collection_3355ab80352f3a96, []int{2}
 }
 func (m *StaticCollectionConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StaticCollectionConfig.Unmarshal(m, b)
@@ -271,6 +276,53 @@ func (m *StaticCollectionConfig) GetMemberOnlyRead() bool {
 	return false
 }
 
+func (m *StaticCollectionConfig) GetAllowedRegions() map[string]*CollectionRegions {
+	if m != nil {
+		return m.AllowedRegions
+	}
+	return nil
+}
+
+// CollectionRegions are the regions the peers of an organization may persist
+// the private data of a collection in
+type CollectionRegions struct {
+	Regions              []string `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectionRegions) Reset()         { *m = CollectionRegions{} }
+func (m *CollectionRegions) String() string { return proto.CompactTextString(m) }
+func (*CollectionRegions) ProtoMessage()    {}
+func (*CollectionRegions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_collection_3355ab80352f3a96, []int{3}
+}
+func (m *CollectionRegions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionRegions.Unmarshal(m, b)
+}
+func (m *CollectionRegions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionRegions.Marshal(b, m, deterministic)
+}
+func (dst *CollectionRegions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionRegions.Merge(dst, src)
+}
+func (m *CollectionRegions) XXX_Size() int {
+	return xxx_messageInfo_CollectionRegions.Size(m)
+}
+func (m *CollectionRegions) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionRegions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionRegions proto.InternalMessageInfo
+
+func (m *CollectionRegions) GetRegions() []string {
+	if m != nil {
+		return m.Regions
+	}
+	return nil
+}
+
 // Collection policy configuration. Initially, the configuration can only
 // contain a SignaturePolicy. In the future, the SignaturePolicy may be a
 // more general Policy. Instead of containing the actual policy, the
@@ -288,7 +340,7 @@ func (m *CollectionPolicyConfig) Reset()         { *m = CollectionPolicyConfig{}
 func (m *CollectionPolicyConfig) String() string { return proto.CompactTextString(m) }
 func (*CollectionPolicyConfig) ProtoMessage()    {}
 func (*CollectionPolicyConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{3}
+	return fileDescriptor_collection_3355ab80352f3a96, []int{4}
 }
 func (m *CollectionPolicyConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionPolicyConfig.Unmarshal(m, b)
@@ -403,7 +455,7 @@ func (m *CollectionCriteria) Reset()         { *m = CollectionCriteria{} }
 func (m *CollectionCriteria) String() string { return proto.CompactTextString(m) }
 func (*CollectionCriteria) ProtoMessage()    {}
 func (*CollectionCriteria) Descriptor() ([]byte, []int) {
-	return fileDescriptor_collection_12a2cf6632dc7d83, []int{4}
+	return fileDescriptor_collection_3355ab80352f3a96, []int{5}
 }
 func (m *CollectionCriteria) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CollectionCriteria.Unmarshal(m, b)
@@ -455,42 +507,52 @@ func init() {
 	proto.RegisterType((*CollectionConfigPackage)(nil), "common.CollectionConfigPackage")
 	proto.RegisterType((*CollectionConfig)(nil), "common.CollectionConfig")
 	proto.RegisterType((*StaticCollectionConfig)(nil), "common.StaticCollectionConfig")
+	proto.RegisterMapType((map[string]*CollectionRegions)(nil), "common.StaticCollectionConfig.AllowedRegionsEntry")
+	proto.RegisterType((*CollectionRegions)(nil), "common.CollectionRegions")
 	proto.RegisterType((*CollectionPolicyConfig)(nil), "common.CollectionPolicyConfig")
 	proto.RegisterType((*CollectionCriteria)(nil), "common.CollectionCriteria")
 }
 
-func init() { proto.RegisterFile("common/collection.proto", fileDescriptor_collection_12a2cf6632dc7d83) }
-
-var fileDescriptor_collection_12a2cf6632dc7d83 = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x93, 0x51, 0x6b, 0xdb, 0x30,
-	0x10, 0xc7, 0xeb, 0x36, 0x4d, 0xe7, 0x0b, 0xdb, 0x32, 0x95, 0xa5, 0x66, 0x8c, 0x2e, 0x84, 0x3d,
-	0x18, 0x36, 0x9c, 0xd1, 0x7d, 0x83, 0x86, 0x41, 0xc7, 0x02, 0x0b, 0xea, 0x9e, 0xfa, 0x62, 0x14,
-	0xf9, 0xea, 0x88, 0xca, 0x92, 0x2b, 0x2b, 0x21, 0x7e, 0xdc, 0x97, 0xd9, 0xe7, 0x1c, 0x91, 0xec,
-	0x24, 0x0d, 0x79, 0xf3, 0xdd, 0xff, 0x77, 0xe7, 0xbb, 0xfb, 0xdb, 0x70, 0xc5, 0x75, 0x51, 0x68,
-	0x35, 0xe6, 0x5a, 0x4a, 0xe4, 0x56, 0x68, 0x95, 0x94, 0x46, 0x5b, 0x4d, 0xba, 0x5e, 0xf8, 0xf0,
-	0xbe, 0x01, 0x4a, 0x2d, 0x05, 0x17, 0x58, 0x79, 0x79, 0xf4, 0x0b, 0xae, 0x26, 0xdb, 0x92, 0x89,
-	0x56, 0x8f, 0x22, 0x9f, 0x31, 0xfe, 0xc4, 0x72, 0x24, 0xdf, 0xa0, 0xcb, 0x5d, 0x22, 0x0a, 0x86,
-	0x67, 0x71, 0xef, 0x26, 0x4a, 0x7c, 0x8b, 0xe4, 0xb0, 0x80, 0x36, 0xdc, 0xa8, 0x86, 0xfe, 0xa1,
-	0x46, 0x1e, 0x20, 0xaa, 0x2c, 0xb3, 0x82, 0xa7, 0xbb, 0xd1, 0xd2, 0x6d, 0xdf, 0x20, 0xee, 0xdd,
-	0x5c, 0xb7, 0x7d, 0xef, 0x1d, 0x77, 0xd8, 0xe1, 0xee, 0x84, 0x0e, 0xaa, 0xa3, 0xca, 0x6d, 0x08,
-	0x17, 0x25, 0xab, 0xa5, 0x66, 0xd9, 0xe8, 0xdf, 0x29, 0x0c, 0x8e, 0xd7, 0x13, 0x02, 0x1d, 0xc5,
-	0x0a, 0x74, 0x6f, 0x0b, 0xa9, 0x7b, 0x26, 0x53, 0x20, 0x05, 0x16, 0x73, 0x34, 0xa9, 0x36, 0x79,
-	0x95, 0xba, 0xa3, 0xd4, 0xd1, 0xe9, 0xcb, 0x79, 0x76, 0x9d, 0x66, 0x4e, 0x6f, 0xb6, 0xed, 0xfb,
-	0xca, 0xdf, 0x26, 0xaf, 0x7c, 0x9e, 0x24, 0x70, 0x69, 0xf0, 0x79, 0x29, 0x0c, 0x66, 0x69, 0x89,
-	0x68, 0x52, 0xae, 0x97, 0xca, 0x46, 0x67, 0xc3, 0x20, 0x3e, 0xa7, 0xef, 0x5a, 0x69, 0x86, 0x68,
-	0x26, 0x1b, 0x81, 0x7c, 0x05, 0x52, 0xb0, 0xb5, 0x28, 0x96, 0xc5, 0x3e, 0xde, 0x71, 0x78, 0xbf,
-	0x51, 0x76, 0xf4, 0x08, 0x5e, 0xcf, 0xa5, 0xe6, 0x4f, 0xa9, 0xd5, 0xa9, 0x14, 0x2b, 0x8c, 0xce,
-	0x87, 0x41, 0xdc, 0xa1, 0x3d, 0x97, 0xfc, 0xa3, 0xa7, 0x62, 0x85, 0x24, 0x86, 0x7e, 0xbb, 0x8f,
-	0x92, 0x75, 0x6a, 0x90, 0x65, 0x51, 0x77, 0x18, 0xc4, 0xaf, 0xe8, 0x9b, 0x66, 0x5a, 0x25, 0x6b,
-	0x8a, 0x2c, 0x1b, 0x3d, 0xc3, 0xe0, 0xf8, 0x5e, 0x64, 0x0a, 0xfd, 0x4a, 0xe4, 0x8a, 0xd9, 0xa5,
-	0xc1, 0xf6, 0x22, 0xde, 0xa1, 0x4f, 0x5b, 0x87, 0x5a, 0xdd, 0x17, 0xfe, 0x50, 0x2b, 0x94, 0xba,
-	0xc4, 0xbb, 0x13, 0xfa, 0xb6, 0x7a, 0x29, 0xed, 0x7b, 0xf3, 0x37, 0x00, 0xb2, 0xe7, 0x8a, 0x11,
-	0x16, 0x8d, 0x60, 0x24, 0x82, 0x0b, 0xbe, 0x60, 0x4a, 0xa1, 0x6c, 0xac, 0x69, 0x43, 0x72, 0x09,
-	0xe7, 0x76, 0x9d, 0x8a, 0xcc, 0x19, 0x12, 0xd2, 0x8e, 0x5d, 0xff, 0xcc, 0xc8, 0x35, 0xc0, 0xee,
-	0x0b, 0x72, 0xb7, 0x0d, 0xe9, 0x5e, 0x86, 0x7c, 0x84, 0x70, 0x63, 0x6d, 0x55, 0x32, 0x8e, 0xee,
-	0x96, 0x21, 0xdd, 0x25, 0x6e, 0xef, 0xe1, 0xb3, 0x36, 0x79, 0xb2, 0xa8, 0x4b, 0x34, 0x12, 0xb3,
-	0x1c, 0x4d, 0xf2, 0xc8, 0xe6, 0x46, 0x70, 0xff, 0x1f, 0x54, 0xcd, 0x86, 0x0f, 0x5f, 0x72, 0x61,
-	0x17, 0xcb, 0xf9, 0x26, 0x1c, 0xef, 0xc1, 0x63, 0x0f, 0x8f, 0x3d, 0x3c, 0xf6, 0xf0, 0xbc, 0xeb,
-	0xc2, 0xef, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xf0, 0x3b, 0x7c, 0x15, 0x7d, 0x03, 0x00, 0x00,
+func init() {
+	proto.RegisterFile("common/collection.proto", fileDescriptor_collection_3355ab80352f3a96)
+}
+
+var fileDescriptor_collection_3355ab80352f3a96 = []byte{
+	// 566 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xdf, 0x4f, 0xdb, 0x30,
+	0x10, 0x26, 0xf4, 0x07, 0xcb, 0xa1, 0x41, 0x31, 0x1a, 0x64, 0x68, 0x62, 0x55, 0xb5, 0x87, 0x4a,
+	0xdb, 0xd2, 0x89, 0xbd, 0x4c, 0x7b, 0x1b, 0x08, 0x89, 0x69, 0x48, 0x43, 0x66, 0x4f, 0x6c, 0x52,
+	0xe4, 0x3a, 0x47, 0xb0, 0x70, 0xec, 0xe0, 0xb8, 0x1d, 0x79, 0xdc, 0x9f, 0xba, 0xff, 0x64, 0xaa,
+	0x9d, 0xd0, 0x52, 0xaa, 0xbd, 0xe5, 0xee, 0xfb, 0xbe, 0xcb, 0xdd, 0x77, 0x67, 0xd8, 0xe7, 0x3a,
+	0xcf, 0xb5, 0x1a, 0x71, 0x2d, 0x25, 0x72, 0x2b, 0xb4, 0x8a, 0x0b, 0xa3, 0xad, 0x26, 0x5d, 0x0f,
+	0x1c, 0xbc, 0xa8, 0x09, 0x85, 0x96, 0x82, 0x0b, 0x2c, 0x3d, 0x3c, 0xf8, 0x06, 0xfb, 0x27, 0x0f,
+	0x92, 0x13, 0xad, 0xae, 0x45, 0x76, 0xc1, 0xf8, 0x2d, 0xcb, 0x90, 0x7c, 0x80, 0x2e, 0x77, 0x89,
+	0x28, 0xe8, 0xb7, 0x86, 0x9b, 0x47, 0x51, 0xec, 0x4b, 0xc4, 0xcb, 0x02, 0x5a, 0xf3, 0x06, 0x15,
+	0xf4, 0x96, 0x31, 0x72, 0x05, 0x51, 0x69, 0x99, 0x15, 0x3c, 0x99, 0xb7, 0x96, 0x3c, 0xd4, 0x0d,
+	0x86, 0x9b, 0x47, 0x87, 0x4d, 0xdd, 0x4b, 0xc7, 0x5b, 0xae, 0x70, 0xb6, 0x46, 0xf7, 0xca, 0x95,
+	0xc8, 0x71, 0x08, 0x1b, 0x05, 0xab, 0xa4, 0x66, 0xe9, 0xe0, 0x6f, 0x0b, 0xf6, 0x56, 0xeb, 0x09,
+	0x81, 0xb6, 0x62, 0x39, 0xba, 0xbf, 0x85, 0xd4, 0x7d, 0x93, 0x73, 0x20, 0x39, 0xe6, 0x63, 0x34,
+	0x89, 0x36, 0x59, 0x99, 0x38, 0x53, 0xaa, 0x68, 0xfd, 0x71, 0x3f, 0xf3, 0x4a, 0x17, 0x0e, 0xaf,
+	0xa7, 0xed, 0x79, 0xe5, 0x77, 0x93, 0x95, 0x3e, 0x4f, 0x62, 0xd8, 0x35, 0x78, 0x37, 0x11, 0x06,
+	0xd3, 0xa4, 0x40, 0x34, 0x09, 0xd7, 0x13, 0x65, 0xa3, 0x56, 0x3f, 0x18, 0x76, 0xe8, 0x4e, 0x03,
+	0x5d, 0x20, 0x9a, 0x93, 0x19, 0x40, 0xde, 0x01, 0xc9, 0xd9, 0xbd, 0xc8, 0x27, 0xf9, 0x22, 0xbd,
+	0xed, 0xe8, 0xbd, 0x1a, 0x99, 0xb3, 0x07, 0xf0, 0x7c, 0x2c, 0x35, 0xbf, 0x4d, 0xac, 0x4e, 0xa4,
+	0x98, 0x62, 0xd4, 0xe9, 0x07, 0xc3, 0x36, 0xdd, 0x74, 0xc9, 0x1f, 0xfa, 0x5c, 0x4c, 0x91, 0x0c,
+	0xa1, 0xd7, 0xcc, 0xa3, 0x64, 0x95, 0x18, 0x64, 0x69, 0xd4, 0xed, 0x07, 0xc3, 0x67, 0x74, 0xab,
+	0xee, 0x56, 0xc9, 0x8a, 0x22, 0x4b, 0xc9, 0x4f, 0xd8, 0x66, 0x52, 0xea, 0xdf, 0x98, 0x26, 0x06,
+	0x33, 0xa1, 0x55, 0x19, 0x6d, 0xb8, 0xf5, 0x1e, 0xfd, 0x7f, 0x0d, 0xf1, 0x17, 0xaf, 0xa2, 0x5e,
+	0x74, 0xaa, 0xac, 0xa9, 0xe8, 0x16, 0x7b, 0x94, 0x3c, 0xf8, 0x05, 0xbb, 0x2b, 0x68, 0xa4, 0x07,
+	0xad, 0x5b, 0xac, 0xea, 0x05, 0xcc, 0x3e, 0xc9, 0x08, 0x3a, 0x53, 0x26, 0x27, 0x58, 0x5b, 0xfe,
+	0xf2, 0xa9, 0xe5, 0x75, 0x01, 0xea, 0x79, 0x9f, 0xd7, 0x3f, 0x05, 0x83, 0xf7, 0xb0, 0xf3, 0x04,
+	0x27, 0x11, 0x6c, 0x34, 0x73, 0xcc, 0xce, 0x34, 0xa4, 0x4d, 0x38, 0xb8, 0x83, 0xbd, 0xd5, 0x1b,
+	0x24, 0xe7, 0xd0, 0x2b, 0x45, 0xa6, 0x98, 0x9d, 0x18, 0x6c, 0x76, 0xef, 0x6f, 0xf1, 0xf5, 0x83,
+	0x09, 0x0d, 0xee, 0x85, 0xa7, 0x6a, 0x8a, 0x52, 0x17, 0x78, 0xb6, 0x46, 0xb7, 0xcb, 0xc7, 0xd0,
+	0xe2, 0x15, 0xfe, 0x09, 0x80, 0x2c, 0x18, 0x67, 0x84, 0x45, 0x23, 0xd8, 0xac, 0x47, 0x7e, 0xc3,
+	0x94, 0x42, 0x59, 0x7b, 0xd0, 0x84, 0x64, 0x17, 0x3a, 0xf6, 0x3e, 0x11, 0xa9, 0xf3, 0x21, 0xa4,
+	0x6d, 0x7b, 0xff, 0x35, 0x25, 0x87, 0x00, 0xf3, 0xb7, 0xe2, 0xae, 0x28, 0xa4, 0x0b, 0x19, 0xf2,
+	0x0a, 0xc2, 0xd9, 0x11, 0x97, 0x05, 0xe3, 0xe8, 0xae, 0x26, 0xa4, 0xf3, 0xc4, 0xf1, 0x25, 0xbc,
+	0xd1, 0x26, 0x8b, 0x6f, 0xaa, 0x02, 0x8d, 0xc4, 0x34, 0x43, 0x13, 0x5f, 0xb3, 0xb1, 0x11, 0xdc,
+	0xbf, 0xf8, 0xb2, 0x9e, 0xf0, 0xea, 0x6d, 0x26, 0xec, 0xcd, 0x64, 0x3c, 0x0b, 0x47, 0x0b, 0xe4,
+	0x91, 0x27, 0x8f, 0x3c, 0x79, 0xe4, 0xc9, 0xe3, 0xae, 0x0b, 0x3f, 0xfe, 0x1b, 0x00, 0x85, 0x37,
+	0xf9, 0x4c, 0x67, 0x04, 0x00, 0x00,
 }
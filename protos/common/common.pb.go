@@ -61,7 +61,7 @@ func (x Status) String() string {
 	return proto.EnumName(Status_name, int32(x))
 }
 func (Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{0}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{0}
 }
 
 type HeaderType int32
@@ -105,7 +105,7 @@ func (x HeaderType) String() string {
 	return proto.EnumName(HeaderType_name, int32(x))
 }
 func (HeaderType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{1}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{1}
 }
 
 // This enum enlists indexes of the block metadata array
@@ -116,6 +116,9 @@ const (
 	BlockMetadataIndex_LAST_CONFIG         BlockMetadataIndex = 1
 	BlockMetadataIndex_TRANSACTIONS_FILTER BlockMetadataIndex = 2
 	BlockMetadataIndex_ORDERER             BlockMetadataIndex = 3
+	// e.g. For Kafka, this is where we store the last offset written to the local ledger.
+	BlockMetadataIndex_COMMIT_DIAGNOSTICS BlockMetadataIndex = 4
+	BlockMetadataIndex_COMMIT_HASH        BlockMetadataIndex = 5
 )
 
 var BlockMetadataIndex_name = map[int32]string{
@@ -123,19 +126,23 @@ var BlockMetadataIndex_name = map[int32]string{
 	1: "LAST_CONFIG",
 	2: "TRANSACTIONS_FILTER",
 	3: "ORDERER",
+	4: "COMMIT_DIAGNOSTICS",
+	5: "COMMIT_HASH",
 }
 var BlockMetadataIndex_value = map[string]int32{
 	"SIGNATURES":          0,
 	"LAST_CONFIG":         1,
 	"TRANSACTIONS_FILTER": 2,
 	"ORDERER":             3,
+	"COMMIT_DIAGNOSTICS":  4,
+	"COMMIT_HASH":         5,
 }
 
 func (x BlockMetadataIndex) String() string {
 	return proto.EnumName(BlockMetadataIndex_name, int32(x))
 }
 func (BlockMetadataIndex) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{2}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{2}
 }
 
 // LastConfig is the encoded value for the Metadata message which is encoded in the LAST_CONFIGURATION block metadata index
@@ -150,7 +157,7 @@ func (m *LastConfig) Reset()         { *m = LastConfig{} }
 func (m *LastConfig) String() string { return proto.CompactTextString(m) }
 func (*LastConfig) ProtoMessage()    {}
 func (*LastConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{0}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{0}
 }
 func (m *LastConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LastConfig.Unmarshal(m, b)
@@ -190,7 +197,7 @@ func (m *Metadata) Reset()         { *m = Metadata{} }
 func (m *Metadata) String() string { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()    {}
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{1}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{1}
 }
 func (m *Metadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Metadata.Unmarshal(m, b)
@@ -236,7 +243,7 @@ func (m *MetadataSignature) Reset()         { *m = MetadataSignature{} }
 func (m *MetadataSignature) String() string { return proto.CompactTextString(m) }
 func (*MetadataSignature) ProtoMessage()    {}
 func (*MetadataSignature) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{2}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{2}
 }
 func (m *MetadataSignature) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MetadataSignature.Unmarshal(m, b)
@@ -282,7 +289,7 @@ func (m *Header) Reset()         { *m = Header{} }
 func (m *Header) String() string { return proto.CompactTextString(m) }
 func (*Header) ProtoMessage()    {}
 func (*Header) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{3}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{3}
 }
 func (m *Header) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Header.Unmarshal(m, b)
@@ -345,17 +352,40 @@ type ChannelHeader struct {
 	Extension []byte `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
 	// If mutual TLS is employed, this represents
 	// the hash of the client's TLS certificate
-	TlsCertHash          []byte   `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	TlsCertHash []byte `protobuf:"bytes,8,opt,name=tls_cert_hash,json=tlsCertHash,proto3" json:"tls_cert_hash,omitempty"`
+	// The number of the block from which the transaction expires, it is only
+	// valid in the blocks with a lower number. Zero means that it does not
+	// expire. The expiration height is enforced by the committers of the
+	// channels with the TX_EXPIRATION application capability
+	ExpirationHeight uint64 `protobuf:"varint,9,opt,name=expiration_height,json=expirationHeight,proto3" json:"expiration_height,omitempty"`
+	// The time from which the transaction expires. As the peers do not share
+	// a clock, the expiration time is enforced by the endorsers and upon
+	// broadcast by the orderers, not by the committers
+	ExpirationTime *timestamp.Timestamp `protobuf:"bytes,10,opt,name=expiration_time,json=expirationTime,proto3" json:"expiration_time,omitempty"`
+	// The number of the block from which a scheduled transaction is valid, it
+	// is only valid in the blocks with this number or a higher one. A
+	// transaction is scheduled if it has a scheduled height or time: its
+	// proposal is signed by its creator ahead of time, and a peer collects its
+	// endorsements and submits it on behalf of its creator once it is due. The
+	// signature of the envelope of a scheduled transaction is the signature of
+	// its proposal by its creator. The scheduled height is enforced by the
+	// committers of the channels with the SCHEDULED_TRANSACTIONS application
+	// capability
+	ScheduledHeight uint64 `protobuf:"varint,11,opt,name=scheduled_height,json=scheduledHeight,proto3" json:"scheduled_height,omitempty"`
+	// The time from which a scheduled transaction is valid. As the peers do not
+	// share a clock, the scheduled time is enforced by the endorsers and upon
+	// broadcast by the orderers, not by the committers
+	ScheduledTime        *timestamp.Timestamp `protobuf:"bytes,12,opt,name=scheduled_time,json=scheduledTime,proto3" json:"scheduled_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
 }
 
 func (m *ChannelHeader) Reset()         { *m = ChannelHeader{} }
 func (m *ChannelHeader) String() string { return proto.CompactTextString(m) }
 func (*ChannelHeader) ProtoMessage()    {}
 func (*ChannelHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{4}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{4}
 }
 func (m *ChannelHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelHeader.Unmarshal(m, b)
@@ -431,6 +461,34 @@ func (m *ChannelHeader) GetTlsCertHash() []byte {
 	return nil
 }
 
+func (m *ChannelHeader) GetExpirationHeight() uint64 {
+	if m != nil {
+		return m.ExpirationHeight
+	}
+	return 0
+}
+
+func (m *ChannelHeader) GetExpirationTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpirationTime
+	}
+	return nil
+}
+
+func (m *ChannelHeader) GetScheduledHeight() uint64 {
+	if m != nil {
+		return m.ScheduledHeight
+	}
+	return 0
+}
+
+func (m *ChannelHeader) GetScheduledTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.ScheduledTime
+	}
+	return nil
+}
+
 type SignatureHeader struct {
 	// Creator of the message, a marshaled msp.SerializedIdentity
 	Creator []byte `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
@@ -445,7 +503,7 @@ func (m *SignatureHeader) Reset()         { *m = SignatureHeader{} }
 func (m *SignatureHeader) String() string { return proto.CompactTextString(m) }
 func (*SignatureHeader) ProtoMessage()    {}
 func (*SignatureHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{5}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{5}
 }
 func (m *SignatureHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignatureHeader.Unmarshal(m, b)
@@ -494,7 +552,7 @@ func (m *Payload) Reset()         { *m = Payload{} }
 func (m *Payload) String() string { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()    {}
 func (*Payload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{6}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{6}
 }
 func (m *Payload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Payload.Unmarshal(m, b)
@@ -543,7 +601,7 @@ func (m *Envelope) Reset()         { *m = Envelope{} }
 func (m *Envelope) String() string { return proto.CompactTextString(m) }
 func (*Envelope) ProtoMessage()    {}
 func (*Envelope) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{7}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{7}
 }
 func (m *Envelope) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Envelope.Unmarshal(m, b)
@@ -594,7 +652,7 @@ func (m *Block) Reset()         { *m = Block{} }
 func (m *Block) String() string { return proto.CompactTextString(m) }
 func (*Block) ProtoMessage()    {}
 func (*Block) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{8}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{8}
 }
 func (m *Block) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Block.Unmarshal(m, b)
@@ -651,7 +709,7 @@ func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
 func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
 func (*BlockHeader) ProtoMessage()    {}
 func (*BlockHeader) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{9}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{9}
 }
 func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
@@ -703,7 +761,7 @@ func (m *BlockData) Reset()         { *m = BlockData{} }
 func (m *BlockData) String() string { return proto.CompactTextString(m) }
 func (*BlockData) ProtoMessage()    {}
 func (*BlockData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{10}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{10}
 }
 func (m *BlockData) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockData.Unmarshal(m, b)
@@ -741,7 +799,7 @@ func (m *BlockMetadata) Reset()         { *m = BlockMetadata{} }
 func (m *BlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*BlockMetadata) ProtoMessage()    {}
 func (*BlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{11}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{11}
 }
 func (m *BlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BlockMetadata.Unmarshal(m, b)
@@ -781,7 +839,7 @@ func (m *OrdererBlockMetadata) Reset()         { *m = OrdererBlockMetadata{} }
 func (m *OrdererBlockMetadata) String() string { return proto.CompactTextString(m) }
 func (*OrdererBlockMetadata) ProtoMessage()    {}
 func (*OrdererBlockMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_common_1349c48f9b9adc99, []int{12}
+	return fileDescriptor_common_0d6c4d574b5cddc8, []int{12}
 }
 func (m *OrdererBlockMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OrdererBlockMetadata.Unmarshal(m, b)
@@ -834,72 +892,78 @@ func init() {
 	proto.RegisterEnum("common.BlockMetadataIndex", BlockMetadataIndex_name, BlockMetadataIndex_value)
 }
 
-func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_1349c48f9b9adc99) }
-
-var fileDescriptor_common_1349c48f9b9adc99 = []byte{
-	// 1013 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0x5f, 0x6f, 0xe3, 0xc4,
-	0x17, 0xdd, 0xc4, 0xf9, 0x7b, 0xdd, 0xb4, 0xee, 0xa4, 0xfd, 0xad, 0x7f, 0x85, 0xd5, 0x56, 0x86,
-	0x45, 0xa5, 0x15, 0xa9, 0xe8, 0xbe, 0xc0, 0xa3, 0x63, 0x4f, 0x5b, 0xab, 0xa9, 0x1d, 0xc6, 0xce,
-	0x22, 0x76, 0x91, 0x2c, 0x37, 0x99, 0x26, 0x11, 0x8e, 0x1d, 0xd9, 0x93, 0xaa, 0xe5, 0x95, 0x77,
-	0x84, 0x04, 0xaf, 0x7c, 0x17, 0x1e, 0x11, 0x9f, 0x07, 0xc4, 0x2b, 0x1a, 0x8f, 0xed, 0x26, 0x65,
-	0x25, 0x9e, 0xe2, 0x73, 0xe7, 0xcc, 0xbd, 0x67, 0xee, 0xb9, 0x99, 0x81, 0xee, 0x38, 0x5e, 0x2c,
-	0xe2, 0xe8, 0x54, 0xfc, 0xf4, 0x96, 0x49, 0xcc, 0x62, 0xd4, 0x10, 0xe8, 0xe0, 0xe5, 0x34, 0x8e,
-	0xa7, 0x21, 0x3d, 0xcd, 0xa2, 0x37, 0xab, 0xdb, 0x53, 0x36, 0x5f, 0xd0, 0x94, 0x05, 0x8b, 0xa5,
-	0x20, 0x6a, 0x1a, 0xc0, 0x20, 0x48, 0x99, 0x11, 0x47, 0xb7, 0xf3, 0x29, 0xda, 0x83, 0xfa, 0x3c,
-	0x9a, 0xd0, 0x7b, 0xb5, 0x72, 0x58, 0x39, 0xaa, 0x11, 0x01, 0xb4, 0x77, 0xd0, 0xba, 0xa6, 0x2c,
-	0x98, 0x04, 0x2c, 0xe0, 0x8c, 0xbb, 0x20, 0x5c, 0xd1, 0x8c, 0xb1, 0x45, 0x04, 0x40, 0x5f, 0x02,
-	0xa4, 0xf3, 0x69, 0x14, 0xb0, 0x55, 0x42, 0x53, 0xb5, 0x7a, 0x28, 0x1d, 0xc9, 0x67, 0xff, 0xef,
-	0xe5, 0x8a, 0x8a, 0xbd, 0x6e, 0xc1, 0x20, 0x6b, 0x64, 0xed, 0x5b, 0xd8, 0xfd, 0x17, 0x01, 0x7d,
-	0x0a, 0x4a, 0x49, 0xf1, 0x67, 0x34, 0x98, 0xd0, 0x24, 0x2f, 0xb8, 0x53, 0xc6, 0x2f, 0xb3, 0x30,
-	0xfa, 0x10, 0xda, 0x65, 0x48, 0xad, 0x66, 0x9c, 0xc7, 0x80, 0xf6, 0x16, 0x1a, 0x39, 0xef, 0x15,
-	0x6c, 0x8f, 0x67, 0x41, 0x14, 0xd1, 0x70, 0x33, 0x61, 0x27, 0x8f, 0xe6, 0xb4, 0xf7, 0x55, 0xae,
-	0xbe, 0xb7, 0xb2, 0xf6, 0x43, 0x15, 0x3a, 0xc6, 0xc6, 0x66, 0x04, 0x35, 0xf6, 0xb0, 0x14, 0xbd,
-	0xa9, 0x93, 0xec, 0x1b, 0xa9, 0xd0, 0xbc, 0xa3, 0x49, 0x3a, 0x8f, 0xa3, 0x2c, 0x4f, 0x9d, 0x14,
-	0x10, 0x7d, 0x01, 0xed, 0xd2, 0x0d, 0x55, 0x3a, 0xac, 0x1c, 0xc9, 0x67, 0x07, 0x3d, 0xe1, 0x57,
-	0xaf, 0xf0, 0xab, 0xe7, 0x15, 0x0c, 0xf2, 0x48, 0x46, 0x2f, 0x00, 0x8a, 0xb3, 0xcc, 0x27, 0x6a,
-	0xed, 0xb0, 0x72, 0xd4, 0x26, 0xed, 0x3c, 0x62, 0x4d, 0x50, 0x17, 0xea, 0xec, 0x9e, 0xaf, 0xd4,
-	0xb3, 0x95, 0x1a, 0xbb, 0xb7, 0x26, 0xdc, 0x38, 0xba, 0x8c, 0xc7, 0x33, 0xb5, 0x21, 0xac, 0xcd,
-	0x00, 0xef, 0x1e, 0xbd, 0x67, 0x34, 0xca, 0xf4, 0x35, 0x45, 0xf7, 0xca, 0x00, 0xd2, 0xa0, 0xc3,
-	0xc2, 0xd4, 0x1f, 0xd3, 0x84, 0xf9, 0xb3, 0x20, 0x9d, 0xa9, 0xad, 0x8c, 0x21, 0xb3, 0x30, 0x35,
-	0x68, 0xc2, 0x2e, 0x83, 0x74, 0xa6, 0xe9, 0xb0, 0xe3, 0x3e, 0xb1, 0x44, 0x85, 0xe6, 0x38, 0xa1,
-	0x01, 0x8b, 0x8b, 0x1e, 0x17, 0x90, 0x8b, 0x88, 0xe2, 0x68, 0x5c, 0x18, 0x25, 0x80, 0x86, 0xa1,
-	0x39, 0x0c, 0x1e, 0xc2, 0x38, 0x98, 0xa0, 0x4f, 0xa0, 0xb1, 0xe6, 0x8e, 0x7c, 0xb6, 0x5d, 0x0c,
-	0x91, 0x48, 0x4d, 0xf2, 0x55, 0xde, 0x69, 0x3e, 0x31, 0x79, 0x9e, 0xec, 0x5b, 0xeb, 0x43, 0x0b,
-	0x47, 0x77, 0x34, 0x8c, 0x45, 0xd7, 0x97, 0x22, 0x65, 0x21, 0x21, 0x87, 0xff, 0x31, 0x2f, 0x3f,
-	0x56, 0xa0, 0xde, 0x0f, 0xe3, 0xf1, 0x77, 0xe8, 0xe4, 0x89, 0x92, 0x6e, 0xa1, 0x24, 0x5b, 0x7e,
-	0x22, 0xe7, 0xd5, 0x9a, 0x1c, 0xf9, 0x6c, 0x77, 0x83, 0x6a, 0x06, 0x2c, 0x10, 0x0a, 0xd1, 0xe7,
-	0xd0, 0x5a, 0xe4, 0xb3, 0x9e, 0x1b, 0xbe, 0xbf, 0x41, 0x2d, 0xfe, 0x08, 0xa4, 0xa4, 0x69, 0x53,
-	0x90, 0xd7, 0x0a, 0xa2, 0xff, 0x41, 0x23, 0x5a, 0x2d, 0x6e, 0x72, 0x55, 0x35, 0x92, 0x23, 0xf4,
-	0x11, 0x74, 0x96, 0x09, 0xbd, 0x9b, 0xc7, 0xab, 0x54, 0x38, 0x25, 0x4e, 0xb6, 0x55, 0x04, 0xb9,
-	0x55, 0xe8, 0x03, 0x68, 0xf3, 0x9c, 0x82, 0x20, 0x65, 0x84, 0x16, 0x0f, 0x64, 0x3e, 0xbe, 0x84,
-	0x76, 0x29, 0xb7, 0x6c, 0x6f, 0xe5, 0x50, 0x2a, 0xdb, 0x7b, 0x02, 0x9d, 0x0d, 0x91, 0xe8, 0x60,
-	0xed, 0x34, 0x82, 0xf8, 0x28, 0xfb, 0x7b, 0xd8, 0x73, 0x92, 0x09, 0x4d, 0x68, 0xb2, 0xb9, 0xe7,
-	0x35, 0xc8, 0x61, 0x90, 0x32, 0x7f, 0x9c, 0xdd, 0x37, 0x79, 0x6b, 0x51, 0xd1, 0x84, 0xc7, 0x9b,
-	0x88, 0x40, 0xf8, 0x78, 0x2b, 0x7d, 0x06, 0x68, 0x1c, 0x47, 0x29, 0x8d, 0x18, 0x4d, 0xfc, 0xb2,
-	0xa4, 0x38, 0xe1, 0x6e, 0xb9, 0x52, 0xd4, 0x38, 0xfe, 0xad, 0x02, 0x0d, 0x97, 0x05, 0x6c, 0x95,
-	0x22, 0x19, 0x9a, 0x23, 0xfb, 0xca, 0x76, 0xbe, 0xb6, 0x95, 0x67, 0x68, 0x0b, 0x9a, 0xee, 0xc8,
-	0x30, 0xb0, 0xeb, 0x2a, 0xbf, 0x57, 0x90, 0x02, 0x72, 0x5f, 0x37, 0x7d, 0x82, 0xbf, 0x1a, 0x61,
-	0xd7, 0x53, 0x7e, 0x92, 0xd0, 0x36, 0xb4, 0xcf, 0x1d, 0xd2, 0xb7, 0x4c, 0x13, 0xdb, 0xca, 0xcf,
-	0x19, 0xb6, 0x1d, 0xcf, 0x3f, 0x77, 0x46, 0xb6, 0xa9, 0xfc, 0x22, 0xa1, 0x17, 0xa0, 0xe6, 0x6c,
-	0x1f, 0xdb, 0x9e, 0xe5, 0x7d, 0xe3, 0x7b, 0x8e, 0xe3, 0x0f, 0x74, 0x72, 0x81, 0x95, 0x5f, 0x25,
-	0x74, 0x00, 0xfb, 0x96, 0xed, 0x61, 0x62, 0xeb, 0x03, 0xdf, 0xc5, 0xe4, 0x0d, 0x26, 0x3e, 0x26,
-	0xc4, 0x21, 0xca, 0x9f, 0x12, 0xda, 0x83, 0x1d, 0x9e, 0xca, 0xba, 0x1e, 0x0e, 0xf0, 0x35, 0xb6,
-	0x3d, 0x6c, 0x2a, 0x7f, 0x49, 0x48, 0x85, 0x2e, 0x27, 0x5a, 0x06, 0xf6, 0x47, 0xb6, 0xfe, 0x46,
-	0xb7, 0x06, 0x7a, 0x7f, 0x80, 0x95, 0xbf, 0xa5, 0xe3, 0x3f, 0x2a, 0x00, 0xc2, 0x71, 0x8f, 0xdf,
-	0x21, 0x32, 0x34, 0xaf, 0xb1, 0xeb, 0xea, 0x17, 0x58, 0x79, 0x86, 0x00, 0x1a, 0x86, 0x63, 0x9f,
-	0x5b, 0x17, 0x4a, 0x05, 0xed, 0x42, 0x47, 0x7c, 0xfb, 0xa3, 0xa1, 0xa9, 0x7b, 0x58, 0xa9, 0x22,
-	0x15, 0xf6, 0xb0, 0x6d, 0x3a, 0xc4, 0xc5, 0xc4, 0xf7, 0x88, 0x6e, 0xbb, 0xba, 0xe1, 0x59, 0x8e,
-	0xad, 0x48, 0xe8, 0x39, 0x74, 0x1d, 0x62, 0x62, 0xf2, 0x64, 0xa1, 0x86, 0xf6, 0x61, 0xd7, 0xc4,
-	0x03, 0x8b, 0x2b, 0x76, 0x31, 0xbe, 0xf2, 0x2d, 0xfb, 0xdc, 0x51, 0xea, 0x3c, 0x6c, 0x5c, 0xea,
-	0x96, 0x6d, 0x38, 0x26, 0xf6, 0x87, 0xba, 0x71, 0xc5, 0xeb, 0x37, 0x78, 0x81, 0x21, 0xc6, 0xc4,
-	0xd7, 0xcd, 0x6b, 0xcb, 0xf6, 0x9d, 0x21, 0x26, 0x7a, 0x96, 0xa7, 0xc5, 0x37, 0x78, 0xce, 0x15,
-	0xb6, 0x37, 0xd2, 0xb7, 0x8f, 0xdf, 0x01, 0xda, 0x18, 0x02, 0x8b, 0x3f, 0x2a, 0x68, 0x1b, 0xc0,
-	0xb5, 0x2e, 0x6c, 0xdd, 0x1b, 0x11, 0xec, 0x2a, 0xcf, 0xd0, 0x0e, 0xc8, 0x03, 0xdd, 0xf5, 0xfc,
-	0xf2, 0x6c, 0xcf, 0xa1, 0xbb, 0x96, 0xc7, 0xf5, 0xcf, 0xad, 0x81, 0x87, 0x89, 0x52, 0xe5, 0xdd,
-	0xc8, 0xcf, 0xa1, 0x48, 0x7d, 0x17, 0x3e, 0x8e, 0x93, 0x69, 0x6f, 0xf6, 0xb0, 0xa4, 0x49, 0x48,
-	0x27, 0x53, 0x9a, 0xf4, 0x6e, 0x83, 0x9b, 0x64, 0x3e, 0x16, 0x57, 0x68, 0x9a, 0x8f, 0xd6, 0xdb,
-	0x93, 0xe9, 0x9c, 0xcd, 0x56, 0x37, 0x1c, 0x9e, 0xae, 0x91, 0x4f, 0x05, 0x59, 0xbc, 0x8f, 0x69,
-	0xfe, 0x86, 0xde, 0x34, 0x32, 0xf8, 0xfa, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd4, 0xcc, 0xf9,
-	0x76, 0x5b, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("common/common.proto", fileDescriptor_common_0d6c4d574b5cddc8) }
+
+var fileDescriptor_common_0d6c4d574b5cddc8 = []byte{
+	// 1113 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x56, 0x4f, 0x6f, 0xe3, 0xc4,
+	0x1b, 0xde, 0xd4, 0xf9, 0xd3, 0xbc, 0x6e, 0x5a, 0x77, 0xda, 0xdd, 0xf5, 0xaf, 0x3f, 0x56, 0x5b,
+	0x19, 0x16, 0x95, 0xad, 0x48, 0x45, 0xf7, 0x02, 0x47, 0xd7, 0x9e, 0xb6, 0x56, 0x13, 0xbb, 0x8c,
+	0x9d, 0x45, 0x2c, 0x48, 0x96, 0x9b, 0xcc, 0xc6, 0x16, 0x8e, 0x1d, 0xd9, 0x93, 0xaa, 0xcb, 0x99,
+	0x33, 0x42, 0x82, 0x2b, 0xdf, 0x85, 0x23, 0xe2, 0x6b, 0xf0, 0x15, 0x40, 0x5c, 0xd1, 0x78, 0x6c,
+	0x27, 0x29, 0x2b, 0xf5, 0x94, 0x79, 0x9f, 0x79, 0xe6, 0x7d, 0xde, 0x7f, 0x33, 0x31, 0xec, 0x8d,
+	0xd3, 0xd9, 0x2c, 0x4d, 0x4e, 0xc4, 0x4f, 0x7f, 0x9e, 0xa5, 0x2c, 0x45, 0x6d, 0x61, 0x1d, 0x3c,
+	0x9f, 0xa6, 0xe9, 0x34, 0xa6, 0x27, 0x05, 0x7a, 0xb3, 0x78, 0x7b, 0xc2, 0xa2, 0x19, 0xcd, 0x59,
+	0x30, 0x9b, 0x0b, 0xa2, 0xa6, 0x01, 0x0c, 0x82, 0x9c, 0x19, 0x69, 0xf2, 0x36, 0x9a, 0xa2, 0x7d,
+	0x68, 0x45, 0xc9, 0x84, 0xde, 0xa9, 0x8d, 0xc3, 0xc6, 0x51, 0x93, 0x08, 0x43, 0xfb, 0x06, 0x36,
+	0x87, 0x94, 0x05, 0x93, 0x80, 0x05, 0x9c, 0x71, 0x1b, 0xc4, 0x0b, 0x5a, 0x30, 0xb6, 0x88, 0x30,
+	0xd0, 0x17, 0x00, 0x79, 0x34, 0x4d, 0x02, 0xb6, 0xc8, 0x68, 0xae, 0x6e, 0x1c, 0x4a, 0x47, 0xf2,
+	0xe9, 0xff, 0xfa, 0x65, 0x44, 0xd5, 0x59, 0xb7, 0x62, 0x90, 0x15, 0xb2, 0xf6, 0x2d, 0xec, 0xfe,
+	0x87, 0x80, 0x3e, 0x01, 0xa5, 0xa6, 0xf8, 0x21, 0x0d, 0x26, 0x34, 0x2b, 0x05, 0x77, 0x6a, 0xfc,
+	0xb2, 0x80, 0xd1, 0x07, 0xd0, 0xad, 0x21, 0x75, 0xa3, 0xe0, 0x2c, 0x01, 0xed, 0x0d, 0xb4, 0x4b,
+	0xde, 0x0b, 0xd8, 0x1e, 0x87, 0x41, 0x92, 0xd0, 0x78, 0xdd, 0x61, 0xaf, 0x44, 0x4b, 0xda, 0xfb,
+	0x94, 0x37, 0xde, 0xab, 0xac, 0xfd, 0x29, 0x41, 0xcf, 0x58, 0x3b, 0x8c, 0xa0, 0xc9, 0xde, 0xcd,
+	0x45, 0x6d, 0x5a, 0xa4, 0x58, 0x23, 0x15, 0x3a, 0xb7, 0x34, 0xcb, 0xa3, 0x34, 0x29, 0xfc, 0xb4,
+	0x48, 0x65, 0xa2, 0xcf, 0xa1, 0x5b, 0x77, 0x43, 0x95, 0x0e, 0x1b, 0x47, 0xf2, 0xe9, 0x41, 0x5f,
+	0xf4, 0xab, 0x5f, 0xf5, 0xab, 0xef, 0x55, 0x0c, 0xb2, 0x24, 0xa3, 0x67, 0x00, 0x55, 0x2e, 0xd1,
+	0x44, 0x6d, 0x1e, 0x36, 0x8e, 0xba, 0xa4, 0x5b, 0x22, 0xd6, 0x04, 0xed, 0x41, 0x8b, 0xdd, 0xf1,
+	0x9d, 0x56, 0xb1, 0xd3, 0x64, 0x77, 0xd6, 0x84, 0x37, 0x8e, 0xce, 0xd3, 0x71, 0xa8, 0xb6, 0x45,
+	0x6b, 0x0b, 0x83, 0x57, 0x8f, 0xde, 0x31, 0x9a, 0x14, 0xf1, 0x75, 0x44, 0xf5, 0x6a, 0x00, 0x69,
+	0xd0, 0x63, 0x71, 0xee, 0x8f, 0x69, 0xc6, 0xfc, 0x30, 0xc8, 0x43, 0x75, 0xb3, 0x60, 0xc8, 0x2c,
+	0xce, 0x0d, 0x9a, 0xb1, 0xcb, 0x20, 0x0f, 0xd1, 0x31, 0xec, 0xd2, 0xbb, 0x79, 0x94, 0x05, 0x2c,
+	0x4a, 0x13, 0x3f, 0xa4, 0xd1, 0x34, 0x64, 0x6a, 0xb7, 0xd0, 0x50, 0x96, 0x1b, 0x97, 0x05, 0x8e,
+	0x0c, 0xd8, 0x59, 0x21, 0xf3, 0x84, 0x54, 0x78, 0x30, 0xf1, 0xed, 0xe5, 0x11, 0x0e, 0x16, 0x2d,
+	0x1a, 0x87, 0x74, 0xb2, 0x88, 0xe9, 0xa4, 0x12, 0x94, 0x0b, 0xc1, 0x9d, 0x1a, 0x2f, 0xf5, 0x74,
+	0xd8, 0x5e, 0x52, 0x0b, 0xb9, 0xad, 0x07, 0xe5, 0x7a, 0xf5, 0x09, 0x8e, 0x69, 0x3a, 0xec, 0xb8,
+	0xf7, 0x46, 0x4e, 0x85, 0xce, 0x38, 0xa3, 0x01, 0x4b, 0xab, 0x19, 0xaa, 0x4c, 0x5e, 0xe4, 0x24,
+	0x4d, 0xc6, 0xd5, 0x20, 0x0a, 0x43, 0xc3, 0xd0, 0xb9, 0x0e, 0xde, 0xc5, 0x69, 0x30, 0x41, 0x1f,
+	0x43, 0x7b, 0x65, 0xfa, 0xe4, 0xd3, 0xed, 0xea, 0x92, 0x08, 0xd7, 0xa4, 0x1d, 0xd6, 0x93, 0xc4,
+	0x6f, 0x44, 0xe9, 0xa7, 0x58, 0x6b, 0x67, 0xb0, 0x89, 0x93, 0x5b, 0x1a, 0xa7, 0x62, 0xaa, 0xe6,
+	0xc2, 0x65, 0x15, 0x42, 0x69, 0x3e, 0x70, 0x1f, 0x7e, 0x6c, 0x40, 0xeb, 0x2c, 0x4e, 0xc7, 0xdf,
+	0xa1, 0xe3, 0x7b, 0x91, 0xec, 0x55, 0x91, 0x14, 0xdb, 0xf7, 0xc2, 0x79, 0xb1, 0x12, 0x8e, 0x7c,
+	0xba, 0xbb, 0x46, 0x35, 0x03, 0x16, 0x88, 0x08, 0xd1, 0x67, 0xb0, 0x39, 0x2b, 0xef, 0x72, 0x39,
+	0xd0, 0x8f, 0xd7, 0xa8, 0xd5, 0x45, 0x27, 0x35, 0x4d, 0x9b, 0x82, 0xbc, 0x22, 0x88, 0x9e, 0x40,
+	0x3b, 0x59, 0xcc, 0x6e, 0xca, 0xa8, 0x9a, 0xa4, 0xb4, 0xd0, 0x87, 0xd0, 0x9b, 0x67, 0xf4, 0x36,
+	0x4a, 0x17, 0xb9, 0x98, 0x44, 0x91, 0xd9, 0x56, 0x05, 0x16, 0xa3, 0xf8, 0x7f, 0xe8, 0x72, 0x9f,
+	0x82, 0x20, 0x15, 0x84, 0x4d, 0x0e, 0xf0, 0x4d, 0xed, 0x39, 0x74, 0xeb, 0x70, 0xeb, 0xf2, 0x36,
+	0x0e, 0xa5, 0xba, 0xbc, 0xc7, 0xd0, 0x5b, 0x0b, 0x12, 0x1d, 0xac, 0x64, 0x23, 0x88, 0xcb, 0xb0,
+	0xbf, 0x87, 0x7d, 0x27, 0x9b, 0xd0, 0x8c, 0x66, 0xeb, 0x67, 0x5e, 0x81, 0x1c, 0x07, 0x39, 0xf3,
+	0xc7, 0xc5, 0x7b, 0x5a, 0x96, 0x16, 0x55, 0x45, 0x58, 0xbe, 0xb4, 0x04, 0xe2, 0x7a, 0x8d, 0x3e,
+	0x05, 0x34, 0x4e, 0x93, 0x9c, 0x26, 0x8c, 0x66, 0x7e, 0x2d, 0x29, 0x32, 0xdc, 0xad, 0x77, 0x2a,
+	0x8d, 0x97, 0xbf, 0x35, 0xa0, 0xed, 0xb2, 0x80, 0x2d, 0x72, 0x24, 0x43, 0x67, 0x64, 0x5f, 0xd9,
+	0xce, 0x57, 0xb6, 0xf2, 0x08, 0x6d, 0x41, 0xc7, 0x1d, 0x19, 0x06, 0x76, 0x5d, 0xe5, 0xf7, 0x06,
+	0x52, 0x40, 0x3e, 0xd3, 0x4d, 0x9f, 0xe0, 0x2f, 0x47, 0xd8, 0xf5, 0x94, 0x9f, 0x24, 0xb4, 0x0d,
+	0xdd, 0x73, 0x87, 0x9c, 0x59, 0xa6, 0x89, 0x6d, 0xe5, 0xe7, 0xc2, 0xb6, 0x1d, 0xcf, 0x3f, 0x77,
+	0x46, 0xb6, 0xa9, 0xfc, 0x22, 0xa1, 0x67, 0xa0, 0x96, 0x6c, 0x1f, 0xdb, 0x9e, 0xe5, 0x7d, 0xed,
+	0x7b, 0x8e, 0xe3, 0x0f, 0x74, 0x72, 0x81, 0x95, 0x5f, 0x25, 0x74, 0x00, 0x8f, 0x2d, 0xdb, 0xc3,
+	0xc4, 0xd6, 0x07, 0xbe, 0x8b, 0xc9, 0x6b, 0x4c, 0x7c, 0x4c, 0x88, 0x43, 0x94, 0xbf, 0x24, 0xb4,
+	0x0f, 0x3b, 0xdc, 0x95, 0x35, 0xbc, 0x1e, 0xe0, 0x21, 0xb6, 0x3d, 0x6c, 0x2a, 0x7f, 0x4b, 0x48,
+	0x85, 0x3d, 0x4e, 0xb4, 0x0c, 0xec, 0x8f, 0x6c, 0xfd, 0xb5, 0x6e, 0x0d, 0xf4, 0xb3, 0x01, 0x56,
+	0xfe, 0x91, 0x5e, 0xfe, 0xd1, 0x00, 0x10, 0x1d, 0xf7, 0xf8, 0x1b, 0x29, 0x43, 0x67, 0x88, 0x5d,
+	0x57, 0xbf, 0xc0, 0xca, 0x23, 0x04, 0xd0, 0x36, 0x1c, 0xfb, 0xdc, 0xba, 0x50, 0x1a, 0x68, 0x17,
+	0x7a, 0x62, 0xed, 0x8f, 0xae, 0x4d, 0xdd, 0xc3, 0xca, 0x06, 0x52, 0x61, 0x1f, 0xdb, 0xa6, 0x43,
+	0x5c, 0x4c, 0x7c, 0x8f, 0xe8, 0xb6, 0xab, 0x1b, 0x9e, 0xe5, 0xd8, 0x8a, 0x84, 0x9e, 0xc2, 0x9e,
+	0x43, 0x4c, 0x4c, 0xee, 0x6d, 0x34, 0xd1, 0x63, 0xd8, 0x35, 0xf1, 0xc0, 0xe2, 0x11, 0xbb, 0x18,
+	0x5f, 0xf9, 0x96, 0x7d, 0xee, 0x28, 0x2d, 0x0e, 0x1b, 0x97, 0xba, 0x65, 0x1b, 0x8e, 0x89, 0xfd,
+	0x6b, 0xdd, 0xb8, 0xe2, 0xfa, 0x6d, 0x2e, 0x70, 0x8d, 0x31, 0xf1, 0x75, 0x73, 0x68, 0xd9, 0xbe,
+	0x73, 0x8d, 0x89, 0x5e, 0xf8, 0xd9, 0xe4, 0x07, 0x3c, 0xe7, 0x0a, 0xdb, 0x6b, 0xee, 0xbb, 0x2f,
+	0x7f, 0x68, 0x00, 0x5a, 0x9b, 0x02, 0x8b, 0xff, 0x6b, 0xa2, 0x6d, 0x00, 0xd7, 0xba, 0xb0, 0x75,
+	0x6f, 0x44, 0xb0, 0xab, 0x3c, 0x42, 0x3b, 0x20, 0x0f, 0x74, 0xd7, 0xf3, 0xeb, 0xe4, 0x9e, 0xc2,
+	0xde, 0x8a, 0x23, 0xd7, 0x3f, 0xb7, 0x06, 0x1e, 0x26, 0xca, 0x06, 0x2f, 0x47, 0x99, 0x88, 0x22,
+	0xa1, 0x27, 0x80, 0x0c, 0x67, 0x38, 0xb4, 0x3c, 0xdf, 0xb4, 0xf4, 0x0b, 0xdb, 0x71, 0x3d, 0xcb,
+	0x70, 0x95, 0x26, 0x77, 0x57, 0xe2, 0x97, 0xba, 0x7b, 0xa9, 0xb4, 0xce, 0x5c, 0xf8, 0x28, 0xcd,
+	0xa6, 0xfd, 0xf0, 0xdd, 0x9c, 0x66, 0x31, 0x9d, 0x4c, 0x69, 0xd6, 0x7f, 0x1b, 0xdc, 0x64, 0xd1,
+	0x58, 0x3c, 0x72, 0x79, 0x39, 0x84, 0x6f, 0x8e, 0xa7, 0x11, 0x0b, 0x17, 0x37, 0xdc, 0x3c, 0x59,
+	0x21, 0x9f, 0x08, 0xb2, 0xf8, 0x52, 0xc8, 0xcb, 0xaf, 0x89, 0x9b, 0x76, 0x61, 0xbe, 0xfa, 0x77,
+	0x00, 0xd3, 0x9f, 0x9f, 0xd9, 0x65, 0x08, 0x00, 0x00,
 }
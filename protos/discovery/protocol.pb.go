@@ -42,7 +42,7 @@ func (m *SignedRequest) Reset()         { *m = SignedRequest{} }
 func (m *SignedRequest) String() string { return proto.CompactTextString(m) }
 func (*SignedRequest) ProtoMessage()    {}
 func (*SignedRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{0}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{0}
 }
 func (m *SignedRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedRequest.Unmarshal(m, b)
@@ -93,7 +93,7 @@ func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{1}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{1}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Request.Unmarshal(m, b)
@@ -139,7 +139,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{2}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{2}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -190,7 +190,7 @@ func (m *AuthInfo) Reset()         { *m = AuthInfo{} }
 func (m *AuthInfo) String() string { return proto.CompactTextString(m) }
 func (*AuthInfo) ProtoMessage()    {}
 func (*AuthInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{3}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{3}
 }
 func (m *AuthInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AuthInfo.Unmarshal(m, b)
@@ -242,7 +242,7 @@ func (m *Query) Reset()         { *m = Query{} }
 func (m *Query) String() string { return proto.CompactTextString(m) }
 func (*Query) ProtoMessage()    {}
 func (*Query) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{4}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{4}
 }
 func (m *Query) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Query.Unmarshal(m, b)
@@ -464,7 +464,7 @@ func (m *QueryResult) Reset()         { *m = QueryResult{} }
 func (m *QueryResult) String() string { return proto.CompactTextString(m) }
 func (*QueryResult) ProtoMessage()    {}
 func (*QueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{5}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{5}
 }
 func (m *QueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResult.Unmarshal(m, b)
@@ -670,7 +670,7 @@ func (m *ConfigQuery) Reset()         { *m = ConfigQuery{} }
 func (m *ConfigQuery) String() string { return proto.CompactTextString(m) }
 func (*ConfigQuery) ProtoMessage()    {}
 func (*ConfigQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{6}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{6}
 }
 func (m *ConfigQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigQuery.Unmarshal(m, b)
@@ -704,7 +704,7 @@ func (m *ConfigResult) Reset()         { *m = ConfigResult{} }
 func (m *ConfigResult) String() string { return proto.CompactTextString(m) }
 func (*ConfigResult) ProtoMessage()    {}
 func (*ConfigResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{7}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{7}
 }
 func (m *ConfigResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ConfigResult.Unmarshal(m, b)
@@ -754,7 +754,7 @@ func (m *PeerMembershipQuery) Reset()         { *m = PeerMembershipQuery{} }
 func (m *PeerMembershipQuery) String() string { return proto.CompactTextString(m) }
 func (*PeerMembershipQuery) ProtoMessage()    {}
 func (*PeerMembershipQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{8}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{8}
 }
 func (m *PeerMembershipQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerMembershipQuery.Unmarshal(m, b)
@@ -793,7 +793,7 @@ func (m *PeerMembershipResult) Reset()         { *m = PeerMembershipResult{} }
 func (m *PeerMembershipResult) String() string { return proto.CompactTextString(m) }
 func (*PeerMembershipResult) ProtoMessage()    {}
 func (*PeerMembershipResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{9}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{9}
 }
 func (m *PeerMembershipResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PeerMembershipResult.Unmarshal(m, b)
@@ -835,7 +835,7 @@ func (m *ChaincodeQuery) Reset()         { *m = ChaincodeQuery{} }
 func (m *ChaincodeQuery) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeQuery) ProtoMessage()    {}
 func (*ChaincodeQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{10}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{10}
 }
 func (m *ChaincodeQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeQuery.Unmarshal(m, b)
@@ -876,7 +876,7 @@ func (m *ChaincodeInterest) Reset()         { *m = ChaincodeInterest{} }
 func (m *ChaincodeInterest) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInterest) ProtoMessage()    {}
 func (*ChaincodeInterest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{11}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{11}
 }
 func (m *ChaincodeInterest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInterest.Unmarshal(m, b)
@@ -917,7 +917,7 @@ func (m *ChaincodeCall) Reset()         { *m = ChaincodeCall{} }
 func (m *ChaincodeCall) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeCall) ProtoMessage()    {}
 func (*ChaincodeCall) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{12}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{12}
 }
 func (m *ChaincodeCall) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeCall.Unmarshal(m, b)
@@ -964,7 +964,7 @@ func (m *ChaincodeQueryResult) Reset()         { *m = ChaincodeQueryResult{} }
 func (m *ChaincodeQueryResult) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeQueryResult) ProtoMessage()    {}
 func (*ChaincodeQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{13}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{13}
 }
 func (m *ChaincodeQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeQueryResult.Unmarshal(m, b)
@@ -1002,7 +1002,7 @@ func (m *LocalPeerQuery) Reset()         { *m = LocalPeerQuery{} }
 func (m *LocalPeerQuery) String() string { return proto.CompactTextString(m) }
 func (*LocalPeerQuery) ProtoMessage()    {}
 func (*LocalPeerQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{14}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{14}
 }
 func (m *LocalPeerQuery) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LocalPeerQuery.Unmarshal(m, b)
@@ -1041,17 +1041,19 @@ type EndorsementDescriptor struct {
 	// Specifies options of fulfulling the endorsement policy.
 	// Each option lists the group names, and the amount of signatures needed
 	// from each group.
-	Layouts              []*Layout `protobuf:"bytes,3,rep,name=layouts,proto3" json:"layouts,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Layouts []*Layout `protobuf:"bytes,3,rep,name=layouts,proto3" json:"layouts,omitempty"`
+	// The annotations of the chaincode definition
+	Annotations          map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *EndorsementDescriptor) Reset()         { *m = EndorsementDescriptor{} }
 func (m *EndorsementDescriptor) String() string { return proto.CompactTextString(m) }
 func (*EndorsementDescriptor) ProtoMessage()    {}
 func (*EndorsementDescriptor) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{15}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{15}
 }
 func (m *EndorsementDescriptor) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_EndorsementDescriptor.Unmarshal(m, b)
@@ -1092,6 +1094,13 @@ func (m *EndorsementDescriptor) GetLayouts() []*Layout {
 	return nil
 }
 
+func (m *EndorsementDescriptor) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
 // Layout contains a mapping from a group name to number of peers
 // that are needed for fulfilling an endorsement policy
 type Layout struct {
@@ -1107,7 +1116,7 @@ func (m *Layout) Reset()         { *m = Layout{} }
 func (m *Layout) String() string { return proto.CompactTextString(m) }
 func (*Layout) ProtoMessage()    {}
 func (*Layout) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{16}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{16}
 }
 func (m *Layout) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Layout.Unmarshal(m, b)
@@ -1146,7 +1155,7 @@ func (m *Peers) Reset()         { *m = Peers{} }
 func (m *Peers) String() string { return proto.CompactTextString(m) }
 func (*Peers) ProtoMessage()    {}
 func (*Peers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{17}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{17}
 }
 func (m *Peers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Peers.Unmarshal(m, b)
@@ -1191,7 +1200,7 @@ func (m *Peer) Reset()         { *m = Peer{} }
 func (m *Peer) String() string { return proto.CompactTextString(m) }
 func (*Peer) ProtoMessage()    {}
 func (*Peer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{18}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{18}
 }
 func (m *Peer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Peer.Unmarshal(m, b)
@@ -1244,7 +1253,7 @@ func (m *Error) Reset()         { *m = Error{} }
 func (m *Error) String() string { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()    {}
 func (*Error) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{19}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{19}
 }
 func (m *Error) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Error.Unmarshal(m, b)
@@ -1283,7 +1292,7 @@ func (m *Endpoints) Reset()         { *m = Endpoints{} }
 func (m *Endpoints) String() string { return proto.CompactTextString(m) }
 func (*Endpoints) ProtoMessage()    {}
 func (*Endpoints) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{20}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{20}
 }
 func (m *Endpoints) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endpoints.Unmarshal(m, b)
@@ -1323,7 +1332,7 @@ func (m *Endpoint) Reset()         { *m = Endpoint{} }
 func (m *Endpoint) String() string { return proto.CompactTextString(m) }
 func (*Endpoint) ProtoMessage()    {}
 func (*Endpoint) Descriptor() ([]byte, []int) {
-	return fileDescriptor_protocol_b2f93a2b7b5bdad4, []int{21}
+	return fileDescriptor_protocol_1b8a62c7e7b8e64a, []int{21}
 }
 func (m *Endpoint) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endpoint.Unmarshal(m, b)
@@ -1377,6 +1386,7 @@ func init() {
 	proto.RegisterType((*ChaincodeQueryResult)(nil), "discovery.ChaincodeQueryResult")
 	proto.RegisterType((*LocalPeerQuery)(nil), "discovery.LocalPeerQuery")
 	proto.RegisterType((*EndorsementDescriptor)(nil), "discovery.EndorsementDescriptor")
+	proto.RegisterMapType((map[string]string)(nil), "discovery.EndorsementDescriptor.AnnotationsEntry")
 	proto.RegisterMapType((map[string]*Peers)(nil), "discovery.EndorsementDescriptor.EndorsersByGroupsEntry")
 	proto.RegisterType((*Layout)(nil), "discovery.Layout")
 	proto.RegisterMapType((map[string]uint32)(nil), "discovery.Layout.QuantitiesByGroupEntry")
@@ -1461,80 +1471,82 @@ var _Discovery_serviceDesc = grpc.ServiceDesc{
 	Metadata: "discovery/protocol.proto",
 }
 
-func init() { proto.RegisterFile("discovery/protocol.proto", fileDescriptor_protocol_b2f93a2b7b5bdad4) }
-
-var fileDescriptor_protocol_b2f93a2b7b5bdad4 = []byte{
-	// 1145 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x5b, 0x6f, 0xe3, 0x44,
-	0x14, 0x6e, 0xd2, 0xa6, 0x49, 0x4e, 0x9a, 0x5e, 0xa6, 0x61, 0x09, 0xd1, 0x8a, 0xdd, 0xb5, 0xb4,
-	0x50, 0x16, 0xc9, 0x59, 0x95, 0xdb, 0xd2, 0x56, 0xa0, 0xed, 0x85, 0x4d, 0xc5, 0x76, 0xdb, 0xce,
-	0x22, 0x84, 0x78, 0x89, 0x5c, 0xe7, 0x34, 0xb1, 0x70, 0x3c, 0xee, 0xcc, 0xb8, 0x92, 0x9f, 0x79,
-	0xe7, 0x27, 0xf0, 0xc2, 0x0b, 0xe2, 0x27, 0xf0, 0xeb, 0x90, 0xe7, 0xe2, 0x38, 0xa9, 0xcb, 0x22,
-	0xf1, 0xe6, 0x39, 0xe7, 0x7c, 0xe7, 0xf2, 0x9d, 0xe3, 0x99, 0x03, 0xdd, 0x51, 0x20, 0x7c, 0x76,
-	0x8b, 0x3c, 0xed, 0xc7, 0x9c, 0x49, 0xe6, 0xb3, 0xd0, 0x55, 0x1f, 0xa4, 0x99, 0x6b, 0x7a, 0x9d,
-	0x31, 0x13, 0x22, 0x88, 0xfb, 0x53, 0x14, 0xc2, 0x1b, 0xa3, 0x36, 0xe8, 0x75, 0xa6, 0x22, 0xee,
-	0x4f, 0x45, 0x3c, 0xf4, 0x59, 0x74, 0x1d, 0x8c, 0x8b, 0xd2, 0x60, 0x84, 0x91, 0x0c, 0x64, 0x80,
-	0x42, 0x4b, 0x9d, 0x57, 0xd0, 0x7e, 0x1b, 0x8c, 0x23, 0x1c, 0x51, 0xbc, 0x49, 0x50, 0x48, 0xd2,
-	0x85, 0x7a, 0xec, 0xa5, 0x21, 0xf3, 0x46, 0xdd, 0xca, 0xe3, 0xca, 0xce, 0x1a, 0xb5, 0x47, 0xf2,
-	0x10, 0x9a, 0x22, 0x18, 0x47, 0x9e, 0x4c, 0x38, 0x76, 0xab, 0x4a, 0x37, 0x13, 0x38, 0x1c, 0xea,
-	0xd6, 0xc5, 0x3e, 0xac, 0x7b, 0x89, 0x9c, 0x64, 0x91, 0x7c, 0x4f, 0x06, 0x2c, 0x52, 0x9e, 0x5a,
-	0xbb, 0xdb, 0x6e, 0x9e, 0xb9, 0xfb, 0x32, 0x91, 0x93, 0xd3, 0xe8, 0x9a, 0xd1, 0x05, 0x53, 0xf2,
-	0x0c, 0xea, 0x37, 0x09, 0xf2, 0x00, 0x45, 0xb7, 0xfa, 0x78, 0x79, 0xa7, 0xb5, 0xbb, 0x59, 0x40,
-	0x5d, 0x26, 0xc8, 0x53, 0x6a, 0x0d, 0x9c, 0x03, 0x68, 0x50, 0x14, 0x31, 0x8b, 0x04, 0x92, 0xe7,
-	0x50, 0xe7, 0x28, 0x92, 0x50, 0x8a, 0x6e, 0x45, 0xe1, 0x1e, 0xdc, 0xc1, 0x29, 0x35, 0xb5, 0x66,
-	0xce, 0x08, 0x1a, 0x36, 0x0b, 0xf2, 0x31, 0x6c, 0xf8, 0x61, 0x80, 0x91, 0x1c, 0x1a, 0x86, 0x52,
-	0x53, 0xfd, 0xba, 0x16, 0x9f, 0x1a, 0x29, 0xe9, 0x43, 0xc7, 0x18, 0xca, 0x50, 0x0c, 0x7d, 0xe4,
-	0x72, 0x38, 0xf1, 0xc4, 0xc4, 0xf0, 0xb1, 0xa5, 0x75, 0x3f, 0x84, 0xe2, 0x08, 0xb9, 0x1c, 0x78,
-	0x62, 0xe2, 0xfc, 0x5e, 0x85, 0x9a, 0x0a, 0x9f, 0x31, 0xeb, 0x4f, 0xbc, 0x28, 0xc2, 0x50, 0xf9,
-	0x6e, 0x52, 0x7b, 0x24, 0xfb, 0xb0, 0xa6, 0x5b, 0x35, 0xcc, 0x2a, 0x4b, 0x95, 0xb3, 0xf9, 0x02,
-	0x8e, 0x94, 0x5a, 0xf9, 0x19, 0x2c, 0xd1, 0x96, 0x3f, 0x3b, 0x92, 0x6f, 0x01, 0x62, 0x44, 0x6e,
-	0xa0, 0xcb, 0x0a, 0xfa, 0x61, 0x01, 0x7a, 0x81, 0xc8, 0xcf, 0x70, 0x7a, 0x85, 0x5c, 0x4c, 0x82,
-	0xd8, 0xba, 0x68, 0x66, 0x18, 0xed, 0xe0, 0x4b, 0x68, 0xf8, 0xbe, 0x81, 0xaf, 0x28, 0xf8, 0x07,
-	0xc5, 0xc8, 0x13, 0x2f, 0x88, 0x7c, 0x36, 0x42, 0x8b, 0xac, 0xfb, 0xbe, 0xc6, 0x1d, 0x40, 0x2b,
-	0x64, 0xbe, 0x17, 0x0e, 0x33, 0x57, 0xa2, 0x5b, 0xbb, 0x03, 0x7d, 0x9d, 0x69, 0x2f, 0x6c, 0x9c,
-	0xc1, 0x12, 0x85, 0xd0, 0x4a, 0xc4, 0x61, 0x1d, 0x6a, 0x2a, 0xa4, 0xf3, 0x6b, 0x15, 0x5a, 0x85,
-	0xfe, 0x90, 0x1d, 0xa8, 0x21, 0xe7, 0x8c, 0x9b, 0xa1, 0x29, 0xb6, 0xff, 0x24, 0x93, 0x0f, 0x96,
-	0xa8, 0x36, 0x20, 0xdf, 0x40, 0xdb, 0xd0, 0xa6, 0x5b, 0x6a, 0x78, 0x7b, 0xff, 0x0e, 0x6f, 0xda,
-	0xf3, 0x60, 0x89, 0x1a, 0x9a, 0x4d, 0xa4, 0x23, 0x58, 0xb3, 0x85, 0x67, 0x1e, 0x0c, 0x77, 0x8f,
-	0xee, 0x2d, 0x3e, 0x77, 0x03, 0x86, 0x02, 0x8a, 0x82, 0xec, 0x43, 0x7d, 0xaa, 0xd9, 0x35, 0xe4,
-	0x3d, 0xba, 0x97, 0xfb, 0x1c, 0x6f, 0x11, 0x87, 0x0d, 0x58, 0xd5, 0xa9, 0x3b, 0x6d, 0x68, 0x15,
-	0x7a, 0xec, 0xfc, 0x55, 0x85, 0xb5, 0x62, 0xee, 0xe4, 0x0b, 0x58, 0x99, 0x8a, 0xd8, 0xce, 0xf6,
-	0x93, 0x7b, 0x4a, 0x74, 0xcf, 0x44, 0x2c, 0x4e, 0x22, 0xc9, 0x53, 0xaa, 0xcc, 0xc9, 0x4b, 0x68,
-	0x30, 0x3e, 0x42, 0x9e, 0xa5, 0xa7, 0x7f, 0xa7, 0xa7, 0xf7, 0x41, 0xcf, 0x8d, 0x9d, 0x86, 0xe7,
-	0xb0, 0xde, 0x19, 0x34, 0x73, 0xaf, 0x64, 0x13, 0x96, 0x7f, 0xc1, 0xd4, 0xcc, 0x6f, 0xf6, 0x49,
-	0x9e, 0x41, 0xed, 0xd6, 0x0b, 0x13, 0x34, 0xe4, 0x77, 0xdc, 0xa9, 0x88, 0xdd, 0xef, 0xbc, 0x2b,
-	0x1e, 0xf8, 0x67, 0x6f, 0x2f, 0x4c, 0x04, 0x6d, 0xb2, 0x57, 0x7d, 0x51, 0xe9, 0x5d, 0x42, 0x7b,
-	0x2e, 0xd2, 0x7f, 0x71, 0x59, 0x98, 0x80, 0x68, 0x14, 0xb3, 0x20, 0x92, 0xa2, 0xe0, 0xd2, 0xf9,
-	0x1e, 0xb6, 0x4b, 0x86, 0x9c, 0x7c, 0x0e, 0xab, 0xd7, 0x41, 0x28, 0xd1, 0x4e, 0xd2, 0xc3, 0xb2,
-	0xc6, 0x9e, 0x46, 0x12, 0x39, 0x0a, 0x49, 0x8d, 0xad, 0xf3, 0x77, 0x05, 0x3a, 0x65, 0x6d, 0x23,
-	0x97, 0xb0, 0xa6, 0x06, 0x7d, 0x78, 0x95, 0x0e, 0x19, 0x1f, 0x9b, 0x4e, 0xf4, 0xdf, 0xd1, 0x6d,
-	0x57, 0x4f, 0x7b, 0x7a, 0xce, 0xc7, 0x9a, 0x58, 0xf5, 0xb3, 0x6a, 0x41, 0xef, 0x1c, 0x36, 0x16,
-	0xd4, 0x25, 0x6c, 0x7c, 0x34, 0xcf, 0xc6, 0xe6, 0x42, 0xc0, 0x39, 0x26, 0x5e, 0xc3, 0xfa, 0xfc,
-	0xc8, 0x92, 0x3d, 0x68, 0x06, 0xa6, 0x44, 0x3b, 0x3c, 0xff, 0xce, 0xc3, 0xcc, 0xdc, 0x39, 0x83,
-	0xad, 0x3b, 0x7a, 0xf2, 0x02, 0xc0, 0xb7, 0x42, 0xeb, 0xb1, 0x5b, 0xe6, 0xf1, 0xc8, 0x0b, 0x43,
-	0x5a, 0xb0, 0x75, 0xde, 0x40, 0x7b, 0x4e, 0x49, 0x08, 0xac, 0x44, 0xde, 0x14, 0x4d, 0xb1, 0xea,
-	0x9b, 0x7c, 0x02, 0x9b, 0x3e, 0x0b, 0x43, 0xf4, 0xb3, 0xc7, 0x60, 0x98, 0x89, 0xf4, 0xe0, 0x36,
-	0xe9, 0xc6, 0x4c, 0xfe, 0x26, 0x13, 0x3b, 0x14, 0x3a, 0x65, 0xff, 0x27, 0xd9, 0x83, 0xba, 0xcf,
-	0x22, 0x89, 0x91, 0x34, 0xe9, 0x3d, 0x9e, 0x1f, 0x20, 0xc6, 0x05, 0x4e, 0x31, 0x92, 0xc7, 0x28,
-	0x7c, 0x1e, 0xc4, 0x92, 0x71, 0x6a, 0x01, 0xce, 0x26, 0xac, 0xcf, 0xdf, 0x5a, 0xce, 0x1f, 0x55,
-	0x78, 0xaf, 0x14, 0x94, 0xbd, 0x87, 0x79, 0x75, 0xa6, 0x86, 0x99, 0x80, 0x8c, 0x61, 0x1b, 0x35,
-	0x4c, 0x8f, 0xcc, 0x98, 0xb3, 0x24, 0xb6, 0x3f, 0xe1, 0x57, 0xef, 0xca, 0xc8, 0x4a, 0xb3, 0xd9,
-	0x78, 0xa5, 0x90, 0x7a, 0x7a, 0xb6, 0x70, 0x51, 0x4e, 0x3e, 0x85, 0x7a, 0xe8, 0xa5, 0x2c, 0x91,
-	0xd9, 0x05, 0x96, 0x39, 0xdf, 0x2a, 0x5e, 0xc1, 0x4a, 0x43, 0xad, 0x45, 0xef, 0x47, 0x78, 0x50,
-	0xee, 0xf9, 0x7f, 0x0e, 0xde, 0x9f, 0x15, 0x58, 0xd5, 0xb1, 0xc8, 0x4f, 0xb0, 0x7d, 0x93, 0x78,
-	0x66, 0xcb, 0xc8, 0x2b, 0x37, 0xad, 0xd8, 0xb9, 0x93, 0x9b, 0x7b, 0x99, 0x1b, 0x9b, 0x84, 0x4c,
-	0xa5, 0x37, 0x8b, 0xf2, 0xde, 0x31, 0x3c, 0x28, 0x37, 0x2e, 0x49, 0xbe, 0x53, 0x4c, 0xbe, 0x5d,
-	0x4c, 0xd5, 0x85, 0x9a, 0x4a, 0x9f, 0x3c, 0x85, 0x9a, 0x7e, 0xb9, 0x74, 0x6a, 0x1b, 0x0b, 0xf5,
-	0x51, 0xad, 0x75, 0x7e, 0xab, 0xc0, 0x4a, 0x76, 0x26, 0x7d, 0x00, 0x21, 0x3d, 0x89, 0xc3, 0x20,
-	0xba, 0x66, 0xf9, 0xeb, 0xa4, 0x37, 0x30, 0xf7, 0x24, 0xba, 0xc5, 0x90, 0xc5, 0x48, 0x9b, 0xca,
-	0x46, 0x2d, 0x15, 0x5f, 0xc3, 0xc6, 0x34, 0xbf, 0x0e, 0x34, 0xaa, 0x7a, 0x0f, 0x6a, 0x7d, 0x66,
-	0xa8, 0xa0, 0x3d, 0x68, 0xe4, 0x8b, 0xc8, 0xb2, 0x5a, 0x2d, 0xf2, 0xb3, 0xf3, 0x04, 0x6a, 0xea,
-	0x21, 0x54, 0x0b, 0x45, 0x3e, 0xe8, 0x7a, 0xa1, 0x30, 0x63, 0x7c, 0x00, 0xcd, 0xfc, 0xa6, 0x24,
-	0x7d, 0x68, 0xa0, 0x39, 0x98, 0x52, 0xb7, 0x4b, 0x6e, 0x54, 0x9a, 0x1b, 0x39, 0xbb, 0xd0, 0xb0,
-	0xd2, 0xec, 0x1f, 0x9d, 0x30, 0x61, 0x03, 0xa8, 0xef, 0x4c, 0x16, 0x33, 0x2e, 0x0d, 0xb5, 0xea,
-	0x7b, 0x77, 0x00, 0xcd, 0x63, 0xeb, 0x93, 0xec, 0x43, 0xc3, 0x1e, 0x48, 0xf1, 0x6e, 0x98, 0xdb,
-	0x34, 0x7b, 0xc5, 0x2c, 0xec, 0x1a, 0xe7, 0x2c, 0x1d, 0x3e, 0xff, 0xd9, 0x1d, 0x07, 0x72, 0x92,
-	0x5c, 0xb9, 0x3e, 0x9b, 0xf6, 0x27, 0x69, 0x8c, 0x3c, 0xc4, 0xd1, 0x18, 0x79, 0xff, 0x5a, 0xbd,
-	0x2a, 0x7a, 0x1d, 0x16, 0xfd, 0x1c, 0x7c, 0xb5, 0xaa, 0x24, 0x9f, 0xfd, 0x13, 0x00, 0x00, 0xff,
-	0xff, 0x32, 0x94, 0x32, 0xb7, 0x33, 0x0b, 0x00, 0x00,
+func init() { proto.RegisterFile("discovery/protocol.proto", fileDescriptor_protocol_1b8a62c7e7b8e64a) }
+
+var fileDescriptor_protocol_1b8a62c7e7b8e64a = []byte{
+	// 1177 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xdb, 0x6e, 0x23, 0x45,
+	0x13, 0x8e, 0x9d, 0x38, 0xb6, 0xcb, 0x76, 0xe2, 0x74, 0xfc, 0xef, 0x6f, 0xac, 0x15, 0xec, 0x8e,
+	0xb4, 0x10, 0x16, 0x69, 0xbc, 0x84, 0xd3, 0xb2, 0x89, 0x16, 0xe5, 0xc4, 0x3a, 0x62, 0xb3, 0x49,
+	0x26, 0x08, 0x21, 0x6e, 0xac, 0xc9, 0xb8, 0x62, 0x8f, 0x18, 0x77, 0x4f, 0xba, 0x7b, 0x22, 0xf9,
+	0x9a, 0x7b, 0x1e, 0x81, 0x6b, 0xc4, 0x13, 0x20, 0x9e, 0x0e, 0x4d, 0x1f, 0xc6, 0xe3, 0x43, 0x08,
+	0x12, 0x77, 0xdd, 0x55, 0xf5, 0x7d, 0x5d, 0xf5, 0x75, 0xf5, 0x01, 0xda, 0x83, 0x50, 0x04, 0xec,
+	0x0e, 0xf9, 0xa4, 0x1b, 0x73, 0x26, 0x59, 0xc0, 0x22, 0x57, 0x0d, 0x48, 0x35, 0xf3, 0x74, 0x5a,
+	0x43, 0x26, 0x44, 0x18, 0x77, 0xc7, 0x28, 0x84, 0x3f, 0x44, 0x1d, 0xd0, 0x69, 0x8d, 0x45, 0xdc,
+	0x1d, 0x8b, 0xb8, 0x1f, 0x30, 0x7a, 0x13, 0x0e, 0xf3, 0xd6, 0x70, 0x80, 0x54, 0x86, 0x32, 0x44,
+	0xa1, 0xad, 0xce, 0x1b, 0x68, 0x5c, 0x85, 0x43, 0x8a, 0x03, 0x0f, 0x6f, 0x13, 0x14, 0x92, 0xb4,
+	0xa1, 0x1c, 0xfb, 0x93, 0x88, 0xf9, 0x83, 0x76, 0xe1, 0x49, 0x61, 0xa7, 0xee, 0xd9, 0x29, 0x79,
+	0x0c, 0x55, 0x11, 0x0e, 0xa9, 0x2f, 0x13, 0x8e, 0xed, 0xa2, 0xf2, 0x4d, 0x0d, 0x0e, 0x87, 0xb2,
+	0xa5, 0xd8, 0x83, 0x0d, 0x3f, 0x91, 0xa3, 0x74, 0xa5, 0xc0, 0x97, 0x21, 0xa3, 0x8a, 0xa9, 0xb6,
+	0xbb, 0xed, 0x66, 0x99, 0xbb, 0x07, 0x89, 0x1c, 0x9d, 0xd2, 0x1b, 0xe6, 0xcd, 0x85, 0x92, 0xe7,
+	0x50, 0xbe, 0x4d, 0x90, 0x87, 0x28, 0xda, 0xc5, 0x27, 0xab, 0x3b, 0xb5, 0xdd, 0x66, 0x0e, 0x75,
+	0x99, 0x20, 0x9f, 0x78, 0x36, 0xc0, 0xd9, 0x87, 0x8a, 0x87, 0x22, 0x66, 0x54, 0x20, 0x79, 0x01,
+	0x65, 0x8e, 0x22, 0x89, 0xa4, 0x68, 0x17, 0x14, 0xee, 0xd1, 0x02, 0x4e, 0xb9, 0x3d, 0x1b, 0xe6,
+	0x0c, 0xa0, 0x62, 0xb3, 0x20, 0x1f, 0xc1, 0x66, 0x10, 0x85, 0x48, 0x65, 0xdf, 0x28, 0x34, 0x31,
+	0xd5, 0x6f, 0x68, 0xf3, 0xa9, 0xb1, 0x92, 0x2e, 0xb4, 0x4c, 0xa0, 0x8c, 0x44, 0x3f, 0x40, 0x2e,
+	0xfb, 0x23, 0x5f, 0x8c, 0x8c, 0x1e, 0x5b, 0xda, 0xf7, 0x7d, 0x24, 0x8e, 0x90, 0xcb, 0x9e, 0x2f,
+	0x46, 0xce, 0x6f, 0x45, 0x28, 0xa9, 0xe5, 0x53, 0x65, 0x83, 0x91, 0x4f, 0x29, 0x46, 0x8a, 0xbb,
+	0xea, 0xd9, 0x29, 0xd9, 0x83, 0xba, 0xde, 0xaa, 0x7e, 0x5a, 0xd9, 0x44, 0x91, 0xcd, 0x16, 0x70,
+	0xa4, 0xdc, 0x8a, 0xa7, 0xb7, 0xe2, 0xd5, 0x82, 0xe9, 0x94, 0x7c, 0x03, 0x10, 0x23, 0x72, 0x03,
+	0x5d, 0x55, 0xd0, 0xf7, 0x73, 0xd0, 0x0b, 0x44, 0x7e, 0x86, 0xe3, 0x6b, 0xe4, 0x62, 0x14, 0xc6,
+	0x96, 0xa2, 0x9a, 0x62, 0x34, 0xc1, 0x97, 0x50, 0x09, 0x02, 0x03, 0x5f, 0x53, 0xf0, 0xf7, 0xf2,
+	0x2b, 0x8f, 0xfc, 0x90, 0x06, 0x6c, 0x80, 0x16, 0x59, 0x0e, 0x02, 0x8d, 0xdb, 0x87, 0x5a, 0xc4,
+	0x02, 0x3f, 0xea, 0xa7, 0x54, 0xa2, 0x5d, 0x5a, 0x80, 0xbe, 0x4d, 0xbd, 0x17, 0x76, 0x9d, 0xde,
+	0x8a, 0x07, 0x91, 0xb5, 0x88, 0xc3, 0x32, 0x94, 0xd4, 0x92, 0xce, 0x2f, 0x45, 0xa8, 0xe5, 0xf6,
+	0x87, 0xec, 0x40, 0x09, 0x39, 0x67, 0xdc, 0x34, 0x4d, 0x7e, 0xfb, 0x4f, 0x52, 0x7b, 0x6f, 0xc5,
+	0xd3, 0x01, 0xe4, 0x35, 0x34, 0x8c, 0x6c, 0x7a, 0x4b, 0x8d, 0x6e, 0xff, 0x5f, 0xd0, 0x4d, 0x33,
+	0xf7, 0x56, 0xbc, 0x7a, 0x90, 0x9b, 0x93, 0x23, 0xa8, 0xdb, 0xc2, 0x53, 0x06, 0xa3, 0xdd, 0x07,
+	0xf7, 0x16, 0x9f, 0xd1, 0x80, 0x91, 0xc0, 0x43, 0x41, 0xf6, 0xa0, 0x3c, 0xd6, 0xea, 0xb6, 0xd7,
+	0x16, 0xf0, 0xb3, 0xda, 0x67, 0x78, 0x8b, 0x38, 0xac, 0xc0, 0xba, 0x4e, 0xdd, 0x69, 0x40, 0x2d,
+	0xb7, 0xc7, 0xce, 0x1f, 0x45, 0xa8, 0xe7, 0x73, 0x27, 0x5f, 0xc0, 0xda, 0x58, 0xc4, 0xb6, 0xb7,
+	0x9f, 0xde, 0x53, 0xa2, 0x7b, 0x26, 0x62, 0x71, 0x42, 0x25, 0x9f, 0x78, 0x2a, 0x9c, 0x1c, 0x40,
+	0x85, 0xf1, 0x01, 0x72, 0xe4, 0xf6, 0x38, 0x3d, 0xbb, 0x0f, 0x7a, 0x6e, 0xe2, 0x34, 0x3c, 0x83,
+	0x75, 0xce, 0xa0, 0x9a, 0xb1, 0x92, 0x26, 0xac, 0xfe, 0x8c, 0x13, 0xd3, 0xbf, 0xe9, 0x90, 0x3c,
+	0x87, 0xd2, 0x9d, 0x1f, 0x25, 0x68, 0xc4, 0x6f, 0xb9, 0x63, 0x11, 0xbb, 0xdf, 0xfa, 0xd7, 0x3c,
+	0x0c, 0xce, 0xae, 0x2e, 0xcc, 0x0a, 0x3a, 0xe4, 0x55, 0xf1, 0x65, 0xa1, 0x73, 0x09, 0x8d, 0x99,
+	0x95, 0xfe, 0x0d, 0x65, 0xae, 0x03, 0xe8, 0x20, 0x66, 0x21, 0x95, 0x22, 0x47, 0xe9, 0x7c, 0x07,
+	0xdb, 0x4b, 0x9a, 0x9c, 0x7c, 0x0e, 0xeb, 0x37, 0x61, 0x24, 0xd1, 0x76, 0xd2, 0xe3, 0x65, 0x1b,
+	0x7b, 0x4a, 0x25, 0x72, 0x14, 0xd2, 0x33, 0xb1, 0xce, 0x5f, 0x05, 0x68, 0x2d, 0xdb, 0x36, 0x72,
+	0x09, 0x75, 0xd5, 0xe8, 0xfd, 0xeb, 0x49, 0x9f, 0xf1, 0xa1, 0xd9, 0x89, 0xee, 0x03, 0xbb, 0xed,
+	0xea, 0x6e, 0x9f, 0x9c, 0xf3, 0xa1, 0x16, 0x16, 0xe2, 0xcc, 0xd0, 0x39, 0x87, 0xcd, 0x39, 0xf7,
+	0x12, 0x35, 0x3e, 0x9c, 0x55, 0xa3, 0x39, 0xb7, 0xe0, 0x8c, 0x12, 0x6f, 0x61, 0x63, 0xb6, 0x65,
+	0xc9, 0x2b, 0xa8, 0x86, 0xa6, 0x44, 0xdb, 0x3c, 0xff, 0xac, 0xc3, 0x34, 0xdc, 0x39, 0x83, 0xad,
+	0x05, 0x3f, 0x79, 0x09, 0x10, 0x58, 0xa3, 0x65, 0x6c, 0x2f, 0x63, 0x3c, 0xf2, 0xa3, 0xc8, 0xcb,
+	0xc5, 0x3a, 0xef, 0xa0, 0x31, 0xe3, 0x24, 0x04, 0xd6, 0xa8, 0x3f, 0x46, 0x53, 0xac, 0x1a, 0x93,
+	0x8f, 0xa1, 0x19, 0xb0, 0x28, 0xc2, 0x20, 0x7d, 0x0c, 0xfa, 0xa9, 0x49, 0x37, 0x6e, 0xd5, 0xdb,
+	0x9c, 0xda, 0xdf, 0xa5, 0x66, 0xc7, 0x83, 0xd6, 0xb2, 0xf3, 0x49, 0x5e, 0x41, 0x39, 0x60, 0x54,
+	0x22, 0x95, 0x26, 0xbd, 0x27, 0xb3, 0x0d, 0xc4, 0xb8, 0xc0, 0x31, 0x52, 0x79, 0x8c, 0x22, 0xe0,
+	0x61, 0x2c, 0x19, 0xf7, 0x2c, 0xc0, 0x69, 0xc2, 0xc6, 0xec, 0xad, 0xe5, 0xfc, 0xb9, 0x0a, 0xff,
+	0x5b, 0x0a, 0x4a, 0xdf, 0xc3, 0xac, 0x3a, 0x53, 0xc3, 0xd4, 0x40, 0x86, 0xb0, 0x8d, 0x1a, 0xa6,
+	0x5b, 0x66, 0xc8, 0x59, 0x12, 0xdb, 0x43, 0xf8, 0xd5, 0x43, 0x19, 0x59, 0x6b, 0xda, 0x1b, 0x6f,
+	0x14, 0x52, 0x77, 0xcf, 0x16, 0xce, 0xdb, 0xc9, 0x27, 0x50, 0x8e, 0xfc, 0x09, 0x4b, 0x64, 0x7a,
+	0x81, 0xa5, 0xe4, 0x5b, 0xf9, 0x2b, 0x58, 0x79, 0x3c, 0x1b, 0x41, 0xae, 0xa0, 0xe6, 0x53, 0xca,
+	0xa4, 0x7a, 0x6b, 0xd3, 0x1b, 0x2b, 0x05, 0x7c, 0xfa, 0x60, 0x36, 0x07, 0x53, 0x8c, 0xce, 0x23,
+	0xcf, 0xd2, 0xf9, 0x01, 0x1e, 0x2d, 0x4f, 0xf7, 0xbf, 0x75, 0x73, 0xe7, 0x35, 0x34, 0xe7, 0x17,
+	0x5e, 0xc2, 0xd8, 0xca, 0x33, 0x56, 0xf3, 0xa7, 0xe1, 0xf7, 0x02, 0xac, 0x6b, 0x01, 0xc8, 0x8f,
+	0xb0, 0x7d, 0x9b, 0xf8, 0xe6, 0xeb, 0x93, 0x6d, 0x87, 0xe9, 0x8f, 0x9d, 0x05, 0xc1, 0xdc, 0xcb,
+	0x2c, 0xd8, 0x14, 0x64, 0xe4, 0xbf, 0x9d, 0xb7, 0x77, 0x8e, 0xe1, 0xd1, 0xf2, 0xe0, 0x87, 0x52,
+	0x6d, 0xe4, 0x53, 0x75, 0xa1, 0xa4, 0xca, 0x27, 0xcf, 0xa0, 0xa4, 0x9f, 0x53, 0x9d, 0xda, 0xe6,
+	0x9c, 0x3e, 0x9e, 0xf6, 0x3a, 0xbf, 0x16, 0x60, 0x2d, 0x9d, 0x93, 0x2e, 0x80, 0x90, 0xbe, 0xc4,
+	0x7e, 0x48, 0x6f, 0x58, 0xf6, 0x64, 0xea, 0x6f, 0xa1, 0x7b, 0x42, 0xef, 0x30, 0x62, 0x31, 0x7a,
+	0x55, 0x15, 0xa3, 0x7e, 0x3a, 0x5f, 0xc3, 0xe6, 0x38, 0xbb, 0xa3, 0x34, 0xaa, 0x78, 0x0f, 0x6a,
+	0x63, 0x1a, 0xa8, 0xa0, 0x1d, 0xa8, 0x64, 0xbf, 0xa3, 0x55, 0xf5, 0xdf, 0xc9, 0xe6, 0xce, 0x53,
+	0x28, 0xa9, 0xd7, 0x59, 0xfd, 0x72, 0xb2, 0xd3, 0xa7, 0x7f, 0x39, 0xe6, 0x6c, 0xed, 0x43, 0x35,
+	0xbb, 0xbe, 0x49, 0x17, 0x2a, 0x68, 0x26, 0xa6, 0xd4, 0xed, 0x25, 0xd7, 0xbc, 0x97, 0x05, 0x39,
+	0xbb, 0x50, 0xb1, 0xd6, 0xf4, 0xe2, 0x18, 0x31, 0x61, 0x17, 0x50, 0xe3, 0xd4, 0x16, 0x33, 0x2e,
+	0x8d, 0xb4, 0x6a, 0xbc, 0xdb, 0x83, 0xea, 0xb1, 0xe5, 0x24, 0x7b, 0x50, 0xb1, 0x13, 0x92, 0xbf,
+	0xb0, 0x66, 0xbe, 0xbf, 0x9d, 0x7c, 0x16, 0xf6, 0x6f, 0xe9, 0xac, 0x1c, 0xbe, 0xf8, 0xc9, 0x1d,
+	0x86, 0x72, 0x94, 0x5c, 0xbb, 0x01, 0x1b, 0x77, 0x47, 0x93, 0x18, 0x79, 0x84, 0x83, 0x21, 0xf2,
+	0xee, 0x8d, 0x7a, 0xea, 0xf4, 0x1f, 0x5d, 0x74, 0x33, 0xf0, 0xf5, 0xba, 0xb2, 0x7c, 0xf6, 0xf7,
+	0x00, 0xb6, 0x44, 0xa6, 0xcc, 0xc8, 0x0b, 0x00, 0x00,
 }
@@ -33,7 +33,7 @@ func (m *KV) Reset()         { *m = KV{} }
 func (m *KV) String() string { return proto.CompactTextString(m) }
 func (*KV) ProtoMessage()    {}
 func (*KV) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_query_result_8f59f813f4fe5e5a, []int{0}
+	return fileDescriptor_kv_query_result_15d16bcd44e20214, []int{0}
 }
 func (m *KV) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KV.Unmarshal(m, b)
@@ -90,7 +90,7 @@ func (m *KeyModification) Reset()         { *m = KeyModification{} }
 func (m *KeyModification) String() string { return proto.CompactTextString(m) }
 func (*KeyModification) ProtoMessage()    {}
 func (*KeyModification) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_query_result_8f59f813f4fe5e5a, []int{1}
+	return fileDescriptor_kv_query_result_15d16bcd44e20214, []int{1}
 }
 func (m *KeyModification) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyModification.Unmarshal(m, b)
@@ -138,33 +138,253 @@ func (m *KeyModification) GetIsDelete() bool {
 	return false
 }
 
+// StateProof -- Proof that a key of a namespace was set to a value by a valid transaction
+// of a block, which is verified by recomputing the commit hash of the block from the digest
+// of the updates of the state by the block, from its transactions filter and from the commit
+// hash of the previous block, and by comparing it to the commit hash of the block obtained
+// from other peers.
+type StateProof struct {
+	Namespace            string        `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key                  string        `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value                []byte        `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Metadata             []byte        `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	BlockNum             uint64        `protobuf:"varint,5,opt,name=block_num,json=blockNum,proto3" json:"block_num,omitempty"`
+	TxNum                uint64        `protobuf:"varint,6,opt,name=tx_num,json=txNum,proto3" json:"tx_num,omitempty"`
+	Updates              *StateUpdates `protobuf:"bytes,7,opt,name=updates,proto3" json:"updates,omitempty"`
+	TransactionsFilter   []byte        `protobuf:"bytes,8,opt,name=transactions_filter,json=transactionsFilter,proto3" json:"transactions_filter,omitempty"`
+	PreviousCommitHash   []byte        `protobuf:"bytes,9,opt,name=previous_commit_hash,json=previousCommitHash,proto3" json:"previous_commit_hash,omitempty"`
+	CommitHash           []byte        `protobuf:"bytes,10,opt,name=commit_hash,json=commitHash,proto3" json:"commit_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *StateProof) Reset()         { *m = StateProof{} }
+func (m *StateProof) String() string { return proto.CompactTextString(m) }
+func (*StateProof) ProtoMessage()    {}
+func (*StateProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_query_result_15d16bcd44e20214, []int{2}
+}
+func (m *StateProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateProof.Unmarshal(m, b)
+}
+func (m *StateProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateProof.Marshal(b, m, deterministic)
+}
+func (dst *StateProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateProof.Merge(dst, src)
+}
+func (m *StateProof) XXX_Size() int {
+	return xxx_messageInfo_StateProof.Size(m)
+}
+func (m *StateProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateProof proto.InternalMessageInfo
+
+func (m *StateProof) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StateProof) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *StateProof) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *StateProof) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *StateProof) GetBlockNum() uint64 {
+	if m != nil {
+		return m.BlockNum
+	}
+	return 0
+}
+
+func (m *StateProof) GetTxNum() uint64 {
+	if m != nil {
+		return m.TxNum
+	}
+	return 0
+}
+
+func (m *StateProof) GetUpdates() *StateUpdates {
+	if m != nil {
+		return m.Updates
+	}
+	return nil
+}
+
+func (m *StateProof) GetTransactionsFilter() []byte {
+	if m != nil {
+		return m.TransactionsFilter
+	}
+	return nil
+}
+
+func (m *StateProof) GetPreviousCommitHash() []byte {
+	if m != nil {
+		return m.PreviousCommitHash
+	}
+	return nil
+}
+
+func (m *StateProof) GetCommitHash() []byte {
+	if m != nil {
+		return m.CommitHash
+	}
+	return nil
+}
+
+// StateUpdates -- Digest of the updates of the public state and of the hashes of the private
+// data by the valid transactions of a block, ordered by namespace.
+type StateUpdates struct {
+	Namespaces           []*NamespaceStateUpdates `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *StateUpdates) Reset()         { *m = StateUpdates{} }
+func (m *StateUpdates) String() string { return proto.CompactTextString(m) }
+func (*StateUpdates) ProtoMessage()    {}
+func (*StateUpdates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_query_result_15d16bcd44e20214, []int{3}
+}
+func (m *StateUpdates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateUpdates.Unmarshal(m, b)
+}
+func (m *StateUpdates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateUpdates.Marshal(b, m, deterministic)
+}
+func (dst *StateUpdates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateUpdates.Merge(dst, src)
+}
+func (m *StateUpdates) XXX_Size() int {
+	return xxx_messageInfo_StateUpdates.Size(m)
+}
+func (m *StateUpdates) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateUpdates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateUpdates proto.InternalMessageInfo
+
+func (m *StateUpdates) GetNamespaces() []*NamespaceStateUpdates {
+	if m != nil {
+		return m.Namespaces
+	}
+	return nil
+}
+
+// NamespaceStateUpdates -- Digest of the updates of the keys of a namespace, ordered by key.
+// Each entry is a delete marker byte followed by the hash of the key, of the value, of the
+// metadata and of the version written.
+type NamespaceStateUpdates struct {
+	Namespace            string   `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Entries              [][]byte `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NamespaceStateUpdates) Reset()         { *m = NamespaceStateUpdates{} }
+func (m *NamespaceStateUpdates) String() string { return proto.CompactTextString(m) }
+func (*NamespaceStateUpdates) ProtoMessage()    {}
+func (*NamespaceStateUpdates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_query_result_15d16bcd44e20214, []int{4}
+}
+func (m *NamespaceStateUpdates) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NamespaceStateUpdates.Unmarshal(m, b)
+}
+func (m *NamespaceStateUpdates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NamespaceStateUpdates.Marshal(b, m, deterministic)
+}
+func (dst *NamespaceStateUpdates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceStateUpdates.Merge(dst, src)
+}
+func (m *NamespaceStateUpdates) XXX_Size() int {
+	return xxx_messageInfo_NamespaceStateUpdates.Size(m)
+}
+func (m *NamespaceStateUpdates) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceStateUpdates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceStateUpdates proto.InternalMessageInfo
+
+func (m *NamespaceStateUpdates) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *NamespaceStateUpdates) GetEntries() [][]byte {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*KV)(nil), "queryresult.KV")
 	proto.RegisterType((*KeyModification)(nil), "queryresult.KeyModification")
+	proto.RegisterType((*StateProof)(nil), "queryresult.StateProof")
+	proto.RegisterType((*StateUpdates)(nil), "queryresult.StateUpdates")
+	proto.RegisterType((*NamespaceStateUpdates)(nil), "queryresult.NamespaceStateUpdates")
 }
 
 func init() {
-	proto.RegisterFile("ledger/queryresult/kv_query_result.proto", fileDescriptor_kv_query_result_8f59f813f4fe5e5a)
-}
-
-var fileDescriptor_kv_query_result_8f59f813f4fe5e5a = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x51, 0x4f, 0x4b, 0xc3, 0x30,
-	0x1c, 0xa5, 0xdd, 0x26, 0x6b, 0x26, 0x28, 0xd1, 0x43, 0x99, 0x82, 0x65, 0xa7, 0x9e, 0x12, 0xd1,
-	0x83, 0x9e, 0xc5, 0x8b, 0x0e, 0x2f, 0x45, 0x3c, 0x78, 0x29, 0x69, 0xfb, 0x6b, 0x17, 0xda, 0x2e,
-	0x35, 0x7f, 0xc6, 0xfa, 0x39, 0xfc, 0xc2, 0x62, 0xb2, 0xd9, 0x82, 0xb7, 0xbc, 0xf7, 0x7b, 0xef,
-	0xf1, 0x78, 0x41, 0x71, 0x03, 0x45, 0x05, 0x92, 0x7e, 0x19, 0x90, 0xbd, 0x04, 0x65, 0x1a, 0x4d,
-	0xeb, 0x5d, 0x6a, 0x61, 0xea, 0x30, 0xe9, 0xa4, 0xd0, 0x02, 0x2f, 0x46, 0x92, 0xe5, 0x4d, 0x25,
-	0x44, 0xd5, 0x00, 0xb5, 0xa7, 0xcc, 0x94, 0x54, 0xf3, 0x16, 0x94, 0x66, 0x6d, 0xe7, 0xd4, 0xab,
-	0x57, 0xe4, 0xaf, 0x3f, 0xf0, 0x35, 0x0a, 0xb6, 0xac, 0x05, 0xd5, 0xb1, 0x1c, 0x42, 0x2f, 0xf2,
-	0xe2, 0x20, 0x19, 0x08, 0x7c, 0x8e, 0x26, 0x35, 0xf4, 0xa1, 0x6f, 0xf9, 0xdf, 0x27, 0xbe, 0x44,
-	0xb3, 0x1d, 0x6b, 0x0c, 0x84, 0x93, 0xc8, 0x8b, 0x4f, 0x13, 0x07, 0x56, 0xdf, 0x1e, 0x3a, 0x5b,
-	0x43, 0xff, 0x26, 0x0a, 0x5e, 0xf2, 0x9c, 0x69, 0x2e, 0xb6, 0xf8, 0x02, 0xcd, 0xf4, 0x3e, 0xe5,
-	0xc5, 0x21, 0x75, 0xaa, 0xf7, 0x2f, 0xc5, 0x60, 0xf7, 0x47, 0x76, 0xfc, 0x88, 0x82, 0xbf, 0x76,
-	0x36, 0x78, 0x71, 0xb7, 0x24, 0xae, 0x3f, 0x39, 0xf6, 0x27, 0xef, 0x47, 0x45, 0x32, 0x88, 0xf1,
-	0x15, 0x0a, 0xb8, 0x4a, 0x0b, 0x68, 0x40, 0x43, 0x38, 0x8d, 0xbc, 0x78, 0x9e, 0xcc, 0xb9, 0x7a,
-	0xb6, 0xf8, 0xa9, 0x46, 0xb7, 0x42, 0x56, 0x64, 0xd3, 0x77, 0x20, 0xdd, 0x88, 0xa4, 0x64, 0x99,
-	0xe4, 0xb9, 0x0b, 0x55, 0xe4, 0x40, 0x8e, 0x66, 0xfb, 0x7c, 0xa8, 0xb8, 0xde, 0x98, 0x8c, 0xe4,
-	0xa2, 0xa5, 0x23, 0x23, 0x75, 0x46, 0xb7, 0xa6, 0xa2, 0xff, 0xbf, 0x24, 0x3b, 0xb1, 0xa7, 0xfb,
-	0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xa2, 0xb7, 0x3e, 0x86, 0xaf, 0x01, 0x00, 0x00,
+	proto.RegisterFile("ledger/queryresult/kv_query_result.proto", fileDescriptor_kv_query_result_15d16bcd44e20214)
+}
+
+var fileDescriptor_kv_query_result_15d16bcd44e20214 = []byte{
+	// 486 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcb, 0x6e, 0xd3, 0x4c,
+	0x14, 0x96, 0x73, 0xcf, 0x49, 0xa4, 0xff, 0xd7, 0xb4, 0x95, 0x86, 0x80, 0x54, 0xcb, 0x2b, 0xaf,
+	0xec, 0xaa, 0x5d, 0xc0, 0xba, 0x20, 0x04, 0x54, 0x14, 0x64, 0x2e, 0x0b, 0x36, 0xd6, 0xc4, 0x3e,
+	0x49, 0x46, 0xb1, 0x33, 0x66, 0xe6, 0x38, 0x4a, 0x9e, 0x83, 0xc7, 0xe3, 0x65, 0x50, 0x66, 0xea,
+	0xc4, 0x15, 0x48, 0x6c, 0xd8, 0xe5, 0xbb, 0x4d, 0xbe, 0x33, 0xe3, 0x03, 0x61, 0x81, 0xf9, 0x12,
+	0x75, 0xfc, 0xbd, 0x46, 0xbd, 0xd7, 0x68, 0xea, 0x82, 0xe2, 0xf5, 0x36, 0xb5, 0x30, 0x75, 0x38,
+	0xaa, 0xb4, 0x22, 0xc5, 0x26, 0x2d, 0xcb, 0xec, 0x72, 0xa9, 0xd4, 0xb2, 0xc0, 0xd8, 0x4a, 0xf3,
+	0x7a, 0x11, 0x93, 0x2c, 0xd1, 0x90, 0x28, 0x2b, 0xe7, 0x0e, 0xde, 0x41, 0xe7, 0xee, 0x2b, 0x7b,
+	0x06, 0xe3, 0x8d, 0x28, 0xd1, 0x54, 0x22, 0x43, 0xee, 0xf9, 0x5e, 0x38, 0x4e, 0x4e, 0x04, 0xfb,
+	0x1f, 0xba, 0x6b, 0xdc, 0xf3, 0x8e, 0xe5, 0x0f, 0x3f, 0xd9, 0x39, 0xf4, 0xb7, 0xa2, 0xa8, 0x91,
+	0x77, 0x7d, 0x2f, 0x9c, 0x26, 0x0e, 0x04, 0x3f, 0x3c, 0xf8, 0xef, 0x0e, 0xf7, 0xef, 0x55, 0x2e,
+	0x17, 0x32, 0x13, 0x24, 0xd5, 0x86, 0x9d, 0x41, 0x9f, 0x76, 0xa9, 0xcc, 0x1f, 0x4e, 0xed, 0xd1,
+	0xee, 0x6d, 0x7e, 0x8a, 0x77, 0x5a, 0x71, 0xf6, 0x02, 0xc6, 0xc7, 0x76, 0xf6, 0xe0, 0xc9, 0xf5,
+	0x2c, 0x72, 0xfd, 0xa3, 0xa6, 0x7f, 0xf4, 0xb9, 0x71, 0x24, 0x27, 0x33, 0x7b, 0x0a, 0x63, 0x69,
+	0xd2, 0x1c, 0x0b, 0x24, 0xe4, 0x3d, 0xdf, 0x0b, 0x47, 0xc9, 0x48, 0x9a, 0x57, 0x16, 0x07, 0x3f,
+	0x3b, 0x00, 0x9f, 0x48, 0x10, 0x7e, 0xd4, 0x4a, 0x2d, 0xfe, 0xcd, 0xa8, 0x6c, 0x06, 0xa3, 0x12,
+	0x49, 0xe4, 0x82, 0x84, 0xfd, 0xc3, 0x69, 0x72, 0xc4, 0x87, 0x36, 0xf3, 0x42, 0x65, 0xeb, 0x74,
+	0x53, 0x97, 0xbc, 0xef, 0x7b, 0x61, 0x2f, 0x19, 0x59, 0xe2, 0xbe, 0x2e, 0xd9, 0x05, 0x0c, 0x68,
+	0x67, 0x95, 0x81, 0x55, 0xfa, 0xb4, 0x3b, 0xd0, 0x37, 0x30, 0xac, 0xab, 0x5c, 0x10, 0x1a, 0x3e,
+	0xb4, 0x93, 0x3f, 0x89, 0x5a, 0xcf, 0x18, 0xd9, 0xfe, 0x5f, 0x9c, 0x21, 0x69, 0x9c, 0x2c, 0x86,
+	0x33, 0xd2, 0x62, 0x63, 0x44, 0x76, 0xb8, 0x6a, 0x93, 0x2e, 0x64, 0x41, 0xa8, 0xf9, 0xc8, 0xf6,
+	0x61, 0x6d, 0xe9, 0xb5, 0x55, 0xd8, 0x15, 0x9c, 0x57, 0x1a, 0xb7, 0x52, 0xd5, 0x26, 0xcd, 0x54,
+	0x59, 0x4a, 0x4a, 0x57, 0xc2, 0xac, 0xf8, 0xd8, 0x25, 0x1a, 0xed, 0xa5, 0x95, 0xde, 0x08, 0xb3,
+	0x62, 0x97, 0x30, 0x69, 0x1b, 0xc1, 0x1a, 0x21, 0x3b, 0x1a, 0x82, 0x04, 0xa6, 0xed, 0x72, 0xec,
+	0x16, 0xe0, 0x78, 0x9b, 0x86, 0x7b, 0x7e, 0x37, 0x9c, 0x5c, 0x07, 0x8f, 0x66, 0xb9, 0x6f, 0xe4,
+	0x47, 0x43, 0xb5, 0x52, 0xc1, 0x07, 0xb8, 0xf8, 0xa3, 0xe9, 0x2f, 0x6f, 0xc7, 0x61, 0x88, 0x1b,
+	0xd2, 0x12, 0x0d, 0xef, 0xf8, 0xdd, 0x70, 0x9a, 0x34, 0xf0, 0x76, 0x0d, 0x57, 0x4a, 0x2f, 0xa3,
+	0xd5, 0xbe, 0x42, 0xed, 0xf6, 0x28, 0x5a, 0x88, 0xb9, 0x96, 0x99, 0xfb, 0xae, 0x4c, 0xf4, 0x40,
+	0xb6, 0x6a, 0x7e, 0x7b, 0xbe, 0x94, 0xb4, 0xaa, 0xe7, 0x51, 0xa6, 0xca, 0xb8, 0x15, 0x8c, 0x5d,
+	0xd0, 0x2d, 0x94, 0x89, 0x7f, 0xdf, 0xca, 0xf9, 0xc0, 0x4a, 0x37, 0xbf, 0x06, 0x00, 0xdd, 0xe7,
+	0x50, 0xf7, 0xb2, 0x03, 0x00, 0x00,
 }
@@ -25,6 +25,8 @@ type KVRWSet struct {
 	RangeQueriesInfo     []*RangeQueryInfo  `protobuf:"bytes,2,rep,name=range_queries_info,json=rangeQueriesInfo,proto3" json:"range_queries_info,omitempty"`
 	Writes               []*KVWrite         `protobuf:"bytes,3,rep,name=writes,proto3" json:"writes,omitempty"`
 	MetadataWrites       []*KVMetadataWrite `protobuf:"bytes,4,rep,name=metadata_writes,json=metadataWrites,proto3" json:"metadata_writes,omitempty"`
+	Increments           []*KVIncrement     `protobuf:"bytes,5,rep,name=increments,proto3" json:"increments,omitempty"`
+	RangeDeletes         []*KVRangeDelete   `protobuf:"bytes,6,rep,name=range_deletes,json=rangeDeletes,proto3" json:"range_deletes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
 	XXX_unrecognized     []byte             `json:"-"`
 	XXX_sizecache        int32              `json:"-"`
@@ -34,7 +36,7 @@ func (m *KVRWSet) Reset()         { *m = KVRWSet{} }
 func (m *KVRWSet) String() string { return proto.CompactTextString(m) }
 func (*KVRWSet) ProtoMessage()    {}
 func (*KVRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{0}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{0}
 }
 func (m *KVRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRWSet.Unmarshal(m, b)
@@ -82,6 +84,20 @@ func (m *KVRWSet) GetMetadataWrites() []*KVMetadataWrite {
 	return nil
 }
 
+func (m *KVRWSet) GetIncrements() []*KVIncrement {
+	if m != nil {
+		return m.Increments
+	}
+	return nil
+}
+
+func (m *KVRWSet) GetRangeDeletes() []*KVRangeDelete {
+	if m != nil {
+		return m.RangeDeletes
+	}
+	return nil
+}
+
 // HashedRWSet encapsulates hashed representation of a private read-write set for KV or Document data model
 type HashedRWSet struct {
 	HashedReads          []*KVReadHash          `protobuf:"bytes,1,rep,name=hashed_reads,json=hashedReads,proto3" json:"hashed_reads,omitempty"`
@@ -96,7 +112,7 @@ func (m *HashedRWSet) Reset()         { *m = HashedRWSet{} }
 func (m *HashedRWSet) String() string { return proto.CompactTextString(m) }
 func (*HashedRWSet) ProtoMessage()    {}
 func (*HashedRWSet) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{1}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{1}
 }
 func (m *HashedRWSet) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HashedRWSet.Unmarshal(m, b)
@@ -151,7 +167,7 @@ func (m *KVRead) Reset()         { *m = KVRead{} }
 func (m *KVRead) String() string { return proto.CompactTextString(m) }
 func (*KVRead) ProtoMessage()    {}
 func (*KVRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{2}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{2}
 }
 func (m *KVRead) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVRead.Unmarshal(m, b)
@@ -199,7 +215,7 @@ func (m *KVWrite) Reset()         { *m = KVWrite{} }
 func (m *KVWrite) String() string { return proto.CompactTextString(m) }
 func (*KVWrite) ProtoMessage()    {}
 func (*KVWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{3}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{3}
 }
 func (m *KVWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWrite.Unmarshal(m, b)
@@ -240,6 +256,113 @@ func (m *KVWrite) GetValue() []byte {
 	return nil
 }
 
+// KVIncrement captures a commutative update of a counter performed during transaction simulation.
+// The delta is added at commit time to the value of the key committed at that time, hence the
+// increment does not require the key to be read and does not cause MVCC read conflicts
+type KVIncrement struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Delta                int64    `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVIncrement) Reset()         { *m = KVIncrement{} }
+func (m *KVIncrement) String() string { return proto.CompactTextString(m) }
+func (*KVIncrement) ProtoMessage()    {}
+func (*KVIncrement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{4}
+}
+func (m *KVIncrement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVIncrement.Unmarshal(m, b)
+}
+func (m *KVIncrement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVIncrement.Marshal(b, m, deterministic)
+}
+func (dst *KVIncrement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVIncrement.Merge(dst, src)
+}
+func (m *KVIncrement) XXX_Size() int {
+	return xxx_messageInfo_KVIncrement.Size(m)
+}
+func (m *KVIncrement) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVIncrement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVIncrement proto.InternalMessageInfo
+
+func (m *KVIncrement) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KVIncrement) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
+// KVRangeDelete captures the deletion of the keys of the range [start_key, end_key) performed during
+// transaction simulation. The keys are deleted at commit time, keys_hash being the fingerprint of the
+// keys found in the range during the simulation, the hash of the keys and of their versions, which
+// the committers check against the keys of the range at commit time as for a range query
+type KVRangeDelete struct {
+	StartKey             string   `protobuf:"bytes,1,opt,name=start_key,json=startKey,proto3" json:"start_key,omitempty"`
+	EndKey               string   `protobuf:"bytes,2,opt,name=end_key,json=endKey,proto3" json:"end_key,omitempty"`
+	KeysHash             []byte   `protobuf:"bytes,3,opt,name=keys_hash,json=keysHash,proto3" json:"keys_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KVRangeDelete) Reset()         { *m = KVRangeDelete{} }
+func (m *KVRangeDelete) String() string { return proto.CompactTextString(m) }
+func (*KVRangeDelete) ProtoMessage()    {}
+func (*KVRangeDelete) Descriptor() ([]byte, []int) {
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{5}
+}
+func (m *KVRangeDelete) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KVRangeDelete.Unmarshal(m, b)
+}
+func (m *KVRangeDelete) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KVRangeDelete.Marshal(b, m, deterministic)
+}
+func (dst *KVRangeDelete) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KVRangeDelete.Merge(dst, src)
+}
+func (m *KVRangeDelete) XXX_Size() int {
+	return xxx_messageInfo_KVRangeDelete.Size(m)
+}
+func (m *KVRangeDelete) XXX_DiscardUnknown() {
+	xxx_messageInfo_KVRangeDelete.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KVRangeDelete proto.InternalMessageInfo
+
+func (m *KVRangeDelete) GetStartKey() string {
+	if m != nil {
+		return m.StartKey
+	}
+	return ""
+}
+
+func (m *KVRangeDelete) GetEndKey() string {
+	if m != nil {
+		return m.EndKey
+	}
+	return ""
+}
+
+func (m *KVRangeDelete) GetKeysHash() []byte {
+	if m != nil {
+		return m.KeysHash
+	}
+	return nil
+}
+
 // KVMetadataWrite captures all the entries in the metadata associated with a key
 type KVMetadataWrite struct {
 	Key                  string             `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -253,7 +376,7 @@ func (m *KVMetadataWrite) Reset()         { *m = KVMetadataWrite{} }
 func (m *KVMetadataWrite) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWrite) ProtoMessage()    {}
 func (*KVMetadataWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{4}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{6}
 }
 func (m *KVMetadataWrite) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWrite.Unmarshal(m, b)
@@ -302,7 +425,7 @@ func (m *KVReadHash) Reset()         { *m = KVReadHash{} }
 func (m *KVReadHash) String() string { return proto.CompactTextString(m) }
 func (*KVReadHash) ProtoMessage()    {}
 func (*KVReadHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{5}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{7}
 }
 func (m *KVReadHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVReadHash.Unmarshal(m, b)
@@ -350,7 +473,7 @@ func (m *KVWriteHash) Reset()         { *m = KVWriteHash{} }
 func (m *KVWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVWriteHash) ProtoMessage()    {}
 func (*KVWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{6}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{8}
 }
 func (m *KVWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVWriteHash.Unmarshal(m, b)
@@ -404,7 +527,7 @@ func (m *KVMetadataWriteHash) Reset()         { *m = KVMetadataWriteHash{} }
 func (m *KVMetadataWriteHash) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataWriteHash) ProtoMessage()    {}
 func (*KVMetadataWriteHash) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{7}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{9}
 }
 func (m *KVMetadataWriteHash) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataWriteHash.Unmarshal(m, b)
@@ -451,7 +574,7 @@ func (m *KVMetadataEntry) Reset()         { *m = KVMetadataEntry{} }
 func (m *KVMetadataEntry) String() string { return proto.CompactTextString(m) }
 func (*KVMetadataEntry) ProtoMessage()    {}
 func (*KVMetadataEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{8}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{10}
 }
 func (m *KVMetadataEntry) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KVMetadataEntry.Unmarshal(m, b)
@@ -501,7 +624,7 @@ func (m *Version) Reset()         { *m = Version{} }
 func (m *Version) String() string { return proto.CompactTextString(m) }
 func (*Version) ProtoMessage()    {}
 func (*Version) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{9}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{11}
 }
 func (m *Version) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Version.Unmarshal(m, b)
@@ -558,7 +681,7 @@ func (m *RangeQueryInfo) Reset()         { *m = RangeQueryInfo{} }
 func (m *RangeQueryInfo) String() string { return proto.CompactTextString(m) }
 func (*RangeQueryInfo) ProtoMessage()    {}
 func (*RangeQueryInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{10}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{12}
 }
 func (m *RangeQueryInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RangeQueryInfo.Unmarshal(m, b)
@@ -722,7 +845,7 @@ func (m *QueryReads) Reset()         { *m = QueryReads{} }
 func (m *QueryReads) String() string { return proto.CompactTextString(m) }
 func (*QueryReads) ProtoMessage()    {}
 func (*QueryReads) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{11}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{13}
 }
 func (m *QueryReads) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReads.Unmarshal(m, b)
@@ -767,7 +890,7 @@ func (m *QueryReadsMerkleSummary) Reset()         { *m = QueryReadsMerkleSummary
 func (m *QueryReadsMerkleSummary) String() string { return proto.CompactTextString(m) }
 func (*QueryReadsMerkleSummary) ProtoMessage()    {}
 func (*QueryReadsMerkleSummary) Descriptor() ([]byte, []int) {
-	return fileDescriptor_kv_rwset_b744a14a894993b5, []int{12}
+	return fileDescriptor_kv_rwset_9f9a6aff967468cc, []int{14}
 }
 func (m *QueryReadsMerkleSummary) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryReadsMerkleSummary.Unmarshal(m, b)
@@ -813,6 +936,8 @@ func init() {
 	proto.RegisterType((*HashedRWSet)(nil), "kvrwset.HashedRWSet")
 	proto.RegisterType((*KVRead)(nil), "kvrwset.KVRead")
 	proto.RegisterType((*KVWrite)(nil), "kvrwset.KVWrite")
+	proto.RegisterType((*KVIncrement)(nil), "kvrwset.KVIncrement")
+	proto.RegisterType((*KVRangeDelete)(nil), "kvrwset.KVRangeDelete")
 	proto.RegisterType((*KVMetadataWrite)(nil), "kvrwset.KVMetadataWrite")
 	proto.RegisterType((*KVReadHash)(nil), "kvrwset.KVReadHash")
 	proto.RegisterType((*KVWriteHash)(nil), "kvrwset.KVWriteHash")
@@ -825,56 +950,60 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_b744a14a894993b5)
-}
-
-var fileDescriptor_kv_rwset_b744a14a894993b5 = []byte{
-	// 740 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdf, 0x6b, 0xdb, 0x48,
-	0x10, 0x8e, 0x7f, 0xcb, 0x63, 0x3b, 0xf1, 0x6d, 0x72, 0x44, 0xc7, 0xdd, 0x81, 0x51, 0x38, 0x30,
-	0x79, 0xb0, 0xc1, 0x07, 0xc7, 0x85, 0xe3, 0x1e, 0x5a, 0xe2, 0x92, 0x92, 0x26, 0xd0, 0x0d, 0x24,
-	0xd0, 0x17, 0xb1, 0x8e, 0x26, 0xb6, 0xb0, 0x25, 0xa5, 0xab, 0x95, 0x6d, 0x3d, 0x95, 0xfe, 0x75,
-	0xfd, 0x47, 0xfa, 0x87, 0x94, 0x9d, 0x95, 0x63, 0xc5, 0x75, 0x0c, 0xed, 0x93, 0xb5, 0xf3, 0xcd,
-	0x37, 0x3b, 0xdf, 0x37, 0xde, 0x5d, 0x38, 0x99, 0xa1, 0x37, 0x46, 0xd9, 0x97, 0x8b, 0x18, 0x55,
-	0x7f, 0x3a, 0x5f, 0xfd, 0xba, 0xf4, 0xd1, 0x7b, 0x94, 0x91, 0x8a, 0x58, 0x2d, 0x8b, 0x3b, 0x5f,
-	0x0b, 0x50, 0xbb, 0xbc, 0xe5, 0x77, 0x37, 0xa8, 0xd8, 0x5f, 0x50, 0x91, 0x28, 0xbc, 0xd8, 0x2e,
-	0x74, 0x4a, 0xdd, 0xc6, 0xe0, 0xa0, 0x97, 0x25, 0xf5, 0x2e, 0x6f, 0x39, 0x0a, 0x8f, 0x1b, 0x94,
-	0x0d, 0x81, 0x49, 0x11, 0x8e, 0xd1, 0xfd, 0x98, 0xa0, 0xf4, 0x31, 0x76, 0xfd, 0xf0, 0x21, 0xb2,
-	0x8b, 0xc4, 0x39, 0x7e, 0xe2, 0x70, 0x9d, 0xf2, 0x3e, 0x41, 0x99, 0xbe, 0x0d, 0x1f, 0x22, 0xde,
-	0x96, 0xab, 0xb5, 0x8f, 0xb1, 0x8e, 0xb0, 0x2e, 0x54, 0x17, 0xd2, 0x57, 0x18, 0xdb, 0x25, 0xa2,
-	0xb6, 0x73, 0xdb, 0xdd, 0x69, 0x80, 0x67, 0x38, 0x7b, 0x05, 0x07, 0x01, 0x2a, 0xe1, 0x09, 0x25,
-	0xdc, 0x8c, 0x52, 0x26, 0x8a, 0x9d, 0xa3, 0x5c, 0x65, 0x19, 0x86, 0xba, 0x1f, 0xe4, 0x97, 0xb1,
-	0xf3, 0xa5, 0x00, 0x8d, 0x0b, 0x11, 0x4f, 0xd0, 0x33, 0x52, 0xff, 0x81, 0xe6, 0x84, 0x96, 0x6e,
-	0x5e, 0xf1, 0xe1, 0x86, 0x62, 0xcd, 0xe0, 0x0d, 0x93, 0xc8, 0x49, 0xfb, 0x19, 0xb4, 0x32, 0x5e,
-	0xd6, 0x88, 0x91, 0x7d, 0xb4, 0xd9, 0x3b, 0x31, 0xb3, 0x2d, 0x4c, 0x0b, 0x6c, 0xf8, 0xbd, 0x0a,
-	0x23, 0xfc, 0x8f, 0x97, 0x54, 0x50, 0x91, 0x4d, 0x25, 0x6f, 0xa0, 0x6a, 0x9a, 0x63, 0x6d, 0x28,
-	0x4d, 0x31, 0xb5, 0x0b, 0x9d, 0x42, 0xb7, 0xce, 0xf5, 0x27, 0x3b, 0x85, 0xda, 0x1c, 0x65, 0xec,
-	0x47, 0xa1, 0x5d, 0xec, 0x14, 0x9e, 0x79, 0x7a, 0x6b, 0xe2, 0x7c, 0x95, 0xe0, 0x5c, 0xeb, 0xb9,
-	0x53, 0xcd, 0x2d, 0x85, 0x7e, 0x87, 0xba, 0x1f, 0xbb, 0x1e, 0xce, 0x50, 0x21, 0x95, 0xb2, 0xb8,
-	0xe5, 0xc7, 0xe7, 0xb4, 0x66, 0x47, 0x50, 0x99, 0x8b, 0x59, 0x82, 0x76, 0xa9, 0x53, 0xe8, 0x36,
-	0xb9, 0x59, 0x38, 0x77, 0x70, 0xb0, 0xd1, 0xfe, 0x96, 0xba, 0x03, 0xa8, 0x61, 0xa8, 0xf4, 0x5f,
-	0x20, 0x33, 0x6e, 0xdb, 0x04, 0x87, 0xa1, 0x92, 0x29, 0x5f, 0x25, 0x3a, 0x37, 0x00, 0xeb, 0x69,
-	0xb0, 0xdf, 0xc0, 0x9a, 0x62, 0xea, 0x6a, 0x67, 0xa9, 0x70, 0x93, 0xd7, 0xa6, 0x98, 0x12, 0xf4,
-	0x23, 0xea, 0x3d, 0x68, 0xe4, 0x26, 0xb5, 0xab, 0xea, 0x4e, 0x2b, 0xfe, 0x04, 0x20, 0xf5, 0x86,
-	0x69, 0xfc, 0xa8, 0x53, 0x44, 0x73, 0x1d, 0x0f, 0x0e, 0xb7, 0x8c, 0x74, 0xd7, 0x6e, 0x3f, 0x63,
-	0xd0, 0x7f, 0x79, 0xe7, 0x09, 0x63, 0x0c, 0xca, 0xa1, 0x08, 0x30, 0xb3, 0x9e, 0xbe, 0xd7, 0x63,
-	0x2b, 0xe6, 0xc7, 0xf6, 0x3f, 0xd4, 0x32, 0x73, 0xb4, 0xd2, 0xd1, 0x2c, 0xba, 0x9f, 0xba, 0x61,
-	0x12, 0x10, 0xb3, 0xcc, 0x2d, 0x0a, 0x5c, 0x27, 0x01, 0xfb, 0x15, 0xaa, 0x6a, 0x49, 0x48, 0x91,
-	0x90, 0x8a, 0x5a, 0x5e, 0x27, 0x81, 0xf3, 0xb9, 0x08, 0xfb, 0xcf, 0x4f, 0xba, 0x2e, 0x13, 0x2b,
-	0x21, 0x95, 0xbb, 0x9e, 0xbd, 0x45, 0x81, 0x4b, 0x4c, 0xd9, 0xb1, 0xd6, 0xe7, 0x11, 0x54, 0x24,
-	0xa8, 0x8a, 0xa1, 0xa7, 0x81, 0x13, 0x68, 0xf9, 0x4a, 0xba, 0xb8, 0x9c, 0x88, 0x24, 0x56, 0xe8,
-	0x91, 0x99, 0x16, 0x6f, 0xfa, 0x4a, 0x0e, 0x57, 0x31, 0x36, 0x80, 0xba, 0x14, 0x8b, 0xec, 0xc8,
-	0x96, 0x69, 0xc6, 0xeb, 0x23, 0x4b, 0x1d, 0xd0, 0x29, 0xbd, 0xd8, 0xe3, 0x96, 0x14, 0x0b, 0x73,
-	0x62, 0x39, 0x1c, 0x52, 0xbe, 0x1b, 0xa0, 0x9c, 0xce, 0xcc, 0xa4, 0x30, 0xb6, 0x2b, 0xc4, 0xee,
-	0x6c, 0x61, 0x5f, 0x51, 0xde, 0x4d, 0x12, 0x04, 0x42, 0xa6, 0x17, 0x7b, 0xfc, 0x17, 0xb9, 0x8e,
-	0xd2, 0x15, 0x12, 0xbf, 0x6e, 0x02, 0x98, 0x9a, 0xfa, 0xe6, 0x73, 0xfe, 0x05, 0x58, 0xb3, 0xd9,
-	0x29, 0x58, 0xfa, 0xae, 0xdd, 0x75, 0x8f, 0xd6, 0xa6, 0x73, 0xca, 0x75, 0x3e, 0xc1, 0xf1, 0x0b,
-	0xfb, 0xea, 0x7f, 0x56, 0x20, 0x96, 0xae, 0x87, 0x63, 0x89, 0x66, 0x8e, 0x2d, 0x5e, 0x0f, 0xc4,
-	0xf2, 0x9c, 0x02, 0xda, 0x64, 0x0d, 0xcf, 0x70, 0x8e, 0x33, 0x72, 0xb2, 0xc5, 0xad, 0x40, 0x2c,
-	0xdf, 0xe9, 0x35, 0xeb, 0x42, 0xfb, 0x09, 0x5c, 0xe9, 0xd5, 0x57, 0x4d, 0x93, 0xef, 0xaf, 0x72,
-	0x32, 0x21, 0x11, 0x0c, 0x22, 0x39, 0xee, 0x4d, 0xd2, 0x47, 0x94, 0xe6, 0xd9, 0xe8, 0x3d, 0x88,
-	0x91, 0xf4, 0xef, 0xcd, 0x33, 0x11, 0xf7, 0xb2, 0xa0, 0x69, 0x3f, 0x93, 0xf1, 0xe1, 0x6c, 0xec,
-	0xab, 0x49, 0x32, 0xea, 0xdd, 0x47, 0x41, 0x3f, 0x47, 0xed, 0x1b, 0x6a, 0xdf, 0x50, 0xfb, 0xdb,
-	0x9e, 0xa1, 0x51, 0x95, 0xc0, 0xbf, 0xbf, 0x05, 0x00, 0x00, 0xff, 0xff, 0xe3, 0xe1, 0xb5, 0x07,
-	0xa5, 0x06, 0x00, 0x00,
+	proto.RegisterFile("ledger/rwset/kvrwset/kv_rwset.proto", fileDescriptor_kv_rwset_9f9a6aff967468cc)
+}
+
+var fileDescriptor_kv_rwset_9f9a6aff967468cc = []byte{
+	// 802 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x9d, 0x55, 0x6d, 0x4b, 0x1b, 0x41,
+	0x10, 0x6e, 0xde, 0x2f, 0x93, 0xa4, 0xa6, 0xa7, 0xad, 0x29, 0x6d, 0x41, 0x4e, 0x0a, 0xa1, 0x1f,
+	0x12, 0xb0, 0x2f, 0x54, 0xa4, 0x1f, 0x5a, 0x4c, 0x51, 0xac, 0x42, 0x57, 0x50, 0xe8, 0x97, 0x63,
+	0x93, 0x5b, 0x93, 0x23, 0xb9, 0x3b, 0xbb, 0xbb, 0x89, 0xc9, 0xa7, 0xd2, 0x5f, 0xd7, 0x9f, 0xd0,
+	0xbf, 0xd3, 0xdd, 0xd9, 0x8d, 0x39, 0x35, 0x06, 0xea, 0xa7, 0xdc, 0x3c, 0x33, 0xcf, 0xec, 0xec,
+	0x33, 0x93, 0x59, 0xd8, 0x1e, 0xb1, 0xa0, 0xcf, 0x78, 0x9b, 0x5f, 0x09, 0x26, 0xdb, 0xc3, 0xc9,
+	0xfc, 0xd7, 0xc7, 0x8f, 0xd6, 0x25, 0x4f, 0x64, 0xe2, 0x96, 0x2c, 0xee, 0xfd, 0xcd, 0x42, 0xe9,
+	0xe8, 0x8c, 0x9c, 0x9f, 0x32, 0xe9, 0xbe, 0x86, 0x02, 0x67, 0x34, 0x10, 0x8d, 0xcc, 0x56, 0xae,
+	0x59, 0xd9, 0x59, 0x6b, 0xd9, 0xa0, 0x96, 0x0a, 0x50, 0x38, 0x31, 0x5e, 0xb7, 0x03, 0x2e, 0xa7,
+	0x71, 0x9f, 0xf9, 0x3f, 0xc7, 0x8c, 0x87, 0x4c, 0xf8, 0x61, 0x7c, 0x91, 0x34, 0xb2, 0xc8, 0xd9,
+	0xbc, 0xe6, 0x10, 0x1d, 0xf2, 0x5d, 0x45, 0xcc, 0x0e, 0x95, 0x9b, 0xd4, 0xf9, 0xdc, 0x56, 0x0c,
+	0x8d, 0xb8, 0x4d, 0x28, 0x5e, 0xf1, 0x50, 0x32, 0xd1, 0xc8, 0x21, 0xb5, 0x9e, 0x3a, 0xee, 0x5c,
+	0x3b, 0x88, 0xf5, 0xbb, 0x9f, 0x61, 0x2d, 0x62, 0x92, 0x06, 0x54, 0x52, 0xdf, 0x52, 0xf2, 0x48,
+	0x69, 0xa4, 0x28, 0xc7, 0x36, 0xc2, 0x50, 0x1f, 0x47, 0x69, 0x53, 0xb8, 0xef, 0x00, 0xc2, 0xb8,
+	0xc7, 0x59, 0xc4, 0x62, 0x29, 0x1a, 0x05, 0x64, 0x6f, 0xa4, 0xd8, 0x87, 0x73, 0x27, 0x49, 0xc5,
+	0xb9, 0x7b, 0x50, 0x33, 0x37, 0x0d, 0xd8, 0x88, 0xe9, 0x63, 0x8b, 0x48, 0x7c, 0x96, 0x16, 0x46,
+	0xfb, 0xf7, 0xd1, 0x4d, 0xaa, 0x7c, 0x61, 0x08, 0xef, 0x4f, 0x06, 0x2a, 0x07, 0x54, 0x0c, 0x58,
+	0x60, 0xd4, 0xfd, 0x00, 0xd5, 0x01, 0x9a, 0x7e, 0x5a, 0xe4, 0xf5, 0x5b, 0x22, 0x6b, 0x06, 0xa9,
+	0x98, 0x40, 0x82, 0x72, 0xef, 0x42, 0xcd, 0xf2, 0xec, 0xdd, 0xb3, 0x77, 0xaa, 0xc7, 0x4b, 0x22,
+	0xd3, 0x1e, 0x61, 0x6f, 0xdd, 0xb9, 0x2b, 0x9c, 0xd1, 0xfa, 0xe5, 0x7d, 0xc2, 0x61, 0x92, 0x5b,
+	0xe2, 0x79, 0x5f, 0xa1, 0x68, 0x8a, 0x73, 0xeb, 0x90, 0x1b, 0xb2, 0x99, 0x2a, 0x3d, 0xd3, 0x2c,
+	0x13, 0xfd, 0xe9, 0xbe, 0x81, 0xd2, 0x84, 0x71, 0x11, 0x26, 0xb1, 0xaa, 0x2b, 0x73, 0xa3, 0x8d,
+	0x67, 0x06, 0x27, 0xf3, 0x00, 0xef, 0x44, 0x8f, 0x1a, 0xe6, 0x5c, 0x92, 0xe8, 0x05, 0x94, 0x43,
+	0x61, 0x85, 0xc6, 0x54, 0x0e, 0x71, 0x42, 0x61, 0xc4, 0x74, 0x37, 0xa0, 0x30, 0xa1, 0xa3, 0x31,
+	0x53, 0xe5, 0x67, 0x9a, 0x55, 0x62, 0x0c, 0xef, 0x3d, 0x54, 0x52, 0x9d, 0x5b, 0x92, 0x53, 0xd1,
+	0x54, 0x42, 0x49, 0x31, 0x5f, 0x8e, 0x18, 0xc3, 0xeb, 0x42, 0xed, 0x46, 0xdf, 0xf4, 0xd1, 0x42,
+	0x52, 0x2e, 0xfd, 0x05, 0xdd, 0x41, 0xe0, 0x48, 0xe5, 0xd8, 0x84, 0x12, 0x8b, 0x03, 0x74, 0x65,
+	0xd1, 0x55, 0x54, 0xe6, 0x91, 0x29, 0x58, 0x81, 0xc2, 0xd7, 0x8a, 0xdb, 0xba, 0x1c, 0x0d, 0x68,
+	0x09, 0xbd, 0x73, 0x58, 0xbb, 0xa5, 0xec, 0x92, 0xf2, 0x76, 0x74, 0x6a, 0xa9, 0xff, 0x10, 0xb6,
+	0xa7, 0xcb, 0xe6, 0xb9, 0xa3, 0x22, 0x66, 0x64, 0x1e, 0xe8, 0x9d, 0x02, 0x2c, 0x06, 0xc5, 0x7d,
+	0x0e, 0xfa, 0x48, 0x53, 0x42, 0x06, 0x4b, 0x28, 0x29, 0x1b, 0x5d, 0xff, 0xd3, 0x98, 0x40, 0x0b,
+	0x79, 0xdd, 0xff, 0x55, 0x59, 0x57, 0x76, 0xe9, 0x15, 0x00, 0x36, 0x26, 0x2d, 0x49, 0x19, 0x11,
+	0xd4, 0x24, 0x80, 0xf5, 0x25, 0xd3, 0xb6, 0xea, 0xb4, 0x87, 0x08, 0xb4, 0x97, 0x56, 0x1e, 0x7d,
+	0xae, 0x0b, 0xf9, 0x98, 0x46, 0xcc, 0x4a, 0x8f, 0xdf, 0x8b, 0x89, 0xca, 0xa6, 0x27, 0xea, 0x13,
+	0x94, 0xac, 0x38, 0xfa, 0xa6, 0xdd, 0x51, 0xd2, 0x1b, 0xfa, 0xf1, 0x38, 0x42, 0x66, 0x9e, 0x38,
+	0x08, 0x9c, 0x8c, 0x23, 0xf7, 0x29, 0x14, 0xe5, 0x14, 0x3d, 0x59, 0xf4, 0x14, 0xe4, 0x54, 0xc1,
+	0xde, 0xef, 0x2c, 0x3c, 0xbe, 0xb9, 0xf7, 0x1e, 0x38, 0x5b, 0xdb, 0x50, 0x0b, 0x25, 0xf7, 0xd9,
+	0x74, 0x40, 0xc7, 0x42, 0xb2, 0x00, 0xc5, 0x74, 0x48, 0x55, 0x81, 0x9d, 0x39, 0xa6, 0xd4, 0x29,
+	0x73, 0x7a, 0x65, 0xb7, 0x49, 0x1e, 0x7b, 0xbc, 0xd8, 0x26, 0x58, 0x01, 0x2e, 0x90, 0x83, 0x47,
+	0xc4, 0x51, 0x71, 0x66, 0x99, 0x10, 0x58, 0xc7, 0x78, 0x3f, 0x62, 0x7c, 0x38, 0x32, 0x9d, 0x62,
+	0x7a, 0x21, 0x6a, 0xf6, 0xd6, 0x12, 0xf6, 0x31, 0xc6, 0x9d, 0x8e, 0xa3, 0x88, 0xf2, 0x99, 0x4a,
+	0xf5, 0x84, 0x2f, 0x50, 0xdc, 0x6e, 0xe2, 0x4b, 0x15, 0xc0, 0xe4, 0xd4, 0xef, 0x80, 0xf7, 0x11,
+	0x60, 0xc1, 0x56, 0x53, 0xe8, 0xe8, 0x97, 0x67, 0xd5, 0xab, 0xa2, 0x9e, 0x22, 0x8c, 0xf5, 0x7e,
+	0xc1, 0xe6, 0x3d, 0xe7, 0xea, 0xc9, 0x8a, 0xe8, 0x54, 0xcd, 0x5d, 0x9f, 0x33, 0xd3, 0xc7, 0x1a,
+	0x29, 0x2b, 0x64, 0x1f, 0x01, 0x2d, 0xb2, 0x76, 0x8f, 0xd8, 0x84, 0x8d, 0x50, 0xc9, 0x1a, 0x71,
+	0x14, 0xf0, 0x4d, 0xdb, 0xea, 0x9d, 0xa9, 0x5f, 0x3b, 0xe7, 0xf7, 0xd5, 0x5b, 0xb0, 0xaa, 0xf6,
+	0x9c, 0x8d, 0xb1, 0x17, 0x49, 0x60, 0x27, 0xe1, 0xfd, 0xd6, 0x60, 0x76, 0xc9, 0xb8, 0x79, 0x44,
+	0x5b, 0x17, 0xb4, 0xcb, 0xc3, 0x9e, 0x79, 0x34, 0x45, 0xcb, 0x82, 0xa6, 0x7c, 0x7b, 0x8d, 0x1f,
+	0xbb, 0xfd, 0x50, 0x0e, 0xc6, 0xdd, 0x56, 0x2f, 0x89, 0xda, 0x29, 0x6a, 0xdb, 0x50, 0xdb, 0x86,
+	0xda, 0x5e, 0xf6, 0x28, 0x77, 0x8b, 0xe8, 0x7c, 0xfb, 0x0f, 0xe6, 0x2a, 0x87, 0x8c, 0xb3, 0x07,
+	0x00, 0x00,
 }
@@ -36,7 +36,7 @@ func (m *MSPConfig) Reset()         { *m = MSPConfig{} }
 func (m *MSPConfig) String() string { return proto.CompactTextString(m) }
 func (*MSPConfig) ProtoMessage()    {}
 func (*MSPConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{0}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{0}
 }
 func (m *MSPConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_MSPConfig.Unmarshal(m, b)
@@ -121,17 +121,21 @@ type FabricMSPConfig struct {
 	TlsIntermediateCerts [][]byte `protobuf:"bytes,10,rep,name=tls_intermediate_certs,json=tlsIntermediateCerts,proto3" json:"tls_intermediate_certs,omitempty"`
 	// fabric_node_ous contains the configuration to distinguish clients from peers from orderers
 	// based on the OUs.
-	FabricNodeOus        *FabricNodeOUs `protobuf:"bytes,11,opt,name=fabric_node_ous,json=fabricNodeOus,proto3" json:"fabric_node_ous,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	FabricNodeOus *FabricNodeOUs `protobuf:"bytes,11,opt,name=fabric_node_ous,json=fabricNodeOus,proto3" json:"fabric_node_ous,omitempty"`
+	// additional_signing_identities are the other signing identities of a local
+	// MSP, such as the next identity of a certificate rotation. The MSP signs
+	// with the valid signing identity issued last
+	AdditionalSigningIdentities []*SigningIdentityInfo `protobuf:"bytes,12,rep,name=additional_signing_identities,json=additionalSigningIdentities,proto3" json:"additional_signing_identities,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{}               `json:"-"`
+	XXX_unrecognized            []byte                 `json:"-"`
+	XXX_sizecache               int32                  `json:"-"`
 }
 
 func (m *FabricMSPConfig) Reset()         { *m = FabricMSPConfig{} }
 func (m *FabricMSPConfig) String() string { return proto.CompactTextString(m) }
 func (*FabricMSPConfig) ProtoMessage()    {}
 func (*FabricMSPConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{1}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{1}
 }
 func (m *FabricMSPConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricMSPConfig.Unmarshal(m, b)
@@ -228,6 +232,13 @@ func (m *FabricMSPConfig) GetFabricNodeOus() *FabricNodeOUs {
 	return nil
 }
 
+func (m *FabricMSPConfig) GetAdditionalSigningIdentities() []*SigningIdentityInfo {
+	if m != nil {
+		return m.AdditionalSigningIdentities
+	}
+	return nil
+}
+
 // FabricCryptoConfig contains configuration parameters
 // for the cryptographic algorithms used by the MSP
 // this configuration refers to
@@ -249,7 +260,7 @@ func (m *FabricCryptoConfig) Reset()         { *m = FabricCryptoConfig{} }
 func (m *FabricCryptoConfig) String() string { return proto.CompactTextString(m) }
 func (*FabricCryptoConfig) ProtoMessage()    {}
 func (*FabricCryptoConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{2}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{2}
 }
 func (m *FabricCryptoConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricCryptoConfig.Unmarshal(m, b)
@@ -305,7 +316,7 @@ func (m *IdemixMSPConfig) Reset()         { *m = IdemixMSPConfig{} }
 func (m *IdemixMSPConfig) String() string { return proto.CompactTextString(m) }
 func (*IdemixMSPConfig) ProtoMessage()    {}
 func (*IdemixMSPConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{3}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{3}
 }
 func (m *IdemixMSPConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdemixMSPConfig.Unmarshal(m, b)
@@ -383,7 +394,7 @@ func (m *IdemixMSPSignerConfig) Reset()         { *m = IdemixMSPSignerConfig{} }
 func (m *IdemixMSPSignerConfig) String() string { return proto.CompactTextString(m) }
 func (*IdemixMSPSignerConfig) ProtoMessage()    {}
 func (*IdemixMSPSignerConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{4}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{4}
 }
 func (m *IdemixMSPSignerConfig) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_IdemixMSPSignerConfig.Unmarshal(m, b)
@@ -465,7 +476,7 @@ func (m *SigningIdentityInfo) Reset()         { *m = SigningIdentityInfo{} }
 func (m *SigningIdentityInfo) String() string { return proto.CompactTextString(m) }
 func (*SigningIdentityInfo) ProtoMessage()    {}
 func (*SigningIdentityInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{5}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{5}
 }
 func (m *SigningIdentityInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SigningIdentityInfo.Unmarshal(m, b)
@@ -520,7 +531,7 @@ func (m *KeyInfo) Reset()         { *m = KeyInfo{} }
 func (m *KeyInfo) String() string { return proto.CompactTextString(m) }
 func (*KeyInfo) ProtoMessage()    {}
 func (*KeyInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{6}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{6}
 }
 func (m *KeyInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_KeyInfo.Unmarshal(m, b)
@@ -577,7 +588,7 @@ func (m *FabricOUIdentifier) Reset()         { *m = FabricOUIdentifier{} }
 func (m *FabricOUIdentifier) String() string { return proto.CompactTextString(m) }
 func (*FabricOUIdentifier) ProtoMessage()    {}
 func (*FabricOUIdentifier) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{7}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{7}
 }
 func (m *FabricOUIdentifier) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricOUIdentifier.Unmarshal(m, b)
@@ -630,7 +641,7 @@ func (m *FabricNodeOUs) Reset()         { *m = FabricNodeOUs{} }
 func (m *FabricNodeOUs) String() string { return proto.CompactTextString(m) }
 func (*FabricNodeOUs) ProtoMessage()    {}
 func (*FabricNodeOUs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_msp_config_e749e5bd1d6d997b, []int{8}
+	return fileDescriptor_msp_config_88205c70379dbcf6, []int{8}
 }
 func (m *FabricNodeOUs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FabricNodeOUs.Unmarshal(m, b)
@@ -683,61 +694,63 @@ func init() {
 	proto.RegisterType((*FabricNodeOUs)(nil), "msp.FabricNodeOUs")
 }
 
-func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor_msp_config_e749e5bd1d6d997b) }
+func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor_msp_config_88205c70379dbcf6) }
 
-var fileDescriptor_msp_config_e749e5bd1d6d997b = []byte{
-	// 847 bytes of a gzipped FileDescriptorProto
+var fileDescriptor_msp_config_88205c70379dbcf6 = []byte{
+	// 869 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x5f, 0x6f, 0xe3, 0x44,
-	0x10, 0x57, 0x92, 0x26, 0x77, 0x99, 0x38, 0x49, 0xd9, 0xeb, 0x15, 0x0b, 0x71, 0x77, 0xa9, 0x01,
-	0x91, 0x17, 0x52, 0xa9, 0x87, 0x84, 0x84, 0x78, 0xba, 0xc2, 0x09, 0x03, 0xa5, 0xd5, 0x56, 0x7d,
-	0xe1, 0xc5, 0xda, 0xd8, 0x9b, 0x64, 0x65, 0x7b, 0xd7, 0xda, 0x5d, 0x9f, 0x08, 0xe2, 0x99, 0x2f,
-	0xc0, 0x77, 0xe0, 0x99, 0x57, 0xbe, 0x1d, 0xda, 0x3f, 0x8d, 0x9d, 0x6b, 0x15, 0x78, 0x9b, 0x9d,
-	0xf9, 0xcd, 0xcf, 0xb3, 0xbf, 0x99, 0x59, 0xc3, 0x49, 0xa9, 0xaa, 0xf3, 0x52, 0x55, 0x49, 0x2a,
-	0xf8, 0x8a, 0xad, 0x17, 0x95, 0x14, 0x5a, 0xa0, 0x5e, 0xa9, 0xaa, 0xe8, 0x2b, 0x18, 0x5e, 0xdd,
-	0xde, 0x5c, 0x5a, 0x3f, 0x42, 0x70, 0xa4, 0xb7, 0x15, 0x0d, 0x3b, 0xb3, 0xce, 0xbc, 0x8f, 0xad,
-	0x8d, 0x4e, 0x61, 0xe0, 0xb2, 0xc2, 0xee, 0xac, 0x33, 0x0f, 0xb0, 0x3f, 0x45, 0x7f, 0x1f, 0xc1,
-	0xf4, 0x2d, 0x59, 0x4a, 0x96, 0xee, 0xe5, 0x73, 0x52, 0xba, 0xfc, 0x21, 0xb6, 0x36, 0x7a, 0x01,
-	0x20, 0x85, 0xd0, 0x49, 0x4a, 0xa5, 0x56, 0x61, 0x77, 0xd6, 0x9b, 0x07, 0x78, 0x68, 0x3c, 0x97,
-	0xc6, 0x81, 0xbe, 0x00, 0xc4, 0xb8, 0xa6, 0xb2, 0xa4, 0x19, 0x23, 0x9a, 0x7a, 0x58, 0xcf, 0xc2,
-	0x3e, 0x68, 0x47, 0x1c, 0xfc, 0x14, 0x06, 0x24, 0x2b, 0x19, 0x57, 0xe1, 0x91, 0x85, 0xf8, 0x13,
-	0xfa, 0x1c, 0xa6, 0x92, 0xbe, 0x13, 0x29, 0xd1, 0x4c, 0xf0, 0xa4, 0x60, 0x4a, 0x87, 0x7d, 0x0b,
-	0x98, 0x34, 0xee, 0x9f, 0x98, 0xd2, 0xe8, 0x12, 0x8e, 0x15, 0x5b, 0x73, 0xc6, 0xd7, 0x09, 0xcb,
-	0x28, 0xd7, 0x4c, 0x6f, 0xc3, 0xc1, 0xac, 0x33, 0x1f, 0x5d, 0x84, 0x8b, 0x52, 0x55, 0x8b, 0x5b,
-	0x17, 0x8c, 0x7d, 0x2c, 0xe6, 0x2b, 0x81, 0xa7, 0x6a, 0xdf, 0x89, 0x12, 0x78, 0x25, 0xe4, 0x9a,
-	0x70, 0xf6, 0x9b, 0x25, 0x26, 0x45, 0x52, 0x73, 0xa6, 0x3d, 0xe1, 0x8a, 0x51, 0xa9, 0xc2, 0x27,
-	0xb3, 0xde, 0x7c, 0x74, 0xf1, 0xa1, 0xe5, 0x74, 0x32, 0x5d, 0xdf, 0xc5, 0xbb, 0x38, 0x7e, 0xb1,
-	0x9f, 0x7f, 0xc7, 0x99, 0x6e, 0xa2, 0x0a, 0x7d, 0x03, 0xe3, 0x54, 0x6e, 0x2b, 0x2d, 0x7c, 0xc7,
-	0xc2, 0xa7, 0xb6, 0xc4, 0x36, 0xdd, 0xa5, 0x8d, 0x3b, 0xe1, 0x71, 0x90, 0xb6, 0x4e, 0xe8, 0x53,
-	0x98, 0xe8, 0x42, 0x25, 0x2d, 0xd9, 0x87, 0x56, 0x8b, 0x40, 0x17, 0x0a, 0xef, 0x94, 0xff, 0x12,
-	0x4e, 0x0d, 0xea, 0x11, 0xf5, 0xc1, 0xa2, 0x4f, 0x74, 0xa1, 0xe2, 0x07, 0x0d, 0xf8, 0x1a, 0xa6,
-	0x2b, 0xfb, 0xfd, 0x84, 0x8b, 0x8c, 0x26, 0xa2, 0x56, 0xe1, 0xc8, 0xd6, 0x86, 0x5a, 0xb5, 0xfd,
-	0x2c, 0x32, 0x7a, 0x7d, 0xa7, 0xf0, 0x78, 0xd5, 0x1c, 0x6b, 0x15, 0xfd, 0xd9, 0x01, 0xf4, 0xb0,
-	0x78, 0x74, 0x01, 0xcf, 0x8d, 0xc0, 0x44, 0xd7, 0x92, 0x26, 0x1b, 0xa2, 0x36, 0xc9, 0x8a, 0x94,
-	0xac, 0xd8, 0xfa, 0x31, 0x7a, 0xb6, 0x0b, 0x7e, 0x4f, 0xd4, 0xe6, 0xad, 0x0d, 0xa1, 0x18, 0xce,
-	0xee, 0xdb, 0xd7, 0x92, 0xdd, 0x67, 0xd7, 0x3c, 0x35, 0xb2, 0xda, 0x81, 0x1d, 0xe2, 0x97, 0xf7,
-	0xc0, 0x46, 0x60, 0x4b, 0xe4, 0x51, 0xd1, 0x5f, 0x1d, 0x98, 0xc6, 0x19, 0x2d, 0xd9, 0xaf, 0x87,
-	0x07, 0xf9, 0x18, 0x7a, 0xac, 0xca, 0xfd, 0x16, 0x18, 0x13, 0x5d, 0xc0, 0xc0, 0xd4, 0x46, 0x65,
-	0xd8, 0xb3, 0x12, 0x7c, 0x64, 0x25, 0xd8, 0x71, 0xdd, 0xda, 0x98, 0xef, 0x90, 0x47, 0xa2, 0x4f,
-	0x60, 0xdc, 0x1a, 0xd4, 0x2a, 0x0f, 0x8f, 0x2c, 0x5f, 0xd0, 0x38, 0x6f, 0x72, 0x74, 0x02, 0x7d,
-	0x5a, 0x89, 0x74, 0x13, 0xf6, 0x67, 0x9d, 0x79, 0x0f, 0xbb, 0x43, 0xf4, 0x47, 0x17, 0x9e, 0x3f,
-	0x4a, 0x6e, 0xca, 0x4d, 0x25, 0xcd, 0x6c, 0xb9, 0x01, 0xb6, 0x36, 0x9a, 0x40, 0x57, 0xdd, 0x57,
-	0xdb, 0x55, 0x39, 0xfa, 0x16, 0x5e, 0x1e, 0x9e, 0x59, 0x7b, 0x89, 0x21, 0xfe, 0xf8, 0xd0, 0x64,
-	0x9a, 0x2f, 0x49, 0x51, 0x50, 0x5b, 0x75, 0x1f, 0x5b, 0xdb, 0x5c, 0x89, 0x72, 0x29, 0x8a, 0xa2,
-	0xa4, 0xdc, 0x10, 0xda, 0xaa, 0x87, 0x38, 0x68, 0x9c, 0x71, 0x86, 0x7e, 0x80, 0x33, 0x53, 0x96,
-	0x21, 0x22, 0x45, 0xd2, 0x92, 0x80, 0xf1, 0x95, 0x90, 0xa5, 0xb5, 0xed, 0x22, 0x06, 0xf8, 0x55,
-	0x03, 0xc4, 0x3b, 0x5c, 0xdc, 0xc0, 0x22, 0x01, 0xcf, 0x1e, 0x59, 0x53, 0x53, 0x47, 0x55, 0x2f,
-	0x0b, 0x96, 0x26, 0xbe, 0x2b, 0x4e, 0x8e, 0xc0, 0x39, 0x9d, 0x60, 0xe8, 0x35, 0x4c, 0x2a, 0xc9,
-	0xde, 0x99, 0x61, 0xf7, 0xa8, 0xae, 0xed, 0x5d, 0x60, 0x7b, 0xf7, 0x23, 0x75, 0x1b, 0x3f, 0xf6,
-	0x18, 0x97, 0x14, 0xdd, 0xc2, 0x13, 0x1f, 0x41, 0x9f, 0xc1, 0x24, 0xa7, 0xed, 0x99, 0xf3, 0x33,
-	0x32, 0xce, 0x69, 0x6b, 0xc0, 0xd0, 0x19, 0x04, 0x06, 0x56, 0x12, 0x4d, 0x25, 0x23, 0x85, 0xef,
-	0xc3, 0x28, 0xa7, 0xdb, 0x2b, 0xef, 0x8a, 0x7e, 0xbf, 0x5f, 0x86, 0xf6, 0xc3, 0x80, 0x66, 0x30,
-	0x32, 0x4b, 0xc8, 0x56, 0x2c, 0x25, 0x9a, 0xfa, 0x2b, 0xb4, 0x5d, 0xff, 0xa3, 0x91, 0xdd, 0xff,
-	0x6e, 0x64, 0xf4, 0x4f, 0x07, 0xc6, 0x7b, 0xcb, 0x6a, 0x9e, 0x56, 0xca, 0xc9, 0xb2, 0x70, 0x1f,
-	0x7d, 0x8a, 0xfd, 0x09, 0xc5, 0x70, 0x92, 0x16, 0xcc, 0xb4, 0x56, 0xd4, 0xef, 0x7f, 0xe5, 0xc0,
-	0x0b, 0x87, 0x5c, 0xd2, 0x75, 0xdd, 0xba, 0xdc, 0x77, 0x80, 0x2a, 0x4a, 0xe5, 0x7b, 0x44, 0xbd,
-	0xc3, 0x44, 0xc7, 0x26, 0xa5, 0x4d, 0xf3, 0x26, 0x81, 0x33, 0x21, 0xd7, 0x8b, 0xcd, 0xb6, 0xa2,
-	0xb2, 0xa0, 0xd9, 0x9a, 0xca, 0x85, 0x7b, 0x68, 0xdc, 0x8f, 0x4d, 0x19, 0xa6, 0x37, 0xc7, 0x57,
-	0xaa, 0x72, 0xeb, 0x71, 0x43, 0xd2, 0x9c, 0xac, 0xe9, 0x2f, 0xf3, 0x35, 0xd3, 0x9b, 0x7a, 0xb9,
-	0x48, 0x45, 0x79, 0xde, 0xca, 0x3d, 0x77, 0xb9, 0xe7, 0x2e, 0xd7, 0xfc, 0x26, 0x97, 0x03, 0x6b,
-	0xbf, 0xfe, 0x37, 0x00, 0x00, 0xff, 0xff, 0x54, 0x67, 0x46, 0xdb, 0x38, 0x07, 0x00, 0x00,
+	0x10, 0x57, 0x92, 0x26, 0x77, 0x99, 0x38, 0x49, 0xd9, 0xeb, 0x15, 0x0b, 0xe8, 0x5d, 0x6a, 0x40,
+	0xe4, 0x85, 0x54, 0xea, 0x21, 0x21, 0x21, 0x9e, 0xae, 0x70, 0xc2, 0x40, 0x69, 0xb5, 0x55, 0x5f,
+	0x10, 0x92, 0xb5, 0xb1, 0x37, 0xc9, 0x2a, 0xf6, 0xae, 0xb5, 0xbb, 0xae, 0x08, 0xe2, 0x99, 0x47,
+	0x5e, 0xf8, 0x0e, 0x7c, 0x07, 0xbe, 0x1d, 0xda, 0x3f, 0x8d, 0x9d, 0xb6, 0x0a, 0xbc, 0xcd, 0xce,
+	0xfc, 0xe6, 0xb7, 0xe3, 0xf9, 0xcd, 0xac, 0xe1, 0xa8, 0x50, 0xe5, 0x59, 0xa1, 0xca, 0x24, 0x15,
+	0x7c, 0xc1, 0x96, 0xb3, 0x52, 0x0a, 0x2d, 0x50, 0xa7, 0x50, 0x65, 0xf4, 0x25, 0xf4, 0x2f, 0x6f,
+	0xae, 0x2f, 0xac, 0x1f, 0x21, 0x38, 0xd0, 0x9b, 0x92, 0x86, 0xad, 0x49, 0x6b, 0xda, 0xc5, 0xd6,
+	0x46, 0xc7, 0xd0, 0x73, 0x59, 0x61, 0x7b, 0xd2, 0x9a, 0x06, 0xd8, 0x9f, 0xa2, 0x3f, 0xbb, 0x30,
+	0x7e, 0x47, 0xe6, 0x92, 0xa5, 0x3b, 0xf9, 0x9c, 0x14, 0x2e, 0xbf, 0x8f, 0xad, 0x8d, 0x4e, 0x00,
+	0xa4, 0x10, 0x3a, 0x49, 0xa9, 0xd4, 0x2a, 0x6c, 0x4f, 0x3a, 0xd3, 0x00, 0xf7, 0x8d, 0xe7, 0xc2,
+	0x38, 0xd0, 0xe7, 0x80, 0x18, 0xd7, 0x54, 0x16, 0x34, 0x63, 0x44, 0x53, 0x0f, 0xeb, 0x58, 0xd8,
+	0x7b, 0xcd, 0x88, 0x83, 0x1f, 0x43, 0x8f, 0x64, 0x05, 0xe3, 0x2a, 0x3c, 0xb0, 0x10, 0x7f, 0x42,
+	0x9f, 0xc1, 0x58, 0xd2, 0x3b, 0x91, 0x12, 0xcd, 0x04, 0x4f, 0x72, 0xa6, 0x74, 0xd8, 0xb5, 0x80,
+	0x51, 0xed, 0xfe, 0x91, 0x29, 0x8d, 0x2e, 0xe0, 0x50, 0xb1, 0x25, 0x67, 0x7c, 0x99, 0xb0, 0x8c,
+	0x72, 0xcd, 0xf4, 0x26, 0xec, 0x4d, 0x5a, 0xd3, 0xc1, 0x79, 0x38, 0x2b, 0x54, 0x39, 0xbb, 0x71,
+	0xc1, 0xd8, 0xc7, 0x62, 0xbe, 0x10, 0x78, 0xac, 0x76, 0x9d, 0x28, 0x81, 0xd7, 0x42, 0x2e, 0x09,
+	0x67, 0xbf, 0x59, 0x62, 0x92, 0x27, 0x15, 0x67, 0xda, 0x13, 0x2e, 0x18, 0x95, 0x2a, 0x7c, 0x36,
+	0xe9, 0x4c, 0x07, 0xe7, 0xef, 0x5b, 0x4e, 0xd7, 0xa6, 0xab, 0xdb, 0x78, 0x1b, 0xc7, 0x27, 0xbb,
+	0xf9, 0xb7, 0x9c, 0xe9, 0x3a, 0xaa, 0xd0, 0xd7, 0x30, 0x4c, 0xe5, 0xa6, 0xd4, 0xc2, 0x2b, 0x16,
+	0x3e, 0x9f, 0xb4, 0x1e, 0xd0, 0x5d, 0xd8, 0xb8, 0x6b, 0x3c, 0x0e, 0xd2, 0xc6, 0x09, 0x7d, 0x02,
+	0x23, 0x9d, 0xab, 0xa4, 0xd1, 0xf6, 0xbe, 0xed, 0x45, 0xa0, 0x73, 0x85, 0xb7, 0x9d, 0xff, 0x02,
+	0x8e, 0x0d, 0xea, 0x89, 0xee, 0x83, 0x45, 0x1f, 0xe9, 0x5c, 0xc5, 0x8f, 0x04, 0xf8, 0x0a, 0xc6,
+	0x0b, 0x7b, 0x7f, 0xc2, 0x45, 0x46, 0x13, 0x51, 0xa9, 0x70, 0x60, 0x6b, 0x43, 0x8d, 0xda, 0x7e,
+	0x12, 0x19, 0xbd, 0xba, 0x55, 0x78, 0xb8, 0xa8, 0x8f, 0x95, 0x42, 0xbf, 0xc0, 0x09, 0xc9, 0x32,
+	0xe6, 0x5b, 0xf6, 0x40, 0x06, 0x46, 0x55, 0x18, 0x4c, 0x3a, 0x7b, 0x85, 0xf8, 0xb0, 0x4e, 0xdf,
+	0x0d, 0x33, 0xaa, 0xa2, 0xbf, 0x5a, 0x80, 0x1e, 0xb7, 0x06, 0x9d, 0xc3, 0x4b, 0x73, 0x13, 0xd1,
+	0x95, 0xa4, 0xc9, 0x8a, 0xa8, 0x55, 0xb2, 0x20, 0x05, 0xcb, 0x37, 0x7e, 0x48, 0x5f, 0x6c, 0x83,
+	0xdf, 0x11, 0xb5, 0x7a, 0x67, 0x43, 0x28, 0x86, 0xd3, 0xfb, 0xe1, 0x68, 0x88, 0xea, 0xb3, 0x2b,
+	0x9e, 0x9a, 0x12, 0xec, 0x3a, 0xf4, 0xf1, 0xab, 0x7b, 0x60, 0x2d, 0x9f, 0x25, 0xf2, 0xa8, 0xe8,
+	0xef, 0x16, 0x8c, 0xe3, 0x8c, 0x16, 0xec, 0xd7, 0xfd, 0x6b, 0x72, 0x08, 0x1d, 0x56, 0xae, 0xfd,
+	0x8e, 0x19, 0x13, 0x9d, 0x43, 0xcf, 0xd4, 0x46, 0x65, 0xd8, 0xb1, 0x0d, 0xfe, 0xc0, 0xb6, 0x65,
+	0xcb, 0x75, 0x63, 0x63, 0x5e, 0x7f, 0x8f, 0x44, 0x1f, 0xc3, 0xb0, 0xb1, 0x06, 0xe5, 0x3a, 0x3c,
+	0xb0, 0x7c, 0x41, 0xed, 0xbc, 0x5e, 0xa3, 0x23, 0xe8, 0xd2, 0x52, 0xa4, 0xab, 0xb0, 0x3b, 0x69,
+	0x4d, 0x3b, 0xd8, 0x1d, 0xa2, 0x3f, 0xda, 0xf0, 0xf2, 0x49, 0x72, 0x53, 0x6e, 0x2a, 0x69, 0x66,
+	0xcb, 0x0d, 0xb0, 0xb5, 0xd1, 0x08, 0xda, 0xea, 0xbe, 0xda, 0xb6, 0x5a, 0xa3, 0x6f, 0xe0, 0xd5,
+	0xfe, 0x8d, 0xb0, 0x1f, 0xd1, 0xc7, 0x1f, 0xed, 0x9b, 0x7b, 0x73, 0x93, 0x14, 0x39, 0xb5, 0x55,
+	0x77, 0xb1, 0xb5, 0xcd, 0x27, 0x51, 0x2e, 0x45, 0x9e, 0x17, 0x94, 0x1b, 0x42, 0x5b, 0x75, 0x1f,
+	0x07, 0xb5, 0x33, 0xce, 0xd0, 0xf7, 0x70, 0x6a, 0xca, 0x32, 0x44, 0x24, 0x4f, 0x1a, 0x2d, 0x60,
+	0x7c, 0x21, 0x64, 0x61, 0x6d, 0xbb, 0xe6, 0x01, 0x7e, 0x5d, 0x03, 0xf1, 0x16, 0x17, 0xd7, 0xb0,
+	0x48, 0xc0, 0x8b, 0x27, 0x66, 0xcf, 0xd4, 0x51, 0x56, 0xf3, 0x9c, 0xa5, 0x89, 0x57, 0xc5, 0xb5,
+	0x23, 0x70, 0x4e, 0xd7, 0x30, 0xf4, 0x06, 0x46, 0xa5, 0x64, 0x77, 0x66, 0x95, 0x3c, 0xaa, 0x6d,
+	0xb5, 0x0b, 0xac, 0x76, 0x3f, 0x50, 0x37, 0xc6, 0x43, 0x8f, 0x71, 0x49, 0xd1, 0x0d, 0x3c, 0xf3,
+	0x11, 0xf4, 0x29, 0x8c, 0xd6, 0xb4, 0x39, 0x73, 0x7e, 0x46, 0x86, 0x6b, 0xda, 0x18, 0x30, 0x74,
+	0x0a, 0x81, 0x81, 0x15, 0x44, 0x53, 0xc9, 0x48, 0xee, 0x75, 0x18, 0xac, 0xe9, 0xe6, 0xd2, 0xbb,
+	0xa2, 0xdf, 0x01, 0x3d, 0x7e, 0x76, 0xd0, 0x04, 0x06, 0x66, 0xc5, 0xd9, 0x82, 0xa5, 0x44, 0x53,
+	0xff, 0x09, 0x4d, 0xd7, 0xff, 0x10, 0xb2, 0xfd, 0xdf, 0x42, 0x46, 0xff, 0xb4, 0x60, 0xb8, 0xf3,
+	0x14, 0x98, 0x87, 0x9b, 0x72, 0x32, 0xcf, 0xdd, 0xa5, 0xcf, 0xb1, 0x3f, 0xa1, 0x18, 0x8e, 0xd2,
+	0x9c, 0x19, 0x69, 0x45, 0xf5, 0xf0, 0x96, 0x3d, 0xef, 0x27, 0x72, 0x49, 0x57, 0x55, 0xe3, 0xe3,
+	0xbe, 0x05, 0x54, 0x52, 0x2a, 0x1f, 0x10, 0x75, 0xf6, 0x13, 0x1d, 0x9a, 0x94, 0x26, 0xcd, 0xdb,
+	0x04, 0x4e, 0x85, 0x5c, 0xce, 0x56, 0x9b, 0x92, 0xca, 0x9c, 0x66, 0x4b, 0x2a, 0x67, 0xee, 0x19,
+	0x73, 0xbf, 0x4d, 0x65, 0x98, 0xde, 0x1e, 0x5e, 0xaa, 0xd2, 0xad, 0xc7, 0x35, 0x49, 0xd7, 0x64,
+	0x49, 0x7f, 0x9e, 0x2e, 0x99, 0x5e, 0x55, 0xf3, 0x59, 0x2a, 0x8a, 0xb3, 0x46, 0xee, 0x99, 0xcb,
+	0x3d, 0x73, 0xb9, 0xe6, 0x27, 0x3c, 0xef, 0x59, 0xfb, 0xcd, 0xbf, 0x03, 0x00, 0xa1, 0x64, 0x12,
+	0x00, 0x96, 0x07, 0x00, 0x00,
 }
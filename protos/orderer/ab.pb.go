@@ -44,7 +44,7 @@ func (x SeekInfo_SeekBehavior) String() string {
 	return proto.EnumName(SeekInfo_SeekBehavior_name, int32(x))
 }
 func (SeekInfo_SeekBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{5, 0}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{5, 0}
 }
 
 type BroadcastResponse struct {
@@ -61,7 +61,7 @@ func (m *BroadcastResponse) Reset()         { *m = BroadcastResponse{} }
 func (m *BroadcastResponse) String() string { return proto.CompactTextString(m) }
 func (*BroadcastResponse) ProtoMessage()    {}
 func (*BroadcastResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{0}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{0}
 }
 func (m *BroadcastResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BroadcastResponse.Unmarshal(m, b)
@@ -105,7 +105,7 @@ func (m *SeekNewest) Reset()         { *m = SeekNewest{} }
 func (m *SeekNewest) String() string { return proto.CompactTextString(m) }
 func (*SeekNewest) ProtoMessage()    {}
 func (*SeekNewest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{1}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{1}
 }
 func (m *SeekNewest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekNewest.Unmarshal(m, b)
@@ -135,7 +135,7 @@ func (m *SeekOldest) Reset()         { *m = SeekOldest{} }
 func (m *SeekOldest) String() string { return proto.CompactTextString(m) }
 func (*SeekOldest) ProtoMessage()    {}
 func (*SeekOldest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{2}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{2}
 }
 func (m *SeekOldest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekOldest.Unmarshal(m, b)
@@ -166,7 +166,7 @@ func (m *SeekSpecified) Reset()         { *m = SeekSpecified{} }
 func (m *SeekSpecified) String() string { return proto.CompactTextString(m) }
 func (*SeekSpecified) ProtoMessage()    {}
 func (*SeekSpecified) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{3}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{3}
 }
 func (m *SeekSpecified) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekSpecified.Unmarshal(m, b)
@@ -208,7 +208,7 @@ func (m *SeekPosition) Reset()         { *m = SeekPosition{} }
 func (m *SeekPosition) String() string { return proto.CompactTextString(m) }
 func (*SeekPosition) ProtoMessage()    {}
 func (*SeekPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{4}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{4}
 }
 func (m *SeekPosition) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekPosition.Unmarshal(m, b)
@@ -380,19 +380,23 @@ func _SeekPosition_OneofSizer(msg proto.Message) (n int) {
 // as they are created, behavior should be set to BLOCK_UNTIL_READY and the stop should be set to
 // specified with a number of MAX_UINT64
 type SeekInfo struct {
-	Start                *SeekPosition         `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
-	Stop                 *SeekPosition         `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
-	Behavior             SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	Start    *SeekPosition         `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Stop     *SeekPosition         `protobuf:"bytes,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	Behavior SeekInfo_SeekBehavior `protobuf:"varint,3,opt,name=behavior,proto3,enum=orderer.SeekInfo_SeekBehavior" json:"behavior,omitempty"`
+	// Resume the deliver after the last block the server delivered to the same
+	// client identity on the channel, in place of the start position, when the
+	// server tracks the deliver sessions and still has the one of the client
+	Resume               bool     `protobuf:"varint,4,opt,name=resume,proto3" json:"resume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SeekInfo) Reset()         { *m = SeekInfo{} }
 func (m *SeekInfo) String() string { return proto.CompactTextString(m) }
 func (*SeekInfo) ProtoMessage()    {}
 func (*SeekInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{5}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{5}
 }
 func (m *SeekInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SeekInfo.Unmarshal(m, b)
@@ -433,6 +437,13 @@ func (m *SeekInfo) GetBehavior() SeekInfo_SeekBehavior {
 	return SeekInfo_BLOCK_UNTIL_READY
 }
 
+func (m *SeekInfo) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
 type DeliverResponse struct {
 	// Types that are valid to be assigned to Type:
 	//	*DeliverResponse_Status
@@ -447,7 +458,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ab_86effae0ebc2388c, []int{6}
+	return fileDescriptor_ab_d4fdb7709f53d09d, []int{6}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -757,40 +768,41 @@ var _AtomicBroadcast_serviceDesc = grpc.ServiceDesc{
 	Metadata: "orderer/ab.proto",
 }
 
-func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_86effae0ebc2388c) }
-
-var fileDescriptor_ab_86effae0ebc2388c = []byte{
-	// 505 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0x12, 0x51,
-	0x10, 0xc6, 0x59, 0xa5, 0xb4, 0x8c, 0x2d, 0xa5, 0xa7, 0x69, 0x43, 0xb8, 0x30, 0xcd, 0x26, 0x55,
-	0x8c, 0xba, 0x6b, 0x30, 0xf1, 0x42, 0x4d, 0x0c, 0x6b, 0xdb, 0x40, 0x24, 0x60, 0x16, 0x7a, 0xa1,
-	0x37, 0x64, 0x77, 0x19, 0x60, 0xed, 0xb2, 0x67, 0x73, 0xce, 0x01, 0xd3, 0xa7, 0xf0, 0x45, 0x7c,
-	0x24, 0x1f, 0xc6, 0x9c, 0x3f, 0xbb, 0x48, 0xd5, 0x5e, 0xb1, 0x33, 0xf3, 0xfb, 0xce, 0x7c, 0x33,
-	0x19, 0xa0, 0x4e, 0xd9, 0x14, 0x19, 0x32, 0x37, 0x08, 0x9d, 0x8c, 0x51, 0x41, 0xc9, 0xae, 0xc9,
-	0x34, 0x8f, 0x23, 0xba, 0x5c, 0xd2, 0xd4, 0xd5, 0x3f, 0xba, 0x6a, 0x0f, 0xe1, 0xc8, 0x63, 0x34,
-	0x98, 0x46, 0x01, 0x17, 0x3e, 0xf2, 0x8c, 0xa6, 0x1c, 0xc9, 0x13, 0xa8, 0x70, 0x11, 0x88, 0x15,
-	0x6f, 0x58, 0x67, 0x56, 0xab, 0xd6, 0xae, 0x39, 0x46, 0x33, 0x52, 0x59, 0xdf, 0x54, 0x09, 0x81,
-	0x72, 0x9c, 0xce, 0x68, 0xe3, 0xc1, 0x99, 0xd5, 0xaa, 0xfa, 0xea, 0xdb, 0xde, 0x07, 0x18, 0x21,
-	0xde, 0x0c, 0xf0, 0x3b, 0x72, 0x91, 0x47, 0xc3, 0x64, 0x2a, 0xa3, 0xa7, 0x70, 0x20, 0xa3, 0x51,
-	0x86, 0x51, 0x3c, 0x8b, 0x71, 0x4a, 0x4e, 0xa1, 0x92, 0xae, 0x96, 0x21, 0x32, 0xd5, 0xa8, 0xec,
-	0x9b, 0xc8, 0xfe, 0x69, 0xc1, 0xbe, 0x24, 0x3f, 0x53, 0x1e, 0x8b, 0x98, 0xa6, 0xe4, 0x25, 0x54,
-	0x52, 0xf5, 0xa2, 0x02, 0x1f, 0xb5, 0x8f, 0x1d, 0x33, 0x95, 0xb3, 0x69, 0xd6, 0x2d, 0xf9, 0x06,
-	0x92, 0x38, 0x55, 0x2d, 0x95, 0xb5, 0xbb, 0xb8, 0x76, 0x23, 0x71, 0x0d, 0x91, 0x37, 0x50, 0xe5,
-	0xb9, 0xa7, 0xc6, 0x43, 0xa5, 0x38, 0xdd, 0x52, 0x14, 0x8e, 0xbb, 0x25, 0x7f, 0x83, 0x7a, 0x15,
-	0x28, 0x8f, 0x6f, 0x33, 0xb4, 0x7f, 0x59, 0xb0, 0x27, 0xb1, 0x5e, 0x3a, 0xa3, 0xe4, 0x39, 0xec,
-	0x70, 0x11, 0xb0, 0xdc, 0xe9, 0xc9, 0xd6, 0x43, 0xf9, 0x40, 0xbe, 0x66, 0xc8, 0x33, 0x28, 0x73,
-	0x41, 0x33, 0x63, 0xf3, 0x3f, 0xac, 0x42, 0xc8, 0x5b, 0xd8, 0x0b, 0x71, 0x11, 0xac, 0x63, 0xca,
-	0x94, 0xc7, 0x5a, 0xfb, 0xf1, 0x16, 0x2e, 0x9b, 0xab, 0x0f, 0xcf, 0x50, 0x7e, 0xc1, 0xdb, 0xef,
-	0xf5, 0x3a, 0xf3, 0x0a, 0x39, 0x81, 0x23, 0xaf, 0x3f, 0xfc, 0xf8, 0x69, 0x72, 0x3d, 0x18, 0xf7,
-	0xfa, 0x13, 0xff, 0xb2, 0x73, 0xf1, 0xa5, 0x5e, 0x92, 0xe9, 0xab, 0x4e, 0xaf, 0x3f, 0xe9, 0x5d,
-	0x4d, 0x06, 0xc3, 0xb1, 0x49, 0x5b, 0xf6, 0x37, 0x38, 0xbc, 0xc0, 0x24, 0x5e, 0x23, 0x2b, 0x2e,
-	0xa4, 0x75, 0xff, 0x85, 0xc8, 0xdd, 0x9a, 0x1b, 0x39, 0x87, 0x9d, 0x30, 0xa1, 0xd1, 0x8d, 0x19,
-	0xf1, 0x20, 0x07, 0x3d, 0x99, 0xec, 0x96, 0x7c, 0x5d, 0xcd, 0x57, 0xd9, 0xfe, 0x61, 0xc1, 0x61,
-	0x47, 0xd0, 0x65, 0x1c, 0x15, 0x67, 0x49, 0x3e, 0x40, 0x75, 0x13, 0xd4, 0xf3, 0x07, 0x2e, 0xd3,
-	0x35, 0x26, 0x34, 0xc3, 0x66, 0xb3, 0x58, 0xc3, 0x5f, 0x97, 0x6c, 0x97, 0x5a, 0xd6, 0x2b, 0x8b,
-	0xbc, 0x83, 0x5d, 0x33, 0xc0, 0x3f, 0xe4, 0x8d, 0x42, 0x7e, 0x67, 0x48, 0x2d, 0xf6, 0xae, 0xe1,
-	0x9c, 0xb2, 0xb9, 0xb3, 0xb8, 0xcd, 0x90, 0x25, 0x38, 0x9d, 0x23, 0x73, 0x66, 0x41, 0xc8, 0xe2,
-	0x48, 0xff, 0x83, 0x78, 0x2e, 0xff, 0xfa, 0x62, 0x1e, 0x8b, 0xc5, 0x2a, 0x94, 0x0d, 0xdc, 0x3f,
-	0x68, 0x57, 0xd3, 0xae, 0xa6, 0x5d, 0x43, 0x87, 0x15, 0x15, 0xbf, 0xfe, 0x1d, 0x00, 0x00, 0xff,
-	0xff, 0x4b, 0x88, 0xa4, 0x39, 0xb1, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("orderer/ab.proto", fileDescriptor_ab_d4fdb7709f53d09d) }
+
+var fileDescriptor_ab_d4fdb7709f53d09d = []byte{
+	// 516 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x93, 0xdf, 0x6e, 0xda, 0x3e,
+	0x14, 0xc7, 0x09, 0x3f, 0x4a, 0xe1, 0xfc, 0x28, 0xa5, 0xae, 0x5a, 0x45, 0x5c, 0x4c, 0x28, 0x52,
+	0x37, 0xa6, 0x6d, 0xc9, 0xc4, 0xa4, 0x5d, 0x6c, 0x93, 0x26, 0xb2, 0xb6, 0x02, 0x0d, 0xc1, 0x64,
+	0xe8, 0xc5, 0x76, 0x83, 0x92, 0x70, 0x80, 0xac, 0x10, 0x47, 0x76, 0x60, 0xea, 0x53, 0xec, 0x45,
+	0xf6, 0x78, 0x7b, 0x80, 0xc9, 0x8e, 0x13, 0xca, 0x56, 0xf5, 0x2a, 0xfe, 0x1e, 0x7f, 0xbe, 0x3e,
+	0x7f, 0x74, 0x02, 0x0d, 0xc6, 0x67, 0xc8, 0x91, 0x3b, 0x9e, 0x6f, 0xc7, 0x9c, 0x25, 0x8c, 0x1c,
+	0xea, 0x48, 0xf3, 0x34, 0x60, 0xeb, 0x35, 0x8b, 0x9c, 0xf4, 0x93, 0xde, 0x5a, 0x23, 0x38, 0x71,
+	0x39, 0xf3, 0x66, 0x81, 0x27, 0x12, 0x8a, 0x22, 0x66, 0x91, 0x40, 0xf2, 0x14, 0xca, 0x22, 0xf1,
+	0x92, 0x8d, 0x30, 0x8d, 0x96, 0xd1, 0xae, 0x77, 0xea, 0xb6, 0xf6, 0x8c, 0x55, 0x94, 0xea, 0x5b,
+	0x42, 0xa0, 0x14, 0x46, 0x73, 0x66, 0x16, 0x5b, 0x46, 0xbb, 0x4a, 0xd5, 0xd9, 0xaa, 0x01, 0x8c,
+	0x11, 0x6f, 0x87, 0xf8, 0x03, 0x45, 0x92, 0xa9, 0xd1, 0x6a, 0x26, 0xd5, 0x33, 0x38, 0x92, 0x6a,
+	0x1c, 0x63, 0x10, 0xce, 0x43, 0x9c, 0x91, 0x73, 0x28, 0x47, 0x9b, 0xb5, 0x8f, 0x5c, 0x25, 0x2a,
+	0x51, 0xad, 0xac, 0x5f, 0x06, 0xd4, 0x24, 0xf9, 0x85, 0x89, 0x30, 0x09, 0x59, 0x44, 0x5e, 0x41,
+	0x39, 0x52, 0x2f, 0x2a, 0xf0, 0xff, 0xce, 0xa9, 0xad, 0xbb, 0xb2, 0x77, 0xc9, 0x7a, 0x05, 0xaa,
+	0x21, 0x89, 0x33, 0x95, 0xd2, 0x2c, 0x3e, 0x80, 0xa7, 0xd5, 0x48, 0x3c, 0x85, 0xc8, 0x5b, 0xa8,
+	0x8a, 0xac, 0x26, 0xf3, 0x3f, 0xe5, 0x38, 0xdf, 0x73, 0xe4, 0x15, 0xf7, 0x0a, 0x74, 0x87, 0xba,
+	0x65, 0x28, 0x4d, 0xee, 0x62, 0xb4, 0x7e, 0x1b, 0x50, 0x91, 0x58, 0x3f, 0x9a, 0x33, 0xf2, 0x02,
+	0x0e, 0x44, 0xe2, 0xf1, 0xac, 0xd2, 0xb3, 0xbd, 0x87, 0xb2, 0x86, 0x68, 0xca, 0x90, 0xe7, 0x50,
+	0x12, 0x09, 0x8b, 0xcd, 0xe2, 0x63, 0xac, 0x42, 0xc8, 0x3b, 0xa8, 0xf8, 0xb8, 0xf4, 0xb6, 0x21,
+	0xe3, 0xaa, 0xc6, 0x7a, 0xe7, 0xc9, 0x1e, 0x2e, 0x93, 0xab, 0x83, 0xab, 0x29, 0x9a, 0xf3, 0x72,
+	0xce, 0x1c, 0xc5, 0x66, 0x8d, 0x66, 0xa9, 0x65, 0xb4, 0x2b, 0x54, 0x2b, 0xeb, 0x03, 0xd4, 0xee,
+	0x3b, 0xc8, 0x19, 0x9c, 0xb8, 0x83, 0xd1, 0xa7, 0xcf, 0xd3, 0x9b, 0xe1, 0xa4, 0x3f, 0x98, 0xd2,
+	0xab, 0xee, 0xe5, 0xd7, 0x46, 0x41, 0x86, 0xaf, 0xbb, 0xfd, 0xc1, 0xb4, 0x7f, 0x3d, 0x1d, 0x8e,
+	0x26, 0x3a, 0x6c, 0x58, 0xdf, 0xe1, 0xf8, 0x12, 0x57, 0xe1, 0x16, 0x79, 0xbe, 0x39, 0xed, 0xc7,
+	0x37, 0x47, 0xce, 0x5c, 0xef, 0xce, 0x05, 0x1c, 0xf8, 0x2b, 0x16, 0xdc, 0xea, 0xd6, 0x8f, 0x32,
+	0xd0, 0x95, 0xc1, 0x5e, 0x81, 0xa6, 0xb7, 0xd9, 0x88, 0x3b, 0x3f, 0x0d, 0x38, 0xee, 0x26, 0x6c,
+	0x1d, 0x06, 0xf9, 0xba, 0x92, 0x8f, 0x50, 0xdd, 0x89, 0x46, 0xf6, 0xc0, 0x55, 0xb4, 0xc5, 0x15,
+	0x8b, 0xb1, 0xd9, 0xcc, 0xc7, 0xf3, 0xcf, 0x86, 0x5b, 0x85, 0xb6, 0xf1, 0xda, 0x20, 0xef, 0xe1,
+	0x50, 0x37, 0xf0, 0x80, 0xdd, 0xcc, 0xed, 0x7f, 0x35, 0x99, 0x9a, 0xdd, 0x1b, 0xb8, 0x60, 0x7c,
+	0x61, 0x2f, 0xef, 0x62, 0xe4, 0x2b, 0x9c, 0x2d, 0x90, 0xdb, 0x73, 0xcf, 0xe7, 0x61, 0x90, 0xfe,
+	0x59, 0x22, 0xb3, 0x7f, 0x7b, 0xb9, 0x08, 0x93, 0xe5, 0xc6, 0x97, 0x09, 0x9c, 0x7b, 0xb4, 0x93,
+	0xd2, 0x4e, 0x4a, 0x3b, 0x9a, 0xf6, 0xcb, 0x4a, 0xbf, 0xf9, 0x33, 0x00, 0x29, 0x19, 0x6f, 0x43,
+	0xc9, 0x03, 0x00, 0x00,
 }
@@ -57,7 +57,7 @@ func (x ServerStatus_StatusCode) String() string {
 	return proto.EnumName(ServerStatus_StatusCode_name, int32(x))
 }
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0, 0}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{0, 0}
 }
 
 type ServerStatus struct {
@@ -71,7 +71,7 @@ func (m *ServerStatus) Reset()         { *m = ServerStatus{} }
 func (m *ServerStatus) String() string { return proto.CompactTextString(m) }
 func (*ServerStatus) ProtoMessage()    {}
 func (*ServerStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{0}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{0}
 }
 func (m *ServerStatus) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ServerStatus.Unmarshal(m, b)
@@ -110,7 +110,7 @@ func (m *LogLevelRequest) Reset()         { *m = LogLevelRequest{} }
 func (m *LogLevelRequest) String() string { return proto.CompactTextString(m) }
 func (*LogLevelRequest) ProtoMessage()    {}
 func (*LogLevelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{1}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{1}
 }
 func (m *LogLevelRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelRequest.Unmarshal(m, b)
@@ -156,7 +156,7 @@ func (m *LogLevelResponse) Reset()         { *m = LogLevelResponse{} }
 func (m *LogLevelResponse) String() string { return proto.CompactTextString(m) }
 func (*LogLevelResponse) ProtoMessage()    {}
 func (*LogLevelResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{2}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{2}
 }
 func (m *LogLevelResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogLevelResponse.Unmarshal(m, b)
@@ -201,7 +201,7 @@ func (m *LogSpecRequest) Reset()         { *m = LogSpecRequest{} }
 func (m *LogSpecRequest) String() string { return proto.CompactTextString(m) }
 func (*LogSpecRequest) ProtoMessage()    {}
 func (*LogSpecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{3}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{3}
 }
 func (m *LogSpecRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecRequest.Unmarshal(m, b)
@@ -240,7 +240,7 @@ func (m *LogSpecResponse) Reset()         { *m = LogSpecResponse{} }
 func (m *LogSpecResponse) String() string { return proto.CompactTextString(m) }
 func (*LogSpecResponse) ProtoMessage()    {}
 func (*LogSpecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{4}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{4}
 }
 func (m *LogSpecResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LogSpecResponse.Unmarshal(m, b)
@@ -274,10 +274,174 @@ func (m *LogSpecResponse) GetError() string {
 	return ""
 }
 
+// JoinChannelRequest carries the genesis block of the channel to join
+type JoinChannelRequest struct {
+	GenesisBlock         []byte   `protobuf:"bytes,1,opt,name=genesis_block,json=genesisBlock,proto3" json:"genesis_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinChannelRequest) Reset()         { *m = JoinChannelRequest{} }
+func (m *JoinChannelRequest) String() string { return proto.CompactTextString(m) }
+func (*JoinChannelRequest) ProtoMessage()    {}
+func (*JoinChannelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{5}
+}
+func (m *JoinChannelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinChannelRequest.Unmarshal(m, b)
+}
+func (m *JoinChannelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinChannelRequest.Marshal(b, m, deterministic)
+}
+func (dst *JoinChannelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinChannelRequest.Merge(dst, src)
+}
+func (m *JoinChannelRequest) XXX_Size() int {
+	return xxx_messageInfo_JoinChannelRequest.Size(m)
+}
+func (m *JoinChannelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinChannelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinChannelRequest proto.InternalMessageInfo
+
+func (m *JoinChannelRequest) GetGenesisBlock() []byte {
+	if m != nil {
+		return m.GenesisBlock
+	}
+	return nil
+}
+
+type LeaveChannelRequest struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaveChannelRequest) Reset()         { *m = LeaveChannelRequest{} }
+func (m *LeaveChannelRequest) String() string { return proto.CompactTextString(m) }
+func (*LeaveChannelRequest) ProtoMessage()    {}
+func (*LeaveChannelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{6}
+}
+func (m *LeaveChannelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeaveChannelRequest.Unmarshal(m, b)
+}
+func (m *LeaveChannelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeaveChannelRequest.Marshal(b, m, deterministic)
+}
+func (dst *LeaveChannelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaveChannelRequest.Merge(dst, src)
+}
+func (m *LeaveChannelRequest) XXX_Size() int {
+	return xxx_messageInfo_LeaveChannelRequest.Size(m)
+}
+func (m *LeaveChannelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaveChannelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaveChannelRequest proto.InternalMessageInfo
+
+func (m *LeaveChannelRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// ChannelHeight is the height of the ledger of a channel joined by the peer
+type ChannelHeight struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelHeight) Reset()         { *m = ChannelHeight{} }
+func (m *ChannelHeight) String() string { return proto.CompactTextString(m) }
+func (*ChannelHeight) ProtoMessage()    {}
+func (*ChannelHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{7}
+}
+func (m *ChannelHeight) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelHeight.Unmarshal(m, b)
+}
+func (m *ChannelHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelHeight.Marshal(b, m, deterministic)
+}
+func (dst *ChannelHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelHeight.Merge(dst, src)
+}
+func (m *ChannelHeight) XXX_Size() int {
+	return xxx_messageInfo_ChannelHeight.Size(m)
+}
+func (m *ChannelHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelHeight proto.InternalMessageInfo
+
+func (m *ChannelHeight) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ChannelHeight) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type ChannelList struct {
+	Channels             []*ChannelHeight `protobuf:"bytes,1,rep,name=channels,proto3" json:"channels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ChannelList) Reset()         { *m = ChannelList{} }
+func (m *ChannelList) String() string { return proto.CompactTextString(m) }
+func (*ChannelList) ProtoMessage()    {}
+func (*ChannelList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{8}
+}
+func (m *ChannelList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelList.Unmarshal(m, b)
+}
+func (m *ChannelList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelList.Marshal(b, m, deterministic)
+}
+func (dst *ChannelList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelList.Merge(dst, src)
+}
+func (m *ChannelList) XXX_Size() int {
+	return xxx_messageInfo_ChannelList.Size(m)
+}
+func (m *ChannelList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelList proto.InternalMessageInfo
+
+func (m *ChannelList) GetChannels() []*ChannelHeight {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
 type AdminOperation struct {
 	// Types that are valid to be assigned to Content:
 	//	*AdminOperation_LogReq
 	//	*AdminOperation_LogSpecReq
+	//	*AdminOperation_JoinChannelReq
+	//	*AdminOperation_LeaveChannelReq
 	Content              isAdminOperation_Content `protobuf_oneof:"content"`
 	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
 	XXX_unrecognized     []byte                   `json:"-"`
@@ -288,7 +452,7 @@ func (m *AdminOperation) Reset()         { *m = AdminOperation{} }
 func (m *AdminOperation) String() string { return proto.CompactTextString(m) }
 func (*AdminOperation) ProtoMessage()    {}
 func (*AdminOperation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_admin_b2904393863b6bc5, []int{5}
+	return fileDescriptor_admin_5db5291bbd8bb2b8, []int{9}
 }
 func (m *AdminOperation) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AdminOperation.Unmarshal(m, b)
@@ -320,10 +484,22 @@ type AdminOperation_LogSpecReq struct {
 	LogSpecReq *LogSpecRequest `protobuf:"bytes,2,opt,name=logSpecReq,proto3,oneof"`
 }
 
+type AdminOperation_JoinChannelReq struct {
+	JoinChannelReq *JoinChannelRequest `protobuf:"bytes,3,opt,name=joinChannelReq,proto3,oneof"`
+}
+
+type AdminOperation_LeaveChannelReq struct {
+	LeaveChannelReq *LeaveChannelRequest `protobuf:"bytes,4,opt,name=leaveChannelReq,proto3,oneof"`
+}
+
 func (*AdminOperation_LogReq) isAdminOperation_Content() {}
 
 func (*AdminOperation_LogSpecReq) isAdminOperation_Content() {}
 
+func (*AdminOperation_JoinChannelReq) isAdminOperation_Content() {}
+
+func (*AdminOperation_LeaveChannelReq) isAdminOperation_Content() {}
+
 func (m *AdminOperation) GetContent() isAdminOperation_Content {
 	if m != nil {
 		return m.Content
@@ -345,11 +521,27 @@ func (m *AdminOperation) GetLogSpecReq() *LogSpecRequest {
 	return nil
 }
 
+func (m *AdminOperation) GetJoinChannelReq() *JoinChannelRequest {
+	if x, ok := m.GetContent().(*AdminOperation_JoinChannelReq); ok {
+		return x.JoinChannelReq
+	}
+	return nil
+}
+
+func (m *AdminOperation) GetLeaveChannelReq() *LeaveChannelRequest {
+	if x, ok := m.GetContent().(*AdminOperation_LeaveChannelReq); ok {
+		return x.LeaveChannelReq
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*AdminOperation) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _AdminOperation_OneofMarshaler, _AdminOperation_OneofUnmarshaler, _AdminOperation_OneofSizer, []interface{}{
 		(*AdminOperation_LogReq)(nil),
 		(*AdminOperation_LogSpecReq)(nil),
+		(*AdminOperation_JoinChannelReq)(nil),
+		(*AdminOperation_LeaveChannelReq)(nil),
 	}
 }
 
@@ -367,6 +559,16 @@ func _AdminOperation_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.LogSpecReq); err != nil {
 			return err
 		}
+	case *AdminOperation_JoinChannelReq:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.JoinChannelReq); err != nil {
+			return err
+		}
+	case *AdminOperation_LeaveChannelReq:
+		b.EncodeVarint(4<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.LeaveChannelReq); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("AdminOperation.Content has unexpected type %T", x)
@@ -393,6 +595,22 @@ func _AdminOperation_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto
 		err := b.DecodeMessage(msg)
 		m.Content = &AdminOperation_LogSpecReq{msg}
 		return true, err
+	case 3: // content.joinChannelReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(JoinChannelRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_JoinChannelReq{msg}
+		return true, err
+	case 4: // content.leaveChannelReq
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(LeaveChannelRequest)
+		err := b.DecodeMessage(msg)
+		m.Content = &AdminOperation_LeaveChannelReq{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -412,6 +630,16 @@ func _AdminOperation_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *AdminOperation_JoinChannelReq:
+		s := proto.Size(x.JoinChannelReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *AdminOperation_LeaveChannelReq:
+		s := proto.Size(x.LeaveChannelReq)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -425,6 +653,10 @@ func init() {
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
 	proto.RegisterType((*LogSpecRequest)(nil), "protos.LogSpecRequest")
 	proto.RegisterType((*LogSpecResponse)(nil), "protos.LogSpecResponse")
+	proto.RegisterType((*JoinChannelRequest)(nil), "protos.JoinChannelRequest")
+	proto.RegisterType((*LeaveChannelRequest)(nil), "protos.LeaveChannelRequest")
+	proto.RegisterType((*ChannelHeight)(nil), "protos.ChannelHeight")
+	proto.RegisterType((*ChannelList)(nil), "protos.ChannelList")
 	proto.RegisterType((*AdminOperation)(nil), "protos.AdminOperation")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
@@ -448,6 +680,9 @@ type AdminClient interface {
 	RevertLogLevels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
 	GetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
 	SetLogSpec(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*LogSpecResponse, error)
+	JoinChannel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	LeaveChannel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListChannels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChannelList, error)
 }
 
 type adminClient struct {
@@ -521,6 +756,33 @@ func (c *adminClient) SetLogSpec(ctx context.Context, in *common.Envelope, opts
 	return out, nil
 }
 
+func (c *adminClient) JoinChannel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.Admin/JoinChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) LeaveChannel(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/protos.Admin/LeaveChannel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListChannels(ctx context.Context, in *common.Envelope, opts ...grpc.CallOption) (*ChannelList, error) {
+	out := new(ChannelList)
+	err := c.cc.Invoke(ctx, "/protos.Admin/ListChannels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServer is the server API for Admin service.
 type AdminServer interface {
 	GetStatus(context.Context, *common.Envelope) (*ServerStatus, error)
@@ -530,6 +792,9 @@ type AdminServer interface {
 	RevertLogLevels(context.Context, *common.Envelope) (*empty.Empty, error)
 	GetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
 	SetLogSpec(context.Context, *common.Envelope) (*LogSpecResponse, error)
+	JoinChannel(context.Context, *common.Envelope) (*empty.Empty, error)
+	LeaveChannel(context.Context, *common.Envelope) (*empty.Empty, error)
+	ListChannels(context.Context, *common.Envelope) (*ChannelList, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -662,6 +927,60 @@ func _Admin_SetLogSpec_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_JoinChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).JoinChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/JoinChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).JoinChannel(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_LeaveChannel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).LeaveChannel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/LeaveChannel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).LeaveChannel(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListChannels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Envelope)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListChannels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ListChannels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListChannels(ctx, req.(*common.Envelope))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -694,48 +1013,70 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetLogSpec",
 			Handler:    _Admin_SetLogSpec_Handler,
 		},
+		{
+			MethodName: "JoinChannel",
+			Handler:    _Admin_JoinChannel_Handler,
+		},
+		{
+			MethodName: "LeaveChannel",
+			Handler:    _Admin_LeaveChannel_Handler,
+		},
+		{
+			MethodName: "ListChannels",
+			Handler:    _Admin_ListChannels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
 }
 
-func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_b2904393863b6bc5) }
-
-var fileDescriptor_admin_b2904393863b6bc5 = []byte{
-	// 555 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
-	0x14, 0x6e, 0x37, 0xda, 0x92, 0xd3, 0xd1, 0x05, 0x33, 0x6d, 0xa5, 0x13, 0x02, 0xe5, 0x0a, 0x84,
-	0x94, 0x88, 0x22, 0x34, 0x76, 0xc1, 0x45, 0x4b, 0x43, 0x87, 0xe8, 0xd2, 0x2a, 0x59, 0x85, 0x40,
-	0x42, 0x55, 0x9a, 0x9e, 0x79, 0x15, 0x6e, 0x9c, 0x39, 0x6e, 0xa5, 0xbd, 0x01, 0xcf, 0xc1, 0x23,
-	0xf0, 0x84, 0x28, 0x76, 0xc2, 0x2a, 0x56, 0x2e, 0xd8, 0xae, 0xec, 0x73, 0xfc, 0x7d, 0xdf, 0xf9,
-	0xb3, 0x0d, 0x66, 0x82, 0x28, 0x9c, 0x70, 0xb6, 0x98, 0xc7, 0x76, 0x22, 0xb8, 0xe4, 0xa4, 0xaa,
-	0x96, 0xb4, 0x75, 0x48, 0x39, 0xa7, 0x0c, 0x1d, 0x65, 0x4e, 0x97, 0xe7, 0x0e, 0x2e, 0x12, 0x79,
-	0xa5, 0x41, 0xad, 0x47, 0x11, 0x5f, 0x2c, 0x78, 0xec, 0xe8, 0x45, 0x3b, 0xad, 0x9f, 0x65, 0xd8,
-	0x09, 0x50, 0xac, 0x50, 0x04, 0x32, 0x94, 0xcb, 0x94, 0x1c, 0x41, 0x35, 0x55, 0xbb, 0x66, 0xf9,
-	0x59, 0xf9, 0x79, 0xa3, 0xfd, 0x54, 0x03, 0x53, 0x7b, 0x1d, 0x65, 0xeb, 0xe5, 0x3d, 0x9f, 0xa1,
-	0x9f, 0xc3, 0xad, 0x2f, 0x00, 0xd7, 0x5e, 0xf2, 0x00, 0x8c, 0xb1, 0xd7, 0x73, 0x3f, 0x7c, 0xf4,
-	0xdc, 0x9e, 0x59, 0x22, 0x75, 0xa8, 0x05, 0x67, 0x1d, 0xff, 0xcc, 0xed, 0x99, 0x65, 0x6d, 0x0c,
-	0x47, 0x23, 0xb7, 0x67, 0x6e, 0x11, 0x80, 0xea, 0xa8, 0x33, 0x0e, 0xdc, 0x9e, 0xb9, 0x4d, 0x0c,
-	0xa8, 0xb8, 0xbe, 0x3f, 0xf4, 0xcd, 0x7b, 0x19, 0x66, 0xec, 0x7d, 0xf2, 0x86, 0x9f, 0x3d, 0xb3,
-	0x62, 0x9d, 0xc2, 0xee, 0x80, 0xd3, 0x01, 0xae, 0x90, 0xf9, 0x78, 0xb9, 0xc4, 0x54, 0x92, 0x27,
-	0x00, 0x8c, 0xd3, 0xc9, 0x82, 0xcf, 0x96, 0x0c, 0x55, 0xaa, 0x86, 0x6f, 0x30, 0x4e, 0x4f, 0x95,
-	0x83, 0x1c, 0x42, 0x66, 0x4c, 0x58, 0x46, 0x69, 0x6e, 0xa9, 0xd3, 0xfb, 0x2c, 0x97, 0xb0, 0x3c,
-	0x30, 0xaf, 0xe5, 0xd2, 0x84, 0xc7, 0x29, 0xde, 0x49, 0xef, 0x25, 0x34, 0x06, 0x9c, 0x06, 0x09,
-	0x46, 0x45, 0x76, 0x8f, 0x21, 0x3b, 0x9d, 0xa4, 0x09, 0x46, 0xb9, 0x56, 0x8d, 0x69, 0x84, 0xd5,
-	0x55, 0xb5, 0x68, 0x70, 0x1e, 0xfb, 0xdf, 0x68, 0xb2, 0x07, 0x15, 0x14, 0x82, 0x8b, 0x3c, 0xa6,
-	0x36, 0xac, 0x1f, 0x65, 0x68, 0x74, 0xb2, 0xf1, 0x0f, 0x13, 0x14, 0xa1, 0x9c, 0xf3, 0x98, 0xbc,
-	0x82, 0x2a, 0xe3, 0xd4, 0xc7, 0x4b, 0xa5, 0x50, 0x6f, 0x1f, 0x14, 0x63, 0xfb, 0xab, 0x71, 0x27,
-	0x25, 0x3f, 0x07, 0x92, 0xb7, 0xaa, 0xe4, 0x3c, 0x6d, 0x15, 0xa0, 0xde, 0xde, 0x5f, 0xa3, 0xad,
-	0x15, 0x74, 0x52, 0xf2, 0xd7, 0xb0, 0x5d, 0x03, 0x6a, 0x11, 0x8f, 0x25, 0xc6, 0xb2, 0xfd, 0x6b,
-	0x1b, 0x2a, 0x2a, 0x15, 0xf2, 0x06, 0x8c, 0x3e, 0xca, 0xfc, 0x16, 0x99, 0x76, 0x7e, 0xcb, 0xdc,
-	0x78, 0x85, 0x8c, 0x27, 0xd8, 0xda, 0xdb, 0x74, 0x8f, 0xac, 0x12, 0x39, 0x82, 0x7a, 0x20, 0x43,
-	0x21, 0xb5, 0xfb, 0x3f, 0x88, 0x1d, 0x78, 0xd8, 0x47, 0xa9, 0xe7, 0x53, 0x14, 0xb9, 0x81, 0xde,
-	0xbc, 0xd9, 0x08, 0xdd, 0x76, 0x2d, 0x11, 0xdc, 0x51, 0xe2, 0x1d, 0xec, 0xfa, 0xb8, 0x42, 0x21,
-	0x8b, 0xb3, 0x4d, 0xb5, 0xef, 0xdb, 0xfa, 0x5d, 0xda, 0xc5, 0xbb, 0xb4, 0xdd, 0xec, 0x5d, 0x5a,
-	0x25, 0x72, 0x0c, 0xd0, 0x47, 0x99, 0x37, 0x7b, 0x03, 0xf3, 0xe0, 0xc6, 0x3c, 0xfe, 0x44, 0x3e,
-	0x06, 0x08, 0x6e, 0x47, 0xed, 0x7e, 0x03, 0x8b, 0x0b, 0x6a, 0x5f, 0x5c, 0x25, 0x28, 0x18, 0xce,
-	0x28, 0x0a, 0xfb, 0x3c, 0x9c, 0x8a, 0x79, 0x54, 0x50, 0xb2, 0x0f, 0xa6, 0xbb, 0xa3, 0xe6, 0x3a,
-	0x0a, 0xa3, 0xef, 0x21, 0xc5, 0xaf, 0x2f, 0xe8, 0x5c, 0x5e, 0x2c, 0xa7, 0x59, 0x18, 0x67, 0x8d,
-	0xe8, 0x68, 0xa2, 0xfe, 0x71, 0x52, 0x27, 0x23, 0x4e, 0xf5, 0x6f, 0xf4, 0xfa, 0x77, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0xb0, 0x77, 0x16, 0x8f, 0xa8, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor_admin_5db5291bbd8bb2b8) }
+
+var fileDescriptor_admin_5db5291bbd8bb2b8 = []byte{
+	// 719 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0xa5, 0x55, 0x6d, 0x4f, 0xd3, 0x50,
+	0x14, 0xde, 0x60, 0x1b, 0xec, 0x74, 0x8c, 0x79, 0x41, 0x98, 0x23, 0x46, 0x53, 0xbf, 0x60, 0x4c,
+	0xda, 0x38, 0x35, 0x88, 0x89, 0x89, 0x1b, 0x8c, 0x17, 0x1d, 0xdb, 0xd2, 0x42, 0x8c, 0x26, 0x66,
+	0xe9, 0xba, 0x4b, 0x57, 0xe9, 0x7a, 0xeb, 0x6d, 0x47, 0xc2, 0x2f, 0xf1, 0xbb, 0x3f, 0xcd, 0x5f,
+	0xe2, 0xed, 0xbd, 0xb7, 0x50, 0xb6, 0x19, 0x83, 0x7c, 0x6a, 0xcf, 0xe9, 0xf3, 0x3c, 0xe7, 0xdc,
+	0xf3, 0x72, 0x0b, 0x95, 0x00, 0x63, 0xaa, 0x5b, 0xc3, 0xb1, 0xeb, 0x6b, 0x01, 0x25, 0x11, 0x41,
+	0x05, 0xfe, 0x08, 0x6b, 0x5b, 0x0e, 0x21, 0x8e, 0x87, 0x75, 0x6e, 0x0e, 0x26, 0xe7, 0x3a, 0x1e,
+	0x07, 0xd1, 0x95, 0x00, 0xd5, 0xd6, 0x6c, 0x32, 0x1e, 0x13, 0x5f, 0x17, 0x0f, 0xe1, 0x54, 0x7f,
+	0x65, 0xa1, 0x64, 0x62, 0x7a, 0x89, 0xa9, 0x19, 0x59, 0xd1, 0x24, 0x44, 0x3b, 0x50, 0x08, 0xf9,
+	0x5b, 0x35, 0xfb, 0x34, 0xbb, 0x5d, 0xae, 0x3f, 0x11, 0xc0, 0x50, 0x4b, 0xa3, 0x34, 0xf1, 0xd8,
+	0x23, 0x43, 0x6c, 0x48, 0xb8, 0xfa, 0x05, 0xe0, 0xc6, 0x8b, 0x56, 0xa0, 0x78, 0xd6, 0xd9, 0x6f,
+	0x1d, 0x1c, 0x77, 0x5a, 0xfb, 0x95, 0x0c, 0x52, 0x60, 0xc9, 0x3c, 0x6d, 0x18, 0xa7, 0xcc, 0xc8,
+	0x0a, 0xa3, 0xdb, 0xeb, 0x31, 0x63, 0x01, 0x01, 0x14, 0x7a, 0x8d, 0x33, 0x93, 0xbd, 0x2f, 0xa2,
+	0x22, 0xe4, 0x5b, 0x86, 0xd1, 0x35, 0x2a, 0xb9, 0x18, 0x73, 0xd6, 0xf9, 0xd4, 0xe9, 0x7e, 0xee,
+	0x54, 0xf2, 0xea, 0x09, 0xac, 0xb6, 0x89, 0xd3, 0xc6, 0x97, 0xd8, 0x33, 0xf0, 0x8f, 0x09, 0x0e,
+	0x23, 0xf4, 0x18, 0xc0, 0x23, 0x4e, 0x7f, 0x4c, 0x86, 0x13, 0x0f, 0xf3, 0x54, 0x8b, 0x46, 0x91,
+	0x79, 0x4e, 0xb8, 0x03, 0x6d, 0x41, 0x6c, 0xf4, 0xbd, 0x98, 0x52, 0x5d, 0xe0, 0x5f, 0x97, 0x3d,
+	0x29, 0xa1, 0x76, 0xa0, 0x72, 0x23, 0x17, 0x06, 0xc4, 0x0f, 0xf1, 0xbd, 0xf4, 0x5e, 0x40, 0x99,
+	0xe9, 0x99, 0x01, 0xb6, 0x93, 0xec, 0x1e, 0x41, 0xfc, 0xb5, 0x1f, 0x32, 0x97, 0xd4, 0x5a, 0xf2,
+	0x04, 0x42, 0x6d, 0xf2, 0xb3, 0x08, 0xb0, 0x8c, 0xfd, 0x77, 0x34, 0x5a, 0x87, 0x3c, 0xa6, 0x94,
+	0x50, 0x19, 0x53, 0x18, 0xea, 0x2e, 0xa0, 0x8f, 0xc4, 0xf5, 0xf7, 0x46, 0x96, 0xef, 0xdf, 0x94,
+	0xe4, 0x19, 0xac, 0x38, 0xd8, 0xc7, 0xa1, 0x1b, 0xf6, 0x07, 0x1e, 0xb1, 0x2f, 0xb8, 0x56, 0xc9,
+	0x28, 0x49, 0x67, 0x33, 0xf6, 0xa9, 0xaf, 0x61, 0xad, 0x8d, 0xad, 0x4b, 0x3c, 0xc5, 0x65, 0xc7,
+	0xb7, 0x85, 0xa7, 0xef, 0x0e, 0x93, 0xe3, 0x4b, 0xcf, 0xf1, 0x50, 0x3d, 0x80, 0x15, 0x49, 0x38,
+	0xc2, 0xae, 0x33, 0xfa, 0x17, 0x1e, 0x6d, 0x40, 0x61, 0xc4, 0x81, 0x3c, 0xef, 0x9c, 0x21, 0x2d,
+	0xf5, 0x03, 0x28, 0x52, 0xa7, 0xed, 0xb2, 0xa8, 0x2f, 0x61, 0x59, 0x72, 0xe2, 0x69, 0x5b, 0xdc,
+	0x56, 0xea, 0x0f, 0x93, 0x69, 0xbb, 0x15, 0xce, 0xb8, 0x86, 0xa9, 0x3f, 0x17, 0xa0, 0xdc, 0x88,
+	0x27, 0xbf, 0x1b, 0x60, 0x6a, 0x45, 0x2e, 0xf1, 0x99, 0x4a, 0x81, 0x95, 0x8b, 0x9d, 0x84, 0xe7,
+	0xa1, 0xd4, 0x37, 0x13, 0x8d, 0xa9, 0x99, 0x39, 0xca, 0x18, 0x12, 0x88, 0xde, 0xf2, 0x6e, 0xcb,
+	0x8e, 0xf1, 0x1c, 0x95, 0xfa, 0x46, 0x8a, 0x96, 0xea, 0x25, 0x63, 0xa5, 0xb0, 0x68, 0x1f, 0xca,
+	0xdf, 0x6f, 0x95, 0xbe, 0xba, 0xc8, 0xd9, 0xb5, 0x84, 0x3d, 0xdb, 0x18, 0xa6, 0x30, 0xc5, 0x41,
+	0x87, 0xb0, 0xea, 0xdd, 0xee, 0x42, 0x35, 0xc7, 0x65, 0xb6, 0xae, 0x93, 0x98, 0x6d, 0x12, 0xd3,
+	0x99, 0x66, 0x35, 0x8b, 0xb0, 0x64, 0x13, 0x3f, 0xc2, 0x7e, 0x54, 0xff, 0x9d, 0x83, 0x3c, 0xaf,
+	0x0c, 0x7a, 0x03, 0xc5, 0x43, 0x1c, 0xc9, 0x7d, 0xae, 0x68, 0x72, 0xdf, 0x5b, 0x3e, 0x2b, 0x05,
+	0x09, 0x70, 0x6d, 0x7d, 0xde, 0x46, 0xab, 0x19, 0xb6, 0xf9, 0x0a, 0x7b, 0xa7, 0x91, 0x70, 0xdf,
+	0x81, 0xd8, 0x80, 0x07, 0x2c, 0x9e, 0xd8, 0x94, 0xa4, 0xe6, 0x73, 0xe8, 0xd5, 0xd9, 0xbe, 0x88,
+	0x05, 0x10, 0x12, 0xe6, 0x3d, 0x25, 0xde, 0xc3, 0xaa, 0xc1, 0x5c, 0x34, 0x4a, 0xbe, 0xcd, 0x3b,
+	0xfb, 0x86, 0x26, 0x6e, 0x48, 0x2d, 0xb9, 0x21, 0xb5, 0x56, 0x7c, 0x43, 0x32, 0xfa, 0x2e, 0x00,
+	0x3b, 0x84, 0xec, 0xfd, 0x1c, 0xe6, 0xe6, 0xcc, 0x78, 0x5c, 0x47, 0x66, 0x54, 0xf3, 0xbf, 0xa9,
+	0x4a, 0x6a, 0x60, 0xee, 0x94, 0xf0, 0x3b, 0x28, 0xa5, 0x87, 0xe4, 0x4e, 0xdc, 0x1d, 0xc6, 0x65,
+	0x0b, 0x28, 0xa9, 0xf3, 0x0a, 0xb5, 0x36, 0xb5, 0x88, 0x31, 0x5c, 0xcd, 0x34, 0xbf, 0x81, 0x4a,
+	0xa8, 0xa3, 0x8d, 0xae, 0xd8, 0xf6, 0x79, 0x78, 0xe8, 0x60, 0xaa, 0x9d, 0x5b, 0x03, 0xea, 0xda,
+	0x09, 0x3c, 0xfe, 0x35, 0x35, 0x4b, 0x7c, 0x0e, 0x7b, 0x96, 0x7d, 0x61, 0x39, 0xf8, 0xeb, 0x73,
+	0xc7, 0x8d, 0x46, 0x93, 0x41, 0x1c, 0x42, 0x4f, 0x11, 0x75, 0x41, 0x14, 0xff, 0xaa, 0x50, 0x8f,
+	0x89, 0x03, 0xf1, 0x1f, 0x7b, 0xf5, 0x07, 0x7b, 0xe9, 0x29, 0x41, 0xe2, 0x06, 0x00, 0x00,
 }
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/batch.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ChaincodeBatch is the list of the invocations of the chaincodes of a
+// channel carried by a single transaction of the batch system chaincode,
+// which commits the updates of all of them or of none of them
+type ChaincodeBatch struct {
+	Invocations          []*ChaincodeSpec `protobuf:"bytes,1,rep,name=invocations,proto3" json:"invocations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ChaincodeBatch) Reset()         { *m = ChaincodeBatch{} }
+func (m *ChaincodeBatch) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeBatch) ProtoMessage()    {}
+func (*ChaincodeBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_batch_3e1160cd9d4e2ae0, []int{0}
+}
+func (m *ChaincodeBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeBatch.Unmarshal(m, b)
+}
+func (m *ChaincodeBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeBatch.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeBatch.Merge(dst, src)
+}
+func (m *ChaincodeBatch) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeBatch.Size(m)
+}
+func (m *ChaincodeBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeBatch proto.InternalMessageInfo
+
+func (m *ChaincodeBatch) GetInvocations() []*ChaincodeSpec {
+	if m != nil {
+		return m.Invocations
+	}
+	return nil
+}
+
+// ChaincodeBatchResponse holds the responses of the invocations of a
+// ChaincodeBatch, in the order of the invocations
+type ChaincodeBatchResponse struct {
+	Responses            []*Response `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ChaincodeBatchResponse) Reset()         { *m = ChaincodeBatchResponse{} }
+func (m *ChaincodeBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeBatchResponse) ProtoMessage()    {}
+func (*ChaincodeBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_batch_3e1160cd9d4e2ae0, []int{1}
+}
+func (m *ChaincodeBatchResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeBatchResponse.Unmarshal(m, b)
+}
+func (m *ChaincodeBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeBatchResponse.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeBatchResponse.Merge(dst, src)
+}
+func (m *ChaincodeBatchResponse) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeBatchResponse.Size(m)
+}
+func (m *ChaincodeBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeBatchResponse proto.InternalMessageInfo
+
+func (m *ChaincodeBatchResponse) GetResponses() []*Response {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeBatch)(nil), "protos.ChaincodeBatch")
+	proto.RegisterType((*ChaincodeBatchResponse)(nil), "protos.ChaincodeBatchResponse")
+}
+
+func init() { proto.RegisterFile("peer/batch.proto", fileDescriptor_batch_3e1160cd9d4e2ae0) }
+
+var fileDescriptor_batch_3e1160cd9d4e2ae0 = []byte{
+	// 217 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x90, 0xc1, 0x4b, 0x04, 0x21,
+	0x14, 0xc6, 0x89, 0x20, 0xc8, 0x8d, 0x58, 0x86, 0x8a, 0x58, 0x3a, 0xc4, 0x9c, 0xea, 0xa2, 0x50,
+	0x87, 0xee, 0xd3, 0xa5, 0x6e, 0x31, 0xdd, 0x82, 0x08, 0x7d, 0xfb, 0x52, 0x69, 0xf3, 0xc9, 0xd3,
+	0x82, 0xfe, 0xfb, 0x18, 0x5d, 0x6b, 0xf6, 0x24, 0xf8, 0xfd, 0x7e, 0xdf, 0x87, 0x8a, 0x65, 0x44,
+	0x64, 0x65, 0x74, 0x06, 0x27, 0x23, 0x53, 0xa6, 0xee, 0xa0, 0x1c, 0x69, 0x75, 0x52, 0x12, 0x70,
+	0xda, 0x07, 0xa0, 0x35, 0xd6, 0x74, 0x75, 0x51, 0x6e, 0x23, 0x53, 0xa4, 0xa4, 0x37, 0x6f, 0x8c,
+	0x29, 0x52, 0x48, 0xdb, 0xb4, 0x7f, 0x14, 0xc7, 0xf7, 0x4d, 0x18, 0xa6, 0xce, 0xee, 0x4e, 0x2c,
+	0x7c, 0xf8, 0x26, 0xd0, 0xd9, 0x53, 0x48, 0xe7, 0x7b, 0x97, 0xfb, 0x57, 0x8b, 0x9b, 0xd3, 0x8a,
+	0x27, 0xf9, 0x07, 0x3f, 0x47, 0x84, 0x71, 0x4e, 0xf6, 0x0f, 0xe2, 0x6c, 0xb7, 0x6a, 0xdc, 0x4e,
+	0x75, 0x52, 0x1c, 0xb6, 0xd9, 0x56, 0xb8, 0x6c, 0x85, 0x0d, 0x1a, 0xff, 0x91, 0xe1, 0x55, 0xf4,
+	0xc4, 0x56, 0xba, 0x9f, 0x88, 0xbc, 0xc1, 0xb5, 0x45, 0x96, 0xef, 0xda, 0xb0, 0x87, 0x26, 0x4d,
+	0x4f, 0x1a, 0x8e, 0xca, 0xc8, 0x93, 0x86, 0x0f, 0x6d, 0xf1, 0xe5, 0xda, 0xfa, 0xec, 0xbe, 0x8c,
+	0x04, 0xfa, 0x54, 0x33, 0x51, 0x55, 0x51, 0x55, 0x51, 0x4d, 0xa2, 0xa9, 0xff, 0x75, 0xfb, 0x3b,
+	0x00, 0x83, 0xd9, 0xb5, 0xa0, 0x4a, 0x01, 0x00, 0x00,
+}
@@ -39,7 +39,7 @@ func (x ConfidentialityLevel) String() string {
 	return proto.EnumName(ConfidentialityLevel_name, int32(x))
 }
 func (ConfidentialityLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{0}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{0}
 }
 
 type ChaincodeSpec_Type int32
@@ -50,6 +50,7 @@ const (
 	ChaincodeSpec_NODE      ChaincodeSpec_Type = 2
 	ChaincodeSpec_CAR       ChaincodeSpec_Type = 3
 	ChaincodeSpec_JAVA      ChaincodeSpec_Type = 4
+	ChaincodeSpec_BINARY    ChaincodeSpec_Type = 5
 )
 
 var ChaincodeSpec_Type_name = map[int32]string{
@@ -58,6 +59,7 @@ var ChaincodeSpec_Type_name = map[int32]string{
 	2: "NODE",
 	3: "CAR",
 	4: "JAVA",
+	5: "BINARY",
 }
 var ChaincodeSpec_Type_value = map[string]int32{
 	"UNDEFINED": 0,
@@ -65,13 +67,14 @@ var ChaincodeSpec_Type_value = map[string]int32{
 	"NODE":      2,
 	"CAR":       3,
 	"JAVA":      4,
+	"BINARY":    5,
 }
 
 func (x ChaincodeSpec_Type) String() string {
 	return proto.EnumName(ChaincodeSpec_Type_name, int32(x))
 }
 func (ChaincodeSpec_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{2, 0}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{2, 0}
 }
 
 type ChaincodeDeploymentSpec_ExecutionEnvironment int32
@@ -94,7 +97,7 @@ func (x ChaincodeDeploymentSpec_ExecutionEnvironment) String() string {
 	return proto.EnumName(ChaincodeDeploymentSpec_ExecutionEnvironment_name, int32(x))
 }
 func (ChaincodeDeploymentSpec_ExecutionEnvironment) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{3, 0}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{3, 0}
 }
 
 // ChaincodeID contains the path as specified by the deploy transaction
@@ -121,7 +124,7 @@ func (m *ChaincodeID) Reset()         { *m = ChaincodeID{} }
 func (m *ChaincodeID) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeID) ProtoMessage()    {}
 func (*ChaincodeID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{0}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{0}
 }
 func (m *ChaincodeID) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeID.Unmarshal(m, b)
@@ -166,18 +169,21 @@ func (m *ChaincodeID) GetVersion() string {
 // UnmarshalJSON in transaction.go converts the string-based REST/JSON input to
 // the []byte-based current ChaincodeInput structure.
 type ChaincodeInput struct {
-	Args                 [][]byte          `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
-	Decorations          map[string][]byte `protobuf:"bytes,2,rep,name=decorations,proto3" json:"decorations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Args        [][]byte          `protobuf:"bytes,1,rep,name=args,proto3" json:"args,omitempty"`
+	Decorations map[string][]byte `protobuf:"bytes,2,rep,name=decorations,proto3" json:"decorations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// is_init is true when the invocation initializes a chaincode
+	// whose definition requires initialization
+	IsInit               bool     `protobuf:"varint,3,opt,name=is_init,json=isInit,proto3" json:"is_init,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChaincodeInput) Reset()         { *m = ChaincodeInput{} }
 func (m *ChaincodeInput) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInput) ProtoMessage()    {}
 func (*ChaincodeInput) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{1}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{1}
 }
 func (m *ChaincodeInput) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInput.Unmarshal(m, b)
@@ -211,6 +217,13 @@ func (m *ChaincodeInput) GetDecorations() map[string][]byte {
 	return nil
 }
 
+func (m *ChaincodeInput) GetIsInit() bool {
+	if m != nil {
+		return m.IsInit
+	}
+	return false
+}
+
 // Carries the chaincode specification. This is the actual metadata required for
 // defining a chaincode.
 type ChaincodeSpec struct {
@@ -227,7 +240,7 @@ func (m *ChaincodeSpec) Reset()         { *m = ChaincodeSpec{} }
 func (m *ChaincodeSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeSpec) ProtoMessage()    {}
 func (*ChaincodeSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{2}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{2}
 }
 func (m *ChaincodeSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeSpec.Unmarshal(m, b)
@@ -278,19 +291,35 @@ func (m *ChaincodeSpec) GetTimeout() int32 {
 // Specify the deployment of a chaincode.
 // TODO: Define `codePackage`.
 type ChaincodeDeploymentSpec struct {
-	ChaincodeSpec        *ChaincodeSpec                               `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec,proto3" json:"chaincode_spec,omitempty"`
-	CodePackage          []byte                                       `protobuf:"bytes,3,opt,name=code_package,json=codePackage,proto3" json:"code_package,omitempty"`
-	ExecEnv              ChaincodeDeploymentSpec_ExecutionEnvironment `protobuf:"varint,4,opt,name=exec_env,json=execEnv,proto3,enum=protos.ChaincodeDeploymentSpec_ExecutionEnvironment" json:"exec_env,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                                     `json:"-"`
-	XXX_unrecognized     []byte                                       `json:"-"`
-	XXX_sizecache        int32                                        `json:"-"`
+	ChaincodeSpec *ChaincodeSpec                               `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec,proto3" json:"chaincode_spec,omitempty"`
+	CodePackage   []byte                                       `protobuf:"bytes,3,opt,name=code_package,json=codePackage,proto3" json:"code_package,omitempty"`
+	ExecEnv       ChaincodeDeploymentSpec_ExecutionEnvironment `protobuf:"varint,4,opt,name=exec_env,json=execEnv,proto3,enum=protos.ChaincodeDeploymentSpec_ExecutionEnvironment" json:"exec_env,omitempty"`
+	// Upon upgrade, the peer invokes the Migrate function of the
+	// chaincode instead of its Init function
+	Migrate bool `protobuf:"varint,5,opt,name=migrate,proto3" json:"migrate,omitempty"`
+	// Annotations attached to the chaincode definition upon instantiation
+	// or upgrade, which are opaque to the peer
+	Annotations map[string]string `protobuf:"bytes,6,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The endorsement policies of the contracts exposed by the chaincode,
+	// by contract name. Each policy is a marshaled SignaturePolicyEnvelope
+	// which replaces the endorsement policy of the chaincode for the
+	// transactions invoking the functions of the contract
+	Contracts map[string][]byte `protobuf:"bytes,7,rep,name=contracts,proto3" json:"contracts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// When the chaincode definition requires initialization, the Init
+	// function of the chaincode is not invoked upon instantiation or upgrade,
+	// but by a subsequent invocation, which the peers enforce to happen
+	// exactly once before any other invocation of the chaincode
+	InitRequired         bool     `protobuf:"varint,8,opt,name=init_required,json=initRequired,proto3" json:"init_required,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChaincodeDeploymentSpec) Reset()         { *m = ChaincodeDeploymentSpec{} }
 func (m *ChaincodeDeploymentSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeDeploymentSpec) ProtoMessage()    {}
 func (*ChaincodeDeploymentSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{3}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{3}
 }
 func (m *ChaincodeDeploymentSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeDeploymentSpec.Unmarshal(m, b)
@@ -331,6 +360,34 @@ func (m *ChaincodeDeploymentSpec) GetExecEnv() ChaincodeDeploymentSpec_Execution
 	return ChaincodeDeploymentSpec_DOCKER
 }
 
+func (m *ChaincodeDeploymentSpec) GetMigrate() bool {
+	if m != nil {
+		return m.Migrate
+	}
+	return false
+}
+
+func (m *ChaincodeDeploymentSpec) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *ChaincodeDeploymentSpec) GetContracts() map[string][]byte {
+	if m != nil {
+		return m.Contracts
+	}
+	return nil
+}
+
+func (m *ChaincodeDeploymentSpec) GetInitRequired() bool {
+	if m != nil {
+		return m.InitRequired
+	}
+	return false
+}
+
 // Carries the chaincode function and its arguments.
 type ChaincodeInvocationSpec struct {
 	ChaincodeSpec        *ChaincodeSpec `protobuf:"bytes,1,opt,name=chaincode_spec,json=chaincodeSpec,proto3" json:"chaincode_spec,omitempty"`
@@ -343,7 +400,7 @@ func (m *ChaincodeInvocationSpec) Reset()         { *m = ChaincodeInvocationSpec
 func (m *ChaincodeInvocationSpec) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInvocationSpec) ProtoMessage()    {}
 func (*ChaincodeInvocationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{4}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{4}
 }
 func (m *ChaincodeInvocationSpec) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInvocationSpec.Unmarshal(m, b)
@@ -382,7 +439,7 @@ func (m *LifecycleEvent) Reset()         { *m = LifecycleEvent{} }
 func (m *LifecycleEvent) String() string { return proto.CompactTextString(m) }
 func (*LifecycleEvent) ProtoMessage()    {}
 func (*LifecycleEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_d56d9efe15650cad, []int{5}
+	return fileDescriptor_chaincode_bdf479a84b140ff7, []int{5}
 }
 func (m *LifecycleEvent) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LifecycleEvent.Unmarshal(m, b)
@@ -415,6 +472,8 @@ func init() {
 	proto.RegisterMapType((map[string][]byte)(nil), "protos.ChaincodeInput.DecorationsEntry")
 	proto.RegisterType((*ChaincodeSpec)(nil), "protos.ChaincodeSpec")
 	proto.RegisterType((*ChaincodeDeploymentSpec)(nil), "protos.ChaincodeDeploymentSpec")
+	proto.RegisterMapType((map[string]string)(nil), "protos.ChaincodeDeploymentSpec.AnnotationsEntry")
+	proto.RegisterMapType((map[string][]byte)(nil), "protos.ChaincodeDeploymentSpec.ContractsEntry")
 	proto.RegisterType((*ChaincodeInvocationSpec)(nil), "protos.ChaincodeInvocationSpec")
 	proto.RegisterType((*LifecycleEvent)(nil), "protos.LifecycleEvent")
 	proto.RegisterEnum("protos.ConfidentialityLevel", ConfidentialityLevel_name, ConfidentialityLevel_value)
@@ -422,48 +481,56 @@ func init() {
 	proto.RegisterEnum("protos.ChaincodeDeploymentSpec_ExecutionEnvironment", ChaincodeDeploymentSpec_ExecutionEnvironment_name, ChaincodeDeploymentSpec_ExecutionEnvironment_value)
 }
 
-func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor_chaincode_d56d9efe15650cad) }
-
-var fileDescriptor_chaincode_d56d9efe15650cad = []byte{
-	// 632 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x6d, 0x6f, 0xda, 0x3c,
-	0x14, 0x6d, 0x80, 0xbe, 0xdd, 0x50, 0x94, 0xc7, 0x0f, 0xcf, 0x33, 0xd4, 0x4f, 0x2c, 0xd2, 0x34,
-	0x36, 0x4d, 0x41, 0x62, 0xd5, 0x36, 0x4d, 0x53, 0x25, 0x4a, 0xd2, 0x2a, 0x1d, 0x83, 0xca, 0x6d,
-	0x27, 0x6d, 0x5f, 0x50, 0xea, 0x5c, 0xc0, 0x6a, 0x70, 0xa2, 0x60, 0xa2, 0xe6, 0x67, 0xec, 0x97,
-	0xec, 0x27, 0x6e, 0xb2, 0x53, 0x5e, 0xba, 0xf6, 0xdb, 0x3e, 0x71, 0x7d, 0x7c, 0x7c, 0xee, 0x3d,
-	0x07, 0xc7, 0x50, 0x4f, 0x10, 0xd3, 0x36, 0x9b, 0x06, 0x5c, 0xb0, 0x38, 0x44, 0x27, 0x49, 0x63,
-	0x19, 0x93, 0x1d, 0xfd, 0x33, 0xb7, 0x87, 0x60, 0xf6, 0x96, 0x5b, 0xbe, 0x4b, 0x08, 0x54, 0x92,
-	0x40, 0x4e, 0x1b, 0x46, 0xd3, 0x68, 0xed, 0x53, 0x5d, 0x2b, 0x4c, 0x04, 0x33, 0x6c, 0x94, 0x0a,
-	0x4c, 0xd5, 0xa4, 0x01, 0xbb, 0x19, 0xa6, 0x73, 0x1e, 0x8b, 0x46, 0x59, 0xc3, 0xcb, 0xa5, 0xfd,
-	0xd3, 0x80, 0xda, 0x5a, 0x51, 0x24, 0x0b, 0xa9, 0x04, 0x82, 0x74, 0x32, 0x6f, 0x18, 0xcd, 0x72,
-	0xab, 0x4a, 0x75, 0x4d, 0x7c, 0x30, 0x43, 0x64, 0x71, 0x1a, 0x48, 0x1e, 0x8b, 0x79, 0xa3, 0xd4,
-	0x2c, 0xb7, 0xcc, 0xce, 0xcb, 0x62, 0xb8, 0xb9, 0xf3, 0x50, 0xc0, 0x71, 0xd7, 0x4c, 0x4f, 0xc8,
-	0x34, 0xa7, 0x9b, 0x67, 0x0f, 0x8f, 0xc1, 0xfa, 0x93, 0x40, 0x2c, 0x28, 0xdf, 0x62, 0x7e, 0x6f,
-	0x43, 0x95, 0xa4, 0x0e, 0xdb, 0x59, 0x10, 0x2d, 0x0a, 0x1b, 0x55, 0x5a, 0x2c, 0x3e, 0x96, 0x3e,
-	0x18, 0xf6, 0x2f, 0x03, 0x0e, 0x56, 0x0d, 0x2f, 0x13, 0x64, 0xc4, 0x81, 0x8a, 0xcc, 0x13, 0xd4,
-	0xc7, 0x6b, 0x9d, 0xc3, 0x47, 0x53, 0x29, 0x92, 0x73, 0x95, 0x27, 0x48, 0x35, 0x8f, 0xbc, 0x83,
-	0xea, 0x2a, 0xdf, 0x11, 0x0f, 0x75, 0x0b, 0xb3, 0xf3, 0xef, 0x63, 0x37, 0x2e, 0x35, 0x57, 0x44,
-	0x3f, 0x24, 0x6f, 0x60, 0x9b, 0x2b, 0x83, 0x3a, 0x43, 0xb3, 0xf3, 0xff, 0xd3, 0xf6, 0x69, 0x41,
-	0x52, 0x99, 0x4b, 0x3e, 0xc3, 0x78, 0x21, 0x1b, 0x95, 0xa6, 0xd1, 0xda, 0xa6, 0xcb, 0xa5, 0x7d,
-	0x0c, 0x15, 0x35, 0x0d, 0x39, 0x80, 0xfd, 0xeb, 0x81, 0xeb, 0x9d, 0xfa, 0x03, 0xcf, 0xb5, 0xb6,
-	0x08, 0xc0, 0xce, 0xd9, 0xb0, 0xdf, 0x1d, 0x9c, 0x59, 0x06, 0xd9, 0x83, 0xca, 0x60, 0xe8, 0x7a,
-	0x56, 0x89, 0xec, 0x42, 0xb9, 0xd7, 0xa5, 0x56, 0x59, 0x41, 0xe7, 0xdd, 0xaf, 0x5d, 0xab, 0x62,
-	0xff, 0x28, 0xc1, 0xb3, 0x55, 0x4f, 0x17, 0x93, 0x28, 0xce, 0x67, 0x28, 0xa4, 0xce, 0xe2, 0x13,
-	0xd4, 0xd6, 0xde, 0xe6, 0x09, 0x32, 0x9d, 0x8a, 0xd9, 0xf9, 0xef, 0xc9, 0x54, 0xe8, 0x01, 0x7b,
-	0x90, 0xe4, 0x73, 0xa8, 0xea, 0x83, 0x49, 0xc0, 0x6e, 0x83, 0x09, 0x6a, 0xa3, 0x55, 0x6a, 0x2a,
-	0xec, 0xa2, 0x80, 0xc8, 0x10, 0xf6, 0xf0, 0x0e, 0xd9, 0x08, 0x45, 0xa6, 0x7d, 0xd5, 0x3a, 0x47,
-	0x8f, 0xa4, 0x1f, 0xce, 0xe4, 0x78, 0x77, 0xc8, 0x16, 0xea, 0xdf, 0xf6, 0x44, 0xc6, 0xd3, 0x58,
-	0xa8, 0x0d, 0xba, 0xab, 0x54, 0x3c, 0x91, 0xd9, 0x0e, 0xd4, 0x9f, 0x22, 0xa8, 0x38, 0xdc, 0x61,
-	0xef, 0xb3, 0x47, 0x8b, 0x68, 0x2e, 0xbf, 0x5d, 0x5e, 0x79, 0x5f, 0x2c, 0xe3, 0xbc, 0xb2, 0x57,
-	0xb2, 0xca, 0xb4, 0x86, 0xe3, 0x31, 0x32, 0xc9, 0x33, 0x1c, 0x85, 0x81, 0x44, 0x3b, 0xd9, 0x88,
-	0xc4, 0x17, 0x59, 0xcc, 0xf4, 0xf5, 0xfa, 0xfb, 0x48, 0xee, 0xdb, 0xfd, 0xc3, 0xc3, 0xd1, 0x04,
-	0x05, 0x16, 0xb7, 0x76, 0x14, 0x44, 0x13, 0xfb, 0x3d, 0xd4, 0xfa, 0x7c, 0x8c, 0x2c, 0x67, 0x11,
-	0x7a, 0x99, 0x9a, 0xf8, 0xc5, 0x66, 0x23, 0xfd, 0x0d, 0x16, 0x17, 0x7a, 0xad, 0x38, 0x08, 0x66,
-	0xf8, 0xfa, 0x08, 0xea, 0xbd, 0x58, 0x8c, 0x79, 0x88, 0x42, 0xf2, 0x20, 0xe2, 0x32, 0xef, 0x63,
-	0x86, 0x91, 0x32, 0x79, 0x71, 0x7d, 0xd2, 0xf7, 0x7b, 0xd6, 0x16, 0xb1, 0xa0, 0xda, 0x1b, 0x0e,
-	0x4e, 0x7d, 0xd7, 0x1b, 0x5c, 0xf9, 0xdd, 0xbe, 0x65, 0x9c, 0x0c, 0xc1, 0x8e, 0xd3, 0x89, 0x33,
-	0xcd, 0x13, 0x4c, 0x23, 0x0c, 0x27, 0x98, 0x3a, 0xe3, 0xe0, 0x26, 0xe5, 0x6c, 0xe9, 0x42, 0xbd,
-	0x1b, 0xdf, 0x5f, 0x4d, 0xb8, 0x9c, 0x2e, 0x6e, 0x1c, 0x16, 0xcf, 0xda, 0x1b, 0xd4, 0x76, 0x41,
-	0x6d, 0x17, 0xd4, 0xb6, 0xa2, 0xde, 0x14, 0x4f, 0xca, 0xdb, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0xea, 0x1f, 0x3b, 0xe4, 0x71, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/chaincode.proto", fileDescriptor_chaincode_bdf479a84b140ff7) }
+
+var fileDescriptor_chaincode_bdf479a84b140ff7 = []byte{
+	// 757 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x6d, 0x6f, 0xe3, 0x44,
+	0x10, 0x3e, 0xe7, 0xa5, 0x49, 0xc7, 0x69, 0x64, 0x96, 0xc2, 0x59, 0xfd, 0x54, 0x82, 0x10, 0x05,
+	0x21, 0x07, 0x85, 0x13, 0x20, 0x74, 0x42, 0x4a, 0x63, 0xdf, 0xc9, 0x25, 0x38, 0xa7, 0x6d, 0x0f,
+	0xe9, 0xf8, 0x12, 0x6d, 0xd7, 0x13, 0x77, 0x75, 0xc9, 0xda, 0xd8, 0x1b, 0xeb, 0xfc, 0x1b, 0xf8,
+	0x63, 0xfc, 0x1c, 0x7e, 0x02, 0xda, 0x75, 0xd2, 0xa4, 0x2f, 0x52, 0x85, 0xee, 0x53, 0x76, 0xc6,
+	0xcf, 0x3c, 0x33, 0xcf, 0xec, 0x93, 0x85, 0xe3, 0x0c, 0x31, 0x1f, 0xf2, 0x1b, 0x26, 0x24, 0x4f,
+	0x63, 0xf4, 0xb2, 0x3c, 0x55, 0x29, 0x39, 0x30, 0x3f, 0xc5, 0x60, 0x06, 0xf6, 0x64, 0xfb, 0x29,
+	0xf4, 0x09, 0x81, 0x56, 0xc6, 0xd4, 0x8d, 0x6b, 0x9d, 0x5a, 0x67, 0x87, 0xd4, 0x9c, 0x75, 0x4e,
+	0xb2, 0x15, 0xba, 0x8d, 0x3a, 0xa7, 0xcf, 0xc4, 0x85, 0x4e, 0x89, 0x79, 0x21, 0x52, 0xe9, 0x36,
+	0x4d, 0x7a, 0x1b, 0x0e, 0xfe, 0xb1, 0xa0, 0xbf, 0x63, 0x94, 0xd9, 0x5a, 0x69, 0x02, 0x96, 0x27,
+	0x85, 0x6b, 0x9d, 0x36, 0xcf, 0x7a, 0xd4, 0x9c, 0x49, 0x08, 0x76, 0x8c, 0x3c, 0xcd, 0x99, 0x12,
+	0xa9, 0x2c, 0xdc, 0xc6, 0x69, 0xf3, 0xcc, 0x1e, 0x7d, 0x5d, 0x0f, 0x57, 0x78, 0x77, 0x09, 0x3c,
+	0x7f, 0x87, 0x0c, 0xa4, 0xca, 0x2b, 0xba, 0x5f, 0x4b, 0x9e, 0x43, 0x47, 0x14, 0x73, 0x21, 0x85,
+	0x32, 0xb3, 0x74, 0xe9, 0x81, 0x28, 0x42, 0x29, 0xd4, 0xc9, 0xaf, 0xe0, 0xdc, 0xaf, 0x24, 0x0e,
+	0x34, 0xdf, 0x63, 0xb5, 0xd1, 0xa7, 0x8f, 0xe4, 0x18, 0xda, 0x25, 0x5b, 0xae, 0x6b, 0x7d, 0x3d,
+	0x5a, 0x07, 0xbf, 0x34, 0x7e, 0xb6, 0x06, 0x7f, 0x37, 0xe0, 0xe8, 0x76, 0x92, 0xcb, 0x0c, 0x39,
+	0xf1, 0xa0, 0xa5, 0xaa, 0x0c, 0x4d, 0x79, 0x7f, 0x74, 0xf2, 0x60, 0x5c, 0x0d, 0xf2, 0xae, 0xaa,
+	0x0c, 0xa9, 0xc1, 0x91, 0x1f, 0xa1, 0x77, 0xbb, 0xf8, 0xb9, 0x88, 0x4d, 0x0b, 0x7b, 0xf4, 0xe9,
+	0x43, 0x99, 0x3e, 0xb5, 0x6f, 0x81, 0x61, 0x4c, 0xbe, 0x83, 0xb6, 0xd0, 0xca, 0x8d, 0x20, 0x7b,
+	0xf4, 0xf9, 0xe3, 0x7b, 0xa1, 0x35, 0x48, 0x5f, 0x86, 0x12, 0x2b, 0x4c, 0xd7, 0xca, 0x6d, 0x9d,
+	0x5a, 0x67, 0x6d, 0xba, 0x0d, 0x07, 0x17, 0xd0, 0xd2, 0xd3, 0x90, 0x23, 0x38, 0x7c, 0x1b, 0xf9,
+	0xc1, 0xab, 0x30, 0x0a, 0x7c, 0xe7, 0x19, 0x01, 0x38, 0x78, 0x3d, 0x9b, 0x8e, 0xa3, 0xd7, 0x8e,
+	0x45, 0xba, 0xd0, 0x8a, 0x66, 0x7e, 0xe0, 0x34, 0x48, 0x07, 0x9a, 0x93, 0x31, 0x75, 0x9a, 0x3a,
+	0x75, 0x31, 0xfe, 0x63, 0xec, 0xb4, 0x34, 0xf0, 0x3c, 0x8c, 0xc6, 0xf4, 0x9d, 0xd3, 0x1e, 0xfc,
+	0xdb, 0x82, 0xe7, 0xb7, 0xfd, 0x7d, 0xcc, 0x96, 0x69, 0xb5, 0x42, 0xa9, 0xcc, 0x5e, 0x5e, 0x42,
+	0x7f, 0xa7, 0xb3, 0xc8, 0x90, 0x9b, 0x0d, 0xd9, 0xa3, 0xcf, 0x1e, 0xdd, 0x10, 0x3d, 0xe2, 0xfb,
+	0x21, 0xf9, 0x02, 0x7a, 0xa6, 0x30, 0x63, 0xfc, 0x3d, 0x4b, 0xd0, 0x88, 0xee, 0x51, 0x5b, 0xe7,
+	0xde, 0xd4, 0x29, 0x32, 0x83, 0x2e, 0x7e, 0x40, 0x3e, 0x47, 0x59, 0x1a, 0x8d, 0xfd, 0xd1, 0x8b,
+	0x07, 0xd4, 0x77, 0x67, 0xf2, 0x82, 0x0f, 0xc8, 0xd7, 0xfa, 0xe6, 0x03, 0x59, 0x8a, 0x3c, 0x95,
+	0xfa, 0x03, 0xed, 0x68, 0x96, 0x40, 0x96, 0x7a, 0x67, 0x2b, 0x91, 0xe4, 0x4c, 0xa1, 0xdb, 0x36,
+	0xa6, 0xd9, 0x86, 0x84, 0x82, 0xcd, 0xa4, 0x4c, 0xd5, 0xc6, 0x99, 0x07, 0xc6, 0x99, 0xdf, 0x3f,
+	0xd5, 0x6d, 0xbc, 0x2b, 0xd9, 0x58, 0x74, 0x8f, 0x84, 0x4c, 0xe1, 0x90, 0xa7, 0x52, 0xe5, 0x8c,
+	0xab, 0xc2, 0xed, 0x18, 0x46, 0xef, 0x29, 0xc6, 0xc9, 0xb6, 0xa0, 0xe6, 0xdb, 0x11, 0x90, 0x2f,
+	0xe1, 0x48, 0xbb, 0x7d, 0x9e, 0xe3, 0x5f, 0x6b, 0x91, 0x63, 0xec, 0x76, 0x8d, 0x82, 0x9e, 0x4e,
+	0xd2, 0x4d, 0x4e, 0x9b, 0xff, 0xfe, 0x4c, 0x4f, 0x99, 0xff, 0x70, 0xcf, 0xfc, 0x27, 0x2f, 0xa1,
+	0x7f, 0x77, 0x82, 0xff, 0xf5, 0xd7, 0xf1, 0xe0, 0xf8, 0xb1, 0xfd, 0x6b, 0x43, 0xf9, 0xb3, 0xc9,
+	0x6f, 0x01, 0xad, 0x5d, 0x78, 0xf9, 0xee, 0xf2, 0x2a, 0xf8, 0xdd, 0xb1, 0x2e, 0x5a, 0xdd, 0x86,
+	0xd3, 0xa4, 0x7d, 0x5c, 0x2c, 0x90, 0x2b, 0x51, 0xe2, 0x3c, 0x66, 0x0a, 0x07, 0xd9, 0x9e, 0xe3,
+	0x42, 0x59, 0xa6, 0xdc, 0x88, 0xf9, 0x78, 0xc7, 0x6d, 0xda, 0x7d, 0x22, 0xe2, 0x79, 0x82, 0x12,
+	0xeb, 0x07, 0x62, 0xce, 0x96, 0xc9, 0xe0, 0x27, 0xe8, 0x4f, 0xc5, 0x02, 0x79, 0xc5, 0x97, 0x18,
+	0x94, 0x7a, 0xe2, 0xaf, 0xf6, 0x1b, 0x99, 0x77, 0xb0, 0x5e, 0xc0, 0x8e, 0x31, 0x62, 0x2b, 0xfc,
+	0xf6, 0x05, 0x1c, 0x4f, 0x52, 0xb9, 0x10, 0x31, 0x4a, 0x25, 0xd8, 0x52, 0xa8, 0x6a, 0x8a, 0x25,
+	0x2e, 0xb5, 0xc8, 0x37, 0x6f, 0xcf, 0xa7, 0xe1, 0xc4, 0x79, 0x46, 0x1c, 0xe8, 0x4d, 0x66, 0xd1,
+	0xab, 0xd0, 0x0f, 0xa2, 0xab, 0x70, 0x3c, 0x75, 0xac, 0xf3, 0x19, 0x0c, 0xd2, 0x3c, 0xf1, 0x6e,
+	0xaa, 0x0c, 0xf3, 0x25, 0xc6, 0x09, 0xe6, 0xde, 0x82, 0x5d, 0xe7, 0x82, 0x6f, 0x55, 0xe8, 0xb7,
+	0xfb, 0xcf, 0x6f, 0x12, 0xa1, 0x6e, 0xd6, 0xd7, 0x1e, 0x4f, 0x57, 0xc3, 0x3d, 0xe8, 0xb0, 0x86,
+	0x0e, 0x6b, 0xe8, 0x50, 0x43, 0xaf, 0xeb, 0x67, 0xfd, 0x87, 0xff, 0x06, 0x00, 0x26, 0x24, 0x7f,
+	0x19, 0xf5, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,199 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/chaincode_channel_config.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ChannelConfigSnapshot holds the values of the channel config returned to a
+// chaincode by GET_CHANNEL_CONFIG, as of the state read by the transaction.
+// The organizations and the capabilities are sorted by name, and the groups
+// of the Application group which are not organizations are returned as is
+type ChannelConfigSnapshot struct {
+	Sequence                 uint64                         `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	ApplicationOrganizations []*ChannelConfigOrganization   `protobuf:"bytes,2,rep,name=application_organizations,json=applicationOrganizations,proto3" json:"application_organizations,omitempty"`
+	OrdererOrganizations     []*ChannelConfigOrganization   `protobuf:"bytes,3,rep,name=orderer_organizations,json=ordererOrganizations,proto3" json:"orderer_organizations,omitempty"`
+	ChannelCapabilities      []string                       `protobuf:"bytes,4,rep,name=channel_capabilities,json=channelCapabilities,proto3" json:"channel_capabilities,omitempty"`
+	OrdererCapabilities      []string                       `protobuf:"bytes,5,rep,name=orderer_capabilities,json=ordererCapabilities,proto3" json:"orderer_capabilities,omitempty"`
+	ApplicationCapabilities  []string                       `protobuf:"bytes,6,rep,name=application_capabilities,json=applicationCapabilities,proto3" json:"application_capabilities,omitempty"`
+	ApplicationGroups        map[string]*common.ConfigGroup `protobuf:"bytes,7,rep,name=application_groups,json=applicationGroups,proto3" json:"application_groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral     struct{}                       `json:"-"`
+	XXX_unrecognized         []byte                         `json:"-"`
+	XXX_sizecache            int32                          `json:"-"`
+}
+
+func (m *ChannelConfigSnapshot) Reset()         { *m = ChannelConfigSnapshot{} }
+func (m *ChannelConfigSnapshot) String() string { return proto.CompactTextString(m) }
+func (*ChannelConfigSnapshot) ProtoMessage()    {}
+func (*ChannelConfigSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_channel_config_86b4a805ca98ffbb, []int{0}
+}
+func (m *ChannelConfigSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelConfigSnapshot.Unmarshal(m, b)
+}
+func (m *ChannelConfigSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelConfigSnapshot.Marshal(b, m, deterministic)
+}
+func (dst *ChannelConfigSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelConfigSnapshot.Merge(dst, src)
+}
+func (m *ChannelConfigSnapshot) XXX_Size() int {
+	return xxx_messageInfo_ChannelConfigSnapshot.Size(m)
+}
+func (m *ChannelConfigSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelConfigSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelConfigSnapshot proto.InternalMessageInfo
+
+func (m *ChannelConfigSnapshot) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *ChannelConfigSnapshot) GetApplicationOrganizations() []*ChannelConfigOrganization {
+	if m != nil {
+		return m.ApplicationOrganizations
+	}
+	return nil
+}
+
+func (m *ChannelConfigSnapshot) GetOrdererOrganizations() []*ChannelConfigOrganization {
+	if m != nil {
+		return m.OrdererOrganizations
+	}
+	return nil
+}
+
+func (m *ChannelConfigSnapshot) GetChannelCapabilities() []string {
+	if m != nil {
+		return m.ChannelCapabilities
+	}
+	return nil
+}
+
+func (m *ChannelConfigSnapshot) GetOrdererCapabilities() []string {
+	if m != nil {
+		return m.OrdererCapabilities
+	}
+	return nil
+}
+
+func (m *ChannelConfigSnapshot) GetApplicationCapabilities() []string {
+	if m != nil {
+		return m.ApplicationCapabilities
+	}
+	return nil
+}
+
+func (m *ChannelConfigSnapshot) GetApplicationGroups() map[string]*common.ConfigGroup {
+	if m != nil {
+		return m.ApplicationGroups
+	}
+	return nil
+}
+
+// ChannelConfigOrganization is an organization of the channel config, which
+// is identified by the name of its group and by the ID of its MSP
+type ChannelConfigOrganization struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MspId                string   `protobuf:"bytes,2,opt,name=msp_id,json=mspId,proto3" json:"msp_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChannelConfigOrganization) Reset()         { *m = ChannelConfigOrganization{} }
+func (m *ChannelConfigOrganization) String() string { return proto.CompactTextString(m) }
+func (*ChannelConfigOrganization) ProtoMessage()    {}
+func (*ChannelConfigOrganization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_channel_config_86b4a805ca98ffbb, []int{1}
+}
+func (m *ChannelConfigOrganization) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChannelConfigOrganization.Unmarshal(m, b)
+}
+func (m *ChannelConfigOrganization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChannelConfigOrganization.Marshal(b, m, deterministic)
+}
+func (dst *ChannelConfigOrganization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelConfigOrganization.Merge(dst, src)
+}
+func (m *ChannelConfigOrganization) XXX_Size() int {
+	return xxx_messageInfo_ChannelConfigOrganization.Size(m)
+}
+func (m *ChannelConfigOrganization) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelConfigOrganization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelConfigOrganization proto.InternalMessageInfo
+
+func (m *ChannelConfigOrganization) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ChannelConfigOrganization) GetMspId() string {
+	if m != nil {
+		return m.MspId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ChannelConfigSnapshot)(nil), "protos.ChannelConfigSnapshot")
+	proto.RegisterMapType((map[string]*common.ConfigGroup)(nil), "protos.ChannelConfigSnapshot.ApplicationGroupsEntry")
+	proto.RegisterType((*ChannelConfigOrganization)(nil), "protos.ChannelConfigOrganization")
+}
+
+func init() {
+	proto.RegisterFile("peer/chaincode_channel_config.proto", fileDescriptor_chaincode_channel_config_86b4a805ca98ffbb)
+}
+
+var fileDescriptor_chaincode_channel_config_86b4a805ca98ffbb = []byte{
+	// 419 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0x41, 0x8f, 0x94, 0x30,
+	0x14, 0xc7, 0x33, 0x33, 0xcc, 0xe8, 0x74, 0x2f, 0xda, 0x5d, 0x94, 0x9d, 0xc4, 0x04, 0xc7, 0x0b,
+	0x7b, 0x81, 0xb8, 0x7a, 0x50, 0x6f, 0x2e, 0x51, 0xe3, 0x49, 0x83, 0x89, 0x89, 0x1e, 0x24, 0xa5,
+	0xbc, 0x85, 0x66, 0xa1, 0xad, 0x2d, 0x18, 0xc7, 0xcf, 0xe8, 0x87, 0x32, 0xb4, 0xb0, 0x96, 0xcd,
+	0x9a, 0x78, 0xa2, 0xe5, 0xff, 0x7e, 0xff, 0xd7, 0xd7, 0xf7, 0x8a, 0x9e, 0x48, 0x00, 0x95, 0xd0,
+	0x9a, 0x30, 0x4e, 0x45, 0x09, 0x39, 0xad, 0x09, 0xe7, 0xd0, 0xe4, 0x54, 0xf0, 0x4b, 0x56, 0xc5,
+	0x52, 0x89, 0x4e, 0xe0, 0x8d, 0xf9, 0xe8, 0x9d, 0x4f, 0x45, 0xdb, 0x0a, 0x9e, 0x58, 0xb1, 0xfb,
+	0x69, 0xe5, 0xfd, 0x6f, 0x0f, 0xf9, 0xa9, 0xe5, 0x52, 0xa3, 0x7c, 0xe2, 0x44, 0xea, 0x5a, 0x74,
+	0x78, 0x87, 0xee, 0x6a, 0xf8, 0xde, 0x03, 0xa7, 0x10, 0x2c, 0xc2, 0x45, 0xe4, 0x65, 0xd7, 0x7b,
+	0xfc, 0x0d, 0x9d, 0x12, 0x29, 0x1b, 0x46, 0x49, 0xc7, 0x04, 0xcf, 0x85, 0xaa, 0x08, 0x67, 0xbf,
+	0xcc, 0x46, 0x07, 0xcb, 0x70, 0x15, 0x1d, 0x9d, 0x3f, 0xb6, 0x09, 0x74, 0x3c, 0x73, 0xff, 0xe0,
+	0x44, 0x66, 0x81, 0xe3, 0xe1, 0x0a, 0x1a, 0x7f, 0x46, 0xbe, 0x50, 0x25, 0x28, 0x50, 0x37, 0xbc,
+	0x57, 0xff, 0xeb, 0x7d, 0x32, 0xf2, 0x73, 0xdf, 0xa7, 0xe8, 0xe4, 0xfa, 0x92, 0x88, 0x24, 0x05,
+	0x6b, 0x58, 0xc7, 0x40, 0x07, 0x5e, 0xb8, 0x8a, 0xb6, 0xd9, 0xf1, 0xa8, 0xa5, 0x8e, 0x34, 0x20,
+	0xd3, 0x51, 0x66, 0xc8, 0xda, 0x22, 0xa3, 0x36, 0x43, 0x5e, 0x22, 0xb7, 0xb2, 0x39, 0xb6, 0x31,
+	0xd8, 0x43, 0x47, 0x9f, 0xa1, 0x14, 0x61, 0x17, 0xad, 0x94, 0xe8, 0xa5, 0x0e, 0xee, 0x98, 0xaa,
+	0x9f, 0xdf, 0x5a, 0xf5, 0xd4, 0xaf, 0xf8, 0xf5, 0x5f, 0xee, 0x9d, 0xc1, 0xde, 0xf0, 0x4e, 0x1d,
+	0xb2, 0xfb, 0xe4, 0xe6, 0xff, 0xdd, 0x17, 0xf4, 0xe0, 0xf6, 0x60, 0x7c, 0x0f, 0xad, 0xae, 0xe0,
+	0x60, 0xda, 0xbd, 0xcd, 0x86, 0x25, 0x3e, 0x43, 0xeb, 0x1f, 0xa4, 0xe9, 0x21, 0x58, 0x86, 0x8b,
+	0xe8, 0xe8, 0xfc, 0x38, 0xb6, 0x63, 0x14, 0xdb, 0xe4, 0x86, 0xcd, 0x6c, 0xc4, 0xab, 0xe5, 0x8b,
+	0xc5, 0xfe, 0x2d, 0x3a, 0xfd, 0x67, 0x4f, 0x30, 0x46, 0x1e, 0x27, 0x2d, 0x8c, 0xf6, 0x66, 0x8d,
+	0x7d, 0xb4, 0x69, 0xb5, 0xcc, 0x59, 0x69, 0x12, 0x6c, 0xb3, 0x75, 0xab, 0xe5, 0xfb, 0xf2, 0x82,
+	0xa3, 0xbd, 0x50, 0x55, 0x5c, 0x1f, 0x24, 0xa8, 0x06, 0xca, 0x0a, 0x54, 0x7c, 0x49, 0x0a, 0xc5,
+	0xe8, 0x74, 0x07, 0xc3, 0xe8, 0x5f, 0x3c, 0x4a, 0xa7, 0xd9, 0x9f, 0x25, 0xfd, 0x48, 0xe8, 0x15,
+	0xa9, 0xe0, 0xeb, 0x59, 0xc5, 0xba, 0xba, 0x2f, 0x86, 0x23, 0x27, 0x8e, 0x53, 0x62, 0x9d, 0x12,
+	0xeb, 0x94, 0x0c, 0x4e, 0x85, 0x7d, 0x25, 0xcf, 0xfe, 0x0c, 0x00, 0xca, 0x18, 0x68, 0x76, 0x53,
+	0x03, 0x00, 0x00,
+}
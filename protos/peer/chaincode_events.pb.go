@@ -0,0 +1,429 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/chaincode_events.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ChaincodeEventsRequest holds either a subscription or an ack
+type ChaincodeEventsRequest struct {
+	Subscription         *ChaincodeEventsSubscription `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	Ack                  *ChaincodeEventsAck          `protobuf:"bytes,2,opt,name=ack,proto3" json:"ack,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *ChaincodeEventsRequest) Reset()         { *m = ChaincodeEventsRequest{} }
+func (m *ChaincodeEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsRequest) ProtoMessage()    {}
+func (*ChaincodeEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_events_6e44f1b7bb4421db, []int{0}
+}
+func (m *ChaincodeEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsRequest.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsRequest.Merge(dst, src)
+}
+func (m *ChaincodeEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsRequest.Size(m)
+}
+func (m *ChaincodeEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsRequest proto.InternalMessageInfo
+
+func (m *ChaincodeEventsRequest) GetSubscription() *ChaincodeEventsSubscription {
+	if m != nil {
+		return m.Subscription
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsRequest) GetAck() *ChaincodeEventsAck {
+	if m != nil {
+		return m.Ack
+	}
+	return nil
+}
+
+// ChaincodeEventsSubscription subscribes a consumer to the events of a
+// chaincode
+type ChaincodeEventsSubscription struct {
+	// seek_info is an Envelope of type DELIVER_SEEK_INFO with a marshaled
+	// orderer.SeekInfo as payload data, signed by the client identity of the
+	// consumer. When its resume flag is set, the delivery starts after the
+	// last block the consumer acknowledged rather than at its start position
+	SeekInfo *common.Envelope `protobuf:"bytes,1,opt,name=seek_info,json=seekInfo,proto3" json:"seek_info,omitempty"`
+	// consumer_id distinguishes the consumers of the same client identity,
+	// each of which has its own acknowledged block
+	ConsumerId string `protobuf:"bytes,2,opt,name=consumer_id,json=consumerId,proto3" json:"consumer_id,omitempty"`
+	// chaincode_id is the name of the chaincode whose events are delivered,
+	// the events of all the chaincodes being delivered if it is empty
+	ChaincodeId          string   `protobuf:"bytes,3,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeEventsSubscription) Reset()         { *m = ChaincodeEventsSubscription{} }
+func (m *ChaincodeEventsSubscription) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsSubscription) ProtoMessage()    {}
+func (*ChaincodeEventsSubscription) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_events_6e44f1b7bb4421db, []int{1}
+}
+func (m *ChaincodeEventsSubscription) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsSubscription.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsSubscription) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsSubscription.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventsSubscription) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsSubscription.Merge(dst, src)
+}
+func (m *ChaincodeEventsSubscription) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsSubscription.Size(m)
+}
+func (m *ChaincodeEventsSubscription) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsSubscription.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsSubscription proto.InternalMessageInfo
+
+func (m *ChaincodeEventsSubscription) GetSeekInfo() *common.Envelope {
+	if m != nil {
+		return m.SeekInfo
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsSubscription) GetConsumerId() string {
+	if m != nil {
+		return m.ConsumerId
+	}
+	return ""
+}
+
+func (m *ChaincodeEventsSubscription) GetChaincodeId() string {
+	if m != nil {
+		return m.ChaincodeId
+	}
+	return ""
+}
+
+// ChaincodeEventsAck acknowledges that the consumer processed the events of
+// the block with the given number and of all the blocks before it, which are
+// never delivered to it again
+type ChaincodeEventsAck struct {
+	BlockNumber          uint64   `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ChaincodeEventsAck) Reset()         { *m = ChaincodeEventsAck{} }
+func (m *ChaincodeEventsAck) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsAck) ProtoMessage()    {}
+func (*ChaincodeEventsAck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_events_6e44f1b7bb4421db, []int{2}
+}
+func (m *ChaincodeEventsAck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsAck.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsAck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsAck.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventsAck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsAck.Merge(dst, src)
+}
+func (m *ChaincodeEventsAck) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsAck.Size(m)
+}
+func (m *ChaincodeEventsAck) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsAck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsAck proto.InternalMessageInfo
+
+func (m *ChaincodeEventsAck) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+// ChaincodeEventsResponse holds either the status terminating the delivery of
+// a subscription or a block of events
+type ChaincodeEventsResponse struct {
+	Status               common.Status         `protobuf:"varint,1,opt,name=status,proto3,enum=common.Status" json:"status,omitempty"`
+	Block                *ChaincodeEventsBlock `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ChaincodeEventsResponse) Reset()         { *m = ChaincodeEventsResponse{} }
+func (m *ChaincodeEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsResponse) ProtoMessage()    {}
+func (*ChaincodeEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_events_6e44f1b7bb4421db, []int{3}
+}
+func (m *ChaincodeEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsResponse.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsResponse.Merge(dst, src)
+}
+func (m *ChaincodeEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsResponse.Size(m)
+}
+func (m *ChaincodeEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsResponse proto.InternalMessageInfo
+
+func (m *ChaincodeEventsResponse) GetStatus() common.Status {
+	if m != nil {
+		return m.Status
+	}
+	return common.Status_UNKNOWN
+}
+
+func (m *ChaincodeEventsResponse) GetBlock() *ChaincodeEventsBlock {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+// ChaincodeEventsBlock holds the events of the valid transactions of a block,
+// in the order of the transactions. The blocks without events of the chaincode
+// are not delivered
+type ChaincodeEventsBlock struct {
+	BlockNumber          uint64            `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Events               []*ChaincodeEvent `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ChaincodeEventsBlock) Reset()         { *m = ChaincodeEventsBlock{} }
+func (m *ChaincodeEventsBlock) String() string { return proto.CompactTextString(m) }
+func (*ChaincodeEventsBlock) ProtoMessage()    {}
+func (*ChaincodeEventsBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_events_6e44f1b7bb4421db, []int{4}
+}
+func (m *ChaincodeEventsBlock) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ChaincodeEventsBlock.Unmarshal(m, b)
+}
+func (m *ChaincodeEventsBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ChaincodeEventsBlock.Marshal(b, m, deterministic)
+}
+func (dst *ChaincodeEventsBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChaincodeEventsBlock.Merge(dst, src)
+}
+func (m *ChaincodeEventsBlock) XXX_Size() int {
+	return xxx_messageInfo_ChaincodeEventsBlock.Size(m)
+}
+func (m *ChaincodeEventsBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChaincodeEventsBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChaincodeEventsBlock proto.InternalMessageInfo
+
+func (m *ChaincodeEventsBlock) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventsBlock) GetEvents() []*ChaincodeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ChaincodeEventsRequest)(nil), "protos.ChaincodeEventsRequest")
+	proto.RegisterType((*ChaincodeEventsSubscription)(nil), "protos.ChaincodeEventsSubscription")
+	proto.RegisterType((*ChaincodeEventsAck)(nil), "protos.ChaincodeEventsAck")
+	proto.RegisterType((*ChaincodeEventsResponse)(nil), "protos.ChaincodeEventsResponse")
+	proto.RegisterType((*ChaincodeEventsBlock)(nil), "protos.ChaincodeEventsBlock")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ChaincodeEventsClient is the client API for ChaincodeEvents service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ChaincodeEventsClient interface {
+	// Deliver first requires a request with a subscription, then a stream of
+	// blocks of events is received, terminated by a status when the stop
+	// position of the subscription is reached. The consumer acknowledges the
+	// blocks of events with requests with an ack.
+	Deliver(ctx context.Context, opts ...grpc.CallOption) (ChaincodeEvents_DeliverClient, error)
+}
+
+type chaincodeEventsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeEventsClient(cc *grpc.ClientConn) ChaincodeEventsClient {
+	return &chaincodeEventsClient{cc}
+}
+
+func (c *chaincodeEventsClient) Deliver(ctx context.Context, opts ...grpc.CallOption) (ChaincodeEvents_DeliverClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ChaincodeEvents_serviceDesc.Streams[0], "/protos.ChaincodeEvents/Deliver", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeEventsDeliverClient{stream}
+	return x, nil
+}
+
+type ChaincodeEvents_DeliverClient interface {
+	Send(*ChaincodeEventsRequest) error
+	Recv() (*ChaincodeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type chaincodeEventsDeliverClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeEventsDeliverClient) Send(m *ChaincodeEventsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeEventsDeliverClient) Recv() (*ChaincodeEventsResponse, error) {
+	m := new(ChaincodeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ChaincodeEventsServer is the server API for ChaincodeEvents service.
+type ChaincodeEventsServer interface {
+	// Deliver first requires a request with a subscription, then a stream of
+	// blocks of events is received, terminated by a status when the stop
+	// position of the subscription is reached. The consumer acknowledges the
+	// blocks of events with requests with an ack.
+	Deliver(ChaincodeEvents_DeliverServer) error
+}
+
+func RegisterChaincodeEventsServer(s *grpc.Server, srv ChaincodeEventsServer) {
+	s.RegisterService(&_ChaincodeEvents_serviceDesc, srv)
+}
+
+func _ChaincodeEvents_Deliver_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeEventsServer).Deliver(&chaincodeEventsDeliverServer{stream})
+}
+
+type ChaincodeEvents_DeliverServer interface {
+	Send(*ChaincodeEventsResponse) error
+	Recv() (*ChaincodeEventsRequest, error)
+	grpc.ServerStream
+}
+
+type chaincodeEventsDeliverServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeEventsDeliverServer) Send(m *ChaincodeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeEventsDeliverServer) Recv() (*ChaincodeEventsRequest, error) {
+	m := new(ChaincodeEventsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _ChaincodeEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.ChaincodeEvents",
+	HandlerType: (*ChaincodeEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Deliver",
+			Handler:       _ChaincodeEvents_Deliver_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/chaincode_events.proto",
+}
+
+func init() {
+	proto.RegisterFile("peer/chaincode_events.proto", fileDescriptor_chaincode_events_6e44f1b7bb4421db)
+}
+
+var fileDescriptor_chaincode_events_6e44f1b7bb4421db = []byte{
+	// 418 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xcf, 0x6b, 0xd4, 0x40,
+	0x14, 0x36, 0x5d, 0x5d, 0xed, 0x4b, 0xa9, 0x32, 0x4a, 0x0d, 0xa9, 0xd8, 0x1a, 0x41, 0x56, 0xd0,
+	0x44, 0xe2, 0xc1, 0xb3, 0xab, 0x45, 0xf6, 0x22, 0x65, 0x7a, 0xf3, 0x12, 0x92, 0xc9, 0xdb, 0xec,
+	0x90, 0x64, 0x26, 0xce, 0x24, 0x0b, 0xfe, 0x15, 0xfa, 0x27, 0x4b, 0x66, 0x12, 0xad, 0xdb, 0x8d,
+	0xf4, 0x34, 0xf0, 0xbe, 0x1f, 0xf3, 0xcd, 0xc7, 0x1b, 0x38, 0x6d, 0x10, 0x55, 0xc4, 0x36, 0x29,
+	0x17, 0x4c, 0xe6, 0x98, 0xe0, 0x16, 0x45, 0xab, 0xc3, 0x46, 0xc9, 0x56, 0x92, 0xb9, 0x39, 0xb4,
+	0xff, 0x98, 0xc9, 0xba, 0x96, 0x22, 0xb2, 0x87, 0x05, 0x7d, 0x7f, 0x9f, 0xd2, 0x62, 0xc1, 0x4f,
+	0x07, 0x4e, 0x3e, 0x8d, 0xc8, 0x85, 0xb1, 0xa4, 0xf8, 0xbd, 0x43, 0xdd, 0x92, 0x2f, 0x70, 0xa4,
+	0xbb, 0x4c, 0x33, 0xc5, 0x9b, 0x96, 0x4b, 0xe1, 0x39, 0xe7, 0xce, 0xc2, 0x8d, 0x5f, 0x5a, 0xa1,
+	0x0e, 0x77, 0x54, 0x57, 0xd7, 0xa8, 0xf4, 0x1f, 0x21, 0x79, 0x03, 0xb3, 0x94, 0x95, 0xde, 0x81,
+	0xd1, 0xfb, 0x13, 0xfa, 0x8f, 0xac, 0xa4, 0x3d, 0x2d, 0xf8, 0xe5, 0xc0, 0xe9, 0x7f, 0xbc, 0xc9,
+	0x5b, 0x38, 0xd4, 0x88, 0x65, 0xc2, 0xc5, 0x5a, 0x0e, 0x99, 0x1e, 0x85, 0xc3, 0x7b, 0x2f, 0xc4,
+	0x16, 0x2b, 0xd9, 0x20, 0x7d, 0xd0, 0x53, 0x56, 0x62, 0x2d, 0xc9, 0x19, 0xb8, 0x4c, 0x0a, 0xdd,
+	0xd5, 0xa8, 0x12, 0x9e, 0x9b, 0x10, 0x87, 0x14, 0xc6, 0xd1, 0x2a, 0x27, 0x2f, 0xe0, 0xe8, 0x6f,
+	0x35, 0x3c, 0xf7, 0x66, 0x86, 0xe1, 0xfe, 0x99, 0xad, 0xf2, 0xe0, 0x03, 0x90, 0x9b, 0x69, 0x7b,
+	0x61, 0x56, 0x49, 0x56, 0x26, 0xa2, 0xab, 0x33, 0x54, 0x26, 0xcb, 0x5d, 0xea, 0x9a, 0xd9, 0x57,
+	0x33, 0x0a, 0x3a, 0x78, 0x7a, 0xa3, 0x5c, 0xdd, 0x48, 0xa1, 0x91, 0xbc, 0x82, 0xb9, 0x6e, 0xd3,
+	0xb6, 0xd3, 0x46, 0x77, 0x1c, 0x1f, 0x8f, 0x6f, 0xb8, 0x32, 0x53, 0x3a, 0xa0, 0x24, 0x86, 0x7b,
+	0xc6, 0x71, 0xa8, 0xef, 0xd9, 0x44, 0x7d, 0xcb, 0x9e, 0x43, 0x2d, 0x35, 0xe0, 0xf0, 0x64, 0x1f,
+	0x7c, 0x8b, 0xc4, 0x24, 0x84, 0xb9, 0x5d, 0x2c, 0xef, 0xe0, 0x7c, 0xb6, 0x70, 0xe3, 0x93, 0xfd,
+	0xf7, 0xd1, 0x81, 0x15, 0x33, 0x78, 0xb8, 0x73, 0x15, 0xb9, 0x84, 0xfb, 0x9f, 0xb1, 0xe2, 0x5b,
+	0x54, 0xe4, 0xf9, 0x44, 0xda, 0x61, 0xc5, 0xfc, 0xb3, 0x49, 0xdc, 0xb6, 0x14, 0xdc, 0x59, 0x38,
+	0xef, 0x9c, 0x65, 0x01, 0x81, 0x54, 0x45, 0xb8, 0xf9, 0xd1, 0xa0, 0xaa, 0x30, 0x2f, 0x50, 0x85,
+	0xeb, 0x34, 0x53, 0x9c, 0x8d, 0xf2, 0x7e, 0xc1, 0x97, 0xbb, 0x7b, 0x7c, 0x99, 0xb2, 0x32, 0x2d,
+	0xf0, 0xdb, 0xeb, 0x82, 0xb7, 0x9b, 0x2e, 0xeb, 0xfb, 0x8d, 0xae, 0x59, 0x44, 0xd6, 0x22, 0xb2,
+	0x16, 0x51, 0x6f, 0x91, 0xd9, 0x6f, 0xf4, 0xfe, 0xf7, 0x00, 0xbf, 0xf1, 0x90, 0x67, 0x6c, 0x03,
+	0x00, 0x00,
+}
@@ -27,27 +27,33 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED           ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER            ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED          ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY               ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION         ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED           ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR               ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE           ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE           ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE           ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE    ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE            ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE  ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT    ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT    ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
-	ChaincodeMessage_GET_STATE_METADATA  ChaincodeMessage_Type = 20
-	ChaincodeMessage_PUT_STATE_METADATA  ChaincodeMessage_Type = 21
+	ChaincodeMessage_UNDEFINED             ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER              ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED            ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                  ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                 ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION           ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED             ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                 ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE             ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE             ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE             ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE      ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE              ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE    ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT      ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT      ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE     ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE             ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY   ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_STATE_METADATA    ChaincodeMessage_Type = 20
+	ChaincodeMessage_PUT_STATE_METADATA    ChaincodeMessage_Type = 21
+	ChaincodeMessage_MIGRATE               ChaincodeMessage_Type = 22
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 23
+	ChaincodeMessage_GET_STATE_PROOF       ChaincodeMessage_Type = 24
+	ChaincodeMessage_DEL_STATE_RANGE       ChaincodeMessage_Type = 25
+	ChaincodeMessage_GET_CHANNEL_CONFIG    ChaincodeMessage_Type = 26
+	ChaincodeMessage_INCREMENT_STATE       ChaincodeMessage_Type = 27
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -72,36 +78,80 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	19: "GET_HISTORY_FOR_KEY",
 	20: "GET_STATE_METADATA",
 	21: "PUT_STATE_METADATA",
+	22: "MIGRATE",
+	23: "GET_PRIVATE_DATA_HASH",
+	24: "GET_STATE_PROOF",
+	25: "DEL_STATE_RANGE",
+	26: "GET_CHANNEL_CONFIG",
+	27: "INCREMENT_STATE",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":           0,
-	"REGISTER":            1,
-	"REGISTERED":          2,
-	"INIT":                3,
-	"READY":               4,
-	"TRANSACTION":         5,
-	"COMPLETED":           6,
-	"ERROR":               7,
-	"GET_STATE":           8,
-	"PUT_STATE":           9,
-	"DEL_STATE":           10,
-	"INVOKE_CHAINCODE":    11,
-	"RESPONSE":            13,
-	"GET_STATE_BY_RANGE":  14,
-	"GET_QUERY_RESULT":    15,
-	"QUERY_STATE_NEXT":    16,
-	"QUERY_STATE_CLOSE":   17,
-	"KEEPALIVE":           18,
-	"GET_HISTORY_FOR_KEY": 19,
-	"GET_STATE_METADATA":  20,
-	"PUT_STATE_METADATA":  21,
+	"UNDEFINED":             0,
+	"REGISTER":              1,
+	"REGISTERED":            2,
+	"INIT":                  3,
+	"READY":                 4,
+	"TRANSACTION":           5,
+	"COMPLETED":             6,
+	"ERROR":                 7,
+	"GET_STATE":             8,
+	"PUT_STATE":             9,
+	"DEL_STATE":             10,
+	"INVOKE_CHAINCODE":      11,
+	"RESPONSE":              13,
+	"GET_STATE_BY_RANGE":    14,
+	"GET_QUERY_RESULT":      15,
+	"QUERY_STATE_NEXT":      16,
+	"QUERY_STATE_CLOSE":     17,
+	"KEEPALIVE":             18,
+	"GET_HISTORY_FOR_KEY":   19,
+	"GET_STATE_METADATA":    20,
+	"PUT_STATE_METADATA":    21,
+	"MIGRATE":               22,
+	"GET_PRIVATE_DATA_HASH": 23,
+	"GET_STATE_PROOF":       24,
+	"DEL_STATE_RANGE":       25,
+	"GET_CHANNEL_CONFIG":    26,
+	"INCREMENT_STATE":       27,
 }
 
 func (x ChaincodeMessage_Type) String() string {
 	return proto.EnumName(ChaincodeMessage_Type_name, int32(x))
 }
 func (ChaincodeMessage_Type) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{0, 0}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{0, 0}
+}
+
+type CompositeKeyFilter_Operator int32
+
+const (
+	CompositeKeyFilter_EQUALS           CompositeKeyFilter_Operator = 0
+	CompositeKeyFilter_NOT_EQUALS       CompositeKeyFilter_Operator = 1
+	CompositeKeyFilter_PREFIX           CompositeKeyFilter_Operator = 2
+	CompositeKeyFilter_GREATER_OR_EQUAL CompositeKeyFilter_Operator = 3
+	CompositeKeyFilter_LESS             CompositeKeyFilter_Operator = 4
+)
+
+var CompositeKeyFilter_Operator_name = map[int32]string{
+	0: "EQUALS",
+	1: "NOT_EQUALS",
+	2: "PREFIX",
+	3: "GREATER_OR_EQUAL",
+	4: "LESS",
+}
+var CompositeKeyFilter_Operator_value = map[string]int32{
+	"EQUALS":           0,
+	"NOT_EQUALS":       1,
+	"PREFIX":           2,
+	"GREATER_OR_EQUAL": 3,
+	"LESS":             4,
+}
+
+func (x CompositeKeyFilter_Operator) String() string {
+	return proto.EnumName(CompositeKeyFilter_Operator_name, int32(x))
+}
+func (CompositeKeyFilter_Operator) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{10, 0}
 }
 
 type ChaincodeMessage struct {
@@ -125,7 +175,7 @@ func (m *ChaincodeMessage) Reset()         { *m = ChaincodeMessage{} }
 func (m *ChaincodeMessage) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeMessage) ProtoMessage()    {}
 func (*ChaincodeMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{0}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{0}
 }
 func (m *ChaincodeMessage) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeMessage.Unmarshal(m, b)
@@ -209,7 +259,7 @@ func (m *GetState) Reset()         { *m = GetState{} }
 func (m *GetState) String() string { return proto.CompactTextString(m) }
 func (*GetState) ProtoMessage()    {}
 func (*GetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{1}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{1}
 }
 func (m *GetState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetState.Unmarshal(m, b)
@@ -255,7 +305,7 @@ func (m *GetStateMetadata) Reset()         { *m = GetStateMetadata{} }
 func (m *GetStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*GetStateMetadata) ProtoMessage()    {}
 func (*GetStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{2}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{2}
 }
 func (m *GetStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateMetadata.Unmarshal(m, b)
@@ -306,7 +356,7 @@ func (m *PutState) Reset()         { *m = PutState{} }
 func (m *PutState) String() string { return proto.CompactTextString(m) }
 func (*PutState) ProtoMessage()    {}
 func (*PutState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{3}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{3}
 }
 func (m *PutState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutState.Unmarshal(m, b)
@@ -360,7 +410,7 @@ func (m *PutStateMetadata) Reset()         { *m = PutStateMetadata{} }
 func (m *PutStateMetadata) String() string { return proto.CompactTextString(m) }
 func (*PutStateMetadata) ProtoMessage()    {}
 func (*PutStateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{4}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{4}
 }
 func (m *PutStateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PutStateMetadata.Unmarshal(m, b)
@@ -417,7 +467,7 @@ func (m *DelState) Reset()         { *m = DelState{} }
 func (m *DelState) String() string { return proto.CompactTextString(m) }
 func (*DelState) ProtoMessage()    {}
 func (*DelState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{5}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{5}
 }
 func (m *DelState) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DelState.Unmarshal(m, b)
@@ -451,10 +501,60 @@ func (m *DelState) GetCollection() string {
 	return ""
 }
 
+// IncrementState is the payload of a ChaincodeMessage. It contains a key
+// holding a counter and the delta to add to it, which is recorded in the
+// transaction's write set as an increment applied when the transaction commits.
+type IncrementState struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Delta                int64    `protobuf:"varint,2,opt,name=delta,proto3" json:"delta,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IncrementState) Reset()         { *m = IncrementState{} }
+func (m *IncrementState) String() string { return proto.CompactTextString(m) }
+func (*IncrementState) ProtoMessage()    {}
+func (*IncrementState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{6}
+}
+func (m *IncrementState) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IncrementState.Unmarshal(m, b)
+}
+func (m *IncrementState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IncrementState.Marshal(b, m, deterministic)
+}
+func (dst *IncrementState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IncrementState.Merge(dst, src)
+}
+func (m *IncrementState) XXX_Size() int {
+	return xxx_messageInfo_IncrementState.Size(m)
+}
+func (m *IncrementState) XXX_DiscardUnknown() {
+	xxx_messageInfo_IncrementState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IncrementState proto.InternalMessageInfo
+
+func (m *IncrementState) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *IncrementState) GetDelta() int64 {
+	if m != nil {
+		return m.Delta
+	}
+	return 0
+}
+
 // GetStateByRange is the payload of a ChaincodeMessage. It contains a start key and
 // a end key required to execute range query. If the collection is specified,
 // the range query needs to be executed on the private data. The metadata hold
-// the byte representation of QueryMetadata.
+// the byte representation of QueryMetadata. It is also the payload of the
+// DEL_STATE_RANGE messages, which delete the keys of the range.
 type GetStateByRange struct {
 	StartKey             string   `protobuf:"bytes,1,opt,name=startKey,proto3" json:"startKey,omitempty"`
 	EndKey               string   `protobuf:"bytes,2,opt,name=endKey,proto3" json:"endKey,omitempty"`
@@ -469,7 +569,7 @@ func (m *GetStateByRange) Reset()         { *m = GetStateByRange{} }
 func (m *GetStateByRange) String() string { return proto.CompactTextString(m) }
 func (*GetStateByRange) ProtoMessage()    {}
 func (*GetStateByRange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{6}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{7}
 }
 func (m *GetStateByRange) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStateByRange.Unmarshal(m, b)
@@ -534,7 +634,7 @@ func (m *GetQueryResult) Reset()         { *m = GetQueryResult{} }
 func (m *GetQueryResult) String() string { return proto.CompactTextString(m) }
 func (*GetQueryResult) ProtoMessage()    {}
 func (*GetQueryResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{7}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{8}
 }
 func (m *GetQueryResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetQueryResult.Unmarshal(m, b)
@@ -579,18 +679,19 @@ func (m *GetQueryResult) GetMetadata() []byte {
 // It contains a pageSize which denotes the number of records to be fetched
 // and a bookmark.
 type QueryMetadata struct {
-	PageSize             int32    `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
-	Bookmark             string   `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageSize             int32                 `protobuf:"varint,1,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	Bookmark             string                `protobuf:"bytes,2,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
+	Filters              []*CompositeKeyFilter `protobuf:"bytes,3,rep,name=filters,proto3" json:"filters,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *QueryMetadata) Reset()         { *m = QueryMetadata{} }
 func (m *QueryMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryMetadata) ProtoMessage()    {}
 func (*QueryMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{8}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{9}
 }
 func (m *QueryMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryMetadata.Unmarshal(m, b)
@@ -624,6 +725,72 @@ func (m *QueryMetadata) GetBookmark() string {
 	return ""
 }
 
+func (m *QueryMetadata) GetFilters() []*CompositeKeyFilter {
+	if m != nil {
+		return m.Filters
+	}
+	return nil
+}
+
+// CompositeKeyFilter is a predicate on an attribute of the composite keys
+// returned by a range query. The filters of a query are evaluated by the
+// peer, which returns to the chaincode only the keys that satisfy all of them
+type CompositeKeyFilter struct {
+	// attribute is the index of the attribute of the composite key,
+	// the first attribute following the object type being at index 0
+	Attribute            uint32                      `protobuf:"varint,1,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	Operator             CompositeKeyFilter_Operator `protobuf:"varint,2,opt,name=operator,proto3,enum=protos.CompositeKeyFilter_Operator" json:"operator,omitempty"`
+	Value                string                      `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *CompositeKeyFilter) Reset()         { *m = CompositeKeyFilter{} }
+func (m *CompositeKeyFilter) String() string { return proto.CompactTextString(m) }
+func (*CompositeKeyFilter) ProtoMessage()    {}
+func (*CompositeKeyFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{10}
+}
+func (m *CompositeKeyFilter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompositeKeyFilter.Unmarshal(m, b)
+}
+func (m *CompositeKeyFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompositeKeyFilter.Marshal(b, m, deterministic)
+}
+func (dst *CompositeKeyFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompositeKeyFilter.Merge(dst, src)
+}
+func (m *CompositeKeyFilter) XXX_Size() int {
+	return xxx_messageInfo_CompositeKeyFilter.Size(m)
+}
+func (m *CompositeKeyFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompositeKeyFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompositeKeyFilter proto.InternalMessageInfo
+
+func (m *CompositeKeyFilter) GetAttribute() uint32 {
+	if m != nil {
+		return m.Attribute
+	}
+	return 0
+}
+
+func (m *CompositeKeyFilter) GetOperator() CompositeKeyFilter_Operator {
+	if m != nil {
+		return m.Operator
+	}
+	return CompositeKeyFilter_EQUALS
+}
+
+func (m *CompositeKeyFilter) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
 // GetHistoryForKey is the payload of a ChaincodeMessage. It contains a key
 // for which the historical values need to be retrieved.
 type GetHistoryForKey struct {
@@ -637,7 +804,7 @@ func (m *GetHistoryForKey) Reset()         { *m = GetHistoryForKey{} }
 func (m *GetHistoryForKey) String() string { return proto.CompactTextString(m) }
 func (*GetHistoryForKey) ProtoMessage()    {}
 func (*GetHistoryForKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{9}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{11}
 }
 func (m *GetHistoryForKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetHistoryForKey.Unmarshal(m, b)
@@ -675,7 +842,7 @@ func (m *QueryStateNext) Reset()         { *m = QueryStateNext{} }
 func (m *QueryStateNext) String() string { return proto.CompactTextString(m) }
 func (*QueryStateNext) ProtoMessage()    {}
 func (*QueryStateNext) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{10}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{12}
 }
 func (m *QueryStateNext) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateNext.Unmarshal(m, b)
@@ -713,7 +880,7 @@ func (m *QueryStateClose) Reset()         { *m = QueryStateClose{} }
 func (m *QueryStateClose) String() string { return proto.CompactTextString(m) }
 func (*QueryStateClose) ProtoMessage()    {}
 func (*QueryStateClose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{11}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{13}
 }
 func (m *QueryStateClose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryStateClose.Unmarshal(m, b)
@@ -752,7 +919,7 @@ func (m *QueryResultBytes) Reset()         { *m = QueryResultBytes{} }
 func (m *QueryResultBytes) String() string { return proto.CompactTextString(m) }
 func (*QueryResultBytes) ProtoMessage()    {}
 func (*QueryResultBytes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{12}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{14}
 }
 func (m *QueryResultBytes) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResultBytes.Unmarshal(m, b)
@@ -798,7 +965,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{13}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{15}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -860,7 +1027,7 @@ func (m *QueryResponseMetadata) Reset()         { *m = QueryResponseMetadata{} }
 func (m *QueryResponseMetadata) String() string { return proto.CompactTextString(m) }
 func (*QueryResponseMetadata) ProtoMessage()    {}
 func (*QueryResponseMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{14}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{16}
 }
 func (m *QueryResponseMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponseMetadata.Unmarshal(m, b)
@@ -906,7 +1073,7 @@ func (m *StateMetadata) Reset()         { *m = StateMetadata{} }
 func (m *StateMetadata) String() string { return proto.CompactTextString(m) }
 func (*StateMetadata) ProtoMessage()    {}
 func (*StateMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{15}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{17}
 }
 func (m *StateMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadata.Unmarshal(m, b)
@@ -951,7 +1118,7 @@ func (m *StateMetadataResult) Reset()         { *m = StateMetadataResult{} }
 func (m *StateMetadataResult) String() string { return proto.CompactTextString(m) }
 func (*StateMetadataResult) ProtoMessage()    {}
 func (*StateMetadataResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_chaincode_shim_3c6cdf1ba834f673, []int{16}
+	return fileDescriptor_chaincode_shim_21863a0171e37502, []int{18}
 }
 func (m *StateMetadataResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StateMetadataResult.Unmarshal(m, b)
@@ -985,9 +1152,11 @@ func init() {
 	proto.RegisterType((*PutState)(nil), "protos.PutState")
 	proto.RegisterType((*PutStateMetadata)(nil), "protos.PutStateMetadata")
 	proto.RegisterType((*DelState)(nil), "protos.DelState")
+	proto.RegisterType((*IncrementState)(nil), "protos.IncrementState")
 	proto.RegisterType((*GetStateByRange)(nil), "protos.GetStateByRange")
 	proto.RegisterType((*GetQueryResult)(nil), "protos.GetQueryResult")
 	proto.RegisterType((*QueryMetadata)(nil), "protos.QueryMetadata")
+	proto.RegisterType((*CompositeKeyFilter)(nil), "protos.CompositeKeyFilter")
 	proto.RegisterType((*GetHistoryForKey)(nil), "protos.GetHistoryForKey")
 	proto.RegisterType((*QueryStateNext)(nil), "protos.QueryStateNext")
 	proto.RegisterType((*QueryStateClose)(nil), "protos.QueryStateClose")
@@ -997,6 +1166,7 @@ func init() {
 	proto.RegisterType((*StateMetadata)(nil), "protos.StateMetadata")
 	proto.RegisterType((*StateMetadataResult)(nil), "protos.StateMetadataResult")
 	proto.RegisterEnum("protos.ChaincodeMessage_Type", ChaincodeMessage_Type_name, ChaincodeMessage_Type_value)
+	proto.RegisterEnum("protos.CompositeKeyFilter_Operator", CompositeKeyFilter_Operator_name, CompositeKeyFilter_Operator_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1104,73 +1274,86 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 }
 
 func init() {
-	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_3c6cdf1ba834f673)
-}
-
-var fileDescriptor_chaincode_shim_3c6cdf1ba834f673 = []byte{
-	// 1010 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x73, 0xda, 0x46,
-	0x14, 0x0e, 0x06, 0x8c, 0x78, 0xd8, 0x78, 0xb3, 0x8e, 0x5d, 0xc2, 0x4c, 0x5a, 0xca, 0xf4, 0x40,
-	0x2f, 0xd0, 0xd0, 0x1e, 0x7a, 0xe8, 0x4c, 0x06, 0xc3, 0x1a, 0x33, 0xb6, 0x05, 0x59, 0xc9, 0x99,
-	0xb8, 0x17, 0x8d, 0x90, 0xd6, 0x42, 0x63, 0xa1, 0x55, 0xa5, 0x25, 0x0d, 0xbd, 0xf5, 0xda, 0x7f,
-	0xa9, 0x7f, 0x58, 0xaf, 0x9d, 0xd5, 0x2f, 0x03, 0xae, 0x93, 0x4e, 0x4e, 0xe8, 0x7b, 0xef, 0xdb,
-	0xef, 0xfd, 0xda, 0x87, 0x04, 0x2f, 0x03, 0xc6, 0xc2, 0x9e, 0xb5, 0x30, 0x5d, 0xdf, 0xe2, 0x36,
-	0x33, 0xa2, 0x85, 0xbb, 0xec, 0x06, 0x21, 0x17, 0x1c, 0xef, 0xc7, 0x3f, 0x51, 0xb3, 0xb9, 0x43,
-	0x61, 0x1f, 0x98, 0x2f, 0x12, 0x4e, 0xf3, 0x38, 0xf6, 0x05, 0x21, 0x0f, 0x78, 0x64, 0x7a, 0xa9,
-	0xf1, 0x1b, 0x87, 0x73, 0xc7, 0x63, 0xbd, 0x18, 0xcd, 0x57, 0x77, 0x3d, 0xe1, 0x2e, 0x59, 0x24,
-	0xcc, 0x65, 0x90, 0x10, 0xda, 0x7f, 0x97, 0x01, 0x0d, 0x33, 0xbd, 0x6b, 0x16, 0x45, 0xa6, 0xc3,
-	0xf0, 0x6b, 0x28, 0x89, 0x75, 0xc0, 0x1a, 0x85, 0x56, 0xa1, 0x53, 0xef, 0xbf, 0x4a, 0xa8, 0x51,
-	0x77, 0x97, 0xd7, 0xd5, 0xd7, 0x01, 0xa3, 0x31, 0x15, 0xff, 0x0c, 0xd5, 0x5c, 0xba, 0xb1, 0xd7,
-	0x2a, 0x74, 0x6a, 0xfd, 0x66, 0x37, 0x09, 0xde, 0xcd, 0x82, 0x77, 0xf5, 0x8c, 0x41, 0x1f, 0xc8,
-	0xb8, 0x01, 0x95, 0xc0, 0x5c, 0x7b, 0xdc, 0xb4, 0x1b, 0xc5, 0x56, 0xa1, 0x73, 0x40, 0x33, 0x88,
-	0x31, 0x94, 0xc4, 0x47, 0xd7, 0x6e, 0x94, 0x5a, 0x85, 0x4e, 0x95, 0xc6, 0xcf, 0xb8, 0x0f, 0x4a,
-	0x56, 0x62, 0xa3, 0x1c, 0x87, 0x39, 0xcd, 0xd2, 0xd3, 0x5c, 0xc7, 0x67, 0xf6, 0x2c, 0xf5, 0xd2,
-	0x9c, 0x87, 0xdf, 0xc0, 0xd1, 0x4e, 0xcb, 0x1a, 0xfb, 0xdb, 0x47, 0xf3, 0xca, 0x88, 0xf4, 0xd2,
-	0xba, 0xb5, 0x85, 0xf1, 0x2b, 0x00, 0x6b, 0x61, 0xfa, 0x3e, 0xf3, 0x0c, 0xd7, 0x6e, 0x54, 0xe2,
-	0x74, 0xaa, 0xa9, 0x65, 0x62, 0xb7, 0xff, 0xd9, 0x83, 0x92, 0x6c, 0x05, 0x3e, 0x84, 0xea, 0x8d,
-	0x3a, 0x22, 0xe7, 0x13, 0x95, 0x8c, 0xd0, 0x33, 0x7c, 0x00, 0x0a, 0x25, 0xe3, 0x89, 0xa6, 0x13,
-	0x8a, 0x0a, 0xb8, 0x0e, 0x90, 0x21, 0x32, 0x42, 0x7b, 0x58, 0x81, 0xd2, 0x44, 0x9d, 0xe8, 0xa8,
-	0x88, 0xab, 0x50, 0xa6, 0x64, 0x30, 0xba, 0x45, 0x25, 0x7c, 0x04, 0x35, 0x9d, 0x0e, 0x54, 0x6d,
-	0x30, 0xd4, 0x27, 0x53, 0x15, 0x95, 0xa5, 0xe4, 0x70, 0x7a, 0x3d, 0xbb, 0x22, 0x3a, 0x19, 0xa1,
-	0x7d, 0x49, 0x25, 0x94, 0x4e, 0x29, 0xaa, 0x48, 0xcf, 0x98, 0xe8, 0x86, 0xa6, 0x0f, 0x74, 0x82,
-	0x14, 0x09, 0x67, 0x37, 0x19, 0xac, 0x4a, 0x38, 0x22, 0x57, 0x29, 0x04, 0xfc, 0x02, 0xd0, 0x44,
-	0x7d, 0x37, 0xbd, 0x24, 0xc6, 0xf0, 0x62, 0x30, 0x51, 0x87, 0xd3, 0x11, 0x41, 0xb5, 0x24, 0x41,
-	0x6d, 0x36, 0x55, 0x35, 0x82, 0x0e, 0xf1, 0x29, 0xe0, 0x5c, 0xd0, 0x38, 0xbb, 0x35, 0xe8, 0x40,
-	0x1d, 0x13, 0x54, 0x97, 0x67, 0xa5, 0xfd, 0xed, 0x0d, 0xa1, 0xb7, 0x06, 0x25, 0xda, 0xcd, 0x95,
-	0x8e, 0x8e, 0xa4, 0x35, 0xb1, 0x24, 0x7c, 0x95, 0xbc, 0xd7, 0x11, 0xc2, 0x27, 0xf0, 0x7c, 0xd3,
-	0x3a, 0xbc, 0x9a, 0x6a, 0x04, 0x3d, 0x97, 0xd9, 0x5c, 0x12, 0x32, 0x1b, 0x5c, 0x4d, 0xde, 0x11,
-	0x84, 0xf1, 0x57, 0x70, 0x2c, 0x15, 0x2f, 0x26, 0x9a, 0x3e, 0xa5, 0xb7, 0xc6, 0xf9, 0x94, 0x1a,
-	0x97, 0xe4, 0x16, 0x1d, 0x6f, 0xa7, 0x70, 0x4d, 0xf4, 0xc1, 0x68, 0xa0, 0x0f, 0xd0, 0x0b, 0x69,
-	0xcf, 0x8b, 0x7b, 0xb0, 0x9f, 0xb4, 0x7f, 0x01, 0x65, 0xcc, 0x84, 0x26, 0x4c, 0xc1, 0x30, 0x82,
-	0xe2, 0x3d, 0x5b, 0xc7, 0x77, 0xb6, 0x4a, 0xe5, 0x23, 0xfe, 0x1a, 0xc0, 0xe2, 0x9e, 0xc7, 0x2c,
-	0xe1, 0x72, 0x3f, 0xbe, 0x94, 0x55, 0xba, 0x61, 0x69, 0x8f, 0x00, 0x65, 0xa7, 0xaf, 0x99, 0x30,
-	0x6d, 0x53, 0x98, 0x5f, 0xa0, 0x42, 0x41, 0x99, 0xad, 0x9e, 0xcc, 0xe1, 0x05, 0x94, 0x3f, 0x98,
-	0xde, 0x8a, 0xc5, 0x07, 0x0f, 0x68, 0x02, 0x76, 0x34, 0x8b, 0x8f, 0x34, 0x7f, 0x07, 0x94, 0x69,
-	0xfe, 0xef, 0xcc, 0x1e, 0xa9, 0xe0, 0xd7, 0xa0, 0x2c, 0xd3, 0xd3, 0xf1, 0x0e, 0xd5, 0xfa, 0x27,
-	0xf9, 0xae, 0x6c, 0x4a, 0xd3, 0x9c, 0x26, 0x1b, 0x3a, 0x62, 0xde, 0x97, 0x36, 0xf4, 0xcf, 0x02,
-	0x1c, 0x65, 0x1d, 0x3d, 0x5b, 0x53, 0xd3, 0x77, 0x18, 0x6e, 0x82, 0x12, 0x09, 0x33, 0x14, 0x97,
-	0xb9, 0x54, 0x8e, 0xf1, 0x29, 0xec, 0x33, 0xdf, 0x96, 0x9e, 0x44, 0x2b, 0x45, 0x9f, 0x2d, 0xac,
-	0xb9, 0x53, 0xd8, 0xc1, 0x46, 0x05, 0x73, 0xa8, 0x8f, 0x99, 0x78, 0xbb, 0x62, 0xe1, 0x9a, 0xb2,
-	0x68, 0xe5, 0x09, 0x39, 0x82, 0xdf, 0x24, 0x4c, 0xc3, 0x27, 0xe0, 0x73, 0xb5, 0x6c, 0xc5, 0x28,
-	0xee, 0xc4, 0x18, 0xc3, 0x61, 0x1c, 0x20, 0x9f, 0x4d, 0x13, 0x94, 0xc0, 0x74, 0x98, 0xe6, 0xfe,
-	0x91, 0xfc, 0x69, 0x96, 0x69, 0x8e, 0xa5, 0x6f, 0xce, 0xf9, 0xfd, 0xd2, 0x0c, 0xef, 0xd3, 0x30,
-	0x39, 0x6e, 0x7f, 0x17, 0xdf, 0xc0, 0x0b, 0x37, 0x12, 0x3c, 0x5c, 0x9f, 0xf3, 0x50, 0x16, 0xff,
-	0xa8, 0xed, 0xed, 0x16, 0xd4, 0xe3, 0x70, 0x71, 0x5f, 0x55, 0xf6, 0x51, 0xe0, 0x3a, 0xec, 0xb9,
-	0x76, 0x4a, 0xd9, 0x73, 0xed, 0xf6, 0xb7, 0x70, 0xf4, 0xc0, 0x18, 0x7a, 0x3c, 0x62, 0x8f, 0x28,
-	0x3f, 0x01, 0xda, 0x68, 0xca, 0xd9, 0x5a, 0xb0, 0x08, 0xb7, 0xa0, 0x16, 0x3e, 0xc0, 0x98, 0x7c,
-	0x40, 0x37, 0x4d, 0xed, 0xbf, 0x0a, 0x69, 0xa9, 0x94, 0x45, 0x01, 0xf7, 0x23, 0x86, 0xfb, 0x50,
-	0x49, 0x08, 0x92, 0x5f, 0xec, 0xd4, 0xfa, 0x8d, 0xec, 0x4e, 0xed, 0xca, 0xd3, 0x8c, 0x88, 0x5f,
-	0x82, 0xb2, 0x30, 0x23, 0x63, 0xc9, 0xc3, 0x64, 0x0f, 0x14, 0x5a, 0x59, 0x98, 0xd1, 0x35, 0x0f,
-	0xb3, 0x34, 0x8b, 0x59, 0x9a, 0x9f, 0x1c, 0xad, 0x03, 0x27, 0x5b, 0xb9, 0xe4, 0xed, 0xef, 0xc3,
-	0xc9, 0x1d, 0x13, 0xd6, 0x82, 0xd9, 0x46, 0xc8, 0x2c, 0x1e, 0xda, 0x91, 0x61, 0xf1, 0x95, 0x2f,
-	0xd2, 0x59, 0x1c, 0xa7, 0x4e, 0x9a, 0xf8, 0x86, 0xd2, 0xf5, 0xc9, 0xb1, 0xbc, 0x81, 0xc3, 0xed,
-	0xdd, 0x6b, 0x40, 0x45, 0x66, 0xf1, 0x30, 0x97, 0x0c, 0xfe, 0xf7, 0x7e, 0xb7, 0xcf, 0xe1, 0x78,
-	0x7b, 0xc3, 0x92, 0x9b, 0xd8, 0x83, 0x0a, 0xf3, 0x45, 0xe8, 0xb2, 0xac, 0x77, 0x4f, 0xec, 0x63,
-	0xc6, 0xea, 0xbf, 0xdf, 0x78, 0x39, 0x6b, 0xab, 0x20, 0xe0, 0xa1, 0xc0, 0x23, 0x50, 0x28, 0x73,
-	0xdc, 0x48, 0xb0, 0x10, 0x37, 0x9e, 0x7a, 0x35, 0x37, 0x9f, 0xf4, 0xb4, 0x9f, 0x75, 0x0a, 0x3f,
-	0x14, 0xce, 0xa6, 0xd0, 0xe6, 0xa1, 0xd3, 0x5d, 0xac, 0x03, 0x16, 0x7a, 0xcc, 0x76, 0x58, 0xd8,
-	0xbd, 0x33, 0xe7, 0xa1, 0x6b, 0x65, 0xe7, 0xe4, 0xd7, 0xc4, 0xaf, 0xdf, 0x3b, 0xae, 0x58, 0xac,
-	0xe6, 0x5d, 0x8b, 0x2f, 0x7b, 0x1b, 0xd4, 0x5e, 0x42, 0x4d, 0xbe, 0x2a, 0xa2, 0x9e, 0xa4, 0xce,
-	0x93, 0x4f, 0x94, 0x1f, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x41, 0xbd, 0x1a, 0x01, 0xc6, 0x08,
-	0x00, 0x00,
+	proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor_chaincode_shim_21863a0171e37502)
+}
+
+var fileDescriptor_chaincode_shim_21863a0171e37502 = []byte{
+	// 1230 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4f, 0x73, 0xda, 0x46,
+	0x14, 0x0f, 0xc6, 0x36, 0xf0, 0x6c, 0xe3, 0xcd, 0x3a, 0x76, 0x30, 0x6d, 0x5a, 0xaa, 0xf6, 0xe0,
+	0x5e, 0xa0, 0xa1, 0x39, 0xe4, 0xd0, 0x99, 0x8c, 0x0c, 0x0b, 0xd6, 0x18, 0x24, 0xb2, 0x92, 0x33,
+	0x71, 0x2f, 0x1a, 0x81, 0xd6, 0xa0, 0x09, 0xb0, 0xaa, 0xb4, 0xa4, 0xa1, 0x33, 0x3d, 0xf4, 0xda,
+	0x8f, 0xd4, 0xef, 0xd2, 0xaf, 0xd1, 0x73, 0x67, 0xf5, 0x8f, 0x3f, 0xae, 0x93, 0x69, 0x4e, 0xd2,
+	0xef, 0xbd, 0xdf, 0xfb, 0xb3, 0x6f, 0xdf, 0x7b, 0xb3, 0x70, 0xee, 0x33, 0x16, 0x34, 0x46, 0x13,
+	0xc7, 0x9b, 0x8f, 0xb8, 0xcb, 0xec, 0x70, 0xe2, 0xcd, 0xea, 0x7e, 0xc0, 0x05, 0xc7, 0xfb, 0xd1,
+	0x27, 0xac, 0x56, 0xb7, 0x28, 0xec, 0x3d, 0x9b, 0x8b, 0x98, 0x53, 0x3d, 0x89, 0x74, 0x7e, 0xc0,
+	0x7d, 0x1e, 0x3a, 0xd3, 0x44, 0xf8, 0xf5, 0x98, 0xf3, 0xf1, 0x94, 0x35, 0x22, 0x34, 0x5c, 0xdc,
+	0x35, 0x84, 0x37, 0x63, 0xa1, 0x70, 0x66, 0x7e, 0x4c, 0x50, 0xfe, 0xda, 0x07, 0xd4, 0x4a, 0xfd,
+	0xf5, 0x59, 0x18, 0x3a, 0x63, 0x86, 0x9f, 0xc3, 0xae, 0x58, 0xfa, 0xac, 0x92, 0xab, 0xe5, 0x2e,
+	0xca, 0xcd, 0x67, 0x31, 0x35, 0xac, 0x6f, 0xf3, 0xea, 0xd6, 0xd2, 0x67, 0x34, 0xa2, 0xe2, 0x97,
+	0x50, 0xca, 0x5c, 0x57, 0x76, 0x6a, 0xb9, 0x8b, 0x83, 0x66, 0xb5, 0x1e, 0x07, 0xaf, 0xa7, 0xc1,
+	0xeb, 0x56, 0xca, 0xa0, 0x2b, 0x32, 0xae, 0x40, 0xc1, 0x77, 0x96, 0x53, 0xee, 0xb8, 0x95, 0x7c,
+	0x2d, 0x77, 0x71, 0x48, 0x53, 0x88, 0x31, 0xec, 0x8a, 0x0f, 0x9e, 0x5b, 0xd9, 0xad, 0xe5, 0x2e,
+	0x4a, 0x34, 0xfa, 0xc7, 0x4d, 0x28, 0xa6, 0x47, 0xac, 0xec, 0x45, 0x61, 0xce, 0xd2, 0xf4, 0x4c,
+	0x6f, 0x3c, 0x67, 0xee, 0x20, 0xd1, 0xd2, 0x8c, 0x87, 0x5f, 0xc1, 0xf1, 0x56, 0xc9, 0x2a, 0xfb,
+	0x9b, 0xa6, 0xd9, 0xc9, 0x88, 0xd4, 0xd2, 0xf2, 0x68, 0x03, 0xe3, 0x67, 0x00, 0xa3, 0x89, 0x33,
+	0x9f, 0xb3, 0xa9, 0xed, 0xb9, 0x95, 0x42, 0x94, 0x4e, 0x29, 0x91, 0x68, 0xae, 0xf2, 0x4f, 0x1e,
+	0x76, 0x65, 0x29, 0xf0, 0x11, 0x94, 0x6e, 0xf4, 0x36, 0xe9, 0x68, 0x3a, 0x69, 0xa3, 0x47, 0xf8,
+	0x10, 0x8a, 0x94, 0x74, 0x35, 0xd3, 0x22, 0x14, 0xe5, 0x70, 0x19, 0x20, 0x45, 0xa4, 0x8d, 0x76,
+	0x70, 0x11, 0x76, 0x35, 0x5d, 0xb3, 0x50, 0x1e, 0x97, 0x60, 0x8f, 0x12, 0xb5, 0x7d, 0x8b, 0x76,
+	0xf1, 0x31, 0x1c, 0x58, 0x54, 0xd5, 0x4d, 0xb5, 0x65, 0x69, 0x86, 0x8e, 0xf6, 0xa4, 0xcb, 0x96,
+	0xd1, 0x1f, 0xf4, 0x88, 0x45, 0xda, 0x68, 0x5f, 0x52, 0x09, 0xa5, 0x06, 0x45, 0x05, 0xa9, 0xe9,
+	0x12, 0xcb, 0x36, 0x2d, 0xd5, 0x22, 0xa8, 0x28, 0xe1, 0xe0, 0x26, 0x85, 0x25, 0x09, 0xdb, 0xa4,
+	0x97, 0x40, 0xc0, 0x4f, 0x00, 0x69, 0xfa, 0x1b, 0xe3, 0x9a, 0xd8, 0xad, 0x2b, 0x55, 0xd3, 0x5b,
+	0x46, 0x9b, 0xa0, 0x83, 0x38, 0x41, 0x73, 0x60, 0xe8, 0x26, 0x41, 0x47, 0xf8, 0x0c, 0x70, 0xe6,
+	0xd0, 0xbe, 0xbc, 0xb5, 0xa9, 0xaa, 0x77, 0x09, 0x2a, 0x4b, 0x5b, 0x29, 0x7f, 0x7d, 0x43, 0xe8,
+	0xad, 0x4d, 0x89, 0x79, 0xd3, 0xb3, 0xd0, 0xb1, 0x94, 0xc6, 0x92, 0x98, 0xaf, 0x93, 0xb7, 0x16,
+	0x42, 0xf8, 0x14, 0x1e, 0xaf, 0x4b, 0x5b, 0x3d, 0xc3, 0x24, 0xe8, 0xb1, 0xcc, 0xe6, 0x9a, 0x90,
+	0x81, 0xda, 0xd3, 0xde, 0x10, 0x84, 0xf1, 0x53, 0x38, 0x91, 0x1e, 0xaf, 0x34, 0xd3, 0x32, 0xe8,
+	0xad, 0xdd, 0x31, 0xa8, 0x7d, 0x4d, 0x6e, 0xd1, 0xc9, 0x66, 0x0a, 0x7d, 0x62, 0xa9, 0x6d, 0xd5,
+	0x52, 0xd1, 0x13, 0x29, 0x1f, 0xdc, 0xdc, 0x93, 0x9f, 0xe2, 0x03, 0x28, 0xf4, 0xb5, 0x2e, 0x95,
+	0x67, 0x3c, 0xc3, 0xe7, 0x70, 0x2a, 0x8d, 0x07, 0x54, 0x7b, 0x23, 0x69, 0x92, 0x62, 0x5f, 0xa9,
+	0xe6, 0x15, 0x7a, 0x8a, 0x4f, 0xe0, 0x78, 0xe5, 0x77, 0x40, 0x0d, 0xa3, 0x83, 0x2a, 0x52, 0x98,
+	0x95, 0x28, 0x39, 0xec, 0x79, 0x9a, 0x41, 0xeb, 0x4a, 0xd5, 0x75, 0xd2, 0xb3, 0x5b, 0x86, 0xde,
+	0xd1, 0xba, 0xa8, 0x2a, 0xc9, 0x9a, 0xde, 0xa2, 0xa4, 0x4f, 0xf4, 0xb4, 0xc8, 0x5f, 0x28, 0x3f,
+	0x41, 0xb1, 0xcb, 0x84, 0x29, 0x1c, 0xc1, 0x30, 0x82, 0xfc, 0x3b, 0xb6, 0x8c, 0x46, 0xa6, 0x44,
+	0xe5, 0x2f, 0xfe, 0x0a, 0x60, 0xc4, 0xa7, 0x53, 0x36, 0x12, 0x1e, 0x9f, 0x47, 0x33, 0x51, 0xa2,
+	0x6b, 0x12, 0xa5, 0x0d, 0x28, 0xb5, 0xee, 0x33, 0xe1, 0xb8, 0x8e, 0x70, 0x3e, 0xc3, 0x0b, 0x85,
+	0xe2, 0x60, 0xf1, 0x60, 0x0e, 0x4f, 0x60, 0xef, 0xbd, 0x33, 0x5d, 0xb0, 0xc8, 0xf0, 0x90, 0xc6,
+	0x60, 0xcb, 0x67, 0xfe, 0x9e, 0xcf, 0x5f, 0x01, 0x0d, 0x16, 0xff, 0x33, 0xb3, 0x7b, 0x5e, 0xf0,
+	0x73, 0x28, 0xce, 0x12, 0xeb, 0x68, 0x84, 0x0f, 0x9a, 0xa7, 0xd9, 0xa8, 0xae, 0xbb, 0xa6, 0x19,
+	0x4d, 0x16, 0xb4, 0xcd, 0xa6, 0x9f, 0x5b, 0xd0, 0x97, 0x50, 0xd6, 0xe6, 0xa3, 0x80, 0xcd, 0xd8,
+	0xfc, 0x63, 0x05, 0x71, 0xd9, 0x54, 0x38, 0x91, 0x79, 0x9e, 0xc6, 0x40, 0xf9, 0x23, 0x07, 0xc7,
+	0xe9, 0x5d, 0x5c, 0x2e, 0xa9, 0x33, 0x1f, 0x33, 0x5c, 0x85, 0x62, 0x28, 0x9c, 0x40, 0x5c, 0x67,
+	0x0e, 0x32, 0x8c, 0xcf, 0x60, 0x9f, 0xcd, 0x5d, 0xa9, 0x89, 0xb3, 0x48, 0xd0, 0x27, 0x4b, 0x52,
+	0xdd, 0x2a, 0xc9, 0xe1, 0xda, 0xd9, 0x87, 0x50, 0xee, 0x32, 0xf1, 0x7a, 0xc1, 0x82, 0x25, 0x65,
+	0xe1, 0x62, 0x2a, 0x64, 0xae, 0xbf, 0x48, 0x98, 0x84, 0x8f, 0xc1, 0xa7, 0xaa, 0xb0, 0x11, 0x23,
+	0xbf, 0x15, 0xe3, 0x77, 0x38, 0x8a, 0x02, 0x64, 0xb7, 0x5a, 0x85, 0xa2, 0xef, 0x8c, 0x99, 0xe9,
+	0xfd, 0x16, 0x6f, 0xfb, 0x3d, 0x9a, 0x61, 0xa9, 0x1b, 0x72, 0xfe, 0x6e, 0xe6, 0x04, 0xef, 0x92,
+	0x30, 0x19, 0xc6, 0x2f, 0xa0, 0x70, 0xe7, 0x4d, 0x05, 0x0b, 0xc2, 0x4a, 0xbe, 0x96, 0x8f, 0x96,
+	0x7d, 0xba, 0x4a, 0xf9, 0xcc, 0xe7, 0xa1, 0x27, 0xd8, 0x35, 0x5b, 0x76, 0x22, 0x0a, 0x4d, 0xa9,
+	0xca, 0xdf, 0x39, 0xc0, 0xf7, 0xf5, 0xf8, 0x4b, 0x28, 0x39, 0x42, 0x04, 0xde, 0x70, 0x21, 0xe2,
+	0x2c, 0x8e, 0xe8, 0x4a, 0x80, 0x5f, 0x41, 0x91, 0xfb, 0x2c, 0x70, 0x04, 0x0f, 0xa2, 0x34, 0xca,
+	0xcd, 0x6f, 0x1f, 0x8e, 0x55, 0x37, 0x12, 0x2a, 0xcd, 0x8c, 0x56, 0x33, 0x10, 0xdf, 0x47, 0x0c,
+	0xe4, 0xdc, 0xa4, 0x5c, 0x0c, 0xb0, 0x4f, 0x5e, 0xdf, 0xa8, 0x3d, 0x13, 0x3d, 0x92, 0x6b, 0x5a,
+	0x37, 0x2c, 0x3b, 0xc1, 0x39, 0xa9, 0x1b, 0x50, 0xd2, 0xd1, 0xde, 0xa2, 0x9d, 0x68, 0x13, 0x52,
+	0xa2, 0x5a, 0x84, 0xda, 0x06, 0x8d, 0x29, 0x28, 0x2f, 0x17, 0x79, 0x8f, 0x98, 0x26, 0xda, 0x55,
+	0xbe, 0x8b, 0x26, 0xfa, 0xca, 0x0b, 0x05, 0x0f, 0x96, 0x1d, 0x1e, 0xc8, 0x96, 0xb8, 0xd7, 0x82,
+	0x4a, 0x0d, 0xca, 0xd1, 0x25, 0x44, 0xdd, 0xa6, 0xb3, 0x0f, 0x02, 0x97, 0x61, 0xc7, 0x73, 0x13,
+	0xca, 0x8e, 0xe7, 0x2a, 0xdf, 0xc0, 0xf1, 0x8a, 0xd1, 0x9a, 0xf2, 0x90, 0xdd, 0xa3, 0xbc, 0x00,
+	0xb4, 0xd6, 0x2a, 0x97, 0x4b, 0xc1, 0x42, 0x5c, 0x83, 0x83, 0x60, 0x05, 0x23, 0xf2, 0x21, 0x5d,
+	0x17, 0x29, 0x7f, 0xe6, 0x92, 0x06, 0xa0, 0x2c, 0xf4, 0xf9, 0x3c, 0x64, 0xb8, 0x09, 0x85, 0x98,
+	0x20, 0xf9, 0xf2, 0x22, 0x2b, 0x69, 0x71, 0xb7, 0xdd, 0xd3, 0x94, 0x88, 0xcf, 0xa1, 0x38, 0x71,
+	0x42, 0x7b, 0xc6, 0x83, 0x78, 0xaf, 0x14, 0x69, 0x61, 0xe2, 0x84, 0x7d, 0x1e, 0xa4, 0x69, 0xe6,
+	0xd3, 0x34, 0x3f, 0xda, 0xf0, 0x63, 0x38, 0xdd, 0xc8, 0x25, 0x6b, 0xca, 0x26, 0x9c, 0xde, 0x31,
+	0x31, 0x9a, 0x30, 0xd7, 0x0e, 0xd8, 0x88, 0x07, 0x6e, 0x68, 0x8f, 0xf8, 0x62, 0x2e, 0x92, 0x0e,
+	0x3d, 0x49, 0x94, 0x34, 0xd6, 0xb5, 0xa4, 0xea, 0x63, 0xcd, 0xaa, 0xbc, 0x82, 0xa3, 0xcd, 0x5d,
+	0x56, 0x81, 0x82, 0xcc, 0x62, 0x75, 0x2f, 0x29, 0xfc, 0xef, 0x7d, 0xa9, 0x74, 0xe0, 0x64, 0x73,
+	0x63, 0xc5, 0xf3, 0xd9, 0x80, 0x02, 0x9b, 0x8b, 0xc0, 0x63, 0x69, 0xed, 0x1e, 0xd8, 0x6f, 0x29,
+	0xab, 0xf9, 0x76, 0xed, 0xad, 0x65, 0x2e, 0x7c, 0x9f, 0x07, 0x02, 0xb7, 0xa1, 0x48, 0xd9, 0xd8,
+	0x0b, 0xe5, 0x20, 0x54, 0x1e, 0x7a, 0x69, 0x55, 0x1f, 0xd4, 0x28, 0x8f, 0x2e, 0x72, 0x3f, 0xe4,
+	0x2e, 0x0d, 0x50, 0x78, 0x30, 0xae, 0x4f, 0x96, 0x3e, 0x0b, 0xa6, 0xcc, 0x1d, 0xb3, 0xa0, 0x7e,
+	0xe7, 0x0c, 0x03, 0x6f, 0x94, 0xda, 0xc9, 0xc7, 0xe1, 0xcf, 0xdf, 0x8f, 0x3d, 0x31, 0x59, 0x0c,
+	0xeb, 0x23, 0x3e, 0x6b, 0xac, 0x51, 0x1b, 0x31, 0x35, 0x7e, 0x24, 0x86, 0x0d, 0x49, 0x1d, 0xc6,
+	0x2f, 0xce, 0x1f, 0xff, 0x1d, 0x00, 0xed, 0xbf, 0x75, 0x9c, 0x95, 0x0a, 0x00, 0x00,
 }
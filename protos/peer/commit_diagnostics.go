@@ -0,0 +1,17 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+// MVCCConflict returns the conflict recorded for the transaction at the given index of the block, if any
+func (m *CommitDiagnostics) MVCCConflict(txIndex int) *MVCCConflict {
+	for _, conflict := range m.GetMvccConflicts() {
+		if conflict.TxIndex == uint64(txIndex) {
+			return conflict
+		}
+	}
+	return nil
+}
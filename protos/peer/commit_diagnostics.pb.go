@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/commit_diagnostics.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import kvrwset "github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// CommitDiagnostics carries the reasons for which the committer invalidated
+// the transactions of a block. It is stored at the index COMMIT_DIAGNOSTICS
+// of the block metadata, which is left empty if the committer did not record
+// any diagnostics for the block
+type CommitDiagnostics struct {
+	MvccConflicts        []*MVCCConflict `protobuf:"bytes,1,rep,name=mvcc_conflicts,json=mvccConflicts,proto3" json:"mvcc_conflicts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CommitDiagnostics) Reset()         { *m = CommitDiagnostics{} }
+func (m *CommitDiagnostics) String() string { return proto.CompactTextString(m) }
+func (*CommitDiagnostics) ProtoMessage()    {}
+func (*CommitDiagnostics) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commit_diagnostics_8960b8b4ca29add3, []int{0}
+}
+func (m *CommitDiagnostics) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitDiagnostics.Unmarshal(m, b)
+}
+func (m *CommitDiagnostics) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitDiagnostics.Marshal(b, m, deterministic)
+}
+func (dst *CommitDiagnostics) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitDiagnostics.Merge(dst, src)
+}
+func (m *CommitDiagnostics) XXX_Size() int {
+	return xxx_messageInfo_CommitDiagnostics.Size(m)
+}
+func (m *CommitDiagnostics) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitDiagnostics.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommitDiagnostics proto.InternalMessageInfo
+
+func (m *CommitDiagnostics) GetMvccConflicts() []*MVCCConflict {
+	if m != nil {
+		return m.MvccConflicts
+	}
+	return nil
+}
+
+// MVCCConflict describes the read that caused a transaction to be invalidated
+// with the validation code MVCC_READ_CONFLICT. For a read of private data, the
+// collection and the hash of the key are set instead of the key. A nil version
+// stands for a key that did not exist, at the time of the simulation for the
+// read version and at the time of the validation for the committed version
+type MVCCConflict struct {
+	TxIndex          uint64           `protobuf:"varint,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Namespace        string           `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Collection       string           `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	Key              string           `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	KeyHash          []byte           `protobuf:"bytes,5,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	ReadVersion      *kvrwset.Version `protobuf:"bytes,6,opt,name=read_version,json=readVersion,proto3" json:"read_version,omitempty"`
+	CommittedVersion *kvrwset.Version `protobuf:"bytes,7,opt,name=committed_version,json=committedVersion,proto3" json:"committed_version,omitempty"`
+	// updated_in_block is true if the key was updated by a preceding valid
+	// transaction of the same block, in which case the committed version is
+	// the height of that transaction
+	UpdatedInBlock       bool     `protobuf:"varint,8,opt,name=updated_in_block,json=updatedInBlock,proto3" json:"updated_in_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MVCCConflict) Reset()         { *m = MVCCConflict{} }
+func (m *MVCCConflict) String() string { return proto.CompactTextString(m) }
+func (*MVCCConflict) ProtoMessage()    {}
+func (*MVCCConflict) Descriptor() ([]byte, []int) {
+	return fileDescriptor_commit_diagnostics_8960b8b4ca29add3, []int{1}
+}
+func (m *MVCCConflict) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MVCCConflict.Unmarshal(m, b)
+}
+func (m *MVCCConflict) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MVCCConflict.Marshal(b, m, deterministic)
+}
+func (dst *MVCCConflict) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MVCCConflict.Merge(dst, src)
+}
+func (m *MVCCConflict) XXX_Size() int {
+	return xxx_messageInfo_MVCCConflict.Size(m)
+}
+func (m *MVCCConflict) XXX_DiscardUnknown() {
+	xxx_messageInfo_MVCCConflict.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MVCCConflict proto.InternalMessageInfo
+
+func (m *MVCCConflict) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *MVCCConflict) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+func (m *MVCCConflict) GetReadVersion() *kvrwset.Version {
+	if m != nil {
+		return m.ReadVersion
+	}
+	return nil
+}
+
+func (m *MVCCConflict) GetCommittedVersion() *kvrwset.Version {
+	if m != nil {
+		return m.CommittedVersion
+	}
+	return nil
+}
+
+func (m *MVCCConflict) GetUpdatedInBlock() bool {
+	if m != nil {
+		return m.UpdatedInBlock
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*CommitDiagnostics)(nil), "protos.CommitDiagnostics")
+	proto.RegisterType((*MVCCConflict)(nil), "protos.MVCCConflict")
+}
+
+func init() {
+	proto.RegisterFile("peer/commit_diagnostics.proto", fileDescriptor_commit_diagnostics_8960b8b4ca29add3)
+}
+
+var fileDescriptor_commit_diagnostics_8960b8b4ca29add3 = []byte{
+	// 369 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x3f, 0xaf, 0xd3, 0x30,
+	0x10, 0x57, 0x5e, 0x1f, 0xaf, 0xad, 0x5b, 0xaa, 0xd4, 0x62, 0x30, 0x08, 0x50, 0x54, 0x96, 0xb0,
+	0x24, 0x52, 0x3b, 0x22, 0x96, 0x86, 0x81, 0x0e, 0x48, 0x55, 0x86, 0x0e, 0x2c, 0x91, 0xe3, 0x5c,
+	0x13, 0x2b, 0x7f, 0x1c, 0xd9, 0x6e, 0x69, 0xbe, 0x16, 0x9f, 0x10, 0x39, 0x4e, 0x68, 0x25, 0xc4,
+	0x74, 0x77, 0xbf, 0x7f, 0x96, 0xee, 0x8c, 0x3e, 0xb4, 0x00, 0x32, 0x64, 0xa2, 0xae, 0xb9, 0x4e,
+	0x32, 0x4e, 0xf3, 0x46, 0x28, 0xcd, 0x99, 0x0a, 0x5a, 0x29, 0xb4, 0xc0, 0x2f, 0x7d, 0x51, 0xef,
+	0x3e, 0x55, 0x90, 0xe5, 0x20, 0x43, 0xf9, 0x4b, 0x81, 0x0e, 0xcb, 0xeb, 0x58, 0x93, 0xbe, 0xb1,
+	0xe2, 0xcd, 0x11, 0xad, 0xa3, 0x3e, 0xe8, 0xdb, 0x3d, 0x07, 0x7f, 0x41, 0xab, 0xfa, 0xca, 0x58,
+	0xc2, 0x44, 0x73, 0xae, 0x38, 0xd3, 0x8a, 0x38, 0xde, 0xc4, 0x5f, 0x6c, 0xdf, 0x58, 0x93, 0x0a,
+	0x7e, 0x9c, 0xa2, 0x28, 0x1a, 0xc8, 0xf8, 0xb5, 0xd1, 0x8e, 0x93, 0xda, 0xfc, 0x7e, 0x42, 0xcb,
+	0x47, 0x1e, 0xbf, 0x45, 0x33, 0x7d, 0x4b, 0x78, 0x93, 0xc1, 0x8d, 0x38, 0x9e, 0xe3, 0x3f, 0xc7,
+	0x53, 0x7d, 0x3b, 0x98, 0x11, 0xbf, 0x47, 0xf3, 0x86, 0xd6, 0xa0, 0x5a, 0xca, 0x80, 0x3c, 0x79,
+	0x8e, 0x3f, 0x8f, 0xef, 0x00, 0xfe, 0x88, 0x10, 0x13, 0x55, 0x05, 0x4c, 0x73, 0xd1, 0x90, 0x49,
+	0x4f, 0x3f, 0x20, 0xd8, 0x45, 0x93, 0x12, 0x3a, 0xf2, 0xdc, 0x13, 0xa6, 0x35, 0x4f, 0x95, 0xd0,
+	0x25, 0x05, 0x55, 0x05, 0x79, 0xe5, 0x39, 0xfe, 0x32, 0x9e, 0x96, 0xd0, 0x7d, 0xa7, 0xaa, 0xc0,
+	0x3b, 0xb4, 0x94, 0x40, 0xb3, 0xe4, 0x0a, 0x52, 0x99, 0xb8, 0x17, 0xcf, 0xf1, 0x17, 0x5b, 0x37,
+	0x18, 0xf6, 0x12, 0x9c, 0x2c, 0x1e, 0x2f, 0x8c, 0x6a, 0x18, 0xf0, 0x57, 0xb4, 0xb6, 0x6b, 0xd6,
+	0x70, 0x77, 0x4e, 0xff, 0xe3, 0x74, 0xff, 0x4a, 0x47, 0xbb, 0x8f, 0xdc, 0x4b, 0x9b, 0x51, 0x63,
+	0xe6, 0x4d, 0x92, 0x56, 0x82, 0x95, 0x64, 0xe6, 0x39, 0xfe, 0x2c, 0x5e, 0x0d, 0xf8, 0xa1, 0xd9,
+	0x1b, 0x74, 0xcf, 0xd1, 0x46, 0xc8, 0x3c, 0x28, 0xba, 0x16, 0xa4, 0x3d, 0x5b, 0x70, 0xa6, 0xa9,
+	0xe4, 0x6c, 0xdc, 0xb8, 0x39, 0xf9, 0x9e, 0xfc, 0x73, 0xaa, 0x23, 0x65, 0x25, 0xcd, 0xe1, 0xe7,
+	0xe7, 0x9c, 0xeb, 0xe2, 0x92, 0x06, 0x4c, 0xd4, 0xe1, 0x43, 0x48, 0x68, 0x43, 0x42, 0x1b, 0x12,
+	0x9a, 0x90, 0xd4, 0x7e, 0x8f, 0xdd, 0x9f, 0x01, 0x00, 0x1c, 0x0f, 0x90, 0x3f, 0x46, 0x02, 0x00,
+	0x00,
+}
@@ -0,0 +1,80 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	compositeKeyNamespace = "\x00"
+	compositeKeySeparator = "\x00"
+)
+
+// Validate checks that the filter can be evaluated
+func (f *CompositeKeyFilter) Validate() error {
+	if _, ok := CompositeKeyFilter_Operator_name[int32(f.Operator)]; !ok {
+		return errors.Errorf("unknown operator %d in the filter on attribute %d", f.Operator, f.Attribute)
+	}
+	if !utf8.ValidString(f.Value) || strings.Contains(f.Value, compositeKeySeparator) {
+		return errors.Errorf("invalid value [%s] in the filter on attribute %d", f.Value, f.Attribute)
+	}
+	return nil
+}
+
+// Matches returns true if the attribute of the given composite key attributes satisfies the filter.
+// A filter on an attribute that the key does not have is never satisfied
+func (f *CompositeKeyFilter) Matches(attributes []string) bool {
+	if int(f.Attribute) >= len(attributes) {
+		return false
+	}
+	attribute := attributes[f.Attribute]
+	switch f.Operator {
+	case CompositeKeyFilter_EQUALS:
+		return attribute == f.Value
+	case CompositeKeyFilter_NOT_EQUALS:
+		return attribute != f.Value
+	case CompositeKeyFilter_PREFIX:
+		return strings.HasPrefix(attribute, f.Value)
+	case CompositeKeyFilter_GREATER_OR_EQUAL:
+		return attribute >= f.Value
+	case CompositeKeyFilter_LESS:
+		return attribute < f.Value
+	default:
+		return false
+	}
+}
+
+// MatchCompositeKey returns true if the given key is a composite key whose attributes satisfy all the filters.
+// A key that is not a composite key does not match unless there are no filters
+func MatchCompositeKey(key string, filters []*CompositeKeyFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	attributes, ok := compositeKeyAttributes(key)
+	if !ok {
+		return false
+	}
+	for _, filter := range filters {
+		if !filter.Matches(attributes) {
+			return false
+		}
+	}
+	return true
+}
+
+// compositeKeyAttributes returns the attributes, excluding the object type, of the given composite key
+func compositeKeyAttributes(key string) ([]string, bool) {
+	if !strings.HasPrefix(key, compositeKeyNamespace) || !strings.HasSuffix(key, compositeKeySeparator) || len(key) < 2 {
+		return nil, false
+	}
+	components := strings.Split(key[len(compositeKeyNamespace):len(key)-len(compositeKeySeparator)], compositeKeySeparator)
+	return components[1:], true
+}
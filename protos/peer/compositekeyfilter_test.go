@@ -0,0 +1,62 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeKeyFilterMatches(t *testing.T) {
+	attributes := []string{"blue", "tom"}
+	tests := []struct {
+		filter  *CompositeKeyFilter
+		matches bool
+	}{
+		{&CompositeKeyFilter{Attribute: 0, Operator: CompositeKeyFilter_EQUALS, Value: "blue"}, true},
+		{&CompositeKeyFilter{Attribute: 0, Operator: CompositeKeyFilter_EQUALS, Value: "red"}, false},
+		{&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_NOT_EQUALS, Value: "tom"}, false},
+		{&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_NOT_EQUALS, Value: "bob"}, true},
+		{&CompositeKeyFilter{Attribute: 0, Operator: CompositeKeyFilter_PREFIX, Value: "bl"}, true},
+		{&CompositeKeyFilter{Attribute: 0, Operator: CompositeKeyFilter_PREFIX, Value: "lu"}, false},
+		{&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_GREATER_OR_EQUAL, Value: "tom"}, true},
+		{&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_GREATER_OR_EQUAL, Value: "zoe"}, false},
+		{&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_LESS, Value: "zoe"}, true},
+		{&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_LESS, Value: "tom"}, false},
+		{&CompositeKeyFilter{Attribute: 2, Operator: CompositeKeyFilter_NOT_EQUALS, Value: "x"}, false},
+		{&CompositeKeyFilter{Attribute: 0, Operator: CompositeKeyFilter_Operator(42), Value: "blue"}, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.matches, test.filter.Matches(attributes), "filter %s", test.filter)
+	}
+}
+
+func TestCompositeKeyFilterValidate(t *testing.T) {
+	assert.NoError(t, (&CompositeKeyFilter{Operator: CompositeKeyFilter_LESS, Value: "a"}).Validate())
+	assert.EqualError(t,
+		(&CompositeKeyFilter{Attribute: 1, Operator: CompositeKeyFilter_Operator(42)}).Validate(),
+		"unknown operator 42 in the filter on attribute 1",
+	)
+	assert.EqualError(t,
+		(&CompositeKeyFilter{Attribute: 2, Value: "a\x00b"}).Validate(),
+		"invalid value [a\x00b] in the filter on attribute 2",
+	)
+}
+
+func TestMatchCompositeKey(t *testing.T) {
+	key := "\x00color~name\x00blue\x00tom\x00"
+	filters := []*CompositeKeyFilter{
+		{Attribute: 0, Operator: CompositeKeyFilter_EQUALS, Value: "blue"},
+		{Attribute: 1, Operator: CompositeKeyFilter_PREFIX, Value: "t"},
+	}
+	assert.True(t, MatchCompositeKey(key, filters))
+	assert.True(t, MatchCompositeKey("simplekey", nil))
+	assert.False(t, MatchCompositeKey("\x00color~name\x00red\x00tom\x00", filters))
+	assert.False(t, MatchCompositeKey("simplekey", filters))
+	assert.False(t, MatchCompositeKey("\x00color~name\x00blue", filters))
+}
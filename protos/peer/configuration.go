@@ -79,6 +79,12 @@ func (dag *DynamicApplicationOrgGroup) DynamicMapFieldProto(name string, key str
 			return nil, fmt.Errorf("ConfigGroup values can only contain ConfigValue messages")
 		}
 
+		// The values of the custom groups of the application config, which share the
+		// groups of the application orgs, are opaque and kept as raw bytes
+		if !isApplicationOrgConfigValue(key) {
+			return cv, nil
+		}
+
 		return &DynamicApplicationOrgConfigValue{
 			ConfigValue: cv,
 			name:        key,
@@ -88,6 +94,15 @@ func (dag *DynamicApplicationOrgGroup) DynamicMapFieldProto(name string, key str
 	}
 }
 
+func isApplicationOrgConfigValue(name string) bool {
+	switch name {
+	case "MSP", "AnchorPeers":
+		return true
+	default:
+		return false
+	}
+}
+
 type DynamicApplicationConfigValue struct {
 	*common.ConfigValue
 	name string
@@ -102,6 +117,10 @@ func (ccv *DynamicApplicationConfigValue) VariablyOpaqueFieldProto(name string)
 		return &common.Capabilities{}, nil
 	case "ACLs":
 		return &ACLs{}, nil
+	case "ResourceBudget":
+		return &ResourceBudget{}, nil
+	case "SystemChaincodePolicies":
+		return &SystemChaincodePolicies{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application ConfigValue name: %s", ccv.name)
 	}
@@ -6,6 +6,7 @@ package peer // import "github.com/hyperledger/fabric/protos/peer"
 import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
+import common "github.com/hyperledger/fabric/protos/common"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -30,7 +31,7 @@ func (m *AnchorPeers) Reset()         { *m = AnchorPeers{} }
 func (m *AnchorPeers) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeers) ProtoMessage()    {}
 func (*AnchorPeers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{0}
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{0}
 }
 func (m *AnchorPeers) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeers.Unmarshal(m, b)
@@ -73,7 +74,7 @@ func (m *AnchorPeer) Reset()         { *m = AnchorPeer{} }
 func (m *AnchorPeer) String() string { return proto.CompactTextString(m) }
 func (*AnchorPeer) ProtoMessage()    {}
 func (*AnchorPeer) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{1}
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{1}
 }
 func (m *AnchorPeer) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_AnchorPeer.Unmarshal(m, b)
@@ -110,17 +111,20 @@ func (m *AnchorPeer) GetPort() int32 {
 // APIResource represents an API resource in the peer whose ACL
 // is determined by the policy_ref field
 type APIResource struct {
-	PolicyRef            string   `protobuf:"bytes,1,opt,name=policy_ref,json=policyRef,proto3" json:"policy_ref,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PolicyRef string `protobuf:"bytes,1,opt,name=policy_ref,json=policyRef,proto3" json:"policy_ref,omitempty"`
+	// The limits of the deliver streams of the clients, which override the
+	// defaults of the peers for the event/Block and event/FilteredBlock APIs
+	DeliverLimits        *DeliverLimits `protobuf:"bytes,2,opt,name=deliver_limits,json=deliverLimits,proto3" json:"deliver_limits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *APIResource) Reset()         { *m = APIResource{} }
 func (m *APIResource) String() string { return proto.CompactTextString(m) }
 func (*APIResource) ProtoMessage()    {}
 func (*APIResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{2}
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{2}
 }
 func (m *APIResource) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_APIResource.Unmarshal(m, b)
@@ -147,6 +151,194 @@ func (m *APIResource) GetPolicyRef() string {
 	return ""
 }
 
+func (m *APIResource) GetDeliverLimits() *DeliverLimits {
+	if m != nil {
+		return m.DeliverLimits
+	}
+	return nil
+}
+
+// DeliverLimits limits the concurrent deliver streams and the bandwidth of the
+// blocks delivered to each client identity and to each organization on a
+// channel. Zero keeps the limit configured on the peer
+type DeliverLimits struct {
+	MaxStreamsPerIdentity        uint32   `protobuf:"varint,1,opt,name=max_streams_per_identity,json=maxStreamsPerIdentity,proto3" json:"max_streams_per_identity,omitempty"`
+	MaxStreamsPerOrg             uint32   `protobuf:"varint,2,opt,name=max_streams_per_org,json=maxStreamsPerOrg,proto3" json:"max_streams_per_org,omitempty"`
+	MaxBytesPerSecondPerIdentity uint64   `protobuf:"varint,3,opt,name=max_bytes_per_second_per_identity,json=maxBytesPerSecondPerIdentity,proto3" json:"max_bytes_per_second_per_identity,omitempty"`
+	MaxBytesPerSecondPerOrg      uint64   `protobuf:"varint,4,opt,name=max_bytes_per_second_per_org,json=maxBytesPerSecondPerOrg,proto3" json:"max_bytes_per_second_per_org,omitempty"`
+	XXX_NoUnkeyedLiteral         struct{} `json:"-"`
+	XXX_unrecognized             []byte   `json:"-"`
+	XXX_sizecache                int32    `json:"-"`
+}
+
+func (m *DeliverLimits) Reset()         { *m = DeliverLimits{} }
+func (m *DeliverLimits) String() string { return proto.CompactTextString(m) }
+func (*DeliverLimits) ProtoMessage()    {}
+func (*DeliverLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{3}
+}
+func (m *DeliverLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeliverLimits.Unmarshal(m, b)
+}
+func (m *DeliverLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeliverLimits.Marshal(b, m, deterministic)
+}
+func (dst *DeliverLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverLimits.Merge(dst, src)
+}
+func (m *DeliverLimits) XXX_Size() int {
+	return xxx_messageInfo_DeliverLimits.Size(m)
+}
+func (m *DeliverLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverLimits proto.InternalMessageInfo
+
+func (m *DeliverLimits) GetMaxStreamsPerIdentity() uint32 {
+	if m != nil {
+		return m.MaxStreamsPerIdentity
+	}
+	return 0
+}
+
+func (m *DeliverLimits) GetMaxStreamsPerOrg() uint32 {
+	if m != nil {
+		return m.MaxStreamsPerOrg
+	}
+	return 0
+}
+
+func (m *DeliverLimits) GetMaxBytesPerSecondPerIdentity() uint64 {
+	if m != nil {
+		return m.MaxBytesPerSecondPerIdentity
+	}
+	return 0
+}
+
+func (m *DeliverLimits) GetMaxBytesPerSecondPerOrg() uint64 {
+	if m != nil {
+		return m.MaxBytesPerSecondPerOrg
+	}
+	return 0
+}
+
+// ResourceBudget limits the resources used by the simulation of each proposal
+// to the application chaincodes of a channel. The endorsers refuse to endorse
+// the proposals exceeding the budget, and the committers invalidate the
+// transactions whose read-write set exceeds it. Zero means no limit
+type ResourceBudget struct {
+	// The maximum time of the simulation in milliseconds, as measured by the
+	// endorsers. The committers cannot measure it and do not enforce it
+	MaxSimulationTimeMs uint64 `protobuf:"varint,1,opt,name=max_simulation_time_ms,json=maxSimulationTimeMs,proto3" json:"max_simulation_time_ms,omitempty"`
+	// The maximum number of state reads, including the keys read by the range
+	// queries and the reads of private data
+	MaxStateReads uint64 `protobuf:"varint,2,opt,name=max_state_reads,json=maxStateReads,proto3" json:"max_state_reads,omitempty"`
+	// The maximum number of state writes, including the deletions, the
+	// metadata writes and the writes of private data
+	MaxStateWrites uint64 `protobuf:"varint,3,opt,name=max_state_writes,json=maxStateWrites,proto3" json:"max_state_writes,omitempty"`
+	// The maximum number of bytes produced, which are the public read-write
+	// set, the chaincode event and the chaincode response
+	MaxBytesProduced     uint64   `protobuf:"varint,4,opt,name=max_bytes_produced,json=maxBytesProduced,proto3" json:"max_bytes_produced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceBudget) Reset()         { *m = ResourceBudget{} }
+func (m *ResourceBudget) String() string { return proto.CompactTextString(m) }
+func (*ResourceBudget) ProtoMessage()    {}
+func (*ResourceBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{4}
+}
+func (m *ResourceBudget) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceBudget.Unmarshal(m, b)
+}
+func (m *ResourceBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceBudget.Marshal(b, m, deterministic)
+}
+func (dst *ResourceBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceBudget.Merge(dst, src)
+}
+func (m *ResourceBudget) XXX_Size() int {
+	return xxx_messageInfo_ResourceBudget.Size(m)
+}
+func (m *ResourceBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceBudget proto.InternalMessageInfo
+
+func (m *ResourceBudget) GetMaxSimulationTimeMs() uint64 {
+	if m != nil {
+		return m.MaxSimulationTimeMs
+	}
+	return 0
+}
+
+func (m *ResourceBudget) GetMaxStateReads() uint64 {
+	if m != nil {
+		return m.MaxStateReads
+	}
+	return 0
+}
+
+func (m *ResourceBudget) GetMaxStateWrites() uint64 {
+	if m != nil {
+		return m.MaxStateWrites
+	}
+	return 0
+}
+
+func (m *ResourceBudget) GetMaxBytesProduced() uint64 {
+	if m != nil {
+		return m.MaxBytesProduced
+	}
+	return 0
+}
+
+// SystemChaincodePolicies are the endorsement policies of the namespaces of the
+// system chaincodes of a channel, keyed by the name of the system chaincode. The
+// writes to the namespace of a system chaincode without a policy are valid when
+// endorsed by any member of the channel
+type SystemChaincodePolicies struct {
+	Policies             map[string]*common.SignaturePolicyEnvelope `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}                                   `json:"-"`
+	XXX_unrecognized     []byte                                     `json:"-"`
+	XXX_sizecache        int32                                      `json:"-"`
+}
+
+func (m *SystemChaincodePolicies) Reset()         { *m = SystemChaincodePolicies{} }
+func (m *SystemChaincodePolicies) String() string { return proto.CompactTextString(m) }
+func (*SystemChaincodePolicies) ProtoMessage()    {}
+func (*SystemChaincodePolicies) Descriptor() ([]byte, []int) {
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{5}
+}
+func (m *SystemChaincodePolicies) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SystemChaincodePolicies.Unmarshal(m, b)
+}
+func (m *SystemChaincodePolicies) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SystemChaincodePolicies.Marshal(b, m, deterministic)
+}
+func (dst *SystemChaincodePolicies) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SystemChaincodePolicies.Merge(dst, src)
+}
+func (m *SystemChaincodePolicies) XXX_Size() int {
+	return xxx_messageInfo_SystemChaincodePolicies.Size(m)
+}
+func (m *SystemChaincodePolicies) XXX_DiscardUnknown() {
+	xxx_messageInfo_SystemChaincodePolicies.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SystemChaincodePolicies proto.InternalMessageInfo
+
+func (m *SystemChaincodePolicies) GetPolicies() map[string]*common.SignaturePolicyEnvelope {
+	if m != nil {
+		return m.Policies
+	}
+	return nil
+}
+
 // ACLs provides mappings for resources in a channel. APIResource encapsulates
 // reference to a policy used to determine ACL for the resource
 type ACLs struct {
@@ -160,7 +352,7 @@ func (m *ACLs) Reset()         { *m = ACLs{} }
 func (m *ACLs) String() string { return proto.CompactTextString(m) }
 func (*ACLs) ProtoMessage()    {}
 func (*ACLs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_configuration_d9ec63ae33c182ef, []int{3}
+	return fileDescriptor_configuration_9c063a6eb1172633, []int{6}
 }
 func (m *ACLs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ACLs.Unmarshal(m, b)
@@ -191,33 +383,57 @@ func init() {
 	proto.RegisterType((*AnchorPeers)(nil), "protos.AnchorPeers")
 	proto.RegisterType((*AnchorPeer)(nil), "protos.AnchorPeer")
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
+	proto.RegisterType((*DeliverLimits)(nil), "protos.DeliverLimits")
+	proto.RegisterType((*ResourceBudget)(nil), "protos.ResourceBudget")
+	proto.RegisterType((*SystemChaincodePolicies)(nil), "protos.SystemChaincodePolicies")
+	proto.RegisterMapType((map[string]*common.SignaturePolicyEnvelope)(nil), "protos.SystemChaincodePolicies.PoliciesEntry")
 	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterMapType((map[string]*APIResource)(nil), "protos.ACLs.AclsEntry")
 }
 
 func init() {
-	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_d9ec63ae33c182ef)
-}
-
-var fileDescriptor_configuration_d9ec63ae33c182ef = []byte{
-	// 295 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x91, 0xdf, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0x7e, 0x7c, 0x61, 0xb7, 0xdf, 0x07, 0x89, 0x20, 0x45, 0x10, 0x46, 0x9f, 0x36,
-	0x91, 0x14, 0xa6, 0x82, 0xf8, 0x56, 0xa7, 0x0f, 0xc2, 0xc0, 0x91, 0x47, 0x5f, 0x46, 0x16, 0x6f,
-	0x7f, 0x60, 0x6d, 0xca, 0x4d, 0x2a, 0xf4, 0xcd, 0x3f, 0x5d, 0x9a, 0x6c, 0xab, 0x4f, 0x39, 0x39,
-	0xf9, 0x9c, 0xcb, 0x21, 0x17, 0xa2, 0x06, 0x91, 0x12, 0xa5, 0xeb, 0xac, 0xcc, 0x5b, 0x92, 0xb6,
-	0xd4, 0x35, 0x6f, 0x48, 0x5b, 0xcd, 0xfe, 0xb9, 0xc3, 0xc4, 0xcf, 0x10, 0xa6, 0xb5, 0x2a, 0x34,
-	0x6d, 0x11, 0xc9, 0xb0, 0x7b, 0xf8, 0x2f, 0xdd, 0x75, 0xd7, 0x27, 0x4d, 0x14, 0xcc, 0xc7, 0x8b,
-	0x70, 0xc5, 0x7c, 0xc8, 0xf0, 0x01, 0x15, 0xa1, 0x1c, 0x62, 0xf1, 0x1d, 0xc0, 0xf0, 0xc4, 0x18,
-	0x4c, 0x0a, 0x6d, 0x6c, 0x14, 0xcc, 0x83, 0xc5, 0x4c, 0x38, 0xdd, 0x7b, 0x8d, 0x26, 0x1b, 0x8d,
-	0xe6, 0xc1, 0x62, 0x2a, 0x9c, 0x8e, 0x6f, 0x20, 0x4c, 0xb7, 0xaf, 0x02, 0x8d, 0x6e, 0x49, 0x21,
-	0xbb, 0x02, 0x68, 0x74, 0x55, 0xaa, 0x6e, 0x47, 0x98, 0x1d, 0xc2, 0x33, 0xef, 0x08, 0xcc, 0xe2,
-	0x9f, 0x00, 0x26, 0xe9, 0x7a, 0x63, 0xd8, 0x35, 0x4c, 0xa4, 0xaa, 0x8e, 0xdd, 0x2e, 0x4e, 0xdd,
-	0xd6, 0x1b, 0xc3, 0x53, 0x55, 0x99, 0x97, 0xda, 0x52, 0x27, 0x1c, 0x73, 0xb9, 0x81, 0xd9, 0xc9,
-	0x62, 0x67, 0x30, 0xfe, 0xc4, 0xee, 0x30, 0xb9, 0x97, 0x6c, 0x09, 0xd3, 0x6f, 0x59, 0xb5, 0xe8,
-	0x6a, 0x85, 0xab, 0xf3, 0xd3, 0xac, 0xa1, 0x96, 0xf0, 0xc4, 0xe3, 0xe8, 0x21, 0x78, 0x7a, 0x83,
-	0x58, 0x53, 0xce, 0x8b, 0xae, 0x41, 0xaa, 0xf0, 0x23, 0x47, 0xe2, 0x99, 0xdc, 0x53, 0xa9, 0x8e,
-	0xb9, 0xfe, 0xd3, 0xde, 0x97, 0x79, 0x69, 0x8b, 0x76, 0xcf, 0x95, 0xfe, 0x4a, 0xfe, 0xa0, 0x89,
-	0x47, 0x13, 0x8f, 0x26, 0x3d, 0xba, 0xf7, 0x5b, 0xb8, 0xfd, 0x0d, 0x00, 0x00, 0xff, 0xff, 0xae,
-	0x0a, 0x1d, 0x41, 0xa8, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/configuration.proto", fileDescriptor_configuration_9c063a6eb1172633)
+}
+
+var fileDescriptor_configuration_9c063a6eb1172633 = []byte{
+	// 618 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x5d, 0x6b, 0xdb, 0x4a,
+	0x10, 0x45, 0x89, 0x72, 0xb9, 0x19, 0x5f, 0xe7, 0x9a, 0x0d, 0x49, 0x4c, 0xc8, 0xe5, 0xba, 0x7a,
+	0x28, 0x4e, 0x69, 0x64, 0x48, 0x1a, 0x5a, 0x4a, 0xfb, 0xe0, 0x7c, 0x50, 0x02, 0x2e, 0x31, 0xeb,
+	0x42, 0xa1, 0x14, 0xc4, 0x5a, 0x1a, 0xcb, 0xdb, 0x4a, 0x5a, 0xb1, 0xbb, 0x4a, 0xa3, 0xb7, 0xfe,
+	0xb6, 0x42, 0x7f, 0x57, 0x8b, 0x76, 0x25, 0x7f, 0x94, 0xe4, 0xc9, 0xa3, 0x39, 0x67, 0x66, 0xce,
+	0x59, 0xcf, 0x2e, 0x74, 0x73, 0x44, 0x39, 0x08, 0x45, 0x36, 0xe3, 0x71, 0x21, 0x99, 0xe6, 0x22,
+	0xf3, 0x73, 0x29, 0xb4, 0x20, 0x7f, 0x99, 0x1f, 0x75, 0xb8, 0x17, 0x8a, 0x34, 0x15, 0xd9, 0x20,
+	0x17, 0x09, 0x0f, 0x39, 0x2a, 0x0b, 0x7b, 0x57, 0xd0, 0x1a, 0x66, 0xe1, 0x5c, 0xc8, 0x31, 0xa2,
+	0x54, 0xe4, 0x1c, 0xfe, 0x61, 0xe6, 0x33, 0xa8, 0x1a, 0xaa, 0xae, 0xd3, 0xdb, 0xec, 0xb7, 0x4e,
+	0x89, 0x25, 0x2b, 0x7f, 0x49, 0xa5, 0x2d, 0xb6, 0x2c, 0xf3, 0x5e, 0x00, 0x2c, 0x21, 0x42, 0xc0,
+	0x9d, 0x0b, 0xa5, 0xbb, 0x4e, 0xcf, 0xe9, 0x6f, 0x53, 0x13, 0x57, 0xb9, 0x5c, 0x48, 0xdd, 0xdd,
+	0xe8, 0x39, 0xfd, 0x2d, 0x6a, 0x62, 0xef, 0x0b, 0xb4, 0x86, 0xe3, 0x1b, 0x8a, 0x4a, 0x14, 0x32,
+	0x44, 0xf2, 0x1f, 0x80, 0x11, 0x57, 0x06, 0x12, 0x67, 0x75, 0xf1, 0xb6, 0xcd, 0x50, 0x9c, 0x91,
+	0x37, 0xb0, 0x13, 0x61, 0xc2, 0xef, 0x50, 0x06, 0x09, 0x4f, 0xb9, 0x56, 0xa6, 0x57, 0xeb, 0x74,
+	0xaf, 0x11, 0x77, 0x65, 0xd1, 0x91, 0x01, 0x69, 0x3b, 0x5a, 0xfd, 0xf4, 0x7e, 0x39, 0xd0, 0x5e,
+	0x23, 0x90, 0x97, 0xd0, 0x4d, 0xd9, 0x7d, 0xa0, 0xb4, 0x44, 0x96, 0xaa, 0x20, 0x47, 0x19, 0xf0,
+	0x08, 0x33, 0xcd, 0x75, 0x69, 0x86, 0xb7, 0xe9, 0x5e, 0xca, 0xee, 0x27, 0x16, 0x1e, 0xa3, 0xbc,
+	0xa9, 0x41, 0x72, 0x02, 0xbb, 0x7f, 0x16, 0x0a, 0x19, 0x1b, 0x35, 0x6d, 0xda, 0x59, 0xab, 0xb9,
+	0x95, 0x31, 0x79, 0x07, 0x4f, 0x2a, 0xfa, 0xb4, 0xd4, 0x68, 0xc9, 0x0a, 0x43, 0x91, 0x45, 0xeb,
+	0x03, 0x37, 0x7b, 0x4e, 0xdf, 0xa5, 0x47, 0x29, 0xbb, 0xbf, 0xa8, 0x78, 0x63, 0x94, 0x13, 0xc3,
+	0x5a, 0x9d, 0xfb, 0x16, 0x8e, 0x1e, 0x6d, 0x54, 0x09, 0x70, 0x4d, 0x8f, 0x83, 0x87, 0x7a, 0xdc,
+	0xca, 0xd8, 0xfb, 0xe9, 0xc0, 0x4e, 0x73, 0xd6, 0x17, 0x45, 0x14, 0xa3, 0x26, 0x67, 0xb0, 0x6f,
+	0x9c, 0xf0, 0xb4, 0x48, 0xcc, 0xce, 0x04, 0x9a, 0xa7, 0x18, 0xa4, 0xca, 0x1c, 0x80, 0x4b, 0x2b,
+	0x9f, 0x93, 0x05, 0xf8, 0x81, 0xa7, 0xf8, 0x5e, 0x91, 0xa7, 0xf0, 0xaf, 0xb5, 0xcf, 0x34, 0x06,
+	0x12, 0x59, 0x64, 0xff, 0x08, 0x97, 0xb6, 0x8d, 0x75, 0xa6, 0x91, 0x56, 0x49, 0xd2, 0x87, 0xce,
+	0x92, 0xf7, 0x4d, 0x72, 0x8d, 0xaa, 0xb6, 0xb9, 0xd3, 0x10, 0x3f, 0x9a, 0x2c, 0x79, 0x0e, 0x64,
+	0xc5, 0x98, 0x14, 0x51, 0x11, 0x62, 0x54, 0xdb, 0xe9, 0x2c, 0xec, 0xd4, 0x79, 0xef, 0x87, 0x03,
+	0x07, 0x93, 0x52, 0x69, 0x4c, 0x2f, 0xe7, 0x8c, 0x67, 0xa1, 0x88, 0x70, 0x5c, 0xef, 0x34, 0xb9,
+	0x81, 0xbf, 0x9b, 0xfd, 0xae, 0x57, 0xf7, 0xa4, 0xd9, 0x8e, 0x47, 0x4a, 0xfc, 0x26, 0xb8, 0xce,
+	0xb4, 0x2c, 0xe9, 0xa2, 0xfc, 0xf0, 0x33, 0xb4, 0xd7, 0x20, 0xd2, 0x81, 0xcd, 0xaf, 0x58, 0xd6,
+	0x7b, 0x59, 0x85, 0xe4, 0x1c, 0xb6, 0xee, 0x58, 0x52, 0x60, 0xbd, 0x88, 0xff, 0xfb, 0xf6, 0x8a,
+	0xf9, 0x13, 0x1e, 0x67, 0x4c, 0x17, 0xd2, 0x0e, 0x29, 0xaf, 0xb3, 0x3b, 0x4c, 0x44, 0x8e, 0xd4,
+	0xb2, 0x5f, 0x6f, 0xbc, 0x72, 0xbc, 0xef, 0x0e, 0xb8, 0xc3, 0xcb, 0x91, 0x22, 0xcf, 0xc0, 0x65,
+	0x61, 0xd2, 0xa8, 0xdd, 0x5f, 0x5c, 0xb4, 0xcb, 0x91, 0xf2, 0x87, 0x61, 0x52, 0xcb, 0x32, 0x9c,
+	0xc3, 0x11, 0x6c, 0x2f, 0x52, 0x0f, 0xc8, 0x39, 0x5e, 0x97, 0xb3, 0xbb, 0xe8, 0xb5, 0xbc, 0x63,
+	0x2b, 0x12, 0x2e, 0x6e, 0xc1, 0x13, 0x32, 0xf6, 0xe7, 0x65, 0x8e, 0x32, 0xc1, 0x28, 0x46, 0xe9,
+	0xcf, 0xd8, 0x54, 0xf2, 0xb0, 0xa9, 0xab, 0x5e, 0x80, 0x4f, 0xc7, 0x31, 0xd7, 0xf3, 0x62, 0x5a,
+	0x59, 0x1b, 0xac, 0x50, 0x07, 0x96, 0x3a, 0xb0, 0xd4, 0x41, 0x45, 0x9d, 0xda, 0x97, 0xe6, 0xec,
+	0xf7, 0x00, 0x16, 0x6c, 0x6a, 0xff, 0x8c, 0x04, 0x00, 0x00,
 }
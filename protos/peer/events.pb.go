@@ -30,16 +30,21 @@ type FilteredBlock struct {
 	ChannelId            string                 `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
 	Number               uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
 	FilteredTransactions []*FilteredTransaction `protobuf:"bytes,4,rep,name=filtered_transactions,json=filteredTransactions,proto3" json:"filtered_transactions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+	// The hash of the header of the block. Along with the height of the
+	// ledger, number + 1, it is the commit token of the transactions of the
+	// block, whose height clients pass as the minimum ledger height of their
+	// next proposals to read their writes
+	BlockHash            []byte   `protobuf:"bytes,5,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FilteredBlock) Reset()         { *m = FilteredBlock{} }
 func (m *FilteredBlock) String() string { return proto.CompactTextString(m) }
 func (*FilteredBlock) ProtoMessage()    {}
 func (*FilteredBlock) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{0}
+	return fileDescriptor_events_08bbefae89a7a79c, []int{0}
 }
 func (m *FilteredBlock) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredBlock.Unmarshal(m, b)
@@ -80,6 +85,13 @@ func (m *FilteredBlock) GetFilteredTransactions() []*FilteredTransaction {
 	return nil
 }
 
+func (m *FilteredBlock) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 // FilteredTransaction is a minimal set of information about a transaction
 // within a block
 type FilteredTransaction struct {
@@ -88,17 +100,20 @@ type FilteredTransaction struct {
 	TxValidationCode TxValidationCode  `protobuf:"varint,3,opt,name=tx_validation_code,json=txValidationCode,proto3,enum=protos.TxValidationCode" json:"tx_validation_code,omitempty"`
 	// Types that are valid to be assigned to Data:
 	//	*FilteredTransaction_TransactionActions
-	Data                 isFilteredTransaction_Data `protobuf_oneof:"Data"`
-	XXX_NoUnkeyedLiteral struct{}                   `json:"-"`
-	XXX_unrecognized     []byte                     `json:"-"`
-	XXX_sizecache        int32                      `json:"-"`
+	Data isFilteredTransaction_Data `protobuf_oneof:"Data"`
+	// mvcc_conflict is the read that caused the transaction to be invalidated
+	// with the validation code MVCC_READ_CONFLICT, if the committer recorded it
+	MvccConflict         *MVCCConflict `protobuf:"bytes,5,opt,name=mvcc_conflict,json=mvccConflict,proto3" json:"mvcc_conflict,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
 }
 
 func (m *FilteredTransaction) Reset()         { *m = FilteredTransaction{} }
 func (m *FilteredTransaction) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransaction) ProtoMessage()    {}
 func (*FilteredTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{1}
+	return fileDescriptor_events_08bbefae89a7a79c, []int{1}
 }
 func (m *FilteredTransaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransaction.Unmarshal(m, b)
@@ -163,6 +178,13 @@ func (m *FilteredTransaction) GetTransactionActions() *FilteredTransactionAction
 	return nil
 }
 
+func (m *FilteredTransaction) GetMvccConflict() *MVCCConflict {
+	if m != nil {
+		return m.MvccConflict
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*FilteredTransaction) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _FilteredTransaction_OneofMarshaler, _FilteredTransaction_OneofUnmarshaler, _FilteredTransaction_OneofSizer, []interface{}{
@@ -231,7 +253,7 @@ func (m *FilteredTransactionActions) Reset()         { *m = FilteredTransactionA
 func (m *FilteredTransactionActions) String() string { return proto.CompactTextString(m) }
 func (*FilteredTransactionActions) ProtoMessage()    {}
 func (*FilteredTransactionActions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{2}
+	return fileDescriptor_events_08bbefae89a7a79c, []int{2}
 }
 func (m *FilteredTransactionActions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredTransactionActions.Unmarshal(m, b)
@@ -271,7 +293,7 @@ func (m *FilteredChaincodeAction) Reset()         { *m = FilteredChaincodeAction
 func (m *FilteredChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*FilteredChaincodeAction) ProtoMessage()    {}
 func (*FilteredChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{3}
+	return fileDescriptor_events_08bbefae89a7a79c, []int{3}
 }
 func (m *FilteredChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FilteredChaincodeAction.Unmarshal(m, b)
@@ -314,7 +336,7 @@ func (m *DeliverResponse) Reset()         { *m = DeliverResponse{} }
 func (m *DeliverResponse) String() string { return proto.CompactTextString(m) }
 func (*DeliverResponse) ProtoMessage()    {}
 func (*DeliverResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_events_8af932975aef5a3c, []int{4}
+	return fileDescriptor_events_08bbefae89a7a79c, []int{4}
 }
 func (m *DeliverResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeliverResponse.Unmarshal(m, b)
@@ -661,43 +683,48 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/events.proto",
 }
 
-func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_8af932975aef5a3c) }
-
-var fileDescriptor_events_8af932975aef5a3c = []byte{
-	// 560 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0x8e, 0x69, 0x08, 0xea, 0x44, 0x49, 0xdb, 0x2d, 0x4d, 0xa3, 0x20, 0xd4, 0xc8, 0x12, 0xc8,
-	0x5c, 0x62, 0x64, 0x6e, 0x1c, 0x40, 0xa4, 0x3f, 0x0a, 0x12, 0x87, 0x6a, 0x09, 0x1c, 0x7a, 0xc0,
-	0x5a, 0xdb, 0x13, 0xc7, 0xd4, 0xf1, 0x5a, 0xbb, 0x9b, 0x28, 0x79, 0x04, 0xde, 0x80, 0x67, 0xe0,
-	0x09, 0x39, 0x22, 0xaf, 0xbd, 0x49, 0x9a, 0x52, 0x24, 0x4e, 0xf6, 0xce, 0x7c, 0x3f, 0x33, 0xb3,
-	0x63, 0xc3, 0x51, 0x8e, 0x28, 0x5c, 0x5c, 0x60, 0xa6, 0xe4, 0x20, 0x17, 0x5c, 0x71, 0xd2, 0xd0,
-	0x0f, 0xd9, 0x3b, 0x0e, 0xf9, 0x6c, 0xc6, 0x33, 0xb7, 0x7c, 0x94, 0xc9, 0xde, 0x59, 0xcc, 0x79,
-	0x9c, 0xa2, 0xab, 0x4f, 0xc1, 0x7c, 0xe2, 0xaa, 0x64, 0x86, 0x52, 0xb1, 0x59, 0x5e, 0x01, 0x7a,
-	0x5a, 0x30, 0x9c, 0xb2, 0x24, 0x0b, 0x79, 0x84, 0xbe, 0x96, 0xae, 0x72, 0x1d, 0x9d, 0x53, 0x82,
-	0x65, 0x92, 0x85, 0x2a, 0x31, 0xa2, 0xf6, 0x4f, 0x0b, 0x5a, 0x57, 0x49, 0xaa, 0x50, 0x60, 0x34,
-	0x4c, 0x79, 0x78, 0x4b, 0x9e, 0x03, 0x84, 0x53, 0x96, 0x65, 0x98, 0xfa, 0x49, 0xd4, 0xb5, 0xfa,
-	0x96, 0xb3, 0x4f, 0xf7, 0xab, 0xc8, 0xc7, 0x88, 0x74, 0xa0, 0x91, 0xcd, 0x67, 0x01, 0x8a, 0xee,
-	0xa3, 0xbe, 0xe5, 0xd4, 0x69, 0x75, 0x22, 0xd7, 0x70, 0x32, 0xa9, 0x74, 0xfc, 0x2d, 0x1b, 0xd9,
-	0xad, 0xf7, 0xf7, 0x9c, 0xa6, 0xf7, 0xac, 0xf4, 0x93, 0x03, 0x63, 0x36, 0xde, 0x60, 0xe8, 0xd3,
-	0xc9, 0xfd, 0xa0, 0xb4, 0x7f, 0x5b, 0x70, 0xfc, 0x17, 0x34, 0x21, 0x50, 0x57, 0xcb, 0x75, 0x69,
-	0xfa, 0x9d, 0xbc, 0x84, 0xba, 0x5a, 0xe5, 0xa8, 0x6b, 0x6a, 0x7b, 0x64, 0x50, 0x0d, 0x6e, 0x84,
-	0x2c, 0x42, 0x31, 0x5e, 0xe5, 0x48, 0x75, 0x9e, 0x5c, 0x01, 0x51, 0x4b, 0x7f, 0xc1, 0xd2, 0x24,
-	0x62, 0x85, 0x98, 0x5f, 0x0c, 0xaa, 0xbb, 0xa7, 0x59, 0x5d, 0x53, 0xe2, 0x78, 0xf9, 0x75, 0x0d,
-	0x38, 0xe7, 0x11, 0xd2, 0x43, 0xb5, 0x13, 0x21, 0x5f, 0xe0, 0x78, 0xab, 0x49, 0x7f, 0xd3, 0xab,
-	0xe5, 0x34, 0x3d, 0xfb, 0x1f, 0xbd, 0x7e, 0x28, 0x91, 0xa3, 0x1a, 0x25, 0xea, 0x5e, 0x74, 0xd8,
-	0x80, 0xfa, 0x05, 0x53, 0xcc, 0xfe, 0x0e, 0xbd, 0x87, 0xb9, 0xe4, 0x13, 0x1c, 0x6d, 0x2e, 0xd9,
-	0x58, 0x5b, 0x7a, 0xcc, 0x67, 0xbb, 0xd6, 0xe7, 0x06, 0x58, 0x92, 0xe9, 0x61, 0x78, 0x37, 0x20,
-	0xed, 0x1b, 0x38, 0x7d, 0x00, 0x4c, 0xde, 0xc3, 0xc1, 0xce, 0x36, 0xe9, 0xa1, 0x37, 0xbd, 0x8e,
-	0xb1, 0x59, 0x33, 0x2e, 0x8b, 0x2c, 0x6d, 0x87, 0x77, 0xce, 0xf6, 0x2f, 0x0b, 0x0e, 0x2e, 0x30,
-	0x4d, 0x16, 0x28, 0x28, 0xca, 0x9c, 0x67, 0x12, 0x89, 0x03, 0x0d, 0xa9, 0x98, 0x9a, 0x4b, 0xad,
-	0xd5, 0xf6, 0xda, 0xe6, 0xb2, 0x3e, 0xeb, 0xe8, 0xa8, 0x46, 0xab, 0x3c, 0x79, 0x01, 0x8f, 0x83,
-	0x62, 0x25, 0xf5, 0xad, 0x36, 0xbd, 0x96, 0x01, 0xea, 0x3d, 0x1d, 0xd5, 0x68, 0x99, 0x25, 0xef,
-	0xa0, 0xbd, 0xde, 0xbc, 0x12, 0xbf, 0xa7, 0xf1, 0x27, 0xbb, 0xb3, 0x30, 0xbc, 0xd6, 0x64, 0x3b,
-	0x50, 0x0c, 0xbd, 0xd8, 0x10, 0xef, 0x87, 0x05, 0x4f, 0xaa, 0x62, 0xc9, 0xdb, 0xcd, 0xeb, 0xa1,
-	0xb1, 0xbd, 0xcc, 0x16, 0x98, 0xf2, 0x1c, 0x7b, 0xa7, 0x46, 0x78, 0xa7, 0x35, 0xbb, 0xe6, 0x58,
-	0xaf, 0x2d, 0x32, 0x5c, 0xf7, 0x6c, 0x8c, 0xff, 0x5b, 0x63, 0xf8, 0x0d, 0x6c, 0x2e, 0xe2, 0xc1,
-	0x74, 0x95, 0xa3, 0x48, 0x31, 0x8a, 0x51, 0x0c, 0x26, 0x2c, 0x10, 0x49, 0x68, 0x68, 0xc5, 0xe7,
-	0x3c, 0x6c, 0xe9, 0x29, 0xcb, 0x6b, 0x16, 0xde, 0xb2, 0x18, 0x6f, 0x5e, 0xc5, 0x89, 0x9a, 0xce,
-	0x83, 0xc2, 0xcb, 0xdd, 0x62, 0xba, 0x25, 0xb3, 0xfc, 0x6f, 0x48, 0xb7, 0x60, 0x06, 0xe5, 0x8f,
-	0xe6, 0xcd, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x19, 0xd1, 0xa9, 0x84, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/events.proto", fileDescriptor_events_08bbefae89a7a79c) }
+
+var fileDescriptor_events_08bbefae89a7a79c = []byte{
+	// 627 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5f, 0x6f, 0xd3, 0x3e,
+	0x14, 0x6d, 0xb6, 0xae, 0x3f, 0xcd, 0x5d, 0xbb, 0xcd, 0xfb, 0x57, 0xf5, 0xa7, 0x69, 0x55, 0x24,
+	0x50, 0x78, 0x69, 0x50, 0x78, 0x82, 0x07, 0x10, 0xed, 0x36, 0x15, 0x09, 0xa4, 0xc9, 0x8c, 0x3d,
+	0xec, 0x81, 0xc8, 0x75, 0x6e, 0x13, 0xb3, 0x24, 0x8e, 0x62, 0xb7, 0x5a, 0x3f, 0x02, 0x5f, 0x85,
+	0x57, 0x1e, 0xf8, 0x7a, 0x28, 0x4e, 0xdc, 0x76, 0x1d, 0x43, 0xe2, 0x29, 0xf6, 0xbd, 0xe7, 0x9e,
+	0x73, 0x7d, 0x7c, 0x63, 0xb4, 0x9f, 0x01, 0xe4, 0x2e, 0xcc, 0x20, 0x55, 0xb2, 0x9f, 0xe5, 0x42,
+	0x09, 0xdc, 0xd0, 0x1f, 0xd9, 0x3d, 0x60, 0x22, 0x49, 0x44, 0xea, 0x96, 0x9f, 0x32, 0xd9, 0x3d,
+	0x0b, 0x85, 0x08, 0x63, 0x70, 0xf5, 0x6e, 0x3c, 0x9d, 0xb8, 0x8a, 0x27, 0x20, 0x15, 0x4d, 0xb2,
+	0x0a, 0xd0, 0xd5, 0x84, 0x2c, 0xa2, 0x3c, 0x65, 0x22, 0x00, 0x5f, 0x53, 0x57, 0xb9, 0xd3, 0x32,
+	0x27, 0x92, 0x84, 0x2b, 0x3f, 0xe0, 0x34, 0x4c, 0x85, 0x54, 0x9c, 0x55, 0xc2, 0xdd, 0x63, 0x9d,
+	0x56, 0x39, 0x4d, 0x25, 0x65, 0x8a, 0x1b, 0x4d, 0xfb, 0x97, 0x85, 0x5a, 0x97, 0x3c, 0x56, 0x90,
+	0x43, 0x30, 0x88, 0x05, 0xbb, 0xc3, 0xa7, 0x08, 0xb1, 0x88, 0xa6, 0x29, 0xc4, 0x3e, 0x0f, 0x3a,
+	0x56, 0xcf, 0x72, 0xb6, 0xc9, 0x76, 0x15, 0xf9, 0x10, 0xe0, 0x63, 0xd4, 0x48, 0xa7, 0xc9, 0x18,
+	0xf2, 0xce, 0x46, 0xcf, 0x72, 0xea, 0xa4, 0xda, 0xe1, 0x2b, 0x74, 0x34, 0xa9, 0x78, 0xfc, 0x15,
+	0x19, 0xd9, 0xa9, 0xf7, 0x36, 0x9d, 0xa6, 0xf7, 0x7f, 0xa9, 0x27, 0xfb, 0x46, 0xec, 0x7a, 0x89,
+	0x21, 0x87, 0x93, 0xc7, 0x41, 0x59, 0x34, 0x32, 0x2e, 0x3a, 0xf2, 0x23, 0x2a, 0xa3, 0xce, 0x56,
+	0xcf, 0x72, 0x76, 0xc8, 0xb6, 0x8e, 0x8c, 0xa8, 0x8c, 0xec, 0x9f, 0x1b, 0xe8, 0xe0, 0x0f, 0x64,
+	0x18, 0xa3, 0xba, 0xba, 0x5f, 0x74, 0xae, 0xd7, 0xf8, 0x39, 0xaa, 0xab, 0x79, 0x06, 0xba, 0xe5,
+	0xb6, 0x87, 0xfb, 0x95, 0xed, 0x23, 0xa0, 0x01, 0xe4, 0xd7, 0xf3, 0x0c, 0x88, 0xce, 0xe3, 0x4b,
+	0x84, 0xd5, 0xbd, 0x3f, 0xa3, 0x31, 0x0f, 0x68, 0x41, 0xe6, 0x17, 0x36, 0x77, 0x36, 0x75, 0x55,
+	0xc7, 0x9c, 0xe0, 0xfa, 0xfe, 0x66, 0x01, 0x18, 0x8a, 0x00, 0xc8, 0x9e, 0x5a, 0x8b, 0xe0, 0x2f,
+	0xe8, 0x60, 0xc5, 0x03, 0x7f, 0x69, 0x85, 0xe5, 0x34, 0x3d, 0xfb, 0x2f, 0x56, 0xbc, 0x2f, 0x91,
+	0xa3, 0x1a, 0xc1, 0xea, 0x51, 0x14, 0xbf, 0x46, 0xad, 0x64, 0xc6, 0x98, 0xcf, 0x44, 0x3a, 0x89,
+	0x39, 0x53, 0xda, 0x94, 0xa6, 0x77, 0x68, 0x08, 0x3f, 0xdd, 0x0c, 0x87, 0xc3, 0x2a, 0x47, 0x76,
+	0x0a, 0xa8, 0xd9, 0x0d, 0x1a, 0xa8, 0x7e, 0x4e, 0x15, 0xb5, 0xbf, 0xa1, 0xee, 0xd3, 0xb2, 0xf8,
+	0x23, 0xda, 0x5f, 0x4e, 0x97, 0xe9, 0xda, 0xd2, 0x17, 0x78, 0xb6, 0xde, 0xf5, 0xd0, 0x00, 0xcb,
+	0x62, 0xb2, 0xc7, 0x1e, 0x06, 0xa4, 0x7d, 0x8b, 0x4e, 0x9e, 0x00, 0xe3, 0x77, 0x68, 0x77, 0x6d,
+	0x8c, 0xf5, 0x7d, 0x35, 0xbd, 0x63, 0x23, 0xb3, 0xa8, 0xb8, 0x28, 0xb2, 0xa4, 0xcd, 0x1e, 0xec,
+	0xed, 0x1f, 0x16, 0xda, 0x3d, 0x87, 0x98, 0xcf, 0x20, 0x27, 0x20, 0x33, 0x91, 0x4a, 0xc0, 0x0e,
+	0x6a, 0x48, 0x45, 0xd5, 0x54, 0x6a, 0xae, 0xb6, 0xd7, 0x36, 0xf7, 0xfc, 0x59, 0x47, 0x47, 0x35,
+	0x52, 0xe5, 0xf1, 0x33, 0xb4, 0xa5, 0x07, 0x49, 0x0f, 0x44, 0xd3, 0x6b, 0x19, 0xa0, 0xfe, 0x03,
+	0x46, 0x35, 0x52, 0x66, 0xf1, 0x5b, 0xd4, 0x5e, 0xcc, 0x74, 0x89, 0xdf, 0xd4, 0xf8, 0xa3, 0x75,
+	0x2f, 0x4c, 0x5d, 0x6b, 0xb2, 0x1a, 0x28, 0x4c, 0x2f, 0x86, 0xcb, 0xfb, 0x6e, 0xa1, 0xff, 0xaa,
+	0x66, 0xf1, 0x9b, 0xe5, 0x72, 0xcf, 0xc8, 0x5e, 0xa4, 0x33, 0x88, 0x45, 0x06, 0xdd, 0x13, 0x43,
+	0xbc, 0x76, 0x34, 0xbb, 0xe6, 0x58, 0x2f, 0x2d, 0x3c, 0x58, 0x9c, 0xd9, 0x08, 0xff, 0x33, 0xc7,
+	0xe0, 0x2b, 0xb2, 0x45, 0x1e, 0xf6, 0xa3, 0x79, 0x06, 0x79, 0x0c, 0x41, 0x08, 0x79, 0x7f, 0x42,
+	0xc7, 0x39, 0x67, 0xa6, 0xac, 0x78, 0x28, 0x06, 0x2d, 0xed, 0xb2, 0xbc, 0xa2, 0xec, 0x8e, 0x86,
+	0x70, 0xfb, 0x22, 0xe4, 0x2a, 0x9a, 0x8e, 0x0b, 0x2d, 0x77, 0xa5, 0xd2, 0x2d, 0x2b, 0xcb, 0x07,
+	0x4b, 0xba, 0x45, 0xe5, 0xb8, 0x7c, 0xe1, 0x5e, 0xfd, 0x1e, 0x00, 0x33, 0x79, 0x00, 0xa9, 0xfd,
+	0x04, 0x00, 0x00,
 }
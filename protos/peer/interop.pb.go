@@ -0,0 +1,357 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/interop.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import msp "github.com/hyperledger/fabric/protos/msp"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// ViewRequest requests a view of the state of a network, which is the
+// response of a query of a chaincode of one of its channels
+type ViewRequest struct {
+	Network              string   `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
+	Channel              string   `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	Chaincode            string   `protobuf:"bytes,3,opt,name=chaincode,proto3" json:"chaincode,omitempty"`
+	Args                 [][]byte `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ViewRequest) Reset()         { *m = ViewRequest{} }
+func (m *ViewRequest) String() string { return proto.CompactTextString(m) }
+func (*ViewRequest) ProtoMessage()    {}
+func (*ViewRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_interop_b5570d3437d08c55, []int{0}
+}
+func (m *ViewRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewRequest.Unmarshal(m, b)
+}
+func (m *ViewRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewRequest.Marshal(b, m, deterministic)
+}
+func (dst *ViewRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewRequest.Merge(dst, src)
+}
+func (m *ViewRequest) XXX_Size() int {
+	return xxx_messageInfo_ViewRequest.Size(m)
+}
+func (m *ViewRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewRequest proto.InternalMessageInfo
+
+func (m *ViewRequest) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *ViewRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ViewRequest) GetChaincode() string {
+	if m != nil {
+		return m.Chaincode
+	}
+	return ""
+}
+
+func (m *ViewRequest) GetArgs() [][]byte {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+// ViewEndorsement is the response of the query of a view endorsed by a peer
+// of the network of the view
+type ViewEndorsement struct {
+	// proposal is the marshaled Proposal of the query, signed by the relay
+	// which served the view
+	Proposal             []byte            `protobuf:"bytes,1,opt,name=proposal,proto3" json:"proposal,omitempty"`
+	Response             *ProposalResponse `protobuf:"bytes,2,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ViewEndorsement) Reset()         { *m = ViewEndorsement{} }
+func (m *ViewEndorsement) String() string { return proto.CompactTextString(m) }
+func (*ViewEndorsement) ProtoMessage()    {}
+func (*ViewEndorsement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_interop_b5570d3437d08c55, []int{1}
+}
+func (m *ViewEndorsement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ViewEndorsement.Unmarshal(m, b)
+}
+func (m *ViewEndorsement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ViewEndorsement.Marshal(b, m, deterministic)
+}
+func (dst *ViewEndorsement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ViewEndorsement.Merge(dst, src)
+}
+func (m *ViewEndorsement) XXX_Size() int {
+	return xxx_messageInfo_ViewEndorsement.Size(m)
+}
+func (m *ViewEndorsement) XXX_DiscardUnknown() {
+	xxx_messageInfo_ViewEndorsement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ViewEndorsement proto.InternalMessageInfo
+
+func (m *ViewEndorsement) GetProposal() []byte {
+	if m != nil {
+		return m.Proposal
+	}
+	return nil
+}
+
+func (m *ViewEndorsement) GetResponse() *ProposalResponse {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+// View is the response of the query of a view along with its endorsements,
+// which proves the state of the network of the view to the networks trusting
+// the MSPs of its organizations
+type View struct {
+	Request              *ViewRequest       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Endorsements         []*ViewEndorsement `protobuf:"bytes,2,rep,name=endorsements,proto3" json:"endorsements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *View) Reset()         { *m = View{} }
+func (m *View) String() string { return proto.CompactTextString(m) }
+func (*View) ProtoMessage()    {}
+func (*View) Descriptor() ([]byte, []int) {
+	return fileDescriptor_interop_b5570d3437d08c55, []int{2}
+}
+func (m *View) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_View.Unmarshal(m, b)
+}
+func (m *View) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_View.Marshal(b, m, deterministic)
+}
+func (dst *View) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_View.Merge(dst, src)
+}
+func (m *View) XXX_Size() int {
+	return xxx_messageInfo_View.Size(m)
+}
+func (m *View) XXX_DiscardUnknown() {
+	xxx_messageInfo_View.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_View proto.InternalMessageInfo
+
+func (m *View) GetRequest() *ViewRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *View) GetEndorsements() []*ViewEndorsement {
+	if m != nil {
+		return m.Endorsements
+	}
+	return nil
+}
+
+// InteropNetwork is the configuration of another network whose views are
+// verified by the interop system chaincode
+type InteropNetwork struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// msps are the MSPs of the organizations of the network
+	Msps []*msp.MSPConfig `protobuf:"bytes,2,rep,name=msps,proto3" json:"msps,omitempty"`
+	// policy is the marshaled SignaturePolicyEnvelope the endorsements of the
+	// views of the network must satisfy
+	Policy               []byte   `protobuf:"bytes,3,opt,name=policy,proto3" json:"policy,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *InteropNetwork) Reset()         { *m = InteropNetwork{} }
+func (m *InteropNetwork) String() string { return proto.CompactTextString(m) }
+func (*InteropNetwork) ProtoMessage()    {}
+func (*InteropNetwork) Descriptor() ([]byte, []int) {
+	return fileDescriptor_interop_b5570d3437d08c55, []int{3}
+}
+func (m *InteropNetwork) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_InteropNetwork.Unmarshal(m, b)
+}
+func (m *InteropNetwork) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_InteropNetwork.Marshal(b, m, deterministic)
+}
+func (dst *InteropNetwork) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InteropNetwork.Merge(dst, src)
+}
+func (m *InteropNetwork) XXX_Size() int {
+	return xxx_messageInfo_InteropNetwork.Size(m)
+}
+func (m *InteropNetwork) XXX_DiscardUnknown() {
+	xxx_messageInfo_InteropNetwork.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InteropNetwork proto.InternalMessageInfo
+
+func (m *InteropNetwork) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *InteropNetwork) GetMsps() []*msp.MSPConfig {
+	if m != nil {
+		return m.Msps
+	}
+	return nil
+}
+
+func (m *InteropNetwork) GetPolicy() []byte {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ViewRequest)(nil), "protos.ViewRequest")
+	proto.RegisterType((*ViewEndorsement)(nil), "protos.ViewEndorsement")
+	proto.RegisterType((*View)(nil), "protos.View")
+	proto.RegisterType((*InteropNetwork)(nil), "protos.InteropNetwork")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RelayClient is the client API for Relay service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RelayClient interface {
+	RequestView(ctx context.Context, in *ViewRequest, opts ...grpc.CallOption) (*View, error)
+}
+
+type relayClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRelayClient(cc *grpc.ClientConn) RelayClient {
+	return &relayClient{cc}
+}
+
+func (c *relayClient) RequestView(ctx context.Context, in *ViewRequest, opts ...grpc.CallOption) (*View, error) {
+	out := new(View)
+	err := c.cc.Invoke(ctx, "/protos.Relay/RequestView", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelayServer is the server API for Relay service.
+type RelayServer interface {
+	RequestView(context.Context, *ViewRequest) (*View, error)
+}
+
+func RegisterRelayServer(s *grpc.Server, srv RelayServer) {
+	s.RegisterService(&_Relay_serviceDesc, srv)
+}
+
+func _Relay_RequestView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelayServer).RequestView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Relay/RequestView",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelayServer).RequestView(ctx, req.(*ViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Relay_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.Relay",
+	HandlerType: (*RelayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestView",
+			Handler:    _Relay_RequestView_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "peer/interop.proto",
+}
+
+func init() { proto.RegisterFile("peer/interop.proto", fileDescriptor_interop_b5570d3437d08c55) }
+
+var fileDescriptor_interop_b5570d3437d08c55 = []byte{
+	// 392 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xcf, 0x8b, 0xd4, 0x30,
+	0x14, 0x76, 0x3b, 0x75, 0x7f, 0xbc, 0x96, 0x11, 0xa2, 0x68, 0x29, 0x7b, 0x18, 0x7a, 0x1a, 0x0f,
+	0xb6, 0x50, 0xbd, 0xed, 0x6d, 0xc5, 0x83, 0x07, 0x65, 0x88, 0xe0, 0x41, 0x84, 0x21, 0x93, 0xbe,
+	0x6d, 0xc3, 0xb6, 0x49, 0x4c, 0xba, 0x2c, 0xf3, 0xdf, 0x4b, 0x93, 0x66, 0xa6, 0x82, 0xa7, 0xf6,
+	0xe5, 0xfb, 0xbe, 0xbc, 0xef, 0x7b, 0x2f, 0x40, 0x34, 0xa2, 0xa9, 0x84, 0x1c, 0xd1, 0x28, 0x5d,
+	0x6a, 0xa3, 0x46, 0x45, 0x2e, 0xdd, 0xc7, 0xe6, 0x6f, 0x06, 0xab, 0xab, 0xc1, 0xea, 0x3d, 0x57,
+	0xf2, 0x41, 0xb4, 0x1e, 0xcd, 0x6f, 0x9d, 0x42, 0x1b, 0xa5, 0x95, 0x65, 0xfd, 0xde, 0xa0, 0xd5,
+	0x4a, 0x5a, 0xf4, 0x68, 0x61, 0x21, 0xf9, 0x29, 0xf0, 0x99, 0xe2, 0x9f, 0x27, 0xb4, 0x23, 0xc9,
+	0xe0, 0x4a, 0xe2, 0xf8, 0xac, 0xcc, 0x63, 0x76, 0xb1, 0xb9, 0xd8, 0xde, 0xd0, 0x50, 0x4e, 0x08,
+	0xef, 0x98, 0x94, 0xd8, 0x67, 0x91, 0x47, 0xe6, 0x92, 0xdc, 0xc2, 0x0d, 0xef, 0x98, 0x90, 0x5c,
+	0x35, 0x98, 0xad, 0x1c, 0x76, 0x3e, 0x20, 0x04, 0x62, 0x66, 0x5a, 0x9b, 0xc5, 0x9b, 0xd5, 0x36,
+	0xa5, 0xee, 0xbf, 0xe0, 0xf0, 0x6a, 0x6a, 0xfa, 0x45, 0x36, 0xca, 0x58, 0x1c, 0x50, 0x8e, 0x24,
+	0x87, 0xeb, 0x60, 0xd1, 0x75, 0x4e, 0xe9, 0xa9, 0x26, 0x9f, 0xe0, 0x3a, 0xb8, 0x76, 0xbd, 0x93,
+	0x3a, 0xf3, 0xee, 0x6d, 0xb9, 0x9b, 0x39, 0x74, 0xc6, 0xe9, 0x89, 0x59, 0x18, 0x88, 0xa7, 0x26,
+	0xe4, 0x03, 0x5c, 0x19, 0x9f, 0xce, 0x5d, 0x9c, 0xd4, 0xaf, 0x83, 0x78, 0x11, 0x9c, 0x06, 0x0e,
+	0xb9, 0x83, 0x14, 0xcf, 0xbe, 0x6c, 0x16, 0x6d, 0x56, 0xdb, 0xa4, 0x7e, 0xb7, 0xd4, 0x2c, 0x7c,
+	0xd3, 0x7f, 0xc8, 0xc5, 0x6f, 0x58, 0x7f, 0xf5, 0xab, 0xf9, 0x3e, 0x8f, 0x6d, 0x0d, 0x91, 0x68,
+	0xe6, 0x59, 0x46, 0xa2, 0x21, 0x05, 0xc4, 0x83, 0xd5, 0xe1, 0xda, 0x75, 0x39, 0x58, 0x5d, 0x7e,
+	0xfb, 0xb1, 0xfb, 0xec, 0x36, 0x46, 0x1d, 0x46, 0xde, 0xc2, 0xa5, 0x56, 0xbd, 0xe0, 0x47, 0x37,
+	0xcd, 0x94, 0xce, 0x55, 0x7d, 0x07, 0x2f, 0x29, 0xf6, 0xec, 0x48, 0x6a, 0x48, 0x66, 0xdf, 0x2e,
+	0xe1, 0xff, 0x02, 0xe5, 0xe9, 0xf2, 0xb0, 0x78, 0x71, 0xbf, 0x87, 0x42, 0x99, 0xb6, 0xec, 0x8e,
+	0x1a, 0x4d, 0x8f, 0x4d, 0x8b, 0xa6, 0x7c, 0x60, 0x07, 0x23, 0x78, 0xe0, 0x69, 0x44, 0x73, 0x1f,
+	0xec, 0xef, 0x18, 0x7f, 0x64, 0x2d, 0xfe, 0x7a, 0xdf, 0x8a, 0xb1, 0x7b, 0x3a, 0x94, 0x5c, 0x0d,
+	0xd5, 0x42, 0x5a, 0x79, 0x69, 0xe5, 0xa5, 0xd5, 0x24, 0x3d, 0xf8, 0x57, 0xf8, 0xf1, 0xef, 0x00,
+	0xc8, 0x57, 0xbf, 0xbd, 0xa2, 0x02, 0x00, 0x00,
+}
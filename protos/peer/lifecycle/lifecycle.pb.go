@@ -33,7 +33,7 @@ func (m *InstallChaincodeArgs) Reset()         { *m = InstallChaincodeArgs{} }
 func (m *InstallChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeArgs) ProtoMessage()    {}
 func (*InstallChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{0}
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{0}
 }
 func (m *InstallChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeArgs.Unmarshal(m, b)
@@ -87,7 +87,7 @@ func (m *InstallChaincodeResult) Reset()         { *m = InstallChaincodeResult{}
 func (m *InstallChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*InstallChaincodeResult) ProtoMessage()    {}
 func (*InstallChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{1}
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{1}
 }
 func (m *InstallChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InstallChaincodeResult.Unmarshal(m, b)
@@ -128,7 +128,7 @@ func (m *QueryInstalledChaincodeArgs) Reset()         { *m = QueryInstalledChain
 func (m *QueryInstalledChaincodeArgs) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeArgs) ProtoMessage()    {}
 func (*QueryInstalledChaincodeArgs) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{2}
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{2}
 }
 func (m *QueryInstalledChaincodeArgs) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeArgs.Unmarshal(m, b)
@@ -175,7 +175,7 @@ func (m *QueryInstalledChaincodeResult) Reset()         { *m = QueryInstalledCha
 func (m *QueryInstalledChaincodeResult) String() string { return proto.CompactTextString(m) }
 func (*QueryInstalledChaincodeResult) ProtoMessage()    {}
 func (*QueryInstalledChaincodeResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_lifecycle_f98901bea638af10, []int{3}
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{3}
 }
 func (m *QueryInstalledChaincodeResult) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryInstalledChaincodeResult.Unmarshal(m, b)
@@ -202,33 +202,254 @@ func (m *QueryInstalledChaincodeResult) GetHash() []byte {
 	return nil
 }
 
+// QueryInstalledChaincodesArgs is the message used as the argument to
+// '+lifecycle.QueryInstalledChaincodes'
+type QueryInstalledChaincodesArgs struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesArgs) Reset()         { *m = QueryInstalledChaincodesArgs{} }
+func (m *QueryInstalledChaincodesArgs) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesArgs) ProtoMessage()    {}
+func (*QueryInstalledChaincodesArgs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{4}
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesArgs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesArgs.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesArgs.Size(m)
+}
+func (m *QueryInstalledChaincodesArgs) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesArgs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesArgs proto.InternalMessageInfo
+
+// QueryInstalledChaincodesResult is the message returned by
+// '+lifecycle.QueryInstalledChaincodes'. The installed chaincodes
+// which no chaincode definition references are orphaned
+type QueryInstalledChaincodesResult struct {
+	InstalledChaincodes  []*QueryInstalledChaincodesResult_InstalledChaincode `protobuf:"bytes,1,rep,name=installed_chaincodes,json=installedChaincodes,proto3" json:"installed_chaincodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                                             `json:"-"`
+	XXX_unrecognized     []byte                                               `json:"-"`
+	XXX_sizecache        int32                                                `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult) Reset()         { *m = QueryInstalledChaincodesResult{} }
+func (m *QueryInstalledChaincodesResult) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesResult) ProtoMessage()    {}
+func (*QueryInstalledChaincodesResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{5}
+}
+func (m *QueryInstalledChaincodesResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult.Size(m)
+}
+func (m *QueryInstalledChaincodesResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult) GetInstalledChaincodes() []*QueryInstalledChaincodesResult_InstalledChaincode {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+// Reference is a chaincode definition of a channel which references
+// an installed chaincode
+type QueryInstalledChaincodesResult_Reference struct {
+	ChannelId            string   `protobuf:"bytes,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Version              string   `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult_Reference) Reset() {
+	*m = QueryInstalledChaincodesResult_Reference{}
+}
+func (m *QueryInstalledChaincodesResult_Reference) String() string { return proto.CompactTextString(m) }
+func (*QueryInstalledChaincodesResult_Reference) ProtoMessage()    {}
+func (*QueryInstalledChaincodesResult_Reference) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{5, 0}
+}
+func (m *QueryInstalledChaincodesResult_Reference) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_Reference.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult_Reference) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_Reference.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult_Reference) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult_Reference.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult_Reference) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_Reference.Size(m)
+}
+func (m *QueryInstalledChaincodesResult_Reference) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult_Reference.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult_Reference proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult_Reference) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_Reference) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_Reference) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+// InstalledChaincode is an installed chaincode with the chaincode
+// definitions referencing it
+type QueryInstalledChaincodesResult_InstalledChaincode struct {
+	Name       string                                      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version    string                                      `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Hash       []byte                                      `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	References []*QueryInstalledChaincodesResult_Reference `protobuf:"bytes,4,rep,name=references,proto3" json:"references,omitempty"`
+	// package_id is empty for the chaincodes installed with the legacy
+	// lifecycle
+	PackageId            string   `protobuf:"bytes,5,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) Reset() {
+	*m = QueryInstalledChaincodesResult_InstalledChaincode{}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) String() string {
+	return proto.CompactTextString(m)
+}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) ProtoMessage() {}
+func (*QueryInstalledChaincodesResult_InstalledChaincode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_lifecycle_d034912ff6f22ded, []int{5, 1}
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Unmarshal(m, b)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Marshal(b, m, deterministic)
+}
+func (dst *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Merge(dst, src)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_Size() int {
+	return xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.Size(m)
+}
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryInstalledChaincodesResult_InstalledChaincode proto.InternalMessageInfo
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetReferences() []*QueryInstalledChaincodesResult_Reference {
+	if m != nil {
+		return m.References
+	}
+	return nil
+}
+
+func (m *QueryInstalledChaincodesResult_InstalledChaincode) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*InstallChaincodeArgs)(nil), "lifecycle.InstallChaincodeArgs")
 	proto.RegisterType((*InstallChaincodeResult)(nil), "lifecycle.InstallChaincodeResult")
 	proto.RegisterType((*QueryInstalledChaincodeArgs)(nil), "lifecycle.QueryInstalledChaincodeArgs")
 	proto.RegisterType((*QueryInstalledChaincodeResult)(nil), "lifecycle.QueryInstalledChaincodeResult")
+	proto.RegisterType((*QueryInstalledChaincodesArgs)(nil), "lifecycle.QueryInstalledChaincodesArgs")
+	proto.RegisterType((*QueryInstalledChaincodesResult)(nil), "lifecycle.QueryInstalledChaincodesResult")
+	proto.RegisterType((*QueryInstalledChaincodesResult_Reference)(nil), "lifecycle.QueryInstalledChaincodesResult.Reference")
+	proto.RegisterType((*QueryInstalledChaincodesResult_InstalledChaincode)(nil), "lifecycle.QueryInstalledChaincodesResult.InstalledChaincode")
 }
 
 func init() {
-	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_f98901bea638af10)
-}
-
-var fileDescriptor_lifecycle_f98901bea638af10 = []byte{
-	// 255 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x3f, 0x4f, 0xc3, 0x30,
-	0x10, 0xc5, 0x15, 0x40, 0xa0, 0x9e, 0x3a, 0x59, 0x08, 0x82, 0x10, 0xa8, 0xca, 0xd4, 0xa1, 0xb2,
-	0x87, 0x6e, 0x6c, 0xc0, 0x84, 0x58, 0x20, 0x23, 0x4b, 0xe5, 0x38, 0x57, 0xdb, 0xc2, 0x8d, 0xa3,
-	0x73, 0x82, 0x94, 0x8d, 0x8f, 0x8e, 0x6a, 0xb7, 0xe1, 0x8f, 0xd4, 0x89, 0xed, 0xf9, 0xd9, 0xef,
-	0xfc, 0xd3, 0x3b, 0xb8, 0x6d, 0x11, 0x49, 0x38, 0xbb, 0x46, 0x35, 0x28, 0x87, 0xdf, 0x8a, 0xb7,
-	0xe4, 0x3b, 0xcf, 0x26, 0xa3, 0x51, 0x7c, 0x66, 0x70, 0xfe, 0xd4, 0x84, 0x4e, 0x3a, 0xf7, 0x68,
-	0xa4, 0x6d, 0x94, 0xaf, 0xf1, 0x9e, 0x74, 0x60, 0x0c, 0x4e, 0x1a, 0xb9, 0xc1, 0x3c, 0x9b, 0x65,
-	0xf3, 0x49, 0x19, 0x35, 0xcb, 0xe1, 0xec, 0x03, 0x29, 0x58, 0xdf, 0xe4, 0x47, 0xd1, 0xde, 0x1f,
-	0xd9, 0x1d, 0x5c, 0xa9, 0x7d, 0x7c, 0x65, 0xd3, 0xbc, 0x55, 0x2b, 0xd5, 0xbb, 0xd4, 0x98, 0x1f,
-	0xcf, 0xb2, 0xf9, 0xb4, 0xbc, 0x1c, 0x1f, 0xec, 0xfe, 0x7b, 0x49, 0xd7, 0xc5, 0x02, 0x2e, 0xfe,
-	0x12, 0x94, 0x18, 0x7a, 0xd7, 0x6d, 0x19, 0x8c, 0x0c, 0x26, 0x32, 0x4c, 0xcb, 0xa8, 0x8b, 0x67,
-	0xb8, 0x7e, 0xed, 0x91, 0x86, 0x5d, 0x04, 0xeb, 0x7f, 0x60, 0x17, 0x4b, 0xb8, 0x39, 0x30, 0xec,
-	0x30, 0xc1, 0x83, 0x82, 0x85, 0x27, 0xcd, 0xcd, 0xd0, 0x22, 0x39, 0xac, 0x35, 0x12, 0x5f, 0xcb,
-	0x8a, 0xac, 0x4a, 0xed, 0x06, 0xbe, 0x6d, 0x9f, 0x8f, 0x15, 0xbf, 0x2d, 0xb5, 0xed, 0x4c, 0x5f,
-	0x71, 0xe5, 0x37, 0xe2, 0x47, 0x48, 0xa4, 0x90, 0x48, 0x21, 0xf1, 0x7b, 0x65, 0xd5, 0x69, 0xb4,
-	0x97, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd3, 0x6e, 0xec, 0xb6, 0xcb, 0x01, 0x00, 0x00,
+	proto.RegisterFile("peer/lifecycle/lifecycle.proto", fileDescriptor_lifecycle_d034912ff6f22ded)
+}
+
+var fileDescriptor_lifecycle_d034912ff6f22ded = []byte{
+	// 380 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x93, 0xcd, 0x4e, 0x83, 0x40,
+	0x10, 0xc7, 0x43, 0xa9, 0x1a, 0xc6, 0x9e, 0xd6, 0x46, 0xb1, 0xda, 0xa6, 0xe1, 0xd4, 0x43, 0x03,
+	0x89, 0xdc, 0x8c, 0x17, 0xf5, 0xd4, 0x78, 0x51, 0xbc, 0x18, 0x2f, 0x64, 0xbb, 0x4c, 0x61, 0x23,
+	0x05, 0xb2, 0x4b, 0x4d, 0x7a, 0xf3, 0xb1, 0x7c, 0x06, 0x9f, 0xca, 0xf0, 0xdd, 0xda, 0x8f, 0x68,
+	0xbc, 0x0d, 0xb3, 0xf3, 0x1f, 0x7e, 0xf3, 0xdf, 0x1d, 0x18, 0x24, 0x88, 0xc2, 0x0a, 0xf9, 0x0c,
+	0xd9, 0x92, 0x85, 0xd8, 0x44, 0x66, 0x22, 0xe2, 0x34, 0x26, 0x5a, 0x9d, 0x30, 0x3e, 0x14, 0xe8,
+	0x4e, 0x22, 0x99, 0xd2, 0x30, 0xbc, 0x0f, 0x28, 0x8f, 0x58, 0xec, 0xe1, 0xad, 0xf0, 0x25, 0x21,
+	0xd0, 0x8e, 0xe8, 0x1c, 0x75, 0x65, 0xa8, 0x8c, 0x34, 0x27, 0x8f, 0x89, 0x0e, 0x47, 0xef, 0x28,
+	0x24, 0x8f, 0x23, 0xbd, 0x95, 0xa7, 0xab, 0x4f, 0x72, 0x0d, 0xe7, 0xac, 0x92, 0xbb, 0xbc, 0xe8,
+	0xe7, 0x26, 0x94, 0xbd, 0x51, 0x1f, 0x75, 0x75, 0xa8, 0x8c, 0x3a, 0xce, 0x59, 0x5d, 0x50, 0xfe,
+	0xef, 0xb1, 0x38, 0x36, 0xc6, 0x70, 0xfa, 0x93, 0xc0, 0x41, 0xb9, 0x08, 0xd3, 0x8c, 0x21, 0xa0,
+	0x32, 0xc8, 0x19, 0x3a, 0x4e, 0x1e, 0x1b, 0x0f, 0x70, 0xf1, 0xb4, 0x40, 0xb1, 0x2c, 0x25, 0xe8,
+	0xfd, 0x03, 0xdb, 0xb0, 0xa1, 0xbf, 0xa3, 0xd9, 0x1e, 0x82, 0x01, 0x5c, 0xee, 0x10, 0xc9, 0x0c,
+	0xc1, 0xf8, 0x54, 0x61, 0xb0, 0xab, 0xa0, 0x6c, 0x1b, 0x43, 0x97, 0x57, 0x87, 0x6e, 0xed, 0x8b,
+	0xd4, 0x95, 0xa1, 0x3a, 0x3a, 0xbe, 0xba, 0x31, 0x9b, 0x0b, 0xdb, 0xdf, 0xc8, 0xdc, 0x02, 0x7e,
+	0xc2, 0x37, 0xab, 0x7b, 0x2f, 0xa0, 0x39, 0x38, 0x43, 0x81, 0x11, 0x43, 0xd2, 0x07, 0x60, 0x01,
+	0x8d, 0x22, 0x0c, 0x5d, 0xee, 0x95, 0x4e, 0x69, 0x65, 0x66, 0xe2, 0xd5, 0x16, 0xb6, 0xb6, 0x5b,
+	0xa8, 0xae, 0x59, 0xd8, 0xfb, 0x52, 0x80, 0x6c, 0x52, 0xfc, 0xf1, 0xf9, 0x54, 0x36, 0xab, 0x8d,
+	0xcd, 0xe4, 0x19, 0x40, 0x54, 0xc8, 0x52, 0x6f, 0xe7, 0xce, 0xd8, 0xbf, 0x77, 0xa6, 0x1e, 0xd7,
+	0x59, 0x69, 0x93, 0x8d, 0x5e, 0xbe, 0xca, 0x6c, 0xf4, 0x83, 0x62, 0xf4, 0x32, 0x33, 0xf1, 0xee,
+	0x18, 0x8c, 0x63, 0xe1, 0x9b, 0xc1, 0x32, 0x41, 0x11, 0xa2, 0xe7, 0xa3, 0x30, 0x67, 0x74, 0x2a,
+	0x38, 0x2b, 0x16, 0x47, 0x9a, 0xd9, 0x62, 0x35, 0x0c, 0xaf, 0xb6, 0xcf, 0xd3, 0x60, 0x31, 0x35,
+	0x59, 0x3c, 0xb7, 0x56, 0x44, 0x56, 0x21, 0xb2, 0x0a, 0x91, 0xb5, 0xbe, 0x8d, 0xd3, 0xc3, 0x3c,
+	0x6d, 0x7f, 0x0f, 0x00, 0x05, 0xf6, 0xf1, 0x98, 0xa6, 0x03, 0x00, 0x00,
 }
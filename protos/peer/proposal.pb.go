@@ -59,7 +59,7 @@ func (m *SignedProposal) Reset()         { *m = SignedProposal{} }
 func (m *SignedProposal) String() string { return proto.CompactTextString(m) }
 func (*SignedProposal) ProtoMessage()    {}
 func (*SignedProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{0}
+	return fileDescriptor_proposal_0010a4cf82ffd316, []int{0}
 }
 func (m *SignedProposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SignedProposal.Unmarshal(m, b)
@@ -131,7 +131,7 @@ func (m *Proposal) Reset()         { *m = Proposal{} }
 func (m *Proposal) String() string { return proto.CompactTextString(m) }
 func (*Proposal) ProtoMessage()    {}
 func (*Proposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{1}
+	return fileDescriptor_proposal_0010a4cf82ffd316, []int{1}
 }
 func (m *Proposal) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Proposal.Unmarshal(m, b)
@@ -189,17 +189,22 @@ type ChaincodeHeaderExtension struct {
 	// this field impacts the content of ProposalResponsePayload.proposalHash.
 	PayloadVisibility []byte `protobuf:"bytes,1,opt,name=payload_visibility,json=payloadVisibility,proto3" json:"payload_visibility,omitempty"`
 	// The ID of the chaincode to target.
-	ChaincodeId          *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	ChaincodeId *ChaincodeID `protobuf:"bytes,2,opt,name=chaincode_id,json=chaincodeId,proto3" json:"chaincode_id,omitempty"`
+	// The minimum height the ledger of the channel must have reached for the
+	// endorser to simulate the proposal, such as the height of the block that
+	// committed a transaction of the client, so that a client reading through
+	// different peers gets a monotonic view of the ledger. Zero means no minimum.
+	MinLedgerHeight      uint64   `protobuf:"varint,3,opt,name=min_ledger_height,json=minLedgerHeight,proto3" json:"min_ledger_height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ChaincodeHeaderExtension) Reset()         { *m = ChaincodeHeaderExtension{} }
 func (m *ChaincodeHeaderExtension) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeHeaderExtension) ProtoMessage()    {}
 func (*ChaincodeHeaderExtension) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{2}
+	return fileDescriptor_proposal_0010a4cf82ffd316, []int{2}
 }
 func (m *ChaincodeHeaderExtension) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeHeaderExtension.Unmarshal(m, b)
@@ -233,6 +238,13 @@ func (m *ChaincodeHeaderExtension) GetChaincodeId() *ChaincodeID {
 	return nil
 }
 
+func (m *ChaincodeHeaderExtension) GetMinLedgerHeight() uint64 {
+	if m != nil {
+		return m.MinLedgerHeight
+	}
+	return 0
+}
+
 // ChaincodeProposalPayload is the Proposal's payload message to be used when
 // the Header's type is CHAINCODE.  It contains the arguments for this
 // invocation.
@@ -255,7 +267,7 @@ func (m *ChaincodeProposalPayload) Reset()         { *m = ChaincodeProposalPaylo
 func (m *ChaincodeProposalPayload) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeProposalPayload) ProtoMessage()    {}
 func (*ChaincodeProposalPayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{3}
+	return fileDescriptor_proposal_0010a4cf82ffd316, []int{3}
 }
 func (m *ChaincodeProposalPayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeProposalPayload.Unmarshal(m, b)
@@ -318,7 +330,7 @@ func (m *ChaincodeAction) Reset()         { *m = ChaincodeAction{} }
 func (m *ChaincodeAction) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeAction) ProtoMessage()    {}
 func (*ChaincodeAction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_2be65988745cf952, []int{4}
+	return fileDescriptor_proposal_0010a4cf82ffd316, []int{4}
 }
 func (m *ChaincodeAction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeAction.Unmarshal(m, b)
@@ -382,39 +394,40 @@ func init() {
 	proto.RegisterType((*ChaincodeAction)(nil), "protos.ChaincodeAction")
 }
 
-func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_2be65988745cf952) }
-
-var fileDescriptor_proposal_2be65988745cf952 = []byte{
-	// 487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x4f, 0x6f, 0xd3, 0x30,
-	0x14, 0x57, 0x5a, 0x36, 0x36, 0xb7, 0x6c, 0xad, 0x37, 0xa1, 0xa8, 0xda, 0x61, 0x8a, 0x84, 0x34,
-	0x24, 0x48, 0xa4, 0x22, 0x21, 0xc4, 0x05, 0x51, 0xa8, 0xc4, 0x0e, 0x48, 0x53, 0x18, 0x3b, 0xec,
-	0x52, 0x9c, 0xe4, 0x91, 0x5a, 0x0d, 0xb6, 0x65, 0x3b, 0xd5, 0x72, 0xe4, 0xe3, 0xf1, 0x6d, 0xf8,
-	0x08, 0xc8, 0xb1, 0x9d, 0x76, 0xed, 0x85, 0x53, 0xf2, 0xde, 0xef, 0xfd, 0x7e, 0xef, 0xaf, 0xd1,
-	0x99, 0x00, 0x90, 0x89, 0x90, 0x5c, 0x70, 0x45, 0xaa, 0x58, 0x48, 0xae, 0x39, 0x3e, 0x6c, 0x3f,
-	0x6a, 0x72, 0xde, 0x82, 0xf9, 0x92, 0x50, 0x96, 0xf3, 0x02, 0x2c, 0x3a, 0xb9, 0x78, 0x44, 0x59,
-	0x48, 0x50, 0x82, 0x33, 0xe5, 0xd1, 0x50, 0xf3, 0x15, 0xb0, 0x04, 0x1e, 0x04, 0xe4, 0x9a, 0x68,
-	0xca, 0x99, 0xb2, 0x48, 0xf4, 0x1d, 0x9d, 0x7c, 0xa3, 0x25, 0x83, 0xe2, 0xc6, 0x51, 0xf1, 0x0b,
-	0x74, 0xd2, 0xc9, 0x64, 0x8d, 0x06, 0x15, 0x06, 0x97, 0xc1, 0xd5, 0x30, 0x7d, 0xe6, 0xbd, 0x33,
-	0xe3, 0xc4, 0x17, 0xe8, 0x58, 0xd1, 0x92, 0x11, 0x5d, 0x4b, 0x08, 0x7b, 0x6d, 0xc4, 0xc6, 0x11,
-	0xdd, 0xa3, 0xa3, 0x4e, 0xf0, 0x39, 0x3a, 0x5c, 0x02, 0x29, 0x40, 0x3a, 0x21, 0x67, 0xe1, 0x10,
-	0x3d, 0x15, 0xa4, 0xa9, 0x38, 0x29, 0x1c, 0xdf, 0x9b, 0x46, 0x1b, 0x1e, 0x34, 0x30, 0x45, 0x39,
-	0x0b, 0xfb, 0x56, 0xbb, 0x73, 0x44, 0xbf, 0x03, 0x14, 0x7e, 0xf2, 0xed, 0x7f, 0x69, 0xb5, 0xe6,
-	0x1e, 0xc4, 0xaf, 0x11, 0x76, 0x2a, 0x8b, 0x35, 0x55, 0x34, 0xa3, 0x15, 0xd5, 0x8d, 0x4b, 0x3c,
-	0x76, 0xc8, 0x5d, 0x07, 0xe0, 0xb7, 0x68, 0xd8, 0x4d, 0x72, 0x41, 0x6d, 0x21, 0x83, 0xe9, 0x99,
-	0x1d, 0x8e, 0x8a, 0xbb, 0x34, 0xd7, 0x9f, 0xd3, 0x41, 0x17, 0x78, 0x5d, 0x44, 0x7f, 0xb6, 0x6b,
-	0xf0, 0x9d, 0xde, 0xb8, 0xf2, 0xcf, 0xd1, 0x01, 0x65, 0xa2, 0xd6, 0x2e, 0xad, 0x35, 0xf0, 0x1d,
-	0x1a, 0xde, 0x4a, 0xc2, 0x14, 0x05, 0xa6, 0xbf, 0x12, 0x11, 0xf6, 0x2e, 0xfb, 0x57, 0x83, 0xe9,
-	0x74, 0x2f, 0xd5, 0x8e, 0x5a, 0xbc, 0x4d, 0x9a, 0x33, 0x2d, 0x9b, 0xf4, 0x91, 0xce, 0xe4, 0x03,
-	0x1a, 0xef, 0x85, 0xe0, 0x11, 0xea, 0xaf, 0xc0, 0xf6, 0x7d, 0x9c, 0x9a, 0x5f, 0x53, 0xd4, 0x9a,
-	0x54, 0xb5, 0xdf, 0x95, 0x35, 0xde, 0xf7, 0xde, 0x05, 0xd1, 0xdf, 0x00, 0x9d, 0x76, 0xd9, 0x3f,
-	0xe6, 0xe6, 0x3a, 0xcc, 0x6e, 0x24, 0xa8, 0xba, 0xd2, 0x7e, 0xfb, 0xde, 0x34, 0xdb, 0x84, 0x35,
-	0x30, 0xad, 0x9c, 0x90, 0xb3, 0xf0, 0x2b, 0x74, 0xe4, 0x8f, 0xae, 0x5d, 0xd9, 0x60, 0x3a, 0xf2,
-	0xad, 0xa5, 0xce, 0x9f, 0x76, 0x11, 0x7b, 0x73, 0x7f, 0xf2, 0x7f, 0x73, 0xc7, 0x73, 0x34, 0x6e,
-	0x4f, 0x79, 0xb1, 0x75, 0xca, 0xe1, 0x41, 0x4b, 0x0e, 0x3d, 0xf9, 0xd6, 0x04, 0xcc, 0x37, 0x78,
-	0x3a, 0xd2, 0x3b, 0x9e, 0xd9, 0x0f, 0x14, 0x71, 0x59, 0xc6, 0xcb, 0x46, 0x80, 0xac, 0xa0, 0x28,
-	0x41, 0xc6, 0x3f, 0x49, 0x26, 0x69, 0xee, 0x35, 0xcc, 0x6b, 0x9a, 0x9d, 0x6e, 0x56, 0x91, 0xaf,
-	0x48, 0x09, 0xf7, 0x2f, 0x4b, 0xaa, 0x97, 0x75, 0x16, 0xe7, 0xfc, 0x57, 0xb2, 0xc5, 0x4d, 0x2c,
-	0x37, 0xb1, 0xdc, 0xc4, 0x70, 0x33, 0xfb, 0x5a, 0xdf, 0xfc, 0x0b, 0x00, 0x00, 0xff, 0xff, 0xaa,
-	0xf2, 0x0a, 0xf6, 0xcb, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("peer/proposal.proto", fileDescriptor_proposal_0010a4cf82ffd316) }
+
+var fileDescriptor_proposal_0010a4cf82ffd316 = []byte{
+	// 512 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xdd, 0x6a, 0xdb, 0x30,
+	0x14, 0x26, 0x49, 0xdb, 0xb5, 0x4a, 0xd6, 0x24, 0x6a, 0x19, 0x26, 0xf4, 0xa2, 0x18, 0x06, 0xdd,
+	0xd8, 0x6c, 0xc8, 0x60, 0x8c, 0xdd, 0x8c, 0x65, 0x0b, 0xb4, 0xb0, 0x41, 0xf1, 0xba, 0x5e, 0xf4,
+	0xc6, 0x53, 0xec, 0x33, 0x5b, 0xc4, 0x91, 0x8c, 0xa4, 0x84, 0xe6, 0xa1, 0xf6, 0x20, 0x7b, 0x9b,
+	0x3d, 0xc2, 0xd0, 0x9f, 0x93, 0x36, 0x37, 0xbd, 0xb2, 0xcf, 0xf7, 0x9d, 0xef, 0xd3, 0xd1, 0x39,
+	0x47, 0xe8, 0xa4, 0x06, 0x10, 0x71, 0x2d, 0x78, 0xcd, 0x25, 0xa9, 0xa2, 0x5a, 0x70, 0xc5, 0xf1,
+	0x81, 0xf9, 0xc8, 0xd1, 0xa9, 0x21, 0xb3, 0x92, 0x50, 0x96, 0xf1, 0x1c, 0x2c, 0x3b, 0x3a, 0x7b,
+	0x20, 0x49, 0x05, 0xc8, 0x9a, 0x33, 0xe9, 0xd9, 0x40, 0xf1, 0x39, 0xb0, 0x18, 0xee, 0x6b, 0xc8,
+	0x14, 0x51, 0x94, 0x33, 0x69, 0x99, 0xf0, 0x27, 0x3a, 0xfe, 0x41, 0x0b, 0x06, 0xf9, 0xb5, 0x93,
+	0xe2, 0x97, 0xe8, 0xb8, 0xb1, 0x99, 0xad, 0x15, 0xc8, 0xa0, 0x75, 0xde, 0xba, 0xe8, 0x25, 0xcf,
+	0x3d, 0x3a, 0xd1, 0x20, 0x3e, 0x43, 0x47, 0x92, 0x16, 0x8c, 0xa8, 0xa5, 0x80, 0xa0, 0x6d, 0x32,
+	0x36, 0x40, 0x78, 0x87, 0x0e, 0x1b, 0xc3, 0x17, 0xe8, 0xa0, 0x04, 0x92, 0x83, 0x70, 0x46, 0x2e,
+	0xc2, 0x01, 0x7a, 0x56, 0x93, 0x75, 0xc5, 0x49, 0xee, 0xf4, 0x3e, 0xd4, 0xde, 0x70, 0xaf, 0x80,
+	0x49, 0xca, 0x59, 0xd0, 0xb1, 0xde, 0x0d, 0x10, 0xfe, 0x69, 0xa1, 0xe0, 0x8b, 0xbf, 0xfe, 0xa5,
+	0xf1, 0x9a, 0x7a, 0x12, 0xbf, 0x45, 0xd8, 0xb9, 0xa4, 0x2b, 0x2a, 0xe9, 0x8c, 0x56, 0x54, 0xad,
+	0xdd, 0xc1, 0x43, 0xc7, 0xdc, 0x36, 0x04, 0x7e, 0x8f, 0x7a, 0x4d, 0x27, 0x53, 0x6a, 0x0b, 0xe9,
+	0x8e, 0x4f, 0x6c, 0x73, 0x64, 0xd4, 0x1c, 0x73, 0xf5, 0x35, 0xe9, 0x36, 0x89, 0x57, 0x39, 0x7e,
+	0x8d, 0x86, 0x0b, 0xca, 0xd2, 0x0a, 0xf2, 0x02, 0x44, 0x5a, 0x02, 0x2d, 0x4a, 0x65, 0x2a, 0xdd,
+	0x4b, 0xfa, 0x0b, 0xca, 0xbe, 0x19, 0xfc, 0xd2, 0xc0, 0xe1, 0xdf, 0xed, 0x7a, 0x7d, 0x57, 0xae,
+	0xdd, 0x55, 0x4f, 0xd1, 0x3e, 0x65, 0xf5, 0x52, 0xb9, 0x12, 0x6d, 0x80, 0x6f, 0x51, 0xef, 0x46,
+	0x10, 0x26, 0x29, 0x30, 0xf5, 0x9d, 0xd4, 0x41, 0xfb, 0xbc, 0x73, 0xd1, 0x1d, 0x8f, 0x77, 0xca,
+	0x7a, 0xe4, 0x16, 0x6d, 0x8b, 0xa6, 0x4c, 0x89, 0x75, 0xf2, 0xc0, 0x67, 0xf4, 0x09, 0x0d, 0x77,
+	0x52, 0xf0, 0x00, 0x75, 0xe6, 0x60, 0x7b, 0x74, 0x94, 0xe8, 0x5f, 0x5d, 0xd4, 0x8a, 0x54, 0x4b,
+	0x3f, 0x57, 0x1b, 0x7c, 0x6c, 0x7f, 0x68, 0x85, 0xff, 0x5a, 0xa8, 0xdf, 0x9c, 0xfe, 0x39, 0xd3,
+	0x9b, 0xa4, 0xe7, 0x28, 0x40, 0x2e, 0x2b, 0xe5, 0x37, 0xc5, 0x87, 0x7a, 0xf2, 0xb0, 0x02, 0xa6,
+	0xa4, 0x33, 0x72, 0x11, 0x7e, 0x83, 0x0e, 0xfd, 0x82, 0x9a, 0xa6, 0x75, 0xc7, 0x03, 0x7f, 0xb5,
+	0xc4, 0xe1, 0x49, 0x93, 0xb1, 0x33, 0xa3, 0xbd, 0x27, 0xce, 0x68, 0x8a, 0x86, 0x66, 0xed, 0xd3,
+	0xad, 0xb5, 0x0f, 0xf6, 0x8d, 0x38, 0xf0, 0xe2, 0x1b, 0x9d, 0x30, 0xdd, 0xf0, 0xc9, 0x40, 0x3d,
+	0x42, 0x26, 0xbf, 0x50, 0xc8, 0x45, 0x11, 0x95, 0xeb, 0x1a, 0x84, 0x9d, 0x77, 0xf4, 0x9b, 0xcc,
+	0x04, 0xcd, 0xbc, 0x87, 0x7e, 0x79, 0x93, 0xfe, 0x66, 0x14, 0xd9, 0x9c, 0x14, 0x70, 0xf7, 0xaa,
+	0xa0, 0xaa, 0x5c, 0xce, 0xa2, 0x8c, 0x2f, 0xe2, 0x2d, 0x6d, 0x6c, 0xb5, 0xb1, 0xd5, 0xc6, 0x5a,
+	0x3b, 0xb3, 0x2f, 0xfb, 0xdd, 0xff, 0x01, 0x00, 0x56, 0xb7, 0xd4, 0x92, 0xf7, 0x03, 0x00, 0x00,
 }
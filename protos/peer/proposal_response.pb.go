@@ -40,17 +40,20 @@ type ProposalResponse struct {
 	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
 	// The endorsement of the proposal, basically
 	// the endorser's signature over the payload
-	Endorsement          *Endorsement `protobuf:"bytes,6,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	Endorsement *Endorsement `protobuf:"bytes,6,opt,name=endorsement,proto3" json:"endorsement,omitempty"`
+	// The resources used by the simulation of the proposal, as measured by
+	// the endorser. It is not covered by the endorsement
+	ResourceUsage        *ResourceUsage `protobuf:"bytes,7,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *ProposalResponse) Reset()         { *m = ProposalResponse{} }
 func (m *ProposalResponse) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponse) ProtoMessage()    {}
 func (*ProposalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{0}
+	return fileDescriptor_proposal_response_d235bb8f81c4617d, []int{0}
 }
 func (m *ProposalResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponse.Unmarshal(m, b)
@@ -105,6 +108,85 @@ func (m *ProposalResponse) GetEndorsement() *Endorsement {
 	return nil
 }
 
+func (m *ProposalResponse) GetResourceUsage() *ResourceUsage {
+	if m != nil {
+		return m.ResourceUsage
+	}
+	return nil
+}
+
+// ResourceUsage is the measure of the resources used by the simulation of a
+// proposal to an application chaincode
+type ResourceUsage struct {
+	// The time of the simulation in microseconds, including the execution of
+	// the chaincode and the reads of the state on its behalf
+	SimulationTimeUs uint64 `protobuf:"varint,1,opt,name=simulation_time_us,json=simulationTimeUs,proto3" json:"simulation_time_us,omitempty"`
+	// The number of state reads, including the keys read by the range queries
+	// and the reads of private data
+	StateReads uint64 `protobuf:"varint,2,opt,name=state_reads,json=stateReads,proto3" json:"state_reads,omitempty"`
+	// The number of state writes, including the deletions, the metadata writes
+	// and the writes of private data
+	StateWrites uint64 `protobuf:"varint,3,opt,name=state_writes,json=stateWrites,proto3" json:"state_writes,omitempty"`
+	// The number of bytes produced, which are the public read-write set, the
+	// chaincode event and the chaincode response
+	BytesProduced        uint64   `protobuf:"varint,4,opt,name=bytes_produced,json=bytesProduced,proto3" json:"bytes_produced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResourceUsage) Reset()         { *m = ResourceUsage{} }
+func (m *ResourceUsage) String() string { return proto.CompactTextString(m) }
+func (*ResourceUsage) ProtoMessage()    {}
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_proposal_response_d235bb8f81c4617d, []int{1}
+}
+func (m *ResourceUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResourceUsage.Unmarshal(m, b)
+}
+func (m *ResourceUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResourceUsage.Marshal(b, m, deterministic)
+}
+func (dst *ResourceUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResourceUsage.Merge(dst, src)
+}
+func (m *ResourceUsage) XXX_Size() int {
+	return xxx_messageInfo_ResourceUsage.Size(m)
+}
+func (m *ResourceUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResourceUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResourceUsage proto.InternalMessageInfo
+
+func (m *ResourceUsage) GetSimulationTimeUs() uint64 {
+	if m != nil {
+		return m.SimulationTimeUs
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetStateReads() uint64 {
+	if m != nil {
+		return m.StateReads
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetStateWrites() uint64 {
+	if m != nil {
+		return m.StateWrites
+	}
+	return 0
+}
+
+func (m *ResourceUsage) GetBytesProduced() uint64 {
+	if m != nil {
+		return m.BytesProduced
+	}
+	return 0
+}
+
 // A response with a representation similar to an HTTP response that can
 // be used within another message.
 type Response struct {
@@ -123,7 +205,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{1}
+	return fileDescriptor_proposal_response_d235bb8f81c4617d, []int{2}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Response.Unmarshal(m, b)
@@ -201,7 +283,7 @@ func (m *ProposalResponsePayload) Reset()         { *m = ProposalResponsePayload
 func (m *ProposalResponsePayload) String() string { return proto.CompactTextString(m) }
 func (*ProposalResponsePayload) ProtoMessage()    {}
 func (*ProposalResponsePayload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{2}
+	return fileDescriptor_proposal_response_d235bb8f81c4617d, []int{3}
 }
 func (m *ProposalResponsePayload) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ProposalResponsePayload.Unmarshal(m, b)
@@ -259,7 +341,7 @@ func (m *Endorsement) Reset()         { *m = Endorsement{} }
 func (m *Endorsement) String() string { return proto.CompactTextString(m) }
 func (*Endorsement) ProtoMessage()    {}
 func (*Endorsement) Descriptor() ([]byte, []int) {
-	return fileDescriptor_proposal_response_22a755721b685f40, []int{3}
+	return fileDescriptor_proposal_response_d235bb8f81c4617d, []int{4}
 }
 func (m *Endorsement) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Endorsement.Unmarshal(m, b)
@@ -295,38 +377,47 @@ func (m *Endorsement) GetSignature() []byte {
 
 func init() {
 	proto.RegisterType((*ProposalResponse)(nil), "protos.ProposalResponse")
+	proto.RegisterType((*ResourceUsage)(nil), "protos.ResourceUsage")
 	proto.RegisterType((*Response)(nil), "protos.Response")
 	proto.RegisterType((*ProposalResponsePayload)(nil), "protos.ProposalResponsePayload")
 	proto.RegisterType((*Endorsement)(nil), "protos.Endorsement")
 }
 
 func init() {
-	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_22a755721b685f40)
-}
-
-var fileDescriptor_proposal_response_22a755721b685f40 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0xfe, 0xff, 0xcd, 0x2d, 0x9b, 0x30, 0x2a, 0x68, 0x19, 0x03, 0x47, 0x7d, 0x99,
-	0x20, 0x29, 0x28, 0x82, 0xcf, 0x03, 0xd1, 0xc7, 0x11, 0xc4, 0x07, 0x11, 0x24, 0xdd, 0xee, 0xd2,
-	0x62, 0xdb, 0x84, 0xdc, 0x54, 0xdc, 0x07, 0xf6, 0x7b, 0x48, 0xd3, 0xa6, 0xab, 0xe2, 0xd3, 0x38,
-	0x77, 0x27, 0xbf, 0x7b, 0xcf, 0xed, 0x25, 0x73, 0x05, 0xa0, 0x23, 0xa5, 0xa5, 0x92, 0xc8, 0xb3,
-	0x37, 0x0d, 0xa8, 0x64, 0x81, 0x40, 0x95, 0x96, 0x46, 0xfa, 0x03, 0xfb, 0x83, 0xb3, 0x73, 0x21,
-	0xa5, 0xc8, 0x20, 0xb2, 0x32, 0x2e, 0x77, 0x91, 0x49, 0x73, 0x40, 0xc3, 0x73, 0x55, 0x1b, 0xc3,
-	0x2f, 0x8f, 0x4c, 0xd7, 0x0d, 0x84, 0x35, 0x0c, 0x3f, 0x20, 0x47, 0x1f, 0xa0, 0x31, 0x95, 0x45,
-	0xe0, 0x2d, 0xbc, 0x65, 0x9f, 0x39, 0xe9, 0xdf, 0x91, 0x51, 0x4b, 0x08, 0x7a, 0x0b, 0x6f, 0x39,
-	0xbe, 0x9e, 0xd1, 0xba, 0x07, 0x75, 0x3d, 0xe8, 0x93, 0x73, 0xb0, 0x83, 0xd9, 0xbf, 0x22, 0x43,
-	0x37, 0x63, 0xf0, 0xdf, 0x3e, 0x9c, 0xd6, 0x2f, 0x90, 0xba, 0xbe, 0xac, 0x75, 0x54, 0x13, 0x28,
-	0xbe, 0xcf, 0x24, 0xdf, 0x06, 0xfd, 0x85, 0xb7, 0x9c, 0x30, 0x27, 0xfd, 0x5b, 0x32, 0x86, 0x62,
-	0x2b, 0x35, 0x42, 0x0e, 0x85, 0x09, 0x06, 0x16, 0x75, 0xe2, 0x50, 0xf7, 0x87, 0xbf, 0x58, 0xd7,
-	0x17, 0x3e, 0x93, 0x61, 0x1b, 0xef, 0x94, 0x0c, 0xd0, 0x70, 0x53, 0x62, 0x93, 0xae, 0x51, 0x55,
-	0xd3, 0x1c, 0x10, 0xb9, 0x00, 0x1b, 0x6d, 0xc4, 0x9c, 0xec, 0x8e, 0xf3, 0xef, 0xc7, 0x38, 0xe1,
-	0x2b, 0x39, 0xfb, 0xbd, 0xbe, 0x75, 0x33, 0xe9, 0x05, 0x39, 0x6e, 0x3f, 0x4f, 0xc2, 0x31, 0xb1,
-	0xdd, 0x26, 0x6c, 0xe2, 0x8a, 0x8f, 0x1c, 0x13, 0x7f, 0x4e, 0x46, 0xf0, 0x69, 0xa0, 0xb0, 0xcb,
-	0xee, 0x59, 0xc3, 0xa1, 0x10, 0x3e, 0x90, 0x71, 0x27, 0x91, 0x3f, 0x23, 0xc3, 0x26, 0x93, 0x6e,
-	0x60, 0xad, 0xae, 0x40, 0x98, 0x8a, 0x82, 0x9b, 0x52, 0x83, 0x03, 0xb5, 0x85, 0x55, 0x42, 0x42,
-	0xa9, 0x05, 0x4d, 0xf6, 0x0a, 0x74, 0x06, 0x5b, 0x01, 0x9a, 0xee, 0x78, 0xac, 0xd3, 0x8d, 0x5b,
-	0x5c, 0x75, 0x4d, 0xab, 0x3f, 0xa2, 0x6c, 0xde, 0xb9, 0x80, 0x97, 0x4b, 0x91, 0x9a, 0xa4, 0x8c,
-	0xe9, 0x46, 0xe6, 0x51, 0x87, 0x11, 0xd5, 0x8c, 0xfa, 0xba, 0x30, 0xaa, 0x18, 0x71, 0x7d, 0x79,
-	0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x52, 0x0b, 0x35, 0xa0, 0x02, 0x00, 0x00,
+	proto.RegisterFile("peer/proposal_response.proto", fileDescriptor_proposal_response_d235bb8f81c4617d)
+}
+
+var fileDescriptor_proposal_response_d235bb8f81c4617d = []byte{
+	// 484 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x51, 0x8b, 0xd3, 0x40,
+	0x10, 0xa6, 0x77, 0x6d, 0xaf, 0x9d, 0xb6, 0x47, 0x59, 0x51, 0x43, 0x39, 0xb8, 0x1a, 0x11, 0x2a,
+	0x1c, 0x09, 0x28, 0x82, 0x0f, 0x3e, 0x1d, 0x88, 0x3e, 0x96, 0xc5, 0x53, 0x10, 0x21, 0x6c, 0x9b,
+	0xb9, 0x24, 0xd8, 0x64, 0xc3, 0xcc, 0x46, 0xed, 0xef, 0xf1, 0xc5, 0x9f, 0x29, 0xd9, 0x64, 0xd3,
+	0x9c, 0xf8, 0x14, 0xbe, 0x6f, 0xbf, 0xf9, 0x66, 0xf2, 0xcd, 0x2e, 0x5c, 0x95, 0x88, 0x14, 0x96,
+	0xa4, 0x4b, 0xcd, 0xea, 0x10, 0x11, 0x72, 0xa9, 0x0b, 0xc6, 0xa0, 0x24, 0x6d, 0xb4, 0x18, 0xdb,
+	0x0f, 0xaf, 0xae, 0x13, 0xad, 0x93, 0x03, 0x86, 0x16, 0xee, 0xaa, 0xfb, 0xd0, 0x64, 0x39, 0xb2,
+	0x51, 0x79, 0xd9, 0x08, 0xfd, 0xdf, 0x67, 0xb0, 0xdc, 0xb6, 0x26, 0xb2, 0xf5, 0x10, 0x1e, 0x5c,
+	0xfc, 0x40, 0xe2, 0x4c, 0x17, 0xde, 0x60, 0x3d, 0xd8, 0x8c, 0xa4, 0x83, 0xe2, 0x2d, 0x4c, 0x3b,
+	0x07, 0xef, 0x6c, 0x3d, 0xd8, 0xcc, 0x5e, 0xad, 0x82, 0xa6, 0x47, 0xe0, 0x7a, 0x04, 0x9f, 0x9c,
+	0x42, 0x9e, 0xc4, 0xe2, 0x06, 0x26, 0x6e, 0x46, 0x6f, 0x68, 0x0b, 0x97, 0x4d, 0x05, 0x07, 0xae,
+	0xaf, 0x9c, 0x50, 0x6f, 0x82, 0x52, 0x1d, 0x0f, 0x5a, 0xc5, 0xde, 0x68, 0x3d, 0xd8, 0xcc, 0xa5,
+	0x83, 0xe2, 0x0d, 0xcc, 0xb0, 0x88, 0x35, 0x31, 0xe6, 0x58, 0x18, 0x6f, 0x6c, 0xad, 0x1e, 0x39,
+	0xab, 0xf7, 0xa7, 0x23, 0xd9, 0xd7, 0x89, 0x77, 0x70, 0x49, 0xc8, 0xba, 0xa2, 0x3d, 0x46, 0x15,
+	0xab, 0x04, 0xbd, 0x0b, 0x5b, 0xf9, 0xb8, 0x37, 0x84, 0x3d, 0xbd, 0xab, 0x0f, 0xe5, 0x82, 0xfa,
+	0xd0, 0xff, 0x33, 0x80, 0xc5, 0x03, 0x81, 0xb8, 0x01, 0xc1, 0x59, 0x5e, 0x1d, 0x94, 0xc9, 0x74,
+	0x11, 0xd5, 0xbf, 0x19, 0x55, 0x6c, 0xd3, 0x1a, 0xca, 0xe5, 0xe9, 0xa4, 0x8e, 0xe2, 0x8e, 0xc5,
+	0x35, 0xcc, 0xd8, 0x28, 0x83, 0x11, 0xa1, 0x8a, 0xd9, 0x06, 0x37, 0x94, 0x60, 0x29, 0x59, 0x33,
+	0xe2, 0x19, 0xcc, 0x1b, 0xc1, 0x4f, 0xca, 0x0c, 0xb2, 0x77, 0x6e, 0x15, 0x4d, 0xd1, 0x17, 0x4b,
+	0x89, 0x17, 0x70, 0xb9, 0x3b, 0x1a, 0xe4, 0xa8, 0x24, 0x1d, 0x57, 0x7b, 0x8c, 0x6d, 0x8c, 0x43,
+	0xb9, 0xb0, 0xec, 0xb6, 0x25, 0xfd, 0xcf, 0x30, 0xe9, 0xf6, 0xf8, 0x04, 0xc6, 0xb5, 0x43, 0x3b,
+	0xd8, 0x48, 0xb6, 0xa8, 0x4e, 0x37, 0x47, 0xb6, 0x29, 0xd4, 0xa3, 0x4c, 0xa5, 0x83, 0xfd, 0xdc,
+	0xcf, 0x1f, 0xe4, 0xee, 0x7f, 0x83, 0xa7, 0xff, 0xde, 0x93, 0x6d, 0xbb, 0x92, 0xe7, 0xb0, 0xe8,
+	0xee, 0x61, 0xaa, 0x38, 0xb5, 0xdd, 0xe6, 0x72, 0xee, 0xc8, 0x8f, 0x8a, 0x53, 0x71, 0x05, 0x53,
+	0xfc, 0x65, 0xb0, 0xb0, 0xb7, 0xea, 0xcc, 0x0a, 0x4e, 0x84, 0xff, 0x01, 0x66, 0xbd, 0xd5, 0x89,
+	0x15, 0x4c, 0xda, 0xe5, 0x51, 0x6b, 0xd6, 0xe1, 0xda, 0x88, 0xb3, 0xa4, 0x50, 0xa6, 0x22, 0x74,
+	0x46, 0x1d, 0x71, 0x9b, 0x82, 0xaf, 0x29, 0x09, 0xd2, 0x63, 0x89, 0x74, 0xc0, 0x38, 0x41, 0x0a,
+	0xee, 0xd5, 0x8e, 0xb2, 0xbd, 0xdb, 0x73, 0x89, 0x48, 0xb7, 0xff, 0xf9, 0x95, 0xfd, 0x77, 0x95,
+	0xe0, 0xd7, 0x97, 0x49, 0x66, 0xd2, 0x6a, 0x17, 0xec, 0x75, 0x1e, 0xf6, 0x3c, 0xc2, 0xc6, 0xa3,
+	0x79, 0x46, 0x1c, 0xd6, 0x1e, 0xbb, 0xe6, 0x89, 0xbd, 0xfe, 0x3b, 0x00, 0xde, 0xdb, 0x1d, 0xc7,
+	0x89, 0x03, 0x00, 0x00,
 }
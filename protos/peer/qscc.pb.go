@@ -0,0 +1,474 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: peer/qscc.proto
+
+package peer // import "github.com/hyperledger/fabric/protos/peer"
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+import common "github.com/hyperledger/fabric/protos/common"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// BlockRange is the response of the GetBlocksByRange query of qscc. When the
+// requested range could not be returned at once, next_block_number is the
+// number of the first block that was left out and should be used as the start
+// of the next query
+type BlockRange struct {
+	Blocks               []*common.Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	HasMore              bool            `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextBlockNumber      uint64          `protobuf:"varint,3,opt,name=next_block_number,json=nextBlockNumber,proto3" json:"next_block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BlockRange) Reset()         { *m = BlockRange{} }
+func (m *BlockRange) String() string { return proto.CompactTextString(m) }
+func (*BlockRange) ProtoMessage()    {}
+func (*BlockRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_qscc_9b7032b24babea6b, []int{0}
+}
+func (m *BlockRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockRange.Unmarshal(m, b)
+}
+func (m *BlockRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockRange.Marshal(b, m, deterministic)
+}
+func (dst *BlockRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockRange.Merge(dst, src)
+}
+func (m *BlockRange) XXX_Size() int {
+	return xxx_messageInfo_BlockRange.Size(m)
+}
+func (m *BlockRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockRange proto.InternalMessageInfo
+
+func (m *BlockRange) GetBlocks() []*common.Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *BlockRange) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+func (m *BlockRange) GetNextBlockNumber() uint64 {
+	if m != nil {
+		return m.NextBlockNumber
+	}
+	return 0
+}
+
+// BlockTransactions is the response of the GetTransactionsByBlockNumber query
+// of qscc
+type BlockTransactions struct {
+	BlockNumber          uint64                `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Transactions         []*TransactionSummary `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *BlockTransactions) Reset()         { *m = BlockTransactions{} }
+func (m *BlockTransactions) String() string { return proto.CompactTextString(m) }
+func (*BlockTransactions) ProtoMessage()    {}
+func (*BlockTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_qscc_9b7032b24babea6b, []int{1}
+}
+func (m *BlockTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockTransactions.Unmarshal(m, b)
+}
+func (m *BlockTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockTransactions.Marshal(b, m, deterministic)
+}
+func (dst *BlockTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockTransactions.Merge(dst, src)
+}
+func (m *BlockTransactions) XXX_Size() int {
+	return xxx_messageInfo_BlockTransactions.Size(m)
+}
+func (m *BlockTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockTransactions proto.InternalMessageInfo
+
+func (m *BlockTransactions) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockTransactions) GetTransactions() []*TransactionSummary {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// TransactionSummary carries the decoded headers of a transaction of a block
+// along with the validation code that the committer assigned to it
+type TransactionSummary struct {
+	TxIndex              uint64               `protobuf:"varint,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	TxId                 string               `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Type                 string               `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	ChannelId            string               `protobuf:"bytes,4,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	CreatorMspId         string               `protobuf:"bytes,6,opt,name=creator_msp_id,json=creatorMspId,proto3" json:"creator_msp_id,omitempty"`
+	ValidationCode       string               `protobuf:"bytes,7,opt,name=validation_code,json=validationCode,proto3" json:"validation_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *TransactionSummary) Reset()         { *m = TransactionSummary{} }
+func (m *TransactionSummary) String() string { return proto.CompactTextString(m) }
+func (*TransactionSummary) ProtoMessage()    {}
+func (*TransactionSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_qscc_9b7032b24babea6b, []int{2}
+}
+func (m *TransactionSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionSummary.Unmarshal(m, b)
+}
+func (m *TransactionSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionSummary.Marshal(b, m, deterministic)
+}
+func (dst *TransactionSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionSummary.Merge(dst, src)
+}
+func (m *TransactionSummary) XXX_Size() int {
+	return xxx_messageInfo_TransactionSummary.Size(m)
+}
+func (m *TransactionSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionSummary proto.InternalMessageInfo
+
+func (m *TransactionSummary) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *TransactionSummary) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TransactionSummary) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TransactionSummary) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *TransactionSummary) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *TransactionSummary) GetCreatorMspId() string {
+	if m != nil {
+		return m.CreatorMspId
+	}
+	return ""
+}
+
+func (m *TransactionSummary) GetValidationCode() string {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return ""
+}
+
+// BlockStatsRange is the response of the GetBlockStatsByRange query of qscc.
+// has_more and next_block_number have the same meaning as in BlockRange
+type BlockStatsRange struct {
+	Blocks               []*BlockStats `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	HasMore              bool          `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextBlockNumber      uint64        `protobuf:"varint,3,opt,name=next_block_number,json=nextBlockNumber,proto3" json:"next_block_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *BlockStatsRange) Reset()         { *m = BlockStatsRange{} }
+func (m *BlockStatsRange) String() string { return proto.CompactTextString(m) }
+func (*BlockStatsRange) ProtoMessage()    {}
+func (*BlockStatsRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_qscc_9b7032b24babea6b, []int{3}
+}
+func (m *BlockStatsRange) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockStatsRange.Unmarshal(m, b)
+}
+func (m *BlockStatsRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockStatsRange.Marshal(b, m, deterministic)
+}
+func (dst *BlockStatsRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockStatsRange.Merge(dst, src)
+}
+func (m *BlockStatsRange) XXX_Size() int {
+	return xxx_messageInfo_BlockStatsRange.Size(m)
+}
+func (m *BlockStatsRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockStatsRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockStatsRange proto.InternalMessageInfo
+
+func (m *BlockStatsRange) GetBlocks() []*BlockStats {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *BlockStatsRange) GetHasMore() bool {
+	if m != nil {
+		return m.HasMore
+	}
+	return false
+}
+
+func (m *BlockStatsRange) GetNextBlockNumber() uint64 {
+	if m != nil {
+		return m.NextBlockNumber
+	}
+	return 0
+}
+
+// BlockStats carries the size and the transaction counts of a block, along
+// with the size and the validation code of each of its transactions
+type BlockStats struct {
+	BlockNumber          uint64              `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Size                 uint64              `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	TxCount              uint64              `protobuf:"varint,3,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	ValidTxCount         uint64              `protobuf:"varint,4,opt,name=valid_tx_count,json=validTxCount,proto3" json:"valid_tx_count,omitempty"`
+	InvalidTxCount       uint64              `protobuf:"varint,5,opt,name=invalid_tx_count,json=invalidTxCount,proto3" json:"invalid_tx_count,omitempty"`
+	TxBytes              uint64              `protobuf:"varint,6,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	MaxTxSize            uint64              `protobuf:"varint,7,opt,name=max_tx_size,json=maxTxSize,proto3" json:"max_tx_size,omitempty"`
+	Transactions         []*TransactionStats `protobuf:"bytes,8,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *BlockStats) Reset()         { *m = BlockStats{} }
+func (m *BlockStats) String() string { return proto.CompactTextString(m) }
+func (*BlockStats) ProtoMessage()    {}
+func (*BlockStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_qscc_9b7032b24babea6b, []int{4}
+}
+func (m *BlockStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockStats.Unmarshal(m, b)
+}
+func (m *BlockStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockStats.Marshal(b, m, deterministic)
+}
+func (dst *BlockStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockStats.Merge(dst, src)
+}
+func (m *BlockStats) XXX_Size() int {
+	return xxx_messageInfo_BlockStats.Size(m)
+}
+func (m *BlockStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockStats proto.InternalMessageInfo
+
+func (m *BlockStats) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *BlockStats) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *BlockStats) GetTxCount() uint64 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+func (m *BlockStats) GetValidTxCount() uint64 {
+	if m != nil {
+		return m.ValidTxCount
+	}
+	return 0
+}
+
+func (m *BlockStats) GetInvalidTxCount() uint64 {
+	if m != nil {
+		return m.InvalidTxCount
+	}
+	return 0
+}
+
+func (m *BlockStats) GetTxBytes() uint64 {
+	if m != nil {
+		return m.TxBytes
+	}
+	return 0
+}
+
+func (m *BlockStats) GetMaxTxSize() uint64 {
+	if m != nil {
+		return m.MaxTxSize
+	}
+	return 0
+}
+
+func (m *BlockStats) GetTransactions() []*TransactionStats {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+// TransactionStats carries the size of a transaction of a block and the
+// validation code that the committer assigned to it
+type TransactionStats struct {
+	TxIndex              uint64   `protobuf:"varint,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	Size                 uint64   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ValidationCode       string   `protobuf:"bytes,3,opt,name=validation_code,json=validationCode,proto3" json:"validation_code,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransactionStats) Reset()         { *m = TransactionStats{} }
+func (m *TransactionStats) String() string { return proto.CompactTextString(m) }
+func (*TransactionStats) ProtoMessage()    {}
+func (*TransactionStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_qscc_9b7032b24babea6b, []int{5}
+}
+func (m *TransactionStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TransactionStats.Unmarshal(m, b)
+}
+func (m *TransactionStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TransactionStats.Marshal(b, m, deterministic)
+}
+func (dst *TransactionStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransactionStats.Merge(dst, src)
+}
+func (m *TransactionStats) XXX_Size() int {
+	return xxx_messageInfo_TransactionStats.Size(m)
+}
+func (m *TransactionStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransactionStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransactionStats proto.InternalMessageInfo
+
+func (m *TransactionStats) GetTxIndex() uint64 {
+	if m != nil {
+		return m.TxIndex
+	}
+	return 0
+}
+
+func (m *TransactionStats) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *TransactionStats) GetValidationCode() string {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*BlockRange)(nil), "protos.BlockRange")
+	proto.RegisterType((*BlockTransactions)(nil), "protos.BlockTransactions")
+	proto.RegisterType((*TransactionSummary)(nil), "protos.TransactionSummary")
+	proto.RegisterType((*BlockStatsRange)(nil), "protos.BlockStatsRange")
+	proto.RegisterType((*BlockStats)(nil), "protos.BlockStats")
+	proto.RegisterType((*TransactionStats)(nil), "protos.TransactionStats")
+}
+
+func init() { proto.RegisterFile("peer/qscc.proto", fileDescriptor_qscc_9b7032b24babea6b) }
+
+var fileDescriptor_qscc_9b7032b24babea6b = []byte{
+	// 571 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xbd, 0x8f, 0xd3, 0x30,
+	0x14, 0xc0, 0x95, 0x36, 0xfd, 0x7a, 0x2d, 0xed, 0x9d, 0x6f, 0x09, 0x95, 0x80, 0x52, 0x81, 0x28,
+	0x37, 0x24, 0x12, 0x2c, 0x0c, 0x88, 0xa1, 0x37, 0x75, 0x38, 0x04, 0x69, 0x27, 0x84, 0x14, 0x39,
+	0x8e, 0x2f, 0x0d, 0xd7, 0xd8, 0xc1, 0x76, 0x4f, 0xe9, 0x4d, 0x0c, 0xfc, 0x2f, 0xfc, 0x9b, 0xc8,
+	0x76, 0x7a, 0xfd, 0xa0, 0x42, 0x0c, 0x4c, 0xc9, 0x7b, 0xef, 0xf7, 0xbe, 0x9f, 0x0c, 0x83, 0x82,
+	0x52, 0x11, 0x7c, 0x97, 0x84, 0xf8, 0x85, 0xe0, 0x8a, 0xa3, 0xa6, 0xf9, 0xc8, 0xe1, 0x05, 0xe1,
+	0x79, 0xce, 0x59, 0x60, 0x3f, 0xd6, 0x38, 0x7c, 0x96, 0x72, 0x9e, 0xae, 0x68, 0x60, 0xa4, 0x78,
+	0x7d, 0x13, 0xa8, 0x2c, 0xa7, 0x52, 0xe1, 0xbc, 0xb0, 0xc0, 0xf8, 0x1e, 0x60, 0xba, 0xe2, 0xe4,
+	0x36, 0xc4, 0x2c, 0xa5, 0xe8, 0x25, 0x34, 0x63, 0x2d, 0x49, 0xcf, 0x19, 0xd5, 0x27, 0xdd, 0x37,
+	0x8f, 0xfc, 0x2a, 0x9a, 0x65, 0x2a, 0x23, 0x7a, 0x0c, 0xed, 0x25, 0x96, 0x51, 0xce, 0x05, 0xf5,
+	0x6a, 0x23, 0x67, 0xd2, 0x0e, 0x5b, 0x4b, 0x2c, 0xaf, 0xb9, 0xa0, 0xe8, 0x12, 0xce, 0x19, 0x2d,
+	0x55, 0x64, 0xc8, 0x88, 0xad, 0xf3, 0x98, 0x0a, 0xaf, 0x3e, 0x72, 0x26, 0x6e, 0x38, 0xd0, 0x06,
+	0x13, 0xe8, 0xa3, 0x51, 0x8f, 0xef, 0xe0, 0xdc, 0x88, 0x0b, 0x81, 0x99, 0xc4, 0x44, 0x65, 0x9c,
+	0x49, 0xf4, 0x1c, 0x7a, 0x07, 0xbe, 0x8e, 0xf1, 0xed, 0xc6, 0x3b, 0x3f, 0xf4, 0x01, 0x7a, 0x6a,
+	0xcf, 0xc5, 0xab, 0x99, 0x5a, 0x87, 0xb6, 0x23, 0xe9, 0xef, 0x85, 0x9b, 0xaf, 0xf3, 0x1c, 0x8b,
+	0x4d, 0x78, 0xc0, 0x8f, 0x7f, 0xd4, 0x00, 0xfd, 0x09, 0xe9, 0xae, 0x54, 0x19, 0x65, 0x2c, 0xa1,
+	0x65, 0x95, 0xb5, 0xa5, 0xca, 0x99, 0x16, 0xd1, 0x05, 0x34, 0xb4, 0x29, 0x31, 0xdd, 0x76, 0x42,
+	0x57, 0x95, 0xb3, 0x04, 0x21, 0x70, 0xd5, 0xa6, 0xa0, 0x5e, 0xbd, 0xd2, 0x6d, 0x0a, 0x8a, 0x9e,
+	0x00, 0x90, 0x25, 0x66, 0x8c, 0xae, 0x34, 0xed, 0x1a, 0x4b, 0xa7, 0xd2, 0xcc, 0x12, 0xf4, 0x0e,
+	0x3a, 0x0f, 0x0b, 0xf0, 0x1a, 0x23, 0xc7, 0x94, 0x6d, 0x57, 0xe4, 0x6f, 0x57, 0xe4, 0x2f, 0xb6,
+	0x44, 0xb8, 0x83, 0xd1, 0x0b, 0xe8, 0x13, 0x41, 0xb1, 0xe2, 0x22, 0xca, 0x65, 0xa1, 0x83, 0x37,
+	0x4d, 0xf0, 0x5e, 0xa5, 0xbd, 0x96, 0xc5, 0x2c, 0x41, 0xaf, 0x60, 0x70, 0x87, 0x57, 0x59, 0x82,
+	0x75, 0x5f, 0x11, 0xe1, 0x09, 0xf5, 0x5a, 0x06, 0xeb, 0xef, 0xd4, 0x57, 0x3c, 0xa1, 0xe3, 0x9f,
+	0x0e, 0x0c, 0xcc, 0xec, 0xe7, 0x0a, 0x2b, 0x69, 0x97, 0x7f, 0x79, 0xb4, 0x7c, 0xb4, 0x1d, 0xe8,
+	0x1e, 0xf8, 0x9f, 0x2f, 0xe0, 0x57, 0x0d, 0x60, 0x17, 0xfd, 0x5f, 0x76, 0x8f, 0xc0, 0x95, 0xd9,
+	0xbd, 0x4d, 0xea, 0x86, 0xe6, 0xbf, 0x5a, 0x1c, 0xe1, 0x6b, 0xa6, 0xaa, 0x44, 0x2d, 0x55, 0x5e,
+	0x69, 0x51, 0x8f, 0xcd, 0x74, 0x1e, 0x3d, 0x00, 0xae, 0x01, 0x7a, 0x46, 0xbb, 0xa8, 0xa8, 0x09,
+	0x9c, 0x65, 0xec, 0x88, 0x6b, 0x18, 0xae, 0x9f, 0xb1, 0x03, 0xd2, 0xa6, 0x8a, 0x37, 0x8a, 0x4a,
+	0xaf, 0xb9, 0x4d, 0x35, 0xd5, 0x22, 0x7a, 0x0a, 0xdd, 0x1c, 0x97, 0x3a, 0x80, 0x29, 0xb0, 0x65,
+	0xac, 0x9d, 0x1c, 0x97, 0x8b, 0x72, 0xae, 0xab, 0x7c, 0x7f, 0x74, 0xb5, 0x6d, 0x33, 0x64, 0xef,
+	0xd4, 0xd5, 0x9a, 0x51, 0x1f, 0xde, 0xec, 0x37, 0x38, 0x3b, 0x26, 0xfe, 0x76, 0xb0, 0xa7, 0xc6,
+	0x74, 0xe2, 0x38, 0xea, 0xa7, 0x8e, 0x63, 0xfa, 0x15, 0xc6, 0x5c, 0xa4, 0xfe, 0x72, 0x53, 0x50,
+	0xb1, 0xa2, 0x49, 0x4a, 0x85, 0x7f, 0x83, 0x63, 0x91, 0x91, 0x6d, 0xad, 0xfa, 0x09, 0x9a, 0x76,
+	0x3f, 0x4b, 0x42, 0x3e, 0x61, 0x72, 0x8b, 0x53, 0xfa, 0xe5, 0x75, 0x9a, 0xa9, 0xe5, 0x3a, 0xd6,
+	0xcf, 0x45, 0xb0, 0xe7, 0x17, 0x58, 0x3f, 0xfb, 0xfc, 0xc8, 0x40, 0xfb, 0xc5, 0xf6, 0xbd, 0x7a,
+	0xfb, 0x7b, 0x00, 0x5d, 0x5f, 0xa0, 0x76, 0xc9, 0x04, 0x00, 0x00,
+}
@@ -33,7 +33,7 @@ func (m *ChaincodeQueryResponse) Reset()         { *m = ChaincodeQueryResponse{}
 func (m *ChaincodeQueryResponse) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeQueryResponse) ProtoMessage()    {}
 func (*ChaincodeQueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_query_37e5d407118e73f5, []int{0}
+	return fileDescriptor_query_37bd4c90e72bffcb, []int{0}
 }
 func (m *ChaincodeQueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeQueryResponse.Unmarshal(m, b)
@@ -81,17 +81,19 @@ type ChaincodeInfo struct {
 	//                H(name || version) ||
 	//                H(CodePackage)
 	//              )
-	Id                   []byte   `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id []byte `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	// the annotations of the chaincode definition
+	Annotations          map[string]string `protobuf:"bytes,8,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *ChaincodeInfo) Reset()         { *m = ChaincodeInfo{} }
 func (m *ChaincodeInfo) String() string { return proto.CompactTextString(m) }
 func (*ChaincodeInfo) ProtoMessage()    {}
 func (*ChaincodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_query_37e5d407118e73f5, []int{1}
+	return fileDescriptor_query_37bd4c90e72bffcb, []int{1}
 }
 func (m *ChaincodeInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChaincodeInfo.Unmarshal(m, b)
@@ -160,6 +162,13 @@ func (m *ChaincodeInfo) GetId() []byte {
 	return nil
 }
 
+func (m *ChaincodeInfo) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
 // ChannelQueryResponse returns information about each channel that pertains
 // to a query in lscc.go, such as GetChannels (returns all channels for a
 // given peer)
@@ -174,7 +183,7 @@ func (m *ChannelQueryResponse) Reset()         { *m = ChannelQueryResponse{} }
 func (m *ChannelQueryResponse) String() string { return proto.CompactTextString(m) }
 func (*ChannelQueryResponse) ProtoMessage()    {}
 func (*ChannelQueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_query_37e5d407118e73f5, []int{2}
+	return fileDescriptor_query_37bd4c90e72bffcb, []int{2}
 }
 func (m *ChannelQueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelQueryResponse.Unmarshal(m, b)
@@ -213,7 +222,7 @@ func (m *ChannelInfo) Reset()         { *m = ChannelInfo{} }
 func (m *ChannelInfo) String() string { return proto.CompactTextString(m) }
 func (*ChannelInfo) ProtoMessage()    {}
 func (*ChannelInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_query_37e5d407118e73f5, []int{3}
+	return fileDescriptor_query_37bd4c90e72bffcb, []int{3}
 }
 func (m *ChannelInfo) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ChannelInfo.Unmarshal(m, b)
@@ -243,31 +252,36 @@ func (m *ChannelInfo) GetChannelId() string {
 func init() {
 	proto.RegisterType((*ChaincodeQueryResponse)(nil), "protos.ChaincodeQueryResponse")
 	proto.RegisterType((*ChaincodeInfo)(nil), "protos.ChaincodeInfo")
+	proto.RegisterMapType((map[string]string)(nil), "protos.ChaincodeInfo.AnnotationsEntry")
 	proto.RegisterType((*ChannelQueryResponse)(nil), "protos.ChannelQueryResponse")
 	proto.RegisterType((*ChannelInfo)(nil), "protos.ChannelInfo")
 }
 
-func init() { proto.RegisterFile("peer/query.proto", fileDescriptor_query_37e5d407118e73f5) }
+func init() { proto.RegisterFile("peer/query.proto", fileDescriptor_query_37bd4c90e72bffcb) }
 
-var fileDescriptor_query_37e5d407118e73f5 = []byte{
-	// 296 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xdf, 0x4a, 0xc3, 0x30,
-	0x14, 0xc6, 0xe9, 0xfe, 0xba, 0x33, 0x15, 0x89, 0x53, 0x72, 0x23, 0x8c, 0x5e, 0x4d, 0x90, 0x16,
-	0x14, 0x5f, 0xc0, 0x5d, 0xc8, 0xae, 0x86, 0xbd, 0xf4, 0x46, 0xba, 0xe4, 0x6c, 0x0d, 0x6c, 0x49,
-	0x4c, 0xba, 0xc1, 0x9e, 0xc6, 0x57, 0x95, 0x93, 0xac, 0xa3, 0xbb, 0xea, 0x39, 0xbf, 0xef, 0x17,
-	0xca, 0x97, 0xc0, 0x9d, 0x45, 0x74, 0xf9, 0xef, 0x1e, 0xdd, 0x31, 0xb3, 0xce, 0xd4, 0x86, 0x0d,
-	0xc2, 0xc7, 0xa7, 0x4b, 0x78, 0x9c, 0x57, 0xa5, 0xd2, 0xc2, 0x48, 0xfc, 0xa2, 0xbc, 0x40, 0x6f,
-	0x8d, 0xf6, 0xc8, 0xde, 0x01, 0x44, 0x93, 0x78, 0x9e, 0x4c, 0xbb, 0xb3, 0xf1, 0xeb, 0x43, 0x3c,
-	0xed, 0xb3, 0xf3, 0x99, 0x85, 0x5e, 0x9b, 0xa2, 0x25, 0xa6, 0x7f, 0x09, 0xdc, 0x5c, 0xa4, 0x8c,
-	0x41, 0x4f, 0x97, 0x3b, 0xe4, 0xc9, 0x34, 0x99, 0x8d, 0x8a, 0x30, 0x33, 0x0e, 0xc3, 0x03, 0x3a,
-	0xaf, 0x8c, 0xe6, 0x9d, 0x80, 0x9b, 0x95, 0x6c, 0x5b, 0xd6, 0x15, 0xef, 0x46, 0x9b, 0x66, 0x36,
-	0x81, 0xbe, 0xd2, 0x76, 0x5f, 0xf3, 0x5e, 0x80, 0x71, 0x21, 0x13, 0xbd, 0x10, 0xbc, 0x1f, 0x4d,
-	0x9a, 0x89, 0x1d, 0x88, 0x0d, 0x22, 0xa3, 0x99, 0xdd, 0x42, 0x47, 0x49, 0x3e, 0x9c, 0x26, 0xb3,
-	0xeb, 0xa2, 0xa3, 0x64, 0xfa, 0x09, 0x93, 0x79, 0x55, 0x6a, 0x8d, 0xdb, 0xcb, 0xc2, 0x39, 0x5c,
-	0x89, 0xc8, 0x9b, 0xba, 0xf7, 0xad, 0xba, 0xc4, 0x43, 0xd9, 0xb3, 0x94, 0xbe, 0xc0, 0xb8, 0x15,
-	0xb0, 0xa7, 0x70, 0x61, 0xb4, 0xfe, 0x28, 0x79, 0x6a, 0x3b, 0x3a, 0x91, 0x85, 0xfc, 0x58, 0x42,
-	0x6a, 0xdc, 0x26, 0xab, 0x8e, 0x16, 0xdd, 0x16, 0xe5, 0x06, 0x5d, 0xb6, 0x2e, 0x57, 0x4e, 0x89,
-	0xe6, 0x27, 0xf4, 0x46, 0xdf, 0xcf, 0x1b, 0x55, 0x57, 0xfb, 0x55, 0x26, 0xcc, 0x2e, 0x6f, 0xa9,
-	0x79, 0x54, 0xf3, 0xa8, 0xe6, 0xa4, 0xae, 0xe2, 0x13, 0xbe, 0xfd, 0x07, 0x00, 0x00, 0xff, 0xff,
-	0x79, 0x94, 0xb3, 0xbd, 0xdd, 0x01, 0x00, 0x00,
+var fileDescriptor_query_37bd4c90e72bffcb = []byte{
+	// 356 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0x4b, 0xeb, 0x40,
+	0x10, 0xc7, 0x49, 0xfa, 0x7b, 0xfa, 0xde, 0xa3, 0xec, 0xab, 0xb2, 0x08, 0x42, 0xc9, 0x41, 0x2a,
+	0x48, 0x02, 0x8a, 0x20, 0x1e, 0x04, 0x2d, 0xa2, 0x3d, 0x15, 0x73, 0xf4, 0x22, 0xdb, 0x64, 0xda,
+	0x2c, 0xb6, 0xbb, 0x71, 0x37, 0x29, 0xe4, 0x4f, 0xf2, 0xbf, 0x94, 0xcd, 0x26, 0x35, 0x15, 0x4f,
+	0xf9, 0xce, 0x77, 0x3e, 0x43, 0x66, 0x66, 0x07, 0x46, 0x29, 0xa2, 0x0a, 0x3e, 0x72, 0x54, 0x85,
+	0x9f, 0x2a, 0x99, 0x49, 0xd2, 0x2d, 0x3f, 0xda, 0x5b, 0xc0, 0xf1, 0x2c, 0x61, 0x5c, 0x44, 0x32,
+	0xc6, 0x17, 0x93, 0x0f, 0x51, 0xa7, 0x52, 0x68, 0x24, 0xd7, 0x00, 0x51, 0x9d, 0xd1, 0xd4, 0x99,
+	0xb4, 0xa6, 0xc3, 0xcb, 0x23, 0x5b, 0xad, 0xfd, 0x7d, 0xcd, 0x5c, 0xac, 0x64, 0xd8, 0x00, 0xbd,
+	0x4f, 0x17, 0xfe, 0x1e, 0x64, 0x09, 0x81, 0xb6, 0x60, 0x5b, 0xa4, 0xce, 0xc4, 0x99, 0x0e, 0xc2,
+	0x52, 0x13, 0x0a, 0xbd, 0x1d, 0x2a, 0xcd, 0xa5, 0xa0, 0x6e, 0x69, 0xd7, 0xa1, 0xa1, 0x53, 0x96,
+	0x25, 0xb4, 0x65, 0x69, 0xa3, 0xc9, 0x18, 0x3a, 0x5c, 0xa4, 0x79, 0x46, 0xdb, 0xa5, 0x69, 0x03,
+	0x43, 0xa2, 0x8e, 0x22, 0xda, 0xb1, 0xa4, 0xd1, 0xc6, 0xdb, 0x19, 0xaf, 0x6b, 0x3d, 0xa3, 0xc9,
+	0x3f, 0x70, 0x79, 0x4c, 0x7b, 0x13, 0x67, 0xfa, 0x27, 0x74, 0x79, 0x4c, 0x9e, 0x61, 0xc8, 0x84,
+	0x90, 0x19, 0xcb, 0xb8, 0x14, 0x9a, 0xf6, 0xcb, 0xc9, 0xce, 0x7e, 0x9d, 0xcc, 0xbf, 0xff, 0x06,
+	0x1f, 0x45, 0xa6, 0x8a, 0xb0, 0x59, 0x7a, 0x72, 0x07, 0xa3, 0x9f, 0x00, 0x19, 0x41, 0xeb, 0x1d,
+	0x8b, 0x6a, 0x58, 0x23, 0x4d, 0xf7, 0x3b, 0xb6, 0xc9, 0xb1, 0x9a, 0xd4, 0x06, 0xb7, 0xee, 0x8d,
+	0xe3, 0x3d, 0xc1, 0x78, 0x96, 0x30, 0x21, 0x70, 0x73, 0xb8, 0xfa, 0x00, 0xfa, 0x91, 0xf5, 0xeb,
+	0xc5, 0xff, 0x6f, 0xb4, 0x67, 0xfc, 0x72, 0xed, 0x7b, 0xc8, 0xbb, 0x80, 0x61, 0x23, 0x41, 0x4e,
+	0xcb, 0xa7, 0x33, 0xe1, 0x1b, 0x8f, 0xab, 0x56, 0x06, 0x95, 0x33, 0x8f, 0x1f, 0x16, 0xe0, 0x49,
+	0xb5, 0xf6, 0x93, 0x22, 0x45, 0xb5, 0xc1, 0x78, 0x8d, 0xca, 0x5f, 0xb1, 0xa5, 0xe2, 0x51, 0xfd,
+	0x13, 0x73, 0x2d, 0xaf, 0xe7, 0x6b, 0x9e, 0x25, 0xf9, 0xd2, 0x8f, 0xe4, 0x36, 0x68, 0xa0, 0x81,
+	0x45, 0x03, 0x8b, 0x06, 0x06, 0x5d, 0xda, 0x63, 0xba, 0xfa, 0x1a, 0x00, 0x0a, 0xd2, 0xd2, 0xc4,
+	0x67, 0x02, 0x00, 0x00,
 }
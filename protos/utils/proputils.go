@@ -9,8 +9,10 @@ package utils
 import (
 	"encoding/binary"
 	"encoding/hex"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/crypto"
@@ -142,6 +144,94 @@ func GetChaincodeHeaderExtension(hdr *common.Header) (*peer.ChaincodeHeaderExten
 	return chaincodeHdrExt, errors.Wrap(err, "error unmarshaling ChaincodeHeaderExtension")
 }
 
+// SetProposalMinLedgerHeight sets the minimum height the ledger of the channel
+// must have reached for an endorser to simulate a chaincode proposal. It must
+// be called before the proposal is signed
+func SetProposalMinLedgerHeight(prop *peer.Proposal, height uint64) error {
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+	chdr, err := UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	hdrExt, err := GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return err
+	}
+
+	hdrExt.MinLedgerHeight = height
+	if chdr.Extension, err = proto.Marshal(hdrExt); err != nil {
+		return errors.Wrap(err, "error marshaling ChaincodeHeaderExtension")
+	}
+	if hdr.ChannelHeader, err = proto.Marshal(chdr); err != nil {
+		return errors.Wrap(err, "error marshaling ChannelHeader")
+	}
+	prop.Header, err = proto.Marshal(hdr)
+	return errors.Wrap(err, "error marshaling Header")
+}
+
+// SetProposalExpiration sets the expiration height and time of the proposal,
+// which the transaction of the proposal keeps. Zero values mean no expiration
+func SetProposalExpiration(prop *peer.Proposal, height uint64, expirationTime time.Time) error {
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+	chdr, err := UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+
+	chdr.ExpirationHeight = height
+	chdr.ExpirationTime = nil
+	if !expirationTime.IsZero() {
+		if chdr.ExpirationTime, err = ptypes.TimestampProto(expirationTime); err != nil {
+			return errors.Wrap(err, "invalid expiration time")
+		}
+	}
+	if hdr.ChannelHeader, err = proto.Marshal(chdr); err != nil {
+		return errors.Wrap(err, "error marshaling ChannelHeader")
+	}
+	prop.Header, err = proto.Marshal(hdr)
+	return errors.Wrap(err, "error marshaling Header")
+}
+
+// SetProposalSchedule sets the height the ledger of the channel must have
+// reached and the time that must have come for the transaction of the proposal
+// to be valid. Zero values mean no constraint. It must be called before the
+// proposal is signed
+func SetProposalSchedule(prop *peer.Proposal, height uint64, scheduledTime time.Time) error {
+	hdr, err := GetHeader(prop.Header)
+	if err != nil {
+		return err
+	}
+	chdr, err := UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return err
+	}
+
+	chdr.ScheduledHeight = height
+	chdr.ScheduledTime = nil
+	if !scheduledTime.IsZero() {
+		if chdr.ScheduledTime, err = ptypes.TimestampProto(scheduledTime); err != nil {
+			return errors.Wrap(err, "invalid scheduled time")
+		}
+	}
+	if hdr.ChannelHeader, err = proto.Marshal(chdr); err != nil {
+		return errors.Wrap(err, "error marshaling ChannelHeader")
+	}
+	prop.Header, err = proto.Marshal(hdr)
+	return errors.Wrap(err, "error marshaling Header")
+}
+
+// IsScheduled returns whether the transaction with the given channel header is
+// scheduled at a height or a time
+func IsScheduled(chdr *common.ChannelHeader) bool {
+	return chdr.ScheduledHeight > 0 || chdr.ScheduledTime != nil
+}
+
 // GetProposalResponse given proposal in bytes
 func GetProposalResponse(prBytes []byte) (*peer.ProposalResponse, error) {
 	proposalResponse := &peer.ProposalResponse{}
@@ -13,6 +13,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/util"
@@ -386,6 +387,100 @@ func TestProposalWithTxID(t *testing.T) {
 	assert.NotEmpty(t, txid)
 }
 
+func TestSetProposalMinLedgerHeight(t *testing.T) {
+	prop, txid, err := utils.CreateChaincodeProposalWithTxIDAndTransient(
+		common.HeaderType_ENDORSER_TRANSACTION,
+		util.GetTestChainID(),
+		createCIS(),
+		[]byte("creator"),
+		"",
+		nil,
+	)
+	assert.NoError(t, err)
+
+	assert.NoError(t, utils.SetProposalMinLedgerHeight(prop, 42))
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	hdrExt, err := utils.GetChaincodeHeaderExtension(hdr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), hdrExt.MinLedgerHeight)
+	assert.Equal(t, "chaincode_name", hdrExt.ChaincodeId.Name)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, txid, chdr.TxId)
+
+	err = utils.SetProposalMinLedgerHeight(&pb.Proposal{Header: []byte("garbage")}, 42)
+	assert.Error(t, err)
+}
+
+func TestSetProposalExpiration(t *testing.T) {
+	prop, txid, err := utils.CreateChaincodeProposalWithTxIDAndTransient(
+		common.HeaderType_ENDORSER_TRANSACTION,
+		util.GetTestChainID(),
+		createCIS(),
+		[]byte("creator"),
+		"",
+		nil,
+	)
+	assert.NoError(t, err)
+
+	expirationTime := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, utils.SetProposalExpiration(prop, 42, expirationTime))
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, txid, chdr.TxId)
+	assert.Equal(t, uint64(42), chdr.ExpirationHeight)
+	assert.Equal(t, expirationTime.Unix(), chdr.ExpirationTime.Seconds)
+
+	assert.NoError(t, utils.SetProposalExpiration(prop, 0, time.Time{}))
+	hdr, err = utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err = utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), chdr.ExpirationHeight)
+	assert.Nil(t, chdr.ExpirationTime)
+
+	err = utils.SetProposalExpiration(&pb.Proposal{Header: []byte("garbage")}, 42, time.Time{})
+	assert.Error(t, err)
+}
+
+func TestSetProposalSchedule(t *testing.T) {
+	prop, txid, err := utils.CreateChaincodeProposalWithTxIDAndTransient(
+		common.HeaderType_ENDORSER_TRANSACTION,
+		util.GetTestChainID(),
+		createCIS(),
+		[]byte("creator"),
+		"",
+		nil,
+	)
+	assert.NoError(t, err)
+
+	scheduledTime := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, utils.SetProposalSchedule(prop, 42, scheduledTime))
+	hdr, err := utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err := utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, txid, chdr.TxId)
+	assert.Equal(t, uint64(42), chdr.ScheduledHeight)
+	assert.Equal(t, scheduledTime.Unix(), chdr.ScheduledTime.Seconds)
+	assert.True(t, utils.IsScheduled(chdr))
+
+	assert.NoError(t, utils.SetProposalSchedule(prop, 0, time.Time{}))
+	hdr, err = utils.GetHeader(prop.Header)
+	assert.NoError(t, err)
+	chdr, err = utils.UnmarshalChannelHeader(hdr.ChannelHeader)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), chdr.ScheduledHeight)
+	assert.Nil(t, chdr.ScheduledTime)
+	assert.False(t, utils.IsScheduled(chdr))
+
+	err = utils.SetProposalSchedule(&pb.Proposal{Header: []byte("garbage")}, 42, time.Time{})
+	assert.Error(t, err)
+}
+
 func TestProposalResponse(t *testing.T) {
 	events := &pb.ChaincodeEvent{
 		ChaincodeId: "ccid",
@@ -147,6 +147,141 @@ func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps .
 		return nil, errors.New("signer must be the same as the one referenced in the header")
 	}
 
+	paylBytes, err := createTxPayload(hdr, pPayl, resps, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// sign the payload
+	sig, err := signer.Sign(paylBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// here's the envelope
+	return &common.Envelope{Payload: paylBytes, Signature: sig}, nil
+}
+
+// CreateScheduledTx assembles an Envelope message from a signed scheduled
+// proposal and the endorsements. The transaction of a scheduled proposal is not
+// signed by its creator, who only signed the proposal before the endorsements
+// were collected: the transaction carries the bytes of the signed proposal, and
+// the signature of the envelope is the signature of the proposal, which the
+// committers verify against these bytes
+func CreateScheduledTx(signedProp *peer.SignedProposal, resps ...*peer.ProposalResponse) (*common.Envelope, error) {
+	if len(resps) == 0 {
+		return nil, errors.New("at least one proposal response is required")
+	}
+
+	proposal, err := GetProposal(signedProp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	hdr, err := GetHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	chdr, err := UnmarshalChannelHeader(hdr.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	if !IsScheduled(chdr) {
+		return nil, errors.New("the proposal is not scheduled")
+	}
+
+	pPayl, err := GetChaincodeProposalPayload(proposal.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	paylBytes, err := createTxPayload(hdr, pPayl, resps, signedProp.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.Envelope{Payload: paylBytes, Signature: signedProp.Signature}, nil
+}
+
+// GetScheduledProposalBytes returns the bytes of the proposal of the scheduled
+// transaction with the given payload, over which its envelope is signed, after
+// checking that the transaction is the transaction of this proposal
+func GetScheduledProposalBytes(payload *common.Payload) ([]byte, error) {
+	tx, err := GetTransaction(payload.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(tx.Actions) != 1 {
+		return nil, errors.Errorf("only one action per transaction is supported, tx contains %d", len(tx.Actions))
+	}
+	cap, err := GetChaincodeActionPayload(tx.Actions[0].Payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(cap.ScheduledProposal) == 0 {
+		return nil, errors.New("the scheduled transaction has no proposal")
+	}
+
+	proposal, err := GetProposal(cap.ScheduledProposal)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := GetHeader(proposal.Header)
+	if err != nil {
+		return nil, err
+	}
+	if !proto.Equal(hdr, payload.Header) {
+		return nil, errors.New("the header of the scheduled proposal does not match the header of the transaction")
+	}
+	hdrExt, err := GetChaincodeHeaderExtension(hdr)
+	if err != nil {
+		return nil, err
+	}
+	pPayl, err := GetChaincodeProposalPayload(proposal.Payload)
+	if err != nil {
+		return nil, err
+	}
+	propPayloadBytes, err := GetBytesProposalPayloadForTx(pPayl, hdrExt.PayloadVisibility)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(propPayloadBytes, cap.ChaincodeProposalPayload) {
+		return nil, errors.New("the payload of the scheduled proposal does not match the proposal payload of the transaction")
+	}
+	return cap.ScheduledProposal, nil
+}
+
+// ScheduledSignedData returns the signed data of the envelope of a scheduled
+// transaction, whose signature is over the bytes of its proposal
+func ScheduledSignedData(env *common.Envelope) ([]*common.SignedData, error) {
+	payload, err := UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("missing header")
+	}
+	shdr, err := GetSignatureHeader(payload.Header.SignatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	propBytes, err := GetScheduledProposalBytes(payload)
+	if err != nil {
+		return nil, err
+	}
+	return []*common.SignedData{{
+		Data:      propBytes,
+		Identity:  shdr.Creator,
+		Signature: env.Signature,
+	}}, nil
+}
+
+// createTxPayload assembles the bytes of the payload of the transaction of the
+// proposal with the given header and payload, checking that the endorsements
+// are of the same successful response. The bytes of a scheduled proposal are
+// carried by its transaction
+func createTxPayload(hdr *common.Header, pPayl *peer.ChaincodeProposalPayload, resps []*peer.ProposalResponse, scheduledProposal []byte) ([]byte, error) {
 	// get header extensions so we have the visibility field
 	hdrExt, err := GetChaincodeHeaderExtension(hdr)
 	if err != nil {
@@ -185,7 +320,7 @@ func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps .
 	}
 
 	// serialize the chaincode action payload
-	cap := &peer.ChaincodeActionPayload{ChaincodeProposalPayload: propPayloadBytes, Action: cea}
+	cap := &peer.ChaincodeActionPayload{ChaincodeProposalPayload: propPayloadBytes, Action: cea, ScheduledProposal: scheduledProposal}
 	capBytes, err := GetBytesChaincodeActionPayload(cap)
 	if err != nil {
 		return nil, err
@@ -210,14 +345,7 @@ func CreateSignedTx(proposal *peer.Proposal, signer msp.SigningIdentity, resps .
 		return nil, err
 	}
 
-	// sign the payload
-	sig, err := signer.Sign(paylBytes)
-	if err != nil {
-		return nil, err
-	}
-
-	// here's the envelope
-	return &common.Envelope{Payload: paylBytes, Signature: sig}, nil
+	return paylBytes, nil
 }
 
 // CreateProposalResponse creates a proposal response.
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	mockmsp "github.com/hyperledger/fabric/common/mocks/msp"
@@ -284,6 +285,85 @@ func TestCreateSignedTxStatus(t *testing.T) {
 	}
 }
 
+func TestCreateScheduledTx(t *testing.T) {
+	prop, _, err := utils.CreateChaincodeProposalWithTxIDAndTransient(
+		cb.HeaderType_ENDORSER_TRANSACTION,
+		util.GetTestChainID(),
+		&pb.ChaincodeInvocationSpec{ChaincodeSpec: &pb.ChaincodeSpec{
+			ChaincodeId: &pb.ChaincodeID{Name: "mycc"},
+			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte("settle")}},
+		}},
+		[]byte("creator"),
+		"",
+		map[string][]byte{"secret": []byte("value")},
+	)
+	assert.NoError(t, err)
+	response := &pb.ProposalResponse{
+		Payload:     []byte("payload"),
+		Endorsement: &pb.Endorsement{},
+		Response:    &pb.Response{Status: 200},
+	}
+	signedProposal := func() *pb.SignedProposal {
+		propBytes, err := utils.GetBytesProposal(prop)
+		assert.NoError(t, err)
+		return &pb.SignedProposal{ProposalBytes: propBytes, Signature: []byte("signature")}
+	}
+
+	_, err = utils.CreateScheduledTx(signedProposal(), response)
+	assert.EqualError(t, err, "the proposal is not scheduled")
+
+	assert.NoError(t, utils.SetProposalSchedule(prop, 42, time.Time{}))
+	_, err = utils.CreateScheduledTx(signedProposal())
+	assert.EqualError(t, err, "at least one proposal response is required")
+
+	signedProp := signedProposal()
+	env, err := utils.CreateScheduledTx(signedProp, response)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("signature"), env.Signature)
+
+	// the signature is over the proposal, transient map included, which the
+	// transaction holds
+	signedData, err := utils.ScheduledSignedData(env)
+	assert.NoError(t, err)
+	assert.Len(t, signedData, 1)
+	assert.Equal(t, signedProp.ProposalBytes, signedData[0].Data)
+	assert.Equal(t, []byte("creator"), signedData[0].Identity)
+	assert.Equal(t, []byte("signature"), signedData[0].Signature)
+
+	_, err = utils.ScheduledSignedData(&cb.Envelope{Payload: utils.MarshalOrPanic(&cb.Payload{})})
+	assert.EqualError(t, err, "missing header")
+
+	// the proposal must be the proposal of the transaction
+	withScheduledProposal := func(scheduledProposal []byte) *cb.Envelope {
+		payload, err := utils.UnmarshalPayload(env.Payload)
+		assert.NoError(t, err)
+		tx, err := utils.GetTransaction(payload.Data)
+		assert.NoError(t, err)
+		cap, err := utils.GetChaincodeActionPayload(tx.Actions[0].Payload)
+		assert.NoError(t, err)
+		cap.ScheduledProposal = scheduledProposal
+		tx.Actions[0].Payload = utils.MarshalOrPanic(cap)
+		payload.Data = utils.MarshalOrPanic(tx)
+		return &cb.Envelope{Payload: utils.MarshalOrPanic(payload), Signature: env.Signature}
+	}
+
+	_, err = utils.ScheduledSignedData(withScheduledProposal(nil))
+	assert.EqualError(t, err, "the scheduled transaction has no proposal")
+
+	other := proto.Clone(prop).(*pb.Proposal)
+	assert.NoError(t, utils.SetProposalSchedule(other, 43, time.Time{}))
+	_, err = utils.ScheduledSignedData(withScheduledProposal(utils.MarshalOrPanic(other)))
+	assert.EqualError(t, err, "the header of the scheduled proposal does not match the header of the transaction")
+
+	other = proto.Clone(prop).(*pb.Proposal)
+	cpp, err := utils.GetChaincodeProposalPayload(other.Payload)
+	assert.NoError(t, err)
+	cpp.Input = []byte("another input")
+	other.Payload = utils.MarshalOrPanic(cpp)
+	_, err = utils.ScheduledSignedData(withScheduledProposal(utils.MarshalOrPanic(other)))
+	assert.EqualError(t, err, "the payload of the scheduled proposal does not match the proposal payload of the transaction")
+}
+
 func TestCreateSignedEnvelope(t *testing.T) {
 	var env *cb.Envelope
 	channelID := "mychannelID"
@@ -34,7 +34,7 @@ cat docs/wrappers/peer_chaincode_postscript.md >> $DOC
 DOC=docs/source/commands/peerchannel.md
 cat docs/wrappers/peer_channel_preamble.md > $DOC
 
-for x in "peer channel" "peer channel create" "peer channel fetch" "peer channel getinfo" "peer channel join" "peer channel list" "peer channel signconfigtx" "peer channel update"; do
+for x in "peer channel" "peer channel create" "peer channel diffconfig" "peer channel fetch" "peer channel fetchconfig" "peer channel getinfo" "peer channel join" "peer channel leave" "peer channel list" "peer channel provision" "peer channel signconfigtx" "peer channel update"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC
@@ -44,6 +44,19 @@ for x in "peer channel" "peer channel create" "peer channel fetch" "peer channel
 done
 cat docs/wrappers/peer_channel_postscript.md >> $DOC
 
+DOC=docs/source/commands/peerlifecycle.md
+cat docs/wrappers/peer_lifecycle_preamble.md > $DOC
+
+for x in "peer lifecycle" "peer lifecycle chaincode" "peer lifecycle chaincode buildlogs" "peer lifecycle chaincode install" "peer lifecycle chaincode queryinstalled"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_lifecycle_postscript.md >> $DOC
+
 DOC=docs/source/commands/peerlogging.md
 cat docs/wrappers/peer_logging_preamble.md > $DOC
 
@@ -57,10 +70,23 @@ for x in "peer logging" "peer logging getlevel" "peer logging revertlevels" "pee
 done
 cat docs/wrappers/peer_logging_postscript.md >> $DOC
 
+DOC=docs/source/commands/peerutils.md
+cat docs/wrappers/peer_utils_preamble.md > $DOC
+
+for x in "peer utils" "peer utils compare" "peer utils decode" "peer utils encode"; do
+  echo "" >> $DOC
+  echo "##" $x >> $DOC
+  echo "\`\`\`" >> $DOC
+  .build/bin/${x} --help 1>> $DOC 2>/dev/null
+  echo "\`\`\`" >> $DOC
+  echo "" >> $DOC
+done
+cat docs/wrappers/peer_utils_postscript.md >> $DOC
+
 DOC=docs/source/commands/peernode.md
 cat docs/wrappers/peer_node_preamble.md > $DOC
 
-for x in "peer node start" "peer node status"; do
+for x in "peer node start" "peer node status" "peer node rollback" "peer node statehash" "peer node exportstate" "peer node importstate" "peer node migratestate" "peer node backup" "peer node restore"; do
   echo "" >> $DOC
   echo "##" $x >> $DOC
   echo "\`\`\`" >> $DOC